@@ -87,6 +87,9 @@ type APIServerDeploymentContainer struct {
 	// If used in conjunction with the deprecated ComponentResources, then this value takes precedence.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // APIServerDeploymentInitContainer is an API server Deployment init container.
@@ -146,6 +149,9 @@ type APIServerDeploymentPodSpec struct {
 	// WARNING: Please note that this field will override the default API server Deployment tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the API server pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // APIServerDeploymentPodTemplateSpec is the API server Deployment's PodTemplateSpec
@@ -185,6 +191,11 @@ type APIServerDeploymentSpec struct {
 	// Template describes the API server Deployment pod that will be created.
 	// +optional
 	Template *APIServerDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the API server Deployment with new ones.
+	// If omitted, the API server Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 func (c *APIServerDeployment) GetMetadata() *Metadata {
@@ -251,6 +262,17 @@ func (c *APIServerDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *APIServerDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *APIServerDeployment) GetAffinity() *v1.Affinity {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -300,9 +322,23 @@ func (c *APIServerDeployment) GetTerminationGracePeriodSeconds() *int64 {
 }
 
 func (c *APIServerDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *APIServerDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *APIServerDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *APIServerDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
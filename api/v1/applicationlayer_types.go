@@ -25,6 +25,10 @@ type ApplicationLayerSpec struct {
 	// WebApplicationFirewall controls whether or not ModSecurity enforcement is enabled for the cluster.
 	// When enabled, Services may opt-in to having ingress traffic examed by ModSecurity.
 	WebApplicationFirewall *WAFStatusType `json:"webApplicationFirewall,omitempty"`
+	// WebApplicationFirewallSettings restricts the traffic that is examined by ModSecurity when
+	// WebApplicationFirewall is enabled.
+	// +optional
+	WebApplicationFirewallSettings *WAFSettings `json:"webApplicationFirewallSettings,omitempty"`
 	// Specification for application layer (L7) log collection.
 	LogCollection *LogCollectionSpec `json:"logCollection,omitempty"`
 	// Application Layer Policy controls whether or not ALP enforcement is enabled for the cluster.
@@ -51,10 +55,36 @@ const (
 	ApplicationLayerPolicyDisabled ApplicationLayerPolicyStatusType = "Disabled"
 )
 
+// L7Protocol identifies a protocol of the traffic proxied by Envoy. HTTP is HTTP/1.0 and HTTP/1.1, HTTP2 is HTTP/2 over
+// cleartext (h2c), TLS is any TLS encrypted traffic and TCP is any other traffic.
+// +kubebuilder:validation:Enum=HTTP;HTTP2;TLS;TCP
+type L7Protocol string
+
+const (
+	L7ProtocolHTTP  L7Protocol = "HTTP"
+	L7ProtocolHTTP2 L7Protocol = "HTTP2"
+	L7ProtocolTLS   L7Protocol = "TLS"
+	L7ProtocolTCP   L7Protocol = "TCP"
+)
+
+// WAFSettings restricts the traffic that is examined by ModSecurity.
+type WAFSettings struct {
+	// NamespaceSelector restricts ModSecurity to traffic to workloads in the namespaces that match the selector.
+	// If omitted, traffic to workloads in all namespaces is examined.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Protocols restricts ModSecurity to traffic of the listed protocols. Only HTTP and HTTP2 are supported.
+	// If omitted, traffic of all supported protocols is examined.
+	// +optional
+	Protocols []L7Protocol `json:"protocols,omitempty"`
+}
+
 type EnvoySettings struct {
 	// The number of additional ingress proxy hops from the right side of the
 	// x-forwarded-for HTTP header to trust when determining the origin client’s
 	// IP address. 0 is permitted, but >=1 is the typical setting.
+	// Default: 0
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=2147483647
 	// +kubebuilder:default:=0
@@ -63,6 +93,7 @@ type EnvoySettings struct {
 	// If set to true, the Envoy connection manager will use the real remote address
 	// of the client connection when determining internal versus external origin and
 	// manipulating various headers.
+	// Default: false
 	// +kubebuilder:default:=false
 	// +optional
 	UseRemoteAddress bool `json:"useRemoteAddress,omitempty"`
@@ -85,6 +116,16 @@ type LogCollectionSpec struct {
 	// +optional
 	// Default: -1
 	LogRequestsPerInterval *int64 `json:"logRequestsPerInterval,omitempty"`
+
+	// NamespaceSelector restricts log collection to traffic to workloads in the namespaces that match the selector.
+	// If omitted, logs are collected for workloads in all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Protocols restricts log collection to traffic of the listed protocols.
+	// If omitted, logs are collected for all protocols.
+	// +optional
+	Protocols []L7Protocol `json:"protocols,omitempty"`
 }
 
 // ApplicationLayerStatus defines the observed state of ApplicationLayer
@@ -35,6 +35,11 @@ type ComplianceBenchmarkerDaemonSetSpec struct {
 	// Template describes the Compliance Benchmarker DaemonSet pod that will be created.
 	// +optional
 	Template *ComplianceBenchmarkerDaemonSetPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the Compliance Benchmarker DaemonSet with new ones.
+	// If omitted, the Compliance Benchmarker DaemonSet will use its default update strategy.
+	// +optional
+	Strategy *DaemonSetUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // ComplianceBenchmarkerDaemonSetPodTemplateSpec is the Compliance Benchmarker DaemonSet's PodTemplateSpec
@@ -58,6 +63,9 @@ type ComplianceBenchmarkerDaemonSetPodSpec struct {
 	// If omitted, the Compliance Benchmarker DaemonSet will use its default values for its containers.
 	// +optional
 	Containers []ComplianceBenchmarkerDaemonSetContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the compliance benchmarker pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // ComplianceBenchmarkerDaemonSetContainer is a Compliance Benchmarker DaemonSet container.
@@ -72,6 +80,9 @@ type ComplianceBenchmarkerDaemonSetContainer struct {
 	// If omitted, the Compliance Benchmarker DaemonSet will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // ComplianceBenchmarkerDaemonSetInitContainer is a Compliance Benchmarker DaemonSet init container.
@@ -144,6 +155,17 @@ func (c *ComplianceBenchmarkerDaemonSet) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *ComplianceBenchmarkerDaemonSet) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *ComplianceBenchmarkerDaemonSet) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -168,6 +190,20 @@ func (c *ComplianceBenchmarkerDaemonSet) GetDeploymentStrategy() *appsv1.Deploym
 	return nil
 }
 
+func (c *ComplianceBenchmarkerDaemonSet) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.daemonSetUpdateStrategy()
+	}
+	return nil
+}
+
 func (c *ComplianceBenchmarkerDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ComplianceBenchmarkerDaemonSet) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
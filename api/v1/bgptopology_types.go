@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BGPTopologySpec describes the BGP peerings of the cluster. The operator generates the BGPPeers and BGPFilters that
+// implement it, and reverts any changes made to them.
+type BGPTopologySpec struct {
+	// RouteReflectors makes the selected nodes route reflectors for all other nodes. The full node-to-node mesh is
+	// disabled while route reflectors are configured.
+	// +optional
+	RouteReflectors *BGPRouteReflectors `json:"routeReflectors,omitempty"`
+
+	// Racks peers the nodes of each rack with the routers of that rack, e.g. its top-of-rack switches.
+	// +optional
+	Racks []BGPRack `json:"racks,omitempty"`
+
+	// Filters are BGP filters that RouteReflectors and Racks refer to by name.
+	// +optional
+	Filters []BGPTopologyFilter `json:"filters,omitempty"`
+}
+
+// BGPRouteReflectors selects the nodes that act as route reflectors.
+type BGPRouteReflectors struct {
+	// NodeSelector selects the route reflector nodes by their labels.
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// ClusterID is the route reflector cluster ID of the route reflector nodes.
+	// Default: 244.0.0.1
+	// +kubebuilder:default:="244.0.0.1"
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// Filters are the names of the filters that are applied to the peerings with the route reflectors.
+	// +optional
+	Filters []string `json:"filters,omitempty"`
+}
+
+// BGPRack describes the routers that the nodes of a rack peer with.
+type BGPRack struct {
+	// Name identifies the rack. It must be a valid DNS label, as it is used in the names of the BGPPeers of the rack.
+	Name string `json:"name"`
+
+	// NodeSelector selects the nodes of the rack by their labels.
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// Peers are the routers that each node of the rack peers with.
+	Peers []BGPRackPeer `json:"peers"`
+
+	// Filters are the names of the filters that are applied to the peerings with the routers of the rack.
+	// +optional
+	Filters []string `json:"filters,omitempty"`
+}
+
+// BGPRackPeer is a router that the nodes of a rack peer with.
+type BGPRackPeer struct {
+	// PeerIP is the IP address of the router.
+	PeerIP string `json:"peerIP"`
+
+	// ASNumber is the AS number of the router.
+	// +kubebuilder:validation:Minimum=1
+	ASNumber uint32 `json:"asNumber"`
+}
+
+// BGPTopologyFilter is a named BGP filter.
+type BGPTopologyFilter struct {
+	// Name of the filter. The BGPFilter that is generated for it has the same name.
+	Name string `json:"name"`
+
+	v3.BGPFilterSpec `json:",inline"`
+}
+
+// BGPTopologyStatus defines the observed state of BGPTopology.
+type BGPTopologyStatus struct {
+	// State provides user-readable status.
+	State string `json:"state,omitempty"`
+
+	// Conditions represents the latest observed set of conditions for the component. A component may be one or more of
+	// Ready, Progressing, Degraded or other customer types.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+
+// BGPTopology configures the BGP peerings of the cluster from a higher-level description of it, such as route
+// reflectors and per-rack peers. At most one instance of this resource is supported. It must be named "default".
+type BGPTopology struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired BGP topology.
+	Spec BGPTopologySpec `json:"spec,omitempty"`
+
+	// Most recently observed state for the BGP topology.
+	Status BGPTopologyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BGPTopologyList contains a list of BGPTopology
+type BGPTopologyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BGPTopology `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BGPTopology{}, &BGPTopologyList{})
+}
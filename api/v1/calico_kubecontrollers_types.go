@@ -34,6 +34,9 @@ type CalicoKubeControllersDeploymentContainer struct {
 	// If used in conjunction with the deprecated ComponentResources, then this value takes precedence.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // CalicoKubeControllersDeploymentPodSpec is the calico-kube-controller Deployment's PodSpec.
@@ -67,6 +70,9 @@ type CalicoKubeControllersDeploymentPodSpec struct {
 	// WARNING: Please note that this field will override the default calico-kube-controllers Deployment tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the calico-kube-controllers pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // CalicoKubeControllersDeploymentPodTemplateSpec is the calico-kube-controllers Deployment's PodTemplateSpec
@@ -106,6 +112,11 @@ type CalicoKubeControllersDeploymentSpec struct {
 	// Template describes the calico-kube-controllers Deployment pod that will be created.
 	// +optional
 	Template *CalicoKubeControllersDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the calico-kube-controllers Deployment with new ones.
+	// If omitted, the calico-kube-controllers Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 func (c *CalicoKubeControllersDeployment) GetMetadata() *Metadata {
@@ -155,6 +166,17 @@ func (c *CalicoKubeControllersDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *CalicoKubeControllersDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *CalicoKubeControllersDeployment) GetAffinity() *v1.Affinity {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -200,9 +222,23 @@ func (c *CalicoKubeControllersDeployment) GetTerminationGracePeriodSeconds() *in
 }
 
 func (c *CalicoKubeControllersDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *CalicoKubeControllersDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *CalicoKubeControllersDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *CalicoKubeControllersDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
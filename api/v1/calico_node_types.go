@@ -34,6 +34,9 @@ type CalicoNodeDaemonSetContainer struct {
 	// If used in conjunction with the deprecated ComponentResources, then this value takes precedence.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // CalicoNodeDaemonSetInitContainer is a calico-node DaemonSet init container.
@@ -86,6 +89,9 @@ type CalicoNodeDaemonSetPodSpec struct {
 	// WARNING: Please note that this field will override the default calico-node DaemonSet tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the calico-node pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // CalicoNodeDaemonSetPodTemplateSpec is the calico-node DaemonSet's PodTemplateSpec
@@ -125,6 +131,11 @@ type CalicoNodeDaemonSetSpec struct {
 	// Template describes the calico-node DaemonSet pod that will be created.
 	// +optional
 	Template *CalicoNodeDaemonSetPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the calico-node DaemonSet with new ones.
+	// If omitted, the calico-node DaemonSet will use its default update strategy.
+	// +optional
+	Strategy *DaemonSetUpdateStrategy `json:"strategy,omitempty"`
 }
 
 func (c *CalicoNodeDaemonSet) GetMetadata() *Metadata {
@@ -191,6 +202,17 @@ func (c *CalicoNodeDaemonSet) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *CalicoNodeDaemonSet) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *CalicoNodeDaemonSet) GetAffinity() *v1.Affinity {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -237,6 +259,20 @@ func (c *CalicoNodeDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentStrategy
 	return nil
 }
 
+func (c *CalicoNodeDaemonSet) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.daemonSetUpdateStrategy()
+	}
+	return nil
+}
+
 func (c *CalicoNodeDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *CalicoNodeDaemonSet) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
@@ -34,6 +34,9 @@ type CalicoNodeWindowsDaemonSetContainer struct {
 	// If used in conjunction with the deprecated ComponentResources, then this value takes precedence.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // CalicoNodeWindowsDaemonSetInitContainer is a calico-node-windows DaemonSet init container.
@@ -86,6 +89,9 @@ type CalicoNodeWindowsDaemonSetPodSpec struct {
 	// WARNING: Please note that this field will override the default calico-node-windows DaemonSet tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the calico-node-windows pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // CalicoNodeWindowsDaemonSetPodTemplateSpec is the calico-node-windows DaemonSet's PodTemplateSpec
@@ -125,6 +131,11 @@ type CalicoNodeWindowsDaemonSetSpec struct {
 	// Template describes the calico-node-windows DaemonSet pod that will be created.
 	// +optional
 	Template *CalicoNodeWindowsDaemonSetPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the calico-node-windows DaemonSet with new ones.
+	// If omitted, the calico-node-windows DaemonSet will use its default update strategy.
+	// +optional
+	Strategy *DaemonSetUpdateStrategy `json:"strategy,omitempty"`
 }
 
 func (c *CalicoNodeWindowsDaemonSet) GetMetadata() *Metadata {
@@ -191,6 +202,17 @@ func (c *CalicoNodeWindowsDaemonSet) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *CalicoNodeWindowsDaemonSet) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *CalicoNodeWindowsDaemonSet) GetAffinity() *v1.Affinity {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -237,6 +259,20 @@ func (c *CalicoNodeWindowsDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentS
 	return nil
 }
 
+func (c *CalicoNodeWindowsDaemonSet) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.daemonSetUpdateStrategy()
+	}
+	return nil
+}
+
 func (c *CalicoNodeWindowsDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *CalicoNodeWindowsDaemonSet) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
@@ -16,6 +16,11 @@ limitations under the License.
 
 package v1
 
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
 // Metadata contains the standard Kubernetes labels and annotations fields.
 type Metadata struct {
 	// Labels is a map of string keys and values that may match replicaset and
@@ -41,3 +46,110 @@ const (
 	LogLevelFatal LogLevel = "Fatal"
 	LogLevelError LogLevel = "Error"
 )
+
+// DeploymentUpdateStrategy describes how to replace the existing pods of a Deployment with new ones. Only RollingUpdate
+// is supported at this time so the Type field is not exposed.
+type DeploymentUpdateStrategy struct {
+	// RollingUpdate configures how many pods may be unavailable, and how many pods may be created above the desired
+	// number of pods, while the Deployment is updated.
+	// +optional
+	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+}
+
+func (s *DeploymentUpdateStrategy) deploymentStrategy() *appsv1.DeploymentStrategy {
+	if s == nil || s.RollingUpdate == nil {
+		return nil
+	}
+	return &appsv1.DeploymentStrategy{
+		Type:          appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: s.RollingUpdate,
+	}
+}
+
+// DaemonSetUpdateStrategy describes how to replace the existing pods of a DaemonSet with new ones. Only RollingUpdate
+// is supported at this time so the Type field is not exposed. It is ignored while the Installation has a
+// RolloutPolicy, which updates DaemonSets in waves instead.
+type DaemonSetUpdateStrategy struct {
+	// RollingUpdate configures how many nodes may have an unavailable pod, and on how many nodes the updated pod may
+	// be started before the existing pod is removed, while the DaemonSet is updated.
+	// +optional
+	RollingUpdate *appsv1.RollingUpdateDaemonSet `json:"rollingUpdate,omitempty"`
+}
+
+func (s *DaemonSetUpdateStrategy) daemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	if s == nil || s.RollingUpdate == nil {
+		return nil
+	}
+	return &appsv1.DaemonSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+		RollingUpdate: s.RollingUpdate,
+	}
+}
+
+// ContainerProbeOverrides overrides the timings of the probes of a container. The checks performed by the probes are
+// not changed.
+type ContainerProbeOverrides struct {
+	// LivenessProbe overrides the timings of the container's liveness probe.
+	// If omitted, the container will use its default timings for its liveness probe.
+	// +optional
+	LivenessProbe *ProbeOverride `json:"livenessProbe,omitempty"`
+
+	// ReadinessProbe overrides the timings of the container's readiness probe.
+	// If omitted, the container will use its default timings for its readiness probe.
+	// +optional
+	ReadinessProbe *ProbeOverride `json:"readinessProbe,omitempty"`
+
+	// StartupProbe overrides the timings of the container's startup probe. If the container has no startup probe, one
+	// is added that performs the same check as its liveness probe, so that slow starting containers are not
+	// restarted by their liveness probe.
+	// +optional
+	StartupProbe *ProbeOverride `json:"startupProbe,omitempty"`
+}
+
+// ProbeOverride contains the timings of a probe. Fields that are omitted keep the value the container's probe
+// already has.
+type ProbeOverride struct {
+	// InitialDelaySeconds is the number of seconds after the container has started before the probe is initiated.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// PeriodSeconds is how often, in seconds, to perform the probe.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// SuccessThreshold is the minimum number of consecutive successes for the probe to be considered successful after
+	// having failed. Must be 1 for liveness and startup probes.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures for the probe to be considered failed after having
+	// succeeded.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// PodDNSOverrides overrides the DNS settings of the pods of a component, e.g. to use a node-local DNS cache or to add
+// search domains needed to resolve an external Elasticsearch cluster.
+type PodDNSOverrides struct {
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy that the component's pods are rendered with, which is ClusterFirst,
+	// or ClusterFirstWithHostNet for pods that use the host network.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig contains the nameservers, search domains and resolver options that are merged into the DNS
+	// configuration generated from DNSPolicy. It must contain at least one nameserver if DNSPolicy is None.
+	// If omitted, the pods use the DNS configuration generated from their DNS policy.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+}
@@ -35,6 +35,11 @@ type ComplianceControllerDeploymentSpec struct {
 	// Template describes the compliance controller Deployment pod that will be created.
 	// +optional
 	Template *ComplianceControllerDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the compliance controller Deployment with new ones.
+	// If omitted, the compliance controller Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // ComplianceControllerDeploymentPodTemplateSpec is the compliance controller Deployment's PodTemplateSpec
@@ -58,6 +63,9 @@ type ComplianceControllerDeploymentPodSpec struct {
 	// If omitted, the compliance controller Deployment will use its default values for its containers.
 	// +optional
 	Containers []ComplianceControllerDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the compliance controller pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // ComplianceControllerDeploymentContainer is a compliance controller Deployment container.
@@ -72,6 +80,9 @@ type ComplianceControllerDeploymentContainer struct {
 	// If omitted, the compliance controller Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // ComplianceControllerDeploymentInitContainer is a compliance controller Deployment init container.
@@ -144,6 +155,17 @@ func (c *ComplianceControllerDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *ComplianceControllerDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *ComplianceControllerDeployment) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -165,9 +187,23 @@ func (c *ComplianceControllerDeployment) GetTerminationGracePeriodSeconds() *int
 }
 
 func (c *ComplianceControllerDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *ComplianceControllerDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *ComplianceControllerDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ComplianceControllerDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
@@ -50,6 +50,9 @@ type ComplianceReporterPodSpec struct {
 	// If omitted, the ComplianceServer Deployment will use its default values for its containers.
 	// +optional
 	Containers []ComplianceReporterPodTemplateContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the compliance reporter pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // ComplianceReporterPodTemplateContainer is a ComplianceServer Deployment container.
@@ -132,6 +135,10 @@ func (c *ComplianceReporterPodTemplate) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *ComplianceReporterPodTemplate) GetContainerProbes() map[string]ContainerProbeOverrides {
+	return nil
+}
+
 func (c *ComplianceReporterPodTemplate) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -156,6 +163,17 @@ func (c *ComplianceReporterPodTemplate) GetDeploymentStrategy() *appsv1.Deployme
 	return nil
 }
 
+func (c *ComplianceReporterPodTemplate) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	return nil
+}
+
 func (c *ComplianceReporterPodTemplate) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ComplianceReporterPodTemplate) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Template != nil && c.Template.Spec != nil {
+		return &c.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
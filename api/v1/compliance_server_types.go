@@ -35,6 +35,11 @@ type ComplianceServerDeploymentSpec struct {
 	// Template describes the ComplianceServer Deployment pod that will be created.
 	// +optional
 	Template *ComplianceServerDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the ComplianceServer Deployment with new ones.
+	// If omitted, the ComplianceServer Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // ComplianceServerDeploymentPodTemplateSpec is the ComplianceServer Deployment's PodTemplateSpec
@@ -58,6 +63,9 @@ type ComplianceServerDeploymentPodSpec struct {
 	// If omitted, the ComplianceServer Deployment will use its default values for its containers.
 	// +optional
 	Containers []ComplianceServerDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the ComplianceServer pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // ComplianceServerDeploymentContainer is a ComplianceServer Deployment container.
@@ -72,6 +80,9 @@ type ComplianceServerDeploymentContainer struct {
 	// If omitted, the ComplianceServer Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // ComplianceServerDeploymentInitContainer is a ComplianceServer Deployment init container.
@@ -144,6 +155,17 @@ func (c *ComplianceServerDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *ComplianceServerDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *ComplianceServerDeployment) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -165,9 +187,23 @@ func (c *ComplianceServerDeployment) GetTerminationGracePeriodSeconds() *int64 {
 }
 
 func (c *ComplianceServerDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *ComplianceServerDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *ComplianceServerDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ComplianceServerDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
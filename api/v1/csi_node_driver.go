@@ -33,6 +33,9 @@ type CSINodeDriverDaemonSetContainer struct {
 	// If omitted, the csi-node-driver DaemonSet will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // CSINodeDriverDaemonSetPodSpec is the csi-node-driver DaemonSet's PodSpec.
@@ -63,6 +66,9 @@ type CSINodeDriverDaemonSetPodSpec struct {
 	// WARNING: Please note that this field will override the default csi-node-driver DaemonSet tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the csi-node-driver pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // CSINodeDriverDaemonSetPodTemplateSpec is the csi-node-driver DaemonSet's PodTemplateSpec
@@ -101,6 +107,11 @@ type CSINodeDriverDaemonSetSpec struct {
 	// Template describes the csi-node-driver DaemonSet pod that will be created.
 	// +optional
 	Template *CSINodeDriverDaemonSetPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the csi-node-driver DaemonSet with new ones.
+	// If omitted, the csi-node-driver DaemonSet will use its default update strategy.
+	// +optional
+	Strategy *DaemonSetUpdateStrategy `json:"strategy,omitempty"`
 }
 
 func (c *CSINodeDriverDaemonSet) GetMetadata() *Metadata {
@@ -150,6 +161,17 @@ func (c *CSINodeDriverDaemonSet) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *CSINodeDriverDaemonSet) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *CSINodeDriverDaemonSet) GetAffinity() *v1.Affinity {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -196,6 +218,20 @@ func (c *CSINodeDriverDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentStrat
 	return nil
 }
 
+func (c *CSINodeDriverDaemonSet) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.daemonSetUpdateStrategy()
+	}
+	return nil
+}
+
 func (c *CSINodeDriverDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *CSINodeDriverDaemonSet) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
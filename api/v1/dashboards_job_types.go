@@ -67,6 +67,10 @@ func (in *DashboardsJob) GetContainers() []v1.Container {
 	return nil
 }
 
+func (in *DashboardsJob) GetContainerProbes() map[string]ContainerProbeOverrides {
+	return nil
+}
+
 func (in *DashboardsJob) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -91,10 +95,21 @@ func (in *DashboardsJob) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
 	return nil
 }
 
+func (in *DashboardsJob) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	return nil
+}
+
 func (in *DashboardsJob) GetPriorityClassName() string {
 	return ""
 }
 
+func (in *DashboardsJob) GetPodDNSOverrides() *PodDNSOverrides {
+	if in.Spec != nil && in.Spec.Template != nil && in.Spec.Template.Spec != nil {
+		return &in.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
+
 // DashboardsJobSpec defines configuration for the Dashboards job.
 type DashboardsJobSpec struct {
 
@@ -119,6 +134,9 @@ type DashboardsJobPodSpec struct {
 	// If omitted, the Dashboard job will use its default values for its containers.
 	// +optional
 	Containers []DashboardsJobContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the dashboards job pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // DashboardsJobContainer is the Dashboards job container.
@@ -35,6 +35,11 @@ type DexDeploymentSpec struct {
 	// Template describes the Dex Deployment pod that will be created.
 	// +optional
 	Template *DexDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the Dex Deployment with new ones.
+	// If omitted, the Dex Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // DexDeploymentPodTemplateSpec is the Dex Deployment's PodTemplateSpec
@@ -58,6 +63,9 @@ type DexDeploymentPodSpec struct {
 	// If omitted, the Dex Deployment will use its default values for its containers.
 	// +optional
 	Containers []DexDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the Dex pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // DexDeploymentContainer is a Dex Deployment container.
@@ -72,6 +80,9 @@ type DexDeploymentContainer struct {
 	// If omitted, the Dex Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // DexDeploymentInitContainer is a Dex Deployment init container.
@@ -147,6 +158,17 @@ func (c *DexDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *DexDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *DexDeployment) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -168,9 +190,23 @@ func (c *DexDeployment) GetTerminationGracePeriodSeconds() *int64 {
 }
 
 func (c *DexDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *DexDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *DexDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *DexDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
@@ -58,6 +58,9 @@ type ECKOperatorStatefulSetPodSpec struct {
 	// If omitted, the ECKOperator StatefulSet will use its default values for its containers.
 	// +optional
 	Containers []ECKOperatorStatefulSetContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the ECKOperator pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // ECKOperatorStatefulSetContainer is a ECKOperator StatefulSet container.
@@ -145,6 +148,10 @@ func (c *ECKOperatorStatefulSet) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *ECKOperatorStatefulSet) GetContainerProbes() map[string]ContainerProbeOverrides {
+	return nil
+}
+
 func (c *ECKOperatorStatefulSet) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -169,6 +176,17 @@ func (c *ECKOperatorStatefulSet) GetDeploymentStrategy() *appsv1.DeploymentStrat
 	return nil
 }
 
+func (c *ECKOperatorStatefulSet) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	return nil
+}
+
 func (c *ECKOperatorStatefulSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ECKOperatorStatefulSet) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
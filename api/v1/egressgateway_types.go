@@ -55,6 +55,7 @@ type EGWDeploymentInitContainer struct {
 // EgressGatewaySpec defines the desired state of EgressGateway
 type EgressGatewaySpec struct {
 	// Replicas defines how many instances of the Egress Gateway pod will run.
+	// Default: 1
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=2147483647
 	// +optional
@@ -137,6 +138,9 @@ type EgressGatewayDeploymentPodSpec struct {
 	// PriorityClassName allows to specify a PriorityClass resource to be used.
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the egress gateway pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // EgressGatewayDeploymentPodTemplateSpec is the EGW Deployment's PodTemplateSpec
@@ -390,10 +394,18 @@ func (c *EgressGateway) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *EgressGateway) GetContainerProbes() map[string]ContainerProbeOverrides {
+	return nil
+}
+
 func (c *EgressGateway) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
 	return nil
 }
 
+func (c *EgressGateway) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	return nil
+}
+
 func (c *EgressGateway) GetTolerations() []v1.Toleration {
 	if c.Spec.Template != nil {
 		if c.Spec.Template.Spec != nil {
@@ -414,6 +426,13 @@ func (c *EgressGateway) GetPriorityClassName() string {
 	return ""
 }
 
+func (c *EgressGateway) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
+
 func (c *EgressGateway) GetPodTemplateMetadata() *Metadata {
 	if c.Spec.Template != nil {
 		m := &Metadata{Labels: c.Spec.Template.Metadata.Labels, Annotations: c.Spec.Template.Metadata.Annotations}
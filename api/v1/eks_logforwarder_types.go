@@ -35,6 +35,11 @@ type EKSLogForwarderDeploymentSpec struct {
 	// Template describes the EKSLogForwarder Deployment pod that will be created.
 	// +optional
 	Template *EKSLogForwarderDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the EKSLogForwarder Deployment with new ones.
+	// If omitted, the EKSLogForwarder Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // EKSLogForwarderDeploymentPodTemplateSpec is the EKSLogForwarder Deployment's PodTemplateSpec
@@ -58,6 +63,9 @@ type EKSLogForwarderDeploymentPodSpec struct {
 	// If omitted, the EKSLogForwarder Deployment will use its default values for its containers.
 	// +optional
 	Containers []EKSLogForwarderDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the EKSLogForwarder pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // EKSLogForwarderDeploymentContainer is a EKSLogForwarder Deployment container.
@@ -72,6 +80,9 @@ type EKSLogForwarderDeploymentContainer struct {
 	// If omitted, the EKSLogForwarder Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // EKSLogForwarderDeploymentInitContainer is a EKSLogForwarder Deployment init container.
@@ -147,6 +158,17 @@ func (c *EKSLogForwarderDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *EKSLogForwarderDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *EKSLogForwarderDeployment) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -168,9 +190,23 @@ func (c *EKSLogForwarderDeployment) GetTerminationGracePeriodSeconds() *int64 {
 }
 
 func (c *EKSLogForwarderDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *EKSLogForwarderDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *EKSLogForwarderDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *EKSLogForwarderDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
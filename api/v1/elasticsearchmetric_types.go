@@ -35,6 +35,11 @@ type ElasticsearchMetricsDeploymentSpec struct {
 	// Template describes the ElasticsearchMetrics Deployment pod that will be created.
 	// +optional
 	Template *ElasticsearchMetricsDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the ElasticsearchMetrics Deployment with new ones.
+	// If omitted, the ElasticsearchMetrics Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // ElasticsearchMetricsDeploymentPodTemplateSpec is the ElasticsearchMetricsDeployment's PodTemplateSpec
@@ -58,6 +63,9 @@ type ElasticsearchMetricsDeploymentPodSpec struct {
 	// If omitted, the ElasticsearchMetrics Deployment will use its default values for its containers.
 	// +optional
 	Containers []ElasticsearchMetricsDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the Elasticsearch metrics pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // ElasticsearchMetricsDeploymentContainer is a ElasticsearchMetricsDeployment container.
@@ -72,6 +80,9 @@ type ElasticsearchMetricsDeploymentContainer struct {
 	// If omitted, the ElasticsearchMetrics Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // ElasticsearchMetricsDeploymentInitContainer is a ElasticsearchMetricsDeployment init container.
@@ -144,6 +155,17 @@ func (c *ElasticsearchMetricsDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *ElasticsearchMetricsDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *ElasticsearchMetricsDeployment) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -165,9 +187,23 @@ func (c *ElasticsearchMetricsDeployment) GetTerminationGracePeriodSeconds() *int
 }
 
 func (c *ElasticsearchMetricsDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *ElasticsearchMetricsDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *ElasticsearchMetricsDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ElasticsearchMetricsDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
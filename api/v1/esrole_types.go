@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ESRoleSubjectKind is the kind of an ESRole subject.
+// +kubebuilder:validation:Enum=User;Group
+type ESRoleSubjectKind string
+
+const (
+	ESRoleSubjectKindUser  ESRoleSubjectKind = "User"
+	ESRoleSubjectKindGroup ESRoleSubjectKind = "Group"
+)
+
+// ESIndexPrivilege is a privilege that an ESRole grants on the log indices.
+// +kubebuilder:validation:Enum=read;view_index_metadata;monitor
+type ESIndexPrivilege string
+
+const (
+	ESIndexPrivilegeRead              ESIndexPrivilege = "read"
+	ESIndexPrivilegeViewIndexMetadata ESIndexPrivilege = "view_index_metadata"
+	ESIndexPrivilegeMonitor           ESIndexPrivilege = "monitor"
+)
+
+// ESRoleSubject is a user or group that an ESRole grants access to.
+type ESRoleSubject struct {
+	// Kind is User or Group.
+	Kind ESRoleSubjectKind `json:"kind"`
+
+	// Name is the name of the user or group, as in the subjects of a Kubernetes RoleBinding. The usernamePrefix and
+	// groupsPrefix of the Authentication are removed from it, so that it matches the name that the identity provider
+	// reports to Elasticsearch.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ESRoleIndex is access to the log indices of some clusters.
+type ESRoleIndex struct {
+	// Names are the prefixes of the indices, such as tigera_secure_ee_flows. Each must start with tigera_secure_ee_.
+	// +kubebuilder:validation:MinItems=1
+	Names []string `json:"names"`
+
+	// Clusters restricts the access to the logs of the named clusters. When empty, the access is to the logs of all the
+	// clusters.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// Privileges are the privileges on the indices.
+	// Default: [read]
+	// +optional
+	Privileges []ESIndexPrivilege `json:"privileges,omitempty"`
+}
+
+// ESRoleSpec defines the access to the log indices in Elasticsearch that an ESRole grants.
+type ESRoleSpec struct {
+	// Subjects are the users and groups that the access is granted to.
+	// +kubebuilder:validation:MinItems=1
+	Subjects []ESRoleSubject `json:"subjects"`
+
+	// Indices are the log indices that the subjects can access.
+	// +kubebuilder:validation:MinItems=1
+	Indices []ESRoleIndex `json:"indices"`
+}
+
+// ESRoleStatus defines the observed state of ESRole.
+type ESRoleStatus struct {
+	// Role is the name of the Elasticsearch role, and of the role mapping that grants it to the subjects.
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// Conditions represents the latest observed set of conditions for the ESRole. The Ready condition reports whether
+	// the role and role mapping are in sync with the spec.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:subresource:status
+
+// ESRole grants users and groups of the identity provider of the cluster read access to log indices in Elasticsearch.
+// The operator syncs each ESRole to an Elasticsearch role and a role mapping, which apply to the users that a realm that
+// supports role mappings, such as an OIDC or SAML realm, authenticates. Since an ESRole can grant access to the logs of
+// every namespace, the permission to create ESRoles should be granted like the permission to read the logs. It is not
+// supported in multi-tenant mode.
+type ESRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired access.
+	Spec ESRoleSpec `json:"spec,omitempty"`
+
+	// Most recently observed state for the ESRole.
+	Status ESRoleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ESRoleList contains a list of ESRole
+type ESRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ESRole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ESRole{}, &ESRoleList{})
+}
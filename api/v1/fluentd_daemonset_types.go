@@ -35,6 +35,11 @@ type FluentdDaemonSetSpec struct {
 	// Template describes the Fluentd DaemonSet pod that will be created.
 	// +optional
 	Template *FluentdDaemonSetPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the Fluentd DaemonSet with new ones.
+	// If omitted, the Fluentd DaemonSet will use its default update strategy.
+	// +optional
+	Strategy *DaemonSetUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // FluentdDaemonSetPodTemplateSpec is the Fluentd DaemonSet's PodTemplateSpec
@@ -58,6 +63,9 @@ type FluentdDaemonSetPodSpec struct {
 	// If omitted, the Fluentd DaemonSet will use its default values for its containers.
 	// +optional
 	Containers []FluentdDaemonSetContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the Fluentd pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // FluentdDaemonSetContainer is a Fluentd DaemonSet container.
@@ -72,6 +80,9 @@ type FluentdDaemonSetContainer struct {
 	// If omitted, the Fluentd DaemonSet will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // FluentdDaemonSetInitContainer is a Fluentd DaemonSet init container.
@@ -147,6 +158,17 @@ func (c *FluentdDaemonSet) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *FluentdDaemonSet) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *FluentdDaemonSet) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -171,6 +193,20 @@ func (c *FluentdDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
 	return nil
 }
 
+func (c *FluentdDaemonSet) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.daemonSetUpdateStrategy()
+	}
+	return nil
+}
+
 func (c *FluentdDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *FluentdDaemonSet) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
@@ -32,9 +32,22 @@ type GuardianDeployment struct {
 // GuardianDeploymentSpec defines configuration for the guardian Deployment.
 type GuardianDeploymentSpec struct {
 
+	// Replicas is the number of guardian pods. Each pod opens its own tunnel to the management cluster, so that the
+	// management cluster can still reach the managed cluster through the remaining tunnels when a pod is lost. When
+	// more than one replica is run, the pods are spread across nodes and replaced one at a time.
+	// Default: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// Template describes the guardian Deployment pod that will be created.
 	// +optional
 	Template *GuardianDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the guardian Deployment with new ones.
+	// If omitted, the guardian Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // GuardianDeploymentPodTemplateSpec is the guardian Deployment's PodTemplateSpec
@@ -58,6 +71,31 @@ type GuardianDeploymentPodSpec struct {
 	// If omitted, the guardian Deployment will use its default values for its containers.
 	// +optional
 	Containers []GuardianDeploymentContainer `json:"containers,omitempty"`
+
+	// Affinity is a group of affinity scheduling rules for the guardian pods.
+	// If specified, this overrides any affinity that may be set on the guardian Deployment.
+	// If omitted, the guardian Deployment will use its default value for affinity.
+	// WARNING: Please note that this field will override the default guardian Deployment affinity.
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+
+	// NodeSelector is the guardian pod's scheduling constraints.
+	// If specified, each of the key/value pairs are added to the guardian Deployment nodeSelector provided
+	// the key does not already exist in the object's nodeSelector.
+	// If omitted, the guardian Deployment will use its default value for nodeSelector.
+	// WARNING: Please note that this field will modify the default guardian Deployment nodeSelector.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations is the guardian pod's tolerations.
+	// If specified, this overrides any tolerations that may be set on the guardian Deployment.
+	// If omitted, the guardian Deployment will use its default value for tolerations.
+	// WARNING: Please note that this field will override the default guardian Deployment tolerations.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the guardian pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // GuardianDeploymentContainer is a guardian Deployment container.
@@ -72,6 +110,9 @@ type GuardianDeploymentContainer struct {
 	// If omitted, the guardian Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // GuardianDeploymentInitContainer is a guardian Deployment init container.
@@ -142,7 +183,21 @@ func (c *GuardianDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *GuardianDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *GuardianDeployment) GetAffinity() *v1.Affinity {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return c.Spec.Template.Spec.Affinity
+	}
 	return nil
 }
 
@@ -151,10 +206,16 @@ func (c *GuardianDeployment) GetTopologySpreadConstraints() []v1.TopologySpreadC
 }
 
 func (c *GuardianDeployment) GetNodeSelector() map[string]string {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return c.Spec.Template.Spec.NodeSelector
+	}
 	return nil
 }
 
 func (c *GuardianDeployment) GetTolerations() []v1.Toleration {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return c.Spec.Template.Spec.Tolerations
+	}
 	return nil
 }
 
@@ -163,9 +224,31 @@ func (c *GuardianDeployment) GetTerminationGracePeriodSeconds() *int64 {
 }
 
 func (c *GuardianDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *GuardianDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *GuardianDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *GuardianDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
+
+// GetReplicas returns the number of guardian pods.
+func (c *GuardianDeployment) GetReplicas() int32 {
+	if c == nil || c.Spec == nil || c.Spec.Replicas == nil {
+		return 1
+	}
+	return *c.Spec.Replicas
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HostProtectionSpec defines the HostEndpoints that the operator creates for the nodes of the cluster.
+type HostProtectionSpec struct {
+	// InterfaceName is the name of the interface that the HostEndpoint of each node applies to. The value "*"
+	// applies the HostEndpoint to all interfaces of the node, including the ones that are added later.
+	// Default: *
+	// +kubebuilder:default:="*"
+	// +optional
+	InterfaceName string `json:"interfaceName,omitempty"`
+
+	// NodeSelector selects the nodes that a HostEndpoint is created for by their labels. If omitted, a HostEndpoint
+	// is created for every node.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Labels are added to each HostEndpoint, in addition to the labels of its node, so that policies can select them.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// FailsafePolicy controls whether a policy is rendered in the allow-tigera tier that allows the traffic of the
+	// HostEndpoints that the cluster needs to function, such as SSH, BGP, DNS and the Kubernetes API server, before
+	// any other policy is applied. It is only rendered for Calico Enterprise.
+	// Default: Enabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +kubebuilder:default:=Enabled
+	// +optional
+	FailsafePolicy *FailsafePolicyType `json:"failsafePolicy,omitempty"`
+}
+
+type FailsafePolicyType string
+
+const (
+	FailsafePolicyEnabled  FailsafePolicyType = "Enabled"
+	FailsafePolicyDisabled FailsafePolicyType = "Disabled"
+)
+
+// HostProtectionStatus defines the observed state of HostProtection.
+type HostProtectionStatus struct {
+	// State provides user-readable status.
+	State string `json:"state,omitempty"`
+
+	// Conditions represents the latest observed set of conditions for the component. A component may be one or more of
+	// Ready, Progressing, Degraded or other customer types.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+
+// HostProtection makes the operator create and maintain a HostEndpoint for each node of the cluster, so that host
+// protection can be enabled declaratively. The HostEndpoints use the projectcalico-default-allow profile, so traffic
+// that no policy selects is still allowed. At most one instance of this resource is supported. It must be named
+// "default".
+type HostProtection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired state for HostProtection.
+	Spec HostProtectionSpec `json:"spec,omitempty"`
+
+	// Most recently observed state for HostProtection.
+	Status HostProtectionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HostProtectionList contains a list of HostProtection
+type HostProtectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HostProtection `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HostProtection{}, &HostProtectionList{})
+}
@@ -37,6 +37,39 @@ type Image struct {
 	// Digest is the image identifier that will be used for the Image.
 	// The field should not include a leading `@` and must be prefixed with `sha256:`.
 	Digest string `json:"digest"`
+
+	// Registry overrides the Installation Registry for this image only.
+	// If specified then the given value must end with a slash character (`/`). A special case value,
+	// UseDefault, is supported to explicitly use the default registry for the image.
+	// +optional
+	Registry string `json:"registry,omitempty"`
+
+	// ImagePath overrides the Installation ImagePath for this image only.
+	// A special case value, UseDefault, is supported to explicitly use the default image path for the image.
+	// +optional
+	ImagePath string `json:"imagePath,omitempty"`
+
+	// ImagePrefix overrides the Installation ImagePrefix for this image only.
+	// A special case value, UseDefault, is supported to explicitly use no prefix for the image.
+	// +optional
+	ImagePrefix string `json:"imagePrefix,omitempty"`
+
+	// ArchitectureDigests optionally specifies digests of the single-architecture images for the Image.
+	// When every node in the cluster reports the same architecture and a digest is listed for that
+	// architecture, it is used instead of Digest.
+	// +optional
+	ArchitectureDigests []ArchitectureDigest `json:"architectureDigests,omitempty"`
+}
+
+type ArchitectureDigest struct {
+	// Architecture is the CPU architecture the Digest was built for, as reported by
+	// the `kubernetes.io/arch` node label.
+	// +kubebuilder:validation:Enum=amd64;arm64
+	Architecture string `json:"architecture"`
+
+	// Digest is the image identifier that will be used for the Image on the given Architecture.
+	// The field should not include a leading `@` and must be prefixed with `sha256:`.
+	Digest string `json:"digest"`
 }
 
 // +kubebuilder:object:root=true
@@ -24,6 +24,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	pcv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
 )
@@ -125,9 +126,21 @@ type InstallationSpec struct {
 	// +optional
 	TyphaMetricsPort *int32 `json:"typhaMetricsPort,omitempty"`
 
+	// FlowLogs configures how calico-node writes flow logs to file for collection by fluentd.
+	// If specified, these settings take precedence over the equivalent fields of the default FelixConfiguration.
+	// Only supported for the TigeraSecureEnterprise variant.
+	// +optional
+	FlowLogs *FlowLogsSpec `json:"flowLogs,omitempty"`
+
+	// TyphaAutoscaling configures how the number of Typha replicas scales with the number of nodes in the cluster.
+	// If omitted, the operator runs one Typha for every 200 nodes plus one, with at least three Typhas
+	// in clusters of more than four nodes.
+	// +optional
+	TyphaAutoscaling *TyphaAutoscaling `json:"typhaAutoscaling,omitempty"`
+
 	// FlexVolumePath optionally specifies a custom path for FlexVolume. If not specified, FlexVolume will be
 	// enabled by default. If set to 'None', FlexVolume will be disabled. The default is based on the
-	// kubernetesProvider.
+	// kubernetesProvider. On EKS clusters with nodes running Bottlerocket, FlexVolume is disabled by default.
 	// +optional
 	FlexVolumePath string `json:"flexVolumePath,omitempty"`
 
@@ -197,8 +210,246 @@ type InstallationSpec struct {
 	// Kubernetes Service CIDRs. Specifying this is required when using Calico for Windows.
 	// +optional
 	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// ImageVerification configures an admission policy that requires the images deployed by the operator
+	// to be signed. If not specified, image signatures are not verified.
+	// +optional
+	ImageVerification *ImageVerification `json:"imageVerification,omitempty"`
+
+	// RolloutPolicy configures staged rollouts of the DaemonSets managed by the operator. When set, updated pods are
+	// rolled out in waves instead of across the whole cluster at once, and the rollout is paused if the updated pods
+	// restart too often. If not specified, DaemonSets are updated using their rolling update strategy.
+	// +optional
+	RolloutPolicy *RolloutPolicy `json:"rolloutPolicy,omitempty"`
+
+	// RollbackPolicy configures rollbacks of the Deployments and DaemonSets managed by the operator. When set, the
+	// operator records the last pod template of each of them that was fully rolled out and ready, and rolls back to
+	// it when a new rollout fails. If not specified, failed rollouts are not rolled back.
+	// +optional
+	RollbackPolicy *RollbackPolicy `json:"rollbackPolicy,omitempty"`
+
+	// ResourceAdoption controls how the operator handles existing resources that it did not create, for example the
+	// resources of a manifest-based install of Calico or Elasticsearch. When Enabled, the operator adopts an existing
+	// resource of the same name as one it renders instead of failing or creating a duplicate: it labels the resource as
+	// managed by the operator and takes ownership of its fields using server-side apply. IP pools that match the name
+	// and CIDR of a pool in the Installation are adopted as well.
+	// Default: Disabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	ResourceAdoption *ResourceAdoption `json:"resourceAdoption,omitempty"`
+
+	// ServiceMesh renders the components that support it so that they run inside a service mesh: their pods get a
+	// sidecar proxy that is started before the component, the ports on which the components terminate TLS themselves
+	// are not intercepted by the proxy, and their policies allow the proxy to reach the control plane of the mesh.
+	// If not specified, components are rendered without any service mesh configuration.
+	// +optional
+	ServiceMesh *ServiceMesh `json:"serviceMesh,omitempty"`
+
+	// NamespaceDefaultDeny renders a Kubernetes NetworkPolicy that denies all ingress and egress traffic in each
+	// namespace that the operator secures with allow-tigera policies. Traffic that the allow-tigera policies pass on to
+	// later tiers, instead of allowing it, is then denied unless it is allowed by policies in those tiers.
+	// Default: Disabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	NamespaceDefaultDeny *NamespaceDefaultDenyType `json:"namespaceDefaultDeny,omitempty"`
+
+	// WireGuard enables WireGuard encryption of the IPv4 traffic between the nodes that it selects. The operator
+	// reports in the Installation status on how many of those nodes traffic is encrypted.
+	// If not specified, WireGuard is not managed by the operator.
+	// +optional
+	WireGuard *WireGuard `json:"wireGuard,omitempty"`
+
+	// Features enables or disables the optional subsystems of Calico Enterprise in one place. The operator creates or
+	// deletes the custom resources of the subsystems that have one, and renders or removes the others.
+	// If not specified, the subsystems are managed through their own custom resources.
+	// Only supported for the TigeraSecureEnterprise variant.
+	// +optional
+	Features *Features `json:"features,omitempty"`
+}
+
+// Features enables or disables the optional subsystems of Calico Enterprise. A subsystem that is not set is left as
+// it is.
+type Features struct {
+	// Compliance enables the compliance reports by creating the default Compliance resource, or disables them by
+	// deleting it.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	Compliance *FeatureState `json:"compliance,omitempty"`
+
+	// IntrusionDetection enables intrusion detection by creating the default IntrusionDetection resource, or
+	// disables it by deleting it.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	IntrusionDetection *FeatureState `json:"intrusionDetection,omitempty"`
+
+	// PacketCapture enables the packet capture API by creating the default PacketCaptureAPI resource, or disables it
+	// by deleting it.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	PacketCapture *FeatureState `json:"packetCapture,omitempty"`
+
+	// ElasticsearchMetrics enables or disables the Elasticsearch metrics exporter that is deployed along with the
+	// LogStorage. It is enabled unless set to Disabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	ElasticsearchMetrics *FeatureState `json:"elasticsearchMetrics,omitempty"`
+
+	// Kibana enables or disables the Kibana that is deployed along with the LogStorage. It is enabled unless set to
+	// Disabled, or FIPS mode is enabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	Kibana *FeatureState `json:"kibana,omitempty"`
+}
+
+type FeatureState string
+
+const (
+	FeatureEnabled  FeatureState = "Enabled"
+	FeatureDisabled FeatureState = "Disabled"
+)
+
+// IsFeatureEnabled returns true if the feature is set to Enabled.
+func IsFeatureEnabled(state *FeatureState) bool {
+	return state != nil && *state == FeatureEnabled
+}
+
+// IsFeatureDisabled returns true if the feature is set to Disabled.
+func IsFeatureDisabled(state *FeatureState) bool {
+	return state != nil && *state == FeatureDisabled
+}
+
+// KibanaDisabled returns true if Kibana is disabled by the features. It is safe to call on nil Features.
+func (f *Features) KibanaDisabled() bool {
+	return f != nil && IsFeatureDisabled(f.Kibana)
+}
+
+// ElasticsearchMetricsDisabled returns true if the Elasticsearch metrics exporter is disabled by the features. It is
+// safe to call on nil Features.
+func (f *Features) ElasticsearchMetricsDisabled() bool {
+	return f != nil && IsFeatureDisabled(f.ElasticsearchMetrics)
+}
+
+// WireGuard configures the nodes on which WireGuard is enabled.
+type WireGuard struct {
+	// NodeSelector selects the nodes, for example the node pools, on which WireGuard is enabled. Traffic between two
+	// nodes is only encrypted if WireGuard is enabled on both of them.
+	// If not specified, WireGuard is enabled on all Linux nodes.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+}
+
+type NamespaceDefaultDenyType string
+
+const (
+	NamespaceDefaultDenyEnabled  NamespaceDefaultDenyType = "Enabled"
+	NamespaceDefaultDenyDisabled NamespaceDefaultDenyType = "Disabled"
+)
+
+// ServiceMesh configures the service mesh that components run inside.
+type ServiceMesh struct {
+	// Type is the service mesh that is installed in the cluster.
+	// +kubebuilder:validation:Enum=Istio;Linkerd
+	Type ServiceMeshType `json:"type"`
+}
+
+type ServiceMeshType string
+
+const (
+	ServiceMeshTypeIstio   ServiceMeshType = "Istio"
+	ServiceMeshTypeLinkerd ServiceMeshType = "Linkerd"
+)
+
+// RolloutPolicy configures staged rollouts of the DaemonSets managed by the operator. The pods of each DaemonSet are
+// updated wave by wave. A wave starts once the updated pods of the previous waves are ready, and the pods that are not
+// covered by any wave are updated after the last wave.
+type RolloutPolicy struct {
+	// Waves lists the waves of the rollout, in order.
+	// +kubebuilder:validation:MinItems=1
+	Waves []RolloutWave `json:"waves"`
+
+	// MaxUnavailable is the maximum number of pods of a DaemonSet that can be unavailable while it is updated. Value
+	// can be an absolute number (ex: 5) or a percentage of the DaemonSet's pods (ex: 10%).
+	// Default: 1
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MaxRestarts is the number of times a container of an updated pod can restart since the rollout started before
+	// the rollout of its DaemonSet is paused. A paused rollout resumes once a new revision of the DaemonSet is rolled
+	// out, or when the RolloutPolicy is removed.
+	// Default: 3
+	// +optional
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+}
+
+type RollbackMode string
+
+const (
+	// RollbackModeAutomatic rolls back a workload when its rollout is not done within the failure window.
+	RollbackModeAutomatic RollbackMode = "Automatic"
+
+	// RollbackModeManual only rolls back a workload when it is annotated with operator.tigera.io/rollback=true.
+	RollbackModeManual RollbackMode = "Manual"
+)
+
+// RollbackPolicy configures rollbacks of the Deployments and DaemonSets managed by the operator to their
+// last-known-good pod template. A workload that was rolled back stays rolled back until the operator renders a
+// different pod template for it, e.g. after the configuration is fixed or the operator is upgraded.
+type RollbackPolicy struct {
+	// Mode determines whether failed rollouts are rolled back automatically once the failure window has passed, or
+	// only when a workload is annotated with operator.tigera.io/rollback=true. The annotation triggers a rollback
+	// in either mode.
+	// Default: Manual
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// +optional
+	Mode *RollbackMode `json:"mode,omitempty"`
+
+	// FailureWindowSeconds is how long a rollout may take before it is considered failed, i.e. before all the pods
+	// of the workload are updated and ready.
+	// Default: 600
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureWindowSeconds *int32 `json:"failureWindowSeconds,omitempty"`
+}
+
+// RolloutWave selects the nodes whose pods are updated in a wave of a staged rollout. Exactly one of NodeSelector and
+// Percentage must be set.
+type RolloutWave struct {
+	// NodeSelector selects the nodes whose pods are updated in this wave.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Percentage is the percentage of the nodes that the DaemonSet runs on whose pods are updated by the end of this
+	// wave, including the nodes of the previous waves. Nodes are picked in order of their names.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+}
+
+// ImageVerification configures signature verification of the images deployed by the operator.
+// The operator renders a Kyverno ClusterPolicy that verifies the cosign signatures of the images, so
+// Kyverno must be installed in the cluster for the policy to take effect.
+type ImageVerification struct {
+	// PublicKeys is a list of PEM encoded cosign public keys. Images deployed by the operator must be
+	// signed by at least one of the keys.
+	// +kubebuilder:validation:MinItems=1
+	PublicKeys []string `json:"publicKeys"`
+
+	// FailureAction determines whether pods using an image that fails verification are rejected or only
+	// reported by the policy.
+	// Default: Enforce
+	// +kubebuilder:validation:Enum=Enforce;Audit
+	// +optional
+	FailureAction *ImageVerificationFailureAction `json:"failureAction,omitempty"`
 }
 
+type ImageVerificationFailureAction string
+
+const (
+	ImageVerificationFailureActionEnforce ImageVerificationFailureAction = "Enforce"
+	ImageVerificationFailureActionAudit   ImageVerificationFailureAction = "Audit"
+)
+
 type Logging struct {
 	// Customized logging specification for calico-cni plugin
 	// +optional
@@ -231,6 +482,94 @@ const (
 	FIPSModeDisabled FIPSMode = "Disabled"
 )
 
+type ResourceAdoption string
+
+const (
+	ResourceAdoptionEnabled  ResourceAdoption = "Enabled"
+	ResourceAdoptionDisabled ResourceAdoption = "Disabled"
+)
+
+// FlowLogsAggregationKind is how calico-node aggregates flow log entries.
+// +kubebuilder:validation:Enum=None;SourcePort;PodPrefix
+type FlowLogsAggregationKind string
+
+const (
+	// FlowLogsAggregationKindNone writes an entry for every flow.
+	FlowLogsAggregationKindNone FlowLogsAggregationKind = "None"
+	// FlowLogsAggregationKindSourcePort aggregates flows that only differ by source port.
+	FlowLogsAggregationKindSourcePort FlowLogsAggregationKind = "SourcePort"
+	// FlowLogsAggregationKindPodPrefix aggregates flows between pods of the same workload, and source ports.
+	FlowLogsAggregationKindPodPrefix FlowLogsAggregationKind = "PodPrefix"
+)
+
+// FlowLogsSpec configures flow log output of calico-node. Longer flush intervals and coarser aggregation reduce the
+// volume of flow logs, and so how quickly flow log indices reach the rollover size computed from the LogStorage.
+type FlowLogsSpec struct {
+	// FlushInterval is how often calico-node writes flow logs to file.
+	// If omitted, the FelixConfiguration value is used, which defaults to 300s.
+	// +optional
+	FlushInterval *metav1.Duration `json:"flushInterval,omitempty"`
+
+	// FileMaxFileSizeMB is the size in MB that a flow log file may reach before it is rotated.
+	// If omitted, the FelixConfiguration value is used, which defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FileMaxFileSizeMB *int32 `json:"fileMaxFileSizeMB,omitempty"`
+
+	// AggregationKindForAllowed is how flow log entries for allowed connections are aggregated.
+	// If omitted, the FelixConfiguration value is used, which defaults to PodPrefix.
+	// +optional
+	AggregationKindForAllowed *FlowLogsAggregationKind `json:"aggregationKindForAllowed,omitempty"`
+
+	// AggregationKindForDenied is how flow log entries for denied connections are aggregated.
+	// If omitted, the FelixConfiguration value is used, which defaults to SourcePort.
+	// +optional
+	AggregationKindForDenied *FlowLogsAggregationKind `json:"aggregationKindForDenied,omitempty"`
+}
+
+// Level returns the Felix aggregation level for the aggregation kind.
+func (k FlowLogsAggregationKind) Level() int {
+	switch k {
+	case FlowLogsAggregationKindSourcePort:
+		return 1
+	case FlowLogsAggregationKindPodPrefix:
+		return 2
+	}
+	return 0
+}
+
+// TyphaAutoscaling configures the number of Typha replicas for ranges of node counts, in the same way as the
+// ladder mode of the cluster-proportional-autoscaler.
+type TyphaAutoscaling struct {
+	// NodesToReplicas is a list of steps, in increasing order of nodes. The step with the largest number of nodes
+	// that does not exceed the number of schedulable nodes in the cluster gives the number of Typha replicas.
+	// Clusters smaller than the first step use the replicas of the first step.
+	// +kubebuilder:validation:MinItems=1
+	NodesToReplicas []TyphaScaleStep `json:"nodesToReplicas"`
+}
+
+// TyphaScaleStep sets the number of Typha replicas from a number of nodes onwards.
+type TyphaScaleStep struct {
+	// Nodes is the number of schedulable nodes from which this step applies.
+	// +kubebuilder:validation:Minimum=0
+	Nodes int32 `json:"nodes"`
+
+	// Replicas is the number of Typha replicas to run.
+	// +kubebuilder:validation:Minimum=1
+	Replicas int32 `json:"replicas"`
+}
+
+// ReplicasForNodes returns the number of Typha replicas for the given number of nodes.
+func (t *TyphaAutoscaling) ReplicasForNodes(nodes int) int {
+	replicas := 0
+	for i, step := range t.NodesToReplicas {
+		if i == 0 || int(step.Nodes) <= nodes {
+			replicas = int(step.Replicas)
+		}
+	}
+	return replicas
+}
+
 // Deprecated. Please use TyphaDeployment instead.
 // TyphaAffinity allows configuration of node affinity characteristics for Typha pods.
 type TyphaAffinity struct {
@@ -667,6 +1006,13 @@ type IPPool struct {
 	// AllowedUse controls what the IP pool will be used for.  If not specified or empty, defaults to
 	// ["Tunnel", "Workload"] for back-compatibility
 	AllowedUses []IPPoolAllowedUse `json:"allowedUses,omitempty" validate:"omitempty"`
+
+	// Drain stops new IP addresses from being allocated from the IP pool, so that workloads move to the other IP
+	// pools as they are recreated. The progress of the drain is reported in the Installation status. Once no IP
+	// addresses are allocated from the pool anymore, it can be removed from the Installation. If not specified, the
+	// operator does not change whether an existing IP pool is disabled.
+	// +optional
+	Drain *bool `json:"drain,omitempty"`
 }
 
 type IPPoolAllowedUse string
@@ -726,12 +1072,19 @@ func (p *IPPool) ToProjectCalicoV1() (*pcv1.IPPool, error) {
 		pool.Spec.AllowedUses = append(pool.Spec.AllowedUses, pcv1.IPPoolAllowedUse(use))
 	}
 
+	// A drained pool is disabled, so that no new addresses are allocated from it.
+	if p.Drain != nil {
+		pool.Spec.Disabled = *p.Drain
+	}
+
 	return &pool, nil
 }
 
 // FromProjectCalicoV1 populates the IP pool with the data from the given
 // crd.projectcalico.org/v1 IP pool. It is the direct inverse of ToProjectCalicoV1,
-// and should be updated with every new field added to the IP pool structure.
+// and should be updated with every new field added to the IP pool structure. The only
+// exception is Drain, which is only ever set in the Installation: a disabled pool in
+// the cluster is not necessarily being drained.
 func (p *IPPool) FromProjectCalicoV1(crd pcv1.IPPool) {
 	p.Name = crd.Name
 	p.CIDR = crd.Spec.CIDR
@@ -897,10 +1250,131 @@ type InstallationStatus struct {
 	// version deployed.
 	CalicoVersion string `json:"calicoVersion,omitempty"`
 
+	// OperatorVersion is the version of the operator that most recently reconciled the Installation.
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// Images lists the images of the variant, as resolved from the registry, image path and image prefix of the
+	// Installation and the ImageSet in use, so that it can be audited exactly which images are deployed.
+	// +optional
+	Images []ImageStatus `json:"images,omitempty"`
+
 	// Conditions represents the latest observed set of conditions for the component. A component may be one or more of
 	// Ready, Progressing, Degraded or other customer types.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BPFDataplane reports the progress of the transition to the eBPF dataplane. It is only set while the
+	// Installation selects the eBPF dataplane.
+	// +optional
+	BPFDataplane *BPFDataplaneStatus `json:"bpfDataplane,omitempty"`
+
+	// WireGuard reports the WireGuard encryption coverage of the nodes. It is only set while the Installation
+	// enables WireGuard.
+	// +optional
+	WireGuard *WireGuardStatus `json:"wireGuard,omitempty"`
+
+	// DrainingIPPools reports the progress of the IP pools that are being drained.
+	// +optional
+	DrainingIPPools []IPPoolDrainStatus `json:"drainingIPPools,omitempty"`
+}
+
+// ImageStatus reports the image resolved for a component.
+type ImageStatus struct {
+	// Image is the name of the image, e.g. calico/node.
+	Image string `json:"image"`
+
+	// Version is the version of the component.
+	Version string `json:"version"`
+
+	// Reference is the fully qualified reference of the image that is deployed. It ends with the digest of the image
+	// if the ImageSet in use specifies one, and with its version otherwise.
+	Reference string `json:"reference"`
+
+	// Digest is the digest of the image, if the ImageSet in use specifies one.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// IPPoolDrainStatus reports the progress of an IP pool that is being drained.
+type IPPoolDrainStatus struct {
+	// Name is the name of the IP pool.
+	Name string `json:"name"`
+
+	// CIDR is the CIDR of the IP pool.
+	CIDR string `json:"cidr"`
+
+	// AllocatedIPs is the number of IP addresses that are still allocated from the IP pool.
+	AllocatedIPs int32 `json:"allocatedIPs"`
+
+	// Drained is true once no IP addresses are allocated from the IP pool anymore, so that it can be removed from
+	// the Installation.
+	Drained bool `json:"drained"`
+}
+
+// BPFDataplaneReadyCondition is the type of the Installation condition that reports whether the eBPF dataplane
+// is enabled and running on every node.
+const BPFDataplaneReadyCondition = "BPFDataplaneReady"
+
+// UpgradeBlockedCondition is the type of the Installation condition that is set while an upgrade is blocked because
+// it moves between versions that are not supported to be upgraded between, e.g. because it skips a release.
+const UpgradeBlockedCondition = "UpgradeBlocked"
+
+// BPFDataplanePhase is the stage of the transition to the eBPF dataplane.
+type BPFDataplanePhase string
+
+const (
+	// BPFDataplanePreflightFailed means that some nodes do not meet the requirements of the eBPF dataplane, so it has
+	// not been enabled.
+	BPFDataplanePreflightFailed BPFDataplanePhase = "PreflightFailed"
+	// BPFDataplaneEnabling means that calico-node is being rolled out with the eBPF dataplane.
+	BPFDataplaneEnabling BPFDataplanePhase = "Enabling"
+	// BPFDataplaneEnabled means that the eBPF dataplane is enabled.
+	BPFDataplaneEnabled BPFDataplanePhase = "Enabled"
+	// BPFDataplaneRolledBack means that calico-node did not become ready with the eBPF dataplane, so the previous
+	// dataplane was restored. The operator retries once the Installation is changed.
+	BPFDataplaneRolledBack BPFDataplanePhase = "RolledBack"
+)
+
+// BPFDataplaneStatus is the status of the transition to the eBPF dataplane.
+type BPFDataplaneStatus struct {
+	// Phase is the stage of the transition to the eBPF dataplane.
+	Phase BPFDataplanePhase `json:"phase"`
+
+	// NodesNotReady lists the nodes that failed the preflight checks, or whose calico-node is not ready.
+	// +optional
+	NodesNotReady []BPFNodeStatus `json:"nodesNotReady,omitempty"`
+}
+
+// BPFNodeStatus reports why a node is not ready for the eBPF dataplane.
+type BPFNodeStatus struct {
+	// Name is the name of the node.
+	Name string `json:"name"`
+
+	// Reason explains why the node is not ready.
+	Reason string `json:"reason"`
+}
+
+// WireGuardEncryptedCondition is the type of the Installation condition that reports whether WireGuard encrypts
+// traffic on every node that it is enabled on.
+const WireGuardEncryptedCondition = "WireGuardEncrypted"
+
+// WireGuardStatus reports on how many nodes WireGuard encrypts traffic.
+type WireGuardStatus struct {
+	// Nodes is the number of Linux nodes in the cluster.
+	Nodes int32 `json:"nodes"`
+
+	// EnabledNodes is the number of nodes that WireGuard is enabled on.
+	EnabledNodes int32 `json:"enabledNodes"`
+
+	// EncryptedNodes is the number of nodes that WireGuard is enabled on and that have published their WireGuard
+	// public key, so that the traffic to them is encrypted.
+	EncryptedNodes int32 `json:"encryptedNodes"`
+
+	// NodesNotEncrypted lists the nodes that WireGuard is enabled on but that have not published their WireGuard
+	// public key yet.
+	// +optional
+	NodesNotEncrypted []string `json:"nodesNotEncrypted,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -971,6 +1445,11 @@ func IsFIPSModeEnabled(mode *FIPSMode) bool {
 	return mode != nil && *mode == FIPSModeEnabled
 }
 
+// IsResourceAdoptionEnabled is a convenience function for turning a ResourceAdoption reference into a bool.
+func IsResourceAdoptionEnabled(adoption *ResourceAdoption) bool {
+	return adoption != nil && *adoption == ResourceAdoptionEnabled
+}
+
 // IsFIPSModeEnabledString is a convenience function for turning a FIPSMode reference into a string formatted bool.
 func IsFIPSModeEnabledString(mode *FIPSMode) string {
 	return fmt.Sprintf("%t", IsFIPSModeEnabled(mode))
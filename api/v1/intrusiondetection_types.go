@@ -33,9 +33,120 @@ type IntrusionDetectionSpec struct {
 	// +optional
 	AnomalyDetection AnomalyDetectionSpec `json:"anomalyDetection,omitempty"`
 
+	// DeepPacketInspection configures the DeepPacketInspection DaemonSet.
+	// +optional
+	DeepPacketInspection *DeepPacketInspectionSpec `json:"deepPacketInspection,omitempty"`
+
+	// Detectors configures the built-in anomaly detectors and threat-detection jobs run by the IntrusionDetection
+	// Controller. Detectors that are not listed run with their default configuration.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Detectors []IntrusionDetectionDetector `json:"detectors,omitempty"`
+
 	// IntrusionDetectionControllerDeployment configures the IntrusionDetection Controller Deployment.
 	// +optional
 	IntrusionDetectionControllerDeployment *IntrusionDetectionControllerDeployment `json:"intrusionDetectionControllerDeployment,omitempty"`
+
+	// ThreatFeeds configures how the IntrusionDetection Controller pulls GlobalThreatFeeds.
+	// +optional
+	ThreatFeeds *ThreatFeedsSpec `json:"threatFeeds,omitempty"`
+}
+
+// ThreatFeedsSpec configures the scheduling and retry policy used to pull GlobalThreatFeeds.
+type ThreatFeedsSpec struct {
+	// PullInterval is how often feeds that do not set their own pull period are pulled.
+	// If omitted, the IntrusionDetection Controller default is used.
+	// +optional
+	PullInterval *metav1.Duration `json:"pullInterval,omitempty"`
+
+	// PullTimeout is the maximum duration of a single attempt to pull a feed.
+	// If omitted, the IntrusionDetection Controller default is used.
+	// +optional
+	PullTimeout *metav1.Duration `json:"pullTimeout,omitempty"`
+
+	// MaxRetries is the number of times a failed pull is retried before the feed is reported as failed until its next
+	// pull. If omitted, the IntrusionDetection Controller default is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// RetryBackoff is the delay before the first retry of a failed pull. The delay doubles with every further retry.
+	// If omitted, the IntrusionDetection Controller default is used.
+	// +optional
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+}
+
+// DeepPacketInspectionSpec configures the DeepPacketInspection DaemonSet.
+type DeepPacketInspectionSpec struct {
+	// Resources allows customization of limits and requests for compute resources such as cpu and memory.
+	// If specified, this takes precedence over the DeepPacketInspection entry in ComponentResources.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector restricts DeepPacketInspection to the nodes that match the selector.
+	// If omitted, DeepPacketInspection runs on all nodes.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// MaxConcurrentInspections is the maximum number of DeepPacketInspection resources that each
+	// DeepPacketInspection pod inspects traffic for at the same time.
+	// If omitted, there is no limit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentInspections *int32 `json:"maxConcurrentInspections,omitempty"`
+}
+
+// IntrusionDetectionDetectorName identifies a built-in anomaly detector or threat-detection job.
+// +kubebuilder:validation:Enum=dga;http-connection-spike;http-response-codes;http-verbs;port-scan;generic-dns;generic-flows;multivariable-flow;generic-l7;dns-latency;dns-tunnel;l7-bytes;l7-latency;bytes-in;bytes-out;process-bytes;process-restarts;suspicious-ips;suspicious-domains;waf
+type IntrusionDetectionDetectorName string
+
+const (
+	// Anomaly detectors.
+	DetectorDGA                 IntrusionDetectionDetectorName = "dga"
+	DetectorHTTPConnectionSpike IntrusionDetectionDetectorName = "http-connection-spike"
+	DetectorHTTPResponseCodes   IntrusionDetectionDetectorName = "http-response-codes"
+	DetectorHTTPVerbs           IntrusionDetectionDetectorName = "http-verbs"
+	DetectorPortScan            IntrusionDetectionDetectorName = "port-scan"
+	DetectorGenericDNS          IntrusionDetectionDetectorName = "generic-dns"
+	DetectorGenericFlows        IntrusionDetectionDetectorName = "generic-flows"
+	DetectorMultivariableFlow   IntrusionDetectionDetectorName = "multivariable-flow"
+	DetectorGenericL7           IntrusionDetectionDetectorName = "generic-l7"
+	DetectorDNSLatency          IntrusionDetectionDetectorName = "dns-latency"
+	DetectorDNSTunnel           IntrusionDetectionDetectorName = "dns-tunnel"
+	DetectorL7Bytes             IntrusionDetectionDetectorName = "l7-bytes"
+	DetectorL7Latency           IntrusionDetectionDetectorName = "l7-latency"
+	DetectorBytesIn             IntrusionDetectionDetectorName = "bytes-in"
+	DetectorBytesOut            IntrusionDetectionDetectorName = "bytes-out"
+	DetectorProcessBytes        IntrusionDetectionDetectorName = "process-bytes"
+	DetectorProcessRestarts     IntrusionDetectionDetectorName = "process-restarts"
+
+	// Threat-detection jobs.
+	DetectorSuspiciousIPs     IntrusionDetectionDetectorName = "suspicious-ips"
+	DetectorSuspiciousDomains IntrusionDetectionDetectorName = "suspicious-domains"
+	DetectorWAF               IntrusionDetectionDetectorName = "waf"
+)
+
+// IntrusionDetectionDetector configures a built-in anomaly detector or threat-detection job.
+type IntrusionDetectionDetector struct {
+	// Name identifies the detector.
+	Name IntrusionDetectionDetectorName `json:"name"`
+
+	// Enabled controls whether the detector is run. Disabling a detector stops it from generating events.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Threshold overrides the score at or above which the detector generates an event. Lower values make the
+	// detector more sensitive. If omitted, the detector's default threshold is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Threshold *int32 `json:"threshold,omitempty"`
+}
+
+// IsEnabled returns whether the detector is run.
+func (d IntrusionDetectionDetector) IsEnabled() bool {
+	return d.Enabled == nil || *d.Enabled
 }
 
 type AnomalyDetectionSpec struct {
@@ -54,6 +165,24 @@ type IntrusionDetectionStatus struct {
 	// Ready, Progressing, Degraded or other customer types.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ThreatFeeds reports the sync status of each GlobalThreatFeed, sorted by name.
+	// +optional
+	ThreatFeeds []ThreatFeedStatus `json:"threatFeeds,omitempty"`
+}
+
+// ThreatFeedStatus is the sync status of a GlobalThreatFeed.
+type ThreatFeedStatus struct {
+	// Name is the name of the GlobalThreatFeed.
+	Name string `json:"name"`
+
+	// LastSuccessfulSync is the last time the feed was pulled successfully.
+	// +optional
+	LastSuccessfulSync *metav1.Time `json:"lastSuccessfulSync,omitempty"`
+
+	// Error describes why the feed is failing to sync. Empty if the feed has no errors.
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -110,6 +239,11 @@ type IntrusionDetectionControllerDeploymentSpec struct {
 	// Template describes the IntrusionDetectionController Deployment pod that will be created.
 	// +optional
 	Template *IntrusionDetectionControllerDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the IntrusionDetectionController Deployment with new ones.
+	// If omitted, the IntrusionDetectionController Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // IntrusionDetectionControllerDeploymentPodTemplateSpec is the IntrusionDetectionController Deployment's PodTemplateSpec
@@ -133,6 +267,9 @@ type IntrusionDetectionControllerDeploymentPodSpec struct {
 	// If omitted, the IntrusionDetectionController Deployment will use its default values for its containers.
 	// +optional
 	Containers []IntrusionDetectionControllerDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the IntrusionDetectionController pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // IntrusionDetectionControllerDeploymentContainer is a IntrusionDetectionController Deployment container.
@@ -147,6 +284,9 @@ type IntrusionDetectionControllerDeploymentContainer struct {
 	// If omitted, the IntrusionDetection Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // IntrusionDetectionControllerDeploymentInitContainer is a IntrusionDetectionController Deployment init container.
@@ -222,6 +362,17 @@ func (c *IntrusionDetectionControllerDeployment) GetContainers() []corev1.Contai
 	return nil
 }
 
+func (c *IntrusionDetectionControllerDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *IntrusionDetectionControllerDeployment) GetAffinity() *corev1.Affinity {
 	return nil
 }
@@ -243,6 +394,13 @@ func (c *IntrusionDetectionControllerDeployment) GetTerminationGracePeriodSecond
 }
 
 func (c *IntrusionDetectionControllerDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *IntrusionDetectionControllerDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
@@ -250,6 +408,13 @@ func (c *IntrusionDetectionControllerDeployment) GetPriorityClassName() string {
 	return ""
 }
 
+func (c *IntrusionDetectionControllerDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&IntrusionDetection{}, &IntrusionDetectionList{})
 }
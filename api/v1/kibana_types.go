@@ -54,6 +54,9 @@ type KibanaPodSpec struct {
 	// If omitted, the Kibana Deployment will use its default values for its containers.
 	// +optional
 	Containers []KibanaContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the Kibana pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // KibanaContainer is a Kibana container.
@@ -145,6 +148,10 @@ func (c *Kibana) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *Kibana) GetContainerProbes() map[string]ContainerProbeOverrides {
+	return nil
+}
+
 func (c *Kibana) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -169,6 +176,17 @@ func (c *Kibana) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
 	return nil
 }
 
+func (c *Kibana) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	return nil
+}
+
 func (c *Kibana) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *Kibana) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
@@ -35,6 +35,11 @@ type L7LogCollectorDaemonSetSpec struct {
 	// Template describes the L7LogCollector DaemonSet pod that will be created.
 	// +optional
 	Template *L7LogCollectorDaemonSetPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the L7LogCollector DaemonSet with new ones.
+	// If omitted, the L7LogCollector DaemonSet will use its default update strategy.
+	// +optional
+	Strategy *DaemonSetUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // L7LogCollectorDaemonSetPodTemplateSpec is the L7LogCollector DaemonSet's PodTemplateSpec
@@ -58,6 +63,9 @@ type L7LogCollectorDaemonSetPodSpec struct {
 	// If omitted, the L7LogCollector DaemonSet will use its default values for its containers.
 	// +optional
 	Containers []L7LogCollectorDaemonSetContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the L7LogCollector pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // L7LogCollectorDaemonSetContainer is a L7LogCollector DaemonSet container.
@@ -72,6 +80,9 @@ type L7LogCollectorDaemonSetContainer struct {
 	// If omitted, the L7LogCollector DaemonSet will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // L7LogCollectorDaemonSetInitContainer is a L7LogCollector DaemonSet init container.
@@ -145,6 +156,17 @@ func (c *L7LogCollectorDaemonSet) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *L7LogCollectorDaemonSet) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *L7LogCollectorDaemonSet) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -169,6 +191,20 @@ func (c *L7LogCollectorDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentStra
 	return nil
 }
 
+func (c *L7LogCollectorDaemonSet) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.daemonSetUpdateStrategy()
+	}
+	return nil
+}
+
 func (c *L7LogCollectorDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *L7LogCollectorDaemonSet) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
@@ -35,6 +35,11 @@ type LinseedDeploymentSpec struct {
 	// Template describes the linseed Deployment pod that will be created.
 	// +optional
 	Template *LinseedDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the linseed Deployment with new ones.
+	// If omitted, the linseed Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // LinseedDeploymentPodTemplateSpec is the linseed Deployment's PodTemplateSpec
@@ -58,6 +63,9 @@ type LinseedDeploymentPodSpec struct {
 	// If omitted, the linseed Deployment will use its default values for its containers.
 	// +optional
 	Containers []LinseedDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the linseed pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // LinseedDeploymentContainer is a linseed Deployment container.
@@ -72,6 +80,9 @@ type LinseedDeploymentContainer struct {
 	// If omitted, the linseed Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // LinseedDeploymentInitContainer is a linseed Deployment init container.
@@ -144,6 +155,17 @@ func (c *LinseedDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *LinseedDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *LinseedDeployment) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -165,9 +187,23 @@ func (c *LinseedDeployment) GetTerminationGracePeriodSeconds() *int64 {
 }
 
 func (c *LinseedDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *LinseedDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *LinseedDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *LinseedDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
@@ -33,6 +33,7 @@ type LogCollectorSpec struct {
 	// Configuration for enabling/disabling process path collection in flowlogs.
 	// If Enabled, this feature sets hostPID to true in order to read process cmdline.
 	// Default: Enabled
+	// +kubebuilder:default:=Enabled
 	// +optional
 	// +kubebuilder:validation:Enum=Enabled;Disabled
 	CollectProcessPath *CollectProcessPathOption `json:"collectProcessPath,omitempty"`
@@ -97,6 +98,12 @@ type S3StoreSpec struct {
 
 	// Path in the S3 bucket where to send logs
 	BucketPath string `json:"bucketPath"`
+
+	// RoleARN is the ARN of an AWS IAM role that fluentd assumes to write to the S3 bucket, using IAM roles for
+	// service accounts (IRSA). When specified, the fluentd ServiceAccount is annotated with the role and the
+	// log-collector-s3-credentials secret is not needed. The cluster must run the EKS pod identity webhook.
+	// +optional
+	RoleARN string `json:"roleARN,omitempty"`
 }
 
 // SyslogLogType represents the allowable log types for syslog.
@@ -153,6 +160,7 @@ type SyslogStoreSpec struct {
 
 	// Encryption configures traffic encryption to the Syslog server.
 	// Default: None
+	// +kubebuilder:default:=None
 	// +optional
 	// +kubebuilder:validation:Enum=None;TLS
 	Encryption EncryptionOption `json:"encryption,omitempty"`
@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -24,24 +25,51 @@ import (
 // LogStorageSpec defines the desired state of Tigera flow and DNS log storage.
 type LogStorageSpec struct {
 	// Nodes defines the configuration for a set of identical Elasticsearch cluster nodes, each of type master, data, and ingest.
+	// Default: a single node
+	// +kubebuilder:default:={count: 1}
 	Nodes *Nodes `json:"nodes,omitempty"`
 
 	// Index defines the configuration for the indices in the Elasticsearch cluster.
+	// Default: indices without replicas
+	// +kubebuilder:default:={replicas: 0}
 	// +optional
 	Indices *Indices `json:"indices,omitempty"`
 
 	// Retention defines how long data is retained in the Elasticsearch cluster before it is cleared.
+	// Default: the default retention period of each log type
+	// +kubebuilder:default:={flows: 8, auditReports: 91, snapshots: 91, complianceReports: 91, dnsLogs: 8, bgpLogs: 8}
 	// +optional
 	Retention *Retention `json:"retention,omitempty"`
 
+	// RetentionGuardrail shortens the retention periods while the disks of the Elasticsearch cluster are fuller than a
+	// target, to keep the cluster from running out of disk space. It only applies to the Elasticsearch cluster that the
+	// operator provisions.
+	// +optional
+	RetentionGuardrail *RetentionGuardrail `json:"retentionGuardrail,omitempty"`
+
+	// CuratedIndexPrefixes limits the indices that the operator curates to those whose names start with one of the
+	// prefixes. Curating an index binds it to an ILM policy of the operator, which deletes the index once its retention
+	// period has passed. Set it to protect the indices of other applications when the Elasticsearch cluster is shared.
+	// Every prefix must start with tigera_secure_ee_. System indices, such as .kibana and .security, are never curated.
+	// Default: tigera_secure_ee_
+	// +optional
+	CuratedIndexPrefixes []string `json:"curatedIndexPrefixes,omitempty"`
+
 	// StorageClassName will populate the PersistentVolumeClaim.StorageClassName that is used to provision disks to the
 	// Tigera Elasticsearch cluster. The StorageClassName should only be modified when no LogStorage is currently
 	// active. We recommend choosing a storage class dedicated to Tigera LogStorage only. Otherwise, data retention
 	// cannot be guaranteed during upgrades. See https://docs.tigera.io/maintenance/upgrading for up-to-date instructions.
 	// Default: tigera-elasticsearch
+	// +kubebuilder:default:=tigera-elasticsearch
 	// +optional
 	StorageClassName string `json:"storageClassName,omitempty"`
 
+	// UpgradeSnapshots snapshots the disks of the Elasticsearch nodes before the operator upgrades Elasticsearch, so
+	// that an upgrade can be rolled back by restoring the disks from their snapshots. It only applies to the
+	// Elasticsearch cluster that the operator provisions.
+	// +optional
+	UpgradeSnapshots *UpgradeSnapshots `json:"upgradeSnapshots,omitempty"`
+
 	// DataNodeSelector gives you more control over the node that Elasticsearch will run on. The contents of DataNodeSelector will
 	// be added to the PodSpec of the Elasticsearch nodes. For the pod to be eligible to run on a node, the node must have
 	// each of the indicated key-value pairs as labels as well as access to the specified StorageClassName.
@@ -50,6 +78,8 @@ type LogStorageSpec struct {
 
 	// ComponentResources can be used to customize the resource requirements for each component.
 	// Only ECKOperator is supported for this spec.
+	// Default: 512Mi of memory for the ECKOperator
+	// +kubebuilder:default:={{componentName: ECKOperator, resourceRequirements: {limits: {memory: "512Mi"}, requests: {memory: "512Mi"}}}}
 	// +optional
 	ComponentResources []LogStorageComponentResource `json:"componentResources,omitempty"`
 
@@ -58,6 +88,11 @@ type LogStorageSpec struct {
 	// +optional
 	ECKOperatorStatefulSet *ECKOperatorStatefulSet `json:"eckOperatorStatefulSet,omitempty"`
 
+	// ECKOperator configures the ECK operator that manages the Elasticsearch cluster and Kibana: either the operator
+	// deploys one, optionally pinned to a version, or it reuses an ECK operator that is already installed.
+	// +optional
+	ECKOperator *ECKOperator `json:"eckOperator,omitempty"`
+
 	// Kibana configures the Kibana Spec.
 	// +optional
 	Kibana *Kibana `json:"kibana,omitempty"`
@@ -67,8 +102,510 @@ type LogStorageSpec struct {
 
 	// ElasticsearchMetricsDeployment configures the tigera-elasticsearch-metric Deployment.
 	ElasticsearchMetricsDeployment *ElasticsearchMetricsDeployment `json:"elasticsearchMetricsDeployment,omitempty"`
+
+	// ESGatewayDeployment configures the tigera-secure-es-gateway Deployment.
+	// +optional
+	ESGatewayDeployment *ESGatewayDeployment `json:"esGatewayDeployment,omitempty"`
+
+	// DeletionPolicy determines what happens to the Elasticsearch data when the LogStorage is deleted. When set to
+	// Retain, the PersistentVolumeClaims holding the data and the tigera-elasticsearch namespace are left behind so
+	// that a new LogStorage can pick the data up again, and the Elasticsearch users created by the operator are
+	// removed before Elasticsearch is shut down. When set to Delete, the data is deleted along with the cluster.
+	// Default: Delete
+	// +kubebuilder:default:=Delete
+	// +optional
+	DeletionPolicy *LogStorageDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// ElasticsearchEndpoint overrides the HTTPS URL, including the port, that the operator and the components it
+	// renders use to reach the Elasticsearch cluster it provisions. Set it when the cluster is fronted by a service mesh
+	// or load balancer. Unlike external Elasticsearch, the cluster is still provisioned and managed by the operator.
+	// Default: https://tigera-secure-es-http.tigera-elasticsearch.svc:9200
+	// +optional
+	ElasticsearchEndpoint string `json:"elasticsearchEndpoint,omitempty"`
+
+	// NonClusterHosts exposes an authenticated endpoint on the Elasticsearch gateway that hosts running Calico outside
+	// of the cluster use to send their flow and DNS logs to Elasticsearch. The credentials that the hosts must present
+	// are written to the tigera-noncluster-host-elasticsearch-access secret in the tigera-operator namespace.
+	// +optional
+	NonClusterHosts *NonClusterHostLogIngestion `json:"nonClusterHosts,omitempty"`
+
+	// TLS configures the TLS versions and cipher suites that the es-gateway, Linseed and es-metrics servers accept.
+	// +optional
+	TLS *LogStorageTLS `json:"tls,omitempty"`
+
+	// ESGatewayCertificateDNSNames are added to the DNS names of the certificate that the operator issues for es-gateway,
+	// so that the certificate is valid when es-gateway is reached through an external hostname, such as the one of a
+	// load balancer. They have no effect when the certificate is provided by the user.
+	// +optional
+	ESGatewayCertificateDNSNames []string `json:"esGatewayCertificateDNSNames,omitempty"`
+
+	// ESGatewayAuditLog makes es-gateway log the requests that it proxies to Elasticsearch and Kibana, together with
+	// the identity that made them, for forensic analysis of the access to the log storage. Credentials and the
+	// contents of the documents are never logged.
+	// +optional
+	ESGatewayAuditLog *ESGatewayAuditLog `json:"esGatewayAuditLog,omitempty"`
+
+	// ESGatewayTenancy makes es-gateway validate the tenant of each request that it proxies to Elasticsearch and
+	// Kibana, so that the log storage of a single-tenant management cluster only serves requests made for its tenant.
+	// The tenant of a request is read from its x-tenant-id header, or from the tenant claim of the token that
+	// authenticates it. es-gateway counts the requests that it accepts and rejects, by reason, in metrics that
+	// Prometheus can collect. It is not supported in multi-tenant mode, where es-gateway is not deployed.
+	// +optional
+	ESGatewayTenancy *ESGatewayTenancy `json:"esGatewayTenancy,omitempty"`
+
+	// SecondaryElasticsearch configures a second Elasticsearch cluster that keeps a copy of the log data, so that the
+	// data survives the loss of the cluster that the operator provisions. It is not supported in multi-tenant mode.
+	// +optional
+	SecondaryElasticsearch *SecondaryElasticsearch `json:"secondaryElasticsearch,omitempty"`
+
+	// RemoteClusters are the Elasticsearch clusters, typically those of other management clusters, that the
+	// Elasticsearch cluster connects to for cross-cluster search, so that Kibana can query their indices as
+	// <name>:<index>. The transport certificates of the remote clusters must be signed by a CA in the
+	// tigera-elasticsearch-remote-cluster-ca ConfigMap (ca.crt) in the tigera-operator namespace, and the remote
+	// clusters must in turn trust the transport certificate of this cluster. It is not supported in multi-tenant mode.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	RemoteClusters []ElasticsearchRemoteCluster `json:"remoteClusters,omitempty"`
+
+	// ReadOnlyUsers are Elasticsearch users that the operator provisions for people, such as security analysts, who
+	// need to search the logs in Elasticsearch and Kibana without being able to change them. The password of each
+	// user is written to the tigera-ee-read-only-<name>-elasticsearch-access secret in the tigera-operator namespace.
+	// It is not supported in multi-tenant mode.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ReadOnlyUsers []ElasticsearchReadOnlyUser `json:"readOnlyUsers,omitempty"`
+
+	// LinseedTokens are tokens that the operator issues for workloads, such as third-party analytics tools, that read
+	// the logs through the Linseed API. Each token is written to the tigera-linseed-token-<name> secret in the
+	// namespace of the workload, and is renewed before it expires. Deleting the secret revokes the token and has a new
+	// one issued, and removing the token from the LogStorage revokes it. It is not supported in multi-tenant mode.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	LinseedTokens []LinseedToken `json:"linseedTokens,omitempty"`
+
+	// ErrorBudget sets thresholds on the rate of requests that Elasticsearch rejects and on the latency of its
+	// queries. While a threshold is exceeded, the LogStorage is Degraded with the ElasticsearchErrorBudgetExceeded
+	// code. It is not supported in multi-tenant mode.
+	// +optional
+	ErrorBudget *ElasticsearchErrorBudget `json:"errorBudget,omitempty"`
+
+	// IngestionBackpressure sets when backpressure on the log ingestion is reported. The operator collects the depth of
+	// the ingestion queue of Linseed and the rate at which Linseed fails to write logs to Elasticsearch every minute.
+	// While either stays above its threshold for the sustained duration, the LogStorage has the IngestionBackpressure
+	// condition and is Degraded with the LinseedIngestionBackpressure code. Since Linseed has then received the logs,
+	// backpressure points to the capacity of Elasticsearch rather than to the network or to the log collectors. It is
+	// not supported in multi-tenant mode.
+	// +optional
+	IngestionBackpressure *IngestionBackpressureThresholds `json:"ingestionBackpressure,omitempty"`
+
+	// ElasticsearchMetricsAuthentication determines how es-metrics authenticates to Elasticsearch.
+	// Default: BasicAuth
+	// +kubebuilder:default:=BasicAuth
+	// +optional
+	ElasticsearchMetricsAuthentication *ElasticsearchMetricsAuthentication `json:"elasticsearchMetricsAuthentication,omitempty"`
+
+	// ElasticsearchMetricsCollection limits the metrics of Elasticsearch that es-metrics collects and that Prometheus
+	// keeps, to control their cardinality on clusters with many indices. It is not supported in multi-tenant mode.
+	// +optional
+	ElasticsearchMetricsCollection *ElasticsearchMetricsCollection `json:"elasticsearchMetricsCollection,omitempty"`
+}
+
+// ECKOperatorMode determines who manages the ECK operator.
+// +kubebuilder:validation:Enum=Managed;UserManaged
+type ECKOperatorMode string
+
+const (
+	// ECKOperatorModeManaged deploys an ECK operator in the tigera-eck-operator namespace.
+	ECKOperatorModeManaged ECKOperatorMode = "Managed"
+
+	// ECKOperatorModeUserManaged reuses an ECK operator installed by the user, instead of deploying a second one.
+	ECKOperatorModeUserManaged ECKOperatorMode = "UserManaged"
+)
+
+// ECKOperator configures the ECK operator that manages the Elasticsearch cluster and Kibana.
+type ECKOperator struct {
+	// Mode determines whether the operator deploys the ECK operator, or reuses one that was installed by the user.
+	// A user-managed ECK operator is detected by the control-plane=elastic-operator label of its StatefulSet, and it
+	// must manage the tigera-elasticsearch and tigera-kibana namespaces. When switching to UserManaged, the ECK
+	// operator deployed by the operator is removed.
+	// Default: Managed
+	// +kubebuilder:default:=Managed
+	// +optional
+	Mode *ECKOperatorMode `json:"mode,omitempty"`
+
+	// Version pins the version of the ECK operator image that the operator deploys, instead of the version that comes
+	// with the operator. It must be 2.0.0 or later and cannot be combined with an ImageSet, which pins images by digest
+	// instead. It only applies when Mode is Managed.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// TLSVersion is a version of the TLS protocol.
+// +kubebuilder:validation:Enum=VersionTLS12;VersionTLS13
+type TLSVersion string
+
+const (
+	TLSVersion12 TLSVersion = "VersionTLS12"
+	TLSVersion13 TLSVersion = "VersionTLS13"
+)
+
+// LogStorageTLS configures the TLS settings of the servers of the log storage components.
+type LogStorageTLS struct {
+	// MinVersion is the minimum version of TLS that the servers accept.
+	// Default: VersionTLS12
+	// +optional
+	MinVersion *TLSVersion `json:"minVersion,omitempty"`
+
+	// CipherSuites lists the TLS 1.2 cipher suites that the servers accept, by their IANA names, for example
+	// TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384. The cipher suites of TLS 1.3 are not configurable, so CipherSuites cannot be
+	// set when MinVersion is VersionTLS13. When empty, the servers use their default cipher suites.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// NonClusterHostLogIngestion configures the endpoint that non-cluster hosts send their logs to.
+type NonClusterHostLogIngestion struct {
+	// ServiceType is the type of the Service that exposes the endpoint outside of the cluster.
+	// Default: LoadBalancer
+	// +kubebuilder:validation:Enum=LoadBalancer;NodePort
+	// +kubebuilder:default:=LoadBalancer
+	// +optional
+	ServiceType *corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// SourceCIDRs restricts the addresses that the endpoint accepts connections from. When empty, connections are
+	// accepted from any address.
+	// +optional
+	SourceCIDRs []string `json:"sourceCIDRs,omitempty"`
 }
 
+// ESGatewayAuditLogVerbosity determines how much of each request es-gateway writes to the audit log.
+// +kubebuilder:validation:Enum=Metadata;Request
+type ESGatewayAuditLogVerbosity string
+
+const (
+	// ESGatewayAuditLogVerbosityMetadata logs the identity, method, path, target indices, response status and duration
+	// of each request.
+	ESGatewayAuditLogVerbosityMetadata ESGatewayAuditLogVerbosity = "Metadata"
+
+	// ESGatewayAuditLogVerbosityRequest also logs the query of search requests, with the values that the query matches
+	// on redacted.
+	ESGatewayAuditLogVerbosityRequest ESGatewayAuditLogVerbosity = "Request"
+)
+
+// ESGatewayAuditLogSink determines where es-gateway writes the audit log.
+// +kubebuilder:validation:Enum=Stdout;Elasticsearch
+type ESGatewayAuditLogSink string
+
+const (
+	// ESGatewayAuditLogSinkStdout writes the audit log to the log of es-gateway.
+	ESGatewayAuditLogSinkStdout ESGatewayAuditLogSink = "Stdout"
+
+	// ESGatewayAuditLogSinkElasticsearch writes the audit log to the tigera_secure_ee_esgateway_audit index, which is
+	// kept for the AuditReports retention period.
+	ESGatewayAuditLogSinkElasticsearch ESGatewayAuditLogSink = "Elasticsearch"
+)
+
+// ESGatewayAuditLog configures the audit log of the requests proxied by es-gateway.
+type ESGatewayAuditLog struct {
+	// Verbosity determines how much of each request is logged.
+	// Default: Metadata
+	// +kubebuilder:default:=Metadata
+	// +optional
+	Verbosity *ESGatewayAuditLogVerbosity `json:"verbosity,omitempty"`
+
+	// Sink determines where the audit log is written.
+	// Default: Stdout
+	// +kubebuilder:default:=Stdout
+	// +optional
+	Sink *ESGatewayAuditLogSink `json:"sink,omitempty"`
+
+	// MaxEventsPerSecond limits the number of requests that each es-gateway replica logs per second. The requests over
+	// the limit are counted, and the count is logged with the next request that is logged.
+	// Default: 100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=100
+	// +optional
+	MaxEventsPerSecond *int32 `json:"maxEventsPerSecond,omitempty"`
+}
+
+// ESGatewayTenancyMode determines what es-gateway does with the requests whose tenant is missing or does not match.
+// +kubebuilder:validation:Enum=Enforce;Audit
+type ESGatewayTenancyMode string
+
+const (
+	// ESGatewayTenancyModeEnforce rejects the requests with 403 Forbidden.
+	ESGatewayTenancyModeEnforce ESGatewayTenancyMode = "Enforce"
+
+	// ESGatewayTenancyModeAudit proxies the requests, and only logs and counts them. Use it to find the clients that do
+	// not present the tenant before enforcing it.
+	ESGatewayTenancyModeAudit ESGatewayTenancyMode = "Audit"
+)
+
+// ESGatewayTenancy configures the validation of the tenant of the requests proxied by es-gateway.
+type ESGatewayTenancy struct {
+	// ExpectedTenantID is the ID of the tenant that the requests must be made for.
+	// +kubebuilder:validation:MinLength=1
+	ExpectedTenantID string `json:"expectedTenantID"`
+
+	// Mode determines what es-gateway does with the requests whose tenant is missing or does not match.
+	// Default: Enforce
+	// +kubebuilder:default:=Enforce
+	// +optional
+	Mode *ESGatewayTenancyMode `json:"mode,omitempty"`
+}
+
+// SecondaryElasticsearchMode determines how the log data is copied to the secondary Elasticsearch cluster.
+// +kubebuilder:validation:Enum=DualWrite;CrossClusterReplication;ExternalMigration
+type SecondaryElasticsearchMode string
+
+const (
+	// SecondaryElasticsearchModeDualWrite makes Linseed write the log data to both clusters (active/active).
+	SecondaryElasticsearchModeDualWrite SecondaryElasticsearchMode = "DualWrite"
+
+	// SecondaryElasticsearchModeCrossClusterReplication makes the secondary cluster follow the log indices of the
+	// primary cluster asynchronously, using Elasticsearch cross-cluster replication. The operator registers the primary
+	// cluster as a remote cluster of the secondary cluster and creates an auto-follow pattern for the log indices.
+	// It requires an Elasticsearch license that includes cross-cluster replication on both clusters.
+	SecondaryElasticsearchModeCrossClusterReplication SecondaryElasticsearchMode = "CrossClusterReplication"
+
+	// SecondaryElasticsearchModeExternalMigration makes Linseed write the log data to both clusters while the log
+	// storage is migrated to an external Elasticsearch cluster, which is the secondary cluster. Once the operator runs
+	// with external Elasticsearch, Linseed writes to the external cluster only and the secondary output is removed.
+	SecondaryElasticsearchModeExternalMigration SecondaryElasticsearchMode = "ExternalMigration"
+)
+
+// SecondaryElasticsearch configures the secondary Elasticsearch cluster that log data is copied to. The username and
+// password used to access it, and optionally the PEM encoded CA certificate (ca.crt) that signed its server certificate,
+// must be provided in the tigera-secondary-elasticsearch secret in the tigera-operator namespace. In DualWrite and
+// ExternalMigration modes the user must be able to write the log indices, and in CrossClusterReplication mode it must be
+// able to manage the cluster settings and cross-cluster replication.
+type SecondaryElasticsearch struct {
+	// Endpoint is the HTTPS URL, including the port, of the secondary Elasticsearch cluster.
+	Endpoint string `json:"endpoint"`
+
+	// Mode determines how the log data is copied to the secondary cluster.
+	// Default: DualWrite
+	// +kubebuilder:default:=DualWrite
+	// +optional
+	Mode *SecondaryElasticsearchMode `json:"mode,omitempty"`
+
+	// RemoteClusterAddress is the host:port at which the secondary cluster reaches the transport port of the primary
+	// cluster, for example through a load balancer. The secondary cluster must trust the transport certificate of the
+	// primary cluster. It is required in CrossClusterReplication mode and must not be set otherwise.
+	// +optional
+	RemoteClusterAddress string `json:"remoteClusterAddress,omitempty"`
+}
+
+// ElasticsearchRemoteCluster is an Elasticsearch cluster that is queried with cross-cluster search. Exactly one of
+// Seeds and ProxyAddress must be set.
+type ElasticsearchRemoteCluster struct {
+	// Name is the alias of the remote cluster, used to refer to its indices as <name>:<index>.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-_a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Seeds are the host:port transport addresses of nodes of the remote cluster. The cluster connects to the seeds and
+	// discovers the other nodes of the remote cluster from them, so all of them must be reachable.
+	// +optional
+	Seeds []string `json:"seeds,omitempty"`
+
+	// ProxyAddress is a single host:port transport address, such as that of a load balancer, through which all the
+	// connections to the remote cluster are made.
+	// +optional
+	ProxyAddress string `json:"proxyAddress,omitempty"`
+
+	// SkipUnavailable makes searches skip the remote cluster when it is unreachable, instead of failing.
+	// Default: false
+	// +optional
+	SkipUnavailable *bool `json:"skipUnavailable,omitempty"`
+}
+
+// ElasticsearchReadOnlyUser is an Elasticsearch user that can read, but not write, the indices that it is scoped to.
+type ElasticsearchReadOnlyUser struct {
+	// Name identifies the user. The username in Elasticsearch is tigera-ee-read-only-<name>.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// Indices are the prefixes of the indices that the user can read, such as tigera_secure_ee_flows. Each must start
+	// with tigera_secure_ee_.
+	// +kubebuilder:validation:MinItems=1
+	Indices []string `json:"indices"`
+
+	// Clusters restricts the user to the logs of the named clusters. When empty, the user can read the logs of all
+	// the clusters.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// LinseedToken is a token that can read, but not write, the logs of the Linseed API that it is scoped to.
+type LinseedToken struct {
+	// Name identifies the token.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the workload that uses the token. The operator writes the token to a secret in
+	// this namespace, and allows the pods in it to connect to Linseed.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Resources are the logs that the token can read.
+	// +kubebuilder:validation:MinItems=1
+	Resources []LinseedResource `json:"resources"`
+
+	// Expiry is how long each token that the operator issues is valid for. A new token is issued once 80% of it has
+	// elapsed. It must be at least 10m.
+	// Default: 24h
+	// +kubebuilder:default:="24h"
+	// +optional
+	Expiry *metav1.Duration `json:"expiry,omitempty"`
+}
+
+// LinseedResource is a kind of log that can be read through the Linseed API.
+// +kubebuilder:validation:Enum=flows;flowlogs;bgplogs;auditlogs;dnsflows;dnslogs;l7flows;l7logs;events;processes
+type LinseedResource string
+
+const (
+	LinseedResourceFlows     LinseedResource = "flows"
+	LinseedResourceFlowLogs  LinseedResource = "flowlogs"
+	LinseedResourceBGPLogs   LinseedResource = "bgplogs"
+	LinseedResourceAuditLogs LinseedResource = "auditlogs"
+	LinseedResourceDNSFlows  LinseedResource = "dnsflows"
+	LinseedResourceDNSLogs   LinseedResource = "dnslogs"
+	LinseedResourceL7Flows   LinseedResource = "l7flows"
+	LinseedResourceL7Logs    LinseedResource = "l7logs"
+	LinseedResourceEvents    LinseedResource = "events"
+	LinseedResourceProcesses LinseedResource = "processes"
+)
+
+// ElasticsearchMetricsAuthentication determines how es-metrics authenticates to Elasticsearch.
+// +kubebuilder:validation:Enum=BasicAuth;ClientCertificate
+type ElasticsearchMetricsAuthentication string
+
+const (
+	// ElasticsearchMetricsAuthenticationBasicAuth passes the username and password of the es-metrics Elasticsearch user
+	// to es-metrics in environment variables.
+	ElasticsearchMetricsAuthenticationBasicAuth ElasticsearchMetricsAuthentication = "BasicAuth"
+
+	// ElasticsearchMetricsAuthenticationClientCertificate makes es-metrics present a client certificate issued by the
+	// operator to es-gateway, which authenticates to Elasticsearch with the credentials of the es-metrics user on its
+	// behalf. The credentials are then not part of the es-metrics pod spec.
+	ElasticsearchMetricsAuthenticationClientCertificate ElasticsearchMetricsAuthentication = "ClientCertificate"
+)
+
+// PerIndexMetricsMode determines whether es-metrics collects the metrics of each index.
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type PerIndexMetricsMode string
+
+const (
+	PerIndexMetricsEnabled  PerIndexMetricsMode = "Enabled"
+	PerIndexMetricsDisabled PerIndexMetricsMode = "Disabled"
+)
+
+// ElasticsearchMetricsCollection limits the metrics of Elasticsearch that es-metrics collects and that Prometheus keeps.
+type ElasticsearchMetricsCollection struct {
+	// PerIndexMetrics determines whether es-metrics collects the metrics of each index and shard, whose number grows with
+	// the number of indices. The metrics of the cluster and of its nodes, such as the JVM heap and garbage collection
+	// metrics, are always collected.
+	// Default: Enabled
+	// +kubebuilder:default:=Enabled
+	// +optional
+	PerIndexMetrics *PerIndexMetricsMode `json:"perIndexMetrics,omitempty"`
+
+	// IndexAllowlist lists regular expressions matching the names of the indices whose per-index metrics Prometheus
+	// keeps. The per-index metrics of the other indices are dropped when they are scraped. The metrics of all the
+	// indices are kept when it is empty.
+	// +optional
+	IndexAllowlist []string `json:"indexAllowlist,omitempty"`
+
+	// MetricRelabelings are applied by Prometheus to the metrics of es-metrics when they are scraped, after the
+	// IndexAllowlist, e.g. to drop the metrics of the JVM memory pools or to drop a label with many values.
+	// +optional
+	MetricRelabelings []*monitoringv1.RelabelConfig `json:"metricRelabelings,omitempty"`
+}
+
+// ElasticsearchErrorBudget sets thresholds on statistics of the Elasticsearch nodes, which the operator collects every
+// minute and exports as metrics. A threshold that is not set is not checked.
+type ElasticsearchErrorBudget struct {
+	// MaxRejectedExecutionsPerMinute is the maximum rate at which the search and write thread pools of the
+	// Elasticsearch nodes may reject requests because their queues are full, summed over all nodes.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRejectedExecutionsPerMinute *int32 `json:"maxRejectedExecutionsPerMinute,omitempty"`
+
+	// MaxAverageQueryLatency is the maximum average time that the Elasticsearch nodes may spend in the query phase of
+	// a search. Elasticsearch does not expose the number of slow log entries through its API, so the average latency
+	// of the queries is used to detect that queries are slow.
+	// +optional
+	MaxAverageQueryLatency *metav1.Duration `json:"maxAverageQueryLatency,omitempty"`
+}
+
+// IngestionBackpressureThresholds sets the thresholds on the ingestion metrics of Linseed above which the log ingestion is
+// considered to be backpressured.
+type IngestionBackpressureThresholds struct {
+	// MaxQueueDepth is the maximum number of documents that may wait in the ingestion queues of Linseed to be written
+	// to Elasticsearch, summed over all Linseed pods.
+	// Default: 10000
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default:=10000
+	// +optional
+	MaxQueueDepth *int32 `json:"maxQueueDepth,omitempty"`
+
+	// MaxErrorsPerMinute is the maximum rate at which Linseed may fail to write documents to Elasticsearch, summed over
+	// all Linseed pods.
+	// Default: 100
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default:=100
+	// +optional
+	MaxErrorsPerMinute *int32 `json:"maxErrorsPerMinute,omitempty"`
+
+	// SustainedFor is how long a threshold must be exceeded before backpressure is reported, so that short bursts of
+	// logs are not.
+	// Default: 5m
+	// +kubebuilder:default:="5m"
+	// +optional
+	SustainedFor *metav1.Duration `json:"sustainedFor,omitempty"`
+}
+
+// LogStorageDeletionPolicy determines what happens to the Elasticsearch data when the LogStorage is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type LogStorageDeletionPolicy string
+
+const (
+	LogStorageDeletionPolicyRetain LogStorageDeletionPolicy = "Retain"
+	LogStorageDeletionPolicyDelete LogStorageDeletionPolicy = "Delete"
+)
+
+// LogStorageDeletionPhase describes the step the operator is at while tearing down a LogStorage that is being deleted.
+type LogStorageDeletionPhase string
+
+const (
+	// LogStorageDeletionPhaseRetainingData means the operator is detaching the data volumes from Elasticsearch so
+	// that they outlive it.
+	LogStorageDeletionPhaseRetainingData LogStorageDeletionPhase = "RetainingData"
+
+	// LogStorageDeletionPhaseDeprovisioningUsers means the operator is removing the Elasticsearch users it created.
+	LogStorageDeletionPhaseDeprovisioningUsers LogStorageDeletionPhase = "DeprovisioningUsers"
+
+	// LogStorageDeletionPhaseDeletingKibana means the operator is waiting for Kibana to be deleted.
+	LogStorageDeletionPhaseDeletingKibana LogStorageDeletionPhase = "DeletingKibana"
+
+	// LogStorageDeletionPhaseDeletingElasticsearch means the operator is waiting for Elasticsearch to be deleted.
+	LogStorageDeletionPhaseDeletingElasticsearch LogStorageDeletionPhase = "DeletingElasticsearch"
+)
+
+// LogStorageIngestionBackpressure is the type of the LogStorage condition that is present while the log ingestion is
+// backpressured. See LogStorageSpec.IngestionBackpressure.
+const LogStorageIngestionBackpressure = "IngestionBackpressure"
+
+// LogStorageElasticsearchIncompatible is the type of the LogStorage condition that is present while the operator holds
+// back a major version upgrade of Elasticsearch, because the new version doesn't support constructs that the operator
+// manages in Elasticsearch, such as its REST APIs or the actions of its ILM policies. The message lists them.
+const LogStorageElasticsearchIncompatible = "ElasticsearchIncompatible"
+
 // LogStorageStatus defines the observed state of Tigera flow and DNS log storage.
 type LogStorageStatus struct {
 	// State provides user-readable status.
@@ -86,6 +623,163 @@ type LogStorageStatus struct {
 	// Ready, Progressing, Degraded or other customer types.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// DeletionPhase is set while the LogStorage is being deleted, and reports the step of the teardown the operator
+	// is waiting on.
+	// +optional
+	DeletionPhase LogStorageDeletionPhase `json:"deletionPhase,omitempty"`
+
+	// IndexMigrations reports the progress of copying indices whose mappings were changed by an upgrade into new
+	// indices.
+	// +optional
+	IndexMigrations []IndexMigrationStatus `json:"indexMigrations,omitempty"`
+
+	// Usage summarizes the number of documents and bytes of log data held in Elasticsearch, as last collected by the
+	// operator. The same figures are exported as Prometheus metrics, broken down by index group, managed cluster and
+	// tenant.
+	// +optional
+	Usage *LogStorageUsage `json:"usage,omitempty"`
+
+	// RetentionGuardrail reports the retention periods in effect while the retention guardrail is configured.
+	// +optional
+	RetentionGuardrail *RetentionGuardrailStatus `json:"retentionGuardrail,omitempty"`
+
+	// PostRestoreReconcile reports the last post-restore reconcile, which is requested by setting the
+	// operator.tigera.io/post-restore-reconcile annotation on the LogStorage after restoring Elasticsearch from a
+	// snapshot.
+	// +optional
+	PostRestoreReconcile *PostRestoreReconcileStatus `json:"postRestoreReconcile,omitempty"`
+
+	// UpgradeSnapshots lists the snapshots of the disks of the Elasticsearch nodes that were taken before the last
+	// upgrades of Elasticsearch, oldest first.
+	// +optional
+	UpgradeSnapshots []UpgradeSnapshotStatus `json:"upgradeSnapshots,omitempty"`
+}
+
+// UpgradeSnapshotStatus reports the snapshots of the disks of the Elasticsearch nodes taken before an upgrade.
+type UpgradeSnapshotStatus struct {
+	// Version is the version of Elasticsearch that the disks were snapshotted before upgrading to.
+	Version string `json:"version"`
+
+	// PreviousVersion is the version of Elasticsearch that the snapshots hold the data of. Restoring the disks from the
+	// snapshots rolls Elasticsearch back to it.
+	PreviousVersion string `json:"previousVersion"`
+
+	// CreationTime is the time the snapshots were requested.
+	CreationTime metav1.Time `json:"creationTime"`
+
+	// ReadyToUse is true once all the snapshots are ready to use. The upgrade waits for it.
+	ReadyToUse bool `json:"readyToUse"`
+
+	// VolumeSnapshots lists the VolumeSnapshots, in the tigera-elasticsearch namespace, one per disk.
+	// +optional
+	VolumeSnapshots []UpgradeVolumeSnapshot `json:"volumeSnapshots,omitempty"`
+}
+
+// UpgradeVolumeSnapshot is the snapshot of the disk of an Elasticsearch node.
+type UpgradeVolumeSnapshot struct {
+	// Name is the name of the VolumeSnapshot.
+	Name string `json:"name"`
+
+	// PersistentVolumeClaimName is the name of the PersistentVolumeClaim of the disk that was snapshotted.
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+}
+
+// PostRestoreReconcileStatus reports what a post-restore reconcile found and repaired.
+type PostRestoreReconcileStatus struct {
+	// Token is the value of the operator.tigera.io/post-restore-reconcile annotation that the reconcile ran for.
+	Token string `json:"token"`
+
+	// CompletionTime is the time the reconcile completed.
+	CompletionTime metav1.Time `json:"completionTime"`
+
+	// Repairs describes each inconsistency that the reconcile repaired.
+	// +optional
+	Repairs []string `json:"repairs,omitempty"`
+
+	// Failures describes each inconsistency that the reconcile found but could not repair.
+	// +optional
+	Failures []string `json:"failures,omitempty"`
+}
+
+// RetentionGuardrailStatus reports the state of the retention guardrail.
+type RetentionGuardrailStatus struct {
+	// Retention holds the retention periods in effect, in days. They are shorter than the configured ones while the
+	// guardrail is reclaiming disk space.
+	Retention Retention `json:"retention"`
+
+	// DiskUsagePercent is the disk usage of the fullest Elasticsearch node, as a percentage, when it was last checked.
+	DiskUsagePercent int32 `json:"diskUsagePercent"`
+
+	// LastChecked is the time the disk usage was last checked.
+	LastChecked metav1.Time `json:"lastChecked"`
+}
+
+// LogStorageUsage summarizes the log data held in Elasticsearch.
+type LogStorageUsage struct {
+	// LastCollected is the time the usage was last collected.
+	LastCollected metav1.Time `json:"lastCollected"`
+
+	// IndexGroups reports the usage of each group of log indices, such as flows or dns.
+	// +optional
+	IndexGroups []LogUsage `json:"indexGroups,omitempty"`
+
+	// Tenants reports the usage of each tenant. It is only set in multi-tenant mode.
+	// +optional
+	Tenants []LogUsage `json:"tenants,omitempty"`
+}
+
+// LogUsage is the usage of a set of log indices.
+type LogUsage struct {
+	// Name identifies the set of indices.
+	Name string `json:"name"`
+
+	// Documents is the number of documents in the indices.
+	Documents int64 `json:"documents"`
+
+	// SizeBytes is the size of the primary shards of the indices, in bytes. Replicas are not included.
+	SizeBytes int64 `json:"sizeBytes"`
+}
+
+// IndexMigrationPhase is the phase of an index migration.
+type IndexMigrationPhase string
+
+const (
+	IndexMigrationRunning   IndexMigrationPhase = "Running"
+	IndexMigrationCompleted IndexMigrationPhase = "Completed"
+	IndexMigrationFailed    IndexMigrationPhase = "Failed"
+)
+
+// IndexMigrationStatus reports the progress of copying an index into a new index with updated mappings. Once the
+// documents are copied, the alias pointing at the source index is moved to the destination index.
+type IndexMigrationStatus struct {
+	// Name identifies the migration.
+	Name string `json:"name"`
+
+	// Source is the index the documents are copied from.
+	Source string `json:"source"`
+
+	// Destination is the index the documents are copied to.
+	Destination string `json:"destination"`
+
+	// Phase is the phase of the migration.
+	Phase IndexMigrationPhase `json:"phase"`
+
+	// TaskID is the ID of the Elasticsearch reindex task.
+	// +optional
+	TaskID string `json:"taskID,omitempty"`
+
+	// Total is the number of documents to copy.
+	// +optional
+	Total int64 `json:"total,omitempty"`
+
+	// Copied is the number of documents copied so far.
+	// +optional
+	Copied int64 `json:"copied,omitempty"`
+
+	// Message explains why the migration failed.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // Nodes defines the configuration for a set of identical Elasticsearch cluster nodes, each of type master, data, and ingest.
@@ -108,6 +802,17 @@ type NodeSet struct {
 	// Elasticsearch cluster awareness attributes for the Elasticsearch nodes. The list of SelectionAttributes are used
 	// to define Node Affinities and set the node awareness configuration in the running Elasticsearch instance.
 	SelectionAttributes []NodeSetSelectionAttribute `json:"selectionAttributes,omitempty"`
+
+	// StorageClassName is the storage class of the disks of the Elasticsearch nodes of the NodeSet. It overrides the
+	// StorageClassName of the LogStorage for this NodeSet. Changing it migrates the data of the NodeSet to new disks.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// VolumeSnapshotClassName is the volume snapshot class used to snapshot the disks of the Elasticsearch nodes of the
+	// NodeSet before Elasticsearch is upgraded. The upgrade waits for the snapshots to be ready to use. The disks are not
+	// snapshotted when it is not set.
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
 }
 
 // NodeSetSelectionAttribute defines a K8s node "attribute" the Elasticsearch nodes should be aware of. The "Name" and "Value"
@@ -174,6 +879,39 @@ type Retention struct {
 	BGPLogs *int32 `json:"bgpLogs"`
 }
 
+// RetentionGuardrail configures a target maximum disk usage for the Elasticsearch cluster. While the fullest
+// Elasticsearch node uses more of its disk than the target, the operator shortens the retention period of each log type
+// by one day at each check, down to its minimum, and records an event on the LogStorage. Once the disk usage has
+// fallen 10 percentage points below the target, the retention periods are lengthened back to the configured ones in the
+// same way.
+type RetentionGuardrail struct {
+	// MaxDiskUsagePercent is the target maximum disk usage of the Elasticsearch nodes, as a percentage. It should be
+	// below the high disk watermark of Elasticsearch, which is 90% by default.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MaxDiskUsagePercent int32 `json:"maxDiskUsagePercent"`
+
+	// MinimumRetention holds the retention periods, in days, below which the operator does not shorten the retention of
+	// each log type. The retention of a log type without a minimum is never shortened.
+	// +optional
+	MinimumRetention *Retention `json:"minimumRetention,omitempty"`
+}
+
+// UpgradeSnapshots configures the snapshots of the disks of the Elasticsearch nodes taken before upgrades.
+type UpgradeSnapshots struct {
+	// VolumeSnapshotClassName is the volume snapshot class used to snapshot the disks. The VolumeSnapshotClassName of a
+	// NodeSet overrides it for the disks of the NodeSet.
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+
+	// Retain is the number of upgrades whose snapshots are kept. The snapshots of older upgrades are deleted once the
+	// snapshots of the next upgrade are ready to use.
+	// Default: 1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	// +optional
+	Retain *int32 `json:"retain,omitempty"`
+}
+
 // LogStorageComponentName CRD enum
 type LogStorageComponentName string
 
@@ -203,6 +941,7 @@ type LogStorage struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	// Specification of the desired state for Tigera log storage.
+	// +kubebuilder:default:={}
 	Spec LogStorageSpec `json:"spec,omitempty"`
 	// Most recently observed state for Tigera log storage.
 	Status LogStorageStatus `json:"status,omitempty"`
@@ -224,6 +963,61 @@ func (ls LogStorage) Replicas() int {
 	return int(*ls.Spec.Indices.Replicas)
 }
 
+// RetainDataOnDeletion returns true if the Elasticsearch data must be kept when the LogStorage is deleted.
+func (ls LogStorage) RetainDataOnDeletion() bool {
+	return ls.Spec.DeletionPolicy != nil && *ls.Spec.DeletionPolicy == LogStorageDeletionPolicyRetain
+}
+
+// UserManagedECKOperator returns whether the LogStorage reuses an ECK operator installed by the user.
+func (ls LogStorage) UserManagedECKOperator() bool {
+	eck := ls.Spec.ECKOperator
+	return eck != nil && eck.Mode != nil && *eck.Mode == ECKOperatorModeUserManaged
+}
+
+// DefaultCuratedIndexPrefix is the prefix of the names of the log indices, which the operator curates by default.
+const DefaultCuratedIndexPrefix = "tigera_secure_ee_"
+
+// CuratedIndexPrefixes returns the prefixes of the names of the indices that the operator may curate.
+func (ls LogStorage) CuratedIndexPrefixes() []string {
+	if len(ls.Spec.CuratedIndexPrefixes) == 0 {
+		return []string{DefaultCuratedIndexPrefix}
+	}
+	return ls.Spec.CuratedIndexPrefixes
+}
+
+// SecondaryElasticsearchMode returns the mode of the secondary Elasticsearch cluster, or an empty string if none is
+// configured.
+func (ls LogStorage) SecondaryElasticsearchMode() SecondaryElasticsearchMode {
+	secondary := ls.Spec.SecondaryElasticsearch
+	if secondary == nil {
+		return ""
+	}
+	if secondary.Mode == nil {
+		return SecondaryElasticsearchModeDualWrite
+	}
+	return *secondary.Mode
+}
+
+// ElasticsearchMetricsClientCertificate returns true if es-metrics authenticates to Elasticsearch with a client
+// certificate rather than with a username and password.
+func (ls LogStorage) ElasticsearchMetricsClientCertificate() bool {
+	auth := ls.Spec.ElasticsearchMetricsAuthentication
+	return auth != nil && *auth == ElasticsearchMetricsAuthenticationClientCertificate
+}
+
+// ESGatewayAuditLogSink returns where es-gateway writes its audit log, or an empty string if the audit log is not
+// enabled.
+func (ls LogStorage) ESGatewayAuditLogSink() ESGatewayAuditLogSink {
+	auditLog := ls.Spec.ESGatewayAuditLog
+	if auditLog == nil {
+		return ""
+	}
+	if auditLog.Sink == nil {
+		return ESGatewayAuditLogSinkStdout
+	}
+	return *auditLog.Sink
+}
+
 func init() {
 	SchemeBuilder.Register(&LogStorage{}, &LogStorageList{})
 }
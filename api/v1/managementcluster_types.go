@@ -29,10 +29,31 @@ type ManagementClusterSpec struct {
 	Address string `json:"address,omitempty"`
 
 	// TLS provides options for configuring how Managed Clusters can establish an mTLS connection with the Management Cluster.
+	// +kubebuilder:default:={}
 	// +optional
 	TLS *TLS `json:"tls,omitempty"`
 }
 
+// ManagementClusterStatus defines the observed state of a ManagementCluster
+type ManagementClusterStatus struct {
+	// Address is the address that the installation manifests of the managed clusters were last generated for. When
+	// spec.address changes, the operator regenerates the installation manifests of the existing managed clusters for
+	// the new address.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// PreviousAddress is the address before the last change of spec.address.
+	// +optional
+	PreviousAddress string `json:"previousAddress,omitempty"`
+
+	// StaleManagedClusters are the managed clusters that are disconnected since the last change of spec.address, and so
+	// may still connect to the previous address. Apply their regenerated installation manifests to the managed
+	// clusters to reconnect them. The management cluster cannot see the address that a managed cluster connects to,
+	// so managed clusters that are still connected through the previous address are not listed.
+	// +optional
+	StaleManagedClusters []string `json:"staleManagedClusters,omitempty"`
+}
+
 type TLS struct {
 	// SecretName indicates the name of the secret in the tigera-operator namespace that contains the private key and certificate that the management cluster uses when it listens for incoming connections.
 	//
@@ -51,6 +72,7 @@ type TLS struct {
 	// Default: tigera-management-cluster-connection
 	//
 	// +kubebuilder:validation:Enum=tigera-management-cluster-connection;manager-tls
+	// +kubebuilder:default:=tigera-management-cluster-connection
 	// +optional
 	SecretName string `json:"secretName,omitempty"`
 }
@@ -65,7 +87,9 @@ type ManagementCluster struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ManagementClusterSpec `json:"spec,omitempty"`
+	// +kubebuilder:default:={}
+	Spec   ManagementClusterSpec   `json:"spec,omitempty"`
+	Status ManagementClusterStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -28,6 +28,7 @@ type ManagementClusterConnectionSpec struct {
 	ManagementClusterAddr string `json:"managementClusterAddr,omitempty"`
 
 	// TLS provides options for configuring how Managed Clusters can establish an mTLS connection with the Management Cluster.
+	// +kubebuilder:default:={}
 	// +optional
 	TLS *ManagementClusterTLS `json:"tls,omitempty"`
 
@@ -46,6 +47,7 @@ type ManagementClusterTLS struct {
 	// Default: Tigera
 	//
 	// +kubebuilder:validation:Enum=Tigera;Public
+	// +kubebuilder:default:=Tigera
 	CA CAType `json:"ca,omitempty"`
 }
 
@@ -69,6 +71,7 @@ type ManagementClusterConnection struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
+	// +kubebuilder:default:={}
 	Spec   ManagementClusterConnectionSpec   `json:"spec,omitempty"`
 	Status ManagementClusterConnectionStatus `json:"status,omitempty"`
 }
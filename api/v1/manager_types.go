@@ -28,6 +28,12 @@ type ManagerSpec struct {
 	// ManagerDeployment configures the Manager Deployment.
 	// +optional
 	ManagerDeployment *ManagerDeployment `json:"managerDeployment,omitempty"`
+
+	// CertificateDNSNames are added to the DNS names of the certificate that the operator issues for the Manager UI, so
+	// that the certificate is valid when the Manager is reached through an external hostname, such as the one of a load
+	// balancer. They have no effect when the certificate is provided by the user.
+	// +optional
+	CertificateDNSNames []string `json:"certificateDNSNames,omitempty"`
 }
 
 // ManagerDeployment is the configuration for the Manager Deployment.
@@ -44,6 +50,11 @@ type ManagerDeploymentSpec struct {
 	// Template describes the Manager Deployment pod that will be created.
 	// +optional
 	Template *ManagerDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the Manager Deployment with new ones.
+	// If omitted, the Manager Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // ManagerDeploymentPodTemplateSpec is the Manager Deployment's PodTemplateSpec
@@ -67,6 +78,9 @@ type ManagerDeploymentPodSpec struct {
 	// If omitted, the Manager Deployment will use its default values for its containers.
 	// +optional
 	Containers []ManagerDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the Manager pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // ManagerDeploymentContainer is a Manager Deployment container.
@@ -81,6 +95,9 @@ type ManagerDeploymentContainer struct {
 	// If omitted, the Manager Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // ManagerDeploymentInitContainer is a Manager Deployment init container.
@@ -194,6 +211,17 @@ func (c *ManagerDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *ManagerDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *ManagerDeployment) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -215,6 +243,13 @@ func (c *ManagerDeployment) GetTerminationGracePeriodSeconds() *int64 {
 }
 
 func (c *ManagerDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *ManagerDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
@@ -222,6 +257,13 @@ func (c *ManagerDeployment) GetPriorityClassName() string {
 	return ""
 }
 
+func (c *ManagerDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&Manager{}, &ManagerList{})
 }
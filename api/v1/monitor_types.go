@@ -36,8 +36,39 @@ type MonitorSpec struct {
 	// AlertManager is the configuration for the AlertManager.
 	// +optional
 	AlertManager *AlertManager `json:"alertManager,omitempty"`
+
+	// GrafanaDashboards configures the Grafana dashboards that the operator renders as ConfigMaps. The dashboards are
+	// rendered whenever the Monitor exists, so that they can be discovered by a Grafana dashboard sidecar.
+	// +optional
+	GrafanaDashboards *GrafanaDashboards `json:"grafanaDashboards,omitempty"`
 }
 
+// GrafanaDashboards configures where the operator renders the ConfigMaps of the Grafana dashboards. When the namespace
+// is changed, the operator deletes the dashboards from the namespace that was configured before.
+type GrafanaDashboards struct {
+	// Namespace is the namespace where the operator creates the dashboard ConfigMaps. The namespace must be created
+	// before the operator will create the ConfigMaps.
+	// Default: tigera-prometheus
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Labels are the metadata.labels of the dashboard ConfigMaps. They should match the label that your Grafana
+	// dashboard sidecar watches for.
+	// Default: grafana_dashboard=1
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ClientKeyExport determines whether the client private key of the in-cluster Prometheus is copied to the namespace of
+// the external Prometheus.
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type ClientKeyExport string
+
+const (
+	ClientKeyExportEnabled  ClientKeyExport = "Enabled"
+	ClientKeyExportDisabled ClientKeyExport = "Disabled"
+)
+
 type ExternalPrometheus struct {
 	// ServiceMonitor when specified, the operator will create a ServiceMonitor object in the namespace. It is recommended
 	// that you configure labels if you want your prometheus instance to pick up the configuration automatically.
@@ -52,6 +83,21 @@ type ExternalPrometheus struct {
 	// must be created before the operator will create Prometheus resources.
 	// +required
 	Namespace string `json:"namespace"`
+
+	// ClientKeyExport determines whether the operator copies the client certificate and private key of the in-cluster
+	// Prometheus to the Namespace when the Prometheus state is Disabled, so that the external Prometheus can scrape the
+	// components that require a client certificate. The key grants the same access to the metrics of the components as
+	// the in-cluster Prometheus has, so only enable this if access to the secrets of the Namespace is restricted. When
+	// Disabled, only the trusted CA bundle is copied.
+	// Default: Disabled
+	// +optional
+	ClientKeyExport *ClientKeyExport `json:"clientKeyExport,omitempty"`
+}
+
+// ClientKeyExportEnabled returns whether the client certificate and private key of the in-cluster Prometheus are
+// copied to the namespace of the external Prometheus.
+func (e *ExternalPrometheus) ClientKeyExportEnabled() bool {
+	return e != nil && e.ClientKeyExport != nil && *e.ClientKeyExport == ClientKeyExportEnabled
 }
 
 type ServiceMonitor struct {
@@ -133,7 +179,24 @@ type MonitorList struct {
 	Items           []Monitor `json:"items"`
 }
 
+// PrometheusState determines whether the operator deploys the in-cluster Prometheus server.
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type PrometheusState string
+
+const (
+	PrometheusStateEnabled  PrometheusState = "Enabled"
+	PrometheusStateDisabled PrometheusState = "Disabled"
+)
+
 type Prometheus struct {
+	// State determines whether the operator deploys the in-cluster Prometheus server. When Disabled, the operator
+	// removes the Prometheus server and renders the ServiceMonitors and PrometheusRules for Calico Enterprise in the
+	// ExternalPrometheus namespace instead, so that they are picked up by an external Prometheus operator instance.
+	// ExternalPrometheus must be specified when the state is Disabled.
+	// Default: Enabled
+	// +optional
+	State *PrometheusState `json:"state,omitempty"`
+
 	// Spec is the specification of the Prometheus.
 	// +optional
 	PrometheusSpec *PrometheusSpec `json:"spec,omitempty"`
@@ -141,6 +204,30 @@ type Prometheus struct {
 type PrometheusSpec struct {
 	// CommonPrometheusFields are the options available to both the Prometheus server and agent.
 	CommonPrometheusFields *CommonPrometheusFields `json:"commonPrometheusFields,omitempty"`
+
+	// Retention is the duration for which Prometheus keeps its samples on local storage.
+	// Default: 24h
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +optional
+	Retention string `json:"retention,omitempty"`
+
+	// Thanos configures a Thanos sidecar that uploads the blocks of the in-cluster Prometheus to object storage, so
+	// that metrics are kept beyond the local retention.
+	// +optional
+	Thanos *Thanos `json:"thanos,omitempty"`
+}
+
+// Thanos configures the Thanos sidecar of the in-cluster Prometheus.
+type Thanos struct {
+	// ObjectStorageConfig is the secret key that holds the Thanos object storage configuration. The secret must exist
+	// in the tigera-operator namespace; the operator copies it to the tigera-prometheus namespace.
+	// More info: https://thanos.io/tip/thanos/storage.md/
+	ObjectStorageConfig corev1.SecretKeySelector `json:"objectStorageConfig"`
+
+	// Resources allows customization of limits and requests for compute resources such as cpu and memory of the
+	// Thanos sidecar.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 type CommonPrometheusFields struct {
 
@@ -152,6 +239,68 @@ type CommonPrometheusFields struct {
 
 	// Define resources requests and limits for single Pods.
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// RemoteWrite is a list of endpoints that the in-cluster Prometheus forwards its samples to using the Prometheus
+	// remote write protocol.
+	// +optional
+	RemoteWrite []RemoteWriteSpec `json:"remoteWrite,omitempty"`
+}
+
+// RemoteWriteSpec defines an endpoint that Prometheus sends its samples to. All secrets that are referenced must exist
+// in the tigera-operator namespace; the operator copies them to the tigera-prometheus namespace.
+type RemoteWriteSpec struct {
+	// Name of the remote write queue. It must be unique if specified.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// URL of the endpoint to send samples to.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// BearerTokenSecret is the secret key that holds the bearer token used to authenticate with the endpoint.
+	// It cannot be combined with BasicAuth.
+	// +optional
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+
+	// BasicAuth configures basic authentication with the endpoint. It cannot be combined with BearerTokenSecret.
+	// +optional
+	BasicAuth *RemoteWriteBasicAuth `json:"basicAuth,omitempty"`
+
+	// TLS configures the TLS connection to the endpoint.
+	// +optional
+	TLS *RemoteWriteTLS `json:"tls,omitempty"`
+}
+
+// RemoteWriteBasicAuth references the secret keys that hold the basic authentication credentials.
+type RemoteWriteBasicAuth struct {
+	// Username is the secret key that holds the username.
+	Username corev1.SecretKeySelector `json:"username"`
+
+	// Password is the secret key that holds the password.
+	Password corev1.SecretKeySelector `json:"password"`
+}
+
+// RemoteWriteTLS references the secret keys that make up the TLS configuration of a remote write endpoint.
+type RemoteWriteTLS struct {
+	// CASecret is the secret key that holds the CA bundle used to verify the endpoint.
+	// +optional
+	CASecret *corev1.SecretKeySelector `json:"caSecret,omitempty"`
+
+	// CertSecret is the secret key that holds the client certificate. It must be combined with KeySecret.
+	// +optional
+	CertSecret *corev1.SecretKeySelector `json:"certSecret,omitempty"`
+
+	// KeySecret is the secret key that holds the client private key. It must be combined with CertSecret.
+	// +optional
+	KeySecret *corev1.SecretKeySelector `json:"keySecret,omitempty"`
+
+	// ServerName is used to verify the hostname of the endpoint.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the endpoint certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 // PrometheusContainer is a Prometheus container.
@@ -176,6 +325,184 @@ type AlertManager struct {
 type AlertManagerSpec struct {
 	// Define resources requests and limits for single Pods.
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Configuration declares the routes and receivers of the Alertmanager. When specified, the operator generates the
+	// alertmanager-calico-node-alertmanager secret in the tigera-operator namespace from it, overwriting any manual
+	// changes to that secret. All secrets that are referenced must exist in the tigera-operator namespace.
+	// +optional
+	Configuration *AlertmanagerConfiguration `json:"configuration,omitempty"`
+}
+
+// AlertmanagerConfiguration is a subset of the Alertmanager configuration file.
+type AlertmanagerConfiguration struct {
+	// Route is the root of the routing tree. Its receiver handles all alerts that do not match any of its child routes.
+	Route AlertmanagerRoute `json:"route"`
+
+	// Receivers is the list of notification receivers that routes can refer to by name.
+	// +kubebuilder:validation:MinItems=1
+	Receivers []AlertmanagerReceiver `json:"receivers"`
+}
+
+// AlertmanagerRoute is the root route of the Alertmanager.
+type AlertmanagerRoute struct {
+	AlertmanagerRouteOptions `json:",inline"`
+
+	// Routes are the child routes of the root route. Alerts are matched against the child routes in order.
+	// +optional
+	Routes []AlertmanagerChildRoute `json:"routes,omitempty"`
+}
+
+// AlertmanagerChildRoute is a route below the root route that only handles the alerts that match its matchers.
+type AlertmanagerChildRoute struct {
+	AlertmanagerRouteOptions `json:",inline"`
+
+	// Matchers is a list of matchers, such as severity="critical", that an alert has to fulfill to match the route.
+	// +optional
+	Matchers []string `json:"matchers,omitempty"`
+
+	// Continue determines whether alerts that match this route continue matching the subsequent sibling routes.
+	// +optional
+	Continue bool `json:"continue,omitempty"`
+}
+
+// AlertmanagerRouteOptions are the options that are shared by the root route and its child routes.
+type AlertmanagerRouteOptions struct {
+	// Receiver is the name of the receiver that handles the alerts of this route.
+	// +kubebuilder:validation:MinLength=1
+	Receiver string `json:"receiver"`
+
+	// GroupBy is the list of labels that alerts are grouped by.
+	// +optional
+	GroupBy []string `json:"groupBy,omitempty"`
+
+	// GroupWait is how long to wait before sending the initial notification for a group of alerts.
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +optional
+	GroupWait string `json:"groupWait,omitempty"`
+
+	// GroupInterval is how long to wait before sending a notification about new alerts that are added to a group.
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +optional
+	GroupInterval string `json:"groupInterval,omitempty"`
+
+	// RepeatInterval is how long to wait before sending a notification again if it has already been sent.
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +optional
+	RepeatInterval string `json:"repeatInterval,omitempty"`
+}
+
+// AlertmanagerReceiver is a named set of notification integrations.
+type AlertmanagerReceiver struct {
+	// Name of the receiver. It must be unique.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// SlackConfigs sends notifications to Slack.
+	// +optional
+	SlackConfigs []AlertmanagerSlackConfig `json:"slackConfigs,omitempty"`
+
+	// PagerDutyConfigs sends notifications to PagerDuty.
+	// +optional
+	PagerDutyConfigs []AlertmanagerPagerDutyConfig `json:"pagerDutyConfigs,omitempty"`
+
+	// WebhookConfigs sends notifications to a generic webhook.
+	// +optional
+	WebhookConfigs []AlertmanagerWebhookConfig `json:"webhookConfigs,omitempty"`
+
+	// EmailConfigs sends notifications by email.
+	// +optional
+	EmailConfigs []AlertmanagerEmailConfig `json:"emailConfigs,omitempty"`
+}
+
+// AlertmanagerSlackConfig configures notifications to Slack.
+type AlertmanagerSlackConfig struct {
+	// APIURL is the secret key that holds the Slack webhook URL.
+	APIURL corev1.SecretKeySelector `json:"apiURL"`
+
+	// Channel is the channel or user to send notifications to.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// SendResolved determines whether to notify about resolved alerts.
+	// +optional
+	SendResolved *bool `json:"sendResolved,omitempty"`
+}
+
+// AlertmanagerPagerDutyConfig configures notifications to PagerDuty. Exactly one of RoutingKey and ServiceKey must be
+// specified.
+type AlertmanagerPagerDutyConfig struct {
+	// RoutingKey is the secret key that holds the PagerDuty integration key when using the Events API v2.
+	// +optional
+	RoutingKey *corev1.SecretKeySelector `json:"routingKey,omitempty"`
+
+	// ServiceKey is the secret key that holds the PagerDuty integration key when using the Prometheus integration type.
+	// +optional
+	ServiceKey *corev1.SecretKeySelector `json:"serviceKey,omitempty"`
+
+	// SendResolved determines whether to notify about resolved alerts.
+	// +optional
+	SendResolved *bool `json:"sendResolved,omitempty"`
+}
+
+// AlertmanagerWebhookConfig configures notifications to a generic webhook. Exactly one of URL and URLSecret must be
+// specified.
+type AlertmanagerWebhookConfig struct {
+	// URL is the endpoint to send HTTP POST requests to.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// URLSecret is the secret key that holds the endpoint to send HTTP POST requests to.
+	// +optional
+	URLSecret *corev1.SecretKeySelector `json:"urlSecret,omitempty"`
+
+	// MaxAlerts is the maximum number of alerts to include in a single message. 0 means that all alerts are included.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAlerts int32 `json:"maxAlerts,omitempty"`
+
+	// SendResolved determines whether to notify about resolved alerts.
+	// +optional
+	SendResolved *bool `json:"sendResolved,omitempty"`
+}
+
+// AlertmanagerEmailConfig configures notifications by email.
+type AlertmanagerEmailConfig struct {
+	// To is the email address to send notifications to.
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+
+	// From is the sender address.
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+
+	// Smarthost is the SMTP host through which emails are sent, in host:port format.
+	// +kubebuilder:validation:MinLength=1
+	Smarthost string `json:"smarthost"`
+
+	// AuthUsername is the username to authenticate with the SMTP host.
+	// +optional
+	AuthUsername string `json:"authUsername,omitempty"`
+
+	// AuthPassword is the secret key that holds the password to authenticate with the SMTP host.
+	// +optional
+	AuthPassword *corev1.SecretKeySelector `json:"authPassword,omitempty"`
+
+	// RequireTLS determines whether STARTTLS is required.
+	// Default: true
+	// +optional
+	RequireTLS *bool `json:"requireTLS,omitempty"`
+
+	// SendResolved determines whether to notify about resolved alerts.
+	// +optional
+	SendResolved *bool `json:"sendResolved,omitempty"`
+}
+
+// GetConfiguration returns the routes and receivers of the Alertmanager.
+func (c *AlertManager) GetConfiguration() *AlertmanagerConfiguration {
+	if c != nil && c.AlertManagerSpec != nil {
+		return c.AlertManagerSpec.Configuration
+	}
+	return nil
 }
 
 func (c *Prometheus) GetContainers() []corev1.Container {
@@ -200,6 +527,35 @@ func (c *Prometheus) GetContainers() []corev1.Container {
 	return nil
 }
 
+// InClusterPrometheusEnabled returns whether the operator deploys the in-cluster Prometheus server.
+func (c *Prometheus) InClusterPrometheusEnabled() bool {
+	return c == nil || c.State == nil || *c.State != PrometheusStateDisabled
+}
+
+// GetRemoteWrite returns the remote write endpoints of the Prometheus server.
+func (c *Prometheus) GetRemoteWrite() []RemoteWriteSpec {
+	if c != nil && c.PrometheusSpec != nil && c.PrometheusSpec.CommonPrometheusFields != nil {
+		return c.PrometheusSpec.CommonPrometheusFields.RemoteWrite
+	}
+	return nil
+}
+
+// GetThanos returns the Thanos sidecar configuration of the Prometheus server.
+func (c *Prometheus) GetThanos() *Thanos {
+	if c != nil && c.PrometheusSpec != nil {
+		return c.PrometheusSpec.Thanos
+	}
+	return nil
+}
+
+// GetRetention returns the local retention of the Prometheus server.
+func (c *Prometheus) GetRetention() string {
+	if c != nil && c.PrometheusSpec != nil {
+		return c.PrometheusSpec.Retention
+	}
+	return ""
+}
+
 func (c *Prometheus) GetPrometheusResource() *corev1.ResourceRequirements {
 	if c.PrometheusSpec != nil {
 		if c.PrometheusSpec.CommonPrometheusFields != nil {
@@ -0,0 +1,65 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkPolicyOverrideSpec defines the rules that are added to a policy rendered by the operator.
+type NetworkPolicyOverrideSpec struct {
+	// PolicyName is the name of the policy in the allow-tigera tier that the rules are added to, e.g.
+	// allow-tigera.elasticsearch-metrics. The policy must be in the namespace of the NetworkPolicyOverride.
+	PolicyName string `json:"policyName"`
+
+	// Ingress rules are added after the ingress rules rendered by the operator, but before the Pass and Deny rules that
+	// those end with. They are ignored if the policy does not apply to ingress traffic.
+	// +optional
+	Ingress []v3.Rule `json:"ingress,omitempty"`
+
+	// Egress rules are added after the egress rules rendered by the operator, but before the Pass and Deny rules that
+	// those end with. They are ignored if the policy does not apply to egress traffic.
+	// +optional
+	Egress []v3.Rule `json:"egress,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+
+// NetworkPolicyOverride adds rules to a policy that the operator renders in the allow-tigera tier, e.g. to allow an
+// external monitoring system to scrape a component. Manual edits to these policies are reverted by the operator, while
+// the rules of a NetworkPolicyOverride are added to the policy every time it is rendered.
+type NetworkPolicyOverride struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NetworkPolicyOverrideSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NetworkPolicyOverrideList contains a list of NetworkPolicyOverride
+type NetworkPolicyOverrideList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkPolicyOverride `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetworkPolicyOverride{}, &NetworkPolicyOverrideList{})
+}
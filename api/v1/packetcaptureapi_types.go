@@ -19,6 +19,7 @@ package v1
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,6 +29,47 @@ type PacketCaptureAPISpec struct {
 	// PacketCaptureAPIDeployment configures the PacketCaptureAPI Deployment.
 	// +optional
 	PacketCaptureAPIDeployment *PacketCaptureAPIDeployment `json:"packetCaptureAPIDeployment,omitempty"`
+
+	// Storage configures where the PacketCaptureAPI keeps the capture files that it serves.
+	// If omitted, capture files are kept in an emptyDir volume.
+	// +optional
+	Storage *PacketCaptureStorage `json:"storage,omitempty"`
+}
+
+// PacketCaptureStorageType is the type of volume used to store capture files.
+// +kubebuilder:validation:Enum=EmptyDir;PersistentVolumeClaim
+type PacketCaptureStorageType string
+
+const (
+	PacketCaptureStorageEmptyDir              PacketCaptureStorageType = "EmptyDir"
+	PacketCaptureStoragePersistentVolumeClaim PacketCaptureStorageType = "PersistentVolumeClaim"
+)
+
+// PacketCaptureStorage defines the volume used to store capture files.
+type PacketCaptureStorage struct {
+	// Type is the type of volume used to store capture files.
+	// Default: EmptyDir
+	// +optional
+	Type *PacketCaptureStorageType `json:"type,omitempty"`
+
+	// Size is the size of the volume. For an EmptyDir volume this is used as its size limit, and for a
+	// PersistentVolumeClaim it is the requested storage. If omitted, a PersistentVolumeClaim requests 10Gi
+	// and an EmptyDir volume has no size limit.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the storage class of the PersistentVolumeClaim. It is ignored for EmptyDir volumes.
+	// If omitted, the cluster's default storage class is used.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// GetType returns the type of volume used to store capture files.
+func (s *PacketCaptureStorage) GetType() PacketCaptureStorageType {
+	if s == nil || s.Type == nil {
+		return PacketCaptureStorageEmptyDir
+	}
+	return *s.Type
 }
 
 // PacketCaptureAPIDeployment is the configuration for the PacketCaptureAPI Deployment.
@@ -44,6 +86,11 @@ type PacketCaptureAPIDeploymentSpec struct {
 	// Template describes the PacketCaptureAPI Deployment pod that will be created.
 	// +optional
 	Template *PacketCaptureAPIDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the PacketCaptureAPI Deployment with new ones.
+	// If omitted, the PacketCaptureAPI Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // PacketCaptureAPIDeploymentPodTemplateSpec is the PacketCaptureAPI Deployment's PodTemplateSpec
@@ -67,6 +114,31 @@ type PacketCaptureAPIDeploymentPodSpec struct {
 	// If omitted, the PacketCaptureAPI Deployment will use its default values for its containers.
 	// +optional
 	Containers []PacketCaptureAPIDeploymentContainer `json:"containers,omitempty"`
+
+	// Affinity is a group of affinity scheduling rules for the PacketCaptureAPI pods.
+	// If specified, this overrides any affinity that may be set on the PacketCaptureAPI Deployment.
+	// If omitted, the PacketCaptureAPI Deployment will use its default value for affinity.
+	// WARNING: Please note that this field will override the default PacketCaptureAPI Deployment affinity.
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+
+	// NodeSelector is the PacketCaptureAPI pod's scheduling constraints.
+	// If specified, each of the key/value pairs are added to the PacketCaptureAPI Deployment nodeSelector provided
+	// the key does not already exist in the object's nodeSelector.
+	// If omitted, the PacketCaptureAPI Deployment will use its default value for nodeSelector.
+	// WARNING: Please note that this field will modify the default PacketCaptureAPI Deployment nodeSelector.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations is the PacketCaptureAPI pod's tolerations.
+	// If specified, this overrides any tolerations that may be set on the PacketCaptureAPI Deployment.
+	// If omitted, the PacketCaptureAPI Deployment will use its default value for tolerations.
+	// WARNING: Please note that this field will override the default PacketCaptureAPI Deployment tolerations.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the PacketCaptureAPI pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // PacketCaptureAPIDeploymentContainer is a PacketCaptureAPI Deployment container.
@@ -81,6 +153,9 @@ type PacketCaptureAPIDeploymentContainer struct {
 	// If omitted, the PacketCaptureAPI Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // PacketCaptureAPIDeploymentInitContainer is a PacketCaptureAPI Deployment init container.
@@ -192,7 +267,25 @@ func (c *PacketCaptureAPIDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *PacketCaptureAPIDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *PacketCaptureAPIDeployment) GetAffinity() *v1.Affinity {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.Affinity
+			}
+		}
+	}
 	return nil
 }
 
@@ -201,10 +294,24 @@ func (c *PacketCaptureAPIDeployment) GetTopologySpreadConstraints() []v1.Topolog
 }
 
 func (c *PacketCaptureAPIDeployment) GetNodeSelector() map[string]string {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.NodeSelector
+			}
+		}
+	}
 	return nil
 }
 
 func (c *PacketCaptureAPIDeployment) GetTolerations() []v1.Toleration {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.Tolerations
+			}
+		}
+	}
 	return nil
 }
 
@@ -213,6 +320,13 @@ func (c *PacketCaptureAPIDeployment) GetTerminationGracePeriodSeconds() *int64 {
 }
 
 func (c *PacketCaptureAPIDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *PacketCaptureAPIDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
@@ -220,6 +334,13 @@ func (c *PacketCaptureAPIDeployment) GetPriorityClassName() string {
 	return ""
 }
 
+func (c *PacketCaptureAPIDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&PacketCaptureAPI{}, &PacketCaptureAPIList{})
 }
@@ -43,6 +43,11 @@ type PolicyRecommendationDeploymentSpec struct {
 	// Template describes the PolicyRecommendation Deployment pod that will be created.
 	// +optional
 	Template *PolicyRecommendationDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the PolicyRecommendation Deployment with new ones.
+	// If omitted, the PolicyRecommendation Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // PolicyRecommendationDeploymentPodTemplateSpec is the PolicyRecommendation Deployment's PodTemplateSpec
@@ -66,6 +71,9 @@ type PolicyRecommendationDeploymentPodSpec struct {
 	// If omitted, the PolicyRecommendation Deployment will use its default values for its containers.
 	// +optional
 	Containers []PolicyRecommendationDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the PolicyRecommendation pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // PolicyRecommendationDeploymentContainer is a PolicyRecommendation Deployment container.
@@ -80,6 +88,9 @@ type PolicyRecommendationDeploymentContainer struct {
 	// If omitted, the PolicyRecommendation Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // PolicyRecommendationDeploymentInitContainer is a PolicyRecommendation Deployment init container.
@@ -184,6 +195,17 @@ func (c *PolicyRecommendationDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *PolicyRecommendationDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *PolicyRecommendationDeployment) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -205,6 +227,13 @@ func (c *PolicyRecommendationDeployment) GetTerminationGracePeriodSeconds() *int
 }
 
 func (c *PolicyRecommendationDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *PolicyRecommendationDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
@@ -212,6 +241,13 @@ func (c *PolicyRecommendationDeployment) GetPriorityClassName() string {
 	return ""
 }
 
+func (c *PolicyRecommendationDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&PolicyRecommendation{}, &PolicyRecommendationList{})
 }
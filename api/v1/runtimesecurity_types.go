@@ -0,0 +1,77 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RuntimeSecuritySpec defines configuration for the Calico Enterprise runtime threat detection agent.
+type RuntimeSecuritySpec struct {
+	// Resources allows customization of limits and requests for compute resources such as cpu and memory.
+	// If omitted, the runtime security agent runs without requests or limits.
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector restricts the runtime security agent to the nodes that match the selector.
+	// If omitted, the runtime security agent runs on all nodes.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// RuntimeSecurityStatus defines the observed state of RuntimeSecurity.
+type RuntimeSecurityStatus struct {
+	// State provides user-readable status.
+	State string `json:"state,omitempty"`
+
+	// Conditions represents the latest observed set of conditions for the component. A component may be one or more of
+	// Ready, Progressing, Degraded or other customer types.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+
+// RuntimeSecurity installs the Calico Enterprise runtime threat detection agent, which runs on each node, detects
+// malicious process, file and network activity in containers, and forwards the resulting security events to Linseed.
+// At most one instance of this resource is supported. It must be named "tigera-secure".
+type RuntimeSecurity struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired state for RuntimeSecurity.
+	Spec RuntimeSecuritySpec `json:"spec,omitempty"`
+
+	// Most recently observed state for RuntimeSecurity.
+	Status RuntimeSecurityStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RuntimeSecurityList contains a list of RuntimeSecurity
+type RuntimeSecurityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuntimeSecurity `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RuntimeSecurity{}, &RuntimeSecurityList{})
+}
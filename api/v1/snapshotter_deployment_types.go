@@ -35,6 +35,11 @@ type ComplianceSnapshotterDeploymentSpec struct {
 	// Template describes the compliance snapshotter Deployment pod that will be created.
 	// +optional
 	Template *ComplianceSnapshotterDeploymentPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the compliance snapshotter Deployment with new ones.
+	// If omitted, the compliance snapshotter Deployment will use its default update strategy.
+	// +optional
+	Strategy *DeploymentUpdateStrategy `json:"strategy,omitempty"`
 }
 
 // ComplianceSnapshotterDeploymentPodTemplateSpec is the compliance snapshotter Deployment's PodTemplateSpec
@@ -58,6 +63,9 @@ type ComplianceSnapshotterDeploymentPodSpec struct {
 	// If omitted, the compliance snapshotter Deployment will use its default values for its containers.
 	// +optional
 	Containers []ComplianceSnapshotterDeploymentContainer `json:"containers,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the compliance snapshotter pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // ComplianceSnapshotterDeploymentContainer is a compliance snapshotter Deployment container.
@@ -72,6 +80,9 @@ type ComplianceSnapshotterDeploymentContainer struct {
 	// If omitted, the compliance snapshotter Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // ComplianceSnapshotterDeploymentInitContainer is a compliance snapshotter Deployment init container.
@@ -144,6 +155,17 @@ func (c *ComplianceSnapshotterDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *ComplianceSnapshotterDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *ComplianceSnapshotterDeployment) GetAffinity() *v1.Affinity {
 	return nil
 }
@@ -165,9 +187,23 @@ func (c *ComplianceSnapshotterDeployment) GetTerminationGracePeriodSeconds() *in
 }
 
 func (c *ComplianceSnapshotterDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.deploymentStrategy()
+	}
+	return nil
+}
+
+func (c *ComplianceSnapshotterDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
 	return nil
 }
 
 func (c *ComplianceSnapshotterDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ComplianceSnapshotterDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
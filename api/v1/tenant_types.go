@@ -107,6 +107,12 @@ type TenantElasticSpec struct {
 	URL       string `json:"url"`
 	KibanaURL string `json:"kibanaURL,omitempty"`
 	MutualTLS bool   `json:"mutualTLS"`
+
+	// InsecureSkipTLSVerify disables the verification of the certificate that the Elasticsearch cluster at URL presents
+	// to the operator. It is meant for lab and proof of concept environments that use short-lived self-signed
+	// certificates, and is rejected unless the operator runs with the --allow-insecure-skip-tls-verify feature gate.
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 }
 
 type TenantStatus struct{}
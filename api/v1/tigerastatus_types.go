@@ -33,6 +33,38 @@ type TigeraStatusStatus struct {
 	// Conditions represents the latest observed set of conditions for this component. A component may be one or more of
 	// Available, Progressing, or Degraded.
 	Conditions []TigeraStatusCondition `json:"conditions"`
+
+	// Workloads reports the readiness of each Deployment, DaemonSet and StatefulSet of the component.
+	// +optional
+	Workloads []TigeraStatusWorkload `json:"workloads,omitempty"`
+}
+
+// TigeraStatusWorkload reports the readiness of one of the workloads of a component.
+type TigeraStatusWorkload struct {
+	// Kind is the kind of the workload, one of Deployment, DaemonSet or StatefulSet.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the workload.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the workload.
+	Name string `json:"name"`
+
+	// DesiredReplicas is the number of pods that the workload should run. For a DaemonSet, this is the number of nodes
+	// that should run its pod.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// ReadyReplicas is the number of pods of the workload that are ready.
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// Generation is the generation of the workload that was last observed.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// LastRolloutTime is the time at which the latest change to the spec of the workload, which rolls out its pods, was
+	// observed. It is not set when the workload was changed before the operator started to report its rollouts.
+	// +optional
+	LastRolloutTime *metav1.Time `json:"lastRolloutTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -97,6 +129,11 @@ type TigeraStatusCondition struct {
 	// Optionally, a detailed message providing additional context.
 	Message string `json:"message,omitempty"`
 
+	// Optionally, a machine-readable code identifying the dependency or configuration that caused the condition.
+	// Only set on Degraded conditions. The set of codes is defined by TigeraStatusErrorCode.
+	// +optional
+	Code string `json:"code,omitempty"`
+
 	// observedGeneration represents the generation that the condition was set based upon.
 	// For instance, if generation is currently 12, but the .status.conditions[x].observedGeneration is 9, the condition is out of date
 	// with respect to the current state of the instance.
@@ -137,6 +174,34 @@ const (
 	UpgradeError              TigeraStatusReason = "UpgradeError"
 	Unknown                   TigeraStatusReason = "Unknown"
 	ImageSetError             TigeraStatusReason = "ImageSetError"
+	Paused                    TigeraStatusReason = "Paused"
+	DependencyNotReady        TigeraStatusReason = "DependencyNotReady"
+)
+
+// TigeraStatusErrorCode is a machine-readable code reported alongside the reason of a Degraded condition. Where the
+// reason describes the kind of operation that failed, the code identifies what the component is missing or waiting for,
+// so that automation can react to specific failures without parsing the condition message.
+type TigeraStatusErrorCode string
+
+const (
+	APIServerNotReady                TigeraStatusErrorCode = "APIServerNotReady"
+	CertificateNotAvailable          TigeraStatusErrorCode = "CertificateNotAvailable"
+	ElasticsearchErrorBudgetExceeded TigeraStatusErrorCode = "ElasticsearchErrorBudgetExceeded"
+	ElasticsearchIncompatible        TigeraStatusErrorCode = "ElasticsearchIncompatible"
+	ElasticsearchNotReady            TigeraStatusErrorCode = "ElasticsearchNotReady"
+	ElasticsearchUnavailable         TigeraStatusErrorCode = "ElasticsearchUnavailable"
+	ImageSetInvalid                  TigeraStatusErrorCode = "ImageSetInvalid"
+	InstallationNotFound             TigeraStatusErrorCode = "InstallationNotFound"
+	InstallationNotReady             TigeraStatusErrorCode = "InstallationNotReady"
+	LicenseAPINotReady               TigeraStatusErrorCode = "LicenseAPINotReady"
+	LicenseFeatureNotAvailable       TigeraStatusErrorCode = "LicenseFeatureNotAvailable"
+	LicenseNotFound                  TigeraStatusErrorCode = "LicenseNotFound"
+	LinseedIngestionBackpressure     TigeraStatusErrorCode = "LinseedIngestionBackpressure"
+	PullSecretsNotAvailable          TigeraStatusErrorCode = "PullSecretsNotAvailable"
+	SecretNotAvailable               TigeraStatusErrorCode = "SecretNotAvailable"
+	TierNotReady                     TigeraStatusErrorCode = "TierNotReady"
+	TigeraCANotAvailable             TigeraStatusErrorCode = "TigeraCANotAvailable"
+	UpgradeNotSupported              TigeraStatusErrorCode = "UpgradeNotSupported"
 )
 
 func init() {
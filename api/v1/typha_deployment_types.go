@@ -34,6 +34,9 @@ type TyphaDeploymentContainer struct {
 	// If used in conjunction with the deprecated ComponentResources, then this value takes precedence.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // TyphaDeploymentInitContainer is a typha Deployment init container.
@@ -103,6 +106,9 @@ type TyphaDeploymentPodSpec struct {
 	// WARNING: Please note that this field will override the default calico-typha Deployment tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// PodDNSOverrides overrides the DNS policy and config of the typha pods.
+	PodDNSOverrides `json:",inline"`
 }
 
 // TyphaDeploymentPodTemplateSpec is the typha Deployment's PodTemplateSpec
@@ -223,6 +229,17 @@ func (c *TyphaDeployment) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *TyphaDeployment) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *TyphaDeployment) GetAffinity() *v1.Affinity {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -288,6 +305,17 @@ func (c *TyphaDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
 	return nil
 }
 
+func (c *TyphaDeployment) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	return nil
+}
+
 func (c *TyphaDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *TyphaDeployment) GetPodDNSOverrides() *PodDNSOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		return &c.Spec.Template.Spec.PodDNSOverrides
+	}
+	return nil
+}
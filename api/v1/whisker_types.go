@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WhiskerNotificationMode controls whether Whisker displays notifications.
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type WhiskerNotificationMode string
+
+const (
+	WhiskerNotificationsEnabled  WhiskerNotificationMode = "Enabled"
+	WhiskerNotificationsDisabled WhiskerNotificationMode = "Disabled"
+)
+
+// WhiskerSpec defines the configuration of Whisker, the flow log visualization UI, and of Goldmane, the flow log
+// aggregator that Felix sends flow logs to and that Whisker reads them from.
+type WhiskerSpec struct {
+	// Notifications controls whether Whisker checks for and displays notifications about new Calico releases and
+	// security advisories. Checking for notifications requires the Whisker pod to have access to the internet.
+	// Default: Enabled
+	// +kubebuilder:default:=Enabled
+	// +optional
+	Notifications *WhiskerNotificationMode `json:"notifications,omitempty"`
+}
+
+// WhiskerStatus defines the observed state of Whisker.
+type WhiskerStatus struct {
+	// State provides user-readable status.
+	State string `json:"state,omitempty"`
+
+	// Conditions represents the latest observed set of conditions for the component. A component may be one or more of
+	// Ready, Progressing, Degraded or other customer types.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+
+// Whisker installs Whisker, a UI to observe the flows of the cluster, along with Goldmane, which aggregates the flow
+// logs of all nodes. It is only supported for Calico, and the operator must be started with --enable-whisker. At most
+// one instance of this resource is supported. It must be named "default".
+type Whisker struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired state for Whisker.
+	Spec WhiskerSpec `json:"spec,omitempty"`
+
+	// Most recently observed state for Whisker.
+	Status WhiskerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WhiskerList contains a list of Whisker
+type WhiskerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Whisker `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Whisker{}, &WhiskerList{})
+}
@@ -32,6 +32,9 @@ type CalicoWindowsUpgradeDaemonSetContainer struct {
 	// If omitted, the calico-windows-upgrade DaemonSet will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ContainerProbeOverrides overrides the timings of the named container's probes.
+	ContainerProbeOverrides `json:",inline"`
 }
 
 // CalicoWindowsUpgradeDaemonSetPodSpec is the calico-windows-upgrade DaemonSet's PodSpec.
@@ -103,6 +106,11 @@ type CalicoWindowsUpgradeDaemonSetSpec struct {
 	// Template describes the calico-windows-upgrade DaemonSet pod that will be created.
 	// +optional
 	Template *CalicoWindowsUpgradeDaemonSetPodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy describes how to replace the existing pods of the calico-windows-upgrade DaemonSet with new ones.
+	// If omitted, the calico-windows-upgrade DaemonSet will use its default update strategy.
+	// +optional
+	Strategy *DaemonSetUpdateStrategy `json:"strategy,omitempty"`
 }
 
 func (c *CalicoWindowsUpgradeDaemonSet) GetMetadata() *Metadata {
@@ -152,6 +160,17 @@ func (c *CalicoWindowsUpgradeDaemonSet) GetContainers() []v1.Container {
 	return nil
 }
 
+func (c *CalicoWindowsUpgradeDaemonSet) GetContainerProbes() map[string]ContainerProbeOverrides {
+	if c.Spec != nil && c.Spec.Template != nil && c.Spec.Template.Spec != nil {
+		probes := map[string]ContainerProbeOverrides{}
+		for _, v := range c.Spec.Template.Spec.Containers {
+			probes[v.Name] = v.ContainerProbeOverrides
+		}
+		return probes
+	}
+	return nil
+}
+
 func (c *CalicoWindowsUpgradeDaemonSet) GetAffinity() *v1.Affinity {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -197,3 +216,10 @@ func (c *CalicoWindowsUpgradeDaemonSet) GetTerminationGracePeriodSeconds() *int6
 func (c *CalicoWindowsUpgradeDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
 	return nil
 }
+
+func (c *CalicoWindowsUpgradeDaemonSet) GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy {
+	if c.Spec != nil {
+		return c.Spec.Strategy.daemonSetUpdateStrategy()
+	}
+	return nil
+}
@@ -22,10 +22,12 @@ package v1
 
 import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/tigera/api/pkg/apis/projectcalico/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -88,6 +90,7 @@ func (in *APIServerDeploymentContainer) DeepCopyInto(out *APIServerDeploymentCon
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerDeploymentContainer.
@@ -163,6 +166,7 @@ func (in *APIServerDeploymentPodSpec) DeepCopyInto(out *APIServerDeploymentPodSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerDeploymentPodSpec.
@@ -213,6 +217,11 @@ func (in *APIServerDeploymentSpec) DeepCopyInto(out *APIServerDeploymentSpec) {
 		*out = new(APIServerDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerDeploymentSpec.
@@ -398,6 +407,11 @@ func (in *AlertManager) DeepCopy() *AlertManager {
 func (in *AlertManagerSpec) DeepCopyInto(out *AlertManagerSpec) {
 	*out = *in
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = new(AlertmanagerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertManagerSpec.
@@ -410,6 +424,242 @@ func (in *AlertManagerSpec) DeepCopy() *AlertManagerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerChildRoute) DeepCopyInto(out *AlertmanagerChildRoute) {
+	*out = *in
+	in.AlertmanagerRouteOptions.DeepCopyInto(&out.AlertmanagerRouteOptions)
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerChildRoute.
+func (in *AlertmanagerChildRoute) DeepCopy() *AlertmanagerChildRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerChildRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerConfiguration) DeepCopyInto(out *AlertmanagerConfiguration) {
+	*out = *in
+	in.Route.DeepCopyInto(&out.Route)
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make([]AlertmanagerReceiver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerConfiguration.
+func (in *AlertmanagerConfiguration) DeepCopy() *AlertmanagerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerEmailConfig) DeepCopyInto(out *AlertmanagerEmailConfig) {
+	*out = *in
+	if in.AuthPassword != nil {
+		in, out := &in.AuthPassword, &out.AuthPassword
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequireTLS != nil {
+		in, out := &in.RequireTLS, &out.RequireTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerEmailConfig.
+func (in *AlertmanagerEmailConfig) DeepCopy() *AlertmanagerEmailConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerEmailConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerPagerDutyConfig) DeepCopyInto(out *AlertmanagerPagerDutyConfig) {
+	*out = *in
+	if in.RoutingKey != nil {
+		in, out := &in.RoutingKey, &out.RoutingKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceKey != nil {
+		in, out := &in.ServiceKey, &out.ServiceKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerPagerDutyConfig.
+func (in *AlertmanagerPagerDutyConfig) DeepCopy() *AlertmanagerPagerDutyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerPagerDutyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerReceiver) DeepCopyInto(out *AlertmanagerReceiver) {
+	*out = *in
+	if in.SlackConfigs != nil {
+		in, out := &in.SlackConfigs, &out.SlackConfigs
+		*out = make([]AlertmanagerSlackConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PagerDutyConfigs != nil {
+		in, out := &in.PagerDutyConfigs, &out.PagerDutyConfigs
+		*out = make([]AlertmanagerPagerDutyConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WebhookConfigs != nil {
+		in, out := &in.WebhookConfigs, &out.WebhookConfigs
+		*out = make([]AlertmanagerWebhookConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EmailConfigs != nil {
+		in, out := &in.EmailConfigs, &out.EmailConfigs
+		*out = make([]AlertmanagerEmailConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerReceiver.
+func (in *AlertmanagerReceiver) DeepCopy() *AlertmanagerReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerRoute) DeepCopyInto(out *AlertmanagerRoute) {
+	*out = *in
+	in.AlertmanagerRouteOptions.DeepCopyInto(&out.AlertmanagerRouteOptions)
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]AlertmanagerChildRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerRoute.
+func (in *AlertmanagerRoute) DeepCopy() *AlertmanagerRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerRouteOptions) DeepCopyInto(out *AlertmanagerRouteOptions) {
+	*out = *in
+	if in.GroupBy != nil {
+		in, out := &in.GroupBy, &out.GroupBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerRouteOptions.
+func (in *AlertmanagerRouteOptions) DeepCopy() *AlertmanagerRouteOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerRouteOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerSlackConfig) DeepCopyInto(out *AlertmanagerSlackConfig) {
+	*out = *in
+	in.APIURL.DeepCopyInto(&out.APIURL)
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerSlackConfig.
+func (in *AlertmanagerSlackConfig) DeepCopy() *AlertmanagerSlackConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerSlackConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerWebhookConfig) DeepCopyInto(out *AlertmanagerWebhookConfig) {
+	*out = *in
+	if in.URLSecret != nil {
+		in, out := &in.URLSecret, &out.URLSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerWebhookConfig.
+func (in *AlertmanagerWebhookConfig) DeepCopy() *AlertmanagerWebhookConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerWebhookConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AnomalyDetectionSpec) DeepCopyInto(out *AnomalyDetectionSpec) {
 	*out = *in
@@ -492,6 +742,11 @@ func (in *ApplicationLayerSpec) DeepCopyInto(out *ApplicationLayerSpec) {
 		*out = new(WAFStatusType)
 		**out = **in
 	}
+	if in.WebApplicationFirewallSettings != nil {
+		in, out := &in.WebApplicationFirewallSettings, &out.WebApplicationFirewallSettings
+		*out = new(WAFSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LogCollection != nil {
 		in, out := &in.LogCollection, &out.LogCollection
 		*out = new(LogCollectionSpec)
@@ -546,6 +801,21 @@ func (in *ApplicationLayerStatus) DeepCopy() *ApplicationLayerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchitectureDigest) DeepCopyInto(out *ArchitectureDigest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchitectureDigest.
+func (in *ArchitectureDigest) DeepCopy() *ArchitectureDigest {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchitectureDigest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Authentication) DeepCopyInto(out *Authentication) {
 	*out = *in
@@ -738,109 +1008,350 @@ func (in *AuthenticationStatus) DeepCopy() *AuthenticationStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNILogging) DeepCopyInto(out *CNILogging) {
+func (in *BGPRack) DeepCopyInto(out *BGPRack) {
 	*out = *in
-	if in.LogSeverity != nil {
-		in, out := &in.LogSeverity, &out.LogSeverity
-		*out = new(LogLevel)
-		**out = **in
-	}
-	if in.LogFileMaxSize != nil {
-		in, out := &in.LogFileMaxSize, &out.LogFileMaxSize
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.LogFileMaxAgeDays != nil {
-		in, out := &in.LogFileMaxAgeDays, &out.LogFileMaxAgeDays
-		*out = new(uint32)
-		**out = **in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]BGPRackPeer, len(*in))
+		copy(*out, *in)
 	}
-	if in.LogFileMaxCount != nil {
-		in, out := &in.LogFileMaxCount, &out.LogFileMaxCount
-		*out = new(uint32)
-		**out = **in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNILogging.
-func (in *CNILogging) DeepCopy() *CNILogging {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPRack.
+func (in *BGPRack) DeepCopy() *BGPRack {
 	if in == nil {
 		return nil
 	}
-	out := new(CNILogging)
+	out := new(BGPRack)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNISpec) DeepCopyInto(out *CNISpec) {
+func (in *BGPRackPeer) DeepCopyInto(out *BGPRackPeer) {
 	*out = *in
-	if in.IPAM != nil {
-		in, out := &in.IPAM, &out.IPAM
-		*out = new(IPAMSpec)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNISpec.
-func (in *CNISpec) DeepCopy() *CNISpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPRackPeer.
+func (in *BGPRackPeer) DeepCopy() *BGPRackPeer {
 	if in == nil {
 		return nil
 	}
-	out := new(CNISpec)
+	out := new(BGPRackPeer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CSINodeDriverDaemonSet) DeepCopyInto(out *CSINodeDriverDaemonSet) {
+func (in *BGPRouteReflectors) DeepCopyInto(out *BGPRouteReflectors) {
 	*out = *in
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = new(Metadata)
-		(*in).DeepCopyInto(*out)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(CSINodeDriverDaemonSetSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSINodeDriverDaemonSet.
-func (in *CSINodeDriverDaemonSet) DeepCopy() *CSINodeDriverDaemonSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPRouteReflectors.
+func (in *BGPRouteReflectors) DeepCopy() *BGPRouteReflectors {
 	if in == nil {
 		return nil
 	}
-	out := new(CSINodeDriverDaemonSet)
+	out := new(BGPRouteReflectors)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CSINodeDriverDaemonSetContainer) DeepCopyInto(out *CSINodeDriverDaemonSetContainer) {
+func (in *BGPTopology) DeepCopyInto(out *BGPTopology) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSINodeDriverDaemonSetContainer.
-func (in *CSINodeDriverDaemonSetContainer) DeepCopy() *CSINodeDriverDaemonSetContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPTopology.
+func (in *BGPTopology) DeepCopy() *BGPTopology {
 	if in == nil {
 		return nil
 	}
-	out := new(CSINodeDriverDaemonSetContainer)
+	out := new(BGPTopology)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BGPTopology) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CSINodeDriverDaemonSetPodSpec) DeepCopyInto(out *CSINodeDriverDaemonSetPodSpec) {
+func (in *BGPTopologyFilter) DeepCopyInto(out *BGPTopologyFilter) {
 	*out = *in
-	if in.Containers != nil {
+	in.BGPFilterSpec.DeepCopyInto(&out.BGPFilterSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPTopologyFilter.
+func (in *BGPTopologyFilter) DeepCopy() *BGPTopologyFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPTopologyFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPTopologyList) DeepCopyInto(out *BGPTopologyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BGPTopology, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPTopologyList.
+func (in *BGPTopologyList) DeepCopy() *BGPTopologyList {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPTopologyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BGPTopologyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPTopologySpec) DeepCopyInto(out *BGPTopologySpec) {
+	*out = *in
+	if in.RouteReflectors != nil {
+		in, out := &in.RouteReflectors, &out.RouteReflectors
+		*out = new(BGPRouteReflectors)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Racks != nil {
+		in, out := &in.Racks, &out.Racks
+		*out = make([]BGPRack, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]BGPTopologyFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPTopologySpec.
+func (in *BGPTopologySpec) DeepCopy() *BGPTopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPTopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPTopologyStatus) DeepCopyInto(out *BGPTopologyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPTopologyStatus.
+func (in *BGPTopologyStatus) DeepCopy() *BGPTopologyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPTopologyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BPFDataplaneStatus) DeepCopyInto(out *BPFDataplaneStatus) {
+	*out = *in
+	if in.NodesNotReady != nil {
+		in, out := &in.NodesNotReady, &out.NodesNotReady
+		*out = make([]BPFNodeStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BPFDataplaneStatus.
+func (in *BPFDataplaneStatus) DeepCopy() *BPFDataplaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BPFDataplaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BPFNodeStatus) DeepCopyInto(out *BPFNodeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BPFNodeStatus.
+func (in *BPFNodeStatus) DeepCopy() *BPFNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BPFNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CNILogging) DeepCopyInto(out *CNILogging) {
+	*out = *in
+	if in.LogSeverity != nil {
+		in, out := &in.LogSeverity, &out.LogSeverity
+		*out = new(LogLevel)
+		**out = **in
+	}
+	if in.LogFileMaxSize != nil {
+		in, out := &in.LogFileMaxSize, &out.LogFileMaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LogFileMaxAgeDays != nil {
+		in, out := &in.LogFileMaxAgeDays, &out.LogFileMaxAgeDays
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.LogFileMaxCount != nil {
+		in, out := &in.LogFileMaxCount, &out.LogFileMaxCount
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNILogging.
+func (in *CNILogging) DeepCopy() *CNILogging {
+	if in == nil {
+		return nil
+	}
+	out := new(CNILogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CNISpec) DeepCopyInto(out *CNISpec) {
+	*out = *in
+	if in.IPAM != nil {
+		in, out := &in.IPAM, &out.IPAM
+		*out = new(IPAMSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNISpec.
+func (in *CNISpec) DeepCopy() *CNISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CNISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSINodeDriverDaemonSet) DeepCopyInto(out *CSINodeDriverDaemonSet) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(CSINodeDriverDaemonSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSINodeDriverDaemonSet.
+func (in *CSINodeDriverDaemonSet) DeepCopy() *CSINodeDriverDaemonSet {
+	if in == nil {
+		return nil
+	}
+	out := new(CSINodeDriverDaemonSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSINodeDriverDaemonSetContainer) DeepCopyInto(out *CSINodeDriverDaemonSetContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSINodeDriverDaemonSetContainer.
+func (in *CSINodeDriverDaemonSetContainer) DeepCopy() *CSINodeDriverDaemonSetContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(CSINodeDriverDaemonSetContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSINodeDriverDaemonSetPodSpec) DeepCopyInto(out *CSINodeDriverDaemonSetPodSpec) {
+	*out = *in
+	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
 		*out = make([]CSINodeDriverDaemonSetContainer, len(*in))
 		for i := range *in {
@@ -866,6 +1377,7 @@ func (in *CSINodeDriverDaemonSetPodSpec) DeepCopyInto(out *CSINodeDriverDaemonSe
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSINodeDriverDaemonSetPodSpec.
@@ -916,6 +1428,11 @@ func (in *CSINodeDriverDaemonSetSpec) DeepCopyInto(out *CSINodeDriverDaemonSetSp
 		*out = new(CSINodeDriverDaemonSetPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSINodeDriverDaemonSetSpec.
@@ -961,6 +1478,7 @@ func (in *CalicoKubeControllersDeploymentContainer) DeepCopyInto(out *CalicoKube
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoKubeControllersDeploymentContainer.
@@ -1002,6 +1520,7 @@ func (in *CalicoKubeControllersDeploymentPodSpec) DeepCopyInto(out *CalicoKubeCo
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoKubeControllersDeploymentPodSpec.
@@ -1052,6 +1571,11 @@ func (in *CalicoKubeControllersDeploymentSpec) DeepCopyInto(out *CalicoKubeContr
 		*out = new(CalicoKubeControllersDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoKubeControllersDeploymentSpec.
@@ -1174,6 +1698,7 @@ func (in *CalicoNodeDaemonSetContainer) DeepCopyInto(out *CalicoNodeDaemonSetCon
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeDaemonSetContainer.
@@ -1242,6 +1767,7 @@ func (in *CalicoNodeDaemonSetPodSpec) DeepCopyInto(out *CalicoNodeDaemonSetPodSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeDaemonSetPodSpec.
@@ -1292,6 +1818,11 @@ func (in *CalicoNodeDaemonSetSpec) DeepCopyInto(out *CalicoNodeDaemonSetSpec) {
 		*out = new(CalicoNodeDaemonSetPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeDaemonSetSpec.
@@ -1337,6 +1868,7 @@ func (in *CalicoNodeWindowsDaemonSetContainer) DeepCopyInto(out *CalicoNodeWindo
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeWindowsDaemonSetContainer.
@@ -1405,6 +1937,7 @@ func (in *CalicoNodeWindowsDaemonSetPodSpec) DeepCopyInto(out *CalicoNodeWindows
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeWindowsDaemonSetPodSpec.
@@ -1455,6 +1988,11 @@ func (in *CalicoNodeWindowsDaemonSetSpec) DeepCopyInto(out *CalicoNodeWindowsDae
 		*out = new(CalicoNodeWindowsDaemonSetPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeWindowsDaemonSetSpec.
@@ -1500,6 +2038,7 @@ func (in *CalicoWindowsUpgradeDaemonSetContainer) DeepCopyInto(out *CalicoWindow
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoWindowsUpgradeDaemonSetContainer.
@@ -1591,6 +2130,11 @@ func (in *CalicoWindowsUpgradeDaemonSetSpec) DeepCopyInto(out *CalicoWindowsUpgr
 		*out = new(CalicoWindowsUpgradeDaemonSetPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoWindowsUpgradeDaemonSetSpec.
@@ -1634,6 +2178,13 @@ func (in *CommonPrometheusFields) DeepCopyInto(out *CommonPrometheusFields) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]RemoteWriteSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonPrometheusFields.
@@ -1701,6 +2252,7 @@ func (in *ComplianceBenchmarkerDaemonSetContainer) DeepCopyInto(out *ComplianceB
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetContainer.
@@ -1750,6 +2302,7 @@ func (in *ComplianceBenchmarkerDaemonSetPodSpec) DeepCopyInto(out *ComplianceBen
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetPodSpec.
@@ -1790,6 +2343,11 @@ func (in *ComplianceBenchmarkerDaemonSetSpec) DeepCopyInto(out *ComplianceBenchm
 		*out = new(ComplianceBenchmarkerDaemonSetPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetSpec.
@@ -1830,6 +2388,7 @@ func (in *ComplianceControllerDeploymentContainer) DeepCopyInto(out *ComplianceC
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentContainer.
@@ -1879,6 +2438,7 @@ func (in *ComplianceControllerDeploymentPodSpec) DeepCopyInto(out *ComplianceCon
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentPodSpec.
@@ -1919,6 +2479,11 @@ func (in *ComplianceControllerDeploymentSpec) DeepCopyInto(out *ComplianceContro
 		*out = new(ComplianceControllerDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentSpec.
@@ -1980,6 +2545,7 @@ func (in *ComplianceReporterPodSpec) DeepCopyInto(out *ComplianceReporterPodSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceReporterPodSpec.
@@ -2100,6 +2666,7 @@ func (in *ComplianceServerDeploymentContainer) DeepCopyInto(out *ComplianceServe
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceServerDeploymentContainer.
@@ -2149,6 +2716,7 @@ func (in *ComplianceServerDeploymentPodSpec) DeepCopyInto(out *ComplianceServerD
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceServerDeploymentPodSpec.
@@ -2189,6 +2757,11 @@ func (in *ComplianceServerDeploymentSpec) DeepCopyInto(out *ComplianceServerDepl
 		*out = new(ComplianceServerDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceServerDeploymentSpec.
@@ -2229,6 +2802,7 @@ func (in *ComplianceSnapshotterDeploymentContainer) DeepCopyInto(out *Compliance
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSnapshotterDeploymentContainer.
@@ -2278,6 +2852,7 @@ func (in *ComplianceSnapshotterDeploymentPodSpec) DeepCopyInto(out *ComplianceSn
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSnapshotterDeploymentPodSpec.
@@ -2318,6 +2893,11 @@ func (in *ComplianceSnapshotterDeploymentSpec) DeepCopyInto(out *ComplianceSnaps
 		*out = new(ComplianceSnapshotterDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSnapshotterDeploymentSpec.
@@ -2413,30 +2993,80 @@ func (in *ComponentResource) DeepCopy() *ComponentResource {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardsJob) DeepCopyInto(out *DashboardsJob) {
+func (in *ContainerProbeOverrides) DeepCopyInto(out *ContainerProbeOverrides) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(DashboardsJobSpec)
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeOverride)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardsJob.
-func (in *DashboardsJob) DeepCopy() *DashboardsJob {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerProbeOverrides.
+func (in *ContainerProbeOverrides) DeepCopy() *ContainerProbeOverrides {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardsJob)
+	out := new(ContainerProbeOverrides)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardsJobContainer) DeepCopyInto(out *DashboardsJobContainer) {
+func (in *DaemonSetUpdateStrategy) DeepCopyInto(out *DaemonSetUpdateStrategy) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDaemonSet)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetUpdateStrategy.
+func (in *DaemonSetUpdateStrategy) DeepCopy() *DaemonSetUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardsJob) DeepCopyInto(out *DashboardsJob) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(DashboardsJobSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardsJob.
+func (in *DashboardsJob) DeepCopy() *DashboardsJob {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardsJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardsJobContainer) DeepCopyInto(out *DashboardsJobContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
@@ -2462,6 +3092,7 @@ func (in *DashboardsJobPodSpec) DeepCopyInto(out *DashboardsJobPodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardsJobPodSpec.
@@ -2514,6 +3145,58 @@ func (in *DashboardsJobSpec) DeepCopy() *DashboardsJobSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeepPacketInspectionSpec) DeepCopyInto(out *DeepPacketInspectionSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxConcurrentInspections != nil {
+		in, out := &in.MaxConcurrentInspections, &out.MaxConcurrentInspections
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeepPacketInspectionSpec.
+func (in *DeepPacketInspectionSpec) DeepCopy() *DeepPacketInspectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeepPacketInspectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentUpdateStrategy) DeepCopyInto(out *DeploymentUpdateStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentUpdateStrategy.
+func (in *DeploymentUpdateStrategy) DeepCopy() *DeploymentUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DexDeployment) DeepCopyInto(out *DexDeployment) {
 	*out = *in
@@ -2542,6 +3225,7 @@ func (in *DexDeploymentContainer) DeepCopyInto(out *DexDeploymentContainer) {
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexDeploymentContainer.
@@ -2591,6 +3275,7 @@ func (in *DexDeploymentPodSpec) DeepCopyInto(out *DexDeploymentPodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexDeploymentPodSpec.
@@ -2631,6 +3316,11 @@ func (in *DexDeploymentSpec) DeepCopyInto(out *DexDeploymentSpec) {
 		*out = new(DexDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexDeploymentSpec.
@@ -2643,6 +3333,26 @@ func (in *DexDeploymentSpec) DeepCopy() *DexDeploymentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ECKOperator) DeepCopyInto(out *ECKOperator) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(ECKOperatorMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ECKOperator.
+func (in *ECKOperator) DeepCopy() *ECKOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(ECKOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ECKOperatorStatefulSet) DeepCopyInto(out *ECKOperatorStatefulSet) {
 	*out = *in
@@ -2720,6 +3430,7 @@ func (in *ECKOperatorStatefulSetPodSpec) DeepCopyInto(out *ECKOperatorStatefulSe
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ECKOperatorStatefulSetPodSpec.
@@ -2840,6 +3551,7 @@ func (in *EKSLogForwarderDeploymentContainer) DeepCopyInto(out *EKSLogForwarderD
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSLogForwarderDeploymentContainer.
@@ -2889,6 +3601,7 @@ func (in *EKSLogForwarderDeploymentPodSpec) DeepCopyInto(out *EKSLogForwarderDep
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSLogForwarderDeploymentPodSpec.
@@ -2929,6 +3642,11 @@ func (in *EKSLogForwarderDeploymentSpec) DeepCopyInto(out *EKSLogForwarderDeploy
 		*out = new(EKSLogForwarderDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSLogForwarderDeploymentSpec.
@@ -2942,188 +3660,212 @@ func (in *EKSLogForwarderDeploymentSpec) DeepCopy() *EKSLogForwarderDeploymentSp
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EgressGateway) DeepCopyInto(out *EgressGateway) {
+func (in *ESGatewayAuditLog) DeepCopyInto(out *ESGatewayAuditLog) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Verbosity != nil {
+		in, out := &in.Verbosity, &out.Verbosity
+		*out = new(ESGatewayAuditLogVerbosity)
+		**out = **in
+	}
+	if in.Sink != nil {
+		in, out := &in.Sink, &out.Sink
+		*out = new(ESGatewayAuditLogSink)
+		**out = **in
+	}
+	if in.MaxEventsPerSecond != nil {
+		in, out := &in.MaxEventsPerSecond, &out.MaxEventsPerSecond
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGateway.
-func (in *EgressGateway) DeepCopy() *EgressGateway {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayAuditLog.
+func (in *ESGatewayAuditLog) DeepCopy() *ESGatewayAuditLog {
 	if in == nil {
 		return nil
 	}
-	out := new(EgressGateway)
+	out := new(ESGatewayAuditLog)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EgressGateway) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESGatewayDeployment) DeepCopyInto(out *ESGatewayDeployment) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(ESGatewayDeploymentSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeployment.
+func (in *ESGatewayDeployment) DeepCopy() *ESGatewayDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ESGatewayDeployment)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EgressGatewayDeploymentPodSpec) DeepCopyInto(out *EgressGatewayDeploymentPodSpec) {
+func (in *ESGatewayDeploymentContainer) DeepCopyInto(out *ESGatewayDeploymentContainer) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]EGWDeploymentInitContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]EGWDeploymentContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentContainer.
+func (in *ESGatewayDeploymentContainer) DeepCopy() *ESGatewayDeploymentContainer {
+	if in == nil {
+		return nil
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
+	out := new(ESGatewayDeploymentContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESGatewayDeploymentInitContainer) DeepCopyInto(out *ESGatewayDeploymentInitContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.TerminationGracePeriodSeconds != nil {
-		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
-		*out = new(int64)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentInitContainer.
+func (in *ESGatewayDeploymentInitContainer) DeepCopy() *ESGatewayDeploymentInitContainer {
+	if in == nil {
+		return nil
 	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+	out := new(ESGatewayDeploymentInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESGatewayDeploymentPodSpec) DeepCopyInto(out *ESGatewayDeploymentPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]ESGatewayDeploymentInitContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ESGatewayDeploymentContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayDeploymentPodSpec.
-func (in *EgressGatewayDeploymentPodSpec) DeepCopy() *EgressGatewayDeploymentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentPodSpec.
+func (in *ESGatewayDeploymentPodSpec) DeepCopy() *ESGatewayDeploymentPodSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EgressGatewayDeploymentPodSpec)
+	out := new(ESGatewayDeploymentPodSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EgressGatewayDeploymentPodTemplateSpec) DeepCopyInto(out *EgressGatewayDeploymentPodTemplateSpec) {
+func (in *ESGatewayDeploymentPodTemplateSpec) DeepCopyInto(out *ESGatewayDeploymentPodTemplateSpec) {
 	*out = *in
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = new(EgressGatewayMetadata)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(EgressGatewayDeploymentPodSpec)
+		*out = new(ESGatewayDeploymentPodSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayDeploymentPodTemplateSpec.
-func (in *EgressGatewayDeploymentPodTemplateSpec) DeepCopy() *EgressGatewayDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentPodTemplateSpec.
+func (in *ESGatewayDeploymentPodTemplateSpec) DeepCopy() *ESGatewayDeploymentPodTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EgressGatewayDeploymentPodTemplateSpec)
+	out := new(ESGatewayDeploymentPodTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EgressGatewayFailureDetection) DeepCopyInto(out *EgressGatewayFailureDetection) {
+func (in *ESGatewayDeploymentSpec) DeepCopyInto(out *ESGatewayDeploymentSpec) {
 	*out = *in
-	if in.HealthTimeoutDataStoreSeconds != nil {
-		in, out := &in.HealthTimeoutDataStoreSeconds, &out.HealthTimeoutDataStoreSeconds
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ICMPProbe != nil {
-		in, out := &in.ICMPProbe, &out.ICMPProbe
-		*out = new(ICMPProbe)
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ESGatewayDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.HTTPProbe != nil {
-		in, out := &in.HTTPProbe, &out.HTTPProbe
-		*out = new(HTTPProbe)
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayFailureDetection.
-func (in *EgressGatewayFailureDetection) DeepCopy() *EgressGatewayFailureDetection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentSpec.
+func (in *ESGatewayDeploymentSpec) DeepCopy() *ESGatewayDeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EgressGatewayFailureDetection)
+	out := new(ESGatewayDeploymentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EgressGatewayIPPool) DeepCopyInto(out *EgressGatewayIPPool) {
+func (in *ESGatewayTenancy) DeepCopyInto(out *ESGatewayTenancy) {
 	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(ESGatewayTenancyMode)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayIPPool.
-func (in *EgressGatewayIPPool) DeepCopy() *EgressGatewayIPPool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayTenancy.
+func (in *ESGatewayTenancy) DeepCopy() *ESGatewayTenancy {
 	if in == nil {
 		return nil
 	}
-	out := new(EgressGatewayIPPool)
+	out := new(ESGatewayTenancy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EgressGatewayList) DeepCopyInto(out *EgressGatewayList) {
+func (in *ESRole) DeepCopyInto(out *ESRole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]EgressGateway, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayList.
-func (in *EgressGatewayList) DeepCopy() *EgressGatewayList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRole.
+func (in *ESRole) DeepCopy() *ESRole {
 	if in == nil {
 		return nil
 	}
-	out := new(EgressGatewayList)
+	out := new(ESRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EgressGatewayList) DeepCopyObject() runtime.Object {
+func (in *ESRole) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3131,535 +3873,541 @@ func (in *EgressGatewayList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EgressGatewayMetadata) DeepCopyInto(out *EgressGatewayMetadata) {
+func (in *ESRoleIndex) DeepCopyInto(out *ESRoleIndex) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayMetadata.
-func (in *EgressGatewayMetadata) DeepCopy() *EgressGatewayMetadata {
-	if in == nil {
-		return nil
-	}
-	out := new(EgressGatewayMetadata)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EgressGatewaySpec) DeepCopyInto(out *EgressGatewaySpec) {
-	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.IPPools != nil {
-		in, out := &in.IPPools, &out.IPPools
-		*out = make([]EgressGatewayIPPool, len(*in))
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ExternalNetworks != nil {
-		in, out := &in.ExternalNetworks, &out.ExternalNetworks
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.LogSeverity != nil {
-		in, out := &in.LogSeverity, &out.LogSeverity
-		*out = new(LogLevel)
-		**out = **in
-	}
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(EgressGatewayDeploymentPodTemplateSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EgressGatewayFailureDetection != nil {
-		in, out := &in.EgressGatewayFailureDetection, &out.EgressGatewayFailureDetection
-		*out = new(EgressGatewayFailureDetection)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSEgressGateway)
-		(*in).DeepCopyInto(*out)
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]ESIndexPrivilege, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewaySpec.
-func (in *EgressGatewaySpec) DeepCopy() *EgressGatewaySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoleIndex.
+func (in *ESRoleIndex) DeepCopy() *ESRoleIndex {
 	if in == nil {
 		return nil
 	}
-	out := new(EgressGatewaySpec)
+	out := new(ESRoleIndex)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EgressGatewayStatus) DeepCopyInto(out *EgressGatewayStatus) {
+func (in *ESRoleList) DeepCopyInto(out *ESRoleList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ESRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayStatus.
-func (in *EgressGatewayStatus) DeepCopy() *EgressGatewayStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoleList.
+func (in *ESRoleList) DeepCopy() *ESRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(EgressGatewayStatus)
+	out := new(ESRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ESRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EksCloudwatchLogsSpec) DeepCopyInto(out *EksCloudwatchLogsSpec) {
+func (in *ESRoleSpec) DeepCopyInto(out *ESRoleSpec) {
 	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]ESRoleSubject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]ESRoleIndex, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EksCloudwatchLogsSpec.
-func (in *EksCloudwatchLogsSpec) DeepCopy() *EksCloudwatchLogsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoleSpec.
+func (in *ESRoleSpec) DeepCopy() *ESRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EksCloudwatchLogsSpec)
+	out := new(ESRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchMetricsDeployment) DeepCopyInto(out *ElasticsearchMetricsDeployment) {
+func (in *ESRoleStatus) DeepCopyInto(out *ESRoleStatus) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(ElasticsearchMetricsDeploymentSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeployment.
-func (in *ElasticsearchMetricsDeployment) DeepCopy() *ElasticsearchMetricsDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoleStatus.
+func (in *ESRoleStatus) DeepCopy() *ESRoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchMetricsDeployment)
+	out := new(ESRoleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchMetricsDeploymentContainer) DeepCopyInto(out *ElasticsearchMetricsDeploymentContainer) {
+func (in *ESRoleSubject) DeepCopyInto(out *ESRoleSubject) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentContainer.
-func (in *ElasticsearchMetricsDeploymentContainer) DeepCopy() *ElasticsearchMetricsDeploymentContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoleSubject.
+func (in *ESRoleSubject) DeepCopy() *ESRoleSubject {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchMetricsDeploymentContainer)
+	out := new(ESRoleSubject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchMetricsDeploymentInitContainer) DeepCopyInto(out *ElasticsearchMetricsDeploymentInitContainer) {
+func (in *EgressGateway) DeepCopyInto(out *EgressGateway) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentInitContainer.
-func (in *ElasticsearchMetricsDeploymentInitContainer) DeepCopy() *ElasticsearchMetricsDeploymentInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGateway.
+func (in *EgressGateway) DeepCopy() *EgressGateway {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchMetricsDeploymentInitContainer)
+	out := new(EgressGateway)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EgressGateway) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchMetricsDeploymentPodSpec) DeepCopyInto(out *ElasticsearchMetricsDeploymentPodSpec) {
+func (in *EgressGatewayDeploymentPodSpec) DeepCopyInto(out *EgressGatewayDeploymentPodSpec) {
 	*out = *in
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]ElasticsearchMetricsDeploymentInitContainer, len(*in))
+		*out = make([]EGWDeploymentInitContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
-		*out = make([]ElasticsearchMetricsDeploymentContainer, len(*in))
+		*out = make([]EGWDeploymentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentPodSpec.
-func (in *ElasticsearchMetricsDeploymentPodSpec) DeepCopy() *ElasticsearchMetricsDeploymentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayDeploymentPodSpec.
+func (in *EgressGatewayDeploymentPodSpec) DeepCopy() *EgressGatewayDeploymentPodSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchMetricsDeploymentPodSpec)
+	out := new(EgressGatewayDeploymentPodSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchMetricsDeploymentPodTemplateSpec) DeepCopyInto(out *ElasticsearchMetricsDeploymentPodTemplateSpec) {
+func (in *EgressGatewayDeploymentPodTemplateSpec) DeepCopyInto(out *EgressGatewayDeploymentPodTemplateSpec) {
 	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(EgressGatewayMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(ElasticsearchMetricsDeploymentPodSpec)
+		*out = new(EgressGatewayDeploymentPodSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentPodTemplateSpec.
-func (in *ElasticsearchMetricsDeploymentPodTemplateSpec) DeepCopy() *ElasticsearchMetricsDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayDeploymentPodTemplateSpec.
+func (in *EgressGatewayDeploymentPodTemplateSpec) DeepCopy() *EgressGatewayDeploymentPodTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchMetricsDeploymentPodTemplateSpec)
+	out := new(EgressGatewayDeploymentPodTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchMetricsDeploymentSpec) DeepCopyInto(out *ElasticsearchMetricsDeploymentSpec) {
+func (in *EgressGatewayFailureDetection) DeepCopyInto(out *EgressGatewayFailureDetection) {
 	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(ElasticsearchMetricsDeploymentPodTemplateSpec)
+	if in.HealthTimeoutDataStoreSeconds != nil {
+		in, out := &in.HealthTimeoutDataStoreSeconds, &out.HealthTimeoutDataStoreSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ICMPProbe != nil {
+		in, out := &in.ICMPProbe, &out.ICMPProbe
+		*out = new(ICMPProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPProbe != nil {
+		in, out := &in.HTTPProbe, &out.HTTPProbe
+		*out = new(HTTPProbe)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentSpec.
-func (in *ElasticsearchMetricsDeploymentSpec) DeepCopy() *ElasticsearchMetricsDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayFailureDetection.
+func (in *EgressGatewayFailureDetection) DeepCopy() *EgressGatewayFailureDetection {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchMetricsDeploymentSpec)
+	out := new(EgressGatewayFailureDetection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+func (in *EgressGatewayIPPool) DeepCopyInto(out *EgressGatewayIPPool) {
 	*out = *in
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
-	}
-	in.BearerTokenSecret.DeepCopyInto(&out.BearerTokenSecret)
-	if in.HonorTimestamps != nil {
-		in, out := &in.HonorTimestamps, &out.HonorTimestamps
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayIPPool.
+func (in *EgressGatewayIPPool) DeepCopy() *EgressGatewayIPPool {
+	if in == nil {
+		return nil
 	}
-	if in.MetricRelabelConfigs != nil {
-		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
-		*out = make([]*monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(monitoringv1.RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.RelabelConfigs != nil {
-		in, out := &in.RelabelConfigs, &out.RelabelConfigs
-		*out = make([]*monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(monitoringv1.RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
-func (in *Endpoint) DeepCopy() *Endpoint {
-	if in == nil {
-		return nil
-	}
-	out := new(Endpoint)
+	out := new(EgressGatewayIPPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoySettings) DeepCopyInto(out *EnvoySettings) {
+func (in *EgressGatewayList) DeepCopyInto(out *EgressGatewayList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EgressGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoySettings.
-func (in *EnvoySettings) DeepCopy() *EnvoySettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayList.
+func (in *EgressGatewayList) DeepCopy() *EgressGatewayList {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvoySettings)
+	out := new(EgressGatewayList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalPrometheus) DeepCopyInto(out *ExternalPrometheus) {
-	*out = *in
-	if in.ServiceMonitor != nil {
-		in, out := &in.ServiceMonitor, &out.ServiceMonitor
-		*out = new(ServiceMonitor)
-		(*in).DeepCopyInto(*out)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPrometheus.
-func (in *ExternalPrometheus) DeepCopy() *ExternalPrometheus {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EgressGatewayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(ExternalPrometheus)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluentdDaemonSet) DeepCopyInto(out *FluentdDaemonSet) {
+func (in *EgressGatewayMetadata) DeepCopyInto(out *EgressGatewayMetadata) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(FluentdDaemonSetSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSet.
-func (in *FluentdDaemonSet) DeepCopy() *FluentdDaemonSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayMetadata.
+func (in *EgressGatewayMetadata) DeepCopy() *EgressGatewayMetadata {
 	if in == nil {
 		return nil
 	}
-	out := new(FluentdDaemonSet)
+	out := new(EgressGatewayMetadata)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluentdDaemonSetContainer) DeepCopyInto(out *FluentdDaemonSetContainer) {
+func (in *EgressGatewaySpec) DeepCopyInto(out *EgressGatewaySpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IPPools != nil {
+		in, out := &in.IPPools, &out.IPPools
+		*out = make([]EgressGatewayIPPool, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalNetworks != nil {
+		in, out := &in.ExternalNetworks, &out.ExternalNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogSeverity != nil {
+		in, out := &in.LogSeverity, &out.LogSeverity
+		*out = new(LogLevel)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(EgressGatewayDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetContainer.
-func (in *FluentdDaemonSetContainer) DeepCopy() *FluentdDaemonSetContainer {
-	if in == nil {
-		return nil
+	if in.EgressGatewayFailureDetection != nil {
+		in, out := &in.EgressGatewayFailureDetection, &out.EgressGatewayFailureDetection
+		*out = new(EgressGatewayFailureDetection)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(FluentdDaemonSetContainer)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluentdDaemonSetInitContainer) DeepCopyInto(out *FluentdDaemonSetInitContainer) {
-	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSEgressGateway)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetInitContainer.
-func (in *FluentdDaemonSetInitContainer) DeepCopy() *FluentdDaemonSetInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewaySpec.
+func (in *EgressGatewaySpec) DeepCopy() *EgressGatewaySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FluentdDaemonSetInitContainer)
+	out := new(EgressGatewaySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluentdDaemonSetPodSpec) DeepCopyInto(out *FluentdDaemonSetPodSpec) {
+func (in *EgressGatewayStatus) DeepCopyInto(out *EgressGatewayStatus) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]FluentdDaemonSetInitContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]FluentdDaemonSetContainer, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetPodSpec.
-func (in *FluentdDaemonSetPodSpec) DeepCopy() *FluentdDaemonSetPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayStatus.
+func (in *EgressGatewayStatus) DeepCopy() *EgressGatewayStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FluentdDaemonSetPodSpec)
+	out := new(EgressGatewayStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluentdDaemonSetPodTemplateSpec) DeepCopyInto(out *FluentdDaemonSetPodTemplateSpec) {
+func (in *EksCloudwatchLogsSpec) DeepCopyInto(out *EksCloudwatchLogsSpec) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(FluentdDaemonSetPodSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetPodTemplateSpec.
-func (in *FluentdDaemonSetPodTemplateSpec) DeepCopy() *FluentdDaemonSetPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EksCloudwatchLogsSpec.
+func (in *EksCloudwatchLogsSpec) DeepCopy() *EksCloudwatchLogsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FluentdDaemonSetPodTemplateSpec)
+	out := new(EksCloudwatchLogsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluentdDaemonSetSpec) DeepCopyInto(out *FluentdDaemonSetSpec) {
+func (in *ElasticsearchErrorBudget) DeepCopyInto(out *ElasticsearchErrorBudget) {
 	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(FluentdDaemonSetPodTemplateSpec)
-		(*in).DeepCopyInto(*out)
+	if in.MaxRejectedExecutionsPerMinute != nil {
+		in, out := &in.MaxRejectedExecutionsPerMinute, &out.MaxRejectedExecutionsPerMinute
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAverageQueryLatency != nil {
+		in, out := &in.MaxAverageQueryLatency, &out.MaxAverageQueryLatency
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetSpec.
-func (in *FluentdDaemonSetSpec) DeepCopy() *FluentdDaemonSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchErrorBudget.
+func (in *ElasticsearchErrorBudget) DeepCopy() *ElasticsearchErrorBudget {
 	if in == nil {
 		return nil
 	}
-	out := new(FluentdDaemonSetSpec)
+	out := new(ElasticsearchErrorBudget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupSearch) DeepCopyInto(out *GroupSearch) {
+func (in *ElasticsearchMetricsCollection) DeepCopyInto(out *ElasticsearchMetricsCollection) {
 	*out = *in
-	if in.UserMatchers != nil {
-		in, out := &in.UserMatchers, &out.UserMatchers
-		*out = make([]UserMatch, len(*in))
+	if in.PerIndexMetrics != nil {
+		in, out := &in.PerIndexMetrics, &out.PerIndexMetrics
+		*out = new(PerIndexMetricsMode)
+		**out = **in
+	}
+	if in.IndexAllowlist != nil {
+		in, out := &in.IndexAllowlist, &out.IndexAllowlist
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MetricRelabelings != nil {
+		in, out := &in.MetricRelabelings, &out.MetricRelabelings
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSearch.
-func (in *GroupSearch) DeepCopy() *GroupSearch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsCollection.
+func (in *ElasticsearchMetricsCollection) DeepCopy() *ElasticsearchMetricsCollection {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupSearch)
+	out := new(ElasticsearchMetricsCollection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GuardianDeployment) DeepCopyInto(out *GuardianDeployment) {
+func (in *ElasticsearchMetricsDeployment) DeepCopyInto(out *ElasticsearchMetricsDeployment) {
 	*out = *in
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(GuardianDeploymentSpec)
+		*out = new(ElasticsearchMetricsDeploymentSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeployment.
-func (in *GuardianDeployment) DeepCopy() *GuardianDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeployment.
+func (in *ElasticsearchMetricsDeployment) DeepCopy() *ElasticsearchMetricsDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(GuardianDeployment)
+	out := new(ElasticsearchMetricsDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GuardianDeploymentContainer) DeepCopyInto(out *GuardianDeploymentContainer) {
+func (in *ElasticsearchMetricsDeploymentContainer) DeepCopyInto(out *ElasticsearchMetricsDeploymentContainer) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentContainer.
-func (in *GuardianDeploymentContainer) DeepCopy() *GuardianDeploymentContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentContainer.
+func (in *ElasticsearchMetricsDeploymentContainer) DeepCopy() *ElasticsearchMetricsDeploymentContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(GuardianDeploymentContainer)
+	out := new(ElasticsearchMetricsDeploymentContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GuardianDeploymentInitContainer) DeepCopyInto(out *GuardianDeploymentInitContainer) {
+func (in *ElasticsearchMetricsDeploymentInitContainer) DeepCopyInto(out *ElasticsearchMetricsDeploymentInitContainer) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
@@ -3668,625 +4416,536 @@ func (in *GuardianDeploymentInitContainer) DeepCopyInto(out *GuardianDeploymentI
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentInitContainer.
-func (in *GuardianDeploymentInitContainer) DeepCopy() *GuardianDeploymentInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentInitContainer.
+func (in *ElasticsearchMetricsDeploymentInitContainer) DeepCopy() *ElasticsearchMetricsDeploymentInitContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(GuardianDeploymentInitContainer)
+	out := new(ElasticsearchMetricsDeploymentInitContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GuardianDeploymentPodSpec) DeepCopyInto(out *GuardianDeploymentPodSpec) {
+func (in *ElasticsearchMetricsDeploymentPodSpec) DeepCopyInto(out *ElasticsearchMetricsDeploymentPodSpec) {
 	*out = *in
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]GuardianDeploymentInitContainer, len(*in))
+		*out = make([]ElasticsearchMetricsDeploymentInitContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
-		*out = make([]GuardianDeploymentContainer, len(*in))
+		*out = make([]ElasticsearchMetricsDeploymentContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentPodSpec.
-func (in *GuardianDeploymentPodSpec) DeepCopy() *GuardianDeploymentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentPodSpec.
+func (in *ElasticsearchMetricsDeploymentPodSpec) DeepCopy() *ElasticsearchMetricsDeploymentPodSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GuardianDeploymentPodSpec)
+	out := new(ElasticsearchMetricsDeploymentPodSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GuardianDeploymentPodTemplateSpec) DeepCopyInto(out *GuardianDeploymentPodTemplateSpec) {
+func (in *ElasticsearchMetricsDeploymentPodTemplateSpec) DeepCopyInto(out *ElasticsearchMetricsDeploymentPodTemplateSpec) {
 	*out = *in
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(GuardianDeploymentPodSpec)
+		*out = new(ElasticsearchMetricsDeploymentPodSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentPodTemplateSpec.
-func (in *GuardianDeploymentPodTemplateSpec) DeepCopy() *GuardianDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentPodTemplateSpec.
+func (in *ElasticsearchMetricsDeploymentPodTemplateSpec) DeepCopy() *ElasticsearchMetricsDeploymentPodTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GuardianDeploymentPodTemplateSpec)
+	out := new(ElasticsearchMetricsDeploymentPodTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GuardianDeploymentSpec) DeepCopyInto(out *GuardianDeploymentSpec) {
+func (in *ElasticsearchMetricsDeploymentSpec) DeepCopyInto(out *ElasticsearchMetricsDeploymentSpec) {
 	*out = *in
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
-		*out = new(GuardianDeploymentPodTemplateSpec)
+		*out = new(ElasticsearchMetricsDeploymentPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentSpec.
-func (in *GuardianDeploymentSpec) DeepCopy() *GuardianDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentSpec.
+func (in *ElasticsearchMetricsDeploymentSpec) DeepCopy() *ElasticsearchMetricsDeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GuardianDeploymentSpec)
+	out := new(ElasticsearchMetricsDeploymentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPProbe) DeepCopyInto(out *HTTPProbe) {
+func (in *ElasticsearchReadOnlyUser) DeepCopyInto(out *ElasticsearchReadOnlyUser) {
 	*out = *in
-	if in.URLs != nil {
-		in, out := &in.URLs, &out.URLs
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.IntervalSeconds != nil {
-		in, out := &in.IntervalSeconds, &out.IntervalSeconds
-		*out = new(int32)
-		**out = **in
-	}
-	if in.TimeoutSeconds != nil {
-		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
-		*out = new(int32)
-		**out = **in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProbe.
-func (in *HTTPProbe) DeepCopy() *HTTPProbe {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchReadOnlyUser.
+func (in *ElasticsearchReadOnlyUser) DeepCopy() *ElasticsearchReadOnlyUser {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPProbe)
+	out := new(ElasticsearchReadOnlyUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ICMPProbe) DeepCopyInto(out *ICMPProbe) {
+func (in *ElasticsearchRemoteCluster) DeepCopyInto(out *ElasticsearchRemoteCluster) {
 	*out = *in
-	if in.IPs != nil {
-		in, out := &in.IPs, &out.IPs
+	if in.Seeds != nil {
+		in, out := &in.Seeds, &out.Seeds
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.IntervalSeconds != nil {
-		in, out := &in.IntervalSeconds, &out.IntervalSeconds
-		*out = new(int32)
-		**out = **in
-	}
-	if in.TimeoutSeconds != nil {
-		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
-		*out = new(int32)
+	if in.SkipUnavailable != nil {
+		in, out := &in.SkipUnavailable, &out.SkipUnavailable
+		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ICMPProbe.
-func (in *ICMPProbe) DeepCopy() *ICMPProbe {
-	if in == nil {
-		return nil
-	}
-	out := new(ICMPProbe)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPAMSpec) DeepCopyInto(out *IPAMSpec) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMSpec.
-func (in *IPAMSpec) DeepCopy() *IPAMSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRemoteCluster.
+func (in *ElasticsearchRemoteCluster) DeepCopy() *ElasticsearchRemoteCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(IPAMSpec)
+	out := new(ElasticsearchRemoteCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPPool) DeepCopyInto(out *IPPool) {
+func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
-	if in.BlockSize != nil {
-		in, out := &in.BlockSize, &out.BlockSize
-		*out = new(int32)
-		**out = **in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
 	}
-	if in.DisableBGPExport != nil {
-		in, out := &in.DisableBGPExport, &out.DisableBGPExport
+	in.BearerTokenSecret.DeepCopyInto(&out.BearerTokenSecret)
+	if in.HonorTimestamps != nil {
+		in, out := &in.HonorTimestamps, &out.HonorTimestamps
 		*out = new(bool)
 		**out = **in
 	}
-	if in.AllowedUses != nil {
-		in, out := &in.AllowedUses, &out.AllowedUses
-		*out = make([]IPPoolAllowedUse, len(*in))
-		copy(*out, *in)
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPool.
-func (in *IPPool) DeepCopy() *IPPool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
 	if in == nil {
 		return nil
 	}
-	out := new(IPPool)
+	out := new(Endpoint)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Image) DeepCopyInto(out *Image) {
+func (in *EnvoySettings) DeepCopyInto(out *EnvoySettings) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Image.
-func (in *Image) DeepCopy() *Image {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoySettings.
+func (in *EnvoySettings) DeepCopy() *EnvoySettings {
 	if in == nil {
 		return nil
 	}
-	out := new(Image)
+	out := new(EnvoySettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageSet) DeepCopyInto(out *ImageSet) {
+func (in *ExternalPrometheus) DeepCopyInto(out *ExternalPrometheus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ServiceMonitor != nil {
+		in, out := &in.ServiceMonitor, &out.ServiceMonitor
+		*out = new(ServiceMonitor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientKeyExport != nil {
+		in, out := &in.ClientKeyExport, &out.ClientKeyExport
+		*out = new(ClientKeyExport)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSet.
-func (in *ImageSet) DeepCopy() *ImageSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPrometheus.
+func (in *ExternalPrometheus) DeepCopy() *ExternalPrometheus {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageSet)
+	out := new(ExternalPrometheus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ImageSet) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageSetList) DeepCopyInto(out *ImageSetList) {
+func (in *Features) DeepCopyInto(out *Features) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ImageSet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Compliance != nil {
+		in, out := &in.Compliance, &out.Compliance
+		*out = new(FeatureState)
+		**out = **in
+	}
+	if in.IntrusionDetection != nil {
+		in, out := &in.IntrusionDetection, &out.IntrusionDetection
+		*out = new(FeatureState)
+		**out = **in
+	}
+	if in.PacketCapture != nil {
+		in, out := &in.PacketCapture, &out.PacketCapture
+		*out = new(FeatureState)
+		**out = **in
+	}
+	if in.ElasticsearchMetrics != nil {
+		in, out := &in.ElasticsearchMetrics, &out.ElasticsearchMetrics
+		*out = new(FeatureState)
+		**out = **in
+	}
+	if in.Kibana != nil {
+		in, out := &in.Kibana, &out.Kibana
+		*out = new(FeatureState)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetList.
-func (in *ImageSetList) DeepCopy() *ImageSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Features.
+func (in *Features) DeepCopy() *Features {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageSetList)
+	out := new(Features)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ImageSetList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageSetSpec) DeepCopyInto(out *ImageSetSpec) {
+func (in *FlowLogsSpec) DeepCopyInto(out *FlowLogsSpec) {
 	*out = *in
-	if in.Images != nil {
-		in, out := &in.Images, &out.Images
-		*out = make([]Image, len(*in))
-		copy(*out, *in)
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FileMaxFileSizeMB != nil {
+		in, out := &in.FileMaxFileSizeMB, &out.FileMaxFileSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AggregationKindForAllowed != nil {
+		in, out := &in.AggregationKindForAllowed, &out.AggregationKindForAllowed
+		*out = new(FlowLogsAggregationKind)
+		**out = **in
+	}
+	if in.AggregationKindForDenied != nil {
+		in, out := &in.AggregationKindForDenied, &out.AggregationKindForDenied
+		*out = new(FlowLogsAggregationKind)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetSpec.
-func (in *ImageSetSpec) DeepCopy() *ImageSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowLogsSpec.
+func (in *FlowLogsSpec) DeepCopy() *FlowLogsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageSetSpec)
+	out := new(FlowLogsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Index) DeepCopyInto(out *Index) {
+func (in *FluentdDaemonSet) DeepCopyInto(out *FluentdDaemonSet) {
 	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(FluentdDaemonSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Index.
-func (in *Index) DeepCopy() *Index {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSet.
+func (in *FluentdDaemonSet) DeepCopy() *FluentdDaemonSet {
 	if in == nil {
 		return nil
 	}
-	out := new(Index)
+	out := new(FluentdDaemonSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Indices) DeepCopyInto(out *Indices) {
+func (in *FluentdDaemonSetContainer) DeepCopyInto(out *FluentdDaemonSetContainer) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Indices.
-func (in *Indices) DeepCopy() *Indices {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetContainer.
+func (in *FluentdDaemonSetContainer) DeepCopy() *FluentdDaemonSetContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(Indices)
+	out := new(FluentdDaemonSetContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Installation) DeepCopyInto(out *Installation) {
+func (in *FluentdDaemonSetInitContainer) DeepCopyInto(out *FluentdDaemonSetInitContainer) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Installation.
-func (in *Installation) DeepCopy() *Installation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetInitContainer.
+func (in *FluentdDaemonSetInitContainer) DeepCopy() *FluentdDaemonSetInitContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(Installation)
+	out := new(FluentdDaemonSetInitContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Installation) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstallationList) DeepCopyInto(out *InstallationList) {
+func (in *FluentdDaemonSetPodSpec) DeepCopyInto(out *FluentdDaemonSetPodSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Installation, len(*in))
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]FluentdDaemonSetInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]FluentdDaemonSetContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationList.
-func (in *InstallationList) DeepCopy() *InstallationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetPodSpec.
+func (in *FluentdDaemonSetPodSpec) DeepCopy() *FluentdDaemonSetPodSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InstallationList)
+	out := new(FluentdDaemonSetPodSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InstallationList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstallationSpec) DeepCopyInto(out *InstallationSpec) {
+func (in *FluentdDaemonSetPodTemplateSpec) DeepCopyInto(out *FluentdDaemonSetPodTemplateSpec) {
 	*out = *in
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.CNI != nil {
-		in, out := &in.CNI, &out.CNI
-		*out = new(CNISpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CalicoNetwork != nil {
-		in, out := &in.CalicoNetwork, &out.CalicoNetwork
-		*out = new(CalicoNetworkSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TyphaAffinity != nil {
-		in, out := &in.TyphaAffinity, &out.TyphaAffinity
-		*out = new(TyphaAffinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ControlPlaneNodeSelector != nil {
-		in, out := &in.ControlPlaneNodeSelector, &out.ControlPlaneNodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ControlPlaneTolerations != nil {
-		in, out := &in.ControlPlaneTolerations, &out.ControlPlaneTolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ControlPlaneReplicas != nil {
-		in, out := &in.ControlPlaneReplicas, &out.ControlPlaneReplicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.NodeMetricsPort != nil {
-		in, out := &in.NodeMetricsPort, &out.NodeMetricsPort
-		*out = new(int32)
-		**out = **in
-	}
-	if in.TyphaMetricsPort != nil {
-		in, out := &in.TyphaMetricsPort, &out.TyphaMetricsPort
-		*out = new(int32)
-		**out = **in
-	}
-	in.NodeUpdateStrategy.DeepCopyInto(&out.NodeUpdateStrategy)
-	if in.ComponentResources != nil {
-		in, out := &in.ComponentResources, &out.ComponentResources
-		*out = make([]ComponentResource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.CertificateManagement != nil {
-		in, out := &in.CertificateManagement, &out.CertificateManagement
-		*out = new(CertificateManagement)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NonPrivileged != nil {
-		in, out := &in.NonPrivileged, &out.NonPrivileged
-		*out = new(NonPrivilegedType)
-		**out = **in
-	}
-	if in.CalicoNodeDaemonSet != nil {
-		in, out := &in.CalicoNodeDaemonSet, &out.CalicoNodeDaemonSet
-		*out = new(CalicoNodeDaemonSet)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CSINodeDriverDaemonSet != nil {
-		in, out := &in.CSINodeDriverDaemonSet, &out.CSINodeDriverDaemonSet
-		*out = new(CSINodeDriverDaemonSet)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CalicoKubeControllersDeployment != nil {
-		in, out := &in.CalicoKubeControllersDeployment, &out.CalicoKubeControllersDeployment
-		*out = new(CalicoKubeControllersDeployment)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TyphaDeployment != nil {
-		in, out := &in.TyphaDeployment, &out.TyphaDeployment
-		*out = new(TyphaDeployment)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CalicoWindowsUpgradeDaemonSet != nil {
-		in, out := &in.CalicoWindowsUpgradeDaemonSet, &out.CalicoWindowsUpgradeDaemonSet
-		*out = new(CalicoWindowsUpgradeDaemonSet)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CalicoNodeWindowsDaemonSet != nil {
-		in, out := &in.CalicoNodeWindowsDaemonSet, &out.CalicoNodeWindowsDaemonSet
-		*out = new(CalicoNodeWindowsDaemonSet)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.FIPSMode != nil {
-		in, out := &in.FIPSMode, &out.FIPSMode
-		*out = new(FIPSMode)
-		**out = **in
-	}
-	if in.Logging != nil {
-		in, out := &in.Logging, &out.Logging
-		*out = new(Logging)
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(FluentdDaemonSetPodSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.WindowsNodes != nil {
-		in, out := &in.WindowsNodes, &out.WindowsNodes
-		*out = new(WindowsNodeSpec)
-		**out = **in
-	}
-	if in.ServiceCIDRs != nil {
-		in, out := &in.ServiceCIDRs, &out.ServiceCIDRs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationSpec.
-func (in *InstallationSpec) DeepCopy() *InstallationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetPodTemplateSpec.
+func (in *FluentdDaemonSetPodTemplateSpec) DeepCopy() *FluentdDaemonSetPodTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InstallationSpec)
+	out := new(FluentdDaemonSetPodTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstallationStatus) DeepCopyInto(out *InstallationStatus) {
+func (in *FluentdDaemonSetSpec) DeepCopyInto(out *FluentdDaemonSetSpec) {
 	*out = *in
-	if in.Computed != nil {
-		in, out := &in.Computed, &out.Computed
-		*out = new(InstallationSpec)
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(FluentdDaemonSetPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationStatus.
-func (in *InstallationStatus) DeepCopy() *InstallationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetSpec.
+func (in *FluentdDaemonSetSpec) DeepCopy() *FluentdDaemonSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InstallationStatus)
+	out := new(FluentdDaemonSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetection) DeepCopyInto(out *IntrusionDetection) {
+func (in *GrafanaDashboards) DeepCopyInto(out *GrafanaDashboards) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetection.
-func (in *IntrusionDetection) DeepCopy() *IntrusionDetection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboards.
+func (in *GrafanaDashboards) DeepCopy() *GrafanaDashboards {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetection)
+	out := new(GrafanaDashboards)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IntrusionDetection) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionComponentResource) DeepCopyInto(out *IntrusionDetectionComponentResource) {
+func (in *GroupSearch) DeepCopyInto(out *GroupSearch) {
 	*out = *in
-	if in.ResourceRequirements != nil {
-		in, out := &in.ResourceRequirements, &out.ResourceRequirements
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.UserMatchers != nil {
+		in, out := &in.UserMatchers, &out.UserMatchers
+		*out = make([]UserMatch, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionComponentResource.
-func (in *IntrusionDetectionComponentResource) DeepCopy() *IntrusionDetectionComponentResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSearch.
+func (in *GroupSearch) DeepCopy() *GroupSearch {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionComponentResource)
+	out := new(GroupSearch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionControllerDeployment) DeepCopyInto(out *IntrusionDetectionControllerDeployment) {
+func (in *GuardianDeployment) DeepCopyInto(out *GuardianDeployment) {
 	*out = *in
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(IntrusionDetectionControllerDeploymentSpec)
+		*out = new(GuardianDeploymentSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeployment.
-func (in *IntrusionDetectionControllerDeployment) DeepCopy() *IntrusionDetectionControllerDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeployment.
+func (in *GuardianDeployment) DeepCopy() *GuardianDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionControllerDeployment)
+	out := new(GuardianDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionControllerDeploymentContainer) DeepCopyInto(out *IntrusionDetectionControllerDeploymentContainer) {
+func (in *GuardianDeploymentContainer) DeepCopyInto(out *GuardianDeploymentContainer) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentContainer.
-func (in *IntrusionDetectionControllerDeploymentContainer) DeepCopy() *IntrusionDetectionControllerDeploymentContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentContainer.
+func (in *GuardianDeploymentContainer) DeepCopy() *GuardianDeploymentContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionControllerDeploymentContainer)
+	out := new(GuardianDeploymentContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionControllerDeploymentInitContainer) DeepCopyInto(out *IntrusionDetectionControllerDeploymentInitContainer) {
+func (in *GuardianDeploymentInitContainer) DeepCopyInto(out *GuardianDeploymentInitContainer) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
@@ -4295,111 +4954,1838 @@ func (in *IntrusionDetectionControllerDeploymentInitContainer) DeepCopyInto(out
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentInitContainer.
-func (in *IntrusionDetectionControllerDeploymentInitContainer) DeepCopy() *IntrusionDetectionControllerDeploymentInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentInitContainer.
+func (in *GuardianDeploymentInitContainer) DeepCopy() *GuardianDeploymentInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardianDeploymentInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardianDeploymentPodSpec) DeepCopyInto(out *GuardianDeploymentPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]GuardianDeploymentInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]GuardianDeploymentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentPodSpec.
+func (in *GuardianDeploymentPodSpec) DeepCopy() *GuardianDeploymentPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardianDeploymentPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardianDeploymentPodTemplateSpec) DeepCopyInto(out *GuardianDeploymentPodTemplateSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(GuardianDeploymentPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentPodTemplateSpec.
+func (in *GuardianDeploymentPodTemplateSpec) DeepCopy() *GuardianDeploymentPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardianDeploymentPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardianDeploymentSpec) DeepCopyInto(out *GuardianDeploymentSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(GuardianDeploymentPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentSpec.
+func (in *GuardianDeploymentSpec) DeepCopy() *GuardianDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardianDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProbe) DeepCopyInto(out *HTTPProbe) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProbe.
+func (in *HTTPProbe) DeepCopy() *HTTPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostProtection) DeepCopyInto(out *HostProtection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostProtection.
+func (in *HostProtection) DeepCopy() *HostProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(HostProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostProtection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostProtectionList) DeepCopyInto(out *HostProtectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostProtection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostProtectionList.
+func (in *HostProtectionList) DeepCopy() *HostProtectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostProtectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostProtectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostProtectionSpec) DeepCopyInto(out *HostProtectionSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailsafePolicy != nil {
+		in, out := &in.FailsafePolicy, &out.FailsafePolicy
+		*out = new(FailsafePolicyType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostProtectionSpec.
+func (in *HostProtectionSpec) DeepCopy() *HostProtectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostProtectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostProtectionStatus) DeepCopyInto(out *HostProtectionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostProtectionStatus.
+func (in *HostProtectionStatus) DeepCopy() *HostProtectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostProtectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ICMPProbe) DeepCopyInto(out *ICMPProbe) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ICMPProbe.
+func (in *ICMPProbe) DeepCopy() *ICMPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(ICMPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMSpec) DeepCopyInto(out *IPAMSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMSpec.
+func (in *IPAMSpec) DeepCopy() *IPAMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPool) DeepCopyInto(out *IPPool) {
+	*out = *in
+	if in.BlockSize != nil {
+		in, out := &in.BlockSize, &out.BlockSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DisableBGPExport != nil {
+		in, out := &in.DisableBGPExport, &out.DisableBGPExport
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowedUses != nil {
+		in, out := &in.AllowedUses, &out.AllowedUses
+		*out = make([]IPPoolAllowedUse, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPool.
+func (in *IPPool) DeepCopy() *IPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolDrainStatus) DeepCopyInto(out *IPPoolDrainStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolDrainStatus.
+func (in *IPPoolDrainStatus) DeepCopy() *IPPoolDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Image) DeepCopyInto(out *Image) {
+	*out = *in
+	if in.ArchitectureDigests != nil {
+		in, out := &in.ArchitectureDigests, &out.ArchitectureDigests
+		*out = make([]ArchitectureDigest, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Image.
+func (in *Image) DeepCopy() *Image {
+	if in == nil {
+		return nil
+	}
+	out := new(Image)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSet) DeepCopyInto(out *ImageSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSet.
+func (in *ImageSet) DeepCopy() *ImageSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSetList) DeepCopyInto(out *ImageSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImageSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetList.
+func (in *ImageSetList) DeepCopy() *ImageSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSetSpec) DeepCopyInto(out *ImageSetSpec) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]Image, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetSpec.
+func (in *ImageSetSpec) DeepCopy() *ImageSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageStatus.
+func (in *ImageStatus) DeepCopy() *ImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerification) DeepCopyInto(out *ImageVerification) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureAction != nil {
+		in, out := &in.FailureAction, &out.FailureAction
+		*out = new(ImageVerificationFailureAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerification.
+func (in *ImageVerification) DeepCopy() *ImageVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Index) DeepCopyInto(out *Index) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Index.
+func (in *Index) DeepCopy() *Index {
+	if in == nil {
+		return nil
+	}
+	out := new(Index)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexMigrationStatus) DeepCopyInto(out *IndexMigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexMigrationStatus.
+func (in *IndexMigrationStatus) DeepCopy() *IndexMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Indices) DeepCopyInto(out *Indices) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Indices.
+func (in *Indices) DeepCopy() *Indices {
+	if in == nil {
+		return nil
+	}
+	out := new(Indices)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngestionBackpressureThresholds) DeepCopyInto(out *IngestionBackpressureThresholds) {
+	*out = *in
+	if in.MaxQueueDepth != nil {
+		in, out := &in.MaxQueueDepth, &out.MaxQueueDepth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxErrorsPerMinute != nil {
+		in, out := &in.MaxErrorsPerMinute, &out.MaxErrorsPerMinute
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SustainedFor != nil {
+		in, out := &in.SustainedFor, &out.SustainedFor
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngestionBackpressureThresholds.
+func (in *IngestionBackpressureThresholds) DeepCopy() *IngestionBackpressureThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(IngestionBackpressureThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Installation) DeepCopyInto(out *Installation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Installation.
+func (in *Installation) DeepCopy() *Installation {
+	if in == nil {
+		return nil
+	}
+	out := new(Installation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Installation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallationList) DeepCopyInto(out *InstallationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Installation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationList.
+func (in *InstallationList) DeepCopy() *InstallationList {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InstallationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallationSpec) DeepCopyInto(out *InstallationSpec) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.CNI != nil {
+		in, out := &in.CNI, &out.CNI
+		*out = new(CNISpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CalicoNetwork != nil {
+		in, out := &in.CalicoNetwork, &out.CalicoNetwork
+		*out = new(CalicoNetworkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TyphaAffinity != nil {
+		in, out := &in.TyphaAffinity, &out.TyphaAffinity
+		*out = new(TyphaAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneNodeSelector != nil {
+		in, out := &in.ControlPlaneNodeSelector, &out.ControlPlaneNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControlPlaneTolerations != nil {
+		in, out := &in.ControlPlaneTolerations, &out.ControlPlaneTolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ControlPlaneReplicas != nil {
+		in, out := &in.ControlPlaneReplicas, &out.ControlPlaneReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeMetricsPort != nil {
+		in, out := &in.NodeMetricsPort, &out.NodeMetricsPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TyphaMetricsPort != nil {
+		in, out := &in.TyphaMetricsPort, &out.TyphaMetricsPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FlowLogs != nil {
+		in, out := &in.FlowLogs, &out.FlowLogs
+		*out = new(FlowLogsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TyphaAutoscaling != nil {
+		in, out := &in.TyphaAutoscaling, &out.TyphaAutoscaling
+		*out = new(TyphaAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	in.NodeUpdateStrategy.DeepCopyInto(&out.NodeUpdateStrategy)
+	if in.ComponentResources != nil {
+		in, out := &in.ComponentResources, &out.ComponentResources
+		*out = make([]ComponentResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificateManagement != nil {
+		in, out := &in.CertificateManagement, &out.CertificateManagement
+		*out = new(CertificateManagement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NonPrivileged != nil {
+		in, out := &in.NonPrivileged, &out.NonPrivileged
+		*out = new(NonPrivilegedType)
+		**out = **in
+	}
+	if in.CalicoNodeDaemonSet != nil {
+		in, out := &in.CalicoNodeDaemonSet, &out.CalicoNodeDaemonSet
+		*out = new(CalicoNodeDaemonSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CSINodeDriverDaemonSet != nil {
+		in, out := &in.CSINodeDriverDaemonSet, &out.CSINodeDriverDaemonSet
+		*out = new(CSINodeDriverDaemonSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CalicoKubeControllersDeployment != nil {
+		in, out := &in.CalicoKubeControllersDeployment, &out.CalicoKubeControllersDeployment
+		*out = new(CalicoKubeControllersDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TyphaDeployment != nil {
+		in, out := &in.TyphaDeployment, &out.TyphaDeployment
+		*out = new(TyphaDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CalicoWindowsUpgradeDaemonSet != nil {
+		in, out := &in.CalicoWindowsUpgradeDaemonSet, &out.CalicoWindowsUpgradeDaemonSet
+		*out = new(CalicoWindowsUpgradeDaemonSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CalicoNodeWindowsDaemonSet != nil {
+		in, out := &in.CalicoNodeWindowsDaemonSet, &out.CalicoNodeWindowsDaemonSet
+		*out = new(CalicoNodeWindowsDaemonSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FIPSMode != nil {
+		in, out := &in.FIPSMode, &out.FIPSMode
+		*out = new(FIPSMode)
+		**out = **in
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(Logging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WindowsNodes != nil {
+		in, out := &in.WindowsNodes, &out.WindowsNodes
+		*out = new(WindowsNodeSpec)
+		**out = **in
+	}
+	if in.ServiceCIDRs != nil {
+		in, out := &in.ServiceCIDRs, &out.ServiceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageVerification != nil {
+		in, out := &in.ImageVerification, &out.ImageVerification
+		*out = new(ImageVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutPolicy != nil {
+		in, out := &in.RolloutPolicy, &out.RolloutPolicy
+		*out = new(RolloutPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(RollbackPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceAdoption != nil {
+		in, out := &in.ResourceAdoption, &out.ResourceAdoption
+		*out = new(ResourceAdoption)
+		**out = **in
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMesh)
+		**out = **in
+	}
+	if in.NamespaceDefaultDeny != nil {
+		in, out := &in.NamespaceDefaultDeny, &out.NamespaceDefaultDeny
+		*out = new(NamespaceDefaultDenyType)
+		**out = **in
+	}
+	if in.WireGuard != nil {
+		in, out := &in.WireGuard, &out.WireGuard
+		*out = new(WireGuard)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = new(Features)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationSpec.
+func (in *InstallationSpec) DeepCopy() *InstallationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallationStatus) DeepCopyInto(out *InstallationStatus) {
+	*out = *in
+	if in.Computed != nil {
+		in, out := &in.Computed, &out.Computed
+		*out = new(InstallationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BPFDataplane != nil {
+		in, out := &in.BPFDataplane, &out.BPFDataplane
+		*out = new(BPFDataplaneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WireGuard != nil {
+		in, out := &in.WireGuard, &out.WireGuard
+		*out = new(WireGuardStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DrainingIPPools != nil {
+		in, out := &in.DrainingIPPools, &out.DrainingIPPools
+		*out = make([]IPPoolDrainStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationStatus.
+func (in *InstallationStatus) DeepCopy() *InstallationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetection) DeepCopyInto(out *IntrusionDetection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetection.
+func (in *IntrusionDetection) DeepCopy() *IntrusionDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IntrusionDetection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionComponentResource) DeepCopyInto(out *IntrusionDetectionComponentResource) {
+	*out = *in
+	if in.ResourceRequirements != nil {
+		in, out := &in.ResourceRequirements, &out.ResourceRequirements
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionComponentResource.
+func (in *IntrusionDetectionComponentResource) DeepCopy() *IntrusionDetectionComponentResource {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionComponentResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionControllerDeployment) DeepCopyInto(out *IntrusionDetectionControllerDeployment) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(IntrusionDetectionControllerDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeployment.
+func (in *IntrusionDetectionControllerDeployment) DeepCopy() *IntrusionDetectionControllerDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionControllerDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionControllerDeploymentContainer) DeepCopyInto(out *IntrusionDetectionControllerDeploymentContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentContainer.
+func (in *IntrusionDetectionControllerDeploymentContainer) DeepCopy() *IntrusionDetectionControllerDeploymentContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionControllerDeploymentContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionControllerDeploymentInitContainer) DeepCopyInto(out *IntrusionDetectionControllerDeploymentInitContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentInitContainer.
+func (in *IntrusionDetectionControllerDeploymentInitContainer) DeepCopy() *IntrusionDetectionControllerDeploymentInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionControllerDeploymentInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionControllerDeploymentPodSpec) DeepCopyInto(out *IntrusionDetectionControllerDeploymentPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]IntrusionDetectionControllerDeploymentInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]IntrusionDetectionControllerDeploymentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentPodSpec.
+func (in *IntrusionDetectionControllerDeploymentPodSpec) DeepCopy() *IntrusionDetectionControllerDeploymentPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionControllerDeploymentPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionControllerDeploymentPodTemplateSpec) DeepCopyInto(out *IntrusionDetectionControllerDeploymentPodTemplateSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(IntrusionDetectionControllerDeploymentPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentPodTemplateSpec.
+func (in *IntrusionDetectionControllerDeploymentPodTemplateSpec) DeepCopy() *IntrusionDetectionControllerDeploymentPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionControllerDeploymentPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionControllerDeploymentSpec) DeepCopyInto(out *IntrusionDetectionControllerDeploymentSpec) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(IntrusionDetectionControllerDeploymentPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentSpec.
+func (in *IntrusionDetectionControllerDeploymentSpec) DeepCopy() *IntrusionDetectionControllerDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionControllerDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionDetector) DeepCopyInto(out *IntrusionDetectionDetector) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionDetector.
+func (in *IntrusionDetectionDetector) DeepCopy() *IntrusionDetectionDetector {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionDetector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionList) DeepCopyInto(out *IntrusionDetectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IntrusionDetection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionList.
+func (in *IntrusionDetectionList) DeepCopy() *IntrusionDetectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IntrusionDetectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionSpec) DeepCopyInto(out *IntrusionDetectionSpec) {
+	*out = *in
+	if in.ComponentResources != nil {
+		in, out := &in.ComponentResources, &out.ComponentResources
+		*out = make([]IntrusionDetectionComponentResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.AnomalyDetection = in.AnomalyDetection
+	if in.DeepPacketInspection != nil {
+		in, out := &in.DeepPacketInspection, &out.DeepPacketInspection
+		*out = new(DeepPacketInspectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Detectors != nil {
+		in, out := &in.Detectors, &out.Detectors
+		*out = make([]IntrusionDetectionDetector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IntrusionDetectionControllerDeployment != nil {
+		in, out := &in.IntrusionDetectionControllerDeployment, &out.IntrusionDetectionControllerDeployment
+		*out = new(IntrusionDetectionControllerDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ThreatFeeds != nil {
+		in, out := &in.ThreatFeeds, &out.ThreatFeeds
+		*out = new(ThreatFeedsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionSpec.
+func (in *IntrusionDetectionSpec) DeepCopy() *IntrusionDetectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntrusionDetectionStatus) DeepCopyInto(out *IntrusionDetectionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ThreatFeeds != nil {
+		in, out := &in.ThreatFeeds, &out.ThreatFeeds
+		*out = make([]ThreatFeedStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionStatus.
+func (in *IntrusionDetectionStatus) DeepCopy() *IntrusionDetectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntrusionDetectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Kibana) DeepCopyInto(out *Kibana) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(KibanaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kibana.
+func (in *Kibana) DeepCopy() *Kibana {
+	if in == nil {
+		return nil
+	}
+	out := new(Kibana)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaContainer) DeepCopyInto(out *KibanaContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaContainer.
+func (in *KibanaContainer) DeepCopy() *KibanaContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaInitContainer) DeepCopyInto(out *KibanaInitContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaInitContainer.
+func (in *KibanaInitContainer) DeepCopy() *KibanaInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaPodSpec) DeepCopyInto(out *KibanaPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]KibanaInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]KibanaContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaPodSpec.
+func (in *KibanaPodSpec) DeepCopy() *KibanaPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaPodTemplateSpec) DeepCopyInto(out *KibanaPodTemplateSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(KibanaPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaPodTemplateSpec.
+func (in *KibanaPodTemplateSpec) DeepCopy() *KibanaPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSpec) DeepCopyInto(out *KibanaSpec) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(KibanaPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpec.
+func (in *KibanaSpec) DeepCopy() *KibanaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L7LogCollectorDaemonSet) DeepCopyInto(out *L7LogCollectorDaemonSet) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(L7LogCollectorDaemonSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSet.
+func (in *L7LogCollectorDaemonSet) DeepCopy() *L7LogCollectorDaemonSet {
+	if in == nil {
+		return nil
+	}
+	out := new(L7LogCollectorDaemonSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L7LogCollectorDaemonSetContainer) DeepCopyInto(out *L7LogCollectorDaemonSetContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetContainer.
+func (in *L7LogCollectorDaemonSetContainer) DeepCopy() *L7LogCollectorDaemonSetContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(L7LogCollectorDaemonSetContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L7LogCollectorDaemonSetInitContainer) DeepCopyInto(out *L7LogCollectorDaemonSetInitContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetInitContainer.
+func (in *L7LogCollectorDaemonSetInitContainer) DeepCopy() *L7LogCollectorDaemonSetInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(L7LogCollectorDaemonSetInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L7LogCollectorDaemonSetPodSpec) DeepCopyInto(out *L7LogCollectorDaemonSetPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]L7LogCollectorDaemonSetInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]L7LogCollectorDaemonSetContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetPodSpec.
+func (in *L7LogCollectorDaemonSetPodSpec) DeepCopy() *L7LogCollectorDaemonSetPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(L7LogCollectorDaemonSetPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L7LogCollectorDaemonSetPodTemplateSpec) DeepCopyInto(out *L7LogCollectorDaemonSetPodTemplateSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(L7LogCollectorDaemonSetPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetPodTemplateSpec.
+func (in *L7LogCollectorDaemonSetPodTemplateSpec) DeepCopy() *L7LogCollectorDaemonSetPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(L7LogCollectorDaemonSetPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L7LogCollectorDaemonSetSpec) DeepCopyInto(out *L7LogCollectorDaemonSetSpec) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(L7LogCollectorDaemonSetPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetSpec.
+func (in *L7LogCollectorDaemonSetSpec) DeepCopy() *L7LogCollectorDaemonSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(L7LogCollectorDaemonSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinseedDeployment) DeepCopyInto(out *LinseedDeployment) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(LinseedDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeployment.
+func (in *LinseedDeployment) DeepCopy() *LinseedDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(LinseedDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinseedDeploymentContainer) DeepCopyInto(out *LinseedDeploymentContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentContainer.
+func (in *LinseedDeploymentContainer) DeepCopy() *LinseedDeploymentContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(LinseedDeploymentContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinseedDeploymentInitContainer) DeepCopyInto(out *LinseedDeploymentInitContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentInitContainer.
+func (in *LinseedDeploymentInitContainer) DeepCopy() *LinseedDeploymentInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(LinseedDeploymentInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinseedDeploymentPodSpec) DeepCopyInto(out *LinseedDeploymentPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]LinseedDeploymentInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]LinseedDeploymentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentPodSpec.
+func (in *LinseedDeploymentPodSpec) DeepCopy() *LinseedDeploymentPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinseedDeploymentPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinseedDeploymentPodTemplateSpec) DeepCopyInto(out *LinseedDeploymentPodTemplateSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(LinseedDeploymentPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentPodTemplateSpec.
+func (in *LinseedDeploymentPodTemplateSpec) DeepCopy() *LinseedDeploymentPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinseedDeploymentPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinseedDeploymentSpec) DeepCopyInto(out *LinseedDeploymentSpec) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(LinseedDeploymentPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentSpec.
+func (in *LinseedDeploymentSpec) DeepCopy() *LinseedDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinseedDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinseedToken) DeepCopyInto(out *LinseedToken) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]LinseedResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expiry != nil {
+		in, out := &in.Expiry, &out.Expiry
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedToken.
+func (in *LinseedToken) DeepCopy() *LinseedToken {
+	if in == nil {
+		return nil
+	}
+	out := new(LinseedToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogCollectionSpec) DeepCopyInto(out *LogCollectionSpec) {
+	*out = *in
+	if in.CollectLogs != nil {
+		in, out := &in.CollectLogs, &out.CollectLogs
+		*out = new(LogCollectionStatusType)
+		**out = **in
+	}
+	if in.LogIntervalSeconds != nil {
+		in, out := &in.LogIntervalSeconds, &out.LogIntervalSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LogRequestsPerInterval != nil {
+		in, out := &in.LogRequestsPerInterval, &out.LogRequestsPerInterval
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Protocols != nil {
+		in, out := &in.Protocols, &out.Protocols
+		*out = make([]L7Protocol, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectionSpec.
+func (in *LogCollectionSpec) DeepCopy() *LogCollectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogCollectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogCollector) DeepCopyInto(out *LogCollector) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollector.
+func (in *LogCollector) DeepCopy() *LogCollector {
+	if in == nil {
+		return nil
+	}
+	out := new(LogCollector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogCollector) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogCollectorList) DeepCopyInto(out *LogCollectorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LogCollector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorList.
+func (in *LogCollectorList) DeepCopy() *LogCollectorList {
+	if in == nil {
+		return nil
+	}
+	out := new(LogCollectorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogCollectorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogCollectorSpec) DeepCopyInto(out *LogCollectorSpec) {
+	*out = *in
+	if in.AdditionalStores != nil {
+		in, out := &in.AdditionalStores, &out.AdditionalStores
+		*out = new(AdditionalLogStoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalSources != nil {
+		in, out := &in.AdditionalSources, &out.AdditionalSources
+		*out = new(AdditionalLogSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CollectProcessPath != nil {
+		in, out := &in.CollectProcessPath, &out.CollectProcessPath
+		*out = new(CollectProcessPathOption)
+		**out = **in
+	}
+	if in.FluentdDaemonSet != nil {
+		in, out := &in.FluentdDaemonSet, &out.FluentdDaemonSet
+		*out = new(FluentdDaemonSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EKSLogForwarderDeployment != nil {
+		in, out := &in.EKSLogForwarderDeployment, &out.EKSLogForwarderDeployment
+		*out = new(EKSLogForwarderDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorSpec.
+func (in *LogCollectorSpec) DeepCopy() *LogCollectorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionControllerDeploymentInitContainer)
+	out := new(LogCollectorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionControllerDeploymentPodSpec) DeepCopyInto(out *IntrusionDetectionControllerDeploymentPodSpec) {
+func (in *LogCollectorStatus) DeepCopyInto(out *LogCollectorStatus) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]IntrusionDetectionControllerDeploymentInitContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]IntrusionDetectionControllerDeploymentContainer, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentPodSpec.
-func (in *IntrusionDetectionControllerDeploymentPodSpec) DeepCopy() *IntrusionDetectionControllerDeploymentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorStatus.
+func (in *LogCollectorStatus) DeepCopy() *LogCollectorStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionControllerDeploymentPodSpec)
+	out := new(LogCollectorStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionControllerDeploymentPodTemplateSpec) DeepCopyInto(out *IntrusionDetectionControllerDeploymentPodTemplateSpec) {
+func (in *LogStorage) DeepCopyInto(out *LogStorage) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(IntrusionDetectionControllerDeploymentPodSpec)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentPodTemplateSpec.
-func (in *IntrusionDetectionControllerDeploymentPodTemplateSpec) DeepCopy() *IntrusionDetectionControllerDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorage.
+func (in *LogStorage) DeepCopy() *LogStorage {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionControllerDeploymentPodTemplateSpec)
+	out := new(LogStorage)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogStorage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionControllerDeploymentSpec) DeepCopyInto(out *IntrusionDetectionControllerDeploymentSpec) {
+func (in *LogStorageComponentResource) DeepCopyInto(out *LogStorageComponentResource) {
 	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(IntrusionDetectionControllerDeploymentPodTemplateSpec)
+	if in.ResourceRequirements != nil {
+		in, out := &in.ResourceRequirements, &out.ResourceRequirements
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentSpec.
-func (in *IntrusionDetectionControllerDeploymentSpec) DeepCopy() *IntrusionDetectionControllerDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageComponentResource.
+func (in *LogStorageComponentResource) DeepCopy() *LogStorageComponentResource {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionControllerDeploymentSpec)
+	out := new(LogStorageComponentResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionList) DeepCopyInto(out *IntrusionDetectionList) {
+func (in *LogStorageList) DeepCopyInto(out *LogStorageList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IntrusionDetection, len(*in))
+		*out = make([]LogStorage, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionList.
-func (in *IntrusionDetectionList) DeepCopy() *IntrusionDetectionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageList.
+func (in *LogStorageList) DeepCopy() *LogStorageList {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionList)
+	out := new(LogStorageList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IntrusionDetectionList) DeepCopyObject() runtime.Object {
+func (in *LogStorageList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4407,35 +6793,172 @@ func (in *IntrusionDetectionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionSpec) DeepCopyInto(out *IntrusionDetectionSpec) {
+func (in *LogStorageSpec) DeepCopyInto(out *LogStorageSpec) {
 	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(Nodes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = new(Indices)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(Retention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetentionGuardrail != nil {
+		in, out := &in.RetentionGuardrail, &out.RetentionGuardrail
+		*out = new(RetentionGuardrail)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CuratedIndexPrefixes != nil {
+		in, out := &in.CuratedIndexPrefixes, &out.CuratedIndexPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpgradeSnapshots != nil {
+		in, out := &in.UpgradeSnapshots, &out.UpgradeSnapshots
+		*out = new(UpgradeSnapshots)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataNodeSelector != nil {
+		in, out := &in.DataNodeSelector, &out.DataNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ComponentResources != nil {
 		in, out := &in.ComponentResources, &out.ComponentResources
-		*out = make([]IntrusionDetectionComponentResource, len(*in))
+		*out = make([]LogStorageComponentResource, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.AnomalyDetection = in.AnomalyDetection
-	if in.IntrusionDetectionControllerDeployment != nil {
-		in, out := &in.IntrusionDetectionControllerDeployment, &out.IntrusionDetectionControllerDeployment
-		*out = new(IntrusionDetectionControllerDeployment)
+	if in.ECKOperatorStatefulSet != nil {
+		in, out := &in.ECKOperatorStatefulSet, &out.ECKOperatorStatefulSet
+		*out = new(ECKOperatorStatefulSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ECKOperator != nil {
+		in, out := &in.ECKOperator, &out.ECKOperator
+		*out = new(ECKOperator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kibana != nil {
+		in, out := &in.Kibana, &out.Kibana
+		*out = new(Kibana)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LinseedDeployment != nil {
+		in, out := &in.LinseedDeployment, &out.LinseedDeployment
+		*out = new(LinseedDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ElasticsearchMetricsDeployment != nil {
+		in, out := &in.ElasticsearchMetricsDeployment, &out.ElasticsearchMetricsDeployment
+		*out = new(ElasticsearchMetricsDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ESGatewayDeployment != nil {
+		in, out := &in.ESGatewayDeployment, &out.ESGatewayDeployment
+		*out = new(ESGatewayDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(LogStorageDeletionPolicy)
+		**out = **in
+	}
+	if in.NonClusterHosts != nil {
+		in, out := &in.NonClusterHosts, &out.NonClusterHosts
+		*out = new(NonClusterHostLogIngestion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(LogStorageTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ESGatewayCertificateDNSNames != nil {
+		in, out := &in.ESGatewayCertificateDNSNames, &out.ESGatewayCertificateDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ESGatewayAuditLog != nil {
+		in, out := &in.ESGatewayAuditLog, &out.ESGatewayAuditLog
+		*out = new(ESGatewayAuditLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ESGatewayTenancy != nil {
+		in, out := &in.ESGatewayTenancy, &out.ESGatewayTenancy
+		*out = new(ESGatewayTenancy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryElasticsearch != nil {
+		in, out := &in.SecondaryElasticsearch, &out.SecondaryElasticsearch
+		*out = new(SecondaryElasticsearch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]ElasticsearchRemoteCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReadOnlyUsers != nil {
+		in, out := &in.ReadOnlyUsers, &out.ReadOnlyUsers
+		*out = make([]ElasticsearchReadOnlyUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinseedTokens != nil {
+		in, out := &in.LinseedTokens, &out.LinseedTokens
+		*out = make([]LinseedToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ErrorBudget != nil {
+		in, out := &in.ErrorBudget, &out.ErrorBudget
+		*out = new(ElasticsearchErrorBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngestionBackpressure != nil {
+		in, out := &in.IngestionBackpressure, &out.IngestionBackpressure
+		*out = new(IngestionBackpressureThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ElasticsearchMetricsAuthentication != nil {
+		in, out := &in.ElasticsearchMetricsAuthentication, &out.ElasticsearchMetricsAuthentication
+		*out = new(ElasticsearchMetricsAuthentication)
+		**out = **in
+	}
+	if in.ElasticsearchMetricsCollection != nil {
+		in, out := &in.ElasticsearchMetricsCollection, &out.ElasticsearchMetricsCollection
+		*out = new(ElasticsearchMetricsCollection)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionSpec.
-func (in *IntrusionDetectionSpec) DeepCopy() *IntrusionDetectionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageSpec.
+func (in *LogStorageSpec) DeepCopy() *LogStorageSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionSpec)
+	out := new(LogStorageSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntrusionDetectionStatus) DeepCopyInto(out *IntrusionDetectionStatus) {
+func (in *LogStorageStatus) DeepCopyInto(out *LogStorageStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -4444,318 +6967,421 @@ func (in *IntrusionDetectionStatus) DeepCopyInto(out *IntrusionDetectionStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.IndexMigrations != nil {
+		in, out := &in.IndexMigrations, &out.IndexMigrations
+		*out = make([]IndexMigrationStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(LogStorageUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetentionGuardrail != nil {
+		in, out := &in.RetentionGuardrail, &out.RetentionGuardrail
+		*out = new(RetentionGuardrailStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostRestoreReconcile != nil {
+		in, out := &in.PostRestoreReconcile, &out.PostRestoreReconcile
+		*out = new(PostRestoreReconcileStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeSnapshots != nil {
+		in, out := &in.UpgradeSnapshots, &out.UpgradeSnapshots
+		*out = make([]UpgradeSnapshotStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionStatus.
-func (in *IntrusionDetectionStatus) DeepCopy() *IntrusionDetectionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageStatus.
+func (in *LogStorageStatus) DeepCopy() *LogStorageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IntrusionDetectionStatus)
+	out := new(LogStorageStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Kibana) DeepCopyInto(out *Kibana) {
+func (in *LogStorageTLS) DeepCopyInto(out *LogStorageTLS) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(KibanaSpec)
-		(*in).DeepCopyInto(*out)
+	if in.MinVersion != nil {
+		in, out := &in.MinVersion, &out.MinVersion
+		*out = new(TLSVersion)
+		**out = **in
+	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kibana.
-func (in *Kibana) DeepCopy() *Kibana {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageTLS.
+func (in *LogStorageTLS) DeepCopy() *LogStorageTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(Kibana)
+	out := new(LogStorageTLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KibanaContainer) DeepCopyInto(out *KibanaContainer) {
+func (in *LogStorageUsage) DeepCopyInto(out *LogStorageUsage) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
+	in.LastCollected.DeepCopyInto(&out.LastCollected)
+	if in.IndexGroups != nil {
+		in, out := &in.IndexGroups, &out.IndexGroups
+		*out = make([]LogUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]LogUsage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageUsage.
+func (in *LogStorageUsage) DeepCopy() *LogStorageUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(LogStorageUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogUsage) DeepCopyInto(out *LogUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogUsage.
+func (in *LogUsage) DeepCopy() *LogUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(LogUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Logging) DeepCopyInto(out *Logging) {
+	*out = *in
+	if in.CNI != nil {
+		in, out := &in.CNI, &out.CNI
+		*out = new(CNILogging)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaContainer.
-func (in *KibanaContainer) DeepCopy() *KibanaContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logging.
+func (in *Logging) DeepCopy() *Logging {
 	if in == nil {
 		return nil
 	}
-	out := new(KibanaContainer)
+	out := new(Logging)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KibanaInitContainer) DeepCopyInto(out *KibanaInitContainer) {
+func (in *ManagementCluster) DeepCopyInto(out *ManagementCluster) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaInitContainer.
-func (in *KibanaInitContainer) DeepCopy() *KibanaInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementCluster.
+func (in *ManagementCluster) DeepCopy() *ManagementCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(KibanaInitContainer)
+	out := new(ManagementCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KibanaPodSpec) DeepCopyInto(out *KibanaPodSpec) {
+func (in *ManagementClusterConnection) DeepCopyInto(out *ManagementClusterConnection) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]KibanaInitContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]KibanaContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaPodSpec.
-func (in *KibanaPodSpec) DeepCopy() *KibanaPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterConnection.
+func (in *ManagementClusterConnection) DeepCopy() *ManagementClusterConnection {
 	if in == nil {
 		return nil
 	}
-	out := new(KibanaPodSpec)
+	out := new(ManagementClusterConnection)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementClusterConnection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KibanaPodTemplateSpec) DeepCopyInto(out *KibanaPodTemplateSpec) {
+func (in *ManagementClusterConnectionList) DeepCopyInto(out *ManagementClusterConnectionList) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(KibanaPodSpec)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ManagementClusterConnection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaPodTemplateSpec.
-func (in *KibanaPodTemplateSpec) DeepCopy() *KibanaPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterConnectionList.
+func (in *ManagementClusterConnectionList) DeepCopy() *ManagementClusterConnectionList {
 	if in == nil {
 		return nil
 	}
-	out := new(KibanaPodTemplateSpec)
+	out := new(ManagementClusterConnectionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementClusterConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KibanaSpec) DeepCopyInto(out *KibanaSpec) {
+func (in *ManagementClusterConnectionSpec) DeepCopyInto(out *ManagementClusterConnectionSpec) {
 	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(KibanaPodTemplateSpec)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ManagementClusterTLS)
+		**out = **in
+	}
+	if in.GuardianDeployment != nil {
+		in, out := &in.GuardianDeployment, &out.GuardianDeployment
+		*out = new(GuardianDeployment)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpec.
-func (in *KibanaSpec) DeepCopy() *KibanaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterConnectionSpec.
+func (in *ManagementClusterConnectionSpec) DeepCopy() *ManagementClusterConnectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KibanaSpec)
+	out := new(ManagementClusterConnectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L7LogCollectorDaemonSet) DeepCopyInto(out *L7LogCollectorDaemonSet) {
+func (in *ManagementClusterConnectionStatus) DeepCopyInto(out *ManagementClusterConnectionStatus) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(L7LogCollectorDaemonSetSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSet.
-func (in *L7LogCollectorDaemonSet) DeepCopy() *L7LogCollectorDaemonSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterConnectionStatus.
+func (in *ManagementClusterConnectionStatus) DeepCopy() *ManagementClusterConnectionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(L7LogCollectorDaemonSet)
+	out := new(ManagementClusterConnectionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L7LogCollectorDaemonSetContainer) DeepCopyInto(out *L7LogCollectorDaemonSetContainer) {
+func (in *ManagementClusterList) DeepCopyInto(out *ManagementClusterList) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ManagementCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetContainer.
-func (in *L7LogCollectorDaemonSetContainer) DeepCopy() *L7LogCollectorDaemonSetContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterList.
+func (in *ManagementClusterList) DeepCopy() *ManagementClusterList {
 	if in == nil {
 		return nil
 	}
-	out := new(L7LogCollectorDaemonSetContainer)
+	out := new(ManagementClusterList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L7LogCollectorDaemonSetInitContainer) DeepCopyInto(out *L7LogCollectorDaemonSetInitContainer) {
+func (in *ManagementClusterSpec) DeepCopyInto(out *ManagementClusterSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLS)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetInitContainer.
-func (in *L7LogCollectorDaemonSetInitContainer) DeepCopy() *L7LogCollectorDaemonSetInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterSpec.
+func (in *ManagementClusterSpec) DeepCopy() *ManagementClusterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(L7LogCollectorDaemonSetInitContainer)
+	out := new(ManagementClusterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L7LogCollectorDaemonSetPodSpec) DeepCopyInto(out *L7LogCollectorDaemonSetPodSpec) {
+func (in *ManagementClusterStatus) DeepCopyInto(out *ManagementClusterStatus) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]L7LogCollectorDaemonSetInitContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]L7LogCollectorDaemonSetContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.StaleManagedClusters != nil {
+		in, out := &in.StaleManagedClusters, &out.StaleManagedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetPodSpec.
-func (in *L7LogCollectorDaemonSetPodSpec) DeepCopy() *L7LogCollectorDaemonSetPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterStatus.
+func (in *ManagementClusterStatus) DeepCopy() *ManagementClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(L7LogCollectorDaemonSetPodSpec)
+	out := new(ManagementClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L7LogCollectorDaemonSetPodTemplateSpec) DeepCopyInto(out *L7LogCollectorDaemonSetPodTemplateSpec) {
+func (in *ManagementClusterTLS) DeepCopyInto(out *ManagementClusterTLS) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(L7LogCollectorDaemonSetPodSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetPodTemplateSpec.
-func (in *L7LogCollectorDaemonSetPodTemplateSpec) DeepCopy() *L7LogCollectorDaemonSetPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterTLS.
+func (in *ManagementClusterTLS) DeepCopy() *ManagementClusterTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(L7LogCollectorDaemonSetPodTemplateSpec)
+	out := new(ManagementClusterTLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L7LogCollectorDaemonSetSpec) DeepCopyInto(out *L7LogCollectorDaemonSetSpec) {
+func (in *Manager) DeepCopyInto(out *Manager) {
 	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(L7LogCollectorDaemonSetPodTemplateSpec)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetSpec.
-func (in *L7LogCollectorDaemonSetSpec) DeepCopy() *L7LogCollectorDaemonSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Manager.
+func (in *Manager) DeepCopy() *Manager {
 	if in == nil {
 		return nil
 	}
-	out := new(L7LogCollectorDaemonSetSpec)
+	out := new(Manager)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Manager) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinseedDeployment) DeepCopyInto(out *LinseedDeployment) {
+func (in *ManagerDeployment) DeepCopyInto(out *ManagerDeployment) {
 	*out = *in
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(LinseedDeploymentSpec)
+		*out = new(ManagerDeploymentSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeployment.
-func (in *LinseedDeployment) DeepCopy() *LinseedDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeployment.
+func (in *ManagerDeployment) DeepCopy() *ManagerDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(LinseedDeployment)
+	out := new(ManagerDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinseedDeploymentContainer) DeepCopyInto(out *LinseedDeploymentContainer) {
+func (in *ManagerDeploymentContainer) DeepCopyInto(out *ManagerDeploymentContainer) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentContainer.
-func (in *LinseedDeploymentContainer) DeepCopy() *LinseedDeploymentContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentContainer.
+func (in *ManagerDeploymentContainer) DeepCopy() *ManagerDeploymentContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(LinseedDeploymentContainer)
+	out := new(ManagerDeploymentContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinseedDeploymentInitContainer) DeepCopyInto(out *LinseedDeploymentInitContainer) {
+func (in *ManagerDeploymentInitContainer) DeepCopyInto(out *ManagerDeploymentInitContainer) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
@@ -4764,168 +7390,117 @@ func (in *LinseedDeploymentInitContainer) DeepCopyInto(out *LinseedDeploymentIni
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentInitContainer.
-func (in *LinseedDeploymentInitContainer) DeepCopy() *LinseedDeploymentInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentInitContainer.
+func (in *ManagerDeploymentInitContainer) DeepCopy() *ManagerDeploymentInitContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(LinseedDeploymentInitContainer)
+	out := new(ManagerDeploymentInitContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinseedDeploymentPodSpec) DeepCopyInto(out *LinseedDeploymentPodSpec) {
+func (in *ManagerDeploymentPodSpec) DeepCopyInto(out *ManagerDeploymentPodSpec) {
 	*out = *in
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]LinseedDeploymentInitContainer, len(*in))
+		*out = make([]ManagerDeploymentInitContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
-		*out = make([]LinseedDeploymentContainer, len(*in))
+		*out = make([]ManagerDeploymentContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentPodSpec.
-func (in *LinseedDeploymentPodSpec) DeepCopy() *LinseedDeploymentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentPodSpec.
+func (in *ManagerDeploymentPodSpec) DeepCopy() *ManagerDeploymentPodSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LinseedDeploymentPodSpec)
+	out := new(ManagerDeploymentPodSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinseedDeploymentPodTemplateSpec) DeepCopyInto(out *LinseedDeploymentPodTemplateSpec) {
+func (in *ManagerDeploymentPodTemplateSpec) DeepCopyInto(out *ManagerDeploymentPodTemplateSpec) {
 	*out = *in
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(LinseedDeploymentPodSpec)
+		*out = new(ManagerDeploymentPodSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentPodTemplateSpec.
-func (in *LinseedDeploymentPodTemplateSpec) DeepCopy() *LinseedDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentPodTemplateSpec.
+func (in *ManagerDeploymentPodTemplateSpec) DeepCopy() *ManagerDeploymentPodTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LinseedDeploymentPodTemplateSpec)
+	out := new(ManagerDeploymentPodTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinseedDeploymentSpec) DeepCopyInto(out *LinseedDeploymentSpec) {
+func (in *ManagerDeploymentSpec) DeepCopyInto(out *ManagerDeploymentSpec) {
 	*out = *in
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
-		*out = new(LinseedDeploymentPodTemplateSpec)
+		*out = new(ManagerDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentSpec.
-func (in *LinseedDeploymentSpec) DeepCopy() *LinseedDeploymentSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(LinseedDeploymentSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogCollectionSpec) DeepCopyInto(out *LogCollectionSpec) {
-	*out = *in
-	if in.CollectLogs != nil {
-		in, out := &in.CollectLogs, &out.CollectLogs
-		*out = new(LogCollectionStatusType)
-		**out = **in
-	}
-	if in.LogIntervalSeconds != nil {
-		in, out := &in.LogIntervalSeconds, &out.LogIntervalSeconds
-		*out = new(int64)
-		**out = **in
-	}
-	if in.LogRequestsPerInterval != nil {
-		in, out := &in.LogRequestsPerInterval, &out.LogRequestsPerInterval
-		*out = new(int64)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectionSpec.
-func (in *LogCollectionSpec) DeepCopy() *LogCollectionSpec {
-	if in == nil {
-		return nil
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(LogCollectionSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogCollector) DeepCopyInto(out *LogCollector) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollector.
-func (in *LogCollector) DeepCopy() *LogCollector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentSpec.
+func (in *ManagerDeploymentSpec) DeepCopy() *ManagerDeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LogCollector)
+	out := new(ManagerDeploymentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LogCollector) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogCollectorList) DeepCopyInto(out *LogCollectorList) {
+func (in *ManagerList) DeepCopyInto(out *ManagerList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]LogCollector, len(*in))
+		*out = make([]Manager, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorList.
-func (in *LogCollectorList) DeepCopy() *LogCollectorList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerList.
+func (in *ManagerList) DeepCopy() *ManagerList {
 	if in == nil {
 		return nil
 	}
-	out := new(LogCollectorList)
+	out := new(ManagerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LogCollectorList) DeepCopyObject() runtime.Object {
+func (in *ManagerList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4933,47 +7508,32 @@ func (in *LogCollectorList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogCollectorSpec) DeepCopyInto(out *LogCollectorSpec) {
+func (in *ManagerSpec) DeepCopyInto(out *ManagerSpec) {
 	*out = *in
-	if in.AdditionalStores != nil {
-		in, out := &in.AdditionalStores, &out.AdditionalStores
-		*out = new(AdditionalLogStoreSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AdditionalSources != nil {
-		in, out := &in.AdditionalSources, &out.AdditionalSources
-		*out = new(AdditionalLogSourceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CollectProcessPath != nil {
-		in, out := &in.CollectProcessPath, &out.CollectProcessPath
-		*out = new(CollectProcessPathOption)
-		**out = **in
-	}
-	if in.FluentdDaemonSet != nil {
-		in, out := &in.FluentdDaemonSet, &out.FluentdDaemonSet
-		*out = new(FluentdDaemonSet)
+	if in.ManagerDeployment != nil {
+		in, out := &in.ManagerDeployment, &out.ManagerDeployment
+		*out = new(ManagerDeployment)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.EKSLogForwarderDeployment != nil {
-		in, out := &in.EKSLogForwarderDeployment, &out.EKSLogForwarderDeployment
-		*out = new(EKSLogForwarderDeployment)
-		(*in).DeepCopyInto(*out)
+	if in.CertificateDNSNames != nil {
+		in, out := &in.CertificateDNSNames, &out.CertificateDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorSpec.
-func (in *LogCollectorSpec) DeepCopy() *LogCollectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerSpec.
+func (in *ManagerSpec) DeepCopy() *ManagerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LogCollectorSpec)
+	out := new(ManagerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogCollectorStatus) DeepCopyInto(out *LogCollectorStatus) {
+func (in *ManagerStatus) DeepCopyInto(out *ManagerStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -4984,161 +7544,141 @@ func (in *LogCollectorStatus) DeepCopyInto(out *LogCollectorStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorStatus.
-func (in *LogCollectorStatus) DeepCopy() *LogCollectorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerStatus.
+func (in *ManagerStatus) DeepCopy() *ManagerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LogCollectorStatus)
+	out := new(ManagerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogStorage) DeepCopyInto(out *LogStorage) {
+func (in *Metadata) DeepCopyInto(out *Metadata) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorage.
-func (in *LogStorage) DeepCopy() *LogStorage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metadata.
+func (in *Metadata) DeepCopy() *Metadata {
 	if in == nil {
 		return nil
 	}
-	out := new(LogStorage)
+	out := new(Metadata)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LogStorage) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogStorageComponentResource) DeepCopyInto(out *LogStorageComponentResource) {
+func (in *Monitor) DeepCopyInto(out *Monitor) {
 	*out = *in
-	if in.ResourceRequirements != nil {
-		in, out := &in.ResourceRequirements, &out.ResourceRequirements
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageComponentResource.
-func (in *LogStorageComponentResource) DeepCopy() *LogStorageComponentResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitor.
+func (in *Monitor) DeepCopy() *Monitor {
 	if in == nil {
 		return nil
 	}
-	out := new(LogStorageComponentResource)
+	out := new(Monitor)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Monitor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogStorageList) DeepCopyInto(out *LogStorageList) {
+func (in *MonitorList) DeepCopyInto(out *MonitorList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]LogStorage, len(*in))
+		*out = make([]Monitor, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageList.
-func (in *LogStorageList) DeepCopy() *LogStorageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorList.
+func (in *MonitorList) DeepCopy() *MonitorList {
 	if in == nil {
 		return nil
 	}
-	out := new(LogStorageList)
+	out := new(MonitorList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LogStorageList) DeepCopyObject() runtime.Object {
+func (in *MonitorList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogStorageSpec) DeepCopyInto(out *LogStorageSpec) {
-	*out = *in
-	if in.Nodes != nil {
-		in, out := &in.Nodes, &out.Nodes
-		*out = new(Nodes)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Indices != nil {
-		in, out := &in.Indices, &out.Indices
-		*out = new(Indices)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Retention != nil {
-		in, out := &in.Retention, &out.Retention
-		*out = new(Retention)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DataNodeSelector != nil {
-		in, out := &in.DataNodeSelector, &out.DataNodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ComponentResources != nil {
-		in, out := &in.ComponentResources, &out.ComponentResources
-		*out = make([]LogStorageComponentResource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ECKOperatorStatefulSet != nil {
-		in, out := &in.ECKOperatorStatefulSet, &out.ECKOperatorStatefulSet
-		*out = new(ECKOperatorStatefulSet)
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitorSpec) DeepCopyInto(out *MonitorSpec) {
+	*out = *in
+	if in.ExternalPrometheus != nil {
+		in, out := &in.ExternalPrometheus, &out.ExternalPrometheus
+		*out = new(ExternalPrometheus)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Kibana != nil {
-		in, out := &in.Kibana, &out.Kibana
-		*out = new(Kibana)
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(Prometheus)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.LinseedDeployment != nil {
-		in, out := &in.LinseedDeployment, &out.LinseedDeployment
-		*out = new(LinseedDeployment)
+	if in.AlertManager != nil {
+		in, out := &in.AlertManager, &out.AlertManager
+		*out = new(AlertManager)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ElasticsearchMetricsDeployment != nil {
-		in, out := &in.ElasticsearchMetricsDeployment, &out.ElasticsearchMetricsDeployment
-		*out = new(ElasticsearchMetricsDeployment)
+	if in.GrafanaDashboards != nil {
+		in, out := &in.GrafanaDashboards, &out.GrafanaDashboards
+		*out = new(GrafanaDashboards)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageSpec.
-func (in *LogStorageSpec) DeepCopy() *LogStorageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorSpec.
+func (in *MonitorSpec) DeepCopy() *MonitorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LogStorageSpec)
+	out := new(MonitorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogStorageStatus) DeepCopyInto(out *LogStorageStatus) {
+func (in *MonitorStatus) DeepCopyInto(out *MonitorStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -5149,56 +7689,36 @@ func (in *LogStorageStatus) DeepCopyInto(out *LogStorageStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageStatus.
-func (in *LogStorageStatus) DeepCopy() *LogStorageStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(LogStorageStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Logging) DeepCopyInto(out *Logging) {
-	*out = *in
-	if in.CNI != nil {
-		in, out := &in.CNI, &out.CNI
-		*out = new(CNILogging)
-		(*in).DeepCopyInto(*out)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logging.
-func (in *Logging) DeepCopy() *Logging {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorStatus.
+func (in *MonitorStatus) DeepCopy() *MonitorStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Logging)
+	out := new(MonitorStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementCluster) DeepCopyInto(out *ManagementCluster) {
+func (in *NetworkPolicyOverride) DeepCopyInto(out *NetworkPolicyOverride) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementCluster.
-func (in *ManagementCluster) DeepCopy() *ManagementCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyOverride.
+func (in *NetworkPolicyOverride) DeepCopy() *NetworkPolicyOverride {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementCluster)
+	out := new(NetworkPolicyOverride)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagementCluster) DeepCopyObject() runtime.Object {
+func (in *NetworkPolicyOverride) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5206,26 +7726,31 @@ func (in *ManagementCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementClusterConnection) DeepCopyInto(out *ManagementClusterConnection) {
+func (in *NetworkPolicyOverrideList) DeepCopyInto(out *NetworkPolicyOverrideList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetworkPolicyOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterConnection.
-func (in *ManagementClusterConnection) DeepCopy() *ManagementClusterConnection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyOverrideList.
+func (in *NetworkPolicyOverrideList) DeepCopy() *NetworkPolicyOverrideList {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementClusterConnection)
+	out := new(NetworkPolicyOverrideList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagementClusterConnection) DeepCopyObject() runtime.Object {
+func (in *NetworkPolicyOverrideList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5233,153 +7758,180 @@ func (in *ManagementClusterConnection) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementClusterConnectionList) DeepCopyInto(out *ManagementClusterConnectionList) {
+func (in *NetworkPolicyOverrideSpec) DeepCopyInto(out *NetworkPolicyOverrideSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ManagementClusterConnection, len(*in))
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]v3.Rule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]v3.Rule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterConnectionList.
-func (in *ManagementClusterConnectionList) DeepCopy() *ManagementClusterConnectionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyOverrideSpec.
+func (in *NetworkPolicyOverrideSpec) DeepCopy() *NetworkPolicyOverrideSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementClusterConnectionList)
+	out := new(NetworkPolicyOverrideSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagementClusterConnectionList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementClusterConnectionSpec) DeepCopyInto(out *ManagementClusterConnectionSpec) {
+func (in *NodeAddressAutodetection) DeepCopyInto(out *NodeAddressAutodetection) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(ManagementClusterTLS)
+	if in.FirstFound != nil {
+		in, out := &in.FirstFound, &out.FirstFound
+		*out = new(bool)
 		**out = **in
 	}
-	if in.GuardianDeployment != nil {
-		in, out := &in.GuardianDeployment, &out.GuardianDeployment
-		*out = new(GuardianDeployment)
-		(*in).DeepCopyInto(*out)
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(KubernetesAutodetectionMethod)
+		**out = **in
+	}
+	if in.CIDRS != nil {
+		in, out := &in.CIDRS, &out.CIDRS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterConnectionSpec.
-func (in *ManagementClusterConnectionSpec) DeepCopy() *ManagementClusterConnectionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressAutodetection.
+func (in *NodeAddressAutodetection) DeepCopy() *NodeAddressAutodetection {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementClusterConnectionSpec)
+	out := new(NodeAddressAutodetection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementClusterConnectionStatus) DeepCopyInto(out *ManagementClusterConnectionStatus) {
+func (in *NodeAffinity) DeepCopyInto(out *NodeAffinity) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	if in.PreferredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.PreferredDuringSchedulingIgnoredDuringExecution, &out.PreferredDuringSchedulingIgnoredDuringExecution
+		*out = make([]corev1.PreferredSchedulingTerm, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.RequiredDuringSchedulingIgnoredDuringExecution, &out.RequiredDuringSchedulingIgnoredDuringExecution
+		*out = new(corev1.NodeSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterConnectionStatus.
-func (in *ManagementClusterConnectionStatus) DeepCopy() *ManagementClusterConnectionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAffinity.
+func (in *NodeAffinity) DeepCopy() *NodeAffinity {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementClusterConnectionStatus)
+	out := new(NodeAffinity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementClusterList) DeepCopyInto(out *ManagementClusterList) {
+func (in *NodeSet) DeepCopyInto(out *NodeSet) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ManagementCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SelectionAttributes != nil {
+		in, out := &in.SelectionAttributes, &out.SelectionAttributes
+		*out = make([]NodeSetSelectionAttribute, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterList.
-func (in *ManagementClusterList) DeepCopy() *ManagementClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSet.
+func (in *NodeSet) DeepCopy() *NodeSet {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementClusterList)
+	out := new(NodeSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagementClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetSelectionAttribute) DeepCopyInto(out *NodeSetSelectionAttribute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSelectionAttribute.
+func (in *NodeSetSelectionAttribute) DeepCopy() *NodeSetSelectionAttribute {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(NodeSetSelectionAttribute)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementClusterSpec) DeepCopyInto(out *ManagementClusterSpec) {
+func (in *Nodes) DeepCopyInto(out *Nodes) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(TLS)
-		**out = **in
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]NodeSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceRequirements != nil {
+		in, out := &in.ResourceRequirements, &out.ResourceRequirements
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterSpec.
-func (in *ManagementClusterSpec) DeepCopy() *ManagementClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nodes.
+func (in *Nodes) DeepCopy() *Nodes {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementClusterSpec)
+	out := new(Nodes)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementClusterTLS) DeepCopyInto(out *ManagementClusterTLS) {
+func (in *NonClusterHostLogIngestion) DeepCopyInto(out *NonClusterHostLogIngestion) {
 	*out = *in
+	if in.ServiceType != nil {
+		in, out := &in.ServiceType, &out.ServiceType
+		*out = new(corev1.ServiceType)
+		**out = **in
+	}
+	if in.SourceCIDRs != nil {
+		in, out := &in.SourceCIDRs, &out.SourceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterTLS.
-func (in *ManagementClusterTLS) DeepCopy() *ManagementClusterTLS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NonClusterHostLogIngestion.
+func (in *NonClusterHostLogIngestion) DeepCopy() *NonClusterHostLogIngestion {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementClusterTLS)
+	out := new(NonClusterHostLogIngestion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Manager) DeepCopyInto(out *Manager) {
+func (in *PacketCaptureAPI) DeepCopyInto(out *PacketCaptureAPI) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5387,18 +7939,18 @@ func (in *Manager) DeepCopyInto(out *Manager) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Manager.
-func (in *Manager) DeepCopy() *Manager {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPI.
+func (in *PacketCaptureAPI) DeepCopy() *PacketCaptureAPI {
 	if in == nil {
 		return nil
 	}
-	out := new(Manager)
+	out := new(PacketCaptureAPI)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Manager) DeepCopyObject() runtime.Object {
+func (in *PacketCaptureAPI) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5406,47 +7958,48 @@ func (in *Manager) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagerDeployment) DeepCopyInto(out *ManagerDeployment) {
+func (in *PacketCaptureAPIDeployment) DeepCopyInto(out *PacketCaptureAPIDeployment) {
 	*out = *in
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(ManagerDeploymentSpec)
+		*out = new(PacketCaptureAPIDeploymentSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeployment.
-func (in *ManagerDeployment) DeepCopy() *ManagerDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeployment.
+func (in *PacketCaptureAPIDeployment) DeepCopy() *PacketCaptureAPIDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagerDeployment)
+	out := new(PacketCaptureAPIDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagerDeploymentContainer) DeepCopyInto(out *ManagerDeploymentContainer) {
+func (in *PacketCaptureAPIDeploymentContainer) DeepCopyInto(out *PacketCaptureAPIDeploymentContainer) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentContainer.
-func (in *ManagerDeploymentContainer) DeepCopy() *ManagerDeploymentContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentContainer.
+func (in *PacketCaptureAPIDeploymentContainer) DeepCopy() *PacketCaptureAPIDeploymentContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagerDeploymentContainer)
+	out := new(PacketCaptureAPIDeploymentContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagerDeploymentInitContainer) DeepCopyInto(out *ManagerDeploymentInitContainer) {
+func (in *PacketCaptureAPIDeploymentInitContainer) DeepCopyInto(out *PacketCaptureAPIDeploymentInitContainer) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
@@ -5455,111 +8008,136 @@ func (in *ManagerDeploymentInitContainer) DeepCopyInto(out *ManagerDeploymentIni
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentInitContainer.
-func (in *ManagerDeploymentInitContainer) DeepCopy() *ManagerDeploymentInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentInitContainer.
+func (in *PacketCaptureAPIDeploymentInitContainer) DeepCopy() *PacketCaptureAPIDeploymentInitContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagerDeploymentInitContainer)
+	out := new(PacketCaptureAPIDeploymentInitContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagerDeploymentPodSpec) DeepCopyInto(out *ManagerDeploymentPodSpec) {
+func (in *PacketCaptureAPIDeploymentPodSpec) DeepCopyInto(out *PacketCaptureAPIDeploymentPodSpec) {
 	*out = *in
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]ManagerDeploymentInitContainer, len(*in))
+		*out = make([]PacketCaptureAPIDeploymentInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]PacketCaptureAPIDeploymentContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]ManagerDeploymentContainer, len(*in))
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentPodSpec.
-func (in *ManagerDeploymentPodSpec) DeepCopy() *ManagerDeploymentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentPodSpec.
+func (in *PacketCaptureAPIDeploymentPodSpec) DeepCopy() *PacketCaptureAPIDeploymentPodSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagerDeploymentPodSpec)
+	out := new(PacketCaptureAPIDeploymentPodSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagerDeploymentPodTemplateSpec) DeepCopyInto(out *ManagerDeploymentPodTemplateSpec) {
+func (in *PacketCaptureAPIDeploymentPodTemplateSpec) DeepCopyInto(out *PacketCaptureAPIDeploymentPodTemplateSpec) {
 	*out = *in
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(ManagerDeploymentPodSpec)
+		*out = new(PacketCaptureAPIDeploymentPodSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentPodTemplateSpec.
-func (in *ManagerDeploymentPodTemplateSpec) DeepCopy() *ManagerDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentPodTemplateSpec.
+func (in *PacketCaptureAPIDeploymentPodTemplateSpec) DeepCopy() *PacketCaptureAPIDeploymentPodTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagerDeploymentPodTemplateSpec)
+	out := new(PacketCaptureAPIDeploymentPodTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagerDeploymentSpec) DeepCopyInto(out *ManagerDeploymentSpec) {
+func (in *PacketCaptureAPIDeploymentSpec) DeepCopyInto(out *PacketCaptureAPIDeploymentSpec) {
 	*out = *in
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
-		*out = new(ManagerDeploymentPodTemplateSpec)
+		*out = new(PacketCaptureAPIDeploymentPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentSpec.
-func (in *ManagerDeploymentSpec) DeepCopy() *ManagerDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentSpec.
+func (in *PacketCaptureAPIDeploymentSpec) DeepCopy() *PacketCaptureAPIDeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagerDeploymentSpec)
+	out := new(PacketCaptureAPIDeploymentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagerList) DeepCopyInto(out *ManagerList) {
+func (in *PacketCaptureAPIList) DeepCopyInto(out *PacketCaptureAPIList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Manager, len(*in))
+		*out = make([]PacketCaptureAPI, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerList.
-func (in *ManagerList) DeepCopy() *ManagerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIList.
+func (in *PacketCaptureAPIList) DeepCopy() *PacketCaptureAPIList {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagerList)
+	out := new(PacketCaptureAPIList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagerList) DeepCopyObject() runtime.Object {
+func (in *PacketCaptureAPIList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5567,27 +8145,32 @@ func (in *ManagerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagerSpec) DeepCopyInto(out *ManagerSpec) {
+func (in *PacketCaptureAPISpec) DeepCopyInto(out *PacketCaptureAPISpec) {
 	*out = *in
-	if in.ManagerDeployment != nil {
-		in, out := &in.ManagerDeployment, &out.ManagerDeployment
-		*out = new(ManagerDeployment)
+	if in.PacketCaptureAPIDeployment != nil {
+		in, out := &in.PacketCaptureAPIDeployment, &out.PacketCaptureAPIDeployment
+		*out = new(PacketCaptureAPIDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(PacketCaptureStorage)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerSpec.
-func (in *ManagerSpec) DeepCopy() *ManagerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPISpec.
+func (in *PacketCaptureAPISpec) DeepCopy() *PacketCaptureAPISpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagerSpec)
+	out := new(PacketCaptureAPISpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagerStatus) DeepCopyInto(out *ManagerStatus) {
+func (in *PacketCaptureAPIStatus) DeepCopyInto(out *PacketCaptureAPIStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -5598,296 +8181,275 @@ func (in *ManagerStatus) DeepCopyInto(out *ManagerStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerStatus.
-func (in *ManagerStatus) DeepCopy() *ManagerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIStatus.
+func (in *PacketCaptureAPIStatus) DeepCopy() *PacketCaptureAPIStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagerStatus)
+	out := new(PacketCaptureAPIStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Metadata) DeepCopyInto(out *Metadata) {
+func (in *PacketCaptureStorage) DeepCopyInto(out *PacketCaptureStorage) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(PacketCaptureStorageType)
+		**out = **in
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metadata.
-func (in *Metadata) DeepCopy() *Metadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureStorage.
+func (in *PacketCaptureStorage) DeepCopy() *PacketCaptureStorage {
 	if in == nil {
 		return nil
 	}
-	out := new(Metadata)
+	out := new(PacketCaptureStorage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Monitor) DeepCopyInto(out *Monitor) {
+func (in *PathMatch) DeepCopyInto(out *PathMatch) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.PathRegexp != nil {
+		in, out := &in.PathRegexp, &out.PathRegexp
+		*out = new(string)
+		**out = **in
+	}
+	if in.PathReplace != nil {
+		in, out := &in.PathReplace, &out.PathReplace
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitor.
-func (in *Monitor) DeepCopy() *Monitor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PathMatch.
+func (in *PathMatch) DeepCopy() *PathMatch {
 	if in == nil {
 		return nil
 	}
-	out := new(Monitor)
+	out := new(PathMatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Monitor) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitorList) DeepCopyInto(out *MonitorList) {
+func (in *PodDNSOverrides) DeepCopyInto(out *PodDNSOverrides) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Monitor, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorList.
-func (in *MonitorList) DeepCopy() *MonitorList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDNSOverrides.
+func (in *PodDNSOverrides) DeepCopy() *PodDNSOverrides {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitorList)
+	out := new(PodDNSOverrides)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MonitorList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitorSpec) DeepCopyInto(out *MonitorSpec) {
+func (in *PolicyRecommendation) DeepCopyInto(out *PolicyRecommendation) {
 	*out = *in
-	if in.ExternalPrometheus != nil {
-		in, out := &in.ExternalPrometheus, &out.ExternalPrometheus
-		*out = new(ExternalPrometheus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Prometheus != nil {
-		in, out := &in.Prometheus, &out.Prometheus
-		*out = new(Prometheus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AlertManager != nil {
-		in, out := &in.AlertManager, &out.AlertManager
-		*out = new(AlertManager)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorSpec.
-func (in *MonitorSpec) DeepCopy() *MonitorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendation.
+func (in *PolicyRecommendation) DeepCopy() *PolicyRecommendation {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitorSpec)
+	out := new(PolicyRecommendation)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyRecommendation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitorStatus) DeepCopyInto(out *MonitorStatus) {
+func (in *PolicyRecommendationDeployment) DeepCopyInto(out *PolicyRecommendationDeployment) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(PolicyRecommendationDeploymentSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorStatus.
-func (in *MonitorStatus) DeepCopy() *MonitorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeployment.
+func (in *PolicyRecommendationDeployment) DeepCopy() *PolicyRecommendationDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitorStatus)
+	out := new(PolicyRecommendationDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeAddressAutodetection) DeepCopyInto(out *NodeAddressAutodetection) {
+func (in *PolicyRecommendationDeploymentContainer) DeepCopyInto(out *PolicyRecommendationDeploymentContainer) {
 	*out = *in
-	if in.FirstFound != nil {
-		in, out := &in.FirstFound, &out.FirstFound
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Kubernetes != nil {
-		in, out := &in.Kubernetes, &out.Kubernetes
-		*out = new(KubernetesAutodetectionMethod)
-		**out = **in
-	}
-	if in.CIDRS != nil {
-		in, out := &in.CIDRS, &out.CIDRS
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressAutodetection.
-func (in *NodeAddressAutodetection) DeepCopy() *NodeAddressAutodetection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentContainer.
+func (in *PolicyRecommendationDeploymentContainer) DeepCopy() *PolicyRecommendationDeploymentContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeAddressAutodetection)
+	out := new(PolicyRecommendationDeploymentContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeAffinity) DeepCopyInto(out *NodeAffinity) {
+func (in *PolicyRecommendationDeploymentInitContainer) DeepCopyInto(out *PolicyRecommendationDeploymentInitContainer) {
 	*out = *in
-	if in.PreferredDuringSchedulingIgnoredDuringExecution != nil {
-		in, out := &in.PreferredDuringSchedulingIgnoredDuringExecution, &out.PreferredDuringSchedulingIgnoredDuringExecution
-		*out = make([]corev1.PreferredSchedulingTerm, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
-		in, out := &in.RequiredDuringSchedulingIgnoredDuringExecution, &out.RequiredDuringSchedulingIgnoredDuringExecution
-		*out = new(corev1.NodeSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAffinity.
-func (in *NodeAffinity) DeepCopy() *NodeAffinity {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentInitContainer.
+func (in *PolicyRecommendationDeploymentInitContainer) DeepCopy() *PolicyRecommendationDeploymentInitContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeAffinity)
+	out := new(PolicyRecommendationDeploymentInitContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSet) DeepCopyInto(out *NodeSet) {
+func (in *PolicyRecommendationDeploymentPodSpec) DeepCopyInto(out *PolicyRecommendationDeploymentPodSpec) {
 	*out = *in
-	if in.SelectionAttributes != nil {
-		in, out := &in.SelectionAttributes, &out.SelectionAttributes
-		*out = make([]NodeSetSelectionAttribute, len(*in))
-		copy(*out, *in)
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]PolicyRecommendationDeploymentInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]PolicyRecommendationDeploymentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSet.
-func (in *NodeSet) DeepCopy() *NodeSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentPodSpec.
+func (in *PolicyRecommendationDeploymentPodSpec) DeepCopy() *PolicyRecommendationDeploymentPodSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSet)
+	out := new(PolicyRecommendationDeploymentPodSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetSelectionAttribute) DeepCopyInto(out *NodeSetSelectionAttribute) {
+func (in *PolicyRecommendationDeploymentPodTemplateSpec) DeepCopyInto(out *PolicyRecommendationDeploymentPodTemplateSpec) {
 	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(PolicyRecommendationDeploymentPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSelectionAttribute.
-func (in *NodeSetSelectionAttribute) DeepCopy() *NodeSetSelectionAttribute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentPodTemplateSpec.
+func (in *PolicyRecommendationDeploymentPodTemplateSpec) DeepCopy() *PolicyRecommendationDeploymentPodTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetSelectionAttribute)
+	out := new(PolicyRecommendationDeploymentPodTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Nodes) DeepCopyInto(out *Nodes) {
+func (in *PolicyRecommendationDeploymentSpec) DeepCopyInto(out *PolicyRecommendationDeploymentSpec) {
 	*out = *in
-	if in.NodeSets != nil {
-		in, out := &in.NodeSets, &out.NodeSets
-		*out = make([]NodeSet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(PolicyRecommendationDeploymentPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ResourceRequirements != nil {
-		in, out := &in.ResourceRequirements, &out.ResourceRequirements
-		*out = new(corev1.ResourceRequirements)
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(DeploymentUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nodes.
-func (in *Nodes) DeepCopy() *Nodes {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentSpec.
+func (in *PolicyRecommendationDeploymentSpec) DeepCopy() *PolicyRecommendationDeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Nodes)
+	out := new(PolicyRecommendationDeploymentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPI) DeepCopyInto(out *PacketCaptureAPI) {
+func (in *PolicyRecommendationList) DeepCopyInto(out *PolicyRecommendationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicyRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPI.
-func (in *PacketCaptureAPI) DeepCopy() *PacketCaptureAPI {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationList.
+func (in *PolicyRecommendationList) DeepCopy() *PolicyRecommendationList {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPI)
+	out := new(PolicyRecommendationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PacketCaptureAPI) DeepCopyObject() runtime.Object {
+func (in *PolicyRecommendationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5895,415 +8457,472 @@ func (in *PacketCaptureAPI) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPIDeployment) DeepCopyInto(out *PacketCaptureAPIDeployment) {
+func (in *PolicyRecommendationSpec) DeepCopyInto(out *PolicyRecommendationSpec) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(PacketCaptureAPIDeploymentSpec)
+	if in.PolicyRecommendationDeployment != nil {
+		in, out := &in.PolicyRecommendationDeployment, &out.PolicyRecommendationDeployment
+		*out = new(PolicyRecommendationDeployment)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeployment.
-func (in *PacketCaptureAPIDeployment) DeepCopy() *PacketCaptureAPIDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationSpec.
+func (in *PolicyRecommendationSpec) DeepCopy() *PolicyRecommendationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPIDeployment)
+	out := new(PolicyRecommendationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPIDeploymentContainer) DeepCopyInto(out *PacketCaptureAPIDeploymentContainer) {
+func (in *PolicyRecommendationStatus) DeepCopyInto(out *PolicyRecommendationStatus) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentContainer.
-func (in *PacketCaptureAPIDeploymentContainer) DeepCopy() *PacketCaptureAPIDeploymentContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationStatus.
+func (in *PolicyRecommendationStatus) DeepCopy() *PolicyRecommendationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPIDeploymentContainer)
+	out := new(PolicyRecommendationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPIDeploymentInitContainer) DeepCopyInto(out *PacketCaptureAPIDeploymentInitContainer) {
+func (in *PostRestoreReconcileStatus) DeepCopyInto(out *PostRestoreReconcileStatus) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.Repairs != nil {
+		in, out := &in.Repairs, &out.Repairs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentInitContainer.
-func (in *PacketCaptureAPIDeploymentInitContainer) DeepCopy() *PacketCaptureAPIDeploymentInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostRestoreReconcileStatus.
+func (in *PostRestoreReconcileStatus) DeepCopy() *PostRestoreReconcileStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPIDeploymentInitContainer)
+	out := new(PostRestoreReconcileStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPIDeploymentPodSpec) DeepCopyInto(out *PacketCaptureAPIDeploymentPodSpec) {
+func (in *ProbeOverride) DeepCopyInto(out *ProbeOverride) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]PacketCaptureAPIDeploymentInitContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]PacketCaptureAPIDeploymentContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessThreshold != nil {
+		in, out := &in.SuccessThreshold, &out.SuccessThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentPodSpec.
-func (in *PacketCaptureAPIDeploymentPodSpec) DeepCopy() *PacketCaptureAPIDeploymentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeOverride.
+func (in *ProbeOverride) DeepCopy() *ProbeOverride {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPIDeploymentPodSpec)
+	out := new(ProbeOverride)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPIDeploymentPodTemplateSpec) DeepCopyInto(out *PacketCaptureAPIDeploymentPodTemplateSpec) {
+func (in *Prometheus) DeepCopyInto(out *Prometheus) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(PacketCaptureAPIDeploymentPodSpec)
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(PrometheusState)
+		**out = **in
+	}
+	if in.PrometheusSpec != nil {
+		in, out := &in.PrometheusSpec, &out.PrometheusSpec
+		*out = new(PrometheusSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentPodTemplateSpec.
-func (in *PacketCaptureAPIDeploymentPodTemplateSpec) DeepCopy() *PacketCaptureAPIDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prometheus.
+func (in *Prometheus) DeepCopy() *Prometheus {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPIDeploymentPodTemplateSpec)
+	out := new(Prometheus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPIDeploymentSpec) DeepCopyInto(out *PacketCaptureAPIDeploymentSpec) {
+func (in *PrometheusContainer) DeepCopyInto(out *PrometheusContainer) {
 	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(PacketCaptureAPIDeploymentPodTemplateSpec)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentSpec.
-func (in *PacketCaptureAPIDeploymentSpec) DeepCopy() *PacketCaptureAPIDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusContainer.
+func (in *PrometheusContainer) DeepCopy() *PrometheusContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPIDeploymentSpec)
+	out := new(PrometheusContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPIList) DeepCopyInto(out *PacketCaptureAPIList) {
+func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PacketCaptureAPI, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.CommonPrometheusFields != nil {
+		in, out := &in.CommonPrometheusFields, &out.CommonPrometheusFields
+		*out = new(CommonPrometheusFields)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Thanos != nil {
+		in, out := &in.Thanos, &out.Thanos
+		*out = new(Thanos)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIList.
-func (in *PacketCaptureAPIList) DeepCopy() *PacketCaptureAPIList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSpec.
+func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPIList)
+	out := new(PrometheusSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PacketCaptureAPIList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPISpec) DeepCopyInto(out *PacketCaptureAPISpec) {
+func (in *RemoteWriteBasicAuth) DeepCopyInto(out *RemoteWriteBasicAuth) {
 	*out = *in
-	if in.PacketCaptureAPIDeployment != nil {
-		in, out := &in.PacketCaptureAPIDeployment, &out.PacketCaptureAPIDeployment
-		*out = new(PacketCaptureAPIDeployment)
-		(*in).DeepCopyInto(*out)
-	}
+	in.Username.DeepCopyInto(&out.Username)
+	in.Password.DeepCopyInto(&out.Password)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPISpec.
-func (in *PacketCaptureAPISpec) DeepCopy() *PacketCaptureAPISpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteBasicAuth.
+func (in *RemoteWriteBasicAuth) DeepCopy() *RemoteWriteBasicAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPISpec)
+	out := new(RemoteWriteBasicAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketCaptureAPIStatus) DeepCopyInto(out *PacketCaptureAPIStatus) {
+func (in *RemoteWriteSpec) DeepCopyInto(out *RemoteWriteSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.BearerTokenSecret != nil {
+		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(RemoteWriteBasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RemoteWriteTLS)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIStatus.
-func (in *PacketCaptureAPIStatus) DeepCopy() *PacketCaptureAPIStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteSpec.
+func (in *RemoteWriteSpec) DeepCopy() *RemoteWriteSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketCaptureAPIStatus)
+	out := new(RemoteWriteSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PathMatch) DeepCopyInto(out *PathMatch) {
+func (in *RemoteWriteTLS) DeepCopyInto(out *RemoteWriteTLS) {
 	*out = *in
-	if in.PathRegexp != nil {
-		in, out := &in.PathRegexp, &out.PathRegexp
-		*out = new(string)
-		**out = **in
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.PathReplace != nil {
-		in, out := &in.PathReplace, &out.PathReplace
-		*out = new(string)
-		**out = **in
+	if in.CertSecret != nil {
+		in, out := &in.CertSecret, &out.CertSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeySecret != nil {
+		in, out := &in.KeySecret, &out.KeySecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PathMatch.
-func (in *PathMatch) DeepCopy() *PathMatch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteTLS.
+func (in *RemoteWriteTLS) DeepCopy() *RemoteWriteTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(PathMatch)
+	out := new(RemoteWriteTLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendation) DeepCopyInto(out *PolicyRecommendation) {
+func (in *Retention) DeepCopyInto(out *Retention) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.Flows != nil {
+		in, out := &in.Flows, &out.Flows
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AuditReports != nil {
+		in, out := &in.AuditReports, &out.AuditReports
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ComplianceReports != nil {
+		in, out := &in.ComplianceReports, &out.ComplianceReports
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DNSLogs != nil {
+		in, out := &in.DNSLogs, &out.DNSLogs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BGPLogs != nil {
+		in, out := &in.BGPLogs, &out.BGPLogs
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendation.
-func (in *PolicyRecommendation) DeepCopy() *PolicyRecommendation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retention.
+func (in *Retention) DeepCopy() *Retention {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRecommendation)
+	out := new(Retention)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PolicyRecommendation) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendationDeployment) DeepCopyInto(out *PolicyRecommendationDeployment) {
+func (in *RetentionGuardrail) DeepCopyInto(out *RetentionGuardrail) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(PolicyRecommendationDeploymentSpec)
+	if in.MinimumRetention != nil {
+		in, out := &in.MinimumRetention, &out.MinimumRetention
+		*out = new(Retention)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeployment.
-func (in *PolicyRecommendationDeployment) DeepCopy() *PolicyRecommendationDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionGuardrail.
+func (in *RetentionGuardrail) DeepCopy() *RetentionGuardrail {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRecommendationDeployment)
+	out := new(RetentionGuardrail)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendationDeploymentContainer) DeepCopyInto(out *PolicyRecommendationDeploymentContainer) {
+func (in *RetentionGuardrailStatus) DeepCopyInto(out *RetentionGuardrailStatus) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
+	in.Retention.DeepCopyInto(&out.Retention)
+	in.LastChecked.DeepCopyInto(&out.LastChecked)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentContainer.
-func (in *PolicyRecommendationDeploymentContainer) DeepCopy() *PolicyRecommendationDeploymentContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionGuardrailStatus.
+func (in *RetentionGuardrailStatus) DeepCopy() *RetentionGuardrailStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRecommendationDeploymentContainer)
+	out := new(RetentionGuardrailStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendationDeploymentInitContainer) DeepCopyInto(out *PolicyRecommendationDeploymentInitContainer) {
+func (in *RollbackPolicy) DeepCopyInto(out *RollbackPolicy) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(RollbackMode)
+		**out = **in
+	}
+	if in.FailureWindowSeconds != nil {
+		in, out := &in.FailureWindowSeconds, &out.FailureWindowSeconds
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentInitContainer.
-func (in *PolicyRecommendationDeploymentInitContainer) DeepCopy() *PolicyRecommendationDeploymentInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackPolicy.
+func (in *RollbackPolicy) DeepCopy() *RollbackPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRecommendationDeploymentInitContainer)
+	out := new(RollbackPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendationDeploymentPodSpec) DeepCopyInto(out *PolicyRecommendationDeploymentPodSpec) {
+func (in *RolloutPolicy) DeepCopyInto(out *RolloutPolicy) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]PolicyRecommendationDeploymentInitContainer, len(*in))
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]RolloutWave, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]PolicyRecommendationDeploymentContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentPodSpec.
-func (in *PolicyRecommendationDeploymentPodSpec) DeepCopy() *PolicyRecommendationDeploymentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicy.
+func (in *RolloutPolicy) DeepCopy() *RolloutPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRecommendationDeploymentPodSpec)
+	out := new(RolloutPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendationDeploymentPodTemplateSpec) DeepCopyInto(out *PolicyRecommendationDeploymentPodTemplateSpec) {
+func (in *RolloutWave) DeepCopyInto(out *RolloutWave) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(PolicyRecommendationDeploymentPodSpec)
-		(*in).DeepCopyInto(*out)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentPodTemplateSpec.
-func (in *PolicyRecommendationDeploymentPodTemplateSpec) DeepCopy() *PolicyRecommendationDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutWave.
+func (in *RolloutWave) DeepCopy() *RolloutWave {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRecommendationDeploymentPodTemplateSpec)
+	out := new(RolloutWave)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendationDeploymentSpec) DeepCopyInto(out *PolicyRecommendationDeploymentSpec) {
+func (in *RuntimeSecurity) DeepCopyInto(out *RuntimeSecurity) {
 	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(PolicyRecommendationDeploymentPodTemplateSpec)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentSpec.
-func (in *PolicyRecommendationDeploymentSpec) DeepCopy() *PolicyRecommendationDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeSecurity.
+func (in *RuntimeSecurity) DeepCopy() *RuntimeSecurity {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRecommendationDeploymentSpec)
+	out := new(RuntimeSecurity)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RuntimeSecurity) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendationList) DeepCopyInto(out *PolicyRecommendationList) {
+func (in *RuntimeSecurityList) DeepCopyInto(out *RuntimeSecurityList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PolicyRecommendation, len(*in))
+		*out = make([]RuntimeSecurity, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationList.
-func (in *PolicyRecommendationList) DeepCopy() *PolicyRecommendationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeSecurityList.
+func (in *RuntimeSecurityList) DeepCopy() *RuntimeSecurityList {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRecommendationList)
+	out := new(RuntimeSecurityList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PolicyRecommendationList) DeepCopyObject() runtime.Object {
+func (in *RuntimeSecurityList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6311,171 +8930,115 @@ func (in *PolicyRecommendationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendationSpec) DeepCopyInto(out *PolicyRecommendationSpec) {
+func (in *RuntimeSecuritySpec) DeepCopyInto(out *RuntimeSecuritySpec) {
 	*out = *in
-	if in.PolicyRecommendationDeployment != nil {
-		in, out := &in.PolicyRecommendationDeployment, &out.PolicyRecommendationDeployment
-		*out = new(PolicyRecommendationDeployment)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationSpec.
-func (in *PolicyRecommendationSpec) DeepCopy() *PolicyRecommendationSpec {
-	if in == nil {
-		return nil
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	out := new(PolicyRecommendationSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRecommendationStatus) DeepCopyInto(out *PolicyRecommendationStatus) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationStatus.
-func (in *PolicyRecommendationStatus) DeepCopy() *PolicyRecommendationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeSecuritySpec.
+func (in *RuntimeSecuritySpec) DeepCopy() *RuntimeSecuritySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRecommendationStatus)
+	out := new(RuntimeSecuritySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Prometheus) DeepCopyInto(out *Prometheus) {
+func (in *RuntimeSecurityStatus) DeepCopyInto(out *RuntimeSecurityStatus) {
 	*out = *in
-	if in.PrometheusSpec != nil {
-		in, out := &in.PrometheusSpec, &out.PrometheusSpec
-		*out = new(PrometheusSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prometheus.
-func (in *Prometheus) DeepCopy() *Prometheus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeSecurityStatus.
+func (in *RuntimeSecurityStatus) DeepCopy() *RuntimeSecurityStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Prometheus)
+	out := new(RuntimeSecurityStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusContainer) DeepCopyInto(out *PrometheusContainer) {
+func (in *S3StoreSpec) DeepCopyInto(out *S3StoreSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusContainer.
-func (in *PrometheusContainer) DeepCopy() *PrometheusContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3StoreSpec.
+func (in *S3StoreSpec) DeepCopy() *S3StoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusContainer)
+	out := new(S3StoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
+func (in *SNIMatch) DeepCopyInto(out *SNIMatch) {
 	*out = *in
-	if in.CommonPrometheusFields != nil {
-		in, out := &in.CommonPrometheusFields, &out.CommonPrometheusFields
-		*out = new(CommonPrometheusFields)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSpec.
-func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNIMatch.
+func (in *SNIMatch) DeepCopy() *SNIMatch {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusSpec)
+	out := new(SNIMatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Retention) DeepCopyInto(out *Retention) {
+func (in *SecondaryElasticsearch) DeepCopyInto(out *SecondaryElasticsearch) {
 	*out = *in
-	if in.Flows != nil {
-		in, out := &in.Flows, &out.Flows
-		*out = new(int32)
-		**out = **in
-	}
-	if in.AuditReports != nil {
-		in, out := &in.AuditReports, &out.AuditReports
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Snapshots != nil {
-		in, out := &in.Snapshots, &out.Snapshots
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ComplianceReports != nil {
-		in, out := &in.ComplianceReports, &out.ComplianceReports
-		*out = new(int32)
-		**out = **in
-	}
-	if in.DNSLogs != nil {
-		in, out := &in.DNSLogs, &out.DNSLogs
-		*out = new(int32)
-		**out = **in
-	}
-	if in.BGPLogs != nil {
-		in, out := &in.BGPLogs, &out.BGPLogs
-		*out = new(int32)
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(SecondaryElasticsearchMode)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retention.
-func (in *Retention) DeepCopy() *Retention {
-	if in == nil {
-		return nil
-	}
-	out := new(Retention)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3StoreSpec) DeepCopyInto(out *S3StoreSpec) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3StoreSpec.
-func (in *S3StoreSpec) DeepCopy() *S3StoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondaryElasticsearch.
+func (in *SecondaryElasticsearch) DeepCopy() *SecondaryElasticsearch {
 	if in == nil {
 		return nil
 	}
-	out := new(S3StoreSpec)
+	out := new(SecondaryElasticsearch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SNIMatch) DeepCopyInto(out *SNIMatch) {
+func (in *ServiceMesh) DeepCopyInto(out *ServiceMesh) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNIMatch.
-func (in *SNIMatch) DeepCopy() *SNIMatch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMesh.
+func (in *ServiceMesh) DeepCopy() *ServiceMesh {
 	if in == nil {
 		return nil
 	}
-	out := new(SNIMatch)
+	out := new(ServiceMesh)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -6884,6 +9447,81 @@ func (in *TenantStatus) DeepCopy() *TenantStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Thanos) DeepCopyInto(out *Thanos) {
+	*out = *in
+	in.ObjectStorageConfig.DeepCopyInto(&out.ObjectStorageConfig)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Thanos.
+func (in *Thanos) DeepCopy() *Thanos {
+	if in == nil {
+		return nil
+	}
+	out := new(Thanos)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThreatFeedStatus) DeepCopyInto(out *ThreatFeedStatus) {
+	*out = *in
+	if in.LastSuccessfulSync != nil {
+		in, out := &in.LastSuccessfulSync, &out.LastSuccessfulSync
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThreatFeedStatus.
+func (in *ThreatFeedStatus) DeepCopy() *ThreatFeedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ThreatFeedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThreatFeedsSpec) DeepCopyInto(out *ThreatFeedsSpec) {
+	*out = *in
+	if in.PullInterval != nil {
+		in, out := &in.PullInterval, &out.PullInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PullTimeout != nil {
+		in, out := &in.PullTimeout, &out.PullTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThreatFeedsSpec.
+func (in *ThreatFeedsSpec) DeepCopy() *ThreatFeedsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ThreatFeedsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TigeraStatus) DeepCopyInto(out *TigeraStatus) {
 	*out = *in
@@ -6984,6 +9622,13 @@ func (in *TigeraStatusStatus) DeepCopyInto(out *TigeraStatusStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]TigeraStatusWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TigeraStatusStatus.
@@ -6996,6 +9641,25 @@ func (in *TigeraStatusStatus) DeepCopy() *TigeraStatusStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TigeraStatusWorkload) DeepCopyInto(out *TigeraStatusWorkload) {
+	*out = *in
+	if in.LastRolloutTime != nil {
+		in, out := &in.LastRolloutTime, &out.LastRolloutTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TigeraStatusWorkload.
+func (in *TigeraStatusWorkload) DeepCopy() *TigeraStatusWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(TigeraStatusWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TyphaAffinity) DeepCopyInto(out *TyphaAffinity) {
 	*out = *in
@@ -7016,6 +9680,26 @@ func (in *TyphaAffinity) DeepCopy() *TyphaAffinity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TyphaAutoscaling) DeepCopyInto(out *TyphaAutoscaling) {
+	*out = *in
+	if in.NodesToReplicas != nil {
+		in, out := &in.NodesToReplicas, &out.NodesToReplicas
+		*out = make([]TyphaScaleStep, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TyphaAutoscaling.
+func (in *TyphaAutoscaling) DeepCopy() *TyphaAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(TyphaAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TyphaDeployment) DeepCopyInto(out *TyphaDeployment) {
 	*out = *in
@@ -7049,6 +9733,7 @@ func (in *TyphaDeploymentContainer) DeepCopyInto(out *TyphaDeploymentContainer)
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.ContainerProbeOverrides.DeepCopyInto(&out.ContainerProbeOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TyphaDeploymentContainer.
@@ -7129,6 +9814,7 @@ func (in *TyphaDeploymentPodSpec) DeepCopyInto(out *TyphaDeploymentPodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.PodDNSOverrides.DeepCopyInto(&out.PodDNSOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TyphaDeploymentPodSpec.
@@ -7216,6 +9902,77 @@ func (in *TyphaDeploymentStrategy) DeepCopy() *TyphaDeploymentStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TyphaScaleStep) DeepCopyInto(out *TyphaScaleStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TyphaScaleStep.
+func (in *TyphaScaleStep) DeepCopy() *TyphaScaleStep {
+	if in == nil {
+		return nil
+	}
+	out := new(TyphaScaleStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeSnapshotStatus) DeepCopyInto(out *UpgradeSnapshotStatus) {
+	*out = *in
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+	if in.VolumeSnapshots != nil {
+		in, out := &in.VolumeSnapshots, &out.VolumeSnapshots
+		*out = make([]UpgradeVolumeSnapshot, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeSnapshotStatus.
+func (in *UpgradeSnapshotStatus) DeepCopy() *UpgradeSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeSnapshots) DeepCopyInto(out *UpgradeSnapshots) {
+	*out = *in
+	if in.Retain != nil {
+		in, out := &in.Retain, &out.Retain
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeSnapshots.
+func (in *UpgradeSnapshots) DeepCopy() *UpgradeSnapshots {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeSnapshots)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeVolumeSnapshot) DeepCopyInto(out *UpgradeVolumeSnapshot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeVolumeSnapshot.
+func (in *UpgradeVolumeSnapshot) DeepCopy() *UpgradeVolumeSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeVolumeSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserMatch) DeepCopyInto(out *UserMatch) {
 	*out = *in
@@ -7246,6 +10003,132 @@ func (in *UserSearch) DeepCopy() *UserSearch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFSettings) DeepCopyInto(out *WAFSettings) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Protocols != nil {
+		in, out := &in.Protocols, &out.Protocols
+		*out = make([]L7Protocol, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFSettings.
+func (in *WAFSettings) DeepCopy() *WAFSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Whisker) DeepCopyInto(out *Whisker) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Whisker.
+func (in *Whisker) DeepCopy() *Whisker {
+	if in == nil {
+		return nil
+	}
+	out := new(Whisker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Whisker) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhiskerList) DeepCopyInto(out *WhiskerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Whisker, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhiskerList.
+func (in *WhiskerList) DeepCopy() *WhiskerList {
+	if in == nil {
+		return nil
+	}
+	out := new(WhiskerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WhiskerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhiskerSpec) DeepCopyInto(out *WhiskerSpec) {
+	*out = *in
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(WhiskerNotificationMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhiskerSpec.
+func (in *WhiskerSpec) DeepCopy() *WhiskerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WhiskerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhiskerStatus) DeepCopyInto(out *WhiskerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhiskerStatus.
+func (in *WhiskerStatus) DeepCopy() *WhiskerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WhiskerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WindowsNodeSpec) DeepCopyInto(out *WindowsNodeSpec) {
 	*out = *in
@@ -7260,3 +10143,43 @@ func (in *WindowsNodeSpec) DeepCopy() *WindowsNodeSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WireGuard) DeepCopyInto(out *WireGuard) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WireGuard.
+func (in *WireGuard) DeepCopy() *WireGuard {
+	if in == nil {
+		return nil
+	}
+	out := new(WireGuard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WireGuardStatus) DeepCopyInto(out *WireGuardStatus) {
+	*out = *in
+	if in.NodesNotEncrypted != nil {
+		in, out := &in.NodesNotEncrypted, &out.NodesNotEncrypted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WireGuardStatus.
+func (in *WireGuardStatus) DeepCopy() *WireGuardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WireGuardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
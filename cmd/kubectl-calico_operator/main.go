@@ -0,0 +1,123 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// kubectl-calico_operator is a kubectl plugin, run as `kubectl calico-operator`, that summarizes the state of the
+// operator and runs the common operations of support cases.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/kubectlplugin"
+)
+
+const usage = `Usage: kubectl calico-operator [--kubeconfig <path>] [--operator-namespace <namespace>] <command> [flags]
+
+Commands:
+  status                          Summarize the status of each component and list paused resources.
+  degraded [-f]                   Show why components are degraded, and with -f keep watching for changes.
+  diagnostics [-o <dir>]          Have the operator collect a diagnostics bundle and write it to a directory.
+  pause <kind> [name] [-n <ns>]   Pause the reconciliation of an operator.tigera.io resource.
+  resume <kind> [name] [-n <ns>]  Resume the reconciliation of an operator.tigera.io resource.
+
+Global flags:
+`
+
+func main() {
+	// The global flags have their own flag set, so that the flags that dependencies register on the default one
+	// aren't offered by the plugin.
+	global := flag.NewFlagSet("kubectl calico-operator", flag.ExitOnError)
+	config.RegisterFlags(global)
+	operatorNamespace := global.String("operator-namespace", "tigera-operator", "namespace the operator runs in")
+	global.Usage = func() {
+		fmt.Fprint(global.Output(), usage)
+		global.PrintDefaults()
+	}
+	_ = global.Parse(os.Args[1:])
+	if global.NArg() == 0 {
+		global.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*operatorNamespace, global.Arg(0), global.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(operatorNamespace, command string, args []string) error {
+	switch command {
+	case "status", "degraded", "diagnostics", "pause", "resume":
+	default:
+		return fmt.Errorf("unknown command %q, run kubectl calico-operator -h for the list of commands", command)
+	}
+
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	follow := fs.Bool("f", false, "keep watching for changes to the degraded components")
+	outputDir := fs.String("o", fmt.Sprintf("calico-operator-diagnostics-%d", time.Now().Unix()), "directory to write the diagnostics bundle to")
+	timeout := fs.Duration("timeout", 5*time.Minute, "how long to wait for the operator to collect the diagnostics bundle")
+	namespace := fs.String("n", "", "namespace of the resource, for namespaced kinds")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	plugin, err := newPlugin()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	switch command {
+	case "status":
+		return plugin.Status(ctx)
+	case "degraded":
+		return plugin.Degraded(ctx, *follow)
+	case "diagnostics":
+		return plugin.CollectDiagnostics(ctx, operatorNamespace, *outputDir, *timeout)
+	case "pause", "resume":
+		if fs.NArg() == 0 || fs.NArg() > 2 {
+			return fmt.Errorf("%s requires a kind and optionally a name", command)
+		}
+		return plugin.SetPaused(ctx, fs.Arg(0), *namespace, fs.Arg(1), command == "pause")
+	}
+	return nil
+}
+
+func newPlugin() (*kubectlplugin.Plugin, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(operatorv1.AddToScheme(scheme))
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a client: %w", err)
+	}
+	return &kubectlplugin.Plugin{Client: c, Scheme: scheme, Out: os.Stdout, PollInterval: 2 * time.Second}, nil
+}
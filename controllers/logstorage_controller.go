@@ -18,18 +18,25 @@ package controllers
 
 import (
 	"github.com/go-logr/logr"
+	"github.com/tigera/operator/pkg/controller/logstorage/aliases"
 	"github.com/tigera/operator/pkg/controller/logstorage/dashboards"
 	"github.com/tigera/operator/pkg/controller/logstorage/esmetrics"
+	"github.com/tigera/operator/pkg/controller/logstorage/esroles"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/tigera/operator/pkg/controller/logstorage/elastic"
+	"github.com/tigera/operator/pkg/controller/logstorage/ilm"
 	"github.com/tigera/operator/pkg/controller/logstorage/initializer"
 	"github.com/tigera/operator/pkg/controller/logstorage/kubecontrollers"
 	"github.com/tigera/operator/pkg/controller/logstorage/linseed"
+	"github.com/tigera/operator/pkg/controller/logstorage/linseedtokens"
 	"github.com/tigera/operator/pkg/controller/logstorage/managedcluster"
+	"github.com/tigera/operator/pkg/controller/logstorage/readonlyusers"
+	"github.com/tigera/operator/pkg/controller/logstorage/restore"
 	"github.com/tigera/operator/pkg/controller/logstorage/secrets"
+	"github.com/tigera/operator/pkg/controller/logstorage/usage"
 	"github.com/tigera/operator/pkg/controller/logstorage/users"
 	"github.com/tigera/operator/pkg/controller/options"
 )
@@ -43,6 +50,9 @@ type LogStorageReconciler struct {
 
 // +kubebuilder:rbac:groups=operator.tigera.io,resources=logstorages,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=operator.tigera.io,resources=logstorages/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;create;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
 
 // SetupWithManager adds all of the relevant log storage sub-controllers to the controller manager.
 // Each of these controllers reconciles independently, but they work together in order to implement log storage
@@ -79,6 +89,13 @@ func (r *LogStorageReconciler) SetupWithManager(mgr ctrl.Manager, opts options.A
 		return err
 	}
 
+	// The ILM controller applies the ILM policies of the log indices to the Elasticsearch cluster that the elastic
+	// controller installs. It only reconciles when the retention or storage of the LogStorage, or the Elasticsearch
+	// cluster, changes.
+	if err := ilm.Add(mgr, opts); err != nil {
+		return err
+	}
+
 	// The ES metrics controller installs ES metrics into the cluster. It will only install ES metrics in a single-tenant
 	// management cluster.
 	if err := esmetrics.Add(mgr, opts); err != nil {
@@ -97,6 +114,40 @@ func (r *LogStorageReconciler) SetupWithManager(mgr ctrl.Manager, opts options.A
 		return err
 	}
 
+	// The usage controller periodically collects the size of the log indices, and exports it as metrics for chargeback.
+	if err := usage.Add(mgr, opts); err != nil {
+		return err
+	}
+
+	// The aliases controller periodically repairs the aliases that Linseed writes the logs through, which restores and
+	// manual index operations can leave missing or pointing at the wrong index.
+	if err := aliases.Add(mgr, opts); err != nil {
+		return err
+	}
+
+	// The restore controller revalidates and repairs the ILM bindings, aliases, users and index settings after
+	// Elasticsearch is restored from a snapshot, when requested through an annotation on the LogStorage.
+	if err := restore.Add(mgr, opts); err != nil {
+		return err
+	}
+
+	// The read-only users controller provisions the read-only Elasticsearch users configured in the LogStorage, in
+	// single-tenant mode only.
+	if err := readonlyusers.Add(mgr, opts); err != nil {
+		return err
+	}
+
+	// The Linseed tokens controller issues the Linseed tokens configured in the LogStorage for third-party consumers,
+	// in single-tenant mode only.
+	if err := linseedtokens.Add(mgr, opts); err != nil {
+		return err
+	}
+
+	// The ESRoles controller syncs the ESRoles to Elasticsearch roles and role mappings, in single-tenant mode only.
+	if err := esroles.Add(mgr, opts); err != nil {
+		return err
+	}
+
 	// The users controller runs in multi-tenant mode only, and is responsible for generating unique credentials for each Linseed instance
 	// and provisioning users into Elasticsearch for them to use.
 	if err := users.Add(mgr, opts); err != nil {
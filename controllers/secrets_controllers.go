@@ -43,5 +43,11 @@ func (r *SecretsReconciler) SetupWithManager(mgr ctrl.Manager, opts options.AddO
 	if err := secrets.AddTenantController(mgr, opts); err != nil {
 		return err
 	}
+	if err := secrets.AddPullSecretController(mgr, opts); err != nil {
+		return err
+	}
+	if err := secrets.AddSecretMirrorController(mgr, opts); err != nil {
+		return err
+	}
 	return nil
 }
@@ -146,6 +146,27 @@ var (
 		Image:    "{{ .Image }}",
 		Registry: "{{ .Registry }}",
 	}
+{{- end }}
+{{ with index .Components "calico/goldmane"}}
+	ComponentCalicoGoldmane = component{
+		Version:  "{{ .Version }}",
+		Image:    "{{ .Image }}",
+		Registry: "{{ .Registry }}",
+	}
+{{- end }}
+{{ with index .Components "calico/whisker"}}
+	ComponentCalicoWhisker = component{
+		Version:  "{{ .Version }}",
+		Image:    "{{ .Image }}",
+		Registry: "{{ .Registry }}",
+	}
+{{- end }}
+{{ with index .Components "calico/whisker-backend"}}
+	ComponentCalicoWhiskerBackend = component{
+		Version:  "{{ .Version }}",
+		Image:    "{{ .Image }}",
+		Registry: "{{ .Registry }}",
+	}
 {{- end }}
 	ComponentOperatorInit = component{
 		Version: version.VERSION,
@@ -172,5 +193,8 @@ var (
 		ComponentCalicoCSIFIPS,
 		ComponentCalicoCSIRegistrar,
 		ComponentCalicoCSIRegistrarFIPS,
+		ComponentCalicoGoldmane,
+		ComponentCalicoWhisker,
+		ComponentCalicoWhiskerBackend,
 	}
 )
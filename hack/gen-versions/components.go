@@ -34,6 +34,9 @@ var defaultImages = map[string]string{
 	"flexvol":                    "calico/pod2daemon-flexvol",
 	"calico/csi":                 "calico/csi",
 	"csi-node-driver-registrar":  "calico/node-driver-registrar",
+	"calico/goldmane":            "calico/goldmane",
+	"calico/whisker":             "calico/whisker",
+	"calico/whisker-backend":     "calico/whisker-backend",
 	"typha":                      "calico/typha",
 	"key-cert-provisioner":       "calico/key-cert-provisioner",
 	"eck-elasticsearch":          "unused/image",
@@ -219,6 +219,13 @@ var (
 		Registry: "{{ .Registry }}",
 	}
 {{- end }}
+{{ with index .Components "runtime-security" }}
+	ComponentRuntimeSecurity = component{
+		Version:  "{{ .Version }}",
+		Image:    "{{ .Image }}",
+		Registry: "{{ .Registry }}",
+	}
+{{- end }}
 {{ with index .Components "egress-gateway" }}
 	ComponentEgressGateway = component{
 		Version:  "{{ .Version }}",
@@ -280,6 +287,13 @@ var (
 		Registry: "{{ .Registry }}",
 	}
 {{- end }}
+{{ with index .Components "thanos" }}
+	ComponentThanos = component{
+		Version:  "{{ .Version }}",
+		Image:    "{{ .Image }}",
+		Registry: "{{ .Registry }}",
+	}
+{{- end }}
 {{ with index .Components "cnx-queryserver" }}
 	ComponentQueryServer = component{
 		Version:  "{{ .Version }}",
@@ -391,12 +405,14 @@ var (
 		ComponentManagerProxy,
 		ComponentPacketCapture,
 		ComponentPolicyRecommendation,
+		ComponentRuntimeSecurity,
 		ComponentEgressGateway,
 		ComponentL7Collector,
 		ComponentEnvoyProxy,
 		ComponentPrometheus,
 		ComponentTigeraPrometheusService,
 		ComponentPrometheusAlertmanager,
+		ComponentThanos,
 		ComponentQueryServer,
 		ComponentTigeraKubeControllers,
 		ComponentTigeraNode,
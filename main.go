@@ -33,19 +33,23 @@ import (
 	"github.com/tigera/operator/pkg/active"
 	"github.com/tigera/operator/pkg/apis"
 	"github.com/tigera/operator/pkg/awssgsetup"
+	"github.com/tigera/operator/pkg/backup"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/crds"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/preflight"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/intrusiondetection/dpi"
 	"github.com/tigera/operator/pkg/render/logstorage"
 	"github.com/tigera/operator/pkg/render/logstorage/eck"
+	"github.com/tigera/operator/pkg/telemetry"
 	"github.com/tigera/operator/version"
 
+	uzap "go.uber.org/zap"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -62,6 +66,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/yaml"
 	// +kubebuilder:scaffold:imports
 )
@@ -104,6 +109,14 @@ func main() {
 	var sgSetup bool
 	var manageCRDs bool
 	var preDelete bool
+	var backupPath string
+	var restorePath string
+	var clusterDomain string
+	var enableWhisker bool
+	var runPreflight string
+	var printPreflightJob bool
+	var elasticsearchBackend string
+	var allowInsecureSkipTLSVerify bool
 
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", true,
 		"Enable leader election for controller manager. "+
@@ -124,12 +137,29 @@ func main() {
 		"Operator should manage the projectcalico.org and operator.tigera.io CRDs.")
 	flag.BoolVar(&preDelete, "pre-delete", false,
 		"Run helm pre-deletion hook logic, then exit.")
+	flag.StringVar(&backupPath, "backup", "",
+		"Export the operator custom resources and generated secrets to an archive at the given path, then exit.")
+	flag.StringVar(&restorePath, "restore", "",
+		"Import the operator custom resources and generated secrets from an archive created by --backup, then exit.")
+	flag.StringVar(&clusterDomain, "cluster-domain", "",
+		"The DNS domain of the cluster. If not set, it is detected from the operator's resolv.conf or the cluster DNS configuration.")
+	flag.BoolVar(&enableWhisker, "enable-whisker", false,
+		"Feature gate for Whisker. Enables the controller that installs Whisker and Goldmane from the Whisker resource.")
+	flag.StringVar(&runPreflight, "preflight", "",
+		"Check that the cluster meets the prerequisites of its configuration, print a report then exit. Exits non-zero if a check fails. Possible values: json, text")
+	flag.BoolVar(&printPreflightJob, "print-preflight-job", false,
+		"Print the manifests of a Job that runs --preflight in the cluster, then exit.")
+	flag.StringVar(&elasticsearchBackend, "elasticsearch-backend", utils.DefaultElasticsearchBackend,
+		"The registered Elasticsearch backend used to create Elasticsearch clients. Possible values: "+strings.Join(utils.ElasticsearchBackends(), ", "))
+	flag.BoolVar(&allowInsecureSkipTLSVerify, "allow-insecure-skip-tls-verify", false,
+		"Feature gate for insecureSkipTLSVerify. Lets Tenants disable the verification of the certificate of their external Elasticsearch cluster, for lab environments only.")
 
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.WriteTo(os.Stdout), zap.UseFlagOptions(&opts)))
+	// Logs are also teed into the OpenTelemetry logger provider, which only exports them once telemetry is started below.
+	ctrl.SetLogger(zap.New(zap.WriteTo(os.Stdout), zap.UseFlagOptions(&opts), zap.RawZapOpts(uzap.WrapCore(telemetry.WrapCore))))
 
 	if showVersion {
 		// If the following line is updated then it might be necessary to update the release-verify target in the Makefile
@@ -168,6 +198,19 @@ func main() {
 		os.Exit(0)
 	}
 
+	if runPreflight != "" && runPreflight != "json" && runPreflight != "text" {
+		fmt.Println("Invalid option for --preflight flag", runPreflight)
+		os.Exit(1)
+	}
+
+	if printPreflightJob {
+		if err := showPreflightJob(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if urlOnlyKubeconfig != "" {
 		if err := setKubernetesServiceEnv(urlOnlyKubeconfig); err != nil {
 			setupLog.Error(err, "Terminating")
@@ -226,6 +269,34 @@ func main() {
 		os.Exit(0)
 	}
 
+	if runPreflight != "" {
+		passed, err := executePreflight(ctx, c, cs, runPreflight)
+		if err != nil {
+			log.Error(err, "Failed to run preflight checks")
+			os.Exit(1)
+		}
+		if !passed {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if backupPath != "" {
+		if err := executeBackup(ctx, c, backupPath); err != nil {
+			log.Error(err, "Failed to back up operator configuration")
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if restorePath != "" {
+		if err := executeRestore(ctx, c, restorePath); err != nil {
+			log.Error(err, "Failed to restore operator configuration")
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// sigHandler is a context that is canceled when we receive a termination
 	// signal. We don't want to immeditely terminate upon receipt of such a signal since
 	// there may be cleanup required. So, we will pass a separate context to our controllers.
@@ -371,11 +442,14 @@ func main() {
 	}
 	setupLog.WithValues("required", enterpriseCRDExists).Info("Checking if TSEE controllers are required")
 
-	clusterDomain, err := dns.GetClusterDomain(dns.DefaultResolveConfPath)
-	if err != nil {
-		clusterDomain = dns.DefaultClusterDomain
-		log.Error(err, fmt.Sprintf("Couldn't find the cluster domain from the resolv.conf, defaulting to %s", clusterDomain))
+	if clusterDomain == "" {
+		clusterDomain, err = dns.DetectClusterDomain(ctx, clientset, dns.DefaultResolveConfPath)
+		if err != nil {
+			clusterDomain = dns.DefaultClusterDomain
+			log.Error(err, fmt.Sprintf("Couldn't detect the cluster domain, defaulting to %s", clusterDomain))
+		}
 	}
+	setupLog.WithValues("clusterDomain", clusterDomain).Info("Checking cluster domain")
 
 	kubernetesVersion, err := common.GetKubernetesVersion(clientset)
 	if err != nil {
@@ -420,15 +494,36 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Export the operator's logs, metrics and traces over OTLP, if configured. Failing to do so shouldn't stop the
+	// operator from running.
+	telemetryConfig, err := telemetry.ConfigFromBootstrap(ctx, clientset, bootConfig)
+	if err != nil {
+		setupLog.Error(err, "Invalid telemetry configuration, telemetry will not be exported")
+	} else if telemetryConfig != nil {
+		shutdown, err := telemetry.Start(ctx, telemetryConfig, metrics.Registry)
+		if err != nil {
+			setupLog.Error(err, "Failed to start telemetry export")
+		} else {
+			setupLog.WithValues("endpoint", telemetryConfig.Endpoint).Info("Exporting telemetry over OTLP")
+			defer func() {
+				if err := shutdown(context.Background()); err != nil {
+					setupLog.Error(err, "Failed to flush telemetry")
+				}
+			}()
+		}
+	}
+
 	options := options.AddOptions{
-		DetectedProvider:    provider,
-		EnterpriseCRDExists: enterpriseCRDExists,
-		ClusterDomain:       clusterDomain,
-		KubernetesVersion:   kubernetesVersion,
-		ManageCRDs:          manageCRDs,
-		ShutdownContext:     ctx,
-		MultiTenant:         multiTenant,
-		ElasticExternal:     utils.UseExternalElastic(bootConfig),
+		DetectedProvider:     provider,
+		EnterpriseCRDExists:  enterpriseCRDExists,
+		ClusterDomain:        clusterDomain,
+		KubernetesVersion:    kubernetesVersion,
+		ManageCRDs:           manageCRDs,
+		ShutdownContext:      ctx,
+		MultiTenant:          multiTenant,
+		ElasticExternal:      utils.UseExternalElastic(bootConfig),
+		ElasticsearchBackend: elasticsearchBackend,
+		WhiskerEnabled:       enableWhisker,
 	}
 
 	// Before we start any controllers, make sure our options are valid.
@@ -437,6 +532,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if allowInsecureSkipTLSVerify {
+		setupLog.Info("WARNING: the insecureSkipTLSVerify feature gate is enabled, so Tenants may disable the verification of the certificate of their external Elasticsearch cluster. Do not enable it in production.")
+	}
+	utils.AllowInsecureSkipTLSVerify(allowInsecureSkipTLSVerify)
+
+	// Only render the objects of optional APIs, such as SecurityContextConstraints and the monitoring.coreos.com
+	// APIs, when the cluster serves them.
+	utils.SetCapabilities(render.NewCapabilities(clientset.Discovery()))
+
 	err = controllers.AddToManager(mgr, options)
 	if err != nil {
 		setupLog.Error(err, "unable to create controllers")
@@ -526,6 +630,62 @@ func showCRDs(variant operatorv1.ProductVariant, outputType string) error {
 	return nil
 }
 
+func showPreflightJob() error {
+	component := render.Preflight(&render.PreflightConfiguration{Installation: &operatorv1.InstallationSpec{}})
+	if err := component.ResolveImages(nil); err != nil {
+		return err
+	}
+	objs, _ := component.Objects()
+	for i, obj := range objs {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("Failed to Marshal %s: %v", obj.GetName(), err)
+		}
+		if i > 0 {
+			fmt.Println("---")
+		}
+		fmt.Println(string(b))
+	}
+	return nil
+}
+
+// executePreflight runs the preflight checks and prints the report to stdout. It returns whether all checks passed.
+func executePreflight(ctx context.Context, c client.Client, cs kubernetes.Interface, format string) (bool, error) {
+	bootConfig, err := cs.CoreV1().ConfigMaps(common.OperatorNamespace()).Get(ctx, bootstrapConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		bootConfig = nil
+	}
+	report := preflight.Run(ctx, c, preflight.Config{ElasticExternal: utils.UseExternalElastic(bootConfig)})
+	if err = report.Write(os.Stdout, format); err != nil {
+		return false, err
+	}
+	return report.Passed, nil
+}
+
+func executeBackup(ctx context.Context, c client.Client, path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err = backup.Write(ctx, c, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func executeRestore(ctx context.Context, c client.Client, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return backup.Restore(ctx, c, f)
+}
+
 func executePreDeleteHook(ctx context.Context, c client.Client) error {
 	defer log.Info("preDelete hook exiting")
 
@@ -563,6 +723,9 @@ func executePreDeleteHook(ctx context.Context, c client.Client) error {
 
 // verifyConfiguration verifies that the final configuration of the operator is correct before starting any controllers.
 func verifyConfiguration(ctx context.Context, cs kubernetes.Interface, opts options.AddOptions) error {
+	if _, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend); err != nil {
+		return err
+	}
 	if opts.ElasticExternal {
 		// There should not be an internal-es cert
 		if _, err := cs.CoreV1().Secrets(render.ElasticsearchNamespace).Get(ctx, render.TigeraElasticsearchInternalCertSecret, metav1.GetOptions{}); err != nil {
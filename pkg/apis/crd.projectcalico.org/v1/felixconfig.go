@@ -395,6 +395,17 @@ type FelixConfigurationSpec struct {
 	// `[fd00:83a6::12]:5353`.Note that Felix (calico-node) will need RBAC permission to read the details of
 	// each service specified by a `k8s-service:...` form. [Default: "k8s-service:kube-dns"].
 	DNSTrustedServers *[]string `json:"dnsTrustedServers,omitempty"`
+
+	// FlowLogsFlushInterval configures the interval at which Felix exports flow logs. [Default: 300s]
+	FlowLogsFlushInterval *metav1.Duration `json:"flowLogsFlushInterval,omitempty" configv1timescale:"seconds"`
+	// FlowLogsFileMaxFileSizeMB sets the max size in MB of flow logs files before rotation. [Default: 100]
+	FlowLogsFileMaxFileSizeMB *int `json:"flowLogsFileMaxFileSizeMB,omitempty"`
+	// FlowLogsFileAggregationKindForAllowed is used to choose the type of aggregation for flow log entries created for
+	// allowed connections. [Default: 2 - pod prefix name based aggregation].
+	FlowLogsFileAggregationKindForAllowed *int `json:"flowLogsFileAggregationKindForAllowed,omitempty" validate:"omitempty,flowLogAggregationKind"`
+	// FlowLogsFileAggregationKindForDenied is used to choose the type of aggregation for flow log entries created for
+	// denied connections. [Default: 1 - source port based aggregation].
+	FlowLogsFileAggregationKindForDenied *int `json:"flowLogsFileAggregationKindForDenied,omitempty" validate:"omitempty,flowLogAggregationKind"`
 }
 
 type RouteTableRange struct {
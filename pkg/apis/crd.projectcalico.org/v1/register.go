@@ -44,6 +44,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&BGPConfigurationList{},
 		&ExternalNetwork{},
 		&ExternalNetworkList{},
+		&IPAMBlock{},
+		&IPAMBlockList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
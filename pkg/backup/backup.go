@@ -0,0 +1,247 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup exports the configuration state managed by the operator to an archive, and imports it into a
+// replacement cluster for disaster recovery.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	logstoragecommon "github.com/tigera/operator/pkg/controller/logstorage/common"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+var log = logf.Log.WithName("backup")
+
+// Write exports the operator's configuration state to w as a gzipped tar archive with one YAML file per object. The
+// archive contains:
+//   - All operator.tigera.io custom resources.
+//   - The operator CA, so that certificates issued in the replacement cluster are trusted by existing clients.
+//   - The Elasticsearch user secrets, so that users in retained Elasticsearch data keep working.
+//   - The Elasticsearch cluster configuration. ILM policies are programmed from the LogStorage, so restoring the
+//     LogStorage restores them.
+func Write(ctx context.Context, c client.Client, w io.Writer) error {
+	objs, err := collect(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", entryName(obj), err)
+		}
+		hdr := &tar.Header{Name: entryName(obj), Mode: 0600, Size: int64(len(data))}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err = tw.Write(data); err != nil {
+			return err
+		}
+		log.Info("Exported object", "name", hdr.Name)
+	}
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// Restore imports the objects in an archive created by Write. Objects that already exist are overwritten. Secrets and
+// config maps are restored before the custom resources, so that the operator picks them up instead of generating new
+// ones when it reconciles the restored custom resources.
+func Restore(ctx context.Context, c client.Client, r io.Reader) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	var objs []*unstructured.Unstructured
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		obj := &unstructured.Unstructured{}
+		if err = yaml.Unmarshal(data, &obj.Object); err != nil {
+			return fmt.Errorf("failed to unmarshal %s: %w", hdr.Name, err)
+		}
+		objs = append(objs, obj)
+	}
+
+	sort.SliceStable(objs, func(i, j int) bool {
+		return objs[i].GroupVersionKind().Group == "" && objs[j].GroupVersionKind().Group != ""
+	})
+	for _, obj := range objs {
+		if err = restoreObject(ctx, c, obj); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", entryName(obj), err)
+		}
+		log.Info("Restored object", "name", entryName(obj))
+	}
+	return nil
+}
+
+// collect returns the objects to export.
+func collect(ctx context.Context, c client.Client) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, gvk := range operatorKinds(c.Scheme()) {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				// The CRD is not installed, e.g. an enterprise only resource on a Calico cluster.
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(common.OperatorNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for i := range secrets.Items {
+		s := &secrets.Items[i]
+		if s.Name != certificatemanagement.CASecretName && s.Labels[logstoragecommon.TigeraElasticsearchUserSecretLabel] == "" {
+			continue
+		}
+		obj, err := toUnstructured(s, "Secret")
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := c.Get(ctx, client.ObjectKey{Name: relasticsearch.ClusterConfigConfigMapName, Namespace: common.OperatorNamespace()}, cm)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get the Elasticsearch cluster configuration: %w", err)
+	} else if err == nil {
+		obj, err := toUnstructured(cm, "ConfigMap")
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+
+	for _, obj := range objs {
+		clean(obj)
+	}
+	return objs, nil
+}
+
+// operatorKinds returns the kinds in the operator.tigera.io API group that can be listed, sorted by name.
+func operatorKinds(scheme *runtime.Scheme) []schema.GroupVersionKind {
+	var kinds []schema.GroupVersionKind
+	for gvk := range scheme.AllKnownTypes() {
+		if gvk.GroupVersion() != operatorv1.GroupVersion || !strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		kind := gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List"))
+		if scheme.Recognizes(kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].Kind < kinds[j].Kind })
+	return kinds
+}
+
+func toUnstructured(obj runtime.Object, kind string) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind))
+	return u, nil
+}
+
+// clean removes the fields that are specific to the source cluster. Owner references are removed since the UIDs of
+// the owners change in the replacement cluster; the operator sets them again when it reconciles.
+func clean(obj *unstructured.Unstructured) {
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+	obj.SetOwnerReferences(nil)
+	obj.SetDeletionTimestamp(nil)
+	obj.SetFinalizers(nil)
+	unstructured.RemoveNestedField(obj.Object, "status")
+}
+
+func restoreObject(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	clean(obj)
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if kerrors.IsNotFound(err) {
+		return c.Create(ctx, obj)
+	} else if err != nil {
+		return err
+	}
+	obj.SetResourceVersion(current.GetResourceVersion())
+	obj.SetOwnerReferences(current.GetOwnerReferences())
+	obj.SetFinalizers(current.GetFinalizers())
+	return c.Update(ctx, obj)
+}
+
+// entryName returns the name of the archive entry for the object, e.g. operator.tigera.io/Installation/default.yaml.
+func entryName(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	group := gvk.Group
+	if group == "" {
+		group = "core"
+	}
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+	return path.Join(group, gvk.Kind, name+".yaml")
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup_test
+
+import (
+	"bytes"
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/backup"
+	"github.com/tigera/operator/pkg/common"
+	logstoragecommon "github.com/tigera/operator/pkg/controller/logstorage/common"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+var _ = Describe("Backup and restore", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		src    client.Client
+		dst    client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		src = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		dst = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		Expect(src.Create(ctx, &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Finalizers: []string{"tigera.io/operator-cleanup"}},
+			Spec:       operatorv1.InstallationSpec{Variant: operatorv1.TigeraSecureEnterprise, Registry: "my-registry.io/"},
+		})).ShouldNot(HaveOccurred())
+		Expect(src.Create(ctx, &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec:       operatorv1.LogStorageSpec{StorageClassName: "my-storage-class"},
+		})).ShouldNot(HaveOccurred())
+		Expect(src.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            certificatemanagement.CASecretName,
+				Namespace:       common.OperatorNamespace(),
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "operator.tigera.io/v1", Kind: "Installation", Name: "default", UID: "1234"}},
+			},
+			Data: map[string][]byte{corev1.TLSCertKey: []byte("ca-cert")},
+		})).ShouldNot(HaveOccurred())
+		Expect(src.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tigera-ee-linseed-elasticsearch-user-secret",
+				Namespace: common.OperatorNamespace(),
+				Labels:    map[string]string{logstoragecommon.TigeraElasticsearchUserSecretLabel: "true"},
+			},
+			Data: map[string][]byte{"password": []byte("secret")},
+		})).ShouldNot(HaveOccurred())
+		Expect(src.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: common.OperatorNamespace()},
+		})).ShouldNot(HaveOccurred())
+	})
+
+	roundTrip := func() {
+		var buf bytes.Buffer
+		Expect(backup.Write(ctx, src, &buf)).ShouldNot(HaveOccurred())
+		Expect(backup.Restore(ctx, dst, &buf)).ShouldNot(HaveOccurred())
+	}
+
+	It("should restore the custom resources and generated secrets", func() {
+		roundTrip()
+
+		install := &operatorv1.Installation{}
+		Expect(dst.Get(ctx, client.ObjectKey{Name: "default"}, install)).ShouldNot(HaveOccurred())
+		Expect(install.Spec.Registry).To(Equal("my-registry.io/"))
+		Expect(install.Finalizers).To(BeEmpty())
+
+		ls := &operatorv1.LogStorage{}
+		Expect(dst.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, ls)).ShouldNot(HaveOccurred())
+		Expect(ls.Spec.StorageClassName).To(Equal("my-storage-class"))
+
+		ca := &corev1.Secret{}
+		Expect(dst.Get(ctx, client.ObjectKey{Name: certificatemanagement.CASecretName, Namespace: common.OperatorNamespace()}, ca)).ShouldNot(HaveOccurred())
+		Expect(ca.Data).To(Equal(map[string][]byte{corev1.TLSCertKey: []byte("ca-cert")}))
+		Expect(ca.OwnerReferences).To(BeEmpty())
+
+		user := &corev1.Secret{}
+		Expect(dst.Get(ctx, client.ObjectKey{Name: "tigera-ee-linseed-elasticsearch-user-secret", Namespace: common.OperatorNamespace()}, user)).ShouldNot(HaveOccurred())
+		Expect(user.Data).To(Equal(map[string][]byte{"password": []byte("secret")}))
+
+		err := dst.Get(ctx, client.ObjectKey{Name: "unrelated", Namespace: common.OperatorNamespace()}, &corev1.Secret{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should overwrite objects that already exist", func() {
+		Expect(dst.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: certificatemanagement.CASecretName, Namespace: common.OperatorNamespace()},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("new-ca-cert")},
+		})).ShouldNot(HaveOccurred())
+
+		roundTrip()
+
+		ca := &corev1.Secret{}
+		Expect(dst.Get(ctx, client.ObjectKey{Name: certificatemanagement.CASecretName, Namespace: common.OperatorNamespace()}, ca)).ShouldNot(HaveOccurred())
+		Expect(ca.Data).To(Equal(map[string][]byte{corev1.TLSCertKey: []byte("ca-cert")}))
+	})
+})
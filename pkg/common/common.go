@@ -41,4 +41,73 @@ const (
 	// references with any that already exist on the object rather than replace the owner references. Further
 	// the controller in the owner reference will not be set.
 	MultipleOwnersLabel = "operator.tigera.io/multipleOwners"
+
+	// StagedRolloutAnnotation is set on DaemonSets whose pods are updated by the rollout controller in waves, as
+	// configured by the Installation RolloutPolicy, rather than by their rolling update strategy. It is set when the
+	// operator renders a new pod template for the DaemonSet, and removed by the rollout controller once all of the pods
+	// are updated.
+	StagedRolloutAnnotation = "operator.tigera.io/staged-rollout"
+
+	// StagedRolloutTemplateHashAnnotation is the hash of the pod template last rendered for a DaemonSet with a staged
+	// rollout, so that a staged rollout only starts when the pod template changes.
+	StagedRolloutTemplateHashAnnotation = "operator.tigera.io/staged-rollout-template-hash"
+
+	// RolloutRestartsBaselineAnnotation is set by the rollout controller on DaemonSets with a staged rollout. It
+	// records the revision being rolled out and the restart counts of its pods when the rollout started, so that only
+	// the restarts since then count towards the MaxRestarts of the RolloutPolicy.
+	RolloutRestartsBaselineAnnotation = "operator.tigera.io/rollout-restarts-baseline"
+
+	// CollectDiagnosticsAnnotation is set on the Installation to request a diagnostics bundle for a support case. The
+	// bundle is collected once for each value of the annotation, so setting a new value requests a new bundle.
+	CollectDiagnosticsAnnotation = "operator.tigera.io/collect-diagnostics"
+
+	// PostRestoreReconcileAnnotation is set on the LogStorage after restoring Elasticsearch from a snapshot, to have the
+	// operator check and repair the Elasticsearch state that it expects. The check runs once for each value of the
+	// annotation.
+	PostRestoreReconcileAnnotation = "operator.tigera.io/post-restore-reconcile"
+
+	// The following annotations are set on the Deployments and DaemonSets managed by the operator when the
+	// Installation has a RollbackPolicy. RenderedTemplateHashAnnotation is the hash of the pod template rendered by the
+	// operator and RolloutStartedAnnotation the time at which the operator started rolling it out.
+	// LastKnownGoodTemplateHashAnnotation is the hash of the last pod template that was fully rolled out and ready,
+	// which is stored in a ConfigMap next to the workload, and RolledBackFromAnnotation the hash of the rendered pod
+	// template that was rolled back from it.
+	RenderedTemplateHashAnnotation      = "operator.tigera.io/rendered-template-hash"
+	RolloutStartedAnnotation            = "operator.tigera.io/rollout-started"
+	LastKnownGoodTemplateHashAnnotation = "operator.tigera.io/last-known-good-template-hash"
+	RolledBackFromAnnotation            = "operator.tigera.io/rolled-back-from"
+
+	// RollbackAnnotation is set to "true" on a Deployment or DaemonSet managed by the operator to roll it back to its
+	// last-known-good pod template.
+	RollbackAnnotation = "operator.tigera.io/rollback"
+
+	// ManagedByLabel is set to ManagedByValue on the IP pools managed by the operator, and on the existing resources
+	// that the operator adopted when the Installation enables ResourceAdoption. ManagedByValue is also the field
+	// manager with which adopted resources are server-side applied.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "tigera-operator"
+
+	// The following labels are set on every object rendered by the operator, to record which component rendered it,
+	// the kind, name and namespace of the custom resource that owns it and the version of the operator that last
+	// reconciled it. The owner labels are not set on objects without an owner, or with multiple owners.
+	ComponentLabel       = "operator.tigera.io/component"
+	OwnerKindLabel       = "operator.tigera.io/owner-kind"
+	OwnerNameLabel       = "operator.tigera.io/owner-name"
+	OwnerNamespaceLabel  = "operator.tigera.io/owner-namespace"
+	OperatorVersionLabel = "operator.tigera.io/version"
+
+	// KeepOnDeleteAnnotation is set to "true" on an object rendered by the operator to keep it when the operator
+	// tears down the component that rendered it. The operator also removes its owner reference from the object, so
+	// that it isn't garbage collected when its owner is deleted.
+	KeepOnDeleteAnnotation = "operator.tigera.io/keep-on-delete"
+
+	// ReconcileAnnotation is set to ReconcilePaused on any operator.tigera.io custom resource to pause the controllers
+	// that reconcile it, for example while responding to an incident. The controllers return as soon as they have
+	// found the custom resource, so they no longer default it, render its components or make any other change to the
+	// cluster until the annotation is removed. They only report the component as paused in its TigeraStatus and copy
+	// the conditions of the TigeraStatus to the status of the custom resource. The controllers that don't reconcile a
+	// custom resource of their own, such as those that approve CSRs, mirror pull secrets and other secrets, create the
+	// tiers, collect diagnostics bundles or report the usage of the LogStorage, keep running.
+	ReconcileAnnotation = "operator.tigera.io/reconcile"
+	ReconcilePaused     = "paused"
 )
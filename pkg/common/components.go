@@ -54,3 +54,9 @@ func MergeOwnerReferences(desired, current []metav1.OwnerReference) []metav1.Own
 	}
 	return refList
 }
+
+// IsReconcilePaused returns true if the reconciliation of the given custom resource is paused by the
+// ReconcileAnnotation.
+func IsReconcilePaused(obj metav1.Object) bool {
+	return obj != nil && obj.GetAnnotations()[ReconcileAnnotation] == ReconcilePaused
+}
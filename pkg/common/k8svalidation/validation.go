@@ -362,6 +362,55 @@ func ValidateRollingUpdateDeployment(rollingUpdate *appsv1.RollingUpdateDeployme
 	return allErrs
 }
 
+// ValidateDaemonSetUpdateStrategy validates given DaemonSetUpdateStrategy.
+func ValidateDaemonSetUpdateStrategy(strategy *appsv1.DaemonSetUpdateStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch strategy.Type {
+	case appsv1.OnDeleteDaemonSetStrategyType:
+	case appsv1.RollingUpdateDaemonSetStrategyType:
+		if strategy.RollingUpdate == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("rollingUpdate"), ""))
+		} else {
+			allErrs = append(allErrs, ValidateRollingUpdateDaemonSet(strategy.RollingUpdate, fldPath.Child("rollingUpdate"))...)
+		}
+	default:
+		validValues := []string{string(appsv1.RollingUpdateDaemonSetStrategyType), string(appsv1.OnDeleteDaemonSetStrategyType)}
+		allErrs = append(allErrs, field.NotSupported(fldPath, strategy, validValues))
+	}
+	return allErrs
+}
+
+// ValidateRollingUpdateDaemonSet validates a given RollingUpdateDaemonSet.
+func ValidateRollingUpdateDaemonSet(rollingUpdate *appsv1.RollingUpdateDaemonSet, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Swap nils for the values the API server defaults them to, so that the result matches the validation of the
+	// defaulted DaemonSet.
+	maxUnavailable, maxSurge := intstr.FromInt(1), intstr.FromInt(0)
+	if rollingUpdate.MaxUnavailable != nil {
+		maxUnavailable = *rollingUpdate.MaxUnavailable
+	}
+	if rollingUpdate.MaxSurge != nil {
+		maxSurge = *rollingUpdate.MaxSurge
+	}
+
+	allErrs = append(allErrs, ValidatePositiveIntOrPercent(maxUnavailable, fldPath.Child("maxUnavailable"))...)
+	allErrs = append(allErrs, ValidatePositiveIntOrPercent(maxSurge, fldPath.Child("maxSurge"))...)
+	allErrs = append(allErrs, IsNotMoreThan100Percent(maxUnavailable, fldPath.Child("maxUnavailable"))...)
+	allErrs = append(allErrs, IsNotMoreThan100Percent(maxSurge, fldPath.Child("maxSurge"))...)
+
+	// Exactly one of MaxUnavailable and MaxSurge must be non-zero.
+	hasUnavailable := getIntOrPercentValue(maxUnavailable) != 0
+	hasSurge := getIntOrPercentValue(maxSurge) != 0
+	switch {
+	case hasUnavailable && hasSurge:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxSurge"), maxSurge, "may not be set when `maxUnavailable` is non-zero"))
+	case !hasUnavailable && !hasSurge:
+		allErrs = append(allErrs, field.Required(fldPath.Child("maxUnavailable"), "cannot be 0 when `maxSurge` is 0"))
+	}
+	return allErrs
+}
+
 func getIntOrPercentValue(intOrStringValue intstr.IntOrString) int {
 	value, isPercent := getPercentValue(intOrStringValue)
 	if isPercent {
@@ -515,3 +564,74 @@ func validateMinDomains(fldPath *field.Path, minDomains *int32, action core.Unsa
 	}
 	return allErrs
 }
+
+const (
+	// Limits on the DNS configuration of a pod, as enforced by the kubelet.
+	maxDNSNameservers     = 3
+	maxDNSSearchPaths     = 32
+	maxDNSSearchListChars = 2048
+)
+
+var validDNSPolicies = sets.New(string(core.DNSClusterFirstWithHostNet), string(core.DNSClusterFirst), string(core.DNSDefault), string(core.DNSNone))
+
+// ValidateDNSPolicy validates the given DNS policy. An empty policy is allowed and means the default policy is used.
+func ValidateDNSPolicy(dnsPolicy core.DNSPolicy, fldPath *field.Path) field.ErrorList {
+	allErrors := field.ErrorList{}
+	if dnsPolicy != "" && !validDNSPolicies.Has(string(dnsPolicy)) {
+		allErrors = append(allErrors, field.NotSupported(fldPath, dnsPolicy, sets.List(validDNSPolicies)))
+	}
+	return allErrors
+}
+
+// ValidatePodDNSConfig validates the given pod DNS config, which is used along with dnsPolicy.
+func ValidatePodDNSConfig(dnsConfig *core.PodDNSConfig, dnsPolicy core.DNSPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Validate DNSNone case. Must provide at least one DNS name server.
+	if dnsPolicy == core.DNSNone {
+		if dnsConfig == nil {
+			return append(allErrs, field.Required(fldPath, fmt.Sprintf("must provide `dnsConfig` when `dnsPolicy` is %s", core.DNSNone)))
+		}
+		if len(dnsConfig.Nameservers) == 0 {
+			return append(allErrs, field.Required(fldPath.Child("nameservers"), fmt.Sprintf("must provide at least one DNS nameserver when `dnsPolicy` is %s", core.DNSNone)))
+		}
+	}
+
+	if dnsConfig == nil {
+		return allErrs
+	}
+
+	// Validate nameservers.
+	if len(dnsConfig.Nameservers) > maxDNSNameservers {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("nameservers"), dnsConfig.Nameservers, fmt.Sprintf("must not have more than %v nameservers", maxDNSNameservers)))
+	}
+	for i, ns := range dnsConfig.Nameservers {
+		for _, msg := range validation.IsValidIP(ns) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nameservers").Index(i), ns, msg))
+		}
+	}
+
+	// Validate searches.
+	if len(dnsConfig.Searches) > maxDNSSearchPaths {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("searches"), dnsConfig.Searches, fmt.Sprintf("must not have more than %v search paths", maxDNSSearchPaths)))
+	}
+	// Include the space between search paths.
+	if len(strings.Join(dnsConfig.Searches, " ")) > maxDNSSearchListChars {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("searches"), dnsConfig.Searches, fmt.Sprintf("must not have more than %v characters (including spaces) in the search list", maxDNSSearchListChars)))
+	}
+	for i, search := range dnsConfig.Searches {
+		// A trailing dot is allowed and marks the search path as fully qualified.
+		search = strings.TrimSuffix(search, ".")
+		for _, msg := range validation.IsDNS1123Subdomain(search) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("searches").Index(i), search, msg))
+		}
+	}
+
+	// Validate options.
+	for i, option := range dnsConfig.Options {
+		if len(option.Name) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("options").Index(i), "must not be empty"))
+		}
+	}
+	return allErrs
+}
@@ -29,7 +29,10 @@ func init() {
 		return
 	}
 	body, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
-	if err != nil {
+	if os.IsNotExist(err) {
+		// Not running in a pod, for example when running the operator locally or in the kubectl plugin.
+		log.Debugf("Failed to read namespace file: %v", err)
+	} else if err != nil {
 		log.Errorf("Failed to read namespace file: %v", err)
 	} else {
 		namespace = string(body)
@@ -43,7 +43,9 @@ func getServiceAccount() string {
 		return v
 	}
 	body, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
-	if err != nil {
+	if os.IsNotExist(err) {
+		log.Debug("Failed to read serviceaccount/namespace file")
+	} else if err != nil {
 		log.Info("Failed to read serviceaccount/namespace file")
 	} else {
 		return string(body)
@@ -65,6 +65,17 @@ func ValidateReplicatedPodResourceOverrides(overrides components.ReplicatedPodRe
 			}
 		}
 	}
+	for name, probes := range overrides.GetContainerProbes() {
+		if err := validateProbeOverride(probes.LivenessProbe, true); err != nil {
+			return fmt.Errorf("spec.Template.Spec.Containers[%q].LivenessProbe is invalid: %w", name, err)
+		}
+		if err := validateProbeOverride(probes.ReadinessProbe, false); err != nil {
+			return fmt.Errorf("spec.Template.Spec.Containers[%q].ReadinessProbe is invalid: %w", name, err)
+		}
+		if err := validateProbeOverride(probes.StartupProbe, true); err != nil {
+			return fmt.Errorf("spec.Template.Spec.Containers[%q].StartupProbe is invalid: %w", name, err)
+		}
+	}
 	if affinity := overrides.GetAffinity(); affinity != nil {
 		if errs := k8svalidation.ValidateAffinity(affinity, field.NewPath("spec", "template", "spec", "affinity")); errs.ToAggregate() != nil {
 			return fmt.Errorf("spec.Template.Spec.Affinity is invalid: %w", errs.ToAggregate())
@@ -87,6 +98,15 @@ func ValidateReplicatedPodResourceOverrides(overrides components.ReplicatedPodRe
 		}
 	}
 
+	if dns := overrides.GetPodDNSOverrides(); dns != nil {
+		if errs := k8svalidation.ValidateDNSPolicy(dns.DNSPolicy, field.NewPath("spec", "template", "spec", "dnsPolicy")); errs.ToAggregate() != nil {
+			return fmt.Errorf("spec.Template.Spec.DNSPolicy is invalid: %w", errs.ToAggregate())
+		}
+		if errs := k8svalidation.ValidatePodDNSConfig(dns.DNSConfig, dns.DNSPolicy, field.NewPath("spec", "template", "spec", "dnsConfig")); errs.ToAggregate() != nil {
+			return fmt.Errorf("spec.Template.Spec.DNSConfig is invalid: %w", errs.ToAggregate())
+		}
+	}
+
 	tgp := overrides.GetTerminationGracePeriodSeconds()
 	if tgp != nil && *tgp < 0 {
 		return fmt.Errorf("spec.Template.Spec.TerminationGracePeriodSeconds is invalid: cannot be negative")
@@ -98,6 +118,40 @@ func ValidateReplicatedPodResourceOverrides(overrides components.ReplicatedPodRe
 		}
 	}
 
+	if st := overrides.GetDaemonSetUpdateStrategy(); st != nil {
+		if err := k8svalidation.ValidateDaemonSetUpdateStrategy(st, field.NewPath("spec", "strategy")); err.ToAggregate() != nil {
+			return fmt.Errorf("spec.Strategy is invalid: %w", err.ToAggregate())
+		}
+	}
+
+	return nil
+}
+
+// validateProbeOverride validates the given probe timings. Liveness and startup probes must succeed once to be
+// considered successful.
+func validateProbeOverride(probe *operatorv1.ProbeOverride, singleSuccess bool) error {
+	if probe == nil {
+		return nil
+	}
+	if probe.InitialDelaySeconds != nil && *probe.InitialDelaySeconds < 0 {
+		return fmt.Errorf("initialDelaySeconds must be greater than or equal to 0")
+	}
+	for _, f := range []struct {
+		name  string
+		value *int32
+	}{
+		{"timeoutSeconds", probe.TimeoutSeconds},
+		{"periodSeconds", probe.PeriodSeconds},
+		{"successThreshold", probe.SuccessThreshold},
+		{"failureThreshold", probe.FailureThreshold},
+	} {
+		if f.value != nil && *f.value < 1 {
+			return fmt.Errorf("%s must be greater than 0", f.name)
+		}
+	}
+	if singleSuccess && probe.SuccessThreshold != nil && *probe.SuccessThreshold != 1 {
+		return fmt.Errorf("successThreshold must be 1")
+	}
 	return nil
 }
 
@@ -27,6 +27,7 @@ import (
 
 	opv1 "github.com/tigera/operator/api/v1"
 	node "github.com/tigera/operator/pkg/common/validation/calico-node"
+	"github.com/tigera/operator/pkg/ptr"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -137,6 +138,89 @@ var _ = Describe("Test overrides validation (NodeDaemonset)", func() {
 		Expect(err).NotTo(BeNil())
 		Expect(err.Error()).Should(HavePrefix("spec.Template.Spec.Tolerations is invalid: spec.template.spec.tolerations[0].operator: Invalid value: \"Equal\": operator must be Exists when `key` is empty"))
 	})
+
+	DescribeTable(
+		"should validate update strategies",
+		func(maxUnav, maxSurge *intstr.IntOrString, expectedErr string) {
+			overrides.Spec.Strategy = &opv1.DaemonSetUpdateStrategy{
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: maxUnav, MaxSurge: maxSurge},
+			}
+			err := ValidateReplicatedPodResourceOverrides(overrides, node.ValidateCalicoNodeDaemonSetContainer, node.ValidateCalicoNodeDaemonSetInitContainer)
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(expectedErr))
+			}
+		},
+		Entry("maxUnavailable only", ptr.IntOrStrPtr("10%"), nil, ""),
+		Entry("maxSurge with zero maxUnavailable", ptr.IntOrStrPtr("0"), ptr.IntOrStrPtr("1"), ""),
+		Entry("maxSurge with defaulted maxUnavailable", nil, ptr.IntOrStrPtr("1"), "may not be set when `maxUnavailable` is non-zero"),
+		Entry("both zero", ptr.IntOrStrPtr("0"), ptr.IntOrStrPtr("0"), "cannot be 0 when `maxSurge` is 0"),
+		Entry("more than 100%", ptr.IntOrStrPtr("150%"), nil, "must not be greater than 100%"),
+	)
+
+	DescribeTable(
+		"should validate probe overrides",
+		func(probes opv1.ContainerProbeOverrides, expectedErr string) {
+			overrides.Spec.Template.Spec.Containers = []opv1.CalicoNodeDaemonSetContainer{{Name: "calico-node", ContainerProbeOverrides: probes}}
+			err := ValidateReplicatedPodResourceOverrides(overrides, node.ValidateCalicoNodeDaemonSetContainer, node.ValidateCalicoNodeDaemonSetInitContainer)
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal(expectedErr))
+			}
+		},
+		Entry("valid timings", opv1.ContainerProbeOverrides{
+			LivenessProbe:  &opv1.ProbeOverride{InitialDelaySeconds: ptr.Int32ToPtr(0), PeriodSeconds: ptr.Int32ToPtr(30), FailureThreshold: ptr.Int32ToPtr(5)},
+			ReadinessProbe: &opv1.ProbeOverride{TimeoutSeconds: ptr.Int32ToPtr(10), SuccessThreshold: ptr.Int32ToPtr(2)},
+			StartupProbe:   &opv1.ProbeOverride{FailureThreshold: ptr.Int32ToPtr(30)},
+		}, ""),
+		Entry("negative initial delay", opv1.ContainerProbeOverrides{
+			LivenessProbe: &opv1.ProbeOverride{InitialDelaySeconds: ptr.Int32ToPtr(-1)},
+		}, `spec.Template.Spec.Containers["calico-node"].LivenessProbe is invalid: initialDelaySeconds must be greater than or equal to 0`),
+		Entry("zero period", opv1.ContainerProbeOverrides{
+			ReadinessProbe: &opv1.ProbeOverride{PeriodSeconds: ptr.Int32ToPtr(0)},
+		}, `spec.Template.Spec.Containers["calico-node"].ReadinessProbe is invalid: periodSeconds must be greater than 0`),
+		Entry("startup probe success threshold", opv1.ContainerProbeOverrides{
+			StartupProbe: &opv1.ProbeOverride{SuccessThreshold: ptr.Int32ToPtr(2)},
+		}, `spec.Template.Spec.Containers["calico-node"].StartupProbe is invalid: successThreshold must be 1`),
+	)
+
+	DescribeTable(
+		"should validate DNS overrides",
+		func(dns opv1.PodDNSOverrides, expectedErr string) {
+			overrides.Spec.Template.Spec.PodDNSOverrides = dns
+			err := ValidateReplicatedPodResourceOverrides(overrides, node.ValidateCalicoNodeDaemonSetContainer, node.ValidateCalicoNodeDaemonSetInitContainer)
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(expectedErr))
+			}
+		},
+		Entry("no overrides", opv1.PodDNSOverrides{}, ""),
+		Entry("policy only", opv1.PodDNSOverrides{DNSPolicy: corev1.DNSDefault}, ""),
+		Entry("custom search domains", opv1.PodDNSOverrides{
+			DNSPolicy: corev1.DNSClusterFirstWithHostNet,
+			DNSConfig: &corev1.PodDNSConfig{Searches: []string{"es.example.com."}, Options: []corev1.PodDNSConfigOption{{Name: "ndots", Value: ptr.ToPtr("2")}}},
+		}, ""),
+		Entry("node-local DNS", opv1.PodDNSOverrides{
+			DNSPolicy: corev1.DNSNone,
+			DNSConfig: &corev1.PodDNSConfig{Nameservers: []string{"169.254.20.10"}},
+		}, ""),
+		Entry("unknown policy", opv1.PodDNSOverrides{DNSPolicy: "Custom"},
+			"spec.Template.Spec.DNSPolicy is invalid"),
+		Entry("None without nameservers", opv1.PodDNSOverrides{DNSPolicy: corev1.DNSNone},
+			"spec.Template.Spec.DNSConfig is invalid"),
+		Entry("invalid nameserver", opv1.PodDNSOverrides{DNSConfig: &corev1.PodDNSConfig{Nameservers: []string{"dns.example.com"}}},
+			"spec.Template.Spec.DNSConfig is invalid"),
+		Entry("invalid search domain", opv1.PodDNSOverrides{DNSConfig: &corev1.PodDNSConfig{Searches: []string{"Not_A_Domain"}}},
+			"spec.Template.Spec.DNSConfig is invalid"),
+		Entry("option without a name", opv1.PodDNSOverrides{DNSConfig: &corev1.PodDNSConfig{Options: []corev1.PodDNSConfigOption{{}}}},
+			"spec.Template.Spec.DNSConfig is invalid"),
+	)
 })
 
 var _ = Describe("Test overrides validation (TyphaDeployment)", func() {
@@ -0,0 +1,95 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+import (
+	operator "github.com/tigera/operator/api/v1"
+)
+
+// CPU architectures as reported by the kubernetes.io/arch node label.
+const (
+	ArchitectureAMD64 = "amd64"
+	ArchitectureARM64 = "arm64"
+)
+
+// amd64OnlyImages lists the components whose images are not published for any architecture
+// other than amd64. Components not in this list are expected to be multi-arch.
+var amd64OnlyImages = []component{
+	ComponentCalicoCNIFIPS,
+	ComponentCalicoNodeFIPS,
+	ComponentCalicoTyphaFIPS,
+	ComponentCalicoKubeControllersFIPS,
+	ComponentCalicoAPIServerFIPS,
+	ComponentCalicoCSIFIPS,
+	ComponentCalicoCSIRegistrarFIPS,
+	ComponentTigeraCNIFIPS,
+	ComponentElasticsearchFIPS,
+	ComponentDeepPacketInspection,
+}
+
+// SupportsArchitecture returns true if an image for the component is published for arch. An empty
+// arch is treated as unknown and is always supported.
+func SupportsArchitecture(c component, arch string) bool {
+	if arch == "" || arch == ArchitectureAMD64 {
+		return true
+	}
+	// FIPS images share their image name with the multi-arch images, and only differ in their version.
+	for _, x := range amd64OnlyImages {
+		if x == c {
+			return false
+		}
+	}
+	return true
+}
+
+// UnsupportedImages returns the images from CalicoImages and EnterpriseImages that are not published for arch.
+func UnsupportedImages(arch string) []component {
+	return imagesByArchitecture(arch, false)
+}
+
+// SupportedImages returns the images from CalicoImages and EnterpriseImages that are published for arch.
+func SupportedImages(arch string) []component {
+	return imagesByArchitecture(arch, true)
+}
+
+func imagesByArchitecture(arch string, supported bool) []component {
+	var images []component
+	for _, list := range [][]component{CalicoImages, EnterpriseImages} {
+		for _, c := range list {
+			if SupportsArchitecture(c, arch) == supported {
+				images = append(images, c)
+			}
+		}
+	}
+	return images
+}
+
+// ImageSetForArchitecture returns a copy of the ImageSet where the Digest of every image that lists
+// a digest for arch is replaced with that digest. If is is nil or arch is empty, is is returned as-is.
+func ImageSetForArchitecture(is *operator.ImageSet, arch string) *operator.ImageSet {
+	if is == nil || arch == "" {
+		return is
+	}
+	out := is.DeepCopy()
+	for i, img := range out.Spec.Images {
+		for _, ad := range img.ArchitectureDigests {
+			if ad.Architecture == arch {
+				out.Spec.Images[i].Digest = ad.Digest
+				break
+			}
+		}
+	}
+	return out
+}
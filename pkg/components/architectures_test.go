@@ -0,0 +1,41 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("image architectures", func() {
+	DescribeTable("SupportsArchitecture",
+		func(c component, arch string, expected bool) {
+			Expect(SupportsArchitecture(c, arch)).To(Equal(expected))
+		},
+		Entry("a multi-arch image on arm64", ComponentCalicoKubeControllers, ArchitectureARM64, true),
+		Entry("a FIPS image on arm64", ComponentCalicoKubeControllersFIPS, ArchitectureARM64, false),
+		Entry("a FIPS image on amd64", ComponentCalicoKubeControllersFIPS, ArchitectureAMD64, true),
+		Entry("a FIPS image on an unknown architecture", ComponentCalicoKubeControllersFIPS, "", true),
+	)
+
+	It("should only list the amd64-only images as unsupported on arm64", func() {
+		unsupported := UnsupportedImages(ArchitectureARM64)
+		Expect(unsupported).To(ContainElement(ComponentCalicoNodeFIPS))
+		Expect(unsupported).NotTo(ContainElement(ComponentCalicoNode))
+		Expect(unsupported).NotTo(ContainElement(ComponentCalicoTypha))
+		Expect(UnsupportedImages(ArchitectureAMD64)).To(BeEmpty())
+	})
+})
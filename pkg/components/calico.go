@@ -129,6 +129,24 @@ var (
 		Image:    "calico/node-driver-registrar",
 		Registry: "",
 	}
+
+	ComponentCalicoGoldmane = component{
+		Version:  "master",
+		Image:    "calico/goldmane",
+		Registry: "",
+	}
+
+	ComponentCalicoWhisker = component{
+		Version:  "master",
+		Image:    "calico/whisker",
+		Registry: "",
+	}
+
+	ComponentCalicoWhiskerBackend = component{
+		Version:  "master",
+		Image:    "calico/whisker-backend",
+		Registry: "",
+	}
 	ComponentOperatorInit = component{
 		Version: version.VERSION,
 		Image:   "tigera/operator",
@@ -154,5 +172,8 @@ var (
 		ComponentCalicoCSIFIPS,
 		ComponentCalicoCSIRegistrar,
 		ComponentCalicoCSIRegistrarFIPS,
+		ComponentCalicoGoldmane,
+		ComponentCalicoWhisker,
+		ComponentCalicoWhiskerBackend,
 	}
 )
@@ -191,6 +191,12 @@ var (
 		Registry: "",
 	}
 
+	ComponentRuntimeSecurity = component{
+		Version:  "master",
+		Image:    "tigera/runtime-security",
+		Registry: "",
+	}
+
 	ComponentEgressGateway = component{
 		Version:  "master",
 		Image:    "tigera/egress-gateway",
@@ -243,6 +249,12 @@ var (
 		Registry: "",
 	}
 
+	ComponentThanos = component{
+		Version:  "master",
+		Image:    "tigera/thanos",
+		Registry: "",
+	}
+
 	ComponentQueryServer = component{
 		Version:  "master",
 		Image:    "tigera/cnx-queryserver",
@@ -341,12 +353,14 @@ var (
 		ComponentManagerProxy,
 		ComponentPacketCapture,
 		ComponentPolicyRecommendation,
+		ComponentRuntimeSecurity,
 		ComponentEgressGateway,
 		ComponentL7Collector,
 		ComponentEnvoyProxy,
 		ComponentPrometheus,
 		ComponentTigeraPrometheusService,
 		ComponentPrometheusAlertmanager,
+		ComponentThanos,
 		ComponentQueryServer,
 		ComponentTigeraKubeControllers,
 		ComponentTigeraNode,
@@ -131,4 +131,63 @@ var _ = Describe("test GetReference", func() {
 			Entry("a CSR init image correctly", ComponentTigeraCSRInitContainer, "userpath/key-cert-provisioner", "@sha256:tigerakeycertprovisionerhash"),
 		)
 	})
+	Context("with ImageSet overrides", func() {
+		is := &op.ImageSet{
+			Spec: op.ImageSetSpec{
+				Images: []op.Image{
+					{Image: "calico/node", Digest: "sha256:caliconodehash"},
+					{Image: "tigera/es-gateway", Digest: "sha256:esgatewayhash", Registry: "internal.io/mirror"},
+					{Image: "tigera/linseed", Digest: "sha256:linseedhash", Registry: UseDefault, ImagePath: "mirror", ImagePrefix: "pfx-"},
+				},
+			},
+		}
+		DescribeTable("should render",
+			func(c component, expected string) {
+				Expect(GetReference(c, "quay.io/", "", "", is)).To(Equal(expected))
+			},
+			Entry("an image without overrides using the installation registry", ComponentCalicoNode, "quay.io/calico/node@sha256:caliconodehash"),
+			Entry("an image with a registry override", ComponentESGateway, "internal.io/mirror/tigera/es-gateway@sha256:esgatewayhash"),
+			Entry("an image with default registry, path and prefix overrides", ComponentLinseed, TigeraRegistry+"mirror/pfx-linseed@sha256:linseedhash"),
+		)
+	})
+})
+
+var _ = Describe("test ImageStatuses", func() {
+	It("should report every image of the variant", func() {
+		statuses := ImageStatuses(op.Calico, "my.registry/", "", "", nil)
+		Expect(statuses).To(HaveLen(len(CalicoImages)))
+		Expect(statuses).To(ContainElement(op.ImageStatus{
+			Image:     "calico/node",
+			Version:   ComponentCalicoNode.Version,
+			Reference: fmt.Sprintf("my.registry/calico/node:%s", ComponentCalicoNode.Version),
+		}))
+	})
+
+	It("should report the digests of the ImageSet and leave out images it does not contain", func() {
+		is := &op.ImageSet{Spec: op.ImageSetSpec{Images: []op.Image{
+			{Image: "tigera/cnx-node", Digest: "sha256:nodehash", Registry: "other.registry"},
+		}}}
+		Expect(ImageStatuses(op.TigeraSecureEnterprise, "my.registry/", "", "", is)).To(Equal([]op.ImageStatus{{
+			Image:     "tigera/cnx-node",
+			Version:   ComponentTigeraNode.Version,
+			Reference: "other.registry/tigera/cnx-node@sha256:nodehash",
+			Digest:    "sha256:nodehash",
+		}}))
+	})
+})
+
+var _ = Describe("test IsTigeraImage", func() {
+	DescribeTable("should detect the images of Tigera components",
+		func(ref string, expected bool) {
+			Expect(IsTigeraImage(ref)).To(Equal(expected))
+		},
+		Entry("a calico image", "docker.io/calico/node:master", true),
+		Entry("a tigera image with a digest", "gcr.io/unique-caldron-775/cnx/tigera/cnx-node@sha256:nodehash", true),
+		Entry("an image with path and prefix overrides", "my.registry/mirror/pfx-linseed:master", true),
+		Entry("the operator", "quay.io/tigera/operator:master", true),
+		Entry("the ECK operator", "quay.io/tigera/eck-operator:master", false),
+		Entry("elasticsearch", "quay.io/tigera/elasticsearch:master", false),
+		Entry("kibana", "quay.io/tigera/kibana:master", false),
+		Entry("an image that is not a component", "docker.io/library/busybox:latest", false),
+	)
 })
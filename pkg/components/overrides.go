@@ -39,6 +39,9 @@ type ReplicatedPodResourceOverrides interface {
 	// Only containers with fields specified (other than its name) should be returned.
 	GetContainers() []corev1.Container
 
+	// GetContainerProbes returns the probe timings used to override a DaemonSet/Deployment's containers, by container name.
+	GetContainerProbes() map[string]opv1.ContainerProbeOverrides
+
 	// GetAffinity returns the value used to override a DaemonSet/Deployment's affinity.
 	GetAffinity() *corev1.Affinity
 
@@ -55,6 +58,12 @@ type ReplicatedPodResourceOverrides interface {
 
 	GetDeploymentStrategy() *appsv1.DeploymentStrategy
 
+	// GetDaemonSetUpdateStrategy returns the value used to override a DaemonSet's updateStrategy.
+	GetDaemonSetUpdateStrategy() *appsv1.DaemonSetUpdateStrategy
+
+	// GetPodDNSOverrides returns the DNS policy and config used to override a DaemonSet/Deployment's pod DNS settings.
+	GetPodDNSOverrides() *opv1.PodDNSOverrides
+
 	// GetPriorityClassName() returns the value used to override a DaemonSet/Deployment's priorityClassName.
 	GetPriorityClassName() string
 }
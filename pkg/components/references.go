@@ -15,6 +15,7 @@ package components
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	operator "github.com/tigera/operator/api/v1"
@@ -34,12 +35,36 @@ type component struct {
 const UseDefault = "UseDefault"
 
 // GetReference returns the fully qualified image to use, including registry and version.
+// If the ImageSet specifies a registry, image path or image prefix for the image, it takes precedence
+// over the given values.
 func GetReference(c component, registry, imagePath, imagePrefix string, is *operator.ImageSet) (string, error) {
+	var digest string
+	if is != nil {
+		img := findImage(is, c.Image)
+		if img == nil {
+			return "", fmt.Errorf("ImageSet did not contain image %s", c.Image)
+		}
+		digest = img.Digest
+		if img.Registry != "" {
+			registry = img.Registry
+			if registry != UseDefault && !strings.HasSuffix(registry, "/") {
+				registry = fmt.Sprintf("%s/", registry)
+			}
+		}
+		if img.ImagePath != "" {
+			imagePath = img.ImagePath
+		}
+		if img.ImagePrefix != "" {
+			imagePrefix = img.ImagePrefix
+		}
+	}
+
 	// If a user did not supply a registry, use the default registry
 	// based on component
 	if registry == "" || registry == UseDefault {
 		switch c {
 		case ComponentCalicoNode,
+			ComponentCalicoNodeFIPS,
 			ComponentCalicoNodeWindows,
 			ComponentCalicoCNI,
 			ComponentCalicoCNIFIPS,
@@ -55,7 +80,10 @@ func GetReference(c component, registry, imagePath, imagePrefix string, is *oper
 			ComponentCalicoCSI,
 			ComponentCalicoCSIFIPS,
 			ComponentCalicoCSIRegistrar,
-			ComponentCalicoCSIRegistrarFIPS:
+			ComponentCalicoCSIRegistrarFIPS,
+			ComponentCalicoGoldmane,
+			ComponentCalicoWhisker,
+			ComponentCalicoWhiskerBackend:
 
 			registry = CalicoRegistry
 		case ComponentOperatorInit:
@@ -80,17 +108,90 @@ func GetReference(c component, registry, imagePath, imagePrefix string, is *oper
 		image = ReplaceImagePath(image, imagePath)
 	}
 
-	if is == nil {
+	if digest == "" {
 		return fmt.Sprintf("%s%s:%s", registry, image, c.Version), nil
 	}
+	return fmt.Sprintf("%s%s@%s", registry, image, digest), nil
+}
+
+// ImageStatuses returns the images of the given variant, resolved with GetReference, for reporting in the
+// Installation status. Images that the ImageSet does not contain are left out, since they cannot be deployed.
+func ImageStatuses(v operator.ProductVariant, registry, imagePath, imagePrefix string, is *operator.ImageSet) []operator.ImageStatus {
+	images := CalicoImages
+	if v == operator.TigeraSecureEnterprise {
+		images = EnterpriseImages
+	}
+
+	var statuses []operator.ImageStatus
+	for _, c := range images {
+		ref, err := GetReference(c, registry, imagePath, imagePrefix, is)
+		if err != nil {
+			continue
+		}
+		status := operator.ImageStatus{Image: c.Image, Version: c.Version, Reference: ref}
+		if is != nil {
+			status.Digest = findImage(is, c.Image).Digest
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// thirdPartyComponents are the components that package software that is not developed by Tigera. They do not
+// understand the settings that the operator passes to the components it develops, such as the OpenTelemetry SDK
+// environment.
+var thirdPartyComponents = []component{
+	ComponentCalicoCSIRegistrar,
+	ComponentCalicoCSIRegistrarFIPS,
+	ComponentDex,
+	ComponentElasticsearch,
+	ComponentElasticsearchFIPS,
+	ComponentElasticsearchOperator,
+	ComponentEnvoyProxy,
+	ComponentFluentd,
+	ComponentFluentdWindows,
+	ComponentKibana,
+	ComponentPrometheus,
+	ComponentPrometheusAlertmanager,
+	ComponentThanos,
+	ComponentTigeraCSINodeDriverRegistrar,
+}
+
+// IsTigeraImage returns true if the given image reference, as returned by GetReference, is the image of a component
+// developed by Tigera. The image is matched on its name, which may carry an image prefix, so the references of any
+// registry and image path match. Images that are not one of the components, or that package third-party software,
+// return false.
+func IsTigeraImage(ref string) bool {
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	if i := strings.IndexAny(name, ":@"); i >= 0 {
+		name = name[:i]
+	}
 
-	for _, img := range is.Spec.Images {
-		if img.Image == c.Image {
-			return fmt.Sprintf("%s%s@%s", registry, image, img.Digest), nil
+	// The longest matching component name wins, so that e.g. eck-operator is not mistaken for the operator.
+	var match string
+	for _, c := range append(append([]component{}, CalicoImages...), EnterpriseImages...) {
+		if n := path.Base(c.Image); strings.HasSuffix(name, n) && len(n) > len(match) {
+			match = n
 		}
 	}
+	if match == "" {
+		return false
+	}
+	for _, c := range thirdPartyComponents {
+		if path.Base(c.Image) == match {
+			return false
+		}
+	}
+	return true
+}
 
-	return "", fmt.Errorf("ImageSet did not contain image %s", c.Image)
+func findImage(is *operator.ImageSet, image string) *operator.Image {
+	for i := range is.Spec.Images {
+		if is.Spec.Images[i].Image == image {
+			return &is.Spec.Images[i]
+		}
+	}
+	return nil
 }
 
 func ReplaceImagePath(image, imagePath string) string {
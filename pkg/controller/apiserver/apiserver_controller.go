@@ -219,6 +219,10 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	r.status.OnCRFound()
 	reqLogger.V(2).Info("Loaded config", "config", instance)
 
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// Validate APIServer resource.
 	if err := validateAPIServerResource(instance); err != nil {
 		r.status.SetDegraded(operatorv1.ResourceValidationError, "APIServer is invalid", err, reqLogger)
@@ -246,21 +250,21 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	variant, installationSpec, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 	if variant == "" {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Installation to be ready", nil, reqLogger)
-		return reconcile.Result{}, nil
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Installation to be ready", status.WithCode(operatorv1.InstallationNotReady, nil), reqLogger)
+		return utils.RequeueWithBackoff(), nil
 	}
 	ns := rmeta.APIServerNamespace(variant)
 
 	certificateManager, err := certificatemanager.Create(r.client, installationSpec, r.clusterDomain, common.OperatorNamespace())
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -276,7 +280,7 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(installationSpec, r.client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -317,7 +321,7 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 			tunnelSecretName := managementCluster.Spec.TLS.SecretName
 			tunnelCASecret, err := utils.GetSecret(ctx, r.client, tunnelSecretName, common.OperatorNamespace())
 			if err != nil {
-				r.status.SetDegraded(operatorv1.ResourceReadError, "Unable to fetch the tunnel secret", err, reqLogger)
+				r.status.SetDegraded(operatorv1.ResourceReadError, "Unable to fetch the tunnel secret", status.WithCode(operatorv1.SecretNotAvailable, err), reqLogger)
 				return reconcile.Result{}, err
 			}
 			if tunnelCASecret != nil {
@@ -409,7 +413,7 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	}
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, components...); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
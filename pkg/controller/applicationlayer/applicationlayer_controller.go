@@ -34,6 +34,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -202,6 +203,10 @@ func (r *ReconcileApplicationLayer) Reconcile(ctx context.Context, request recon
 		}
 	}
 
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	preDefaultPatchFrom := client.MergeFrom(instance.DeepCopy())
 
 	updateApplicationLayerWithDefaults(instance)
@@ -220,7 +225,7 @@ func (r *ReconcileApplicationLayer) Reconcile(ctx context.Context, request recon
 	variant, installation, err := utils.GetInstallation(ctx, r.client)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, nil
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, reqLogger)
@@ -229,7 +234,7 @@ func (r *ReconcileApplicationLayer) Reconcile(ctx context.Context, request recon
 
 	if variant != operatorv1.TigeraSecureEnterprise {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for network to be %s", operatorv1.TigeraSecureEnterprise), nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	if operatorv1.IsFIPSModeEnabled(installation.FIPSMode) {
@@ -240,7 +245,7 @@ func (r *ReconcileApplicationLayer) Reconcile(ctx context.Context, request recon
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(installation, r.client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -273,17 +278,23 @@ func (r *ReconcileApplicationLayer) Reconcile(ctx context.Context, request recon
 		ALPEnabled:             r.isALPEnabled(&instance.Spec),
 		LogRequestsPerInterval: lcSpec.LogRequestsPerInterval,
 		LogIntervalSeconds:     lcSpec.LogIntervalSeconds,
+		LogNamespaceSelector:   formatNamespaceSelector(lcSpec.NamespaceSelector),
+		LogProtocols:           lcSpec.Protocols,
 		ModSecurityConfigMap:   modSecurityRuleSet,
 		UseRemoteAddressXFF:    instance.Spec.EnvoySettings.UseRemoteAddress,
 		NumTrustedHopsXFF:      instance.Spec.EnvoySettings.XFFNumTrustedHops,
 		ApplicationLayer:       instance,
 	}
+	if waf := instance.Spec.WebApplicationFirewallSettings; waf != nil {
+		config.WAFNamespaceSelector = formatNamespaceSelector(waf.NamespaceSelector)
+		config.WAFProtocols = waf.Protocols
+	}
 	component := applicationlayer.ApplicationLayer(config)
 
 	ch := utils.NewComponentHandler(log, r.client, r.scheme, instance)
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -385,9 +396,32 @@ func validateApplicationLayer(al *operatorv1.ApplicationLayer) error {
 		return errors.New("at least one of webApplicationFirewall, policy.Mode or logCollection.collectLogs must be specified in ApplicationLayer resource")
 	}
 
+	if _, err := metav1.LabelSelectorAsSelector(al.Spec.LogCollection.NamespaceSelector); err != nil {
+		return fmt.Errorf("invalid logCollection.namespaceSelector: %w", err)
+	}
+
+	if waf := al.Spec.WebApplicationFirewallSettings; waf != nil {
+		if _, err := metav1.LabelSelectorAsSelector(waf.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid webApplicationFirewallSettings.namespaceSelector: %w", err)
+		}
+		for _, p := range waf.Protocols {
+			if p != operatorv1.L7ProtocolHTTP && p != operatorv1.L7ProtocolHTTP2 {
+				return fmt.Errorf("webApplicationFirewallSettings.protocols contains %s, only HTTP and HTTP2 are supported", p)
+			}
+		}
+	}
+
 	return nil
 }
 
+// formatNamespaceSelector returns the string form of the given selector, or an empty string if it is not set.
+func formatNamespaceSelector(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	return metav1.FormatLabelSelector(selector)
+}
+
 // getModSecurityRuleSet returns 'owasp-ruleset-config' ConfigMap from calico-operator namespace.
 // The ConfigMap is meant to contain rule set files for ModSecurity library.
 // If the ConfigMap does not exist a ConfigMap with OWASP provided Core Rule Set will be returned.
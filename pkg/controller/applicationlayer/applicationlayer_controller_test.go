@@ -549,5 +549,45 @@ var _ = Describe("Application layer controller tests", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			mockStatus.AssertExpectations(GinkgoT())
 		})
+		It("should reject WAF protocols other than HTTP and HTTP2", func() {
+			Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "", mock.Anything, mock.Anything).Return()
+			mockStatus.On("SetMetaData", mock.Anything).Return()
+			By("applying the ApplicationLayer CR to the fake cluster")
+			enabled := operatorv1.WAFEnabled
+			Expect(c.Create(ctx, &operatorv1.ApplicationLayer{
+				ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+				Spec: operatorv1.ApplicationLayerSpec{
+					WebApplicationFirewall: &enabled,
+					WebApplicationFirewallSettings: &operatorv1.WAFSettings{
+						Protocols: []operatorv1.L7Protocol{operatorv1.L7ProtocolTCP},
+					},
+				},
+			})).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertExpectations(GinkgoT())
+		})
+		It("should reject an invalid log collection namespace selector", func() {
+			Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "", mock.Anything, mock.Anything).Return()
+			mockStatus.On("SetMetaData", mock.Anything).Return()
+			By("applying the ApplicationLayer CR to the fake cluster")
+			enabled := operatorv1.L7LogCollectionEnabled
+			Expect(c.Create(ctx, &operatorv1.ApplicationLayer{
+				ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+				Spec: operatorv1.ApplicationLayerSpec{
+					LogCollection: &operatorv1.LogCollectionSpec{
+						CollectLogs: &enabled,
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "l7-logs", Operator: "Bogus"}},
+						},
+					},
+				},
+			})).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertExpectations(GinkgoT())
+		})
 	})
 })
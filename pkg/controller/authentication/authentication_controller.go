@@ -201,6 +201,10 @@ func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconci
 		}
 	}
 
+	if utils.IsReconcilePaused(&authentication.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	reqLogger.V(2).Info("Loaded config", "config", authentication)
 	preDefaultPatchFrom := client.MergeFrom(authentication.DeepCopy())
 
@@ -223,7 +227,7 @@ func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconci
 	variant, install, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(oprv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(oprv1.ResourceNotFound, "Installation not found", status.WithCode(oprv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(oprv1.ResourceReadError, "Error querying installation", err, reqLogger)
@@ -231,20 +235,20 @@ func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconci
 	}
 	if variant != oprv1.TigeraSecureEnterprise {
 		r.status.SetDegraded(oprv1.ResourceNotReady, fmt.Sprintf("Waiting for network to be %s", oprv1.TigeraSecureEnterprise), nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(oprv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(oprv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(oprv1.TierNotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
 	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(oprv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
-			return reconcile.Result{}, nil
+			r.status.SetDegraded(oprv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", status.WithCode(oprv1.TierNotReady, err), reqLogger)
+			return utils.RequeueWithBackoff(), nil
 		} else {
 			r.status.SetDegraded(oprv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
 			return reconcile.Result{}, err
@@ -265,7 +269,7 @@ func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconci
 	// Secret used for TLS between dex and other components.
 	certificateManager, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace())
 	if err != nil {
-		r.status.SetDegraded(oprv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(oprv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(oprv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 	dnsNames := dns.GetServiceDNSNames(render.DexObjectName, render.DexNamespace, r.clusterDomain)
@@ -302,7 +306,7 @@ func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconci
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(install, r.client)
 	if err != nil {
-		r.status.SetDegraded(oprv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
+		r.status.SetDegraded(oprv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(oprv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -331,7 +335,7 @@ func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconci
 	component := render.Dex(dexComponentCfg)
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
-		r.status.SetDegraded(oprv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(oprv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(oprv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -0,0 +1,346 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgptopology
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/ptr"
+	"github.com/tigera/operator/pkg/render/bgptopology"
+)
+
+const (
+	ResourceName = "bgp-topology"
+
+	DefaultRouteReflectorClusterID = "244.0.0.1"
+
+	// RouteReflectorClusterIDAnnotation is the annotation on the Kubernetes node that holds the route reflector
+	// cluster ID of the Calico node.
+	RouteReflectorClusterIDAnnotation = "projectcalico.org/RouteReflectorClusterID"
+
+	// routeReflectorAnnotation marks the nodes that were made route reflectors by this controller.
+	routeReflectorAnnotation = "operator.tigera.io/route-reflector"
+
+	// nodeMeshAnnotation marks the default BGPConfiguration when this controller disabled the node-to-node mesh.
+	nodeMeshAnnotation = "operator.tigera.io/node-mesh-disabled"
+)
+
+var log = logf.Log.WithName("controller_bgptopology")
+
+// Add creates a new BGPTopology Controller and adds it to the Manager.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	r := &ReconcileBGPTopology{
+		client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		status: status.New(mgr.GetClient(), ResourceName, opts.KubernetesVersion),
+	}
+	r.status.Run(opts.ShutdownContext)
+
+	c, err := ctrlruntime.NewController("bgptopology-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	if err = c.WatchObject(&operatorv1.BGPTopology{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("bgptopology-controller failed to watch BGPTopology resource: %w", err)
+	}
+
+	if err = utils.AddInstallationWatch(c); err != nil {
+		return fmt.Errorf("bgptopology-controller failed to watch Installation resource: %w", err)
+	}
+
+	if err = utils.AddAPIServerWatch(c); err != nil {
+		return fmt.Errorf("bgptopology-controller failed to watch APIServer resource: %w", err)
+	}
+
+	if err = c.WatchObject(&crdv1.BGPConfiguration{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("bgptopology-controller failed to watch BGPConfiguration resource: %w", err)
+	}
+
+	// Only label changes of nodes affect which nodes are route reflectors.
+	if err = c.WatchObject(&corev1.Node{}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+	}); err != nil {
+		return fmt.Errorf("bgptopology-controller failed to watch Node resource: %w", err)
+	}
+
+	if err = utils.AddTigeraStatusWatch(c, ResourceName); err != nil {
+		return fmt.Errorf("bgptopology-controller failed to watch bgp-topology Tigerastatus: %w", err)
+	}
+
+	// BGPPeers and BGPFilters are served by the API server, which may not be running when the operator starts, so
+	// changes made to them are reverted by periodic reconciliation instead of watches.
+	if err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("bgptopology-controller failed to create periodic reconcile watch: %w", err)
+	}
+
+	return nil
+}
+
+// Blank assignment to verify that ReconcileBGPTopology implements reconcile.Reconciler.
+var _ reconcile.Reconciler = &ReconcileBGPTopology{}
+
+// ReconcileBGPTopology reconciles the BGPPeers and BGPFilters of a BGPTopology.
+type ReconcileBGPTopology struct {
+	client client.Client
+	scheme *runtime.Scheme
+	status status.StatusManager
+}
+
+func (r *ReconcileBGPTopology) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling BGPTopology")
+
+	instance := &operatorv1.BGPTopology{}
+	err := r.client.Get(ctx, utils.DefaultInstanceKey, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			reqLogger.Info("BGPTopology object not found")
+			r.status.OnCRNotFound()
+			if err = r.removeTopology(ctx, reqLogger); err != nil {
+				reqLogger.Error(err, "Error removing the BGP topology")
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying BGPTopology", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	r.status.OnCRFound()
+	// SetMetaData in the TigeraStatus such as observedGenerations.
+	defer r.status.SetMetaData(&instance.ObjectMeta)
+
+	// Changes for updating BGPTopology status conditions.
+	if request.Name == ResourceName && request.Namespace == "" {
+		ts := &operatorv1.TigeraStatus{}
+		err := r.client.Get(ctx, types.NamespacedName{Name: ResourceName}, ts)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		instance.Status.Conditions = status.UpdateStatusCondition(instance.Status.Conditions, ts.Status.Conditions)
+		if err := r.client.Status().Update(ctx, instance); err != nil {
+			log.WithValues("reason", err).Info("Failed to create BGPTopology status conditions.")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
+	preDefaultPatchFrom := client.MergeFrom(instance.DeepCopy())
+
+	fillDefaults(instance)
+
+	if err = validateBGPTopology(instance); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid BGPTopology", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
+
+	// Write the defaults back to the datastore.
+	if err = r.client.Patch(ctx, instance, preDefaultPatchFrom); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to write defaults to BGPTopology", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	_, installation, err := utils.GetInstallation(ctx, r.client)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
+			return reconcile.Result{}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	if installation.CalicoNetwork != nil && installation.CalicoNetwork.BGP != nil && *installation.CalicoNetwork.BGP == operatorv1.BGPDisabled {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "BGP is disabled in the Installation", nil, reqLogger)
+		return reconcile.Result{}, nil
+	}
+
+	// BGPPeers and BGPFilters are written through the API server.
+	if !utils.IsAPIServerReady(r.client, reqLogger) {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", status.WithCode(operatorv1.APIServerNotReady, nil), reqLogger)
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
+	cfg, err := r.existingTopology(ctx)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying BGPPeers and BGPFilters", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	cfg.BGPTopology = instance
+
+	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	if err = handler.CreateOrUpdateOrDelete(ctx, bgptopology.BGPTopology(cfg), r.status); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating / deleting resource", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	if err = r.reconcileRouteReflectors(ctx, instance.Spec.RouteReflectors); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error configuring the route reflector nodes", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	if err = r.reconcileNodeMesh(ctx, instance.Spec.RouteReflectors != nil); err != nil {
+		r.status.SetDegraded(operatorv1.ResourcePatchError, "Error patching BGPConfiguration", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	r.status.ReadyToMonitor()
+	r.status.ClearDegraded()
+
+	instance.Status.State = operatorv1.TigeraStatusReady
+	if err = r.client.Status().Update(ctx, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// removeTopology deletes the BGPPeers and BGPFilters, and reverts the node and BGPConfiguration changes, after the
+// BGPTopology is deleted.
+func (r *ReconcileBGPTopology) removeTopology(ctx context.Context, reqLogger logr.Logger) error {
+	if err := r.reconcileRouteReflectors(ctx, nil); err != nil {
+		return err
+	}
+	if err := r.reconcileNodeMesh(ctx, false); err != nil {
+		return err
+	}
+
+	if !utils.IsAPIServerReady(r.client, reqLogger) {
+		// Without the API server the BGPPeers and BGPFilters cannot have been created.
+		return nil
+	}
+	cfg, err := r.existingTopology(ctx)
+	if err != nil {
+		return err
+	}
+	handler := utils.NewComponentHandler(log, r.client, r.scheme, nil)
+	return handler.CreateOrUpdateOrDelete(ctx, bgptopology.BGPTopology(cfg), nil)
+}
+
+// existingTopology returns a config that holds the BGPPeers and BGPFilters that were rendered earlier.
+func (r *ReconcileBGPTopology) existingTopology(ctx context.Context) (*bgptopology.Config, error) {
+	managed := client.MatchingLabels{bgptopology.ManagedByLabel: bgptopology.ManagedByValue}
+	peers := &v3.BGPPeerList{}
+	if err := r.client.List(ctx, peers, managed); err != nil {
+		return nil, err
+	}
+	filters := &v3.BGPFilterList{}
+	if err := r.client.List(ctx, filters, managed); err != nil {
+		return nil, err
+	}
+	return &bgptopology.Config{ExistingPeers: peers.Items, ExistingFilters: filters.Items}, nil
+}
+
+// reconcileRouteReflectors sets the route reflector cluster ID on the nodes selected by rr, and removes it from the
+// nodes that this controller made route reflectors earlier but that are no longer selected.
+func (r *ReconcileBGPTopology) reconcileRouteReflectors(ctx context.Context, rr *operatorv1.BGPRouteReflectors) error {
+	nodes := &corev1.NodeList{}
+	if err := r.client.List(ctx, nodes); err != nil {
+		return err
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		patchFrom := client.MergeFrom(node.DeepCopy())
+
+		selected := rr != nil && labels.SelectorFromSet(rr.NodeSelector).Matches(labels.Set(node.Labels))
+		if selected {
+			if node.Annotations[RouteReflectorClusterIDAnnotation] == rr.ClusterID && node.Annotations[routeReflectorAnnotation] == "true" {
+				continue
+			}
+			if node.Annotations == nil {
+				node.Annotations = map[string]string{}
+			}
+			node.Annotations[RouteReflectorClusterIDAnnotation] = rr.ClusterID
+			node.Annotations[routeReflectorAnnotation] = "true"
+		} else {
+			if _, ok := node.Annotations[routeReflectorAnnotation]; !ok {
+				// Route reflectors that were not configured by this controller are left alone.
+				continue
+			}
+			delete(node.Annotations, RouteReflectorClusterIDAnnotation)
+			delete(node.Annotations, routeReflectorAnnotation)
+		}
+
+		if err := r.client.Patch(ctx, node, patchFrom); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileNodeMesh disables the node-to-node mesh while route reflectors are configured. The mesh is only
+// re-enabled if it was disabled by this controller.
+func (r *ReconcileBGPTopology) reconcileNodeMesh(ctx context.Context, routeReflectors bool) error {
+	_, err := utils.PatchBGPConfiguration(ctx, r.client, func(bc *crdv1.BGPConfiguration) (bool, error) {
+		if routeReflectors {
+			if bc.Spec.NodeToNodeMeshEnabled != nil && !*bc.Spec.NodeToNodeMeshEnabled && bc.Annotations[nodeMeshAnnotation] == "true" {
+				return false, nil
+			}
+			if bc.Annotations == nil {
+				bc.Annotations = map[string]string{}
+			}
+			bc.Annotations[nodeMeshAnnotation] = "true"
+			bc.Spec.NodeToNodeMeshEnabled = ptr.BoolToPtr(false)
+			return true, nil
+		}
+
+		if _, ok := bc.Annotations[nodeMeshAnnotation]; !ok {
+			return false, nil
+		}
+		delete(bc.Annotations, nodeMeshAnnotation)
+		bc.Spec.NodeToNodeMeshEnabled = ptr.BoolToPtr(true)
+		return true, nil
+	})
+	return err
+}
+
+// fillDefaults populates the default values onto a BGPTopology object.
+func fillDefaults(instance *operatorv1.BGPTopology) {
+	if rr := instance.Spec.RouteReflectors; rr != nil && rr.ClusterID == "" {
+		rr.ClusterID = DefaultRouteReflectorClusterID
+	}
+}
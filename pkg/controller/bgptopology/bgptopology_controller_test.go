@@ -0,0 +1,327 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgptopology
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+	"github.com/tigera/operator/pkg/render/bgptopology"
+)
+
+var _ = Describe("BGPTopology controller tests", func() {
+	var r ReconcileBGPTopology
+	var c client.Client
+	var ctx context.Context
+	var scheme *runtime.Scheme
+	var mockStatus *status.MockStatus
+
+	node := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	topology := func() *operatorv1.BGPTopology {
+		return &operatorv1.BGPTopology{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operatorv1.BGPTopologySpec{
+				RouteReflectors: &operatorv1.BGPRouteReflectors{
+					NodeSelector: map[string]string{"route-reflector": "true"},
+				},
+				Racks: []operatorv1.BGPRack{
+					{
+						Name:         "r1",
+						NodeSelector: map[string]string{"rack": "r1"},
+						Peers:        []operatorv1.BGPRackPeer{{PeerIP: "10.0.0.1", ASNumber: 65001}},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		// The schema contains all objects that should be known to the fake client when the test runs.
+		scheme = runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(operatorv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+
+		// Create a client that will have a crud interface of k8s objects.
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		mockStatus = &status.MockStatus{}
+		mockStatus.On("OnCRFound").Return()
+		mockStatus.On("SetMetaData", mock.Anything).Return()
+		mockStatus.On("ReadyToMonitor")
+		mockStatus.On("ClearDegraded")
+
+		r = ReconcileBGPTopology{
+			client: c,
+			scheme: scheme,
+			status: mockStatus,
+		}
+
+		Expect(c.Create(ctx, &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operatorv1.InstallationSpec{Variant: operatorv1.Calico},
+			Status: operatorv1.InstallationStatus{
+				Variant:  operatorv1.Calico,
+				Computed: &operatorv1.InstallationSpec{},
+			},
+		})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &operatorv1.APIServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Status:     operatorv1.APIServerStatus{State: operatorv1.TigeraStatusReady},
+		})).NotTo(HaveOccurred())
+
+		Expect(c.Create(ctx, node("node-a", map[string]string{"route-reflector": "true"}))).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, node("node-b", map[string]string{"rack": "r1"}))).NotTo(HaveOccurred())
+	})
+
+	It("creates the peers, configures the route reflectors and disables the node-to-node mesh", func() {
+		Expect(c.Create(ctx, topology())).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		peers := &v3.BGPPeerList{}
+		Expect(c.List(ctx, peers)).NotTo(HaveOccurred())
+		Expect(peers.Items).To(HaveLen(2))
+		Expect(c.Get(ctx, client.ObjectKey{Name: bgptopology.RouteReflectorPeerName}, &v3.BGPPeer{})).NotTo(HaveOccurred())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "rack-r1-0"}, &v3.BGPPeer{})).NotTo(HaveOccurred())
+
+		n := &corev1.Node{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "node-a"}, n)).NotTo(HaveOccurred())
+		Expect(n.Annotations).To(HaveKeyWithValue(RouteReflectorClusterIDAnnotation, DefaultRouteReflectorClusterID))
+		Expect(c.Get(ctx, client.ObjectKey{Name: "node-b"}, n)).NotTo(HaveOccurred())
+		Expect(n.Annotations).NotTo(HaveKey(RouteReflectorClusterIDAnnotation))
+
+		bc := &crdv1.BGPConfiguration{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, bc)).NotTo(HaveOccurred())
+		Expect(*bc.Spec.NodeToNodeMeshEnabled).To(BeFalse())
+
+		// The defaults are written back to the BGPTopology.
+		instance := &operatorv1.BGPTopology{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, instance)).NotTo(HaveOccurred())
+		Expect(instance.Spec.RouteReflectors.ClusterID).To(Equal(DefaultRouteReflectorClusterID))
+		Expect(instance.Status.State).To(Equal(operatorv1.TigeraStatusReady))
+	})
+
+	It("reverts changes made to the peers", func() {
+		Expect(c.Create(ctx, topology())).NotTo(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		peer := &v3.BGPPeer{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "rack-r1-0"}, peer)).NotTo(HaveOccurred())
+		peer.Spec.PeerIP = "10.0.0.99"
+		Expect(c.Update(ctx, peer)).NotTo(HaveOccurred())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "rack-r1-0"}, peer)).NotTo(HaveOccurred())
+		Expect(peer.Spec.PeerIP).To(Equal("10.0.0.1"))
+	})
+
+	It("removes the route reflector configuration when the route reflectors are removed", func() {
+		instance := topology()
+		Expect(c.Create(ctx, instance)).NotTo(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, instance)).NotTo(HaveOccurred())
+		instance.Spec.RouteReflectors = nil
+		Expect(c.Update(ctx, instance)).NotTo(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		peers := &v3.BGPPeerList{}
+		Expect(c.List(ctx, peers)).NotTo(HaveOccurred())
+		Expect(peers.Items).To(HaveLen(1))
+		Expect(peers.Items[0].Name).To(Equal("rack-r1-0"))
+
+		n := &corev1.Node{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "node-a"}, n)).NotTo(HaveOccurred())
+		Expect(n.Annotations).NotTo(HaveKey(RouteReflectorClusterIDAnnotation))
+
+		bc := &crdv1.BGPConfiguration{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, bc)).NotTo(HaveOccurred())
+		Expect(*bc.Spec.NodeToNodeMeshEnabled).To(BeTrue())
+	})
+
+	It("does not touch BGPPeers and BGPConfiguration that it does not manage", func() {
+		Expect(c.Create(ctx, &v3.BGPPeer{ObjectMeta: metav1.ObjectMeta{Name: "user-peer"}})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &crdv1.BGPConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       crdv1.BGPConfigurationSpec{NodeToNodeMeshEnabled: ptr.BoolToPtr(false)},
+		})).NotTo(HaveOccurred())
+		instance := topology()
+		instance.Spec.RouteReflectors = nil
+		Expect(c.Create(ctx, instance)).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: "user-peer"}, &v3.BGPPeer{})).NotTo(HaveOccurred())
+		bc := &crdv1.BGPConfiguration{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, bc)).NotTo(HaveOccurred())
+		Expect(*bc.Spec.NodeToNodeMeshEnabled).To(BeFalse())
+	})
+
+	It("removes the topology when the BGPTopology is deleted", func() {
+		instance := topology()
+		Expect(c.Create(ctx, instance)).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &v3.BGPPeer{ObjectMeta: metav1.ObjectMeta{Name: "user-peer"}})).NotTo(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		mockStatus.On("OnCRNotFound").Return()
+		Expect(c.Delete(ctx, instance)).NotTo(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		peers := &v3.BGPPeerList{}
+		Expect(c.List(ctx, peers)).NotTo(HaveOccurred())
+		Expect(peers.Items).To(HaveLen(1))
+		Expect(peers.Items[0].Name).To(Equal("user-peer"))
+
+		n := &corev1.Node{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "node-a"}, n)).NotTo(HaveOccurred())
+		Expect(n.Annotations).NotTo(HaveKey(RouteReflectorClusterIDAnnotation))
+
+		bc := &crdv1.BGPConfiguration{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, bc)).NotTo(HaveOccurred())
+		Expect(*bc.Spec.NodeToNodeMeshEnabled).To(BeTrue())
+	})
+
+	It("degrades when the BGPTopology is invalid", func() {
+		instance := topology()
+		instance.Spec.Racks[0].Filters = []string{"missing"}
+		Expect(c.Create(ctx, instance)).NotTo(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid BGPTopology", mock.Anything, mock.Anything).Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid BGPTopology", mock.Anything, mock.Anything)
+
+		peers := &v3.BGPPeerList{}
+		Expect(c.List(ctx, peers)).NotTo(HaveOccurred())
+		Expect(peers.Items).To(BeEmpty())
+	})
+
+	It("degrades when BGP is disabled in the Installation", func() {
+		installation := &operatorv1.Installation{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, installation)).NotTo(HaveOccurred())
+		bgp := operatorv1.BGPDisabled
+		installation.Spec.CalicoNetwork = &operatorv1.CalicoNetworkSpec{BGP: &bgp}
+		installation.Status.Computed = installation.Spec.DeepCopy()
+		Expect(c.Update(ctx, installation)).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, topology())).NotTo(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "BGP is disabled in the Installation", mock.Anything, mock.Anything).Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "BGP is disabled in the Installation", mock.Anything, mock.Anything)
+	})
+
+	It("waits for the API server to be available", func() {
+		Expect(c.Delete(ctx, &operatorv1.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, topology())).NotTo(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", mock.Anything, mock.Anything).Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(utils.StandardRetry))
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", mock.Anything, mock.Anything)
+	})
+})
+
+var _ = Describe("BGPTopology validation tests", func() {
+	var instance *operatorv1.BGPTopology
+
+	BeforeEach(func() {
+		instance = &operatorv1.BGPTopology{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operatorv1.BGPTopologySpec{
+				RouteReflectors: &operatorv1.BGPRouteReflectors{
+					NodeSelector: map[string]string{"route-reflector": "true"},
+					ClusterID:    DefaultRouteReflectorClusterID,
+					Filters:      []string{"f1"},
+				},
+				Racks: []operatorv1.BGPRack{
+					{
+						Name:         "r1",
+						NodeSelector: map[string]string{"rack": "r1"},
+						Peers:        []operatorv1.BGPRackPeer{{PeerIP: "10.0.0.1", ASNumber: 65001}},
+						Filters:      []string{"f1"},
+					},
+				},
+				Filters: []operatorv1.BGPTopologyFilter{{Name: "f1"}},
+			},
+		}
+	})
+
+	It("accepts a valid topology", func() {
+		Expect(validateBGPTopology(instance)).NotTo(HaveOccurred())
+	})
+
+	It("rejects duplicate filters", func() {
+		instance.Spec.Filters = append(instance.Spec.Filters, operatorv1.BGPTopologyFilter{Name: "f1"})
+		Expect(validateBGPTopology(instance)).To(HaveOccurred())
+	})
+
+	It("rejects an invalid route reflector cluster ID", func() {
+		instance.Spec.RouteReflectors.ClusterID = "not-an-ip"
+		Expect(validateBGPTopology(instance)).To(HaveOccurred())
+	})
+
+	It("rejects route reflectors without a node selector", func() {
+		instance.Spec.RouteReflectors.NodeSelector = nil
+		Expect(validateBGPTopology(instance)).To(HaveOccurred())
+	})
+
+	It("rejects duplicate racks", func() {
+		instance.Spec.Racks = append(instance.Spec.Racks, instance.Spec.Racks[0])
+		Expect(validateBGPTopology(instance)).To(HaveOccurred())
+	})
+
+	It("rejects an invalid peer IP", func() {
+		instance.Spec.Racks[0].Peers[0].PeerIP = "10.0.0"
+		Expect(validateBGPTopology(instance)).To(HaveOccurred())
+	})
+
+	It("rejects references to unknown filters", func() {
+		instance.Spec.Racks[0].Filters = []string{"f2"}
+		Expect(validateBGPTopology(instance)).To(HaveOccurred())
+	})
+})
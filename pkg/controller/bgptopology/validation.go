@@ -0,0 +1,99 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgptopology
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render/bgptopology"
+)
+
+// validateBGPTopology validates the BGPTopology after its defaults are filled in.
+func validateBGPTopology(instance *operatorv1.BGPTopology) error {
+	filters := map[string]bool{}
+	for _, f := range instance.Spec.Filters {
+		if errs := validation.IsDNS1123Subdomain(f.Name); len(errs) > 0 {
+			return fmt.Errorf("filter name %q is invalid: %s", f.Name, strings.Join(errs, ", "))
+		}
+		if filters[f.Name] {
+			return fmt.Errorf("filter %q is specified more than once", f.Name)
+		}
+		filters[f.Name] = true
+	}
+
+	validateFilterRefs := func(refs []string, owner string) error {
+		for _, ref := range refs {
+			if !filters[ref] {
+				return fmt.Errorf("%s refers to filter %q, which is not specified in spec.filters", owner, ref)
+			}
+		}
+		return nil
+	}
+
+	if rr := instance.Spec.RouteReflectors; rr != nil {
+		if len(rr.NodeSelector) == 0 {
+			return fmt.Errorf("routeReflectors.nodeSelector must not be empty")
+		}
+		if ip := net.ParseIP(rr.ClusterID); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("routeReflectors.clusterID %q is not an IPv4 address", rr.ClusterID)
+		}
+		if err := validateFilterRefs(rr.Filters, "routeReflectors"); err != nil {
+			return err
+		}
+	}
+
+	racks := map[string]bool{}
+	peerNames := map[string]bool{bgptopology.RouteReflectorPeerName: true}
+	for _, rack := range instance.Spec.Racks {
+		if errs := validation.IsDNS1123Label(rack.Name); len(errs) > 0 {
+			return fmt.Errorf("rack name %q is invalid: %s", rack.Name, strings.Join(errs, ", "))
+		}
+		if racks[rack.Name] {
+			return fmt.Errorf("rack %q is specified more than once", rack.Name)
+		}
+		racks[rack.Name] = true
+
+		if len(rack.NodeSelector) == 0 {
+			return fmt.Errorf("rack %q: nodeSelector must not be empty", rack.Name)
+		}
+		if len(rack.Peers) == 0 {
+			return fmt.Errorf("rack %q: at least one peer must be specified", rack.Name)
+		}
+		for i, p := range rack.Peers {
+			if net.ParseIP(p.PeerIP) == nil {
+				return fmt.Errorf("rack %q: peerIP %q is not an IP address", rack.Name, p.PeerIP)
+			}
+			if p.ASNumber == 0 {
+				return fmt.Errorf("rack %q: asNumber of peer %s must be set", rack.Name, p.PeerIP)
+			}
+			// Rack names may contain dashes, so check that the generated names do not collide.
+			name := bgptopology.RackPeerName(rack.Name, i)
+			if peerNames[name] {
+				return fmt.Errorf("rack %q: the name of the BGPPeer for peer %s, %q, is already in use", rack.Name, p.PeerIP, name)
+			}
+			peerNames[name] = true
+		}
+		if err := validateFilterRefs(rack.Filters, fmt.Sprintf("rack %q", rack.Name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -557,6 +557,31 @@ func HasExpectedDNSNames(secretName, secretNamespace string, cert *x509.Certific
 	return ErrInvalidCertDNSNames(secretName, secretNamespace)
 }
 
+// ValidateBYOKeyPair returns an error if the key pair was provided by the user and its certificate is not valid for
+// all the expected DNS names. Operator issued key pairs are not validated, since GetOrCreateKeyPair replaces them
+// whenever the expected DNS names change.
+func ValidateBYOKeyPair(keyPair certificatemanagement.KeyPairInterface, expectedDNSNames []string) error {
+	if keyPair == nil || !keyPair.BYO() {
+		return nil
+	}
+	cert, err := certificatemanagement.ParseCertificate(keyPair.GetCertificatePEM())
+	if err != nil {
+		return fmt.Errorf("user provided certificate %s/%s could not be parsed: %w", keyPair.GetNamespace(), keyPair.GetName(), err)
+	}
+	certDNSNames := sets.NewString(cert.DNSNames...)
+	var missing []string
+	for _, name := range expectedDNSNames {
+		// Wildcard SANs of the certificate may cover the expected name.
+		if !certDNSNames.Has(name) && cert.VerifyHostname(name) != nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("user provided certificate %s/%s is missing the DNS names: %s", keyPair.GetNamespace(), keyPair.GetName(), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // CreateTrustedBundle creates a TrustedBundle, which provides standardized methods for mounting a bundle of certificates to trust.
 // It will include:
 // - A bundle with Calico's root certificates + any user supplied certificates in /etc/pki/tls/certs/tigera-ca-bundle.crt.
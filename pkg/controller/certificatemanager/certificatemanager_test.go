@@ -462,6 +462,36 @@ var _ = Describe("Test CertificateManagement suite", func() {
 			Expect(keyPair.GetIssuer()).NotTo(Equal(certificateManager.KeyPair()))
 		})
 
+		It("validates the DNS names of BYO secrets only", func() {
+			missingDNSNames := append(appDNSNames, "missing-name")
+			By("verifying that operator issued key pairs are always valid")
+			keyPair, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificatemanager.ValidateBYOKeyPair(keyPair, missingDNSNames)).NotTo(HaveOccurred())
+
+			By("verifying that a BYO secret must have all the expected DNS names")
+			Expect(cli.Create(ctx, byoSecret)).NotTo(HaveOccurred())
+			keyPair, err = certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, missingDNSNames)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificatemanager.ValidateBYOKeyPair(keyPair, appDNSNames)).NotTo(HaveOccurred())
+			err = certificatemanager.ValidateBYOKeyPair(keyPair, missingDNSNames)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("missing the DNS names: missing-name"))
+		})
+
+		It("accepts BYO secrets with wildcard DNS names", func() {
+			cryptoCA, err := tls.MakeCA("byo-ca")
+			Expect(err).NotTo(HaveOccurred())
+			wildcardSecret, err := secret.CreateTLSSecret(cryptoCA, appSecretName, appNs, corev1.TLSPrivateKeyKey, corev1.TLSCertKey, time.Hour, nil, "*.example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.Create(ctx, wildcardSecret)).NotTo(HaveOccurred())
+
+			keyPair, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificatemanager.ValidateBYOKeyPair(keyPair, []string{"gateway.example.com"})).NotTo(HaveOccurred())
+			Expect(certificatemanager.ValidateBYOKeyPair(keyPair, []string{"gateway.example.org"})).To(HaveOccurred())
+		})
+
 		It("renders the right spec for legacy certs (<= v1.24)", func() {
 			By("creating a legacy secret and then create a KeyPair using the certificateManager")
 			Expect(cli.Create(ctx, legacySecret)).NotTo(HaveOccurred())
@@ -234,6 +234,10 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 		}
 	}
 
+	if utils.IsReconcilePaused(&managementClusterConnection.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	if managementClusterConnection != nil && managementCluster != nil {
 		err = fmt.Errorf("having both a ManagementCluster and a ManagementClusterConnection is not supported")
 		r.status.SetDegraded(operatorv1.ResourceValidationError, "", err, reqLogger)
@@ -254,13 +258,13 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(instl, r.Client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return result, err
 	}
 
 	certificateManager, err := certificatemanager.Create(r.Client, instl, r.clusterDomain, common.OperatorNamespace())
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -318,14 +322,14 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 		} else if secret == nil {
 			reqLogger.Info(fmt.Sprintf("Waiting for secret '%s' to become available", secretName))
 			r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for secret '%s' to become available", secretName), nil, reqLogger)
-			return reconcile.Result{}, nil
+			return utils.RequeueWithBackoff(), nil
 		}
 		trustedCertBundle.AddCertificates(secret)
 	}
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
@@ -349,7 +353,7 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 			license, err := utils.FetchLicenseKey(ctx, r.Client)
 			if err != nil {
 				if k8serrors.IsNotFound(err) {
-					r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", err, reqLogger)
+					r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", status.WithCode(operatorv1.LicenseNotFound, err), reqLogger)
 					return reconcile.Result{}, nil
 				}
 				r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying license", err, reqLogger)
@@ -357,7 +361,7 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 			}
 
 			if !utils.IsFeatureActive(license, common.EgressAccessControlFeature) {
-				r.status.SetDegraded(operatorv1.ResourceReadError, "Feature is not active - License does not support feature: egress-access-control", nil, reqLogger)
+				r.status.SetDegraded(operatorv1.ResourceReadError, "Feature is not active - License does not support feature: egress-access-control", status.WithCode(operatorv1.LicenseFeatureNotAvailable, nil), reqLogger)
 				return reconcile.Result{}, nil
 			}
 		}
@@ -391,7 +395,7 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	if err = imageset.ApplyImageSet(ctx, r.Client, variant, components...); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
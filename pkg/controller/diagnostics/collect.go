@@ -0,0 +1,269 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/utils"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+)
+
+// Keys of the diagnostics bundle ConfigMap.
+const (
+	OperatorLogsKey        = "operator-logs.txt"
+	ResourcesKey           = "resources.yaml"
+	PodsKey                = "pods.txt"
+	ElasticsearchHealthKey = "elasticsearch-health.json"
+	ElasticsearchILMKey    = "elasticsearch-ilm-explain.json"
+	ErrorsKey              = "errors.txt"
+)
+
+const (
+	operatorLogsTailLines = 1000
+
+	// maxEntrySize bounds the size of each entry, so that the bundle stays below the size limit of a ConfigMap.
+	maxEntrySize    = 128 * 1024
+	truncatedMarker = "... truncated ...\n"
+
+	componentNamespacePrefix = "tigera-"
+)
+
+// operatorPodLabels select the pods of the operator.
+var operatorPodLabels = client.MatchingLabels{"k8s-app": "tigera-operator"}
+
+// resourceLists are the operator resources whose snapshots are included in the bundle. Resources whose CRD is not
+// installed, e.g. enterprise resources in a Calico cluster, are skipped.
+var resourceLists = []client.ObjectList{
+	&operatorv1.InstallationList{},
+	&operatorv1.TigeraStatusList{},
+	&operatorv1.APIServerList{},
+	&operatorv1.ImageSetList{},
+	&operatorv1.ApplicationLayerList{},
+	&operatorv1.AuthenticationList{},
+	&operatorv1.BGPTopologyList{},
+	&operatorv1.ComplianceList{},
+	&operatorv1.EgressGatewayList{},
+	&operatorv1.HostProtectionList{},
+	&operatorv1.IntrusionDetectionList{},
+	&operatorv1.LogCollectorList{},
+	&operatorv1.LogStorageList{},
+	&operatorv1.ManagementClusterList{},
+	&operatorv1.ManagementClusterConnectionList{},
+	&operatorv1.ManagerList{},
+	&operatorv1.MonitorList{},
+	&operatorv1.PacketCaptureAPIList{},
+	&operatorv1.PolicyRecommendationList{},
+	&operatorv1.RuntimeSecurityList{},
+	&operatorv1.TenantList{},
+	&operatorv1.WhiskerList{},
+}
+
+// collect gathers the diagnostics bundle. A failure to collect one part of the bundle doesn't stop the others from
+// being collected; it is recorded under ErrorsKey instead, so that a bundle is produced even for a broken cluster.
+func (r *ReconcileDiagnostics) collect(ctx context.Context) map[string]string {
+	data := map[string]string{}
+	var errs []string
+	record := func(key string, value string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+		}
+		if value != "" {
+			data[key] = truncate(value)
+		}
+	}
+
+	v, err := r.operatorLogs(ctx)
+	record(OperatorLogsKey, v, err)
+	v, err = r.resources(ctx)
+	record(ResourcesKey, v, err)
+	v, err = r.pods(ctx)
+	record(PodsKey, v, err)
+
+	health, ilm, err := r.elasticsearch(ctx)
+	record(ElasticsearchHealthKey, health, err)
+	record(ElasticsearchILMKey, ilm, nil)
+
+	if len(errs) > 0 {
+		data[ErrorsKey] = strings.Join(errs, "\n") + "\n"
+	}
+	return data
+}
+
+// operatorLogs returns the last lines of the logs of the operator pods.
+func (r *ReconcileDiagnostics) operatorLogs(ctx context.Context) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(common.OperatorNamespace()), operatorPodLabels); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	var errs []string
+	tail := int64(operatorLogsTailLines)
+	for _, pod := range pods.Items {
+		logs, err := r.k8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{TailLines: &tail}).DoRaw(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("pod %s: %v", pod.Name, err))
+			continue
+		}
+		fmt.Fprintf(&buf, "==> %s/%s <==\n%s\n", pod.Namespace, pod.Name, logs)
+	}
+	if len(errs) > 0 {
+		return buf.String(), fmt.Errorf("failed to get logs: %s", strings.Join(errs, "; "))
+	}
+	return buf.String(), nil
+}
+
+// resources returns the operator resources in the cluster as a YAML stream.
+func (r *ReconcileDiagnostics) resources(ctx context.Context) (string, error) {
+	var buf bytes.Buffer
+	var errs []string
+	for _, list := range resourceLists {
+		if err := r.client.List(ctx, list); err != nil {
+			if !meta.IsNoMatchError(err) && !errors.IsNotFound(err) {
+				errs = append(errs, fmt.Sprintf("%T: %v", list, err))
+			}
+			continue
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%T: %v", list, err))
+			continue
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			obj.SetManagedFields(nil)
+			out, err := yaml.Marshal(obj)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%T %s: %v", obj, obj.GetName(), err))
+				continue
+			}
+			buf.WriteString("---\n")
+			buf.Write(out)
+		}
+	}
+	if len(errs) > 0 {
+		return buf.String(), fmt.Errorf("failed to list resources: %s", strings.Join(errs, "; "))
+	}
+	return buf.String(), nil
+}
+
+// pods returns a table of the status of the pods in the namespaces of the operator and its components.
+func (r *ReconcileDiagnostics) pods(ctx context.Context) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods); err != nil {
+		return "", err
+	}
+	items := pods.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tPHASE\tREADY\tRESTARTS\tNODE\tREASON")
+	for _, pod := range items {
+		if !isComponentNamespace(pod.Namespace) {
+			continue
+		}
+		var ready, restarts int
+		var reasons []string
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				ready++
+			}
+			restarts += int(cs.RestartCount)
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+				reasons = append(reasons, fmt.Sprintf("%s: %s", cs.Name, cs.State.Waiting.Reason))
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d/%d\t%d\t%s\t%s\n", pod.Namespace, pod.Name, pod.Status.Phase,
+			ready, len(pod.Spec.Containers), restarts, pod.Spec.NodeName, strings.Join(reasons, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// elasticsearch returns the cluster health and ILM explain output of the Elasticsearch cluster managed by the
+// operator. Nothing is returned if the operator doesn't manage an Elasticsearch cluster.
+func (r *ReconcileDiagnostics) elasticsearch(ctx context.Context) (string, string, error) {
+	if r.elasticExternal || r.multiTenant {
+		return "", "", nil
+	}
+	ls := &operatorv1.LogStorage{}
+	if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, ls); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+
+	esClient, err := r.esCliCreator(r.client, ctx, relasticsearch.InternalElasticEndpoint(ls), false)
+	if err != nil {
+		return "", "", err
+	}
+	health, err := esClient.ClusterHealth(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get cluster health: %w", err)
+	}
+	ilm, err := esClient.ExplainILM(ctx)
+	if err != nil {
+		return indent(health), "", fmt.Errorf("failed to explain ILM: %w", err)
+	}
+	return indent(health), indent(ilm), nil
+}
+
+func isComponentNamespace(ns string) bool {
+	return ns == common.OperatorNamespace() || ns == common.CalicoNamespace || strings.HasPrefix(ns, componentNamespacePrefix)
+}
+
+// indent pretty prints the JSON response of Elasticsearch, so that the bundle is readable.
+func indent(raw json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// truncate keeps the end of entries larger than maxEntrySize, since the total size of a ConfigMap is limited.
+func truncate(s string) string {
+	if len(s) <= maxEntrySize {
+		return s
+	}
+	return truncatedMarker + s[len(s)-maxEntrySize+len(truncatedMarker):]
+}
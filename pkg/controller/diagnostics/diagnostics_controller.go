@@ -0,0 +1,142 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+)
+
+var log = logf.Log.WithName("controller_diagnostics")
+
+// BundleName is the name of the ConfigMap in the operator namespace that diagnostics bundles are written to.
+const BundleName = "tigera-diagnostics"
+
+// Add creates the diagnostics controller, which collects a diagnostics bundle for support cases when the Installation
+// is annotated with common.CollectDiagnosticsAnnotation.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	k8sClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to establish a connection to k8s: %w", err)
+	}
+	esCliCreator, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
+	r := &ReconcileDiagnostics{
+		client:          mgr.GetClient(),
+		k8sClient:       k8sClient,
+		esCliCreator:    esCliCreator,
+		elasticExternal: opts.ElasticExternal,
+		multiTenant:     opts.MultiTenant,
+	}
+
+	c, err := ctrlruntime.NewController("diagnostics-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Only changes to the annotation request a new bundle, so other changes to the Installation are filtered out.
+	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return requested(e.Object) != "" },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return requested(e.ObjectNew) != "" && requested(e.ObjectNew) != requested(e.ObjectOld)
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}); err != nil {
+		return fmt.Errorf("diagnostics-controller failed to watch Installation resource: %w", err)
+	}
+	return nil
+}
+
+// ReconcileDiagnostics writes a diagnostics bundle to the tigera-diagnostics ConfigMap each time the value of the
+// collect diagnostics annotation on the Installation changes.
+type ReconcileDiagnostics struct {
+	client          client.Client
+	k8sClient       kubernetes.Interface
+	esCliCreator    utils.ElasticsearchClientCreator
+	elasticExternal bool
+	multiTenant     bool
+}
+
+func (r *ReconcileDiagnostics) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	instance := &operatorv1.Installation{}
+	if err := r.client.Get(ctx, utils.DefaultInstanceKey, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	id := requested(instance)
+	if id == "" {
+		return reconcile.Result{}, nil
+	}
+
+	bundle := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: BundleName, Namespace: common.OperatorNamespace()}, bundle)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if err == nil && bundle.Annotations[common.CollectDiagnosticsAnnotation] == id {
+		// The bundle for this request has already been collected.
+		return reconcile.Result{}, nil
+	}
+
+	reqLogger.Info("Collecting diagnostics bundle", "request", id)
+	data := r.collect(ctx)
+
+	if errors.IsNotFound(err) {
+		bundle = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: BundleName, Namespace: common.OperatorNamespace()}}
+	}
+	bundle.Annotations = map[string]string{common.CollectDiagnosticsAnnotation: id}
+	bundle.Data = data
+	if bundle.ResourceVersion == "" {
+		err = r.client.Create(ctx, bundle)
+	} else {
+		err = r.client.Update(ctx, bundle)
+	}
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to write diagnostics bundle: %w", err)
+	}
+	reqLogger.Info("Wrote diagnostics bundle", "ConfigMap", client.ObjectKeyFromObject(bundle))
+	return reconcile.Result{}, nil
+}
+
+func requested(obj client.Object) string {
+	return obj.GetAnnotations()[common.CollectDiagnosticsAnnotation]
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/logstorage/elastic"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Diagnostics controller", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		esClient *elastic.MockESClient
+		r        *ReconcileDiagnostics
+		install  *operatorv1.Installation
+	)
+
+	getBundle := func() *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: BundleName, Namespace: common.OperatorNamespace()}, cm)).NotTo(HaveOccurred())
+		return cm
+	}
+
+	requestBundle := func(id string) {
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(install), install)).NotTo(HaveOccurred())
+		install.Annotations = map[string]string{common.CollectDiagnosticsAnnotation: id}
+		Expect(cli.Update(ctx, install)).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		esClient = &elastic.MockESClient{}
+		ctx = context.WithValue(context.Background(), elastic.MockESClientKey("mockESClient"), esClient)
+
+		operatorPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tigera-operator-abc",
+				Namespace: common.OperatorNamespace(),
+				Labels:    map[string]string{"k8s-app": "tigera-operator"},
+			},
+		}
+		r = &ReconcileDiagnostics{
+			client:       cli,
+			k8sClient:    kfake.NewSimpleClientset(operatorPod),
+			esCliCreator: elastic.MockESCLICreator,
+		}
+		Expect(cli.Create(ctx, operatorPod)).NotTo(HaveOccurred())
+
+		Expect(cli.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "calico-node-xyz", Namespace: common.CalicoNamespace},
+			Spec:       corev1.PodSpec{NodeName: "node1", Containers: []corev1.Container{{Name: "calico-node"}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:         "calico-node",
+					RestartCount: 4,
+					State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				}},
+			},
+		})).NotTo(HaveOccurred())
+		Expect(cli.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default"},
+		})).NotTo(HaveOccurred())
+
+		install = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operatorv1.InstallationSpec{Variant: operatorv1.Calico},
+		}
+		Expect(cli.Create(ctx, install)).NotTo(HaveOccurred())
+	})
+
+	It("should not collect a bundle unless one is requested", func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = cli.Get(ctx, client.ObjectKey{Name: BundleName, Namespace: common.OperatorNamespace()}, &corev1.ConfigMap{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should collect the operator logs, resources and pod statuses", func() {
+		requestBundle("case-1")
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+
+		bundle := getBundle()
+		Expect(bundle.Annotations).To(HaveKeyWithValue(common.CollectDiagnosticsAnnotation, "case-1"))
+		Expect(bundle.Data[OperatorLogsKey]).To(ContainSubstring(fmt.Sprintf("==> %s/tigera-operator-abc <==", common.OperatorNamespace())))
+		Expect(bundle.Data[ResourcesKey]).To(ContainSubstring("kind: Installation"))
+		Expect(bundle.Data[PodsKey]).To(ContainSubstring("calico-node-xyz"))
+		Expect(bundle.Data[PodsKey]).To(ContainSubstring("calico-node: CrashLoopBackOff"))
+		Expect(bundle.Data[PodsKey]).NotTo(ContainSubstring("unrelated"))
+		Expect(bundle.Data).NotTo(HaveKey(ElasticsearchHealthKey))
+		Expect(bundle.Data).NotTo(HaveKey(ErrorsKey))
+	})
+
+	It("should collect Elasticsearch health and ILM state when LogStorage is configured", func() {
+		Expect(cli.Create(ctx, &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
+		esClient.On("ClusterHealth", ctx).Return(json.RawMessage(`{"status":"yellow"}`), nil)
+		esClient.On("ExplainILM", ctx).Return(json.RawMessage(nil), fmt.Errorf("connection refused"))
+
+		requestBundle("case-1")
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+
+		bundle := getBundle()
+		Expect(bundle.Data[ElasticsearchHealthKey]).To(ContainSubstring(`"status": "yellow"`))
+		Expect(bundle.Data).NotTo(HaveKey(ElasticsearchILMKey))
+		Expect(bundle.Data[ErrorsKey]).To(ContainSubstring("failed to explain ILM: connection refused"))
+	})
+
+	It("should collect a new bundle only when the annotation changes", func() {
+		requestBundle("case-1")
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+
+		bundle := getBundle()
+		bundle.Data = map[string]string{"marker": "old"}
+		Expect(cli.Update(ctx, bundle)).NotTo(HaveOccurred())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getBundle().Data).To(HaveKey("marker"))
+
+		requestBundle("case-2")
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		bundle = getBundle()
+		Expect(bundle.Data).NotTo(HaveKey("marker"))
+		Expect(bundle.Annotations).To(HaveKeyWithValue(common.CollectDiagnosticsAnnotation, "case-2"))
+	})
+
+	It("should keep the end of entries that are too large", func() {
+		s := truncate(strings.Repeat("a", maxEntrySize) + "end")
+		Expect(s).To(HaveLen(maxEntrySize))
+		Expect(s).To(HavePrefix(truncatedMarker))
+		Expect(s).To(HaveSuffix("end"))
+	})
+})
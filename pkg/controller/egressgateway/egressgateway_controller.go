@@ -22,6 +22,7 @@ import (
 
 	"github.com/go-logr/logr"
 	ocsv1 "github.com/openshift/api/security/v1"
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -39,6 +40,7 @@ import (
 	operatorv1 "github.com/tigera/operator/api/v1"
 
 	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
@@ -47,6 +49,7 @@ import (
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/egressgateway"
 )
 
@@ -64,8 +67,9 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return nil
 	}
 	licenseAPIReady := &utils.ReadyFlag{}
+	tierWatchReady := &utils.ReadyFlag{}
 
-	reconciler := newReconciler(mgr, opts, licenseAPIReady)
+	reconciler := newReconciler(mgr, opts, licenseAPIReady, tierWatchReady)
 
 	c, err := ctrlruntime.NewController("egressgateway-controller", mgr, controller.Options{Reconciler: reconcile.Reconciler(reconciler)})
 	if err != nil {
@@ -79,12 +83,13 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	go utils.WaitToAddLicenseKeyWatch(c, k8sClient, log, licenseAPIReady)
+	go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
 
 	return add(mgr, c)
 }
 
 // newReconciler returns a new *reconcile.Reconciler.
-func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
 	r := &ReconcileEgressGateway{
 		client:          mgr.GetClient(),
 		scheme:          mgr.GetScheme(),
@@ -92,6 +97,7 @@ func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady
 		status:          status.New(mgr.GetClient(), "egressgateway", opts.KubernetesVersion),
 		clusterDomain:   opts.ClusterDomain,
 		licenseAPIReady: licenseAPIReady,
+		tierWatchReady:  tierWatchReady,
 	}
 	r.status.Run(opts.ShutdownContext)
 	return r
@@ -141,6 +147,7 @@ type ReconcileEgressGateway struct {
 	status          status.StatusManager
 	clusterDomain   string
 	licenseAPIReady *utils.ReadyFlag
+	tierWatchReady  *utils.ReadyFlag
 }
 
 // Reconcile reads that state of the cluster for an EgressGateway object and makes changes
@@ -241,11 +248,25 @@ func (r *ReconcileEgressGateway) Reconcile(ctx context.Context, request reconcil
 	}
 	r.status.OnCRFound()
 
+	// Leave the Egress Gateways whose reconciliation is paused as they are.
+	var unpaused []operatorv1.EgressGateway
+	for _, egw := range egwsToReconcile {
+		if common.IsReconcilePaused(&egw) {
+			reqLogger.Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation, "name", egw.Name, "namespace", egw.Namespace)
+			continue
+		}
+		unpaused = append(unpaused, egw)
+	}
+	if len(unpaused) == 0 {
+		return reconcile.Result{}, nil
+	}
+	egwsToReconcile = unpaused
+
 	// Get the unready EGW.
 	unreadyEGW := getUnreadyEgressGateway(egws)
 
 	if !r.licenseAPIReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", status.WithCode(operatorv1.LicenseAPINotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
@@ -253,7 +274,7 @@ func (r *ReconcileEgressGateway) Reconcile(ctx context.Context, request reconcil
 	if err != nil {
 		if errors.IsNotFound(err) {
 			reqLogger.Error(err, "Installation not found")
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			// Set the EGW resource's condition to Degraded.
 			for _, egw := range egwsToReconcile {
 				setDegraded(r.client, ctx, &egw, reconcileErr, fmt.Sprintf("Installation not found err = %s", err.Error()))
@@ -296,7 +317,7 @@ func (r *ReconcileEgressGateway) Reconcile(ctx context.Context, request reconcil
 	pullSecrets, err := utils.GetNetworkingPullSecrets(installation, r.client)
 	if err != nil {
 		reqLogger.Error(err, "Error retrieving pull secrets")
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		for _, egw := range egwsToReconcile {
 			setDegraded(r.client, ctx, &egw, reconcileErr, fmt.Sprintf("Error retrieving pull secrets err = %s", err.Error()))
 		}
@@ -320,10 +341,25 @@ func (r *ReconcileEgressGateway) Reconcile(ctx context.Context, request reconcil
 		return reconcile.Result{}, err
 	}
 
+	// Egress gateways carry application traffic, so they are not held back waiting for the allow-tigera tier. The
+	// policy that allows the gateway's own traffic is rendered once the tier exists.
+	includeV3NetworkPolicy := false
+	if r.tierWatchReady.IsReady() {
+		if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
+			if !errors.IsNotFound(err) {
+				reqLogger.Error(err, "Error querying allow-tigera tier")
+				r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
+				return reconcile.Result{}, err
+			}
+		} else {
+			includeV3NetworkPolicy = true
+		}
+	}
+
 	// Reconcile all the EGWs
 	var errMsgs []string
 	for _, egw := range egwsToReconcile {
-		err = r.reconcileEgressGateway(ctx, &egw, reqLogger, variant, fc, pullSecrets, installation, namespaceAndNames)
+		err = r.reconcileEgressGateway(ctx, &egw, reqLogger, variant, fc, pullSecrets, installation, namespaceAndNames, includeV3NetworkPolicy)
 		if err != nil {
 			reqLogger.Error(err, "Error reconciling egress gateway")
 			errMsgs = append(errMsgs, err.Error())
@@ -350,7 +386,7 @@ func (r *ReconcileEgressGateway) Reconcile(ctx context.Context, request reconcil
 
 func (r *ReconcileEgressGateway) reconcileEgressGateway(ctx context.Context, egw *operatorv1.EgressGateway, reqLogger logr.Logger,
 	variant operatorv1.ProductVariant, fc *crdv1.FelixConfiguration, pullSecrets []*v1.Secret,
-	installation *operatorv1.InstallationSpec, namespaceAndNames []string,
+	installation *operatorv1.InstallationSpec, namespaceAndNames []string, includeV3NetworkPolicy bool,
 ) error {
 	preDefaultPatchFrom := client.MergeFrom(egw.DeepCopy())
 	// update the EGW resource with default values.
@@ -403,6 +439,8 @@ func (r *ReconcileEgressGateway) reconcileEgressGateway(ctx context.Context, egw
 		IptablesBackend:   ipTablesBackend,
 		OpenShift:         r.provider.IsOpenShift(),
 		NamespaceAndNames: namespaceAndNames,
+
+		IncludeV3NetworkPolicy: includeV3NetworkPolicy,
 	}
 
 	component := egressgateway.EgressGateway(config)
@@ -410,7 +448,7 @@ func (r *ReconcileEgressGateway) reconcileEgressGateway(ctx context.Context, egw
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
 		reqLogger.Error(err, "Error with images from ImageSet")
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		setDegraded(r.client, ctx, egw, reconcileErr, fmt.Sprintf("Error with images from ImageSet err = %s", err.Error()))
 		return err
 	}
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	ocsv1 "github.com/openshift/api/security/v1"
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -96,6 +97,7 @@ var _ = Describe("Egress Gateway controller tests", func() {
 				scheme:          scheme,
 				status:          mockStatus,
 				licenseAPIReady: &utils.ReadyFlag{},
+				tierWatchReady:  &utils.ReadyFlag{},
 			}
 
 			Expect(c.Create(ctx, &crdv1.IPPool{ObjectMeta: metav1.ObjectMeta{Name: "ippool-1"}, Spec: crdv1.IPPoolSpec{
@@ -400,6 +402,44 @@ var _ = Describe("Egress Gateway controller tests", func() {
 
 		})
 
+		It("should render the allow-tigera policy once the tier exists", func() {
+			mockStatus.On("AddDaemonsets", mock.Anything).Return()
+			mockStatus.On("AddDeployments", mock.Anything).Return()
+			mockStatus.On("IsAvailable").Return(true)
+			mockStatus.On("AddStatefulSets", mock.Anything).Return()
+			mockStatus.On("AddCronJobs", mock.Anything)
+			mockStatus.On("ClearDegraded")
+			mockStatus.On("ReadyToMonitor")
+			Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+
+			logSeverity := operatorv1.LogLevelInfo
+			egw := &operatorv1.EgressGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "calico-red", Namespace: "calico-egress"},
+				Spec: operatorv1.EgressGatewaySpec{
+					LogSeverity: &logSeverity,
+					IPPools:     []operatorv1.EgressGatewayIPPool{{Name: "ippool-1"}},
+				},
+				Status: operatorv1.EgressGatewayStatus{State: operatorv1.TigeraStatusReady},
+			}
+			Expect(c.Create(ctx, egw)).NotTo(HaveOccurred())
+
+			policy := &v3.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera.calico-red", Namespace: "calico-egress"}}
+
+			By("not rendering the policy while the tier does not exist")
+			r.tierWatchReady.MarkAsReady()
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(test.GetResource(c, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "calico-red", Namespace: "calico-egress"}})).To(BeNil())
+			Expect(test.GetResource(c, policy)).NotTo(BeNil())
+
+			By("rendering the policy after the tier is created")
+			Expect(c.Create(ctx, &v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera"}})).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(test.GetResource(c, policy)).To(BeNil())
+			Expect(policy.Spec.Selector).To(Equal("projectcalico.org/egw == 'calico-red'"))
+		})
+
 		It("should use a single scc when EGW is created in openshift", func() {
 			mockStatus.On("AddDaemonsets", mock.Anything).Return()
 			mockStatus.On("AddDeployments", mock.Anything).Return()
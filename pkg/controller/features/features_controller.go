@@ -0,0 +1,138 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+)
+
+var log = logf.Log.WithName("controller_features")
+
+// Add creates the features controller, which creates or deletes the custom resources of the optional subsystems that
+// are enabled or disabled by the Features of the Installation. The subsystems without a custom resource, Kibana and
+// the Elasticsearch metrics exporter, are enabled or disabled by the log storage controllers.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		// No need to start this controller.
+		return nil
+	}
+	// The subsystems of multi-tenant clusters are configured per tenant.
+	if opts.MultiTenant {
+		return nil
+	}
+
+	r := &ReconcileFeatures{client: mgr.GetClient()}
+	c, err := ctrlruntime.NewController("features-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	if err = utils.AddInstallationWatch(c); err != nil {
+		return fmt.Errorf("features-controller failed to watch Installation resource: %w", err)
+	}
+	// The custom resources are watched so that they are recreated, or deleted again, when they are changed by hand.
+	for _, obj := range []client.Object{&operatorv1.Compliance{}, &operatorv1.IntrusionDetection{}, &operatorv1.PacketCaptureAPI{}} {
+		if err = c.WatchObject(obj, &handler.EnqueueRequestForObject{}); err != nil {
+			return fmt.Errorf("features-controller failed to watch %T resource: %w", obj, err)
+		}
+	}
+	return nil
+}
+
+// ReconcileFeatures creates the default custom resource of each subsystem that the Installation Features enable, and
+// deletes it for each subsystem that they disable. Subsystems that are not set are left as they are.
+type ReconcileFeatures struct {
+	client client.Client
+}
+
+func (r *ReconcileFeatures) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	instance := &operatorv1.Installation{}
+	if err := r.client.Get(ctx, utils.DefaultInstanceKey, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if common.IsReconcilePaused(instance) {
+		reqLogger.V(1).Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+		return reconcile.Result{}, nil
+	}
+
+	features := instance.Spec.Features
+	if features == nil || instance.Spec.Variant != operatorv1.TigeraSecureEnterprise || instance.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	meta := metav1.ObjectMeta{Name: utils.DefaultTSEEInstanceKey.Name}
+	for _, f := range []struct {
+		state *operatorv1.FeatureState
+		obj   client.Object
+	}{
+		{features.Compliance, &operatorv1.Compliance{ObjectMeta: meta}},
+		{features.IntrusionDetection, &operatorv1.IntrusionDetection{ObjectMeta: meta}},
+		{features.PacketCapture, &operatorv1.PacketCaptureAPI{ObjectMeta: meta}},
+	} {
+		if err := r.reconcileFeature(ctx, f.state, f.obj, reqLogger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// reconcileFeature creates the custom resource if the feature is enabled and it doesn't exist, or deletes it if the
+// feature is disabled. An existing custom resource of an enabled feature is not modified, so that it can still be
+// used to configure the subsystem.
+func (r *ReconcileFeatures) reconcileFeature(ctx context.Context, state *operatorv1.FeatureState, obj client.Object, reqLogger logr.Logger) error {
+	logCtx := utils.ContextLoggerForResource(reqLogger, obj)
+	switch {
+	case operatorv1.IsFeatureEnabled(state):
+		err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object))
+		if err == nil || !errors.IsNotFound(err) {
+			return err
+		}
+		logCtx.Info("Creating the custom resource of an enabled feature")
+		if err := r.client.Create(ctx, obj); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+	case operatorv1.IsFeatureDisabled(state):
+		err := r.client.Delete(ctx, obj)
+		if err == nil {
+			logCtx.Info("Deleted the custom resource of a disabled feature")
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Features controller", func() {
+	var (
+		cli     client.Client
+		ctx     context.Context
+		r       *ReconcileFeatures
+		install *operatorv1.Installation
+	)
+
+	enabled := operatorv1.FeatureEnabled
+	disabled := operatorv1.FeatureDisabled
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		r = &ReconcileFeatures{client: cli}
+
+		install = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operatorv1.InstallationSpec{Variant: operatorv1.TigeraSecureEnterprise},
+		}
+	})
+
+	exists := func(obj client.Object) bool {
+		obj.SetName(utils.DefaultTSEEInstanceKey.Name)
+		err := cli.Get(ctx, utils.DefaultTSEEInstanceKey, obj)
+		if errors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).ShouldNot(HaveOccurred())
+		return true
+	}
+
+	It("should create the custom resources of enabled features", func() {
+		install.Spec.Features = &operatorv1.Features{
+			Compliance:         &enabled,
+			IntrusionDetection: &enabled,
+			PacketCapture:      &enabled,
+		}
+		Expect(cli.Create(ctx, install)).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(exists(&operatorv1.Compliance{})).To(BeTrue())
+		Expect(exists(&operatorv1.IntrusionDetection{})).To(BeTrue())
+		Expect(exists(&operatorv1.PacketCaptureAPI{})).To(BeTrue())
+	})
+
+	It("should not modify the existing custom resource of an enabled feature", func() {
+		install.Spec.Features = &operatorv1.Features{Compliance: &enabled}
+		Expect(cli.Create(ctx, install)).ShouldNot(HaveOccurred())
+		cr := &operatorv1.Compliance{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure", Labels: map[string]string{"user": "label"}},
+		}
+		Expect(cli.Create(ctx, cr)).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, cr)).ShouldNot(HaveOccurred())
+		Expect(cr.Labels).To(HaveKeyWithValue("user", "label"))
+	})
+
+	It("should delete the custom resources of disabled features and leave unset features alone", func() {
+		install.Spec.Features = &operatorv1.Features{
+			Compliance:         &disabled,
+			IntrusionDetection: &disabled,
+		}
+		Expect(cli.Create(ctx, install)).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, &operatorv1.Compliance{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, &operatorv1.PacketCaptureAPI{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(exists(&operatorv1.Compliance{})).To(BeFalse())
+		Expect(exists(&operatorv1.IntrusionDetection{})).To(BeFalse())
+		Expect(exists(&operatorv1.PacketCaptureAPI{})).To(BeTrue())
+	})
+
+	It("should do nothing for the Calico variant", func() {
+		install.Spec.Variant = operatorv1.Calico
+		install.Spec.Features = &operatorv1.Features{Compliance: &enabled}
+		Expect(cli.Create(ctx, install)).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(exists(&operatorv1.Compliance{})).To(BeFalse())
+	})
+
+	It("should do nothing without an Installation", func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
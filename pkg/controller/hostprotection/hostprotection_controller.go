@@ -0,0 +1,320 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostprotection
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/hostprotection"
+)
+
+const ResourceName = "host-protection"
+
+var log = logf.Log.WithName("controller_hostprotection")
+
+// Add creates a new HostProtection Controller and adds it to the Manager.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	tierWatchReady := &utils.ReadyFlag{}
+
+	reconciler := newReconciler(mgr, opts, tierWatchReady)
+
+	c, err := ctrlruntime.NewController("hostprotection-controller", mgr, controller.Options{Reconciler: reconciler})
+	if err != nil {
+		return err
+	}
+
+	if opts.EnterpriseCRDExists {
+		k8sClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			log.Error(err, "Failed to establish a connection to k8s")
+			return err
+		}
+
+		go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
+		go utils.WaitToAddResourceWatch(c, k8sClient, log, nil, []client.Object{
+			&v3.GlobalNetworkPolicy{
+				TypeMeta:   metav1.TypeMeta{Kind: "GlobalNetworkPolicy", APIVersion: "projectcalico.org/v3"},
+				ObjectMeta: metav1.ObjectMeta{Name: hostprotection.FailsafePolicyName},
+			},
+		})
+	} else {
+		// The failsafe policy is only rendered for Calico Enterprise, so there is no tier to wait for.
+		tierWatchReady.MarkAsReady()
+	}
+
+	return add(c)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, opts options.AddOptions, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
+	r := &ReconcileHostProtection{
+		client:         mgr.GetClient(),
+		scheme:         mgr.GetScheme(),
+		status:         status.New(mgr.GetClient(), ResourceName, opts.KubernetesVersion),
+		tierWatchReady: tierWatchReady,
+	}
+	r.status.Run(opts.ShutdownContext)
+	return r
+}
+
+// add adds watches for resources that are available at startup.
+func add(c ctrlruntime.Controller) error {
+	if err := c.WatchObject(&operatorv1.HostProtection{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("hostprotection-controller failed to watch HostProtection resource: %w", err)
+	}
+
+	if err := utils.AddInstallationWatch(c); err != nil {
+		return fmt.Errorf("hostprotection-controller failed to watch Installation resource: %w", err)
+	}
+
+	if err := utils.AddAPIServerWatch(c); err != nil {
+		return fmt.Errorf("hostprotection-controller failed to watch APIServer resource: %w", err)
+	}
+
+	// Nodes report their status frequently, so only the changes that affect the rendered HostEndpoints trigger a
+	// reconcile.
+	if err := c.WatchObject(&corev1.Node{}, &handler.EnqueueRequestForObject{}, nodePredicate()); err != nil {
+		return fmt.Errorf("hostprotection-controller failed to watch Node resource: %w", err)
+	}
+
+	if err := utils.AddTigeraStatusWatch(c, ResourceName); err != nil {
+		return fmt.Errorf("hostprotection-controller failed to watch host-protection Tigerastatus: %w", err)
+	}
+
+	return nil
+}
+
+func nodePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			return !reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+				!reflect.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses)
+		},
+	}
+}
+
+// Blank assignment to verify that ReconcileHostProtection implements reconcile.Reconciler.
+var _ reconcile.Reconciler = &ReconcileHostProtection{}
+
+// ReconcileHostProtection reconciles the HostEndpoints of the nodes of the cluster.
+type ReconcileHostProtection struct {
+	client         client.Client
+	scheme         *runtime.Scheme
+	status         status.StatusManager
+	tierWatchReady *utils.ReadyFlag
+}
+
+func (r *ReconcileHostProtection) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling HostProtection")
+
+	instance := &operatorv1.HostProtection{}
+	err := r.client.Get(ctx, utils.DefaultInstanceKey, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			reqLogger.Info("HostProtection object not found")
+			r.status.OnCRNotFound()
+			return r.removeHostEndpoints(ctx, reqLogger)
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying HostProtection", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	r.status.OnCRFound()
+	// SetMetaData in the TigeraStatus such as observedGenerations.
+	defer r.status.SetMetaData(&instance.ObjectMeta)
+
+	// Changes for updating HostProtection status conditions.
+	if request.Name == ResourceName && request.Namespace == "" {
+		ts := &operatorv1.TigeraStatus{}
+		err := r.client.Get(ctx, types.NamespacedName{Name: ResourceName}, ts)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		instance.Status.Conditions = status.UpdateStatusCondition(instance.Status.Conditions, ts.Status.Conditions)
+		if err := r.client.Status().Update(ctx, instance); err != nil {
+			log.WithValues("reason", err).Info("Failed to create HostProtection status conditions.")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
+	preDefaultPatchFrom := client.MergeFrom(instance.DeepCopy())
+
+	fillDefaults(instance)
+
+	// Write the defaults back to the datastore.
+	if err = r.client.Patch(ctx, instance, preDefaultPatchFrom); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to write defaults to HostProtection", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	variant, _, err := utils.GetInstallation(ctx, r.client)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
+			return reconcile.Result{}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	// HostEndpoints are written through the API server.
+	if !utils.IsAPIServerReady(r.client, reqLogger) {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", status.WithCode(operatorv1.APIServerNotReady, nil), reqLogger)
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
+	if variant == operatorv1.TigeraSecureEnterprise {
+		// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
+		if !r.tierWatchReady.IsReady() {
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
+			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		}
+
+		// Ensure the allow-tigera tier exists, before rendering the failsafe policy within it.
+		if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", status.WithCode(operatorv1.TierNotReady, err), reqLogger)
+				return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+			}
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
+	nodes := &corev1.NodeList{}
+	if err = r.client.List(ctx, nodes, client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(instance.Spec.NodeSelector)}); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying nodes", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	existing, err := r.existingHostEndpoints(ctx)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying HostEndpoints", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	component := hostprotection.HostProtection(&hostprotection.Config{
+		HostProtection:        instance,
+		Nodes:                 nodes.Items,
+		ExistingHostEndpoints: existing,
+		FailsafePolicy:        *instance.Spec.FailsafePolicy == operatorv1.FailsafePolicyEnabled,
+		Variant:               variant,
+	})
+
+	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	if err = handler.CreateOrUpdateOrDelete(ctx, component, r.status); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating / deleting resource", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	r.status.ReadyToMonitor()
+	r.status.ClearDegraded()
+
+	instance.Status.State = operatorv1.TigeraStatusReady
+	if err = r.client.Status().Update(ctx, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// removeHostEndpoints deletes the HostEndpoints and the failsafe policy after the HostProtection is deleted.
+func (r *ReconcileHostProtection) removeHostEndpoints(ctx context.Context, reqLogger logr.Logger) (reconcile.Result, error) {
+	if !utils.IsAPIServerReady(r.client, reqLogger) {
+		// Without the API server the HostEndpoints cannot have been created.
+		return reconcile.Result{}, nil
+	}
+
+	variant, _, err := utils.GetInstallation(ctx, r.client)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	existing, err := r.existingHostEndpoints(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	component := hostprotection.HostProtection(&hostprotection.Config{
+		ExistingHostEndpoints: existing,
+		Variant:               variant,
+	})
+	handler := utils.NewComponentHandler(log, r.client, r.scheme, nil)
+	if err = handler.CreateOrUpdateOrDelete(ctx, component, nil); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// existingHostEndpoints returns the HostEndpoints that were created by the operator.
+func (r *ReconcileHostProtection) existingHostEndpoints(ctx context.Context) ([]v3.HostEndpoint, error) {
+	heps := &v3.HostEndpointList{}
+	if err := r.client.List(ctx, heps, client.MatchingLabels{hostprotection.CreatedByLabel: hostprotection.CreatedByValue}); err != nil {
+		return nil, err
+	}
+	return heps.Items, nil
+}
+
+// fillDefaults populates the default values onto a HostProtection object.
+func fillDefaults(instance *operatorv1.HostProtection) {
+	if instance.Spec.InterfaceName == "" {
+		instance.Spec.InterfaceName = hostprotection.AllInterfaces
+	}
+	if instance.Spec.FailsafePolicy == nil {
+		enabled := operatorv1.FailsafePolicyEnabled
+		instance.Spec.FailsafePolicy = &enabled
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostprotection
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render/hostprotection"
+)
+
+var _ = Describe("HostProtection controller tests", func() {
+	var r ReconcileHostProtection
+	var c client.Client
+	var ctx context.Context
+	var scheme *runtime.Scheme
+	var mockStatus *status.MockStatus
+
+	node := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	BeforeEach(func() {
+		// The schema contains all objects that should be known to the fake client when the test runs.
+		scheme = runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(operatorv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+
+		// Create a client that will have a crud interface of k8s objects.
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		mockStatus = &status.MockStatus{}
+		mockStatus.On("OnCRFound").Return()
+		mockStatus.On("SetMetaData", mock.Anything).Return()
+		mockStatus.On("ReadyToMonitor")
+		mockStatus.On("ClearDegraded")
+
+		readyFlag := &utils.ReadyFlag{}
+		readyFlag.MarkAsReady()
+
+		r = ReconcileHostProtection{
+			client:         c,
+			scheme:         scheme,
+			status:         mockStatus,
+			tierWatchReady: readyFlag,
+		}
+
+		Expect(c.Create(ctx, &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operatorv1.InstallationSpec{Variant: operatorv1.TigeraSecureEnterprise},
+			Status: operatorv1.InstallationStatus{
+				Variant:  operatorv1.TigeraSecureEnterprise,
+				Computed: &operatorv1.InstallationSpec{},
+			},
+		})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &operatorv1.APIServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Status:     operatorv1.APIServerStatus{State: operatorv1.TigeraStatusReady},
+		})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera"}})).NotTo(HaveOccurred())
+
+		Expect(c.Create(ctx, node("node-a", map[string]string{"role": "worker"}))).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, node("node-b", map[string]string{"role": "control-plane"}))).NotTo(HaveOccurred())
+	})
+
+	It("creates a HostEndpoint for each selected node and the failsafe policy", func() {
+		Expect(c.Create(ctx, &operatorv1.HostProtection{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operatorv1.HostProtectionSpec{NodeSelector: map[string]string{"role": "worker"}},
+		})).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		heps := &v3.HostEndpointList{}
+		Expect(c.List(ctx, heps)).NotTo(HaveOccurred())
+		Expect(heps.Items).To(HaveLen(1))
+		Expect(heps.Items[0].Name).To(Equal("node-a-tigera-hep"))
+		Expect(heps.Items[0].Spec.Node).To(Equal("node-a"))
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: hostprotection.FailsafePolicyName}, &v3.GlobalNetworkPolicy{})).NotTo(HaveOccurred())
+
+		// The defaults are written back to the HostProtection.
+		instance := &operatorv1.HostProtection{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, instance)).NotTo(HaveOccurred())
+		Expect(instance.Spec.InterfaceName).To(Equal("*"))
+		Expect(*instance.Spec.FailsafePolicy).To(Equal(operatorv1.FailsafePolicyEnabled))
+		Expect(instance.Status.State).To(Equal(operatorv1.TigeraStatusReady))
+	})
+
+	It("deletes the HostEndpoints of nodes that are no longer selected", func() {
+		instance := &operatorv1.HostProtection{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+		Expect(c.Create(ctx, instance)).NotTo(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		heps := &v3.HostEndpointList{}
+		Expect(c.List(ctx, heps)).NotTo(HaveOccurred())
+		Expect(heps.Items).To(HaveLen(2))
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, instance)).NotTo(HaveOccurred())
+		instance.Spec.NodeSelector = map[string]string{"role": "control-plane"}
+		Expect(c.Update(ctx, instance)).NotTo(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.List(ctx, heps)).NotTo(HaveOccurred())
+		Expect(heps.Items).To(HaveLen(1))
+		Expect(heps.Items[0].Name).To(Equal("node-b-tigera-hep"))
+	})
+
+	It("does not touch HostEndpoints that were not created by the operator", func() {
+		Expect(c.Create(ctx, &v3.HostEndpoint{ObjectMeta: metav1.ObjectMeta{Name: "user-hep"}})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &operatorv1.HostProtection{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		heps := &v3.HostEndpointList{}
+		Expect(c.List(ctx, heps)).NotTo(HaveOccurred())
+		Expect(heps.Items).To(HaveLen(3))
+	})
+
+	It("removes the HostEndpoints and the failsafe policy when the HostProtection is deleted", func() {
+		instance := &operatorv1.HostProtection{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+		Expect(c.Create(ctx, instance)).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &v3.HostEndpoint{ObjectMeta: metav1.ObjectMeta{Name: "user-hep"}})).NotTo(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		mockStatus.On("OnCRNotFound").Return()
+		Expect(c.Delete(ctx, instance)).NotTo(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		heps := &v3.HostEndpointList{}
+		Expect(c.List(ctx, heps)).NotTo(HaveOccurred())
+		Expect(heps.Items).To(HaveLen(1))
+		Expect(heps.Items[0].Name).To(Equal("user-hep"))
+
+		err = c.Get(ctx, client.ObjectKey{Name: hostprotection.FailsafePolicyName}, &v3.GlobalNetworkPolicy{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("waits for the API server to be available", func() {
+		Expect(c.Delete(ctx, &operatorv1.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &operatorv1.HostProtection{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", mock.Anything, mock.Anything).Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(utils.StandardRetry))
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", mock.Anything, mock.Anything)
+	})
+})
@@ -16,11 +16,15 @@ package installation
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/tigera/operator/pkg/controller/utils"
 
+	operator "github.com/tigera/operator/api/v1"
 	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/render"
@@ -28,6 +32,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+const (
+	// bpfEnabledAtAnnotation records on the FelixConfiguration when the operator enabled the eBPF dataplane. It is
+	// removed once calico-node is ready on every node.
+	bpfEnabledAtAnnotation = "operator.tigera.io/bpfEnabledAt"
+
+	// bpfRolledBackAnnotation records on the FelixConfiguration the Installation generation for which the operator
+	// rolled back the eBPF dataplane, so that it is not enabled again until the Installation changes.
+	bpfRolledBackAnnotation = "operator.tigera.io/bpfRolledBackGeneration"
+
+	// bpfRollbackTimeout is how long calico-node may stay unready after enabling the eBPF dataplane before the
+	// operator rolls it back.
+	bpfRollbackTimeout = 10 * time.Minute
+)
+
 // bpfValidateAnnotations validate Felix Configuration annotations match BPF Enabled spec for all scenarios.
 func bpfValidateAnnotations(fc *crdv1.FelixConfiguration) error {
 	var annotationValue *bool
@@ -118,3 +136,47 @@ func bpfEnabledOnDaemonsetWithEnvVar(ds *appsv1.DaemonSet) (bool, error) {
 func bpfEnabledOnFelixConfig(fc *crdv1.FelixConfiguration) bool {
 	return fc.Spec.BPFEnabled != nil && *fc.Spec.BPFEnabled
 }
+
+// bpfNodesNotReady returns the nodes whose calico-node pod is not ready.
+func bpfNodesNotReady(pods []corev1.Pod) []operator.BPFNodeStatus {
+	var notReady []operator.BPFNodeStatus
+	for _, p := range pods {
+		ready := false
+		for _, c := range p.Status.Conditions {
+			if c.Type == corev1.PodReady {
+				ready = c.Status == corev1.ConditionTrue
+			}
+		}
+		if !ready {
+			notReady = append(notReady, operator.BPFNodeStatus{Name: p.Spec.NodeName, Reason: fmt.Sprintf("calico-node pod %s is not ready", p.Name)})
+		}
+	}
+	return notReady
+}
+
+// bpfRolledBack returns true if the operator rolled back the eBPF dataplane for the current Installation generation.
+func bpfRolledBack(fc *crdv1.FelixConfiguration, install *operator.Installation) bool {
+	return fc.Annotations[bpfRolledBackAnnotation] == strconv.FormatInt(install.Generation, 10)
+}
+
+// bpfRollbackDue returns true if the eBPF dataplane was enabled by the operator longer than the rollback timeout ago.
+func bpfRollbackDue(fc *crdv1.FelixConfiguration, now time.Time) bool {
+	enabledAt, ok := fc.Annotations[bpfEnabledAtAnnotation]
+	if !ok {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, enabledAt)
+	if err != nil {
+		return false
+	}
+	return now.Sub(t) > bpfRollbackTimeout
+}
+
+// bpfNodeNames returns the names of the nodes as a comma separated list.
+func bpfNodeNames(nodes []operator.BPFNodeStatus) string {
+	var names []string
+	for _, n := range nodes {
+		names = append(names, n.Name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -16,6 +16,7 @@ package installation
 
 import (
 	"strconv"
+	"time"
 
 	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
 	"github.com/tigera/operator/pkg/common"
@@ -252,6 +253,20 @@ var _ = Describe("BPF functional tests", func() {
 		})
 	})
 
+	Context("preflight tests", func() {
+		It("should only roll back after the timeout", func() {
+			now := time.Now()
+			fc := &crdv1.FelixConfiguration{}
+			Expect(bpfRollbackDue(fc, now)).To(BeFalse())
+
+			fc.Annotations = map[string]string{bpfEnabledAtAnnotation: now.Add(-time.Minute).Format(time.RFC3339)}
+			Expect(bpfRollbackDue(fc, now)).To(BeFalse())
+
+			fc.Annotations[bpfEnabledAtAnnotation] = now.Add(-bpfRollbackTimeout - time.Minute).Format(time.RFC3339)
+			Expect(bpfRollbackDue(fc, now)).To(BeTrue())
+		})
+	})
+
 	Context("setBPFEnabledOnFelixConfiguration tests", func() {
 		var fc *crdv1.FelixConfiguration
 
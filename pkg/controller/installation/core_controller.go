@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/elastic/cloud-on-k8s/v2/pkg/utils/stringsutil"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
@@ -73,13 +74,17 @@ import (
 	"github.com/tigera/operator/pkg/crds"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/preflight"
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/resourcequota"
+	"github.com/tigera/operator/pkg/render/imageverification"
 	"github.com/tigera/operator/pkg/render/kubecontrollers"
 	"github.com/tigera/operator/pkg/render/monitor"
+	"github.com/tigera/operator/pkg/render/whisker"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+	"github.com/tigera/operator/version"
 )
 
 const (
@@ -192,6 +197,7 @@ func newReconciler(mgr manager.Manager, opts options.AddOptions) (*ReconcileInst
 		enterpriseCRDsExist:  opts.EnterpriseCRDExists,
 		clusterDomain:        opts.ClusterDomain,
 		manageCRDs:           opts.ManageCRDs,
+		whiskerEnabled:       opts.WhiskerEnabled,
 		tierWatchReady:       &utils.ReadyFlag{},
 		newComponentHandler:  utils.NewComponentHandler,
 	}
@@ -307,12 +313,31 @@ func add(c ctrlruntime.Controller, r *ReconcileInstallation) error {
 		}
 	}
 
+	if r.whiskerEnabled {
+		// Watch for changes to Whisker, which determines whether Felix sends flow logs to Goldmane.
+		err = c.WatchObject(&operator.Whisker{}, &handler.EnqueueRequestForObject{})
+		if err != nil {
+			return fmt.Errorf("tigera-installation-controller failed to watch Whisker resource: %w", err)
+		}
+	}
+
 	// Watch for changes to IPPool.
 	err = c.WatchObject(&crdv1.IPPool{}, &handler.EnqueueRequestForObject{})
 	if err != nil {
 		return fmt.Errorf("tigera-installation-controller failed to watch IPPool resource: %w", err)
 	}
 
+	// Watch for the changes to nodes that affect on which of them WireGuard is enabled and encrypts traffic.
+	err = c.WatchObject(&corev1.Node{}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				e.ObjectOld.GetAnnotations()[wireguardPublicKeyAnnotation] != e.ObjectNew.GetAnnotations()[wireguardPublicKeyAnnotation]
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("tigera-installation-controller failed to watch Node resource: %w", err)
+	}
+
 	// Perform periodic reconciliation. This acts as a backstop to catch reconcile issues,
 	// and also makes sure we spot when things change that might not trigger a reconciliation.
 	err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{})
@@ -364,6 +389,7 @@ type ReconcileInstallation struct {
 	migrationChecked     bool
 	clusterDomain        string
 	manageCRDs           bool
+	whiskerEnabled       bool
 	tierWatchReady       *utils.ReadyFlag
 
 	// newComponentHandler returns a new component handler. Useful stub for unit testing.
@@ -410,6 +436,10 @@ func updateInstallationWithDefaults(ctx context.Context, client client.Client, i
 		return fmt.Errorf("unable to list IPPools: %s", err.Error())
 	}
 
+	if err = updateInstallationForBottlerocket(ctx, client, instance); err != nil {
+		return err
+	}
+
 	err = MergeAndFillDefaults(instance, awsNode, currentPools)
 	if err != nil {
 		return err
@@ -781,6 +811,10 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		}
 	}
 
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	instanceStatus := instance.Status
 	if !r.migrationChecked {
 		// update Installation resource with existing install if it exists.
@@ -899,6 +933,22 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		}
 	}
 
+	// Block the upgrade before anything is upgraded if the components can't be upgraded from the installed release to
+	// the release of this operator.
+	upgradeErr := validateUpgrade(instance)
+	conditions := append([]metav1.Condition(nil), instance.Status.Conditions...)
+	setUpgradeBlockedCondition(instance, upgradeErr)
+	if !reflect.DeepEqual(conditions, instance.Status.Conditions) {
+		if err := r.client.Status().Update(ctx, instance); err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Failed to update the UpgradeBlocked condition", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+	if upgradeErr != nil {
+		r.status.SetDegraded(operator.UpgradeError, "Upgrade is not supported", status.WithCode(operator.UpgradeNotSupported, upgradeErr), reqLogger)
+		return reconcile.Result{}, nil
+	}
+
 	if err = r.updateCRDs(ctx, instance.Spec.Variant, reqLogger); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -943,6 +993,9 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, nil
 	}
 
+	// Pass the Typha autoscaling configuration to the autoscaler before checking its status.
+	r.typhaAutoscaler.configure(instance.Spec.TyphaAutoscaling)
+
 	// If the autoscalar is degraded then trigger a run and recheck the degraded status. If it is still degraded after the
 	// the run the reset the degraded status and requeue the request.
 	if r.typhaAutoscaler.isDegraded() {
@@ -976,7 +1029,7 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 	// Query for pull secrets in operator namespace
 	pullSecrets, err := utils.GetNetworkingPullSecrets(&instance.Spec, r.client)
 	if err != nil {
-		r.status.SetDegraded(operator.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
+		r.status.SetDegraded(operator.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operator.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -1031,7 +1084,7 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 
 	certificateManager, err := certificatemanager.Create(r.client, &instance.Spec, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithLogger(reqLogger))
 	if err != nil {
-		r.status.SetDegraded(operator.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operator.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operator.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -1319,6 +1372,19 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, err
 	}
 
+	// Felix sends its flow logs to Goldmane when Whisker is installed.
+	var goldmaneServer string
+	if r.whiskerEnabled && instance.Spec.Variant == operator.Calico {
+		whiskerCR, err := utils.GetWhisker(ctx, r.client)
+		if err != nil {
+			r.status.SetDegraded(operator.ResourceReadError, "Error reading Whisker", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if whiskerCR != nil {
+			goldmaneServer = whisker.GoldmaneServer(r.clusterDomain)
+		}
+	}
+
 	// Build a configuration for rendering calico/node.
 	nodeCfg := render.NodeConfiguration{
 		K8sServiceEp:            k8sapi.Endpoint,
@@ -1336,6 +1402,7 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		PrometheusServerTLS:     nodePrometheusTLS,
 		FelixHealthPort:         *felixConfiguration.Spec.HealthPort,
 		BindMode:                bgpConfiguration.Spec.BindMode,
+		GoldmaneServer:          goldmaneServer,
 	}
 	components = append(components, render.Node(&nodeCfg))
 
@@ -1372,6 +1439,21 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		)
 	}
 
+	// Render the image verification policy. Kyverno provides the policy API, so when it is not installed we
+	// can only report that verification is not possible, and there is no policy to clean up.
+	_, err = r.client.RESTMapper().RESTMapping(imageverification.ClusterPolicyGVK.GroupKind(), imageverification.ClusterPolicyGVK.Version)
+	if err != nil && !meta.IsNoMatchError(err) {
+		r.status.SetDegraded(operator.ResourceReadError, "Error querying the Kyverno ClusterPolicy API", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	kyvernoAvailable := err == nil
+	if kyvernoAvailable {
+		components = append(components, imageverification.ImageVerification(&imageverification.Config{Installation: &instance.Spec}))
+	} else if instance.Spec.ImageVerification != nil {
+		r.status.SetDegraded(operator.ResourceNotReady, "Image verification requires Kyverno to be installed", err, reqLogger)
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
 	imageSet, err := imageset.GetImageSet(ctx, r.client, instance.Spec.Variant)
 	if err != nil {
 		r.status.SetDegraded(operator.ResourceReadError, "Error getting ImageSet", err, reqLogger)
@@ -1379,7 +1461,13 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 	}
 
 	if err = imageset.ValidateImageSet(imageSet); err != nil {
-		r.status.SetDegraded(operator.ResourceValidationError, "Error validating ImageSet", err, reqLogger)
+		r.status.SetDegraded(operator.ResourceValidationError, "Error validating ImageSet", status.WithCode(operator.ImageSetInvalid, err), reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	imageSet, arch, err := imageset.SelectArchitecture(ctx, r.client, imageSet)
+	if err != nil {
+		r.status.SetDegraded(operator.ResourceReadError, "Error determining cluster architecture", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -1388,6 +1476,11 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, err
 	}
 
+	if err = imageset.ValidateArchitecture(&instance.Spec, imageSet, arch, components...); err != nil {
+		r.status.SetDegraded(operator.ResourceValidationError, "Error validating images for the cluster architecture", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
 	// Create a component handler to create or update the rendered components.
 	handler := r.newComponentHandler(log, r.client, r.scheme, instance)
 	for _, component := range components {
@@ -1477,6 +1570,30 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		r.status.SetDegraded(operator.ResourceUpdateError, "Error updating resource", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+	setBPFDataplaneCondition(instance)
+	if bpf := instance.Status.BPFDataplane; bpf != nil {
+		switch bpf.Phase {
+		case operator.BPFDataplanePreflightFailed:
+			r.status.SetDegraded(operator.InvalidConfigurationError, fmt.Sprintf("Nodes do not support the eBPF dataplane: %s", bpfNodeNames(bpf.NodesNotReady)), nil, reqLogger)
+			if err := r.client.Status().Update(ctx, instance); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		case operator.BPFDataplaneRolledBack:
+			r.status.SetDegraded(operator.PodFailure, "The eBPF dataplane was rolled back because calico-node did not become ready", nil, reqLogger)
+			if err := r.client.Status().Update(ctx, instance); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
+		}
+	}
+
+	// Enable WireGuard on the nodes that the Installation selects, and report on how many of them it encrypts traffic.
+	if err = r.reconcileWireGuard(ctx, instance); err != nil {
+		r.status.SetDegraded(operator.ResourceUpdateError, "Error configuring WireGuard", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	setWireGuardCondition(instance)
 
 	// We can clear the degraded state now since as far as we know everything is in order.
 	r.status.ClearDegraded()
@@ -1500,15 +1617,26 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 	} else {
 		instance.Status.ImageSet = imageSet.Name
 	}
+	instance.Status.OperatorVersion = version.VERSION
+	instance.Status.Images = imageStatuses(&instance.Spec, imageSet)
 	instance.Status.Computed = &instance.Spec
 	if err = r.client.Status().Update(ctx, instance); err != nil {
 		return reconcile.Result{}, err
 	}
 
 	reqLogger.V(1).Info("Finished reconciling Installation")
+	if instance.Status.BPFDataplane != nil && instance.Status.BPFDataplane.Phase == operator.BPFDataplaneEnabling {
+		// Check back on the transition to the eBPF dataplane, so that it can be rolled back if it stalls.
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
+// imageStatuses returns the images of the variant of the installation as resolved for the given ImageSet.
+func imageStatuses(install *operator.InstallationSpec, is *operator.ImageSet) []operator.ImageStatus {
+	return components.ImageStatuses(install.Variant, install.Registry, install.ImagePath, install.ImagePrefix, is)
+}
+
 func readMTUFile() (int, error) {
 	filename := "/var/lib/calico/mtu"
 	data, err := os.ReadFile(filename)
@@ -1684,6 +1812,10 @@ func (r *ReconcileInstallation) setDefaultsOnFelixConfiguration(ctx context.Cont
 				updated = true
 			}
 		}
+
+		if install.Spec.FlowLogs != nil && setFlowLogsOnFelixConfiguration(install.Spec.FlowLogs, fc) {
+			updated = true
+		}
 	}
 
 	// If BPF is enabled, but not set on FelixConfiguration, do so here. This could happen when an older
@@ -1720,8 +1852,42 @@ func (r *ReconcileInstallation) setDefaultsOnFelixConfiguration(ctx context.Cont
 	return updated, nil
 }
 
+// setFlowLogsOnFelixConfiguration sets the flow log fields of the FelixConfiguration that are configured on the
+// Installation, and returns true if any of them changed.
+func setFlowLogsOnFelixConfiguration(fl *operator.FlowLogsSpec, fc *crdv1.FelixConfiguration) bool {
+	updated := false
+	if fl.FlushInterval != nil && !reflect.DeepEqual(fc.Spec.FlowLogsFlushInterval, fl.FlushInterval) {
+		fc.Spec.FlowLogsFlushInterval = fl.FlushInterval.DeepCopy()
+		updated = true
+	}
+	if fl.FileMaxFileSizeMB != nil {
+		size := int(*fl.FileMaxFileSizeMB)
+		if fc.Spec.FlowLogsFileMaxFileSizeMB == nil || *fc.Spec.FlowLogsFileMaxFileSizeMB != size {
+			fc.Spec.FlowLogsFileMaxFileSizeMB = &size
+			updated = true
+		}
+	}
+	if fl.AggregationKindForAllowed != nil {
+		level := fl.AggregationKindForAllowed.Level()
+		if fc.Spec.FlowLogsFileAggregationKindForAllowed == nil || *fc.Spec.FlowLogsFileAggregationKindForAllowed != level {
+			fc.Spec.FlowLogsFileAggregationKindForAllowed = &level
+			updated = true
+		}
+	}
+	if fl.AggregationKindForDenied != nil {
+		level := fl.AggregationKindForDenied.Level()
+		if fc.Spec.FlowLogsFileAggregationKindForDenied == nil || *fc.Spec.FlowLogsFileAggregationKindForDenied != level {
+			fc.Spec.FlowLogsFileAggregationKindForDenied = &level
+			updated = true
+		}
+	}
+	return updated
+}
+
 // setBPFUpdatesOnFelixConfiguration will take the passed in fc and update any BPF properties needed
-// based on the install config and the daemonset.
+// based on the install config and the daemonset. The eBPF dataplane is only enabled once every node passes the
+// preflight checks, and is rolled back if calico-node does not become ready with it. The progress is recorded in
+// the Installation status.
 func (r *ReconcileInstallation) setBPFUpdatesOnFelixConfiguration(ctx context.Context, install *operator.Installation, fc *crdv1.FelixConfiguration, reqLogger logr.Logger) (bool, error) {
 	updated := false
 
@@ -1732,16 +1898,68 @@ func (r *ReconcileInstallation) setBPFUpdatesOnFelixConfiguration(ctx context.Co
 		if err != nil {
 			return false, err
 		}
-		if !bpfEnabledOnFelixConfig(fc) && isRolloutCompleteWithBPFVolumes(ds) {
-			err := setBPFEnabledOnFelixConfiguration(fc, bpfEnabledOnInstall)
-			if err != nil {
-				reqLogger.Error(err, "Unable to enable eBPF data plane")
+
+		if bpfRolledBack(fc, install) {
+			// Leave the previous dataplane in place until the Installation is changed.
+			if install.Status.BPFDataplane == nil || install.Status.BPFDataplane.Phase != operator.BPFDataplaneRolledBack {
+				install.Status.BPFDataplane = &operator.BPFDataplaneStatus{Phase: operator.BPFDataplaneRolledBack}
+			}
+			return false, nil
+		}
+
+		if !bpfEnabledOnFelixConfig(fc) {
+			nodes := &corev1.NodeList{}
+			if err := r.client.List(ctx, nodes); err != nil {
 				return false, err
-			} else {
+			}
+			if failures := preflight.UnsupportedBPFNodes(nodes.Items); len(failures) > 0 {
+				install.Status.BPFDataplane = &operator.BPFDataplaneStatus{Phase: operator.BPFDataplanePreflightFailed, NodesNotReady: failures}
+				return false, nil
+			}
+
+			install.Status.BPFDataplane = &operator.BPFDataplaneStatus{Phase: operator.BPFDataplaneEnabling}
+			if isRolloutCompleteWithBPFVolumes(ds) {
+				err := setBPFEnabledOnFelixConfiguration(fc, bpfEnabledOnInstall)
+				if err != nil {
+					reqLogger.Error(err, "Unable to enable eBPF data plane")
+					return false, err
+				}
+				fc.Annotations[bpfEnabledAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
 				updated = true
 			}
+			return updated, nil
+		}
+
+		pods := &corev1.PodList{}
+		if err := r.client.List(ctx, pods, client.InNamespace(common.CalicoNamespace), client.MatchingLabels{"k8s-app": common.NodeDaemonSetName}); err != nil {
+			return false, err
+		}
+		notReady := bpfNodesNotReady(pods.Items)
+		_, enabling := fc.Annotations[bpfEnabledAtAnnotation]
+		switch {
+		case len(notReady) == 0:
+			install.Status.BPFDataplane = &operator.BPFDataplaneStatus{Phase: operator.BPFDataplaneEnabled}
+			if enabling {
+				delete(fc.Annotations, bpfEnabledAtAnnotation)
+				updated = true
+			}
+		case bpfRollbackDue(fc, time.Now()):
+			reqLogger.Info("Rolling back the eBPF dataplane because calico-node is not ready", "nodes", len(notReady))
+			if err := setBPFEnabledOnFelixConfiguration(fc, false); err != nil {
+				reqLogger.Error(err, "Unable to roll back eBPF data plane")
+				return false, err
+			}
+			delete(fc.Annotations, bpfEnabledAtAnnotation)
+			fc.Annotations[bpfRolledBackAnnotation] = strconv.FormatInt(install.Generation, 10)
+			install.Status.BPFDataplane = &operator.BPFDataplaneStatus{Phase: operator.BPFDataplaneRolledBack, NodesNotReady: notReady}
+			updated = true
+		case enabling:
+			install.Status.BPFDataplane = &operator.BPFDataplaneStatus{Phase: operator.BPFDataplaneEnabling, NodesNotReady: notReady}
+		default:
+			install.Status.BPFDataplane = &operator.BPFDataplaneStatus{Phase: operator.BPFDataplaneEnabled, NodesNotReady: notReady}
 		}
 	} else {
+		install.Status.BPFDataplane = nil
 		if fc.Spec.BPFEnabled == nil || *fc.Spec.BPFEnabled {
 			err := setBPFEnabledOnFelixConfiguration(fc, bpfEnabledOnInstall)
 			if err != nil {
@@ -1751,11 +1969,73 @@ func (r *ReconcileInstallation) setBPFUpdatesOnFelixConfiguration(ctx context.Co
 				updated = true
 			}
 		}
+		if _, ok := fc.Annotations[bpfEnabledAtAnnotation]; ok {
+			delete(fc.Annotations, bpfEnabledAtAnnotation)
+			updated = true
+		}
 	}
 
 	return updated, nil
 }
 
+// validateUpgrade returns an error if upgrading the components of the installation from the installed release to the
+// release of this operator is not supported. Changing the variant installs a different product, so it is not
+// validated.
+func validateUpgrade(install *operator.Installation) error {
+	if install.Status.CalicoVersion == "" || install.Status.Variant != install.Spec.Variant {
+		return nil
+	}
+	target := components.CalicoRelease
+	if install.Spec.Variant == operator.TigeraSecureEnterprise {
+		target = components.EnterpriseRelease
+	}
+	return utils.ValidateReleaseUpgrade(install.Status.CalicoVersion, target)
+}
+
+// setUpgradeBlockedCondition sets the UpgradeBlocked condition of the Installation if the upgrade is blocked by the
+// given error, and removes it otherwise.
+func setUpgradeBlockedCondition(install *operator.Installation, upgradeErr error) {
+	if upgradeErr == nil {
+		meta.RemoveStatusCondition(&install.Status.Conditions, operator.UpgradeBlockedCondition)
+		return
+	}
+	meta.SetStatusCondition(&install.Status.Conditions, metav1.Condition{
+		Type:               operator.UpgradeBlockedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             string(operator.UpgradeNotSupported),
+		Message:            upgradeErr.Error(),
+		ObservedGeneration: install.Generation,
+	})
+}
+
+// setBPFDataplaneCondition sets the BPFDataplaneReady condition of the Installation from its eBPF dataplane status.
+func setBPFDataplaneCondition(install *operator.Installation) {
+	bpf := install.Status.BPFDataplane
+	if bpf == nil {
+		meta.RemoveStatusCondition(&install.Status.Conditions, operator.BPFDataplaneReadyCondition)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               operator.BPFDataplaneReadyCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             string(bpf.Phase),
+		ObservedGeneration: install.Generation,
+	}
+	switch {
+	case bpf.Phase == operator.BPFDataplaneEnabled && len(bpf.NodesNotReady) == 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Message = "The eBPF dataplane is enabled on all nodes"
+	case bpf.Phase == operator.BPFDataplanePreflightFailed:
+		condition.Message = fmt.Sprintf("%d node(s) failed the eBPF dataplane preflight checks", len(bpf.NodesNotReady))
+	case bpf.Phase == operator.BPFDataplaneRolledBack:
+		condition.Message = "The eBPF dataplane was rolled back because calico-node did not become ready; update the Installation to retry"
+	default:
+		condition.Message = fmt.Sprintf("Waiting for calico-node to be ready on %d node(s)", len(bpf.NodesNotReady))
+	}
+	meta.SetStatusCondition(&install.Status.Conditions, condition)
+}
+
 var osExitOverride = os.Exit
 
 // checkActive verifies the operator that calls this function is designated as the active operator.
@@ -1863,6 +2143,27 @@ func updateInstallationForAWSNode(i *operator.Installation, ds *appsv1.DaemonSet
 	return nil
 }
 
+// updateInstallationForBottlerocket disables FlexVolume on EKS clusters with nodes that run Bottlerocket, unless a
+// FlexVolumePath is specified. The FlexVolume driver can't be installed on Bottlerocket, whose /usr directory is
+// read-only.
+func updateInstallationForBottlerocket(ctx context.Context, c client.Client, i *operator.Installation) error {
+	if !i.Spec.KubernetesProvider.IsEKS() || len(i.Spec.FlexVolumePath) != 0 {
+		return nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return fmt.Errorf("unable to list nodes: %s", err.Error())
+	}
+	for _, n := range nodes.Items {
+		if strings.HasPrefix(n.Status.NodeInfo.OSImage, "Bottlerocket") {
+			i.Spec.FlexVolumePath = "None"
+			return nil
+		}
+	}
+	return nil
+}
+
 func addCRDWatches(c ctrlruntime.Controller, v operator.ProductVariant) error {
 	pred := predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
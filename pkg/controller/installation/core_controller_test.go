@@ -32,6 +32,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	schedv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -53,11 +54,13 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/monitor"
 	"github.com/tigera/operator/pkg/tls"
 	"github.com/tigera/operator/test"
+	"github.com/tigera/operator/version"
 )
 
 var errMismatchedError = fmt.Errorf("installation spec.kubernetesProvider 'DockerEnterprise' does not match auto-detected value 'OpenShift'")
@@ -427,6 +430,13 @@ var _ = Describe("Testing core-controller installation", func() {
 			}
 			Expect(test.GetResource(c, &inst)).To(BeNil())
 			Expect(inst.Status.ImageSet).To(Equal("enterprise-" + components.EnterpriseRelease))
+			Expect(inst.Status.OperatorVersion).To(Equal(version.VERSION))
+			Expect(inst.Status.Images).To(ContainElement(operator.ImageStatus{
+				Image:     components.ComponentTigeraNode.Image,
+				Version:   components.ComponentTigeraNode.Version,
+				Reference: fmt.Sprintf("some.registry.org/%s@sha256:tigeracnxnodehash", components.ComponentTigeraNode.Image),
+				Digest:    "sha256:tigeracnxnodehash",
+			}))
 		})
 
 		It("should update version", func() {
@@ -452,6 +462,56 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
 			Expect(instance.Status.CalicoVersion).To(Equal(components.CalicoRelease))
 		})
+
+		It("should block an upgrade that skips a minor release", func() {
+			defer func(release string) { components.EnterpriseRelease = release }(components.EnterpriseRelease)
+			components.EnterpriseRelease = "v3.21.0"
+			mockStatus.On("SetDegraded", operator.UpgradeError, "Upgrade is not supported", mock.Anything, mock.Anything).Return()
+
+			instance := &operator.Installation{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
+			instance.Status.CalicoVersion = "v3.19.1"
+			Expect(c.Update(ctx, instance)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operator.UpgradeError, "Upgrade is not supported", mock.Anything, mock.Anything)
+
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
+			Expect(instance.Status.CalicoVersion).To(Equal("v3.19.1"))
+			condition := meta.FindStatusCondition(instance.Status.Conditions, operator.UpgradeBlockedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("upgrade to v3.20 first"))
+			Expect(test.GetResource(c, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}})).NotTo(BeNil())
+
+			// Once the installed release can be upgraded from, the condition is removed.
+			instance.Status.CalicoVersion = "v3.20.2"
+			Expect(c.Status().Update(ctx, instance)).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
+			Expect(meta.FindStatusCondition(instance.Status.Conditions, operator.UpgradeBlockedCondition)).To(BeNil())
+			Expect(instance.Status.CalicoVersion).To(Equal("v3.21.0"))
+		})
+
+		It("should allow rolling back to the previous minor release", func() {
+			defer func(release string) { components.EnterpriseRelease = release }(components.EnterpriseRelease)
+			components.EnterpriseRelease = "v3.20.2"
+
+			instance := &operator.Installation{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
+			instance.Status.CalicoVersion = "v3.21.0"
+			Expect(c.Update(ctx, instance)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertNotCalled(GinkgoT(), "SetDegraded", operator.UpgradeError, "Upgrade is not supported", mock.Anything, mock.Anything)
+
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
+			Expect(meta.FindStatusCondition(instance.Status.Conditions, operator.UpgradeBlockedCondition)).To(BeNil())
+			Expect(instance.Status.CalicoVersion).To(Equal("v3.20.2"))
+		})
 	})
 
 	Context("Docker Enterprise defaults", func() {
@@ -877,6 +937,20 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(*fc.Spec.BPFEnabled).To(BeFalse())
 		})
 
+		It("should not change the cluster while the reconciliation of the Installation is paused", func() {
+			cr.Annotations = map[string]string{common.ReconcileAnnotation: common.ReconcilePaused}
+			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// Neither the defaults of the Installation nor the FelixConfiguration are written.
+			instance := &operator.Installation{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
+			Expect(instance.Spec.CalicoNetwork).To(BeNil())
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, &crdv1.FelixConfiguration{})).To(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetMetaData", mock.Anything)
+		})
+
 		It("should set BPFEnabled to ture on FelixConfiguration if BPF is enabled on installation", func() {
 			createNodeDaemonSet()
 
@@ -937,6 +1011,77 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(*fc.Spec.BPFEnabled).To(BeFalse())
 		})
 
+		It("should not enable BPF on FelixConfiguration if a node fails the preflight checks", func() {
+			createNodeDaemonSet()
+			Expect(c.Create(ctx, &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "old-kernel"},
+				Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OperatingSystem: "linux", KernelVersion: "4.15.0-20-generic"}},
+			})).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operator.InvalidConfigurationError, "Nodes do not support the eBPF dataplane: old-kernel", mock.Anything, mock.Anything).Return()
+
+			network := operator.LinuxDataplaneBPF
+			cr.Spec.CalicoNetwork = &operator.CalicoNetworkSpec{LinuxDataplane: &network}
+			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			fc := &crdv1.FelixConfiguration{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, fc)).ShouldNot(HaveOccurred())
+			Expect(fc.Spec.BPFEnabled).To(BeNil())
+
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, cr)).ShouldNot(HaveOccurred())
+			Expect(cr.Status.BPFDataplane).To(Equal(&operator.BPFDataplaneStatus{
+				Phase:         operator.BPFDataplanePreflightFailed,
+				NodesNotReady: []operator.BPFNodeStatus{{Name: "old-kernel", Reason: "kernel 4.15.0-20-generic does not support the eBPF dataplane"}},
+			}))
+			condition := meta.FindStatusCondition(cr.Status.Conditions, operator.BPFDataplaneReadyCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		})
+
+		It("should roll back BPF on FelixConfiguration if calico-node does not become ready", func() {
+			createNodeDaemonSet()
+
+			network := operator.LinuxDataplaneBPF
+			cr.Spec.CalicoNetwork = &operator.CalicoNetworkSpec{LinuxDataplane: &network}
+			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			fc := &crdv1.FelixConfiguration{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, fc)).ShouldNot(HaveOccurred())
+			Expect(*fc.Spec.BPFEnabled).To(BeTrue())
+			Expect(fc.Annotations).To(HaveKey(bpfEnabledAtAnnotation))
+
+			// calico-node has been unready on a node for longer than the rollback timeout.
+			fc.Annotations[bpfEnabledAtAnnotation] = time.Now().Add(-2 * bpfRollbackTimeout).UTC().Format(time.RFC3339)
+			Expect(c.Update(ctx, fc)).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "calico-node-a", Namespace: common.CalicoNamespace, Labels: map[string]string{"k8s-app": common.NodeDaemonSetName}},
+				Spec:       corev1.PodSpec{NodeName: "node-a"},
+			})).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operator.PodFailure, "The eBPF dataplane was rolled back because calico-node did not become ready", mock.Anything, mock.Anything).Return()
+
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			fc = &crdv1.FelixConfiguration{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, fc)).ShouldNot(HaveOccurred())
+			Expect(*fc.Spec.BPFEnabled).To(BeFalse())
+			Expect(fc.Annotations).NotTo(HaveKey(bpfEnabledAtAnnotation))
+			Expect(fc.Annotations).To(HaveKey(bpfRolledBackAnnotation))
+
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, cr)).ShouldNot(HaveOccurred())
+			Expect(cr.Status.BPFDataplane.Phase).To(Equal(operator.BPFDataplaneRolledBack))
+			Expect(cr.Status.BPFDataplane.NodesNotReady).To(Equal([]operator.BPFNodeStatus{{Name: "node-a", Reason: "calico-node pod calico-node-a is not ready"}}))
+
+			// The operator does not enable BPF again for the same Installation.
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, fc)).ShouldNot(HaveOccurred())
+			Expect(*fc.Spec.BPFEnabled).To(BeFalse())
+		})
+
 		It("should set BPFEnabled on FelixConfiguration if FELIX_BPFENABLED Env var is set by old version of operator", func() {
 			createNodeDaemonSet()
 
@@ -981,6 +1126,28 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(*fc.Spec.DNSTrustedServers).To(ConsistOf("k8s-service:kube-system/rke2-coredns-rke2-coredns"))
 		})
 
+		It("generates FelixConfiguration with the flow log settings from the Installation", func() {
+			podPrefix := operator.FlowLogsAggregationKindPodPrefix
+			none := operator.FlowLogsAggregationKindNone
+			cr.Spec.FlowLogs = &operator.FlowLogsSpec{
+				FlushInterval:             &metav1.Duration{Duration: 60 * time.Second},
+				FileMaxFileSizeMB:         ptr.Int32ToPtr(50),
+				AggregationKindForAllowed: &podPrefix,
+				AggregationKindForDenied:  &none,
+			}
+			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			fc := &crdv1.FelixConfiguration{}
+			err = c.Get(ctx, types.NamespacedName{Name: "default"}, fc)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fc.Spec.FlowLogsFlushInterval).To(Equal(&metav1.Duration{Duration: 60 * time.Second}))
+			Expect(*fc.Spec.FlowLogsFileMaxFileSizeMB).To(Equal(50))
+			Expect(*fc.Spec.FlowLogsFileAggregationKindForAllowed).To(Equal(2))
+			Expect(*fc.Spec.FlowLogsFileAggregationKindForDenied).To(Equal(0))
+		})
+
 		It("should Reconcile with AWS CNI config", func() {
 			cr.Spec.CNI = &operator.CNISpec{Type: operator.PluginAmazonVPC}
 			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
@@ -1581,6 +1748,26 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(c.Get(ctx, client.ObjectKey{Name: render.TyphaTLSSecretName, Namespace: common.OperatorNamespace()}, secret)).ShouldNot(HaveOccurred())
 			Expect(secret.GetOwnerReferences()).To(HaveLen(1))
 		})
+
+		It("should disable FlexVolume when nodes run Bottlerocket", func() {
+			Expect(c.Create(ctx, &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "bottlerocket"},
+				Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Bottlerocket OS 1.20.0 (aws-k8s-1.29)"}},
+			})).NotTo(HaveOccurred())
+
+			Expect(updateInstallationWithDefaults(ctx, c, cr, operator.ProviderEKS)).NotTo(HaveOccurred())
+			Expect(cr.Spec.FlexVolumePath).To(Equal("None"))
+		})
+
+		It("should keep the default FlexVolume path without Bottlerocket nodes", func() {
+			Expect(c.Create(ctx, &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "al2"},
+				Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Amazon Linux 2"}},
+			})).NotTo(HaveOccurred())
+
+			Expect(updateInstallationWithDefaults(ctx, c, cr, operator.ProviderEKS)).NotTo(HaveOccurred())
+			Expect(cr.Spec.FlexVolumePath).To(Equal("/usr/libexec/kubernetes/kubelet-plugins/volume/exec/"))
+		})
 	})
 
 	Context("with a fake component handler", func() {
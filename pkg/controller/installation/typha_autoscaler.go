@@ -17,6 +17,7 @@ package installation
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -40,19 +41,23 @@ const (
 
 // typhaAutoscaler periodically lists the nodes and, if needed, scales the Typha deployment up/down.
 // Number of replicas should be at least (1 typha for every 200 nodes) + 1 but the number of typhas
-// cannot exceed the number of nodes+masters.
+// cannot exceed the number of nodes+masters. The Installation may replace this with its own ranges of node counts.
 type typhaAutoscaler struct {
 	client            kubernetes.Interface
 	syncPeriod        time.Duration
 	statusManager     status.StatusManager
 	triggerRunChan    chan chan error
 	isDegradedChan    chan chan bool
+	configChan        chan *operator.TyphaAutoscaling
 	nodeIndexInformer cache.SharedIndexInformer
 	typhaInformer     cache.Controller
 	typhaIndexer      cache.Indexer
 
 	// Number of currently running replicas.
 	activeReplicas int32
+
+	// scaling is the node count to replicas configuration from the Installation, nil for the default scale.
+	scaling *operator.TyphaAutoscaling
 }
 
 type typhaAutoscalerOption func(*typhaAutoscaler)
@@ -73,6 +78,7 @@ func newTyphaAutoscaler(cs kubernetes.Interface, nodeIndexInformer cache.SharedI
 		syncPeriod:        defaultTyphaAutoscalerSyncPeriod,
 		triggerRunChan:    make(chan chan error),
 		isDegradedChan:    make(chan chan bool),
+		configChan:        make(chan *operator.TyphaAutoscaling),
 		nodeIndexInformer: nodeIndexInformer,
 	}
 
@@ -151,6 +157,19 @@ func (t *typhaAutoscaler) start(ctx context.Context) {
 
 				ticker.Stop()
 				ticker = time.NewTicker(t.syncPeriod)
+			case scaling := <-t.configChan:
+				if reflect.DeepEqual(scaling, t.scaling) {
+					continue
+				}
+				t.scaling = scaling
+
+				// Scale straight away with the new configuration. The caller checks the degraded status afterwards.
+				if err := t.autoscaleReplicas(); err != nil {
+					degraded = true
+					typhaLog.Error(err, "Failed to autoscale typha")
+				} else {
+					degraded = false
+				}
 			case boolCh := <-t.isDegradedChan:
 				boolCh <- degraded
 				close(boolCh)
@@ -169,6 +188,12 @@ func (t *typhaAutoscaler) triggerRun() error {
 	return <-errChan
 }
 
+// configure sets the node count to replicas configuration used by the autoscaler. A nil configuration restores
+// the default scale. If the configuration changed, the autoscaler runs immediately.
+func (t *typhaAutoscaler) configure(scaling *operator.TyphaAutoscaling) {
+	t.configChan <- scaling
+}
+
 // isDegraded checks if the last run autoscale run failed and returns true if it did and false otherwise.
 func (t *typhaAutoscaler) isDegraded() bool {
 	boolChan := make(chan bool)
@@ -185,6 +210,9 @@ func (t *typhaAutoscaler) autoscaleReplicas() error {
 	}
 	typhaLog.V(5).Info("Number of nodes to consider for typha autoscaling", "all", allSchedulableNodes, "linux", linuxNodes)
 	expectedReplicas := common.GetExpectedTyphaScale(allSchedulableNodes)
+	if t.scaling != nil {
+		expectedReplicas = t.scaling.ReplicasForNodes(allSchedulableNodes)
+	}
 	if linuxNodes < expectedReplicas {
 		return fmt.Errorf("not enough linux nodes to schedule typha pods on, require %d and have %d", expectedReplicas, linuxNodes)
 	}
@@ -164,6 +164,46 @@ var _ = Describe("Test typha autoscaler ", func() {
 		verifyTyphaReplicas(c, 2)
 	})
 
+	It("should scale the Typha using the configured node count ranges", func() {
+		typhaMeta := metav1.ObjectMeta{
+			Name:      "calico-typha",
+			Namespace: "calico-system",
+		}
+		// Create a typha deployment
+		var r int32 = 0
+		typha := &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: typhaMeta,
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &r,
+			},
+		}
+		_, err := c.AppsV1().Deployments("calico-system").Create(ctx, typha, metav1.CreateOptions{})
+		Expect(err).To(BeNil())
+
+		_ = CreateNode(c, "node1", map[string]string{"kubernetes.io/os": "linux"}, nil)
+		_ = CreateNode(c, "node2", map[string]string{"kubernetes.io/os": "linux"}, nil)
+		_ = CreateNode(c, "node3", map[string]string{"kubernetes.io/os": "linux"}, nil)
+
+		// Create the autoscaler and run it
+		ta := newTyphaAutoscaler(c, nodeIndexInformer, tlw, statusManager, typhaAutoscalerPeriod(10*time.Millisecond))
+		ta.start(ctx)
+		verifyTyphaReplicas(c, 2)
+
+		ta.configure(&operator.TyphaAutoscaling{
+			NodesToReplicas: []operator.TyphaScaleStep{
+				{Nodes: 1, Replicas: 1},
+				{Nodes: 3, Replicas: 3},
+				{Nodes: 10, Replicas: 5},
+			},
+		})
+		verifyTyphaReplicas(c, 3)
+
+		// Restoring the default scale brings the replicas back down.
+		ta.configure(nil)
+		verifyTyphaReplicas(c, 2)
+	})
+
 	It("should not ignore non-migrated nodes in its count", func() {
 		typhaMeta := metav1.ObjectMeta{
 			Name:      "calico-typha",
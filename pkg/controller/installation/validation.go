@@ -33,6 +33,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // validateCustomResource validates that the given custom resource is correct. This
@@ -374,6 +375,36 @@ func validateCustomResource(instance *operatorv1.Installation) error {
 		}
 	}
 
+	if fl := instance.Spec.FlowLogs; fl != nil {
+		if instance.Spec.Variant != operatorv1.TigeraSecureEnterprise {
+			return fmt.Errorf("Installation spec.FlowLogs is only supported for the %s variant", operatorv1.TigeraSecureEnterprise)
+		}
+		if fl.FlushInterval != nil && fl.FlushInterval.Duration <= 0 {
+			return fmt.Errorf("Installation spec.FlowLogs.FlushInterval must be greater than 0")
+		}
+		if fl.FileMaxFileSizeMB != nil && *fl.FileMaxFileSizeMB < 1 {
+			return fmt.Errorf("Installation spec.FlowLogs.FileMaxFileSizeMB must be at least 1")
+		}
+	}
+
+	// Verify the TyphaAutoscaling steps, if specified, are in increasing order of nodes.
+	if ta := instance.Spec.TyphaAutoscaling; ta != nil {
+		if len(ta.NodesToReplicas) == 0 {
+			return fmt.Errorf("Installation spec.TyphaAutoscaling.NodesToReplicas must not be empty")
+		}
+		for i, step := range ta.NodesToReplicas {
+			if step.Nodes < 0 {
+				return fmt.Errorf("Installation spec.TyphaAutoscaling.NodesToReplicas[%d].Nodes must not be negative", i)
+			}
+			if step.Replicas < 1 {
+				return fmt.Errorf("Installation spec.TyphaAutoscaling.NodesToReplicas[%d].Replicas must be at least 1", i)
+			}
+			if i > 0 && step.Nodes <= ta.NodesToReplicas[i-1].Nodes {
+				return fmt.Errorf("Installation spec.TyphaAutoscaling.NodesToReplicas must be in increasing order of nodes")
+			}
+		}
+	}
+
 	// Verify the CSINodeDriverDaemonSet overrides, if specified, is valid.
 	if ds := instance.Spec.CSINodeDriverDaemonSet; ds != nil {
 		err := validation.ValidateReplicatedPodResourceOverrides(ds, csinodedriver.ValidateCSINodeDriverDaemonSetContainer, validation.NoContainersDefined)
@@ -411,6 +442,51 @@ func validateCustomResource(instance *operatorv1.Installation) error {
 		}
 	}
 
+	if rp := instance.Spec.RolloutPolicy; rp != nil {
+		if err := validateRolloutPolicy(rp); err != nil {
+			return fmt.Errorf("Installation spec.RolloutPolicy is not valid: %w", err)
+		}
+	}
+
+	if rp := instance.Spec.RollbackPolicy; rp != nil {
+		if rp.Mode != nil && *rp.Mode != operatorv1.RollbackModeAutomatic && *rp.Mode != operatorv1.RollbackModeManual {
+			return fmt.Errorf("Installation spec.RollbackPolicy.Mode %q is not valid, must be %s or %s", *rp.Mode, operatorv1.RollbackModeAutomatic, operatorv1.RollbackModeManual)
+		}
+		if rp.FailureWindowSeconds != nil && *rp.FailureWindowSeconds < 1 {
+			return fmt.Errorf("Installation spec.RollbackPolicy.FailureWindowSeconds must be greater than 0")
+		}
+	}
+
+	if instance.Spec.Features != nil && instance.Spec.Variant != operatorv1.TigeraSecureEnterprise {
+		return fmt.Errorf("Installation spec.Features is only supported for the %s variant", operatorv1.TigeraSecureEnterprise)
+	}
+
+	if wg := instance.Spec.WireGuard; wg != nil && wg.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(wg.NodeSelector); err != nil {
+			return fmt.Errorf("Installation spec.WireGuard.NodeSelector is not valid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateRolloutPolicy checks that each wave selects nodes in exactly one way, and that percentages increase.
+func validateRolloutPolicy(rp *operatorv1.RolloutPolicy) error {
+	var lastPercentage int32
+	for i, w := range rp.Waves {
+		if (len(w.NodeSelector) == 0) == (w.Percentage == nil) {
+			return fmt.Errorf("wave %d must set exactly one of nodeSelector and percentage", i)
+		}
+		if w.Percentage != nil {
+			if *w.Percentage <= lastPercentage || *w.Percentage > 100 {
+				return fmt.Errorf("wave %d percentage must be greater than the previous waves and at most 100", i)
+			}
+			lastPercentage = *w.Percentage
+		}
+	}
+	if rp.MaxRestarts != nil && *rp.MaxRestarts < 1 {
+		return fmt.Errorf("maxRestarts must be greater than 0")
+	}
 	return nil
 }
 
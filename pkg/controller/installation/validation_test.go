@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	operator "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
@@ -407,6 +408,22 @@ var _ = Describe("Installation validation tests", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	DescribeTable("should validate TyphaAutoscaling",
+		func(steps []operator.TyphaScaleStep, valid bool) {
+			instance.Spec.TyphaAutoscaling = &operator.TyphaAutoscaling{NodesToReplicas: steps}
+			err := validateCustomResource(instance)
+			if valid {
+				Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("increasing steps", []operator.TyphaScaleStep{{Nodes: 0, Replicas: 1}, {Nodes: 5, Replicas: 3}}, true),
+		Entry("no steps", []operator.TyphaScaleStep{}, false),
+		Entry("zero replicas", []operator.TyphaScaleStep{{Nodes: 0, Replicas: 0}}, false),
+		Entry("steps out of order", []operator.TyphaScaleStep{{Nodes: 5, Replicas: 3}, {Nodes: 5, Replicas: 1}}, false),
+	)
+
 	Describe("validate Calico CNI plugin Type", func() {
 		DescribeTable("test invalid IPAM",
 			func(ipam operator.IPAMPluginType) {
@@ -1160,4 +1177,89 @@ var _ = Describe("Installation validation tests", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("validate RolloutPolicy", func() {
+		pct := func(p int32) *int32 { return &p }
+
+		It("should accept waves by node selector and increasing percentages", func() {
+			instance.Spec.RolloutPolicy = &operator.RolloutPolicy{
+				Waves: []operator.RolloutWave{
+					{NodeSelector: map[string]string{"canary": "true"}},
+					{Percentage: pct(10)},
+					{Percentage: pct(50)},
+				},
+			}
+			Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should return an error if a wave selects nodes in more than one way", func() {
+			instance.Spec.RolloutPolicy = &operator.RolloutPolicy{
+				Waves: []operator.RolloutWave{{NodeSelector: map[string]string{"canary": "true"}, Percentage: pct(10)}},
+			}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("wave 0 must set exactly one of nodeSelector and percentage")))
+		})
+
+		It("should return an error if percentages don't increase", func() {
+			instance.Spec.RolloutPolicy = &operator.RolloutPolicy{
+				Waves: []operator.RolloutWave{{Percentage: pct(50)}, {Percentage: pct(10)}},
+			}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("wave 1 percentage must be greater")))
+		})
+	})
+
+	Describe("validate RollbackPolicy", func() {
+		It("should accept a valid mode and failure window", func() {
+			mode := operator.RollbackModeAutomatic
+			window := int32(300)
+			instance.Spec.RollbackPolicy = &operator.RollbackPolicy{Mode: &mode, FailureWindowSeconds: &window}
+			Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should return an error for an invalid mode", func() {
+			mode := operator.RollbackMode("Sometimes")
+			instance.Spec.RollbackPolicy = &operator.RollbackPolicy{Mode: &mode}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring(`RollbackPolicy.Mode "Sometimes" is not valid`)))
+		})
+
+		It("should return an error for a failure window that isn't positive", func() {
+			window := int32(0)
+			instance.Spec.RollbackPolicy = &operator.RollbackPolicy{FailureWindowSeconds: &window}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("FailureWindowSeconds must be greater than 0")))
+		})
+	})
+
+	Describe("validate Features", func() {
+		disabled := operator.FeatureDisabled
+
+		It("should accept features for the TigeraSecureEnterprise variant", func() {
+			instance.Spec.Variant = operator.TigeraSecureEnterprise
+			instance.Spec.Features = &operator.Features{Kibana: &disabled}
+			Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should return an error for the Calico variant", func() {
+			instance.Spec.Features = &operator.Features{Kibana: &disabled}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("spec.Features is only supported")))
+		})
+	})
+
+	Describe("validate WireGuard", func() {
+		It("should accept a node selector", func() {
+			instance.Spec.WireGuard = &operator.WireGuard{
+				NodeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "pool", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}},
+				}},
+			}
+			Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should return an error if the node selector is not valid", func() {
+			instance.Spec.WireGuard = &operator.WireGuard{
+				NodeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "pool", Operator: metav1.LabelSelectorOpIn},
+				}},
+			}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("spec.WireGuard.NodeSelector is not valid")))
+		})
+	})
 })
@@ -241,6 +241,10 @@ func (r *ReconcileWindows) Reconcile(ctx context.Context, request reconcile.Requ
 	// FIXME: add logic to update Installation status conditions that doesn't conflict with
 	// core_controller
 
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	instanceStatus := instance.Status
 
 	reqLogger.V(2).Info("Loaded config", "config", instance)
@@ -295,7 +299,7 @@ func (r *ReconcileWindows) Reconcile(ctx context.Context, request reconcile.Requ
 
 	certificateManager, err := certificatemanager.Create(r.client, &instance.Spec, r.clusterDomain, common.OperatorNamespace())
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -401,7 +405,7 @@ func (r *ReconcileWindows) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 
 	if err = imageset.ValidateImageSet(imageSet); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceValidationError, "Error validating ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Error validating ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
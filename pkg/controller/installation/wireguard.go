@@ -0,0 +1,237 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operator "github.com/tigera/operator/api/v1"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+const (
+	// wireguardEnabledAnnotation records on a FelixConfiguration the value of wireguardEnabled that the operator set,
+	// so that the operator can tell whether someone else has modified it since.
+	wireguardEnabledAnnotation = "operator.tigera.io/wireguardEnabled"
+
+	// wireguardPublicKeyAnnotation is set on a node by calico-node once WireGuard is set up on it.
+	wireguardPublicKeyAnnotation = "projectcalico.org/WireguardPublicKey"
+
+	// nodeFelixConfigurationPrefix is the prefix of the names of the FelixConfigurations that apply to a single node.
+	nodeFelixConfigurationPrefix = "node."
+)
+
+// wireguardSelector returns the selector of the nodes that the Installation enables WireGuard on.
+func wireguardSelector(install *operator.Installation) (labels.Selector, error) {
+	wg := install.Spec.WireGuard
+	if wg == nil {
+		return labels.Nothing(), nil
+	}
+	if wg.NodeSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(wg.NodeSelector)
+}
+
+// setWireguardEnabledOnFelixConfiguration sets wireguardEnabled on the FelixConfiguration, or removes the value that
+// the operator set earlier if enabled is nil. It returns whether the FelixConfiguration was changed.
+func setWireguardEnabledOnFelixConfiguration(fc *crdv1.FelixConfiguration, enabled *bool) (bool, error) {
+	annotation, managed := fc.Annotations[wireguardEnabledAnnotation]
+	matches := managed && fc.Spec.WireguardEnabled != nil && strconv.FormatBool(*fc.Spec.WireguardEnabled) == annotation
+
+	if enabled == nil {
+		if !managed {
+			return false, nil
+		}
+		// Only remove the value if nobody else has modified it since the operator set it.
+		if matches {
+			fc.Spec.WireguardEnabled = nil
+		}
+		delete(fc.Annotations, wireguardEnabledAnnotation)
+		return true, nil
+	}
+
+	text := strconv.FormatBool(*enabled)
+	if matches && annotation == text {
+		return false, nil
+	}
+	if (managed && !matches) || (!managed && fc.Spec.WireguardEnabled != nil && *fc.Spec.WireguardEnabled != *enabled) {
+		return false, fmt.Errorf(`Unable to set wireguardEnabled: FelixConfiguration %q has been modified by someone else, refusing to override potential user configuration.`, fc.Name)
+	}
+
+	if fc.Annotations == nil {
+		fc.Annotations = map[string]string{}
+	}
+	fc.Annotations[wireguardEnabledAnnotation] = text
+	fc.Spec.WireguardEnabled = enabled
+	return true, nil
+}
+
+// reconcileWireGuard enables WireGuard on the nodes that the Installation selects, and sets the WireGuard status of
+// the Installation. WireGuard is enabled in the default FelixConfiguration when all nodes are selected, and in the
+// FelixConfigurations of the selected nodes otherwise.
+func (r *ReconcileInstallation) reconcileWireGuard(ctx context.Context, install *operator.Installation) error {
+	selector, err := wireguardSelector(install)
+	if err != nil {
+		return err
+	}
+	perNode := install.Spec.WireGuard != nil && install.Spec.WireGuard.NodeSelector != nil
+
+	var defaultEnabled *bool
+	if install.Spec.WireGuard != nil && !perNode {
+		enabled := true
+		defaultEnabled = &enabled
+	}
+	if _, err = utils.PatchFelixConfiguration(ctx, r.client, func(fc *crdv1.FelixConfiguration) (bool, error) {
+		return setWireguardEnabledOnFelixConfiguration(fc, defaultEnabled)
+	}); err != nil {
+		return err
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err = r.client.List(ctx, nodeList); err != nil {
+		return err
+	}
+	nodes := wireguardNodes(nodeList.Items)
+
+	// Enable WireGuard in the FelixConfigurations of the selected nodes, and remove it from the ones of the nodes
+	// that are no longer selected or that no longer exist.
+	desired := map[string]*bool{}
+	if perNode {
+		for _, n := range nodes {
+			if selector.Matches(labels.Set(n.Labels)) {
+				enabled := true
+				desired[nodeFelixConfigurationPrefix+n.Name] = &enabled
+			}
+		}
+	}
+	fcList := &crdv1.FelixConfigurationList{}
+	if err = r.client.List(ctx, fcList); err != nil {
+		return err
+	}
+	existing := map[string]*crdv1.FelixConfiguration{}
+	for i := range fcList.Items {
+		fc := &fcList.Items[i]
+		existing[fc.Name] = fc
+		if _, ok := fc.Annotations[wireguardEnabledAnnotation]; ok && fc.Name != "default" {
+			if _, ok := desired[fc.Name]; !ok {
+				desired[fc.Name] = nil
+			}
+		}
+	}
+	for name, enabled := range desired {
+		if err = r.setNodeWireguardEnabled(ctx, name, existing[name], enabled); err != nil {
+			return err
+		}
+	}
+
+	install.Status.WireGuard = wireguardStatus(install, nodes, selector)
+	return nil
+}
+
+// setNodeWireguardEnabled sets wireguardEnabled on the FelixConfiguration of a node, creating it if needed. A
+// FelixConfiguration that is left empty after removing the value is deleted.
+func (r *ReconcileInstallation) setNodeWireguardEnabled(ctx context.Context, name string, fc *crdv1.FelixConfiguration, enabled *bool) error {
+	create := fc == nil
+	if create {
+		if enabled == nil {
+			return nil
+		}
+		fc = &crdv1.FelixConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	patchFrom := client.MergeFrom(fc.DeepCopy())
+
+	changed, err := setWireguardEnabledOnFelixConfiguration(fc, enabled)
+	if err != nil || !changed {
+		return err
+	}
+	switch {
+	case create:
+		return r.client.Create(ctx, fc)
+	case enabled == nil && reflect.DeepEqual(fc.Spec, crdv1.FelixConfigurationSpec{}):
+		return r.client.Delete(ctx, fc)
+	default:
+		return r.client.Patch(ctx, fc, patchFrom)
+	}
+}
+
+// wireguardNodes returns the nodes that support WireGuard. Nodes that do not report their operating system are
+// assumed to be Linux nodes.
+func wireguardNodes(nodes []corev1.Node) []corev1.Node {
+	var linux []corev1.Node
+	for _, n := range nodes {
+		if n.Status.NodeInfo.OperatingSystem != "" && n.Status.NodeInfo.OperatingSystem != "linux" {
+			continue
+		}
+		linux = append(linux, n)
+	}
+	return linux
+}
+
+// wireguardStatus returns the WireGuard encryption coverage of the nodes, or nil if the Installation does not enable
+// WireGuard. A node is considered encrypted once calico-node has published its WireGuard public key.
+func wireguardStatus(install *operator.Installation, nodes []corev1.Node, selector labels.Selector) *operator.WireGuardStatus {
+	if install.Spec.WireGuard == nil {
+		return nil
+	}
+	status := &operator.WireGuardStatus{Nodes: int32(len(nodes))}
+	for _, n := range nodes {
+		if !selector.Matches(labels.Set(n.Labels)) {
+			continue
+		}
+		status.EnabledNodes++
+		if n.Annotations[wireguardPublicKeyAnnotation] != "" {
+			status.EncryptedNodes++
+		} else {
+			status.NodesNotEncrypted = append(status.NodesNotEncrypted, n.Name)
+		}
+	}
+	sort.Strings(status.NodesNotEncrypted)
+	return status
+}
+
+// setWireGuardCondition sets the WireGuardEncrypted condition of the Installation from its WireGuard status.
+func setWireGuardCondition(install *operator.Installation) {
+	wg := install.Status.WireGuard
+	if wg == nil {
+		meta.RemoveStatusCondition(&install.Status.Conditions, operator.WireGuardEncryptedCondition)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               operator.WireGuardEncryptedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "AllNodesEncrypted",
+		Message:            fmt.Sprintf("WireGuard encrypts traffic on all %d node(s) that it is enabled on", wg.EnabledNodes),
+		ObservedGeneration: install.Generation,
+	}
+	if wg.EncryptedNodes < wg.EnabledNodes {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NodesNotEncrypted"
+		condition.Message = fmt.Sprintf("Waiting for WireGuard to be set up on %d of %d node(s)", wg.EnabledNodes-wg.EncryptedNodes, wg.EnabledNodes)
+	}
+	meta.SetStatusCondition(&install.Status.Conditions, condition)
+}
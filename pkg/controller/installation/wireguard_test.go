@@ -0,0 +1,200 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operator "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("WireGuard tests", func() {
+	Context("setWireguardEnabledOnFelixConfiguration", func() {
+		var fc *crdv1.FelixConfiguration
+
+		BeforeEach(func() {
+			fc = &crdv1.FelixConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+		})
+
+		It("should set the value and the annotation", func() {
+			changed, err := setWireguardEnabledOnFelixConfiguration(fc, ptr.BoolToPtr(true))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(*fc.Spec.WireguardEnabled).To(BeTrue())
+			Expect(fc.Annotations).To(HaveKeyWithValue(wireguardEnabledAnnotation, "true"))
+
+			changed, err = setWireguardEnabledOnFelixConfiguration(fc, ptr.BoolToPtr(true))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should refuse to override a value that was set by someone else", func() {
+			fc.Spec.WireguardEnabled = ptr.BoolToPtr(false)
+			_, err := setWireguardEnabledOnFelixConfiguration(fc, ptr.BoolToPtr(true))
+			Expect(err).To(HaveOccurred())
+
+			fc.Annotations = map[string]string{wireguardEnabledAnnotation: "true"}
+			_, err = setWireguardEnabledOnFelixConfiguration(fc, ptr.BoolToPtr(true))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should only remove the value that it set", func() {
+			fc.Spec.WireguardEnabled = ptr.BoolToPtr(true)
+			changed, err := setWireguardEnabledOnFelixConfiguration(fc, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(*fc.Spec.WireguardEnabled).To(BeTrue())
+
+			fc.Annotations = map[string]string{wireguardEnabledAnnotation: "true"}
+			changed, err = setWireguardEnabledOnFelixConfiguration(fc, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(fc.Spec.WireguardEnabled).To(BeNil())
+			Expect(fc.Annotations).NotTo(HaveKey(wireguardEnabledAnnotation))
+		})
+	})
+
+	Context("reconcileWireGuard", func() {
+		var c client.Client
+		var ctx context.Context
+		var r *ReconcileInstallation
+		var install *operator.Installation
+
+		node := func(name string, labels, annotations map[string]string, os string) *corev1.Node {
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+				Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OperatingSystem: os}},
+			}
+		}
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+			Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+			c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+			ctx = context.Background()
+			r = &ReconcileInstallation{client: c}
+			install = &operator.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 2}}
+
+			Expect(c.Create(ctx, node("node-a", map[string]string{"pool": "secure"}, map[string]string{wireguardPublicKeyAnnotation: "key"}, "linux"))).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, node("node-b", map[string]string{"pool": "secure"}, nil, "linux"))).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, node("node-c", map[string]string{"pool": "default"}, nil, "linux"))).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, node("node-w", map[string]string{"pool": "secure"}, nil, "windows"))).NotTo(HaveOccurred())
+		})
+
+		It("should not change anything if WireGuard is not configured", func() {
+			Expect(r.reconcileWireGuard(ctx, install)).NotTo(HaveOccurred())
+			Expect(install.Status.WireGuard).To(BeNil())
+
+			fcs := &crdv1.FelixConfigurationList{}
+			Expect(c.List(ctx, fcs)).NotTo(HaveOccurred())
+			Expect(fcs.Items).To(BeEmpty())
+		})
+
+		It("should enable WireGuard on all nodes in the default FelixConfiguration", func() {
+			install.Spec.WireGuard = &operator.WireGuard{}
+			Expect(r.reconcileWireGuard(ctx, install)).NotTo(HaveOccurred())
+
+			fc := &crdv1.FelixConfiguration{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, fc)).NotTo(HaveOccurred())
+			Expect(*fc.Spec.WireguardEnabled).To(BeTrue())
+
+			Expect(install.Status.WireGuard).To(Equal(&operator.WireGuardStatus{
+				Nodes:             3,
+				EnabledNodes:      3,
+				EncryptedNodes:    1,
+				NodesNotEncrypted: []string{"node-b", "node-c"},
+			}))
+		})
+
+		It("should enable WireGuard on the selected nodes only", func() {
+			install.Spec.WireGuard = &operator.WireGuard{
+				NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "secure"}},
+			}
+			Expect(r.reconcileWireGuard(ctx, install)).NotTo(HaveOccurred())
+
+			for _, name := range []string{"node.node-a", "node.node-b"} {
+				fc := &crdv1.FelixConfiguration{}
+				Expect(c.Get(ctx, client.ObjectKey{Name: name}, fc)).NotTo(HaveOccurred())
+				Expect(*fc.Spec.WireguardEnabled).To(BeTrue())
+			}
+			err := c.Get(ctx, client.ObjectKey{Name: "node.node-c"}, &crdv1.FelixConfiguration{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			err = c.Get(ctx, client.ObjectKey{Name: "node.node-w"}, &crdv1.FelixConfiguration{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			Expect(install.Status.WireGuard).To(Equal(&operator.WireGuardStatus{
+				Nodes:             3,
+				EnabledNodes:      2,
+				EncryptedNodes:    1,
+				NodesNotEncrypted: []string{"node-b"},
+			}))
+		})
+
+		It("should remove WireGuard from the nodes that are no longer selected", func() {
+			Expect(c.Create(ctx, &crdv1.FelixConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "node.node-b"},
+				Spec:       crdv1.FelixConfigurationSpec{LogSeverityScreen: "Debug"},
+			})).NotTo(HaveOccurred())
+			install.Spec.WireGuard = &operator.WireGuard{
+				NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "secure"}},
+			}
+			Expect(r.reconcileWireGuard(ctx, install)).NotTo(HaveOccurred())
+
+			install.Spec.WireGuard = nil
+			Expect(r.reconcileWireGuard(ctx, install)).NotTo(HaveOccurred())
+			Expect(install.Status.WireGuard).To(BeNil())
+
+			// The FelixConfiguration that the operator created is deleted, the one that already existed is kept.
+			err := c.Get(ctx, client.ObjectKey{Name: "node.node-a"}, &crdv1.FelixConfiguration{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			fc := &crdv1.FelixConfiguration{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "node.node-b"}, fc)).NotTo(HaveOccurred())
+			Expect(fc.Spec.WireguardEnabled).To(BeNil())
+			Expect(fc.Spec.LogSeverityScreen).To(Equal("Debug"))
+		})
+	})
+
+	Context("setWireGuardCondition", func() {
+		It("should report whether all enabled nodes are encrypted", func() {
+			install := &operator.Installation{}
+			install.Status.WireGuard = &operator.WireGuardStatus{Nodes: 3, EnabledNodes: 2, EncryptedNodes: 1}
+			setWireGuardCondition(install)
+			Expect(meta.IsStatusConditionFalse(install.Status.Conditions, operator.WireGuardEncryptedCondition)).To(BeTrue())
+
+			install.Status.WireGuard.EncryptedNodes = 2
+			setWireGuardCondition(install)
+			Expect(meta.IsStatusConditionTrue(install.Status.Conditions, operator.WireGuardEncryptedCondition)).To(BeTrue())
+
+			install.Status.WireGuard = nil
+			setWireGuardCondition(install)
+			Expect(meta.FindStatusCondition(install.Status.Conditions, operator.WireGuardEncryptedCondition)).To(BeNil())
+		})
+	})
+})
@@ -17,8 +17,8 @@ package intrusiondetection
 import (
 	"context"
 	"fmt"
-
-	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -42,6 +42,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	"github.com/tigera/operator/pkg/controller/installation"
 	"github.com/tigera/operator/pkg/controller/logcollector"
+	"github.com/tigera/operator/pkg/controller/logstorage/initializer"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/tenancy"
@@ -54,11 +55,16 @@ import (
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/intrusiondetection/dpi"
+	"github.com/tigera/operator/pkg/telemetry"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
 const tigeraStatusName = "intrusion-detection"
 
+// logStorageDependencies are the components of the LogStorage that intrusion detection waits for when the operator
+// manages Elasticsearch.
+var logStorageDependencies = []string{initializer.TigeraStatusLogStorageElastic}
+
 var log = logf.Log.WithName("controller_intrusiondetection")
 
 // Add creates a new IntrusionDetection Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -71,13 +77,14 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 	licenseAPIReady := &utils.ReadyFlag{}
 	dpiAPIReady := &utils.ReadyFlag{}
+	threatFeedAPIReady := &utils.ReadyFlag{}
 	tierWatchReady := &utils.ReadyFlag{}
 
 	// Create the reconciler
-	reconciler := newReconciler(mgr, opts, licenseAPIReady, dpiAPIReady, tierWatchReady)
+	reconciler := newReconciler(mgr, opts, licenseAPIReady, dpiAPIReady, threatFeedAPIReady, tierWatchReady)
 
 	// Create a new controller
-	c, err := ctrlruntime.NewController("intrusiondetection-controller", mgr, controller.Options{Reconciler: reconcile.Reconciler(reconciler)})
+	c, err := ctrlruntime.NewController("intrusiondetection-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("intrusiondetection-controller", reconciler)})
 	if err != nil {
 		return fmt.Errorf("failed to create intrusiondetection-controller: %v", err)
 	}
@@ -106,6 +113,11 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		go utils.WaitToAddResourceWatch(c, k8sClient, log, dpiAPIReady,
 			[]client.Object{&v3.DeepPacketInspection{TypeMeta: metav1.TypeMeta{Kind: v3.KindDeepPacketInspection}}})
 		policiesToWatch = append(policiesToWatch, types.NamespacedName{Name: dpi.DeepPacketInspectionPolicyName, Namespace: dpi.DeepPacketInspectionNamespace})
+
+		// GlobalThreatFeeds are watched to report their sync status on the IntrusionDetection status. They are
+		// cluster-scoped, so this is only done in single-tenant mode.
+		go utils.WaitToAddResourceWatch(c, k8sClient, log, threatFeedAPIReady,
+			[]client.Object{&v3.GlobalThreatFeed{TypeMeta: metav1.TypeMeta{Kind: v3.KindGlobalThreatFeed}}})
 	}
 	go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, policiesToWatch)
 	go utils.WaitToAddLicenseKeyWatch(c, k8sClient, log, licenseAPIReady)
@@ -133,6 +145,9 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	if err = utils.AddTigeraStatusWatch(c, tigeraStatusName); err != nil {
 		return fmt.Errorf("intrusiondetection-controller failed to watch intrusion-detection Tigerastatus: %w", err)
 	}
+	if err = utils.AddDependencyWatch(c, eventHandler, logStorageDependencies...); err != nil {
+		return fmt.Errorf("intrusiondetection-controller failed to watch the LogStorage dependencies: %w", err)
+	}
 
 	for _, secretName := range []string{
 		render.ManagerInternalTLSSecretName,
@@ -167,18 +182,19 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, dpiAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, dpiAPIReady *utils.ReadyFlag, threatFeedAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
 	r := &ReconcileIntrusionDetection{
-		client:          mgr.GetClient(),
-		scheme:          mgr.GetScheme(),
-		provider:        opts.DetectedProvider,
-		status:          status.New(mgr.GetClient(), tigeraStatusName, opts.KubernetesVersion),
-		clusterDomain:   opts.ClusterDomain,
-		licenseAPIReady: licenseAPIReady,
-		dpiAPIReady:     dpiAPIReady,
-		tierWatchReady:  tierWatchReady,
-		multiTenant:     opts.MultiTenant,
-		elasticExternal: opts.ElasticExternal,
+		client:             telemetry.Client(mgr.GetClient()),
+		scheme:             mgr.GetScheme(),
+		provider:           opts.DetectedProvider,
+		status:             status.New(mgr.GetClient(), tigeraStatusName, opts.KubernetesVersion),
+		clusterDomain:      opts.ClusterDomain,
+		licenseAPIReady:    licenseAPIReady,
+		dpiAPIReady:        dpiAPIReady,
+		threatFeedAPIReady: threatFeedAPIReady,
+		tierWatchReady:     tierWatchReady,
+		multiTenant:        opts.MultiTenant,
+		elasticExternal:    opts.ElasticExternal,
 	}
 	r.status.Run(opts.ShutdownContext)
 	return r
@@ -191,16 +207,17 @@ var _ reconcile.Reconciler = &ReconcileIntrusionDetection{}
 type ReconcileIntrusionDetection struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
-	client          client.Client
-	scheme          *runtime.Scheme
-	provider        operatorv1.Provider
-	status          status.StatusManager
-	clusterDomain   string
-	licenseAPIReady *utils.ReadyFlag
-	dpiAPIReady     *utils.ReadyFlag
-	tierWatchReady  *utils.ReadyFlag
-	multiTenant     bool
-	elasticExternal bool
+	client             client.Client
+	scheme             *runtime.Scheme
+	provider           operatorv1.Provider
+	status             status.StatusManager
+	clusterDomain      string
+	licenseAPIReady    *utils.ReadyFlag
+	dpiAPIReady        *utils.ReadyFlag
+	threatFeedAPIReady *utils.ReadyFlag
+	tierWatchReady     *utils.ReadyFlag
+	multiTenant        bool
+	elasticExternal    bool
 }
 
 func getIntrusionDetection(ctx context.Context, cli client.Client, mt bool, ns string) (*operatorv1.IntrusionDetection, error) {
@@ -273,6 +290,10 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 		}
 	}
 
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	managementClusterConnection, err := utils.GetManagementClusterConnection(ctx, r.client)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to read ManagementClusterConnection", err, reqLogger)
@@ -287,39 +308,40 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 	}
 	isManagementCluster := managementCluster != nil
 
+	if err := validateIntrusionDetection(instance); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid IntrusionDetection", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
+
 	if err := r.fillDefaults(ctx, instance); err != nil {
 		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Unable to set defaults on IntrusionDetection", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
 	if !utils.IsAPIServerReady(r.client, reqLogger) {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", status.WithCode(operatorv1.APIServerNotReady, nil), reqLogger)
 		return reconcile.Result{}, err
 	}
 
 	if !isManagedCluster && !r.elasticExternal {
-		// Check if Elasticsearch is ready.
-		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
-		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", err, reqLogger)
-			return reconcile.Result{}, err
-		}
-		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", nil, reqLogger)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		// Wait for the Elasticsearch cluster of the LogStorage to be available. The controller is reconciled again once
+		// it is, through the dependency watch.
+		if !r.status.WaitForDependencies(logStorageDependencies...) {
+			reqLogger.Info("Waiting for the LogStorage to be available", "dependencies", logStorageDependencies)
+			return reconcile.Result{}, nil
 		}
 	}
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
 	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", status.WithCode(operatorv1.TierNotReady, err), reqLogger)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		} else {
 			r.status.SetDegraded(operatorv1.ResourceNotReady, "Error querying allow-tigera tier", err, reqLogger)
@@ -328,14 +350,14 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 	}
 
 	if !r.licenseAPIReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", status.WithCode(operatorv1.LicenseAPINotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
 	license, err := utils.FetchLicenseKey(ctx, r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", status.WithCode(operatorv1.LicenseNotFound, err), reqLogger)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying license", err, reqLogger)
@@ -346,7 +368,7 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 	variant, network, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, reqLogger)
@@ -356,7 +378,7 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 	// Query for pull secrets in operator namespace
 	pullSecrets, err := utils.GetNetworkingPullSecrets(network, r.client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -377,7 +399,7 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 	}
 	certificateManager, err := certificatemanager.Create(r.client, network, r.clusterDomain, helper.TruthNamespace(), opts...)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -393,7 +415,7 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 	} else if linseedCertificate == nil {
 		log.Info("Linseed certificate is not available yet, waiting until they become available")
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Linseed certificate are not available yet, waiting until they become available", nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	// intrusionDetectionKeyPair is the key pair intrusion detection presents to identify itself
@@ -475,7 +497,7 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 	intrusionDetectionComponent := render.IntrusionDetection(intrusionDetectionCfg)
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, intrusionDetectionComponent); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -537,7 +559,7 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 			DPICertSecret:      dpiKeyPair,
 		})
 		if err = imageset.ApplyImageSet(ctx, r.client, variant, dpiComponent); err != nil {
-			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		components = append(components, dpiComponent)
@@ -561,7 +583,7 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 
 	if hasNoLicense {
 		log.V(4).Info("IntrusionDetection is not activated as part of this license")
-		r.status.SetDegraded(operatorv1.ResourceValidationError, "Feature is not active - License does not support this feature", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Feature is not active - License does not support this feature", status.WithCode(operatorv1.LicenseFeatureNotAvailable, nil), reqLogger)
 		return reconcile.Result{}, nil
 	}
 
@@ -576,12 +598,65 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 
 	// Everything is available - update the CRD status.
 	instance.Status.State = operatorv1.TigeraStatusReady
+	if !r.multiTenant && r.threatFeedAPIReady.IsReady() {
+		feeds, err := threatFeedStatuses(ctx, r.client)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying GlobalThreatFeeds", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		instance.Status.ThreatFeeds = feeds
+	}
 	if err = r.client.Status().Update(ctx, instance); err != nil {
 		return reconcile.Result{}, err
 	}
 	return reconcile.Result{}, nil
 }
 
+// threatFeedStatuses returns the sync status of each GlobalThreatFeed, sorted by name.
+func threatFeedStatuses(ctx context.Context, cli client.Client) ([]operatorv1.ThreatFeedStatus, error) {
+	feeds := &v3.GlobalThreatFeedList{}
+	if err := cli.List(ctx, feeds); err != nil {
+		return nil, err
+	}
+	var statuses []operatorv1.ThreatFeedStatus
+	for _, feed := range feeds.Items {
+		var errs []string
+		for _, c := range feed.Status.ErrorConditions {
+			errs = append(errs, fmt.Sprintf("%s: %s", c.Type, c.Message))
+		}
+		statuses = append(statuses, operatorv1.ThreatFeedStatus{
+			Name:               feed.Name,
+			LastSuccessfulSync: feed.Status.LastSuccessfulSync,
+			Error:              strings.Join(errs, "; "),
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses, nil
+}
+
+// validateIntrusionDetection validates the IntrusionDetection resource.
+func validateIntrusionDetection(ids *operatorv1.IntrusionDetection) error {
+	detectors := map[operatorv1.IntrusionDetectionDetectorName]bool{}
+	for _, d := range ids.Spec.Detectors {
+		if detectors[d.Name] {
+			return fmt.Errorf("detector %s is configured more than once", d.Name)
+		}
+		detectors[d.Name] = true
+	}
+	if tf := ids.Spec.ThreatFeeds; tf != nil {
+		if tf.PullInterval != nil && tf.PullInterval.Duration <= 0 {
+			return fmt.Errorf("threatFeeds.pullInterval must be greater than 0")
+		}
+		if tf.PullTimeout != nil && tf.PullTimeout.Duration <= 0 {
+			return fmt.Errorf("threatFeeds.pullTimeout must be greater than 0")
+		}
+		if tf.RetryBackoff != nil && tf.RetryBackoff.Duration <= 0 {
+			return fmt.Errorf("threatFeeds.retryBackoff must be greater than 0")
+		}
+	}
+	return nil
+}
+
 // fillDefaults updates the IntrusionDetection resource with defaults if
 // ComponentResources is not populated.
 func (r *ReconcileIntrusionDetection) fillDefaults(ctx context.Context, ids *operatorv1.IntrusionDetection) error {
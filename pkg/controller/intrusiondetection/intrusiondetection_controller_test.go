@@ -44,9 +44,11 @@ import (
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/logstorage/initializer"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
@@ -99,15 +101,17 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 
 		mockStatus.On("ReadyToMonitor")
 		mockStatus.On("SetMetaData", mock.Anything).Return()
+		mockStatus.On("WaitForDependencies", []string{initializer.TigeraStatusLogStorageElastic}).Return(true)
 
 		r = ReconcileIntrusionDetection{
-			client:          c,
-			scheme:          scheme,
-			provider:        operatorv1.ProviderNone,
-			status:          mockStatus,
-			licenseAPIReady: &utils.ReadyFlag{},
-			dpiAPIReady:     &utils.ReadyFlag{},
-			tierWatchReady:  &utils.ReadyFlag{},
+			client:             c,
+			scheme:             scheme,
+			provider:           operatorv1.ProviderNone,
+			status:             mockStatus,
+			licenseAPIReady:    &utils.ReadyFlag{},
+			dpiAPIReady:        &utils.ReadyFlag{},
+			threatFeedAPIReady: &utils.ReadyFlag{},
+			tierWatchReady:     &utils.ReadyFlag{},
 		}
 
 		// We start off with a 'standard' installation, with nothing special
@@ -184,6 +188,7 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 		// mark that the watches were successful
 		r.licenseAPIReady.MarkAsReady()
 		r.dpiAPIReady.MarkAsReady()
+		r.threatFeedAPIReady.MarkAsReady()
 		r.tierWatchReady.MarkAsReady()
 	})
 
@@ -308,17 +313,19 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 			mockStatus = &status.MockStatus{}
 			mockStatus.On("OnCRFound").Return()
 			mockStatus.On("SetMetaData", mock.Anything).Return()
+			mockStatus.On("WaitForDependencies", mock.Anything).Return(true)
 
 			readyFlag = &utils.ReadyFlag{}
 			readyFlag.MarkAsReady()
 			r = ReconcileIntrusionDetection{
-				client:          c,
-				scheme:          scheme,
-				provider:        operatorv1.ProviderNone,
-				status:          mockStatus,
-				licenseAPIReady: readyFlag,
-				dpiAPIReady:     readyFlag,
-				tierWatchReady:  readyFlag,
+				client:             c,
+				scheme:             scheme,
+				provider:           operatorv1.ProviderNone,
+				status:             mockStatus,
+				licenseAPIReady:    readyFlag,
+				dpiAPIReady:        readyFlag,
+				threatFeedAPIReady: readyFlag,
+				tierWatchReady:     readyFlag,
 			}
 		})
 
@@ -386,6 +393,24 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 			Expect(*ids.Spec.ComponentResources[0].ResourceRequirements.Limits.Memory()).Should(Equal(resource.MustParse(dpi.DefaultMemoryLimit)))
 		})
 
+		It("should wait for the LogStorage to be available without rendering", func() {
+			waitingStatus := &status.MockStatus{}
+			waitingStatus.On("OnCRFound").Return()
+			waitingStatus.On("SetMetaData", mock.Anything).Return()
+			waitingStatus.On("WaitForDependencies", []string{initializer.TigeraStatusLogStorageElastic}).Return(false)
+			r.status = waitingStatus
+
+			result, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+			waitingStatus.AssertExpectations(GinkgoT())
+			Expect(waitingStatus.WasCalled("SetDegraded")).To(BeFalse())
+
+			Expect(test.GetResource(c, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "intrusion-detection-controller", Namespace: render.IntrusionDetectionNamespace},
+			})).To(HaveOccurred())
+		})
+
 		It("should not overwrite resource requirements if they are already set", func() {
 			By("Deleting the previous IntrusionDetection")
 			Expect(c.Delete(ctx, &operatorv1.IntrusionDetection{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
@@ -434,6 +459,80 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 		})
 	})
 
+	Context("detectors", func() {
+		It("should degrade when a detector is configured more than once", func() {
+			ids := operatorv1.IntrusionDetection{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+			Expect(test.GetResource(c, &ids)).To(BeNil())
+			ids.Spec.Detectors = []operatorv1.IntrusionDetectionDetector{
+				{Name: operatorv1.DetectorPortScan, Enabled: ptr.BoolToPtr(false)},
+				{Name: operatorv1.DetectorPortScan, Threshold: ptr.Int32ToPtr(10)},
+			}
+			Expect(c.Update(ctx, &ids)).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid IntrusionDetection", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid IntrusionDetection", mock.Anything, mock.Anything)
+		})
+	})
+
+	Context("threat feeds", func() {
+		It("should degrade when a threat feed interval is not positive", func() {
+			ids := operatorv1.IntrusionDetection{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+			Expect(test.GetResource(c, &ids)).To(BeNil())
+			ids.Spec.ThreatFeeds = &operatorv1.ThreatFeedsSpec{PullInterval: &metav1.Duration{}}
+			Expect(c.Update(ctx, &ids)).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid IntrusionDetection", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid IntrusionDetection", mock.Anything, mock.Anything)
+		})
+
+		It("should report the sync status of each feed", func() {
+			lastSync := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			Expect(c.Create(ctx, &v3.GlobalThreatFeed{
+				ObjectMeta: metav1.ObjectMeta{Name: "feed-b"},
+				Status: v3.GlobalThreatFeedStatus{
+					ErrorConditions: []v3.ErrorCondition{
+						{Type: "PullFailed", Message: "connection refused"},
+						{Type: "GlobalNetworkSetSyncFailed", Message: "forbidden"},
+					},
+				},
+			})).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &v3.GlobalThreatFeed{
+				ObjectMeta: metav1.ObjectMeta{Name: "feed-a"},
+				Status:     v3.GlobalThreatFeedStatus{LastSuccessfulSync: &lastSync},
+			})).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			ids := operatorv1.IntrusionDetection{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+			Expect(test.GetResource(c, &ids)).To(BeNil())
+			Expect(ids.Status.ThreatFeeds).To(HaveLen(2))
+			Expect(ids.Status.ThreatFeeds[0].Name).To(Equal("feed-a"))
+			Expect(ids.Status.ThreatFeeds[0].LastSuccessfulSync.Equal(&lastSync)).To(BeTrue())
+			Expect(ids.Status.ThreatFeeds[0].Error).To(BeEmpty())
+			Expect(ids.Status.ThreatFeeds[1]).To(Equal(operatorv1.ThreatFeedStatus{
+				Name:  "feed-b",
+				Error: "PullFailed: connection refused; GlobalNetworkSetSyncFailed: forbidden",
+			}))
+		})
+
+		It("should not report feeds until the GlobalThreatFeed API is ready", func() {
+			r.threatFeedAPIReady = &utils.ReadyFlag{}
+			Expect(c.Create(ctx, &v3.GlobalThreatFeed{ObjectMeta: metav1.ObjectMeta{Name: "feed-a"}})).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			ids := operatorv1.IntrusionDetection{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+			Expect(test.GetResource(c, &ids)).To(BeNil())
+			Expect(ids.Status.ThreatFeeds).To(BeEmpty())
+		})
+	})
+
 	Context("Reconcile for Condition status", func() {
 		generation := int64(2)
 
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"reflect"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	operator "github.com/tigera/operator/api/v1"
 	v1 "github.com/tigera/operator/api/v1"
 	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
@@ -124,8 +126,8 @@ type Reconciler struct {
 const (
 	// This label is used to track which IP pools are managed by this controller. Any IP pool
 	// with this label key/value pair is assumed to be solely managed and reconciled by this controller.
-	managedByLabel = "app.kubernetes.io/managed-by"
-	managedByValue = "tigera-operator"
+	managedByLabel = common.ManagedByLabel
+	managedByValue = common.ManagedByValue
 )
 
 // hasOwnerLabel returns true if the given IP pool is owned by the tigera/operator, and false otheriwse.
@@ -136,6 +138,20 @@ func hasOwnerLabel(pool *crdv1.IPPool) bool {
 	return false
 }
 
+// adoptPool returns true if the Installation enables ResourceAdoption and has a pool with the name and CIDR of the
+// given IP pool.
+func adoptPool(installation *operator.Installation, pool crdv1.IPPool) bool {
+	if !operator.IsResourceAdoptionEnabled(installation.Spec.ResourceAdoption) {
+		return false
+	}
+	for _, p := range installation.Spec.CalicoNetwork.IPPools {
+		if p.Name == pool.Name && p.CIDR == pool.Spec.CIDR {
+			return true
+		}
+	}
+	return false
+}
+
 // Reconcile reconciles IP pools in the cluster.
 //
 // - Query desired IP pools (from Installation)
@@ -160,6 +176,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	r.status.OnCRFound()
 	defer r.status.SetMetaData(&installation.ObjectMeta)
 
+	if utils.IsReconcilePaused(&installation.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// If the installation is terminating, do nothing.
 	if installation.DeletionTimestamp != nil {
 		reqLogger.Info("Installation is terminating, skipping IP pool reconciliation")
@@ -177,12 +197,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		}
 	}
 	if !readyToGo {
-		r.status.SetDegraded(operator.ResourceNotReady, "Waiting for Installation defaulting to occur", nil, reqLogger)
-		return reconcile.Result{}, nil
+		r.status.SetDegraded(operator.ResourceNotReady, "Waiting for Installation defaulting to occur", status.WithCode(operator.InstallationNotReady, nil), reqLogger)
+		return utils.RequeueWithBackoff(), nil
 	}
 	if installation.Spec.CNI == nil || installation.Spec.CNI.Type == "" {
 		r.status.SetDegraded(operator.ResourceNotReady, "Waiting for CNI type to be configured on Installation", nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	// Get all IP pools currently in the cluster.
@@ -260,6 +280,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 				reqLogger.V(1).Info("Assuming ownership of IP pool", "name", p.Name, "cidr", p.Spec.CIDR)
 				ourPools[p.Spec.CIDR] = p
 			}
+			if _, ok := ourPools[p.Spec.CIDR]; !ok && adoptPool(installation, p) {
+				// The Installation enables ResourceAdoption, and this IP pool has the name and CIDR of one of its pools.
+				// Adopt it, so that it is updated to match the Installation instead of conflicting with it.
+				reqLogger.Info("Adopting existing IP pool", "name", p.Name, "cidr", p.Spec.CIDR)
+				ourPools[p.Spec.CIDR] = p
+			}
 			if _, ok := ourPools[p.Spec.CIDR]; !ok {
 				// This IP pool exists in the cluster, but is not owned by us - mark it down so that
 				// we can refuse to update any pool with this CIDR if it exists in the Installation.
@@ -275,7 +301,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	// We will install pools at start-of-day using the CRD API, but otherwise
 	// we require the v3 API to be running. This is so that we properly leverage the v3 API's validation.
 	toCreateOrUpdate := []client.Object{}
-	for _, p := range installation.Spec.CalicoNetwork.IPPools {
+	for _, p := range migrationOrder(installation.Spec.CalicoNetwork.IPPools) {
 		// We need to check if updates are required, but the installation uses the operator API format and the queried
 		// pools are in crd.projectcalico.org/v1 format. Compare the pools using the crd.projectcalico.org/v1 format.
 		v1res, err := p.ToProjectCalicoV1()
@@ -284,6 +310,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 			return reconcile.Result{}, err
 		}
 		v1res.Labels[managedByLabel] = managedByValue
+		if pool, ok := ourPools[p.CIDR]; ok && p.Drain == nil {
+			// Only pools that are drained are disabled by the operator. Leave other pools enabled or disabled as they
+			// are, so that pools disabled outside of the operator stay disabled.
+			v1res.Spec.Disabled = pool.Spec.Disabled
+		}
 
 		// If there is an existing IP pool in the cluster with the same CIDR, but it is not owned by us, then we cannot
 		// take action on it.
@@ -323,7 +354,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 				// The v3 API is not available, and there are existing pools in the cluster. We cannot create new pools until the v3 API is available.
 				// The user may need to manually delete or update pools in order to allow the v3 API to launch successfully.
 				r.status.SetDegraded(operator.ResourceNotReady, "Unable to modify IP pools while Calico API server is unavailable", nil, reqLogger)
-				return reconcile.Result{}, nil
+				return utils.RequeueWithBackoff(), nil
 			}
 		}
 	}
@@ -356,7 +387,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 				// The v3 API is not available, so we can't delete the pool. Mark degraded and return. We'll delete the pool
 				// when the API server become available.
 				r.status.SetDegraded(operator.ResourceNotReady, "Unable to delete IP pools while Calico API server is unavailable", nil, reqLogger)
-				return reconcile.Result{}, nil
+				return utils.RequeueWithBackoff(), nil
 			}
 		}
 	}
@@ -378,6 +409,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, err
 	}
 
+	// Report the progress of the pools that are being drained.
+	drainStatus, err := r.drainStatus(ctx, installation.Spec.CalicoNetwork.IPPools)
+	if err != nil {
+		r.status.SetDegraded(operator.ResourceReadError, "Error querying IPAM blocks", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	if !reflect.DeepEqual(installation.Status.DrainingIPPools, drainStatus) {
+		installation.Status.DrainingIPPools = drainStatus
+		if err := r.client.Status().Update(ctx, installation); err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Error updating the IP pool drain status", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Tell the status manager that we're ready to monitor the resources we've told it about and receive statuses.
 	r.status.ReadyToMonitor()
 
@@ -390,9 +435,74 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	for _, d := range drainStatus {
+		if !d.Drained {
+			// IPAM blocks are not watched, so check back on the progress of the drain.
+			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		}
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// migrationOrder returns the pools with the pools that are not drained first, so that the pools that workloads
+// move to exist before the pools they move away from are disabled.
+func migrationOrder(pools []v1.IPPool) []v1.IPPool {
+	ordered := make([]v1.IPPool, 0, len(pools))
+	for _, p := range pools {
+		if p.Drain == nil || !*p.Drain {
+			ordered = append(ordered, p)
+		}
+	}
+	for _, p := range pools {
+		if p.Drain != nil && *p.Drain {
+			ordered = append(ordered, p)
+		}
+	}
+	return ordered
+}
+
+// drainStatus returns the number of IP addresses that are still allocated from each of the pools that are drained.
+func (r *Reconciler) drainStatus(ctx context.Context, pools []v1.IPPool) ([]v1.IPPoolDrainStatus, error) {
+	var draining []v1.IPPool
+	for _, p := range pools {
+		if p.Drain != nil && *p.Drain {
+			draining = append(draining, p)
+		}
+	}
+	if len(draining) == 0 {
+		return nil, nil
+	}
+
+	blocks := &crdv1.IPAMBlockList{}
+	if err := r.client.List(ctx, blocks); err != nil {
+		return nil, err
+	}
+
+	var status []v1.IPPoolDrainStatus
+	for _, p := range draining {
+		_, poolNet, err := net.ParseCIDR(p.CIDR)
+		if err != nil {
+			return nil, err
+		}
+		allocated := 0
+		for i := range blocks.Items {
+			blockIP, _, err := net.ParseCIDR(blocks.Items[i].Spec.CIDR)
+			if err != nil || !poolNet.Contains(blockIP) {
+				continue
+			}
+			allocated += blocks.Items[i].InUse()
+		}
+		status = append(status, v1.IPPoolDrainStatus{
+			Name:         p.Name,
+			CIDR:         p.CIDR,
+			AllocatedIPs: int32(allocated),
+			Drained:      allocated == 0,
+		})
+	}
+	return status, nil
+}
+
 func CRDPoolsToOperator(crds []crdv1.IPPool) []v1.IPPool {
 	pools := []v1.IPPool{}
 	for _, p := range crds {
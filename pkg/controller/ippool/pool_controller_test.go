@@ -32,6 +32,7 @@ import (
 	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/render"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -66,7 +67,7 @@ var _ = Describe("IP Pool controller tests", func() {
 		Expect(storagev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 
 		// Create a client that will have a crud interface of k8s objects.
-		c = fake.NewClientBuilder().WithScheme(scheme).Build()
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
 		ctx, cancel = context.WithCancel(context.Background())
 
 		// Create an object we can use throughout the test to do the compliance reconcile loops.
@@ -257,7 +258,7 @@ var _ = Describe("IP Pool controller tests", func() {
 		}
 		for _, pool := range instance.Spec.CalicoNetwork.IPPools {
 			Expect(poolsByCIDR).To(HaveKey(pool.CIDR))
-			Expect(poolsByCIDR[pool.CIDR].Labels).To(Equal(map[string]string{"app.kubernetes.io/managed-by": "tigera-operator"}))
+			Expect(poolsByCIDR[pool.CIDR].Labels).To(HaveKeyWithValue("app.kubernetes.io/managed-by", "tigera-operator"))
 		}
 	})
 
@@ -307,6 +308,67 @@ var _ = Describe("IP Pool controller tests", func() {
 		mockStatus.AssertExpectations(GinkgoT())
 	})
 
+	Context("with an IP pool created outside of the operator", func() {
+		var instance *operator.Installation
+
+		BeforeEach(func() {
+			instance = &operator.Installation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "default",
+					Finalizers: []string{"tigera.io/operator-cleanup"},
+				},
+				Spec: operator.InstallationSpec{
+					Variant:  operator.Calico,
+					Registry: "some.registry.org/",
+					CNI: &operator.CNISpec{
+						Type: operator.PluginCalico,
+						IPAM: &operator.IPAMSpec{Type: operator.IPAMPluginCalico},
+					},
+					CalicoNetwork: &operator.CalicoNetworkSpec{
+						IPPools: []operator.IPPool{
+							{Name: "default-ipv4-ippool", CIDR: "192.168.0.0/16", NATOutgoing: "Enabled"},
+						},
+					},
+				},
+			}
+
+			// An IP pool created by the Calico manifests, which doesn't match the pool in the Installation.
+			Expect(c.Create(ctx, &crdv1.IPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "default-ipv4-ippool"},
+				Spec:       crdv1.IPPoolSpec{CIDR: "192.168.0.0/16", IPIPMode: crdv1.IPIPModeAlways},
+			})).ShouldNot(HaveOccurred())
+
+			mockStatus.On("OnCRFound")
+			mockStatus.On("SetMetaData", mock.Anything)
+		})
+
+		It("should not update the IP pool", func() {
+			Expect(c.Create(ctx, instance)).ShouldNot(HaveOccurred())
+			mockStatus.On("SetDegraded", operator.ResourceValidationError, "Cannot update an IP pool not owned by the operator", nil, mock.Anything)
+			mockStatus.On("IsAvailable").Return(true)
+			mockStatus.On("ReadyToMonitor")
+			mockStatus.On("ClearDegraded")
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertExpectations(GinkgoT())
+		})
+
+		It("should adopt the IP pool when ResourceAdoption is enabled", func() {
+			adoption := operator.ResourceAdoptionEnabled
+			instance.Spec.ResourceAdoption = &adoption
+			Expect(c.Create(ctx, instance)).ShouldNot(HaveOccurred())
+
+			// The adopted pool needs to be updated, which requires the API server.
+			mockStatus.On("SetDegraded", operator.ResourceNotReady, "Unable to modify IP pools while Calico API server is unavailable", nil, mock.Anything)
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertExpectations(GinkgoT())
+			mockStatus.AssertNotCalled(GinkgoT(), "SetDegraded", operator.ResourceValidationError, "Cannot update an IP pool not owned by the operator", nil, mock.Anything)
+		})
+	})
+
 	It("should disallow deletion if there is no API server", func() {
 		instance := &operator.Installation{
 			ObjectMeta: metav1.ObjectMeta{
@@ -358,6 +420,136 @@ var _ = Describe("IP Pool controller tests", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(ipPools.Items).To(HaveLen(1))
 	})
+
+	It("should not drain an existing disabled IP pool on upgrade", func() {
+		disableBGPExport := false
+		instance := &operator.Installation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "default",
+				Finalizers: []string{"tigera.io/operator-cleanup"},
+			},
+			Spec: operator.InstallationSpec{
+				Variant:  operator.Calico,
+				Registry: "some.registry.org/",
+				CNI: &operator.CNISpec{
+					Type: operator.PluginCalico,
+					IPAM: &operator.IPAMSpec{Type: operator.IPAMPluginCalico},
+				},
+				CalicoNetwork: &operator.CalicoNetworkSpec{
+					IPPools: []operator.IPPool{
+						{Name: "default-ipv4-ippool", CIDR: "192.168.0.0/16", NATOutgoing: "Enabled", DisableBGPExport: &disableBGPExport},
+					},
+				},
+			},
+		}
+		Expect(c.Create(ctx, instance)).ShouldNot(HaveOccurred())
+
+		mockStatus.On("OnCRFound")
+		mockStatus.On("SetMetaData", mock.Anything)
+		mockStatus.On("IsAvailable").Return(true)
+		mockStatus.On("ReadyToMonitor")
+		mockStatus.On("ClearDegraded")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// Simulate a pool created by an older version of the operator, without the managed-by label, that was
+		// disabled outside of the operator.
+		ipPools := crdv1.IPPoolList{}
+		Expect(c.List(ctx, &ipPools)).ShouldNot(HaveOccurred())
+		Expect(ipPools.Items).To(HaveLen(1))
+		pool := ipPools.Items[0]
+		delete(pool.Labels, "app.kubernetes.io/managed-by")
+		pool.Spec.Disabled = true
+		Expect(c.Update(ctx, &pool)).ShouldNot(HaveOccurred())
+
+		// The pool is still recognized as the pool of the Installation, and left disabled without being drained.
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		mockStatus.AssertExpectations(GinkgoT())
+		mockStatus.AssertNotCalled(GinkgoT(), "SetDegraded", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(&pool), &pool)).ShouldNot(HaveOccurred())
+		Expect(pool.Spec.Disabled).To(BeTrue())
+		Expect(c.Get(ctx, utils.DefaultInstanceKey, instance)).ShouldNot(HaveOccurred())
+		Expect(instance.Spec.CalicoNetwork.IPPools[0].Drain).To(BeNil())
+		Expect(instance.Status.DrainingIPPools).To(BeEmpty())
+	})
+
+	It("should disable drained IP pools and report the progress of the drain", func() {
+		drain := true
+		instance := &operator.Installation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "default",
+				Finalizers: []string{"tigera.io/operator-cleanup"},
+			},
+			Spec: operator.InstallationSpec{
+				Variant:  operator.Calico,
+				Registry: "some.registry.org/",
+				CNI: &operator.CNISpec{
+					Type: operator.PluginCalico,
+					IPAM: &operator.IPAMSpec{Type: operator.IPAMPluginCalico},
+				},
+				CalicoNetwork: &operator.CalicoNetworkSpec{
+					IPPools: []operator.IPPool{
+						{Name: "old-pool", CIDR: "192.168.0.0/16", Drain: &drain},
+						{Name: "new-pool", CIDR: "172.16.0.0/16"},
+					},
+				},
+			},
+		}
+		Expect(c.Create(ctx, instance)).ShouldNot(HaveOccurred())
+
+		// Two addresses are allocated from a block of the drained pool, and one from a block of the new pool.
+		allocation := 0
+		Expect(c.Create(ctx, &crdv1.IPAMBlock{
+			ObjectMeta: metav1.ObjectMeta{Name: "192-168-0-0-26"},
+			Spec:       crdv1.IPAMBlockSpec{CIDR: "192.168.0.0/26", Allocations: []*int{&allocation, nil, &allocation}},
+		})).ShouldNot(HaveOccurred())
+		Expect(c.Create(ctx, &crdv1.IPAMBlock{
+			ObjectMeta: metav1.ObjectMeta{Name: "172-16-0-0-26"},
+			Spec:       crdv1.IPAMBlockSpec{CIDR: "172.16.0.0/26", Allocations: []*int{&allocation}},
+		})).ShouldNot(HaveOccurred())
+
+		mockStatus.On("OnCRFound")
+		mockStatus.On("SetMetaData", mock.Anything)
+		mockStatus.On("IsAvailable").Return(true)
+		mockStatus.On("ReadyToMonitor")
+		mockStatus.On("ClearDegraded")
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(utils.StandardRetry))
+
+		// The drained pool is disabled, so that no new addresses are allocated from it.
+		ipPools := crdv1.IPPoolList{}
+		Expect(c.List(ctx, &ipPools)).ShouldNot(HaveOccurred())
+		Expect(ipPools.Items).To(HaveLen(2))
+		for _, pool := range ipPools.Items {
+			Expect(pool.Spec.Disabled).To(Equal(pool.Spec.CIDR == "192.168.0.0/16"))
+		}
+
+		Expect(c.Get(ctx, utils.DefaultInstanceKey, instance)).ShouldNot(HaveOccurred())
+		Expect(instance.Status.DrainingIPPools).To(Equal([]operator.IPPoolDrainStatus{
+			{Name: "old-pool", CIDR: "192.168.0.0/16", AllocatedIPs: 2, Drained: false},
+		}))
+
+		// Release the addresses of the drained pool.
+		block := &crdv1.IPAMBlock{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "192-168-0-0-26"}, block)).ShouldNot(HaveOccurred())
+		block.Spec.Allocations = []*int{nil, nil, nil}
+		Expect(c.Update(ctx, block)).ShouldNot(HaveOccurred())
+
+		result, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(c.Get(ctx, utils.DefaultInstanceKey, instance)).ShouldNot(HaveOccurred())
+		Expect(instance.Status.DrainingIPPools).To(Equal([]operator.IPPoolDrainStatus{
+			{Name: "old-pool", CIDR: "192.168.0.0/16", AllocatedIPs: 0, Drained: true},
+		}))
+	})
 })
 
 var _ = table.DescribeTable("cidrWithinCidr",
@@ -843,6 +1035,28 @@ var _ = Describe("validate()", func() {
 		err = ValidatePools(instance)
 		Expect(err).To(HaveOccurred())
 	})
+
+	It("should only allow draining a pool if another pool of the same family is left", func() {
+		drain := true
+		pool := func(cidr string, drain *bool) operator.IPPool {
+			return operator.IPPool{
+				Name:          cidr,
+				CIDR:          cidr,
+				Encapsulation: operator.EncapsulationNone,
+				NATOutgoing:   operator.NATOutgoingEnabled,
+				NodeSelector:  "all()",
+				Drain:         drain,
+			}
+		}
+		instance.Spec.CalicoNetwork.IPPools = []operator.IPPool{
+			pool("192.168.0.0/16", &drain),
+			pool("fd5f:abcd::/64", nil),
+		}
+		Expect(ValidatePools(instance)).To(MatchError(ContainSubstring("there is an IPv4 IP pool that is not drained")))
+
+		instance.Spec.CalicoNetwork.IPPools = append(instance.Spec.CalicoNetwork.IPPools, pool("172.16.0.0/16", nil))
+		Expect(ValidatePools(instance)).NotTo(HaveOccurred())
+	})
 })
 
 // fillPrerequisiteDefaults fills in some defaults the IP pool controller relies on.
@@ -15,9 +15,12 @@
 package ippool
 
 import (
+	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+
 	operator "github.com/tigera/operator/api/v1"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
 )
 
 var true_ = true
@@ -44,3 +47,12 @@ var _ = table.DescribeTable("IPPool operator.tigera.io <-> crd.projectcalico.org
 		AllowedUses:      []operator.IPPoolAllowedUse{operator.IPPoolAllowedUseWorkload},
 	}),
 )
+
+var _ = Describe("IPPool crd.projectcalico.org/v1 conversion", func() {
+	It("should not drain disabled IP pools", func() {
+		crdPool := crdv1.IPPool{Spec: crdv1.IPPoolSpec{CIDR: "172.16.0.0/16", Disabled: true}}
+		operPool := operator.IPPool{}
+		operPool.FromProjectCalicoV1(crdPool)
+		Expect(operPool.Drain).To(BeNil())
+	})
+})
@@ -26,6 +26,10 @@ import (
 func ValidatePools(instance *operator.Installation) error {
 	cidrs := map[string]bool{}
 	names := map[string]bool{}
+
+	// Track, per address family, whether pools are being drained and whether any pool is left to allocate from.
+	draining := map[bool]bool{}
+	active := map[bool]bool{}
 	for _, pool := range instance.Spec.CalicoNetwork.IPPools {
 		_, cidr, err := net.ParseCIDR(pool.CIDR)
 		if err != nil {
@@ -77,6 +81,11 @@ func ValidatePools(instance *operator.Installation) error {
 
 		// Verify per-address-family settings.
 		isIPv4 := !strings.Contains(pool.CIDR, ":")
+		if pool.Drain != nil && *pool.Drain {
+			draining[isIPv4] = true
+		} else {
+			active[isIPv4] = true
+		}
 		if isIPv4 {
 			// This is an IPv4 pool.
 			if pool.BlockSize != nil {
@@ -105,5 +114,16 @@ func ValidatePools(instance *operator.Installation) error {
 			}
 		}
 	}
+
+	// Workloads need another pool to move to when a pool is drained.
+	for isIPv4 := range draining {
+		if !active[isIPv4] {
+			family := "IPv6"
+			if isIPv4 {
+				family = "IPv4"
+			}
+			return fmt.Errorf("IP pools can only be drained if there is an %s IP pool that is not drained", family)
+		}
+	}
 	return nil
 }
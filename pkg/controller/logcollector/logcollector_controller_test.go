@@ -311,6 +311,38 @@ var _ = Describe("LogCollector controller tests", func() {
 				Expect(node.Env).To(ContainElements(s3Vars))
 			})
 
+			It("should forward logs to s3 with an IAM role instead of the s3 secret", func() {
+				Expect(c.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "log-collector-s3-credentials", Namespace: "tigera-operator"}})).NotTo(HaveOccurred())
+				lc := &operatorv1.LogCollector{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+				Expect(test.GetResource(c, lc)).To(BeNil())
+				lc.Spec.AdditionalStores.S3.RoleARN = "arn:aws:iam::123456789012:role/fluentd"
+				Expect(c.Update(ctx, lc)).NotTo(HaveOccurred())
+
+				_, err := r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				sa := corev1.ServiceAccount{
+					TypeMeta: metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fluentd-node",
+						Namespace: render.LogCollectorNamespace,
+					},
+				}
+				Expect(test.GetResource(c, &sa)).To(BeNil())
+				Expect(sa.Annotations).To(HaveKeyWithValue("eks.amazonaws.com/role-arn", "arn:aws:iam::123456789012:role/fluentd"))
+
+				ds := appsv1.DaemonSet{
+					TypeMeta: metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fluentd-node",
+						Namespace: render.LogCollectorNamespace,
+					},
+				}
+				Expect(test.GetResource(c, &ds)).To(BeNil())
+				Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ContainElements(s3Vars[2:]))
+				Expect(ds.Spec.Template.Spec.Containers[0].Env).NotTo(ContainElement(s3Vars[0]))
+			})
+
 			Context("Disable feature via license", func() {
 				BeforeEach(func() {
 					By("Deleting the previous license")
@@ -0,0 +1,259 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliases
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	logstoragecommon "github.com/tigera/operator/pkg/controller/logstorage/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/telemetry"
+)
+
+var log = logf.Log.WithName("controller_logstorage_aliases")
+
+// LogIndexPattern matches the log indices in the multi-index format, which Linseed writes through aliases.
+const LogIndexPattern = "tigera_secure_ee_*"
+
+// generationSuffix matches the rollover generation at the end of the name of an index that is written through an alias.
+var generationSuffix = regexp.MustCompile(`-(\d+)$`)
+
+// AliasController repairs the aliases that Linseed writes the logs through. Linseed writes each index group of each
+// cluster to an alias named tigera_secure_ee_<group>.[<tenant>.]<cluster>., and ILM rolls the alias over to a new backing
+// index named after the alias with an increasing generation suffix. Restoring the indices from a snapshot without their
+// aliases, or rolling over or deleting indices by hand, leaves the aliases missing or writing to the wrong index, which
+// makes the ingestion fail. The controller adds the alias back to all of its backing indices, and makes the newest one
+// the write index.
+type AliasController struct {
+	client          client.Client
+	esClientFn      utils.ElasticsearchClientCreator
+	multiTenant     bool
+	elasticExternal bool
+}
+
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		return nil
+	}
+
+	esClientFn, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
+	r := &AliasController{
+		client:          telemetry.Client(mgr.GetClient()),
+		esClientFn:      esClientFn,
+		multiTenant:     opts.MultiTenant,
+		elasticExternal: opts.ElasticExternal,
+	}
+
+	c, err := ctrlruntime.NewController("log-storage-aliases-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-aliases-controller", r)})
+	if err != nil {
+		return err
+	}
+
+	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-aliases-controller failed to watch LogStorage resource: %w", err)
+	}
+	if !opts.ElasticExternal {
+		if err = c.WatchObject(&esv1.Elasticsearch{}, &handler.EnqueueRequestForObject{}); err != nil {
+			return fmt.Errorf("log-storage-aliases-controller failed to watch Elasticsearch resource: %w", err)
+		}
+	}
+
+	// The aliases are changed outside of Kubernetes, so they are checked periodically.
+	if err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-aliases-controller failed to create periodic reconcile watch: %w", err)
+	}
+	return nil
+}
+
+func (r *AliasController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling LogStorage - Aliases")
+
+	ls := &operatorv1.LogStorage{}
+	if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, ls); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if common.IsReconcilePaused(ls) {
+		reqLogger.V(1).Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+		return reconcile.Result{}, nil
+	}
+
+	// Wait for the initializing controller to indicate that the LogStorage object is actionable. The LogStorage watch
+	// triggers a reconcile once it is.
+	if ls.Status.State != operatorv1.TigeraStatusReady || !ls.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	if !r.elasticExternal {
+		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
+			reqLogger.V(1).Info("Waiting for Elasticsearch cluster to be operational")
+			return reconcile.Result{}, nil
+		}
+	}
+
+	endpoints, err := logstoragecommon.ElasticEndpoints(ctx, r.client, ls, r.multiTenant)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, endpoint := range endpoints {
+		esClient, err := r.esClientFn(r.client, ctx, endpoint, r.elasticExternal)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to create the Elasticsearch client for %s: %w", endpoint, err)
+		}
+		if _, _, err = Repair(ctx, esClient, reqLogger.WithValues("endpoint", endpoint)); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// Repair repairs the aliases of the log indices in one Elasticsearch cluster. It returns a description of each alias
+// that it repaired and of each alias that it could not repair, and an error if any of them failed to be updated.
+func Repair(ctx context.Context, esClient utils.ElasticClient, reqLogger logr.Logger) ([]string, []string, error) {
+	indices, err := esClient.GetIndexAliases(ctx, LogIndexPattern)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the aliases of the log indices: %w", err)
+	}
+
+	// Repair as many aliases as possible, so that a single broken alias doesn't hold up the ingestion of the others.
+	var repaired, failed []string
+	var repairErr error
+	for _, a := range desiredAliases(indices) {
+		if a.conflict {
+			// A write to the alias while it was missing created an index with its name, so the alias can't be added
+			// back until the documents in that index are moved to a backing index and the index is deleted.
+			reqLogger.Error(nil, "An index has the name of a log alias and must be removed before the alias can be repaired", "alias", a.name)
+			failed = append(failed, fmt.Sprintf("Alias %s can't be added because an index has its name", a.name))
+			continue
+		}
+		if !a.needsRepair {
+			continue
+		}
+		reqLogger.Info("Repairing log alias", "alias", a.name, "indices", a.indices, "writeIndex", a.writeIndex)
+		if err = esClient.PutAlias(ctx, a.name, a.indices, a.writeIndex); err != nil {
+			reqLogger.Error(err, "Failed to repair log alias", "alias", a.name)
+			failed = append(failed, fmt.Sprintf("Alias %s could not be repaired: %v", a.name, err))
+			if repairErr == nil {
+				repairErr = fmt.Errorf("failed to repair the alias %s: %w", a.name, err)
+			}
+			continue
+		}
+		repaired = append(repaired, fmt.Sprintf("Added alias %s to %d indices with write index %s", a.name, len(a.indices), a.writeIndex))
+	}
+	return repaired, failed, repairErr
+}
+
+// BackingIndexAlias returns the alias that Linseed writes to an index through, and the rollover generation of the index.
+// The backing indices of an alias are named <alias><prefix>-<generation>, where the alias ends with a dot and the rest of
+// the name doesn't hold one.
+func BackingIndexAlias(index string) (string, int, bool) {
+	dot := strings.LastIndex(index, ".")
+	if dot < 0 || dot == len(index)-1 {
+		return "", 0, false
+	}
+	match := generationSuffix.FindStringSubmatch(index[dot+1:])
+	if match == nil {
+		return "", 0, false
+	}
+	generation, err := strconv.Atoi(match[1])
+	if err != nil {
+		return "", 0, false
+	}
+	return index[:dot+1], generation, true
+}
+
+// alias is the desired state of an alias that Linseed writes through.
+type alias struct {
+	name string
+	// indices are the backing indices of the alias, and writeIndex the newest of them.
+	indices    []string
+	writeIndex string
+
+	// needsRepair is set if a backing index is missing the alias, or the alias writes to another index.
+	needsRepair bool
+	// conflict is set if an index has the name of the alias.
+	conflict bool
+}
+
+// desiredAliases returns the aliases that the log indices should have, sorted by name.
+func desiredAliases(indices []utils.IndexAliases) []alias {
+	type backingIndex struct {
+		name       string
+		generation int
+		aliases    map[string]bool
+	}
+	backing := map[string][]backingIndex{}
+	names := map[string]bool{}
+	for _, index := range indices {
+		names[index.Index] = true
+		name, generation, ok := BackingIndexAlias(index.Index)
+		if !ok {
+			continue
+		}
+		backing[name] = append(backing[name], backingIndex{name: index.Index, generation: generation, aliases: index.Aliases})
+	}
+
+	var aliases []alias
+	for name, indices := range backing {
+		sort.Slice(indices, func(i, j int) bool {
+			if indices[i].generation != indices[j].generation {
+				return indices[i].generation < indices[j].generation
+			}
+			return indices[i].name < indices[j].name
+		})
+		a := alias{name: name, writeIndex: indices[len(indices)-1].name, conflict: names[name]}
+		for _, index := range indices {
+			a.indices = append(a.indices, index.name)
+			isWriteIndex, ok := index.aliases[name]
+			if !ok || isWriteIndex != (index.name == a.writeIndex) {
+				a.needsRepair = true
+			}
+		}
+		aliases = append(aliases, a)
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].name < aliases[j].name })
+	return aliases
+}
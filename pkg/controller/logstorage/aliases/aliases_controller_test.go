@@ -0,0 +1,153 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliases
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/testutils"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("LogStorage aliases controller", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		esClient *testutils.FakeElasticClient
+		r        *AliasController
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(esv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		ls := &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		es := &esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}}
+		es.Status.Phase = esv1.ElasticsearchReadyPhase
+		Expect(cli.Create(ctx, es)).ShouldNot(HaveOccurred())
+
+		esClient = testutils.NewFakeElasticClient()
+		r = &AliasController{client: cli, esClientFn: esClient.Creator()}
+	})
+
+	It("should leave healthy aliases alone", func() {
+		esClient.AddWriteIndex("tigera_secure_ee_flows.cluster.linseed-20240101-000001", "tigera_secure_ee_flows.cluster.")
+		esClient.AddWriteIndex("tigera_secure_ee_flows.cluster.linseed-20240102-000002", "tigera_secure_ee_flows.cluster.")
+		esClient.InjectError(testutils.MethodPutAlias, fmt.Errorf("should not be called"))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should add missing aliases back after a restore", func() {
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.linseed-20240101-000001", "")
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.linseed-20240102-000002", "")
+		esClient.AddIndex("tigera_secure_ee_dns.tenant-a.managed-a.linseed-20240101-000001", "")
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.", "")
+		esClient.AddIndex("tigera_secure_ee_events", "")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(esClient.AliasIndices("tigera_secure_ee_flows.cluster.")).To(BeEmpty())
+		Expect(esClient.AliasIndices("tigera_secure_ee_dns.tenant-a.managed-a.")).To(Equal(map[string]bool{
+			"tigera_secure_ee_dns.tenant-a.managed-a.linseed-20240101-000001": true,
+		}))
+
+		By("repairing the alias once the conflicting index is removed")
+		esClient = testutils.NewFakeElasticClient()
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.linseed-20240101-000001", "")
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.linseed-20240102-000002", "")
+		r.esClientFn = esClient.Creator()
+
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(esClient.AliasIndices("tigera_secure_ee_flows.cluster.")).To(Equal(map[string]bool{
+			"tigera_secure_ee_flows.cluster.linseed-20240101-000001": false,
+			"tigera_secure_ee_flows.cluster.linseed-20240102-000002": true,
+		}))
+	})
+
+	It("should move the write index to the newest backing index", func() {
+		esClient.AddWriteIndex("tigera_secure_ee_flows.cluster.linseed-20240101-000001", "tigera_secure_ee_flows.cluster.")
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.linseed-20240102-000002", "")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(esClient.Alias("tigera_secure_ee_flows.cluster.")).To(Equal("tigera_secure_ee_flows.cluster.linseed-20240102-000002"))
+	})
+
+	It("should repair the other aliases when one can't be repaired", func() {
+		esClient.AddIndex("tigera_secure_ee_dns.cluster.linseed-20240101-000001", "")
+		esClient.AddWriteIndex("other", "tigera_secure_ee_dns.cluster.")
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.linseed-20240101-000001", "")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).Should(HaveOccurred())
+		Expect(esClient.Alias("tigera_secure_ee_flows.cluster.")).To(Equal("tigera_secure_ee_flows.cluster.linseed-20240101-000001"))
+	})
+
+	It("should wait for Elasticsearch to be ready", func() {
+		es := &esv1.Elasticsearch{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}, es)).ShouldNot(HaveOccurred())
+		es.Status.Phase = esv1.ElasticsearchApplyingChangesPhase
+		Expect(cli.Update(ctx, es)).ShouldNot(HaveOccurred())
+		esClient.InjectError(testutils.MethodGetIndexAliases, fmt.Errorf("should not be called"))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should repair the aliases in the Elasticsearch cluster of each tenant", func() {
+		r.multiTenant = true
+		Expect(cli.Create(ctx, &operatorv1.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-a"},
+			Spec:       operatorv1.TenantSpec{ID: "tenant-a", Elastic: &operatorv1.TenantElasticSpec{URL: "https://tenant-a.es:9200"}},
+		})).ShouldNot(HaveOccurred())
+
+		var endpoints []string
+		r.esClientFn = func(_ client.Client, _ context.Context, endpoint string, _ bool) (utils.ElasticClient, error) {
+			endpoints = append(endpoints, endpoint)
+			return esClient, nil
+		}
+		esClient.AddIndex("tigera_secure_ee_flows.tenant-a.cluster.linseed-20240101-000001", "")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(endpoints).To(Equal([]string{"https://tenant-a.es:9200"}))
+		Expect(esClient.Alias("tigera_secure_ee_flows.tenant-a.cluster.")).To(Equal("tigera_secure_ee_flows.tenant-a.cluster.linseed-20240101-000001"))
+	})
+})
@@ -16,6 +16,7 @@ package common
 
 import (
 	"context"
+	"sort"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/crypto"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	"github.com/tigera/operator/pkg/render/kubecontrollers"
 )
 
@@ -39,75 +41,101 @@ const (
 	ESGatewaySelectorLabelValue = "credentials"
 )
 
+// GatewayCredentials names the secrets that hold the credentials a client of ES gateway presents, and the real
+// Elasticsearch credentials that ES gateway swaps them for.
+type GatewayCredentials struct {
+	// UserSecret holds the gateway credentials of the client. It is stored in the truth namespace.
+	UserSecret string
+	// Username is the gateway username of the client.
+	Username string
+	// VerificationSecret holds the hashed gateway credentials that ES gateway compares the client's credentials against.
+	VerificationSecret string
+	// SecureUserSecret holds the real Elasticsearch credentials that ES gateway swaps in.
+	SecureUserSecret string
+}
+
 // CreateKubeControllersSecrets checks for the existence of the secrets necessary for Kube controllers to access Elasticsearch through ES gateway and
 // creates them if they are missing. Kube controllers no longer uses admin credentials to make requests directly to Elasticsearch. Instead, gateway credentials
 // are generated and stored in the user secret, a hashed version of the credentials is stored in the tigera-elasticsearch namespace for ES Gateway to retrieve and use to compare
 // the gateway credentials, and a secret containing real admin level credentials is created and stored in the tigera-elasticsearch namespace to be swapped in once
 // ES Gateway has confirmed that the gateway credentials match.
 func CreateKubeControllersSecrets(ctx context.Context, esAdminUserSecret *corev1.Secret, esAdminUserName string, cli client.Client, h utils.NamespaceHelper) (*corev1.Secret, *corev1.Secret, *corev1.Secret, error) {
-	kubeControllersGatewaySecret, err := utils.GetSecret(ctx, cli, kubecontrollers.ElasticsearchKubeControllersUserSecret, h.TruthNamespace())
+	creds := GatewayCredentials{
+		UserSecret:         kubecontrollers.ElasticsearchKubeControllersUserSecret,
+		Username:           kubecontrollers.ElasticsearchKubeControllersUserName,
+		VerificationSecret: kubecontrollers.ElasticsearchKubeControllersVerificationUserSecret,
+		SecureUserSecret:   kubecontrollers.ElasticsearchKubeControllersSecureUserSecret,
+	}
+	return CreateGatewaySecrets(ctx, creds, esAdminUserName, esAdminUserSecret.Data[esAdminUserName], cli, h)
+}
+
+// CreateGatewaySecrets checks for the existence of the secrets that allow a client to access Elasticsearch through ES gateway with the given
+// credentials, and creates them if they are missing. The client presents the generated gateway credentials, which ES gateway swaps for the
+// given Elasticsearch username and password once it has confirmed that they match.
+func CreateGatewaySecrets(ctx context.Context, creds GatewayCredentials, esUserName string, esPassword []byte, cli client.Client, h utils.NamespaceHelper) (*corev1.Secret, *corev1.Secret, *corev1.Secret, error) {
+	gatewaySecret, err := utils.GetSecret(ctx, cli, creds.UserSecret, h.TruthNamespace())
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	if kubeControllersGatewaySecret == nil {
+	if gatewaySecret == nil {
 		password := crypto.GeneratePassword(16)
-		kubeControllersGatewaySecret = &corev1.Secret{
+		gatewaySecret = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      kubecontrollers.ElasticsearchKubeControllersUserSecret,
+				Name:      creds.UserSecret,
 				Namespace: h.TruthNamespace(),
 			},
 			Data: map[string][]byte{
-				"username": []byte(kubecontrollers.ElasticsearchKubeControllersUserName),
+				"username": []byte(creds.Username),
 				"password": []byte(password),
 			},
 		}
 	}
-	hashedPassword, err := bcrypt.GenerateFromPassword(kubeControllersGatewaySecret.Data["password"], bcrypt.MinCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword(gatewaySecret.Data["password"], bcrypt.MinCost)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	kubeControllersVerificationSecret, err := utils.GetSecret(ctx, cli, kubecontrollers.ElasticsearchKubeControllersVerificationUserSecret, h.InstallNamespace())
+	verificationSecret, err := utils.GetSecret(ctx, cli, creds.VerificationSecret, h.InstallNamespace())
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	if kubeControllersVerificationSecret == nil {
-		kubeControllersVerificationSecret = &corev1.Secret{
+	if verificationSecret == nil {
+		verificationSecret = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      kubecontrollers.ElasticsearchKubeControllersVerificationUserSecret,
+				Name:      creds.VerificationSecret,
 				Namespace: h.InstallNamespace(),
 				Labels: map[string]string{
 					ESGatewaySelectorLabel: ESGatewaySelectorLabelValue,
 				},
 			},
 			Data: map[string][]byte{
-				"username": []byte(kubecontrollers.ElasticsearchKubeControllersUserName),
+				"username": []byte(creds.Username),
 				"password": hashedPassword,
 			},
 		}
 	}
 
-	kubeControllersSecureUserSecret, err := utils.GetSecret(ctx, cli, kubecontrollers.ElasticsearchKubeControllersSecureUserSecret, h.InstallNamespace())
+	secureUserSecret, err := utils.GetSecret(ctx, cli, creds.SecureUserSecret, h.InstallNamespace())
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	if kubeControllersSecureUserSecret == nil {
-		kubeControllersSecureUserSecret = &corev1.Secret{
+	if secureUserSecret == nil {
+		secureUserSecret = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      kubecontrollers.ElasticsearchKubeControllersSecureUserSecret,
+				Name:      creds.SecureUserSecret,
 				Namespace: h.InstallNamespace(),
 				Labels: map[string]string{
 					ESGatewaySelectorLabel: ESGatewaySelectorLabelValue,
 				},
 			},
 			Data: map[string][]byte{
-				"username": []byte(esAdminUserName),
-				"password": esAdminUserSecret.Data[esAdminUserName],
+				"username": []byte(esUserName),
+				"password": esPassword,
 			},
 		}
 	}
 
-	return kubeControllersGatewaySecret, kubeControllersVerificationSecret, kubeControllersSecureUserSecret, nil
+	return gatewaySecret, verificationSecret, secureUserSecret, nil
 }
 
 func CalculateFlowShards(nodesSpecifications *operatorv1.Nodes, defaultShards int) int {
@@ -125,3 +153,30 @@ func CalculateFlowShards(nodesSpecifications *operatorv1.Nodes, defaultShards in
 
 	return int(nodes) * shardPerNode
 }
+
+// ElasticEndpoints returns the Elasticsearch clusters that hold the logs. In multi-tenant mode, these are the clusters of
+// the tenants, each of which may be shared by several tenants.
+func ElasticEndpoints(ctx context.Context, cli client.Client, ls *operatorv1.LogStorage, multiTenant bool) ([]string, error) {
+	if !multiTenant {
+		return []string{relasticsearch.InternalElasticEndpoint(ls)}, nil
+	}
+
+	tenants := &operatorv1.TenantList{}
+	if err := cli.List(ctx, tenants); err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var endpoints []string
+	for _, t := range tenants.Items {
+		endpoint := relasticsearch.InternalElasticEndpoint(ls)
+		if t.Spec.Elastic != nil && t.Spec.Elastic.URL != "" {
+			endpoint = t.Spec.Elastic.URL
+		}
+		if !seen[endpoint] {
+			seen[endpoint] = true
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	sort.Strings(endpoints)
+	return endpoints, nil
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/logstorage/kibana"
+	"github.com/tigera/operator/pkg/telemetry"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -74,7 +75,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	r := &DashboardsSubController{
-		client:          mgr.GetClient(),
+		client:          telemetry.Client(mgr.GetClient()),
 		scheme:          mgr.GetScheme(),
 		status:          status.New(mgr.GetClient(), initializer.TigeraStatusLogStorageDashboards, opts.KubernetesVersion),
 		clusterDomain:   opts.ClusterDomain,
@@ -86,7 +87,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	r.status.Run(opts.ShutdownContext)
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("log-storage-dashboards-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-dashboards-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-dashboards-controller", r)})
 	if err != nil {
 		return err
 	}
@@ -202,7 +203,7 @@ func (d DashboardsSubController) Reconcile(ctx context.Context, request reconcil
 	variant, install, err := utils.GetInstallation(context.Background(), d.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			d.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			d.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		d.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, reqLogger)
@@ -211,14 +212,14 @@ func (d DashboardsSubController) Reconcile(ctx context.Context, request reconcil
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !d.tierWatchReady.IsReady() {
-		d.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		d.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
 	if err := d.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
 		if errors.IsNotFound(err) {
-			d.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created", err, reqLogger)
+			d.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created", status.WithCode(operatorv1.TierNotReady, err), reqLogger)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		} else {
 			d.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
@@ -240,7 +241,7 @@ func (d DashboardsSubController) Reconcile(ctx context.Context, request reconcil
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(install, d.client)
 	if err != nil {
-		d.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", err, reqLogger)
+		d.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -259,8 +260,13 @@ func (d DashboardsSubController) Reconcile(ctx context.Context, request reconcil
 
 	d.status.OnCRFound()
 
-	// Determine where to access Kibana.
-	kibanaHost := "tigera-secure-kb-http.tigera-kibana.svc"
+	if utils.IsReconcilePaused(&logStorage.ObjectMeta, d.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
+	// Determine where to access Kibana. The fully qualified name is used so that it resolves regardless of the search
+	// domains of the pod.
+	kibanaHost := fmt.Sprintf("tigera-secure-kb-http.tigera-kibana.svc.%s", d.clusterDomain)
 	kibanaPort := uint16(5601)
 	kibanaScheme := "https"
 
@@ -269,11 +275,11 @@ func (d DashboardsSubController) Reconcile(ctx context.Context, request reconcil
 		// Wait for Elasticsearch to be installed and available.
 		elasticsearch, err := utils.GetElasticsearch(ctx, d.client)
 		if err != nil {
-			d.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", err, reqLogger)
+			d.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", status.WithCode(operatorv1.ElasticsearchUnavailable, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
-			d.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", nil, reqLogger)
+			d.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", status.WithCode(operatorv1.ElasticsearchNotReady, nil), reqLogger)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		}
 	} else {
@@ -332,7 +338,7 @@ func (d DashboardsSubController) Reconcile(ctx context.Context, request reconcil
 	}
 	cm, err := certificatemanager.Create(d.client, install, d.clusterDomain, helper.TruthNamespace(), opts...)
 	if err != nil {
-		d.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		d.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -359,7 +365,7 @@ func (d DashboardsSubController) Reconcile(ctx context.Context, request reconcil
 	dashboardsComponent := dashboards.Dashboards(cfg)
 
 	if err := imageset.ApplyImageSet(ctx, d.client, variant, dashboardsComponent); err != nil {
-		d.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		d.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
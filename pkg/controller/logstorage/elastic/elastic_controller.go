@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	cmnv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/common/v1"
 	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -42,6 +44,7 @@ import (
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	logstoragecommon "github.com/tigera/operator/pkg/controller/logstorage/common"
 	"github.com/tigera/operator/pkg/controller/logstorage/initializer"
@@ -55,11 +58,13 @@ import (
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	rsecret "github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/render/logstorage"
 	"github.com/tigera/operator/pkg/render/logstorage/eck"
 	"github.com/tigera/operator/pkg/render/logstorage/esgateway"
 	"github.com/tigera/operator/pkg/render/logstorage/esmetrics"
 	"github.com/tigera/operator/pkg/render/logstorage/kibana"
 	"github.com/tigera/operator/pkg/render/monitor"
+	"github.com/tigera/operator/pkg/telemetry"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
@@ -80,6 +85,16 @@ type ElasticSubController struct {
 	clusterDomain  string
 	tierWatchReady *utils.ReadyFlag
 	multiTenant    bool
+
+	// secondaryESCliCreator creates the client of the secondary Elasticsearch cluster that the log indices are
+	// replicated to, if any.
+	secondaryESCliCreator utils.SecondaryElasticsearchClientCreator
+
+	// indexMigrations are the index migrations needed by this release.
+	indexMigrations []IndexMigration
+
+	// recorder records the events of the retention guardrail on the LogStorage.
+	recorder record.EventRecorder
 }
 
 func Add(mgr manager.Manager, opts options.AddOptions) error {
@@ -92,21 +107,29 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return nil
 	}
 
+	esCliCreator, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
 	// Create the reconciler
 	r := &ElasticSubController{
-		client:         mgr.GetClient(),
-		scheme:         mgr.GetScheme(),
-		esCliCreator:   utils.NewElasticClient,
-		tierWatchReady: &utils.ReadyFlag{},
-		status:         status.New(mgr.GetClient(), initializer.TigeraStatusLogStorageElastic, opts.KubernetesVersion),
-		clusterDomain:  opts.ClusterDomain,
-		provider:       opts.DetectedProvider,
-		multiTenant:    opts.MultiTenant,
+		client:                telemetry.Client(mgr.GetClient()),
+		scheme:                mgr.GetScheme(),
+		esCliCreator:          esCliCreator,
+		secondaryESCliCreator: utils.NewSecondaryElasticClient,
+		tierWatchReady:        &utils.ReadyFlag{},
+		status:                status.New(mgr.GetClient(), initializer.TigeraStatusLogStorageElastic, opts.KubernetesVersion),
+		clusterDomain:         opts.ClusterDomain,
+		provider:              opts.DetectedProvider,
+		multiTenant:           opts.MultiTenant,
+		indexMigrations:       indexMigrations,
+		recorder:              mgr.GetEventRecorderFor("log-storage-elastic-controller"),
 	}
 	r.status.Run(opts.ShutdownContext)
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("log-storage-elastic-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-elastic-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-elastic-controller", r)})
 	if err != nil {
 		return err
 	}
@@ -189,6 +212,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		monitor.PrometheusClientTLSSecretName,
 		render.ElasticsearchAdminUserSecret,
 		render.TigeraElasticsearchInternalCertSecret,
+		logstorage.SecondaryElasticsearchSecret,
 	} {
 		if err = utils.AddSecretsWatch(c, secretName, common.OperatorNamespace()); err != nil {
 			return fmt.Errorf("log-storage-elastic-controller failed to watch Secret resource: %w", err)
@@ -222,11 +246,18 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		}
 	}
 
-	if err = utils.AddConfigMapWatch(c, relasticsearch.ClusterConfigConfigMapName, common.OperatorNamespace(), &handler.EnqueueRequestForObject{}); err != nil {
-		return fmt.Errorf("log-storage-elastic-controller failed to watch ConfigMap resource: %w", err)
+	// Establish watches for ConfigMaps in the operator namespace.
+	for _, name := range []string{
+		relasticsearch.ClusterConfigConfigMapName,
+		render.ElasticsearchRemoteClusterCAConfigMap,
+	} {
+		if err = utils.AddConfigMapWatch(c, name, common.OperatorNamespace(), &handler.EnqueueRequestForObject{}); err != nil {
+			return fmt.Errorf("log-storage-elastic-controller failed to watch ConfigMap resource: %w", err)
+		}
 	}
 
-	if err = utils.AddConfigMapWatch(c, eck.LicenseConfigMapName, eck.OperatorNamespace, &handler.EnqueueRequestForObject{}); err != nil {
+	// The license ConfigMap is watched in all namespaces, since a user-managed ECK operator writes it to its own.
+	if err = utils.AddConfigMapWatch(c, eck.LicenseConfigMapName, "", &handler.EnqueueRequestForObject{}); err != nil {
 		return fmt.Errorf("log-storage-elastic-controller failed to watch ConfigMap resource: %w", err)
 	}
 
@@ -273,10 +304,14 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 	// We found the LogStorage instance.
 	r.status.OnCRFound()
 
+	if utils.IsReconcilePaused(&ls.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// Wait for the initializing controller to indicate that the LogStorage object is actionable.
 	if ls.Status.State != operatorv1.TigeraStatusReady {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LogStorage defaulting to occur", nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	// Set or remove the finalizer from the LogStorage object as needed.
@@ -284,12 +319,17 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 		// Note: we don't set degraded status here because handleLogStorageFinalizer() already does that.
 		return reconcile.Result{}, err
 	}
+	if isTerminating(ls) {
+		// Nothing else to reconcile while the LogStorage is being torn down by handleLogStorageFinalizer().
+		r.status.ClearDegraded()
+		return reconcile.Result{}, nil
+	}
 
 	// Get Installation resource.
 	variant, install, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, reqLogger)
@@ -297,19 +337,19 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 	}
 	if variant != operatorv1.TigeraSecureEnterprise {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for network to be %s", operatorv1.TigeraSecureEnterprise), nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
 	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", status.WithCode(operatorv1.TierNotReady, err), reqLogger)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		} else {
 			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
@@ -336,7 +376,7 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(install, r.client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -349,7 +389,7 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 	// Get the keypairs we need for rendering components. These are created separately by the ES secrets controller.
 	cm, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace())
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 	cm.AddToStatusManager(r.status, render.ElasticsearchNamespace)
@@ -368,7 +408,7 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	kibanaEnabled := !operatorv1.IsFIPSModeEnabled(install.FIPSMode) && !r.multiTenant
+	kibanaEnabled := !operatorv1.IsFIPSModeEnabled(install.FIPSMode) && !install.Features.KibanaDisabled() && !r.multiTenant
 
 	// Wait for dependencies to exist.
 	if elasticKeyPair == nil {
@@ -401,7 +441,31 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	if operatorv1.IsFIPSModeEnabled(install.FIPSMode) {
+	// Reuse the ECK operator installed by the user instead of deploying a second one, if requested. The ECK operator
+	// deployed before is removed by the ECK component.
+	var eckOperatorNamespace string
+	if ls.UserManagedECKOperator() {
+		eckOperator, err := utils.GetUserManagedECKOperator(ctx, r.client)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to detect the user-managed ECK operator", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if eckOperator == nil {
+			msg := fmt.Sprintf("Waiting for a user-managed ECK operator that manages the %s and %s namespaces", render.ElasticsearchNamespace, kibana.Namespace)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, msg, nil, reqLogger)
+			return utils.RequeueWithBackoff(), nil
+		}
+		if err = utils.ValidateECKOperatorVersion(utils.ECKOperatorVersion(eckOperator)); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "The user-managed ECK operator is not supported", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+		reqLogger.V(2).Info("Using user-managed ECK operator", "StatefulSet", client.ObjectKeyFromObject(eckOperator))
+		eckOperatorNamespace = eckOperator.Namespace
+	}
+
+	// The trial license is applied through the ECK operator deployed by the operator, so it is left to the user when
+	// they manage the ECK operator.
+	if operatorv1.IsFIPSModeEnabled(install.FIPSMode) && !ls.UserManagedECKOperator() {
 		applyTrial, err = r.applyElasticTrialSecret(ctx, install)
 		if err != nil {
 			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to get eck trial license", err, reqLogger)
@@ -421,11 +485,26 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
+	// The CAs of the remote clusters are optional, since their transport certificates may be signed by a CA that
+	// Elasticsearch already trusts.
+	var remoteClusterCA *corev1.ConfigMap
+	if len(ls.Spec.RemoteClusters) > 0 {
+		remoteClusterCA = &corev1.ConfigMap{}
+		key := types.NamespacedName{Name: render.ElasticsearchRemoteClusterCAConfigMap, Namespace: common.OperatorNamespace()}
+		if err := r.client.Get(ctx, key, remoteClusterCA); err != nil {
+			if !errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Failed to read ConfigMap %s", key), err, reqLogger)
+				return reconcile.Result{}, err
+			}
+			remoteClusterCA = nil
+		}
+	}
+
 	// Get the admin user secret to copy to the operator namespace.
 	esAdminUserSecret, err = utils.GetSecret(ctx, r.client, render.ElasticsearchAdminUserSecret, render.ElasticsearchNamespace)
 	if err != nil {
 		reqLogger.Error(err, "failed to get Elasticsearch admin user secret")
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to get Elasticsearch admin user secret", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to get Elasticsearch admin user secret", status.WithCode(operatorv1.SecretNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 	if esAdminUserSecret != nil {
@@ -445,9 +524,36 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 
 	elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", status.WithCode(operatorv1.ElasticsearchUnavailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
+	if elasticsearch != nil {
+		// ECK upgrades Elasticsearch as soon as its version changes, so unsupported upgrades must be caught before the
+		// Elasticsearch resource is updated.
+		if err = utils.ValidateElasticsearchUpgrade(elasticsearch.Status.Version, components.ComponentEckElasticsearch.Version); err != nil {
+			r.status.SetDegraded(operatorv1.UpgradeError, "Elasticsearch upgrade is not supported", status.WithCode(operatorv1.UpgradeNotSupported, err), reqLogger)
+			return reconcile.Result{}, nil
+		}
+		// A major version upgrade may remove constructs that the operator manages in Elasticsearch, which would break
+		// provisioning once Elasticsearch is upgraded.
+		if incompatibilities := utils.ElasticsearchIncompatibilities(ls, elasticsearch.Status.Version, components.ComponentEckElasticsearch.Version); len(incompatibilities) != 0 {
+			msg := fmt.Sprintf("Upgrading Elasticsearch to %s is held back, since it is incompatible with the operator", components.ComponentEckElasticsearch.Version)
+			err = fmt.Errorf("%s", strings.Join(incompatibilities, "; "))
+			r.status.SetDegraded(operatorv1.UpgradeError, msg, status.WithCode(operatorv1.ElasticsearchIncompatible, err), reqLogger)
+			return reconcile.Result{}, nil
+		}
+
+		// The disks of the NodeSets with a volume snapshot class are snapshotted before the upgrade starts.
+		ready, err := r.snapshotVolumesBeforeUpgrade(ctx, ls, elasticsearch, components.ComponentEckElasticsearch.Version, reqLogger)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceCreateError, "Failed to snapshot the Elasticsearch disks before upgrading", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if !ready {
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for the snapshots of the Elasticsearch disks before upgrading", nil, reqLogger)
+			return utils.RequeueWithBackoff(), nil
+		}
+	}
 
 	var kibanaCR *kbv1.Kibana
 	if kibanaEnabled {
@@ -461,7 +567,7 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 	// If Authentication spec present, we use it to configure dex as an authentication proxy.
 	if authentication != nil && authentication.Status.State != operatorv1.TigeraStatusReady {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Authentication is not ready - authentication status: %s", authentication.Status.State), nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	var baseURL string
@@ -544,26 +650,29 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 			TrustedBundle:           trustedBundle,
 			UnusedTLSSecret:         unusedTLSSecret,
 			KeyStoreSecret:          keyStoreSecret,
+			RemoteClusterCA:         remoteClusterCA,
+			ECKOperatorNamespace:    eckOperatorNamespace,
 		}),
 		kibana.Kibana(&kibana.Configuration{
-			LogStorage:      ls,
-			Installation:    install,
-			Kibana:          kibanaCR,
-			KibanaKeyPair:   kibanaKeyPair,
-			PullSecrets:     pullSecrets,
-			Provider:        r.provider,
-			KbService:       kbService,
-			ClusterDomain:   r.clusterDomain,
-			BaseURL:         baseURL,
-			TrustedBundle:   trustedBundle,
-			UnusedTLSSecret: unusedTLSSecret,
-			Enabled:         kibanaEnabled,
+			LogStorage:           ls,
+			Installation:         install,
+			Kibana:               kibanaCR,
+			KibanaKeyPair:        kibanaKeyPair,
+			PullSecrets:          pullSecrets,
+			Provider:             r.provider,
+			KbService:            kbService,
+			ClusterDomain:        r.clusterDomain,
+			BaseURL:              baseURL,
+			TrustedBundle:        trustedBundle,
+			UnusedTLSSecret:      unusedTLSSecret,
+			Enabled:              kibanaEnabled,
+			ECKOperatorNamespace: eckOperatorNamespace,
 		}),
 	}
 
 	for _, component := range components {
 		if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
-			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 	}
@@ -576,24 +685,42 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", nil, reqLogger)
-		return reconcile.Result{}, nil
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", status.WithCode(operatorv1.ElasticsearchNotReady, nil), reqLogger)
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	if kibanaEnabled && kibanaCR == nil {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Kibana cluster to be created", nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	} else if kibanaEnabled && kibanaCR.Status.AssociationStatus != cmnv1.AssociationEstablished {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Kibana association to be established", nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
-	// In multi-tenant mode, ILM programming is created out of band
+	// In multi-tenant mode, index migrations are handled out of band. The ILM policies are applied by the ILM controller.
+	var migrating bool
 	if !r.multiTenant {
-		if err := r.applyILMPolicies(ls, reqLogger, ctx); err != nil {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Error applying ILM policies", nil, reqLogger)
+		if err := r.enforceRetentionGuardrail(ctx, ls, reqLogger); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error enforcing the retention guardrail", err, reqLogger)
 			return reconcile.Result{}, err
 		}
+
+		if ls.SecondaryElasticsearchMode() == operatorv1.SecondaryElasticsearchModeCrossClusterReplication {
+			if err := r.configureCrossClusterReplication(ctx, ls); err != nil {
+				r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error configuring cross-cluster replication to the secondary Elasticsearch cluster", err, reqLogger)
+				return reconcile.Result{}, err
+			}
+		}
+
+		if migrating, err = r.migrateIndices(ctx, ls, reqLogger); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error migrating indices", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if failed := failedIndexMigrations(ls); len(failed) > 0 {
+			msg := fmt.Sprintf("Index migrations failed: %s, see the LogStorage status for details", strings.Join(failed, ", "))
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, msg, nil, reqLogger)
+			return reconcile.Result{}, nil
+		}
 	}
 
 	if kibanaEnabled && esLicenseType == render.ElasticsearchLicenseTypeBasic {
@@ -608,6 +735,10 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 
 	r.status.ReadyToMonitor()
 	r.status.ClearDegraded()
+	if migrating {
+		// Reindex tasks don't trigger a reconcile, so check on their progress periodically.
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -622,29 +753,17 @@ func (r *ElasticSubController) handleLogStorageFinalizer(ctx context.Context, ls
 	prePatch := client.MergeFrom(ls.DeepCopy())
 
 	// Determine if we're terminating, and thus if we need to clean up our finalizers. We add a finalizer to the LogStorage
-	// so that we can block deletion of it until downstream resources have termianted. Specifically, the Elasticsearch and Kibana
+	// so that we can block deletion of it until downstream resources have terminated. Specifically, the Elasticsearch and Kibana
 	// instances. So, check if those have been deleted before removing the finalizer.
 	if isTerminating(ls) {
-		// The LogStorage instance is terminating. Check whether ES and Kibana CRs exist.
-		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
-		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", err, reqLogger)
-			return err
-		}
-		kibana := &kbv1.Kibana{}
-		err = r.client.Get(ctx, client.ObjectKey{Name: kibana.Name, Namespace: kibana.Namespace}, kibana)
-		if errors.IsNotFound(err) {
-			kibana = nil
-		} else if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Kibana", err, reqLogger)
+		// The LogStorage instance is terminating. Tear down what it created, and only remove the finalizer once
+		// Elasticsearch and Kibana are gone.
+		done, err := r.teardownLogStorage(ctx, ls, reqLogger)
+		if err != nil || !done {
+			// Note: teardownLogStorage() sets the degraded status on errors.
 			return err
 		}
 
-		if elasticsearch != nil || kibana != nil {
-			// One or both of ES and Kibana are still present. Don't remove the finalizer just yet.
-			return nil
-		}
-
 		// Remove the finalizer if both ES and Kibana have been cleaned up.
 		ls.SetFinalizers(stringsutil.RemoveStringInSlice(LogStorageFinalizer, ls.GetFinalizers()))
 
@@ -652,7 +771,7 @@ func (r *ElasticSubController) handleLogStorageFinalizer(ctx context.Context, ls
 		if patchErr := r.client.Patch(ctx, ls, prePatch); patchErr != nil {
 			reqLogger.Error(patchErr, "Error patching LogStorage to remove finalizer")
 			r.status.SetDegraded(operatorv1.ResourcePatchError, "Error patching to remove finalizer", patchErr, reqLogger)
-			return err
+			return patchErr
 		}
 	} else if ls != nil {
 		// Not terminating, make sure the finalizer is present.
@@ -667,6 +786,159 @@ func (r *ElasticSubController) handleLogStorageFinalizer(ctx context.Context, ls
 	return nil
 }
 
+// teardownLogStorage deletes what was created for a LogStorage that is being deleted, one step at a time and in a fixed
+// order, and records the step it is waiting on in the LogStorage status. It returns true once Elasticsearch and Kibana
+// are gone and the finalizer can be removed. The steps are:
+//   - If the data must be retained, detach the data volumes from Elasticsearch and the LogStorage.
+//   - If the data must be retained, delete the Elasticsearch users the operator created, since they would otherwise
+//     outlive the LogStorage in the retained data.
+//   - Delete Kibana, and wait for it to be gone so that it doesn't lose its connection to Elasticsearch mid-shutdown.
+//   - Delete Elasticsearch, and wait for it to be gone.
+func (r *ElasticSubController) teardownLogStorage(ctx context.Context, ls *operatorv1.LogStorage, reqLogger logr.Logger) (bool, error) {
+	elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", status.WithCode(operatorv1.ElasticsearchUnavailable, err), reqLogger)
+		return false, err
+	}
+	kibanaCR, err := r.getKibana(ctx)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Kibana", err, reqLogger)
+		return false, err
+	}
+
+	// Users are only deprovisioned before anything has been deleted, so that later steps don't wait on Elasticsearch.
+	deprovisionUsers := ls.Status.DeletionPhase == "" ||
+		ls.Status.DeletionPhase == operatorv1.LogStorageDeletionPhaseRetainingData ||
+		ls.Status.DeletionPhase == operatorv1.LogStorageDeletionPhaseDeprovisioningUsers
+
+	if ls.RetainDataOnDeletion() {
+		retained, err := r.retainElasticsearchData(ctx, ls, elasticsearch, reqLogger)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to retain the Elasticsearch data", err, reqLogger)
+			return false, err
+		}
+		if !retained {
+			return false, r.setDeletionPhase(ctx, ls, operatorv1.LogStorageDeletionPhaseRetainingData, reqLogger)
+		}
+
+		if deprovisionUsers && elasticsearch != nil && elasticsearch.DeletionTimestamp == nil {
+			if err = r.setDeletionPhase(ctx, ls, operatorv1.LogStorageDeletionPhaseDeprovisioningUsers, reqLogger); err != nil {
+				return false, err
+			}
+			if elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
+				reqLogger.Info("Waiting for Elasticsearch cluster to be operational to remove users")
+				return false, nil
+			}
+			if err = r.deprovisionUsers(ctx, ls, reqLogger); err != nil {
+				r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to delete Elasticsearch users", err, reqLogger)
+				return false, err
+			}
+		}
+	}
+
+	if kibanaCR != nil {
+		if kibanaCR.DeletionTimestamp == nil {
+			reqLogger.Info("Deleting Kibana")
+			if err = r.client.Delete(ctx, kibanaCR); err != nil && !errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to delete Kibana", err, reqLogger)
+				return false, err
+			}
+		}
+		return false, r.setDeletionPhase(ctx, ls, operatorv1.LogStorageDeletionPhaseDeletingKibana, reqLogger)
+	}
+
+	if elasticsearch != nil {
+		if elasticsearch.DeletionTimestamp == nil {
+			reqLogger.Info("Deleting Elasticsearch")
+			if err = r.client.Delete(ctx, elasticsearch); err != nil && !errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to delete Elasticsearch", err, reqLogger)
+				return false, err
+			}
+		}
+		return false, r.setDeletionPhase(ctx, ls, operatorv1.LogStorageDeletionPhaseDeletingElasticsearch, reqLogger)
+	}
+
+	return true, nil
+}
+
+// retainElasticsearchData makes sure the Elasticsearch data outlives the LogStorage. It returns false while ECK is still
+// detaching the PersistentVolumeClaims from Elasticsearch.
+func (r *ElasticSubController) retainElasticsearchData(ctx context.Context, ls *operatorv1.LogStorage, elasticsearch *esv1.Elasticsearch, reqLogger logr.Logger) (bool, error) {
+	if elasticsearch != nil && elasticsearch.DeletionTimestamp == nil {
+		// ECK only removes its owner references from the PersistentVolumeClaims once it has observed the policy.
+		if elasticsearch.Spec.VolumeClaimDeletePolicy != esv1.DeleteOnScaledownOnlyPolicy {
+			reqLogger.Info("Setting the Elasticsearch volume claim delete policy to retain data")
+			elasticsearch.Spec.VolumeClaimDeletePolicy = esv1.DeleteOnScaledownOnlyPolicy
+			return false, r.client.Update(ctx, elasticsearch)
+		}
+		if elasticsearch.Status.ObservedGeneration < elasticsearch.Generation {
+			return false, nil
+		}
+	} else if elasticsearch != nil && elasticsearch.Spec.VolumeClaimDeletePolicy != esv1.DeleteOnScaledownOnlyPolicy {
+		reqLogger.Info("Elasticsearch was deleted before its data could be retained")
+	}
+
+	// The namespace is owned by the LogStorage, and garbage collecting it would delete the PersistentVolumeClaims.
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: render.ElasticsearchNamespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	var ownerRefs []metav1.OwnerReference
+	for _, ref := range ns.OwnerReferences {
+		if ref.UID != ls.UID {
+			ownerRefs = append(ownerRefs, ref)
+		}
+	}
+	if len(ownerRefs) != len(ns.OwnerReferences) {
+		reqLogger.Info("Orphaning the Elasticsearch namespace to retain data")
+		ns.OwnerReferences = ownerRefs
+		if err := r.client.Update(ctx, ns); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// deprovisionUsers deletes the Elasticsearch users created by the operator.
+func (r *ElasticSubController) deprovisionUsers(ctx context.Context, ls *operatorv1.LogStorage, reqLogger logr.Logger) error {
+	esClient, err := r.esCliCreator(r.client, ctx, relasticsearch.InternalElasticEndpoint(ls), false)
+	if err != nil {
+		return err
+	}
+
+	users, err := esClient.GetUsers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if !utils.IsOperatorUser(user.Username) {
+			continue
+		}
+		reqLogger.Info("Deleting Elasticsearch user", "user", user.Username)
+		if err = esClient.DeleteUser(ctx, &user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setDeletionPhase records the step of the teardown the operator is waiting on in the LogStorage status.
+func (r *ElasticSubController) setDeletionPhase(ctx context.Context, ls *operatorv1.LogStorage, phase operatorv1.LogStorageDeletionPhase, reqLogger logr.Logger) error {
+	if ls.Status.DeletionPhase == phase {
+		return nil
+	}
+	reqLogger.Info("LogStorage deletion progressing", "phase", phase)
+	ls.Status.DeletionPhase = phase
+	if err := r.client.Status().Update(ctx, ls); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to update LogStorage status", err, reqLogger)
+		return err
+	}
+	return nil
+}
+
 func (r *ElasticSubController) checkOIDCUsersEsResource(ctx context.Context) error {
 	if err := r.client.Get(ctx, types.NamespacedName{Name: render.OIDCUsersConfigMapName, Namespace: render.ElasticsearchNamespace}, &corev1.ConfigMap{}); err != nil {
 		return err
@@ -678,17 +950,23 @@ func (r *ElasticSubController) checkOIDCUsersEsResource(ctx context.Context) err
 	return nil
 }
 
-func (r *ElasticSubController) applyILMPolicies(ls *operatorv1.LogStorage, reqLogger logr.Logger, ctx context.Context) error {
-	// ES should be in ready phase when execution reaches here, apply ILM polices
-	esClient, err := r.esCliCreator(r.client, ctx, relasticsearch.ECKElasticEndpoint(), false)
+// configureCrossClusterReplication makes the secondary Elasticsearch cluster of the LogStorage follow the log indices of
+// the Elasticsearch cluster that the operator provisions.
+func (r *ElasticSubController) configureCrossClusterReplication(ctx context.Context, ls *operatorv1.LogStorage) error {
+	credentials, err := utils.GetSecret(ctx, r.client, logstorage.SecondaryElasticsearchSecret, common.OperatorNamespace())
 	if err != nil {
 		return err
 	}
+	if credentials == nil {
+		return fmt.Errorf("secret %s/%s with the credentials of the secondary Elasticsearch cluster does not exist", common.OperatorNamespace(), logstorage.SecondaryElasticsearchSecret)
+	}
 
-	if err = esClient.SetILMPolicies(ctx, ls); err != nil {
+	secondary := ls.Spec.SecondaryElasticsearch
+	esClient, err := r.secondaryESCliCreator(ctx, secondary.Endpoint, credentials)
+	if err != nil {
 		return err
 	}
-	return nil
+	return esClient.ConfigureCrossClusterReplication(ctx, utils.SecondaryElasticsearchRemoteCluster, secondary.RemoteClusterAddress, utils.SecondaryElasticsearchIndexPatterns)
 }
 
 func (r *ElasticSubController) getElasticsearchService(ctx context.Context) (*corev1.Service, error) {
@@ -37,6 +37,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -56,6 +57,7 @@ import (
 	"github.com/tigera/operator/pkg/render"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	"github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/render/logstorage"
 	"github.com/tigera/operator/pkg/render/logstorage/eck"
 	"github.com/tigera/operator/pkg/render/logstorage/kibana"
 	"github.com/tigera/operator/pkg/render/monitor"
@@ -102,6 +104,7 @@ func NewReconcilerWithShims(
 		clusterDomain:  opts.ClusterDomain,
 		provider:       opts.DetectedProvider,
 		multiTenant:    opts.MultiTenant,
+		recorder:       record.NewFakeRecorder(100),
 	}
 	r.status.Run(opts.ShutdownContext)
 	return r, nil
@@ -368,7 +371,7 @@ var _ = Describe("LogStorage controller", func() {
 				Expect(cli.Get(ctx, esConfigMapKey, &esConfigMap)).NotTo(HaveOccurred())
 
 				// Expect to be waiting for Elasticsearch and Kibana to be functional
-				Expect(result).Should(Equal(reconcile.Result{}))
+				Expect(result).Should(Equal(utils.RequeueWithBackoff()))
 
 				By("asserting the finalizers have been set on the LogStorage CR")
 				ls := &operatorv1.LogStorage{}
@@ -428,6 +431,92 @@ var _ = Describe("LogStorage controller", func() {
 				mockStatus.AssertExpectations(GinkgoT())
 			})
 
+			It("configures cross-cluster replication to the secondary Elasticsearch cluster", func() {
+				Expect(cli.Create(ctx, &storagev1.StorageClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: storageClassName,
+					},
+				})).ShouldNot(HaveOccurred())
+
+				mode := operatorv1.SecondaryElasticsearchModeCrossClusterReplication
+				CreateLogStorage(cli, &operatorv1.LogStorage{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "tigera-secure",
+					},
+					Spec: operatorv1.LogStorageSpec{
+						Nodes: &operatorv1.Nodes{
+							Count: int64(1),
+						},
+						StorageClassName: storageClassName,
+						SecondaryElasticsearch: &operatorv1.SecondaryElasticsearch{
+							Endpoint:             "https://es.dr.example.com:9200",
+							Mode:                 &mode,
+							RemoteClusterAddress: "es-transport.example.com:9300",
+						},
+					},
+					Status: operatorv1.LogStorageStatus{
+						State: operatorv1.TigeraStatusReady,
+					},
+				})
+
+				Expect(cli.Create(ctx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: eck.OperatorNamespace, Name: eck.LicenseConfigMapName},
+					Data:       map[string]string{"eck_license_level": string(render.ElasticsearchLicenseTypeEnterprise)},
+				})).ShouldNot(HaveOccurred())
+
+				secondaryCredentials := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: logstorage.SecondaryElasticsearchSecret, Namespace: common.OperatorNamespace()},
+					Data:       map[string][]byte{"username": []byte("replicator"), "password": []byte("password")},
+				}
+				Expect(cli.Create(ctx, secondaryCredentials)).ShouldNot(HaveOccurred())
+
+				r, err := NewReconcilerWithShims(cli, scheme, mockStatus, operatorv1.ProviderNone, MockESCLICreator, dns.DefaultClusterDomain, readyFlag)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				secondaryESClient := &MockESClient{}
+				secondaryESClient.On("ConfigureCrossClusterReplication", mock.Anything, utils.SecondaryElasticsearchRemoteCluster, "es-transport.example.com:9300", utils.SecondaryElasticsearchIndexPatterns).Return(nil)
+				r.secondaryESCliCreator = func(_ context.Context, endpoint string, credentials *corev1.Secret) (utils.ElasticClient, error) {
+					Expect(endpoint).To(Equal("https://es.dr.example.com:9200"))
+					Expect(credentials.Data).To(Equal(secondaryCredentials.Data))
+					return secondaryESClient, nil
+				}
+
+				mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", mock.Anything, mock.Anything).Return()
+				result, err := r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).Should(Equal(utils.RequeueWithBackoff()))
+				secondaryESClient.AssertNotCalled(GinkgoT(), "ConfigureCrossClusterReplication", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+				es := &esv1.Elasticsearch{}
+				Expect(cli.Get(ctx, esObjKey, es)).ShouldNot(HaveOccurred())
+				es.Status.Phase = esv1.ElasticsearchReadyPhase
+				Expect(cli.Update(ctx, es)).ShouldNot(HaveOccurred())
+
+				kb := &kbv1.Kibana{}
+				Expect(cli.Get(ctx, kbObjKey, kb)).ShouldNot(HaveOccurred())
+				kb.Status.AssociationStatus = cmnv1.AssociationEstablished
+				Expect(cli.Update(ctx, kb)).ShouldNot(HaveOccurred())
+
+				kibanaKeyPair, err := certificateManager.GetOrCreateKeyPair(r.client, kibana.TigeraKibanaCertSecret, common.OperatorNamespace(), kbDNSNames)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(cli.Create(ctx, kibanaKeyPair.Secret(kibana.Namespace))).ShouldNot(HaveOccurred())
+
+				mockStatus.On("ClearDegraded")
+				result, err = r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).Should(Equal(successResult))
+				secondaryESClient.AssertExpectations(GinkgoT())
+
+				By("reporting a degraded status when the secondary cluster can't be configured")
+				secondaryESClient.ExpectedCalls = nil
+				secondaryESClient.On("ConfigureCrossClusterReplication", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("ccr is not licensed"))
+				mockStatus.On("SetDegraded", operatorv1.ResourceUpdateError, "Error configuring cross-cluster replication to the secondary Elasticsearch cluster", mock.Anything, mock.Anything).Return()
+				_, err = r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).To(MatchError("ccr is not licensed"))
+
+				mockStatus.AssertExpectations(GinkgoT())
+			})
+
 			It("test LogStorage reconciles successfully for elasticsearch basic license", func() {
 				Expect(cli.Create(ctx, &operatorv1.Authentication{
 					ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
@@ -485,7 +574,7 @@ var _ = Describe("LogStorage controller", func() {
 				result, err := r.Reconcile(ctx, reconcile.Request{})
 				Expect(err).ShouldNot(HaveOccurred())
 				// Expect to be waiting for Elasticsearch and Kibana to be functional
-				Expect(result).Should(Equal(reconcile.Result{}))
+				Expect(result).Should(Equal(utils.RequeueWithBackoff()))
 
 				By("asserting the finalizers have been set on the LogStorage CR")
 				ls := &operatorv1.LogStorage{}
@@ -644,7 +733,7 @@ var _ = Describe("LogStorage controller", func() {
 				result, err := r.Reconcile(ctx, reconcile.Request{})
 				Expect(err).ShouldNot(HaveOccurred())
 				// Expect to be waiting for Elasticsearch and Kibana to be functional
-				Expect(result).Should(Equal(reconcile.Result{}))
+				Expect(result).Should(Equal(utils.RequeueWithBackoff()))
 
 				secret := &corev1.Secret{}
 				Expect(cli.Get(ctx, kbCertSecretOperKey, secret)).ShouldNot(HaveOccurred())
@@ -733,6 +822,36 @@ var _ = Describe("LogStorage controller", func() {
 				Expect(secret.GetOwnerReferences()).To(HaveLen(0))
 			})
 
+			It("should not downgrade a running Elasticsearch", func() {
+				mockStatus.On("SetDegraded", operatorv1.UpgradeError, "Elasticsearch upgrade is not supported", mock.Anything, mock.Anything).Return()
+
+				CreateLogStorage(cli, &operatorv1.LogStorage{
+					ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+					Spec: operatorv1.LogStorageSpec{
+						Nodes:            &operatorv1.Nodes{Count: int64(1)},
+						StorageClassName: storageClassName,
+					},
+					Status: operatorv1.LogStorageStatus{State: operatorv1.TigeraStatusReady},
+				})
+				Expect(cli.Create(ctx, &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: storageClassName}})).ShouldNot(HaveOccurred())
+				Expect(cli.Create(ctx, &esv1.Elasticsearch{
+					ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace},
+					Spec:       esv1.ElasticsearchSpec{Version: "8.13.0"},
+					Status:     esv1.ElasticsearchStatus{Phase: esv1.ElasticsearchReadyPhase, Version: "8.13.0"},
+				})).ShouldNot(HaveOccurred())
+
+				r, err := NewReconcilerWithShims(cli, scheme, mockStatus, operatorv1.ProviderNone, MockESCLICreator, dns.DefaultClusterDomain, readyFlag)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).ShouldNot(HaveOccurred())
+				mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.UpgradeError, "Elasticsearch upgrade is not supported", mock.Anything, mock.Anything)
+
+				es := &esv1.Elasticsearch{}
+				Expect(cli.Get(ctx, esObjKey, es)).ShouldNot(HaveOccurred())
+				Expect(es.Spec.Version).To(Equal("8.13.0"))
+			})
+
 			It("should add OwnerReference to the public elasticsearch TLS cert secret", func() {
 				Expect(cli.Create(ctx, &storagev1.StorageClass{
 					ObjectMeta: metav1.ObjectMeta{
@@ -767,7 +886,7 @@ var _ = Describe("LogStorage controller", func() {
 				result, err := r.Reconcile(ctx, reconcile.Request{})
 				Expect(err).ShouldNot(HaveOccurred())
 				// Expect to be waiting for Elasticsearch and Kibana to be functional
-				Expect(result).Should(Equal(reconcile.Result{}))
+				Expect(result).Should(Equal(utils.RequeueWithBackoff()))
 			})
 
 			Context("checking rendered images", func() {
@@ -1101,7 +1220,7 @@ var _ = Describe("LogStorage controller", func() {
 				mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Waiting for Kibana cluster to be created", nil, mock.Anything)
 				result, err := r.Reconcile(ctx, reconcile.Request{})
 				Expect(err).ShouldNot(HaveOccurred())
-				Expect(result).Should(Equal(reconcile.Result{}))
+				Expect(result).Should(Equal(utils.RequeueWithBackoff()))
 
 				// However, the Kibana and ES instances should have been created.
 				Expect(cli.Get(ctx, client.ObjectKey{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}, &esv1.Elasticsearch{})).ShouldNot(HaveOccurred())
@@ -1126,27 +1245,35 @@ var _ = Describe("LogStorage controller", func() {
 
 				Expect(ls.Spec.StorageClassName).To(Equal(initializer.DefaultElasticsearchStorageClass))
 
+				By("expecting Kibana to be deleted first")
 				result, err = r.Reconcile(ctx, reconcile.Request{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(result).Should(Equal(successResult))
+				err = cli.Get(ctx, kbObjKey, &kbv1.Kibana{})
+				Expect(errors.IsNotFound(err)).Should(BeTrue())
+				Expect(cli.Get(ctx, esObjKey, &esv1.Elasticsearch{})).ShouldNot(HaveOccurred())
+				ls = &operatorv1.LogStorage{}
+				Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+				Expect(ls.Status.DeletionPhase).To(Equal(operatorv1.LogStorageDeletionPhaseDeletingKibana))
 
-				By("expecting tigera-secure Elasticsearch or Kibana resources to have been deleted")
+				By("expecting Elasticsearch to be deleted once Kibana is gone")
+				result, err = r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).Should(Equal(successResult))
 				err = cli.Get(ctx, esObjKey, &esv1.Elasticsearch{})
 				Expect(errors.IsNotFound(err)).Should(BeTrue())
-				err = cli.Get(ctx, kbObjKey, &kbv1.Kibana{})
-				Expect(errors.IsNotFound(err)).Should(BeTrue())
 
 				// The LogStorage CR should still contain the finalizer, as we wait for ES and KB to finish deleting
 				By("checking LogStorage finalizer")
 				ls = &operatorv1.LogStorage{}
 				Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
 				Expect(ls.Finalizers).Should(ContainElement("tigera.io/eck-cleanup"))
+				Expect(ls.Status.DeletionPhase).To(Equal(operatorv1.LogStorageDeletionPhaseDeletingElasticsearch))
 
 				// One more reconcile should remove the finalizer and thus trigger deletion of the CR.
-				mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", mock.Anything, mock.Anything).Return()
 				result, err = r.Reconcile(ctx, reconcile.Request{})
 				Expect(err).ShouldNot(HaveOccurred())
-				Expect(result).Should(Equal(reconcile.Result{}))
+				Expect(result).Should(Equal(successResult))
 
 				By("expecting the LogStorage CR to have been cleaned up")
 				ls = &operatorv1.LogStorage{}
@@ -1154,6 +1281,76 @@ var _ = Describe("LogStorage controller", func() {
 
 				mockStatus.AssertExpectations(GinkgoT())
 			})
+
+			It("retains the Elasticsearch data and removes the operator's users when asked to", func() {
+				esClient := &MockESClient{}
+				ctx = context.WithValue(ctx, MockESClientKey("mockESClient"), esClient)
+				r, err := NewReconcilerWithShims(cli, scheme, mockStatus, operatorv1.ProviderNone, MockESCLICreator, dns.DefaultClusterDomain, readyFlag)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(cli.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      render.ElasticsearchAdminUserSecret,
+						Namespace: render.ElasticsearchNamespace,
+					},
+					Data: map[string][]byte{
+						"elastic": []byte("password"),
+					},
+				})).ShouldNot(HaveOccurred())
+
+				ls := &operatorv1.LogStorage{}
+				Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+				retain := operatorv1.LogStorageDeletionPolicyRetain
+				ls.Spec.DeletionPolicy = &retain
+				Expect(cli.Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+				mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Waiting for Kibana cluster to be created", nil, mock.Anything)
+				_, err = r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("rendering Elasticsearch so that it keeps its volume claims")
+				es := &esv1.Elasticsearch{}
+				Expect(cli.Get(ctx, esObjKey, es)).ShouldNot(HaveOccurred())
+				Expect(es.Spec.VolumeClaimDeletePolicy).To(Equal(esv1.DeleteOnScaledownOnlyPolicy))
+				es.Status.Phase = esv1.ElasticsearchReadyPhase
+				es.Status.ObservedGeneration = es.Generation
+				Expect(cli.Status().Update(ctx, es)).ShouldNot(HaveOccurred())
+
+				ns := &corev1.Namespace{}
+				Expect(cli.Get(ctx, client.ObjectKey{Name: render.ElasticsearchNamespace}, ns)).ShouldNot(HaveOccurred())
+				Expect(ns.OwnerReferences).To(HaveLen(1))
+
+				operatorUser := utils.User{Username: "tigera-ee-linseed_cluster_tenant", Roles: []utils.Role{{Name: "tigera-ee-linseed_cluster_tenant"}}}
+				esClient.On("GetUsers", mock.Anything).Return([]utils.User{
+					operatorUser,
+					{Username: "elastic", Roles: []utils.Role{{Name: "superuser"}}},
+				}, nil)
+				esClient.On("DeleteRoles", mock.Anything, operatorUser.Roles).Return(nil)
+				esClient.On("DeleteUser", mock.Anything, &operatorUser).Return(nil)
+
+				Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+				Expect(cli.Delete(ctx, ls)).ShouldNot(HaveOccurred())
+
+				By("removing the users and orphaning the namespace before deleting Kibana")
+				_, err = r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).ShouldNot(HaveOccurred())
+				esClient.AssertExpectations(GinkgoT())
+				Expect(cli.Get(ctx, client.ObjectKey{Name: render.ElasticsearchNamespace}, ns)).ShouldNot(HaveOccurred())
+				Expect(ns.OwnerReferences).To(BeEmpty())
+				Expect(errors.IsNotFound(cli.Get(ctx, kbObjKey, &kbv1.Kibana{}))).Should(BeTrue())
+				Expect(cli.Get(ctx, esObjKey, &esv1.Elasticsearch{})).ShouldNot(HaveOccurred())
+
+				By("deleting Elasticsearch and then removing the finalizer")
+				_, err = r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(errors.IsNotFound(cli.Get(ctx, esObjKey, &esv1.Elasticsearch{}))).Should(BeTrue())
+				_, err = r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, &operatorv1.LogStorage{})).Should(HaveOccurred())
+
+				// The users are only removed once.
+				esClient.AssertNumberOfCalls(GinkgoT(), "GetUsers", 1)
+			})
 		})
 	})
 })
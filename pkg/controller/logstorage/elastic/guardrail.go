@@ -0,0 +1,183 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+)
+
+const (
+	// retentionGuardrailInterval is the time between two checks of the disk usage. It gives ILM, which runs every ten
+	// minutes by default, time to delete the indices that a shortened retention period no longer covers.
+	retentionGuardrailInterval = 15 * time.Minute
+
+	// retentionGuardrailHysteresis is how many percentage points the disk usage must fall below the target before the
+	// retention periods are lengthened again.
+	retentionGuardrailHysteresis = 10
+)
+
+// The reasons of the events that the retention guardrail records on the LogStorage.
+const (
+	EventReasonRetentionShortened = "RetentionShortened"
+	EventReasonRetentionAtMinimum = "RetentionAtMinimum"
+	EventReasonRetentionRestored  = "RetentionRestored"
+)
+
+// logRetention is the retention period of a log type. days points into a Retention, so the period can be changed
+// through it.
+type logRetention struct {
+	name string
+	days **int32
+}
+
+// logRetentions returns the retention periods of the log types that the guardrail adjusts.
+func logRetentions(r *operatorv1.Retention) []logRetention {
+	return []logRetention{
+		{"flows", &r.Flows},
+		{"dnsLogs", &r.DNSLogs},
+		{"bgpLogs", &r.BGPLogs},
+		{"auditReports", &r.AuditReports},
+		{"snapshots", &r.Snapshots},
+		{"complianceReports", &r.ComplianceReports},
+	}
+}
+
+// enforceRetentionGuardrail compares the disk usage of Elasticsearch with the target of the retention guardrail, and
+// shortens or lengthens the retention periods in effect by a day when needed. The retention periods in effect are kept
+// in the LogStorage status, and used for the ILM policies. The disk usage is checked at most once per
+// retentionGuardrailInterval.
+func (r *ElasticSubController) enforceRetentionGuardrail(ctx context.Context, ls *operatorv1.LogStorage, reqLogger logr.Logger) error {
+	guardrail := ls.Spec.RetentionGuardrail
+	if guardrail == nil || ls.Spec.Retention == nil {
+		if ls.Status.RetentionGuardrail == nil {
+			return nil
+		}
+		// The guardrail was removed, so the configured retention periods apply again.
+		ls.Status.RetentionGuardrail = nil
+		return r.client.Status().Update(ctx, ls)
+	}
+
+	original := ls.Status.RetentionGuardrail.DeepCopy()
+	if ls.Status.RetentionGuardrail == nil {
+		ls.Status.RetentionGuardrail = &operatorv1.RetentionGuardrailStatus{Retention: *ls.Spec.Retention.DeepCopy()}
+	}
+	st := ls.Status.RetentionGuardrail
+
+	// Keep the retention periods in effect between the minimums and the configured periods, which may have changed
+	// since they were last adjusted. A log type without a minimum keeps its configured period.
+	minimums := &operatorv1.Retention{}
+	if guardrail.MinimumRetention != nil {
+		minimums = guardrail.MinimumRetention
+	}
+	configured := logRetentions(ls.Spec.Retention)
+	effective := logRetentions(&st.Retention)
+	floors := make([]int32, len(configured))
+	for i, c := range configured {
+		if *c.days == nil {
+			continue
+		}
+		ceiling := **c.days
+		floors[i] = ceiling
+		if minimum := *logRetentions(minimums)[i].days; minimum != nil && *minimum < ceiling {
+			floors[i] = *minimum
+		}
+		days := ceiling
+		if e := *effective[i].days; e != nil && *e < ceiling {
+			days = *e
+		}
+		if days < floors[i] {
+			days = floors[i]
+		}
+		*effective[i].days = &days
+	}
+
+	if st.LastChecked.IsZero() || time.Since(st.LastChecked.Time) >= retentionGuardrailInterval {
+		esClient, err := r.esCliCreator(r.client, ctx, relasticsearch.InternalElasticEndpoint(ls), false)
+		if err != nil {
+			return err
+		}
+		usage, err := esClient.DiskUsagePercent(ctx)
+		if err != nil {
+			return err
+		}
+		st.DiskUsagePercent = int32(usage)
+		st.LastChecked = metav1.Now()
+
+		target := guardrail.MaxDiskUsagePercent
+		switch {
+		case st.DiskUsagePercent > target:
+			if adjustRetention(configured, effective, floors, -1) {
+				msg := fmt.Sprintf("Elasticsearch disk usage of %d%% is above the target of %d%%, shortened the retention periods to %s", st.DiskUsagePercent, target, formatRetention(effective))
+				reqLogger.Info(msg)
+				r.recorder.Event(ls, corev1.EventTypeWarning, EventReasonRetentionShortened, msg)
+			} else {
+				msg := fmt.Sprintf("Elasticsearch disk usage of %d%% is above the target of %d%%, but the retention periods are at their minimums", st.DiskUsagePercent, target)
+				reqLogger.Info(msg)
+				r.recorder.Event(ls, corev1.EventTypeWarning, EventReasonRetentionAtMinimum, msg)
+			}
+		case st.DiskUsagePercent <= target-retentionGuardrailHysteresis:
+			if adjustRetention(configured, effective, floors, 1) {
+				msg := fmt.Sprintf("Elasticsearch disk usage of %d%% is below the target of %d%%, lengthened the retention periods to %s", st.DiskUsagePercent, target, formatRetention(effective))
+				reqLogger.Info(msg)
+				r.recorder.Event(ls, corev1.EventTypeNormal, EventReasonRetentionRestored, msg)
+			}
+		}
+	}
+
+	if reflect.DeepEqual(original, st) {
+		return nil
+	}
+	return r.client.Status().Update(ctx, ls)
+}
+
+// adjustRetention moves each retention period in effect by delta days, within its floor and configured period. It
+// returns true if any period changed.
+func adjustRetention(configured, effective []logRetention, floors []int32, delta int32) bool {
+	changed := false
+	for i, e := range effective {
+		if *configured[i].days == nil {
+			continue
+		}
+		days := **e.days + delta
+		if days < floors[i] || days > **configured[i].days {
+			continue
+		}
+		*e.days = &days
+		changed = true
+	}
+	return changed
+}
+
+// formatRetention formats retention periods for events and logs, e.g. flows=7d, dnsLogs=7d.
+func formatRetention(retentions []logRetention) string {
+	var periods []string
+	for _, r := range retentions {
+		if *r.days != nil {
+			periods = append(periods, fmt.Sprintf("%s=%dd", r.name, **r.days))
+		}
+	}
+	return strings.Join(periods, ", ")
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elastic
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/logstorage/initializer"
+	"github.com/tigera/operator/pkg/controller/testutils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Retention guardrail", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		esClient *testutils.FakeElasticClient
+		recorder *record.FakeRecorder
+		ls       *operatorv1.LogStorage
+		r        *ElasticSubController
+	)
+	reqLogger := logf.Log.WithName("test")
+
+	days := func(d int32) *int32 { return &d }
+
+	// enforce runs the guardrail as if the disk usage was last checked long enough ago.
+	enforce := func() {
+		if ls.Status.RetentionGuardrail != nil {
+			ls.Status.RetentionGuardrail.LastChecked = metav1.NewTime(time.Now().Add(-retentionGuardrailInterval))
+		}
+		Expect(r.enforceRetentionGuardrail(ctx, ls, reqLogger)).ShouldNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		esClient = testutils.NewFakeElasticClient()
+		recorder = record.NewFakeRecorder(10)
+
+		ls = &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec: operatorv1.LogStorageSpec{
+				Nodes: &operatorv1.Nodes{Count: 1},
+				RetentionGuardrail: &operatorv1.RetentionGuardrail{
+					MaxDiskUsagePercent: 80,
+					MinimumRetention:    &operatorv1.Retention{Flows: days(6), DNSLogs: days(7)},
+				},
+			},
+		}
+		initializer.FillDefaults(ls)
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+
+		r = &ElasticSubController{client: cli, esCliCreator: esClient.Creator(), recorder: recorder}
+	})
+
+	It("should shorten the retention periods down to their minimums while the disk usage is above the target", func() {
+		esClient.SetDiskUsagePercent(85)
+
+		By("shortening the retention periods that have a minimum by a day")
+		enforce()
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ls), ls)).ShouldNot(HaveOccurred())
+		st := ls.Status.RetentionGuardrail
+		Expect(st).NotTo(BeNil())
+		Expect(st.DiskUsagePercent).To(BeEquivalentTo(85))
+		Expect(st.LastChecked.IsZero()).To(BeFalse())
+		Expect(*st.Retention.Flows).To(BeEquivalentTo(7))
+		Expect(*st.Retention.DNSLogs).To(BeEquivalentTo(7))
+		Expect(*st.Retention.BGPLogs).To(BeEquivalentTo(8))
+		Expect(*st.Retention.AuditReports).To(BeEquivalentTo(91))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning RetentionShortened Elasticsearch disk usage of 85% is above the target of 80%, shortened the retention periods to flows=7d, dnsLogs=7d, bgpLogs=8d")))
+
+		By("not checking again within the interval")
+		Expect(r.enforceRetentionGuardrail(ctx, ls, reqLogger)).ShouldNot(HaveOccurred())
+		Expect(*ls.Status.RetentionGuardrail.Retention.Flows).To(BeEquivalentTo(7))
+		Expect(recorder.Events).NotTo(Receive())
+
+		By("stopping at the minimums")
+		enforce()
+		Expect(*ls.Status.RetentionGuardrail.Retention.Flows).To(BeEquivalentTo(6))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning RetentionShortened")))
+		enforce()
+		Expect(*ls.Status.RetentionGuardrail.Retention.Flows).To(BeEquivalentTo(6))
+		Expect(*ls.Status.RetentionGuardrail.Retention.DNSLogs).To(BeEquivalentTo(7))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning RetentionAtMinimum")))
+	})
+
+	It("should lengthen the retention periods back once the disk usage is well below the target", func() {
+		esClient.SetDiskUsagePercent(90)
+		enforce()
+		enforce()
+		Expect(*ls.Status.RetentionGuardrail.Retention.Flows).To(BeEquivalentTo(6))
+		Eventually(recorder.Events).Should(HaveLen(2))
+		<-recorder.Events
+		<-recorder.Events
+
+		By("keeping the retention periods while the disk usage is just below the target")
+		esClient.SetDiskUsagePercent(75)
+		enforce()
+		Expect(*ls.Status.RetentionGuardrail.Retention.Flows).To(BeEquivalentTo(6))
+		Expect(recorder.Events).NotTo(Receive())
+
+		By("lengthening them a day at a time, up to the configured periods")
+		esClient.SetDiskUsagePercent(60)
+		enforce()
+		Expect(*ls.Status.RetentionGuardrail.Retention.Flows).To(BeEquivalentTo(7))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal RetentionRestored")))
+		enforce()
+		enforce()
+		Expect(*ls.Status.RetentionGuardrail.Retention.Flows).To(BeEquivalentTo(8))
+		Expect(*ls.Status.RetentionGuardrail.Retention.DNSLogs).To(BeEquivalentTo(8))
+	})
+
+	It("should follow changes to the configured retention periods and minimums", func() {
+		esClient.SetDiskUsagePercent(85)
+		enforce()
+		Expect(*ls.Status.RetentionGuardrail.Retention.Flows).To(BeEquivalentTo(7))
+
+		ls.Spec.Retention.Flows = days(5)
+		ls.Spec.RetentionGuardrail.MinimumRetention.DNSLogs = nil
+		Expect(r.enforceRetentionGuardrail(ctx, ls, reqLogger)).ShouldNot(HaveOccurred())
+		Expect(*ls.Status.RetentionGuardrail.Retention.Flows).To(BeEquivalentTo(5))
+		Expect(*ls.Status.RetentionGuardrail.Retention.DNSLogs).To(BeEquivalentTo(8))
+	})
+
+	It("should clear the status once the guardrail is removed", func() {
+		esClient.SetDiskUsagePercent(85)
+		enforce()
+		Expect(ls.Status.RetentionGuardrail).NotTo(BeNil())
+
+		ls.Spec.RetentionGuardrail = nil
+		Expect(r.enforceRetentionGuardrail(ctx, ls, reqLogger)).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ls), ls)).ShouldNot(HaveOccurred())
+		Expect(ls.Status.RetentionGuardrail).To(BeNil())
+	})
+})
@@ -16,6 +16,7 @@ package elastic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/stretchr/testify/mock"
@@ -41,6 +42,10 @@ func (m *MockESClient) CreateUser(_ context.Context, _ *utils.User) error {
 	return fmt.Errorf("CreateUser not implemented in mock client")
 }
 
+func (m *MockESClient) CreateUsers(_ context.Context, _ ...*utils.User) error {
+	return fmt.Errorf("CreateUsers not implemented in mock client")
+}
+
 func (m *MockESClient) SetILMPolicies(_ context.Context, _ *operatorv1.LogStorage) error {
 	return nil
 }
@@ -72,3 +77,93 @@ func (m *MockESClient) GetUsers(ctx context.Context) ([]utils.User, error) {
 	ret := m.Called(ctx)
 	return ret.Get(0).([]utils.User), ret.Error(1)
 }
+
+func (m *MockESClient) CreateRoles(ctx context.Context, roles ...utils.Role) error {
+	ret := m.Called(ctx, roles)
+	return ret.Error(0)
+}
+
+func (m *MockESClient) PutRoleMapping(ctx context.Context, mapping utils.RoleMapping) error {
+	ret := m.Called(ctx, mapping)
+	return ret.Error(0)
+}
+
+func (m *MockESClient) DeleteRoleMapping(ctx context.Context, name string) error {
+	ret := m.Called(ctx, name)
+	return ret.Error(0)
+}
+
+func (m *MockESClient) GetRoleMappings(ctx context.Context) ([]utils.RoleMapping, error) {
+	ret := m.Called(ctx)
+	return ret.Get(0).([]utils.RoleMapping), ret.Error(1)
+}
+
+func (m *MockESClient) IndexExists(ctx context.Context, index string) (bool, error) {
+	ret := m.Called(ctx, index)
+	return ret.Bool(0), ret.Error(1)
+}
+
+func (m *MockESClient) StartReindex(ctx context.Context, source, destination string) (string, error) {
+	ret := m.Called(ctx, source, destination)
+	return ret.String(0), ret.Error(1)
+}
+
+func (m *MockESClient) GetReindexProgress(ctx context.Context, taskID string) (*utils.ReindexProgress, error) {
+	ret := m.Called(ctx, taskID)
+	return ret.Get(0).(*utils.ReindexProgress), ret.Error(1)
+}
+
+func (m *MockESClient) MoveAlias(ctx context.Context, alias, from, to string) error {
+	ret := m.Called(ctx, alias, from, to)
+	return ret.Error(0)
+}
+
+func (m *MockESClient) GetIndexAliases(ctx context.Context, pattern string) ([]utils.IndexAliases, error) {
+	ret := m.Called(ctx, pattern)
+	return ret.Get(0).([]utils.IndexAliases), ret.Error(1)
+}
+
+func (m *MockESClient) PutAlias(ctx context.Context, alias string, indices []string, writeIndex string) error {
+	ret := m.Called(ctx, alias, indices, writeIndex)
+	return ret.Error(0)
+}
+
+func (m *MockESClient) GetIndexSettings(ctx context.Context, pattern string, names ...string) ([]utils.IndexSettings, error) {
+	ret := m.Called(ctx, pattern, names)
+	return ret.Get(0).([]utils.IndexSettings), ret.Error(1)
+}
+
+func (m *MockESClient) PutIndexSettings(ctx context.Context, index string, settings map[string]string) error {
+	ret := m.Called(ctx, index, settings)
+	return ret.Error(0)
+}
+
+func (m *MockESClient) ClusterHealth(ctx context.Context) (json.RawMessage, error) {
+	ret := m.Called(ctx)
+	return ret.Get(0).(json.RawMessage), ret.Error(1)
+}
+
+func (m *MockESClient) ExplainILM(ctx context.Context) (json.RawMessage, error) {
+	ret := m.Called(ctx)
+	return ret.Get(0).(json.RawMessage), ret.Error(1)
+}
+
+func (m *MockESClient) ConfigureCrossClusterReplication(ctx context.Context, remoteCluster, proxyAddress string, leaderIndexPatterns []string) error {
+	ret := m.Called(ctx, remoteCluster, proxyAddress, leaderIndexPatterns)
+	return ret.Error(0)
+}
+
+func (m *MockESClient) IndexUsage(ctx context.Context, pattern string) ([]utils.IndexUsage, error) {
+	ret := m.Called(ctx, pattern)
+	return ret.Get(0).([]utils.IndexUsage), ret.Error(1)
+}
+
+func (m *MockESClient) DiskUsagePercent(ctx context.Context) (int, error) {
+	ret := m.Called(ctx)
+	return ret.Int(0), ret.Error(1)
+}
+
+func (m *MockESClient) NodeStats(ctx context.Context) (*utils.NodeStats, error) {
+	ret := m.Called(ctx)
+	return ret.Get(0).(*utils.NodeStats), ret.Error(1)
+}
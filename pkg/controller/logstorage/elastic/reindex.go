@@ -0,0 +1,163 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/utils"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+)
+
+// IndexMigration describes an index that has to be copied into a new index, because an upgrade changed its mappings in
+// a way that can't be applied to the existing index.
+type IndexMigration struct {
+	// Name identifies the migration in the LogStorage status. It must not be reused.
+	Name string
+
+	// Source is the index to copy the documents from.
+	Source string
+
+	// Destination is the index to copy the documents to. It is expected to be created with the new mappings by the
+	// index template, as soon as the first document is written to it.
+	Destination string
+
+	// Alias is moved from the source index to the destination index once the documents are copied. Optional.
+	Alias string
+}
+
+// indexMigrations lists the index migrations needed by this release. Add an entry when a release changes the
+// mappings of an index in a breaking way. Migrations that have completed are recorded in the LogStorage status, and
+// are not run again.
+var indexMigrations []IndexMigration
+
+// migrateIndices moves the index migrations forward and records their progress in the LogStorage status. It returns
+// true while a migration is still running.
+func (r *ElasticSubController) migrateIndices(ctx context.Context, ls *operatorv1.LogStorage, reqLogger logr.Logger) (bool, error) {
+	if len(r.indexMigrations) == 0 {
+		return false, nil
+	}
+
+	esClient, err := r.esCliCreator(r.client, ctx, relasticsearch.InternalElasticEndpoint(ls), false)
+	if err != nil {
+		return false, err
+	}
+
+	original := ls.Status.DeepCopy()
+	running := false
+	var migrationErr error
+	for _, m := range r.indexMigrations {
+		status := findIndexMigration(ls, m)
+		if err = r.migrateIndex(ctx, esClient, m, status, reqLogger); err != nil {
+			migrationErr = err
+			break
+		}
+		if status.Phase == operatorv1.IndexMigrationRunning {
+			running = true
+		}
+	}
+
+	if !reflect.DeepEqual(original, &ls.Status) {
+		if err = r.client.Status().Update(ctx, ls); err != nil {
+			return running, err
+		}
+	}
+	return running, migrationErr
+}
+
+// migrateIndex moves a single index migration forward by one step.
+func (r *ElasticSubController) migrateIndex(ctx context.Context, esClient utils.ElasticClient, m IndexMigration, status *operatorv1.IndexMigrationStatus, reqLogger logr.Logger) error {
+	reqLogger = reqLogger.WithValues("migration", m.Name, "source", m.Source, "destination", m.Destination)
+
+	switch status.Phase {
+	case operatorv1.IndexMigrationCompleted, operatorv1.IndexMigrationFailed:
+		// A failed migration is left for the user to resolve, since retrying could duplicate documents.
+		return nil
+
+	case "":
+		exists, err := esClient.IndexExists(ctx, m.Source)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// Nothing to copy, e.g. on a new install.
+			status.Phase = operatorv1.IndexMigrationCompleted
+			return nil
+		}
+		reqLogger.Info("Starting index migration")
+		if status.TaskID, err = esClient.StartReindex(ctx, m.Source, m.Destination); err != nil {
+			return err
+		}
+		status.Phase = operatorv1.IndexMigrationRunning
+		return nil
+
+	case operatorv1.IndexMigrationRunning:
+		progress, err := esClient.GetReindexProgress(ctx, status.TaskID)
+		if err != nil {
+			return err
+		}
+		status.Total = progress.Total
+		status.Copied = progress.Copied
+		if !progress.Completed {
+			return nil
+		}
+		if progress.Failure != "" {
+			reqLogger.Info("Index migration failed", "reason", progress.Failure)
+			status.Phase = operatorv1.IndexMigrationFailed
+			status.Message = progress.Failure
+			return nil
+		}
+		if m.Alias != "" {
+			if err = esClient.MoveAlias(ctx, m.Alias, m.Source, m.Destination); err != nil {
+				return err
+			}
+		}
+		reqLogger.Info("Index migration completed")
+		status.Phase = operatorv1.IndexMigrationCompleted
+		return nil
+	}
+	return fmt.Errorf("unknown phase %q for index migration %s", status.Phase, m.Name)
+}
+
+// findIndexMigration returns the status of the migration, adding it to the LogStorage status if it isn't there yet.
+func findIndexMigration(ls *operatorv1.LogStorage, m IndexMigration) *operatorv1.IndexMigrationStatus {
+	for i := range ls.Status.IndexMigrations {
+		if ls.Status.IndexMigrations[i].Name == m.Name {
+			return &ls.Status.IndexMigrations[i]
+		}
+	}
+	ls.Status.IndexMigrations = append(ls.Status.IndexMigrations, operatorv1.IndexMigrationStatus{
+		Name:        m.Name,
+		Source:      m.Source,
+		Destination: m.Destination,
+	})
+	return &ls.Status.IndexMigrations[len(ls.Status.IndexMigrations)-1]
+}
+
+// failedIndexMigrations returns the names of the migrations that failed.
+func failedIndexMigrations(ls *operatorv1.LogStorage) []string {
+	var failed []string
+	for _, m := range ls.Status.IndexMigrations {
+		if m.Phase == operatorv1.IndexMigrationFailed {
+			failed = append(failed, m.Name)
+		}
+	}
+	return failed
+}
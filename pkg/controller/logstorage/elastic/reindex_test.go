@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elastic
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Index migrations", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		esClient *MockESClient
+		ls       *operatorv1.LogStorage
+		r        *ElasticSubController
+	)
+	reqLogger := logf.Log.WithName("test")
+	migration := IndexMigration{Name: "flows-v2", Source: "flows-v1", Destination: "flows-v2", Alias: "flows"}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		esClient = &MockESClient{}
+		ctx = context.WithValue(context.Background(), MockESClientKey("mockESClient"), esClient)
+
+		ls = &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+
+		r = &ElasticSubController{client: cli, esCliCreator: MockESCLICreator, indexMigrations: []IndexMigration{migration}}
+	})
+
+	It("should complete migrations of indices that don't exist", func() {
+		esClient.On("IndexExists", mock.Anything, "flows-v1").Return(false, nil)
+
+		running, err := r.migrateIndices(ctx, ls, reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(running).To(BeFalse())
+
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ls), ls)).ShouldNot(HaveOccurred())
+		Expect(ls.Status.IndexMigrations).To(ConsistOf(operatorv1.IndexMigrationStatus{
+			Name: "flows-v2", Source: "flows-v1", Destination: "flows-v2", Phase: operatorv1.IndexMigrationCompleted,
+		}))
+		esClient.AssertNotCalled(GinkgoT(), "StartReindex", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("should copy the index, track progress and move the alias", func() {
+		esClient.On("IndexExists", mock.Anything, "flows-v1").Return(true, nil)
+		esClient.On("StartReindex", mock.Anything, "flows-v1", "flows-v2").Return("node:1", nil)
+
+		By("starting the reindex task")
+		running, err := r.migrateIndices(ctx, ls, reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(running).To(BeTrue())
+		Expect(ls.Status.IndexMigrations[0].Phase).To(Equal(operatorv1.IndexMigrationRunning))
+		Expect(ls.Status.IndexMigrations[0].TaskID).To(Equal("node:1"))
+
+		By("reporting progress")
+		esClient.On("GetReindexProgress", mock.Anything, "node:1").Return(&utils.ReindexProgress{Total: 10, Copied: 4}, nil).Once()
+		running, err = r.migrateIndices(ctx, ls, reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(running).To(BeTrue())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ls), ls)).ShouldNot(HaveOccurred())
+		Expect(ls.Status.IndexMigrations[0].Total).To(BeEquivalentTo(10))
+		Expect(ls.Status.IndexMigrations[0].Copied).To(BeEquivalentTo(4))
+
+		By("moving the alias once the documents are copied")
+		esClient.On("GetReindexProgress", mock.Anything, "node:1").Return(&utils.ReindexProgress{Completed: true, Total: 10, Copied: 10}, nil).Once()
+		esClient.On("MoveAlias", mock.Anything, "flows", "flows-v1", "flows-v2").Return(nil)
+		running, err = r.migrateIndices(ctx, ls, reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(running).To(BeFalse())
+		Expect(ls.Status.IndexMigrations[0].Phase).To(Equal(operatorv1.IndexMigrationCompleted))
+		esClient.AssertExpectations(GinkgoT())
+
+		By("not running completed migrations again")
+		running, err = r.migrateIndices(ctx, ls, reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(running).To(BeFalse())
+		esClient.AssertNumberOfCalls(GinkgoT(), "StartReindex", 1)
+	})
+
+	It("should report failed migrations without moving the alias", func() {
+		esClient.On("IndexExists", mock.Anything, "flows-v1").Return(true, nil)
+		esClient.On("StartReindex", mock.Anything, "flows-v1", "flows-v2").Return("node:1", nil)
+		esClient.On("GetReindexProgress", mock.Anything, "node:1").Return(&utils.ReindexProgress{Completed: true, Failure: "mapper_parsing_exception"}, nil)
+
+		_, err := r.migrateIndices(ctx, ls, reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		running, err := r.migrateIndices(ctx, ls, reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(running).To(BeFalse())
+
+		Expect(ls.Status.IndexMigrations[0].Phase).To(Equal(operatorv1.IndexMigrationFailed))
+		Expect(ls.Status.IndexMigrations[0].Message).To(Equal("mapper_parsing_exception"))
+		Expect(failedIndexMigrations(ls)).To(ConsistOf("flows-v2"))
+		esClient.AssertNotCalled(GinkgoT(), "MoveAlias", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+})
@@ -0,0 +1,192 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render"
+)
+
+const (
+	// esStatefulSetNameLabel is the label with which ECK labels the PVCs of the Elasticsearch nodes with the name of
+	// their StatefulSet.
+	esStatefulSetNameLabel = "elasticsearch.k8s.elastic.co/statefulset-name"
+
+	// preUpgradeSnapshotLabel is the label of the volume snapshots taken before an upgrade, with the version of
+	// Elasticsearch that they were taken before upgrading to.
+	preUpgradeSnapshotLabel = "operator.tigera.io/pre-upgrade-to"
+)
+
+// volumeSnapshotGVK is the kind of the volume snapshots of the CSI snapshotter. It is used through unstructured objects,
+// since the snapshot CRDs are only installed in clusters that support volume snapshots.
+var volumeSnapshotGVK = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshot"}
+
+// snapshotVolumesBeforeUpgrade snapshots the disks of the Elasticsearch nodes before Elasticsearch is upgraded to the
+// given version. The disks of a NodeSet are snapshotted with the VolumeSnapshotClassName of its NodeSet in the
+// LogStorage, or else the one of spec.UpgradeSnapshots, and aren't snapshotted when neither is set. The snapshots are
+// recorded in the status of the LogStorage. It returns whether the upgrade can proceed, which is once all the snapshots
+// are ready to use. Nothing is snapshotted when Elasticsearch is not being upgraded.
+func (r *ElasticSubController) snapshotVolumesBeforeUpgrade(ctx context.Context, ls *operatorv1.LogStorage, es *esv1.Elasticsearch, version string, reqLogger logr.Logger) (bool, error) {
+	if es == nil || es.Spec.Version == "" || es.Spec.Version == version {
+		return true, nil
+	}
+
+	ready := true
+	var snapshots []operatorv1.UpgradeVolumeSnapshot
+	for _, nodeSet := range es.Spec.NodeSets {
+		snapshotClassName := volumeSnapshotClassName(ls, nodeSet.Name)
+		if snapshotClassName == "" {
+			continue
+		}
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		if err := r.client.List(ctx, pvcs, client.InNamespace(render.ElasticsearchNamespace),
+			client.MatchingLabels{esStatefulSetNameLabel: esv1.StatefulSet(es.Name, nodeSet.Name)}); err != nil {
+			return false, fmt.Errorf("failed to list the PVCs of NodeSet %s: %w", nodeSet.Name, err)
+		}
+		for _, pvc := range pvcs.Items {
+			name, snapshotReady, err := r.snapshotVolume(ctx, pvc.Name, snapshotClassName, version, reqLogger)
+			if err != nil {
+				return false, err
+			}
+			snapshots = append(snapshots, operatorv1.UpgradeVolumeSnapshot{Name: name, PersistentVolumeClaimName: pvc.Name})
+			ready = ready && snapshotReady
+		}
+	}
+	if len(snapshots) == 0 {
+		return true, nil
+	}
+
+	original := ls.Status.DeepCopy()
+	recordUpgradeSnapshots(ls, es.Spec.Version, version, snapshots, ready)
+	if ready {
+		if err := r.pruneUpgradeSnapshots(ctx, ls, reqLogger); err != nil {
+			return false, err
+		}
+	}
+	if !reflect.DeepEqual(original, &ls.Status) {
+		if err := r.client.Status().Update(ctx, ls); err != nil {
+			return false, err
+		}
+	}
+	return ready, nil
+}
+
+// volumeSnapshotClassName returns the volume snapshot class of the disks of the rendered NodeSet, or an empty string if
+// they aren't snapshotted before upgrades.
+func volumeSnapshotClassName(ls *operatorv1.LogStorage, nodeSetName string) string {
+	if ls.Spec.Nodes != nil {
+		// The NodeSets rendered from the NodeSets of the LogStorage are suffixed with their index.
+		for i, nodeSetConfig := range ls.Spec.Nodes.NodeSets {
+			if nodeSetConfig.VolumeSnapshotClassName != "" && strings.HasSuffix(nodeSetName, fmt.Sprintf("-%d", i)) {
+				return nodeSetConfig.VolumeSnapshotClassName
+			}
+		}
+	}
+	if ls.Spec.UpgradeSnapshots != nil {
+		return ls.Spec.UpgradeSnapshots.VolumeSnapshotClassName
+	}
+	return ""
+}
+
+// recordUpgradeSnapshots records the snapshots taken before upgrading Elasticsearch from previousVersion to version in
+// the status of the LogStorage.
+func recordUpgradeSnapshots(ls *operatorv1.LogStorage, previousVersion, version string, snapshots []operatorv1.UpgradeVolumeSnapshot, ready bool) {
+	var upgrade *operatorv1.UpgradeSnapshotStatus
+	for i := range ls.Status.UpgradeSnapshots {
+		if ls.Status.UpgradeSnapshots[i].Version == version {
+			upgrade = &ls.Status.UpgradeSnapshots[i]
+			break
+		}
+	}
+	if upgrade == nil {
+		ls.Status.UpgradeSnapshots = append(ls.Status.UpgradeSnapshots, operatorv1.UpgradeSnapshotStatus{
+			Version:         version,
+			PreviousVersion: previousVersion,
+			CreationTime:    metav1.Now(),
+		})
+		upgrade = &ls.Status.UpgradeSnapshots[len(ls.Status.UpgradeSnapshots)-1]
+	}
+	upgrade.VolumeSnapshots = snapshots
+	upgrade.ReadyToUse = ready
+}
+
+// pruneUpgradeSnapshots deletes the snapshots of the upgrades beyond the number that spec.UpgradeSnapshots retains, oldest
+// first, and removes them from the status of the LogStorage.
+func (r *ElasticSubController) pruneUpgradeSnapshots(ctx context.Context, ls *operatorv1.LogStorage, reqLogger logr.Logger) error {
+	retain := 1
+	if ls.Spec.UpgradeSnapshots != nil && ls.Spec.UpgradeSnapshots.Retain != nil {
+		retain = int(*ls.Spec.UpgradeSnapshots.Retain)
+	}
+	for len(ls.Status.UpgradeSnapshots) > retain {
+		upgrade := ls.Status.UpgradeSnapshots[0]
+		for _, s := range upgrade.VolumeSnapshots {
+			snapshot := &unstructured.Unstructured{}
+			snapshot.SetGroupVersionKind(volumeSnapshotGVK)
+			snapshot.SetName(s.Name)
+			snapshot.SetNamespace(render.ElasticsearchNamespace)
+			if err := r.client.Delete(ctx, snapshot); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete VolumeSnapshot %s: %w", s.Name, err)
+			}
+		}
+		reqLogger.Info("Deleted the snapshots of the Elasticsearch disks taken before an earlier upgrade", "version", upgrade.Version)
+		ls.Status.UpgradeSnapshots = ls.Status.UpgradeSnapshots[1:]
+	}
+	return nil
+}
+
+// snapshotVolume creates the snapshot of the PVC taken before upgrading Elasticsearch to the given version, if it
+// doesn't exist yet, and returns its name and whether it is ready to use.
+func (r *ElasticSubController) snapshotVolume(ctx context.Context, pvcName, snapshotClassName, version string, reqLogger logr.Logger) (string, bool, error) {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(volumeSnapshotGVK)
+	name := fmt.Sprintf("%s-pre-%s", pvcName, strings.ReplaceAll(version, ".", "-"))
+	err := r.client.Get(ctx, client.ObjectKey{Name: name, Namespace: render.ElasticsearchNamespace}, snapshot)
+	if errors.IsNotFound(err) {
+		reqLogger.Info("Snapshotting the disk of an Elasticsearch node before upgrading Elasticsearch", "pvc", pvcName, "snapshot", name, "version", version)
+		snapshot.SetName(name)
+		snapshot.SetNamespace(render.ElasticsearchNamespace)
+		snapshot.SetLabels(map[string]string{preUpgradeSnapshotLabel: version})
+		snapshot.Object["spec"] = map[string]interface{}{
+			"volumeSnapshotClassName": snapshotClassName,
+			"source":                  map[string]interface{}{"persistentVolumeClaimName": pvcName},
+		}
+		if err = r.client.Create(ctx, snapshot); err != nil {
+			return name, false, fmt.Errorf("failed to create VolumeSnapshot %s: %w", name, err)
+		}
+		return name, false, nil
+	} else if err != nil {
+		return name, false, fmt.Errorf("failed to get VolumeSnapshot %s: %w", name, err)
+	}
+
+	if message, found, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message"); found && message != "" {
+		return name, false, fmt.Errorf("VolumeSnapshot %s failed: %s", name, message)
+	}
+	readyToUse, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	return name, readyToUse, nil
+}
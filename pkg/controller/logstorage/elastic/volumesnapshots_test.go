@@ -0,0 +1,222 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elastic
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("Volume snapshots before upgrading Elasticsearch", func() {
+	var (
+		ctx context.Context
+		cli client.Client
+		ls  *operatorv1.LogStorage
+		es  *esv1.Elasticsearch
+		r   *ElasticSubController
+	)
+	reqLogger := logf.Log.WithName("test")
+
+	getSnapshot := func(name string) *unstructured.Unstructured {
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(volumeSnapshotGVK)
+		Expect(cli.Get(ctx, client.ObjectKey{Name: name, Namespace: render.ElasticsearchNamespace}, snapshot)).ShouldNot(HaveOccurred())
+		return snapshot
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(esv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		scheme.AddKnownTypeWithName(volumeSnapshotGVK, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(volumeSnapshotGVK.GroupVersion().WithKind("VolumeSnapshotList"), &unstructured.UnstructuredList{})
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		ls = &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec: operatorv1.LogStorageSpec{
+				Nodes: &operatorv1.Nodes{
+					Count: 2,
+					NodeSets: []operatorv1.NodeSet{
+						{VolumeSnapshotClassName: "csi-snapclass"},
+						{},
+					},
+				},
+			},
+		}
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+
+		es = &esv1.Elasticsearch{
+			ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace},
+			Spec: esv1.ElasticsearchSpec{
+				Version:  "7.17.0",
+				NodeSets: []esv1.NodeSet{{Name: "abc-0"}, {Name: "abc-1"}},
+			},
+		}
+
+		for _, nodeSet := range es.Spec.NodeSets {
+			Expect(cli.Create(ctx, &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "elasticsearch-data-" + esv1.StatefulSet(es.Name, nodeSet.Name) + "-0",
+					Namespace: render.ElasticsearchNamespace,
+					Labels:    map[string]string{esStatefulSetNameLabel: esv1.StatefulSet(es.Name, nodeSet.Name)},
+				},
+			})).ShouldNot(HaveOccurred())
+		}
+
+		r = &ElasticSubController{client: cli}
+	})
+
+	It("should not snapshot the disks when Elasticsearch isn't being upgraded", func() {
+		ready, err := r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "7.17.0", reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ready).To(BeTrue())
+
+		snapshots := &unstructured.UnstructuredList{}
+		snapshots.SetGroupVersionKind(volumeSnapshotGVK.GroupVersion().WithKind("VolumeSnapshotList"))
+		Expect(cli.List(ctx, snapshots)).ShouldNot(HaveOccurred())
+		Expect(snapshots.Items).To(BeEmpty())
+	})
+
+	It("should wait for the snapshots of the NodeSets with a volume snapshot class", func() {
+		ready, err := r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "8.15.0", reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ready).To(BeFalse())
+
+		snapshots := &unstructured.UnstructuredList{}
+		snapshots.SetGroupVersionKind(volumeSnapshotGVK.GroupVersion().WithKind("VolumeSnapshotList"))
+		Expect(cli.List(ctx, snapshots)).ShouldNot(HaveOccurred())
+		Expect(snapshots.Items).To(HaveLen(1))
+
+		name := "elasticsearch-data-tigera-secure-es-abc-0-0-pre-8-15-0"
+		snapshot := getSnapshot(name)
+		Expect(snapshot.Object["spec"]).To(Equal(map[string]interface{}{
+			"volumeSnapshotClassName": "csi-snapclass",
+			"source":                  map[string]interface{}{"persistentVolumeClaimName": "elasticsearch-data-tigera-secure-es-abc-0-0"},
+		}))
+
+		By("proceeding once the snapshots are ready to use")
+		Expect(unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse")).ShouldNot(HaveOccurred())
+		Expect(cli.Update(ctx, snapshot)).ShouldNot(HaveOccurred())
+		ready, err = r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "8.15.0", reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ready).To(BeTrue())
+	})
+
+	It("should record the snapshots in the status of the LogStorage", func() {
+		_, err := r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "8.15.0", reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ls), ls)).ShouldNot(HaveOccurred())
+		Expect(ls.Status.UpgradeSnapshots).To(HaveLen(1))
+		upgrade := ls.Status.UpgradeSnapshots[0]
+		Expect(upgrade.Version).To(Equal("8.15.0"))
+		Expect(upgrade.PreviousVersion).To(Equal("7.17.0"))
+		Expect(upgrade.CreationTime.IsZero()).To(BeFalse())
+		Expect(upgrade.ReadyToUse).To(BeFalse())
+		Expect(upgrade.VolumeSnapshots).To(ConsistOf(operatorv1.UpgradeVolumeSnapshot{
+			Name:                      "elasticsearch-data-tigera-secure-es-abc-0-0-pre-8-15-0",
+			PersistentVolumeClaimName: "elasticsearch-data-tigera-secure-es-abc-0-0",
+		}))
+
+		snapshot := getSnapshot("elasticsearch-data-tigera-secure-es-abc-0-0-pre-8-15-0")
+		Expect(unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse")).ShouldNot(HaveOccurred())
+		Expect(cli.Update(ctx, snapshot)).ShouldNot(HaveOccurred())
+		_, err = r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "8.15.0", reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ls), ls)).ShouldNot(HaveOccurred())
+		Expect(ls.Status.UpgradeSnapshots[0].ReadyToUse).To(BeTrue())
+	})
+
+	It("should snapshot all the disks with the volume snapshot class of spec.UpgradeSnapshots", func() {
+		ls.Spec.UpgradeSnapshots = &operatorv1.UpgradeSnapshots{VolumeSnapshotClassName: "default-snapclass"}
+
+		_, err := r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "8.15.0", reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getSnapshot("elasticsearch-data-tigera-secure-es-abc-0-0-pre-8-15-0").Object["spec"]).To(
+			HaveKeyWithValue("volumeSnapshotClassName", "csi-snapclass"))
+		Expect(getSnapshot("elasticsearch-data-tigera-secure-es-abc-1-0-pre-8-15-0").Object["spec"]).To(
+			HaveKeyWithValue("volumeSnapshotClassName", "default-snapclass"))
+	})
+
+	It("should delete the snapshots of the upgrades beyond the retained number once the new ones are ready", func() {
+		retain := int32(1)
+		ls.Spec.UpgradeSnapshots = &operatorv1.UpgradeSnapshots{VolumeSnapshotClassName: "csi-snapclass", Retain: &retain}
+		old := &unstructured.Unstructured{}
+		old.SetGroupVersionKind(volumeSnapshotGVK)
+		old.SetName("elasticsearch-data-tigera-secure-es-abc-0-0-pre-7-17-0")
+		old.SetNamespace(render.ElasticsearchNamespace)
+		Expect(cli.Create(ctx, old)).ShouldNot(HaveOccurred())
+		ls.Status.UpgradeSnapshots = []operatorv1.UpgradeSnapshotStatus{{
+			Version:         "7.17.0",
+			PreviousVersion: "7.16.0",
+			ReadyToUse:      true,
+			VolumeSnapshots: []operatorv1.UpgradeVolumeSnapshot{{Name: old.GetName(), PersistentVolumeClaimName: "elasticsearch-data-tigera-secure-es-abc-0-0"}},
+		}}
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		By("keeping the old snapshots while the new ones aren't ready")
+		_, err := r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "8.15.0", reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ls.Status.UpgradeSnapshots).To(HaveLen(2))
+		getSnapshot(old.GetName())
+
+		By("deleting the old snapshots once the new ones are ready")
+		for _, name := range []string{"elasticsearch-data-tigera-secure-es-abc-0-0-pre-8-15-0", "elasticsearch-data-tigera-secure-es-abc-1-0-pre-8-15-0"} {
+			snapshot := getSnapshot(name)
+			Expect(unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse")).ShouldNot(HaveOccurred())
+			Expect(cli.Update(ctx, snapshot)).ShouldNot(HaveOccurred())
+		}
+		ready, err := r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "8.15.0", reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ready).To(BeTrue())
+
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ls), ls)).ShouldNot(HaveOccurred())
+		Expect(ls.Status.UpgradeSnapshots).To(HaveLen(1))
+		Expect(ls.Status.UpgradeSnapshots[0].Version).To(Equal("8.15.0"))
+		err = cli.Get(ctx, client.ObjectKeyFromObject(old), old)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should report snapshots that failed", func() {
+		_, err := r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "8.15.0", reqLogger)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		snapshot := getSnapshot("elasticsearch-data-tigera-secure-es-abc-0-0-pre-8-15-0")
+		Expect(unstructured.SetNestedField(snapshot.Object, "no space left", "status", "error", "message")).ShouldNot(HaveOccurred())
+		Expect(cli.Update(ctx, snapshot)).ShouldNot(HaveOccurred())
+
+		_, err = r.snapshotVolumesBeforeUpgrade(ctx, ls, es, "8.15.0", reqLogger)
+		Expect(err).To(MatchError(ContainSubstring("no space left")))
+	})
+})
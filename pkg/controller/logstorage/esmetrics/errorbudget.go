@@ -0,0 +1,156 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package esmetrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/utils"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+)
+
+// errorBudgetInterval is how often the statistics of the Elasticsearch nodes are collected. The rates are computed over
+// the time between two collections.
+const errorBudgetInterval = time.Minute
+
+// errorBudgetThreadPools are the thread pools whose rejected executions count against the error budget. They are the
+// ones that serve the log queries and the log ingestion.
+var errorBudgetThreadPools = []string{"search", "write"}
+
+var (
+	rejectedExecutionsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tigera_operator_elasticsearch_rejected_executions_per_minute",
+		Help: "Rate at which the thread pools of the Elasticsearch nodes reject requests, summed over all nodes.",
+	}, []string{"thread_pool"})
+	queryLatencyGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tigera_operator_elasticsearch_query_latency_seconds",
+		Help: "Average time that the Elasticsearch nodes spent in the query phase of a search, over the last minute.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(rejectedExecutionsGauge, queryLatencyGauge)
+}
+
+// nodeStatsSample is the statistics of the Elasticsearch nodes at the time they were collected.
+type nodeStatsSample struct {
+	time  time.Time
+	stats *utils.NodeStats
+}
+
+// checkErrorBudget collects the statistics of the Elasticsearch nodes once per errorBudgetInterval, exports their rates
+// since the previous collection as metrics, and compares them with the error budget of the LogStorage. It returns a
+// description of the thresholds that are exceeded, or an empty string if there are none. Between two collections, the
+// result of the last one is returned.
+func (r *ESMetricsSubController) checkErrorBudget(ctx context.Context, ls *operatorv1.LogStorage, reqLogger logr.Logger) string {
+	if r.lastSample != nil && time.Since(r.lastSample.time) < errorBudgetInterval {
+		return r.errorBudgetViolation
+	}
+
+	stats, err := r.collectNodeStats(ctx, ls)
+	if err != nil {
+		// The statistics are only informational, so es-metrics isn't degraded when they can't be collected. The rates are
+		// computed again from the next collection.
+		reqLogger.Error(err, "Failed to collect the statistics of the Elasticsearch nodes")
+		r.resetErrorBudget()
+		return ""
+	}
+	if stats == nil {
+		r.resetErrorBudget()
+		return ""
+	}
+
+	previous := r.lastSample
+	r.lastSample = &nodeStatsSample{time: time.Now(), stats: stats}
+	r.errorBudgetViolation = ""
+	if previous == nil {
+		return ""
+	}
+	minutes := r.lastSample.time.Sub(previous.time).Minutes()
+
+	// The counts are cumulative since the nodes started, so they drop when a node restarts. Skip the rates of that
+	// interval rather than reporting them as negative.
+	var rejected float64
+	for _, pool := range errorBudgetThreadPools {
+		delta := stats.RejectedExecutions[pool] - previous.stats.RejectedExecutions[pool]
+		if delta < 0 {
+			return ""
+		}
+		rate := float64(delta) / minutes
+		rejectedExecutionsGauge.WithLabelValues(pool).Set(rate)
+		rejected += rate
+	}
+	queries := stats.QueryTotal - previous.stats.QueryTotal
+	queryTime := stats.QueryTimeMillis - previous.stats.QueryTimeMillis
+	if queries < 0 || queryTime < 0 {
+		return ""
+	}
+	var latency time.Duration
+	if queries > 0 {
+		latency = time.Duration(queryTime) * time.Millisecond / time.Duration(queries)
+	}
+	queryLatencyGauge.Set(latency.Seconds())
+
+	budget := ls.Spec.ErrorBudget
+	if budget == nil {
+		return ""
+	}
+	var violations []string
+	if max := budget.MaxRejectedExecutionsPerMinute; max != nil && rejected > float64(*max) {
+		violations = append(violations, fmt.Sprintf("Elasticsearch rejected %.1f requests per minute, more than the maximum of %d", rejected, *max))
+	}
+	if max := budget.MaxAverageQueryLatency; max != nil && queries > 0 && latency > max.Duration {
+		violations = append(violations, fmt.Sprintf("Elasticsearch queries took %s on average, more than the maximum of %s", latency, max.Duration))
+	}
+	r.errorBudgetViolation = strings.Join(violations, "; ")
+	return r.errorBudgetViolation
+}
+
+// collectNodeStats returns the statistics of the Elasticsearch nodes, or nil if the Elasticsearch cluster that the
+// operator provisions is not operational yet.
+func (r *ESMetricsSubController) collectNodeStats(ctx context.Context, ls *operatorv1.LogStorage) (*utils.NodeStats, error) {
+	if !r.elasticExternal {
+		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
+		if err != nil {
+			return nil, err
+		}
+		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
+			return nil, nil
+		}
+	}
+	esClient, err := r.esClientFn(r.client, ctx, relasticsearch.InternalElasticEndpoint(ls), r.elasticExternal)
+	if err != nil {
+		return nil, err
+	}
+	return esClient.NodeStats(ctx)
+}
+
+// resetErrorBudget forgets the last collection, so that the metrics are no longer exported and the rates are computed
+// from the next two collections.
+func (r *ESMetricsSubController) resetErrorBudget() {
+	r.lastSample = nil
+	r.errorBudgetViolation = ""
+	rejectedExecutionsGauge.Reset()
+	queryLatencyGauge.Set(0)
+}
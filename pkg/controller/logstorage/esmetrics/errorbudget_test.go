@@ -0,0 +1,151 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package esmetrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/testutils"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("Elasticsearch error budget", func() {
+	var (
+		ctx      context.Context
+		esClient *testutils.FakeElasticClient
+		ls       *operatorv1.LogStorage
+		r        *ESMetricsSubController
+	)
+	reqLogger := logf.Log.WithName("test")
+
+	// check checks the error budget as if the statistics were last collected a minute ago.
+	check := func() string {
+		if r.lastSample != nil {
+			r.lastSample.time = time.Now().Add(-errorBudgetInterval)
+		}
+		return r.checkErrorBudget(ctx, ls, reqLogger)
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(esv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli := ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		es := &esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}}
+		es.Status.Phase = esv1.ElasticsearchReadyPhase
+		Expect(cli.Create(ctx, es)).ShouldNot(HaveOccurred())
+
+		maxRejected := int32(10)
+		ls = &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec: operatorv1.LogStorageSpec{
+				ErrorBudget: &operatorv1.ElasticsearchErrorBudget{
+					MaxRejectedExecutionsPerMinute: &maxRejected,
+					MaxAverageQueryLatency:         &metav1.Duration{Duration: 500 * time.Millisecond},
+				},
+			},
+		}
+
+		esClient = testutils.NewFakeElasticClient()
+		r = &ESMetricsSubController{client: cli, esClientFn: esClient.Creator()}
+	})
+
+	AfterEach(func() {
+		r.resetErrorBudget()
+	})
+
+	It("should export the rates since the previous collection and compare them with the budget", func() {
+		esClient.SetNodeStats(utils.NodeStats{
+			RejectedExecutions: map[string]int64{"search": 100, "write": 50, "get": 1000},
+			QueryTotal:         1000,
+			QueryTimeMillis:    10000,
+		})
+		Expect(check()).To(BeEmpty())
+
+		By("staying within the budget")
+		esClient.SetNodeStats(utils.NodeStats{
+			RejectedExecutions: map[string]int64{"search": 103, "write": 52, "get": 5000},
+			QueryTotal:         1100,
+			QueryTimeMillis:    20000,
+		})
+		Expect(check()).To(BeEmpty())
+		Expect(testutil.ToFloat64(rejectedExecutionsGauge.WithLabelValues("search"))).To(BeNumerically("~", 3, 0.01))
+		Expect(testutil.ToFloat64(rejectedExecutionsGauge.WithLabelValues("write"))).To(BeNumerically("~", 2, 0.01))
+		Expect(testutil.CollectAndCount(rejectedExecutionsGauge)).To(Equal(2))
+		Expect(testutil.ToFloat64(queryLatencyGauge)).To(BeNumerically("~", 0.1, 0.001))
+
+		By("exceeding the budget")
+		esClient.SetNodeStats(utils.NodeStats{
+			RejectedExecutions: map[string]int64{"search": 123, "write": 52},
+			QueryTotal:         1200,
+			QueryTimeMillis:    120000,
+		})
+		violation := check()
+		Expect(violation).To(ContainSubstring("Elasticsearch rejected 20.0 requests per minute, more than the maximum of 10"))
+		Expect(violation).To(ContainSubstring("Elasticsearch queries took 1s on average, more than the maximum of 500ms"))
+
+		By("returning the last result until the next collection")
+		Expect(r.checkErrorBudget(ctx, ls, reqLogger)).To(Equal(violation))
+	})
+
+	It("should skip the interval in which a node restarted", func() {
+		esClient.SetNodeStats(utils.NodeStats{RejectedExecutions: map[string]int64{"search": 100}})
+		Expect(check()).To(BeEmpty())
+
+		esClient.SetNodeStats(utils.NodeStats{RejectedExecutions: map[string]int64{"search": 50}})
+		Expect(check()).To(BeEmpty())
+
+		esClient.SetNodeStats(utils.NodeStats{RejectedExecutions: map[string]int64{"search": 80}})
+		Expect(check()).To(ContainSubstring("Elasticsearch rejected"))
+	})
+
+	It("should not degrade when the statistics can't be collected", func() {
+		esClient.SetNodeStats(utils.NodeStats{RejectedExecutions: map[string]int64{"search": 100}})
+		Expect(check()).To(BeEmpty())
+		esClient.SetNodeStats(utils.NodeStats{RejectedExecutions: map[string]int64{"search": 200}})
+		Expect(check()).NotTo(BeEmpty())
+
+		esClient.InjectError(testutils.MethodNodeStats, fmt.Errorf("injected"))
+		Expect(check()).To(BeEmpty())
+		Expect(r.lastSample).To(BeNil())
+		Expect(testutil.CollectAndCount(rejectedExecutionsGauge)).To(Equal(0))
+	})
+
+	It("should only export the metrics when no budget is set", func() {
+		ls.Spec.ErrorBudget = nil
+		esClient.SetNodeStats(utils.NodeStats{RejectedExecutions: map[string]int64{"write": 0}})
+		Expect(check()).To(BeEmpty())
+		esClient.SetNodeStats(utils.NodeStats{RejectedExecutions: map[string]int64{"write": 1000}})
+		Expect(check()).To(BeEmpty())
+		Expect(testutil.ToFloat64(rejectedExecutionsGauge.WithLabelValues("write"))).To(BeNumerically("~", 1000, 1))
+	})
+})
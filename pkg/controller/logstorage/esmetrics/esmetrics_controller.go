@@ -47,6 +47,7 @@ import (
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/logstorage/esmetrics"
+	"github.com/tigera/operator/pkg/telemetry"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
@@ -60,6 +61,14 @@ type ESMetricsSubController struct {
 	clusterDomain  string
 	multiTenant    bool
 	tierWatchReady *utils.ReadyFlag
+
+	esClientFn      utils.ElasticsearchClientCreator
+	elasticExternal bool
+
+	// lastSample is the last collection of the statistics of the Elasticsearch nodes, and errorBudgetViolation the
+	// thresholds of the error budget that were exceeded when it was collected.
+	lastSample           *nodeStatsSample
+	errorBudgetViolation string
 }
 
 func Add(mgr manager.Manager, opts options.AddOptions) error {
@@ -73,17 +82,24 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return nil
 	}
 
+	esClientFn, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
 	r := &ESMetricsSubController{
-		client:         mgr.GetClient(),
-		scheme:         mgr.GetScheme(),
-		status:         status.New(mgr.GetClient(), initializer.TigeraStatusLogStorageESMetrics, opts.KubernetesVersion),
-		clusterDomain:  opts.ClusterDomain,
-		provider:       opts.DetectedProvider,
-		tierWatchReady: &utils.ReadyFlag{},
+		client:          telemetry.Client(mgr.GetClient()),
+		scheme:          mgr.GetScheme(),
+		status:          status.New(mgr.GetClient(), initializer.TigeraStatusLogStorageESMetrics, opts.KubernetesVersion),
+		clusterDomain:   opts.ClusterDomain,
+		provider:        opts.DetectedProvider,
+		tierWatchReady:  &utils.ReadyFlag{},
+		esClientFn:      esClientFn,
+		elasticExternal: opts.ElasticExternal,
 	}
 	r.status.Run(opts.ShutdownContext)
 
-	c, err := ctrlruntime.NewController("log-storage-esmetrics-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-esmetrics-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-esmetrics-controller", r)})
 	if err != nil {
 		return fmt.Errorf("log-storage-esmetrics-controller failed to establish a connection to k8s: %w", err)
 	}
@@ -104,6 +120,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	secretsToWatch := []string{
 		esmetrics.ElasticsearchMetricsSecret,
 		esmetrics.ElasticsearchMetricsServerTLSSecret,
+		esmetrics.ElasticsearchMetricsClientTLSSecret,
 	}
 	for _, name := range secretsToWatch {
 		if err = utils.AddSecretsWatch(c, name, common.OperatorNamespace()); err != nil {
@@ -144,6 +161,7 @@ func (r *ESMetricsSubController) Reconcile(ctx context.Context, request reconcil
 	err = r.client.Get(ctx, key, logStorage)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			r.resetErrorBudget()
 			r.status.OnCRNotFound()
 			return reconcile.Result{}, nil
 		}
@@ -151,8 +169,34 @@ func (r *ESMetricsSubController) Reconcile(ctx context.Context, request reconcil
 		return reconcile.Result{}, err
 	}
 
+	variant, install, err := utils.GetInstallation(context.Background(), r.client)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
+			return reconcile.Result{}, err
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	// Remove the Elasticsearch metrics exporter if the Installation Features disable it.
+	if install.Features.ElasticsearchMetricsDisabled() {
+		hdler := utils.NewComponentHandler(reqLogger, r.client, r.scheme, logStorage)
+		if err = hdler.CreateOrUpdateOrDelete(ctx, render.NewDeletionPassthrough(esmetrics.ObjectsToDelete()...), nil); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error deleting the Elasticsearch metrics resources", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		r.resetErrorBudget()
+		r.status.OnCRNotFound()
+		return reconcile.Result{}, nil
+	}
+
 	r.status.OnCRFound()
 
+	if utils.IsReconcilePaused(&logStorage.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// Wait for the initializing controller to indicate that the LogStorage object is actionable.
 	if logStorage.Status.State != operatorv1.TigeraStatusReady {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LogStorage defaulting to occur", nil, reqLogger)
@@ -161,14 +205,14 @@ func (r *ESMetricsSubController) Reconcile(ctx context.Context, request reconcil
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
 	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", status.WithCode(operatorv1.TierNotReady, err), reqLogger)
 			return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 		} else {
 			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
@@ -183,28 +227,18 @@ func (r *ESMetricsSubController) Reconcile(ctx context.Context, request reconcil
 	} else if esMetricsSecret == nil {
 		reqLogger.Info("Waiting for elasticsearch metrics secrets to become available")
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for elasticsearch metrics secrets to become available", nil, reqLogger)
-		return reconcile.Result{}, nil
-	}
-
-	variant, install, err := utils.GetInstallation(context.Background(), r.client)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
-			return reconcile.Result{}, err
-		}
-		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, reqLogger)
-		return reconcile.Result{}, err
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(install, r.client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
 	cm, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithLogger(reqLogger))
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -222,7 +256,21 @@ func (r *ESMetricsSubController) Reconcile(ctx context.Context, request reconcil
 	} else if serverKeyPair == nil {
 		// Possibly LogStorage was removed and caused the secret to have been deleted.
 		r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for secret %s/%s to be created", render.ElasticsearchNamespace, esmetrics.ElasticsearchMetricsServerTLSSecret), nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
+	}
+
+	// Get the client keypair that ES metrics authenticates with instead of a password, if enabled. This will have
+	// previously been created by the ES secrets controller.
+	var clientKeyPair certificatemanagement.KeyPairInterface
+	if logStorage.ElasticsearchMetricsClientCertificate() {
+		clientKeyPair, err = cm.GetKeyPair(r.client, esmetrics.ElasticsearchMetricsClientTLSSecret, render.ElasticsearchNamespace, []string{esmetrics.ElasticsearchMetricsName})
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Error getting secret %s/%s", render.ElasticsearchNamespace, esmetrics.ElasticsearchMetricsClientTLSSecret), err, reqLogger)
+			return reconcile.Result{}, err
+		} else if clientKeyPair == nil {
+			r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for secret %s/%s to be created", render.ElasticsearchNamespace, esmetrics.ElasticsearchMetricsClientTLSSecret), nil, reqLogger)
+			return utils.RequeueWithBackoff(), nil
+		}
 	}
 
 	trustedBundle, err := cm.LoadTrustedBundle(ctx, r.client, render.ElasticsearchNamespace)
@@ -241,12 +289,13 @@ func (r *ESMetricsSubController) Reconcile(ctx context.Context, request reconcil
 		ESMetricsCredsSecret: esMetricsSecret,
 		ClusterDomain:        r.clusterDomain,
 		ServerTLS:            serverKeyPair,
+		ClientTLS:            clientKeyPair,
 		TrustedBundle:        trustedBundle,
 		LogStorage:           logStorage,
 	}
 	esMetricsComponent := esmetrics.ElasticsearchMetrics(esMetricsCfg)
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, esMetricsComponent); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -258,6 +307,12 @@ func (r *ESMetricsSubController) Reconcile(ctx context.Context, request reconcil
 	}
 
 	r.status.ReadyToMonitor()
+
+	// The statistics of the Elasticsearch nodes are collected on a timer, so requeue even when nothing changed.
+	if violation := r.checkErrorBudget(ctx, logStorage, reqLogger); violation != "" {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, violation, status.WithCode(operatorv1.ElasticsearchErrorBudgetExceeded, nil), reqLogger)
+		return reconcile.Result{RequeueAfter: errorBudgetInterval}, nil
+	}
 	r.status.ClearDegraded()
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: errorBudgetInterval}, nil
 }
@@ -16,17 +16,22 @@ package esmetrics
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+
 	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +45,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/testutils"
 	"github.com/tigera/operator/pkg/controller/utils"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
@@ -55,6 +61,7 @@ func NewESMetricsControllerWithShims(
 	clusterDomain string,
 	multiTenant bool,
 	readyFlag *utils.ReadyFlag,
+	esClientFn utils.ElasticsearchClientCreator,
 ) (*ESMetricsSubController, error) {
 
 	opts := options.AddOptions{
@@ -71,6 +78,7 @@ func NewESMetricsControllerWithShims(
 		clusterDomain:  opts.ClusterDomain,
 		multiTenant:    opts.MultiTenant,
 		tierWatchReady: readyFlag,
+		esClientFn:     esClientFn,
 	}
 	r.status.Run(opts.ShutdownContext)
 	return r, nil
@@ -84,6 +92,7 @@ var _ = Describe("LogStorage Linseed controller", func() {
 		ctx        context.Context
 		r          *ESMetricsSubController
 		readyFlag  *utils.ReadyFlag
+		esClient   *testutils.FakeElasticClient
 	)
 	BeforeEach(func() {
 		scheme = runtime.NewScheme()
@@ -93,6 +102,7 @@ var _ = Describe("LogStorage Linseed controller", func() {
 		Expect(rbacv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 		Expect(batchv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 		Expect(admissionv1beta1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(esv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 
 		ctx = context.Background()
 		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
@@ -112,12 +122,15 @@ var _ = Describe("LogStorage Linseed controller", func() {
 		tier := &v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera"}}
 		Expect(cli.Create(ctx, tier)).ShouldNot(HaveOccurred())
 
+		esClient = testutils.NewFakeElasticClient()
+
 		var err error
-		r, err = NewESMetricsControllerWithShims(cli, scheme, mockStatus, operatorv1.ProviderNone, dns.DefaultClusterDomain, false, readyFlag)
+		r, err = NewESMetricsControllerWithShims(cli, scheme, mockStatus, operatorv1.ProviderNone, dns.DefaultClusterDomain, false, readyFlag, esClient.Creator())
 		Expect(err).ShouldNot(HaveOccurred())
 	})
 
-	It("should reconcile resources", func() {
+	// createPrerequisites creates the Installation, secrets and trusted bundle that es-metrics needs.
+	createPrerequisites := func() {
 		err := cli.Create(ctx, &v1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      esmetrics.ElasticsearchMetricsSecret,
@@ -150,14 +163,88 @@ var _ = Describe("LogStorage Linseed controller", func() {
 
 		bundle := cm.CreateTrustedBundle(serverKeyPair)
 		Expect(cli.Create(ctx, bundle.ConfigMap(render.ElasticsearchNamespace))).ShouldNot(HaveOccurred())
+	}
+
+	It("should reconcile resources", func() {
+		createPrerequisites()
 
 		ls := &operatorv1.LogStorage{}
 		ls.Name = "tigera-secure"
 		ls.Status.State = operatorv1.TigeraStatusReady
 		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
 
-		_, err = r.Reconcile(ctx, reconcile.Request{})
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should degrade when Elasticsearch exceeds its error budget", func() {
+		createPrerequisites()
+
+		es := &esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}}
+		es.Status.Phase = esv1.ElasticsearchReadyPhase
+		Expect(cli.Create(ctx, es)).ShouldNot(HaveOccurred())
+
+		maxRejected := int32(10)
+		ls := &operatorv1.LogStorage{}
+		ls.Name = "tigera-secure"
+		ls.Spec.ErrorBudget = &operatorv1.ElasticsearchErrorBudget{MaxRejectedExecutionsPerMinute: &maxRejected}
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+
+		// Pretend that the statistics were collected a minute ago.
+		r.lastSample = &nodeStatsSample{
+			time:  time.Now().Add(-errorBudgetInterval),
+			stats: &utils.NodeStats{RejectedExecutions: map[string]int64{"search": 5}},
+		}
+		esClient.SetNodeStats(utils.NodeStats{RejectedExecutions: map[string]int64{"search": 25}})
+
+		mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, mock.Anything, mock.Anything, mock.Anything).Return()
+		result, err := r.Reconcile(ctx, reconcile.Request{})
 		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(errorBudgetInterval))
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotReady,
+			mock.MatchedBy(func(msg string) bool {
+				return strings.HasPrefix(msg, "Elasticsearch rejected 20.0 requests per minute")
+			}),
+			mock.Anything, mock.Anything)
+		mockStatus.AssertNotCalled(GinkgoT(), "ClearDegraded")
+	})
+
+	It("should delete the Elasticsearch metrics resources when the feature is disabled", func() {
+		disabled := operatorv1.FeatureDisabled
+		install := &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "default",
+			},
+			Status: operatorv1.InstallationStatus{
+				Variant:  operatorv1.TigeraSecureEnterprise,
+				Computed: &operatorv1.InstallationSpec{},
+			},
+			Spec: operatorv1.InstallationSpec{
+				Variant:  operatorv1.TigeraSecureEnterprise,
+				Features: &operatorv1.Features{ElasticsearchMetrics: &disabled},
+			},
+		}
+		Expect(cli.Create(ctx, install)).ShouldNot(HaveOccurred())
+
+		ls := &operatorv1.LogStorage{}
+		ls.Name = "tigera-secure"
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+
+		key := client.ObjectKey{Name: esmetrics.ElasticsearchMetricsName, Namespace: render.ElasticsearchNamespace}
+		Expect(cli.Create(ctx, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		})).ShouldNot(HaveOccurred())
+
+		mockStatus.On("OnCRNotFound").Return()
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "OnCRNotFound")
+		mockStatus.AssertNotCalled(GinkgoT(), "OnCRFound")
+
+		err = cli.Get(ctx, key, &appsv1.Deployment{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
 	})
 
 	It("should terminate early on managed cluster", func() {
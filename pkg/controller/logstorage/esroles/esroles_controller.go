@@ -0,0 +1,295 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package esroles
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"github.com/go-logr/logr"
+	"github.com/olivere/elastic/v7"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	"github.com/tigera/operator/pkg/telemetry"
+)
+
+var log = logf.Log.WithName("controller_logstorage_esroles")
+
+// RoleNamePrefix is the prefix of the names of the Elasticsearch roles and role mappings of the ESRoles.
+const RoleNamePrefix = "tigera-ee-esrole_"
+
+// The type and reasons of the condition that reports whether an ESRole is in sync.
+const (
+	conditionReady    = "Ready"
+	reasonSynced      = "Synced"
+	reasonInvalidSpec = "InvalidSpec"
+	reasonSyncFailed  = "SyncFailed"
+)
+
+// RoleName returns the name of the Elasticsearch role and role mapping of the ESRole with the given namespace and name.
+// Neither can contain an underscore, so the name is unique.
+func RoleName(namespace, name string) string {
+	return fmt.Sprintf("%s%s_%s", RoleNamePrefix, namespace, name)
+}
+
+// ESRoleController syncs each ESRole to an Elasticsearch role with its index access, and to a role mapping that grants
+// the role to its subjects. The roles and role mappings of deleted ESRoles are deleted.
+type ESRoleController struct {
+	client     client.Client
+	esClientFn utils.ElasticsearchClientCreator
+}
+
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		return nil
+	}
+
+	// In multi-tenant mode, the access to each tenant's Elasticsearch is managed out of band.
+	if opts.MultiTenant {
+		return nil
+	}
+
+	esClientFn, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
+	r := &ESRoleController{
+		client:     telemetry.Client(mgr.GetClient()),
+		esClientFn: esClientFn,
+	}
+
+	c, err := ctrlruntime.NewController("log-storage-esroles-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-esroles-controller", r)})
+	if err != nil {
+		return err
+	}
+
+	if err = c.WatchObject(&operatorv1.ESRole{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-esroles-controller failed to watch ESRole resource: %w", err)
+	}
+	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-esroles-controller failed to watch LogStorage resource: %w", err)
+	}
+	if err = c.WatchObject(&esv1.Elasticsearch{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-esroles-controller failed to watch Elasticsearch resource: %w", err)
+	}
+	// The prefixes of the Authentication are removed from the names of the subjects.
+	if err = c.WatchObject(&operatorv1.Authentication{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-esroles-controller failed to watch Authentication resource: %w", err)
+	}
+
+	// Sync the roles again periodically, since they are lost when the security index of Elasticsearch is.
+	if err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-esroles-controller failed to create periodic reconcile watch: %w", err)
+	}
+	return nil
+}
+
+// Reconcile syncs all the ESRoles on each request, since the roles and role mappings of deleted ESRoles can only be
+// found by comparing the role mappings in Elasticsearch with all the ESRoles.
+func (r *ESRoleController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling LogStorage - ESRoles")
+
+	ls := &operatorv1.LogStorage{}
+	if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, ls); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if common.IsReconcilePaused(ls) {
+		reqLogger.V(1).Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+		return reconcile.Result{}, nil
+	}
+
+	if ls.Status.State != operatorv1.TigeraStatusReady || !ls.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
+		return utils.RequeueWithBackoff(), nil
+	}
+
+	var usernamePrefix, groupsPrefix string
+	authentication, err := utils.GetAuthentication(ctx, r.client)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	} else if authentication != nil {
+		usernamePrefix = authentication.Spec.UsernamePrefix
+		groupsPrefix = authentication.Spec.GroupsPrefix
+	}
+
+	esRoles := &operatorv1.ESRoleList{}
+	if err = r.client.List(ctx, esRoles); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	esClient, err := r.esClientFn(r.client, ctx, relasticsearch.InternalElasticEndpoint(ls), false)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Sync each ESRole, and report the outcome in its status. An invalid ESRole is not retried until it changes.
+	var syncErr error
+	desired := map[string]bool{}
+	for i := range esRoles.Items {
+		esRole := &esRoles.Items[i]
+		if !esRole.DeletionTimestamp.IsZero() {
+			continue
+		}
+		status := esRole.Status.DeepCopy()
+		condition := metav1.Condition{Type: conditionReady, Status: metav1.ConditionTrue, Reason: reasonSynced, ObservedGeneration: esRole.Generation}
+		role, mapping, err := roleAndMapping(esRole, usernamePrefix, groupsPrefix)
+		if err != nil {
+			// The access that an earlier version of the ESRole granted is revoked, like that of a deleted ESRole.
+			status.Role = ""
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, reasonInvalidSpec, err.Error()
+		} else {
+			desired[role.Name] = true
+			status.Role = role.Name
+			if err = sync(ctx, esClient, role, mapping); err != nil {
+				reqLogger.Error(err, "Failed to sync an ESRole", "namespace", esRole.Namespace, "name", esRole.Name)
+				condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, reasonSyncFailed, err.Error()
+				syncErr = err
+			}
+		}
+		meta.SetStatusCondition(&status.Conditions, condition)
+		// The transition time of the condition only changes with its status, so an unchanged status is left alone.
+		if !reflect.DeepEqual(&esRole.Status, status) {
+			esRole.Status = *status
+			if err = r.client.Status().Update(ctx, esRole); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	if err = deleteStaleRoles(ctx, esClient, desired, reqLogger); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, syncErr
+}
+
+// roleAndMapping returns the Elasticsearch role and role mapping of the ESRole, or an error if the ESRole is invalid.
+func roleAndMapping(esRole *operatorv1.ESRole, usernamePrefix, groupsPrefix string) (utils.Role, utils.RoleMapping, error) {
+	name := RoleName(esRole.Namespace, esRole.Name)
+	if len(esRole.Spec.Subjects) == 0 {
+		return utils.Role{}, utils.RoleMapping{}, fmt.Errorf("at least one subject is required")
+	}
+	if len(esRole.Spec.Indices) == 0 {
+		return utils.Role{}, utils.RoleMapping{}, fmt.Errorf("at least one index is required")
+	}
+
+	definition := &utils.RoleDefinition{}
+	for _, index := range esRole.Spec.Indices {
+		if err := utils.ValidateLogIndexAccess(index.Names, index.Clusters); err != nil {
+			return utils.Role{}, utils.RoleMapping{}, err
+		}
+		privileges := []string{}
+		for _, privilege := range index.Privileges {
+			privileges = append(privileges, string(privilege))
+		}
+		if len(privileges) == 0 {
+			privileges = append(privileges, string(operatorv1.ESIndexPrivilegeRead))
+		}
+		definition.Indices = append(definition.Indices, utils.RoleIndex{
+			Names:      utils.LogIndexPatterns(index.Names, index.Clusters),
+			Privileges: privileges,
+		})
+	}
+
+	// The subjects are granted the role when any of them matches the user.
+	var rules []interface{}
+	for _, subject := range esRole.Spec.Subjects {
+		var field string
+		var value string
+		switch subject.Kind {
+		case operatorv1.ESRoleSubjectKindUser:
+			field, value = "username", strings.TrimPrefix(subject.Name, usernamePrefix)
+		case operatorv1.ESRoleSubjectKindGroup:
+			field, value = "groups", strings.TrimPrefix(subject.Name, groupsPrefix)
+		default:
+			return utils.Role{}, utils.RoleMapping{}, fmt.Errorf("subject %s has unsupported kind %q", subject.Name, subject.Kind)
+		}
+		if value == "" {
+			return utils.Role{}, utils.RoleMapping{}, fmt.Errorf("subject %s has an empty name without its prefix", subject.Name)
+		}
+		rules = append(rules, map[string]interface{}{"field": map[string]interface{}{field: value}})
+	}
+
+	role := utils.Role{Name: name, Definition: definition}
+	mapping := utils.RoleMapping{
+		Name:    name,
+		Enabled: true,
+		Roles:   []string{name},
+		Rules:   map[string]interface{}{"any": rules},
+	}
+	return role, mapping, nil
+}
+
+// sync creates or updates the role before the role mapping that grants it.
+func sync(ctx context.Context, esClient utils.ElasticClient, role utils.Role, mapping utils.RoleMapping) error {
+	if err := esClient.CreateRoles(ctx, role); err != nil {
+		return fmt.Errorf("failed to create the Elasticsearch role %s: %w", role.Name, err)
+	}
+	if err := esClient.PutRoleMapping(ctx, mapping); err != nil {
+		return fmt.Errorf("failed to create the Elasticsearch role mapping %s: %w", mapping.Name, err)
+	}
+	return nil
+}
+
+// deleteStaleRoles deletes the roles and role mappings of the ESRoles that no longer exist. The role is deleted first,
+// so that its role mapping, which is what finds it, is only deleted once the role is gone.
+func deleteStaleRoles(ctx context.Context, esClient utils.ElasticClient, desired map[string]bool, reqLogger logr.Logger) error {
+	mappings, err := esClient.GetRoleMappings(ctx)
+	if err != nil {
+		return err
+	}
+	for _, mapping := range mappings {
+		if !strings.HasPrefix(mapping.Name, RoleNamePrefix) || desired[mapping.Name] {
+			continue
+		}
+		reqLogger.Info("Deleting the Elasticsearch role of a deleted ESRole", "role", mapping.Name)
+		if err = esClient.DeleteRoles(ctx, []utils.Role{{Name: mapping.Name}}); err != nil && !elastic.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the Elasticsearch role %s: %w", mapping.Name, err)
+		}
+		if err = esClient.DeleteRoleMapping(ctx, mapping.Name); err != nil {
+			return fmt.Errorf("failed to delete the Elasticsearch role mapping %s: %w", mapping.Name, err)
+		}
+	}
+	return nil
+}
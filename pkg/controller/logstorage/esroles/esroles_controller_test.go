@@ -0,0 +1,196 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package esroles
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/testutils"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("LogStorage ESRole controller", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		esClient *testutils.FakeElasticClient
+		r        *ESRoleController
+	)
+
+	newESRole := func(namespace, name string, spec operatorv1.ESRoleSpec) *operatorv1.ESRole {
+		esRole := &operatorv1.ESRole{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Spec: spec}
+		Expect(cli.Create(ctx, esRole)).ShouldNot(HaveOccurred())
+		return esRole
+	}
+
+	getESRole := func(namespace, name string) *operatorv1.ESRole {
+		esRole := &operatorv1.ESRole{}
+		Expect(cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, esRole)).ShouldNot(HaveOccurred())
+		return esRole
+	}
+
+	flowsAccess := operatorv1.ESRoleSpec{
+		Subjects: []operatorv1.ESRoleSubject{{Kind: operatorv1.ESRoleSubjectKindGroup, Name: "platform"}},
+		Indices:  []operatorv1.ESRoleIndex{{Names: []string{"tigera_secure_ee_flows"}}},
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(esv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		ls := &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		es := &esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}}
+		es.Status.Phase = esv1.ElasticsearchReadyPhase
+		Expect(cli.Create(ctx, es)).ShouldNot(HaveOccurred())
+
+		esClient = testutils.NewFakeElasticClient()
+		r = &ESRoleController{client: cli, esClientFn: esClient.Creator()}
+	})
+
+	It("should sync an ESRole to a role and a role mapping", func() {
+		Expect(cli.Create(ctx, &operatorv1.Authentication{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec:       operatorv1.AuthenticationSpec{UsernamePrefix: "oidc:", GroupsPrefix: "oidc-groups:"},
+		})).ShouldNot(HaveOccurred())
+		newESRole("team-a", "logs", operatorv1.ESRoleSpec{
+			Subjects: []operatorv1.ESRoleSubject{
+				{Kind: operatorv1.ESRoleSubjectKindUser, Name: "oidc:alice@example.com"},
+				{Kind: operatorv1.ESRoleSubjectKindGroup, Name: "oidc-groups:platform"},
+			},
+			Indices: []operatorv1.ESRoleIndex{
+				{Names: []string{"tigera_secure_ee_flows", "tigera_secure_ee_dns"}, Clusters: []string{"cluster"}},
+				{
+					Names:      []string{"tigera_secure_ee_audit_*"},
+					Privileges: []operatorv1.ESIndexPrivilege{operatorv1.ESIndexPrivilegeRead, operatorv1.ESIndexPrivilegeViewIndexMetadata},
+				},
+			},
+		})
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		name := "tigera-ee-esrole_team-a_logs"
+		role, ok := esClient.Role(name)
+		Expect(ok).To(BeTrue())
+		Expect(role.Definition.Indices).To(Equal([]utils.RoleIndex{
+			{Names: []string{"tigera_secure_ee_flows.cluster.*", "tigera_secure_ee_dns.cluster.*"}, Privileges: []string{"read"}},
+			{Names: []string{"tigera_secure_ee_audit_*.*.*"}, Privileges: []string{"read", "view_index_metadata"}},
+		}))
+
+		mapping, ok := esClient.RoleMapping(name)
+		Expect(ok).To(BeTrue())
+		Expect(mapping.Enabled).To(BeTrue())
+		Expect(mapping.Roles).To(Equal([]string{name}))
+		Expect(mapping.Rules).To(Equal(map[string]interface{}{"any": []interface{}{
+			map[string]interface{}{"field": map[string]interface{}{"username": "alice@example.com"}},
+			map[string]interface{}{"field": map[string]interface{}{"groups": "platform"}},
+		}}))
+
+		esRole := getESRole("team-a", "logs")
+		Expect(esRole.Status.Role).To(Equal(name))
+		Expect(meta.IsStatusConditionTrue(esRole.Status.Conditions, "Ready")).To(BeTrue())
+
+		// The status is left alone while the ESRole stays in sync.
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getESRole("team-a", "logs").ResourceVersion).To(Equal(esRole.ResourceVersion))
+	})
+
+	It("should report an invalid ESRole and revoke its access", func() {
+		esRole := newESRole("team-a", "logs", flowsAccess)
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		esRole = getESRole("team-a", "logs")
+		esRole.Spec.Indices = []operatorv1.ESRoleIndex{{Names: []string{".security"}}}
+		Expect(cli.Update(ctx, esRole)).ShouldNot(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, ok := esClient.Role("tigera-ee-esrole_team-a_logs")
+		Expect(ok).To(BeFalse())
+		_, ok = esClient.RoleMapping("tigera-ee-esrole_team-a_logs")
+		Expect(ok).To(BeFalse())
+
+		esRole = getESRole("team-a", "logs")
+		Expect(esRole.Status.Role).To(BeEmpty())
+		condition := meta.FindStatusCondition(esRole.Status.Conditions, "Ready")
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("InvalidSpec"))
+		Expect(condition.Message).To(ContainSubstring(".security"))
+	})
+
+	It("should delete the role and role mapping of a deleted ESRole", func() {
+		esRole := newESRole("team-a", "logs", flowsAccess)
+		newESRole("team-b", "logs", flowsAccess)
+		Expect(esClient.PutRoleMapping(ctx, utils.RoleMapping{Name: "other", Enabled: true, Roles: []string{"other"}})).ShouldNot(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(cli.Delete(ctx, esRole)).ShouldNot(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, ok := esClient.Role("tigera-ee-esrole_team-a_logs")
+		Expect(ok).To(BeFalse())
+		_, ok = esClient.RoleMapping("tigera-ee-esrole_team-a_logs")
+		Expect(ok).To(BeFalse())
+		_, ok = esClient.RoleMapping("tigera-ee-esrole_team-b_logs")
+		Expect(ok).To(BeTrue())
+		_, ok = esClient.RoleMapping("other")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should report a failure to sync and retry", func() {
+		newESRole("team-a", "logs", flowsAccess)
+		esClient.InjectError(testutils.MethodPutRoleMapping, fmt.Errorf("unavailable"))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).Should(HaveOccurred())
+		condition := meta.FindStatusCondition(getESRole("team-a", "logs").Status.Conditions, "Ready")
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("SyncFailed"))
+
+		esClient.ClearErrors()
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(meta.IsStatusConditionTrue(getESRole("team-a", "logs").Status.Conditions, "Ready")).To(BeTrue())
+	})
+})
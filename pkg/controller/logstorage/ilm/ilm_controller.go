@@ -0,0 +1,190 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/render"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/telemetry"
+)
+
+var log = logf.Log.WithName("controller_logstorage_ilm")
+
+// ILMController applies the ILM policies of the log indices to the Elasticsearch cluster that the operator provisions.
+// Unlike the elastic controller, which reconciles the Kubernetes objects of Elasticsearch and Kibana on every change to
+// any of them, it only reconciles when the inputs of the policies change: the retention and storage of the LogStorage,
+// the retention periods in effect under the retention guardrail, the Elasticsearch cluster becoming operational, and
+// the credentials the operator uses to reach it.
+type ILMController struct {
+	client     client.Client
+	esClientFn utils.ElasticsearchClientCreator
+	recorder   record.EventRecorder
+
+	// applied is the hash of the ILM policies that were last applied, and of the Elasticsearch cluster they were applied
+	// to, so that the policies are only applied again when either changes.
+	applied string
+}
+
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		return nil
+	}
+
+	// In multi-tenant mode, ILM programming is handled out of band, and the operator doesn't program ILM for an external
+	// Elasticsearch cluster.
+	if opts.MultiTenant || opts.ElasticExternal {
+		return nil
+	}
+
+	esClientFn, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
+	r := &ILMController{
+		client:     telemetry.Client(mgr.GetClient()),
+		esClientFn: esClientFn,
+		recorder:   mgr.GetEventRecorderFor("log-storage-ilm-controller"),
+	}
+
+	c, err := ctrlruntime.NewController("log-storage-ilm-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-ilm-controller", r)})
+	if err != nil {
+		return err
+	}
+
+	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return policyInputsChanged(e.ObjectOld.(*operatorv1.LogStorage), e.ObjectNew.(*operatorv1.LogStorage))
+		},
+	}); err != nil {
+		return fmt.Errorf("log-storage-ilm-controller failed to watch LogStorage resource: %w", err)
+	}
+	if err = c.WatchObject(&esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: render.ElasticsearchNamespace, Name: render.ElasticsearchName},
+	}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.(*esv1.Elasticsearch).Status.Phase != e.ObjectNew.(*esv1.Elasticsearch).Status.Phase
+		},
+	}); err != nil {
+		return fmt.Errorf("log-storage-ilm-controller failed to watch Elasticsearch resource: %w", err)
+	}
+	if err = utils.AddSecretsWatch(c, render.ElasticsearchAdminUserSecret, common.OperatorNamespace()); err != nil {
+		return fmt.Errorf("log-storage-ilm-controller failed to watch Secret resource: %w", err)
+	}
+	return nil
+}
+
+func (r *ILMController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling LogStorage - ILM")
+
+	ls := &operatorv1.LogStorage{}
+	if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, ls); err != nil {
+		if errors.IsNotFound(err) {
+			r.applied = ""
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if common.IsReconcilePaused(ls) {
+		reqLogger.V(1).Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+		return reconcile.Result{}, nil
+	}
+
+	if ls.Status.State != operatorv1.TigeraStatusReady || !ls.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
+		// The policies are applied when the Elasticsearch cluster becomes operational.
+		reqLogger.V(1).Info("Waiting for Elasticsearch cluster to be operational before applying the ILM policies")
+		return reconcile.Result{}, nil
+	}
+
+	effective := effectiveLogStorage(ls)
+	applied := rmeta.AnnotationHash([]interface{}{elasticsearch.UID, utils.ILMPolicies(effective)})
+	if applied == r.applied {
+		reqLogger.V(1).Info("ILM policies are unchanged since they were last applied")
+		return reconcile.Result{}, nil
+	}
+
+	esClient, err := r.esClientFn(r.client, ctx, relasticsearch.InternalElasticEndpoint(ls), false)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err = esClient.SetILMPolicies(ctx, effective); err != nil {
+		r.recorder.Eventf(ls, corev1.EventTypeWarning, "ILMPoliciesFailed", "Failed to apply the ILM policies: %v", err)
+		return reconcile.Result{}, fmt.Errorf("failed to apply the ILM policies: %w", err)
+	}
+	r.applied = applied
+	reqLogger.Info("Applied the ILM policies")
+	return reconcile.Result{}, nil
+}
+
+// effectiveLogStorage returns the LogStorage with the retention periods in effect, which the retention guardrail may
+// have shortened.
+func effectiveLogStorage(ls *operatorv1.LogStorage) *operatorv1.LogStorage {
+	if ls.Status.RetentionGuardrail == nil {
+		return ls
+	}
+	ls = ls.DeepCopy()
+	ls.Spec.Retention = ls.Status.RetentionGuardrail.Retention.DeepCopy()
+	return ls
+}
+
+// policyInputsChanged returns true if the update of the LogStorage changes the inputs of the ILM policies, or whether
+// they can be applied. The policies themselves aren't compared, since they can only be built once the initializing
+// controller has filled in the defaults of the LogStorage.
+func policyInputsChanged(old, new *operatorv1.LogStorage) bool {
+	if old.Status.State != new.Status.State || old.DeletionTimestamp.IsZero() != new.DeletionTimestamp.IsZero() {
+		return true
+	}
+	return !reflect.DeepEqual(policyInputs(old), policyInputs(new))
+}
+
+// policyInputs returns the fields of the LogStorage that the ILM policies are built from.
+func policyInputs(ls *operatorv1.LogStorage) []interface{} {
+	ls = effectiveLogStorage(ls)
+	return []interface{}{ls.Spec.Retention, ls.Spec.Nodes, ls.ESGatewayAuditLogSink()}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilm
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/logstorage/initializer"
+	"github.com/tigera/operator/pkg/controller/testutils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("LogStorage ILM controller", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		esClient *testutils.FakeElasticClient
+		recorder *record.FakeRecorder
+		ls       *operatorv1.LogStorage
+		es       *esv1.Elasticsearch
+		r        *ILMController
+	)
+
+	days := func(d int32) *int32 { return &d }
+
+	// flowsDeleteAge returns the age at which the ILM policy of the flow logs deletes their indices.
+	flowsDeleteAge := func() interface{} {
+		policy := esClient.ILMPolicies()["tigera_secure_ee_flows_policy"]
+		phases := policy["policy"].(map[string]interface{})["phases"].(map[string]interface{})
+		return phases["delete"].(map[string]interface{})["min_age"]
+	}
+
+	// reconcileApplies reconciles, and returns whether the ILM policies were applied. The fake client fails to apply
+	// them, so that a reconcile that applies them is told apart from one that skips them.
+	reconcileApplies := func() bool {
+		esClient.InjectError(testutils.MethodSetILMPolicies, fmt.Errorf("applied"))
+		defer esClient.InjectError(testutils.MethodSetILMPolicies, nil)
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		return err != nil
+	}
+
+	update := func(mutate func(ls *operatorv1.LogStorage)) {
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ls), ls)).ShouldNot(HaveOccurred())
+		mutate(ls)
+		Expect(cli.Update(ctx, ls)).ShouldNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(esv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		ls = &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec: operatorv1.LogStorageSpec{
+				Nodes:     &operatorv1.Nodes{Count: 1},
+				Retention: &operatorv1.Retention{Flows: days(8)},
+			},
+		}
+		initializer.FillDefaults(ls)
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		es = &esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}}
+		es.Status.Phase = esv1.ElasticsearchReadyPhase
+		Expect(cli.Create(ctx, es)).ShouldNot(HaveOccurred())
+
+		esClient = testutils.NewFakeElasticClient()
+		recorder = record.NewFakeRecorder(10)
+		r = &ILMController{client: cli, esClientFn: esClient.Creator(), recorder: recorder}
+	})
+
+	It("should apply the ILM policies once, and again when the retention changes", func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(flowsDeleteAge()).To(Equal("8d"))
+
+		Expect(reconcileApplies()).To(BeFalse())
+
+		By("applying the policies again when the retention changes")
+		update(func(ls *operatorv1.LogStorage) { ls.Spec.Retention.Flows = days(5) })
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(flowsDeleteAge()).To(Equal("5d"))
+		Expect(reconcileApplies()).To(BeFalse())
+	})
+
+	It("should apply the retention periods in effect under the retention guardrail", func() {
+		retention := ls.Spec.Retention.DeepCopy()
+		retention.Flows = days(7)
+		ls.Status.RetentionGuardrail = &operatorv1.RetentionGuardrailStatus{Retention: *retention}
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(flowsDeleteAge()).To(Equal("7d"))
+	})
+
+	It("should wait for the LogStorage and Elasticsearch to be ready", func() {
+		es.Status.Phase = esv1.ElasticsearchApplyingChangesPhase
+		Expect(cli.Update(ctx, es)).ShouldNot(HaveOccurred())
+		Expect(reconcileApplies()).To(BeFalse())
+
+		es.Status.Phase = esv1.ElasticsearchReadyPhase
+		Expect(cli.Update(ctx, es)).ShouldNot(HaveOccurred())
+		ls.Status.State = operatorv1.TigeraStatusDegraded
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+		Expect(reconcileApplies()).To(BeFalse())
+
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+		Expect(reconcileApplies()).To(BeTrue())
+	})
+
+	It("should record an event and retry when the ILM policies can't be applied", func() {
+		esClient.InjectError(testutils.MethodSetILMPolicies, fmt.Errorf("connection refused"))
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).To(MatchError(ContainSubstring("connection refused")))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Failed to apply the ILM policies: connection refused")))
+
+		esClient.InjectError(testutils.MethodSetILMPolicies, nil)
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(flowsDeleteAge()).To(Equal("8d"))
+	})
+
+	It("should only reconcile the LogStorage updates that change the ILM policies", func() {
+		updated := ls.DeepCopy()
+		updated.Spec.ComponentResources = []operatorv1.LogStorageComponentResource{{ComponentName: operatorv1.ComponentNameECKOperator}}
+		updated.Status.Usage = &operatorv1.LogStorageUsage{}
+		Expect(policyInputsChanged(ls, updated)).To(BeFalse())
+
+		updated.Spec.Retention = &operatorv1.Retention{Flows: days(3)}
+		Expect(policyInputsChanged(ls, updated)).To(BeTrue())
+
+		updated = ls.DeepCopy()
+		updated.Status.RetentionGuardrail = &operatorv1.RetentionGuardrailStatus{Retention: operatorv1.Retention{Flows: days(2)}}
+		Expect(policyInputsChanged(ls, updated)).To(BeTrue())
+
+		updated = ls.DeepCopy()
+		updated.Status.State = operatorv1.TigeraStatusDegraded
+		Expect(policyInputsChanged(ls, updated)).To(BeTrue())
+	})
+})
@@ -32,6 +32,7 @@ import (
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/telemetry"
 )
 
 func AddConditionsController(mgr manager.Manager, opts options.AddOptions) error {
@@ -41,7 +42,7 @@ func AddConditionsController(mgr manager.Manager, opts options.AddOptions) error
 
 	// Create the reconciler
 	r := &LogStorageConditions{
-		client:      mgr.GetClient(),
+		client:      telemetry.Client(mgr.GetClient()),
 		scheme:      mgr.GetScheme(),
 		multiTenant: opts.MultiTenant,
 	}
@@ -51,7 +52,7 @@ func AddConditionsController(mgr manager.Manager, opts options.AddOptions) error
 		Watches(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}).
 		Watches(&operatorv1.TigeraStatus{}, &handler.EnqueueRequestForObject{}).
 		For(&operatorv1.LogStorage{}).
-		Complete(r)
+		Complete(telemetry.Reconciler("log-storage-conditions-controller", r))
 }
 
 var _ reconcile.Reconciler = &LogStorageConditions{}
@@ -125,6 +126,14 @@ func (r *LogStorageConditions) getDesiredConditions(ctx context.Context) (map[st
 	// We also keep track of the oldest observed generation here so we can add it to the conditions later.
 	var observedGeneration int64
 
+	// Backpressure on the log ingestion is reported by Linseed, and surfaced as its own condition so that it can be told
+	// apart from other reasons for the LogStorage to be degraded.
+	var backpressure *operatorv1.TigeraStatusCondition
+
+	// Likewise, a major version upgrade of Elasticsearch that is held back because the new version doesn't support the
+	// constructs that the operator manages is reported by the elastic controller, and surfaced as its own condition.
+	var incompatible *operatorv1.TigeraStatusCondition
+
 	// Build up the lists of which components are in which state.
 	for _, instance := range expectedInstances {
 		ts := &operatorv1.TigeraStatus{}
@@ -141,6 +150,14 @@ func (r *LogStorageConditions) getDesiredConditions(ctx context.Context) (map[st
 			if condition.Status == operatorv1.ConditionTrue {
 				states[string(condition.Type)] = append(states[string(condition.Type)], instance)
 			}
+			if instance == TigeraStatusLogStorageAccess && condition.Type == operatorv1.ComponentDegraded &&
+				condition.Status == operatorv1.ConditionTrue && condition.Code == string(operatorv1.LinseedIngestionBackpressure) {
+				backpressure = condition.DeepCopy()
+			}
+			if instance == TigeraStatusLogStorageElastic && condition.Type == operatorv1.ComponentDegraded &&
+				condition.Status == operatorv1.ConditionTrue && condition.Code == string(operatorv1.ElasticsearchIncompatible) {
+				incompatible = condition.DeepCopy()
+			}
 			if observedGeneration == 0 || condition.ObservedGeneration < observedGeneration {
 				observedGeneration = condition.ObservedGeneration
 			}
@@ -180,6 +197,27 @@ func (r *LogStorageConditions) getDesiredConditions(ctx context.Context) (map[st
 		// Store the condition.
 		conditions[statusType] = condition
 	}
+
+	// The condition is only present while there is backpressure, so that it does not change the conditions of clusters
+	// that never experience it.
+	if backpressure != nil {
+		conditions[operatorv1.LogStorageIngestionBackpressure] = metav1.Condition{
+			Type:               operatorv1.LogStorageIngestionBackpressure,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: observedGeneration,
+			Reason:             string(operatorv1.LinseedIngestionBackpressure),
+			Message:            backpressure.Message,
+		}
+	}
+	if incompatible != nil {
+		conditions[operatorv1.LogStorageElasticsearchIncompatible] = metav1.Condition{
+			Type:               operatorv1.LogStorageElasticsearchIncompatible,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: observedGeneration,
+			Reason:             string(operatorv1.ElasticsearchIncompatible),
+			Message:            incompatible.Message,
+		}
+	}
 	return conditions, nil
 }
 
@@ -304,6 +304,106 @@ var _ = Describe("LogStorage Conditions controller", func() {
 
 	})
 
+	It("should surface backpressure on the log ingestion as its own condition", func() {
+		lsControllers := append(subControllers, TigeraStatusLogStorageESMetrics, TigeraStatusLogStorageKubeController, TigeraStatusLogStorageDashboards)
+		for _, ls := range lsControllers {
+			createTigeraStatus(cli, ctx, ls, generation, []operatorv1.TigeraStatusCondition{})
+		}
+		CreateLogStorage(cli, &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec:       operatorv1.LogStorageSpec{Nodes: &operatorv1.Nodes{Count: int64(1)}},
+			Status:     operatorv1.LogStorageStatus{State: operatorv1.TigeraStatusReady},
+		})
+		r, err := NewTestConditionController(cli, scheme, dns.DefaultClusterDomain)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		setAccessDegraded := func(condition operatorv1.TigeraStatusCondition) {
+			ts := &operatorv1.TigeraStatus{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: TigeraStatusLogStorageAccess}, ts)).ShouldNot(HaveOccurred())
+			ts.Status.Conditions[2] = condition
+			Expect(cli.Status().Update(ctx, ts)).NotTo(HaveOccurred())
+		}
+		reconcileConditions := func() map[string]metav1.Condition {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			instance := &operatorv1.LogStorage{}
+			Expect(cli.Get(ctx, types.NamespacedName{Name: "tigera-secure"}, instance)).ShouldNot(HaveOccurred())
+			return getCurrentConditions(instance.Status.Conditions)
+		}
+
+		message := "The log ingestion is backpressured by Elasticsearch for 5m0s: 20000 documents wait to be written to Elasticsearch, more than the maximum of 10000"
+		setAccessDegraded(operatorv1.TigeraStatusCondition{
+			Type:               operatorv1.ComponentDegraded,
+			Status:             operatorv1.ConditionTrue,
+			Reason:             string(operatorv1.ResourceNotReady),
+			Message:            message,
+			Code:               string(operatorv1.LinseedIngestionBackpressure),
+			ObservedGeneration: generation,
+		})
+		conditions := reconcileConditions()
+		Expect(conditions).To(HaveLen(4))
+		Expect(string(conditions["Degraded"].Status)).To(Equal(string(operatorv1.ConditionTrue)))
+		backpressure, ok := conditions[operatorv1.LogStorageIngestionBackpressure]
+		Expect(ok).To(BeTrue())
+		Expect(backpressure.Status).To(Equal(metav1.ConditionTrue))
+		Expect(backpressure.Reason).To(Equal(string(operatorv1.LinseedIngestionBackpressure)))
+		Expect(backpressure.Message).To(Equal(message))
+		Expect(backpressure.ObservedGeneration).To(Equal(generation))
+
+		By("not reporting backpressure when Linseed is degraded for another reason")
+		setAccessDegraded(operatorv1.TigeraStatusCondition{
+			Type:               operatorv1.ComponentDegraded,
+			Status:             operatorv1.ConditionTrue,
+			Reason:             string(operatorv1.ResourceNotFound),
+			Message:            "Waiting for Linseed credential Secret",
+			ObservedGeneration: generation,
+		})
+		conditions = reconcileConditions()
+		Expect(conditions).To(HaveLen(3))
+		Expect(string(conditions["Degraded"].Status)).To(Equal(string(operatorv1.ConditionTrue)))
+		Expect(conditions).NotTo(HaveKey(operatorv1.LogStorageIngestionBackpressure))
+	})
+
+	It("should surface an Elasticsearch upgrade that is incompatible with the operator as its own condition", func() {
+		lsControllers := append(subControllers, TigeraStatusLogStorageESMetrics, TigeraStatusLogStorageKubeController, TigeraStatusLogStorageDashboards)
+		for _, ls := range lsControllers {
+			createTigeraStatus(cli, ctx, ls, generation, []operatorv1.TigeraStatusCondition{})
+		}
+		CreateLogStorage(cli, &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec:       operatorv1.LogStorageSpec{Nodes: &operatorv1.Nodes{Count: int64(1)}},
+			Status:     operatorv1.LogStorageStatus{State: operatorv1.TigeraStatusReady},
+		})
+		r, err := NewTestConditionController(cli, scheme, dns.DefaultClusterDomain)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		message := "Upgrading Elasticsearch to 8.13.0 is held back, since it is incompatible with the operator: the ILM action freeze was removed in Elasticsearch 8.0.0; it is used by tigera_secure_ee_flows_policy"
+		ts := &operatorv1.TigeraStatus{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: TigeraStatusLogStorageElastic}, ts)).ShouldNot(HaveOccurred())
+		ts.Status.Conditions[2] = operatorv1.TigeraStatusCondition{
+			Type:               operatorv1.ComponentDegraded,
+			Status:             operatorv1.ConditionTrue,
+			Reason:             string(operatorv1.UpgradeError),
+			Message:            message,
+			Code:               string(operatorv1.ElasticsearchIncompatible),
+			ObservedGeneration: generation,
+		}
+		Expect(cli.Status().Update(ctx, ts)).NotTo(HaveOccurred())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		instance := &operatorv1.LogStorage{}
+		Expect(cli.Get(ctx, types.NamespacedName{Name: "tigera-secure"}, instance)).ShouldNot(HaveOccurred())
+		conditions := getCurrentConditions(instance.Status.Conditions)
+		Expect(conditions).To(HaveLen(4))
+		Expect(string(conditions["Degraded"].Status)).To(Equal(string(operatorv1.ConditionTrue)))
+		incompatible, ok := conditions[operatorv1.LogStorageElasticsearchIncompatible]
+		Expect(ok).To(BeTrue())
+		Expect(incompatible.Status).To(Equal(metav1.ConditionTrue))
+		Expect(incompatible.Reason).To(Equal(string(operatorv1.ElasticsearchIncompatible)))
+		Expect(incompatible.Message).To(Equal(message))
+	})
+
 	It("should reconcile with all log-storage-* tigerastatus conditions as Available and later move to degraded", func() {
 		subControllers = append(subControllers, TigeraStatusLogStorageUsers)
 		for _, ls := range subControllers {
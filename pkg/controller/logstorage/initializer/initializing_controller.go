@@ -16,9 +16,17 @@ package initializer
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	gv "github.com/hashicorp/go-version"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -36,8 +44,10 @@ import (
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/dns"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/logstorage/kibana"
+	"github.com/tigera/operator/pkg/telemetry"
 )
 
 var log = logf.Log.WithName("controller_logstorage")
@@ -64,7 +74,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 	// Create the reconciler
 	r := &LogStorageInitializer{
-		client:      mgr.GetClient(),
+		client:      telemetry.Client(mgr.GetClient()),
 		scheme:      mgr.GetScheme(),
 		multiTenant: opts.MultiTenant,
 		status:      status.New(mgr.GetClient(), TigeraStatusName, opts.KubernetesVersion),
@@ -72,7 +82,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	r.status.Run(opts.ShutdownContext)
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("log-storage-initializing-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-initializing-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-initializing-controller", r)})
 	if err != nil {
 		return err
 	}
@@ -164,6 +174,16 @@ func FillDefaults(opr *operatorv1.LogStorage) {
 			},
 		}
 	}
+
+	if opr.Spec.NonClusterHosts != nil && opr.Spec.NonClusterHosts.ServiceType == nil {
+		serviceType := corev1.ServiceTypeLoadBalancer
+		opr.Spec.NonClusterHosts.ServiceType = &serviceType
+	}
+
+	if opr.Spec.SecondaryElasticsearch != nil && opr.Spec.SecondaryElasticsearch.Mode == nil {
+		mode := operatorv1.SecondaryElasticsearchModeDualWrite
+		opr.Spec.SecondaryElasticsearch.Mode = &mode
+	}
 }
 
 func validateComponentResources(spec *operatorv1.LogStorageSpec) error {
@@ -182,6 +202,324 @@ func validateComponentResources(spec *operatorv1.LogStorageSpec) error {
 	return nil
 }
 
+func validateECKOperator(spec *operatorv1.LogStorageSpec) error {
+	if spec.ECKOperator == nil || spec.ECKOperator.Version == "" {
+		return nil
+	}
+	if mode := spec.ECKOperator.Mode; mode != nil && *mode == operatorv1.ECKOperatorModeUserManaged {
+		return fmt.Errorf("LogStorage spec.ECKOperator.Version cannot be set when spec.ECKOperator.Mode is %s", operatorv1.ECKOperatorModeUserManaged)
+	}
+	if _, err := gv.NewVersion(spec.ECKOperator.Version); err != nil {
+		return fmt.Errorf("LogStorage spec.ECKOperator.Version %s is not a valid version", spec.ECKOperator.Version)
+	}
+	if err := utils.ValidateECKOperatorVersion(spec.ECKOperator.Version); err != nil {
+		return fmt.Errorf("LogStorage spec.ECKOperator.Version is invalid: %w", err)
+	}
+	return nil
+}
+
+func validateElasticsearchEndpoint(spec *operatorv1.LogStorageSpec) error {
+	if spec.ElasticsearchEndpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(spec.ElasticsearchEndpoint)
+	if err != nil {
+		return fmt.Errorf("LogStorage spec.ElasticsearchEndpoint is invalid: %w", err)
+	}
+	// The host and port are rendered into the components separately, so both must be present.
+	if u.Scheme != "https" || u.Hostname() == "" || u.Port() == "" {
+		return fmt.Errorf("LogStorage spec.ElasticsearchEndpoint %s must be an https URL with a host and port", spec.ElasticsearchEndpoint)
+	}
+	return nil
+}
+
+func validateNonClusterHosts(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	if spec.NonClusterHosts == nil {
+		return nil
+	}
+	// Non-cluster hosts send their logs through the Elasticsearch gateway, which is not installed for multi-tenant clusters.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.NonClusterHosts is not supported for multi-tenant clusters")
+	}
+	for _, cidr := range spec.NonClusterHosts.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("LogStorage spec.NonClusterHosts.SourceCIDRs contains an invalid CIDR %s", cidr)
+		}
+	}
+	return nil
+}
+
+func validateESGatewayTenancy(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	if spec.ESGatewayTenancy == nil {
+		return nil
+	}
+	// The Elasticsearch gateway is not installed for multi-tenant clusters, where Linseed validates the tenant instead.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.ESGatewayTenancy is not supported for multi-tenant clusters")
+	}
+	if spec.ESGatewayTenancy.ExpectedTenantID == "" {
+		return fmt.Errorf("LogStorage spec.ESGatewayTenancy.ExpectedTenantID must be set")
+	}
+	return nil
+}
+
+func validateSecondaryElasticsearch(ls *operatorv1.LogStorage, multiTenant bool) error {
+	secondary := ls.Spec.SecondaryElasticsearch
+	if secondary == nil {
+		return nil
+	}
+	// In multi-tenant mode every tenant has its own Elasticsearch, so there is no single cluster to copy the data from.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.SecondaryElasticsearch is not supported for multi-tenant clusters")
+	}
+	u, err := url.Parse(secondary.Endpoint)
+	if err != nil {
+		return fmt.Errorf("LogStorage spec.SecondaryElasticsearch.Endpoint is invalid: %w", err)
+	}
+	if u.Scheme != "https" || u.Hostname() == "" || u.Port() == "" {
+		return fmt.Errorf("LogStorage spec.SecondaryElasticsearch.Endpoint %s must be an https URL with a host and port", secondary.Endpoint)
+	}
+
+	switch ls.SecondaryElasticsearchMode() {
+	case operatorv1.SecondaryElasticsearchModeCrossClusterReplication:
+		if secondary.RemoteClusterAddress == "" {
+			return fmt.Errorf("LogStorage spec.SecondaryElasticsearch.RemoteClusterAddress must be set in %s mode", operatorv1.SecondaryElasticsearchModeCrossClusterReplication)
+		}
+		if _, _, err := net.SplitHostPort(secondary.RemoteClusterAddress); err != nil {
+			return fmt.Errorf("LogStorage spec.SecondaryElasticsearch.RemoteClusterAddress %s must be a host:port address", secondary.RemoteClusterAddress)
+		}
+	default:
+		if secondary.RemoteClusterAddress != "" {
+			return fmt.Errorf("LogStorage spec.SecondaryElasticsearch.RemoteClusterAddress can only be set in %s mode", operatorv1.SecondaryElasticsearchModeCrossClusterReplication)
+		}
+	}
+	return nil
+}
+
+func validateRemoteClusters(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	if len(spec.RemoteClusters) == 0 {
+		return nil
+	}
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.RemoteClusters is not supported for multi-tenant clusters")
+	}
+	names := map[string]bool{}
+	for _, remote := range spec.RemoteClusters {
+		if names[remote.Name] {
+			return fmt.Errorf("LogStorage spec.RemoteClusters contains remote cluster %s more than once", remote.Name)
+		}
+		names[remote.Name] = true
+
+		if (len(remote.Seeds) == 0) == (remote.ProxyAddress == "") {
+			return fmt.Errorf("LogStorage spec.RemoteClusters %s must set exactly one of seeds and proxyAddress", remote.Name)
+		}
+		addresses := remote.Seeds
+		if remote.ProxyAddress != "" {
+			addresses = []string{remote.ProxyAddress}
+		}
+		for _, address := range addresses {
+			// The addresses are also rendered into the network policy of Elasticsearch, so the port must be numeric.
+			_, port, err := net.SplitHostPort(address)
+			if err == nil {
+				_, err = strconv.ParseUint(port, 10, 16)
+			}
+			if err != nil {
+				return fmt.Errorf("LogStorage spec.RemoteClusters %s address %s must be a host:port address", remote.Name, address)
+			}
+		}
+	}
+	return nil
+}
+
+func validateReadOnlyUsers(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	if len(spec.ReadOnlyUsers) == 0 {
+		return nil
+	}
+	// In multi-tenant mode the users of each tenant's Elasticsearch are managed out of band.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.ReadOnlyUsers is not supported for multi-tenant clusters")
+	}
+	names := map[string]bool{}
+	for _, user := range spec.ReadOnlyUsers {
+		if names[user.Name] {
+			return fmt.Errorf("LogStorage spec.ReadOnlyUsers contains user %s more than once", user.Name)
+		}
+		names[user.Name] = true
+
+		if err := utils.ValidateLogIndexAccess(user.Indices, user.Clusters); err != nil {
+			return fmt.Errorf("LogStorage spec.ReadOnlyUsers %s: %w", user.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateCuratedIndexPrefixes(spec *operatorv1.LogStorageSpec) error {
+	// The setting can only narrow down the indices that are curated, so that a shared cluster can't have the indices
+	// of other applications deleted by the ILM policies of the operator.
+	for _, prefix := range spec.CuratedIndexPrefixes {
+		if !strings.HasPrefix(prefix, operatorv1.DefaultCuratedIndexPrefix) {
+			return fmt.Errorf("LogStorage spec.CuratedIndexPrefixes contains %q, which doesn't start with %s", prefix, operatorv1.DefaultCuratedIndexPrefix)
+		}
+	}
+	return nil
+}
+
+func validateRetentionGuardrail(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	guardrail := spec.RetentionGuardrail
+	if guardrail == nil {
+		return nil
+	}
+	// In multi-tenant mode the retention of each tenant's Elasticsearch is managed out of band.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.RetentionGuardrail is not supported for multi-tenant clusters")
+	}
+	if guardrail.MaxDiskUsagePercent < 1 || guardrail.MaxDiskUsagePercent > 100 {
+		return fmt.Errorf("LogStorage spec.RetentionGuardrail.MaxDiskUsagePercent must be between 1 and 100")
+	}
+	if guardrail.MinimumRetention == nil || spec.Retention == nil {
+		return nil
+	}
+
+	minimum, retention := guardrail.MinimumRetention, spec.Retention
+	for _, p := range []struct {
+		name               string
+		minimum, retention *int32
+	}{
+		{"Flows", minimum.Flows, retention.Flows},
+		{"AuditReports", minimum.AuditReports, retention.AuditReports},
+		{"Snapshots", minimum.Snapshots, retention.Snapshots},
+		{"ComplianceReports", minimum.ComplianceReports, retention.ComplianceReports},
+		{"DNSLogs", minimum.DNSLogs, retention.DNSLogs},
+		{"BGPLogs", minimum.BGPLogs, retention.BGPLogs},
+	} {
+		if p.minimum == nil {
+			continue
+		}
+		if *p.minimum < 1 {
+			return fmt.Errorf("LogStorage spec.RetentionGuardrail.MinimumRetention.%s must be at least 1", p.name)
+		}
+		if p.retention != nil && *p.minimum > *p.retention {
+			return fmt.Errorf("LogStorage spec.RetentionGuardrail.MinimumRetention.%s must not be longer than spec.Retention.%s", p.name, p.name)
+		}
+	}
+	return nil
+}
+
+func validateErrorBudget(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	budget := spec.ErrorBudget
+	if budget == nil {
+		return nil
+	}
+	// es-metrics, which checks the error budget, doesn't run in multi-tenant mode.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.ErrorBudget is not supported for multi-tenant clusters")
+	}
+	if budget.MaxRejectedExecutionsPerMinute != nil && *budget.MaxRejectedExecutionsPerMinute < 0 {
+		return fmt.Errorf("LogStorage spec.ErrorBudget.MaxRejectedExecutionsPerMinute must not be negative")
+	}
+	if budget.MaxAverageQueryLatency != nil && budget.MaxAverageQueryLatency.Duration <= 0 {
+		return fmt.Errorf("LogStorage spec.ErrorBudget.MaxAverageQueryLatency must be positive")
+	}
+	return nil
+}
+
+func validateUpgradeSnapshots(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	snapshots := spec.UpgradeSnapshots
+	if snapshots == nil {
+		return nil
+	}
+	// The operator doesn't provision Elasticsearch in multi-tenant mode.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.UpgradeSnapshots is not supported for multi-tenant clusters")
+	}
+	if snapshots.VolumeSnapshotClassName == "" {
+		return fmt.Errorf("LogStorage spec.UpgradeSnapshots.VolumeSnapshotClassName must be set")
+	}
+	if snapshots.Retain != nil && *snapshots.Retain < 1 {
+		return fmt.Errorf("LogStorage spec.UpgradeSnapshots.Retain must be at least 1")
+	}
+	return nil
+}
+
+func validateElasticsearchMetricsCollection(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	collection := spec.ElasticsearchMetricsCollection
+	if collection == nil {
+		return nil
+	}
+	// es-metrics isn't deployed in multi-tenant mode.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.ElasticsearchMetricsCollection is not supported for multi-tenant clusters")
+	}
+	for _, pattern := range collection.IndexAllowlist {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("LogStorage spec.ElasticsearchMetricsCollection.IndexAllowlist is invalid: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateLinseedTokens(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	if len(spec.LinseedTokens) == 0 {
+		return nil
+	}
+	// The tokens are bound to the RBAC of the single Linseed, while each tenant has its own in multi-tenant mode.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.LinseedTokens is not supported for multi-tenant clusters")
+	}
+	for _, token := range spec.LinseedTokens {
+		// The Kubernetes API server doesn't issue tokens that expire any sooner.
+		if token.Expiry != nil && token.Expiry.Duration < 10*time.Minute {
+			return fmt.Errorf("LogStorage spec.LinseedTokens[%s].Expiry must be at least 10m", token.Name)
+		}
+	}
+	return nil
+}
+
+func validateIngestionBackpressure(spec *operatorv1.LogStorageSpec, multiTenant bool) error {
+	thresholds := spec.IngestionBackpressure
+	if thresholds == nil {
+		return nil
+	}
+	// Backpressure is reported on the LogStorage, which is shared by the tenants in multi-tenant mode.
+	if multiTenant {
+		return fmt.Errorf("LogStorage spec.IngestionBackpressure is not supported for multi-tenant clusters")
+	}
+	if thresholds.MaxQueueDepth != nil && *thresholds.MaxQueueDepth < 0 {
+		return fmt.Errorf("LogStorage spec.IngestionBackpressure.MaxQueueDepth must not be negative")
+	}
+	if thresholds.MaxErrorsPerMinute != nil && *thresholds.MaxErrorsPerMinute < 0 {
+		return fmt.Errorf("LogStorage spec.IngestionBackpressure.MaxErrorsPerMinute must not be negative")
+	}
+	if thresholds.SustainedFor != nil && thresholds.SustainedFor.Duration < 0 {
+		return fmt.Errorf("LogStorage spec.IngestionBackpressure.SustainedFor must not be negative")
+	}
+	return nil
+}
+
+func validateTLS(spec *operatorv1.LogStorageSpec) error {
+	if spec.TLS == nil || len(spec.TLS.CipherSuites) == 0 {
+		return nil
+	}
+	if spec.TLS.MinVersion != nil && *spec.TLS.MinVersion == operatorv1.TLSVersion13 {
+		return fmt.Errorf("LogStorage spec.TLS.CipherSuites cannot be set when spec.TLS.MinVersion is %s", operatorv1.TLSVersion13)
+	}
+	// Only the secure cipher suites that can be used with TLS 1.2 are accepted.
+	supported := map[string]bool{}
+	for _, suite := range tls.CipherSuites() {
+		for _, v := range suite.SupportedVersions {
+			if v == tls.VersionTLS12 {
+				supported[suite.Name] = true
+			}
+		}
+	}
+	for _, name := range spec.TLS.CipherSuites {
+		if !supported[name] {
+			return fmt.Errorf("LogStorage spec.TLS.CipherSuites contains an unsupported cipher suite %s", name)
+		}
+	}
+	return nil
+}
+
 func (r *LogStorageInitializer) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling LogStorage")
@@ -201,11 +539,15 @@ func (r *LogStorageInitializer) Reconcile(ctx context.Context, request reconcile
 	// We found the LogStorage instance.
 	r.status.OnCRFound()
 
+	if utils.IsReconcilePaused(&ls.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// Get Installation resource.
 	_, install, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, reqLogger)
@@ -213,7 +555,7 @@ func (r *LogStorageInitializer) Reconcile(ctx context.Context, request reconcile
 	}
 
 	// Determine if Kibana is enabled for this cluster.
-	kibanaEnabled := !operatorv1.IsFIPSModeEnabled(install.FIPSMode) && !r.multiTenant
+	kibanaEnabled := !operatorv1.IsFIPSModeEnabled(install.FIPSMode) && !install.Features.KibanaDisabled() && !r.multiTenant
 
 	// Check if there is a management cluster connection. ManagementClusterConnection is a managed cluster only resource.
 	if err = r.client.Get(ctx, utils.DefaultTSEEInstanceKey, &operatorv1.ManagementClusterConnection{}); err == nil {
@@ -234,6 +576,56 @@ func (r *LogStorageInitializer) Reconcile(ctx context.Context, request reconcile
 	// Default and validate the object.
 	FillDefaults(ls)
 	err = validateComponentResources(&ls.Spec)
+	if err == nil {
+		err = validateECKOperator(&ls.Spec)
+	}
+	if err == nil {
+		err = validateElasticsearchEndpoint(&ls.Spec)
+	}
+	if err == nil {
+		err = validateNonClusterHosts(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		err = validateESGatewayTenancy(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		err = validateTLS(&ls.Spec)
+	}
+	if err == nil {
+		err = validateSecondaryElasticsearch(ls, r.multiTenant)
+	}
+	if err == nil {
+		err = validateRemoteClusters(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		err = validateReadOnlyUsers(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		err = validateCuratedIndexPrefixes(&ls.Spec)
+	}
+	if err == nil {
+		err = validateRetentionGuardrail(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		err = validateErrorBudget(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		err = validateUpgradeSnapshots(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		err = validateIngestionBackpressure(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		err = validateElasticsearchMetricsCollection(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		err = validateLinseedTokens(&ls.Spec, r.multiTenant)
+	}
+	if err == nil {
+		if err = dns.ValidateDNSNames(ls.Spec.ESGatewayCertificateDNSNames); err != nil {
+			err = fmt.Errorf("LogStorage spec.ESGatewayCertificateDNSNames is invalid: %w", err)
+		}
+	}
 	if err != nil {
 		// Invalid - mark it as such and return.
 		r.setConditionDegraded(ctx, ls, reqLogger)
@@ -16,7 +16,9 @@ package initializer
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -28,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	apiextenv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,6 +42,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/crds"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
 	"github.com/tigera/operator/pkg/render"
@@ -299,6 +303,412 @@ var _ = Describe("LogStorage Initializing controller", func() {
 		})
 	})
 
+	Context("validateECKOperator", func() {
+		It("should return nil when spec.ECKOperator is not set", func() {
+			Expect(validateECKOperator(&operatorv1.LogStorageSpec{})).To(BeNil())
+		})
+
+		It("should return nil when spec.ECKOperator.Version is a supported version", func() {
+			spec := operatorv1.LogStorageSpec{ECKOperator: &operatorv1.ECKOperator{Version: "2.14.0"}}
+			Expect(validateECKOperator(&spec)).To(BeNil())
+		})
+
+		It("should return an error when spec.ECKOperator.Version is not a version", func() {
+			spec := operatorv1.LogStorageSpec{ECKOperator: &operatorv1.ECKOperator{Version: "latest"}}
+			Expect(validateECKOperator(&spec)).NotTo(BeNil())
+		})
+
+		It("should return an error when spec.ECKOperator.Version is too old", func() {
+			spec := operatorv1.LogStorageSpec{ECKOperator: &operatorv1.ECKOperator{Version: "1.9.1"}}
+			Expect(validateECKOperator(&spec)).NotTo(BeNil())
+		})
+
+		It("should return an error when spec.ECKOperator.Version is set for a user-managed ECK operator", func() {
+			userManaged := operatorv1.ECKOperatorModeUserManaged
+			spec := operatorv1.LogStorageSpec{ECKOperator: &operatorv1.ECKOperator{Mode: &userManaged, Version: "2.14.0"}}
+			Expect(validateECKOperator(&spec)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateElasticsearchEndpoint", func() {
+		It("should return nil when spec.ElasticsearchEndpoint is not set", func() {
+			Expect(validateElasticsearchEndpoint(&operatorv1.LogStorageSpec{})).To(BeNil())
+		})
+
+		It("should return nil when spec.ElasticsearchEndpoint is an https URL with a host and port", func() {
+			spec := operatorv1.LogStorageSpec{ElasticsearchEndpoint: "https://es.mesh.example.com:443"}
+			Expect(validateElasticsearchEndpoint(&spec)).To(BeNil())
+		})
+
+		It("should return an error when spec.ElasticsearchEndpoint is not https", func() {
+			spec := operatorv1.LogStorageSpec{ElasticsearchEndpoint: "http://es.mesh.example.com:9200"}
+			Expect(validateElasticsearchEndpoint(&spec)).NotTo(BeNil())
+		})
+
+		It("should return an error when spec.ElasticsearchEndpoint has no port", func() {
+			spec := operatorv1.LogStorageSpec{ElasticsearchEndpoint: "https://es.mesh.example.com"}
+			Expect(validateElasticsearchEndpoint(&spec)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateNonClusterHosts", func() {
+		It("should return nil when spec.NonClusterHosts has valid source CIDRs", func() {
+			spec := operatorv1.LogStorageSpec{NonClusterHosts: &operatorv1.NonClusterHostLogIngestion{SourceCIDRs: []string{"10.0.0.0/16", "fd00::/64"}}}
+			Expect(validateNonClusterHosts(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error when spec.NonClusterHosts has an invalid source CIDR", func() {
+			spec := operatorv1.LogStorageSpec{NonClusterHosts: &operatorv1.NonClusterHostLogIngestion{SourceCIDRs: []string{"10.0.0.1"}}}
+			Expect(validateNonClusterHosts(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error when spec.NonClusterHosts is set for a multi-tenant cluster", func() {
+			spec := operatorv1.LogStorageSpec{NonClusterHosts: &operatorv1.NonClusterHostLogIngestion{}}
+			Expect(validateNonClusterHosts(&spec, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateESGatewayTenancy", func() {
+		It("should return nil when the expected tenant is set", func() {
+			mode := operatorv1.ESGatewayTenancyModeAudit
+			spec := operatorv1.LogStorageSpec{ESGatewayTenancy: &operatorv1.ESGatewayTenancy{ExpectedTenantID: "tenant-a", Mode: &mode}}
+			Expect(validateESGatewayTenancy(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error when the expected tenant is not set", func() {
+			spec := operatorv1.LogStorageSpec{ESGatewayTenancy: &operatorv1.ESGatewayTenancy{}}
+			Expect(validateESGatewayTenancy(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a multi-tenant cluster", func() {
+			spec := operatorv1.LogStorageSpec{ESGatewayTenancy: &operatorv1.ESGatewayTenancy{ExpectedTenantID: "tenant-a"}}
+			Expect(validateESGatewayTenancy(&spec, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateSecondaryElasticsearch", func() {
+		var ls *operatorv1.LogStorage
+
+		BeforeEach(func() {
+			ls = &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{SecondaryElasticsearch: &operatorv1.SecondaryElasticsearch{
+				Endpoint: "https://es.dr.example.com:9200",
+			}}}
+		})
+
+		It("should return nil when spec.SecondaryElasticsearch is not set", func() {
+			Expect(validateSecondaryElasticsearch(&operatorv1.LogStorage{}, false)).To(BeNil())
+		})
+
+		It("should return nil for a valid secondary cluster in DualWrite mode", func() {
+			Expect(validateSecondaryElasticsearch(ls, false)).To(BeNil())
+		})
+
+		It("should return an error when the endpoint is not an https URL with a port", func() {
+			for _, endpoint := range []string{"http://es.dr.example.com:9200", "https://es.dr.example.com", ""} {
+				ls.Spec.SecondaryElasticsearch.Endpoint = endpoint
+				Expect(validateSecondaryElasticsearch(ls, false)).NotTo(BeNil(), endpoint)
+			}
+		})
+
+		It("should require a remote cluster address only in CrossClusterReplication mode", func() {
+			ls.Spec.SecondaryElasticsearch.RemoteClusterAddress = "es-transport.example.com:9300"
+			Expect(validateSecondaryElasticsearch(ls, false)).NotTo(BeNil())
+
+			mode := operatorv1.SecondaryElasticsearchModeCrossClusterReplication
+			ls.Spec.SecondaryElasticsearch.Mode = &mode
+			Expect(validateSecondaryElasticsearch(ls, false)).To(BeNil())
+
+			ls.Spec.SecondaryElasticsearch.RemoteClusterAddress = "es-transport.example.com"
+			Expect(validateSecondaryElasticsearch(ls, false)).NotTo(BeNil())
+
+			ls.Spec.SecondaryElasticsearch.RemoteClusterAddress = ""
+			Expect(validateSecondaryElasticsearch(ls, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a multi-tenant cluster", func() {
+			Expect(validateSecondaryElasticsearch(ls, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateRemoteClusters", func() {
+		It("should return nil for remote clusters with seeds or a proxy address", func() {
+			spec := operatorv1.LogStorageSpec{RemoteClusters: []operatorv1.ElasticsearchRemoteCluster{
+				{Name: "east", Seeds: []string{"es-east-0.example.com:9300", "10.0.0.1:9300"}},
+				{Name: "west", ProxyAddress: "es-west.example.com:9400"},
+			}}
+			Expect(validateRemoteClusters(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error when a remote cluster is listed twice", func() {
+			spec := operatorv1.LogStorageSpec{RemoteClusters: []operatorv1.ElasticsearchRemoteCluster{
+				{Name: "east", Seeds: []string{"es-east-0.example.com:9300"}},
+				{Name: "east", ProxyAddress: "es-east.example.com:9400"},
+			}}
+			Expect(validateRemoteClusters(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error unless exactly one of seeds and proxy address is set", func() {
+			for _, remote := range []operatorv1.ElasticsearchRemoteCluster{
+				{Name: "east"},
+				{Name: "east", Seeds: []string{"es-east-0.example.com:9300"}, ProxyAddress: "es-east.example.com:9400"},
+			} {
+				spec := operatorv1.LogStorageSpec{RemoteClusters: []operatorv1.ElasticsearchRemoteCluster{remote}}
+				Expect(validateRemoteClusters(&spec, false)).NotTo(BeNil())
+			}
+		})
+
+		It("should return an error for an address without a numeric port", func() {
+			for _, address := range []string{"es-east.example.com", "es-east.example.com:transport"} {
+				spec := operatorv1.LogStorageSpec{RemoteClusters: []operatorv1.ElasticsearchRemoteCluster{{Name: "east", ProxyAddress: address}}}
+				Expect(validateRemoteClusters(&spec, false)).NotTo(BeNil(), address)
+			}
+		})
+
+		It("should return an error for a multi-tenant cluster", func() {
+			spec := operatorv1.LogStorageSpec{RemoteClusters: []operatorv1.ElasticsearchRemoteCluster{{Name: "east", ProxyAddress: "es-east.example.com:9400"}}}
+			Expect(validateRemoteClusters(&spec, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateReadOnlyUsers", func() {
+		It("should return nil for users scoped to log indices", func() {
+			spec := operatorv1.LogStorageSpec{ReadOnlyUsers: []operatorv1.ElasticsearchReadOnlyUser{
+				{Name: "analyst", Indices: []string{"tigera_secure_ee_flows", "tigera_secure_ee_dns"}},
+				{Name: "auditor", Indices: []string{"tigera_secure_ee_audit_*"}, Clusters: []string{"cluster", "managed-1"}},
+			}}
+			Expect(validateReadOnlyUsers(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error in multi-tenant mode", func() {
+			spec := operatorv1.LogStorageSpec{ReadOnlyUsers: []operatorv1.ElasticsearchReadOnlyUser{
+				{Name: "analyst", Indices: []string{"tigera_secure_ee_flows"}},
+			}}
+			Expect(validateReadOnlyUsers(&spec, true)).NotTo(BeNil())
+		})
+
+		It("should return an error for a duplicate user", func() {
+			spec := operatorv1.LogStorageSpec{ReadOnlyUsers: []operatorv1.ElasticsearchReadOnlyUser{
+				{Name: "analyst", Indices: []string{"tigera_secure_ee_flows"}},
+				{Name: "analyst", Indices: []string{"tigera_secure_ee_dns"}},
+			}}
+			Expect(validateReadOnlyUsers(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for indices outside of the log indices", func() {
+			for _, index := range []string{"tigera_secure_ee_", "*", ".security", "tigera_secure_ee_flows,.security", "tigera_secure_ee_flows.*"} {
+				spec := operatorv1.LogStorageSpec{ReadOnlyUsers: []operatorv1.ElasticsearchReadOnlyUser{
+					{Name: "analyst", Indices: []string{index}},
+				}}
+				Expect(validateReadOnlyUsers(&spec, false)).NotTo(BeNil(), "index %q", index)
+			}
+		})
+
+		It("should return an error for an invalid cluster", func() {
+			for _, cluster := range []string{"", "a,b", "remote:cluster"} {
+				spec := operatorv1.LogStorageSpec{ReadOnlyUsers: []operatorv1.ElasticsearchReadOnlyUser{
+					{Name: "analyst", Indices: []string{"tigera_secure_ee_flows"}, Clusters: []string{cluster}},
+				}}
+				Expect(validateReadOnlyUsers(&spec, false)).NotTo(BeNil(), "cluster %q", cluster)
+			}
+		})
+	})
+
+	Context("validateCuratedIndexPrefixes", func() {
+		It("should return nil for prefixes of the log indices", func() {
+			spec := operatorv1.LogStorageSpec{CuratedIndexPrefixes: []string{"tigera_secure_ee_flows", "tigera_secure_ee_dns."}}
+			Expect(validateCuratedIndexPrefixes(&spec)).To(BeNil())
+		})
+
+		It("should return an error for prefixes that match other indices", func() {
+			for _, prefix := range []string{"", "tigera_", ".kibana", "logs-"} {
+				spec := operatorv1.LogStorageSpec{CuratedIndexPrefixes: []string{"tigera_secure_ee_flows", prefix}}
+				Expect(validateCuratedIndexPrefixes(&spec)).NotTo(BeNil(), "prefix %q", prefix)
+			}
+		})
+	})
+
+	Context("validateRetentionGuardrail", func() {
+		var spec operatorv1.LogStorageSpec
+		BeforeEach(func() {
+			var flows, dnsLogs int32 = 8, 8
+			spec = operatorv1.LogStorageSpec{Retention: &operatorv1.Retention{Flows: &flows, DNSLogs: &dnsLogs}}
+		})
+
+		It("should return nil for minimums no longer than the retention periods", func() {
+			var flows, dnsLogs int32 = 2, 8
+			spec.RetentionGuardrail = &operatorv1.RetentionGuardrail{
+				MaxDiskUsagePercent: 80,
+				MinimumRetention:    &operatorv1.Retention{Flows: &flows, DNSLogs: &dnsLogs},
+			}
+			Expect(validateRetentionGuardrail(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error for a minimum longer than the retention period", func() {
+			var flows int32 = 9
+			spec.RetentionGuardrail = &operatorv1.RetentionGuardrail{MaxDiskUsagePercent: 80, MinimumRetention: &operatorv1.Retention{Flows: &flows}}
+			Expect(validateRetentionGuardrail(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a minimum of less than a day", func() {
+			var flows int32 = 0
+			spec.RetentionGuardrail = &operatorv1.RetentionGuardrail{MaxDiskUsagePercent: 80, MinimumRetention: &operatorv1.Retention{Flows: &flows}}
+			Expect(validateRetentionGuardrail(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a target outside of 1-100%", func() {
+			for _, target := range []int32{0, 101} {
+				spec.RetentionGuardrail = &operatorv1.RetentionGuardrail{MaxDiskUsagePercent: target}
+				Expect(validateRetentionGuardrail(&spec, false)).NotTo(BeNil(), "target %d", target)
+			}
+		})
+
+		It("should return an error for a multi-tenant cluster", func() {
+			spec.RetentionGuardrail = &operatorv1.RetentionGuardrail{MaxDiskUsagePercent: 80}
+			Expect(validateRetentionGuardrail(&spec, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateErrorBudget", func() {
+		It("should return nil for positive thresholds", func() {
+			maxRejected := int32(0)
+			spec := operatorv1.LogStorageSpec{ErrorBudget: &operatorv1.ElasticsearchErrorBudget{
+				MaxRejectedExecutionsPerMinute: &maxRejected,
+				MaxAverageQueryLatency:         &metav1.Duration{Duration: 500 * time.Millisecond},
+			}}
+			Expect(validateErrorBudget(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error for a latency that is not positive", func() {
+			spec := operatorv1.LogStorageSpec{ErrorBudget: &operatorv1.ElasticsearchErrorBudget{
+				MaxAverageQueryLatency: &metav1.Duration{},
+			}}
+			Expect(validateErrorBudget(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a multi-tenant cluster", func() {
+			spec := operatorv1.LogStorageSpec{ErrorBudget: &operatorv1.ElasticsearchErrorBudget{}}
+			Expect(validateErrorBudget(&spec, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateUpgradeSnapshots", func() {
+		It("should return nil for a volume snapshot class and a retained number of at least 1", func() {
+			retain := int32(2)
+			spec := operatorv1.LogStorageSpec{UpgradeSnapshots: &operatorv1.UpgradeSnapshots{VolumeSnapshotClassName: "csi-snapclass", Retain: &retain}}
+			Expect(validateUpgradeSnapshots(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error without a volume snapshot class", func() {
+			spec := operatorv1.LogStorageSpec{UpgradeSnapshots: &operatorv1.UpgradeSnapshots{}}
+			Expect(validateUpgradeSnapshots(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a retained number below 1", func() {
+			retain := int32(0)
+			spec := operatorv1.LogStorageSpec{UpgradeSnapshots: &operatorv1.UpgradeSnapshots{VolumeSnapshotClassName: "csi-snapclass", Retain: &retain}}
+			Expect(validateUpgradeSnapshots(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a multi-tenant cluster", func() {
+			spec := operatorv1.LogStorageSpec{UpgradeSnapshots: &operatorv1.UpgradeSnapshots{VolumeSnapshotClassName: "csi-snapclass"}}
+			Expect(validateUpgradeSnapshots(&spec, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateIngestionBackpressure", func() {
+		It("should return nil for thresholds that are not negative", func() {
+			maxQueueDepth, maxErrors := int32(0), int32(50)
+			spec := operatorv1.LogStorageSpec{IngestionBackpressure: &operatorv1.IngestionBackpressureThresholds{
+				MaxQueueDepth:      &maxQueueDepth,
+				MaxErrorsPerMinute: &maxErrors,
+				SustainedFor:       &metav1.Duration{Duration: 10 * time.Minute},
+			}}
+			Expect(validateIngestionBackpressure(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error for a negative duration", func() {
+			spec := operatorv1.LogStorageSpec{IngestionBackpressure: &operatorv1.IngestionBackpressureThresholds{
+				SustainedFor: &metav1.Duration{Duration: -time.Minute},
+			}}
+			Expect(validateIngestionBackpressure(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a multi-tenant cluster", func() {
+			spec := operatorv1.LogStorageSpec{IngestionBackpressure: &operatorv1.IngestionBackpressureThresholds{}}
+			Expect(validateIngestionBackpressure(&spec, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateElasticsearchMetricsCollection", func() {
+		It("should return nil for an index allowlist of valid regular expressions", func() {
+			spec := operatorv1.LogStorageSpec{ElasticsearchMetricsCollection: &operatorv1.ElasticsearchMetricsCollection{
+				IndexAllowlist: []string{"tigera_secure_ee_flows.*", "tigera_secure_ee_audit_kube.*"},
+			}}
+			Expect(validateElasticsearchMetricsCollection(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error for an index allowlist with an invalid regular expression", func() {
+			spec := operatorv1.LogStorageSpec{ElasticsearchMetricsCollection: &operatorv1.ElasticsearchMetricsCollection{
+				IndexAllowlist: []string{"tigera_secure_ee_(flows"},
+			}}
+			Expect(validateElasticsearchMetricsCollection(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a multi-tenant cluster", func() {
+			spec := operatorv1.LogStorageSpec{ElasticsearchMetricsCollection: &operatorv1.ElasticsearchMetricsCollection{}}
+			Expect(validateElasticsearchMetricsCollection(&spec, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateLinseedTokens", func() {
+		It("should return nil for tokens that expire after at least 10m", func() {
+			spec := operatorv1.LogStorageSpec{LinseedTokens: []operatorv1.LinseedToken{
+				{Name: "siem", Namespace: "analytics", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceFlows}},
+				{Name: "reports", Namespace: "reporting", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceDNSLogs}, Expiry: &metav1.Duration{Duration: 10 * time.Minute}},
+			}}
+			Expect(validateLinseedTokens(&spec, false)).To(BeNil())
+		})
+
+		It("should return an error for a token that expires before 10m", func() {
+			spec := operatorv1.LogStorageSpec{LinseedTokens: []operatorv1.LinseedToken{
+				{Name: "siem", Namespace: "analytics", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceFlows}, Expiry: &metav1.Duration{Duration: 5 * time.Minute}},
+			}}
+			Expect(validateLinseedTokens(&spec, false)).NotTo(BeNil())
+		})
+
+		It("should return an error for a multi-tenant cluster", func() {
+			spec := operatorv1.LogStorageSpec{LinseedTokens: []operatorv1.LinseedToken{
+				{Name: "siem", Namespace: "analytics", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceFlows}},
+			}}
+			Expect(validateLinseedTokens(&spec, true)).NotTo(BeNil())
+		})
+	})
+
+	Context("validateTLS", func() {
+		It("should return nil when spec.TLS lists TLS 1.2 cipher suites", func() {
+			spec := operatorv1.LogStorageSpec{TLS: &operatorv1.LogStorageTLS{
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			}}
+			Expect(validateTLS(&spec)).To(BeNil())
+		})
+
+		It("should return an error when spec.TLS lists an unknown or insecure cipher suite", func() {
+			for _, suite := range []string{"TLS_FOO", "TLS_RSA_WITH_RC4_128_SHA", "TLS_AES_128_GCM_SHA256"} {
+				spec := operatorv1.LogStorageSpec{TLS: &operatorv1.LogStorageTLS{CipherSuites: []string{suite}}}
+				Expect(validateTLS(&spec)).NotTo(BeNil(), suite)
+			}
+		})
+
+		It("should return an error when spec.TLS lists cipher suites with a minimum version of TLS 1.3", func() {
+			minVersion := operatorv1.TLSVersion13
+			spec := operatorv1.LogStorageSpec{TLS: &operatorv1.LogStorageTLS{
+				MinVersion:   &minVersion,
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			}}
+			Expect(validateTLS(&spec)).NotTo(BeNil())
+		})
+	})
+
 	Context("FillDefaults", func() {
 		It("should set the replica values to the default settings", func() {
 			retain8 := int32(8)
@@ -380,5 +790,48 @@ var _ = Describe("LogStorage Initializing controller", func() {
 			}
 			Expect(ls.Spec).To(Equal(expectedSpec))
 		})
+
+		It("should not change the defaults of the CRD", func() {
+			var schema *apiextenv1.JSONSchemaProps
+			for _, crd := range crds.GetCRDs(operatorv1.TigeraSecureEnterprise) {
+				if crd.Spec.Names.Kind == "LogStorage" {
+					schema = crd.Spec.Versions[0].Schema.OpenAPIV3Schema
+				}
+			}
+			Expect(schema).NotTo(BeNil())
+
+			obj := map[string]interface{}{}
+			applyCRDDefaults(obj, schema)
+			defaulted := &operatorv1.LogStorage{}
+			Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj, defaulted)).To(Succeed())
+
+			ls := defaulted.DeepCopy()
+			FillDefaults(ls)
+			Expect(ls).To(Equal(defaulted))
+		})
 	})
 })
+
+// applyCRDDefaults sets the defaults of the schema on the object, as the API server does for structural schemas.
+func applyCRDDefaults(obj interface{}, schema *apiextenv1.JSONSchemaProps) {
+	switch x := obj.(type) {
+	case map[string]interface{}:
+		for name, property := range schema.Properties {
+			property := property
+			if _, ok := x[name]; !ok && property.Default != nil {
+				var value interface{}
+				ExpectWithOffset(1, json.Unmarshal(property.Default.Raw, &value)).To(Succeed())
+				x[name] = value
+			}
+			if value, ok := x[name]; ok {
+				applyCRDDefaults(value, &property)
+			}
+		}
+	case []interface{}:
+		if schema.Items != nil && schema.Items.Schema != nil {
+			for _, value := range x {
+				applyCRDDefaults(value, schema.Items.Schema)
+			}
+		}
+	}
+}
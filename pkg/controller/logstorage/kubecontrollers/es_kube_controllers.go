@@ -49,7 +49,9 @@ import (
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/kubecontrollers"
 	"github.com/tigera/operator/pkg/render/logstorage/esgateway"
+	"github.com/tigera/operator/pkg/render/logstorage/esmetrics"
 	"github.com/tigera/operator/pkg/render/monitor"
+	"github.com/tigera/operator/pkg/telemetry"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
@@ -72,7 +74,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 	// Create the reconciler
 	r := &ESKubeControllersController{
-		client:          mgr.GetClient(),
+		client:          telemetry.Client(mgr.GetClient()),
 		scheme:          mgr.GetScheme(),
 		clusterDomain:   opts.ClusterDomain,
 		status:          status.New(mgr.GetClient(), initializer.TigeraStatusLogStorageKubeController, opts.KubernetesVersion),
@@ -83,7 +85,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	r.status.Run(opts.ShutdownContext)
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("log-storage-kubecontrollers-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-kubecontrollers-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-kubecontrollers-controller", r)})
 	if err != nil {
 		return err
 	}
@@ -120,6 +122,8 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	// Watch secrets this controller cares about.
 	secretsToWatch := []string{
 		render.TigeraElasticsearchGatewaySecret,
+		render.ElasticsearchLinseedUserSecret,
+		esmetrics.ElasticsearchMetricsSecret,
 		monitor.PrometheusClientTLSSecretName,
 	}
 
@@ -167,6 +171,9 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		if err := utils.AddServiceWatch(c, esgateway.ServiceName, render.ElasticsearchNamespace); err != nil {
 			return fmt.Errorf("log-storage-kubecontrollers failed to watch the Service resource: %w", err)
 		}
+		if err := utils.AddServiceWatch(c, esgateway.NonClusterHostServiceName, render.ElasticsearchNamespace); err != nil {
+			return fmt.Errorf("log-storage-kubecontrollers failed to watch the Service resource: %w", err)
+		}
 		if err := utils.AddConfigMapWatch(c, certificatemanagement.TrustedCertConfigMapName, render.ElasticsearchNamespace, &handler.EnqueueRequestForObject{}); err != nil {
 			return fmt.Errorf("log-storage-kubecontrollers failed to watch the ConfigMap resource: %w", err)
 		}
@@ -223,6 +230,10 @@ func (r *ESKubeControllersController) Reconcile(ctx context.Context, request rec
 	// We found the LogStorage instance (and Tenant instance if in multi-tenant mode).
 	r.status.OnCRFound()
 
+	if utils.IsReconcilePaused(&logStorage.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// Wait for the initializing controller to indicate that the LogStorage object is actionable.
 	if logStorage.Status.State != operatorv1.TigeraStatusReady {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LogStorage defaulting to occur", nil, reqLogger)
@@ -233,7 +244,7 @@ func (r *ESKubeControllersController) Reconcile(ctx context.Context, request rec
 	variant, install, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, reqLogger)
@@ -242,14 +253,14 @@ func (r *ESKubeControllersController) Reconcile(ctx context.Context, request rec
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
 	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", status.WithCode(operatorv1.TierNotReady, err), reqLogger)
 			return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 		} else {
 			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
@@ -278,11 +289,11 @@ func (r *ESKubeControllersController) Reconcile(ctx context.Context, request rec
 		// Wait for Elasticsearch to be installed and available
 		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
 		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", status.WithCode(operatorv1.ElasticsearchUnavailable, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", nil, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", status.WithCode(operatorv1.ElasticsearchNotReady, nil), reqLogger)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		}
 	}
@@ -320,7 +331,7 @@ func (r *ESKubeControllersController) Reconcile(ctx context.Context, request rec
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(install, r.client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -339,6 +350,7 @@ func (r *ESKubeControllersController) Reconcile(ctx context.Context, request rec
 			ctx,
 			gwNSHelper,
 			install,
+			logStorage,
 			variant,
 			pullSecrets,
 			hdler,
@@ -384,7 +396,7 @@ func (r *ESKubeControllersController) Reconcile(ctx context.Context, request rec
 	}
 
 	if err = imageset.ValidateImageSet(imageSet); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceValidationError, "Error validating ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Error validating ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -247,6 +247,37 @@ var _ = Describe("LogStorage ES kube-controllers controller", func() {
 		Expect(test.GetResource(cli, &dep)).To(BeNil())
 	})
 
+	It("should provision the ingestion endpoint for non-cluster hosts", func() {
+		ls := &operatorv1.LogStorage{}
+		Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+		ls.Spec.NonClusterHosts = &operatorv1.NonClusterHostLogIngestion{}
+		Expect(cli.Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		// Create the Linseed user secret. This is normally created by the users controller.
+		linseedUserSecret := &corev1.Secret{}
+		linseedUserSecret.Name = render.ElasticsearchLinseedUserSecret
+		linseedUserSecret.Namespace = common.OperatorNamespace()
+		linseedUserSecret.Data = map[string][]byte{"username": []byte("tigera-ee-linseed"), "password": []byte("linseed-password")}
+		Expect(cli.Create(ctx, linseedUserSecret)).ShouldNot(HaveOccurred())
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).Should(Equal(successResult))
+		mockStatus.AssertNumberOfCalls(GinkgoT(), "SetDegraded", 0)
+
+		svc := corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: esgateway.NonClusterHostServiceName, Namespace: render.ElasticsearchNamespace}}
+		Expect(test.GetResource(cli, &svc)).To(BeNil())
+
+		// The non-cluster hosts present the gateway credentials, which es-gateway swaps for the Linseed credentials.
+		gatewaySecret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: esgateway.NonClusterHostUserSecret, Namespace: common.OperatorNamespace()}}
+		Expect(test.GetResource(cli, &gatewaySecret)).To(BeNil())
+		Expect(gatewaySecret.Data["username"]).To(Equal([]byte(esgateway.NonClusterHostUserName)))
+		secureSecret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: esgateway.NonClusterHostSecureUserSecret, Namespace: render.ElasticsearchNamespace}}
+		Expect(test.GetResource(cli, &secureSecret)).To(BeNil())
+		Expect(secureSecret.Data["username"]).To(Equal([]byte("tigera-ee-linseed")))
+		Expect(secureSecret.Data["password"]).To(Equal([]byte("linseed-password")))
+	})
+
 	It("should use images from ImageSet", func() {
 		Expect(cli.Create(ctx, &operatorv1.ImageSet{
 			ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
@@ -24,11 +24,12 @@ import (
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	lscommon "github.com/tigera/operator/pkg/controller/logstorage/common"
+	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
-	"github.com/tigera/operator/pkg/dns"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/logstorage/esgateway"
+	"github.com/tigera/operator/pkg/render/logstorage/esmetrics"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
@@ -36,6 +37,7 @@ func (r *ESKubeControllersController) createESGateway(
 	ctx context.Context,
 	helper utils.NamespaceHelper,
 	install *operatorv1.InstallationSpec,
+	logStorage *operatorv1.LogStorage,
 	variant operatorv1.ProductVariant,
 	pullSecrets []*corev1.Secret,
 	hdler utils.ComponentHandler,
@@ -52,7 +54,7 @@ func (r *ESKubeControllersController) createESGateway(
 	esAdminUserSecret, err := utils.GetSecret(ctx, r.client, render.ElasticsearchAdminUserSecret, adminSecretNamespace)
 	if err != nil {
 		reqLogger.Error(err, "failed to get Elasticsearch admin user secret")
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to get Elasticsearch admin user secret", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to get Elasticsearch admin user secret", status.WithCode(operatorv1.SecretNotAvailable, err), reqLogger)
 		return err
 	} else if esAdminUserSecret == nil {
 		r.status.SetDegraded(operatorv1.ResourceNotFound, "Waiting for elasticsearch admin secret", nil, reqLogger)
@@ -74,15 +76,10 @@ func (r *ESKubeControllersController) createESGateway(
 	// Collect the certificates we need to provision ESGW. These will have been provisioned already by the ES secrets controller.
 	cm, err := certificatemanager.Create(r.client, install, r.clusterDomain, helper.TruthNamespace())
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return err
 	}
-	// For legacy reasons, es-gateway is sitting behind two services: tigera-secure-es-http (where originally ES resided)
-	// and tigera-secure-es-gateway-http.
-	gatewayDNSNames := append(
-		dns.GetServiceDNSNames(render.ElasticsearchServiceName, helper.InstallNamespace(), r.clusterDomain),
-		dns.GetServiceDNSNames(esgateway.ServiceName, helper.InstallNamespace(), r.clusterDomain)...,
-	)
+	gatewayDNSNames := esgateway.DNSNames(helper.InstallNamespace(), r.clusterDomain, logStorage)
 	gatewayKeyPair, err := cm.GetKeyPair(r.client, render.TigeraElasticsearchGatewaySecret, helper.TruthNamespace(), gatewayDNSNames)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceNotFound, "Error getting TLS certificate", err, log)
@@ -98,11 +95,55 @@ func (r *ESKubeControllersController) createESGateway(
 		return err
 	}
 
+	var nonClusterHostSecrets []*corev1.Secret
+	if logStorage != nil && logStorage.Spec.NonClusterHosts != nil {
+		// Non-cluster hosts write their logs with the credentials of the Linseed Elasticsearch user, which are provisioned by the
+		// users controller.
+		linseedUserSecret, err := utils.GetSecret(ctx, r.client, render.ElasticsearchLinseedUserSecret, helper.TruthNamespace())
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to get Linseed Elasticsearch user secret", err, reqLogger)
+			return err
+		} else if linseedUserSecret == nil {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Waiting for Linseed Elasticsearch user secret", nil, reqLogger)
+			return nil
+		}
+
+		creds := lscommon.GatewayCredentials{
+			UserSecret:         esgateway.NonClusterHostUserSecret,
+			Username:           esgateway.NonClusterHostUserName,
+			VerificationSecret: esgateway.NonClusterHostVerificationUserSecret,
+			SecureUserSecret:   esgateway.NonClusterHostSecureUserSecret,
+		}
+		gatewaySecret, verificationSecret, secureUserSecret, err := lscommon.CreateGatewaySecrets(
+			ctx, creds, string(linseedUserSecret.Data["username"]), linseedUserSecret.Data["password"], r.client, helper)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceCreateError, "Failed to create non-cluster host secrets for Elasticsearch gateway", err, reqLogger)
+			return err
+		}
+		nonClusterHostSecrets = []*corev1.Secret{gatewaySecret, verificationSecret, secureUserSecret}
+	}
+
+	var esMetricsUserSecret *corev1.Secret
+	if logStorage != nil && logStorage.ElasticsearchMetricsClientCertificate() {
+		// ES gateway authenticates to Elasticsearch with the credentials of es-metrics, on behalf of es-metrics.
+		esMetricsUserSecret, err = utils.GetSecret(ctx, r.client, esmetrics.ElasticsearchMetricsSecret, helper.TruthNamespace())
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to get Elasticsearch metrics user secret", err, reqLogger)
+			return err
+		} else if esMetricsUserSecret == nil {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Waiting for Elasticsearch metrics user secret", nil, reqLogger)
+			return nil
+		}
+	}
+
 	cfg := &esgateway.Config{
 		Installation:               install,
+		LogStorage:                 logStorage,
 		PullSecrets:                pullSecrets,
 		TrustedBundle:              trustedBundle,
 		KubeControllersUserSecrets: []*corev1.Secret{kubeControllersGatewaySecret, kubeControllersVerificationSecret, kubeControllersSecureUserSecret},
+		NonClusterHostUserSecrets:  nonClusterHostSecrets,
+		ESMetricsUserSecret:        esMetricsUserSecret,
 		ClusterDomain:              r.clusterDomain,
 		EsAdminUserName:            esAdminUserName,
 		ESGatewayKeyPair:           gatewayKeyPair,
@@ -112,7 +153,7 @@ func (r *ESKubeControllersController) createESGateway(
 
 	esGatewayComponent := esgateway.EsGateway(cfg)
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, esGatewayComponent); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return err
 	}
 
@@ -0,0 +1,269 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linseed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/render/logstorage/linseed"
+)
+
+// backpressureInterval is how often the ingestion metrics of Linseed are collected. The error rate is computed over the
+// time between two collections.
+const backpressureInterval = time.Minute
+
+// The thresholds that apply when the LogStorage does not set them.
+const (
+	defaultMaxQueueDepth        = 10000
+	defaultMaxErrorsPerMinute   = 100
+	defaultBackpressureDuration = 5 * time.Minute
+)
+
+// The metrics that Linseed exports about the ingestion of logs.
+const (
+	// linseedQueueDepthMetric is a gauge of the documents that wait to be written to Elasticsearch.
+	linseedQueueDepthMetric = "tigera_linseed_ingestion_queue_depth"
+	// linseedDocumentsMetric is a counter of the documents that were written to Elasticsearch.
+	linseedDocumentsMetric = "tigera_linseed_ingestion_documents_total"
+	// linseedErrorsMetric is a counter of the documents that could not be written to Elasticsearch.
+	linseedErrorsMetric = "tigera_linseed_ingestion_errors_total"
+)
+
+var (
+	ingestionQueueDepthGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tigera_operator_linseed_ingestion_queue_depth",
+		Help: "Documents that wait in the ingestion queues of Linseed to be written to Elasticsearch, summed over all pods.",
+	})
+	ingestedDocumentsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tigera_operator_linseed_ingested_documents_per_minute",
+		Help: "Rate at which Linseed writes documents to Elasticsearch, summed over all pods.",
+	})
+	ingestionErrorsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tigera_operator_linseed_ingestion_errors_per_minute",
+		Help: "Rate at which Linseed fails to write documents to Elasticsearch, summed over all pods.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(ingestionQueueDepthGauge, ingestedDocumentsGauge, ingestionErrorsGauge)
+}
+
+// metricsHTTPClient is the client that collects the metrics of the Linseed pods.
+var metricsHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// ingestionStats are the ingestion metrics of Linseed, summed over its pods.
+type ingestionStats struct {
+	queueDepth float64
+	documents  float64
+	errors     float64
+}
+
+// ingestionSample is the ingestion metrics of Linseed at the time they were collected.
+type ingestionSample struct {
+	time  time.Time
+	stats *ingestionStats
+}
+
+// checkBackpressure collects the ingestion metrics of Linseed once per backpressureInterval, exports them as metrics,
+// and compares them with the thresholds of the LogStorage. It returns a description of the thresholds that have been
+// exceeded for longer than the sustained duration, or an empty string if there are none. Between two collections, the
+// result of the last one is returned.
+func (r *LinseedSubController) checkBackpressure(ctx context.Context, ls *operatorv1.LogStorage, reqLogger logr.Logger) string {
+	if r.lastIngestionSample != nil && time.Since(r.lastIngestionSample.time) < backpressureInterval {
+		return r.backpressure
+	}
+
+	stats, err := r.ingestionStatsFn(ctx, r.client)
+	if err != nil {
+		// The metrics are only informational, so Linseed isn't degraded when they can't be collected. Backpressure must
+		// be sustained again from the next collection to be reported.
+		reqLogger.Error(err, "Failed to collect the ingestion metrics of Linseed")
+		r.resetBackpressure()
+		return ""
+	}
+	if stats == nil {
+		r.resetBackpressure()
+		return ""
+	}
+
+	previous := r.lastIngestionSample
+	r.lastIngestionSample = &ingestionSample{time: time.Now(), stats: stats}
+	ingestionQueueDepthGauge.Set(stats.queueDepth)
+
+	maxQueueDepth, maxErrors, sustainedFor := backpressureThresholds(ls)
+	var exceeded []string
+	if stats.queueDepth > float64(maxQueueDepth) {
+		exceeded = append(exceeded, fmt.Sprintf("%.0f documents wait to be written to Elasticsearch, more than the maximum of %d", stats.queueDepth, maxQueueDepth))
+	}
+
+	// The counts are cumulative since the pods started, so they drop when a pod restarts. Skip the rates of that
+	// interval rather than reporting them as negative.
+	if previous != nil {
+		minutes := r.lastIngestionSample.time.Sub(previous.time).Minutes()
+		documents := stats.documents - previous.stats.documents
+		errors := stats.errors - previous.stats.errors
+		if documents >= 0 && errors >= 0 {
+			ingestedDocumentsGauge.Set(documents / minutes)
+			ingestionErrorsGauge.Set(errors / minutes)
+			if rate := errors / minutes; rate > float64(maxErrors) {
+				exceeded = append(exceeded, fmt.Sprintf("Linseed failed to write %.1f documents per minute to Elasticsearch, more than the maximum of %d", rate, maxErrors))
+			}
+		}
+	}
+
+	if len(exceeded) == 0 {
+		r.backpressureSince = time.Time{}
+		r.backpressure = ""
+		return ""
+	}
+	if r.backpressureSince.IsZero() {
+		r.backpressureSince = r.lastIngestionSample.time
+	}
+	r.backpressure = ""
+	if r.lastIngestionSample.time.Sub(r.backpressureSince) >= sustainedFor {
+		r.backpressure = fmt.Sprintf("The log ingestion is backpressured by Elasticsearch for %s: %s", sustainedFor, strings.Join(exceeded, "; "))
+	}
+	return r.backpressure
+}
+
+// resetBackpressure forgets the last collection, so that the metrics are no longer exported and backpressure is only
+// reported once it is sustained from the next collection.
+func (r *LinseedSubController) resetBackpressure() {
+	r.lastIngestionSample = nil
+	r.backpressureSince = time.Time{}
+	r.backpressure = ""
+	ingestionQueueDepthGauge.Set(0)
+	ingestedDocumentsGauge.Set(0)
+	ingestionErrorsGauge.Set(0)
+}
+
+// backpressureThresholds returns the maximum queue depth, the maximum error rate and the duration for which either must
+// be exceeded before backpressure is reported.
+func backpressureThresholds(ls *operatorv1.LogStorage) (int32, int32, time.Duration) {
+	maxQueueDepth, maxErrors, sustainedFor := int32(defaultMaxQueueDepth), int32(defaultMaxErrorsPerMinute), defaultBackpressureDuration
+	if t := ls.Spec.IngestionBackpressure; t != nil {
+		if t.MaxQueueDepth != nil {
+			maxQueueDepth = *t.MaxQueueDepth
+		}
+		if t.MaxErrorsPerMinute != nil {
+			maxErrors = *t.MaxErrorsPerMinute
+		}
+		if t.SustainedFor != nil {
+			sustainedFor = t.SustainedFor.Duration
+		}
+	}
+	return maxQueueDepth, maxErrors, sustainedFor
+}
+
+// collectIngestionStats collects the ingestion metrics of the running Linseed pods, or returns nil if there are none.
+// The operator runs on the host network, so it reaches the pods directly rather than through the Linseed service, which
+// would only reach one of them.
+func collectIngestionStats(ctx context.Context, cli client.Client) (*ingestionStats, error) {
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.InNamespace(render.ElasticsearchNamespace), client.MatchingLabels{"k8s-app": linseed.DeploymentName}); err != nil {
+		return nil, err
+	}
+
+	var total *ingestionStats
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		stats, err := scrapeIngestionStats(ctx, fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(linseed.MetricsPort))))
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect the metrics of pod %s: %w", pod.Name, err)
+		}
+		if total == nil {
+			total = &ingestionStats{}
+		}
+		total.queueDepth += stats.queueDepth
+		total.documents += stats.documents
+		total.errors += stats.errors
+	}
+	return total, nil
+}
+
+// scrapeIngestionStats collects the ingestion metrics from the metrics endpoint of a Linseed pod.
+func scrapeIngestionStats(ctx context.Context, url string) (*ingestionStats, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := metricsHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return parseIngestionStats(resp.Body)
+}
+
+// parseIngestionStats parses the ingestion metrics from metrics in the Prometheus text format. The values of each metric
+// are summed over its labels.
+func parseIngestionStats(r io.Reader) (*ingestionStats, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := func(name string) float64 {
+		var total float64
+		family, ok := families[name]
+		if !ok {
+			return 0
+		}
+		for _, m := range family.GetMetric() {
+			total += metricValue(m)
+		}
+		return total
+	}
+	return &ingestionStats{
+		queueDepth: sum(linseedQueueDepthMetric),
+		documents:  sum(linseedDocumentsMetric),
+		errors:     sum(linseedErrorsMetric),
+	}, nil
+}
+
+func metricValue(m *dto.Metric) float64 {
+	switch {
+	case m.Gauge != nil:
+		return m.Gauge.GetValue()
+	case m.Counter != nil:
+		return m.Counter.GetValue()
+	case m.Untyped != nil:
+		return m.Untyped.GetValue()
+	}
+	return 0
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linseed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+)
+
+var _ = Describe("Linseed ingestion backpressure", func() {
+	var (
+		ctx      context.Context
+		ls       *operatorv1.LogStorage
+		r        *LinseedSubController
+		stats    *ingestionStats
+		statsErr error
+	)
+	reqLogger := logf.Log.WithName("test")
+
+	// check checks for backpressure as if the metrics were last collected a minute ago.
+	check := func() string {
+		if r.lastIngestionSample != nil {
+			r.lastIngestionSample.time = time.Now().Add(-backpressureInterval)
+		}
+		return r.checkBackpressure(ctx, ls, reqLogger)
+	}
+
+	// sustain makes the thresholds appear to have been exceeded since the given time ago.
+	sustain := func(d time.Duration) {
+		r.backpressureSince = r.backpressureSince.Add(-d)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		maxQueueDepth, maxErrors := int32(1000), int32(10)
+		ls = &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec: operatorv1.LogStorageSpec{
+				IngestionBackpressure: &operatorv1.IngestionBackpressureThresholds{
+					MaxQueueDepth:      &maxQueueDepth,
+					MaxErrorsPerMinute: &maxErrors,
+					SustainedFor:       &metav1.Duration{Duration: 3 * time.Minute},
+				},
+			},
+		}
+		stats, statsErr = &ingestionStats{}, nil
+		r = &LinseedSubController{
+			ingestionStatsFn: func(context.Context, client.Client) (*ingestionStats, error) {
+				if stats == nil {
+					return nil, statsErr
+				}
+				copied := *stats
+				return &copied, statsErr
+			},
+		}
+	})
+
+	AfterEach(func() {
+		r.resetBackpressure()
+	})
+
+	It("should report a queue that stays too deep for the sustained duration", func() {
+		Expect(check()).To(BeEmpty())
+		Expect(testutil.ToFloat64(ingestionQueueDepthGauge)).To(Equal(float64(0)))
+
+		stats.queueDepth = 1500
+		Expect(check()).To(BeEmpty())
+		Expect(testutil.ToFloat64(ingestionQueueDepthGauge)).To(Equal(float64(1500)))
+
+		sustain(3 * time.Minute)
+		backpressure := check()
+		Expect(backpressure).To(ContainSubstring("1500 documents wait to be written to Elasticsearch, more than the maximum of 1000"))
+		Expect(backpressure).To(ContainSubstring("for 3m0s"))
+
+		By("not collecting the metrics again within the interval")
+		stats.queueDepth = 0
+		Expect(r.checkBackpressure(ctx, ls, reqLogger)).To(Equal(backpressure))
+
+		By("clearing the backpressure once the queue drains")
+		Expect(check()).To(BeEmpty())
+		Expect(r.backpressureSince.IsZero()).To(BeTrue())
+	})
+
+	It("should report the rate of errors that exceeds the maximum", func() {
+		stats.documents, stats.errors = 1000, 100
+		Expect(check()).To(BeEmpty())
+		Expect(r.backpressureSince.IsZero()).To(BeTrue())
+
+		stats.documents, stats.errors = 2000, 160
+		Expect(check()).To(BeEmpty())
+		Expect(testutil.ToFloat64(ingestedDocumentsGauge)).To(BeNumerically("~", 1000, 1))
+		Expect(testutil.ToFloat64(ingestionErrorsGauge)).To(BeNumerically("~", 60, 0.1))
+
+		sustain(3 * time.Minute)
+		stats.errors = 220
+		Expect(check()).To(ContainSubstring("Linseed failed to write 60.0 documents per minute to Elasticsearch, more than the maximum of 10"))
+	})
+
+	It("should skip the rates of an interval in which a pod restarted", func() {
+		stats.errors = 1000
+		Expect(check()).To(BeEmpty())
+		stats.errors = 0
+		Expect(check()).To(BeEmpty())
+		Expect(r.backpressureSince.IsZero()).To(BeTrue())
+	})
+
+	It("should use the default thresholds", func() {
+		ls.Spec.IngestionBackpressure = nil
+		stats.queueDepth = 5000
+		Expect(check()).To(BeEmpty())
+		Expect(r.backpressureSince.IsZero()).To(BeTrue())
+
+		stats.queueDepth = 20000
+		Expect(check()).To(BeEmpty())
+		sustain(4 * time.Minute)
+		Expect(check()).To(BeEmpty())
+		sustain(time.Minute)
+		Expect(check()).To(ContainSubstring("more than the maximum of 10000"))
+	})
+
+	It("should forget the collections while the metrics can't be collected", func() {
+		stats.queueDepth = 1500
+		Expect(check()).To(BeEmpty())
+		sustain(3 * time.Minute)
+		Expect(check()).NotTo(BeEmpty())
+
+		statsErr = fmt.Errorf("connection refused")
+		Expect(check()).To(BeEmpty())
+		Expect(r.lastIngestionSample).To(BeNil())
+		Expect(testutil.ToFloat64(ingestionQueueDepthGauge)).To(Equal(float64(0)))
+
+		By("requiring the backpressure to be sustained again")
+		statsErr = nil
+		Expect(check()).To(BeEmpty())
+
+		By("not collecting anything while there are no Linseed pods")
+		stats = nil
+		Expect(check()).To(BeEmpty())
+		Expect(r.lastIngestionSample).To(BeNil())
+	})
+
+	It("should sum the ingestion metrics over their labels", func() {
+		parsed, err := parseIngestionStats(strings.NewReader(`# HELP tigera_linseed_ingestion_queue_depth Documents waiting to be written.
+# TYPE tigera_linseed_ingestion_queue_depth gauge
+tigera_linseed_ingestion_queue_depth{type="flows"} 120
+tigera_linseed_ingestion_queue_depth{type="dns"} 30
+# TYPE tigera_linseed_ingestion_documents_total counter
+tigera_linseed_ingestion_documents_total{type="flows"} 5000
+# TYPE tigera_linseed_ingestion_errors_total counter
+tigera_linseed_ingestion_errors_total{type="flows",reason="rejected"} 7
+tigera_linseed_ingestion_errors_total{type="dns",reason="mapping"} 2
+# TYPE go_goroutines gauge
+go_goroutines 42
+`))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*parsed).To(Equal(ingestionStats{queueDepth: 150, documents: 5000, errors: 9}))
+
+		_, err = parseIngestionStats(strings.NewReader("not metrics {"))
+		Expect(err).Should(HaveOccurred())
+	})
+})
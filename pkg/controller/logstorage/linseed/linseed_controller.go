@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 
 	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
 
@@ -54,6 +56,7 @@ import (
 	"github.com/tigera/operator/pkg/render/logstorage/esgateway"
 	"github.com/tigera/operator/pkg/render/logstorage/linseed"
 	"github.com/tigera/operator/pkg/render/monitor"
+	"github.com/tigera/operator/pkg/telemetry"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
@@ -68,6 +71,14 @@ type LinseedSubController struct {
 	dpiAPIReady     *utils.ReadyFlag
 	multiTenant     bool
 	elasticExternal bool
+
+	// ingestionStatsFn collects the ingestion metrics of Linseed. lastIngestionSample is the last collection,
+	// backpressureSince the time of the first collection in a row that exceeded the thresholds of the LogStorage, and
+	// backpressure the thresholds that were exceeded for the sustained duration when it was collected.
+	ingestionStatsFn    func(context.Context, client.Client) (*ingestionStats, error)
+	lastIngestionSample *ingestionSample
+	backpressureSince   time.Time
+	backpressure        string
 }
 
 func Add(mgr manager.Manager, opts options.AddOptions) error {
@@ -77,19 +88,20 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 	// Create the reconciler
 	r := &LinseedSubController{
-		client:          mgr.GetClient(),
-		scheme:          mgr.GetScheme(),
-		clusterDomain:   opts.ClusterDomain,
-		tierWatchReady:  &utils.ReadyFlag{},
-		dpiAPIReady:     &utils.ReadyFlag{},
-		multiTenant:     opts.MultiTenant,
-		status:          status.New(mgr.GetClient(), "log-storage-access", opts.KubernetesVersion),
-		elasticExternal: opts.ElasticExternal,
+		client:           telemetry.Client(mgr.GetClient()),
+		scheme:           mgr.GetScheme(),
+		clusterDomain:    opts.ClusterDomain,
+		tierWatchReady:   &utils.ReadyFlag{},
+		dpiAPIReady:      &utils.ReadyFlag{},
+		multiTenant:      opts.MultiTenant,
+		status:           status.New(mgr.GetClient(), "log-storage-access", opts.KubernetesVersion),
+		elasticExternal:  opts.ElasticExternal,
+		ingestionStatsFn: collectIngestionStats,
 	}
 	r.status.Run(opts.ShutdownContext)
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("log-storage-access-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-access-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-access-controller", r)})
 	if err != nil {
 		return err
 	}
@@ -135,6 +147,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		render.LinseedTokenSecret,
 		monitor.PrometheusClientTLSSecretName,
 		render.ElasticsearchLinseedUserSecret,
+		logstorage.SecondaryElasticsearchSecret,
 	}
 
 	// Determine namespaces to watch.
@@ -236,6 +249,10 @@ func (r *LinseedSubController) Reconcile(ctx context.Context, request reconcile.
 	// We found the LogStorage instance (and Tenant instance if in multi-tenant mode).
 	r.status.OnCRFound()
 
+	if utils.IsReconcilePaused(&logStorage.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// Wait for the initializing controller to indicate that the LogStorage object is actionable.
 	if logStorage.Status.State != operatorv1.TigeraStatusReady {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LogStorage defaulting to occur", nil, reqLogger)
@@ -246,7 +263,7 @@ func (r *LinseedSubController) Reconcile(ctx context.Context, request reconcile.
 	variant, install, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, reqLogger)
@@ -255,7 +272,7 @@ func (r *LinseedSubController) Reconcile(ctx context.Context, request reconcile.
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 	if !r.dpiAPIReady.IsReady() {
@@ -267,7 +284,7 @@ func (r *LinseedSubController) Reconcile(ctx context.Context, request reconcile.
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
 	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", status.WithCode(operatorv1.TierNotReady, err), reqLogger)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		} else {
 			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
@@ -296,25 +313,38 @@ func (r *LinseedSubController) Reconcile(ctx context.Context, request reconcile.
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(install, r.client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurring while retrieving the pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
-	// Determine where to access elasticsearch.
-	elasticHost := "tigera-secure-es-http.tigera-elasticsearch.svc"
+	// Determine where to access elasticsearch. The fully qualified name is used so that it resolves regardless of the
+	// search domains of the pod.
+	elasticHost := fmt.Sprintf("tigera-secure-es-http.tigera-elasticsearch.svc.%s", r.clusterDomain)
 	elasticPort := "9200"
 	var esClientSecret *corev1.Secret
 	if !r.elasticExternal {
 		// Wait for Elasticsearch to be installed and available.
 		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
 		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", status.WithCode(operatorv1.ElasticsearchUnavailable, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", nil, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", status.WithCode(operatorv1.ElasticsearchNotReady, nil), reqLogger)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		}
+
+		// The LogStorage may override the endpoint of Elasticsearch, e.g. to go through a service mesh.
+		if logStorage.Spec.ElasticsearchEndpoint != "" {
+			url, err := url.Parse(logStorage.Spec.ElasticsearchEndpoint)
+			if err != nil {
+				reqLogger.Error(err, "Elasticsearch endpoint is invalid")
+				r.status.SetDegraded(operatorv1.ResourceValidationError, "Elasticsearch endpoint is invalid", err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			elasticHost = url.Hostname()
+			elasticPort = url.Port()
+		}
 	} else {
 		// If we're using an external ES, the Tenant resource must specify the ES endpoint.
 		if tenant == nil || tenant.Spec.Elastic == nil || tenant.Spec.Elastic.URL == "" {
@@ -345,6 +375,36 @@ func (r *LinseedSubController) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
+	// When log data is written to a secondary Elasticsearch cluster as well, Linseed needs the credentials to access it.
+	// While the log storage is migrated to external Elasticsearch, the external cluster is the secondary one, so Linseed
+	// stops writing to it as a secondary once the operator is switched to external Elasticsearch.
+	var secondaryESSecret *corev1.Secret
+	var secondaryESHost string
+	var secondaryESPort uint16
+	if writesToSecondaryElasticsearch(logStorage, r.elasticExternal) {
+		url, err := url.Parse(logStorage.Spec.SecondaryElasticsearch.Endpoint)
+		if err == nil {
+			var port uint64
+			port, err = strconv.ParseUint(url.Port(), 10, 16)
+			secondaryESHost, secondaryESPort = url.Hostname(), uint16(port)
+		}
+		if err != nil {
+			reqLogger.Error(err, "Secondary Elasticsearch endpoint is invalid")
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Secondary Elasticsearch endpoint is invalid", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+
+		secondaryESSecret = &corev1.Secret{}
+		err = r.client.Get(ctx, client.ObjectKey{Name: logstorage.SecondaryElasticsearchSecret, Namespace: common.OperatorNamespace()}, secondaryESSecret)
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Waiting for secondary Elasticsearch secret %s/%s to exist", common.OperatorNamespace(), logstorage.SecondaryElasticsearchSecret), err, reqLogger)
+			return reconcile.Result{}, nil
+		} else if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error getting secondary Elasticsearch secret", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Query the username and password this Linseed instance should use to authenticate with Elasticsearch.
 	// For multi-tenant systems, credentials are created by the elasticsearch users controller.
 	// For single-tenant system, these are created by es-kube-controllers.
@@ -365,7 +425,7 @@ func (r *LinseedSubController) Reconcile(ctx context.Context, request reconcile.
 	}
 	cm, err := certificatemanager.Create(r.client, install, r.clusterDomain, helper.TruthNamespace(), opts...)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 	linseedDNSNames := dns.GetServiceDNSNames(render.LinseedServiceName, helper.InstallNamespace(), r.clusterDomain)
@@ -447,12 +507,15 @@ func (r *LinseedSubController) Reconcile(ctx context.Context, request reconcile.
 		ElasticPort:                    elasticPort,
 		ElasticClientSecret:            esClientSecret,
 		ElasticClientCredentialsSecret: &credentials,
+		SecondaryElasticsearchSecret:   secondaryESSecret,
+		SecondaryElasticHost:           secondaryESHost,
+		SecondaryElasticPort:           secondaryESPort,
 		LogStorage:                     logStorage,
 	}
 	linseedComponent := linseed.Linseed(cfg)
 
 	if err := imageset.ApplyImageSet(ctx, r.client, variant, linseedComponent); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -469,8 +532,22 @@ func (r *LinseedSubController) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	r.status.ReadyToMonitor()
+
+	// Backpressure on the log ingestion is reported on the LogStorage, so it is only checked for the Linseed of a
+	// single-tenant cluster. While Linseed is running, its metrics are collected on a timer, so requeue even when
+	// nothing changed.
+	result := reconcile.Result{}
+	if !r.multiTenant {
+		if backpressure := r.checkBackpressure(ctx, logStorage, reqLogger); backpressure != "" {
+			r.status.SetDegraded(operatorv1.ResourceNotReady, backpressure, status.WithCode(operatorv1.LinseedIngestionBackpressure, nil), reqLogger)
+			return reconcile.Result{RequeueAfter: backpressureInterval}, nil
+		}
+		if r.lastIngestionSample != nil {
+			result.RequeueAfter = backpressureInterval
+		}
+	}
 	r.status.ClearDegraded()
-	return reconcile.Result{}, nil
+	return result, nil
 }
 
 func validateTenant(tenant *operatorv1.Tenant) error {
@@ -499,3 +576,15 @@ func validateTenant(tenant *operatorv1.Tenant) error {
 
 	return nil
 }
+
+// writesToSecondaryElasticsearch returns whether Linseed writes the log data to the secondary Elasticsearch cluster of the
+// LogStorage as well as to the primary one.
+func writesToSecondaryElasticsearch(ls *operatorv1.LogStorage, elasticExternal bool) bool {
+	switch ls.SecondaryElasticsearchMode() {
+	case operatorv1.SecondaryElasticsearchModeDualWrite:
+		return true
+	case operatorv1.SecondaryElasticsearchModeExternalMigration:
+		return !elasticExternal
+	}
+	return false
+}
@@ -82,6 +82,9 @@ func NewLinseedControllerWithShims(
 		multiTenant:    opts.MultiTenant,
 		tierWatchReady: &utils.ReadyFlag{},
 		dpiAPIReady:    &utils.ReadyFlag{},
+
+		// There are no Linseed pods to collect the ingestion metrics of.
+		ingestionStatsFn: func(context.Context, client.Client) (*ingestionStats, error) { return nil, nil },
 	}
 	r.tierWatchReady.MarkAsReady()
 	r.dpiAPIReady.MarkAsReady()
@@ -221,6 +224,31 @@ var _ = Describe("LogStorage Linseed controller", func() {
 			Expect(test.GetResource(cli, &linseedDp)).To(BeNil())
 		})
 
+		It("should use the Elasticsearch endpoint from the LogStorage", func() {
+			ls := &operatorv1.LogStorage{}
+			Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+			ls.Spec.ElasticsearchEndpoint = "https://es.mesh.example.com:8443"
+			Expect(cli.Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+			// Run the reconciler.
+			result, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result).Should(Equal(successResult))
+
+			linseedDp := appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      linseed.DeploymentName,
+					Namespace: render.ElasticsearchNamespace,
+				},
+			}
+			Expect(test.GetResource(cli, &linseedDp)).To(BeNil())
+			linseed := test.GetContainer(linseedDp.Spec.Template.Spec.Containers, linseed.DeploymentName)
+			Expect(linseed).ToNot(BeNil())
+			Expect(linseed.Env).To(ContainElement(corev1.EnvVar{Name: "ELASTIC_HOST", Value: "es.mesh.example.com"}))
+			Expect(linseed.Env).To(ContainElement(corev1.EnvVar{Name: "ELASTIC_PORT", Value: "8443"}))
+		})
+
 		It("should use images from ImageSet", func() {
 			Expect(cli.Create(ctx, &operatorv1.ImageSet{
 				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
@@ -255,6 +283,52 @@ var _ = Describe("LogStorage Linseed controller", func() {
 			Expect(linseed).ToNot(BeNil())
 			Expect(linseed.Image).To(Equal(fmt.Sprintf("some.registry.org/%s@%s", components.ComponentLinseed.Image, "sha256:linseedhash")))
 		})
+
+		It("should write to the external Elasticsearch cluster while the log storage is migrated to it", func() {
+			ls := &operatorv1.LogStorage{}
+			Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+			mode := operatorv1.SecondaryElasticsearchModeExternalMigration
+			ls.Spec.SecondaryElasticsearch = &operatorv1.SecondaryElasticsearch{Endpoint: "https://es.external.example.com:9243", Mode: &mode}
+			Expect(cli.Update(ctx, ls)).ShouldNot(HaveOccurred())
+			Expect(cli.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: logstorage.SecondaryElasticsearchSecret, Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{"username": []byte("external-username"), "password": []byte("external-password")},
+			})).ShouldNot(HaveOccurred())
+
+			result, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result).Should(Equal(successResult))
+
+			linseedDp := appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      linseed.DeploymentName,
+					Namespace: render.ElasticsearchNamespace,
+				},
+			}
+			Expect(test.GetResource(cli, &linseedDp)).To(BeNil())
+			linseed := test.GetContainer(linseedDp.Spec.Template.Spec.Containers, linseed.DeploymentName)
+			Expect(linseed).ToNot(BeNil())
+			Expect(linseed.Env).To(ContainElement(corev1.EnvVar{Name: "ELASTIC_SECONDARY_HOST", Value: "es.external.example.com"}))
+			Expect(linseed.Env).To(ContainElement(corev1.EnvVar{Name: "ELASTIC_SECONDARY_PORT", Value: "9243"}))
+		})
+	})
+
+	It("should stop writing to the secondary cluster once the migration to external Elasticsearch completes", func() {
+		ls := &operatorv1.LogStorage{}
+		Expect(writesToSecondaryElasticsearch(ls, false)).To(BeFalse())
+
+		ls.Spec.SecondaryElasticsearch = &operatorv1.SecondaryElasticsearch{Endpoint: "https://es.example.com:9243"}
+		Expect(writesToSecondaryElasticsearch(ls, false)).To(BeTrue())
+		Expect(writesToSecondaryElasticsearch(ls, true)).To(BeTrue())
+
+		mode := operatorv1.SecondaryElasticsearchModeExternalMigration
+		ls.Spec.SecondaryElasticsearch.Mode = &mode
+		Expect(writesToSecondaryElasticsearch(ls, false)).To(BeTrue())
+		Expect(writesToSecondaryElasticsearch(ls, true)).To(BeFalse())
+
+		mode = operatorv1.SecondaryElasticsearchModeCrossClusterReplication
+		Expect(writesToSecondaryElasticsearch(ls, false)).To(BeFalse())
 	})
 
 	Context("Multi-tenant", func() {
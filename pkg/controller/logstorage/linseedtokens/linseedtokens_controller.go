@@ -0,0 +1,339 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linseedtokens
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/telemetry"
+)
+
+var log = logf.Log.WithName("controller_logstorage_linseedtokens")
+
+const (
+	// TokenLabel is set on the ServiceAccounts, secrets and RBAC of the Linseed tokens to the name of the token, so that
+	// the objects of tokens that are removed from the LogStorage can be found and deleted.
+	TokenLabel = "operator.tigera.io/linseed-token"
+
+	// ExpiryAnnotation is set on the secret of a Linseed token to the time at which the token in it expires.
+	ExpiryAnnotation = "operator.tigera.io/linseed-token-expiry"
+
+	// DefaultExpiry is how long the issued tokens are valid for when the LogStorage doesn't say.
+	DefaultExpiry = 24 * time.Hour
+)
+
+// ResourceName returns the name of the ServiceAccount, secret, ClusterRole and ClusterRoleBinding of the Linseed token
+// with the given name.
+func ResourceName(name string) string {
+	return fmt.Sprintf("tigera-linseed-token-%s", name)
+}
+
+// LinseedTokensController issues the Linseed tokens in the LogStorage spec. Each token is issued for its own
+// ServiceAccount, which is bound to a ClusterRole that can read the resources of the token from Linseed, and is bound to
+// the secret that it is written to, so that deleting the secret revokes the token. The tokens are reissued before they
+// expire, and into a new secret when the secret is deleted. The ServiceAccounts of the tokens that are removed from the
+// LogStorage are deleted, which revokes their tokens.
+type LinseedTokensController struct {
+	client    client.Client
+	k8sClient kubernetes.Interface
+	scheme    *runtime.Scheme
+}
+
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		return nil
+	}
+
+	// In multi-tenant mode, the LogStorage is shared by the tenants, whose Linseeds authorize their own consumers.
+	if opts.MultiTenant {
+		return nil
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+
+	r := &LinseedTokensController{
+		client:    telemetry.Client(mgr.GetClient()),
+		k8sClient: k8sClient,
+		scheme:    mgr.GetScheme(),
+	}
+
+	c, err := ctrlruntime.NewController("log-storage-linseedtokens-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-linseedtokens-controller", r)})
+	if err != nil {
+		return err
+	}
+
+	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-linseedtokens-controller failed to watch LogStorage resource: %w", err)
+	}
+	// Issue the tokens again periodically, so that a token is reissued soon after its secret is deleted, and its
+	// ServiceAccount and RBAC are restored.
+	if err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-linseedtokens-controller failed to create periodic reconcile watch: %w", err)
+	}
+	return nil
+}
+
+func (r *LinseedTokensController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling LogStorage - Linseed tokens")
+
+	ls := &operatorv1.LogStorage{}
+	if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, ls); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if common.IsReconcilePaused(ls) {
+		reqLogger.V(1).Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+		return reconcile.Result{}, nil
+	}
+
+	// Wait for the initializing controller to indicate that the LogStorage object is actionable, which includes the
+	// validation of the tokens.
+	if ls.Status.State != operatorv1.TigeraStatusReady || !ls.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	hdler := utils.NewComponentHandler(reqLogger, r.client, r.scheme, ls)
+	desired := map[types.NamespacedName]bool{}
+	var renewAt time.Time
+	for _, token := range ls.Spec.LinseedTokens {
+		if err := r.client.Get(ctx, client.ObjectKey{Name: token.Namespace}, &corev1.Namespace{}); err != nil {
+			if errors.IsNotFound(err) {
+				reqLogger.Info("Waiting for the namespace of a Linseed token to exist", "token", token.Name, "namespace", token.Namespace)
+				continue
+			}
+			return reconcile.Result{}, err
+		}
+		desired[types.NamespacedName{Name: ResourceName(token.Name), Namespace: token.Namespace}] = true
+
+		if err := hdler.CreateOrUpdateOrDelete(ctx, render.NewPassthrough(tokenObjects(token)...), nil); err != nil {
+			return reconcile.Result{}, err
+		}
+		tokenRenewAt, err := r.issueToken(ctx, ls, token, reqLogger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if renewAt.IsZero() || tokenRenewAt.Before(renewAt) {
+			renewAt = tokenRenewAt
+		}
+	}
+
+	// Delete the objects of the tokens that were removed from the LogStorage. Deleting the ServiceAccount of a token
+	// revokes it.
+	stale, err := r.staleObjects(ctx, desired)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if len(stale) > 0 {
+		reqLogger.Info("Revoking Linseed tokens that were removed from the LogStorage")
+		if err = hdler.CreateOrUpdateOrDelete(ctx, render.NewDeletionPassthrough(stale...), nil); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if renewAt.IsZero() {
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{RequeueAfter: time.Until(renewAt)}, nil
+}
+
+// tokenObjects returns the ServiceAccount that the token is issued for, and the RBAC that lets it read the resources of
+// the token from Linseed.
+func tokenObjects(token operatorv1.LinseedToken) []client.Object {
+	name := ResourceName(token.Name)
+	labels := map[string]string{TokenLabel: token.Name}
+	resources := make([]string, len(token.Resources))
+	for i, resource := range token.Resources {
+		resources[i] = string(resource)
+	}
+	return []client.Object{
+		&corev1.ServiceAccount{
+			TypeMeta:   metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: token.Namespace, Labels: labels},
+		},
+		&rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{"linseed.tigera.io"},
+					Resources: resources,
+					Verbs:     []string{"get"},
+				},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "ClusterRole",
+				Name:     name,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      "ServiceAccount",
+					Name:      name,
+					Namespace: token.Namespace,
+				},
+			},
+		},
+	}
+}
+
+// issueToken writes a new token to the secret of the Linseed token when the secret doesn't hold one that is still
+// valid for at least a fifth of the expiry of the token, and returns the time at which the token must be reissued.
+func (r *LinseedTokensController) issueToken(ctx context.Context, ls *operatorv1.LogStorage, token operatorv1.LinseedToken, reqLogger logr.Logger) (time.Time, error) {
+	expiry := DefaultExpiry
+	if token.Expiry != nil {
+		expiry = token.Expiry.Duration
+	}
+
+	// The token is bound to its secret, which must therefore exist before the token is requested.
+	name := ResourceName(token.Name)
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: name, Namespace: token.Namespace}, secret)
+	if errors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: token.Namespace,
+				Labels:    map[string]string{TokenLabel: token.Name},
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		if err = controllerutil.SetControllerReference(ls, secret, r.scheme); err != nil {
+			return time.Time{}, err
+		}
+		if err = r.client.Create(ctx, secret); err != nil {
+			return time.Time{}, fmt.Errorf("failed to create the secret of Linseed token %s: %w", token.Name, err)
+		}
+	} else if err != nil {
+		return time.Time{}, err
+	}
+
+	if expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[ExpiryAnnotation]); err == nil && len(secret.Data[render.LinseedTokenKey]) > 0 {
+		if renewAt := expiresAt.Add(-expiry / 5); time.Now().Before(renewAt) {
+			return renewAt, nil
+		}
+	}
+
+	reqLogger.Info("Issuing a Linseed token", "token", token.Name, "namespace", token.Namespace)
+	expirationSeconds := int64(expiry.Seconds())
+	tokenRequest, err := r.k8sClient.CoreV1().ServiceAccounts(token.Namespace).CreateToken(ctx, name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expirationSeconds,
+			BoundObjectRef: &authenticationv1.BoundObjectReference{
+				Kind:       "Secret",
+				APIVersion: "v1",
+				Name:       secret.Name,
+				UID:        secret.UID,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to issue Linseed token %s: %w", token.Name, err)
+	}
+
+	expiresAt := tokenRequest.Status.ExpirationTimestamp.Time
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[ExpiryAnnotation] = expiresAt.UTC().Format(time.RFC3339)
+	secret.Data = map[string][]byte{render.LinseedTokenKey: []byte(tokenRequest.Status.Token)}
+	if err = r.client.Update(ctx, secret); err != nil {
+		return time.Time{}, fmt.Errorf("failed to write Linseed token %s: %w", token.Name, err)
+	}
+	return expiresAt.Add(-expiry / 5), nil
+}
+
+// staleObjects returns the objects of the Linseed tokens that are not desired anymore. The namespaced objects are
+// desired when their namespaced name is, and the cluster-scoped objects when that of any token with their name is.
+func (r *LinseedTokensController) staleObjects(ctx context.Context, desired map[types.NamespacedName]bool) ([]client.Object, error) {
+	desiredNames := map[string]bool{}
+	for key := range desired {
+		desiredNames[key.Name] = true
+	}
+
+	var stale []client.Object
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := r.client.List(ctx, serviceAccounts, client.HasLabels{TokenLabel}); err != nil {
+		return nil, err
+	}
+	for i := range serviceAccounts.Items {
+		if !desired[client.ObjectKeyFromObject(&serviceAccounts.Items[i])] {
+			stale = append(stale, &serviceAccounts.Items[i])
+		}
+	}
+	secrets := &corev1.SecretList{}
+	if err := r.client.List(ctx, secrets, client.HasLabels{TokenLabel}); err != nil {
+		return nil, err
+	}
+	for i := range secrets.Items {
+		if !desired[client.ObjectKeyFromObject(&secrets.Items[i])] {
+			stale = append(stale, &secrets.Items[i])
+		}
+	}
+	clusterRoles := &rbacv1.ClusterRoleList{}
+	if err := r.client.List(ctx, clusterRoles, client.HasLabels{TokenLabel}); err != nil {
+		return nil, err
+	}
+	for i := range clusterRoles.Items {
+		if !desiredNames[clusterRoles.Items[i].Name] {
+			stale = append(stale, &clusterRoles.Items[i])
+		}
+	}
+	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+	if err := r.client.List(ctx, clusterRoleBindings, client.HasLabels{TokenLabel}); err != nil {
+		return nil, err
+	}
+	for i := range clusterRoleBindings.Items {
+		if !desiredNames[clusterRoleBindings.Items[i].Name] {
+			stale = append(stale, &clusterRoleBindings.Items[i])
+		}
+	}
+	return stale, nil
+}
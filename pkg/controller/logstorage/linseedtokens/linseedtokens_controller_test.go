@@ -0,0 +1,191 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linseedtokens
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("LogStorage Linseed tokens controller", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		requests []*authenticationv1.TokenRequest
+		r        *LinseedTokensController
+	)
+
+	setTokens := func(tokens ...operatorv1.LinseedToken) {
+		ls := &operatorv1.LogStorage{}
+		Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+		ls.Spec.LinseedTokens = tokens
+		Expect(cli.Update(ctx, ls)).ShouldNot(HaveOccurred())
+	}
+
+	getSecret := func(name, namespace string) *corev1.Secret {
+		secret := &corev1.Secret{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: ResourceName(name), Namespace: namespace}, secret)).ShouldNot(HaveOccurred())
+		return secret
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(rbacv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		ls := &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		for _, ns := range []string{"analytics", "reporting"} {
+			Expect(cli.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})).ShouldNot(HaveOccurred())
+		}
+
+		// Issue a distinct token for each request, which expires when requested.
+		requests = nil
+		k8sClient := kfake.NewSimpleClientset()
+		k8sClient.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "token" {
+				return false, nil, nil
+			}
+			request := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+			requests = append(requests, request)
+			request = request.DeepCopy()
+			request.Status.Token = fmt.Sprintf("token-%d", len(requests))
+			request.Status.ExpirationTimestamp = metav1.NewTime(time.Now().Add(time.Duration(*request.Spec.ExpirationSeconds) * time.Second))
+			return true, request, nil
+		})
+
+		r = &LinseedTokensController{client: cli, k8sClient: k8sClient, scheme: scheme}
+	})
+
+	It("should issue the tokens with RBAC scoped to their resources", func() {
+		setTokens(
+			operatorv1.LinseedToken{Name: "siem", Namespace: "analytics", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceFlows, operatorv1.LinseedResourceAuditLogs}},
+			operatorv1.LinseedToken{Name: "reports", Namespace: "reporting", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceDNSLogs}, Expiry: &metav1.Duration{Duration: time.Hour}},
+		)
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		// The earliest renewal is that of the token that expires after an hour.
+		Expect(result.RequeueAfter).To(BeNumerically("~", 48*time.Minute, time.Minute))
+
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-linseed-token-siem", Namespace: "analytics"}, &corev1.ServiceAccount{})).ShouldNot(HaveOccurred())
+		clusterRole := &rbacv1.ClusterRole{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-linseed-token-siem"}, clusterRole)).ShouldNot(HaveOccurred())
+		Expect(clusterRole.Rules).To(Equal([]rbacv1.PolicyRule{{
+			APIGroups: []string{"linseed.tigera.io"},
+			Resources: []string{"flows", "auditlogs"},
+			Verbs:     []string{"get"},
+		}}))
+		clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-linseed-token-siem"}, clusterRoleBinding)).ShouldNot(HaveOccurred())
+		Expect(clusterRoleBinding.RoleRef.Name).To(Equal("tigera-linseed-token-siem"))
+		Expect(clusterRoleBinding.Subjects).To(Equal([]rbacv1.Subject{{Kind: "ServiceAccount", Name: "tigera-linseed-token-siem", Namespace: "analytics"}}))
+
+		Expect(requests).To(HaveLen(2))
+		Expect(*requests[0].Spec.ExpirationSeconds).To(Equal(int64(DefaultExpiry.Seconds())))
+		Expect(requests[0].Spec.BoundObjectRef).To(Equal(&authenticationv1.BoundObjectReference{Kind: "Secret", APIVersion: "v1", Name: "tigera-linseed-token-siem"}))
+		Expect(*requests[1].Spec.ExpirationSeconds).To(Equal(int64(3600)))
+
+		secret := getSecret("siem", "analytics")
+		Expect(secret.Data).To(Equal(map[string][]byte{render.LinseedTokenKey: []byte("token-1")}))
+		Expect(secret.Annotations).To(HaveKey(ExpiryAnnotation))
+		Expect(getSecret("reports", "reporting").Data).To(Equal(map[string][]byte{render.LinseedTokenKey: []byte("token-2")}))
+	})
+
+	It("should reissue a token only when it is about to expire or its secret is deleted", func() {
+		setTokens(operatorv1.LinseedToken{Name: "siem", Namespace: "analytics", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceFlows}})
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(requests).To(HaveLen(1))
+
+		secret := getSecret("siem", "analytics")
+		secret.Annotations[ExpiryAnnotation] = time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		Expect(cli.Update(ctx, secret)).ShouldNot(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(requests).To(HaveLen(2))
+		Expect(getSecret("siem", "analytics").Data[render.LinseedTokenKey]).To(Equal([]byte("token-2")))
+
+		Expect(cli.Delete(ctx, getSecret("siem", "analytics"))).ShouldNot(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(requests).To(HaveLen(3))
+		Expect(getSecret("siem", "analytics").Data[render.LinseedTokenKey]).To(Equal([]byte("token-3")))
+	})
+
+	It("should revoke the tokens that are removed from the LogStorage", func() {
+		setTokens(
+			operatorv1.LinseedToken{Name: "siem", Namespace: "analytics", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceFlows}},
+			operatorv1.LinseedToken{Name: "reports", Namespace: "reporting", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceDNSLogs}},
+		)
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		setTokens(operatorv1.LinseedToken{Name: "siem", Namespace: "analytics", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceFlows}})
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		for _, obj := range []client.Object{
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "tigera-linseed-token-reports", Namespace: "reporting"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tigera-linseed-token-reports", Namespace: "reporting"}},
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "tigera-linseed-token-reports"}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "tigera-linseed-token-reports"}},
+		} {
+			err = cli.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+			Expect(errors.IsNotFound(err)).To(BeTrue(), obj.GetName())
+		}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-linseed-token-siem", Namespace: "analytics"}, &corev1.ServiceAccount{})).ShouldNot(HaveOccurred())
+		Expect(getSecret("siem", "analytics").Data[render.LinseedTokenKey]).To(Equal([]byte("token-1")))
+	})
+
+	It("should wait for the namespace of a token to exist", func() {
+		setTokens(operatorv1.LinseedToken{Name: "siem", Namespace: "missing", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceFlows}})
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(requests).To(BeEmpty())
+	})
+})
@@ -32,6 +32,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/telemetry"
 )
 
 var log = logf.Log.WithName("controller_logstorage_managed")
@@ -53,14 +54,14 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 	// Create the reconciler
 	r := &LogStorageManagedClusterController{
-		client:        mgr.GetClient(),
+		client:        telemetry.Client(mgr.GetClient()),
 		scheme:        mgr.GetScheme(),
 		clusterDomain: opts.ClusterDomain,
 		provider:      opts.DetectedProvider,
 	}
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("log-storage-managedcluster-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-managedcluster-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-managedcluster-controller", r)})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,217 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readonlyusers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/crypto"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/render"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	"github.com/tigera/operator/pkg/telemetry"
+)
+
+var log = logf.Log.WithName("controller_logstorage_readonlyusers")
+
+// UserLabel is set on the credential secrets of the read-only users to the name of the user, so that the secrets of
+// users that are removed from the LogStorage can be found and deleted.
+const UserLabel = "operator.tigera.io/elasticsearch-read-only-user"
+
+// SecretName returns the name of the secret in the tigera-operator namespace that holds the credentials of the
+// read-only user with the given name.
+func SecretName(name string) string {
+	return fmt.Sprintf("%s%s-elasticsearch-access", utils.ElasticsearchUserNameReadOnlyPrefix, name)
+}
+
+// ReadOnlyUsersController provisions the read-only Elasticsearch users in the LogStorage spec, and deletes the users
+// that are removed from it. The password of each user is generated once and kept in a secret in the tigera-operator
+// namespace. Deleting the secret has a new password generated for the user on the next periodic reconcile.
+type ReadOnlyUsersController struct {
+	client     client.Client
+	scheme     *runtime.Scheme
+	esClientFn utils.ElasticsearchClientCreator
+}
+
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		return nil
+	}
+
+	// In multi-tenant mode, the users of each tenant's Elasticsearch are managed out of band.
+	if opts.MultiTenant {
+		return nil
+	}
+
+	esClientFn, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
+	r := &ReadOnlyUsersController{
+		client:     telemetry.Client(mgr.GetClient()),
+		scheme:     mgr.GetScheme(),
+		esClientFn: esClientFn,
+	}
+
+	c, err := ctrlruntime.NewController("log-storage-readonlyusers-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-readonlyusers-controller", r)})
+	if err != nil {
+		return err
+	}
+
+	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-readonlyusers-controller failed to watch LogStorage resource: %w", err)
+	}
+	if err = c.WatchObject(&esv1.Elasticsearch{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-readonlyusers-controller failed to watch Elasticsearch resource: %w", err)
+	}
+	// Provision the users again periodically, since they are lost when the security index of Elasticsearch is, and
+	// generate new passwords for the users whose secret was deleted.
+	if err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-readonlyusers-controller failed to create periodic reconcile watch: %w", err)
+	}
+	return nil
+}
+
+func (r *ReadOnlyUsersController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling LogStorage - Read-only users")
+
+	ls := &operatorv1.LogStorage{}
+	if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, ls); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if common.IsReconcilePaused(ls) {
+		reqLogger.V(1).Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+		return reconcile.Result{}, nil
+	}
+
+	// Wait for the initializing controller to indicate that the LogStorage object is actionable, which includes the
+	// validation of the users.
+	if ls.Status.State != operatorv1.TigeraStatusReady || !ls.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
+		if len(ls.Spec.ReadOnlyUsers) > 0 {
+			reqLogger.Info("Waiting for Elasticsearch cluster to be operational before provisioning the read-only users")
+		}
+		return utils.RequeueWithBackoff(), nil
+	}
+
+	// Get the existing credentials of the users, and generate the credentials of new users.
+	var users []*utils.User
+	var credentials []client.Object
+	desired := map[string]bool{}
+	for _, u := range ls.Spec.ReadOnlyUsers {
+		user := utils.ReadOnlyUser(u)
+		desired[u.Name] = true
+
+		secret, err := utils.GetSecret(ctx, r.client, SecretName(u.Name), common.OperatorNamespace())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if secret != nil {
+			user.Password = secret.StringData["password"]
+			if user.Password == "" {
+				user.Password = string(secret.Data["password"])
+			}
+		}
+		if user.Password == "" {
+			user.Password = crypto.GeneratePassword(16)
+		}
+		credentials = append(credentials, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      SecretName(u.Name),
+				Namespace: common.OperatorNamespace(),
+				Labels:    map[string]string{UserLabel: u.Name},
+			},
+			StringData: map[string]string{"username": user.Username, "password": user.Password},
+		})
+		users = append(users, user)
+	}
+
+	// Write the credentials before provisioning the users with them, so that a password is never lost.
+	hdler := utils.NewComponentHandler(reqLogger, r.client, r.scheme, ls)
+	if err = hdler.CreateOrUpdateOrDelete(ctx, render.NewPassthrough(credentials...), nil); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	esClient, err := r.esClientFn(r.client, ctx, relasticsearch.InternalElasticEndpoint(ls), false)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err = esClient.CreateUsers(ctx, users...); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to provision the read-only Elasticsearch users: %w", err)
+	}
+
+	// Delete the users that were removed from the LogStorage, and then their credentials.
+	esUsers, err := esClient.GetUsers(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, user := range esUsers {
+		name, ok := strings.CutPrefix(user.Username, utils.ElasticsearchUserNameReadOnlyPrefix)
+		if !ok || desired[name] {
+			continue
+		}
+		reqLogger.Info("Deleting a read-only Elasticsearch user that was removed from the LogStorage", "user", user.Username)
+		if err = esClient.DeleteUser(ctx, &user); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to delete the read-only Elasticsearch user %s: %w", user.Username, err)
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err = r.client.List(ctx, secrets, client.InNamespace(common.OperatorNamespace()), client.HasLabels{UserLabel}); err != nil {
+		return reconcile.Result{}, err
+	}
+	var stale []client.Object
+	for i := range secrets.Items {
+		if !desired[secrets.Items[i].Labels[UserLabel]] {
+			stale = append(stale, &secrets.Items[i])
+		}
+	}
+	if err = hdler.CreateOrUpdateOrDelete(ctx, render.NewDeletionPassthrough(stale...), nil); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readonlyusers
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/testutils"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("LogStorage read-only users controller", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		esClient *testutils.FakeElasticClient
+		r        *ReadOnlyUsersController
+	)
+
+	setUsers := func(users ...operatorv1.ElasticsearchReadOnlyUser) {
+		ls := &operatorv1.LogStorage{}
+		Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+		ls.Spec.ReadOnlyUsers = users
+		Expect(cli.Update(ctx, ls)).ShouldNot(HaveOccurred())
+	}
+
+	getSecret := func(name string) *corev1.Secret {
+		secret, err := utils.GetSecret(ctx, cli, SecretName(name), common.OperatorNamespace())
+		Expect(err).ShouldNot(HaveOccurred())
+		return secret
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(esv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		ls := &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		es := &esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}}
+		es.Status.Phase = esv1.ElasticsearchReadyPhase
+		Expect(cli.Create(ctx, es)).ShouldNot(HaveOccurred())
+
+		esClient = testutils.NewFakeElasticClient()
+		r = &ReadOnlyUsersController{client: cli, scheme: scheme, esClientFn: esClient.Creator()}
+	})
+
+	It("should provision the users with read-only roles scoped to their indices and clusters", func() {
+		setUsers(
+			operatorv1.ElasticsearchReadOnlyUser{Name: "analyst", Indices: []string{"tigera_secure_ee_flows", "tigera_secure_ee_dns"}},
+			operatorv1.ElasticsearchReadOnlyUser{Name: "auditor", Indices: []string{"tigera_secure_ee_audit_*"}, Clusters: []string{"cluster", "managed-1"}},
+		)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		role, ok := esClient.Role("tigera-ee-read-only-analyst")
+		Expect(ok).To(BeTrue())
+		Expect(role.Definition.Cluster).To(BeEmpty())
+		Expect(role.Definition.Indices).To(Equal([]utils.RoleIndex{{
+			Names:      []string{"tigera_secure_ee_flows.*.*", "tigera_secure_ee_dns.*.*"},
+			Privileges: []string{"read", "view_index_metadata"},
+		}}))
+		Expect(role.Definition.Applications).To(Equal([]utils.Application{{
+			Application: "kibana-.kibana",
+			Privileges:  []string{"read"},
+			Resources:   []string{"*"},
+		}}))
+
+		role, ok = esClient.Role("tigera-ee-read-only-auditor")
+		Expect(ok).To(BeTrue())
+		Expect(role.Definition.Indices[0].Names).To(Equal([]string{"tigera_secure_ee_audit_*.cluster.*", "tigera_secure_ee_audit_*.managed-1.*"}))
+
+		for _, name := range []string{"analyst", "auditor"} {
+			secret := getSecret(name)
+			Expect(secret).NotTo(BeNil())
+			Expect(secret.Labels).To(HaveKeyWithValue(UserLabel, name))
+			Expect(secret.OwnerReferences).To(HaveLen(1))
+			Expect(secret.StringData["username"]).To(Equal("tigera-ee-read-only-" + name))
+			Expect(secret.StringData["password"]).To(HaveLen(16))
+
+			user, ok := esClient.User("tigera-ee-read-only-" + name)
+			Expect(ok).To(BeTrue())
+			Expect(user.Password).To(Equal(secret.StringData["password"]))
+		}
+	})
+
+	It("should keep the password of a user", func() {
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: SecretName("analyst"), Namespace: common.OperatorNamespace()},
+			Data:       map[string][]byte{"username": []byte("tigera-ee-read-only-analyst"), "password": []byte("existing-password")},
+		})).ShouldNot(HaveOccurred())
+		setUsers(operatorv1.ElasticsearchReadOnlyUser{Name: "analyst", Indices: []string{"tigera_secure_ee_flows"}})
+
+		for i := 0; i < 2; i++ {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			user, ok := esClient.User("tigera-ee-read-only-analyst")
+			Expect(ok).To(BeTrue())
+			Expect(user.Password).To(Equal("existing-password"))
+		}
+	})
+
+	It("should delete the users that are removed and their credentials", func() {
+		setUsers(
+			operatorv1.ElasticsearchReadOnlyUser{Name: "analyst", Indices: []string{"tigera_secure_ee_flows"}},
+			operatorv1.ElasticsearchReadOnlyUser{Name: "auditor", Indices: []string{"tigera_secure_ee_audit_*"}},
+		)
+		Expect(esClient.CreateUser(ctx, utils.LinseedUser("cluster-id", ""))).ShouldNot(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		setUsers(operatorv1.ElasticsearchReadOnlyUser{Name: "analyst", Indices: []string{"tigera_secure_ee_flows"}})
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, ok := esClient.User("tigera-ee-read-only-auditor")
+		Expect(ok).To(BeFalse())
+		_, ok = esClient.Role("tigera-ee-read-only-auditor")
+		Expect(ok).To(BeFalse())
+		Expect(getSecret("auditor")).To(BeNil())
+
+		_, ok = esClient.User("tigera-ee-read-only-analyst")
+		Expect(ok).To(BeTrue())
+		Expect(getSecret("analyst")).NotTo(BeNil())
+		_, ok = esClient.User(utils.LinseedUser("cluster-id", "").Username)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should keep the credentials of a removed user until the user is deleted", func() {
+		setUsers(operatorv1.ElasticsearchReadOnlyUser{Name: "analyst", Indices: []string{"tigera_secure_ee_flows"}})
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		setUsers()
+		esClient.InjectError(testutils.MethodDeleteUser, fmt.Errorf("unavailable"))
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).Should(HaveOccurred())
+		Expect(getSecret("analyst")).NotTo(BeNil())
+
+		esClient.ClearErrors()
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getSecret("analyst")).To(BeNil())
+	})
+
+	It("should wait for Elasticsearch to be ready", func() {
+		es := &esv1.Elasticsearch{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}, es)).ShouldNot(HaveOccurred())
+		es.Status.Phase = esv1.ElasticsearchApplyingChangesPhase
+		Expect(cli.Update(ctx, es)).ShouldNot(HaveOccurred())
+		setUsers(operatorv1.ElasticsearchReadOnlyUser{Name: "analyst", Indices: []string{"tigera_secure_ee_flows"}})
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.Requeue).To(BeTrue())
+		_, ok := esClient.User("tigera-ee-read-only-analyst")
+		Expect(ok).To(BeFalse())
+		Expect(getSecret("analyst")).To(BeNil())
+	})
+})
@@ -0,0 +1,319 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/logstorage/aliases"
+	logstoragecommon "github.com/tigera/operator/pkg/controller/logstorage/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/render"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	"github.com/tigera/operator/pkg/render/logstorage/dashboards"
+	"github.com/tigera/operator/pkg/telemetry"
+)
+
+var log = logf.Log.WithName("controller_logstorage_restore")
+
+// The index settings that the post-restore reconcile checks on the backing indices of the log aliases.
+const (
+	lifecycleNameSetting  = "index.lifecycle.name"
+	rolloverAliasSetting  = "index.lifecycle.rollover_alias"
+	replicasSetting       = "index.number_of_replicas"
+	lifecyclePolicySuffix = "_policy"
+)
+
+// PostRestoreController checks and repairs the Elasticsearch state that the operator expects after Elasticsearch is
+// restored from a snapshot, when the LogStorage is annotated with common.PostRestoreReconcileAnnotation. A snapshot
+// restore can leave out the ILM policies and the aliases, or bring back indices, settings and users that predate the
+// current configuration. The reconcile runs once for each value of the annotation, and reports what it repaired in the
+// LogStorage status.
+type PostRestoreController struct {
+	client          client.Client
+	esClientFn      utils.ElasticsearchClientCreator
+	multiTenant     bool
+	elasticExternal bool
+}
+
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		return nil
+	}
+
+	esClientFn, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
+	r := &PostRestoreController{
+		client:          telemetry.Client(mgr.GetClient()),
+		esClientFn:      esClientFn,
+		multiTenant:     opts.MultiTenant,
+		elasticExternal: opts.ElasticExternal,
+	}
+
+	c, err := ctrlruntime.NewController("log-storage-restore-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-restore-controller", r)})
+	if err != nil {
+		return err
+	}
+
+	// The reconcile is requested through an annotation on the LogStorage.
+	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-restore-controller failed to watch LogStorage resource: %w", err)
+	}
+	if !opts.ElasticExternal {
+		if err = c.WatchObject(&esv1.Elasticsearch{}, &handler.EnqueueRequestForObject{}); err != nil {
+			return fmt.Errorf("log-storage-restore-controller failed to watch Elasticsearch resource: %w", err)
+		}
+	}
+	return nil
+}
+
+// report collects what the post-restore reconcile repaired and what it could not.
+type report struct {
+	repairs  []string
+	failures []string
+}
+
+func (r *PostRestoreController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling LogStorage - Restore")
+
+	ls := &operatorv1.LogStorage{}
+	if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, ls); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if common.IsReconcilePaused(ls) {
+		reqLogger.V(1).Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+		return reconcile.Result{}, nil
+	}
+
+	token := ls.Annotations[common.PostRestoreReconcileAnnotation]
+	if token == "" || (ls.Status.PostRestoreReconcile != nil && ls.Status.PostRestoreReconcile.Token == token) {
+		return reconcile.Result{}, nil
+	}
+
+	// Wait for the initializing controller to indicate that the LogStorage object is actionable. The LogStorage watch
+	// triggers a reconcile once it is.
+	if ls.Status.State != operatorv1.TigeraStatusReady || !ls.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	if !r.elasticExternal {
+		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
+			reqLogger.Info("Waiting for Elasticsearch cluster to be operational before the post-restore reconcile")
+			return utils.RequeueWithBackoff(), nil
+		}
+	}
+
+	reqLogger.Info("Running the post-restore reconcile", "token", token)
+	rep := &report{}
+
+	// Like the ILM controller, the operator only programs ILM for the Elasticsearch cluster that it provisions in
+	// single-tenant mode.
+	if !r.multiTenant && !r.elasticExternal {
+		esClient, err := r.esClientFn(r.client, ctx, relasticsearch.InternalElasticEndpoint(ls), false)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if err = repairILM(ctx, esClient, ls, rep, reqLogger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	endpoints, err := logstoragecommon.ElasticEndpoints(ctx, r.client, ls, r.multiTenant)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, endpoint := range endpoints {
+		esClient, err := r.esClientFn(r.client, ctx, endpoint, r.elasticExternal)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to create the Elasticsearch client for %s: %w", endpoint, err)
+		}
+		repaired, failed, err := aliases.Repair(ctx, esClient, reqLogger.WithValues("endpoint", endpoint))
+		if err != nil && len(failed) == 0 {
+			// The aliases could not be read, so try again rather than report on a partial reconcile.
+			return reconcile.Result{}, err
+		}
+		rep.repairs = append(rep.repairs, repaired...)
+		rep.failures = append(rep.failures, failed...)
+	}
+
+	// In single-tenant mode, the Elasticsearch users are provisioned by es-kube-controllers instead.
+	if r.multiTenant {
+		if err = r.reprovisionUsers(ctx, ls, rep, reqLogger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	reqLogger.Info("Completed the post-restore reconcile", "token", token, "repairs", len(rep.repairs), "failures", len(rep.failures))
+	ls.Status.PostRestoreReconcile = &operatorv1.PostRestoreReconcileStatus{
+		Token:          token,
+		CompletionTime: metav1.Now(),
+		Repairs:        rep.repairs,
+		Failures:       rep.failures,
+	}
+	if err = r.client.Status().Update(ctx, ls); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// repairILM applies the ILM policies again, since a restore that leaves out the cluster state doesn't bring them back,
+// and binds the backing indices of the log aliases to their policy and alias with the configured number of replicas.
+func repairILM(ctx context.Context, esClient utils.ElasticClient, ls *operatorv1.LogStorage, rep *report, reqLogger logr.Logger) error {
+	if ls.Status.RetentionGuardrail != nil {
+		// Use the retention periods in effect, which the retention guardrail may have shortened.
+		ls = ls.DeepCopy()
+		ls.Spec.Retention = ls.Status.RetentionGuardrail.Retention.DeepCopy()
+	}
+	if err := esClient.SetILMPolicies(ctx, ls); err != nil {
+		return fmt.Errorf("failed to apply the ILM policies: %w", err)
+	}
+	rep.repairs = append(rep.repairs, "Applied the ILM policies of the log indices")
+
+	policies := utils.ILMPolicies(ls)
+	replicas := strconv.Itoa(ls.Replicas())
+	indices, err := esClient.GetIndexSettings(ctx, aliases.LogIndexPattern, lifecycleNameSetting, rolloverAliasSetting, replicasSetting)
+	if err != nil {
+		return fmt.Errorf("failed to get the settings of the log indices: %w", err)
+	}
+	for _, index := range indices {
+		alias, _, ok := aliases.BackingIndexAlias(index.Index)
+		if !ok {
+			continue
+		}
+
+		desired := map[string]string{replicasSetting: replicas}
+		group, _, _ := strings.Cut(alias, ".")
+		if policy := group + lifecyclePolicySuffix; policies[policy] != nil {
+			// Only bind the indices that the operator is allowed to curate, since the policy deletes them.
+			if err = utils.CheckCuratedIndex(ls, index.Index); err != nil {
+				reqLogger.V(1).Info("Not binding the index to its ILM policy", "index", index.Index, "reason", err.Error())
+			} else {
+				desired[lifecycleNameSetting] = policy
+				desired[rolloverAliasSetting] = alias
+			}
+		}
+
+		changes := map[string]string{}
+		var descriptions []string
+		for name, value := range desired {
+			if index.Settings[name] != value {
+				changes[name] = value
+				descriptions = append(descriptions, fmt.Sprintf("%s from %q to %q", name, index.Settings[name], value))
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		sort.Strings(descriptions)
+
+		reqLogger.Info("Repairing the settings of a log index", "index", index.Index, "changes", changes)
+		if err = esClient.PutIndexSettings(ctx, index.Index, changes); err != nil {
+			reqLogger.Error(err, "Failed to repair the settings of a log index", "index", index.Index)
+			rep.failures = append(rep.failures, fmt.Sprintf("Settings of index %s could not be repaired: %v", index.Index, err))
+			continue
+		}
+		rep.repairs = append(rep.repairs, fmt.Sprintf("Changed %s of index %s", strings.Join(descriptions, ", "), index.Index))
+	}
+	return nil
+}
+
+// reprovisionUsers provisions the Elasticsearch users of each tenant again from the credentials that the users controller
+// keeps in the tenant namespace, since a restored security index may hold older passwords or none at all.
+func (r *PostRestoreController) reprovisionUsers(ctx context.Context, ls *operatorv1.LogStorage, rep *report, reqLogger logr.Logger) error {
+	clusterIDConfigMap := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: "cluster-info", Namespace: common.OperatorNamespace()}, clusterIDConfigMap); err != nil {
+		return fmt.Errorf("failed to get the cluster-info ConfigMap: %w", err)
+	}
+	clusterID := clusterIDConfigMap.Data["cluster-id"]
+	if clusterID == "" {
+		return fmt.Errorf("the cluster-info ConfigMap has no cluster-id")
+	}
+
+	tenants := &operatorv1.TenantList{}
+	if err := r.client.List(ctx, tenants); err != nil {
+		return err
+	}
+	for _, tenant := range tenants.Items {
+		if !tenant.DeletionTimestamp.IsZero() {
+			continue
+		}
+		endpoint := relasticsearch.InternalElasticEndpoint(ls)
+		if tenant.Spec.Elastic != nil && tenant.Spec.Elastic.URL != "" {
+			endpoint = tenant.Spec.Elastic.URL
+		}
+		esClient, err := r.esClientFn(r.client, ctx, endpoint, r.elasticExternal)
+		if err != nil {
+			return fmt.Errorf("failed to create the Elasticsearch client for %s: %w", endpoint, err)
+		}
+
+		for _, u := range []struct {
+			secretName string
+			user       *utils.User
+		}{
+			{render.ElasticsearchLinseedUserSecret, utils.LinseedUser(clusterID, tenant.Spec.ID)},
+			{dashboards.ElasticCredentialsSecret, utils.DashboardUser(clusterID, tenant.Spec.ID)},
+		} {
+			user := u.user
+			credentials, err := utils.GetSecret(ctx, r.client, u.secretName, tenant.Namespace)
+			if err != nil {
+				return err
+			}
+			if credentials == nil || len(credentials.Data["password"]) == 0 {
+				// The users controller hasn't generated the credentials yet, and provisions the user once it does.
+				continue
+			}
+			user.Password = string(credentials.Data["password"])
+			if err = esClient.CreateUser(ctx, user); err != nil {
+				reqLogger.Error(err, "Failed to provision an Elasticsearch user", "user", user.Username)
+				rep.failures = append(rep.failures, fmt.Sprintf("User %s could not be provisioned: %v", user.Username, err))
+				continue
+			}
+			rep.repairs = append(rep.repairs, fmt.Sprintf("Provisioned user %s with its current credentials", user.Username))
+		}
+	}
+	return nil
+}
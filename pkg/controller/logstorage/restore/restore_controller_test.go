@@ -0,0 +1,237 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/logstorage/initializer"
+	"github.com/tigera/operator/pkg/controller/testutils"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/render/logstorage/dashboards"
+)
+
+var _ = Describe("LogStorage restore controller", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		esClient *testutils.FakeElasticClient
+		r        *PostRestoreController
+	)
+
+	annotate := func(token string) {
+		ls := &operatorv1.LogStorage{}
+		Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+		ls.Annotations = map[string]string{common.PostRestoreReconcileAnnotation: token}
+		Expect(cli.Update(ctx, ls)).ShouldNot(HaveOccurred())
+	}
+
+	getStatus := func() *operatorv1.PostRestoreReconcileStatus {
+		ls := &operatorv1.LogStorage{}
+		Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+		return ls.Status.PostRestoreReconcile
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(esv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		ls := &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		initializer.FillDefaults(ls)
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		es := &esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}}
+		es.Status.Phase = esv1.ElasticsearchReadyPhase
+		Expect(cli.Create(ctx, es)).ShouldNot(HaveOccurred())
+
+		esClient = testutils.NewFakeElasticClient()
+		r = &PostRestoreController{client: cli, esClientFn: esClient.Creator()}
+	})
+
+	It("should do nothing without the annotation", func() {
+		esClient.InjectError(testutils.MethodSetILMPolicies, fmt.Errorf("should not be called"))
+		esClient.InjectError(testutils.MethodGetIndexAliases, fmt.Errorf("should not be called"))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getStatus()).To(BeNil())
+	})
+
+	It("should repair the ILM policies, index settings and aliases and report the repairs", func() {
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.linseed-20240101-000001", "")
+		esClient.AddWriteIndex("tigera_secure_ee_flows.cluster.linseed-20240102-000002", "tigera_secure_ee_flows.cluster.")
+		Expect(esClient.PutIndexSettings(ctx, "tigera_secure_ee_flows.cluster.linseed-20240102-000002", map[string]string{
+			"index.lifecycle.name":           "tigera_secure_ee_flows_policy",
+			"index.lifecycle.rollover_alias": "tigera_secure_ee_flows.cluster.",
+			"index.number_of_replicas":       "2",
+		})).ShouldNot(HaveOccurred())
+		annotate("restore-1")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(esClient.ILMPolicies()).To(HaveKey("tigera_secure_ee_flows_policy"))
+		for _, index := range []string{"tigera_secure_ee_flows.cluster.linseed-20240101-000001", "tigera_secure_ee_flows.cluster.linseed-20240102-000002"} {
+			Expect(esClient.IndexSettings(index)).To(Equal(map[string]string{
+				"index.lifecycle.name":           "tigera_secure_ee_flows_policy",
+				"index.lifecycle.rollover_alias": "tigera_secure_ee_flows.cluster.",
+				"index.number_of_replicas":       "0",
+			}))
+		}
+		Expect(esClient.AliasIndices("tigera_secure_ee_flows.cluster.")).To(Equal(map[string]bool{
+			"tigera_secure_ee_flows.cluster.linseed-20240101-000001": false,
+			"tigera_secure_ee_flows.cluster.linseed-20240102-000002": true,
+		}))
+
+		status := getStatus()
+		Expect(status).NotTo(BeNil())
+		Expect(status.Token).To(Equal("restore-1"))
+		Expect(status.CompletionTime.IsZero()).To(BeFalse())
+		Expect(status.Failures).To(BeEmpty())
+		Expect(status.Repairs).To(Equal([]string{
+			"Applied the ILM policies of the log indices",
+			`Changed index.lifecycle.name from "" to "tigera_secure_ee_flows_policy", index.lifecycle.rollover_alias from "" to "tigera_secure_ee_flows.cluster.", index.number_of_replicas from "" to "0" of index tigera_secure_ee_flows.cluster.linseed-20240101-000001`,
+			`Changed index.number_of_replicas from "2" to "0" of index tigera_secure_ee_flows.cluster.linseed-20240102-000002`,
+			"Added alias tigera_secure_ee_flows.cluster. to 2 indices with write index tigera_secure_ee_flows.cluster.linseed-20240102-000002",
+		}))
+
+		By("running only once for each value of the annotation")
+		esClient.InjectError(testutils.MethodSetILMPolicies, fmt.Errorf("should not be called"))
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("running again for a new value of the annotation")
+		esClient.ClearErrors()
+		annotate("restore-2")
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		status = getStatus()
+		Expect(status.Token).To(Equal("restore-2"))
+		Expect(status.Repairs).To(Equal([]string{"Applied the ILM policies of the log indices"}))
+	})
+
+	It("should only bind the indices that match the curated index prefixes to their ILM policy", func() {
+		ls := &operatorv1.LogStorage{}
+		Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+		ls.Spec.CuratedIndexPrefixes = []string{"tigera_secure_ee_dns."}
+		Expect(cli.Update(ctx, ls)).ShouldNot(HaveOccurred())
+		esClient.AddWriteIndex("tigera_secure_ee_flows.cluster.linseed-20240101-000001", "tigera_secure_ee_flows.cluster.")
+		esClient.AddWriteIndex("tigera_secure_ee_dns.cluster.linseed-20240101-000001", "tigera_secure_ee_dns.cluster.")
+		annotate("restore-1")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(esClient.IndexSettings("tigera_secure_ee_flows.cluster.linseed-20240101-000001")).To(Equal(map[string]string{
+			"index.number_of_replicas": "0",
+		}))
+		Expect(esClient.IndexSettings("tigera_secure_ee_dns.cluster.linseed-20240101-000001")).To(Equal(map[string]string{
+			"index.lifecycle.name":           "tigera_secure_ee_dns_policy",
+			"index.lifecycle.rollover_alias": "tigera_secure_ee_dns.cluster.",
+			"index.number_of_replicas":       "0",
+		}))
+	})
+
+	It("should report the aliases that can't be repaired", func() {
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.linseed-20240101-000001", "")
+		esClient.AddIndex("tigera_secure_ee_flows.cluster.", "")
+		annotate("restore-1")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getStatus().Failures).To(Equal([]string{
+			"Alias tigera_secure_ee_flows.cluster. can't be added because an index has its name",
+		}))
+	})
+
+	It("should retry without a report when Elasticsearch can't be reached", func() {
+		esClient.InjectError(testutils.MethodGetIndexAliases, fmt.Errorf("connection refused"))
+		annotate("restore-1")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).Should(HaveOccurred())
+		Expect(getStatus()).To(BeNil())
+	})
+
+	It("should wait for Elasticsearch to be ready", func() {
+		es := &esv1.Elasticsearch{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}, es)).ShouldNot(HaveOccurred())
+		es.Status.Phase = esv1.ElasticsearchApplyingChangesPhase
+		Expect(cli.Update(ctx, es)).ShouldNot(HaveOccurred())
+		esClient.InjectError(testutils.MethodSetILMPolicies, fmt.Errorf("should not be called"))
+		annotate("restore-1")
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.Requeue).To(BeTrue())
+		Expect(getStatus()).To(BeNil())
+	})
+
+	It("should provision the users of each tenant again in multi-tenant mode", func() {
+		r.multiTenant = true
+		esClient.InjectError(testutils.MethodSetILMPolicies, fmt.Errorf("should not be called"))
+		Expect(cli.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-info", Namespace: common.OperatorNamespace()},
+			Data:       map[string]string{"cluster-id": "cluster"},
+		})).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, &operatorv1.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-a"},
+			Spec:       operatorv1.TenantSpec{ID: "tenant-a"},
+		})).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchLinseedUserSecret, Namespace: "tenant-a"},
+			Data:       map[string][]byte{"username": []byte("linseed"), "password": []byte("linseed-password")},
+		})).ShouldNot(HaveOccurred())
+		annotate("restore-1")
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		linseedUser := utils.LinseedUser("cluster", "tenant-a")
+		user, ok := esClient.User(linseedUser.Username)
+		Expect(ok).To(BeTrue())
+		Expect(user.Password).To(Equal("linseed-password"))
+
+		// The dashboards credentials haven't been generated yet, so the users controller provisions that user later.
+		_, ok = esClient.User(utils.DashboardUser("cluster", "tenant-a").Username)
+		Expect(ok).To(BeFalse())
+		Expect(cli.Get(ctx, client.ObjectKey{Name: dashboards.ElasticCredentialsSecret, Namespace: "tenant-a"}, &corev1.Secret{})).Should(HaveOccurred())
+
+		Expect(getStatus().Repairs).To(Equal([]string{
+			fmt.Sprintf("Provisioned user %s with its current credentials", linseedUser.Username),
+		}))
+	})
+})
@@ -49,6 +49,7 @@ import (
 	"github.com/tigera/operator/pkg/render/logstorage/kibana"
 	"github.com/tigera/operator/pkg/render/logstorage/linseed"
 	"github.com/tigera/operator/pkg/render/monitor"
+	"github.com/tigera/operator/pkg/telemetry"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
@@ -72,7 +73,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 	// Create the reconciler
 	r := &SecretSubController{
-		client:          mgr.GetClient(),
+		client:          telemetry.Client(mgr.GetClient()),
 		scheme:          mgr.GetScheme(),
 		clusterDomain:   opts.ClusterDomain,
 		multiTenant:     opts.MultiTenant,
@@ -82,7 +83,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	r.status.Run(opts.ShutdownContext)
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("log-storage-secrets-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-secrets-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-secrets-controller", r)})
 	if err != nil {
 		return err
 	}
@@ -192,6 +193,10 @@ func (r *SecretSubController) Reconcile(ctx context.Context, request reconcile.R
 	// We found the LogStorage instance.
 	r.status.OnCRFound()
 
+	if utils.IsReconcilePaused(&ls.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// We skip requests without a namespace specified in multi-tenant setups.
 	if r.multiTenant && request.Namespace == "" {
 		return reconcile.Result{}, nil
@@ -219,7 +224,7 @@ func (r *SecretSubController) Reconcile(ctx context.Context, request reconcile.R
 	_, install, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, reqLogger)
@@ -262,7 +267,7 @@ func (r *SecretSubController) Reconcile(ctx context.Context, request reconcile.R
 	hdler := utils.NewComponentHandler(reqLogger, r.client, r.scheme, ls)
 
 	// Determine if Kibana should be enabled for this cluster.
-	kibanaEnabled := !operatorv1.IsFIPSModeEnabled(install.FIPSMode) && !r.multiTenant
+	kibanaEnabled := !operatorv1.IsFIPSModeEnabled(install.FIPSMode) && !install.Features.KibanaDisabled() && !r.multiTenant
 
 	// Internal ES modes:
 	// - Zero-tenant: everything installed in tigera-elasticsearch/tigera-kibana Namespaces. We need a single trusted bundle in each.
@@ -312,7 +317,7 @@ func (r *SecretSubController) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	// Create secrets for Tigera components.
-	keyPairs, err := r.generateSecrets(reqLogger, helper, cm, managementCluster, install)
+	keyPairs, err := r.generateSecrets(reqLogger, helper, cm, ls, managementCluster, install)
 	if err != nil {
 		// Status manager is handled already, so we can just return
 		return reconcile.Result{}, err
@@ -392,6 +397,7 @@ func (r *SecretSubController) generateSecrets(
 	log logr.Logger,
 	helper utils.NamespaceHelper,
 	cm certificatemanager.CertificateManager,
+	ls *operatorv1.LogStorage,
 	managementCluster *operatorv1.ManagementCluster,
 	install *operatorv1.InstallationSpec,
 ) (*keyPairCollection, error) {
@@ -406,20 +412,34 @@ func (r *SecretSubController) generateSecrets(
 		metricsDNSNames := dns.GetServiceDNSNames(esmetrics.ElasticsearchMetricsName, helper.InstallNamespace(), r.clusterDomain)
 		metricsServerKeyPair, err := cm.GetOrCreateKeyPair(r.client, esmetrics.ElasticsearchMetricsServerTLSSecret, helper.TruthNamespace(), metricsDNSNames)
 		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error finding or creating TLS certificate", err, log)
+			r.setCertificateDegraded(esmetrics.ElasticsearchMetricsServerTLSSecret, err, log)
+			return nil, err
+		}
+		// Prometheus verifies the ES metrics server by its service name.
+		if err = certificatemanager.ValidateBYOKeyPair(metricsServerKeyPair, []string{esmetrics.ElasticsearchMetricsName}); err != nil {
+			r.status.SetDegraded(operatorv1.CertificateError, fmt.Sprintf("Invalid user provided certificate in secret %s", esmetrics.ElasticsearchMetricsServerTLSSecret), err, log)
 			return nil, err
 		}
 		collection.keypairs = append(collection.keypairs, metricsServerKeyPair)
 
-		// For legacy reasons, es-gateway is sitting behind two services: tigera-secure-es-http (where originally ES resided)
-		// and tigera-secure-es-gateway-http.
-		gatewayDNSNames := append(
-			dns.GetServiceDNSNames(render.ElasticsearchServiceName, helper.InstallNamespace(), r.clusterDomain),
-			dns.GetServiceDNSNames(esgateway.ServiceName, helper.InstallNamespace(), r.clusterDomain)...,
-		)
+		// Create a client key pair for ES metrics to authenticate to ES gateway with, if it doesn't use a password.
+		if ls.ElasticsearchMetricsClientCertificate() {
+			metricsClientKeyPair, err := cm.GetOrCreateKeyPair(r.client, esmetrics.ElasticsearchMetricsClientTLSSecret, helper.TruthNamespace(), []string{esmetrics.ElasticsearchMetricsName})
+			if err != nil {
+				r.setCertificateDegraded(esmetrics.ElasticsearchMetricsClientTLSSecret, err, log)
+				return nil, err
+			}
+			collection.keypairs = append(collection.keypairs, metricsClientKeyPair)
+		}
+
+		gatewayDNSNames := esgateway.DNSNames(helper.InstallNamespace(), r.clusterDomain, ls)
 		gatewayKeyPair, err := cm.GetOrCreateKeyPair(r.client, render.TigeraElasticsearchGatewaySecret, helper.TruthNamespace(), gatewayDNSNames)
 		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceCreateError, "Error creating TLS certificate", err, log)
+			r.setCertificateDegraded(render.TigeraElasticsearchGatewaySecret, err, log)
+			return nil, err
+		}
+		if err = certificatemanager.ValidateBYOKeyPair(gatewayKeyPair, esgateway.ClientDNSNames(helper.InstallNamespace(), r.clusterDomain, ls)); err != nil {
+			r.status.SetDegraded(operatorv1.CertificateError, fmt.Sprintf("Invalid user provided certificate in secret %s", render.TigeraElasticsearchGatewaySecret), err, log)
 			return nil, err
 		}
 		collection.keypairs = append(collection.keypairs, gatewayKeyPair)
@@ -450,6 +470,16 @@ func (r *SecretSubController) generateSecrets(
 	return collection, nil
 }
 
+// setCertificateDegraded degrades the status for an error that occurred while getting or creating the named key pair.
+// A user provided certificate without the required key usages is reported as such, rather than as an internal error.
+func (r *SecretSubController) setCertificateDegraded(secretName string, err error, log logr.Logger) {
+	if certificatemanager.IsCertExtKeyUsageError(err) {
+		r.status.SetDegraded(operatorv1.CertificateError, fmt.Sprintf("Invalid user provided certificate in secret %s", secretName), err, log)
+		return
+	}
+	r.status.SetDegraded(operatorv1.ResourceCreateError, "Error creating TLS certificate", err, log)
+}
+
 // collectUpstreamCerts collects certificates generated by upstream components to be added to the trusted bundle
 // provisioned by this controller.
 func (r *SecretSubController) collectUpstreamCerts(log logr.Logger, helper utils.NamespaceHelper, cm certificatemanager.CertificateManager, install *operatorv1.InstallationSpec) (*keyPairCollection, error) {
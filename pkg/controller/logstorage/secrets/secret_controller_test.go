@@ -258,10 +258,10 @@ var _ = Describe("LogStorage Secrets controller", func() {
 		rtest.ExpectBundleContents(bundleKibana, types.NamespacedName{Name: certificatemanagement.CASecretName, Namespace: common.OperatorNamespace()})
 	})
 
-	It("test that LogStorage reconciles if the user-supplied certs have any DNS names", func() {
-		// This test currently just validates that user-provided certs will reconcile and not return an error and won't be
-		// overwritten by the operator. This test will change once we add validation for user-provided certs.
-		esDNSNames := []string{"es.example.com", "192.168.10.10"}
+	It("test that LogStorage reconciles if the user-supplied certs have the DNS names that clients use", func() {
+		// User-provided certs are not overwritten by the operator. The ES gateway cert only needs the names that clients
+		// use to reach it, while the Kibana cert is not validated.
+		esDNSNames := append(esgateway.ClientDNSNames(render.ElasticsearchNamespace, dns.DefaultClusterDomain, nil), "es.example.com")
 		testCA := test.MakeTestCA("logstorage-test")
 		esSecret, err := secret.CreateTLSSecret(testCA,
 			render.TigeraElasticsearchGatewaySecret, common.OperatorNamespace(), "tls.key", "tls.crt",
@@ -479,7 +479,7 @@ var _ = Describe("LogStorage Secrets controller", func() {
 		})
 
 		testCA := test.MakeTestCA("logstorage-test")
-		dnsNames := dns.GetServiceDNSNames(render.ElasticsearchServiceName, render.ElasticsearchNamespace, dns.DefaultClusterDomain)
+		dnsNames := esgateway.ClientDNSNames(render.ElasticsearchNamespace, dns.DefaultClusterDomain, nil)
 		gwSecret, err := secret.CreateTLSSecret(testCA,
 			render.TigeraElasticsearchGatewaySecret,
 			common.OperatorNamespace(),
@@ -504,6 +504,49 @@ var _ = Describe("LogStorage Secrets controller", func() {
 		Expect(secret.GetOwnerReferences()).To(HaveLen(0))
 	})
 
+	It("should degrade if a user supplied ES gateway TLS cert does not have the DNS names that clients use", func() {
+		CreateLogStorage(cli, &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "tigera-secure",
+			},
+			Spec: operatorv1.LogStorageSpec{
+				Nodes: &operatorv1.Nodes{
+					Count: int64(1),
+				},
+				ESGatewayCertificateDNSNames: []string{"gateway.example.com"},
+			},
+			Status: operatorv1.LogStorageStatus{
+				State: operatorv1.TigeraStatusReady,
+			},
+		})
+
+		testCA := test.MakeTestCA("logstorage-test")
+		gwSecret, err := secret.CreateTLSSecret(testCA,
+			render.TigeraElasticsearchGatewaySecret,
+			common.OperatorNamespace(),
+			corev1.TLSPrivateKeyKey,
+			corev1.TLSCertKey,
+			tls.DefaultCertificateDuration,
+			nil,
+			esgateway.ClientDNSNames(render.ElasticsearchNamespace, dns.DefaultClusterDomain, nil)...,
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, gwSecret)).ShouldNot(HaveOccurred())
+
+		r, err := NewSecretControllerWithShims(cli, scheme, mockStatus, operatorv1.ProviderNone, dns.DefaultClusterDomain)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("missing the DNS names: gateway.example.com"))
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.CertificateError, "Invalid user provided certificate in secret tigera-secure-elasticsearch-cert", mock.Anything, mock.Anything)
+
+		// The user supplied cert is left as is.
+		secret := &corev1.Secret{}
+		Expect(cli.Get(ctx, esCertSecretOperKey, secret)).ShouldNot(HaveOccurred())
+		Expect(secret.Data).To(Equal(gwSecret.Data))
+	})
+
 	It("should add OwnerReference to the public elasticsearch TLS cert secret", func() {
 		CreateLogStorage(cli, &operatorv1.LogStorage{
 			ObjectMeta: metav1.ObjectMeta{
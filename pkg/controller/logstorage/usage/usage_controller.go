@@ -0,0 +1,261 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	logstoragecommon "github.com/tigera/operator/pkg/controller/logstorage/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/telemetry"
+)
+
+var log = logf.Log.WithName("controller_logstorage_usage")
+
+const (
+	// collectionInterval is how often the usage of the log indices is collected.
+	collectionInterval = 5 * time.Minute
+
+	// logIndexPattern matches the log indices, in both the multi-index and the single-index format.
+	logIndexPattern = "tigera_secure_ee_*,calico_*"
+
+	multiIndexPrefix  = "tigera_secure_ee_"
+	singleIndexPrefix = "calico_"
+)
+
+var (
+	documentsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tigera_operator_log_storage_documents",
+		Help: "Number of documents in the log indices in Elasticsearch.",
+	}, []string{"index_group", "cluster", "tenant"})
+	bytesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tigera_operator_log_storage_bytes",
+		Help: "Size of the primary shards of the log indices in Elasticsearch, in bytes.",
+	}, []string{"index_group", "cluster", "tenant"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(documentsGauge, bytesGauge)
+}
+
+// UsageController periodically collects the number of documents and bytes held by the log indices in Elasticsearch. The
+// usage is exported as Prometheus metrics per index group, managed cluster and tenant, for chargeback, and summarized in
+// the LogStorage status.
+type UsageController struct {
+	client          client.Client
+	esClientFn      utils.ElasticsearchClientCreator
+	multiTenant     bool
+	elasticExternal bool
+
+	// lastCollected is when the usage was last collected. It is kept in memory rather than read from the LogStorage
+	// status, so that the metrics are populated as soon as the operator starts.
+	lastCollected time.Time
+}
+
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		return nil
+	}
+
+	esClientFn, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
+	r := &UsageController{
+		client:          telemetry.Client(mgr.GetClient()),
+		esClientFn:      esClientFn,
+		multiTenant:     opts.MultiTenant,
+		elasticExternal: opts.ElasticExternal,
+	}
+
+	c, err := ctrlruntime.NewController("log-storage-usage-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-usage-controller", r)})
+	if err != nil {
+		return err
+	}
+
+	// The usage is collected on a timer, so the LogStorage is the only resource that needs to be watched.
+	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("log-storage-usage-controller failed to watch LogStorage resource: %w", err)
+	}
+	return nil
+}
+
+func (r *UsageController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling LogStorage - Usage")
+
+	ls := &operatorv1.LogStorage{}
+	if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, ls); err != nil {
+		if !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		// Stop reporting the usage of a LogStorage that no longer exists.
+		documentsGauge.Reset()
+		bytesGauge.Reset()
+		r.lastCollected = time.Time{}
+		return reconcile.Result{}, nil
+	}
+
+	// Wait for the initializing controller to indicate that the LogStorage object is actionable. The LogStorage watch
+	// triggers a reconcile once it is.
+	if ls.Status.State != operatorv1.TigeraStatusReady || !ls.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	// Updating the LogStorage status triggers a reconcile too, so only collect once the interval has passed.
+	if wait := time.Until(r.lastCollected.Add(collectionInterval)); wait > 0 {
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+
+	if !r.elasticExternal {
+		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
+			reqLogger.V(1).Info("Waiting for Elasticsearch cluster to be operational")
+			return utils.RequeueWithBackoff(), nil
+		}
+	}
+
+	endpoints, err := logstoragecommon.ElasticEndpoints(ctx, r.client, ls, r.multiTenant)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	usage := map[logIndex]*utils.IndexUsage{}
+	for _, endpoint := range endpoints {
+		esClient, err := r.esClientFn(r.client, ctx, endpoint, r.elasticExternal)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to create the Elasticsearch client for %s: %w", endpoint, err)
+		}
+		indices, err := esClient.IndexUsage(ctx, logIndexPattern)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to collect the usage of the log indices at %s: %w", endpoint, err)
+		}
+		for _, index := range indices {
+			key, ok := parseLogIndex(index.Index, r.multiTenant)
+			if !ok {
+				continue
+			}
+			if usage[key] == nil {
+				usage[key] = &utils.IndexUsage{}
+			}
+			usage[key].Documents += index.Documents
+			usage[key].SizeBytes += index.SizeBytes
+		}
+	}
+
+	documentsGauge.Reset()
+	bytesGauge.Reset()
+	for key, u := range usage {
+		documentsGauge.WithLabelValues(key.group, key.cluster, key.tenant).Set(float64(u.Documents))
+		bytesGauge.WithLabelValues(key.group, key.cluster, key.tenant).Set(float64(u.SizeBytes))
+	}
+	r.lastCollected = time.Now()
+
+	ls.Status.Usage = summarize(usage, r.multiTenant)
+	if err = r.client.Status().Update(ctx, ls); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: collectionInterval}, nil
+}
+
+// logIndex identifies the log data that an index holds.
+type logIndex struct {
+	group   string
+	cluster string
+	tenant  string
+}
+
+// parseLogIndex returns the index group, cluster and tenant of a log index. Indices in the multi-index format are named
+// tigera_secure_ee_<group>.[<tenant>.]<cluster>.<suffix>, so their usage can be attributed to a managed cluster and
+// tenant. Indices in the single-index format, calico_<group>.<suffix>, hold the data of all the clusters and tenants,
+// and are returned with an empty cluster and tenant.
+func parseLogIndex(name string, multiTenant bool) (logIndex, bool) {
+	if strings.HasPrefix(name, singleIndexPrefix) {
+		group, _, _ := strings.Cut(strings.TrimPrefix(name, singleIndexPrefix), ".")
+		return logIndex{group: group}, group != ""
+	}
+	if !strings.HasPrefix(name, multiIndexPrefix) {
+		return logIndex{}, false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(name, multiIndexPrefix), ".")
+	if multiTenant {
+		if len(parts) < 4 {
+			return logIndex{}, false
+		}
+		return logIndex{group: parts[0], tenant: parts[1], cluster: parts[2]}, true
+	}
+	if len(parts) < 3 {
+		return logIndex{}, false
+	}
+	return logIndex{group: parts[0], cluster: parts[1]}, true
+}
+
+// summarize totals the usage per index group, and per tenant in multi-tenant mode, for the LogStorage status.
+func summarize(usage map[logIndex]*utils.IndexUsage, multiTenant bool) *operatorv1.LogStorageUsage {
+	groups := map[string]*operatorv1.LogUsage{}
+	tenants := map[string]*operatorv1.LogUsage{}
+	for key, u := range usage {
+		add(groups, key.group, u)
+		if multiTenant && key.tenant != "" {
+			add(tenants, key.tenant, u)
+		}
+	}
+	return &operatorv1.LogStorageUsage{
+		LastCollected: metav1.Now(),
+		IndexGroups:   sorted(groups),
+		Tenants:       sorted(tenants),
+	}
+}
+
+func add(totals map[string]*operatorv1.LogUsage, name string, u *utils.IndexUsage) {
+	if totals[name] == nil {
+		totals[name] = &operatorv1.LogUsage{Name: name}
+	}
+	totals[name].Documents += u.Documents
+	totals[name].SizeBytes += u.SizeBytes
+}
+
+func sorted(totals map[string]*operatorv1.LogUsage) []operatorv1.LogUsage {
+	var usage []operatorv1.LogUsage
+	for _, u := range totals {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Name < usage[j].Name })
+	return usage
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/testutils"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("LogStorage usage controller", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		esClient *testutils.FakeElasticClient
+		r        *UsageController
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(esv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		ls := &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		Expect(cli.Create(ctx, ls)).ShouldNot(HaveOccurred())
+		ls.Status.State = operatorv1.TigeraStatusReady
+		Expect(cli.Status().Update(ctx, ls)).ShouldNot(HaveOccurred())
+
+		es := &esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}}
+		es.Status.Phase = esv1.ElasticsearchReadyPhase
+		Expect(cli.Create(ctx, es)).ShouldNot(HaveOccurred())
+
+		esClient = testutils.NewFakeElasticClient()
+		r = &UsageController{client: cli, esClientFn: esClient.Creator()}
+
+		documentsGauge.Reset()
+		bytesGauge.Reset()
+	})
+
+	getLogStorage := func() *operatorv1.LogStorage {
+		ls := &operatorv1.LogStorage{}
+		Expect(cli.Get(ctx, utils.DefaultTSEEInstanceKey, ls)).ShouldNot(HaveOccurred())
+		return ls
+	}
+
+	It("should export the usage per index group and cluster, and summarize it in the LogStorage status", func() {
+		esClient.SetIndexUsage("tigera_secure_ee_flows.cluster.lma-000001", 100, 4000)
+		esClient.SetIndexUsage("tigera_secure_ee_flows.cluster.lma-000002", 50, 2000)
+		esClient.SetIndexUsage("tigera_secure_ee_flows.managed-a.lma-000001", 10, 400)
+		esClient.SetIndexUsage("tigera_secure_ee_dns.managed-a.lma-000001", 20, 800)
+		esClient.SetIndexUsage("calico_auditlogs_standard.linseed-000001", 5, 200)
+		esClient.SetIndexUsage(".kibana_1", 1000, 100000)
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(collectionInterval))
+
+		Expect(testutil.ToFloat64(documentsGauge.WithLabelValues("flows", "cluster", ""))).To(BeEquivalentTo(150))
+		Expect(testutil.ToFloat64(bytesGauge.WithLabelValues("flows", "cluster", ""))).To(BeEquivalentTo(6000))
+		Expect(testutil.ToFloat64(bytesGauge.WithLabelValues("flows", "managed-a", ""))).To(BeEquivalentTo(400))
+		Expect(testutil.ToFloat64(bytesGauge.WithLabelValues("dns", "managed-a", ""))).To(BeEquivalentTo(800))
+		Expect(testutil.ToFloat64(bytesGauge.WithLabelValues("auditlogs_standard", "", ""))).To(BeEquivalentTo(200))
+		Expect(testutil.CollectAndCount(bytesGauge)).To(Equal(4))
+
+		usage := getLogStorage().Status.Usage
+		Expect(usage).NotTo(BeNil())
+		Expect(usage.LastCollected.IsZero()).To(BeFalse())
+		Expect(usage.IndexGroups).To(Equal([]operatorv1.LogUsage{
+			{Name: "auditlogs_standard", Documents: 5, SizeBytes: 200},
+			{Name: "dns", Documents: 20, SizeBytes: 800},
+			{Name: "flows", Documents: 160, SizeBytes: 6400},
+		}))
+		Expect(usage.Tenants).To(BeEmpty())
+	})
+
+	It("should only collect the usage once per interval", func() {
+		esClient.SetIndexUsage("tigera_secure_ee_flows.cluster.lma-000001", 100, 4000)
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		esClient.SetIndexUsage("tigera_secure_ee_flows.cluster.lma-000001", 200, 8000)
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", collectionInterval))
+		Expect(testutil.ToFloat64(bytesGauge.WithLabelValues("flows", "cluster", ""))).To(BeEquivalentTo(4000))
+
+		r.lastCollected = time.Now().Add(-collectionInterval)
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(testutil.ToFloat64(bytesGauge.WithLabelValues("flows", "cluster", ""))).To(BeEquivalentTo(8000))
+	})
+
+	It("should wait for Elasticsearch to be ready", func() {
+		es := &esv1.Elasticsearch{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: render.ElasticsearchName, Namespace: render.ElasticsearchNamespace}, es)).ShouldNot(HaveOccurred())
+		es.Status.Phase = esv1.ElasticsearchApplyingChangesPhase
+		Expect(cli.Update(ctx, es)).ShouldNot(HaveOccurred())
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).To(Equal(utils.RequeueWithBackoff()))
+		Expect(getLogStorage().Status.Usage).To(BeNil())
+	})
+
+	It("should return an error if the usage can't be collected", func() {
+		esClient.InjectError(testutils.MethodIndexUsage, fmt.Errorf("unavailable"))
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).Should(HaveOccurred())
+		Expect(getLogStorage().Status.Usage).To(BeNil())
+	})
+
+	It("should stop exporting the usage when the LogStorage is deleted", func() {
+		esClient.SetIndexUsage("tigera_secure_ee_flows.cluster.lma-000001", 100, 4000)
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(testutil.CollectAndCount(bytesGauge)).To(Equal(1))
+
+		Expect(cli.Delete(ctx, getLogStorage())).ShouldNot(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(testutil.CollectAndCount(bytesGauge)).To(Equal(0))
+	})
+
+	Context("multi-tenant", func() {
+		BeforeEach(func() {
+			r.multiTenant = true
+			r.elasticExternal = true
+
+			// Each Elasticsearch cluster has its own client.
+			shared := esClient
+			dedicated := testutils.NewFakeElasticClient()
+			r.esClientFn = func(_ client.Client, _ context.Context, endpoint string, _ bool) (utils.ElasticClient, error) {
+				if endpoint == "https://dedicated.example.com:9200" {
+					return dedicated, nil
+				}
+				return shared, nil
+			}
+
+			for _, t := range []struct{ id, url string }{
+				{"tenant-a", "https://shared.example.com:9200"},
+				{"tenant-b", "https://shared.example.com:9200"},
+				{"tenant-c", "https://dedicated.example.com:9200"},
+			} {
+				tenant := &operatorv1.Tenant{
+					ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: t.id},
+					Spec:       operatorv1.TenantSpec{ID: t.id, Elastic: &operatorv1.TenantElasticSpec{URL: t.url}},
+				}
+				Expect(cli.Create(ctx, tenant)).ShouldNot(HaveOccurred())
+			}
+
+			shared.SetIndexUsage("tigera_secure_ee_flows.tenant-a.managed-a.lma-000001", 10, 400)
+			shared.SetIndexUsage("tigera_secure_ee_flows.tenant-b.managed-b.lma-000001", 20, 800)
+			dedicated.SetIndexUsage("tigera_secure_ee_flows.tenant-c.managed-c.lma-000001", 30, 1200)
+			dedicated.SetIndexUsage("tigera_secure_ee_dns.tenant-c.managed-c.lma-000001", 40, 1600)
+		})
+
+		It("should attribute the usage of each Elasticsearch cluster to the tenants", func() {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(testutil.ToFloat64(bytesGauge.WithLabelValues("flows", "managed-a", "tenant-a"))).To(BeEquivalentTo(400))
+			Expect(testutil.ToFloat64(bytesGauge.WithLabelValues("flows", "managed-b", "tenant-b"))).To(BeEquivalentTo(800))
+			Expect(testutil.ToFloat64(bytesGauge.WithLabelValues("dns", "managed-c", "tenant-c"))).To(BeEquivalentTo(1600))
+			Expect(testutil.CollectAndCount(bytesGauge)).To(Equal(4))
+
+			usage := getLogStorage().Status.Usage
+			Expect(usage.IndexGroups).To(Equal([]operatorv1.LogUsage{
+				{Name: "dns", Documents: 40, SizeBytes: 1600},
+				{Name: "flows", Documents: 60, SizeBytes: 2400},
+			}))
+			Expect(usage.Tenants).To(Equal([]operatorv1.LogUsage{
+				{Name: "tenant-a", Documents: 10, SizeBytes: 400},
+				{Name: "tenant-b", Documents: 20, SizeBytes: 800},
+				{Name: "tenant-c", Documents: 70, SizeBytes: 2800},
+			}))
+		})
+	})
+
+	DescribeTable("parsing log index names",
+		func(name string, multiTenant bool, expected logIndex, expectedOK bool) {
+			index, ok := parseLogIndex(name, multiTenant)
+			Expect(ok).To(Equal(expectedOK))
+			Expect(index).To(Equal(expected))
+		},
+		Entry("multi-index", "tigera_secure_ee_flows.cluster.lma-000001", false, logIndex{group: "flows", cluster: "cluster"}, true),
+		Entry("multi-index with a longer suffix", "tigera_secure_ee_audit_kube.cluster.fluentd.lma-000001", false, logIndex{group: "audit_kube", cluster: "cluster"}, true),
+		Entry("multi-index of a tenant", "tigera_secure_ee_dns.tenant.cluster.lma-000001", true, logIndex{group: "dns", tenant: "tenant", cluster: "cluster"}, true),
+		Entry("multi-index without a cluster", "tigera_secure_ee_flows", false, logIndex{}, false),
+		Entry("multi-index without a tenant", "tigera_secure_ee_flows.cluster.lma-000001", true, logIndex{}, false),
+		Entry("single-index", "calico_flowlogs_standard.linseed-000001", false, logIndex{group: "flowlogs_standard"}, true),
+		Entry("single-index in multi-tenant mode", "calico_flowlogs_standard.linseed-000001", true, logIndex{group: "flowlogs_standard"}, true),
+		Entry("other index", ".kibana_1", false, logIndex{}, false),
+	)
+})
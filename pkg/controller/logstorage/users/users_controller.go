@@ -35,6 +35,7 @@ import (
 	"github.com/tigera/operator/pkg/render"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	"github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/telemetry"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -78,19 +79,24 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return nil
 	}
 
+	esClientFn, err := utils.GetElasticsearchClientCreator(opts.ElasticsearchBackend)
+	if err != nil {
+		return err
+	}
+
 	// Create the reconciler
 	r := &UserController{
-		client:          mgr.GetClient(),
+		client:          telemetry.Client(mgr.GetClient()),
 		scheme:          mgr.GetScheme(),
 		multiTenant:     opts.MultiTenant,
 		status:          status.New(mgr.GetClient(), initializer.TigeraStatusLogStorageUsers, opts.KubernetesVersion),
-		esClientFn:      utils.NewElasticClient,
+		esClientFn:      esClientFn,
 		elasticExternal: opts.ElasticExternal,
 	}
 	r.status.Run(opts.ShutdownContext)
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("log-storage-user-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-user-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-user-controller", r)})
 	if err != nil {
 		return err
 	}
@@ -135,14 +141,14 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 	// Now that the users controller is set up, we can also set up the controller that cleans up stale users
 	usersCleanupReconciler := &UsersCleanupController{
-		client:          mgr.GetClient(),
+		client:          telemetry.Client(mgr.GetClient()),
 		scheme:          mgr.GetScheme(),
-		esClientFn:      utils.NewElasticClient,
+		esClientFn:      esClientFn,
 		elasticExternal: opts.ElasticExternal,
 	}
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	usersCleanupController, err := ctrlruntime.NewController("log-storage-cleanup-controller", mgr, controller.Options{Reconciler: usersCleanupReconciler})
+	usersCleanupController, err := ctrlruntime.NewController("log-storage-cleanup-controller", mgr, controller.Options{Reconciler: telemetry.Reconciler("log-storage-cleanup-controller", usersCleanupReconciler)})
 	if err != nil {
 		return err
 	}
@@ -196,22 +202,26 @@ func (r *UserController) Reconcile(ctx context.Context, request reconcile.Reques
 	// We found the LogStorage instance (and Tenant instance if in multi-tenant mode).
 	r.status.OnCRFound()
 
+	if utils.IsReconcilePaused(&logStorage.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// Wait for the initializing controller to indicate that the LogStorage object is actionable.
 	if logStorage.Status.State != operatorv1.TigeraStatusReady {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LogStorage defaulting to occur", nil, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	if !r.elasticExternal {
 		// Wait for Elasticsearch to be installed and available.
 		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
 		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", status.WithCode(operatorv1.ElasticsearchUnavailable, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", nil, reqLogger)
-			return reconcile.Result{}, nil
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", status.WithCode(operatorv1.ElasticsearchNotReady, nil), reqLogger)
+			return utils.RequeueWithBackoff(), nil
 		}
 	}
 
@@ -277,9 +287,9 @@ func (r *UserController) Reconcile(ctx context.Context, request reconcile.Reques
 	}
 
 	if helper.TruthNamespace() != helper.InstallNamespace() {
-		// Copy the credentials into the install namespace.
-		credentialSecrets = append(credentialSecrets, secret.CopyToNamespace(helper.InstallNamespace(), &linseedUserSecret)[0])
-		credentialSecrets = append(credentialSecrets, secret.CopyToNamespace(helper.InstallNamespace(), &dashboardUserSecret)[0])
+		// Mirror the credentials into the install namespace.
+		credentialSecrets = append(credentialSecrets, secret.MirrorToNamespace(helper.InstallNamespace(), &linseedUserSecret)[0])
+		credentialSecrets = append(credentialSecrets, secret.MirrorToNamespace(helper.InstallNamespace(), &dashboardUserSecret)[0])
 	}
 	credentialComponent := render.NewPassthrough(credentialSecrets...)
 
@@ -306,17 +316,15 @@ func (r *UserController) Reconcile(ctx context.Context, request reconcile.Reques
 	}
 
 	// Now that the secret has been created, also provision the user in ES.
-	elasticEndpoint := relasticsearch.ECKElasticEndpoint()
+	elasticEndpoint := relasticsearch.InternalElasticEndpoint(logStorage)
 	if tenant.Spec.Elastic != nil && tenant.Spec.Elastic.URL != "" {
 		elasticEndpoint = tenant.Spec.Elastic.URL
 	}
-	if err = r.createUserLogin(ctx, elasticEndpoint, &linseedUserSecret, linseedUser, reqLogger); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to create Linseed user in ES", err, reqLogger)
-		return reconcile.Result{}, err
-	}
-
-	if err = r.createUserLogin(ctx, elasticEndpoint, &dashboardUserSecret, dashboardUser, reqLogger); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to create Dashboards user in ES", err, reqLogger)
+	if err = r.createUserLogins(ctx, elasticEndpoint, []userLogin{
+		{user: linseedUser, secret: &linseedUserSecret},
+		{user: dashboardUser, secret: &dashboardUserSecret},
+	}, reqLogger); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to create Linseed and Dashboards users in ES", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -325,30 +333,37 @@ func (r *UserController) Reconcile(ctx context.Context, request reconcile.Reques
 	return reconcile.Result{}, nil
 }
 
-func (r *UserController) createUserLogin(ctx context.Context, elasticEndpoint string, secret *corev1.Secret, user *utils.User, reqLogger logr.Logger) error {
+// userLogin is an ES user and the secret that holds its credentials.
+type userLogin struct {
+	user   *utils.User
+	secret *corev1.Secret
+}
+
+// createUserLogins creates the users in ES with the passwords from their secrets. The users are created in a single
+// batch, rather than with one round trip each.
+func (r *UserController) createUserLogins(ctx context.Context, elasticEndpoint string, logins []userLogin, reqLogger logr.Logger) error {
 	esClient, err := r.esClientFn(r.client, ctx, elasticEndpoint, r.elasticExternal)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Failed to connect to Elasticsearch - failed to create the Elasticsearch client", err, reqLogger)
 		return err
 	}
 
-	// Determine the password from the secret.
-	password := secret.StringData["password"]
-	if password == "" {
-		password = string(secret.Data["password"])
-	}
-	if password == "" {
-		return fmt.Errorf("unable to find password in secret")
-	}
-
-	// Create the user in ES.
-	user.Password = password
-	if err = esClient.CreateUser(ctx, user); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to create or update Elasticsearch user", err, reqLogger)
-		return err
+	var users []*utils.User
+	for _, login := range logins {
+		// Determine the password from the secret.
+		password := login.secret.StringData["password"]
+		if password == "" {
+			password = string(login.secret.Data["password"])
+		}
+		if password == "" {
+			return fmt.Errorf("unable to find password in secret %s", login.secret.Name)
+		}
+		login.user.Password = password
+		users = append(users, login.user)
 	}
 
-	return nil
+	// Create the users in ES.
+	return esClient.CreateUsers(ctx, users...)
 }
 
 func (r *UsersCleanupController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
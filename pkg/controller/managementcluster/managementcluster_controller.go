@@ -0,0 +1,187 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managementcluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+)
+
+var log = logf.Log.WithName("controller_managementcluster")
+
+// staleCheckInterval is how often the managed clusters that are disconnected since the address of the management
+// cluster changed are checked. The ManagedCluster resources are served by the API server of Calico, so they are
+// polled rather than watched.
+const staleCheckInterval = time.Minute
+
+// Add creates the management cluster controller, which regenerates the installation manifests of the managed clusters
+// when the address of the ManagementCluster changes, and reports the managed clusters that have not reconnected since.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		// No need to start this controller.
+		return nil
+	}
+
+	r := &ReconcileManagementCluster{client: mgr.GetClient()}
+	c, err := ctrlruntime.NewController("managementcluster-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to create managementcluster-controller: %w", err)
+	}
+
+	if err = c.WatchObject(&operatorv1.ManagementCluster{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("managementcluster-controller failed to watch primary resource: %w", err)
+	}
+	if err = utils.AddPeriodicReconcile(c, staleCheckInterval, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("managementcluster-controller failed to create periodic reconcile watch: %w", err)
+	}
+	return nil
+}
+
+// ReconcileManagementCluster keeps the installation manifests of the managed clusters in line with the address of the
+// ManagementCluster.
+type ReconcileManagementCluster struct {
+	client client.Client
+}
+
+func (r *ReconcileManagementCluster) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	managementCluster, err := utils.GetManagementCluster(ctx, r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	} else if managementCluster == nil {
+		return reconcile.Result{}, nil
+	}
+
+	if common.IsReconcilePaused(managementCluster) {
+		reqLogger.V(1).Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+		return reconcile.Result{}, nil
+	}
+
+	status := managementCluster.Status.DeepCopy()
+	address := managementCluster.Spec.Address
+	if address != status.Address {
+		if status.Address != "" && address != "" {
+			// Managed clusters that were added before the change were given a manifest for the previous address. New
+			// managed clusters are given one for the new address by the API server.
+			reqLogger.Info("The address of the management cluster changed, regenerating the installation manifests of the managed clusters",
+				"previousAddress", status.Address, "address", address)
+			if err = r.regenerateManifests(ctx, status.Address, address); err != nil {
+				return reconcile.Result{}, err
+			}
+			status.PreviousAddress = status.Address
+		}
+		status.Address = address
+	}
+
+	status.StaleManagedClusters = nil
+	if status.PreviousAddress != "" {
+		if status.StaleManagedClusters, err = r.disconnectedManagedClusters(ctx); err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(status.StaleManagedClusters) > 0 {
+			reqLogger.Info("Managed clusters have not reconnected since the address of the management cluster changed",
+				"previousAddress", status.PreviousAddress, "managedClusters", status.StaleManagedClusters)
+		}
+	}
+
+	if !reflect.DeepEqual(*status, managementCluster.Status) {
+		managementCluster.Status = *status
+		if err = r.client.Status().Update(ctx, managementCluster); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update the status of the ManagementCluster: %w", err)
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// regenerateManifests replaces the previous address in the installation manifests of the managed clusters, so that
+// applying them connects the managed clusters to the new address.
+func (r *ReconcileManagementCluster) regenerateManifests(ctx context.Context, previous, address string) error {
+	managedClusters := &v3.ManagedClusterList{}
+	if err := r.client.List(ctx, managedClusters); err != nil {
+		return fmt.Errorf("failed to list ManagedClusters: %w", err)
+	}
+	for i := range managedClusters.Items {
+		mc := &managedClusters.Items[i]
+		manifest := replaceAddress(mc.Spec.InstallationManifest, previous, address)
+		if manifest == mc.Spec.InstallationManifest {
+			continue
+		}
+		mc.Spec.InstallationManifest = manifest
+		if err := r.client.Update(ctx, mc); err != nil {
+			return fmt.Errorf("failed to update the installation manifest of ManagedCluster %s: %w", managedClusterName(mc), err)
+		}
+	}
+	return nil
+}
+
+// disconnectedManagedClusters returns the sorted names of the managed clusters that are not connected.
+func (r *ReconcileManagementCluster) disconnectedManagedClusters(ctx context.Context) ([]string, error) {
+	managedClusters := &v3.ManagedClusterList{}
+	if err := r.client.List(ctx, managedClusters); err != nil {
+		return nil, fmt.Errorf("failed to list ManagedClusters: %w", err)
+	}
+	var names []string
+	for _, mc := range managedClusters.Items {
+		if !connected(&mc) {
+			names = append(names, managedClusterName(&mc))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func connected(mc *v3.ManagedCluster) bool {
+	for _, c := range mc.Status.Conditions {
+		if c.Type == v3.ManagedClusterStatusTypeConnected {
+			return c.Status == v3.ManagedClusterStatusValueTrue
+		}
+	}
+	return false
+}
+
+// managedClusterName returns the name of the managed cluster, prefixed with its namespace in multi-tenant mode, where
+// the managed clusters of each tenant are in the namespace of the tenant.
+func managedClusterName(mc *v3.ManagedCluster) string {
+	if mc.Namespace != "" {
+		return mc.Namespace + "/" + mc.Name
+	}
+	return mc.Name
+}
+
+// replaceAddress replaces the previous address of the management cluster with the new address in the
+// ManagementClusterConnection of an installation manifest.
+func replaceAddress(manifest, previous, address string) string {
+	re := regexp.MustCompile(`(?m)^(\s*managementClusterAddr:\s*["']?)` + regexp.QuoteMeta(previous) + `(["']?\s*)$`)
+	return re.ReplaceAllString(manifest, "${1}"+address+"${2}")
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managementcluster
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+const manifestTemplate = `apiVersion: operator.tigera.io/v1
+kind: ManagementClusterConnection
+metadata:
+  name: tigera-secure
+spec:
+  managementClusterAddr: %s
+  tls:
+    ca: Tigera
+`
+
+var _ = Describe("ManagementCluster controller tests", func() {
+	var r ReconcileManagementCluster
+	var c client.Client
+	var ctx context.Context
+
+	managedCluster := func(name, address string, status v3.ManagedClusterStatusValue) *v3.ManagedCluster {
+		return &v3.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v3.ManagedClusterSpec{InstallationManifest: fmt.Sprintf(manifestTemplate, address)},
+			Status: v3.ManagedClusterStatus{Conditions: []v3.ManagedClusterStatusCondition{
+				{Type: v3.ManagedClusterStatusTypeConnected, Status: status},
+			}},
+		}
+	}
+
+	setAddress := func(address string) {
+		mc := &operatorv1.ManagementCluster{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, mc)).NotTo(HaveOccurred())
+		mc.Spec.Address = address
+		Expect(c.Update(ctx, mc)).NotTo(HaveOccurred())
+	}
+
+	reconcileStatus := func() operatorv1.ManagementClusterStatus {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mc := &operatorv1.ManagementCluster{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, mc)).NotTo(HaveOccurred())
+		return mc.Status
+	}
+
+	manifestOf := func(name string) string {
+		mc := &v3.ManagedCluster{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: name}, mc)).NotTo(HaveOccurred())
+		return mc.Spec.InstallationManifest
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		r = ReconcileManagementCluster{client: c}
+
+		Expect(c.Create(ctx, &operatorv1.ManagementCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec:       operatorv1.ManagementClusterSpec{Address: "mgmt.example.com:30449"},
+		})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, managedCluster("connected", "mgmt.example.com:30449", v3.ManagedClusterStatusValueTrue))).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, managedCluster("disconnected", "mgmt.example.com:30449", v3.ManagedClusterStatusValueFalse))).NotTo(HaveOccurred())
+	})
+
+	It("should do nothing without a ManagementCluster", func() {
+		Expect(c.Delete(ctx, &operatorv1.ManagementCluster{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should record the address without regenerating the manifests the first time", func() {
+		Expect(reconcileStatus()).To(Equal(operatorv1.ManagementClusterStatus{Address: "mgmt.example.com:30449"}))
+		Expect(manifestOf("connected")).To(Equal(fmt.Sprintf(manifestTemplate, "mgmt.example.com:30449")))
+	})
+
+	It("should regenerate the manifests and report the disconnected managed clusters when the address changes", func() {
+		reconcileStatus()
+
+		setAddress("mgmt2.example.com:31449")
+		Expect(reconcileStatus()).To(Equal(operatorv1.ManagementClusterStatus{
+			Address:              "mgmt2.example.com:31449",
+			PreviousAddress:      "mgmt.example.com:30449",
+			StaleManagedClusters: []string{"disconnected"},
+		}))
+		Expect(manifestOf("connected")).To(Equal(fmt.Sprintf(manifestTemplate, "mgmt2.example.com:31449")))
+		Expect(manifestOf("disconnected")).To(Equal(fmt.Sprintf(manifestTemplate, "mgmt2.example.com:31449")))
+
+		By("no longer reporting a managed cluster once it has reconnected")
+		mc := &v3.ManagedCluster{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "disconnected"}, mc)).NotTo(HaveOccurred())
+		mc.Status.Conditions[0].Status = v3.ManagedClusterStatusValueTrue
+		Expect(c.Update(ctx, mc)).NotTo(HaveOccurred())
+		status := reconcileStatus()
+		Expect(status.StaleManagedClusters).To(BeEmpty())
+		Expect(status.PreviousAddress).To(Equal("mgmt.example.com:30449"))
+	})
+
+	It("should only replace the address of the management cluster in the manifests", func() {
+		Expect(replaceAddress(fmt.Sprintf(manifestTemplate, "mgmt.example.com:3044"), "mgmt.example.com:30449", "new:1")).To(
+			Equal(fmt.Sprintf(manifestTemplate, "mgmt.example.com:3044")))
+		Expect(replaceAddress(fmt.Sprintf(manifestTemplate, `"[::1]:30449"`), "[::1]:30449", "[::2]:30449")).To(
+			Equal(fmt.Sprintf(manifestTemplate, `"[::2]:30449"`)))
+	})
+})
@@ -183,7 +183,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	if !opts.ElasticExternal {
-		if err = utils.AddConfigMapWatch(c, eck.LicenseConfigMapName, eck.OperatorNamespace, eventHandler); err != nil {
+		if err = utils.AddConfigMapWatch(c, eck.LicenseConfigMapName, "", eventHandler); err != nil {
 			return fmt.Errorf("manager-controller failed to watch the ConfigMap resource: %v", err)
 		}
 	}
@@ -300,21 +300,25 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 		}
 	}
 
-	if !utils.IsAPIServerReady(r.client, logc) {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", nil, logc)
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, logc) {
 		return reconcile.Result{}, nil
 	}
 
+	if !utils.IsAPIServerReady(r.client, logc) {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", status.WithCode(operatorv1.APIServerNotReady, nil), logc)
+		return utils.RequeueWithBackoff(), nil
+	}
+
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, logc)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), logc)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
 	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, logc)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", status.WithCode(operatorv1.TierNotReady, err), logc)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		} else {
 			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, logc)
@@ -323,7 +327,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 
 	if !r.licenseAPIReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", nil, logc)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", status.WithCode(operatorv1.LicenseAPINotReady, nil), logc)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
@@ -331,7 +335,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 	license, err := utils.FetchLicenseKey(ctx, r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", err, logc)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", status.WithCode(operatorv1.LicenseNotFound, err), logc)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying license", err, logc)
@@ -344,7 +348,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 	variant, installation, err := utils.GetInstallation(ctx, r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, logc)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), logc)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, logc)
@@ -358,20 +362,34 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 	certificateManager, err := certificatemanager.Create(r.client, installation, r.clusterDomain, helper.TruthNamespace(), opts...)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, logc)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), logc)
 		return reconcile.Result{}, err
 	}
 
-	// Get or create a certificate for clients of the manager pod es-proxy container.
+	// Get or create a certificate for clients of the manager pod es-proxy container. The Manager may add the names
+	// that the UI is reached through from outside of the cluster.
+	if err = dns.ValidateDNSNames(instance.Spec.CertificateDNSNames); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid Manager spec.certificateDNSNames", err, logc)
+		return reconcile.Result{}, err
+	}
 	tlsSecret, err := certificateManager.GetOrCreateKeyPair(
 		r.client,
 		render.ManagerTLSSecretName,
 		helper.TruthNamespace(),
-		[]string{"localhost"})
+		append([]string{"localhost"}, instance.Spec.CertificateDNSNames...))
 	if err != nil {
+		if certificatemanager.IsCertExtKeyUsageError(err) {
+			r.status.SetDegraded(operatorv1.CertificateError, fmt.Sprintf("Invalid user provided certificate in secret %s", render.ManagerTLSSecretName), err, logc)
+			return reconcile.Result{}, err
+		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error getting or creating manager TLS certificate", err, logc)
 		return reconcile.Result{}, err
 	}
+	// A user provided certificate need not be valid for localhost, but it must cover the names that are configured.
+	if err = certificatemanager.ValidateBYOKeyPair(tlsSecret, instance.Spec.CertificateDNSNames); err != nil {
+		r.status.SetDegraded(operatorv1.CertificateError, fmt.Sprintf("Invalid user provided certificate in secret %s", render.ManagerTLSSecretName), err, logc)
+		return reconcile.Result{}, err
+	}
 
 	// Get or create a certificate for the manager pod to use within the cluster.
 	dnsNames := dns.GetServiceDNSNames(render.ManagerServiceName, helper.InstallNamespace(), r.clusterDomain)
@@ -442,7 +460,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 			// Check that compliance is running.
 			if complianceCR.Status.State != operatorv1.TigeraStatusReady {
 				r.status.SetDegraded(operatorv1.ResourceNotReady, "Compliance is not ready", nil, logc)
-				return reconcile.Result{}, nil
+				return utils.RequeueWithBackoff(), nil
 			}
 			trustedSecretNames = append(trustedSecretNames, render.ComplianceServerCertSecret)
 		}
@@ -457,7 +475,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 	if authenticationCR != nil && authenticationCR.Status.State != operatorv1.TigeraStatusReady {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Authentication is not ready authenticationCR status: %s", authenticationCR.Status.State), nil, logc)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	} else if authenticationCR != nil && !utils.IsDexDisabled(authenticationCR) {
 		// Do not include DEX TLS Secret Name is authentication CR does not have type Dex
 		trustedSecretNames = append(trustedSecretNames, render.DexTLSSecretName)
@@ -472,7 +490,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 		} else if certificate == nil {
 			logc.Info(fmt.Sprintf("Waiting for secret '%s' to become available", secret))
 			r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for secret '%s' to become available", secret), nil, logc)
-			return reconcile.Result{}, nil
+			return utils.RequeueWithBackoff(), nil
 		}
 		bundleMaker.AddCertificates(certificate)
 	}
@@ -494,7 +512,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 	pullSecrets, err := utils.GetNetworkingPullSecrets(installation, r.client)
 	if err != nil {
 		log.Error(err, "Error with Pull secrets")
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, logc)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), logc)
 		return reconcile.Result{}, err
 	}
 
@@ -556,7 +574,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 		// This certificate will also be presented by Voltron to prove its identity to managed clusters.
 		tunnelCASecret, err := utils.GetSecret(ctx, r.client, tunnelSecretName, helper.TruthNamespace())
 		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Unable to fetch the tunnel secret", err, logc)
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Unable to fetch the tunnel secret", status.WithCode(operatorv1.SecretNotAvailable, err), logc)
 			return reconcile.Result{}, err
 		}
 
@@ -674,7 +692,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, logc)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), logc)
 		return reconcile.Result{}, err
 	}
 
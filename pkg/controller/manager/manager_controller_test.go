@@ -331,6 +331,51 @@ var _ = Describe("Manager controller tests", func() {
 			Expect(c.Get(ctx, types.NamespacedName{Name: render.ManagerInternalTLSSecretName, Namespace: render.ManagerNamespace}, internalSecret)).ShouldNot(HaveOccurred())
 		})
 
+		It("should add the configured certificate DNS names to the operator-managed manager TLS cert", func() {
+			cr.Spec.CertificateDNSNames = []string{"manager.example.com"}
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: render.ManagerTLSSecretName, Namespace: common.OperatorNamespace()}, secret)).ShouldNot(HaveOccurred())
+			test.VerifyCert(secret, "localhost", "manager.example.com")
+		})
+
+		It("should degrade if the configured certificate DNS names are invalid", func() {
+			cr.Spec.CertificateDNSNames = []string{"192.168.10.22"}
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid Manager spec.certificateDNSNames", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).Should(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid Manager spec.certificateDNSNames", mock.Anything, mock.Anything)
+		})
+
+		It("should degrade if a user supplied manager TLS cert does not have the configured certificate DNS names", func() {
+			testCA := test.MakeTestCA("manager-test")
+			userSecret, err := secret.CreateTLSSecret(
+				testCA, render.ManagerTLSSecretName, common.OperatorNamespace(), corev1.TLSPrivateKeyKey, corev1.TLSCertKey, tigeratls.DefaultCertificateDuration, nil, "manager.example.com")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(c.Create(ctx, userSecret)).NotTo(HaveOccurred())
+
+			cr.Spec.CertificateDNSNames = []string{"manager.example.org"}
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.CertificateError, "Invalid user provided certificate in secret manager-tls", mock.Anything, mock.Anything).Return()
+
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("missing the DNS names: manager.example.org"))
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.CertificateError, "Invalid user provided certificate in secret manager-tls", mock.Anything, mock.Anything)
+
+			// A cert that covers the configured names is accepted, even without localhost.
+			cr.Spec.CertificateDNSNames = []string{"manager.example.com"}
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
 		It("should not add OwnerReference to an user supplied manager TLS cert", func() {
 			// Create a manager cert secret.
 			dnsNames := []string{"manager.example.com", "192.168.10.22"}
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -157,6 +158,12 @@ func add(_ manager.Manager, c ctrlruntime.Controller) error {
 		}
 	}
 
+	// The secrets that the Alertmanager receivers, the remote write endpoints and the Thanos object storage of the
+	// Monitor refer to can have any name, so watch all the secrets in the tigera-operator namespace.
+	if err = utils.AddSecretsWatch(c, "", common.OperatorNamespace()); err != nil {
+		return fmt.Errorf("monitor-controller failed to watch secrets: %w", err)
+	}
+
 	// Namespaces are watched in case external monitoring config is used.
 	err = c.WatchObject(&corev1.Namespace{}, &handler.EnqueueRequestForObject{})
 	if err != nil {
@@ -168,6 +175,12 @@ func add(_ manager.Manager, c ctrlruntime.Controller) error {
 		return fmt.Errorf("monitor-controller failed to watch resource: %w", err)
 	}
 
+	// The LogStorage configures the metrics of es-metrics that Prometheus collects.
+	err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return fmt.Errorf("monitor-controller failed to watch LogStorage resource: %w", err)
+	}
+
 	// Watch for changes to TigeraStatus.
 	if err = utils.AddTigeraStatusWatch(c, ResourceName); err != nil {
 		return fmt.Errorf("monitor-controller failed to watch monitor Tigerastatus: %w", err)
@@ -231,6 +244,11 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 			return reconcile.Result{}, err
 		}
 	}
+
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	preDefaultPatchFrom := client.MergeFrom(instance.DeepCopy())
 	fillDefaults(instance)
 	// Patch the monitor resource with defaults added.
@@ -238,6 +256,10 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to write defaults", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+	if err = validateMonitor(instance); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid Monitor spec", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
 	if instance.Spec.ExternalPrometheus != nil {
 		if err = r.client.Get(ctx, client.ObjectKey{Name: instance.Spec.ExternalPrometheus.Namespace}, &corev1.Namespace{}); err != nil {
 			r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Failed to get external prometheus namespace %s",
@@ -249,7 +271,7 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 	variant, install, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to query Installation", err, reqLogger)
@@ -258,10 +280,17 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(install, r.client)
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
+	if !instance.Spec.Prometheus.InClusterPrometheusEnabled() && install.CertificateManagement != nil {
+		// With certificate management the private key of the client certificate never leaves the Prometheus pod, so it
+		// cannot be shared with the external Prometheus.
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Disabling the in-cluster Prometheus is not supported with certificate management", nil, reqLogger)
+		return reconcile.Result{}, nil
+	}
+
 	if !r.prometheusReady.IsReady() {
 		err = fmt.Errorf("waiting for Prometheus resources")
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Prometheus resources to be ready", err, reqLogger)
@@ -270,7 +299,7 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 
 	certificateManager, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace())
 	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -322,7 +351,7 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 	if authenticationCR != nil && authenticationCR.Status.State != operatorv1.TigeraStatusReady {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Authentication is not ready - authenticationCR status: %s", authenticationCR.Status.State), err, reqLogger)
-		return reconcile.Result{}, nil
+		return utils.RequeueWithBackoff(), nil
 	}
 
 	keyValidatorConfig, err := utils.GetKeyValidatorConfig(ctx, r.client, authenticationCR, r.clusterDomain)
@@ -333,7 +362,7 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
@@ -354,30 +383,74 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 	// Create a component handler to manage the rendered component.
 	hdler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
 
-	alertmanagerConfigSecret, createInOperatorNamespace, err := r.readAlertmanagerConfigSecret(ctx)
+	var alertmanagerConfigSecret *corev1.Secret
+	var createInOperatorNamespace bool
+	if configuration := instance.Spec.AlertManager.GetConfiguration(); configuration != nil {
+		alertmanagerConfigSecret, err = r.renderAlertmanagerConfigSecret(ctx, configuration)
+	} else {
+		alertmanagerConfigSecret, createInOperatorNamespace, err = r.readAlertmanagerConfigSecret(ctx)
+	}
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving Alertmanager configuration secret", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
+	remoteWriteSecrets, err := r.readRemoteWriteSecrets(ctx, instance.Spec.Prometheus.GetRemoteWrite())
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving Prometheus remote write secrets", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	thanosSecret, err := r.readThanosSecret(ctx, instance.Spec.Prometheus.GetThanos())
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving Thanos object storage secret", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	dashboardNamespaces, err := r.readGrafanaDashboardNamespaces(ctx)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying Grafana dashboard ConfigMaps", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
 	kubeControllersMetricsPort, err := utils.GetKubeControllerMetricsPort(ctx, r.client)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Unable to read KubeControllersConfiguration", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
+	var esMetricsCollection *operatorv1.ElasticsearchMetricsCollection
+	logStorage := &operatorv1.LogStorage{}
+	if err = r.client.Get(ctx, utils.DefaultTSEEInstanceKey, logStorage); err == nil {
+		esMetricsCollection = logStorage.Spec.ElasticsearchMetricsCollection
+	} else if !errors.IsNotFound(err) {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying LogStorage", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	prometheusStatefulSet := types.NamespacedName{Namespace: common.TigeraPrometheusNamespace, Name: fmt.Sprintf("prometheus-%s", monitor.CalicoNodePrometheus)}
+	if instance.Spec.Prometheus.InClusterPrometheusEnabled() {
+		r.status.AddStatefulSets([]types.NamespacedName{prometheusStatefulSet})
+	} else {
+		r.status.RemoveStatefulSets(prometheusStatefulSet)
+	}
+
 	monitorCfg := &monitor.Config{
-		Monitor:                  instance.Spec,
-		Installation:             install,
-		PullSecrets:              pullSecrets,
-		AlertmanagerConfigSecret: alertmanagerConfigSecret,
-		KeyValidatorConfig:       keyValidatorConfig,
-		ServerTLSSecret:          serverTLSSecret,
-		ClientTLSSecret:          clientTLSSecret,
-		ClusterDomain:            r.clusterDomain,
-		TrustedCertBundle:        trustedBundle,
-		OpenShift:                r.provider.IsOpenShift(),
-		KubeControllerPort:       kubeControllersMetricsPort,
+		Monitor:                        instance.Spec,
+		Installation:                   install,
+		PullSecrets:                    pullSecrets,
+		AlertmanagerConfigSecret:       alertmanagerConfigSecret,
+		RemoteWriteSecrets:             remoteWriteSecrets,
+		ThanosSecret:                   thanosSecret,
+		GrafanaDashboardNamespaces:     dashboardNamespaces,
+		KeyValidatorConfig:             keyValidatorConfig,
+		ServerTLSSecret:                serverTLSSecret,
+		ClientTLSSecret:                clientTLSSecret,
+		ClusterDomain:                  r.clusterDomain,
+		TrustedCertBundle:              trustedBundle,
+		OpenShift:                      r.provider.IsOpenShift(),
+		KubeControllerPort:             kubeControllersMetricsPort,
+		ElasticsearchMetricsCollection: esMetricsCollection,
 	}
 
 	// Render prometheus component
@@ -407,7 +480,7 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, components...); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -468,6 +541,145 @@ func fillDefaults(instance *operatorv1.Monitor) {
 	}
 }
 
+// validateMonitor validates the parts of the Monitor spec that cannot be expressed in the CRD schema.
+func validateMonitor(instance *operatorv1.Monitor) error {
+	if !instance.Spec.Prometheus.InClusterPrometheusEnabled() && instance.Spec.ExternalPrometheus == nil {
+		return fmt.Errorf("spec.externalPrometheus must be specified when the in-cluster Prometheus is disabled")
+	}
+	if !instance.Spec.Prometheus.InClusterPrometheusEnabled() && instance.Spec.Prometheus.GetThanos() != nil {
+		return fmt.Errorf("spec.prometheus.spec.thanos cannot be specified when the in-cluster Prometheus is disabled")
+	}
+	if configuration := instance.Spec.AlertManager.GetConfiguration(); configuration != nil {
+		if err := validateAlertmanagerConfiguration(configuration); err != nil {
+			return err
+		}
+	}
+	for _, rw := range instance.Spec.Prometheus.GetRemoteWrite() {
+		if rw.BearerTokenSecret != nil && rw.BasicAuth != nil {
+			return fmt.Errorf("remote write %s: bearerTokenSecret and basicAuth are mutually exclusive", rw.URL)
+		}
+		if tls := rw.TLS; tls != nil && (tls.CertSecret == nil) != (tls.KeySecret == nil) {
+			return fmt.Errorf("remote write %s: tls.certSecret and tls.keySecret must be specified together", rw.URL)
+		}
+	}
+	return nil
+}
+
+// validateAlertmanagerConfiguration validates that the routes refer to existing receivers and that the receivers are
+// complete.
+func validateAlertmanagerConfiguration(configuration *operatorv1.AlertmanagerConfiguration) error {
+	receivers := map[string]bool{}
+	for _, r := range configuration.Receivers {
+		if receivers[r.Name] {
+			return fmt.Errorf("alertmanager receiver %s is defined more than once", r.Name)
+		}
+		receivers[r.Name] = true
+		for _, c := range r.PagerDutyConfigs {
+			if (c.RoutingKey == nil) == (c.ServiceKey == nil) {
+				return fmt.Errorf("alertmanager receiver %s: exactly one of routingKey and serviceKey must be specified", r.Name)
+			}
+		}
+		for _, c := range r.WebhookConfigs {
+			if (c.URL == "") == (c.URLSecret == nil) {
+				return fmt.Errorf("alertmanager receiver %s: exactly one of url and urlSecret must be specified", r.Name)
+			}
+		}
+	}
+	routeReceivers := []string{configuration.Route.Receiver}
+	for _, r := range configuration.Route.Routes {
+		routeReceivers = append(routeReceivers, r.Receiver)
+	}
+	for _, name := range routeReceivers {
+		if !receivers[name] {
+			return fmt.Errorf("alertmanager route refers to undefined receiver %s", name)
+		}
+	}
+	return nil
+}
+
+// renderAlertmanagerConfigSecret generates the Alertmanager configuration secret from the routes and receivers that are
+// declared in the Monitor.
+func (r *ReconcileMonitor) renderAlertmanagerConfigSecret(ctx context.Context, configuration *operatorv1.AlertmanagerConfiguration) (*corev1.Secret, error) {
+	secrets := map[string]*corev1.Secret{}
+	for _, name := range monitor.AlertmanagerConfigSecretNames(configuration) {
+		secret, err := utils.GetSecret(ctx, r.client, name, common.OperatorNamespace())
+		if err != nil {
+			return nil, err
+		} else if secret == nil {
+			return nil, fmt.Errorf("secret %s/%s not found", common.OperatorNamespace(), name)
+		}
+		secrets[name] = secret
+	}
+	return monitor.AlertmanagerConfigurationSecret(configuration, secrets)
+}
+
+// readRemoteWriteSecrets retrieves the secrets that are referenced by the remote write endpoints from the
+// tigera-operator namespace.
+func (r *ReconcileMonitor) readRemoteWriteSecrets(ctx context.Context, remoteWrite []operatorv1.RemoteWriteSpec) ([]*corev1.Secret, error) {
+	var names []string
+	seen := map[string]bool{}
+	for _, rw := range remoteWrite {
+		var selectors []*corev1.SecretKeySelector
+		selectors = append(selectors, rw.BearerTokenSecret)
+		if rw.BasicAuth != nil {
+			selectors = append(selectors, &rw.BasicAuth.Username, &rw.BasicAuth.Password)
+		}
+		if rw.TLS != nil {
+			selectors = append(selectors, rw.TLS.CASecret, rw.TLS.CertSecret, rw.TLS.KeySecret)
+		}
+		for _, selector := range selectors {
+			if selector != nil && !seen[selector.Name] {
+				seen[selector.Name] = true
+				names = append(names, selector.Name)
+			}
+		}
+	}
+
+	var secrets []*corev1.Secret
+	for _, name := range names {
+		secret, err := utils.GetSecret(ctx, r.client, name, common.OperatorNamespace())
+		if err != nil {
+			return nil, err
+		} else if secret == nil {
+			return nil, fmt.Errorf("secret %s/%s not found", common.OperatorNamespace(), name)
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// readThanosSecret retrieves the secret that holds the Thanos object storage configuration from the tigera-operator
+// namespace.
+func (r *ReconcileMonitor) readThanosSecret(ctx context.Context, thanos *operatorv1.Thanos) (*corev1.Secret, error) {
+	if thanos == nil {
+		return nil, nil
+	}
+	name := thanos.ObjectStorageConfig.Name
+	secret, err := utils.GetSecret(ctx, r.client, name, common.OperatorNamespace())
+	if err != nil {
+		return nil, err
+	} else if secret == nil {
+		return nil, fmt.Errorf("secret %s/%s not found", common.OperatorNamespace(), name)
+	} else if _, ok := secret.Data[thanos.ObjectStorageConfig.Key]; !ok {
+		return nil, fmt.Errorf("secret %s/%s does not contain key %s", common.OperatorNamespace(), name, thanos.ObjectStorageConfig.Key)
+	}
+	return secret, nil
+}
+
+// readGrafanaDashboardNamespaces returns the namespaces that hold the Grafana dashboard ConfigMaps rendered by the
+// operator, so that the dashboards can be deleted from a namespace that is no longer configured.
+func (r *ReconcileMonitor) readGrafanaDashboardNamespaces(ctx context.Context) ([]string, error) {
+	cms := &corev1.ConfigMapList{}
+	if err := r.client.List(ctx, cms, client.HasLabels{monitor.GrafanaDashboardLabel}); err != nil {
+		return nil, err
+	}
+	namespaces := sets.New[string]()
+	for _, cm := range cms.Items {
+		namespaces.Insert(cm.Namespace)
+	}
+	return sets.List(namespaces), nil
+}
+
 // PrometheusTLSServerDNSNames returns all the DNS names valid for the prometheus server TLS asset.
 func PrometheusTLSServerDNSNames(clusterDomain string) []string {
 	return dns.GetServiceDNSNames(monitor.PrometheusServiceServiceName, common.TigeraPrometheusNamespace, clusterDomain)
@@ -522,7 +734,7 @@ func (r *ReconcileMonitor) readAlertmanagerConfigSecret(ctx context.Context) (*c
 	secret, err = utils.GetSecret(ctx, r.client, monitor.AlertmanagerConfigSecret, common.TigeraPrometheusNamespace)
 	if err != nil {
 		return nil, false, err
-	} else if secret != nil {
+	} else if secret != nil && secret.Annotations[monitor.AlertmanagerGeneratedConfigAnnotation] == "" {
 		// Monitor controller will own the secret if it is the same.
 		if reflect.DeepEqual(defaultConfigSecret.Data, secret.Data) {
 			return rsecret.CopyToNamespace(common.OperatorNamespace(), secret)[0], true, nil
@@ -536,7 +748,8 @@ func (r *ReconcileMonitor) readAlertmanagerConfigSecret(ctx context.Context) (*c
 		return s, false, nil
 	}
 
-	// Alertmanager configuration secret is not found in the tigera-operator or tigera-prometheus namespace (new install).
-	// Operator should create a new default secret and set the owner reference.
+	// Alertmanager configuration secret is not found in the tigera-operator or tigera-prometheus namespace (new install),
+	// or the one in the tigera-prometheus namespace was generated from a configuration that has since been removed from
+	// the Monitor. Operator should create a new default secret and set the owner reference.
 	return defaultConfigSecret, true, nil
 }
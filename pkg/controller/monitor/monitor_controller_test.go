@@ -26,6 +26,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -43,6 +44,7 @@ import (
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/monitor"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 	"github.com/tigera/operator/test"
 )
 
@@ -229,7 +231,7 @@ var _ = Describe("Monitor controller tests", func() {
 
 				Expect(serviceMonitor.Spec.Endpoints).To(HaveLen(1))
 				// Verify that the default settings are propagated.
-				Expect(serviceMonitor.Labels).To(Equal(map[string]string{render.AppLabelName: monitor.TigeraExternalPrometheus}))
+				Expect(serviceMonitor.Labels).To(HaveKeyWithValue(render.AppLabelName, monitor.TigeraExternalPrometheus))
 				Expect(serviceMonitor.Spec.Endpoints[0]).To(Equal(monitoringv1.Endpoint{
 					Params: map[string][]string{"match[]": {"{__name__=~\".+\"}"}},
 					Port:   "web",
@@ -255,6 +257,148 @@ var _ = Describe("Monitor controller tests", func() {
 					},
 				}))
 			})
+
+			It("should render the ServiceMonitors in the external namespace when the in-cluster Prometheus is disabled", func() {
+				mockStatus.On("RemoveStatefulSets", mock.Anything)
+				Expect(r.client.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "external-prometheus"}})).NotTo(HaveOccurred())
+				disabled := operatorv1.PrometheusStateDisabled
+				monitorCR.Spec.Prometheus = &operatorv1.Prometheus{State: &disabled}
+				monitorCR.Spec.ExternalPrometheus = &operatorv1.ExternalPrometheus{
+					ServiceMonitor: &operatorv1.ServiceMonitor{},
+					Namespace:      "external-prometheus",
+				}
+				Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+				_, err := r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).NotTo(HaveOccurred())
+				mockStatus.AssertCalled(GinkgoT(), "RemoveStatefulSets", []types.NamespacedName{
+					{Namespace: common.TigeraPrometheusNamespace, Name: "prometheus-" + monitor.CalicoNodePrometheus},
+				})
+
+				// The in-cluster Prometheus and the federation ServiceMonitor should not be rendered.
+				Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.CalicoNodePrometheus, Namespace: common.TigeraPrometheusNamespace}, p)).To(HaveOccurred())
+				Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.CalicoNodeMonitor, Namespace: common.TigeraPrometheusNamespace}, sm)).To(HaveOccurred())
+				Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-external-prometheus", Namespace: "external-prometheus"}, sm)).To(HaveOccurred())
+
+				// The ServiceMonitors, rules and the assets they reference should be rendered in the external namespace.
+				Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.CalicoNodeMonitor, Namespace: "external-prometheus"}, sm)).NotTo(HaveOccurred())
+				Expect(sm.Labels).To(HaveKeyWithValue(render.AppLabelName, monitor.TigeraExternalPrometheus))
+				Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.TigeraPrometheusDPRate, Namespace: "external-prometheus"}, pr)).NotTo(HaveOccurred())
+				Expect(cli.Get(ctx, client.ObjectKey{Name: certificatemanagement.TrustedCertConfigMapName, Namespace: "external-prometheus"}, &corev1.ConfigMap{})).NotTo(HaveOccurred())
+				// The client key of Prometheus is not exported unless asked for.
+				Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.PrometheusClientTLSSecretName, Namespace: "external-prometheus"}, &corev1.Secret{})).To(HaveOccurred())
+				Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-external-prometheus", Namespace: "external-prometheus"}, &corev1.Secret{})).NotTo(HaveOccurred())
+			})
+
+			It("should degrade when the in-cluster Prometheus is disabled without an external Prometheus", func() {
+				mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid Monitor spec", mock.Anything, mock.Anything).Return()
+				disabled := operatorv1.PrometheusStateDisabled
+				monitorCR.Spec.Prometheus = &operatorv1.Prometheus{State: &disabled}
+				Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+				_, err := r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).NotTo(HaveOccurred())
+				mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid Monitor spec", mock.Anything, mock.Anything)
+				Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.CalicoNodePrometheus, Namespace: common.TigeraPrometheusNamespace}, p)).To(HaveOccurred())
+			})
+		})
+
+		It("should copy the remote write secrets to the tigera-prometheus namespace", func() {
+			Expect(cli.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "remote-write-auth", Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{"token": []byte("secret-token")},
+			})).NotTo(HaveOccurred())
+			monitorCR.Spec.Prometheus = &operatorv1.Prometheus{
+				PrometheusSpec: &operatorv1.PrometheusSpec{
+					CommonPrometheusFields: &operatorv1.CommonPrometheusFields{
+						RemoteWrite: []operatorv1.RemoteWriteSpec{{
+							URL: "https://metrics.example.com/api/v1/write",
+							BearerTokenSecret: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write-auth"},
+								Key:                  "token",
+							},
+						}},
+					},
+				},
+			}
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "remote-write-auth", Namespace: common.TigeraPrometheusNamespace}, secret)).NotTo(HaveOccurred())
+			Expect(secret.Data).To(HaveKeyWithValue("token", []byte("secret-token")))
+			Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.CalicoNodePrometheus, Namespace: common.TigeraPrometheusNamespace}, p)).NotTo(HaveOccurred())
+			Expect(p.Spec.RemoteWrite).To(HaveLen(1))
+			Expect(p.Spec.RemoteWrite[0].URL).To(Equal("https://metrics.example.com/api/v1/write"))
+		})
+
+		It("should copy the Thanos object storage secret to the tigera-prometheus namespace", func() {
+			Expect(cli.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "thanos-objstore", Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{"objstore.yml": []byte("type: S3")},
+			})).NotTo(HaveOccurred())
+			monitorCR.Spec.Prometheus = &operatorv1.Prometheus{
+				PrometheusSpec: &operatorv1.PrometheusSpec{
+					Thanos: &operatorv1.Thanos{
+						ObjectStorageConfig: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-objstore"},
+							Key:                  "objstore.yml",
+						},
+					},
+				},
+			}
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "thanos-objstore", Namespace: common.TigeraPrometheusNamespace}, secret)).NotTo(HaveOccurred())
+			Expect(secret.Data).To(HaveKeyWithValue("objstore.yml", []byte("type: S3")))
+			Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.CalicoNodePrometheus, Namespace: common.TigeraPrometheusNamespace}, p)).NotTo(HaveOccurred())
+			Expect(p.Spec.Thanos).NotTo(BeNil())
+			Expect(p.Spec.Thanos.ObjectStorageConfig.Name).To(Equal("thanos-objstore"))
+		})
+
+		It("should delete the Grafana dashboards from a namespace that is no longer configured", func() {
+			monitorCR.Spec.GrafanaDashboards = &operatorv1.GrafanaDashboards{Namespace: "grafana"}
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			dashboard := monitor.GrafanaDashboardPrefix + "denied-traffic"
+			Expect(cli.Get(ctx, client.ObjectKey{Name: dashboard, Namespace: "grafana"}, cm)).NotTo(HaveOccurred())
+
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(monitorCR), monitorCR)).NotTo(HaveOccurred())
+			monitorCR.Spec.GrafanaDashboards.Namespace = "dashboards"
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cli.Get(ctx, client.ObjectKey{Name: dashboard, Namespace: "dashboards"}, cm)).NotTo(HaveOccurred())
+			err = cli.Get(ctx, client.ObjectKey{Name: dashboard, Namespace: "grafana"}, cm)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should degrade when the Thanos object storage secret does not contain the key", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceReadError, "Error retrieving Thanos object storage secret", mock.Anything, mock.Anything).Return()
+			Expect(cli.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "thanos-objstore", Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{"other.yml": []byte("type: S3")},
+			})).NotTo(HaveOccurred())
+			monitorCR.Spec.Prometheus = &operatorv1.Prometheus{
+				PrometheusSpec: &operatorv1.PrometheusSpec{
+					Thanos: &operatorv1.Thanos{
+						ObjectStorageConfig: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-objstore"},
+							Key:                  "objstore.yml",
+						},
+					},
+				},
+			}
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).To(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceReadError, "Error retrieving Thanos object storage secret", mock.Anything, mock.Anything)
 		})
 	})
 
@@ -388,6 +532,113 @@ var _ = Describe("Monitor controller tests", func() {
 			Expect(ownerRefs).To(HaveLen(1))
 			Expect(ownerRefs[0].APIVersion).To(Equal("operator.tigera.io/v1"))
 		})
+
+		It("should render the configuration of the Monitor in the Prometheus namespace only", func() {
+			Expect(cli.Create(ctx, secretOperator)).To(BeNil())
+			Expect(cli.Create(ctx, secretPrometheus)).To(BeNil())
+			Expect(cli.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{"url": []byte("https://hooks.slack.com/services/abc")},
+			})).NotTo(HaveOccurred())
+			monitorCR.Spec.AlertManager = &operatorv1.AlertManager{
+				AlertManagerSpec: &operatorv1.AlertManagerSpec{
+					Configuration: &operatorv1.AlertmanagerConfiguration{
+						Route: operatorv1.AlertmanagerRoute{
+							AlertmanagerRouteOptions: operatorv1.AlertmanagerRouteOptions{Receiver: "slack"},
+						},
+						Receivers: []operatorv1.AlertmanagerReceiver{{
+							Name: "slack",
+							SlackConfigs: []operatorv1.AlertmanagerSlackConfig{{
+								APIURL: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "url"},
+							}},
+						}},
+					},
+				},
+			}
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			s := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(secretPrometheus), s)).NotTo(HaveOccurred())
+			Expect(string(s.Data["alertmanager.yaml"])).To(ContainSubstring("api_url: https://hooks.slack.com/services/abc"))
+			Expect(s.GetObjectMeta().GetOwnerReferences()).To(HaveLen(1))
+
+			// The secret provided by the user is left as-is.
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(secretOperator), s)).NotTo(HaveOccurred())
+			Expect(s.Data).To(HaveKeyWithValue("alertmanager.yaml", []byte("Alertmanager secret in tigera-operator namespace")))
+			Expect(s.GetObjectMeta().GetOwnerReferences()).To(BeEmpty())
+
+			// Once the configuration is removed from the Monitor, the secret provided by the user is used again.
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(monitorCR), monitorCR)).NotTo(HaveOccurred())
+			monitorCR.Spec.AlertManager = nil
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(secretPrometheus), s)).NotTo(HaveOccurred())
+			Expect(s.Data).To(HaveKeyWithValue("alertmanager.yaml", []byte("Alertmanager secret in tigera-operator namespace")))
+		})
+
+		It("should not copy back a configuration generated from the Monitor once it is removed", func() {
+			Expect(cli.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{"url": []byte("https://hooks.slack.com/services/abc")},
+			})).NotTo(HaveOccurred())
+			monitorCR.Spec.AlertManager = &operatorv1.AlertManager{
+				AlertManagerSpec: &operatorv1.AlertManagerSpec{
+					Configuration: &operatorv1.AlertmanagerConfiguration{
+						Route: operatorv1.AlertmanagerRoute{
+							AlertmanagerRouteOptions: operatorv1.AlertmanagerRouteOptions{Receiver: "slack"},
+						},
+						Receivers: []operatorv1.AlertmanagerReceiver{{
+							Name: "slack",
+							SlackConfigs: []operatorv1.AlertmanagerSlackConfig{{
+								APIURL: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "url"},
+							}},
+						}},
+					},
+				},
+			}
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			s := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(secretOperator), s)).To(HaveOccurred())
+
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(monitorCR), monitorCR)).NotTo(HaveOccurred())
+			monitorCR.Spec.AlertManager = nil
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, secret := range []*corev1.Secret{secretOperator, secretPrometheus} {
+				Expect(cli.Get(ctx, client.ObjectKeyFromObject(secret), s)).NotTo(HaveOccurred())
+				Expect(s.Data).To(HaveKeyWithValue("alertmanager.yaml", []byte(alertmanagerConfig)))
+			}
+		})
+
+		It("should degrade when a route of the Monitor refers to an undefined receiver", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid Monitor spec", mock.Anything, mock.Anything).Return()
+			monitorCR.Spec.AlertManager = &operatorv1.AlertManager{
+				AlertManagerSpec: &operatorv1.AlertManagerSpec{
+					Configuration: &operatorv1.AlertmanagerConfiguration{
+						Route: operatorv1.AlertmanagerRoute{
+							AlertmanagerRouteOptions: operatorv1.AlertmanagerRouteOptions{Receiver: "pagerduty"},
+						},
+						Receivers: []operatorv1.AlertmanagerReceiver{{Name: "slack"}},
+					},
+				},
+			}
+			Expect(r.client.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+			Expect(cli.Create(ctx, secretOperator)).To(BeNil())
+			Expect(cli.Create(ctx, secretPrometheus)).To(BeNil())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid Monitor spec", mock.Anything, mock.Anything)
+		})
 	})
 
 	Context("Reconcile for Condition status", func() {
@@ -42,4 +42,12 @@ type AddOptions struct {
 	// use external elasticsearch. When set, the operator will not install Elasticsearch
 	// and instead will configure the cluster to use an external Elasticsearch.
 	ElasticExternal bool
+
+	// The name of the registered Elasticsearch backend that controllers use to create their Elasticsearch
+	// clients. When empty, the default backend is used.
+	ElasticsearchBackend string
+
+	// Whether or not the Whisker feature gate is enabled. When set, the operator installs
+	// Whisker and Goldmane if a Whisker resource exists.
+	WhiskerEnabled bool
 }
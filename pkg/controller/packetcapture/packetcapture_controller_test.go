@@ -191,6 +191,10 @@ var _ = Describe("packet capture controller tests", func() {
 					ReadOnly:  true,
 					MountPath: fmt.Sprintf("/%s", packetCaptureSecret.Name),
 				},
+				{
+					Name:      "packet-captures",
+					MountPath: "/var/lib/packet-captures",
+				},
 				{
 					Name:      "tigera-ca-bundle",
 					ReadOnly:  true,
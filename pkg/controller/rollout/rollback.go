@@ -0,0 +1,152 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+const defaultFailureWindow = 600 * time.Second
+
+// workload is a Deployment or DaemonSet managed by the operator whose rollouts can be rolled back.
+type workload struct {
+	obj      client.Object
+	template *corev1.PodTemplateSpec
+	ready    bool
+}
+
+// rollbackWorkloads records the last-known-good pod template of the Deployments and DaemonSets managed by the operator
+// and rolls back the ones whose rollout failed. It returns the workloads that are rolled back, and whether a rollout
+// that may still be rolled back automatically is in progress.
+func (r *ReconcileRollout) rollbackWorkloads(ctx context.Context, policy *operatorv1.RollbackPolicy, reqLogger logr.Logger) ([]string, bool, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := r.client.List(ctx, deployments); err != nil {
+		return nil, false, err
+	}
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := r.client.List(ctx, daemonSets); err != nil {
+		return nil, false, err
+	}
+
+	var workloads []workload
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		workloads = append(workloads, workload{obj: d, template: &d.Spec.Template, ready: deploymentRolledOut(d)})
+	}
+	for i := range daemonSets.Items {
+		ds := &daemonSets.Items[i]
+		workloads = append(workloads, workload{obj: ds, template: &ds.Spec.Template, ready: daemonSetRolledOut(ds)})
+	}
+
+	automatic := policy.Mode != nil && *policy.Mode == operatorv1.RollbackModeAutomatic
+	window := defaultFailureWindow
+	if policy.FailureWindowSeconds != nil {
+		window = time.Duration(*policy.FailureWindowSeconds) * time.Second
+	}
+
+	var rolledBack []string
+	progressing := false
+	for _, w := range workloads {
+		annotations := w.obj.GetAnnotations()
+		hash := annotations[common.RenderedTemplateHashAnnotation]
+		if hash == "" {
+			continue
+		}
+		name := fmt.Sprintf("%s/%s", w.obj.GetNamespace(), w.obj.GetName())
+		if annotations[common.RolledBackFromAnnotation] == hash {
+			rolledBack = append(rolledBack, name)
+			continue
+		}
+
+		current, err := utils.TemplateHash(w.template)
+		if err != nil {
+			return nil, false, err
+		}
+		lastKnownGood := annotations[common.LastKnownGoodTemplateHashAnnotation]
+		canRollback := lastKnownGood != "" && lastKnownGood != current
+
+		failed := false
+		if annotations[common.RollbackAnnotation] == "true" {
+			failed = true
+		} else if !w.ready && automatic {
+			started, err := time.Parse(time.RFC3339, annotations[common.RolloutStartedAnnotation])
+			failed = err == nil && time.Since(started) > window
+			progressing = !failed || progressing
+		}
+
+		var template *corev1.PodTemplateSpec
+		if failed && canRollback {
+			if template, err = utils.GetLastKnownGoodTemplate(ctx, r.client, w.obj); err != nil {
+				return nil, false, err
+			}
+		}
+
+		switch {
+		case template != nil:
+			reqLogger.Info("Rolling back to the last-known-good pod template", "workload", name, "kind", fmt.Sprintf("%T", w.obj))
+			*w.template = *template
+			annotations[common.RolledBackFromAnnotation] = hash
+			delete(annotations, common.RollbackAnnotation)
+			rolledBack = append(rolledBack, name)
+		case w.ready && lastKnownGood != current:
+			if err = utils.SetLastKnownGoodTemplate(ctx, r.client, w.obj, w.template); err != nil {
+				return nil, false, err
+			}
+			delete(annotations, common.RollbackAnnotation)
+		case annotations[common.RollbackAnnotation] != "":
+			// There is nothing to roll back to.
+			reqLogger.Info("Ignoring rollback request, there is no last-known-good pod template to roll back to", "workload", name)
+			delete(annotations, common.RollbackAnnotation)
+		default:
+			continue
+		}
+		w.obj.SetAnnotations(annotations)
+		if err = r.client.Update(ctx, w.obj); err != nil {
+			return nil, false, err
+		}
+	}
+	return rolledBack, progressing, nil
+}
+
+// deploymentRolledOut returns whether all the pods of the Deployment run its current pod template and are available.
+func deploymentRolledOut(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	s := d.Status
+	return s.ObservedGeneration >= d.Generation && s.Replicas == replicas && s.UpdatedReplicas == replicas && s.AvailableReplicas == replicas
+}
+
+// daemonSetRolledOut returns whether all the pods of the DaemonSet run its current pod template and are available.
+func daemonSetRolledOut(ds *appsv1.DaemonSet) bool {
+	s := ds.Status
+	return s.ObservedGeneration >= ds.Generation && s.UpdatedNumberScheduled == s.DesiredNumberScheduled && s.NumberAvailable == s.DesiredNumberScheduled
+}
+
+func hasRenderedTemplate(obj client.Object) bool {
+	return obj.GetAnnotations()[common.RenderedTemplateHashAnnotation] != ""
+}
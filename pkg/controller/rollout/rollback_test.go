@@ -0,0 +1,239 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("Rollback", func() {
+	var (
+		ctx        context.Context
+		cli        client.Client
+		mockStatus *status.MockStatus
+		r          *ReconcileRollout
+		install    *operatorv1.Installation
+		deployment *appsv1.Deployment
+	)
+
+	const renderedHash = "rendered"
+	rolledBackMsg := "Rolled back to the last-known-good pod template after a failed rollout: tigera-manager/tigera-manager"
+
+	template := func(image string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "manager", Image: image}}}}
+	}
+
+	marshal := func(t corev1.PodTemplateSpec) string {
+		b, err := json.Marshal(t)
+		Expect(err).ShouldNot(HaveOccurred())
+		return string(b)
+	}
+
+	// setDeployment creates the Deployment with the given pod template and rollout status.
+	setDeployment := func(image string, ready bool, started time.Time, annotations map[string]string) {
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tigera-manager",
+				Namespace: "tigera-manager",
+				Annotations: map[string]string{
+					common.RenderedTemplateHashAnnotation: renderedHash,
+					common.RolloutStartedAnnotation:       started.UTC().Format(time.RFC3339),
+				},
+			},
+			Spec: appsv1.DeploymentSpec{Template: template(image)},
+		}
+		for k, v := range annotations {
+			deployment.Annotations[k] = v
+		}
+		deployment.Status = appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1}
+		if ready {
+			deployment.Status.AvailableReplicas = 1
+		}
+		Expect(cli.Create(ctx, deployment)).ShouldNot(HaveOccurred())
+	}
+
+	// lastKnownGood records the given image as the last-known-good pod template of the Deployment the way the controller
+	// does, and returns the annotations to create the Deployment with.
+	lastKnownGood := func(image string) map[string]string {
+		t := template(image)
+		hash, err := utils.TemplateHash(&t)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployment-tigera-manager-last-known-good", Namespace: "tigera-manager"},
+			Data:       map[string]string{"template": marshal(t)},
+		})).ShouldNot(HaveOccurred())
+		return map[string]string{common.LastKnownGoodTemplateHashAnnotation: hash}
+	}
+
+	getDeployment := func() *appsv1.Deployment {
+		d := &appsv1.Deployment{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(deployment), d)).ShouldNot(HaveOccurred())
+		return d
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		mockStatus = &status.MockStatus{}
+		mockStatus.On("OnCRFound").Return()
+		mockStatus.On("ReadyToMonitor").Return()
+		r = &ReconcileRollout{client: cli, status: mockStatus}
+
+		automatic := operatorv1.RollbackModeAutomatic
+		install = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operatorv1.InstallationSpec{
+				RollbackPolicy: &operatorv1.RollbackPolicy{Mode: &automatic},
+			},
+		}
+		Expect(cli.Create(ctx, install)).ShouldNot(HaveOccurred())
+	})
+
+	It("should record the pod template of a ready workload as last-known-good", func() {
+		setDeployment("manager:v1", true, time.Now(), nil)
+		mockStatus.On("ClearDegraded").Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		d := getDeployment()
+		t := template("manager:v1")
+		hash, err := utils.TemplateHash(&t)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(d.Annotations).To(HaveKeyWithValue(common.LastKnownGoodTemplateHashAnnotation, hash))
+
+		// The pod template itself is kept in a ConfigMap owned by the Deployment.
+		cm := &corev1.ConfigMap{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: utils.LastKnownGoodTemplateName(d), Namespace: d.Namespace}, cm)).ShouldNot(HaveOccurred())
+		Expect(cm.Data).To(Equal(map[string]string{"template": marshal(t)}))
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+		Expect(cm.OwnerReferences[0].Kind).To(Equal("Deployment"))
+		Expect(cm.OwnerReferences[0].Name).To(Equal(d.Name))
+	})
+
+	It("should wait for the failure window before rolling back", func() {
+		setDeployment("manager:v2", false, time.Now(), lastKnownGood("manager:v1"))
+		mockStatus.On("ClearDegraded").Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(progressCheckInterval))
+		Expect(getDeployment().Spec.Template).To(Equal(template("manager:v2")))
+	})
+
+	It("should roll back a rollout that is not done within the failure window", func() {
+		setDeployment("manager:v2", false, time.Now().Add(-11*time.Minute), lastKnownGood("manager:v1"))
+		mockStatus.On("SetDegraded", operatorv1.PodFailure, rolledBackMsg, mock.Anything, mock.Anything).Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		d := getDeployment()
+		Expect(d.Spec.Template).To(Equal(template("manager:v1")))
+		Expect(d.Annotations).To(HaveKeyWithValue(common.RolledBackFromAnnotation, renderedHash))
+		mockStatus.AssertExpectations(GinkgoT())
+
+		// The workload stays rolled back, and reported, until a new pod template is rendered.
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getDeployment().Spec.Template).To(Equal(template("manager:v1")))
+		mockStatus.AssertNumberOfCalls(GinkgoT(), "SetDegraded", 2)
+	})
+
+	It("should honour the failure window of the policy", func() {
+		install.Spec.RollbackPolicy.FailureWindowSeconds = ptr.Int32ToPtr(30)
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+		setDeployment("manager:v2", false, time.Now().Add(-time.Minute), lastKnownGood("manager:v1"))
+		mockStatus.On("SetDegraded", operatorv1.PodFailure, rolledBackMsg, mock.Anything, mock.Anything).Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getDeployment().Spec.Template).To(Equal(template("manager:v1")))
+	})
+
+	It("should only roll back on request in manual mode", func() {
+		install.Spec.RollbackPolicy.Mode = nil
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+		setDeployment("manager:v2", false, time.Now().Add(-time.Hour), lastKnownGood("manager:v1"))
+		mockStatus.On("ClearDegraded").Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(getDeployment().Spec.Template).To(Equal(template("manager:v2")))
+
+		d := getDeployment()
+		d.Annotations[common.RollbackAnnotation] = "true"
+		Expect(cli.Update(ctx, d)).ShouldNot(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.PodFailure, rolledBackMsg, mock.Anything, mock.Anything).Return()
+
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		d = getDeployment()
+		Expect(d.Spec.Template).To(Equal(template("manager:v1")))
+		Expect(d.Annotations).NotTo(HaveKey(common.RollbackAnnotation))
+	})
+
+	It("should not roll back to a last-known-good pod template that does not match its hash", func() {
+		annotations := lastKnownGood("manager:v1")
+		annotations[common.LastKnownGoodTemplateHashAnnotation] = "other"
+		annotations[common.RollbackAnnotation] = "true"
+		setDeployment("manager:v2", false, time.Now().Add(-time.Hour), annotations)
+		mockStatus.On("ClearDegraded").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		d := getDeployment()
+		Expect(d.Spec.Template).To(Equal(template("manager:v2")))
+		Expect(d.Annotations).NotTo(HaveKey(common.RolledBackFromAnnotation))
+	})
+
+	It("should not roll back without a last-known-good pod template", func() {
+		setDeployment("manager:v1", false, time.Now().Add(-time.Hour), map[string]string{common.RollbackAnnotation: "true"})
+		mockStatus.On("ClearDegraded").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		d := getDeployment()
+		Expect(d.Spec.Template).To(Equal(template("manager:v1")))
+		Expect(d.Annotations).NotTo(HaveKey(common.RolledBackFromAnnotation))
+		Expect(d.Annotations).NotTo(HaveKey(common.RollbackAnnotation))
+	})
+})
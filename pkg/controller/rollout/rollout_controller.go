@@ -0,0 +1,566 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+)
+
+var log = logf.Log.WithName("controller_rollout")
+
+const (
+	defaultMaxRestarts = 3
+
+	// progressCheckInterval is how often the progress of a rollout is checked. Restarting containers don't always
+	// change the status of their DaemonSet, so the watch on DaemonSets is not enough to spot crashing pods.
+	progressCheckInterval = 30 * time.Second
+)
+
+type rolloutState int
+
+const (
+	rolloutDone rolloutState = iota
+	rolloutProgressing
+	rolloutPaused
+)
+
+// Add creates the rollout controller, which updates the pods of the DaemonSets managed by the operator in waves when
+// the Installation has a RolloutPolicy. The component handler switches those DaemonSets to the OnDelete update
+// strategy, and this controller deletes their outdated pods wave by wave. When the Installation has a RollbackPolicy,
+// the controller also rolls the Deployments and DaemonSets managed by the operator back to their last-known-good pod
+// template when their rollout fails.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	r := &ReconcileRollout{
+		client: mgr.GetClient(),
+		status: status.New(mgr.GetClient(), "rollout", opts.KubernetesVersion),
+	}
+	r.status.Run(opts.ShutdownContext)
+
+	c, err := ctrlruntime.NewController("rollout-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	if err = utils.AddInstallationWatch(c); err != nil {
+		return fmt.Errorf("rollout-controller failed to watch Installation resource: %w", err)
+	}
+	if err = c.WatchObject(&appsv1.DaemonSet{}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isStagedRollout(e.Object) || hasRenderedTemplate(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isStagedRollout(e.ObjectNew) || hasRenderedTemplate(e.ObjectNew)
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}); err != nil {
+		return fmt.Errorf("rollout-controller failed to watch DaemonSets: %w", err)
+	}
+	if err = c.WatchObject(&appsv1.Deployment{}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return hasRenderedTemplate(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return hasRenderedTemplate(e.ObjectNew) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}); err != nil {
+		return fmt.Errorf("rollout-controller failed to watch Deployments: %w", err)
+	}
+	if err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("rollout-controller failed to create periodic reconcile watch: %w", err)
+	}
+	return nil
+}
+
+// ReconcileRollout moves the staged rollouts of DaemonSets forward and rolls back failed rollouts.
+type ReconcileRollout struct {
+	client client.Client
+	status status.StatusManager
+}
+
+func (r *ReconcileRollout) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	instance := &operatorv1.Installation{}
+	if err := r.client.Get(ctx, utils.DefaultInstanceKey, instance); err != nil {
+		if errors.IsNotFound(err) {
+			r.status.OnCRNotFound()
+			return reconcile.Result{}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	policy := instance.Spec.RolloutPolicy
+	rollbackPolicy := instance.Spec.RollbackPolicy
+	if policy == nil {
+		// The DaemonSets are updated by their rolling update strategy again, so any staged rollout has ended.
+		if err := r.clearStagedRollouts(ctx); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if policy == nil && rollbackPolicy == nil {
+		r.status.OnCRNotFound()
+		return reconcile.Result{}, nil
+	}
+	r.status.OnCRFound()
+
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
+	var paused []string
+	progressing := false
+	if policy != nil {
+		daemonSets := &appsv1.DaemonSetList{}
+		if err := r.client.List(ctx, daemonSets); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to list DaemonSets", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		nodes := &corev1.NodeList{}
+		if err := r.client.List(ctx, nodes); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to list nodes", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+
+		for i := range daemonSets.Items {
+			ds := &daemonSets.Items[i]
+			if !isStagedRollout(ds) || ds.Spec.UpdateStrategy.Type != appsv1.OnDeleteDaemonSetStrategyType {
+				continue
+			}
+			state, err := r.rolloutDaemonSet(ctx, ds, policy, nodes.Items, reqLogger.WithValues("DaemonSet", client.ObjectKeyFromObject(ds)))
+			if err != nil {
+				r.status.SetDegraded(operatorv1.ResourceUpdateError, fmt.Sprintf("Failed to roll out DaemonSet %s/%s", ds.Namespace, ds.Name), err, reqLogger)
+				return reconcile.Result{}, err
+			}
+			switch state {
+			case rolloutPaused:
+				paused = append(paused, fmt.Sprintf("%s/%s", ds.Namespace, ds.Name))
+			case rolloutProgressing:
+				progressing = true
+			}
+		}
+	}
+
+	var rolledBack []string
+	if rollbackPolicy != nil {
+		var rollbackPending bool
+		var err error
+		rolledBack, rollbackPending, err = r.rollbackWorkloads(ctx, rollbackPolicy, reqLogger)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to roll back workloads", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		progressing = progressing || rollbackPending
+	}
+
+	r.status.ReadyToMonitor()
+	var msgs []string
+	if len(paused) > 0 {
+		msgs = append(msgs, fmt.Sprintf("Rollout paused because updated pods are restarting: %s", strings.Join(paused, ", ")))
+	}
+	if len(rolledBack) > 0 {
+		msgs = append(msgs, fmt.Sprintf("Rolled back to the last-known-good pod template after a failed rollout: %s", strings.Join(rolledBack, ", ")))
+	}
+	if len(msgs) > 0 {
+		r.status.SetDegraded(operatorv1.PodFailure, strings.Join(msgs, "; "), nil, reqLogger)
+	} else {
+		r.status.ClearDegraded()
+	}
+	if progressing || len(paused) > 0 {
+		return reconcile.Result{RequeueAfter: progressCheckInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// rolloutDaemonSet deletes the outdated pods of the DaemonSet in the current wave, as long as the DaemonSet has
+// fewer unavailable pods than allowed and none of its updated pods restarted too often since the rollout started.
+func (r *ReconcileRollout) rolloutDaemonSet(ctx context.Context, ds *appsv1.DaemonSet, policy *operatorv1.RolloutPolicy, nodes []corev1.Node, reqLogger logr.Logger) (rolloutState, error) {
+	hash, err := r.currentRevisionHash(ctx, ds)
+	if err != nil {
+		return rolloutProgressing, err
+	}
+	if hash == "" {
+		// The DaemonSet controller hasn't created a revision for the DaemonSet yet.
+		return rolloutProgressing, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return rolloutProgressing, err
+	}
+	podList := &corev1.PodList{}
+	if err = r.client.List(ctx, podList, client.InNamespace(ds.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return rolloutProgressing, err
+	}
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		if metav1.IsControlledBy(&podList.Items[i], ds) {
+			pods = append(pods, &podList.Items[i])
+		}
+	}
+
+	outdated := 0
+	unavailable := 0
+	for _, pod := range pods {
+		if pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] != hash {
+			outdated++
+		}
+		if pod.DeletionTimestamp != nil || !podReady(pod) {
+			unavailable++
+		}
+	}
+	if outdated == 0 {
+		if ds.Status.ObservedGeneration < ds.Generation {
+			// The DaemonSet controller may not have created the revision of the latest pod template yet.
+			return rolloutProgressing, nil
+		}
+		reqLogger.Info("Staged rollout is done")
+		return rolloutDone, r.clearStagedRollout(ctx, ds)
+	}
+
+	baseline, err := r.restartsBaseline(ctx, ds, hash, pods)
+	if err != nil {
+		return rolloutProgressing, err
+	}
+	maxRestarts := int32(defaultMaxRestarts)
+	if policy.MaxRestarts != nil {
+		maxRestarts = *policy.MaxRestarts
+	}
+	for _, pod := range pods {
+		if pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] == hash && restarts(pod)-baseline.Restarts[pod.Name] >= maxRestarts {
+			reqLogger.Info("Pausing rollout, updated pod is restarting", "pod", pod.Name, "node", pod.Spec.NodeName)
+			return rolloutPaused, nil
+		}
+	}
+
+	maxUnavailable := intstr.FromInt(1)
+	if policy.MaxUnavailable != nil {
+		maxUnavailable = *policy.MaxUnavailable
+	}
+	budget, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, len(pods), true)
+	if err != nil {
+		return rolloutProgressing, err
+	}
+	if budget < 1 {
+		budget = 1
+	}
+	budget -= unavailable
+
+	waveNodes := currentWave(policy.Waves, pods, nodes, eligibleNodes(ds, nodes), hash)
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Spec.NodeName < pods[j].Spec.NodeName })
+	for _, pod := range pods {
+		if budget <= 0 {
+			break
+		}
+		if pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] == hash || pod.DeletionTimestamp != nil || !waveNodes[pod.Spec.NodeName] {
+			continue
+		}
+		reqLogger.Info("Deleting outdated pod", "pod", pod.Name, "node", pod.Spec.NodeName)
+		if err = r.client.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+			return rolloutProgressing, err
+		}
+		budget--
+	}
+	return rolloutProgressing, nil
+}
+
+// restartsBaseline is the value of the RolloutRestartsBaselineAnnotation.
+type restartsBaseline struct {
+	// Revision is the hash of the revision being rolled out.
+	Revision string `json:"revision"`
+	// Restarts holds the restart counts of the pods of the revision that already existed when the rollout started.
+	// Pods created since then started without restarts.
+	Restarts map[string]int32 `json:"restarts,omitempty"`
+}
+
+// restartsBaseline returns the restart counts of the pods of the DaemonSet when the rollout of the revision with the
+// given hash started. The counts are recorded on the DaemonSet the first time the rollout of a revision is seen.
+func (r *ReconcileRollout) restartsBaseline(ctx context.Context, ds *appsv1.DaemonSet, hash string, pods []*corev1.Pod) (restartsBaseline, error) {
+	var baseline restartsBaseline
+	if v, ok := ds.Annotations[common.RolloutRestartsBaselineAnnotation]; ok {
+		if err := json.Unmarshal([]byte(v), &baseline); err != nil {
+			log.Info("Ignoring invalid rollout restarts baseline", "DaemonSet", client.ObjectKeyFromObject(ds), "error", err.Error())
+		}
+	}
+	if baseline.Revision == hash {
+		return baseline, nil
+	}
+
+	baseline = restartsBaseline{Revision: hash}
+	for _, pod := range pods {
+		if pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] != hash {
+			continue
+		}
+		if baseline.Restarts == nil {
+			baseline.Restarts = map[string]int32{}
+		}
+		baseline.Restarts[pod.Name] = restarts(pod)
+	}
+	value, err := json.Marshal(baseline)
+	if err != nil {
+		return baseline, err
+	}
+	patchFrom := client.MergeFrom(ds.DeepCopy())
+	if ds.Annotations == nil {
+		ds.Annotations = map[string]string{}
+	}
+	ds.Annotations[common.RolloutRestartsBaselineAnnotation] = string(value)
+	return baseline, r.client.Patch(ctx, ds, patchFrom)
+}
+
+// currentRevisionHash returns the hash of the latest revision of the DaemonSet, which the DaemonSet controller sets as
+// a label on the pods it creates for that revision.
+func (r *ReconcileRollout) currentRevisionHash(ctx context.Context, ds *appsv1.DaemonSet) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+	revisions := &appsv1.ControllerRevisionList{}
+	if err = r.client.List(ctx, revisions, client.InNamespace(ds.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", err
+	}
+	var latest *appsv1.ControllerRevision
+	for i := range revisions.Items {
+		rev := &revisions.Items[i]
+		if metav1.IsControlledBy(rev, ds) && (latest == nil || rev.Revision > latest.Revision) {
+			latest = rev
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey], nil
+}
+
+// clearStagedRollout removes the annotations of the staged rollout from the DaemonSet once all of its pods are updated.
+// The component handler sets the StagedRolloutAnnotation again when it renders a new pod template.
+func (r *ReconcileRollout) clearStagedRollout(ctx context.Context, ds *appsv1.DaemonSet) error {
+	patchFrom := client.MergeFrom(ds.DeepCopy())
+	annotations := ds.GetAnnotations()
+	delete(annotations, common.StagedRolloutAnnotation)
+	delete(annotations, common.RolloutRestartsBaselineAnnotation)
+	ds.SetAnnotations(annotations)
+	return r.client.Patch(ctx, ds, patchFrom)
+}
+
+// clearStagedRollouts removes the annotations of the staged rollout from all the DaemonSets that have them, for when
+// the RolloutPolicy is removed from the Installation.
+func (r *ReconcileRollout) clearStagedRollouts(ctx context.Context) error {
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := r.client.List(ctx, daemonSets); err != nil {
+		return err
+	}
+	for i := range daemonSets.Items {
+		if ds := &daemonSets.Items[i]; isStagedRollout(ds) {
+			if err := r.clearStagedRollout(ctx, ds); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// currentWave returns the nodes whose pods can be updated: the nodes of the first wave that has pods that are outdated
+// or not ready yet, together with the nodes of the waves before it. Once all waves are done, all nodes can be updated.
+func currentWave(waves []operatorv1.RolloutWave, pods []*corev1.Pod, nodes []corev1.Node, eligible []string, hash string) map[string]bool {
+	// All the nodes that the DaemonSet runs on count towards the percentages, including the nodes that don't run its
+	// pod yet. Those have nothing to update, since the DaemonSet controller creates their pod from the latest revision.
+	done := map[string]bool{}
+	for _, n := range eligible {
+		done[n] = true
+	}
+	for _, pod := range pods {
+		done[pod.Spec.NodeName] = pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] == hash && pod.DeletionTimestamp == nil && podReady(pod)
+	}
+	var dsNodes []string
+	for n := range done {
+		dsNodes = append(dsNodes, n)
+	}
+	sort.Strings(dsNodes)
+
+	selected := map[string]bool{}
+	for _, w := range waves {
+		if len(w.NodeSelector) > 0 {
+			sel := labels.SelectorFromSet(w.NodeSelector)
+			for _, n := range nodes {
+				if _, ok := done[n.Name]; ok && sel.Matches(labels.Set(n.Labels)) {
+					selected[n.Name] = true
+				}
+			}
+		} else if w.Percentage != nil {
+			target := (len(dsNodes)*int(*w.Percentage) + 99) / 100
+			for _, n := range dsNodes {
+				if len(selected) >= target {
+					break
+				}
+				selected[n] = true
+			}
+		}
+
+		for n := range selected {
+			if !done[n] {
+				return selected
+			}
+		}
+	}
+
+	for _, n := range dsNodes {
+		selected[n] = true
+	}
+	return selected
+}
+
+// daemonSetTolerations are the tolerations that the DaemonSet controller adds to the pods of every DaemonSet, so that
+// they also run on nodes whose conditions aren't healthy.
+var daemonSetTolerations = []corev1.Toleration{
+	{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists},
+	{Key: corev1.TaintNodeUnreachable, Operator: corev1.TolerationOpExists},
+	{Key: corev1.TaintNodeDiskPressure, Operator: corev1.TolerationOpExists},
+	{Key: corev1.TaintNodeMemoryPressure, Operator: corev1.TolerationOpExists},
+	{Key: corev1.TaintNodePIDPressure, Operator: corev1.TolerationOpExists},
+	{Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists},
+}
+
+// eligibleNodes returns the names of the nodes that the DaemonSet runs a pod on, as determined by the node selector,
+// the required node affinity and the tolerations of its pod template.
+func eligibleNodes(ds *appsv1.DaemonSet, nodes []corev1.Node) []string {
+	spec := ds.Spec.Template.Spec
+	tolerations := append(slices.Clone(spec.Tolerations), daemonSetTolerations...)
+	if spec.HostNetwork {
+		tolerations = append(tolerations, corev1.Toleration{Key: corev1.TaintNodeNetworkUnavailable, Operator: corev1.TolerationOpExists})
+	}
+
+	var eligible []string
+	for i := range nodes {
+		n := &nodes[i]
+		if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(n.Labels)) || !matchesNodeAffinity(spec.Affinity, n) {
+			continue
+		}
+		tolerated := true
+		for j := range n.Spec.Taints {
+			taint := &n.Spec.Taints[j]
+			if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+				continue
+			}
+			if !slices.ContainsFunc(tolerations, func(t corev1.Toleration) bool { return t.ToleratesTaint(taint) }) {
+				tolerated = false
+				break
+			}
+		}
+		if tolerated {
+			eligible = append(eligible, n.Name)
+		}
+	}
+	return eligible
+}
+
+// matchesNodeAffinity returns true if the node matches any of the terms of the required node affinity, if any.
+func matchesNodeAffinity(affinity *corev1.Affinity, node *corev1.Node) bool {
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if matchesNodeSelectorTerm(term, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeSelectorOperators maps the operators of node selector requirements to those of label selectors.
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+func matchesNodeSelectorTerm(term corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		// An empty term matches no nodes.
+		return false
+	}
+	for _, expr := range term.MatchExpressions {
+		req, err := labels.NewRequirement(expr.Key, nodeSelectorOperators[expr.Operator], expr.Values)
+		if err != nil || !req.Matches(labels.Set(node.Labels)) {
+			return false
+		}
+	}
+	for _, field := range term.MatchFields {
+		// metadata.name is the only field that node selectors support, with the In and NotIn operators.
+		if field.Key != "metadata.name" {
+			return false
+		}
+		in := slices.Contains(field.Values, node.Name)
+		if field.Operator == corev1.NodeSelectorOpIn && !in || field.Operator == corev1.NodeSelectorOpNotIn && in {
+			return false
+		}
+	}
+	return true
+}
+
+func isStagedRollout(obj client.Object) bool {
+	return obj.GetAnnotations()[common.StagedRolloutAnnotation] == "true"
+}
+
+// restarts returns the highest restart count of the containers of the pod.
+func restarts(pod *corev1.Pod) int32 {
+	var max int32
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.RestartCount > max {
+			max = cs.RestartCount
+		}
+	}
+	return max
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
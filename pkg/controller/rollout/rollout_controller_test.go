@@ -0,0 +1,356 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/status"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("Staged rollout controller", func() {
+	var (
+		ctx        context.Context
+		cli        client.Client
+		mockStatus *status.MockStatus
+		r          *ReconcileRollout
+		ds         *appsv1.DaemonSet
+		install    *operatorv1.Installation
+	)
+
+	const (
+		oldHash = "old"
+		newHash = "new"
+	)
+
+	controllerRef := func() []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion: "apps/v1", Kind: "DaemonSet", Name: ds.Name, UID: ds.UID, Controller: ptr.BoolToPtr(true),
+		}}
+	}
+
+	createNode := func(name string, labels map[string]string) {
+		Expect(cli.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}})).ShouldNot(HaveOccurred())
+	}
+
+	createPod := func(node, hash string, ready bool, restarts int32) {
+		readyStatus := corev1.ConditionFalse
+		if ready {
+			readyStatus = corev1.ConditionTrue
+		}
+		Expect(cli.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            fmt.Sprintf("calico-node-%s", node),
+				Namespace:       common.CalicoNamespace,
+				Labels:          map[string]string{"k8s-app": "calico-node", appsv1.DefaultDaemonSetUniqueLabelKey: hash},
+				OwnerReferences: controllerRef(),
+			},
+			Spec: corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "calico-node", RestartCount: restarts}},
+			},
+		})).ShouldNot(HaveOccurred())
+	}
+
+	remainingPods := func() []string {
+		pods := &corev1.PodList{}
+		Expect(cli.List(ctx, pods)).ShouldNot(HaveOccurred())
+		var names []string
+		for _, p := range pods.Items {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		mockStatus = &status.MockStatus{}
+		mockStatus.On("OnCRFound").Return()
+		mockStatus.On("ReadyToMonitor").Return()
+		r = &ReconcileRollout{client: cli, status: mockStatus}
+
+		install = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operatorv1.InstallationSpec{
+				RolloutPolicy: &operatorv1.RolloutPolicy{
+					Waves: []operatorv1.RolloutWave{
+						{NodeSelector: map[string]string{"canary": "true"}},
+						{Percentage: ptr.Int32ToPtr(50)},
+					},
+				},
+			},
+		}
+		Expect(cli.Create(ctx, install)).ShouldNot(HaveOccurred())
+
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": "calico-node"}}
+		ds = &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "calico-node",
+				Namespace:   common.CalicoNamespace,
+				UID:         types.UID("ds-uid"),
+				Annotations: map[string]string{common.StagedRolloutAnnotation: "true"},
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Selector:       selector,
+				UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType},
+			},
+		}
+		Expect(cli.Create(ctx, ds)).ShouldNot(HaveOccurred())
+		for i, hash := range []string{oldHash, newHash} {
+			Expect(cli.Create(ctx, &appsv1.ControllerRevision{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            fmt.Sprintf("calico-node-%s", hash),
+					Namespace:       common.CalicoNamespace,
+					Labels:          map[string]string{"k8s-app": "calico-node", appsv1.DefaultDaemonSetUniqueLabelKey: hash},
+					OwnerReferences: controllerRef(),
+				},
+				Revision: int64(i + 1),
+			})).ShouldNot(HaveOccurred())
+		}
+
+		createNode("node-a", nil)
+		createNode("node-b", nil)
+		createNode("node-c", map[string]string{"canary": "true"})
+		createNode("node-d", nil)
+	})
+
+	It("should only update the canary nodes in the first wave", func() {
+		for _, n := range []string{"node-a", "node-b", "node-c", "node-d"} {
+			createPod(n, oldHash, true, 0)
+		}
+		mockStatus.On("ClearDegraded").Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(progressCheckInterval))
+		Expect(remainingPods()).To(ConsistOf("calico-node-node-a", "calico-node-node-b", "calico-node-node-d"))
+	})
+
+	It("should not delete pods while the reconciliation of the Installation is paused", func() {
+		install.Annotations = map[string]string{common.ReconcileAnnotation: common.ReconcilePaused}
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+		for _, n := range []string{"node-a", "node-b", "node-c", "node-d"} {
+			createPod(n, oldHash, true, 0)
+		}
+		mockStatus.On("SetMetaData", mock.Anything).Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(remainingPods()).To(HaveLen(4))
+		mockStatus.AssertCalled(GinkgoT(), "SetMetaData", mock.Anything)
+	})
+
+	It("should move to the next wave once the canary pods are updated and ready", func() {
+		createPod("node-a", oldHash, true, 0)
+		createPod("node-b", oldHash, true, 0)
+		createPod("node-c", newHash, true, 0)
+		createPod("node-d", oldHash, true, 0)
+		mockStatus.On("ClearDegraded").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		// The second wave covers half of the nodes, node-a and node-b, and only one pod may be unavailable.
+		Expect(remainingPods()).To(ConsistOf("calico-node-node-b", "calico-node-node-c", "calico-node-node-d"))
+	})
+
+	It("should not delete pods while the budget of unavailable pods is used up", func() {
+		createPod("node-a", oldHash, true, 0)
+		createPod("node-b", oldHash, false, 0)
+		createPod("node-c", newHash, true, 0)
+		createPod("node-d", oldHash, true, 0)
+		mockStatus.On("ClearDegraded").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(remainingPods()).To(HaveLen(4))
+	})
+
+	It("should honour a percentage of unavailable pods", func() {
+		install.Spec.RolloutPolicy.MaxUnavailable = ptr.ToPtr(intstr.FromString("50%"))
+		install.Spec.RolloutPolicy.Waves = []operatorv1.RolloutWave{{Percentage: ptr.Int32ToPtr(100)}}
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+		for _, n := range []string{"node-a", "node-b", "node-c", "node-d"} {
+			createPod(n, oldHash, true, 0)
+		}
+		mockStatus.On("ClearDegraded").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(remainingPods()).To(ConsistOf("calico-node-node-c", "calico-node-node-d"))
+	})
+
+	It("should count the percentage of a wave over all the nodes that the DaemonSet runs on", func() {
+		install.Spec.RolloutPolicy.MaxUnavailable = ptr.ToPtr(intstr.FromInt(2))
+		install.Spec.RolloutPolicy.Waves = []operatorv1.RolloutWave{{Percentage: ptr.Int32ToPtr(50)}}
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+		// The pods on node-c and node-d have not been created yet.
+		createPod("node-a", oldHash, true, 0)
+		createPod("node-b", oldHash, true, 0)
+		mockStatus.On("ClearDegraded").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		// Half of the four nodes are node-a and node-b.
+		Expect(remainingPods()).To(BeEmpty())
+	})
+
+	It("should not count the nodes that the DaemonSet does not tolerate", func() {
+		install.Spec.RolloutPolicy.MaxUnavailable = ptr.ToPtr(intstr.FromInt(3))
+		install.Spec.RolloutPolicy.Waves = []operatorv1.RolloutWave{{Percentage: ptr.Int32ToPtr(60)}}
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+		node := &corev1.Node{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "node-d"}, node)).ShouldNot(HaveOccurred())
+		node.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+		Expect(cli.Update(ctx, node)).ShouldNot(HaveOccurred())
+		for _, n := range []string{"node-a", "node-b", "node-c"} {
+			createPod(n, oldHash, true, 0)
+		}
+		mockStatus.On("ClearDegraded").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		// 60% of the three nodes that the DaemonSet runs on are node-a and node-b.
+		Expect(remainingPods()).To(ConsistOf("calico-node-node-c"))
+	})
+
+	It("should pause the rollout when updated pods are restarting", func() {
+		ds.Annotations[common.RolloutRestartsBaselineAnnotation] = `{"revision":"new"}`
+		Expect(cli.Update(ctx, ds)).ShouldNot(HaveOccurred())
+		createPod("node-a", oldHash, true, 0)
+		createPod("node-b", oldHash, true, 0)
+		createPod("node-c", newHash, false, 3)
+		createPod("node-d", oldHash, true, 0)
+		mockStatus.On("SetDegraded", operatorv1.PodFailure, "Rollout paused because updated pods are restarting: calico-system/calico-node", mock.Anything, mock.Anything).Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(progressCheckInterval))
+		Expect(remainingPods()).To(HaveLen(4))
+		mockStatus.AssertExpectations(GinkgoT())
+	})
+
+	It("should only count the restarts since the rollout started", func() {
+		createPod("node-a", oldHash, true, 0)
+		createPod("node-b", oldHash, true, 0)
+		createPod("node-c", newHash, true, 5)
+		createPod("node-d", oldHash, true, 0)
+		mockStatus.On("ClearDegraded").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(remainingPods()).To(ConsistOf("calico-node-node-b", "calico-node-node-c", "calico-node-node-d"))
+
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ds), ds)).ShouldNot(HaveOccurred())
+		Expect(ds.Annotations).To(HaveKeyWithValue(common.RolloutRestartsBaselineAnnotation, `{"revision":"new","restarts":{"calico-node-node-c":5}}`))
+	})
+
+	It("should reset the baseline of restarts when a new revision is rolled out", func() {
+		ds.Annotations[common.RolloutRestartsBaselineAnnotation] = `{"revision":"old","restarts":{"calico-node-node-c":1}}`
+		Expect(cli.Update(ctx, ds)).ShouldNot(HaveOccurred())
+		for _, n := range []string{"node-a", "node-b", "node-c", "node-d"} {
+			createPod(n, oldHash, true, 4)
+		}
+		mockStatus.On("ClearDegraded").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ds), ds)).ShouldNot(HaveOccurred())
+		Expect(ds.Annotations).To(HaveKeyWithValue(common.RolloutRestartsBaselineAnnotation, `{"revision":"new"}`))
+	})
+
+	It("should not pause a finished rollout because of restarts", func() {
+		for _, n := range []string{"node-a", "node-b", "node-c", "node-d"} {
+			createPod(n, newHash, true, 10)
+		}
+		mockStatus.On("ClearDegraded").Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		mockStatus.AssertExpectations(GinkgoT())
+	})
+
+	It("should be done once all pods are updated", func() {
+		for _, n := range []string{"node-a", "node-b", "node-c", "node-d"} {
+			createPod(n, newHash, true, 0)
+		}
+		mockStatus.On("ClearDegraded").Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(remainingPods()).To(HaveLen(4))
+
+		// The staged rollout is over until a new pod template is rendered.
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ds), ds)).ShouldNot(HaveOccurred())
+		Expect(ds.Annotations).NotTo(HaveKey(common.StagedRolloutAnnotation))
+	})
+
+	It("should end the staged rollouts when the rollout policy is removed", func() {
+		install.Spec.RolloutPolicy = nil
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+		for _, n := range []string{"node-a", "node-b", "node-c", "node-d"} {
+			createPod(n, oldHash, true, 0)
+		}
+		mockStatus.On("OnCRNotFound").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(remainingPods()).To(HaveLen(4))
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ds), ds)).ShouldNot(HaveOccurred())
+		Expect(ds.Annotations).NotTo(HaveKey(common.StagedRolloutAnnotation))
+	})
+
+	It("should ignore DaemonSets without a staged rollout", func() {
+		ds.Annotations = nil
+		Expect(cli.Update(ctx, ds)).ShouldNot(HaveOccurred())
+		for _, n := range []string{"node-a", "node-b", "node-c", "node-d"} {
+			createPod(n, oldHash, true, 0)
+		}
+		mockStatus.On("ClearDegraded").Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(remainingPods()).To(HaveLen(4))
+	})
+})
@@ -0,0 +1,325 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimesecurity
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/controller/utils/imageset"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/render"
+	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/runtimesecurity"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+const ResourceName = "runtime-security"
+
+var log = logf.Log.WithName("controller_runtime_security")
+
+// Add creates a new RuntimeSecurity Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists || opts.MultiTenant {
+		// The runtime security agent forwards the events of its own cluster, so it is not installed in multi-tenant
+		// management clusters.
+		return nil
+	}
+	licenseAPIReady := &utils.ReadyFlag{}
+	tierWatchReady := &utils.ReadyFlag{}
+
+	reconciler := newReconciler(mgr, opts, licenseAPIReady, tierWatchReady)
+
+	c, err := ctrlruntime.NewController("runtime-security-controller", mgr, controller.Options{Reconciler: reconciler})
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		log.Error(err, "Failed to establish a connection to k8s")
+		return err
+	}
+
+	go utils.WaitToAddLicenseKeyWatch(c, k8sClient, log, licenseAPIReady)
+	go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
+	go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, []types.NamespacedName{
+		{Name: runtimesecurity.RuntimeSecurityPolicyName, Namespace: runtimesecurity.RuntimeSecurityNamespace},
+	})
+
+	if err = c.WatchObject(&operatorv1.RuntimeSecurity{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("runtime-security-controller failed to watch RuntimeSecurity resource: %w", err)
+	}
+
+	if err = utils.AddInstallationWatch(c); err != nil {
+		return fmt.Errorf("runtime-security-controller failed to watch Installation resource: %w", err)
+	}
+
+	if err = imageset.AddImageSetWatch(c); err != nil {
+		return fmt.Errorf("runtime-security-controller failed to watch ImageSet: %w", err)
+	}
+
+	if err = utils.AddAPIServerWatch(c); err != nil {
+		return fmt.Errorf("runtime-security-controller failed to watch APIServer resource: %w", err)
+	}
+
+	if err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("runtime-security-controller failed to watch ManagementClusterConnection resource: %w", err)
+	}
+
+	for _, namespace := range []string{common.OperatorNamespace(), runtimesecurity.RuntimeSecurityNamespace} {
+		for _, secretName := range []string{
+			certificatemanagement.CASecretName,
+			render.TigeraLinseedSecret,
+			render.VoltronLinseedPublicCert,
+			runtimesecurity.RuntimeSecurityTLSSecretName,
+		} {
+			if err = utils.AddSecretsWatch(c, secretName, namespace); err != nil {
+				return fmt.Errorf("runtime-security-controller failed to watch the secret '%s' in '%s' namespace: %w", secretName, namespace, err)
+			}
+		}
+	}
+
+	if err = utils.AddTigeraStatusWatch(c, ResourceName); err != nil {
+		return fmt.Errorf("runtime-security-controller failed to watch runtime-security Tigerastatus: %w", err)
+	}
+
+	return nil
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
+	r := &ReconcileRuntimeSecurity{
+		client:          mgr.GetClient(),
+		scheme:          mgr.GetScheme(),
+		provider:        opts.DetectedProvider,
+		status:          status.New(mgr.GetClient(), ResourceName, opts.KubernetesVersion),
+		clusterDomain:   opts.ClusterDomain,
+		licenseAPIReady: licenseAPIReady,
+		tierWatchReady:  tierWatchReady,
+	}
+	r.status.Run(opts.ShutdownContext)
+	return r
+}
+
+// blank assignment to verify that ReconcileRuntimeSecurity implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileRuntimeSecurity{}
+
+// ReconcileRuntimeSecurity reconciles a RuntimeSecurity object
+type ReconcileRuntimeSecurity struct {
+	client          client.Client
+	scheme          *runtime.Scheme
+	provider        operatorv1.Provider
+	status          status.StatusManager
+	clusterDomain   string
+	licenseAPIReady *utils.ReadyFlag
+	tierWatchReady  *utils.ReadyFlag
+}
+
+// Reconcile reads that state of the cluster for a RuntimeSecurity object and makes changes based on the state read
+// and what is in the RuntimeSecurity.Spec.
+func (r *ReconcileRuntimeSecurity) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling RuntimeSecurity")
+
+	instance := &operatorv1.RuntimeSecurity{}
+	if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, instance); err != nil {
+		if errors.IsNotFound(err) {
+			// The rendered objects are owned by the RuntimeSecurity, so they are garbage collected with it.
+			reqLogger.Info("RuntimeSecurity object not found")
+			r.status.OnCRNotFound()
+			return reconcile.Result{}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying RuntimeSecurity", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	r.status.OnCRFound()
+	reqLogger.V(2).Info("Loaded config", "config", instance)
+
+	// SetMetaData in the TigeraStatus such as observedGenerations.
+	defer r.status.SetMetaData(&instance.ObjectMeta)
+
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
+	if !utils.IsAPIServerReady(r.client, reqLogger) {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", status.WithCode(operatorv1.APIServerNotReady, nil), reqLogger)
+		return utils.RequeueWithBackoff(), nil
+	}
+
+	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
+	if !r.tierWatchReady.IsReady() {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", status.WithCode(operatorv1.TierNotReady, nil), reqLogger)
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
+	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
+	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", status.WithCode(operatorv1.TierNotReady, err), reqLogger)
+			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	if !r.licenseAPIReady.IsReady() {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", status.WithCode(operatorv1.LicenseAPINotReady, nil), reqLogger)
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
+	license, err := utils.FetchLicenseKey(ctx, r.client)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", status.WithCode(operatorv1.LicenseNotFound, err), reqLogger)
+			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying license", err, reqLogger)
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
+	// Runtime threat detection is part of the threat defense feature set.
+	if !utils.IsFeatureActive(license, common.ThreatDefenseFeature) {
+		reqLogger.V(4).Info("RuntimeSecurity is not activated as part of this license")
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Feature is not active - License does not support this feature", status.WithCode(operatorv1.LicenseFeatureNotAvailable, nil), reqLogger)
+		return reconcile.Result{}, nil
+	}
+
+	variant, installation, err := utils.GetInstallation(ctx, r.client)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
+			return reconcile.Result{}, err
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	pullSecrets, err := utils.GetNetworkingPullSecrets(installation, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	managementClusterConnection, err := utils.GetManagementClusterConnection(ctx, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading ManagementClusterConnection", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	isManagedCluster := managementClusterConnection != nil
+
+	certificateManager, err := certificatemanager.Create(r.client, installation, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithLogger(reqLogger))
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	// The location of the Linseed certificate varies based on if this is a managed cluster or not.
+	linseedCertLocation := render.TigeraLinseedSecret
+	if isManagedCluster {
+		linseedCertLocation = render.VoltronLinseedPublicCert
+	}
+	linseedCertificate, err := certificateManager.GetCertificate(r.client, linseedCertLocation, common.OperatorNamespace())
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Failed to retrieve / validate %s", linseedCertLocation), err, reqLogger)
+		return reconcile.Result{}, err
+	} else if linseedCertificate == nil {
+		reqLogger.Info("Linseed certificate is not available yet, waiting until it becomes available")
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Linseed certificate is not available yet, waiting until it becomes available", status.WithCode(operatorv1.CertificateNotAvailable, nil), reqLogger)
+		return utils.RequeueWithBackoff(), nil
+	}
+
+	// keyPair is the key pair the runtime security agent presents to Linseed to identify itself.
+	keyPair, err := certificateManager.GetOrCreateKeyPair(r.client, runtimesecurity.RuntimeSecurityTLSSecretName, common.OperatorNamespace(), []string{runtimesecurity.RuntimeSecurityTLSSecretName})
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Error creating TLS certificate", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	certificateManager.AddToStatusManager(r.status, runtimesecurity.RuntimeSecurityNamespace)
+	trustedBundle := certificateManager.CreateTrustedBundle(linseedCertificate)
+
+	component := runtimesecurity.RuntimeSecurity(&runtimesecurity.Config{
+		RuntimeSecurity: instance,
+		Installation:    installation,
+		PullSecrets:     pullSecrets,
+		OpenShift:       r.provider.IsOpenShift(),
+		ManagedCluster:  isManagedCluster,
+		ClusterDomain:   r.clusterDomain,
+		KeyPair:         keyPair,
+		TrustedBundle:   trustedBundle,
+	})
+	if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	components := []render.Component{
+		component,
+		rcertificatemanagement.CertificateManagement(&rcertificatemanagement.Config{
+			Namespace:       runtimesecurity.RuntimeSecurityNamespace,
+			ServiceAccounts: []string{runtimesecurity.RuntimeSecurityName},
+			KeyPairOptions: []rcertificatemanagement.KeyPairOption{
+				rcertificatemanagement.NewKeyPairOption(keyPair, true, true),
+			},
+			TrustedBundle: trustedBundle,
+		}),
+	}
+
+	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	for _, comp := range components {
+		if err = handler.CreateOrUpdateOrDelete(ctx, comp, r.status); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating resource", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Clear the degraded bit if we've reached this far.
+	r.status.ClearDegraded()
+
+	if !r.status.IsAvailable() {
+		// Schedule a kick to check again in the near future. Hopefully by then things will be available.
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
+	// Everything is available - update the CRD status.
+	instance.Status.State = operatorv1.TigeraStatusReady
+	if err = r.client.Status().Update(ctx, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
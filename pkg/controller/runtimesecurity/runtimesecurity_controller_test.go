@@ -0,0 +1,229 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimesecurity
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/render/runtimesecurity"
+	"github.com/tigera/operator/test"
+)
+
+var _ = Describe("RuntimeSecurity controller tests", func() {
+	var c client.Client
+	var ctx context.Context
+	var r ReconcileRuntimeSecurity
+	var mockStatus *status.MockStatus
+	var certificateManager certificatemanager.CertificateManager
+
+	getDaemonSet := func() (*appsv1.DaemonSet, error) {
+		ds := &appsv1.DaemonSet{}
+		err := c.Get(ctx, client.ObjectKey{Name: runtimesecurity.RuntimeSecurityName, Namespace: runtimesecurity.RuntimeSecurityNamespace}, ds)
+		return ds, err
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(rbacv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		mockStatus = &status.MockStatus{}
+		mockStatus.On("AddDaemonsets", mock.Anything).Return()
+		mockStatus.On("AddDeployments", mock.Anything).Return()
+		mockStatus.On("AddStatefulSets", mock.Anything).Return()
+		mockStatus.On("AddCronJobs", mock.Anything)
+		mockStatus.On("IsAvailable").Return(true)
+		mockStatus.On("OnCRFound").Return()
+		mockStatus.On("ClearDegraded")
+		mockStatus.On("ReadyToMonitor")
+		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+		mockStatus.On("SetMetaData", mock.Anything).Return()
+
+		r = ReconcileRuntimeSecurity{
+			client:          c,
+			scheme:          scheme,
+			provider:        operatorv1.ProviderNone,
+			status:          mockStatus,
+			licenseAPIReady: &utils.ReadyFlag{},
+			tierWatchReady:  &utils.ReadyFlag{},
+		}
+
+		Expect(c.Create(ctx, &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operatorv1.InstallationSpec{
+				Variant:  operatorv1.TigeraSecureEnterprise,
+				Registry: "some.registry.org/",
+			},
+			Status: operatorv1.InstallationStatus{
+				Variant: operatorv1.TigeraSecureEnterprise,
+				Computed: &operatorv1.InstallationSpec{
+					Registry:           "my-reg",
+					KubernetesProvider: operatorv1.ProviderNone,
+				},
+			},
+		})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &operatorv1.APIServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Status:     operatorv1.APIServerStatus{State: operatorv1.TigeraStatusReady},
+		})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera"}})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &v3.LicenseKey{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Status:     v3.LicenseKeyStatus{Features: []string{common.ThreatDefenseFeature}},
+		})).NotTo(HaveOccurred())
+
+		var err error
+		certificateManager, err = certificatemanager.Create(c, nil, "", common.OperatorNamespace(), certificatemanager.AllowCACreation())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, certificateManager.KeyPair().Secret(common.OperatorNamespace()))).NotTo(HaveOccurred())
+		linseedTLS, err := certificateManager.GetOrCreateKeyPair(c, render.TigeraLinseedSecret, common.OperatorNamespace(), []string{render.TigeraLinseedSecret})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, linseedTLS.Secret(common.OperatorNamespace()))).NotTo(HaveOccurred())
+
+		Expect(c.Create(ctx, &operatorv1.RuntimeSecurity{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
+
+		r.licenseAPIReady.MarkAsReady()
+		r.tierWatchReady.MarkAsReady()
+	})
+
+	It("should render the runtime security agent", func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		ds, err := getDaemonSet()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ds.Spec.Template.Spec.Containers).To(HaveLen(1))
+		Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal(fmt.Sprintf("some.registry.org/%s:%s",
+			components.ComponentRuntimeSecurity.Image,
+			components.ComponentRuntimeSecurity.Version)))
+
+		Expect(test.GetResource(c, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:      runtimesecurity.RuntimeSecurityTLSSecretName,
+			Namespace: runtimesecurity.RuntimeSecurityNamespace,
+		}})).To(BeNil())
+
+		instance := &operatorv1.RuntimeSecurity{}
+		Expect(c.Get(ctx, utils.DefaultTSEEInstanceKey, instance)).NotTo(HaveOccurred())
+		Expect(instance.Status.State).To(Equal(operatorv1.TigeraStatusReady))
+	})
+
+	It("should use images from imageset", func() {
+		Expect(c.Create(ctx, &operatorv1.ImageSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
+			Spec: operatorv1.ImageSetSpec{
+				Images: []operatorv1.Image{
+					{Image: "tigera/runtime-security", Digest: "sha256:runtimesecurityhash"},
+					{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+				},
+			},
+		})).ToNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		ds, err := getDaemonSet()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal(fmt.Sprintf("some.registry.org/%s@%s",
+			components.ComponentRuntimeSecurity.Image,
+			"sha256:runtimesecurityhash")))
+	})
+
+	It("should not render the agent if the license does not include threat defense", func() {
+		license := &v3.LicenseKey{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, license)).NotTo(HaveOccurred())
+		license.Status.Features = []string{common.PolicyRecommendationFeature}
+		Expect(c.Update(ctx, license)).NotTo(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Feature is not active - License does not support this feature", nil, mock.Anything).Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Feature is not active - License does not support this feature", nil, mock.Anything)
+
+		_, err = getDaemonSet()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should wait for the Linseed certificate of the management cluster in a managed cluster", func() {
+		Expect(c.Create(ctx, &operatorv1.ManagementClusterConnection{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+		})).NotTo(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Linseed certificate is not available yet, waiting until it becomes available", nil, mock.Anything).Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotReady, "Linseed certificate is not available yet, waiting until it becomes available", nil, mock.Anything)
+
+		linseedPublicCert, err := certificateManager.GetOrCreateKeyPair(c, render.VoltronLinseedPublicCert, common.OperatorNamespace(), []string{render.VoltronLinseedPublicCert})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, linseedPublicCert.Secret(common.OperatorNamespace()))).NotTo(HaveOccurred())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		ds, err := getDaemonSet()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "LINSEED_TOKEN", Value: render.LinseedTokenPath}))
+		Expect(test.GetResource(c, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+			Name:      "tigera-linseed",
+			Namespace: runtimesecurity.RuntimeSecurityNamespace,
+		}})).To(BeNil())
+	})
+
+	Context("allow-tigera reconciliation", func() {
+		BeforeEach(func() {
+			mockStatus = &status.MockStatus{}
+			mockStatus.On("OnCRFound").Return()
+			mockStatus.On("SetMetaData", mock.Anything).Return()
+			r.status = mockStatus
+		})
+
+		It("should wait if allow-tigera tier is unavailable", func() {
+			test.DeleteAllowTigeraTierAndExpectWait(ctx, c, &r, mockStatus)
+		})
+
+		It("should wait if tier watch is not ready", func() {
+			r.tierWatchReady = &utils.ReadyFlag{}
+			test.ExpectWaitForTierWatch(ctx, &r, mockStatus)
+		})
+	})
+})
@@ -0,0 +1,218 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/secret"
+)
+
+// SecretMirrorController keeps the secrets that components mirror into their namespaces with secret.MirrorToNamespace
+// in sync with the secrets they are copied from. The components create the mirrors, but only update them when they
+// reconcile, which can leave a namespace with stale data after the source secret changes. Once the source secret is
+// deleted, its mirrors are deleted as well.
+type SecretMirrorController struct {
+	client client.Client
+	log    logr.Logger
+}
+
+func AddSecretMirrorController(mgr manager.Manager, opts options.AddOptions) error {
+	r := &SecretMirrorController{
+		client: mgr.GetClient(),
+		log:    logf.Log.WithName("controller_secret_mirror"),
+	}
+
+	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
+	c, err := ctrlruntime.NewController("secret-mirror-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Any secret may be the source of a mirror, so watch all secrets and map them to the mirrors that they affect.
+	if err = c.WatchObject(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.mirrorsOf)); err != nil {
+		return fmt.Errorf("secret-mirror-controller failed to watch secrets: %w", err)
+	}
+
+	// Perform periodic reconciliation. This acts as a backstop to catch reconcile issues,
+	// and also makes sure we spot when mirrors are modified outside of the operator.
+	err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return fmt.Errorf("secret-mirror-controller failed to create periodic reconcile watch: %w", err)
+	}
+
+	return nil
+}
+
+// mirrorsOf returns a request for the given secret if it is a mirror, and for each of the mirrors of the given secret.
+func (r *SecretMirrorController) mirrorsOf(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetLabels()[secret.MirrorLabel] == "true" {
+		return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(obj)}}
+	}
+	mirrors, err := r.listMirrors(ctx)
+	if err != nil {
+		r.log.Error(err, "Error listing mirrored secrets")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, m := range mirrors {
+		if src, ok := secret.MirrorSource(&m); ok && src == client.ObjectKeyFromObject(obj) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&m)})
+		}
+	}
+	return requests
+}
+
+func (r *SecretMirrorController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logc := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	if request.Name != "" {
+		mirror := &corev1.Secret{}
+		if err := r.client.Get(ctx, request.NamespacedName, mirror); err != nil {
+			if errors.IsNotFound(err) {
+				return reconcile.Result{}, nil
+			}
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.syncMirror(ctx, logc, mirror)
+	}
+
+	// The periodic reconcile does not name a mirror, so sync all of them.
+	mirrors, err := r.listMirrors(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range mirrors {
+		if err = r.syncMirror(ctx, logc, &mirrors[i]); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *SecretMirrorController) listMirrors(ctx context.Context) ([]corev1.Secret, error) {
+	mirrors := &corev1.SecretList{}
+	if err := r.client.List(ctx, mirrors, client.MatchingLabels{secret.MirrorLabel: "true"}); err != nil {
+		return nil, err
+	}
+	return mirrors.Items, nil
+}
+
+// syncMirror updates the data of the given mirror from its source secret, or deletes the mirror if the source no longer
+// exists.
+func (r *SecretMirrorController) syncMirror(ctx context.Context, log logr.Logger, mirror *corev1.Secret) error {
+	src, ok := secret.MirrorSource(mirror)
+	if !ok {
+		return nil
+	}
+	log = log.WithValues("Secret.Namespace", mirror.Namespace, "Secret.Name", mirror.Name, "Source", src.String())
+
+	source := &corev1.Secret{}
+	if err := r.client.Get(ctx, src, source); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if utils.IgnoreObject(mirror) {
+			log.Info("Ignoring annotated object")
+			return nil
+		}
+		log.Info("Deleting mirrored secret, its source no longer exists")
+		if err = r.client.Delete(ctx, mirror); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	return syncSecretCopy(ctx, r.client, log, secret.MirrorToNamespace(mirror.Namespace, source)[0])
+}
+
+// syncSecretCopy creates the given copy of a secret, or updates the data of an existing copy if it differs. The labels
+// and annotations of the desired copy are added to an existing copy, but its other metadata is left as-is, so the
+// ownership set by the component controllers is preserved.
+func syncSecretCopy(ctx context.Context, cli client.Client, log logr.Logger, desired *corev1.Secret) error {
+	log = log.WithValues("Secret.Namespace", desired.Namespace, "Secret.Name", desired.Name)
+
+	current := &corev1.Secret{}
+	err := cli.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, current)
+	if errors.IsNotFound(err) {
+		log.V(2).Info("Creating secret copy")
+		return cli.Create(ctx, desired)
+	} else if err != nil {
+		return err
+	}
+
+	if utils.IgnoreObject(current) {
+		log.Info("Ignoring annotated object")
+		return nil
+	}
+	metadataInSync := containsAll(current.Labels, desired.Labels) && containsAll(current.Annotations, desired.Annotations)
+	if metadataInSync && current.Type == desired.Type && rmeta.SecretDataHash(current) == rmeta.SecretDataHash(desired) {
+		return nil
+	}
+
+	if current.Type != desired.Type {
+		// The type of a secret is immutable, so the copy has to be replaced.
+		log.Info("Replacing secret copy with a different type")
+		if err = cli.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return cli.Create(ctx, desired)
+	}
+
+	log.Info("Updating stale secret copy")
+	current.Labels = mergeInto(current.Labels, desired.Labels)
+	current.Annotations = mergeInto(current.Annotations, desired.Annotations)
+	current.Data = desired.Data
+	current.StringData = desired.StringData
+	return cli.Update(ctx, current)
+}
+
+// containsAll returns true if every key/value pair of sub is present in m.
+func containsAll(m, sub map[string]string) bool {
+	for k, v := range sub {
+		if val, ok := m[k]; !ok || val != v {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeInto sets the key/value pairs of src on dst, initializing dst if needed.
+func mergeInto(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	dst = common.MapExistsOrInitialize(dst)
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render/common/secret"
+)
+
+var _ = Describe("Secret mirror controller", func() {
+	var (
+		cli    client.Client
+		ctx    context.Context
+		source *corev1.Secret
+		r      *SecretMirrorController
+	)
+
+	mirrorKey := client.ObjectKey{Name: "credentials", Namespace: "tigera-elasticsearch"}
+
+	getMirror := func() (*corev1.Secret, error) {
+		s := &corev1.Secret{}
+		err := cli.Get(ctx, mirrorKey, s)
+		return s, err
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		ctx = context.Background()
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		source = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: common.OperatorNamespace()},
+			Data:       map[string][]byte{"password": []byte("old-password")},
+		}
+		Expect(cli.Create(ctx, source)).ShouldNot(HaveOccurred())
+
+		// Create the mirror the way a component would render it.
+		owner := metav1.OwnerReference{APIVersion: operatorv1.GroupVersion.String(), Kind: "LogStorage", Name: "tigera-secure"}
+		mirror := secret.MirrorToNamespace("tigera-elasticsearch", source)[0]
+		mirror.OwnerReferences = []metav1.OwnerReference{owner}
+		Expect(cli.Create(ctx, mirror)).ShouldNot(HaveOccurred())
+
+		r = &SecretMirrorController{client: cli, log: logf.Log.WithName("controller_secret_mirror")}
+	})
+
+	It("should label mirrors with their source", func() {
+		m, err := getMirror()
+		Expect(err).ShouldNot(HaveOccurred())
+		src, ok := secret.MirrorSource(m)
+		Expect(ok).To(BeTrue())
+		Expect(src).To(Equal(client.ObjectKeyFromObject(source)))
+
+		// Secrets that are copied within their namespace are not mirrors.
+		Expect(secret.MirrorToNamespace(common.OperatorNamespace(), source)[0].Labels).To(BeEmpty())
+	})
+
+	It("should update the mirror when the source changes and preserve its metadata", func() {
+		source.Data = map[string][]byte{"password": []byte("new-password")}
+		Expect(cli.Update(ctx, source)).ShouldNot(HaveOccurred())
+
+		Expect(r.mirrorsOf(ctx, source)).To(ConsistOf(reconcile.Request{NamespacedName: mirrorKey}))
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mirrorKey})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		m, err := getMirror()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(m.Data).To(Equal(source.Data))
+		Expect(m.OwnerReferences).To(HaveLen(1))
+		Expect(m.Labels).To(HaveKeyWithValue(secret.MirrorLabel, "true"))
+	})
+
+	It("should sync all mirrors on a periodic reconcile", func() {
+		source.Data = map[string][]byte{"password": []byte("new-password")}
+		Expect(cli.Update(ctx, source)).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		m, err := getMirror()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(m.Data).To(Equal(source.Data))
+	})
+
+	It("should delete the mirror when the source is deleted", func() {
+		Expect(cli.Delete(ctx, source)).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mirrorKey})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = getMirror()
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should not map unrelated secrets to any mirror", func() {
+		other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: common.OperatorNamespace()}}
+		Expect(r.mirrorsOf(ctx, other)).To(BeEmpty())
+	})
+
+	It("should not modify mirrors that are marked as ignored", func() {
+		m, err := getMirror()
+		Expect(err).ShouldNot(HaveOccurred())
+		m.Annotations["unsupported.operator.tigera.io/ignore"] = "true"
+		Expect(cli.Update(ctx, m)).ShouldNot(HaveOccurred())
+		Expect(cli.Delete(ctx, source)).ShouldNot(HaveOccurred())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: mirrorKey})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		m, err = getMirror()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(m.Data).To(Equal(map[string][]byte{"password": []byte("old-password")}))
+	})
+})
@@ -0,0 +1,156 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/render/common/secret"
+)
+
+// PullSecretController copies the image pull secrets configured on the Installation from the operator namespace into
+// every namespace created by the operator, and keeps the copies up to date when the source secrets are rotated.
+// Component controllers also render the pull secrets into their namespaces, but only when they reconcile, which
+// can leave namespaces with stale credentials after the source secrets change.
+type PullSecretController struct {
+	client client.Client
+	log    logr.Logger
+}
+
+func AddPullSecretController(mgr manager.Manager, opts options.AddOptions) error {
+	r := &PullSecretController{
+		client: mgr.GetClient(),
+		log:    logf.Log.WithName("controller_pull_secrets"),
+	}
+
+	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
+	c, err := ctrlruntime.NewController("pull-secret-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for triggers. The names of the pull secrets are configured on the Installation, so watch all secrets
+	// in the operator namespace, and all namespaces so that copies are made as soon as a namespace is created.
+	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("pull-secret-controller failed to watch primary resource: %w", err)
+	}
+	if err = utils.AddSecretsWatch(c, "", common.OperatorNamespace()); err != nil {
+		return fmt.Errorf("pull-secret-controller failed to watch pull secrets: %w", err)
+	}
+	if err = utils.AddNamespacedWatch(c, &corev1.Namespace{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("pull-secret-controller failed to watch namespaces: %w", err)
+	}
+
+	// Perform periodic reconciliation. This acts as a backstop to catch reconcile issues,
+	// and also makes sure we spot when copies are modified outside of the operator.
+	err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return fmt.Errorf("pull-secret-controller failed to create periodic reconcile watch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullSecretController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logc := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	// Get Installation resource.
+	instance := &operatorv1.Installation{}
+	if err := r.client.Get(ctx, utils.DefaultInstanceKey, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	pullSecrets, err := utils.GetNetworkingPullSecrets(&instance.Spec, r.client)
+	if err != nil {
+		logc.Error(err, "Error retrieving pull secrets")
+		return reconcile.Result{}, err
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err = r.client.List(ctx, namespaces); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Keep track of the copies that should exist, so that any other labeled copy can be pruned below.
+	wanted := map[types.NamespacedName]bool{}
+	for _, ns := range namespaces.Items {
+		if ns.Name == common.OperatorNamespace() || ns.DeletionTimestamp != nil || !ownedByOperator(&ns) {
+			continue
+		}
+		for _, s := range secret.MirrorToNamespace(ns.Name, pullSecrets...) {
+			s.Labels = common.MapExistsOrInitialize(s.Labels)
+			s.Labels[secret.PullSecretLabel] = "true"
+			wanted[types.NamespacedName{Namespace: s.Namespace, Name: s.Name}] = true
+			if err = syncSecretCopy(ctx, r.client, logc, s); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	// Delete the copies of secrets that are no longer configured as pull secrets, or that are in namespaces that
+	// no longer need them.
+	copies := &corev1.SecretList{}
+	if err = r.client.List(ctx, copies, client.MatchingLabels{secret.PullSecretLabel: "true"}); err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range copies.Items {
+		s := &copies.Items[i]
+		if wanted[types.NamespacedName{Namespace: s.Namespace, Name: s.Name}] {
+			continue
+		}
+		if utils.IgnoreObject(s) {
+			logc.Info("Ignoring annotated object", "Secret.Namespace", s.Namespace, "Secret.Name", s.Name)
+			continue
+		}
+		logc.Info("Deleting pull secret copy that is no longer needed", "Secret.Namespace", s.Namespace, "Secret.Name", s.Name)
+		if err = r.client.Delete(ctx, s); err != nil && !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// ownedByOperator returns true if the namespace was created by the operator, in which case it is owned by one of
+// the operator's custom resources.
+func ownedByOperator(obj metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.APIVersion == operatorv1.GroupVersion.String() {
+			return true
+		}
+	}
+	return false
+}
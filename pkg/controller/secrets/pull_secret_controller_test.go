@@ -0,0 +1,233 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render/common/secret"
+)
+
+var _ = Describe("Pull secret controller", func() {
+	var (
+		cli     client.Client
+		ctx     context.Context
+		install *operatorv1.Installation
+		r       *PullSecretController
+	)
+
+	operatorNamespace := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: operatorv1.GroupVersion.String(),
+					Kind:       "LogStorage",
+					Name:       "tigera-secure",
+				}},
+			},
+		}
+	}
+
+	getCopy := func(namespace string) (*corev1.Secret, error) {
+		s := &corev1.Secret{}
+		err := cli.Get(ctx, client.ObjectKey{Name: "pull-secret", Namespace: namespace}, s)
+		return s, err
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		ctx = context.Background()
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		install = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operatorv1.InstallationSpec{
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "pull-secret"}},
+			},
+		}
+		Expect(cli.Create(ctx, install)).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: common.OperatorNamespace()},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("new-creds")},
+		})).ShouldNot(HaveOccurred())
+
+		Expect(cli.Create(ctx, operatorNamespace("tigera-elasticsearch"))).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "user-namespace"}})).ShouldNot(HaveOccurred())
+
+		r = &PullSecretController{client: cli, log: logf.Log.WithName("controller_pull_secrets")}
+	})
+
+	It("should copy the pull secrets into namespaces created by the operator", func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		s, err := getCopy("tigera-elasticsearch")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(s.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+		Expect(s.Data).To(Equal(map[string][]byte{corev1.DockerConfigJsonKey: []byte("new-creds")}))
+
+		_, err = getCopy("user-namespace")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should label the copies with the secret they were copied from", func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		s, err := getCopy("tigera-elasticsearch")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(s.Labels).To(HaveKeyWithValue(secret.MirrorLabel, "true"))
+		Expect(s.Labels).To(HaveKeyWithValue(secret.PullSecretLabel, "true"))
+		Expect(s.Annotations).To(HaveKeyWithValue(secret.MirrorSourceAnnotation, common.OperatorNamespace()+"/pull-secret"))
+	})
+
+	It("should delete the copies of secrets that are no longer pull secrets", func() {
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: common.OperatorNamespace()},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("other-creds")},
+		})).ShouldNot(HaveOccurred())
+		install.Spec.ImagePullSecrets = append(install.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: "other-secret"})
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "other-secret", Namespace: "tigera-elasticsearch"}, &corev1.Secret{})).ShouldNot(HaveOccurred())
+
+		install.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "pull-secret"}}
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		err = cli.Get(ctx, client.ObjectKey{Name: "other-secret", Namespace: "tigera-elasticsearch"}, &corev1.Secret{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = getCopy("tigera-elasticsearch")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should delete all copies once there are no pull secrets", func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = getCopy("tigera-elasticsearch")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		install.Spec.ImagePullSecrets = nil
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = getCopy("tigera-elasticsearch")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		// The source secret in the operator namespace is left alone.
+		_, err = getCopy(common.OperatorNamespace())
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should not delete secrets that were not copied by the controller", func() {
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-secret", Namespace: "tigera-elasticsearch"},
+			Type:       corev1.SecretTypeOpaque,
+		})).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "user-secret", Namespace: "tigera-elasticsearch"}, &corev1.Secret{})).ShouldNot(HaveOccurred())
+	})
+
+	It("should update stale copies and preserve their metadata", func() {
+		owner := metav1.OwnerReference{APIVersion: operatorv1.GroupVersion.String(), Kind: "LogStorage", Name: "tigera-secure"}
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "pull-secret",
+				Namespace:       "tigera-elasticsearch",
+				OwnerReferences: []metav1.OwnerReference{owner},
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte("old-creds")},
+		})).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		s, err := getCopy("tigera-elasticsearch")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(s.Data).To(Equal(map[string][]byte{corev1.DockerConfigJsonKey: []byte("new-creds")}))
+		Expect(s.OwnerReferences).To(ConsistOf(owner))
+		Expect(s.Labels).To(HaveKeyWithValue(secret.PullSecretLabel, "true"))
+	})
+
+	It("should replace copies with a different secret type", func() {
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: "tigera-elasticsearch"},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{"creds": []byte("old-creds")},
+		})).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		s, err := getCopy("tigera-elasticsearch")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(s.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+		Expect(s.Data).To(Equal(map[string][]byte{corev1.DockerConfigJsonKey: []byte("new-creds")}))
+	})
+
+	It("should not modify copies that are marked as ignored", func() {
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pull-secret",
+				Namespace:   "tigera-elasticsearch",
+				Annotations: map[string]string{"unsupported.operator.tigera.io/ignore": "true"},
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte("old-creds")},
+		})).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		s, err := getCopy("tigera-elasticsearch")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(s.Data).To(Equal(map[string][]byte{corev1.DockerConfigJsonKey: []byte("old-creds")}))
+	})
+
+	It("should return an error if a pull secret does not exist", func() {
+		install.Spec.ImagePullSecrets = append(install.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: "missing"})
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -119,6 +119,10 @@ func (r *TenantController) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 	r.status.OnCRFound()
 
+	if utils.IsReconcilePaused(&tenant.ObjectMeta, r.status, logc) {
+		return reconcile.Result{}, nil
+	}
+
 	// Get all Tenants so we can perform validation.
 	tenants := operatorv1.TenantList{}
 	if err = r.client.List(ctx, &tenants); err != nil {
@@ -140,7 +144,7 @@ func (r *TenantController) Reconcile(ctx context.Context, request reconcile.Requ
 	_, installation, err := utils.GetInstallation(context.Background(), r.client)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, logc)
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), logc)
 			return reconcile.Result{}, err
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, logc)
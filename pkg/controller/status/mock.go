@@ -88,6 +88,7 @@ func (m *MockStatus) RemoveCertificateSigningRequests(label string) {
 }
 
 func (m *MockStatus) SetDegraded(reason operator.TigeraStatusReason, msg string, err error, log logr.Logger) {
+	_, err = splitCode(err)
 	if err != nil {
 		m.Called(reason, msg, err.Error(), log)
 	} else {
@@ -126,3 +127,7 @@ func (m *MockStatus) WasCalled(method string, arguments ...interface{}) bool {
 func (m *MockStatus) SetMetaData(meta *metav1.ObjectMeta) {
 	m.Called(meta)
 }
+
+func (m *MockStatus) WaitForDependencies(components ...string) bool {
+	return m.Called(components).Bool(0)
+}
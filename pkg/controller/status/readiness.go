@@ -0,0 +1,96 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// Readiness is the registry in which the status managers record whether their components are available. It is shared by
+// all the controllers of the operator, so that a controller can wait for the components it depends on to become
+// available before reconciling, rather than requeueing and reporting itself as degraded until they are.
+var Readiness = NewReadinessRegistry()
+
+// ReadinessRegistry tracks whether each component, identified by the name of its TigeraStatus, is available.
+type ReadinessRegistry struct {
+	lock      sync.Mutex
+	ready     map[string]bool
+	listeners map[string][]chan event.GenericEvent
+}
+
+func NewReadinessRegistry() *ReadinessRegistry {
+	return &ReadinessRegistry{
+		ready:     make(map[string]bool),
+		listeners: make(map[string][]chan event.GenericEvent),
+	}
+}
+
+// IsReady returns true if the component is available. Components that have not reported their status yet are not
+// ready.
+func (r *ReadinessRegistry) IsReady(component string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ready[component]
+}
+
+// NotReady returns the components, out of the given ones, that are not ready.
+func (r *ReadinessRegistry) NotReady(components ...string) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var notReady []string
+	for _, component := range components {
+		if !r.ready[component] {
+			notReady = append(notReady, component)
+		}
+	}
+	return notReady
+}
+
+// SetReady records whether the component is available, and notifies the listeners of the component when it becomes
+// available.
+func (r *ReadinessRegistry) SetReady(component string, ready bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.ready[component] == ready {
+		return
+	}
+	r.ready[component] = ready
+	if !ready {
+		return
+	}
+
+	eventObject := &unstructured.Unstructured{}
+	eventObject.SetName(component + "-ready-event")
+	for _, listener := range r.listeners[component] {
+		// The channels are buffered, and a pending event is enough to trigger a reconcile, so never block on a
+		// listener that hasn't consumed the previous one.
+		select {
+		case listener <- event.GenericEvent{Object: eventObject}:
+		default:
+		}
+	}
+}
+
+// Listen returns a channel that receives an event each time the component becomes available.
+func (r *ReadinessRegistry) Listen(component string) <-chan event.GenericEvent {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	listener := make(chan event.GenericEvent, 1)
+	r.listeners[component] = append(r.listeners[component], listener)
+	return listener
+}
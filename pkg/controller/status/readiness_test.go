@@ -0,0 +1,57 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Readiness registry", func() {
+	It("should track which components are ready", func() {
+		r := NewReadinessRegistry()
+		Expect(r.IsReady("log-storage-elastic")).To(BeFalse())
+		Expect(r.NotReady("log-storage-elastic", "log-storage-access")).To(Equal([]string{"log-storage-elastic", "log-storage-access"}))
+
+		r.SetReady("log-storage-elastic", true)
+		Expect(r.IsReady("log-storage-elastic")).To(BeTrue())
+		Expect(r.NotReady("log-storage-elastic", "log-storage-access")).To(Equal([]string{"log-storage-access"}))
+
+		r.SetReady("log-storage-elastic", false)
+		Expect(r.IsReady("log-storage-elastic")).To(BeFalse())
+	})
+
+	It("should notify the listeners each time a component becomes ready", func() {
+		r := NewReadinessRegistry()
+		listener := r.Listen("log-storage-elastic")
+		other := r.Listen("log-storage-access")
+
+		r.SetReady("log-storage-elastic", true)
+		Eventually(listener).Should(Receive())
+		Consistently(other).ShouldNot(Receive())
+
+		By("not notifying the listeners again while the component stays ready")
+		r.SetReady("log-storage-elastic", true)
+		Consistently(listener).ShouldNot(Receive())
+
+		By("not blocking on a listener that hasn't consumed its event")
+		r.SetReady("log-storage-elastic", false)
+		r.SetReady("log-storage-elastic", true)
+		r.SetReady("log-storage-elastic", false)
+		r.SetReady("log-storage-elastic", true)
+		Eventually(listener).Should(Receive())
+		Consistently(listener).ShouldNot(Receive())
+	})
+})
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -77,6 +78,7 @@ type StatusManager interface {
 	IsDegraded() bool
 	ReadyToMonitor()
 	SetMetaData(meta *metav1.ObjectMeta)
+	WaitForDependencies(components ...string) bool
 }
 
 type statusManager struct {
@@ -95,10 +97,12 @@ type statusManager struct {
 	degraded               bool
 	explicitDegradedMsg    string
 	explicitDegradedReason operator.TigeraStatusReason
+	explicitDegradedCode   operator.TigeraStatusErrorCode
 
 	// Keep track of currently calculated status.
 	progressing []string
 	failing     []string
+	workloads   []operator.TigeraStatusWorkload
 
 	// readyToMonitor tells the status manager that it's ready to monitor the resources that it's been told to monitor,
 	// if there are any, and report statuses based on the state of those resources.
@@ -111,6 +115,14 @@ type statusManager struct {
 	crExists bool
 
 	observedGeneration int64
+
+	// paused tracks whether the reconciliation of the CR is paused by the common.ReconcileAnnotation.
+	paused bool
+
+	// readiness is the registry in which the availability of the component is recorded, and waitingFor the components
+	// that the component waits for, as given to WaitForDependencies.
+	readiness  *ReadinessRegistry
+	waitingFor []string
 }
 
 func New(client client.Client, component string, kubernetesVersion *common.VersionInfo) StatusManager {
@@ -135,6 +147,7 @@ func New(client client.Client, component string, kubernetesVersion *common.Versi
 		certificatestatusrequests: make(map[string]map[string]string),
 		kubernetesVersion:         kubernetesVersion,
 		crExists:                  crExists,
+		readiness:                 Readiness,
 	}
 }
 
@@ -147,6 +160,7 @@ func (m *statusManager) updateStatus() {
 	if m.enabled != nil && !*m.enabled {
 		// This status manager is explicitly disabled, because the controller has called OnCRNotFound.
 		// Remove any TigeraStatus object that had previously been created, and skip updating the status.
+		m.readiness.SetReady(m.component, false)
 		m.removeTigeraStatus()
 		return
 	}
@@ -161,13 +175,19 @@ func (m *statusManager) updateStatus() {
 		// We've collected knowledge about the current state of the objects we're monitoring.
 		// Now, use that to update the TigeraStatus object for this manager.
 		available := m.IsAvailable()
-		if m.IsAvailable() {
+		m.readiness.SetReady(m.component, available)
+		if available {
 			m.setAvailable(operator.AllObjectsAvailable, "All objects available")
 		} else {
 			m.clearAvailable()
 		}
 
-		if m.IsProgressing() {
+		if m.isPaused() {
+			// The controller no longer changes the objects of the component, so report that instead of its progress.
+			m.setPaused()
+		} else if waitingFor := m.dependenciesNotReady(); len(waitingFor) != 0 {
+			m.setWaitingForDependencies(waitingFor)
+		} else if m.IsProgressing() {
 			m.setProgressing(operator.ResourceNotReady, m.progressingMessage())
 		} else {
 			if available {
@@ -178,7 +198,7 @@ func (m *statusManager) updateStatus() {
 		}
 
 		if m.IsDegraded() {
-			m.setDegraded(m.degradedReason(), m.degradedMessage())
+			m.setDegraded(m.degradedReason(), m.degradedCode(), m.degradedMessage())
 		} else {
 			if available {
 				m.clearDegradedWithReason(operator.AllObjectsAvailable, "All Objects Available")
@@ -188,17 +208,35 @@ func (m *statusManager) updateStatus() {
 		}
 	} else {
 		log.V(2).WithName(m.component).Info("Status manager is not ready to report component statuses.")
+		m.readiness.SetReady(m.component, false)
 
 		// If we've been given an explicit degraded reason then it should be reported even if readyToMonitor is false,
 		// as this degraded reason may be the reason why we're not ready to monitor.
 		if m.isExplicitlyDegraded() {
-			m.setDegraded(m.degradedReason(), m.degradedMessage())
+			m.setDegraded(m.degradedReason(), m.degradedCode(), m.degradedMessage())
 		} else {
 			m.clearDegraded()
 		}
+		if m.isPaused() {
+			m.setPaused()
+		} else if waitingFor := m.dependenciesNotReady(); len(waitingFor) != 0 {
+			m.setWaitingForDependencies(waitingFor)
+		}
 	}
 }
 
+func (m *statusManager) isPaused() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.paused
+}
+
+func (m *statusManager) dependenciesNotReady() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.waitingFor
+}
+
 func (m *statusManager) isExplicitlyDegraded() bool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -351,9 +389,11 @@ func (m *statusManager) RemoveCertificateSigningRequests(name string) {
 	delete(m.certificatestatusrequests, name)
 }
 
-// SetDegraded sets degraded state with the provided reason and message.
+// SetDegraded sets degraded state with the provided reason and message. If err was annotated using WithCode, the code
+// is reported in the Degraded condition.
 func (m *statusManager) SetDegraded(reason operator.TigeraStatusReason, msg string, err error, log logr.Logger) {
-	log.WithValues("reason", string(reason)).Error(err, msg)
+	code, err := splitCode(err)
+	log.WithValues("reason", string(reason), "code", string(code)).Error(err, msg)
 	errormsg := ""
 	if err != nil {
 		errormsg = err.Error()
@@ -362,15 +402,48 @@ func (m *statusManager) SetDegraded(reason operator.TigeraStatusReason, msg stri
 	defer m.lock.Unlock()
 	m.degraded = true
 	m.explicitDegradedReason = reason
+	m.explicitDegradedCode = code
 	m.explicitDegradedMsg = fmt.Sprintf("%s: %s", msg, errormsg)
 }
 
+// codedError associates a TigeraStatusErrorCode with the error passed to SetDegraded.
+type codedError struct {
+	code operator.TigeraStatusErrorCode
+	err  error
+}
+
+func (e *codedError) Error() string {
+	if e.err == nil {
+		return string(e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// WithCode annotates err with a machine-readable code that SetDegraded publishes in the Degraded condition of the
+// TigeraStatus. err may be nil when the component is degraded without an error, e.g. while waiting on a dependency.
+func WithCode(code operator.TigeraStatusErrorCode, err error) error {
+	return &codedError{code: code, err: err}
+}
+
+// splitCode returns the code attached to err by WithCode, if any, together with the underlying error.
+func splitCode(err error) (operator.TigeraStatusErrorCode, error) {
+	if ce, ok := err.(*codedError); ok {
+		return ce.code, ce.err
+	}
+	return "", err
+}
+
 // ClearDegraded clears degraded state.
 func (m *statusManager) ClearDegraded() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	m.degraded = false
 	m.explicitDegradedReason = ""
+	m.explicitDegradedCode = ""
 	m.explicitDegradedMsg = ""
 }
 
@@ -435,6 +508,7 @@ func (m *statusManager) syncState() {
 	defer m.lock.Unlock()
 	progressing := []string{}
 	failing := []string{}
+	workloads := []operator.TigeraStatusWorkload{}
 
 	// For each daemonset, check its rollout status.
 	for _, dsnn := range m.daemonsets {
@@ -444,6 +518,7 @@ func (m *statusManager) syncState() {
 			log.WithValues("reason", err).Info("Failed to query daemonset")
 			continue
 		}
+		workloads = append(workloads, workload("DaemonSet", ds, ds.Status.DesiredNumberScheduled, ds.Status.NumberReady))
 		if ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled {
 			progressing = append(progressing, fmt.Sprintf("DaemonSet %q update is rolling out (%d out of %d updated)", dsnn.String(), ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled))
 		} else if ds.Status.NumberUnavailable > 0 {
@@ -494,6 +569,7 @@ func (m *statusManager) syncState() {
 		if dep.Spec.Replicas != nil {
 			replicas = *dep.Spec.Replicas
 		}
+		workloads = append(workloads, workload("Deployment", dep, replicas, dep.Status.ReadyReplicas))
 		// There could be old pods in the Errored, Terminated, or Completed state
 		// but if the following are true then we don't need to worry about those
 		// failed pods so continue.
@@ -532,6 +608,7 @@ func (m *statusManager) syncState() {
 		if ss.Spec.Replicas != nil {
 			replicas = *ss.Spec.Replicas
 		}
+		workloads = append(workloads, workload("StatefulSet", ss, replicas, ss.Status.ReadyReplicas))
 		// There could be old pods in the Errored, Terminated, or Completed state
 		// but if the following are true then we don't need to worry about those
 		// failed pods so continue.
@@ -587,11 +664,61 @@ func (m *statusManager) syncState() {
 		}
 	}
 
+	sort.Slice(workloads, func(i, j int) bool { return workloadKey(workloads[i]) < workloadKey(workloads[j]) })
+
 	m.progressing = progressing
 	m.failing = failing
+	m.workloads = workloads
 	m.hasSynced = true
 }
 
+// workload returns the readiness of a workload as reported in the TigeraStatus.
+func workload(kind string, obj metav1.Object, desired, ready int32) operator.TigeraStatusWorkload {
+	w := operator.TigeraStatusWorkload{
+		Kind:            kind,
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		DesiredReplicas: desired,
+		ReadyReplicas:   ready,
+		Generation:      obj.GetGeneration(),
+	}
+	if created := obj.GetCreationTimestamp(); obj.GetGeneration() == 1 && !created.IsZero() {
+		// The spec of the workload hasn't changed since it was created, which is when it was last rolled out.
+		w.LastRolloutTime = &created
+	}
+	return w
+}
+
+func workloadKey(w operator.TigeraStatusWorkload) string {
+	return w.Kind + "/" + w.Namespace + "/" + w.Name
+}
+
+// withRolloutTimes returns the given workloads with the rollout times of the workloads previously reported in the
+// TigeraStatus. Workloads whose generation has changed since are reported as rolled out now.
+func withRolloutTimes(previous, current []operator.TigeraStatusWorkload) []operator.TigeraStatusWorkload {
+	if len(current) == 0 {
+		return nil
+	}
+	reported := map[string]operator.TigeraStatusWorkload{}
+	for _, w := range previous {
+		reported[workloadKey(w)] = w
+	}
+	workloads := make([]operator.TigeraStatusWorkload, len(current))
+	for i := range current {
+		w := *current[i].DeepCopy()
+		if r, ok := reported[workloadKey(w)]; ok {
+			if r.Generation == w.Generation {
+				w.LastRolloutTime = r.LastRolloutTime
+			} else {
+				now := metav1.NewTime(time.Now())
+				w.LastRolloutTime = &now
+			}
+		}
+		workloads[i] = w
+	}
+	return workloads
+}
+
 // isInitialized returns true if corresponding CR has been queried
 func (m *statusManager) isInitialized() bool {
 	m.lock.Lock()
@@ -714,8 +841,13 @@ func (m *statusManager) set(retry bool, conditions ...operator.TigeraStatusCondi
 		}
 	}
 
+	// The workloads are only known once the state of the cluster has been synced.
+	if m.hasSynced {
+		ts.Status.Workloads = withRolloutTimes(old.Status.Workloads, m.workloads)
+	}
+
 	// If nothing has changed, we don't need to update in the API.
-	if reflect.DeepEqual(ts.Status.Conditions, old.Status.Conditions) {
+	if reflect.DeepEqual(ts.Status, old.Status) {
 		return
 	}
 
@@ -748,12 +880,12 @@ func (m *statusManager) setAvailable(reason operator.TigeraStatusReason, msg str
 	m.set(true, conditions...)
 }
 
-func (m *statusManager) setDegraded(reason operator.TigeraStatusReason, msg string) {
+func (m *statusManager) setDegraded(reason operator.TigeraStatusReason, code operator.TigeraStatusErrorCode, msg string) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
 	conditions := []operator.TigeraStatusCondition{
-		{Type: operator.ComponentDegraded, Status: operator.ConditionTrue, Reason: string(reason), Message: msg},
+		{Type: operator.ComponentDegraded, Status: operator.ConditionTrue, Reason: string(reason), Message: msg, Code: string(code)},
 	}
 	m.set(true, conditions...)
 }
@@ -768,6 +900,17 @@ func (m *statusManager) setProgressing(reason operator.TigeraStatusReason, msg s
 	m.set(true, conditions...)
 }
 
+// setWaitingForDependencies reports the component as progressing while it waits for the given components to become
+// available.
+func (m *statusManager) setWaitingForDependencies(components []string) {
+	m.setProgressing(operator.DependencyNotReady, fmt.Sprintf("Waiting for %s to be available", strings.Join(components, ", ")))
+}
+
+// setPaused reports the component as progressing, with a reason that tells that its reconciliation is paused.
+func (m *statusManager) setPaused() {
+	m.setProgressing(operator.Paused, fmt.Sprintf("Reconciliation is paused by the %s annotation", common.ReconcileAnnotation))
+}
+
 func (m *statusManager) clearDegraded() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -834,6 +977,17 @@ func (m *statusManager) degradedReason() operator.TigeraStatusReason {
 	return operator.Unknown
 }
 
+// degradedCode returns the code given with the explicit degraded state, if any. Degraded states that are not explicitly
+// set, such as failing pods, have no code.
+func (m *statusManager) degradedCode() operator.TigeraStatusErrorCode {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.degraded {
+		return m.explicitDegradedCode
+	}
+	return ""
+}
+
 func (m *statusManager) clearDegradedWithReason(reason operator.TigeraStatusReason, msg string) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -858,6 +1012,25 @@ func (m *statusManager) SetMetaData(meta *metav1.ObjectMeta) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	m.observedGeneration = meta.Generation
+	m.paused = common.IsReconcilePaused(meta)
+}
+
+// WaitForDependencies returns true if all the given components, identified by the names of their TigeraStatus, are
+// available. Otherwise, the component is reported as progressing rather than degraded until they are, since it is
+// expected to wait for them while the cluster is being installed. Controllers call it before reconciling the objects
+// that rely on the components, and watch the components with utils.AddDependencyWatch to reconcile once they become
+// available.
+func (m *statusManager) WaitForDependencies(components ...string) bool {
+	notReady := m.readiness.NotReady(components...)
+	m.lock.Lock()
+	m.waitingFor = notReady
+	m.lock.Unlock()
+	if len(notReady) == 0 {
+		return true
+	}
+	// Any degraded state set while reconciling the component was likely caused by the dependencies not being ready.
+	m.ClearDegraded()
+	return false
 }
 
 func hasPendingCSR(ctx context.Context, m *statusManager, labelMap map[string]string) (bool, error) {
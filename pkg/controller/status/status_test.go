@@ -16,6 +16,8 @@ package status
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -459,6 +461,159 @@ var _ = Describe("Status reporting tests", func() {
 			Expect(sm.degradedMessage()).To(Equal("Controller set us degraded: \nThis pod has died"))
 		})
 
+		It("should report the code given to SetDegraded in the degraded condition", func() {
+			degradedCondition := func() operator.TigeraStatusCondition {
+				ts := &operator.TigeraStatus{}
+				Expect(client.Get(context.TODO(), types.NamespacedName{Name: "test-component"}, ts)).NotTo(HaveOccurred())
+				for _, c := range ts.Status.Conditions {
+					if c.Type == operator.ComponentDegraded {
+						return c
+					}
+				}
+				Fail("no degraded condition")
+				return operator.TigeraStatusCondition{}
+			}
+
+			sm.SetDegraded(operator.ResourceNotReady, "Waiting for Tigera API server to be ready", WithCode(operator.APIServerNotReady, nil), log)
+			sm.updateStatus()
+			c := degradedCondition()
+			Expect(c.Status).To(Equal(operator.ConditionTrue))
+			Expect(c.Reason).To(Equal(string(operator.ResourceNotReady)))
+			Expect(c.Code).To(Equal(string(operator.APIServerNotReady)))
+			Expect(c.Message).To(Equal("Waiting for Tigera API server to be ready: "))
+
+			sm.SetDegraded(operator.ResourceReadError, "Error retrieving pull secrets", WithCode(operator.PullSecretsNotAvailable, fmt.Errorf("secret not found")), log)
+			sm.updateStatus()
+			c = degradedCondition()
+			Expect(c.Code).To(Equal(string(operator.PullSecretsNotAvailable)))
+			Expect(c.Message).To(Equal("Error retrieving pull secrets: secret not found"))
+
+			sm.SetDegraded(operator.ResourceReadError, "Error querying installation", fmt.Errorf("timeout"), log)
+			sm.updateStatus()
+			Expect(degradedCondition().Code).To(BeEmpty())
+
+			sm.ClearDegraded()
+			sm.updateStatus()
+			c = degradedCondition()
+			Expect(c.Status).To(Equal(operator.ConditionFalse))
+			Expect(c.Code).To(BeEmpty())
+		})
+
+		It("should report the component as progressing rather than degraded while it waits for its dependencies", func() {
+			progressingCondition := func() operator.TigeraStatusCondition {
+				ts := &operator.TigeraStatus{}
+				Expect(client.Get(context.TODO(), types.NamespacedName{Name: "test-component"}, ts)).NotTo(HaveOccurred())
+				for _, c := range ts.Status.Conditions {
+					if c.Type == operator.ComponentProgressing {
+						return c
+					}
+				}
+				Fail("no progressing condition")
+				return operator.TigeraStatusCondition{}
+			}
+			sm.readiness = NewReadinessRegistry()
+
+			sm.SetDegraded(operator.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", nil, log)
+			Expect(sm.WaitForDependencies("log-storage-elastic")).To(BeFalse())
+			Expect(sm.IsDegraded()).To(BeFalse())
+			sm.updateStatus()
+			c := progressingCondition()
+			Expect(c.Status).To(Equal(operator.ConditionTrue))
+			Expect(c.Reason).To(Equal(string(operator.DependencyNotReady)))
+			Expect(c.Message).To(Equal("Waiting for log-storage-elastic to be available"))
+			Expect(sm.readiness.IsReady("test-component")).To(BeFalse())
+
+			By("reporting the component as ready once it no longer waits")
+			sm.readiness.SetReady("log-storage-elastic", true)
+			Expect(sm.WaitForDependencies("log-storage-elastic")).To(BeTrue())
+			sm.ReadyToMonitor()
+			sm.updateStatus()
+			Expect(progressingCondition().Status).To(Equal(operator.ConditionFalse))
+			Expect(sm.readiness.IsReady("test-component")).To(BeTrue())
+		})
+
+		It("should report the component as paused while its reconciliation is paused", func() {
+			progressingCondition := func() operator.TigeraStatusCondition {
+				ts := &operator.TigeraStatus{}
+				Expect(client.Get(context.TODO(), types.NamespacedName{Name: "test-component"}, ts)).NotTo(HaveOccurred())
+				for _, c := range ts.Status.Conditions {
+					if c.Type == operator.ComponentProgressing {
+						return c
+					}
+				}
+				Fail("no progressing condition")
+				return operator.TigeraStatusCondition{}
+			}
+
+			sm.SetMetaData(&metav1.ObjectMeta{Annotations: map[string]string{common.ReconcileAnnotation: common.ReconcilePaused}})
+			sm.updateStatus()
+			c := progressingCondition()
+			Expect(c.Status).To(Equal(operator.ConditionTrue))
+			Expect(c.Reason).To(Equal(string(operator.Paused)))
+			Expect(c.Message).To(Equal("Reconciliation is paused by the operator.tigera.io/reconcile annotation"))
+
+			sm.ReadyToMonitor()
+			sm.updateStatus()
+			Expect(progressingCondition().Reason).To(Equal(string(operator.Paused)))
+			Expect(sm.IsAvailable()).To(BeTrue())
+
+			By("reporting the progress of the component again once it is resumed")
+			sm.SetMetaData(&metav1.ObjectMeta{})
+			sm.updateStatus()
+			c = progressingCondition()
+			Expect(c.Status).To(Equal(operator.ConditionFalse))
+			Expect(c.Reason).To(Equal(string(operator.AllObjectsAvailable)))
+		})
+
+		It("should report the readiness of each workload and when it was last rolled out", func() {
+			workloads := func() []operator.TigeraStatusWorkload {
+				ts := &operator.TigeraStatus{}
+				Expect(client.Get(context.TODO(), types.NamespacedName{Name: "test-component"}, ts)).NotTo(HaveOccurred())
+				return ts.Status.Workloads
+			}
+
+			created := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+			replicas := int32(2)
+			Expect(client.Create(ctx, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "NS1", Name: "DP1", Generation: 1, CreationTimestamp: created},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Selector: &metav1.LabelSelector{MatchLabels: labels}},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, AvailableReplicas: 1, ReadyReplicas: 1, UnavailableReplicas: 1},
+			})).NotTo(HaveOccurred())
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "NS1", Name: "DS1", Generation: 3},
+				Spec:       appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+				Status:     appsv1.DaemonSetStatus{ObservedGeneration: 3, DesiredNumberScheduled: 3, NumberReady: 3, NumberAvailable: 3, UpdatedNumberScheduled: 3},
+			}
+			Expect(client.Create(ctx, ds)).NotTo(HaveOccurred())
+			sm.AddDeployments([]types.NamespacedName{{Namespace: "NS1", Name: "DP1"}})
+			sm.AddDaemonsets([]types.NamespacedName{{Namespace: "NS1", Name: "DS1"}})
+			sm.ReadyToMonitor()
+			sm.updateStatus()
+
+			// The DaemonSet has changed since it was created, but not while its rollouts were reported.
+			Expect(workloads()).To(Equal([]operator.TigeraStatusWorkload{
+				{Kind: "DaemonSet", Namespace: "NS1", Name: "DS1", DesiredReplicas: 3, ReadyReplicas: 3, Generation: 3},
+				{Kind: "Deployment", Namespace: "NS1", Name: "DP1", DesiredReplicas: 2, ReadyReplicas: 1, Generation: 1, LastRolloutTime: &created},
+			}))
+
+			By("reporting the rollout of a change to the DaemonSet")
+			Expect(client.Get(ctx, types.NamespacedName{Namespace: "NS1", Name: "DS1"}, ds)).NotTo(HaveOccurred())
+			ds.Generation = 4
+			Expect(client.Update(ctx, ds)).NotTo(HaveOccurred())
+			sm.updateStatus()
+			w := workloads()
+			Expect(w).To(HaveLen(2))
+			Expect(w[0].Generation).To(Equal(int64(4)))
+			Expect(w[0].LastRolloutTime).NotTo(BeNil())
+			Expect(w[0].LastRolloutTime.Time).To(BeTemporally("~", time.Now(), 5*time.Second))
+			Expect(w[1].LastRolloutTime).To(Equal(&created))
+
+			By("keeping the rollout time while the DaemonSet is unchanged")
+			rolledOut := w[0].LastRolloutTime
+			sm.updateStatus()
+			Expect(workloads()[0].LastRolloutTime).To(Equal(rolledOut))
+		})
+
 		It("should contain all the NamespacesNames for all the resources added by multiple calls to Set<Resources>", func() {
 			sm.AddStatefulSets([]types.NamespacedName{{Namespace: "NS1", Name: "SS1"}})
 			sm.AddStatefulSets([]types.NamespacedName{{Namespace: "NS1", Name: "SS2"}})
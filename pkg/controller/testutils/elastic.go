@@ -0,0 +1,720 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testutils contains helpers for testing controllers without the external systems that they talk to.
+package testutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/olivere/elastic/v7"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+// The names of the ElasticClient methods, for use with FakeElasticClient.InjectError.
+const (
+	MethodSetILMPolicies     = "SetILMPolicies"
+	MethodCreateUser         = "CreateUser"
+	MethodDeleteUser         = "DeleteUser"
+	MethodGetUsers           = "GetUsers"
+	MethodCreateRoles        = "CreateRoles"
+	MethodDeleteRoles        = "DeleteRoles"
+	MethodPutRoleMapping     = "PutRoleMapping"
+	MethodDeleteRoleMapping  = "DeleteRoleMapping"
+	MethodGetRoleMappings    = "GetRoleMappings"
+	MethodIndexExists        = "IndexExists"
+	MethodStartReindex       = "StartReindex"
+	MethodGetReindexProgress = "GetReindexProgress"
+	MethodMoveAlias          = "MoveAlias"
+	MethodGetIndexAliases    = "GetIndexAliases"
+	MethodPutAlias           = "PutAlias"
+	MethodGetIndexSettings   = "GetIndexSettings"
+	MethodPutIndexSettings   = "PutIndexSettings"
+	MethodClusterHealth      = "ClusterHealth"
+	MethodExplainILM         = "ExplainILM"
+
+	MethodConfigureCrossClusterReplication = "ConfigureCrossClusterReplication"
+	MethodIndexUsage                       = "IndexUsage"
+	MethodDiskUsagePercent                 = "DiskUsagePercent"
+	MethodNodeStats                        = "NodeStats"
+)
+
+var _ utils.ElasticClient = &FakeElasticClient{}
+
+// FakeElasticClient is an in-memory implementation of utils.ElasticClient. It keeps the users, roles, role mappings, ILM policies,
+// indices and aliases that are written through it, so that controllers which talk to Elasticsearch can be tested
+// without an Elasticsearch cluster. Errors can be injected per method to test the error handling of the callers.
+//
+// Missing users, roles, role mappings, indices, aliases and reindex tasks are reported with the same not found error as Elasticsearch,
+// so elastic.IsNotFound can be used on them. Reindex tasks complete as soon as they are started.
+type FakeElasticClient struct {
+	lock sync.Mutex
+
+	users        map[string]utils.User
+	roles        map[string]utils.Role
+	roleMappings map[string]utils.RoleMapping
+	policies     map[string]map[string]interface{}
+	indices      map[string]bool
+	aliases      map[string]map[string]bool
+	tasks        map[string]*utils.ReindexProgress
+	errors       map[string]error
+
+	// remoteClusters holds the proxy address of each remote cluster, and autoFollow the leader index patterns that are
+	// followed from each remote cluster.
+	remoteClusters map[string]string
+	autoFollow     map[string][]string
+
+	// usage holds the usage of the indices that were given one with SetIndexUsage, and settings the settings of the
+	// indices, keyed by their flat name.
+	usage    map[string]utils.IndexUsage
+	settings map[string]map[string]string
+
+	clusterHealth    json.RawMessage
+	explainILM       json.RawMessage
+	diskUsagePercent int
+	nodeStats        utils.NodeStats
+}
+
+// NewFakeElasticClient returns an empty FakeElasticClient whose cluster health is green.
+func NewFakeElasticClient() *FakeElasticClient {
+	return &FakeElasticClient{
+		users:          map[string]utils.User{},
+		roles:          map[string]utils.Role{},
+		roleMappings:   map[string]utils.RoleMapping{},
+		policies:       map[string]map[string]interface{}{},
+		indices:        map[string]bool{},
+		aliases:        map[string]map[string]bool{},
+		tasks:          map[string]*utils.ReindexProgress{},
+		errors:         map[string]error{},
+		remoteClusters: map[string]string{},
+		autoFollow:     map[string][]string{},
+		usage:          map[string]utils.IndexUsage{},
+		settings:       map[string]map[string]string{},
+		nodeStats:      utils.NodeStats{RejectedExecutions: map[string]int64{}},
+		clusterHealth:  json.RawMessage(`{"status":"green"}`),
+		explainILM:     json.RawMessage(`{"indices":{}}`),
+	}
+}
+
+// Creator returns an ElasticsearchClientCreator that always returns this client, for controllers that create their
+// Elasticsearch client on each reconcile.
+func (f *FakeElasticClient) Creator() utils.ElasticsearchClientCreator {
+	return func(client.Client, context.Context, string, bool) (utils.ElasticClient, error) {
+		return f, nil
+	}
+}
+
+// InjectError makes every call of the given method return err until the error is cleared. A nil err clears it.
+func (f *FakeElasticClient) InjectError(method string, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err == nil {
+		delete(f.errors, method)
+		return
+	}
+	f.errors[method] = err
+}
+
+// ClearErrors clears all the injected errors.
+func (f *FakeElasticClient) ClearErrors() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.errors = map[string]error{}
+}
+
+// AddIndex adds an index, and optionally adds an alias to it. The index is not made the write index of the alias.
+func (f *FakeElasticClient) AddIndex(index, alias string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.indices[index] = true
+	if alias != "" {
+		f.addAlias(alias, index, false)
+	}
+}
+
+// AddWriteIndex adds an index and makes it the write index of the alias, like a rollover does.
+func (f *FakeElasticClient) AddWriteIndex(index, alias string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.indices[index] = true
+	for i := range f.aliases[alias] {
+		f.aliases[alias][i] = false
+	}
+	f.addAlias(alias, index, true)
+}
+
+func (f *FakeElasticClient) addAlias(alias, index string, isWriteIndex bool) {
+	if f.aliases[alias] == nil {
+		f.aliases[alias] = map[string]bool{}
+	}
+	f.aliases[alias][index] = isWriteIndex
+}
+
+// SetIndexUsage adds an index if it doesn't exist yet, and sets the number of documents in it and its size.
+func (f *FakeElasticClient) SetIndexUsage(index string, documents, sizeBytes int64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.indices[index] = true
+	f.usage[index] = utils.IndexUsage{Index: index, Documents: documents, SizeBytes: sizeBytes}
+}
+
+// IndexSettings returns the settings of the index, keyed by their flat name.
+func (f *FakeElasticClient) IndexSettings(index string) map[string]string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	settings := map[string]string{}
+	for name, value := range f.settings[index] {
+		settings[name] = value
+	}
+	return settings
+}
+
+// Alias returns the index that the alias writes to, which is its write index or else its only index. It returns an
+// empty string if the alias doesn't exist or has no index to write to.
+func (f *FakeElasticClient) Alias(alias string) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for index, isWriteIndex := range f.aliases[alias] {
+		if isWriteIndex || len(f.aliases[alias]) == 1 {
+			return index
+		}
+	}
+	return ""
+}
+
+// AliasIndices returns the indices that the alias points to, and whether each of them is its write index.
+func (f *FakeElasticClient) AliasIndices(alias string) map[string]bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	indices := map[string]bool{}
+	for index, isWriteIndex := range f.aliases[alias] {
+		indices[index] = isWriteIndex
+	}
+	return indices
+}
+
+// Role returns the role with the given name, including its definition.
+func (f *FakeElasticClient) Role(name string) (utils.Role, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	role, ok := f.roles[name]
+	return role, ok
+}
+
+// User returns the user with the given username, including its password.
+func (f *FakeElasticClient) User(username string) (utils.User, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	user, ok := f.users[username]
+	return user, ok
+}
+
+// RoleMapping returns the role mapping with the given name.
+func (f *FakeElasticClient) RoleMapping(name string) (utils.RoleMapping, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	mapping, ok := f.roleMappings[name]
+	return mapping, ok
+}
+
+// ILMPolicies returns the ILM policies that have been set, keyed by policy name.
+func (f *FakeElasticClient) ILMPolicies() map[string]map[string]interface{} {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	policies := make(map[string]map[string]interface{}, len(f.policies))
+	for name, policy := range f.policies {
+		policies[name] = policy
+	}
+	return policies
+}
+
+// RemoteCluster returns the proxy address of the remote cluster registered by ConfigureCrossClusterReplication, and the
+// leader index patterns that are followed from it.
+func (f *FakeElasticClient) RemoteCluster(name string) (string, []string, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	address, ok := f.remoteClusters[name]
+	return address, f.autoFollow[name], ok
+}
+
+// SetClusterHealth sets the response of ClusterHealth.
+func (f *FakeElasticClient) SetClusterHealth(health json.RawMessage) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.clusterHealth = health
+}
+
+// SetExplainILM sets the response of ExplainILM.
+func (f *FakeElasticClient) SetExplainILM(explain json.RawMessage) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.explainILM = explain
+}
+
+// SetDiskUsagePercent sets the disk usage of the fullest node, as a percentage.
+func (f *FakeElasticClient) SetDiskUsagePercent(percent int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.diskUsagePercent = percent
+}
+
+// SetNodeStats sets the statistics of the nodes, summed over all nodes.
+func (f *FakeElasticClient) SetNodeStats(stats utils.NodeStats) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.nodeStats = stats
+}
+
+func (f *FakeElasticClient) SetILMPolicies(_ context.Context, ls *operatorv1.LogStorage) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodSetILMPolicies]; err != nil {
+		return err
+	}
+	for name, policy := range utils.ILMPolicies(ls) {
+		f.policies[name] = policy
+	}
+	return nil
+}
+
+func (f *FakeElasticClient) CreateUser(_ context.Context, user *utils.User) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodCreateUser]; err != nil {
+		return err
+	}
+
+	// Like the real client, only the roles with a definition are created.
+	for _, role := range user.Roles {
+		if role.Definition == nil {
+			continue
+		}
+		if role.Name == "" {
+			return fmt.Errorf("can't create a role with an empty name")
+		}
+		f.roles[role.Name] = role
+	}
+	f.users[user.Username] = *user
+	return nil
+}
+
+// CreateUsers creates the users as CreateUser does. Errors injected for CreateUser also apply to it.
+func (f *FakeElasticClient) CreateUsers(ctx context.Context, users ...*utils.User) error {
+	for _, user := range users {
+		if err := f.CreateUser(ctx, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FakeElasticClient) DeleteUser(_ context.Context, user *utils.User) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodDeleteUser]; err != nil {
+		return err
+	}
+
+	for _, role := range user.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("can't delete a role with an empty name")
+		}
+		if _, ok := f.roles[role.Name]; !ok {
+			return notFound("role", role.Name)
+		}
+		delete(f.roles, role.Name)
+	}
+	if _, ok := f.users[user.Username]; !ok {
+		return notFound("user", user.Username)
+	}
+	delete(f.users, user.Username)
+	return nil
+}
+
+// GetUsers returns the users sorted by username. As with Elasticsearch, the passwords and role definitions of the
+// users are not returned.
+func (f *FakeElasticClient) GetUsers(_ context.Context) ([]utils.User, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodGetUsers]; err != nil {
+		return []utils.User{}, err
+	}
+
+	users := []utils.User{}
+	for name, u := range f.users {
+		user := utils.User{Username: name}
+		for _, role := range u.Roles {
+			user.Roles = append(user.Roles, utils.Role{Name: role.Name})
+		}
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+	return users, nil
+}
+
+func (f *FakeElasticClient) CreateRoles(_ context.Context, roles ...utils.Role) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodCreateRoles]; err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		if role.Name == "" {
+			return fmt.Errorf("can't create a role with an empty name")
+		}
+		f.roles[role.Name] = role
+	}
+	return nil
+}
+
+func (f *FakeElasticClient) DeleteRoles(_ context.Context, roles []utils.Role) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodDeleteRoles]; err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		if role.Name == "" {
+			return fmt.Errorf("can't delete a role with an empty name")
+		}
+		if _, ok := f.roles[role.Name]; !ok {
+			return notFound("role", role.Name)
+		}
+		delete(f.roles, role.Name)
+	}
+	return nil
+}
+
+func (f *FakeElasticClient) PutRoleMapping(_ context.Context, mapping utils.RoleMapping) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodPutRoleMapping]; err != nil {
+		return err
+	}
+
+	if mapping.Name == "" {
+		return fmt.Errorf("can't create a role mapping with an empty name")
+	}
+	f.roleMappings[mapping.Name] = mapping
+	return nil
+}
+
+func (f *FakeElasticClient) DeleteRoleMapping(_ context.Context, name string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodDeleteRoleMapping]; err != nil {
+		return err
+	}
+
+	if _, ok := f.roleMappings[name]; !ok {
+		return notFound("role mapping", name)
+	}
+	delete(f.roleMappings, name)
+	return nil
+}
+
+// GetRoleMappings returns the role mappings sorted by name.
+func (f *FakeElasticClient) GetRoleMappings(_ context.Context) ([]utils.RoleMapping, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodGetRoleMappings]; err != nil {
+		return nil, err
+	}
+
+	mappings := []utils.RoleMapping{}
+	for _, mapping := range f.roleMappings {
+		mappings = append(mappings, mapping)
+	}
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].Name < mappings[j].Name })
+	return mappings, nil
+}
+
+func (f *FakeElasticClient) IndexExists(_ context.Context, index string) (bool, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodIndexExists]; err != nil {
+		return false, err
+	}
+	return f.indices[index], nil
+}
+
+func (f *FakeElasticClient) StartReindex(_ context.Context, source, destination string) (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodStartReindex]; err != nil {
+		return "", err
+	}
+	if !f.indices[source] {
+		return "", notFound("index", source)
+	}
+
+	f.indices[destination] = true
+	taskID := fmt.Sprintf("fake:%d", len(f.tasks)+1)
+	f.tasks[taskID] = &utils.ReindexProgress{Completed: true}
+	return taskID, nil
+}
+
+func (f *FakeElasticClient) GetReindexProgress(_ context.Context, taskID string) (*utils.ReindexProgress, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodGetReindexProgress]; err != nil {
+		return nil, err
+	}
+	progress, ok := f.tasks[taskID]
+	if !ok {
+		return nil, notFound("task", taskID)
+	}
+	p := *progress
+	return &p, nil
+}
+
+func (f *FakeElasticClient) MoveAlias(_ context.Context, alias, from, to string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodMoveAlias]; err != nil {
+		return err
+	}
+	isWriteIndex, ok := f.aliases[alias][from]
+	if !ok {
+		return notFound("alias", alias)
+	}
+	if !f.indices[to] {
+		return notFound("index", to)
+	}
+	delete(f.aliases[alias], from)
+	f.aliases[alias][to] = isWriteIndex
+	return nil
+}
+
+func (f *FakeElasticClient) GetIndexAliases(_ context.Context, pattern string) ([]utils.IndexAliases, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodGetIndexAliases]; err != nil {
+		return nil, err
+	}
+	var indices []utils.IndexAliases
+	for index := range f.indices {
+		if !matches(pattern, index) {
+			continue
+		}
+		aliases := utils.IndexAliases{Index: index, Aliases: map[string]bool{}}
+		for alias, members := range f.aliases {
+			if isWriteIndex, ok := members[index]; ok {
+				aliases.Aliases[alias] = isWriteIndex
+			}
+		}
+		indices = append(indices, aliases)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i].Index < indices[j].Index })
+	return indices, nil
+}
+
+// PutAlias fails like Elasticsearch does when an index with the name of the alias exists, or when the alias would end up
+// with more than one write index.
+func (f *FakeElasticClient) PutAlias(_ context.Context, alias string, indices []string, writeIndex string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodPutAlias]; err != nil {
+		return err
+	}
+	if f.indices[alias] {
+		return badRequest("invalid_alias_name_exception", fmt.Sprintf("an index or data stream exists with the same name as the alias [%s]", alias))
+	}
+	for _, index := range indices {
+		if !f.indices[index] {
+			return notFound("index", index)
+		}
+	}
+
+	updated := map[string]bool{}
+	for index, isWriteIndex := range f.aliases[alias] {
+		updated[index] = isWriteIndex
+	}
+	for _, index := range indices {
+		updated[index] = index == writeIndex
+	}
+	writeIndices := 0
+	for _, isWriteIndex := range updated {
+		if isWriteIndex {
+			writeIndices++
+		}
+	}
+	if writeIndices > 1 {
+		return badRequest("illegal_state_exception", fmt.Sprintf("alias [%s] has more than one write index", alias))
+	}
+	f.aliases[alias] = updated
+	return nil
+}
+
+func (f *FakeElasticClient) GetIndexSettings(_ context.Context, pattern string, names ...string) ([]utils.IndexSettings, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodGetIndexSettings]; err != nil {
+		return nil, err
+	}
+	var indices []utils.IndexSettings
+	for index := range f.indices {
+		if !matches(pattern, index) {
+			continue
+		}
+		settings := utils.IndexSettings{Index: index, Settings: map[string]string{}}
+		for _, name := range names {
+			if value, ok := f.settings[index][name]; ok {
+				settings.Settings[name] = value
+			}
+		}
+		indices = append(indices, settings)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i].Index < indices[j].Index })
+	return indices, nil
+}
+
+func (f *FakeElasticClient) PutIndexSettings(_ context.Context, index string, settings map[string]string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodPutIndexSettings]; err != nil {
+		return err
+	}
+	// Like the real client, a system index is never bound to an ILM policy.
+	if _, ok := settings["index.lifecycle.name"]; ok && strings.HasPrefix(index, ".") {
+		return fmt.Errorf("refusing to set index.lifecycle.name on system index %s", index)
+	}
+	if !f.indices[index] {
+		return notFound("index", index)
+	}
+	if f.settings[index] == nil {
+		f.settings[index] = map[string]string{}
+	}
+	for name, value := range settings {
+		f.settings[index][name] = value
+	}
+	return nil
+}
+
+func (f *FakeElasticClient) ClusterHealth(_ context.Context) (json.RawMessage, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodClusterHealth]; err != nil {
+		return nil, err
+	}
+	return f.clusterHealth, nil
+}
+
+func (f *FakeElasticClient) ExplainILM(_ context.Context) (json.RawMessage, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodExplainILM]; err != nil {
+		return nil, err
+	}
+	return f.explainILM, nil
+}
+
+func (f *FakeElasticClient) ConfigureCrossClusterReplication(_ context.Context, remoteCluster, proxyAddress string, leaderIndexPatterns []string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodConfigureCrossClusterReplication]; err != nil {
+		return err
+	}
+	f.remoteClusters[remoteCluster] = proxyAddress
+	f.autoFollow[remoteCluster] = append([]string(nil), leaderIndexPatterns...)
+	return nil
+}
+
+func (f *FakeElasticClient) IndexUsage(_ context.Context, pattern string) ([]utils.IndexUsage, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodIndexUsage]; err != nil {
+		return nil, err
+	}
+	var usage []utils.IndexUsage
+	for index := range f.indices {
+		if matches(pattern, index) {
+			u := f.usage[index]
+			u.Index = index
+			usage = append(usage, u)
+		}
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Index < usage[j].Index })
+	return usage, nil
+}
+
+func (f *FakeElasticClient) DiskUsagePercent(_ context.Context) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodDiskUsagePercent]; err != nil {
+		return 0, err
+	}
+	return f.diskUsagePercent, nil
+}
+
+func (f *FakeElasticClient) NodeStats(_ context.Context) (*utils.NodeStats, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.errors[MethodNodeStats]; err != nil {
+		return nil, err
+	}
+	stats := utils.NodeStats{
+		RejectedExecutions: map[string]int64{},
+		QueryTotal:         f.nodeStats.QueryTotal,
+		QueryTimeMillis:    f.nodeStats.QueryTimeMillis,
+	}
+	for pool, rejected := range f.nodeStats.RejectedExecutions {
+		stats.RejectedExecutions[pool] = rejected
+	}
+	return &stats, nil
+}
+
+// matches returns whether the index matches the pattern, which may hold wildcards and be a comma separated list.
+func matches(pattern, index string) bool {
+	for _, p := range strings.Split(pattern, ",") {
+		if ok, _ := path.Match(p, index); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// notFound returns the error that the Elasticsearch client returns for a missing resource.
+func notFound(kind, name string) error {
+	return &elastic.Error{
+		Status: http.StatusNotFound,
+		Details: &elastic.ErrorDetails{
+			Type:   "resource_not_found_exception",
+			Reason: fmt.Sprintf("%s [%s] not found", kind, name),
+		},
+	}
+}
+
+// badRequest returns the error that the Elasticsearch client returns for a request that Elasticsearch rejects.
+func badRequest(errorType, reason string) error {
+	return &elastic.Error{
+		Status: http.StatusBadRequest,
+		Details: &elastic.ErrorDetails{
+			Type:   errorType,
+			Reason: reason,
+		},
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutils
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/olivere/elastic/v7"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+var _ = Describe("FakeElasticClient", func() {
+	var (
+		f   *FakeElasticClient
+		ctx context.Context
+	)
+
+	BeforeEach(func() {
+		f = NewFakeElasticClient()
+		ctx = context.Background()
+	})
+
+	It("should be returned by its creator", func() {
+		esClient, err := f.Creator()(nil, ctx, "https://tigera-secure-es-http.tigera-elasticsearch.svc:9200", false)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(esClient).To(BeIdenticalTo(f))
+	})
+
+	It("should create, list and delete users and their roles", func() {
+		user := utils.LinseedUser("cluster", "")
+		Expect(f.CreateUser(ctx, user)).ShouldNot(HaveOccurred())
+
+		stored, ok := f.User(user.Username)
+		Expect(ok).To(BeTrue())
+		Expect(stored.Password).To(Equal(user.Password))
+		for _, role := range user.Roles {
+			r, ok := f.Role(role.Name)
+			Expect(ok).To(BeTrue())
+			Expect(r.Definition).To(Equal(role.Definition))
+		}
+
+		users, err := f.GetUsers(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(users).To(HaveLen(1))
+		Expect(users[0].Username).To(Equal(user.Username))
+		Expect(users[0].Password).To(BeEmpty())
+		Expect(users[0].RoleNames()).To(Equal(user.RoleNames()))
+
+		Expect(f.DeleteUser(ctx, user)).ShouldNot(HaveOccurred())
+		users, err = f.GetUsers(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(users).To(BeEmpty())
+		_, ok = f.Role(user.Roles[0].Name)
+		Expect(ok).To(BeFalse())
+
+		err = f.DeleteUser(ctx, &utils.User{Username: user.Username})
+		Expect(elastic.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should create, list and delete roles and role mappings", func() {
+		role := utils.Role{Name: "role", Definition: &utils.RoleDefinition{Indices: []utils.RoleIndex{{Names: []string{"tigera_secure_ee_flows.*"}, Privileges: []string{"read"}}}}}
+		Expect(f.CreateRoles(ctx, role)).ShouldNot(HaveOccurred())
+		stored, ok := f.Role("role")
+		Expect(ok).To(BeTrue())
+		Expect(stored).To(Equal(role))
+
+		for _, name := range []string{"mapping-b", "mapping-a"} {
+			Expect(f.PutRoleMapping(ctx, utils.RoleMapping{Name: name, Enabled: true, Roles: []string{"role"}})).ShouldNot(HaveOccurred())
+		}
+		mappings, err := f.GetRoleMappings(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(mappings).To(HaveLen(2))
+		Expect(mappings[0].Name).To(Equal("mapping-a"))
+		Expect(mappings[1].Name).To(Equal("mapping-b"))
+
+		Expect(f.DeleteRoleMapping(ctx, "mapping-a")).ShouldNot(HaveOccurred())
+		_, ok = f.RoleMapping("mapping-a")
+		Expect(ok).To(BeFalse())
+		Expect(elastic.IsNotFound(f.DeleteRoleMapping(ctx, "mapping-a"))).To(BeTrue())
+
+		Expect(f.DeleteRoles(ctx, []utils.Role{{Name: "role"}})).ShouldNot(HaveOccurred())
+		_, ok = f.Role("role")
+		Expect(ok).To(BeFalse())
+		Expect(elastic.IsNotFound(f.DeleteRoles(ctx, []utils.Role{{Name: "role"}}))).To(BeTrue())
+	})
+
+	It("should persist the ILM policies of the LogStorage", func() {
+		var retention int32 = 8
+		ls := &operatorv1.LogStorage{
+			Spec: operatorv1.LogStorageSpec{
+				Nodes: &operatorv1.Nodes{Count: 1},
+				Retention: &operatorv1.Retention{
+					Flows:             &retention,
+					AuditReports:      &retention,
+					Snapshots:         &retention,
+					ComplianceReports: &retention,
+					DNSLogs:           &retention,
+					BGPLogs:           &retention,
+				},
+			},
+		}
+		Expect(f.SetILMPolicies(ctx, ls)).ShouldNot(HaveOccurred())
+
+		policies := f.ILMPolicies()
+		Expect(policies).To(Equal(utils.ILMPolicies(ls)))
+		Expect(policies).To(HaveKey("tigera_secure_ee_flows_policy"))
+	})
+
+	It("should reindex and move aliases", func() {
+		f.AddIndex("source", "alias")
+
+		exists, err := f.IndexExists(ctx, "destination")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		taskID, err := f.StartReindex(ctx, "source", "destination")
+		Expect(err).ShouldNot(HaveOccurred())
+		progress, err := f.GetReindexProgress(ctx, taskID)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(progress.Completed).To(BeTrue())
+
+		exists, err = f.IndexExists(ctx, "destination")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+
+		Expect(f.MoveAlias(ctx, "alias", "source", "destination")).ShouldNot(HaveOccurred())
+		Expect(f.Alias("alias")).To(Equal("destination"))
+		Expect(elastic.IsNotFound(f.MoveAlias(ctx, "alias", "source", "destination"))).To(BeTrue())
+
+		_, err = f.StartReindex(ctx, "missing", "destination")
+		Expect(elastic.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should report and update the aliases of the indices", func() {
+		f.AddIndex("flows.000001", "flows.")
+		f.AddWriteIndex("flows.000002", "flows.")
+		f.AddIndex("flows.000003", "")
+		f.AddIndex("dns.000001", "dns.")
+
+		aliases, err := f.GetIndexAliases(ctx, "flows.*")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(aliases).To(Equal([]utils.IndexAliases{
+			{Index: "flows.000001", Aliases: map[string]bool{"flows.": false}},
+			{Index: "flows.000002", Aliases: map[string]bool{"flows.": true}},
+			{Index: "flows.000003", Aliases: map[string]bool{}},
+		}))
+		Expect(f.Alias("flows.")).To(Equal("flows.000002"))
+		Expect(f.Alias("dns.")).To(Equal("dns.000001"))
+
+		Expect(f.PutAlias(ctx, "flows.", []string{"flows.000002", "flows.000003"}, "flows.000003")).ShouldNot(HaveOccurred())
+		Expect(f.AliasIndices("flows.")).To(Equal(map[string]bool{"flows.000001": false, "flows.000002": false, "flows.000003": true}))
+
+		By("rejecting a second write index")
+		f.AddWriteIndex("flows.000004", "other")
+		Expect(f.PutAlias(ctx, "flows.", []string{"flows.000004"}, "flows.000004")).Should(HaveOccurred())
+
+		By("rejecting an alias with the name of an index")
+		f.AddIndex("dns.", "")
+		Expect(f.PutAlias(ctx, "dns.", []string{"dns.000001"}, "dns.000001")).Should(HaveOccurred())
+		Expect(elastic.IsNotFound(f.PutAlias(ctx, "flows.", []string{"missing"}, ""))).To(BeTrue())
+	})
+
+	It("should report and update the settings of the indices", func() {
+		f.AddIndex("flows.000001", "")
+		f.AddIndex("dns.000001", "")
+		Expect(f.PutIndexSettings(ctx, "flows.000001", map[string]string{"index.number_of_replicas": "1", "index.lifecycle.name": "flows"})).ShouldNot(HaveOccurred())
+
+		settings, err := f.GetIndexSettings(ctx, "flows.*", "index.lifecycle.name")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(settings).To(Equal([]utils.IndexSettings{{Index: "flows.000001", Settings: map[string]string{"index.lifecycle.name": "flows"}}}))
+		Expect(f.IndexSettings("flows.000001")).To(HaveKeyWithValue("index.number_of_replicas", "1"))
+		Expect(elastic.IsNotFound(f.PutIndexSettings(ctx, "missing", map[string]string{}))).To(BeTrue())
+
+		f.AddIndex(".kibana_1", "")
+		Expect(f.PutIndexSettings(ctx, ".kibana_1", map[string]string{"index.lifecycle.name": "flows"})).Should(HaveOccurred())
+		Expect(f.IndexSettings(".kibana_1")).To(BeEmpty())
+	})
+
+	It("should record the cross-cluster replication configuration", func() {
+		_, _, ok := f.RemoteCluster("primary")
+		Expect(ok).To(BeFalse())
+
+		Expect(f.ConfigureCrossClusterReplication(ctx, "primary", "es-transport.example.com:9300", []string{"tigera_secure_ee_*"})).ShouldNot(HaveOccurred())
+		address, patterns, ok := f.RemoteCluster("primary")
+		Expect(ok).To(BeTrue())
+		Expect(address).To(Equal("es-transport.example.com:9300"))
+		Expect(patterns).To(Equal([]string{"tigera_secure_ee_*"}))
+	})
+
+	It("should report the usage of the indices that match the pattern", func() {
+		f.SetIndexUsage("tigera_secure_ee_flows.cluster.lma-000001", 10, 1024)
+		f.SetIndexUsage("calico_dnslogs_standard.linseed-000001", 5, 512)
+		f.AddIndex("tigera_secure_ee_dns.cluster.lma-000001", "")
+		f.AddIndex(".kibana", "")
+
+		usage, err := f.IndexUsage(ctx, "tigera_secure_ee_*,calico_*")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(usage).To(Equal([]utils.IndexUsage{
+			{Index: "calico_dnslogs_standard.linseed-000001", Documents: 5, SizeBytes: 512},
+			{Index: "tigera_secure_ee_dns.cluster.lma-000001"},
+			{Index: "tigera_secure_ee_flows.cluster.lma-000001", Documents: 10, SizeBytes: 1024},
+		}))
+	})
+
+	It("should return the injected errors until they are cleared", func() {
+		injected := fmt.Errorf("injected")
+		f.InjectError(MethodCreateUser, injected)
+		f.InjectError(MethodClusterHealth, injected)
+
+		Expect(f.CreateUser(ctx, &utils.User{Username: "user"})).To(Equal(injected))
+		_, err := f.ClusterHealth(ctx)
+		Expect(err).To(Equal(injected))
+		_, ok := f.User("user")
+		Expect(ok).To(BeFalse())
+
+		f.InjectError(MethodCreateUser, nil)
+		Expect(f.CreateUser(ctx, &utils.User{Username: "user"})).ShouldNot(HaveOccurred())
+
+		f.ClearErrors()
+		health, err := f.ClusterHealth(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(health)).To(ContainSubstring("green"))
+	})
+})
@@ -0,0 +1,127 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// appliedObjects is the cache of the objects that the component handlers applied. It is shared by all the controllers
+// of the operator, since each controller creates a new component handler on every reconcile.
+var appliedObjects = newApplyCache()
+
+// applyCache remembers, for each object that the operator applied, the hash of the desired state it applied and the
+// hash of the object as the API server stored it. When a component renders the same desired state again, and the stored
+// object has not changed since, applying it again would write the same object, so the write can be skipped. This
+// avoids updating objects, and bumping their resourceVersion, when the merge with the stored object detects
+// differences that the API server then defaults away.
+type applyCache struct {
+	lock    sync.Mutex
+	entries map[string]appliedObject
+}
+
+type appliedObject struct {
+	desired string
+	stored  string
+}
+
+func newApplyCache() *applyCache {
+	return &applyCache{entries: make(map[string]appliedObject)}
+}
+
+// upToDate returns true if the desired state was the last one applied to the object, and the stored object has not
+// changed since.
+func (a *applyCache) upToDate(obj client.Object, desired string, cur client.Object) bool {
+	if desired == "" {
+		return false
+	}
+	a.lock.Lock()
+	entry, ok := a.entries[applyCacheKey(obj)]
+	a.lock.Unlock()
+	if !ok || entry.desired != desired {
+		return false
+	}
+	stored := storedObjectHash(cur)
+	return stored != "" && stored == entry.stored
+}
+
+// record remembers that the desired state was applied to the object, which the API server stored as stored.
+func (a *applyCache) record(obj client.Object, desired string, stored client.Object) {
+	storedHash := storedObjectHash(stored)
+	if desired == "" || storedHash == "" {
+		a.forget(obj)
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.entries[applyCacheKey(obj)] = appliedObject{desired: desired, stored: storedHash}
+}
+
+// forget removes the object from the cache, so that it is merged with the stored object and written the next time it
+// is applied.
+func (a *applyCache) forget(obj client.Object) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.entries, applyCacheKey(obj))
+}
+
+func applyCacheKey(obj client.Object) string {
+	t := reflect.TypeOf(obj).Elem()
+	return t.PkgPath() + "." + t.Name() + "/" + client.ObjectKeyFromObject(obj).String()
+}
+
+// desiredObjectHash returns the hash of the state of the object that the operator applies, or an empty string if it
+// can't be hashed.
+func desiredObjectHash(obj client.Object) string {
+	return hashObject(obj, false)
+}
+
+// storedObjectHash returns the hash of the object as the API server stored it, or an empty string if it can't be
+// hashed. The status and the fields that the API server updates along with it are left out, so that the status of
+// an object changing doesn't cause the object to be written again. So is the type, which the client only sets on
+// some of the objects it returns, and which the cache key already identifies.
+func storedObjectHash(obj client.Object) string {
+	return hashObject(obj, true)
+}
+
+func hashObject(obj client.Object, stored bool) string {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return ""
+	}
+	if stored {
+		delete(u, "apiVersion")
+		delete(u, "kind")
+		delete(u, "status")
+		if metadata, ok := u["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "resourceVersion")
+			delete(metadata, "managedFields")
+		}
+	}
+	// Maps are marshalled with sorted keys, so the hash of an object is stable.
+	data, err := json.Marshal(u)
+	if err != nil {
+		return ""
+	}
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
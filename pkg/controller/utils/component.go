@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
 
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -29,18 +31,28 @@ import (
 	apps "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	apiextenv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/telemetry"
+	"github.com/tigera/operator/version"
 )
 
 type ComponentHandler interface {
@@ -51,39 +63,48 @@ type ComponentHandler interface {
 // this is useful for CRD management so that they are not removed automatically.
 func NewComponentHandler(log logr.Logger, client client.Client, scheme *runtime.Scheme, cr metav1.Object) ComponentHandler {
 	return &componentHandler{
-		client: client,
-		scheme: scheme,
-		cr:     cr,
-		log:    log,
+		client:       client,
+		scheme:       scheme,
+		cr:           cr,
+		log:          log,
+		applied:      appliedObjects,
+		capabilities: capabilities,
 	}
 }
 
-type componentHandler struct {
-	client client.Client
-	scheme *runtime.Scheme
-	cr     metav1.Object
-	log    logr.Logger
+// capabilities detects which of the optional APIs the cluster serves, so that the component handlers leave out the
+// objects of the ones it doesn't. It is set with SetCapabilities; until then the objects of every API are applied.
+var capabilities *render.Capabilities
+
+// SetCapabilities sets the Capabilities that the component handlers use to detect the optional APIs that the cluster
+// serves.
+func SetCapabilities(c *render.Capabilities) {
+	capabilities = c
 }
 
-func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.Object, osType rmeta.OSType) error {
-	om, ok := obj.(metav1.ObjectMetaAccessor)
-	if !ok {
-		return fmt.Errorf("object is not ObjectMetaAccessor")
-	}
+type componentHandler struct {
+	client       client.Client
+	scheme       *runtime.Scheme
+	cr           metav1.Object
+	log          logr.Logger
+	applied      *applyCache
+	capabilities *render.Capabilities
+}
 
-	multipleOwners := checkIfMultipleOwnersLabel(om.GetObjectMeta())
+func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.Object, osType rmeta.OSType, install *installationReader) error {
+	multipleOwners := checkIfMultipleOwnersLabel(obj)
 	// Add owner ref for controller owned resources,
 	switch obj.(type) {
 	case *v3.UISettings:
 		// Never add controller ref for UISettings since these are always GCd through the UISettingsGroup.
 	default:
-		if c.cr != nil && !skipAddingOwnerReference(c.cr, om.GetObjectMeta()) {
+		if c.cr != nil && !skipAddingOwnerReference(c.cr, obj) {
 			if multipleOwners {
-				if err := controllerutil.SetOwnerReference(c.cr, om.GetObjectMeta(), c.scheme); err != nil {
+				if err := controllerutil.SetOwnerReference(c.cr, obj, c.scheme); err != nil {
 					return err
 				}
 			} else {
-				if err := controllerutil.SetControllerReference(c.cr, om.GetObjectMeta(), c.scheme); err != nil {
+				if err := controllerutil.SetControllerReference(c.cr, obj, c.scheme); err != nil {
 					return err
 				}
 			}
@@ -103,9 +124,16 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 	// Modify Liveness and Readiness probe default values if they are not set for this object.
 	setProbeTimeouts(obj)
 
+	// Point Tigera components at the OTLP endpoint if component telemetry is enabled.
+	if err := c.configureTelemetry(ctx, obj, install); err != nil {
+		return err
+	}
+
 	// Make sure we have our standard selector and pod labels
 	setStandardSelectorAndLabels(obj)
 
+	desired := desiredObjectHash(obj)
+
 	cur, ok := obj.DeepCopyObject().(client.Object)
 	if !ok {
 		logCtx.V(2).Info("Failed converting object", "obj", obj)
@@ -122,15 +150,16 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 		// Otherwise, if it was not found, we should create it and move on.
 		logCtx.V(2).Info("Object does not exist, creating it", "error", err)
 		if multipleOwners {
-			labels := om.GetObjectMeta().GetLabels()
+			labels := obj.GetLabels()
 			delete(labels, common.MultipleOwnersLabel)
-			om.GetObjectMeta().SetLabels(labels)
+			obj.SetLabels(labels)
 		}
 		err = c.client.Create(ctx, obj)
 		if err != nil {
 			logCtx.WithValues("key", key).Error(err, "Failed to create object.")
 			return err
 		}
+		c.applied.record(obj, desired, obj)
 		return nil
 	}
 
@@ -141,8 +170,28 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 	}
 	logCtx.V(2).Info("Resource already exists, update it")
 
+	adopt, err := c.shouldAdopt(ctx, cur, install)
+	if err != nil {
+		return err
+	}
+	if !adopt && c.applied.upToDate(obj, desired, cur) {
+		logCtx.V(2).Info("Object is unchanged since it was last applied, skipping update")
+		return nil
+	}
+	// Whatever happens to the object from here, the cache no longer describes it until it is recorded again.
+	c.applied.forget(obj)
+
+	// Objects that the user wants to keep on delete are orphaned, so that they aren't garbage collected with their owner.
+	keep := keepOnDelete(cur)
+	if keep {
+		removeOwnerReference(obj, c.cr)
+	}
+
 	// if mergeState returns nil we don't want to update the object
 	if mobj := mergeState(obj, cur); mobj != nil {
+		if keep {
+			removeOwnerReference(mobj, c.cr)
+		}
 		switch obj.(type) {
 		case *batchv1.Job:
 			// Jobs can't be updated, they can only be deleted then created
@@ -157,6 +206,7 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 				logCtx.WithValues("key", key).Error(err, "Failed to create Job.")
 				return err
 			}
+			c.applied.record(obj, desired, mobj)
 			return nil
 		case *v1.Secret:
 			objSecret := obj.(*v1.Secret)
@@ -176,6 +226,7 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 					logCtx.WithValues("key", key).Error(err, "Failed to create Secret.")
 					return err
 				}
+				c.applied.record(obj, desired, mobj)
 				return nil
 			}
 		case *v1.Service:
@@ -196,6 +247,7 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 					logCtx.WithValues("key", key).Error(err, "Failed to recreate service.", "obj", obj)
 					return err
 				}
+				c.applied.record(obj, desired, mobj)
 				return nil
 			}
 		case *rbacv1.RoleBinding:
@@ -214,6 +266,7 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 					logCtx.WithValues("key", key).Error(err, "Failed to recreate RoleBinding")
 					return err
 				}
+				c.applied.record(obj, desired, mobj)
 				return nil
 			}
 		case *rbacv1.ClusterRoleBinding:
@@ -232,17 +285,167 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 					logCtx.WithValues("key", key).Error(err, "Failed to recreate ClusterRoleBinding")
 					return err
 				}
+				c.applied.record(obj, desired, mobj)
 				return nil
 			}
 		}
+		if adopt {
+			return c.adoptObject(ctx, mobj, logCtx)
+		}
 		if err := c.client.Update(ctx, mobj); err != nil {
 			logCtx.WithValues("key", key).Info("Failed to update object.")
 			return err
 		}
+		c.applied.record(obj, desired, mobj)
+	} else if adopt {
+		return c.adoptObject(ctx, obj, logCtx)
+	} else {
+		c.applied.record(obj, desired, cur)
 	}
 	return nil
 }
 
+// shouldAdopt returns whether the existing object should be adopted: it was not created or adopted by the operator,
+// and the Installation enables ResourceAdoption.
+func (c componentHandler) shouldAdopt(ctx context.Context, cur client.Object, install *installationReader) (bool, error) {
+	if cur.GetLabels()[common.ManagedByLabel] == common.ManagedByValue {
+		return false, nil
+	}
+	spec, err := install.get(ctx)
+	if err != nil || spec == nil {
+		return false, err
+	}
+	return operatorv1.IsResourceAdoptionEnabled(spec.ResourceAdoption), nil
+}
+
+// adoptObject takes ownership of an existing object that was not created by the operator. The object is labelled as
+// managed by the operator and server-side applied with forced ownership, so that the operator becomes the manager of
+// all its fields and other appliers, such as the manifests it was created from, conflict with the operator instead
+// of silently reverting its changes.
+func (c componentHandler) adoptObject(ctx context.Context, obj client.Object, logCtx logr.Logger) error {
+	obj = obj.DeepCopyObject().(client.Object)
+	gvk, err := apiutil.GVKForObject(obj, c.client.Scheme())
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	labels := common.MapExistsOrInitialize(obj.GetLabels())
+	labels[common.ManagedByLabel] = common.ManagedByValue
+	obj.SetLabels(labels)
+	resetMetadataForCreate(obj)
+	obj.SetManagedFields(nil)
+
+	logCtx.Info("Adopting existing object")
+	if err := c.client.Patch(ctx, obj, client.Apply, client.ForceOwnership, client.FieldOwner(common.ManagedByValue)); err != nil {
+		logCtx.Error(err, "Failed to adopt object.")
+		return err
+	}
+	return nil
+}
+
+// ownershipLabels returns the labels that record the component, the owner and the operator version on the objects
+// that the component renders. The owner labels are omitted if the handler has no owner.
+func (c componentHandler) ownershipLabels(component render.Component) (map[string]string, error) {
+	labels := map[string]string{
+		common.ComponentLabel:       labelValue(componentName(component)),
+		common.OperatorVersionLabel: labelValue(version.VERSION),
+	}
+	if c.cr == nil {
+		return labels, nil
+	}
+	if ro, ok := c.cr.(runtime.Object); ok && c.scheme != nil {
+		gvk, err := apiutil.GVKForObject(ro, c.scheme)
+		if err != nil {
+			return nil, err
+		}
+		labels[common.OwnerKindLabel] = gvk.Kind
+	}
+	labels[common.OwnerNameLabel] = c.cr.GetName()
+	if c.cr.GetNamespace() != "" {
+		labels[common.OwnerNamespaceLabel] = c.cr.GetNamespace()
+	}
+	return labels, nil
+}
+
+// setOwnershipLabels sets the ownership labels on the object. Objects with multiple owners only get the version label,
+// since the owners would otherwise keep overwriting each other's labels.
+func setOwnershipLabels(obj client.Object, ownership map[string]string) {
+	labels := common.MapExistsOrInitialize(obj.GetLabels())
+	multipleOwners := checkIfMultipleOwnersLabel(obj)
+	for k, v := range ownership {
+		if multipleOwners && k != common.OperatorVersionLabel {
+			continue
+		}
+		labels[k] = v
+	}
+	obj.SetLabels(labels)
+}
+
+// componentName returns the name of the type of the component, e.g. nodeComponent for a *render.nodeComponent.
+func componentName(component render.Component) string {
+	t := reflect.TypeOf(component)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// labelValue converts s into a valid label value by replacing the characters that labels don't allow with dashes and
+// truncating it to the maximum length of a label value.
+func labelValue(s string) string {
+	v := []byte(s)
+	for i, ch := range v {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_' || ch == '.') {
+			v[i] = '-'
+		}
+	}
+	if len(v) > validation.LabelValueMaxLength {
+		v = v[:validation.LabelValueMaxLength]
+	}
+	return strings.Trim(string(v), "-_.")
+}
+
+// keepOnDelete returns whether the user asked to keep the object when its component is torn down.
+func keepOnDelete(obj metav1.Object) bool {
+	return strings.ToLower(obj.GetAnnotations()[common.KeepOnDeleteAnnotation]) == "true"
+}
+
+// removeOwnerReference removes the owner reference to owner from the object.
+func removeOwnerReference(obj metav1.Object, owner metav1.Object) {
+	if owner == nil {
+		return
+	}
+	refs := slices.DeleteFunc(obj.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+		return ref.UID == owner.GetUID()
+	})
+	obj.SetOwnerReferences(refs)
+}
+
+// keepObject returns whether an object that the component no longer renders must be kept, because it is annotated to
+// be kept on delete. A kept object is orphaned, so that it isn't garbage collected with its owner either.
+func (c componentHandler) keepObject(ctx context.Context, obj client.Object) (bool, error) {
+	cur, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return false, nil
+	}
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(obj), cur); err != nil {
+		// Leave it to the delete to report anything other than an object that doesn't exist.
+		return false, nil
+	}
+	if !keepOnDelete(cur) {
+		return false, nil
+	}
+
+	logCtx := ContextLoggerForResource(c.log, obj)
+	logCtx.Info("Keeping object annotated to be kept on delete")
+	n := len(cur.GetOwnerReferences())
+	removeOwnerReference(cur, c.cr)
+	if len(cur.GetOwnerReferences()) == n {
+		return true, nil
+	}
+	return true, c.client.Update(ctx, cur)
+}
+
 func resetMetadataForCreate(obj client.Object) {
 	obj.SetResourceVersion("")
 	obj.SetUID("")
@@ -258,6 +461,13 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 		cmpLog.Info("Component is not ready, skipping")
 		return nil
 	}
+	if common.IsReconcilePaused(c.cr) {
+		// The custom resource that owns the component is annotated to pause its reconciliation, so leave the objects of
+		// the component as they are. Controllers check this with IsReconcilePaused before they get here, this covers
+		// the components that a controller renders for a custom resource other than its own.
+		cmpLog.Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+		return nil
+	}
 	cmpLog.V(2).Info("Reconciling")
 
 	// Iterate through each object that comprises the component and attempt to create it,
@@ -270,25 +480,107 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 	objsToCreate, objsToDelete := component.Objects()
 	osType := component.SupportedOSType()
 
-	for _, obj := range objsToCreate {
+	// Leave out the objects of the optional APIs that the cluster doesn't serve, unless the component can't work
+	// without them.
+	objsToCreate, missing, err := c.capabilities.ServedObjects(objsToCreate, c.scheme)
+	if err != nil {
+		cmpLog.Error(err, "Failed to discover the APIs that the cluster serves")
+		return err
+	}
+	if len(missing) > 0 {
+		var kinds []string
+		for _, gvk := range missing {
+			kinds = append(kinds, fmt.Sprintf("%s %s", gvk.GroupVersion(), gvk.Kind))
+		}
+		if render.MissingAPIPolicyOf(component) == render.MissingAPIFail {
+			return fmt.Errorf("the cluster does not serve the APIs of the component: %s", strings.Join(kinds, ", "))
+		}
+		cmpLog.V(1).Info("Skipping the objects of APIs that the cluster does not serve", "kinds", kinds)
+	}
+	objsToDelete, _, err = c.capabilities.ServedObjects(objsToDelete, c.scheme)
+	if err != nil {
+		cmpLog.Error(err, "Failed to discover the APIs that the cluster serves")
+		return err
+	}
+
+	// Apply the post-render hooks that integrators have registered.
+	objsToCreate, err = render.MutateObjects(component, objsToCreate)
+	if err != nil {
+		cmpLog.Error(err, "Failed to mutate the objects of the component")
+		return err
+	}
+
+	install := &installationReader{client: c.client}
+	rolloutPolicy, rollbackPolicy, err := c.workloadPolicies(ctx, objsToCreate, install)
+	if err != nil {
+		cmpLog.Error(err, "Failed to read the rollout and rollback policies")
+		return err
+	}
+
+	objsToCreate, objsToDelete, err = c.namespaceDefaultDeny(ctx, objsToCreate, objsToDelete)
+	if err != nil {
+		cmpLog.Error(err, "Failed to read the namespace default-deny setting")
+		return err
+	}
+
+	ownership, err := c.ownershipLabels(component)
+	if err != nil {
+		cmpLog.Error(err, "Failed to determine the ownership labels of the component")
+		return err
+	}
+
+	apply := func(obj client.Object) error {
 		key := client.ObjectKeyFromObject(obj)
 
+		// Roll the pods of the object when any of the secrets or config maps they use change. The object is copied
+		// first so the component's objects are not modified.
+		obj = obj.DeepCopyObject().(client.Object)
+		setOwnershipLabels(obj, ownership)
+		if err := c.setMountedObjectsHash(ctx, obj, objsToCreate); err != nil {
+			cmpLog.Error(err, "Failed to hash the secrets and config maps used by object", "key", key)
+			return err
+		}
+		if err := c.setRolloutStrategy(ctx, obj, rolloutPolicy); err != nil {
+			cmpLog.Error(err, "Failed to read the rollout state of object", "key", key)
+			return err
+		}
+		if err := c.setRollbackState(ctx, obj, rollbackPolicy); err != nil {
+			cmpLog.Error(err, "Failed to read the rollback state of object", "key", key)
+			return err
+		}
+		if err := c.addNetworkPolicyOverrides(ctx, obj); err != nil {
+			cmpLog.Error(err, "Failed to read the NetworkPolicyOverrides of object", "key", key)
+			return err
+		}
+
 		// Pass in a DeepCopy so any modifications made by createOrUpdateObject won't be included
 		// if we need to retry the function
-		err := c.createOrUpdateObject(ctx, obj.DeepCopyObject().(client.Object), osType)
+		err := c.createOrUpdateObject(ctx, obj.DeepCopyObject().(client.Object), osType, install)
 		if err != nil && errors.IsConflict(err) {
 			// If the error is a resource Conflict, try the update again
 			cmpLog.WithValues("key", key, "conflict_message", err).Info("Failed to update object, retrying.")
-			err = c.createOrUpdateObject(ctx, obj, osType)
-			if err != nil {
-				return err
-			}
-		} else if err != nil {
+			err = c.createOrUpdateObject(ctx, obj, osType, install)
+		}
+		if err != nil {
 			cmpLog.Error(err, "Failed to create or update object", "key", key)
+			return fmt.Errorf("failed to create or update %s %s: %w", reflect.TypeOf(obj).Elem().Name(), key, err)
+		}
+		return nil
+	}
+
+	// The objects are applied concurrently, in stages so that the objects that others rely on exist first. An object
+	// that fails to apply doesn't stop the others of its stage from being applied, but does stop the later stages.
+	for _, stage := range applyStages(objsToCreate) {
+		if err := forEachConcurrently(ctx, len(stage), componentApplyWorkers, 0, func(i int) error {
+			return apply(stage[i])
+		}); err != nil {
 			return err
 		}
+	}
 
-		// Keep track of some objects so we can report on their status.
+	// Keep track of some objects so we can report on their status.
+	for _, obj := range objsToCreate {
+		key := client.ObjectKeyFromObject(obj)
 		switch obj.(type) {
 		case *apps.Deployment:
 			deployments = append(deployments, key)
@@ -299,8 +591,6 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 		case *batchv1.CronJob:
 			cronJobs = append(cronJobs, key)
 		}
-
-		continue
 	}
 
 	if status != nil {
@@ -320,12 +610,21 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 	}
 
 	for _, obj := range objsToDelete {
-		err := c.client.Delete(ctx, obj)
-		if err != nil && !errors.IsNotFound(err) {
+		kept, err := c.keepObject(ctx, obj)
+		if err != nil {
 			logCtx := ContextLoggerForResource(c.log, obj)
-			logCtx.Error(err, fmt.Sprintf("Error deleting object %v", obj))
+			logCtx.Error(err, fmt.Sprintf("Error orphaning object %v", obj))
 			return err
 		}
+		if !kept {
+			err = c.client.Delete(ctx, obj)
+			if err != nil && !errors.IsNotFound(err) {
+				logCtx := ContextLoggerForResource(c.log, obj)
+				logCtx.Error(err, fmt.Sprintf("Error deleting object %v", obj))
+				return err
+			}
+		}
+		c.applied.forget(obj)
 
 		key := client.ObjectKeyFromObject(obj)
 		if status != nil {
@@ -350,6 +649,265 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 	return nil
 }
 
+// componentApplyWorkers is the number of objects of a component that are applied concurrently.
+const componentApplyWorkers = 10
+
+// applyStages splits the objects of a component into the stages in which they are applied, keeping their order within
+// each stage. The namespaces, custom resource definitions and tiers come first, since other objects can't be created
+// without them, and the workloads last, so that their pods start with their service accounts, secrets and config maps
+// in place.
+func applyStages(objs []client.Object) [][]client.Object {
+	var prerequisites, config, workloads []client.Object
+	for _, obj := range objs {
+		switch obj.(type) {
+		case *v1.Namespace, *apiextenv1.CustomResourceDefinition, *v3.Tier:
+			prerequisites = append(prerequisites, obj)
+		case *apps.Deployment, *apps.DaemonSet, *apps.StatefulSet, *batchv1.Job, *batchv1.CronJob:
+			workloads = append(workloads, obj)
+		default:
+			config = append(config, obj)
+		}
+	}
+
+	var stages [][]client.Object
+	for _, stage := range [][]client.Object{prerequisites, config, workloads} {
+		if len(stage) > 0 {
+			stages = append(stages, stage)
+		}
+	}
+	return stages
+}
+
+// installationReader reads the Installation for a component at most once, and only when it is needed, since not every
+// controller runs alongside one. It is safe for concurrent use.
+type installationReader struct {
+	client client.Client
+	lock   sync.Mutex
+	read   bool
+	spec   *operatorv1.InstallationSpec
+}
+
+// get returns the spec of the Installation, or nil if there is none.
+func (r *installationReader) get(ctx context.Context) (*operatorv1.InstallationSpec, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.read {
+		return r.spec, nil
+	}
+	instance := &operatorv1.Installation{}
+	if err := r.client.Get(ctx, DefaultInstanceKey, instance); err != nil {
+		if !errors.IsNotFound(err) && !meta.IsNoMatchError(err) && !runtime.IsNotRegisteredError(err) {
+			return nil, err
+		}
+	} else {
+		r.spec = &instance.Spec
+	}
+	r.read = true
+	return r.spec, nil
+}
+
+// workloadPolicies returns the RolloutPolicy and RollbackPolicy of the Installation if any of the objects is a
+// Deployment or DaemonSet.
+func (c componentHandler) workloadPolicies(ctx context.Context, objs []client.Object, install *installationReader) (*operatorv1.RolloutPolicy, *operatorv1.RollbackPolicy, error) {
+	hasWorkload := false
+	for _, obj := range objs {
+		switch obj.(type) {
+		case *apps.Deployment, *apps.DaemonSet:
+			hasWorkload = true
+		}
+	}
+	if !hasWorkload {
+		return nil, nil, nil
+	}
+
+	spec, err := install.get(ctx)
+	if err != nil || spec == nil {
+		return nil, nil, err
+	}
+	return spec.RolloutPolicy, spec.RollbackPolicy, nil
+}
+
+// namespaceDefaultDeny adds a Kubernetes default-deny policy to the objects to create for each namespace in which the
+// component renders the allow-tigera default-deny policy, if the Installation enables NamespaceDefaultDeny. Otherwise
+// the policies are added to the objects to delete, so that they are removed when the setting is disabled.
+func (c componentHandler) namespaceDefaultDeny(ctx context.Context, toCreate, toDelete []client.Object) ([]client.Object, []client.Object, error) {
+	var policies []client.Object
+	for _, obj := range toCreate {
+		if np, ok := obj.(*v3.NetworkPolicy); ok && np.Name == networkpolicy.TigeraComponentDefaultDenyPolicyName {
+			policies = append(policies, networkpolicy.NamespaceDefaultDeny(np.Namespace))
+		}
+	}
+	if len(policies) == 0 {
+		return toCreate, toDelete, nil
+	}
+
+	instance := &operatorv1.Installation{}
+	if err := c.client.Get(ctx, DefaultInstanceKey, instance); err != nil && !errors.IsNotFound(err) {
+		return nil, nil, err
+	}
+	if instance.Spec.NamespaceDefaultDeny != nil && *instance.Spec.NamespaceDefaultDeny == operatorv1.NamespaceDefaultDenyEnabled {
+		return append(slices.Clone(toCreate), policies...), toDelete, nil
+	}
+	return toCreate, append(slices.Clone(toDelete), policies...), nil
+}
+
+// setRolloutStrategy hands the rollout of DaemonSets over to the rollout controller when the Installation has a
+// RolloutPolicy. The DaemonSets are switched to the OnDelete update strategy, so that their pods are only updated when
+// the rollout controller deletes them. A staged rollout starts whenever the pod template rendered for the DaemonSet
+// changes, and the rollout controller ends it once all of the pods are updated.
+func (c componentHandler) setRolloutStrategy(ctx context.Context, obj client.Object, policy *operatorv1.RolloutPolicy) error {
+	ds, ok := obj.(*apps.DaemonSet)
+	if !ok || policy == nil {
+		return nil
+	}
+
+	ds.Spec.UpdateStrategy = apps.DaemonSetUpdateStrategy{Type: apps.OnDeleteDaemonSetStrategyType}
+	hash, err := TemplateHash(&ds.Spec.Template)
+	if err != nil {
+		return err
+	}
+	annotations := common.MapExistsOrInitialize(ds.GetAnnotations())
+	annotations[common.StagedRolloutTemplateHashAnnotation] = hash
+	ds.SetAnnotations(annotations)
+
+	cur := &apps.DaemonSet{}
+	if err = c.client.Get(ctx, client.ObjectKeyFromObject(ds), cur); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if cur.Annotations[common.StagedRolloutTemplateHashAnnotation] != hash {
+		annotations[common.StagedRolloutAnnotation] = "true"
+	}
+	return nil
+}
+
+// addNetworkPolicyOverrides adds the rules of the NetworkPolicyOverrides of obj to the rules rendered by the operator,
+// if obj is a policy in the allow-tigera tier. The rules are inserted before the Pass or Deny rules that the rendered
+// rules end with, since rules after those would never match. Rules are only added for the directions that the policy
+// already applies to, so that an override cannot start denying the traffic of the other direction. The overrides are
+// applied in order of their names, so that the policy does not change between reconciles.
+func (c componentHandler) addNetworkPolicyOverrides(ctx context.Context, obj client.Object) error {
+	policy, ok := obj.(*v3.NetworkPolicy)
+	if !ok || policy.Spec.Tier != networkpolicy.TigeraComponentTierName {
+		return nil
+	}
+
+	overrides := &operatorv1.NetworkPolicyOverrideList{}
+	if err := c.client.List(ctx, overrides, client.InNamespace(policy.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return nil
+		}
+		return err
+	}
+	slices.SortFunc(overrides.Items, func(a, b operatorv1.NetworkPolicyOverride) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var ingress, egress []v3.Rule
+	for _, override := range overrides.Items {
+		if override.Spec.PolicyName != policy.Name {
+			continue
+		}
+		if len(override.Spec.Ingress) > 0 && !slices.Contains(policy.Spec.Types, v3.PolicyTypeIngress) {
+			c.log.Info("Ignoring the ingress rules of NetworkPolicyOverride, the policy does not apply to ingress",
+				"override", override.Name, "namespace", override.Namespace)
+		} else {
+			ingress = append(ingress, override.Spec.Ingress...)
+		}
+		if len(override.Spec.Egress) > 0 && !slices.Contains(policy.Spec.Types, v3.PolicyTypeEgress) {
+			c.log.Info("Ignoring the egress rules of NetworkPolicyOverride, the policy does not apply to egress",
+				"override", override.Name, "namespace", override.Namespace)
+		} else {
+			egress = append(egress, override.Spec.Egress...)
+		}
+	}
+	policy.Spec.Ingress = insertOverrideRules(policy.Spec.Ingress, ingress)
+	policy.Spec.Egress = insertOverrideRules(policy.Spec.Egress, egress)
+	return nil
+}
+
+// insertOverrideRules returns the rendered rules with the override rules inserted before the Pass and Deny rules that
+// the rendered rules end with.
+func insertOverrideRules(rendered, overrides []v3.Rule) []v3.Rule {
+	if len(overrides) == 0 {
+		return rendered
+	}
+	i := len(rendered)
+	for i > 0 && (rendered[i-1].Action == v3.Pass || rendered[i-1].Action == v3.Deny) {
+		i--
+	}
+	return slices.Concat(rendered[:i], overrides, rendered[i:])
+}
+
+// setMountedObjectsHash sets an annotation on the pod template of obj with a hash of the data of the secrets and
+// config maps that its pods use. The objects rendered by the component are used if they include a secret or config
+// map, otherwise it is read from the cluster. Only workloads whose pods are rolled on template changes are
+// annotated; Jobs have an immutable template and ECK restarts Elasticsearch on any template change.
+func (c componentHandler) setMountedObjectsHash(ctx context.Context, obj client.Object, objs []client.Object) error {
+	var template *v1.PodTemplateSpec
+	switch x := obj.(type) {
+	case *apps.Deployment:
+		template = &x.Spec.Template
+	case *apps.DaemonSet:
+		template = &x.Spec.Template
+	case *apps.StatefulSet:
+		template = &x.Spec.Template
+	default:
+		return nil
+	}
+
+	secretNames, configMapNames := rmeta.ReferencedSecretsAndConfigMaps(&template.Spec)
+	if len(secretNames) == 0 && len(configMapNames) == 0 {
+		return nil
+	}
+
+	var secrets []*v1.Secret
+	for _, name := range secretNames {
+		s := &v1.Secret{}
+		found, err := c.getMountedObject(ctx, client.ObjectKey{Name: name, Namespace: obj.GetNamespace()}, s, objs)
+		if err != nil {
+			return err
+		}
+		if found {
+			secrets = append(secrets, s)
+		}
+	}
+	var configMaps []*v1.ConfigMap
+	for _, name := range configMapNames {
+		cm := &v1.ConfigMap{}
+		found, err := c.getMountedObject(ctx, client.ObjectKey{Name: name, Namespace: obj.GetNamespace()}, cm, objs)
+		if err != nil {
+			return err
+		}
+		if found {
+			configMaps = append(configMaps, cm)
+		}
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[rmeta.MountedObjectsHashAnnotation] = rmeta.MountedObjectsHash(secrets, configMaps)
+	return nil
+}
+
+// getMountedObject populates into with the object with the given key and returns true if it was found in objs or in
+// the cluster. Objects that do not exist are ignored, since pods can reference optional or not yet created objects.
+func (c componentHandler) getMountedObject(ctx context.Context, key client.ObjectKey, into client.Object, objs []client.Object) (bool, error) {
+	for _, o := range objs {
+		if reflect.TypeOf(o) == reflect.TypeOf(into) && client.ObjectKeyFromObject(o) == key {
+			reflect.ValueOf(into).Elem().Set(reflect.ValueOf(o).Elem())
+			return true, nil
+		}
+	}
+	if err := c.client.Get(ctx, key, into); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // skipAddingOwnerReference returns true if owner is a namespaced resource and
 // controlled object is a cluster scoped resource.
 func skipAddingOwnerReference(owner, controlled metav1.Object) bool {
@@ -367,14 +925,24 @@ func checkIfMultipleOwnersLabel(controlled metav1.Object) bool {
 	return ok
 }
 
+// secretType returns the type of the given secret, defaulting to Opaque like the API server does.
+func secretType(s *v1.Secret) v1.SecretType {
+	if s.Type == "" {
+		return v1.SecretTypeOpaque
+	}
+	return s.Type
+}
+
 // mergeState returns the object to pass to Update given the current and desired object states.
 func mergeState(desired client.Object, current runtime.Object) client.Object {
 	// Take a copy of the desired object, so we can merge values into it without
 	// adjusting the caller's copy.
 	desired = desired.DeepCopyObject().(client.Object)
 
-	currentMeta := current.(metav1.ObjectMetaAccessor).GetObjectMeta()
-	desiredMeta := desired.(metav1.ObjectMetaAccessor).GetObjectMeta()
+	// Use the metav1.Object interface rather than ObjectMetaAccessor so that unstructured objects, which
+	// are used for resources from APIs the operator does not vendor, can also be merged.
+	currentMeta := current.(metav1.Object)
+	desiredMeta := metav1.Object(desired)
 
 	// Merge common metadata fields if not present on the desired state.
 	if desiredMeta.GetResourceVersion() == "" {
@@ -412,6 +980,19 @@ func mergeState(desired client.Object, current runtime.Object) client.Object {
 	}
 
 	switch desired.(type) {
+	case *v1.Secret:
+		// Skip the update if neither the content nor the metadata of the secret changed, so that watchers of the
+		// secret are not needlessly notified. A change of type is left to the caller, which has to recreate the secret.
+		cs := current.(*v1.Secret)
+		ds := desired.(*v1.Secret)
+		if secretType(cs) == secretType(ds) &&
+			rmeta.SecretDataHash(cs) == rmeta.SecretDataHash(ds) &&
+			apiequality.Semantic.DeepEqual(cs.Labels, ds.Labels) &&
+			apiequality.Semantic.DeepEqual(cs.Annotations, ds.Annotations) &&
+			apiequality.Semantic.DeepEqual(cs.OwnerReferences, ds.OwnerReferences) {
+			return nil
+		}
+		return ds
 	case *v1.Service:
 		// Services are a special case since some fields (namely ClusterIP) are defaulted
 		// and we need to maintain them on updates.
@@ -474,6 +1055,20 @@ func mergeState(desired client.Object, current runtime.Object) client.Object {
 		dd.Spec.Template.SetAnnotations(mergedAnnotations)
 
 		return dd
+	case *v1.PersistentVolumeClaim:
+		// The spec of a claim is immutable once it has been created, except that its storage request may be
+		// increased to expand the volume.
+		cp := current.(*v1.PersistentVolumeClaim)
+		dp := desired.(*v1.PersistentVolumeClaim)
+		desiredStorage, ok := dp.Spec.Resources.Requests[v1.ResourceStorage]
+		dp.Spec = *cp.Spec.DeepCopy()
+		if ok && desiredStorage.Cmp(cp.Spec.Resources.Requests[v1.ResourceStorage]) > 0 {
+			if dp.Spec.Resources.Requests == nil {
+				dp.Spec.Resources.Requests = v1.ResourceList{}
+			}
+			dp.Spec.Resources.Requests[v1.ResourceStorage] = desiredStorage
+		}
+		return dp
 	case *v1.ServiceAccount:
 		// ServiceAccounts generate a new token if we don't include the existing one.
 		csa := current.(*v1.ServiceAccount)
@@ -589,6 +1184,20 @@ func setImagePullPolicy(podSpec *v1.PodSpec) {
 	}
 }
 
+// configureTelemetry points the Tigera containers of obj at the OTLP endpoint. The containers read the OTLP secret
+// from their own namespace, so a mirror of it is applied there first; the secret mirror controller keeps it in sync.
+func (c componentHandler) configureTelemetry(ctx context.Context, obj client.Object, install *installationReader) error {
+	configured := false
+	modifyPodSpec(obj, func(podSpec *v1.PodSpec) {
+		configured = telemetry.ConfigureComponent(podSpec) || configured
+	})
+	s := telemetry.ComponentSecret()
+	if !configured || s == nil || obj.GetNamespace() == s.Namespace {
+		return nil
+	}
+	return c.createOrUpdateObject(ctx, secret.MirrorToNamespace(obj.GetNamespace(), s)[0], rmeta.OSTypeAny, install)
+}
+
 // ensureOSSchedulingRestrictions ensures that if obj is a type that creates pods and if osType is not OSTypeAny that a
 // node selector is set on the pod template for the "kubernetes.io/os" label to ensure that the pod is scheduled
 // on a node running an operating system as specified by osType.
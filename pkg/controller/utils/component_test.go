@@ -17,6 +17,7 @@ package utils
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 
@@ -31,12 +32,16 @@ import (
 	apps "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	restMeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	kfake "k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
@@ -46,8 +51,11 @@ import (
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/controller/status"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
 )
 
 const (
@@ -134,6 +142,119 @@ var _ = Describe("Component handler tests", func() {
 		Expect(ds.OwnerReferences[0]).To(Equal(expectOR))
 	})
 
+	It("does not change the objects of the component while the reconciliation of the Custom Resource is paused", func() {
+		fc := &fakeComponent{
+			supportedOSType: rmeta.OSTypeLinux,
+			objs: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: "default"},
+				Data:       map[string]string{"key": "value"},
+			}},
+		}
+		instance.Annotations = map[string]string{common.ReconcileAnnotation: common.ReconcilePaused}
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "test-cm", Namespace: "default"}, cm)).NotTo(Succeed())
+
+		By("creating the objects once the reconciliation is resumed")
+		delete(instance.Annotations, common.ReconcileAnnotation)
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "test-cm", Namespace: "default"}, cm)).To(Succeed())
+		Expect(cm.Data).To(Equal(map[string]string{"key": "value"}))
+	})
+
+	It("applies the objects in stages, with the prerequisites first and the workloads last", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+		tier := &v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "tier"}}
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa", Namespace: "ns"}}
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}
+		dep := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "dep", Namespace: "ns"}}
+		ds := &apps.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "ns"}}
+
+		Expect(applyStages([]client.Object{dep, sa, ns, ds, cm, tier})).To(Equal([][]client.Object{
+			{ns, tier},
+			{sa, cm},
+			{dep, ds},
+		}))
+		Expect(applyStages([]client.Object{sa, cm})).To(Equal([][]client.Object{{sa, cm}}))
+		Expect(applyStages(nil)).To(BeEmpty())
+	})
+
+	It("applies the other objects of a stage when one fails, and reports each failure", func() {
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if strings.HasPrefix(obj.GetName(), "bad") {
+					return fmt.Errorf("rejected")
+				}
+				return cli.Create(ctx, obj, opts...)
+			},
+		}).Build()
+		handler = NewComponentHandler(log, c, scheme, instance)
+
+		var objs []client.Object
+		for _, name := range []string{"bad-1", "good-1", "bad-2", "good-2"} {
+			objs = append(objs, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+		}
+		objs = append(objs, &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "dep", Namespace: "default"}})
+
+		err := handler.CreateOrUpdateOrDelete(ctx, &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: objs}, sm)
+		Expect(err).To(MatchError(ContainSubstring("failed to create or update ConfigMap default/bad-1: rejected")))
+		Expect(err).To(MatchError(ContainSubstring("failed to create or update ConfigMap default/bad-2: rejected")))
+
+		for _, name := range []string{"good-1", "good-2"} {
+			Expect(c.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, &corev1.ConfigMap{})).To(Succeed())
+		}
+		By("not applying the workloads after a stage failed")
+		Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKey{Name: "dep", Namespace: "default"}, &apps.Deployment{}))).To(BeTrue())
+	})
+
+	It("skips updating the objects that are unchanged since they were last applied", func() {
+		var updates int
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				updates++
+				return cli.Update(ctx, obj, opts...)
+			},
+		}).Build()
+		appliedObjects = newApplyCache()
+		handler = NewComponentHandler(log, c, scheme, instance)
+
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: "default"},
+			Data:       map[string]string{"key": "value"},
+		}
+		fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{cm}}
+		key := client.ObjectKeyFromObject(cm)
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		Expect(NewComponentHandler(log, c, scheme, instance).CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		Expect(updates).To(Equal(0))
+
+		By("updating the object when the component renders it differently")
+		cm.Data["key"] = "changed"
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		Expect(updates).To(Equal(1))
+		stored := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, key, stored)).To(Succeed())
+		Expect(stored.Data).To(Equal(map[string]string{"key": "changed"}))
+
+		By("updating the object when it is changed by someone else")
+		stored.Data["key"] = "edited"
+		Expect(c.Update(ctx, stored)).To(Succeed())
+		updates = 0
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		Expect(updates).To(Equal(1))
+		Expect(c.Get(ctx, key, stored)).To(Succeed())
+		Expect(stored.Data).To(Equal(map[string]string{"key": "changed"}))
+
+		By("creating the object again when it is deleted")
+		Expect(c.Delete(ctx, stored)).To(Succeed())
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(Succeed())
+		Expect(c.Get(ctx, key, stored)).To(Succeed())
+		Expect(stored.Data).To(Equal(map[string]string{"key": "changed"}))
+	})
+
 	It("merges daemonset template annotations and reconciles only operator added annotations", func() {
 		fc := &fakeComponent{
 			supportedOSType: rmeta.OSTypeLinux,
@@ -425,7 +546,7 @@ var _ = Describe("Component handler tests", func() {
 		ns := &corev1.Namespace{}
 		err = c.Get(ctx, nsKey, ns)
 		Expect(err).To(BeNil())
-		Expect(ns.GetLabels()).To(Equal(expectedLabels))
+		Expect(ns.GetLabels()).To(Equal(withOwnershipLabels(expectedLabels)))
 
 		By("ovewriting the namespace with extra label")
 		labels := map[string]string{
@@ -472,7 +593,7 @@ var _ = Describe("Component handler tests", func() {
 		ns = &corev1.Namespace{}
 		err = c.Get(ctx, nsKey, ns)
 		Expect(err).To(BeNil())
-		Expect(ns.GetLabels()).To(Equal(expectedLabels))
+		Expect(ns.GetLabels()).To(Equal(withOwnershipLabels(expectedLabels)))
 
 		By("changing a desired label")
 		labels = map[string]string{
@@ -525,7 +646,7 @@ var _ = Describe("Component handler tests", func() {
 		ns = &corev1.Namespace{}
 		err = c.Get(ctx, nsKey, ns)
 		Expect(err).To(BeNil())
-		Expect(ns.GetLabels()).To(Equal(expectedLabels))
+		Expect(ns.GetLabels()).To(Equal(withOwnershipLabels(expectedLabels)))
 	})
 
 	DescribeTable("ensuring ImagePullPolicy is set", func(obj client.Object) {
@@ -1050,9 +1171,9 @@ var _ = Describe("Component handler tests", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(c.Get(ctx, client.ObjectKey{Name: "my-service"}, svcWithIP)).NotTo(HaveOccurred())
 		Expect(svcWithIP.Spec.ClusterIP).To(Equal("10.96.0.1"))
-		Expect(svcWithIP.Labels).To(Equal(map[string]string{
+		Expect(svcWithIP.Labels).To(Equal(withOwnershipLabels(map[string]string{
 			"old": "should-be-preserved",
-		}))
+		})))
 
 		// Now pretend we're the new operator version, wanting to remove the cluster IP.
 		svcNoIP := &corev1.Service{
@@ -1076,10 +1197,10 @@ var _ = Describe("Component handler tests", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(c.Get(ctx, client.ObjectKey{Name: "my-service"}, svcNoIP)).NotTo(HaveOccurred())
 		Expect(svcNoIP.Spec.ClusterIP).To(Equal("None"))
-		Expect(svcNoIP.Labels).To(Equal(map[string]string{
+		Expect(svcNoIP.Labels).To(Equal(withOwnershipLabels(map[string]string{
 			"old": "should-be-preserved",
 			"new": "should-be-added",
-		}))
+		})))
 
 		// The fake client resets the resource version to 1 on create.
 		Expect(svcNoIP.ObjectMeta.ResourceVersion).To(Equal("1"),
@@ -1090,11 +1211,11 @@ var _ = Describe("Component handler tests", func() {
 		err = handler.CreateOrUpdateOrDelete(ctx, fc, sm)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(c.Get(ctx, client.ObjectKey{Name: "my-service"}, svcNoIP)).NotTo(HaveOccurred())
-		Expect(svcNoIP.Labels).To(Equal(map[string]string{
+		Expect(svcNoIP.Labels).To(Equal(withOwnershipLabels(map[string]string{
 			"old":   "should-be-preserved",
 			"new":   "should-be-added",
 			"newer": "should-be-added",
-		}))
+		})))
 		Expect(svcNoIP.ObjectMeta.ResourceVersion).To(Equal("2"),
 			"Expected update to rev ResourceVersion")
 	})
@@ -1597,7 +1718,7 @@ var _ = Describe("Component handler tests", func() {
 			}
 			d := &apps.Deployment{}
 			Expect(c.Get(ctx, key, d)).NotTo(HaveOccurred())
-			Expect(d.GetLabels()).To(Equal(expectedLabels))
+			Expect(d.GetLabels()).To(Equal(withOwnershipLabels(expectedLabels)))
 			Expect(d.Spec.Template.GetLabels()).To(Equal(expectedLabels))
 			Expect(*d.Spec.Selector).To(Equal(expectedSelector))
 		})
@@ -1636,7 +1757,7 @@ var _ = Describe("Component handler tests", func() {
 			}
 			d := &apps.Deployment{}
 			Expect(c.Get(ctx, key, d)).To(BeNil())
-			Expect(d.GetLabels()).To(Equal(expectedLabels))
+			Expect(d.GetLabels()).To(Equal(withOwnershipLabels(expectedLabels)))
 			Expect(d.Spec.Template.GetLabels()).To(Equal(expectedLabels))
 			Expect(*d.Spec.Selector).To(Equal(expectedSelector))
 		})
@@ -1669,6 +1790,645 @@ var _ = Describe("Component handler tests", func() {
 			Expect(sa.ImagePullSecrets).To(HaveLen(1))
 		})
 	})
+	Context("persistent volume claim updates", func() {
+		It("only allows the storage request of a claim to grow", func() {
+			class := "standard"
+			pvc := &corev1.PersistentVolumeClaim{
+				TypeMeta:   metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "a"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: &class,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+					},
+				},
+			}
+			Expect(c.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a"}})).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, pvc.DeepCopy())).NotTo(HaveOccurred())
+
+			update := func(class, size string) *corev1.PersistentVolumeClaim {
+				desired := pvc.DeepCopy()
+				desired.Spec.StorageClassName = &class
+				desired.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse(size)
+				fc := &fakeComponent{
+					supportedOSType: rmeta.OSTypeLinux,
+					objs:            []client.Object{desired},
+				}
+				Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+				current := &corev1.PersistentVolumeClaim{}
+				Expect(c.Get(ctx, client.ObjectKey{Name: "a", Namespace: "a"}, current)).NotTo(HaveOccurred())
+				return current
+			}
+
+			current := update("premium", "5Gi")
+			Expect(*current.Spec.StorageClassName).To(Equal("standard"))
+			Expect(current.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("10Gi")))
+
+			current = update("standard", "20Gi")
+			Expect(current.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("20Gi")))
+		})
+	})
+	Context("mounted secrets and config maps", func() {
+		var deployment *apps.Deployment
+		var renderedSecret *corev1.Secret
+
+		BeforeEach(func() {
+			deployment = &apps.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "test-namespace"},
+				Spec: apps.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Volumes: []corev1.Volume{
+								{Name: "rendered", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "rendered-secret"}}},
+								{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "external-config"},
+								}}},
+							},
+							Containers: []corev1.Container{{
+								Name: "test",
+								Env: []corev1.EnvVar{{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "missing-secret"},
+									Key:                  "token",
+								}}}},
+							}},
+						},
+					},
+				},
+			}
+			renderedSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "rendered-secret", Namespace: "test-namespace"},
+				Data:       map[string][]byte{"key": []byte("a")},
+			}
+			Expect(c.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "external-config", Namespace: "test-namespace"},
+				Data:       map[string]string{"config": "a"},
+			})).NotTo(HaveOccurred())
+		})
+
+		getHash := func() string {
+			d := &apps.Deployment{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(deployment), d)).NotTo(HaveOccurred())
+			return d.Spec.Template.Annotations[rmeta.MountedObjectsHashAnnotation]
+		}
+
+		It("annotates the pod template with a hash of the rendered and existing objects", func() {
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{renderedSecret, deployment}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "external-config", Namespace: "test-namespace"}, cm)).NotTo(HaveOccurred())
+			Expect(getHash()).To(Equal(rmeta.MountedObjectsHash([]*corev1.Secret{renderedSecret}, []*corev1.ConfigMap{cm})))
+
+			// The component's objects are not modified.
+			Expect(deployment.Spec.Template.Annotations).To(BeNil())
+		})
+
+		It("changes the hash when a rendered secret changes", func() {
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{renderedSecret, deployment}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			before := getHash()
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(getHash()).To(Equal(before))
+
+			renderedSecret.Data["key"] = []byte("b")
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(getHash()).NotTo(Equal(before))
+		})
+
+		It("does not update a secret whose content has not changed", func() {
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{renderedSecret, deployment}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			current := &corev1.Secret{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(renderedSecret), current)).NotTo(HaveOccurred())
+			resourceVersion := current.ResourceVersion
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(renderedSecret), current)).NotTo(HaveOccurred())
+			Expect(current.ResourceVersion).To(Equal(resourceVersion))
+
+			renderedSecret.Data["key"] = []byte("b")
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(renderedSecret), current)).NotTo(HaveOccurred())
+			Expect(current.ResourceVersion).NotTo(Equal(resourceVersion))
+			Expect(current.Data).To(Equal(renderedSecret.Data))
+		})
+
+		It("changes the hash when an existing config map changes", func() {
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{renderedSecret, deployment}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			before := getHash()
+
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "external-config", Namespace: "test-namespace"}, cm)).NotTo(HaveOccurred())
+			cm.Data["config"] = "b"
+			Expect(c.Update(ctx, cm)).NotTo(HaveOccurred())
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(getHash()).NotTo(Equal(before))
+		})
+
+		It("does not annotate jobs", func() {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "test-namespace"},
+				Spec:       batchv1.JobSpec{Template: deployment.Spec.Template},
+			}
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{job}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(job), job)).NotTo(HaveOccurred())
+			Expect(job.Spec.Template.Annotations).NotTo(HaveKey(rmeta.MountedObjectsHashAnnotation))
+		})
+	})
+
+	Context("staged rollouts", func() {
+		var fc *fakeComponent
+
+		BeforeEach(func() {
+			fc = &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{&apps.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-ds", Namespace: "default"},
+					Spec: apps.DaemonSetSpec{
+						UpdateStrategy: apps.DaemonSetUpdateStrategy{Type: apps.RollingUpdateDaemonSetStrategyType},
+					},
+				}},
+			}
+		})
+
+		It("leaves the update strategy of DaemonSets alone without a rollout policy", func() {
+			Expect(c.Create(ctx, &operatorv1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			ds := &apps.DaemonSet{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "test-ds", Namespace: "default"}, ds)).NotTo(HaveOccurred())
+			Expect(ds.Spec.UpdateStrategy.Type).To(Equal(apps.RollingUpdateDaemonSetStrategyType))
+			Expect(ds.Annotations).NotTo(HaveKey(common.StagedRolloutAnnotation))
+		})
+
+		It("switches DaemonSets to the OnDelete strategy with a rollout policy", func() {
+			Expect(c.Create(ctx, &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec: operatorv1.InstallationSpec{
+					RolloutPolicy: &operatorv1.RolloutPolicy{Waves: []operatorv1.RolloutWave{{Percentage: ptr.Int32ToPtr(10)}}},
+				},
+			})).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			ds := &apps.DaemonSet{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "test-ds", Namespace: "default"}, ds)).NotTo(HaveOccurred())
+			Expect(ds.Spec.UpdateStrategy).To(Equal(apps.DaemonSetUpdateStrategy{Type: apps.OnDeleteDaemonSetStrategyType}))
+			Expect(ds.Annotations).To(HaveKeyWithValue(common.StagedRolloutAnnotation, "true"))
+		})
+
+		It("only starts a new staged rollout when the pod template changes", func() {
+			Expect(c.Create(ctx, &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec: operatorv1.InstallationSpec{
+					RolloutPolicy: &operatorv1.RolloutPolicy{Waves: []operatorv1.RolloutWave{{Percentage: ptr.Int32ToPtr(10)}}},
+				},
+			})).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			// The rollout controller ends the staged rollout once all pods are updated.
+			ds := &apps.DaemonSet{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "test-ds", Namespace: "default"}, ds)).NotTo(HaveOccurred())
+			delete(ds.Annotations, common.StagedRolloutAnnotation)
+			Expect(c.Update(ctx, ds)).NotTo(HaveOccurred())
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{Name: "test-ds", Namespace: "default"}, ds)).NotTo(HaveOccurred())
+			Expect(ds.Annotations).NotTo(HaveKey(common.StagedRolloutAnnotation))
+
+			fc.objs[0].(*apps.DaemonSet).Spec.Template.Spec.Containers = []corev1.Container{{Name: "test", Image: "test:v2"}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{Name: "test-ds", Namespace: "default"}, ds)).NotTo(HaveOccurred())
+			Expect(ds.Annotations).To(HaveKeyWithValue(common.StagedRolloutAnnotation, "true"))
+		})
+	})
+
+	Context("rollbacks", func() {
+		var fc *fakeComponent
+		key := client.ObjectKey{Name: "test-deployment", Namespace: "default"}
+
+		renderDeployment := func(image string) {
+			fc = &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{&apps.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec: apps.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: image}}},
+						},
+					},
+				}},
+			}
+		}
+
+		getDeployment := func() *apps.Deployment {
+			d := &apps.Deployment{}
+			Expect(c.Get(ctx, key, d)).NotTo(HaveOccurred())
+			return d
+		}
+
+		BeforeEach(func() {
+			Expect(c.Create(ctx, &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       operatorv1.InstallationSpec{RollbackPolicy: &operatorv1.RollbackPolicy{}},
+			})).NotTo(HaveOccurred())
+		})
+
+		It("records the rendered pod template and when its rollout started", func() {
+			renderDeployment("test:v1")
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			d := getDeployment()
+			hash := d.Annotations[common.RenderedTemplateHashAnnotation]
+			Expect(hash).NotTo(BeEmpty())
+			Expect(d.Annotations).To(HaveKey(common.RolloutStartedAnnotation))
+
+			// The rollout start time is kept while the same pod template is rendered.
+			d.Annotations[common.RolloutStartedAnnotation] = "2024-01-01T00:00:00Z"
+			Expect(c.Update(ctx, d)).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(getDeployment().Annotations).To(HaveKeyWithValue(common.RolloutStartedAnnotation, "2024-01-01T00:00:00Z"))
+
+			renderDeployment("test:v2")
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			d = getDeployment()
+			Expect(d.Annotations[common.RenderedTemplateHashAnnotation]).NotTo(Equal(hash))
+			Expect(d.Annotations[common.RolloutStartedAnnotation]).NotTo(Equal("2024-01-01T00:00:00Z"))
+		})
+
+		It("keeps a rolled back workload on its last-known-good pod template until a new one is rendered", func() {
+			renderDeployment("test:v1")
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			lastKnownGood := getDeployment().Spec.Template
+
+			renderDeployment("test:v2")
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			d := getDeployment()
+			Expect(d.Spec.Template.Spec.Containers[0].Image).To(Equal("test:v2"))
+
+			// Roll back the way the rollout controller does.
+			Expect(SetLastKnownGoodTemplate(ctx, c, d, &lastKnownGood)).NotTo(HaveOccurred())
+			d.Annotations[common.RolledBackFromAnnotation] = d.Annotations[common.RenderedTemplateHashAnnotation]
+			d.Spec.Template.Spec.Containers[0].Image = "test:v1"
+			Expect(c.Update(ctx, d)).NotTo(HaveOccurred())
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(getDeployment().Spec.Template.Spec.Containers[0].Image).To(Equal("test:v1"))
+
+			renderDeployment("test:v3")
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(getDeployment().Spec.Template.Spec.Containers[0].Image).To(Equal("test:v3"))
+		})
+
+		It("does not annotate workloads without a rollback policy", func() {
+			install := &operatorv1.Installation{}
+			Expect(c.Get(ctx, DefaultInstanceKey, install)).NotTo(HaveOccurred())
+			install.Spec.RollbackPolicy = nil
+			Expect(c.Update(ctx, install)).NotTo(HaveOccurred())
+
+			renderDeployment("test:v1")
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(getDeployment().Annotations).NotTo(HaveKey(common.RenderedTemplateHashAnnotation))
+		})
+	})
+
+	Context("resource adoption", func() {
+		key := client.ObjectKey{Name: "calico-config", Namespace: "default"}
+		fc := &fakeComponent{
+			supportedOSType: rmeta.OSTypeLinux,
+			objs: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Data:       map[string]string{"veth_mtu": "1440"},
+			}},
+		}
+
+		setResourceAdoption := func(adoption operatorv1.ResourceAdoption) {
+			Expect(c.Create(ctx, &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       operatorv1.InstallationSpec{ResourceAdoption: &adoption},
+			})).NotTo(HaveOccurred())
+		}
+
+		getConfigMap := func() *corev1.ConfigMap {
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, key, cm)).NotTo(HaveOccurred())
+			return cm
+		}
+
+		BeforeEach(func() {
+			// A ConfigMap created by the Calico manifests.
+			Expect(c.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Labels: map[string]string{"app": "calico"}},
+				Data:       map[string]string{"veth_mtu": "0", "typha_service_name": "none"},
+			})).NotTo(HaveOccurred())
+		})
+
+		It("adopts existing objects when enabled", func() {
+			setResourceAdoption(operatorv1.ResourceAdoptionEnabled)
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			cm := getConfigMap()
+			Expect(cm.Labels).To(HaveKeyWithValue(common.ManagedByLabel, common.ManagedByValue))
+			Expect(cm.Labels).To(HaveKeyWithValue("app", "calico"))
+			Expect(cm.Data).To(HaveKeyWithValue("veth_mtu", "1440"))
+			Expect(cm.OwnerReferences).To(HaveLen(1))
+			Expect(cm.OwnerReferences[0].Name).To(Equal(instance.Name))
+
+			// Adopted objects are updated as usual.
+			fc.objs[0].(*corev1.ConfigMap).Data["veth_mtu"] = "1450"
+			defer func() { fc.objs[0].(*corev1.ConfigMap).Data["veth_mtu"] = "1440" }()
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			cm = getConfigMap()
+			Expect(cm.Data).To(HaveKeyWithValue("veth_mtu", "1450"))
+			Expect(cm.Labels).To(HaveKeyWithValue(common.ManagedByLabel, common.ManagedByValue))
+		})
+
+		It("updates existing objects without adopting them when disabled", func() {
+			setResourceAdoption(operatorv1.ResourceAdoptionDisabled)
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			cm := getConfigMap()
+			Expect(cm.Labels).NotTo(HaveKey(common.ManagedByLabel))
+			Expect(cm.Data).To(HaveKeyWithValue("veth_mtu", "1440"))
+		})
+
+		It("does not adopt objects that the user asked to ignore", func() {
+			setResourceAdoption(operatorv1.ResourceAdoptionEnabled)
+			cm := getConfigMap()
+			cm.Annotations = map[string]string{unsupportedIgnoreAnnotation: "true"}
+			Expect(c.Update(ctx, cm)).NotTo(HaveOccurred())
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			cm = getConfigMap()
+			Expect(cm.Labels).NotTo(HaveKey(common.ManagedByLabel))
+			Expect(cm.Data).To(HaveKeyWithValue("veth_mtu", "0"))
+		})
+	})
+
+	Context("ownership labels and keep-on-delete", func() {
+		key := client.ObjectKey{Name: "owned", Namespace: "default"}
+		configMap := func(labels map[string]string) *corev1.ConfigMap {
+			return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Labels: labels}}
+		}
+		getConfigMap := func() *corev1.ConfigMap {
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, key, cm)).NotTo(HaveOccurred())
+			return cm
+		}
+
+		BeforeEach(func() {
+			instance.UID = "manager-uid"
+		})
+
+		It("labels rendered objects with their component, owner and operator version", func() {
+			Expect(handler.CreateOrUpdateOrDelete(ctx, &fakeComponent{objs: []client.Object{configMap(nil)}}, sm)).NotTo(HaveOccurred())
+			Expect(getConfigMap().Labels).To(Equal(withOwnershipLabels(nil)))
+		})
+
+		It("only sets the version label on objects with multiple owners", func() {
+			cm := configMap(map[string]string{common.MultipleOwnersLabel: "true"})
+			Expect(handler.CreateOrUpdateOrDelete(ctx, &fakeComponent{objs: []client.Object{cm}}, sm)).NotTo(HaveOccurred())
+			Expect(getConfigMap().Labels).To(Equal(map[string]string{common.OperatorVersionLabel: "unknown"}))
+		})
+
+		It("doesn't set the owner labels without an owner", func() {
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, nil)
+			Expect(handler.CreateOrUpdateOrDelete(ctx, &fakeComponent{objs: []client.Object{configMap(nil)}}, sm)).NotTo(HaveOccurred())
+			Expect(getConfigMap().Labels).To(Equal(map[string]string{
+				common.ComponentLabel:       "fakeComponent",
+				common.OperatorVersionLabel: "unknown",
+			}))
+		})
+
+		It("orphans and keeps objects annotated to be kept on delete", func() {
+			Expect(handler.CreateOrUpdateOrDelete(ctx, &fakeComponent{objs: []client.Object{configMap(nil)}}, sm)).NotTo(HaveOccurred())
+			cm := getConfigMap()
+			Expect(cm.OwnerReferences).To(HaveLen(1))
+
+			cm.Annotations = map[string]string{common.KeepOnDeleteAnnotation: "true"}
+			Expect(c.Update(ctx, cm)).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, &fakeComponent{objs: []client.Object{configMap(nil)}}, sm)).NotTo(HaveOccurred())
+			Expect(getConfigMap().OwnerReferences).To(BeEmpty())
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, render.NewDeletionPassthrough(configMap(nil)), sm)).NotTo(HaveOccurred())
+			Expect(getConfigMap().Annotations).To(HaveKeyWithValue(common.KeepOnDeleteAnnotation, "true"))
+		})
+
+		It("orphans objects that are annotated to be kept when they are deleted", func() {
+			Expect(handler.CreateOrUpdateOrDelete(ctx, &fakeComponent{objs: []client.Object{configMap(nil)}}, sm)).NotTo(HaveOccurred())
+			cm := getConfigMap()
+			cm.Annotations = map[string]string{common.KeepOnDeleteAnnotation: "true"}
+			Expect(c.Update(ctx, cm)).NotTo(HaveOccurred())
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, render.NewDeletionPassthrough(configMap(nil)), sm)).NotTo(HaveOccurred())
+			Expect(getConfigMap().OwnerReferences).To(BeEmpty())
+		})
+
+		It("deletes objects that aren't annotated to be kept", func() {
+			Expect(handler.CreateOrUpdateOrDelete(ctx, &fakeComponent{objs: []client.Object{configMap(nil)}}, sm)).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, render.NewDeletionPassthrough(configMap(nil)), sm)).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(c.Get(ctx, key, &corev1.ConfigMap{}))).To(BeTrue())
+		})
+
+		DescribeTable("converts strings into label values",
+			func(s, expected string) {
+				Expect(labelValue(s)).To(Equal(expected))
+			},
+			Entry("valid", "v1.36.0-0.dev-123-gabcdef", "v1.36.0-0.dev-123-gabcdef"),
+			Entry("invalid characters", "v1.36.0+dirty", "v1.36.0-dirty"),
+			Entry("invalid ends", "-v1.36.0/", "v1.36.0"),
+			Entry("too long", strings.Repeat("a", 70), strings.Repeat("a", 63)),
+		)
+	})
+
+	Context("post-render mutators", func() {
+		key := client.ObjectKey{Name: "mutated", Namespace: "default"}
+		var fc *fakeComponent
+
+		BeforeEach(func() {
+			fc = &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				}},
+			}
+		})
+
+		AfterEach(func() {
+			render.UnregisterObjectMutator("test-mutator")
+		})
+
+		It("applies the registered mutators to the created objects", func() {
+			render.RegisterObjectMutator("test-mutator", func(component render.Component, obj client.Object) error {
+				Expect(component).To(BeIdenticalTo(fc))
+				obj.SetLabels(map[string]string{"injected": "true"})
+				return nil
+			})
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, key, cm)).NotTo(HaveOccurred())
+			Expect(cm.Labels).To(HaveKeyWithValue("injected", "true"))
+			Expect(fc.objs[0].GetLabels()).To(BeNil())
+		})
+
+		It("returns the error of a failed mutator without creating the objects", func() {
+			render.RegisterObjectMutator("test-mutator", func(render.Component, client.Object) error {
+				return fmt.Errorf("boom")
+			})
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).To(MatchError(ContainSubstring("boom")))
+			Expect(errors.IsNotFound(c.Get(ctx, key, &corev1.ConfigMap{}))).To(BeTrue())
+		})
+	})
+
+	Context("optional APIs", func() {
+		var fc *fakeComponent
+		cmKey := client.ObjectKey{Name: "served", Namespace: "default"}
+
+		BeforeEach(func() {
+			discovery := kfake.NewSimpleClientset().Discovery()
+			handler.(*componentHandler).capabilities = render.NewCapabilities(discovery)
+			fc = &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{
+					&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cmKey.Name, Namespace: cmKey.Namespace}},
+					securitycontextconstraints.NewNonRootSecurityContextConstraints("optional", nil),
+				},
+			}
+		})
+
+		It("skips the objects of the optional APIs that the cluster doesn't serve", func() {
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, cmKey, &corev1.ConfigMap{})).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKey{Name: "optional"}, &ocsv1.SecurityContextConstraints{}))).To(BeTrue())
+		})
+
+		It("fails the components that require the optional APIs that the cluster doesn't serve", func() {
+			err := handler.CreateOrUpdateOrDelete(ctx, &requiresOptionalAPIs{fc}, sm)
+			Expect(err).To(MatchError("the cluster does not serve the APIs of the component: security.openshift.io/v1 SecurityContextConstraints"))
+			Expect(errors.IsNotFound(c.Get(ctx, cmKey, &corev1.ConfigMap{}))).To(BeTrue())
+		})
+	})
+
+	Context("network policy overrides", func() {
+		var fc *fakeComponent
+		renderedRule := v3.Rule{Action: v3.Allow, Destination: v3.EntityRule{Selector: "k8s-app == 'rendered'"}}
+		passRule := v3.Rule{Action: v3.Pass}
+		scrapeRule := v3.Rule{Action: v3.Allow, Source: v3.EntityRule{Nets: []string{"10.0.0.0/24"}}}
+		forwardRule := v3.Rule{Action: v3.Allow, Destination: v3.EntityRule{Nets: []string{"10.0.1.0/24"}}}
+
+		BeforeEach(func() {
+			fc = &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{
+					&v3.NetworkPolicy{
+						ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera.es-metrics", Namespace: "tigera-elasticsearch"},
+						Spec: v3.NetworkPolicySpec{
+							Tier:   "allow-tigera",
+							Types:  []v3.PolicyType{v3.PolicyTypeEgress},
+							Egress: []v3.Rule{renderedRule, passRule},
+						},
+					},
+					&v3.NetworkPolicy{
+						ObjectMeta: metav1.ObjectMeta{Name: "default.es-metrics", Namespace: "tigera-elasticsearch"},
+						Spec:       v3.NetworkPolicySpec{Tier: "default"},
+					},
+				},
+			}
+		})
+
+		It("adds the rules of the overrides of the policy before the trailing Pass rule", func() {
+			Expect(c.Create(ctx, &operatorv1.NetworkPolicyOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "b-forward", Namespace: "tigera-elasticsearch"},
+				Spec:       operatorv1.NetworkPolicyOverrideSpec{PolicyName: "allow-tigera.es-metrics", Egress: []v3.Rule{forwardRule}},
+			})).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &operatorv1.NetworkPolicyOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "a-scrape", Namespace: "tigera-elasticsearch"},
+				Spec:       operatorv1.NetworkPolicyOverrideSpec{PolicyName: "allow-tigera.es-metrics", Ingress: []v3.Rule{scrapeRule}, Egress: []v3.Rule{scrapeRule}},
+			})).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &operatorv1.NetworkPolicyOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "default"},
+				Spec:       operatorv1.NetworkPolicyOverrideSpec{PolicyName: "allow-tigera.es-metrics", Egress: []v3.Rule{forwardRule}},
+			})).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &operatorv1.NetworkPolicyOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-tier", Namespace: "tigera-elasticsearch"},
+				Spec:       operatorv1.NetworkPolicyOverrideSpec{PolicyName: "default.es-metrics", Egress: []v3.Rule{forwardRule}},
+			})).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			policy := &v3.NetworkPolicy{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "allow-tigera.es-metrics", Namespace: "tigera-elasticsearch"}, policy)).NotTo(HaveOccurred())
+			// The policy only applies to egress, so the ingress rules are ignored.
+			Expect(policy.Spec.Types).To(ConsistOf(v3.PolicyTypeEgress))
+			Expect(policy.Spec.Ingress).To(BeEmpty())
+			Expect(policy.Spec.Egress).To(Equal([]v3.Rule{renderedRule, scrapeRule, forwardRule, passRule}))
+
+			// Only policies in the allow-tigera tier are extended.
+			Expect(c.Get(ctx, client.ObjectKey{Name: "default.es-metrics", Namespace: "tigera-elasticsearch"}, policy)).NotTo(HaveOccurred())
+			Expect(policy.Spec.Egress).To(BeEmpty())
+		})
+
+		It("does not modify the rendered policy", func() {
+			Expect(c.Create(ctx, &operatorv1.NetworkPolicyOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "scrape", Namespace: "tigera-elasticsearch"},
+				Spec:       operatorv1.NetworkPolicyOverrideSpec{PolicyName: "allow-tigera.es-metrics", Egress: []v3.Rule{scrapeRule}},
+			})).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(fc.objs[0].(*v3.NetworkPolicy).Spec.Egress).To(Equal([]v3.Rule{renderedRule, passRule}))
+		})
+	})
+
+	Context("namespace default-deny", func() {
+		var fc *fakeComponent
+		key := client.ObjectKey{Name: networkpolicy.NamespaceDefaultDenyPolicyName, Namespace: "tigera-elasticsearch"}
+
+		BeforeEach(func() {
+			fc = &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{
+					networkpolicy.AllowTigeraDefaultDeny("tigera-elasticsearch"),
+				},
+			}
+		})
+
+		It("renders a default-deny policy in the namespace when enabled", func() {
+			Expect(c.Create(ctx, &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       operatorv1.InstallationSpec{NamespaceDefaultDeny: ptr.ToPtr(operatorv1.NamespaceDefaultDenyEnabled)},
+			})).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			policy := &netv1.NetworkPolicy{}
+			Expect(c.Get(ctx, key, policy)).NotTo(HaveOccurred())
+			Expect(policy.Spec.PodSelector).To(Equal(metav1.LabelSelector{}))
+			Expect(policy.Spec.PolicyTypes).To(ConsistOf(netv1.PolicyTypeIngress, netv1.PolicyTypeEgress))
+			Expect(policy.Spec.Ingress).To(BeEmpty())
+			Expect(policy.Spec.Egress).To(BeEmpty())
+		})
+
+		It("removes the default-deny policy when disabled", func() {
+			installation := &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       operatorv1.InstallationSpec{NamespaceDefaultDeny: ptr.ToPtr(operatorv1.NamespaceDefaultDenyEnabled)},
+			}
+			Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, key, &netv1.NetworkPolicy{})).NotTo(HaveOccurred())
+
+			installation.Spec.NamespaceDefaultDeny = nil
+			Expect(c.Update(ctx, installation)).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(c.Get(ctx, key, &netv1.NetworkPolicy{}))).To(BeTrue())
+		})
+
+		It("does not render a default-deny policy without an Installation", func() {
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(c.Get(ctx, key, &netv1.NetworkPolicy{}))).To(BeTrue())
+		})
+	})
 })
 
 var _ = Describe("Mocked client Component handler tests", func() {
@@ -1687,6 +2447,8 @@ var _ = Describe("Mocked client Component handler tests", func() {
 		c = &mc
 		ctx = context.Background()
 
+		// The mocked client returns the same objects in every test, so start each with no record of applying them.
+		appliedObjects = newApplyCache()
 		handler = NewComponentHandler(log, c, runtime.NewScheme(), nil)
 	})
 
@@ -1716,6 +2478,11 @@ var _ = Describe("Mocked client Component handler tests", func() {
 		}
 
 		It("if Updating a resource conflicts try the update again", func() {
+			// The Installation is read for its rollout and rollback policies since the component has a DaemonSet.
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				Return: errors.NewNotFound(schema.GroupResource{}, "default"),
+			})
 			mc.Info = append(mc.Info, mockReturn{
 				Method:       "Get",
 				Return:       nil,
@@ -1739,10 +2506,15 @@ var _ = Describe("Mocked client Component handler tests", func() {
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
 
-			Expect(mc.Index).To(Equal(4))
+			Expect(mc.Index).To(Equal(5))
 		})
 
 		It("if Updating a resource conflicts try the update again", func() {
+			// The Installation is read for its rollout and rollback policies since the component has a DaemonSet.
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				Return: errors.NewNotFound(schema.GroupResource{}, "default"),
+			})
 			mc.Info = append(mc.Info, mockReturn{
 				Method:       "Get",
 				Return:       nil,
@@ -1765,7 +2537,7 @@ var _ = Describe("Mocked client Component handler tests", func() {
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).NotTo(BeNil())
 
-			Expect(mc.Index).To(Equal(4))
+			Expect(mc.Index).To(Equal(5))
 		})
 	})
 
@@ -1800,15 +2572,25 @@ var _ = Describe("Mocked client Component handler tests", func() {
 		}
 
 		It("NetworkPolicy updates are omitted if there is no change", func() {
+			// The NetworkPolicyOverrides are listed since the policy is in the allow-tigera tier.
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "List",
+				Return: nil,
+			})
 			mc.Info = append(mc.Info, mockReturn{
 				Method:       "Get",
 				Return:       nil,
 				InputMutator: setToBaseNP,
 			})
+			// The Installation is read for its ResourceAdoption since the existing object is not managed by the operator.
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				Return: errors.NewNotFound(schema.GroupResource{}, "default"),
+			})
 
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
-			Expect(mc.Index).To(Equal(1))
+			Expect(mc.Index).To(Equal(3))
 		})
 
 		It("NetworkPolicy updates are applied if there is a change", func() {
@@ -1819,11 +2601,20 @@ var _ = Describe("Mocked client Component handler tests", func() {
 				modifiedNP.DeepCopyInto(npToSet)
 			}
 
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "List",
+				Return: nil,
+			})
 			mc.Info = append(mc.Info, mockReturn{
 				Method:       "Get",
 				Return:       nil,
 				InputMutator: setToModifiedNP,
 			})
+			// The Installation is read for its ResourceAdoption since the existing object is not managed by the operator.
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				Return: errors.NewNotFound(schema.GroupResource{}, "default"),
+			})
 
 			mc.Info = append(mc.Info, mockReturn{
 				Method:       "Update",
@@ -1833,7 +2624,7 @@ var _ = Describe("Mocked client Component handler tests", func() {
 
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
-			Expect(mc.Index).To(Equal(2))
+			Expect(mc.Index).To(Equal(4))
 		})
 	})
 
@@ -1859,10 +2650,15 @@ var _ = Describe("Mocked client Component handler tests", func() {
 				Return:       nil,
 				InputMutator: setToBaseTier,
 			})
+			// The Installation is read for its ResourceAdoption since the existing object is not managed by the operator.
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				Return: errors.NewNotFound(schema.GroupResource{}, "default"),
+			})
 
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
-			Expect(mc.Index).To(Equal(1))
+			Expect(mc.Index).To(Equal(2))
 		})
 
 		It("Tier updates are applied if there is a change", func() {
@@ -1879,6 +2675,11 @@ var _ = Describe("Mocked client Component handler tests", func() {
 				Return:       nil,
 				InputMutator: setToModifiedTier,
 			})
+			// The Installation is read for its ResourceAdoption since the existing object is not managed by the operator.
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				Return: errors.NewNotFound(schema.GroupResource{}, "default"),
+			})
 
 			mc.Info = append(mc.Info, mockReturn{
 				Method:       "Update",
@@ -1888,12 +2689,27 @@ var _ = Describe("Mocked client Component handler tests", func() {
 
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
-			Expect(mc.Index).To(Equal(2))
+			Expect(mc.Index).To(Equal(3))
 		})
 	})
 })
 
 // A fake component that only returns ready and always creates the "test-namespace" Namespace.
+// withOwnershipLabels returns the labels together with the ownership labels that the component handler of the tests
+// sets on the objects of a fakeComponent.
+func withOwnershipLabels(labels map[string]string) map[string]string {
+	owned := map[string]string{
+		common.ComponentLabel:       "fakeComponent",
+		common.OwnerKindLabel:       "Manager",
+		common.OwnerNameLabel:       "tigera-secure",
+		common.OperatorVersionLabel: "unknown",
+	}
+	for k, v := range labels {
+		owned[k] = v
+	}
+	return owned
+}
+
 type fakeComponent struct {
 	objs            []client.Object
 	supportedOSType rmeta.OSType
@@ -1915,6 +2731,15 @@ func (c *fakeComponent) SupportedOSType() rmeta.OSType {
 	return c.supportedOSType
 }
 
+// requiresOptionalAPIs is a component that fails when the cluster doesn't serve the optional APIs of its objects.
+type requiresOptionalAPIs struct {
+	*fakeComponent
+}
+
+func (c *requiresOptionalAPIs) MissingAPIPolicy() render.MissingAPIPolicy {
+	return render.MissingAPIFail
+}
+
 type mockReturn struct {
 	Method       string
 	Return       interface{}
@@ -1959,7 +2784,24 @@ func (mc *mockClient) Get(ctx context.Context, key client.ObjectKey, obj client.
 }
 
 func (mc *mockClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-	panic("List not implemented in mockClient")
+	defer func() { mc.Index++ }()
+	funcName := "List"
+	if len(mc.Info) <= mc.Index {
+		panic(fmt.Sprintf("mockClient Info doesn't have enough entries for %s %T", funcName, list))
+	}
+	if mc.Info[mc.Index].Method != funcName {
+		panic(fmt.Sprintf("mockClient current (%d) call is for %v, not %s", mc.Index, mc.Info[mc.Index].Method, funcName))
+	}
+	if mc.Info[mc.Index].Return == nil {
+		return nil
+	}
+
+	v, ok := mc.Info[mc.Index].Return.(error)
+	if !ok {
+		panic(fmt.Sprintf("mockClient Info didn't have right type for entry %d for %s %T", mc.Index, funcName, list))
+	}
+
+	return v
 }
 func (mc *mockClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
 	panic("Create not implemented in mockClient")
@@ -0,0 +1,147 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	gv "github.com/hashicorp/go-version"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/render/logstorage/eck"
+	"github.com/tigera/operator/pkg/render/logstorage/kibana"
+)
+
+// minECKOperatorVersion is the oldest ECK operator that can manage the Elasticsearch and Kibana versions deployed by
+// the operator.
+var minECKOperatorVersion = gv.Must(gv.NewVersion("2.0.0"))
+
+// eckOperatorLabels select the StatefulSets of ECK operators, as labelled by the manifests and Helm chart of ECK.
+var eckOperatorLabels = client.MatchingLabels{"control-plane": "elastic-operator"}
+
+// GetUserManagedECKOperator returns the StatefulSet of the ECK operator installed by the user that manages the
+// Elasticsearch and Kibana namespaces, or nil if there is none. An error is returned if more than one ECK operator
+// manages them, since they would both reconcile the Elasticsearch cluster.
+func GetUserManagedECKOperator(ctx context.Context, cli client.Client) (*appsv1.StatefulSet, error) {
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := cli.List(ctx, statefulSets, eckOperatorLabels); err != nil {
+		return nil, err
+	}
+
+	var found []*appsv1.StatefulSet
+	for i := range statefulSets.Items {
+		sts := &statefulSets.Items[i]
+		if sts.Namespace == eck.OperatorNamespace || !eckOperatorManages(sts, render.ElasticsearchNamespace, kibana.Namespace) {
+			continue
+		}
+		found = append(found, sts)
+	}
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	}
+	var names []string
+	for _, sts := range found {
+		names = append(names, fmt.Sprintf("%s/%s", sts.Namespace, sts.Name))
+	}
+	return nil, fmt.Errorf("found more than one ECK operator managing the %s namespace: %s", render.ElasticsearchNamespace, strings.Join(names, ", "))
+}
+
+// ECKOperatorNamespace returns the namespace of the ECK operator that manages the Elasticsearch cluster: the namespace
+// of the user-managed ECK operator if the LogStorage reuses one, otherwise the namespace of the ECK operator deployed
+// by the operator.
+func ECKOperatorNamespace(ctx context.Context, cli client.Client) (string, error) {
+	ls := &operatorv1.LogStorage{}
+	if err := cli.Get(ctx, DefaultTSEEInstanceKey, ls); err != nil {
+		if errors.IsNotFound(err) {
+			return eck.OperatorNamespace, nil
+		}
+		return "", err
+	}
+	if !ls.UserManagedECKOperator() {
+		return eck.OperatorNamespace, nil
+	}
+	sts, err := GetUserManagedECKOperator(ctx, cli)
+	if err != nil {
+		return "", err
+	}
+	if sts == nil {
+		return eck.OperatorNamespace, nil
+	}
+	return sts.Namespace, nil
+}
+
+// ValidateECKOperatorVersion returns an error if the given version of the ECK operator is too old to manage the
+// Elasticsearch cluster. Versions that aren't valid versions, e.g. development builds, are not validated.
+func ValidateECKOperatorVersion(version string) error {
+	v, err := gv.NewVersion(version)
+	if err != nil {
+		return nil
+	}
+	if v.LessThan(minECKOperatorVersion) {
+		return fmt.Errorf("ECK operator %s is not supported, version %s or later is required", version, minECKOperatorVersion)
+	}
+	return nil
+}
+
+// ECKOperatorVersion returns the version of the ECK operator StatefulSet, taken from the tag of its image.
+func ECKOperatorVersion(sts *appsv1.StatefulSet) string {
+	for _, c := range sts.Spec.Template.Spec.Containers {
+		image := c.Image[strings.LastIndex(c.Image, "/")+1:]
+		image, _, _ = strings.Cut(image, "@")
+		if _, tag, ok := strings.Cut(image, ":"); ok {
+			return tag
+		}
+	}
+	return ""
+}
+
+// eckOperatorManages returns whether the ECK operator manages all the given namespaces. ECK manages all namespaces
+// unless it is started with the --namespaces flag.
+func eckOperatorManages(sts *appsv1.StatefulSet, namespaces ...string) bool {
+	for _, c := range sts.Spec.Template.Spec.Containers {
+		for i, arg := range c.Args {
+			var value string
+			switch {
+			case strings.HasPrefix(arg, "--namespaces="):
+				value = strings.TrimPrefix(arg, "--namespaces=")
+			case arg == "--namespaces" && i+1 < len(c.Args):
+				value = c.Args[i+1]
+			default:
+				continue
+			}
+			if value == "" {
+				return true
+			}
+			managed := strings.Split(value, ",")
+			for _, ns := range namespaces {
+				if !slices.Contains(managed, ns) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return true
+}
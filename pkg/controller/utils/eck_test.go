@@ -0,0 +1,144 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/render/logstorage/eck"
+)
+
+var _ = Describe("User-managed ECK operator", func() {
+	var (
+		cli client.Client
+		ctx context.Context
+	)
+
+	eckOperator := func(namespace, image string, args ...string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "elastic-operator",
+				Namespace: namespace,
+				Labels:    map[string]string{"control-plane": "elastic-operator"},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "manager", Image: image, Args: args}},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+	})
+
+	It("should find the ECK operator that manages the Elasticsearch and Kibana namespaces", func() {
+		Expect(cli.Create(ctx, eckOperator("elastic-system", "docker.elastic.co/eck/eck-operator:2.14.0"))).ShouldNot(HaveOccurred())
+
+		sts, err := GetUserManagedECKOperator(ctx, cli)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sts).NotTo(BeNil())
+		Expect(sts.Namespace).To(Equal("elastic-system"))
+	})
+
+	It("should ignore the ECK operator deployed by the operator", func() {
+		Expect(cli.Create(ctx, eckOperator(eck.OperatorNamespace, "tigera/eck-operator:2.14.0"))).ShouldNot(HaveOccurred())
+
+		sts, err := GetUserManagedECKOperator(ctx, cli)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sts).To(BeNil())
+	})
+
+	It("should ignore ECK operators that don't manage the Elasticsearch and Kibana namespaces", func() {
+		Expect(cli.Create(ctx, eckOperator("elastic-system", "eck-operator:2.14.0", "manager", "--namespaces=default,"+render.ElasticsearchNamespace))).ShouldNot(HaveOccurred())
+
+		sts, err := GetUserManagedECKOperator(ctx, cli)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sts).To(BeNil())
+	})
+
+	It("should return an error if more than one ECK operator manages the Elasticsearch namespace", func() {
+		Expect(cli.Create(ctx, eckOperator("elastic-system", "eck-operator:2.14.0"))).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, eckOperator("other-system", "eck-operator:2.14.0"))).ShouldNot(HaveOccurred())
+
+		_, err := GetUserManagedECKOperator(ctx, cli)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("elastic-system/elastic-operator, other-system/elastic-operator"))
+	})
+
+	It("should read the license type from the namespace of the user-managed ECK operator", func() {
+		userManaged := operatorv1.ECKOperatorModeUserManaged
+		Expect(cli.Create(ctx, &operatorv1.LogStorage{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec:       operatorv1.LogStorageSpec{ECKOperator: &operatorv1.ECKOperator{Mode: &userManaged}},
+		})).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, eckOperator("elastic-system", "eck-operator:2.14.0"))).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "elastic-system", Name: eck.LicenseConfigMapName},
+			Data:       map[string]string{"eck_license_level": "enterprise"},
+		})).ShouldNot(HaveOccurred())
+
+		license, err := GetElasticLicenseType(ctx, cli, logf.Log.WithName("eck-test-logger"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(license).To(Equal(render.ElasticsearchLicenseTypeEnterprise))
+	})
+
+	DescribeTable("should take the version from the image tag",
+		func(image, version string) {
+			Expect(ECKOperatorVersion(eckOperator("elastic-system", image))).To(Equal(version))
+		},
+		Entry("tag", "docker.elastic.co/eck/eck-operator:2.14.0", "2.14.0"),
+		Entry("tag and digest", "registry:5000/eck-operator:2.9.1@sha256:abcd", "2.9.1"),
+		Entry("registry port without tag", "registry:5000/eck-operator", ""),
+	)
+
+	DescribeTable("should validate the version",
+		func(version string, valid bool) {
+			err := ValidateECKOperatorVersion(version)
+			if valid {
+				Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				Expect(err).Should(HaveOccurred())
+			}
+		},
+		Entry("supported", "2.14.0", true),
+		Entry("minimum", "2.0.0", true),
+		Entry("too old", "1.9.1", false),
+		Entry("development build", "latest", true),
+	)
+})
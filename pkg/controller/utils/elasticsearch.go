@@ -22,6 +22,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
@@ -33,6 +38,8 @@ import (
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/logstorage"
+	"github.com/tigera/operator/pkg/render/logstorage/esgateway"
+	"github.com/tigera/operator/pkg/telemetry"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
@@ -46,6 +53,15 @@ const (
 	ElasticConnRetryInterval     = "500ms"
 )
 
+// The limits on the requests made to Elasticsearch to provision several users or roles at once.
+const (
+	esProvisioningWorkers = 8
+	esProvisioningRate    = 50
+)
+
+// indexLifecycleNameSetting is the index setting that binds an index to an ILM policy.
+const indexLifecycleNameSetting = "index.lifecycle.name"
+
 type Policy struct {
 	Phases struct {
 		Hot struct {
@@ -115,8 +131,69 @@ type ElasticsearchClientCreator func(client client.Client, ctx context.Context,
 type ElasticClient interface {
 	SetILMPolicies(context.Context, *operatorv1.LogStorage) error
 	CreateUser(context.Context, *User) error
+	CreateUsers(ctx context.Context, users ...*User) error
 	DeleteUser(context.Context, *User) error
 	GetUsers(ctx context.Context) ([]User, error)
+	CreateRoles(ctx context.Context, roles ...Role) error
+	DeleteRoles(ctx context.Context, roles []Role) error
+	PutRoleMapping(ctx context.Context, mapping RoleMapping) error
+	DeleteRoleMapping(ctx context.Context, name string) error
+	GetRoleMappings(ctx context.Context) ([]RoleMapping, error)
+	IndexExists(ctx context.Context, index string) (bool, error)
+	StartReindex(ctx context.Context, source, destination string) (string, error)
+	GetReindexProgress(ctx context.Context, taskID string) (*ReindexProgress, error)
+	MoveAlias(ctx context.Context, alias, from, to string) error
+	GetIndexAliases(ctx context.Context, pattern string) ([]IndexAliases, error)
+	PutAlias(ctx context.Context, alias string, indices []string, writeIndex string) error
+	GetIndexSettings(ctx context.Context, pattern string, names ...string) ([]IndexSettings, error)
+	PutIndexSettings(ctx context.Context, index string, settings map[string]string) error
+	ClusterHealth(ctx context.Context) (json.RawMessage, error)
+	ExplainILM(ctx context.Context) (json.RawMessage, error)
+	ConfigureCrossClusterReplication(ctx context.Context, remoteCluster, proxyAddress string, leaderIndexPatterns []string) error
+	IndexUsage(ctx context.Context, pattern string) ([]IndexUsage, error)
+	DiskUsagePercent(ctx context.Context) (int, error)
+	NodeStats(ctx context.Context) (*NodeStats, error)
+}
+
+// ReindexProgress is the progress of an Elasticsearch reindex task.
+type ReindexProgress struct {
+	Completed bool
+	Total     int64
+	Copied    int64
+
+	// Failure is set if the task completed with an error or failed to copy some of the documents.
+	Failure string
+}
+
+// IndexAliases are the aliases of an Elasticsearch index.
+type IndexAliases struct {
+	Index string
+	// Aliases holds, for each alias of the index, whether the index is the write index of the alias.
+	Aliases map[string]bool
+}
+
+// IndexSettings are the settings of an Elasticsearch index, keyed by their flat name, such as index.lifecycle.name.
+type IndexSettings struct {
+	Index    string
+	Settings map[string]string
+}
+
+// IndexUsage is the number of documents in an Elasticsearch index and the size of its primary shards.
+type IndexUsage struct {
+	Index     string
+	Documents int64
+	SizeBytes int64
+}
+
+// NodeStats are statistics of the Elasticsearch nodes, summed over all nodes. The counts are cumulative since the nodes
+// started, so they drop when a node restarts.
+type NodeStats struct {
+	// RejectedExecutions is the number of requests that each thread pool rejected because its queue was full.
+	RejectedExecutions map[string]int64
+	// QueryTotal is the number of queries run by the query phase of searches, and QueryTimeMillis the time spent
+	// running them.
+	QueryTotal      int64
+	QueryTimeMillis int64
 }
 
 type esClient struct {
@@ -130,6 +207,7 @@ func NewElasticClient(client client.Client, ctx context.Context, elasticHTTPSEnd
 	}
 
 	var clientCertificates []tls.Certificate
+	var insecureSkipVerify bool
 	if external {
 		// mTLS is enabled. We need to provide a client certificate.
 		certSecret, err := GetSecret(ctx, client, logstorage.ExternalCertsSecret, common.OperatorNamespace())
@@ -144,14 +222,21 @@ func NewElasticClient(client client.Client, ctx context.Context, elasticHTTPSEnd
 			return nil, err
 		}
 		clientCertificates = []tls.Certificate{cert}
-		root, err = getESRoots(ctx, client, logstorage.ExternalESPublicCertName)
+
+		insecureSkipVerify, err = externalElasticInsecureSkipTLSVerify(ctx, client, elasticHTTPSEndpoint)
 		if err != nil {
 			return nil, err
 		}
+		if !insecureSkipVerify {
+			root, err = getESRoots(ctx, client, logstorage.ExternalESPublicCertName)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// If we're using mTLS, or internal ES, we need to provide a custom HTTP client.
-	tlsClientConfig := &tls.Config{}
+	tlsClientConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
 	if len(clientCertificates) > 0 {
 		tlsClientConfig.Certificates = clientCertificates
 	}
@@ -159,8 +244,101 @@ func NewElasticClient(client client.Client, ctx context.Context, elasticHTTPSEnd
 		tlsClientConfig.RootCAs = root
 	}
 
+	esCli, err := newElasticsearchClient(elasticHTTPSEndpoint, tlsClientConfig, user, password)
+	return &esClient{client: esCli}, err
+}
+
+// CheckCuratedIndex returns an error unless the operator may curate the index, that is bind it to one of its ILM policies,
+// which delete the index once its retention period has passed. System indices, whose names start with a dot, are never
+// curated, and neither are the indices outside of the curated prefixes of the LogStorage.
+func CheckCuratedIndex(ls *operatorv1.LogStorage, index string) error {
+	if strings.HasPrefix(index, ".") {
+		return fmt.Errorf("refusing to curate system index %s", index)
+	}
+	for _, prefix := range ls.CuratedIndexPrefixes() {
+		if strings.HasPrefix(index, prefix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("refusing to curate index %s, which doesn't match the curated index prefixes %s", index, strings.Join(ls.CuratedIndexPrefixes(), ", "))
+}
+
+var (
+	logIndexPrefixRegexp = regexp.MustCompile(`^` + operatorv1.DefaultCuratedIndexPrefix + `[a-z0-9_*]+$`)
+	clusterNameRegexp    = regexp.MustCompile(`^[a-z0-9*][-a-z0-9.*]*$`)
+)
+
+// ValidateLogIndexAccess returns an error unless the index prefixes and cluster names of a grant of access to the log
+// indices can only make up index patterns of log indices, since they make up the index patterns of an Elasticsearch role.
+func ValidateLogIndexAccess(indices, clusters []string) error {
+	if len(indices) == 0 {
+		return fmt.Errorf("at least one index is required")
+	}
+	for _, index := range indices {
+		if !logIndexPrefixRegexp.MatchString(index) {
+			return fmt.Errorf("index %q must start with %s and only contain lowercase letters, digits, _ and *", index, operatorv1.DefaultCuratedIndexPrefix)
+		}
+	}
+	for _, cluster := range clusters {
+		if !clusterNameRegexp.MatchString(cluster) {
+			return fmt.Errorf("cluster %q is not a valid cluster name", cluster)
+		}
+	}
+	return nil
+}
+
+// LogIndexPatterns returns the index patterns of the log indices with the given prefixes for the given clusters, or for
+// all the clusters when none are given.
+func LogIndexPatterns(indices, clusters []string) []string {
+	if len(clusters) == 0 {
+		clusters = []string{"*"}
+	}
+	var patterns []string
+	for _, index := range indices {
+		for _, cluster := range clusters {
+			patterns = append(patterns, indexPattern(index, cluster, ".*", ""))
+		}
+	}
+	return patterns
+}
+
+// insecureSkipTLSVerifyAllowed is whether a Tenant may disable the verification of the certificate of its external
+// Elasticsearch cluster. It is set with AllowInsecureSkipTLSVerify.
+var insecureSkipTLSVerifyAllowed bool
+
+// AllowInsecureSkipTLSVerify sets whether a Tenant may disable the verification of the certificate of its external
+// Elasticsearch cluster with insecureSkipTLSVerify. It is set from the --allow-insecure-skip-tls-verify feature gate, which
+// is off by default so that production clusters reject the option.
+func AllowInsecureSkipTLSVerify(allowed bool) {
+	insecureSkipTLSVerifyAllowed = allowed
+}
+
+// externalElasticInsecureSkipTLSVerify returns whether a Tenant disables the verification of the certificate of the
+// external Elasticsearch cluster at the endpoint, and an error if one does while the feature gate is off.
+func externalElasticInsecureSkipTLSVerify(ctx context.Context, cli client.Client, endpoint string) (bool, error) {
+	tenants := &operatorv1.TenantList{}
+	if err := cli.List(ctx, tenants); err != nil {
+		return false, err
+	}
+	for _, t := range tenants.Items {
+		if t.Spec.Elastic == nil || t.Spec.Elastic.URL != endpoint || !t.Spec.Elastic.InsecureSkipTLSVerify {
+			continue
+		}
+		if !insecureSkipTLSVerifyAllowed {
+			return false, fmt.Errorf("tenant %s/%s sets insecureSkipTLSVerify for %s, which is only allowed when the operator runs with --allow-insecure-skip-tls-verify", t.Namespace, t.Name, endpoint)
+		}
+		log.Info("WARNING: TLS verification of the external Elasticsearch cluster is disabled, so the connection is open to interception. Do not use insecureSkipTLSVerify in production.", "endpoint", endpoint, "tenant", t.Namespace+"/"+t.Name)
+		return true, nil
+	}
+	return false, nil
+}
+
+// newElasticsearchClient returns an Elasticsearch client for the endpoint that authenticates with the username and
+// password, retrying the connection ElasticConnRetries times.
+func newElasticsearchClient(elasticHTTPSEndpoint string, tlsClientConfig *tls.Config, user, password string) (*elastic.Client, error) {
 	h := &http.Client{
-		Transport: &http.Transport{
+		// Requests are traced so that slow Elasticsearch calls show up in the spans of the reconcile that made them.
+		Transport: telemetry.Transport(&http.Transport{
 			// We must disable keep alive since we create a new client instead of persisting the client and reusing it.
 			// If we don't do this, the connections are, by default, kept around in an established state. If we don't do this,
 			// we end up leaking memory as the connections hold references to certs and other http resources.
@@ -174,7 +352,7 @@ func NewElasticClient(client client.Client, ctx context.Context, elasticHTTPSEnd
 			//   could be little more difficult and possibly error-prone, leading to a regression where we leak resources again.
 			DisableKeepAlives: true,
 			TLSClientConfig:   tlsClientConfig,
-		},
+		}),
 	}
 
 	options := []elastic.ClientOptionFunc{
@@ -199,8 +377,7 @@ func NewElasticClient(client client.Client, ctx context.Context, elasticHTTPSEnd
 		log.Error(err, "Elastic connect failed, retrying")
 		time.Sleep(retryInterval)
 	}
-
-	return &esClient{client: esCli}, err
+	return esCli, err
 }
 
 func formatName(name, clusterID, tenantID string) string {
@@ -220,6 +397,16 @@ var (
 	ElasticsearchUserNameDashboardInstaller = "tigera-ee-dashboards-installer"
 )
 
+// IsOperatorUser returns true if the Elasticsearch user with the given name was created by the operator for a tenant.
+func IsOperatorUser(username string) bool {
+	for _, name := range []string{ElasticsearchUserNameLinseed, ElasticsearchUserNameDashboardInstaller} {
+		if strings.HasPrefix(username, name+"_") {
+			return true
+		}
+	}
+	return false
+}
+
 func LinseedUser(clusterID, tenant string) *User {
 	username := formatName(ElasticsearchUserNameLinseed, clusterID, tenant)
 	return &User{
@@ -262,6 +449,37 @@ func DashboardUser(clusterID, tenant string) *User {
 	}
 }
 
+// ElasticsearchUserNameReadOnlyPrefix is the prefix of the names of the Elasticsearch users that the operator provisions
+// for the read-only users in the LogStorage spec.
+const ElasticsearchUserNameReadOnlyPrefix = "tigera-ee-read-only-"
+
+// ReadOnlyUser returns the Elasticsearch user for a read-only user in the LogStorage spec. Its role can read the indices
+// of the user for the clusters of the user, and use Kibana without changing its saved objects.
+func ReadOnlyUser(u operatorv1.ElasticsearchReadOnlyUser) *User {
+	username := ElasticsearchUserNameReadOnlyPrefix + u.Name
+	return &User{
+		Username: username,
+		Roles: []Role{
+			{
+				Name: username,
+				Definition: &RoleDefinition{
+					Indices: []RoleIndex{
+						{
+							Names:      LogIndexPatterns(u.Indices, u.Clusters),
+							Privileges: []string{"read", "view_index_metadata"},
+						},
+					},
+					Applications: []Application{{
+						Application: "kibana-.kibana",
+						Privileges:  []string{"read"},
+						Resources:   []string{"*"},
+					}},
+				},
+			},
+		},
+	}
+}
+
 // User represents an Elasticsearch user, which may or may not have roles attached to it
 type User struct {
 	Username string
@@ -305,15 +523,31 @@ type Application struct {
 	Resources   []string `json:"resources"`
 }
 
-// CreateRoles wraps createRoles to make creating multiple rows slightly more convenient
+// RoleMapping is an Elasticsearch role mapping. It grants its roles to the users of the realms that don't store the
+// roles of their users, such as OIDC and SAML, when the users match its rules.
+type RoleMapping struct {
+	Name    string                 `json:"-"`
+	Enabled bool                   `json:"enabled"`
+	Roles   []string               `json:"roles"`
+	Rules   map[string]interface{} `json:"rules"`
+}
+
+// CreateRoles creates (or updates) the given roles. The roles are created concurrently, and the roles that are given
+// more than once are only created once.
 func (es *esClient) CreateRoles(ctx context.Context, roles ...Role) error {
+	var unique []Role
+	seen := map[string]bool{}
 	for _, role := range roles {
-		if err := es.createRole(ctx, role); err != nil {
-			return err
+		if role.Name != "" && seen[role.Name] {
+			continue
 		}
+		seen[role.Name] = true
+		unique = append(unique, role)
 	}
 
-	return nil
+	return provisionConcurrently(ctx, len(unique), func(i int) error {
+		return es.createRole(ctx, unique[i])
+	})
 }
 
 // createRole attempts to create (or updated) the given Elasticsearch role.
@@ -331,10 +565,18 @@ func (es *esClient) createRole(ctx context.Context, role Role) error {
 }
 
 func (es *esClient) CreateUser(ctx context.Context, user *User) error {
+	return es.CreateUsers(ctx, user)
+}
+
+// CreateUsers creates (or updates) the given users, along with the roles of the users that have a definition. The roles
+// are created first, once each, and then the users. Both are created concurrently.
+func (es *esClient) CreateUsers(ctx context.Context, users ...*User) error {
 	var rolesToCreate []Role
-	for _, role := range user.Roles {
-		if role.Definition != nil {
-			rolesToCreate = append(rolesToCreate, role)
+	for _, user := range users {
+		for _, role := range user.Roles {
+			if role.Definition != nil {
+				rolesToCreate = append(rolesToCreate, role)
+			}
 		}
 	}
 
@@ -344,18 +586,27 @@ func (es *esClient) CreateUser(ctx context.Context, user *User) error {
 		}
 	}
 
-	body := map[string]interface{}{
-		"password": user.Password,
-		"roles":    user.RoleNames(),
-	}
+	return provisionConcurrently(ctx, len(users), func(i int) error {
+		body := map[string]interface{}{
+			"password": users[i].Password,
+			"roles":    users[i].RoleNames(),
+		}
 
-	_, err := es.client.XPackSecurityPutUser(user.Username).Body(body).Do(ctx)
-	if err != nil {
-		log.Error(err, "Error creating user")
-		return err
-	}
+		_, err := es.client.XPackSecurityPutUser(users[i].Username).Body(body).Do(ctx)
+		if err != nil {
+			log.Error(err, "Error creating user", "user", users[i].Username)
+			return fmt.Errorf("failed to create user %s: %w", users[i].Username, err)
+		}
+		return nil
+	})
+}
 
-	return nil
+// provisionConcurrently calls provision for each of the n users or roles to provision, from up to
+// esProvisioningWorkers workers and at no more than esProvisioningRate calls per second, so that provisioning the
+// users of many tenants neither takes one round trip each nor floods Elasticsearch. All the calls are made, even when
+// some of them fail, and their errors are returned joined.
+func provisionConcurrently(ctx context.Context, n int, provision func(i int) error) error {
+	return forEachConcurrently(ctx, n, esProvisioningWorkers, time.Second/esProvisioningRate, provision)
 }
 
 // DeleteRoles wraps deleteRoles to make deleting multiple rows slightly more convenient
@@ -422,18 +673,277 @@ func (es *esClient) GetUsers(ctx context.Context) ([]User, error) {
 	return users, nil
 }
 
+// PutRoleMapping creates or updates the given role mapping.
+func (es *esClient) PutRoleMapping(ctx context.Context, mapping RoleMapping) error {
+	if mapping.Name == "" {
+		return fmt.Errorf("can't create a role mapping with an empty name")
+	}
+	_, err := es.client.XPackSecurityPutRoleMapping(mapping.Name).Body(mapping).Do(ctx)
+	return err
+}
+
+// DeleteRoleMapping deletes the role mapping with the given name.
+func (es *esClient) DeleteRoleMapping(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("can't delete a role mapping with an empty name")
+	}
+	_, err := es.client.XPackSecurityDeleteRoleMapping(name).Do(ctx)
+	return err
+}
+
+// GetRoleMappings returns all the role mappings, sorted by name.
+func (es *esClient) GetRoleMappings(ctx context.Context) ([]RoleMapping, error) {
+	// The role mapping service of the client requires a name, so list them with a plain request instead.
+	body, err := es.get(ctx, "/_security/role_mapping", nil)
+	if err != nil {
+		return nil, err
+	}
+	var response map[string]RoleMapping
+	if err = json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	mappings := []RoleMapping{}
+	for name, mapping := range response {
+		mapping.Name = name
+		mappings = append(mappings, mapping)
+	}
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].Name < mappings[j].Name })
+	return mappings, nil
+}
+
+// IndexExists returns true if the index exists.
+func (es *esClient) IndexExists(ctx context.Context, index string) (bool, error) {
+	return es.client.IndexExists(index).Do(ctx)
+}
+
+// StartReindex starts copying the documents of the source index into the destination index in the background, and
+// returns the ID of the reindex task.
+func (es *esClient) StartReindex(ctx context.Context, source, destination string) (string, error) {
+	res, err := es.client.Reindex().SourceIndex(source).DestinationIndex(destination).DoAsync(ctx)
+	if err != nil {
+		return "", err
+	}
+	return res.TaskId, nil
+}
+
+// reindexTask is the response of the task API for a reindex task.
+type reindexTask struct {
+	Completed bool `json:"completed"`
+	Task      struct {
+		// The status of a reindex task is a BulkByScrollTask.Status.
+		Status struct {
+			Total   int64 `json:"total"`
+			Created int64 `json:"created"`
+			Updated int64 `json:"updated"`
+		} `json:"status"`
+	} `json:"task"`
+	Error *struct {
+		Reason string `json:"reason"`
+	} `json:"error"`
+	Response *struct {
+		Failures []struct {
+			Index string `json:"index"`
+			ID    string `json:"id"`
+			Cause struct {
+				Reason string `json:"reason"`
+			} `json:"cause"`
+		} `json:"failures"`
+	} `json:"response"`
+}
+
+// GetReindexProgress returns the progress of the reindex task with the given ID. A task that completed but failed to
+// copy some of the documents is reported as failed.
+func (es *esClient) GetReindexProgress(ctx context.Context, taskID string) (*ReindexProgress, error) {
+	// The task service of the client drops the response of the task, which holds the failures.
+	body, err := es.get(ctx, "/_tasks/"+url.PathEscape(taskID), nil)
+	if err != nil {
+		return nil, err
+	}
+	var res reindexTask
+	if err = json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	progress := &ReindexProgress{
+		Completed: res.Completed,
+		Total:     res.Task.Status.Total,
+		Copied:    res.Task.Status.Created + res.Task.Status.Updated,
+	}
+	switch {
+	case res.Error != nil:
+		progress.Failure = res.Error.Reason
+	case res.Response != nil && len(res.Response.Failures) > 0:
+		first := res.Response.Failures[0]
+		progress.Failure = fmt.Sprintf("%d documents failed to be copied, the first, %s in index %s, because of: %s",
+			len(res.Response.Failures), first.ID, first.Index, first.Cause.Reason)
+	}
+	return progress, nil
+}
+
+// MoveAlias atomically moves the alias from one index to another.
+func (es *esClient) MoveAlias(ctx context.Context, alias, from, to string) error {
+	_, err := es.client.Alias().Remove(from, alias).Add(to, alias).Do(ctx)
+	return err
+}
+
+// GetIndexAliases returns the aliases of the indices that match the pattern, which may hold wildcards and be a comma
+// separated list, sorted by index. Indices without aliases are returned too.
+func (es *esClient) GetIndexAliases(ctx context.Context, pattern string) ([]IndexAliases, error) {
+	res, err := es.client.Aliases().Index(pattern).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var indices []IndexAliases
+	for index, result := range res.Indices {
+		aliases := IndexAliases{Index: index, Aliases: map[string]bool{}}
+		for _, alias := range result.Aliases {
+			aliases.Aliases[alias.AliasName] = alias.IsWriteIndex
+		}
+		indices = append(indices, aliases)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i].Index < indices[j].Index })
+	return indices, nil
+}
+
+// PutAlias atomically adds the alias to the indices, and makes writeIndex the only one of them that the alias writes to.
+func (es *esClient) PutAlias(ctx context.Context, alias string, indices []string, writeIndex string) error {
+	service := es.client.Alias()
+	for _, index := range indices {
+		service = service.Action(elastic.NewAliasAddAction(alias).Index(index).IsWriteIndex(index == writeIndex))
+	}
+	_, err := service.Do(ctx)
+	return err
+}
+
+// GetIndexSettings returns the named settings of the indices that match the pattern, sorted by index. Settings that are
+// not set on an index are left out.
+func (es *esClient) GetIndexSettings(ctx context.Context, pattern string, names ...string) ([]IndexSettings, error) {
+	res, err := es.client.IndexGetSettings(pattern).Name(names...).FlatSettings(true).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var indices []IndexSettings
+	for index, result := range res {
+		settings := IndexSettings{Index: index, Settings: map[string]string{}}
+		if result != nil {
+			for name, value := range result.Settings {
+				settings.Settings[name] = fmt.Sprint(value)
+			}
+		}
+		indices = append(indices, settings)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i].Index < indices[j].Index })
+	return indices, nil
+}
+
+// PutIndexSettings updates the given settings of an index, keyed by their flat name.
+func (es *esClient) PutIndexSettings(ctx context.Context, index string, settings map[string]string) error {
+	// As a last line of defence, never bind a system index, or the many indices a pattern could match, to an ILM policy.
+	if _, ok := settings[indexLifecycleNameSetting]; ok && (strings.HasPrefix(index, ".") || strings.ContainsAny(index, "*,")) {
+		return fmt.Errorf("refusing to set %s on %s, which is a system index or matches several indices", indexLifecycleNameSetting, index)
+	}
+	_, err := es.client.IndexPutSettings(index).BodyJson(settings).Do(ctx)
+	return err
+}
+
+// ClusterHealth returns the response of the Elasticsearch cluster health API.
+func (es *esClient) ClusterHealth(ctx context.Context) (json.RawMessage, error) {
+	return es.get(ctx, "/_cluster/health", nil)
+}
+
+// ExplainILM returns the lifecycle state of all indices managed by ILM.
+func (es *esClient) ExplainILM(ctx context.Context) (json.RawMessage, error) {
+	return es.get(ctx, "/*/_ilm/explain", url.Values{"only_managed": []string{"true"}})
+}
+
+// IndexUsage returns the usage of the open indices that match the pattern, which may hold wildcards and be a comma
+// separated list.
+func (es *esClient) IndexUsage(ctx context.Context, pattern string) ([]IndexUsage, error) {
+	rows, err := es.client.CatIndices().Index(pattern).Bytes("b").Columns("index", "docs.count", "pri.store.size").Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var usage []IndexUsage
+	for _, row := range rows {
+		// Closed indices have no size.
+		if row.PriStoreSize == "" {
+			continue
+		}
+		size, err := strconv.ParseInt(row.PriStoreSize, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %q of index %s: %w", row.PriStoreSize, row.Index, err)
+		}
+		usage = append(usage, IndexUsage{Index: row.Index, Documents: int64(row.DocsCount), SizeBytes: size})
+	}
+	return usage, nil
+}
+
+// DiskUsagePercent returns the disk usage of the fullest Elasticsearch node, as a percentage.
+func (es *esClient) DiskUsagePercent(ctx context.Context) (int, error) {
+	rows, err := es.client.CatAllocation().Columns("node", "disk.percent").Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	percent := 0
+	for _, row := range rows {
+		if row.DiskPercent > percent {
+			percent = row.DiskPercent
+		}
+	}
+	return percent, nil
+}
+
+// NodeStats returns the thread pool and search statistics of the Elasticsearch nodes, summed over all nodes.
+func (es *esClient) NodeStats(ctx context.Context) (*NodeStats, error) {
+	res, err := es.client.NodesStats().Metric("thread_pool", "indices").Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats := &NodeStats{RejectedExecutions: map[string]int64{}}
+	for _, node := range res.Nodes {
+		for name, pool := range node.ThreadPool {
+			if pool != nil {
+				stats.RejectedExecutions[name] += pool.Rejected
+			}
+		}
+		if node.Indices != nil && node.Indices.Search != nil {
+			stats.QueryTotal += node.Indices.Search.QueryTotal
+			stats.QueryTimeMillis += node.Indices.Search.QueryTimeInMillis
+		}
+	}
+	return stats, nil
+}
+
+func (es *esClient) get(ctx context.Context, path string, params url.Values) (json.RawMessage, error) {
+	res, err := es.client.PerformRequest(ctx, elastic.PerformRequestOptions{Method: http.MethodGet, Path: path, Params: params})
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
 // SetILMPolicies creates ILM policies for each timeseries based index using the retention period and storage size in LogStorage
 func (es *esClient) SetILMPolicies(ctx context.Context, ls *operatorv1.LogStorage) error {
-	policyList := es.listILMPolicies(ls)
+	policyList := listILMPolicies(ls)
 	return es.createOrUpdatePolicies(ctx, policyList)
 }
 
+// ILMPolicies returns the ILM policies that SetILMPolicies applies for the LogStorage, keyed by policy name.
+func ILMPolicies(ls *operatorv1.LogStorage) map[string]map[string]interface{} {
+	policies := map[string]map[string]interface{}{}
+	for indexName, pd := range listILMPolicies(ls) {
+		policies[indexName+"_policy"] = pd.policy
+	}
+	return policies
+}
+
 // listILMPolicies generates ILM policies based on disk space and retention in LogStorage
 // Allocate 70% of ES disk space to flows, dns and bgp logs [majorPctOfTotalDisk]
 // Allocate 90% of the 70% ES disk space to flow logs, 5% of the 70% ES disk space to each dns and bgp logs.
 // Allocate 10% of ES disk space to logs that are NOT flows, dns or bgp [minorPctOfTotalDisk]
 // Equally distribute 10% of the ES disk space among these other log types
-func (es *esClient) listILMPolicies(ls *operatorv1.LogStorage) map[string]policyDetail {
+func listILMPolicies(ls *operatorv1.LogStorage) map[string]policyDetail {
 	totalEsStorage := getTotalEsDisk(ls)
 	majorPctOfTotalDisk := 0.7
 
@@ -444,7 +954,7 @@ func (es *esClient) listILMPolicies(ls *operatorv1.LogStorage) map[string]policy
 	pctOfDisk := minorPctOfTotalDisk / float64(numOfIndicesWithMinorSpace)
 
 	// Retention is not set in LogStorage for l7, benchmark and events logs
-	return map[string]policyDetail{
+	policies := map[string]policyDetail{
 		"tigera_secure_ee_flows": buildILMPolicy(totalEsStorage, majorPctOfTotalDisk, 0.85, int(*ls.Spec.Retention.Flows), true),
 		"tigera_secure_ee_dns":   buildILMPolicy(totalEsStorage, majorPctOfTotalDisk, 0.05, int(*ls.Spec.Retention.DNSLogs), true),
 		"tigera_secure_ee_bgp":   buildILMPolicy(totalEsStorage, majorPctOfTotalDisk, 0.05, int(*ls.Spec.Retention.BGPLogs), true),
@@ -457,6 +967,12 @@ func (es *esClient) listILMPolicies(ls *operatorv1.LogStorage) map[string]policy
 		"tigera_secure_ee_benchmark_results":  buildILMPolicy(totalEsStorage, minorPctOfTotalDisk, pctOfDisk, 91, true),
 		"tigera_secure_ee_events":             buildILMPolicy(totalEsStorage, minorPctOfTotalDisk, pctOfDisk, 91, false),
 	}
+
+	// The audit log of es-gateway is kept for as long as the other audit logs.
+	if ls.ESGatewayAuditLogSink() == operatorv1.ESGatewayAuditLogSinkElasticsearch {
+		policies[esgateway.AuditLogIndex] = buildILMPolicy(totalEsStorage, minorPctOfTotalDisk, pctOfDisk, int(*ls.Spec.Retention.AuditReports), true)
+	}
+	return policies
 }
 
 func (es *esClient) createOrUpdatePolicies(ctx context.Context, listPolicy map[string]policyDetail) error {
@@ -467,7 +983,10 @@ func (es *esClient) createOrUpdatePolicies(ctx context.Context, listPolicy map[s
 		if err != nil {
 			if elastic.IsNotFound(err) {
 				// If policy doesn't exist, create one
-				return applyILMPolicy(ctx, es.client, indexName, pd.policy)
+				if err = applyILMPolicy(ctx, es.client, indexName, pd.policy); err != nil {
+					return err
+				}
+				continue
 			}
 			return err
 		}
@@ -481,7 +1000,9 @@ func (es *esClient) createOrUpdatePolicies(ctx context.Context, listPolicy map[s
 			currentMaxSize != pd.rolloverSize ||
 			currentMinAge != pd.deleteAge ||
 			readOnlyAfterRollover != pd.readOnlyAfterRollover {
-			return applyILMPolicy(ctx, es.client, indexName, pd.policy)
+			if err = applyILMPolicy(ctx, es.client, indexName, pd.policy); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,79 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultElasticsearchBackend is the name of the Elasticsearch backend that connects directly to the Elasticsearch
+// cluster with NewElasticClient.
+const DefaultElasticsearchBackend = "default"
+
+var (
+	elasticsearchBackendsLock sync.RWMutex
+	elasticsearchBackends     = map[string]ElasticsearchClientCreator{
+		DefaultElasticsearchBackend: NewElasticClient,
+	}
+)
+
+// RegisterElasticsearchBackend registers the ElasticsearchClientCreator of a custom Elasticsearch backend, such as one
+// that proxies the requests, under the given name. The backend is used by the controllers that talk to Elasticsearch
+// when the operator is started with --elasticsearch-backend=<name>. It is meant to be called from the init function
+// of the package that provides the backend, and panics if the name is empty or already registered, or the creator is nil.
+func RegisterElasticsearchBackend(name string, creator ElasticsearchClientCreator) {
+	elasticsearchBackendsLock.Lock()
+	defer elasticsearchBackendsLock.Unlock()
+	if name == "" {
+		panic("elasticsearch backend name must not be empty")
+	}
+	if creator == nil {
+		panic(fmt.Sprintf("elasticsearch backend %q has a nil client creator", name))
+	}
+	if _, ok := elasticsearchBackends[name]; ok {
+		panic(fmt.Sprintf("elasticsearch backend %q is already registered", name))
+	}
+	elasticsearchBackends[name] = creator
+}
+
+// ElasticsearchBackends returns the sorted names of the registered Elasticsearch backends.
+func ElasticsearchBackends() []string {
+	elasticsearchBackendsLock.RLock()
+	defer elasticsearchBackendsLock.RUnlock()
+	var names []string
+	for name := range elasticsearchBackends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetElasticsearchClientCreator returns the ElasticsearchClientCreator of the named Elasticsearch backend. An empty
+// name returns the default backend.
+func GetElasticsearchClientCreator(name string) (ElasticsearchClientCreator, error) {
+	if name == "" {
+		name = DefaultElasticsearchBackend
+	}
+	elasticsearchBackendsLock.RLock()
+	creator, ok := elasticsearchBackends[name]
+	elasticsearchBackendsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown elasticsearch backend %q, must be one of: %s", name, strings.Join(ElasticsearchBackends(), ", "))
+	}
+	return creator, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Elasticsearch backends", func() {
+	It("should return the default backend for an empty name", func() {
+		creator, err := GetElasticsearchClientCreator("")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(fmt.Sprintf("%p", creator)).To(Equal(fmt.Sprintf("%p", ElasticsearchClientCreator(NewElasticClient))))
+	})
+
+	It("should return an error for an unknown backend", func() {
+		_, err := GetElasticsearchClientCreator("unknown")
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(DefaultElasticsearchBackend))
+	})
+
+	It("should register a custom backend", func() {
+		proxyErr := fmt.Errorf("proxied")
+		RegisterElasticsearchBackend("test-proxy", func(client.Client, context.Context, string, bool) (ElasticClient, error) {
+			return nil, proxyErr
+		})
+		Expect(ElasticsearchBackends()).To(ContainElements(DefaultElasticsearchBackend, "test-proxy"))
+
+		creator, err := GetElasticsearchClientCreator("test-proxy")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = creator(nil, context.Background(), "", false)
+		Expect(err).To(Equal(proxyErr))
+
+		Expect(func() { RegisterElasticsearchBackend("test-proxy", NewElasticClient) }).To(Panic())
+	})
+
+	It("should reject invalid registrations", func() {
+		Expect(func() { RegisterElasticsearchBackend("", NewElasticClient) }).To(Panic())
+		Expect(func() { RegisterElasticsearchBackend("nil-creator", nil) }).To(Panic())
+	})
+})
@@ -0,0 +1,151 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	gv "github.com/hashicorp/go-version"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+)
+
+// elasticsearchSupport is the range of Elasticsearch versions that support a construct that the operator relies on.
+type elasticsearchSupport struct {
+	// since is the version that introduced the construct.
+	since string
+	// removedIn is the version that removed the construct, if any.
+	removedIn string
+}
+
+// elasticsearchAPIs are the REST APIs that the operator calls to provision the Elasticsearch cluster, keyed by their
+// path. They replaced the APIs under /_xpack, which Elasticsearch 8 removed.
+var elasticsearchAPIs = map[string]elasticsearchSupport{
+	"/_security": {since: "6.5.0"},
+	"/_ilm":      {since: "6.6.0"},
+}
+
+// ilmActions are the ILM actions that the policies of the operator may use, keyed by name.
+var ilmActions = map[string]elasticsearchSupport{
+	"allocate":     {since: "6.6.0"},
+	"delete":       {since: "6.6.0"},
+	"forcemerge":   {since: "6.6.0"},
+	"freeze":       {since: "6.6.0", removedIn: "8.0.0"},
+	"readonly":     {since: "6.6.0"},
+	"rollover":     {since: "6.6.0"},
+	"set_priority": {since: "6.7.0"},
+	"shrink":       {since: "6.6.0"},
+}
+
+// ilmRolloverConditions are the conditions of the rollover action that the policies of the operator may use, keyed by
+// name.
+var ilmRolloverConditions = map[string]elasticsearchSupport{
+	"max_age":                {since: "6.6.0"},
+	"max_docs":               {since: "6.6.0"},
+	"max_primary_shard_size": {since: "7.13.0"},
+	"max_size":               {since: "6.6.0"},
+}
+
+// ElasticsearchIncompatibilities returns the constructs that the operator manages in Elasticsearch for the LogStorage
+// that the target version of Elasticsearch does not support, when upgrading Elasticsearch from the running version
+// changes its major version: the REST APIs that the operator calls, and the actions and rollover conditions of its ILM
+// policies. Constructs the operator doesn't know are not reported. Versions that aren't valid are not validated. The
+// LogStorage must have its defaults filled in.
+func ElasticsearchIncompatibilities(ls *operatorv1.LogStorage, running, target string) []string {
+	from, err := gv.NewVersion(running)
+	if err != nil {
+		return nil
+	}
+	to, err := gv.NewVersion(target)
+	if err != nil {
+		return nil
+	}
+	fromMajor, _ := majorMinor(from)
+	toMajor, _ := majorMinor(to)
+	if fromMajor == toMajor {
+		return nil
+	}
+
+	var incompatibilities []string
+	for _, path := range sortedKeys(elasticsearchAPIs) {
+		if reason := unsupportedReason(elasticsearchAPIs[path], to); reason != "" {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("the %s API %s", path, reason))
+		}
+	}
+
+	// Report each ILM construct once, with the policies that use it.
+	actions := map[string][]string{}
+	conditions := map[string][]string{}
+	for name, policy := range ILMPolicies(ls) {
+		phases, _ := policy["policy"].(map[string]interface{})["phases"].(map[string]interface{})
+		for _, phase := range phases {
+			phaseActions, _ := phase.(map[string]interface{})["actions"].(map[string]interface{})
+			for action, config := range phaseActions {
+				actions[action] = append(actions[action], name)
+				if action != "rollover" {
+					continue
+				}
+				rolloverConditions, _ := config.(map[string]interface{})
+				for condition := range rolloverConditions {
+					conditions[condition] = append(conditions[condition], name)
+				}
+			}
+		}
+	}
+	for _, action := range sortedKeys(actions) {
+		if support, ok := ilmActions[action]; ok {
+			if reason := unsupportedReason(support, to); reason != "" {
+				incompatibilities = append(incompatibilities, fmt.Sprintf("the ILM action %s %s; it is used by %s",
+					action, reason, strings.Join(sorted(actions[action]), ", ")))
+			}
+		}
+	}
+	for _, condition := range sortedKeys(conditions) {
+		if support, ok := ilmRolloverConditions[condition]; ok {
+			if reason := unsupportedReason(support, to); reason != "" {
+				incompatibilities = append(incompatibilities, fmt.Sprintf("the ILM rollover condition %s %s; it is used by %s",
+					condition, reason, strings.Join(sorted(conditions[condition]), ", ")))
+			}
+		}
+	}
+	return incompatibilities
+}
+
+// unsupportedReason returns why the version doesn't support the construct, or an empty string if it does.
+func unsupportedReason(support elasticsearchSupport, version *gv.Version) string {
+	if version.LessThan(gv.Must(gv.NewVersion(support.since))) {
+		return fmt.Sprintf("is not available before Elasticsearch %s", support.since)
+	}
+	if support.removedIn != "" && !version.LessThan(gv.Must(gv.NewVersion(support.removedIn))) {
+		return fmt.Sprintf("was removed in Elasticsearch %s", support.removedIn)
+	}
+	return ""
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sorted(values []string) []string {
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	"github.com/olivere/elastic/v7"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SecondaryElasticsearchRemoteCluster is the name under which the primary Elasticsearch cluster is registered as
+	// a remote cluster of the secondary cluster when cross-cluster replication is used.
+	SecondaryElasticsearchRemoteCluster = "tigera-secure"
+
+	// secondaryElasticsearchAutoFollowPattern is the name of the auto-follow pattern that makes the secondary cluster
+	// follow the log indices of the primary cluster.
+	secondaryElasticsearchAutoFollowPattern = "tigera-secure-logs"
+)
+
+// SecondaryElasticsearchIndexPatterns are the patterns of the indices that are replicated to the secondary Elasticsearch
+// cluster with cross-cluster replication.
+var SecondaryElasticsearchIndexPatterns = []string{"tigera_secure_ee_*"}
+
+// SecondaryElasticsearchClientCreator creates a client for the secondary Elasticsearch cluster of the LogStorage at the
+// endpoint, using the username, password and optional ca.crt in the credentials secret.
+type SecondaryElasticsearchClientCreator func(ctx context.Context, endpoint string, credentials *corev1.Secret) (ElasticClient, error)
+
+// NewSecondaryElasticClient returns a client for the secondary Elasticsearch cluster of the LogStorage. Without a ca.crt
+// in the credentials secret, the server certificate of the cluster is validated with the system roots.
+func NewSecondaryElasticClient(_ context.Context, endpoint string, credentials *corev1.Secret) (ElasticClient, error) {
+	tlsClientConfig := &tls.Config{}
+	if ca, ok := credentials.Data[corev1.ServiceAccountRootCAKey]; ok {
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse the CA certificate of secret %s/%s", credentials.Namespace, credentials.Name)
+		}
+		tlsClientConfig.RootCAs = roots
+	}
+
+	esCli, err := newElasticsearchClient(endpoint, tlsClientConfig, string(credentials.Data["username"]), string(credentials.Data["password"]))
+	return &esClient{client: esCli}, err
+}
+
+// ConfigureCrossClusterReplication registers the remote cluster, reached through proxyAddress, and creates an
+// auto-follow pattern so that this cluster follows the indices of the remote cluster that match leaderIndexPatterns.
+// The follower indices keep the names of the leader indices. Both requests are idempotent.
+func (es *esClient) ConfigureCrossClusterReplication(ctx context.Context, remoteCluster, proxyAddress string, leaderIndexPatterns []string) error {
+	settings := map[string]interface{}{
+		"persistent": map[string]interface{}{
+			"cluster": map[string]interface{}{
+				"remote": map[string]interface{}{
+					remoteCluster: map[string]interface{}{
+						"mode":          "proxy",
+						"proxy_address": proxyAddress,
+					},
+				},
+			},
+		},
+	}
+	if _, err := es.client.PerformRequest(ctx, elastic.PerformRequestOptions{Method: http.MethodPut, Path: "/_cluster/settings", Body: settings}); err != nil {
+		return fmt.Errorf("failed to register remote cluster %s: %w", remoteCluster, err)
+	}
+
+	autoFollow := map[string]interface{}{
+		"remote_cluster":        remoteCluster,
+		"leader_index_patterns": leaderIndexPatterns,
+		"follow_index_pattern":  "{{leader_index}}",
+	}
+	path := fmt.Sprintf("/_ccr/auto_follow/%s", secondaryElasticsearchAutoFollowPattern)
+	if _, err := es.client.PerformRequest(ctx, elastic.PerformRequestOptions{Method: http.MethodPut, Path: path, Body: autoFollow}); err != nil {
+		return fmt.Errorf("failed to create auto-follow pattern %s: %w", secondaryElasticsearchAutoFollowPattern, err)
+	}
+	return nil
+}
@@ -22,6 +22,8 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -29,6 +31,13 @@ import (
 	elastic "github.com/olivere/elastic/v7"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 )
 
 const (
@@ -114,6 +123,186 @@ var _ = Describe("Elasticsearch tests", func() {
 			Expect(err).To(BeNil())
 			Expect(trt.hasUpdatedPolicy).To(BeTrue())
 		})
+		It("should add a policy for the es-gateway audit log only when it is sent to Elasticsearch", func() {
+			var retention int32 = 91
+			ls := &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{
+				Nodes: &operatorv1.Nodes{Count: 1},
+				Retention: &operatorv1.Retention{
+					Flows: &retention, AuditReports: &retention, Snapshots: &retention,
+					ComplianceReports: &retention, DNSLogs: &retention, BGPLogs: &retention,
+				},
+			}}
+			Expect(ILMPolicies(ls)).NotTo(HaveKey("tigera_secure_ee_esgateway_audit_policy"))
+
+			ls.Spec.ESGatewayAuditLog = &operatorv1.ESGatewayAuditLog{}
+			Expect(ILMPolicies(ls)).NotTo(HaveKey("tigera_secure_ee_esgateway_audit_policy"))
+
+			sink := operatorv1.ESGatewayAuditLogSinkElasticsearch
+			ls.Spec.ESGatewayAuditLog.Sink = &sink
+			policies := ILMPolicies(ls)
+			Expect(policies).To(HaveKey("tigera_secure_ee_esgateway_audit_policy"))
+			Expect(policies["tigera_secure_ee_esgateway_audit_policy"]).To(Equal(policies["tigera_secure_ee_audit_kube_policy"]))
+		})
+	})
+
+	Context("index curation", func() {
+		It("should only curate the indices that match the curated index prefixes", func() {
+			ls := &operatorv1.LogStorage{}
+			Expect(CheckCuratedIndex(ls, "tigera_secure_ee_flows.cluster.linseed-000001")).To(Succeed())
+			Expect(CheckCuratedIndex(ls, "logs-app-000001")).NotTo(Succeed())
+			Expect(CheckCuratedIndex(ls, ".kibana_1")).NotTo(Succeed())
+			Expect(CheckCuratedIndex(ls, ".security-7")).NotTo(Succeed())
+
+			ls.Spec.CuratedIndexPrefixes = []string{"tigera_secure_ee_dns."}
+			Expect(CheckCuratedIndex(ls, "tigera_secure_ee_dns.cluster.linseed-000001")).To(Succeed())
+			Expect(CheckCuratedIndex(ls, "tigera_secure_ee_flows.cluster.linseed-000001")).NotTo(Succeed())
+		})
+
+		It("should refuse to bind system indices and index patterns to an ILM policy", func() {
+			es := &esClient{}
+			for _, index := range []string{".kibana_1", ".security-7", "*", "tigera_secure_ee_flows.*", "tigera_secure_ee_flows.a,.kibana_1"} {
+				err := es.PutIndexSettings(context.Background(), index, map[string]string{"index.lifecycle.name": "tigera_secure_ee_flows_policy"})
+				Expect(err).To(MatchError(ContainSubstring("refusing")), index)
+			}
+		})
+	})
+
+	Context("user provisioning", func() {
+		var (
+			eClient *esClient
+			prt     *provisioningRoundTripper
+		)
+
+		BeforeEach(func() {
+			prt = &provisioningRoundTripper{failures: map[string]bool{}}
+			eClient = mockElasticClient(&http.Client{Transport: prt}, baseURI)
+		})
+
+		It("should create the shared roles once and then all the users", func() {
+			var users []*User
+			for i := 0; i < 20; i++ {
+				user := LinseedUser(fmt.Sprintf("cluster-%d", i), "")
+				user.Password = "password"
+				user.Roles = append(user.Roles, Role{Name: "shared", Definition: &RoleDefinition{}})
+				users = append(users, user)
+			}
+
+			Expect(eClient.CreateUsers(context.Background(), users...)).To(Succeed())
+			Expect(prt.requests["/_security/role/shared"]).To(Equal(1))
+			for _, user := range users {
+				Expect(prt.requests["/_security/role/"+user.Roles[0].Name]).To(Equal(1))
+				Expect(prt.requests["/_security/user/"+user.Username]).To(Equal(1))
+			}
+			Expect(prt.maxInFlight).To(BeNumerically(">", 1))
+			Expect(prt.maxInFlight).To(BeNumerically("<=", esProvisioningWorkers))
+		})
+
+		It("should attempt to create all the users and report each that failed", func() {
+			var users []*User
+			for _, name := range []string{"a", "b", "c"} {
+				users = append(users, &User{Username: name, Password: "password"})
+			}
+			prt.failures["/_security/user/a"] = true
+			prt.failures["/_security/user/c"] = true
+
+			err := eClient.CreateUsers(context.Background(), users...)
+			Expect(err).To(MatchError(ContainSubstring("failed to create user a")))
+			Expect(err).To(MatchError(ContainSubstring("failed to create user c")))
+			Expect(err).NotTo(MatchError(ContainSubstring("failed to create user b")))
+			Expect(prt.requests["/_security/user/b"]).To(Equal(1))
+		})
+	})
+
+	Context("reindex", func() {
+		var trt *taskRoundTripper
+
+		BeforeEach(func() {
+			trt = &taskRoundTripper{path: "/_tasks/node:1"}
+		})
+
+		It("should report the progress of a running task", func() {
+			trt.body = `{"completed":false,"task":{"status":{"total":100,"created":40,"updated":2}}}`
+			progress, err := mockElasticClient(&http.Client{Transport: trt}, baseURI).GetReindexProgress(context.Background(), "node:1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*progress).To(Equal(ReindexProgress{Total: 100, Copied: 42}))
+		})
+
+		It("should report a task that failed to copy some documents as failed", func() {
+			trt.body = `{"completed":true,"task":{"status":{"total":3,"created":1}},"response":{"failures":[` +
+				`{"index":"new","id":"a","cause":{"reason":"mapper_parsing_exception"}},` +
+				`{"index":"new","id":"b","cause":{"reason":"mapper_parsing_exception"}}]}}`
+			progress, err := mockElasticClient(&http.Client{Transport: trt}, baseURI).GetReindexProgress(context.Background(), "node:1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(progress.Completed).To(BeTrue())
+			Expect(progress.Failure).To(ContainSubstring("2 documents failed"))
+			Expect(progress.Failure).To(ContainSubstring("mapper_parsing_exception"))
+		})
+
+		It("should report the error of a task that failed", func() {
+			trt.body = `{"completed":true,"task":{"status":{"total":3}},"error":{"type":"index_not_found_exception","reason":"no such index [old]"}}`
+			progress, err := mockElasticClient(&http.Client{Transport: trt}, baseURI).GetReindexProgress(context.Background(), "node:1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(progress.Failure).To(Equal("no such index [old]"))
+		})
+
+		It("should not report a task that copied all the documents as failed", func() {
+			trt.body = `{"completed":true,"task":{"status":{"total":3,"created":3}},"response":{"failures":[]}}`
+			progress, err := mockElasticClient(&http.Client{Transport: trt}, baseURI).GetReindexProgress(context.Background(), "node:1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*progress).To(Equal(ReindexProgress{Completed: true, Total: 3, Copied: 3}))
+		})
+	})
+
+	Context("insecureSkipTLSVerify", func() {
+		var (
+			ctx context.Context
+			cli client.Client
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+			cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+			Expect(cli.Create(ctx, &operatorv1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-a"},
+				Spec: operatorv1.TenantSpec{ID: "tenant-a", Elastic: &operatorv1.TenantElasticSpec{
+					URL: "https://tenant-a.es:9200", MutualTLS: true, InsecureSkipTLSVerify: true,
+				}},
+			})).ShouldNot(HaveOccurred())
+			Expect(cli.Create(ctx, &operatorv1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-b"},
+				Spec: operatorv1.TenantSpec{ID: "tenant-b", Elastic: &operatorv1.TenantElasticSpec{
+					URL: "https://tenant-b.es:9200", MutualTLS: true,
+				}},
+			})).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			AllowInsecureSkipTLSVerify(false)
+		})
+
+		It("should be rejected unless the feature gate is enabled", func() {
+			_, err := externalElasticInsecureSkipTLSVerify(ctx, cli, "https://tenant-a.es:9200")
+			Expect(err).To(MatchError(ContainSubstring("--allow-insecure-skip-tls-verify")))
+
+			insecure, err := externalElasticInsecureSkipTLSVerify(ctx, cli, "https://tenant-b.es:9200")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(insecure).To(BeFalse())
+		})
+
+		It("should only skip the verification of the endpoints of the tenants that set it", func() {
+			AllowInsecureSkipTLSVerify(true)
+
+			insecure, err := externalElasticInsecureSkipTLSVerify(ctx, cli, "https://tenant-a.es:9200")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(insecure).To(BeTrue())
+
+			insecure, err = externalElasticInsecureSkipTLSVerify(ctx, cli, "https://tenant-b.es:9200")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(insecure).To(BeFalse())
+		})
 	})
 })
 
@@ -215,6 +404,59 @@ func (t *testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 	}, nil
 }
 
+// provisioningRoundTripper accepts the requests to create roles and users, except those to the paths that it is told to
+// fail, and records how many were made to each path and how many were in flight at once.
+type provisioningRoundTripper struct {
+	lock        sync.Mutex
+	requests    map[string]int
+	failures    map[string]bool
+	inFlight    int
+	maxInFlight int
+}
+
+func (t *provisioningRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == "HEAD" {
+		return &http.Response{StatusCode: 200, Request: req, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+
+	t.lock.Lock()
+	if t.requests == nil {
+		t.requests = map[string]int{}
+	}
+	t.requests[req.URL.Path]++
+	t.inFlight++
+	if t.inFlight > t.maxInFlight {
+		t.maxInFlight = t.inFlight
+	}
+	fail := t.failures[req.URL.Path]
+	t.lock.Unlock()
+
+	// Give the other workers the time to send their requests.
+	time.Sleep(50 * time.Millisecond)
+
+	t.lock.Lock()
+	t.inFlight--
+	t.lock.Unlock()
+
+	if fail {
+		return &http.Response{StatusCode: 500, Request: req, Body: io.NopCloser(strings.NewReader(`{"error":"failed"}`))}, nil
+	}
+	return &http.Response{StatusCode: 200, Request: req, Body: io.NopCloser(strings.NewReader(`{"created":true}`))}, nil
+}
+
+// taskRoundTripper returns the body it is given for requests to the task at its path.
+type taskRoundTripper struct {
+	path string
+	body string
+}
+
+func (t *taskRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == "GET" && req.URL.Path == t.path {
+		return &http.Response{StatusCode: 200, Request: req, Body: io.NopCloser(strings.NewReader(t.body))}, nil
+	}
+	return &http.Response{StatusCode: 200, Request: req, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
 func mustOpen(name string) io.ReadCloser {
 	f, err := os.Open(name)
 	if err != nil {
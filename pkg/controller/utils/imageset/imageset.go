@@ -19,16 +19,25 @@ import (
 	"fmt"
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
 	operator "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/render"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/handler"
 )
 
+// archLabel is the well-known node label that reports the CPU architecture of the node.
+const archLabel = "kubernetes.io/arch"
+
 // ApplyImageSet gets the appropriate ImageSet, validates the ImageSet, and calls ResolveImages
-// passing in the ImageSet on each of the comps.
+// passing in the ImageSet on each of the comps. If all nodes in the cluster share a single architecture,
+// the architecture specific digests from the ImageSet are used and the comps are validated to only
+// deploy images that are published for that architecture.
 func ApplyImageSet(ctx context.Context, c client.Client, v operator.ProductVariant, comps ...render.Component) error {
 	imageSet, err := GetImageSet(ctx, c, v)
 	if err != nil {
@@ -39,7 +48,53 @@ func ApplyImageSet(ctx context.Context, c client.Client, v operator.ProductVaria
 		return err
 	}
 
-	return ResolveImages(imageSet, comps...)
+	imageSet, arch, err := SelectArchitecture(ctx, c, imageSet)
+	if err != nil {
+		return err
+	}
+
+	if err = ResolveImages(imageSet, comps...); err != nil {
+		return err
+	}
+
+	if len(components.UnsupportedImages(arch)) == 0 {
+		return nil
+	}
+
+	installation := &operator.Installation{}
+	if err = c.Get(ctx, client.ObjectKey{Name: "default"}, installation); err != nil {
+		return fmt.Errorf("failed to get installation: %w", err)
+	}
+	return ValidateArchitecture(&installation.Spec, imageSet, arch, comps...)
+}
+
+// SelectArchitecture returns the ImageSet to resolve images with for the architecture shared by all nodes
+// in the cluster, along with that architecture. If the nodes do not share an architecture, is is returned as-is.
+func SelectArchitecture(ctx context.Context, c client.Client, is *operator.ImageSet) (*operator.ImageSet, string, error) {
+	arch, err := GetClusterArchitecture(ctx, c)
+	if err != nil {
+		return nil, "", err
+	}
+	return components.ImageSetForArchitecture(is, arch), arch, nil
+}
+
+// GetClusterArchitecture returns the CPU architecture shared by all nodes in the cluster. If the nodes
+// do not all report the same architecture, or there are no nodes, an empty string is returned.
+func GetClusterArchitecture(ctx context.Context, cli client.Client) (string, error) {
+	nodes := &corev1.NodeList{}
+	if err := cli.List(ctx, nodes); err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	arch := ""
+	for _, n := range nodes.Items {
+		a := n.Labels[archLabel]
+		if a == "" || (arch != "" && a != arch) {
+			return "", nil
+		}
+		arch = a
+	}
+	return arch, nil
 }
 
 // Utility function to add a watch on ImageSet resources.
@@ -82,8 +137,8 @@ func GetImageSet(ctx context.Context, cli client.Client, v operator.ProductVaria
 	return nil, fmt.Errorf("ImageSets exist but none with the expected name %s", setName)
 }
 
-// ValidateImageSet validates that all the images in an ImageSet are images the operator uses
-// and that the Digest is in an allowed format.
+// ValidateImageSet validates that all the images in an ImageSet are images the operator uses,
+// that each image is listed once, and that the Digest and any overrides are in an allowed format.
 func ValidateImageSet(is *operator.ImageSet) error {
 	// None is valid
 	if is == nil {
@@ -120,9 +175,32 @@ func ValidateImageSet(is *operator.ImageSet) error {
 		if !strings.HasPrefix(img.Digest, "sha256:") {
 			invalidDigests = append(invalidDigests, fmt.Sprintf("%s@%s", img.Image, img.Digest))
 		}
+		for _, ad := range img.ArchitectureDigests {
+			if !strings.HasPrefix(ad.Digest, "sha256:") {
+				invalidDigests = append(invalidDigests, fmt.Sprintf("%s@%s (%s)", img.Image, ad.Digest, ad.Architecture))
+			}
+		}
+	}
+
+	duplicateImages := []string{}
+	invalidOverrides := []string{}
+	seen := map[string]bool{}
+	for _, img := range is.Spec.Images {
+		if seen[img.Image] {
+			duplicateImages = append(duplicateImages, img.Image)
+		}
+		seen[img.Image] = true
+
+		// The overrides each replace a single part of the image reference, so none of them can contain
+		// a digest and only the registry may contain a port.
+		if strings.Contains(img.Registry, "@") ||
+			strings.ContainsAny(img.ImagePath, "@:") || strings.HasSuffix(img.ImagePath, "/") ||
+			strings.ContainsAny(img.ImagePrefix, "@:/") {
+			invalidOverrides = append(invalidOverrides, img.Image)
+		}
 	}
 
-	if len(unknownImages) == 0 && len(invalidDigests) == 0 {
+	if len(unknownImages) == 0 && len(invalidDigests) == 0 && len(duplicateImages) == 0 && len(invalidOverrides) == 0 {
 		return nil
 	}
 
@@ -135,6 +213,14 @@ func ValidateImageSet(is *operator.ImageSet) error {
 	if len(invalidDigests) != 0 {
 		errMsgs = append(errMsgs, fmt.Sprintf("bad digest images: %s", strings.Join(invalidDigests, ", ")))
 	}
+
+	if len(duplicateImages) != 0 {
+		errMsgs = append(errMsgs, fmt.Sprintf("duplicate images: %s", strings.Join(duplicateImages, ", ")))
+	}
+
+	if len(invalidOverrides) != 0 {
+		errMsgs = append(errMsgs, fmt.Sprintf("bad override images: %s", strings.Join(invalidOverrides, ", ")))
+	}
 	return fmt.Errorf("ImageSet %s: %s", is.Name, strings.Join(errMsgs, "; "))
 }
 
@@ -153,3 +239,99 @@ func ResolveImages(is *operator.ImageSet, comps ...render.Component) error {
 
 	return fmt.Errorf("Invalid ImageSet: %s", strings.Join(errMsgs, ", "))
 }
+
+// ValidateArchitecture returns an error if any of the comps deploy an image that is not published for arch.
+// The images are compared against the references produced by GetReference for the given installation and
+// ImageSet, so ResolveImages must have been called on the comps with the same ImageSet.
+func ValidateArchitecture(installation *operator.InstallationSpec, is *operator.ImageSet, arch string, comps ...render.Component) error {
+	unsupportedImages := components.UnsupportedImages(arch)
+	if len(unsupportedImages) == 0 {
+		return nil
+	}
+
+	// With an ImageSet, the FIPS images resolve to the same reference as the multi-arch images that they share their
+	// image name with. The components render the FIPS images only in FIPS mode, so a shared reference is only taken to
+	// be a FIPS image then.
+	fips := operator.IsFIPSModeEnabled(installation.FIPSMode)
+	multiArch := map[string]bool{}
+	for _, c := range components.SupportedImages(arch) {
+		if ref, err := components.GetReference(c, installation.Registry, installation.ImagePath, installation.ImagePrefix, is); err == nil {
+			multiArch[ref] = true
+		}
+	}
+
+	unsupported := map[string]string{}
+	for _, c := range unsupportedImages {
+		if hasArchitectureDigest(is, c.Image, arch) {
+			// The ImageSet lists an image built for arch.
+			continue
+		}
+		ref, err := components.GetReference(c, installation.Registry, installation.ImagePath, installation.ImagePrefix, is)
+		if err != nil {
+			// The ImageSet does not contain the image, so no comp can have resolved it.
+			continue
+		}
+		if multiArch[ref] && !fips {
+			continue
+		}
+		unsupported[ref] = c.Image
+	}
+
+	var images []string
+	for _, comp := range comps {
+		if !comp.Ready() {
+			continue
+		}
+		objs, _ := comp.Objects()
+		for _, obj := range objs {
+			for _, container := range podContainers(obj) {
+				if img, ok := unsupported[container.Image]; ok {
+					images = append(images, img)
+				}
+			}
+		}
+	}
+
+	if len(images) == 0 {
+		return nil
+	}
+	return fmt.Errorf("images not available for the %s architecture used by all cluster nodes: %s", arch, strings.Join(images, ", "))
+}
+
+// hasArchitectureDigest returns true if the ImageSet lists a digest for arch for the image.
+func hasArchitectureDigest(is *operator.ImageSet, image, arch string) bool {
+	if is == nil {
+		return false
+	}
+	for _, img := range is.Spec.Images {
+		if img.Image != image {
+			continue
+		}
+		for _, ad := range img.ArchitectureDigests {
+			if ad.Architecture == arch {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// podContainers returns the containers and init containers of obj if it is a type that creates pods.
+func podContainers(obj client.Object) []corev1.Container {
+	var spec *corev1.PodSpec
+	switch x := obj.(type) {
+	case *appsv1.Deployment:
+		spec = &x.Spec.Template.Spec
+	case *appsv1.DaemonSet:
+		spec = &x.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		spec = &x.Spec.Template.Spec
+	case *batchv1.Job:
+		spec = &x.Spec.Template.Spec
+	case *batchv1.CronJob:
+		spec = &x.Spec.JobTemplate.Spec.Template.Spec
+	default:
+		return nil
+	}
+	return append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+}
@@ -23,6 +23,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	//"k8s.io/client-go/kubernetes/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,6 +33,7 @@ import (
 	operator "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/apis"
 	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/render"
 )
 
 var _ = Describe("imageset tests", func() {
@@ -107,9 +110,156 @@ var _ = Describe("imageset tests", func() {
 			err = ApplyImageSet(context.Background(), c, v)
 			Expect(err).ToNot(BeNil())
 			Expect(err.Error()).To(ContainSubstring("bad digest images"))
+			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(
+				&operator.ImageSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nm,
+					},
+					Spec: operator.ImageSetSpec{
+						Images: []operator.Image{
+							{Image: "calico/cni", Digest: "sha256:xxxxxxxxx"},
+							{Image: "calico/cni", Digest: "sha256:yyyyyyyyy"},
+						},
+					},
+				},
+			).Build()
+			err = ApplyImageSet(context.Background(), c, v)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("duplicate images: calico/cni"))
+			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(
+				&operator.ImageSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nm,
+					},
+					Spec: operator.ImageSetSpec{
+						Images: []operator.Image{
+							{Image: "calico/cni", Digest: "sha256:xxxxxxxxx", Registry: "internal.io:5000/"},
+							{Image: "calico/typha", Digest: "sha256:xxxxxxxxx", ImagePrefix: "prefix/"},
+						},
+					},
+				},
+			).Build()
+			err = ApplyImageSet(context.Background(), c, v)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("bad override images: calico/typha"))
 		},
 			Entry("Calico variant", operator.Calico),
 			Entry("Enterprise variant", operator.TigeraSecureEnterprise),
 		)
 	})
+
+	Context("architecture specific images", func() {
+		node := func(name, arch string) *corev1.Node {
+			return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/arch": arch}}}
+		}
+		deployment := func(image string) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: image}}},
+					},
+				},
+			}
+		}
+		installation := &operator.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+
+		It("should only report an architecture shared by all nodes", func() {
+			c := fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(node("a", "arm64"), node("b", "arm64")).Build()
+			Expect(GetClusterArchitecture(context.Background(), c)).To(Equal("arm64"))
+
+			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(node("a", "arm64"), node("b", "amd64")).Build()
+			Expect(GetClusterArchitecture(context.Background(), c)).To(Equal(""))
+
+			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).Build()
+			Expect(GetClusterArchitecture(context.Background(), c)).To(Equal(""))
+		})
+
+		It("should use the digest for the cluster architecture", func() {
+			is := &operator.ImageSet{
+				Spec: operator.ImageSetSpec{
+					Images: []operator.Image{
+						{
+							Image:  "calico/node",
+							Digest: "sha256:multiarch",
+							ArchitectureDigests: []operator.ArchitectureDigest{
+								{Architecture: "amd64", Digest: "sha256:amd64"},
+								{Architecture: "arm64", Digest: "sha256:arm64"},
+							},
+						},
+						{Image: "calico/cni", Digest: "sha256:multiarch"},
+					},
+				},
+			}
+			Expect(components.ImageSetForArchitecture(is, "").Spec.Images[0].Digest).To(Equal("sha256:multiarch"))
+			armIS := components.ImageSetForArchitecture(is, "arm64")
+			Expect(armIS.Spec.Images[0].Digest).To(Equal("sha256:arm64"))
+			Expect(armIS.Spec.Images[1].Digest).To(Equal("sha256:multiarch"))
+			// The original ImageSet must not be modified.
+			Expect(is.Spec.Images[0].Digest).To(Equal("sha256:multiarch"))
+		})
+
+		It("should reject a bad architecture digest", func() {
+			err := ValidateImageSet(&operator.ImageSet{
+				Spec: operator.ImageSetSpec{
+					Images: []operator.Image{
+						{
+							Image:               "calico/node",
+							Digest:              "sha256:multiarch",
+							ArchitectureDigests: []operator.ArchitectureDigest{{Architecture: "arm64", Digest: "arm64"}},
+						},
+					},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bad digest images"))
+		})
+
+		It("should reject components without an image for an arm64 only cluster", func() {
+			dpiImage, err := components.GetReference(components.ComponentDeepPacketInspection, "", "", "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			nodeImage, err := components.GetReference(components.ComponentTigeraNode, "", "", "", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			c := fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(node("a", "arm64"), installation).Build()
+			Expect(ApplyImageSet(context.Background(), c, operator.TigeraSecureEnterprise, render.NewPassthrough(deployment(nodeImage)))).To(Succeed())
+
+			err = ApplyImageSet(context.Background(), c, operator.TigeraSecureEnterprise, render.NewPassthrough(deployment(dpiImage)))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(components.ComponentDeepPacketInspection.Image))
+
+			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(node("a", "arm64"), node("b", "amd64"), installation).Build()
+			Expect(ApplyImageSet(context.Background(), c, operator.TigeraSecureEnterprise, render.NewPassthrough(deployment(dpiImage)))).To(Succeed())
+		})
+
+		It("should accept the multi-arch images of an ImageSet for an arm64 only cluster", func() {
+			is := &operator.ImageSet{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("calico-%s", components.CalicoRelease)},
+				Spec: operator.ImageSetSpec{
+					Images: []operator.Image{{Image: "calico/node", Digest: "sha256:multiarch"}},
+				},
+			}
+			nodeImage, err := components.GetReference(components.ComponentCalicoNode, "", "", "", is)
+			Expect(err).NotTo(HaveOccurred())
+
+			// The FIPS image shares its name, and so its reference, with the multi-arch image.
+			c := fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(node("a", "arm64"), installation, is).Build()
+			Expect(ApplyImageSet(context.Background(), c, operator.Calico, render.NewPassthrough(deployment(nodeImage)))).To(Succeed())
+
+			fipsMode := operator.FIPSModeEnabled
+			fipsInstallation := installation.DeepCopy()
+			fipsInstallation.Spec.FIPSMode = &fipsMode
+			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(node("a", "arm64"), fipsInstallation, is).Build()
+			err = ApplyImageSet(context.Background(), c, operator.Calico, render.NewPassthrough(deployment(nodeImage)))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("calico/node"))
+
+			// An image built for arm64 is accepted in FIPS mode too.
+			is.Spec.Images[0].ArchitectureDigests = []operator.ArchitectureDigest{{Architecture: "arm64", Digest: "sha256:arm64"}}
+			nodeImage, err = components.GetReference(components.ComponentCalicoNode, "", "", "", components.ImageSetForArchitecture(is, "arm64"))
+			Expect(err).NotTo(HaveOccurred())
+			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(node("a", "arm64"), fipsInstallation, is).Build()
+			Expect(ApplyImageSet(context.Background(), c, operator.Calico, render.NewPassthrough(deployment(nodeImage)))).To(Succeed())
+		})
+	})
 })
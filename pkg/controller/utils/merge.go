@@ -108,6 +108,16 @@ func OverrideInstallationSpec(cfg, override operatorv1.InstallationSpec) operato
 		inst.TyphaMetricsPort = override.TyphaMetricsPort
 	}
 
+	switch compareFields(inst.FlowLogs, override.FlowLogs) {
+	case BOnlySet, Different:
+		inst.FlowLogs = override.FlowLogs
+	}
+
+	switch compareFields(inst.TyphaAutoscaling, override.TyphaAutoscaling) {
+	case BOnlySet, Different:
+		inst.TyphaAutoscaling = override.TyphaAutoscaling
+	}
+
 	switch compareFields(inst.FlexVolumePath, override.FlexVolumePath) {
 	case BOnlySet, Different:
 		inst.FlexVolumePath = override.FlexVolumePath
@@ -209,6 +219,46 @@ func OverrideInstallationSpec(cfg, override operatorv1.InstallationSpec) operato
 		inst.ServiceCIDRs = override.ServiceCIDRs
 	}
 
+	switch compareFields(inst.ImageVerification, override.ImageVerification) {
+	case BOnlySet, Different:
+		inst.ImageVerification = override.ImageVerification.DeepCopy()
+	}
+
+	switch compareFields(inst.RolloutPolicy, override.RolloutPolicy) {
+	case BOnlySet, Different:
+		inst.RolloutPolicy = override.RolloutPolicy.DeepCopy()
+	}
+
+	switch compareFields(inst.RollbackPolicy, override.RollbackPolicy) {
+	case BOnlySet, Different:
+		inst.RollbackPolicy = override.RollbackPolicy.DeepCopy()
+	}
+
+	switch compareFields(inst.Features, override.Features) {
+	case BOnlySet, Different:
+		inst.Features = override.Features.DeepCopy()
+	}
+
+	switch compareFields(inst.ResourceAdoption, override.ResourceAdoption) {
+	case BOnlySet, Different:
+		inst.ResourceAdoption = override.ResourceAdoption
+	}
+
+	switch compareFields(inst.ServiceMesh, override.ServiceMesh) {
+	case BOnlySet, Different:
+		inst.ServiceMesh = override.ServiceMesh.DeepCopy()
+	}
+
+	switch compareFields(inst.NamespaceDefaultDeny, override.NamespaceDefaultDeny) {
+	case BOnlySet, Different:
+		inst.NamespaceDefaultDeny = override.NamespaceDefaultDeny
+	}
+
+	switch compareFields(inst.WireGuard, override.WireGuard) {
+	case BOnlySet, Different:
+		inst.WireGuard = override.WireGuard.DeepCopy()
+	}
+
 	return inst
 }
 
@@ -411,6 +461,11 @@ func mergeCalicoNodeDaemonSet(cfg, override *operatorv1.CalicoNodeDaemonSet) *op
 			out.Template = mergeTemplateSpec(out.Template, override.Template)
 		}
 
+		switch compareFields(out.Strategy, override.Strategy) {
+		case BOnlySet, Different:
+			out.Strategy = override.Strategy.DeepCopy()
+		}
+
 		return out
 	}
 
@@ -499,6 +554,11 @@ func mergeCalicoNodeWindowsDaemonSet(cfg, override *operatorv1.CalicoNodeWindows
 			out.Template = mergeTemplateSpec(out.Template, override.Template)
 		}
 
+		switch compareFields(out.Strategy, override.Strategy) {
+		case BOnlySet, Different:
+			out.Strategy = override.Strategy.DeepCopy()
+		}
+
 		return out
 	}
 
@@ -576,6 +636,11 @@ func mergeCSINodeDriverDaemonset(cfg, override *operatorv1.CSINodeDriverDaemonSe
 			out.Template = mergeTemplateSpec(out.Template, override.Template)
 		}
 
+		switch compareFields(out.Strategy, override.Strategy) {
+		case BOnlySet, Different:
+			out.Strategy = override.Strategy.DeepCopy()
+		}
+
 		return out
 	}
 
@@ -659,6 +724,11 @@ func mergeCalicoKubeControllersDeployment(cfg, override *operatorv1.CalicoKubeCo
 			out.Template = mergeTemplateSpec(out.Template, override.Template)
 		}
 
+		switch compareFields(out.Strategy, override.Strategy) {
+		case BOnlySet, Different:
+			out.Strategy = override.Strategy.DeepCopy()
+		}
+
 		return out
 	}
 
@@ -840,6 +910,11 @@ func mergeCalicoWindowsUpgradeDaemonSet(cfg, override *operatorv1.CalicoWindowsU
 			out.Template = mergeTemplateSpec(out.Template, override.Template)
 		}
 
+		switch compareFields(out.Strategy, override.Strategy) {
+		case BOnlySet, Different:
+			out.Strategy = override.Strategy.DeepCopy()
+		}
+
 		return out
 	}
 
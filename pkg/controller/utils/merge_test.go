@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	opv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/test"
 )
 
@@ -729,6 +730,20 @@ var _ = Describe("Installation merge tests", func() {
 			Entry("Both set equal", intPtr(23), intPtr(23), intPtr(23)),
 			Entry("Both set not equal", intPtr(23), intPtr(42), intPtr(42)),
 		)
+
+		_strategy1 := &opv1.DaemonSetUpdateStrategy{RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: ptr.IntOrStrPtr("1")}}
+		_strategy2 := &opv1.DaemonSetUpdateStrategy{RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: ptr.IntOrStrPtr("10%")}}
+		DescribeTable("merge strategy", func(main, second, expect *opv1.DaemonSetUpdateStrategy) {
+			m.CalicoNodeDaemonSet.Spec.Strategy = main
+			s.CalicoNodeDaemonSet.Spec.Strategy = second
+			inst := OverrideInstallationSpec(m, s)
+			Expect(inst.CalicoNodeDaemonSet.Spec.Strategy).To(Equal(expect))
+		},
+			Entry("Both unset", nil, nil, nil),
+			Entry("Main only set", _strategy1, nil, _strategy1),
+			Entry("Second only set", nil, _strategy2, _strategy2),
+			Entry("Both set not equal", _strategy1, _strategy2, _strategy2),
+		)
 		DescribeTable("merge pod template metadata", func(main, second, expect *opv1.Metadata) {
 			m.CalicoNodeDaemonSet.Spec.Template.Metadata = main
 			s.CalicoNodeDaemonSet.Spec.Template.Metadata = second
@@ -0,0 +1,177 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+// lastKnownGoodTemplateKey is the key of the ConfigMap that holds the last-known-good pod template of a workload.
+const lastKnownGoodTemplateKey = "template"
+
+// setRollbackState annotates a Deployment or DaemonSet with the hash of its rendered pod template and the time at
+// which its rollout started, so that the rollout controller can tell when the rollout failed. The last-known-good
+// pod template is recorded by the rollout controller, see SetLastKnownGoodTemplate, and its hash is kept by
+// mergeState. If the rollout controller rolled the workload back from the rendered pod template, the last-known-good
+// pod template is used instead, so that the rollback is not undone until a different pod template is rendered.
+func (c componentHandler) setRollbackState(ctx context.Context, obj client.Object, policy *operatorv1.RollbackPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	cur, template := newWorkload(obj)
+	if template == nil {
+		return nil
+	}
+
+	hash, err := TemplateHash(template)
+	if err != nil {
+		return err
+	}
+	annotations := common.MapExistsOrInitialize(obj.GetAnnotations())
+	annotations[common.RenderedTemplateHashAnnotation] = hash
+	annotations[common.RolloutStartedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	obj.SetAnnotations(annotations)
+
+	if err = c.client.Get(ctx, client.ObjectKeyFromObject(obj), cur); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	curAnnotations := cur.GetAnnotations()
+	if curAnnotations[common.RenderedTemplateHashAnnotation] != hash {
+		// A new pod template was rendered, so a new rollout starts.
+		return nil
+	}
+	if started, ok := curAnnotations[common.RolloutStartedAnnotation]; ok {
+		annotations[common.RolloutStartedAnnotation] = started
+	}
+	if curAnnotations[common.RolledBackFromAnnotation] == hash {
+		lastKnownGood, err := GetLastKnownGoodTemplate(ctx, c.client, cur)
+		if err != nil {
+			return err
+		}
+		if lastKnownGood != nil {
+			*template = *lastKnownGood
+		}
+	}
+	return nil
+}
+
+// TemplateHash returns the hash of the given pod template, as recorded by the RenderedTemplateHashAnnotation and the
+// LastKnownGoodTemplateHashAnnotation.
+func TemplateHash(template *v1.PodTemplateSpec) (string, error) {
+	b, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	return rmeta.AnnotationHash(string(b)), nil
+}
+
+// LastKnownGoodTemplateName returns the name of the ConfigMap that holds the last-known-good pod template of the given
+// Deployment or DaemonSet. The ConfigMap is in the namespace of the workload.
+func LastKnownGoodTemplateName(obj client.Object) string {
+	kind := "deployment"
+	if _, ok := obj.(*apps.DaemonSet); ok {
+		kind = "daemonset"
+	}
+	return fmt.Sprintf("%s-%s-last-known-good", kind, obj.GetName())
+}
+
+// GetLastKnownGoodTemplate returns the last-known-good pod template of the given Deployment or DaemonSet, or nil if it
+// has none. The pod template is only returned if it matches the LastKnownGoodTemplateHashAnnotation of the workload.
+func GetLastKnownGoodTemplate(ctx context.Context, cli client.Client, obj client.Object) (*v1.PodTemplateSpec, error) {
+	hash := obj.GetAnnotations()[common.LastKnownGoodTemplateHashAnnotation]
+	if hash == "" {
+		return nil, nil
+	}
+	cm := &v1.ConfigMap{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: LastKnownGoodTemplateName(obj), Namespace: obj.GetNamespace()}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	data, ok := cm.Data[lastKnownGoodTemplateKey]
+	if !ok || rmeta.AnnotationHash(data) != hash {
+		return nil, nil
+	}
+	template := &v1.PodTemplateSpec{}
+	if err := json.Unmarshal([]byte(data), template); err != nil {
+		return nil, fmt.Errorf("failed to read the last-known-good pod template of %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+	}
+	return template, nil
+}
+
+// SetLastKnownGoodTemplate records the given pod template as the last-known-good pod template of the given Deployment
+// or DaemonSet. The pod template is stored in a ConfigMap owned by the workload, and its hash is set as the
+// LastKnownGoodTemplateHashAnnotation of the workload, which the caller must update.
+func SetLastKnownGoodTemplate(ctx context.Context, cli client.Client, obj client.Object, template *v1.PodTemplateSpec) error {
+	b, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+	data := string(b)
+
+	cm := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: LastKnownGoodTemplateName(obj), Namespace: obj.GetNamespace()}}
+	if _, err = controllerutil.CreateOrUpdate(ctx, cli, cm, func() error {
+		kind := "Deployment"
+		if _, ok := obj.(*apps.DaemonSet); ok {
+			kind = "DaemonSet"
+		}
+		// The ConfigMap is garbage collected along with the workload.
+		cm.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: apps.SchemeGroupVersion.String(),
+			Kind:       kind,
+			Name:       obj.GetName(),
+			UID:        obj.GetUID(),
+		}}
+		cm.Data = map[string]string{lastKnownGoodTemplateKey: data}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	annotations := common.MapExistsOrInitialize(obj.GetAnnotations())
+	annotations[common.LastKnownGoodTemplateHashAnnotation] = rmeta.AnnotationHash(data)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// newWorkload returns an empty object of the same type as obj and the pod template of obj, if obj is a workload whose
+// rollouts can be rolled back.
+func newWorkload(obj client.Object) (client.Object, *v1.PodTemplateSpec) {
+	switch x := obj.(type) {
+	case *apps.Deployment:
+		return &apps.Deployment{}, &x.Spec.Template
+	case *apps.DaemonSet:
+		return &apps.DaemonSet{}, &x.Spec.Template
+	}
+	return nil, nil
+}
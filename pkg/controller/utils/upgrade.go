@@ -0,0 +1,112 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+
+	gv "github.com/hashicorp/go-version"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/status"
+)
+
+// elasticsearchLastMinors is the last minor release of each Elasticsearch major version. Elasticsearch only supports
+// upgrading to the next major version from the last minor release of the previous one.
+var elasticsearchLastMinors = map[int]int{
+	6: 8,
+	7: 17,
+}
+
+// ValidateReleaseUpgrade returns an error if upgrading the components from the installed release to the target
+// release is not supported. Components of adjacent releases are compatible with each other while they are upgraded,
+// e.g. Linseed with Elasticsearch and the manager with the API server, but components that are more than one minor
+// release apart are not, so upgrades may not skip a minor release. For the same reason, the only downgrade that is
+// supported is the rollback to the previous minor release, e.g. after an upgrade that did not go well. Releases that
+// aren't valid versions, e.g. development builds, are not validated.
+func ValidateReleaseUpgrade(installed, target string) error {
+	from, err := gv.NewVersion(installed)
+	if err != nil {
+		return nil
+	}
+	to, err := gv.NewVersion(target)
+	if err != nil {
+		return nil
+	}
+
+	fromMajor, fromMinor := majorMinor(from)
+	toMajor, toMinor := majorMinor(to)
+	if toMajor < fromMajor {
+		return fmt.Errorf("downgrading from %s to %s is not supported", installed, target)
+	}
+	if toMajor == fromMajor && toMinor < fromMinor-1 {
+		return fmt.Errorf("downgrading from %s to %s is not supported; only rolling back to v%d.%d is",
+			installed, target, fromMajor, fromMinor-1)
+	}
+	if toMajor == fromMajor && toMinor > fromMinor+1 {
+		return fmt.Errorf("upgrading from %s to %s skips a minor release, which is not supported; upgrade to v%d.%d first",
+			installed, target, fromMajor, fromMinor+1)
+	}
+	return nil
+}
+
+// ValidateElasticsearchUpgrade returns an error if upgrading Elasticsearch from the running version to the target
+// version is not supported. Elasticsearch cannot be downgraded, since it cannot read data written by a later
+// version, and only supports upgrading to the next major version from the last minor release of the previous one.
+func ValidateElasticsearchUpgrade(running, target string) error {
+	from, err := gv.NewVersion(running)
+	if err != nil {
+		return nil
+	}
+	to, err := gv.NewVersion(target)
+	if err != nil {
+		return nil
+	}
+
+	if to.LessThan(from) {
+		return fmt.Errorf("downgrading Elasticsearch from %s to %s is not supported", running, target)
+	}
+	fromMajor, fromMinor := majorMinor(from)
+	toMajor, _ := majorMinor(to)
+	if toMajor == fromMajor {
+		return nil
+	}
+	if toMajor > fromMajor+1 {
+		return fmt.Errorf("upgrading Elasticsearch from %s to %s skips a major version, which is not supported", running, target)
+	}
+	if lastMinor, ok := elasticsearchLastMinors[fromMajor]; ok && fromMinor < lastMinor {
+		return fmt.Errorf("upgrading Elasticsearch from %s to %s is not supported; Elasticsearch must be upgraded to %d.%d first",
+			running, target, fromMajor, lastMinor)
+	}
+	return nil
+}
+
+// UpgradeBlocked returns an error if the Installation has the UpgradeBlocked condition, i.e. the installation
+// controller found that the upgrade to the release of this operator is not supported. Other controllers must not
+// upgrade their components while it is blocked.
+func UpgradeBlocked(install *operatorv1.Installation) error {
+	c := meta.FindStatusCondition(install.Status.Conditions, operatorv1.UpgradeBlockedCondition)
+	if c == nil || c.Status != metav1.ConditionTrue {
+		return nil
+	}
+	return status.WithCode(operatorv1.UpgradeNotSupported, fmt.Errorf("upgrade is blocked: %s", c.Message))
+}
+
+func majorMinor(v *gv.Version) (int, int) {
+	s := v.Segments()
+	return s[0], s[1]
+}
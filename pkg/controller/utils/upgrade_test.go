@@ -0,0 +1,144 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+)
+
+var _ = Describe("Upgrade validation", func() {
+	DescribeTable("release upgrades",
+		func(installed, target string, supported bool) {
+			err := ValidateReleaseUpgrade(installed, target)
+			if supported {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("same release", "v3.20.0", "v3.20.0", true),
+		Entry("patch release", "v3.20.0", "v3.20.2", true),
+		Entry("next minor release", "v3.20.2", "v3.21.0", true),
+		Entry("next major release", "v3.29.1", "v4.0.0", true),
+		Entry("skipped minor release", "v3.19.1", "v3.21.0", false),
+		Entry("rollback to the previous minor release", "v3.21.0", "v3.20.3", true),
+		Entry("downgrade past the previous minor release", "v3.21.0", "v3.19.3", false),
+		Entry("major downgrade", "v4.0.0", "v3.29.0", false),
+		Entry("development build installed", "master", "v3.21.0", true),
+		Entry("development build target", "v3.19.0", "master", true),
+	)
+
+	It("should name the release to upgrade to first", func() {
+		Expect(ValidateReleaseUpgrade("v3.19.1", "v3.21.0")).To(MatchError(ContainSubstring("upgrade to v3.20 first")))
+	})
+
+	It("should name the release that can be rolled back to", func() {
+		Expect(ValidateReleaseUpgrade("v3.21.0", "v3.19.3")).To(MatchError(ContainSubstring("only rolling back to v3.20 is")))
+	})
+
+	DescribeTable("Elasticsearch upgrades",
+		func(running, target string, supported bool) {
+			err := ValidateElasticsearchUpgrade(running, target)
+			if supported {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("not running yet", "", "7.17.22", true),
+		Entry("same version", "7.17.22", "7.17.22", true),
+		Entry("minor upgrade", "7.16.3", "7.17.22", true),
+		Entry("major upgrade from the last minor", "7.17.22", "8.13.0", true),
+		Entry("major upgrade from an earlier minor", "7.16.3", "8.13.0", false),
+		Entry("skipped major version", "6.8.23", "8.13.0", false),
+		Entry("downgrade", "8.13.0", "7.17.22", false),
+	)
+
+	Context("ElasticsearchIncompatibilities", func() {
+		var ls *operatorv1.LogStorage
+
+		BeforeEach(func() {
+			var retention int32 = 8
+			ls = &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{
+				Nodes: &operatorv1.Nodes{Count: 1},
+				Retention: &operatorv1.Retention{
+					Flows: &retention, AuditReports: &retention, Snapshots: &retention,
+					ComplianceReports: &retention, DNSLogs: &retention, BGPLogs: &retention,
+				},
+			}}
+		})
+
+		It("should find no incompatibilities in the constructs of the operator for the supported major versions", func() {
+			Expect(ElasticsearchIncompatibilities(ls, "6.8.23", "7.17.22")).To(BeEmpty())
+			Expect(ElasticsearchIncompatibilities(ls, "7.17.22", "8.13.0")).To(BeEmpty())
+		})
+
+		It("should report the ILM constructs that the new major version removed, with the policies that use them", func() {
+			original := ilmActions["readonly"]
+			ilmActions["readonly"] = elasticsearchSupport{since: "6.6.0", removedIn: "8.0.0"}
+			defer func() { ilmActions["readonly"] = original }()
+
+			incompatibilities := ElasticsearchIncompatibilities(ls, "7.17.22", "8.13.0")
+			Expect(incompatibilities).To(HaveLen(1))
+			Expect(incompatibilities[0]).To(HavePrefix("the ILM action readonly was removed in Elasticsearch 8.0.0; it is used by tigera_secure_ee_audit_ee_policy, "))
+			Expect(incompatibilities[0]).NotTo(ContainSubstring("tigera_secure_ee_events_policy"))
+		})
+
+		It("should report the constructs that are not available yet in an earlier major version", func() {
+			Expect(ElasticsearchIncompatibilities(ls, "7.17.22", "6.4.0")).To(ConsistOf(
+				"the /_ilm API is not available before Elasticsearch 6.6.0",
+				"the /_security API is not available before Elasticsearch 6.5.0",
+				ContainSubstring("the ILM action delete is not available before Elasticsearch 6.6.0"),
+				ContainSubstring("the ILM action readonly is not available"),
+				ContainSubstring("the ILM action rollover is not available"),
+				ContainSubstring("the ILM action set_priority is not available before Elasticsearch 6.7.0"),
+				ContainSubstring("the ILM rollover condition max_age is not available"),
+				ContainSubstring("the ILM rollover condition max_size is not available"),
+			))
+		})
+
+		It("should not validate upgrades within a major version or versions that aren't valid", func() {
+			original := ilmActions["readonly"]
+			ilmActions["readonly"] = elasticsearchSupport{since: "6.6.0", removedIn: "8.0.0"}
+			defer func() { ilmActions["readonly"] = original }()
+
+			Expect(ElasticsearchIncompatibilities(ls, "8.12.0", "8.13.0")).To(BeEmpty())
+			Expect(ElasticsearchIncompatibilities(ls, "", "8.13.0")).To(BeEmpty())
+		})
+	})
+
+	Context("UpgradeBlocked", func() {
+		It("should return an error while the UpgradeBlocked condition is true", func() {
+			install := &operatorv1.Installation{}
+			Expect(UpgradeBlocked(install)).NotTo(HaveOccurred())
+
+			install.Status.Conditions = []metav1.Condition{{
+				Type:    operatorv1.UpgradeBlockedCondition,
+				Status:  metav1.ConditionTrue,
+				Message: "upgrading from v3.19.1 to v3.21.0 skips a minor release",
+			}}
+			Expect(UpgradeBlocked(install)).To(MatchError(ContainSubstring("upgrading from v3.19.1 to v3.21.0")))
+
+			install.Status.Conditions[0].Status = metav1.ConditionFalse
+			Expect(UpgradeBlocked(install)).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -44,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
@@ -52,6 +53,7 @@ import (
 	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
+	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/logstorage/eck"
@@ -102,6 +104,15 @@ func IgnoreObject(obj runtime.Object) bool {
 	return false
 }
 
+// RequeueWithBackoff returns the result for a reconcile that cannot proceed until a resource it depends on becomes
+// available. The request is requeued with the exponential backoff of the controller's rate limiter, see
+// ctrlruntime.NewRateLimiter, which is reset once the request reconciles successfully. A watch on the resource may
+// still trigger a reconcile earlier. Readiness waits that are expected to be over shortly, such as for the tier watch
+// or the license API, keep requeueing after the fixed StandardRetry instead.
+func RequeueWithBackoff() reconcile.Result {
+	return reconcile.Result{Requeue: true}
+}
+
 func AddInstallationWatch(c ctrlruntime.Controller) error {
 	return c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{})
 }
@@ -168,6 +179,17 @@ func AddPeriodicReconcile(c ctrlruntime.Controller, period time.Duration, handle
 	return c.Watch(&source.Channel{Source: createPeriodicReconcileChannel(period)}, handler)
 }
 
+// AddDependencyWatch triggers a reconcile each time one of the given components, identified by the names of their
+// TigeraStatus, becomes available. It is used together with StatusManager.WaitForDependencies.
+func AddDependencyWatch(c ctrlruntime.Controller, handler handler.EventHandler, components ...string) error {
+	for _, component := range components {
+		if err := c.Watch(&source.Channel{Source: status.Readiness.Listen(component)}, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // AddSecretWatchWithLabel adds a secret watch for secrets with the given label in the given namespace.
 // If no namespace is provided, it watches cluster-wide.
 func AddSecretWatchWithLabel(c ctrlruntime.Controller, ns, label string) error {
@@ -238,6 +260,12 @@ func WaitToAddNetworkPolicyWatches(controller ctrlruntime.Controller, c kubernet
 		})
 	}
 
+	// The rules of NetworkPolicyOverrides are added to the policies when they are rendered, so reconcile when they
+	// change. Unlike the policies, they are served by a CRD that the operator installs, so they can be watched now.
+	if err := controller.WatchObject(&operatorv1.NetworkPolicyOverride{}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.Error(err, "Failed to watch NetworkPolicyOverrides")
+	}
+
 	// The success of a NetworkPolicy watch is not a dependency for resources to be installed or function correctly.
 	// Therefore, no ready flag is accepted or created for the watch.
 	WaitToAddResourceWatch(controller, c, log, nil, objs)
@@ -280,6 +308,18 @@ func IsAPIServerReady(client client.Client, l logr.Logger) bool {
 	return true
 }
 
+// IsReconcilePaused returns true if the reconciliation of the given custom resource is paused by the
+// common.ReconcileAnnotation, after reporting the component as paused in its TigeraStatus. Controllers call it once
+// they have found their custom resource and return without writing anything else to the cluster if it returns true.
+func IsReconcilePaused(meta *metav1.ObjectMeta, s status.StatusManager, l logr.Logger) bool {
+	if !common.IsReconcilePaused(meta) {
+		return false
+	}
+	s.SetMetaData(meta)
+	l.Info("Reconciliation is paused, skipping", "annotation", common.ReconcileAnnotation)
+	return true
+}
+
 func LogStorageExists(ctx context.Context, cli client.Client) (bool, error) {
 	instance := &operatorv1.LogStorage{}
 	err := cli.Get(ctx, DefaultTSEEInstanceKey, instance)
@@ -305,6 +345,19 @@ func GetLogCollector(ctx context.Context, cli client.Client) (*operatorv1.LogCol
 	return logCollector, nil
 }
 
+// GetWhisker returns the default Whisker instance, or nil if it does not exist.
+func GetWhisker(ctx context.Context, cli client.Client) (*operatorv1.Whisker, error) {
+	whisker := &operatorv1.Whisker{}
+	err := cli.Get(ctx, DefaultInstanceKey, whisker)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return whisker, nil
+}
+
 // FetchLicenseKey returns the license if it has been installed. It's useful
 // to prevent rollout of TSEE components that might require it.
 // It will return an error if the license is not installed/cannot be read
@@ -499,7 +552,8 @@ func GetInstallationStatus(ctx context.Context, client client.Client) (*operator
 
 // GetInstallation returns the current installation, for use by other controllers. It accounts for overlays and
 // returns the variant according to status.Variant, which is leveraged by other controllers to know when it is safe to
-// launch enterprise-dependent components.
+// launch enterprise-dependent components. It returns an error while the upgrade of the installation is blocked, so
+// that no component is upgraded.
 func GetInstallation(ctx context.Context, client client.Client) (operatorv1.ProductVariant, *operatorv1.InstallationSpec, error) {
 	// Fetch the Installation instance. We only support a single instance named "default".
 	instance := &operatorv1.Installation{}
@@ -507,6 +561,10 @@ func GetInstallation(ctx context.Context, client client.Client) (operatorv1.Prod
 		return instance.Status.Variant, nil, err
 	}
 
+	if err := UpgradeBlocked(instance); err != nil {
+		return instance.Status.Variant, nil, err
+	}
+
 	spec := instance.Spec
 
 	// update Installation with 'overlay'
@@ -562,8 +620,12 @@ func GetPacketCaptureAPI(ctx context.Context, cli client.Client) (*operatorv1.Pa
 
 // GetElasticLicenseType returns the license type from elastic-licensing ConfigMap that ECK operator keeps updated.
 func GetElasticLicenseType(ctx context.Context, cli client.Client, logger logr.Logger) (render.ElasticsearchLicenseType, error) {
+	namespace, err := ECKOperatorNamespace(ctx, cli)
+	if err != nil {
+		return render.ElasticsearchLicenseTypeUnknown, err
+	}
 	cm := &corev1.ConfigMap{}
-	err := cli.Get(ctx, client.ObjectKey{Name: eck.LicenseConfigMapName, Namespace: eck.OperatorNamespace}, cm)
+	err = cli.Get(ctx, client.ObjectKey{Name: eck.LicenseConfigMapName, Namespace: namespace}, cm)
 	if err != nil {
 		return render.ElasticsearchLicenseTypeUnknown, err
 	}
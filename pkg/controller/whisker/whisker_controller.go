@@ -0,0 +1,271 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whisker
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/controller/utils/imageset"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/render"
+	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
+	"github.com/tigera/operator/pkg/render/whisker"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+const ResourceName = "whisker"
+
+var log = logf.Log.WithName("controller_whisker")
+
+// Add creates a new Whisker Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.WhiskerEnabled {
+		// The Whisker feature gate is disabled, no need to start this controller.
+		return nil
+	}
+
+	r := newReconciler(mgr, opts)
+
+	c, err := ctrlruntime.NewController("whisker-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to create whisker-controller: %w", err)
+	}
+
+	return add(c)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, opts options.AddOptions) *ReconcileWhisker {
+	r := &ReconcileWhisker{
+		client:        mgr.GetClient(),
+		scheme:        mgr.GetScheme(),
+		provider:      opts.DetectedProvider,
+		status:        status.New(mgr.GetClient(), ResourceName, opts.KubernetesVersion),
+		clusterDomain: opts.ClusterDomain,
+	}
+	r.status.Run(opts.ShutdownContext)
+	return r
+}
+
+// add adds watches for resources that are available at startup.
+func add(c ctrlruntime.Controller) error {
+	if err := c.WatchObject(&operatorv1.Whisker{}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("whisker-controller failed to watch Whisker resource: %w", err)
+	}
+
+	if err := utils.AddInstallationWatch(c); err != nil {
+		return fmt.Errorf("whisker-controller failed to watch Installation resource: %w", err)
+	}
+
+	if err := imageset.AddImageSetWatch(c); err != nil {
+		return fmt.Errorf("whisker-controller failed to watch ImageSet: %w", err)
+	}
+
+	if err := utils.AddSecretsWatch(c, whisker.GoldmaneKeyPairSecret, common.OperatorNamespace()); err != nil {
+		return fmt.Errorf("whisker-controller failed to watch the Secret resource: %w", err)
+	}
+
+	// The trusted bundle of the calico-system namespace is maintained by the installation controller.
+	if err := utils.AddConfigMapWatch(c, certificatemanagement.TrustedCertConfigMapName, whisker.Namespace, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("whisker-controller failed to watch the ConfigMap resource: %w", err)
+	}
+
+	if err := utils.AddTigeraStatusWatch(c, ResourceName); err != nil {
+		return fmt.Errorf("whisker-controller failed to watch whisker Tigerastatus: %w", err)
+	}
+
+	return nil
+}
+
+// Blank assignment to verify that ReconcileWhisker implements reconcile.Reconciler.
+var _ reconcile.Reconciler = &ReconcileWhisker{}
+
+// ReconcileWhisker reconciles a Whisker object.
+type ReconcileWhisker struct {
+	client        client.Client
+	scheme        *runtime.Scheme
+	provider      operatorv1.Provider
+	status        status.StatusManager
+	clusterDomain string
+}
+
+// Reconcile reads that state of the cluster for a Whisker object and makes changes based on the state read
+// and what is in the Whisker.Spec
+func (r *ReconcileWhisker) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling Whisker")
+
+	instance, err := utils.GetWhisker(ctx, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying Whisker", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	if instance == nil {
+		// The rendered objects are owned by the Whisker, so they are garbage collected along with it.
+		reqLogger.V(3).Info("Whisker CR not found")
+		r.status.OnCRNotFound()
+		return reconcile.Result{}, nil
+	}
+	r.status.OnCRFound()
+	// SetMetaData in the TigeraStatus such as observedGenerations.
+	defer r.status.SetMetaData(&instance.ObjectMeta)
+
+	// Changes for updating Whisker status conditions.
+	if request.Name == ResourceName && request.Namespace == "" {
+		ts := &operatorv1.TigeraStatus{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: ResourceName}, ts); err != nil {
+			return reconcile.Result{}, err
+		}
+		instance.Status.Conditions = status.UpdateStatusCondition(instance.Status.Conditions, ts.Status.Conditions)
+		if err := r.client.Status().Update(ctx, instance); err != nil {
+			log.WithValues("reason", err).Info("Failed to create Whisker status conditions.")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if utils.IsReconcilePaused(&instance.ObjectMeta, r.status, reqLogger) {
+		return reconcile.Result{}, nil
+	}
+
+	preDefaultPatchFrom := client.MergeFrom(instance.DeepCopy())
+
+	fillDefaults(instance)
+
+	// Write the defaults back to the datastore.
+	if err = r.client.Patch(ctx, instance, preDefaultPatchFrom); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to write defaults to Whisker", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	variant, installation, err := utils.GetInstallation(ctx, r.client)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", status.WithCode(operatorv1.InstallationNotFound, err), reqLogger)
+			return reconcile.Result{}, err
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	if variant == "" {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Installation to be ready", status.WithCode(operatorv1.InstallationNotReady, nil), reqLogger)
+		return utils.RequeueWithBackoff(), nil
+	}
+	if variant != operatorv1.Calico {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Whisker is only supported for %s", operatorv1.Calico), nil, reqLogger)
+		return reconcile.Result{}, nil
+	}
+
+	pullSecrets, err := utils.GetNetworkingPullSecrets(installation, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", status.WithCode(operatorv1.PullSecretsNotAvailable, err), reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	certificateManager, err := certificatemanager.Create(r.client, installation, r.clusterDomain, common.OperatorNamespace())
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", status.WithCode(operatorv1.TigeraCANotAvailable, err), reqLogger)
+		return reconcile.Result{}, err
+	}
+	goldmaneKeyPair, err := certificateManager.GetOrCreateKeyPair(
+		r.client,
+		whisker.GoldmaneKeyPairSecret,
+		common.OperatorNamespace(),
+		dns.GetServiceDNSNames(whisker.GoldmaneServiceName, whisker.Namespace, r.clusterDomain))
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Error creating the Goldmane TLS certificate", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	certificateManager.AddToStatusManager(r.status, whisker.Namespace)
+
+	trustedBundle, err := certificateManager.LoadTrustedBundle(ctx, r.client, whisker.Namespace)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Error getting trusted bundle in %s", whisker.Namespace), err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	components := []render.Component{
+		whisker.Whisker(&whisker.Config{
+			Whisker:         instance,
+			Installation:    installation,
+			PullSecrets:     pullSecrets,
+			OpenShift:       r.provider.IsOpenShift(),
+			GoldmaneKeyPair: goldmaneKeyPair,
+			TrustedBundle:   trustedBundle,
+			ClusterDomain:   r.clusterDomain,
+		}),
+		rcertificatemanagement.CertificateManagement(&rcertificatemanagement.Config{
+			Namespace:       whisker.Namespace,
+			ServiceAccounts: []string{whisker.GoldmaneServiceAccountName},
+			KeyPairOptions: []rcertificatemanagement.KeyPairOption{
+				rcertificatemanagement.NewKeyPairOption(goldmaneKeyPair, true, true),
+			},
+		}),
+	}
+
+	if err = imageset.ApplyImageSet(ctx, r.client, variant, components...); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", status.WithCode(operatorv1.ImageSetInvalid, err), reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	for _, component := range components {
+		if err := handler.CreateOrUpdateOrDelete(ctx, component, r.status); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating resource", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Clear the degraded bit if we've reached this far.
+	r.status.ClearDegraded()
+
+	if !r.status.IsAvailable() {
+		// Schedule a kick to check again in the near future. Hopefully by then things will be available.
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
+	// Everything is available - update the CR status.
+	instance.Status.State = operatorv1.TigeraStatusReady
+	if err = r.client.Status().Update(ctx, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// fillDefaults populates the default values onto a Whisker object.
+func fillDefaults(instance *operatorv1.Whisker) {
+	if instance.Spec.Notifications == nil {
+		notifications := operatorv1.WhiskerNotificationsEnabled
+		instance.Spec.Notifications = &notifications
+	}
+}
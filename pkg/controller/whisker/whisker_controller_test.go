@@ -0,0 +1,136 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whisker
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/status"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/render/whisker"
+)
+
+var _ = Describe("Whisker controller tests", func() {
+	var r ReconcileWhisker
+	var c client.Client
+	var ctx context.Context
+	var mockStatus *status.MockStatus
+	var installation *operatorv1.Installation
+
+	BeforeEach(func() {
+		// The schema contains all objects that should be known to the fake client when the test runs.
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(rbacv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(netv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+
+		// Create a client that will have a crud interface of k8s objects.
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		mockStatus = &status.MockStatus{}
+		mockStatus.On("OnCRFound").Return()
+		mockStatus.On("SetMetaData", mock.Anything).Return()
+		mockStatus.On("AddDeployments", mock.Anything).Return()
+		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+		mockStatus.On("IsAvailable").Return(true)
+		mockStatus.On("ReadyToMonitor")
+		mockStatus.On("ClearDegraded")
+
+		r = ReconcileWhisker{
+			client:        c,
+			scheme:        scheme,
+			provider:      operatorv1.ProviderNone,
+			status:        mockStatus,
+			clusterDomain: dns.DefaultClusterDomain,
+		}
+
+		installation = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operatorv1.InstallationSpec{Variant: operatorv1.Calico},
+			Status: operatorv1.InstallationStatus{
+				Variant:  operatorv1.Calico,
+				Computed: &operatorv1.InstallationSpec{},
+			},
+		}
+		Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+		// The CA is created by the installation controller.
+		certificateManager, err := certificatemanager.Create(c, nil, "", common.OperatorNamespace(), certificatemanager.AllowCACreation())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, certificateManager.KeyPair().Secret(common.OperatorNamespace()))).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-ca-bundle", Namespace: common.CalicoNamespace},
+		})).NotTo(HaveOccurred())
+	})
+
+	It("installs Whisker and Goldmane", func() {
+		Expect(c.Create(ctx, &operatorv1.Whisker{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		for _, name := range []string{whisker.WhiskerDeploymentName, whisker.GoldmaneDeploymentName} {
+			Expect(c.Get(ctx, client.ObjectKey{Name: name, Namespace: common.CalicoNamespace}, &appsv1.Deployment{})).NotTo(HaveOccurred())
+		}
+		Expect(c.Get(ctx, client.ObjectKey{Name: whisker.GoldmaneKeyPairSecret, Namespace: common.OperatorNamespace()}, &corev1.Secret{})).NotTo(HaveOccurred())
+		Expect(c.Get(ctx, client.ObjectKey{Name: whisker.GoldmaneKeyPairSecret, Namespace: common.CalicoNamespace}, &corev1.Secret{})).NotTo(HaveOccurred())
+
+		// The defaults are written back to the Whisker.
+		instance := &operatorv1.Whisker{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, instance)).NotTo(HaveOccurred())
+		Expect(*instance.Spec.Notifications).To(Equal(operatorv1.WhiskerNotificationsEnabled))
+		Expect(instance.Status.State).To(Equal(operatorv1.TigeraStatusReady))
+	})
+
+	It("is degraded for Calico Enterprise", func() {
+		installation.Status.Variant = operatorv1.TigeraSecureEnterprise
+		Expect(c.Status().Update(ctx, installation)).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &operatorv1.Whisker{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Whisker is only supported for Calico", mock.Anything, mock.Anything).Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Whisker is only supported for Calico", mock.Anything, mock.Anything)
+		Expect(c.Get(ctx, client.ObjectKey{Name: whisker.WhiskerDeploymentName, Namespace: common.CalicoNamespace}, &appsv1.Deployment{})).To(HaveOccurred())
+	})
+
+	It("does nothing when the Whisker does not exist", func() {
+		mockStatus.On("OnCRNotFound").Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "OnCRNotFound")
+		Expect(c.Get(ctx, client.ObjectKey{Name: whisker.WhiskerDeploymentName, Namespace: common.CalicoNamespace}, &appsv1.Deployment{})).To(HaveOccurred())
+	})
+})
@@ -15,9 +15,16 @@
 package crds
 
 import (
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextenv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
 	opv1 "github.com/tigera/operator/api/v1"
 )
 
@@ -60,4 +67,48 @@ var _ = Describe("test crds pkg", func() {
 			Expect(runtime.Seconds()).Should(BeNumerically("<", 0.2), "loading enterprise CRDs shouldnt take too long.")
 		}, 50)
 	})
+	Context("operator CRDs", func() {
+		It("should have structural schemas", func() {
+			for _, crd := range GetCRDs(opv1.TigeraSecureEnterprise) {
+				if crd.Spec.Group != "operator.tigera.io" {
+					continue
+				}
+				for _, version := range crd.Spec.Versions {
+					// The API server only applies the defaults of structural schemas.
+					internal := &apiextensions.JSONSchemaProps{}
+					Expect(apiextenv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(version.Schema.OpenAPIV3Schema, internal, nil)).To(Succeed(), crd.Name)
+					structural, err := structuralschema.NewStructural(internal)
+					Expect(err).NotTo(HaveOccurred(), crd.Name)
+					Expect(structuralschema.ValidateStructural(field.NewPath("openAPIV3Schema"), structural)).To(BeEmpty(), crd.Name)
+				}
+			}
+		})
+
+		It("should document the default of each field that has one", func() {
+			for _, crd := range GetCRDs(opv1.TigeraSecureEnterprise) {
+				if crd.Spec.Group != "operator.tigera.io" {
+					continue
+				}
+				for _, version := range crd.Spec.Versions {
+					expectDocumentedDefaults(crd.Name, "", version.Schema.OpenAPIV3Schema)
+				}
+			}
+		})
+	})
 })
+
+// expectDocumentedDefaults expects the description of each property of the schema that has a default to state its
+// default, so that kubectl explain shows it. Empty objects are defaulted only so that the defaults of their properties
+// apply, and are exempt.
+func expectDocumentedDefaults(crdName, path string, schema *apiextenv1.JSONSchemaProps) {
+	if schema.Default != nil && string(schema.Default.Raw) != "{}" {
+		ExpectWithOffset(1, schema.Description).To(MatchRegexp(`(?i)default`), "%s: %s", crdName, path)
+	}
+	for name, property := range schema.Properties {
+		property := property
+		expectDocumentedDefaults(crdName, strings.TrimPrefix(path+"."+name, "."), &property)
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		expectDocumentedDefaults(crdName, path+"[]", schema.Items.Schema)
+	}
+}
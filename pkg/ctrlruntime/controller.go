@@ -22,6 +22,7 @@
 package ctrlruntime
 
 import (
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -43,26 +44,36 @@ import (
 type Controller interface {
 	controller.Controller
 
-	// WatchObject creates a watch for the specific object, using the cache stored internal to the Controller.
+	// WatchObject creates a watch for the specific object, using the cache stored internal to the Controller. Watches
+	// of the operator.tigera.io custom resources ignore the updates made while their reconciliation is paused.
 	WatchObject(object client.Object, eventhandler handler.EventHandler, predicates ...predicate.Predicate) error
 }
 
-// controler is an implementation of Controller. It stores the cache from the manager it was created from and uses it
-// to create the watches needed for the object provided to the WatchObject function.
+// controler is an implementation of Controller. It stores the cache and scheme from the manager it was created from and
+// uses them to create the watches needed for the object provided to the WatchObject function.
 type controler struct {
 	controller.Controller
-	cach cache.Cache
+	cach   cache.Cache
+	scheme *runtime.Scheme
 }
 
+// NewController creates a new Controller registered with the given manager. Unless the options specify a rate limiter,
+// the controller uses NewRateLimiter.
 func NewController(name string, mgr manager.Manager, options controller.Options) (Controller, error) {
+	if options.RateLimiter == nil {
+		options.RateLimiter = NewRateLimiter()
+	}
 	c, err := controller.New(name, mgr, options)
 	if err != nil {
 		return nil, err
 	}
 
-	return &controler{Controller: c, cach: mgr.GetCache()}, nil
+	return &controler{Controller: c, cach: mgr.GetCache(), scheme: mgr.GetScheme()}, nil
 }
 
 func (c *controler) WatchObject(object client.Object, eventhandler handler.EventHandler, predicates ...predicate.Predicate) error {
+	if isOperatorResource(object, c.scheme) {
+		predicates = append(predicates, ReconcilePausedPredicate())
+	}
 	return c.Watch(source.Kind(c.cach, object), eventhandler, predicates...)
 }
@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctrlruntime
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+)
+
+// ReconcilePausedPredicate filters out the updates of a custom resource whose reconciliation is paused by the
+// common.ReconcileAnnotation, so that changes made to it while it is paused don't trigger its controller. The updates
+// that pause or resume the reconciliation are kept, so that the controller reports the change in the status of the
+// component, and catches up with the changes made in the meantime when it is resumed.
+func ReconcilePausedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !common.IsReconcilePaused(e.ObjectOld) || !common.IsReconcilePaused(e.ObjectNew)
+		},
+	}
+}
+
+// isOperatorResource returns true if the object is a custom resource of the operator.tigera.io API group.
+func isOperatorResource(object client.Object, scheme *runtime.Scheme) bool {
+	gvk, err := apiutil.GVKForObject(object, scheme)
+	return err == nil && gvk.Group == operatorv1.GroupVersion.Group
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctrlruntime
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+)
+
+var _ = Describe("Reconcile paused predicate", func() {
+	apiServer := func(paused bool) *operatorv1.APIServer {
+		as := &operatorv1.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		if paused {
+			as.Annotations = map[string]string{common.ReconcileAnnotation: common.ReconcilePaused}
+		}
+		return as
+	}
+
+	It("should ignore the updates of a custom resource while its reconciliation is paused", func() {
+		p := ReconcilePausedPredicate()
+		Expect(p.Update(event.UpdateEvent{ObjectOld: apiServer(false), ObjectNew: apiServer(false)})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: apiServer(true), ObjectNew: apiServer(true)})).To(BeFalse())
+
+		By("keeping the updates that pause and resume the reconciliation")
+		Expect(p.Update(event.UpdateEvent{ObjectOld: apiServer(false), ObjectNew: apiServer(true)})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: apiServer(true), ObjectNew: apiServer(false)})).To(BeTrue())
+
+		Expect(p.Create(event.CreateEvent{Object: apiServer(true)})).To(BeTrue())
+		Expect(p.Delete(event.DeleteEvent{Object: apiServer(true)})).To(BeTrue())
+	})
+
+	It("should only apply to the custom resources of the operator", func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(isOperatorResource(&operatorv1.APIServer{}, scheme)).To(BeTrue())
+		Expect(isOperatorResource(&appsv1.Deployment{}, scheme)).To(BeFalse())
+	})
+})
@@ -0,0 +1,68 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctrlruntime
+
+import (
+	"math/rand"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+const (
+	// RequeueBaseDelay is the delay before the first rate limited requeue of a request, i.e. after reconciling it
+	// failed or returned a result asking to be requeued. The delay doubles on each further requeue of the request.
+	RequeueBaseDelay = time.Second
+
+	// RequeueMaxDelay is the maximum delay between rate limited requeues of a request.
+	RequeueMaxDelay = 5 * time.Minute
+
+	// requeueJitterFactor is the fraction of the delay by which requeues are spread out, so that controllers waiting
+	// on the same resource do not all retry at the same time.
+	requeueJitterFactor = 0.1
+
+	// requeueQPS and requeueBurst limit the overall rate of requeues of a controller, as in the default rate limiter
+	// of controller-runtime.
+	requeueQPS   = 10
+	requeueBurst = 100
+)
+
+// NewRateLimiter returns the rate limiter used for the work queues of our controllers. Requests that are requeued are
+// retried with an exponential, jittered backoff between RequeueBaseDelay and RequeueMaxDelay, which is reset once the
+// request reconciles successfully. Like the default rate limiter of controller-runtime, the backoff is combined with
+// an overall token bucket, so that many requests failing at once don't flood the API server.
+func NewRateLimiter() ratelimiter.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		&jitterRateLimiter{
+			RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(RequeueBaseDelay, RequeueMaxDelay),
+			factor:      requeueJitterFactor,
+		},
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(requeueQPS), requeueBurst)},
+	)
+}
+
+// jitterRateLimiter shortens the delays of the wrapped rate limiter by a random fraction of up to factor. Jitter is
+// only ever subtracted, so that the maximum delay of the wrapped rate limiter is respected.
+type jitterRateLimiter struct {
+	workqueue.RateLimiter
+	factor float64
+}
+
+func (r *jitterRateLimiter) When(item interface{}) time.Duration {
+	d := r.RateLimiter.When(item)
+	return d - time.Duration(rand.Float64()*r.factor*float64(d))
+}
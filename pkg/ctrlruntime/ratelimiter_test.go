@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctrlruntime
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Rate limiter", func() {
+	It("should back off exponentially with jitter up to the maximum delay", func() {
+		rl := NewRateLimiter()
+		req := reconcile.Request{}
+
+		expected := RequeueBaseDelay
+		for i := 0; i < 12; i++ {
+			d := rl.When(req)
+			Expect(d).To(BeNumerically("<=", expected))
+			Expect(d).To(BeNumerically(">=", time.Duration(float64(expected)*(1-requeueJitterFactor))))
+
+			expected *= 2
+			if expected > RequeueMaxDelay {
+				expected = RequeueMaxDelay
+			}
+		}
+		Expect(rl.NumRequeues(req)).To(Equal(12))
+	})
+
+	It("should reset the backoff when a request is forgotten", func() {
+		rl := NewRateLimiter()
+		req := reconcile.Request{}
+
+		for i := 0; i < 5; i++ {
+			rl.When(req)
+		}
+		rl.Forget(req)
+		Expect(rl.NumRequeues(req)).To(Equal(0))
+		Expect(rl.When(req)).To(BeNumerically("<=", RequeueBaseDelay))
+	})
+
+	It("should limit the overall rate of requeues", func() {
+		rl := NewRateLimiter()
+
+		// Each request is requeued once, so that only the token bucket delays them beyond the base delay.
+		var d time.Duration
+		for i := 0; i < 2*requeueBurst; i++ {
+			d = rl.When(reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("req-%d", i)}})
+		}
+		Expect(d).To(BeNumerically(">", 5*time.Second))
+	})
+})
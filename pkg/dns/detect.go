@@ -0,0 +1,92 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// Names of the cluster DNS resources in the kube-system namespace that the cluster domain can be read from.
+	coreDNSConfigMapName   = "coredns"
+	coreDNSCorefileKey     = "Corefile"
+	kubeDNSDeploymentName  = "kube-dns"
+	kubeSystemNamespace    = "kube-system"
+	kubeDNSDomainArgPrefix = "--domain="
+)
+
+// corefileKubernetesZones matches the zones served by the kubernetes plugin in a Corefile, e.g.
+// "kubernetes cluster.local in-addr.arpa ip6.arpa {".
+var corefileKubernetesZones = regexp.MustCompile(`(?m)^\s*kubernetes\s+([^{\n]+)`)
+
+// DetectClusterDomain determines the cluster domain. It is read from the search domains that the kubelet writes into
+// the resolv.conf of the operator pod and, if that does not contain one, from the configuration of CoreDNS or
+// kube-dns. An error is returned if none of these yield a cluster domain.
+func DetectClusterDomain(ctx context.Context, cs kubernetes.Interface, resolvConfPath string) (string, error) {
+	clusterDomain, resolvErr := GetClusterDomain(resolvConfPath)
+	if resolvErr == nil {
+		return clusterDomain, nil
+	}
+	clusterDomain, coreDNSErr := GetCoreDNSClusterDomain(ctx, cs)
+	if coreDNSErr == nil {
+		return clusterDomain, nil
+	}
+	clusterDomain, kubeDNSErr := GetKubeDNSClusterDomain(ctx, cs)
+	if kubeDNSErr == nil {
+		return clusterDomain, nil
+	}
+	return "", errors.Join(resolvErr, coreDNSErr, kubeDNSErr)
+}
+
+// GetCoreDNSClusterDomain returns the cluster domain served by the kubernetes plugin of CoreDNS.
+func GetCoreDNSClusterDomain(ctx context.Context, cs kubernetes.Interface) (string, error) {
+	cm, err := cs.CoreV1().ConfigMaps(kubeSystemNamespace).Get(ctx, coreDNSConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to read the CoreDNS configuration: %w", err)
+	}
+	for _, match := range corefileKubernetesZones.FindAllStringSubmatch(cm.Data[coreDNSCorefileKey], -1) {
+		// The reverse lookup zones are listed along with the cluster domain.
+		for _, zone := range strings.Fields(match[1]) {
+			if zone = strings.TrimSuffix(zone, "."); zone != "" && !strings.HasSuffix(zone, ".arpa") {
+				return zone, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("failed to find cluster domain in the CoreDNS configuration")
+}
+
+// GetKubeDNSClusterDomain returns the cluster domain that kube-dns is started with.
+func GetKubeDNSClusterDomain(ctx context.Context, cs kubernetes.Interface) (string, error) {
+	d, err := cs.AppsV1().Deployments(kubeSystemNamespace).Get(ctx, kubeDNSDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to read the kube-dns deployment: %w", err)
+	}
+	for _, c := range d.Spec.Template.Spec.Containers {
+		for _, arg := range slices.Concat(c.Command, c.Args) {
+			if domain, ok := strings.CutPrefix(arg, kubeDNSDomainArgPrefix); ok && domain != "" {
+				return strings.TrimSuffix(domain, "."), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("failed to find cluster domain in the kube-dns deployment")
+}
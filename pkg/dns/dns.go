@@ -17,8 +17,12 @@ package dns
 import (
 	"bufio"
 	"fmt"
+	"net"
 	"os"
 	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -73,3 +77,23 @@ func GetServiceDNSNames(name, namespace, clusterDomain string) []string {
 		fmt.Sprintf("%s.%s.svc.%s", name, namespace, clusterDomain),
 	}
 }
+
+// ValidateDNSNames returns an error if any of the names is not a valid DNS name to include in a certificate. Wildcard
+// names, such as *.example.com, are allowed. IP addresses are not.
+func ValidateDNSNames(names []string) error {
+	for _, name := range names {
+		if net.ParseIP(name) != nil {
+			return fmt.Errorf("invalid DNS name %q: IP addresses are not supported", name)
+		}
+		var errs []string
+		if strings.HasPrefix(name, "*.") {
+			errs = validation.IsWildcardDNS1123Subdomain(name)
+		} else {
+			errs = validation.IsDNS1123Subdomain(name)
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("invalid DNS name %q: %s", name, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
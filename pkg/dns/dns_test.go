@@ -15,12 +15,18 @@
 package dns_test
 
 import (
+	"context"
 	"os"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
 	"github.com/tigera/operator/pkg/dns"
 )
 
@@ -44,6 +50,79 @@ var _ = Describe("Common Tests", func() {
 		})
 	})
 
+	Context("Validate DNS names", func() {
+		It("Should accept DNS names and wildcard DNS names", func() {
+			Expect(dns.ValidateDNSNames([]string{"es.example.com", "*.example.com", "localhost"})).To(Succeed())
+		})
+
+		It("Should reject names that are not DNS names", func() {
+			for _, name := range []string{"", "ES.example.com", "10.0.0.1", "10.0.0.1:9200", "*", "es.*.example.com"} {
+				Expect(dns.ValidateDNSNames([]string{"es.example.com", name})).NotTo(Succeed(), name)
+			}
+		})
+	})
+
+	Context("Detect cluster domain", func() {
+		corefile := `.:53 {
+    errors
+    health
+    kubernetes corp.example in-addr.arpa ip6.arpa {
+       pods insecure
+       fallthrough in-addr.arpa ip6.arpa
+    }
+    forward . /etc/resolv.conf
+}
+`
+		kubeDNS := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-dns", Namespace: "kube-system"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "kubedns", Args: []string{"--domain=gke.example.", "--dns-port=10053"}},
+						},
+					},
+				},
+			},
+		}
+
+		It("Should prefer the resolv.conf", func() {
+			cs := fake.NewSimpleClientset(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+				Data:       map[string]string{"Corefile": corefile},
+			})
+			clusterDomain, err := dns.DetectClusterDomain(context.Background(), cs, "testdata/resolv.conf")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterDomain).To(Equal("othername.local"))
+		})
+
+		It("Should fall back to the CoreDNS configuration", func() {
+			cs := fake.NewSimpleClientset(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+				Data:       map[string]string{"Corefile": corefile},
+			}, kubeDNS)
+			clusterDomain, err := dns.DetectClusterDomain(context.Background(), cs, "does-not.exist")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterDomain).To(Equal("corp.example"))
+		})
+
+		It("Should fall back to the kube-dns deployment", func() {
+			cs := fake.NewSimpleClientset(kubeDNS)
+			clusterDomain, err := dns.DetectClusterDomain(context.Background(), cs, "does-not.exist")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterDomain).To(Equal("gke.example"))
+		})
+
+		It("Should return an error if no source has a cluster domain", func() {
+			cs := fake.NewSimpleClientset(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+				Data:       map[string]string{"Corefile": ".:53 {\n    forward . /etc/resolv.conf\n}\n"},
+			})
+			_, err := dns.DetectClusterDomain(context.Background(), cs, "does-not.exist")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Context("Get all DNS names for a service", func() {
 		DescribeTable("Should return the correct services names", func(service, namespace, clusterDomain string, expectedDNSNames []string) {
 			names := dns.GetServiceDNSNames(service, namespace, clusterDomain)
@@ -0,0 +1,88 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubectlplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/diagnostics"
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+// CollectDiagnostics requests a diagnostics bundle from the operator by annotating the Installation with
+// common.CollectDiagnosticsAnnotation, waits for the operator to write the bundle, and then writes each of its entries
+// to a file in dir.
+func (p *Plugin) CollectDiagnostics(ctx context.Context, operatorNamespace, dir string, timeout time.Duration) error {
+	id := time.Now().UTC().Format("20060102T150405Z")
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{common.CollectDiagnosticsAnnotation: id},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	installation := &operatorv1.Installation{}
+	installation.Name = utils.DefaultInstanceKey.Name
+	if err := p.Client.Patch(ctx, installation, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to request a diagnostics bundle: %w", err)
+	}
+	fmt.Fprintf(p.Out, "Requested diagnostics bundle %s, waiting for the operator to collect it\n", id)
+
+	bundle := &corev1.ConfigMap{}
+	key := client.ObjectKey{Name: diagnostics.BundleName, Namespace: operatorNamespace}
+	err = wait.PollUntilContextTimeout(ctx, p.PollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := p.Client.Get(ctx, key, bundle); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return bundle.Annotations[common.CollectDiagnosticsAnnotation] == id, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for diagnostics bundle %s: %w", id, err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(bundle.Data))
+	for k := range bundle.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := os.WriteFile(filepath.Join(dir, k), []byte(bundle.Data[k]), 0o644); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(p.Out, "Wrote diagnostics bundle %s to %s\n", id, dir)
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubectlplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/tigera/operator/pkg/common"
+)
+
+// SetPaused pauses or resumes the reconciliation of an operator.tigera.io custom resource, by setting or removing
+// common.ReconcileAnnotation. The kind is matched case-insensitively. If name is empty, the kind must have exactly
+// one resource in the cluster, which is the one that is paused or resumed.
+func (p *Plugin) SetPaused(ctx context.Context, kind, namespace, name string, paused bool) error {
+	gvk, err := p.kind(kind)
+	if err != nil {
+		return err
+	}
+	obj, err := p.resource(ctx, gvk, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	// A merge patch removes the annotation when it is set to null.
+	var value interface{}
+	if paused {
+		value = common.ReconcilePaused
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{common.ReconcileAnnotation: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := p.Client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to update %s/%s: %w", gvk.Kind, qualifiedName(obj), err)
+	}
+
+	if paused {
+		fmt.Fprintf(p.Out, "Paused the reconciliation of %s/%s\n", gvk.Kind, qualifiedName(obj))
+	} else {
+		fmt.Fprintf(p.Out, "Resumed the reconciliation of %s/%s\n", gvk.Kind, qualifiedName(obj))
+	}
+	return nil
+}
+
+// kind returns the operator.tigera.io kind with the given name.
+func (p *Plugin) kind(name string) (schema.GroupVersionKind, error) {
+	var names []string
+	for _, gvk := range p.kinds() {
+		if strings.EqualFold(gvk.Kind, name) {
+			return gvk, nil
+		}
+		names = append(names, gvk.Kind)
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("unknown kind %q, must be one of %s", name, strings.Join(names, ", "))
+}
+
+// resource returns the resource of the given kind and name, or the only resource of the kind if name is empty.
+func (p *Plugin) resource(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	if name != "" {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := p.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, obj); err != nil {
+			return nil, fmt.Errorf("failed to get %s %s: %w", gvk.Kind, name, err)
+		}
+		return obj, nil
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := p.Client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list %s resources: %w", gvk.Kind, err)
+	}
+	switch len(list.Items) {
+	case 0:
+		return nil, fmt.Errorf("no %s resources found", gvk.Kind)
+	case 1:
+		return &list.Items[0], nil
+	}
+	var names []string
+	for i := range list.Items {
+		names = append(names, qualifiedName(&list.Items[i]))
+	}
+	return nil, fmt.Errorf("found %d %s resources, specify one of %s", len(names), gvk.Kind, strings.Join(names, ", "))
+}
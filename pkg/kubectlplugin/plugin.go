@@ -0,0 +1,200 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kubectlplugin implements the commands of the kubectl calico-operator plugin, which summarizes the state of
+// the operator and runs the common operations of support cases against a cluster.
+package kubectlplugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+)
+
+// Plugin runs the commands of the plugin against the cluster of its client.
+type Plugin struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Out    io.Writer
+
+	// PollInterval is how often the commands that wait for the operator check the cluster.
+	PollInterval time.Duration
+}
+
+// Status writes a summary of the TigeraStatus of each component, followed by the custom resources whose
+// reconciliation is paused.
+func (p *Plugin) Status(ctx context.Context) error {
+	statuses, err := p.tigeraStatuses(ctx)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(p.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tAVAILABLE\tPROGRESSING\tDEGRADED\tSINCE\tREASON")
+	for _, ts := range statuses {
+		available := condition(ts, operatorv1.ComponentAvailable)
+		progressing := condition(ts, operatorv1.ComponentProgressing)
+		degraded := condition(ts, operatorv1.ComponentDegraded)
+		since := ""
+		if !available.LastTransitionTime.IsZero() {
+			since = time.Since(available.LastTransitionTime.Time).Round(time.Second).String()
+		}
+		reason := ""
+		if degraded.Status == operatorv1.ConditionTrue {
+			reason = degraded.Reason
+		} else if progressing.Status == operatorv1.ConditionTrue {
+			reason = progressing.Reason
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", ts.Name, available.Status, progressing.Status, degraded.Status, since, reason)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	paused, err := p.pausedResources(ctx)
+	if err != nil {
+		return err
+	}
+	if len(paused) > 0 {
+		fmt.Fprintf(p.Out, "\nReconciliation is paused for: %s\n", strings.Join(paused, ", "))
+	}
+	return nil
+}
+
+// Degraded writes the reason and message of each degraded component. If follow is set, it then keeps watching the
+// TigeraStatuses until the context is done, and writes each change to a degraded condition as it is observed.
+func (p *Plugin) Degraded(ctx context.Context, follow bool) error {
+	seen := map[string]string{}
+	first := true
+	for {
+		statuses, err := p.tigeraStatuses(ctx)
+		if err != nil {
+			return err
+		}
+		current := map[string]string{}
+		for _, ts := range statuses {
+			degraded := condition(ts, operatorv1.ComponentDegraded)
+			if degraded.Status != operatorv1.ConditionTrue {
+				continue
+			}
+			current[ts.Name] = fmt.Sprintf("%s: %s", degraded.Reason, degraded.Message)
+			if seen[ts.Name] != current[ts.Name] {
+				fmt.Fprintf(p.Out, "%s\t%s\tdegraded\t%s\n", timestamp(degraded.LastTransitionTime.Time), ts.Name, current[ts.Name])
+			}
+		}
+		for name := range seen {
+			if _, ok := current[name]; !ok {
+				fmt.Fprintf(p.Out, "%s\t%s\trecovered\n", timestamp(time.Now()), name)
+			}
+		}
+		if first && len(current) == 0 {
+			fmt.Fprintln(p.Out, "No components are degraded")
+		}
+		if !follow {
+			return nil
+		}
+		seen, first = current, false
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(p.PollInterval):
+		}
+	}
+}
+
+func (p *Plugin) tigeraStatuses(ctx context.Context) ([]operatorv1.TigeraStatus, error) {
+	list := &operatorv1.TigeraStatusList{}
+	if err := p.Client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list TigeraStatuses: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	return list.Items, nil
+}
+
+// pausedResources returns the operator.tigera.io custom resources that are annotated to pause their reconciliation,
+// as kind/name.
+func (p *Plugin) pausedResources(ctx context.Context) ([]string, error) {
+	var paused []string
+	for _, gvk := range p.kinds() {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := p.Client.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				// The CRD of the kind is not installed in this cluster.
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s resources: %w", gvk.Kind, err)
+		}
+		for _, obj := range list.Items {
+			if common.IsReconcilePaused(&obj) {
+				paused = append(paused, gvk.Kind+"/"+qualifiedName(&obj))
+			}
+		}
+	}
+	return paused, nil
+}
+
+// kinds returns the kinds of the operator.tigera.io custom resources that the operator reconciles, sorted by name.
+func (p *Plugin) kinds() []schema.GroupVersionKind {
+	var kinds []schema.GroupVersionKind
+	for kind := range p.Scheme.KnownTypes(operatorv1.GroupVersion) {
+		if strings.HasSuffix(kind, "List") || kind == "TigeraStatus" {
+			continue
+		}
+		if _, err := p.Scheme.New(operatorv1.GroupVersion.WithKind(kind + "List")); err != nil {
+			// Options and other types registered for the group version that aren't custom resources.
+			continue
+		}
+		kinds = append(kinds, operatorv1.GroupVersion.WithKind(kind))
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].Kind < kinds[j].Kind })
+	return kinds
+}
+
+// condition returns the condition of the given type, or an Unknown condition if the TigeraStatus doesn't have one.
+func condition(ts operatorv1.TigeraStatus, t operatorv1.StatusConditionType) operatorv1.TigeraStatusCondition {
+	for _, c := range ts.Status.Conditions {
+		if c.Type == t {
+			return c
+		}
+	}
+	return operatorv1.TigeraStatusCondition{Type: t, Status: operatorv1.ConditionUnknown}
+}
+
+func qualifiedName(obj client.Object) string {
+	if obj.GetNamespace() != "" {
+		return obj.GetNamespace() + "/" + obj.GetName()
+	}
+	return obj.GetName()
+}
+
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubectlplugin
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/diagnostics"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("kubectl calico-operator plugin", func() {
+	var (
+		ctx    context.Context
+		cli    client.Client
+		out    *bytes.Buffer
+		plugin *Plugin
+	)
+
+	tigeraStatus := func(name string, conditions ...operatorv1.TigeraStatusCondition) *operatorv1.TigeraStatus {
+		return &operatorv1.TigeraStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     operatorv1.TigeraStatusStatus{Conditions: conditions},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		out = &bytes.Buffer{}
+		plugin = &Plugin{Client: cli, Scheme: scheme, Out: out, PollInterval: 10 * time.Millisecond}
+	})
+
+	Context("status", func() {
+		It("should summarize each component and list the paused resources", func() {
+			Expect(cli.Create(ctx, tigeraStatus("calico",
+				operatorv1.TigeraStatusCondition{Type: operatorv1.ComponentAvailable, Status: operatorv1.ConditionTrue},
+				operatorv1.TigeraStatusCondition{Type: operatorv1.ComponentProgressing, Status: operatorv1.ConditionFalse},
+				operatorv1.TigeraStatusCondition{Type: operatorv1.ComponentDegraded, Status: operatorv1.ConditionFalse},
+			))).ShouldNot(HaveOccurred())
+			Expect(cli.Create(ctx, tigeraStatus("log-storage",
+				operatorv1.TigeraStatusCondition{Type: operatorv1.ComponentAvailable, Status: operatorv1.ConditionFalse},
+				operatorv1.TigeraStatusCondition{Type: operatorv1.ComponentDegraded, Status: operatorv1.ConditionTrue, Reason: string(operatorv1.ResourceNotReady)},
+			))).ShouldNot(HaveOccurred())
+			Expect(cli.Create(ctx, &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{
+				Name:        "tigera-secure",
+				Annotations: map[string]string{common.ReconcileAnnotation: common.ReconcilePaused},
+			}})).ShouldNot(HaveOccurred())
+
+			Expect(plugin.Status(ctx)).ShouldNot(HaveOccurred())
+			lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+			Expect(lines).To(HaveLen(5))
+			Expect(string(lines[0])).To(MatchRegexp(`^NAME\s+AVAILABLE\s+PROGRESSING\s+DEGRADED\s+SINCE\s+REASON$`))
+			Expect(string(lines[1])).To(MatchRegexp(`^calico\s+True\s+False\s+False\s*$`))
+			Expect(string(lines[2])).To(MatchRegexp(`^log-storage\s+False\s+Unknown\s+True\s+ResourceNotReady$`))
+			Expect(string(lines[4])).To(Equal("Reconciliation is paused for: LogStorage/tigera-secure"))
+		})
+	})
+
+	Context("degraded", func() {
+		It("should report that no components are degraded", func() {
+			Expect(cli.Create(ctx, tigeraStatus("calico",
+				operatorv1.TigeraStatusCondition{Type: operatorv1.ComponentDegraded, Status: operatorv1.ConditionFalse},
+			))).ShouldNot(HaveOccurred())
+
+			Expect(plugin.Degraded(ctx, false)).ShouldNot(HaveOccurred())
+			Expect(out.String()).To(Equal("No components are degraded\n"))
+		})
+
+		It("should follow the changes to the degraded components", func() {
+			ts := tigeraStatus("log-storage", operatorv1.TigeraStatusCondition{
+				Type: operatorv1.ComponentDegraded, Status: operatorv1.ConditionTrue,
+				Reason: string(operatorv1.ResourceNotReady), Message: "Waiting for Elasticsearch",
+			})
+			Expect(cli.Create(ctx, ts)).ShouldNot(HaveOccurred())
+
+			followCtx, cancel := context.WithCancel(ctx)
+			done := make(chan error)
+			buf := &syncBuffer{}
+			plugin.Out = buf
+			go func() { done <- plugin.Degraded(followCtx, true) }()
+			Eventually(buf.String).Should(ContainSubstring("log-storage\tdegraded\tResourceNotReady: Waiting for Elasticsearch\n"))
+
+			ts.Status.Conditions[0].Status = operatorv1.ConditionFalse
+			Expect(cli.Status().Update(ctx, ts)).ShouldNot(HaveOccurred())
+			Eventually(buf.String).Should(ContainSubstring("log-storage\trecovered\n"))
+
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+			Expect(bytes.Count([]byte(buf.String()), []byte("degraded\t"))).To(Equal(1))
+		})
+	})
+
+	Context("pause and resume", func() {
+		BeforeEach(func() {
+			Expect(cli.Create(ctx, &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).ShouldNot(HaveOccurred())
+		})
+
+		It("should pause and resume the only resource of a kind", func() {
+			Expect(plugin.SetPaused(ctx, "logstorage", "", "", true)).ShouldNot(HaveOccurred())
+			ls := &operatorv1.LogStorage{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, ls)).ShouldNot(HaveOccurred())
+			Expect(common.IsReconcilePaused(ls)).To(BeTrue())
+			Expect(out.String()).To(Equal("Paused the reconciliation of LogStorage/tigera-secure\n"))
+
+			Expect(plugin.SetPaused(ctx, "LogStorage", "", "tigera-secure", false)).ShouldNot(HaveOccurred())
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, ls)).ShouldNot(HaveOccurred())
+			Expect(ls.Annotations).NotTo(HaveKey(common.ReconcileAnnotation))
+		})
+
+		It("should require a name when a kind has several resources", func() {
+			Expect(cli.Create(ctx, &operatorv1.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-a"}})).ShouldNot(HaveOccurred())
+			Expect(cli.Create(ctx, &operatorv1.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-b"}})).ShouldNot(HaveOccurred())
+
+			err := plugin.SetPaused(ctx, "tenant", "", "", true)
+			Expect(err).To(MatchError("found 2 Tenant resources, specify one of tenant-a/default, tenant-b/default"))
+
+			Expect(plugin.SetPaused(ctx, "tenant", "tenant-b", "default", true)).ShouldNot(HaveOccurred())
+			tenant := &operatorv1.Tenant{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "default", Namespace: "tenant-b"}, tenant)).ShouldNot(HaveOccurred())
+			Expect(common.IsReconcilePaused(tenant)).To(BeTrue())
+		})
+
+		It("should reject unknown kinds", func() {
+			err := plugin.SetPaused(ctx, "elasticsearch", "", "", true)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`unknown kind "elasticsearch"`))
+			Expect(err.Error()).To(ContainSubstring("LogStorage"))
+			Expect(err.Error()).NotTo(ContainSubstring("TigeraStatus"))
+		})
+	})
+
+	Context("diagnostics", func() {
+		var dir string
+
+		BeforeEach(func() {
+			tmp, err := os.MkdirTemp("", "diagnostics")
+			Expect(err).ShouldNot(HaveOccurred())
+			dir = filepath.Join(tmp, "bundle")
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(filepath.Dir(dir))).ShouldNot(HaveOccurred())
+		})
+
+		It("should request a bundle and write it once the operator has collected it", func() {
+			Expect(cli.Create(ctx, &operatorv1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).ShouldNot(HaveOccurred())
+
+			done := make(chan error)
+			plugin.Out = &syncBuffer{}
+			go func() { done <- plugin.CollectDiagnostics(ctx, "tigera-operator", dir, time.Minute) }()
+
+			// Stand in for the diagnostics controller.
+			var id string
+			Eventually(func() string {
+				installation := &operatorv1.Installation{}
+				Expect(cli.Get(ctx, client.ObjectKey{Name: "default"}, installation)).ShouldNot(HaveOccurred())
+				id = installation.Annotations[common.CollectDiagnosticsAnnotation]
+				return id
+			}).ShouldNot(BeEmpty())
+			Expect(cli.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        diagnostics.BundleName,
+					Namespace:   "tigera-operator",
+					Annotations: map[string]string{common.CollectDiagnosticsAnnotation: id},
+				},
+				Data: map[string]string{diagnostics.PodsKey: "pods", diagnostics.OperatorLogsKey: "logs"},
+			})).ShouldNot(HaveOccurred())
+
+			Eventually(done).Should(Receive(BeNil()))
+			Expect(os.ReadFile(filepath.Join(dir, diagnostics.PodsKey))).To(Equal([]byte("pods")))
+			Expect(os.ReadFile(filepath.Join(dir, diagnostics.OperatorLogsKey))).To(Equal([]byte("logs")))
+		})
+
+		It("should not write a bundle from an earlier request", func() {
+			Expect(cli.Create(ctx, &operatorv1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).ShouldNot(HaveOccurred())
+			Expect(cli.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        diagnostics.BundleName,
+					Namespace:   "tigera-operator",
+					Annotations: map[string]string{common.CollectDiagnosticsAnnotation: "earlier"},
+				},
+			})).ShouldNot(HaveOccurred())
+
+			err := plugin.CollectDiagnostics(ctx, "tigera-operator", dir, 100*time.Millisecond)
+			Expect(err).To(HaveOccurred())
+			Expect(dir).NotTo(BeADirectory())
+		})
+	})
+})
+
+// syncBuffer is a bytes.Buffer that the plugin can write to while a test reads from it.
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package preflight validates that a cluster meets the prerequisites of the configuration in it, so that problems can
+// be found before installing or making major configuration changes rather than from a degraded TigeraStatus.
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusPassed  Status = "Passed"
+	StatusFailed  Status = "Failed"
+	StatusSkipped Status = "Skipped"
+)
+
+const (
+	// defaultStorageClassName is the StorageClass used by LogStorage when it does not specify one.
+	defaultStorageClassName = "tigera-elasticsearch"
+
+	// dialTimeout bounds how long the external Elasticsearch check waits for a connection.
+	dialTimeout = 5 * time.Second
+)
+
+// eckCRDs are the CRDs of the ECK operator, which must be installed for the operator to provision Elasticsearch and
+// Kibana.
+var eckCRDs = []string{
+	"elasticsearches.elasticsearch.k8s.elastic.co",
+	"kibanas.kibana.k8s.elastic.co",
+}
+
+var kernelVersionRegexp = regexp.MustCompile(`^(\d+)\.(\d+)`)
+
+// dial is used to check that external Elasticsearch endpoints are reachable. It is a variable so tests can replace it.
+var dial = (&net.Dialer{Timeout: dialTimeout}).DialContext
+
+// Config contains the operator configuration that determines which checks apply.
+type Config struct {
+	// ElasticExternal is true if the operator is configured to use an Elasticsearch outside of the cluster.
+	ElasticExternal bool
+}
+
+// Result is the outcome of a single check.
+type Result struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Report is the machine-readable result of a preflight run.
+type Report struct {
+	// Passed is true if none of the checks failed.
+	Passed  bool     `json:"passed"`
+	Results []Result `json:"results"`
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context, c client.Client, cfg Config) (Status, string)
+}
+
+var checks = []check{
+	{name: "logstorage-storage-class", run: checkStorageClass},
+	{name: "eck-crds", run: checkECKCRDs},
+	{name: "bpf-kernel", run: checkBPFKernel},
+	{name: "external-elasticsearch", run: checkExternalElasticsearch},
+}
+
+// Run runs all checks against the cluster and returns the report. Failing to query the cluster fails the check that
+// needed the query, so that a single run reports on all prerequisites.
+func Run(ctx context.Context, c client.Client, cfg Config) *Report {
+	report := &Report{Passed: true}
+	for _, chk := range checks {
+		status, msg := chk.run(ctx, c, cfg)
+		if status == StatusFailed {
+			report.Passed = false
+		}
+		report.Results = append(report.Results, Result{Name: chk.name, Status: status, Message: msg})
+	}
+	return report
+}
+
+// Write writes the report to w in the given format, either "json" or "text".
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case "text":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, res := range r.Results {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", res.Name, res.Status, res.Message)
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("unknown preflight report format %q, must be json or text", format)
+}
+
+// checkStorageClass checks that the StorageClass used for Elasticsearch volumes exists.
+func checkStorageClass(ctx context.Context, c client.Client, cfg Config) (Status, string) {
+	if cfg.ElasticExternal {
+		return StatusSkipped, "Elasticsearch is external"
+	}
+	ls, err := logStorage(ctx, c)
+	if err != nil {
+		return StatusFailed, err.Error()
+	}
+	if ls == nil {
+		return StatusSkipped, "LogStorage is not configured"
+	}
+
+	name := ls.Spec.StorageClassName
+	if name == "" {
+		name = defaultStorageClassName
+	}
+	if err = c.Get(ctx, client.ObjectKey{Name: name}, &storagev1.StorageClass{}); err != nil {
+		if errors.IsNotFound(err) {
+			return StatusFailed, fmt.Sprintf("StorageClass %s used by LogStorage does not exist", name)
+		}
+		return StatusFailed, fmt.Sprintf("failed to query StorageClass %s: %v", name, err)
+	}
+	return StatusPassed, fmt.Sprintf("StorageClass %s exists", name)
+}
+
+// checkECKCRDs checks that the ECK operator CRDs are installed.
+func checkECKCRDs(ctx context.Context, c client.Client, cfg Config) (Status, string) {
+	if cfg.ElasticExternal {
+		return StatusSkipped, "Elasticsearch is external"
+	}
+	ls, err := logStorage(ctx, c)
+	if err != nil {
+		return StatusFailed, err.Error()
+	}
+	if ls == nil {
+		return StatusSkipped, "LogStorage is not configured"
+	}
+
+	var missing []string
+	for _, name := range eckCRDs {
+		if err = c.Get(ctx, client.ObjectKey{Name: name}, &apiextensionsv1.CustomResourceDefinition{}); err != nil {
+			if !errors.IsNotFound(err) {
+				return StatusFailed, fmt.Sprintf("failed to query CRD %s: %v", name, err)
+			}
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return StatusFailed, fmt.Sprintf("ECK CRDs are not installed: %s", strings.Join(missing, ", "))
+	}
+	return StatusPassed, "ECK CRDs are installed"
+}
+
+// checkBPFKernel checks that every Linux node runs a kernel that supports the eBPF dataplane, if it is selected.
+func checkBPFKernel(ctx context.Context, c client.Client, cfg Config) (Status, string) {
+	install := &operatorv1.Installation{}
+	if err := c.Get(ctx, utils.DefaultInstanceKey, install); err != nil {
+		if errors.IsNotFound(err) {
+			return StatusSkipped, "Installation is not configured"
+		}
+		return StatusFailed, fmt.Sprintf("failed to query Installation: %v", err)
+	}
+	if !install.Spec.BPFEnabled() {
+		return StatusSkipped, "eBPF dataplane is not enabled"
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return StatusFailed, fmt.Sprintf("failed to list nodes: %v", err)
+	}
+	failures := UnsupportedBPFNodes(nodes.Items)
+	if len(failures) > 0 {
+		var msgs []string
+		for _, f := range failures {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", f.Name, f.Reason))
+		}
+		return StatusFailed, strings.Join(msgs, "; ")
+	}
+	return StatusPassed, "all Linux nodes support the eBPF dataplane"
+}
+
+// checkExternalElasticsearch checks that the external Elasticsearch of every tenant accepts connections.
+func checkExternalElasticsearch(ctx context.Context, c client.Client, cfg Config) (Status, string) {
+	if !cfg.ElasticExternal {
+		return StatusSkipped, "Elasticsearch is not external"
+	}
+	tenants := &operatorv1.TenantList{}
+	if err := c.List(ctx, tenants); err != nil {
+		if meta.IsNoMatchError(err) {
+			return StatusSkipped, "no tenants configure an external Elasticsearch"
+		}
+		return StatusFailed, fmt.Sprintf("failed to list tenants: %v", err)
+	}
+
+	var checked int
+	var msgs []string
+	for _, t := range tenants.Items {
+		if t.Spec.Elastic == nil || t.Spec.Elastic.URL == "" {
+			continue
+		}
+		checked++
+		addr, err := elasticAddress(t.Spec.Elastic.URL)
+		if err == nil {
+			var conn net.Conn
+			if conn, err = dial(ctx, "tcp", addr); err == nil {
+				conn.Close()
+			}
+		}
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s/%s: %v", t.Namespace, t.Name, err))
+		}
+	}
+	if checked == 0 {
+		return StatusSkipped, "no tenants configure an external Elasticsearch"
+	}
+	if len(msgs) > 0 {
+		return StatusFailed, fmt.Sprintf("external Elasticsearch is not reachable for tenants %s", strings.Join(msgs, "; "))
+	}
+	return StatusPassed, fmt.Sprintf("external Elasticsearch is reachable for %d tenant(s)", checked)
+}
+
+// elasticAddress returns the host:port to dial for the given Elasticsearch URL.
+func elasticAddress(u string) (string, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Hostname() == "" {
+		return "", fmt.Errorf("URL %q has no host", u)
+	}
+	port := parsed.Port()
+	if port == "" {
+		port = "443"
+		if parsed.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(parsed.Hostname(), port), nil
+}
+
+func logStorage(ctx context.Context, c client.Client) (*operatorv1.LogStorage, error) {
+	ls := &operatorv1.LogStorage{}
+	if err := c.Get(ctx, utils.DefaultTSEEInstanceKey, ls); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query LogStorage: %w", err)
+	}
+	return ls, nil
+}
+
+// UnsupportedBPFNodes checks that the kernel of every Linux node supports the eBPF dataplane and returns the nodes
+// that do not. Nodes that do not report a kernel version are skipped.
+func UnsupportedBPFNodes(nodes []corev1.Node) []operatorv1.BPFNodeStatus {
+	var failures []operatorv1.BPFNodeStatus
+	for _, n := range nodes {
+		if n.Status.NodeInfo.OperatingSystem != "" && n.Status.NodeInfo.OperatingSystem != "linux" {
+			continue
+		}
+		kernel := n.Status.NodeInfo.KernelVersion
+		if kernel == "" {
+			continue
+		}
+		if !KernelSupportsBPF(kernel) {
+			failures = append(failures, operatorv1.BPFNodeStatus{
+				Name:   n.Name,
+				Reason: fmt.Sprintf("kernel %s does not support the eBPF dataplane", kernel),
+			})
+		}
+	}
+	return failures
+}
+
+// KernelSupportsBPF returns true if the given kernel version supports the eBPF dataplane. The eBPF dataplane requires
+// kernel 5.3 or later, or 4.18 on RHEL 8 and later which carries the backports.
+func KernelSupportsBPF(kernel string) bool {
+	m := kernelVersionRegexp.FindStringSubmatch(kernel)
+	if m == nil {
+		return false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	if major > 5 || major == 5 && minor >= 3 {
+		return true
+	}
+	return major == 4 && minor >= 18 && (strings.Contains(kernel, ".el8") || strings.Contains(kernel, ".el9"))
+}
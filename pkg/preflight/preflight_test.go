@@ -0,0 +1,219 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Preflight checks", func() {
+	var c client.Client
+	var ctx context.Context
+
+	resultFor := func(report *Report, name string) Result {
+		for _, r := range report.Results {
+			if r.Name == name {
+				return r
+			}
+		}
+		Fail(fmt.Sprintf("no result for check %s", name))
+		return Result{}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(storagev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+	})
+
+	It("should skip all checks on an empty cluster", func() {
+		report := Run(ctx, c, Config{})
+		Expect(report.Passed).To(BeTrue())
+		for _, r := range report.Results {
+			Expect(r.Status).To(Equal(StatusSkipped), r.Name)
+		}
+	})
+
+	Context("with LogStorage", func() {
+		BeforeEach(func() {
+			Expect(c.Create(ctx, &operatorv1.LogStorage{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
+		})
+
+		It("should fail if the storage class and the ECK CRDs are missing", func() {
+			report := Run(ctx, c, Config{})
+			Expect(report.Passed).To(BeFalse())
+			Expect(resultFor(report, "logstorage-storage-class")).To(Equal(Result{
+				Name:    "logstorage-storage-class",
+				Status:  StatusFailed,
+				Message: "StorageClass tigera-elasticsearch used by LogStorage does not exist",
+			}))
+			Expect(resultFor(report, "eck-crds").Status).To(Equal(StatusFailed))
+			Expect(resultFor(report, "eck-crds").Message).To(ContainSubstring("kibanas.kibana.k8s.elastic.co"))
+		})
+
+		It("should pass once the storage class and the ECK CRDs exist", func() {
+			Expect(c.Create(ctx, &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "tigera-elasticsearch"}})).NotTo(HaveOccurred())
+			for _, name := range eckCRDs {
+				Expect(c.Create(ctx, &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}})).NotTo(HaveOccurred())
+			}
+
+			report := Run(ctx, c, Config{})
+			Expect(report.Passed).To(BeTrue())
+			Expect(resultFor(report, "logstorage-storage-class").Status).To(Equal(StatusPassed))
+			Expect(resultFor(report, "eck-crds").Status).To(Equal(StatusPassed))
+		})
+
+		It("should use the storage class of the LogStorage", func() {
+			ls := &operatorv1.LogStorage{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, ls)).NotTo(HaveOccurred())
+			ls.Spec.StorageClassName = "fast"
+			Expect(c.Update(ctx, ls)).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast"}})).NotTo(HaveOccurred())
+
+			Expect(resultFor(Run(ctx, c, Config{}), "logstorage-storage-class").Status).To(Equal(StatusPassed))
+		})
+
+		It("should skip the Elasticsearch checks if Elasticsearch is external", func() {
+			report := Run(ctx, c, Config{ElasticExternal: true})
+			Expect(resultFor(report, "logstorage-storage-class").Status).To(Equal(StatusSkipped))
+			Expect(resultFor(report, "eck-crds").Status).To(Equal(StatusSkipped))
+		})
+	})
+
+	It("should check node kernels when the eBPF dataplane is enabled", func() {
+		bpf := operatorv1.LinuxDataplaneBPF
+		Expect(c.Create(ctx, &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operatorv1.InstallationSpec{CalicoNetwork: &operatorv1.CalicoNetworkSpec{LinuxDataplane: &bpf}},
+		})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "old"},
+			Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OperatingSystem: "linux", KernelVersion: "4.15.0"}},
+		})).NotTo(HaveOccurred())
+
+		report := Run(ctx, c, Config{})
+		Expect(report.Passed).To(BeFalse())
+		Expect(resultFor(report, "bpf-kernel")).To(Equal(Result{
+			Name:    "bpf-kernel",
+			Status:  StatusFailed,
+			Message: "old: kernel 4.15.0 does not support the eBPF dataplane",
+		}))
+	})
+
+	Context("with an external Elasticsearch", func() {
+		var dialed []string
+
+		BeforeEach(func() {
+			dialed = nil
+			dial = func(_ context.Context, _, addr string) (net.Conn, error) {
+				dialed = append(dialed, addr)
+				if addr == "unreachable.example.com:443" {
+					return nil, fmt.Errorf("connection refused")
+				}
+				client, server := net.Pipe()
+				server.Close()
+				return client, nil
+			}
+		})
+
+		AfterEach(func() {
+			dial = (&net.Dialer{Timeout: dialTimeout}).DialContext
+		})
+
+		It("should check the Elasticsearch of each tenant", func() {
+			Expect(c.Create(ctx, &operatorv1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-a"},
+				Spec:       operatorv1.TenantSpec{Elastic: &operatorv1.TenantElasticSpec{URL: "https://es.example.com:9200"}},
+			})).NotTo(HaveOccurred())
+
+			report := Run(ctx, c, Config{ElasticExternal: true})
+			Expect(report.Passed).To(BeTrue())
+			Expect(resultFor(report, "external-elasticsearch").Status).To(Equal(StatusPassed))
+			Expect(dialed).To(Equal([]string{"es.example.com:9200"}))
+
+			Expect(c.Create(ctx, &operatorv1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-b"},
+				Spec:       operatorv1.TenantSpec{Elastic: &operatorv1.TenantElasticSpec{URL: "https://unreachable.example.com"}},
+			})).NotTo(HaveOccurred())
+
+			report = Run(ctx, c, Config{ElasticExternal: true})
+			Expect(report.Passed).To(BeFalse())
+			Expect(resultFor(report, "external-elasticsearch").Message).To(Equal(
+				"external Elasticsearch is not reachable for tenants tenant-b/default: connection refused"))
+		})
+	})
+
+	It("should write the report as JSON", func() {
+		report := &Report{Passed: false, Results: []Result{{Name: "eck-crds", Status: StatusFailed, Message: "missing"}}}
+		var buf bytes.Buffer
+		Expect(report.Write(&buf, "json")).NotTo(HaveOccurred())
+
+		decoded := &Report{}
+		Expect(json.Unmarshal(buf.Bytes(), decoded)).NotTo(HaveOccurred())
+		Expect(decoded).To(Equal(report))
+		Expect(report.Write(&buf, "yaml")).To(HaveOccurred())
+	})
+
+	DescribeTable("should check the kernel version",
+		func(kernel string, supported bool) {
+			Expect(KernelSupportsBPF(kernel)).To(Equal(supported))
+		},
+		Entry("5.3", "5.3.0-1-generic", true),
+		Entry("5.10 on AWS", "5.10.184-175.749.amzn2.x86_64", true),
+		Entry("6.1", "6.1.0", true),
+		Entry("5.2", "5.2.21", false),
+		Entry("4.18 on RHEL 8", "4.18.0-305.el8.x86_64", true),
+		Entry("4.18 elsewhere", "4.18.0-1-generic", false),
+		Entry("unparseable", "unknown", false),
+	)
+
+	It("should only report Linux nodes with an unsupported kernel", func() {
+		node := func(name, os, kernel string) corev1.Node {
+			return corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OperatingSystem: os, KernelVersion: kernel}},
+			}
+		}
+		failures := UnsupportedBPFNodes([]corev1.Node{
+			node("new", "linux", "5.15.0"),
+			node("old", "linux", "4.15.0"),
+			node("windows", "windows", "10.0.17763.2686"),
+			node("unknown", "", ""),
+		})
+		Expect(failures).To(Equal([]operatorv1.BPFNodeStatus{{Name: "old", Reason: "kernel 4.15.0 does not support the eBPF dataplane"}}))
+	})
+})
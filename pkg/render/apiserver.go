@@ -126,6 +126,9 @@ type apiServerComponent struct {
 	cfg              *APIServerConfiguration
 	apiServerImage   string
 	queryServerImage string
+
+	// amd64Only is true if the API server image is only published for amd64.
+	amd64Only bool
 }
 
 func (c *apiServerComponent) ResolveImages(is *operatorv1.ImageSet) error {
@@ -145,17 +148,15 @@ func (c *apiServerComponent) ResolveImages(is *operatorv1.ImageSet) error {
 			errMsgs = append(errMsgs, err.Error())
 		}
 	} else {
+		apiServer := components.ComponentCalicoAPIServer
 		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.apiServerImage, err = components.GetReference(components.ComponentCalicoAPIServerFIPS, reg, path, prefix, is)
-			if err != nil {
-				errMsgs = append(errMsgs, err.Error())
-			}
-		} else {
-			c.apiServerImage, err = components.GetReference(components.ComponentCalicoAPIServer, reg, path, prefix, is)
-			if err != nil {
-				errMsgs = append(errMsgs, err.Error())
-			}
+			apiServer = components.ComponentCalicoAPIServerFIPS
+		}
+		c.apiServerImage, err = components.GetReference(apiServer, reg, path, prefix, is)
+		if err != nil {
+			errMsgs = append(errMsgs, err.Error())
 		}
+		c.amd64Only = !components.SupportsArchitecture(apiServer, components.ArchitectureARM64)
 	}
 
 	if len(errMsgs) != 0 {
@@ -1053,7 +1054,7 @@ func (c *apiServerComponent) apiServerContainer() corev1.Container {
 	// In case of OpenShift, apiserver needs privileged access to write audit logs to host path volume.
 	// Audit logs are owned by root on hosts so we need to be root user and group. Audit logs are supported only in Enterprise version.
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
-		apiServer.SecurityContext = securitycontext.NewRootContext(c.cfg.OpenShift)
+		apiServer.SecurityContext = securitycontext.NewBuilder().RunAsRoot().PrivilegedOnOpenShift(c.cfg.OpenShift).Build()
 	} else {
 		apiServer.SecurityContext = securitycontext.NewNonRootContext()
 	}
@@ -1184,7 +1185,7 @@ func (c *apiServerComponent) tolerations() []corev1.Toleration {
 	if c.hostNetwork() {
 		return rmeta.TolerateAll
 	}
-	return append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...)
+	return rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.Workload{AMD64Only: c.amd64Only, CriticalAddon: true}, rmeta.TolerateControlPlane...)
 }
 
 // networkPolicy returns a NP to allow traffic to the API server. This prevents it from
@@ -19,6 +19,7 @@ import (
 	_ "embed"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"text/template"
@@ -83,11 +84,15 @@ type Config struct {
 	// Optional config for WAF.
 	WAFEnabled           bool
 	ModSecurityConfigMap *corev1.ConfigMap
+	WAFNamespaceSelector string
+	WAFProtocols         []operatorv1.L7Protocol
 
 	// Optional config for L7 logs.
 	LogsEnabled            bool
 	LogRequestsPerInterval *int64
 	LogIntervalSeconds     *int64
+	LogNamespaceSelector   string
+	LogProtocols           []operatorv1.L7Protocol
 
 	// Optional config for ALP
 	ALPEnabled bool
@@ -106,6 +111,23 @@ type Config struct {
 	ApplicationLayer *operatorv1.ApplicationLayer
 }
 
+// LogsEnabledFor returns whether L7 logs are collected for traffic of the given protocol. It is used by the Envoy
+// configuration template.
+func (c *Config) LogsEnabledFor(protocol operatorv1.L7Protocol) bool {
+	return c.LogsEnabled && protocolSelected(c.LogProtocols, protocol)
+}
+
+// DikastesEnabledFor returns whether traffic of the given protocol is passed to Dikastes for ALP or WAF. It is used
+// by the Envoy configuration template.
+func (c *Config) DikastesEnabledFor(protocol operatorv1.L7Protocol) bool {
+	return c.ALPEnabled || (c.WAFEnabled && protocolSelected(c.WAFProtocols, protocol))
+}
+
+// protocolSelected returns whether the given protocol is in the list of protocols. An empty list selects all protocols.
+func protocolSelected(protocols []operatorv1.L7Protocol, protocol operatorv1.L7Protocol) bool {
+	return len(protocols) == 0 || slices.Contains(protocols, protocol)
+}
+
 func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
 	reg := c.config.Installation.Registry
 	path := c.config.Installation.ImagePath
@@ -241,11 +263,7 @@ func (c *component) containers() []corev1.Container {
 	var containers []corev1.Container
 
 	// Daemonset needs root and NET_ADMIN, NET_RAW permission to be able to use netfilter tproxy option.
-	sc := securitycontext.NewRootContext(false)
-	sc.Capabilities.Add = []corev1.Capability{
-		"NET_ADMIN",
-		"NET_RAW",
-	}
+	sc := securitycontext.NewBuilder().RunAsRoot().AddCapabilities("NET_ADMIN", "NET_RAW").Build()
 	proxy := corev1.Container{
 		Name:            ProxyContainerName,
 		Image:           c.config.proxyImage,
@@ -295,6 +313,9 @@ func (c *component) containers() []corev1.Container {
 				"--waf-log-file", filepath.Join(CalicologsVolumePath, "waf", "waf.log"),
 				"--waf-ruleset-file", filepath.Join(ModSecurityRulesetVolumePath, "tigera.conf"),
 			)
+			if c.config.WAFNamespaceSelector != "" {
+				commandArgs = append(commandArgs, "--waf-namespace-selector", c.config.WAFNamespaceSelector)
+			}
 			volMounts = append(
 				volMounts,
 				[]corev1.VolumeMount{
@@ -358,6 +379,13 @@ func (c *component) collectorEnv() []corev1.EnvVar {
 		})
 	}
 
+	if c.config.LogNamespaceSelector != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  "ENVOY_LOG_NAMESPACE_SELECTOR",
+			Value: c.config.LogNamespaceSelector,
+		})
+	}
+
 	return envs
 }
 
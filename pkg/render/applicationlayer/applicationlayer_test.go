@@ -16,6 +16,7 @@ package applicationlayer_test
 
 import (
 	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -655,4 +656,61 @@ var _ = Describe("Tigera Secure Application Layer rendering tests", func() {
 			Expect(dikastesVolMounts).To(ContainElement(expected))
 		}
 	})
+
+	It("should only collect l7 logs for the selected namespaces and protocols", func() {
+		cfg := &applicationlayer.Config{
+			Installation:         installation,
+			OsType:               rmeta.OSTypeLinux,
+			LogsEnabled:          true,
+			LogNamespaceSelector: "l7-logs=enabled",
+			LogProtocols:         []operatorv1.L7Protocol{operatorv1.L7ProtocolTLS},
+		}
+		Expect(cfg.LogsEnabledFor(operatorv1.L7ProtocolTLS)).To(BeTrue())
+		Expect(cfg.LogsEnabledFor(operatorv1.L7ProtocolHTTP)).To(BeFalse())
+		Expect(cfg.LogsEnabledFor(operatorv1.L7ProtocolTCP)).To(BeFalse())
+
+		resources, _ := applicationlayer.ApplicationLayer(cfg).Objects()
+
+		envoyConfigMap := rtest.GetResource(resources, applicationlayer.EnvoyConfigMapName, common.CalicoNamespace, "", "v1", "ConfigMap").(*corev1.ConfigMap)
+		envoyConfigMapContents := envoyConfigMap.Data[applicationlayer.EnvoyConfigMapKey]
+		Expect(strings.Count(envoyConfigMapContents, "envoy.access_loggers.file")).To(Equal(1))
+		Expect(envoyConfigMapContents).To(ContainSubstring(`type: "tls"`))
+		Expect(envoyConfigMapContents).NotTo(ContainSubstring(`type: "tcp"`))
+
+		ds := rtest.GetResource(resources, applicationlayer.ApplicationLayerDaemonsetName, common.CalicoNamespace, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		collectorContainer := ds.Spec.Template.Spec.Containers[1]
+		Expect(collectorContainer.Env).To(ContainElement(corev1.EnvVar{Name: "ENVOY_LOG_NAMESPACE_SELECTOR", Value: "l7-logs=enabled"}))
+	})
+
+	It("should only pass the selected protocols to the WAF", func() {
+		cm, err := embed.AsConfigMap(
+			applicationlayer.ModSecurityRulesetConfigMapName,
+			common.OperatorNamespace(),
+		)
+		Expect(err).To(BeNil())
+		cfg := &applicationlayer.Config{
+			Installation:         installation,
+			OsType:               rmeta.OSTypeLinux,
+			WAFEnabled:           true,
+			ModSecurityConfigMap: cm,
+			WAFNamespaceSelector: "waf=enabled",
+			WAFProtocols:         []operatorv1.L7Protocol{operatorv1.L7ProtocolHTTP2},
+		}
+		Expect(cfg.DikastesEnabledFor(operatorv1.L7ProtocolHTTP2)).To(BeTrue())
+		Expect(cfg.DikastesEnabledFor(operatorv1.L7ProtocolHTTP)).To(BeFalse())
+
+		// ALP needs all HTTP traffic to be passed to Dikastes, regardless of the WAF protocols.
+		cfg.ALPEnabled = true
+		Expect(cfg.DikastesEnabledFor(operatorv1.L7ProtocolHTTP)).To(BeTrue())
+		cfg.ALPEnabled = false
+
+		resources, _ := applicationlayer.ApplicationLayer(cfg).Objects()
+
+		envoyConfigMap := rtest.GetResource(resources, applicationlayer.EnvoyConfigMapName, common.CalicoNamespace, "", "v1", "ConfigMap").(*corev1.ConfigMap)
+		Expect(strings.Count(envoyConfigMap.Data[applicationlayer.EnvoyConfigMapKey], "envoy.filters.http.ext_authz")).To(Equal(1))
+
+		ds := rtest.GetResource(resources, applicationlayer.ApplicationLayerDaemonsetName, common.CalicoNamespace, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		dikastesContainer := ds.Spec.Template.Spec.Containers[1]
+		Expect(dikastesContainer.Command).To(ContainElements("--waf-namespace-selector", "waf=enabled"))
+	})
 })
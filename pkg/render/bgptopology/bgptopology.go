@@ -0,0 +1,172 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bgptopology renders the BGPPeers and BGPFilters that implement a BGPTopology.
+package bgptopology
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	"github.com/tigera/api/pkg/lib/numorstring"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+const (
+	// ManagedByLabel is set on the BGPPeers and BGPFilters that are rendered for a BGPTopology. Any BGPPeer or
+	// BGPFilter with this label that is not part of the BGPTopology is deleted.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "tigera-operator"
+
+	RouteReflectorPeerName = "route-reflectors"
+)
+
+// Config contains the information needed to render the BGP topology.
+type Config struct {
+	// BGPTopology is the topology to render. If nil, only the existing resources are deleted.
+	BGPTopology *operatorv1.BGPTopology
+
+	// ExistingPeers and ExistingFilters are the BGPPeers and BGPFilters that were rendered earlier.
+	ExistingPeers   []v3.BGPPeer
+	ExistingFilters []v3.BGPFilter
+}
+
+func BGPTopology(cfg *Config) render.Component {
+	return &component{cfg: cfg}
+}
+
+type component struct {
+	cfg *Config
+}
+
+func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
+	return nil
+}
+
+func (c *component) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeAny
+}
+
+func (c *component) Ready() bool {
+	return true
+}
+
+func (c *component) Objects() ([]client.Object, []client.Object) {
+	var filters []*v3.BGPFilter
+	var peers []*v3.BGPPeer
+	if c.cfg.BGPTopology != nil {
+		spec := c.cfg.BGPTopology.Spec
+		for _, f := range spec.Filters {
+			filters = append(filters, filter(f))
+		}
+		if rr := spec.RouteReflectors; rr != nil {
+			peers = append(peers, peer(RouteReflectorPeerName, v3.BGPPeerSpec{
+				NodeSelector: "all()",
+				PeerSelector: Selector(rr.NodeSelector),
+				Filters:      rr.Filters,
+			}))
+		}
+		for _, rack := range spec.Racks {
+			for i, p := range rack.Peers {
+				peers = append(peers, peer(RackPeerName(rack.Name, i), v3.BGPPeerSpec{
+					NodeSelector: Selector(rack.NodeSelector),
+					PeerIP:       p.PeerIP,
+					ASNumber:     numorstring.ASNumber(p.ASNumber),
+					Filters:      rack.Filters,
+				}))
+			}
+		}
+	}
+
+	// The filters are created before the peers that refer to them, and deleted after them.
+	var objsToCreate, objsToDelete []client.Object
+	rendered := map[string]bool{}
+	for _, f := range filters {
+		rendered[f.Name] = true
+		objsToCreate = append(objsToCreate, f)
+	}
+	for _, f := range c.cfg.ExistingFilters {
+		if !rendered[f.Name] {
+			objsToDelete = append(objsToDelete, &v3.BGPFilter{
+				TypeMeta:   metav1.TypeMeta{Kind: v3.KindBGPFilter, APIVersion: "projectcalico.org/v3"},
+				ObjectMeta: metav1.ObjectMeta{Name: f.Name},
+			})
+		}
+	}
+
+	rendered = map[string]bool{}
+	for _, p := range peers {
+		rendered[p.Name] = true
+		objsToCreate = append(objsToCreate, p)
+	}
+	var peersToDelete []client.Object
+	for _, p := range c.cfg.ExistingPeers {
+		if !rendered[p.Name] {
+			peersToDelete = append(peersToDelete, &v3.BGPPeer{
+				TypeMeta:   metav1.TypeMeta{Kind: v3.KindBGPPeer, APIVersion: "projectcalico.org/v3"},
+				ObjectMeta: metav1.ObjectMeta{Name: p.Name},
+			})
+		}
+	}
+
+	return objsToCreate, append(peersToDelete, objsToDelete...)
+}
+
+// RackPeerName returns the name of the BGPPeer for the i-th router of the rack.
+func RackPeerName(rack string, i int) string {
+	return fmt.Sprintf("rack-%s-%d", rack, i)
+}
+
+// Selector returns a Calico selector that selects the resources with all the given labels.
+func Selector(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "all()"
+	}
+	var terms []string
+	for k, v := range labels {
+		terms = append(terms, fmt.Sprintf("%s == '%s'", k, v))
+	}
+	sort.Strings(terms)
+	return strings.Join(terms, " && ")
+}
+
+func filter(f operatorv1.BGPTopologyFilter) *v3.BGPFilter {
+	return &v3.BGPFilter{
+		TypeMeta: metav1.TypeMeta{Kind: v3.KindBGPFilter, APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   f.Name,
+			Labels: map[string]string{ManagedByLabel: ManagedByValue},
+		},
+		Spec: *f.BGPFilterSpec.DeepCopy(),
+	}
+}
+
+func peer(name string, spec v3.BGPPeerSpec) *v3.BGPPeer {
+	return &v3.BGPPeer{
+		TypeMeta: metav1.TypeMeta{Kind: v3.KindBGPPeer, APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{ManagedByLabel: ManagedByValue},
+		},
+		Spec: spec,
+	}
+}
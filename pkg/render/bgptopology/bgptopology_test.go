@@ -0,0 +1,127 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgptopology_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	"github.com/tigera/api/pkg/lib/numorstring"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render/bgptopology"
+)
+
+var _ = Describe("BGPTopology rendering tests", func() {
+	var cfg *bgptopology.Config
+
+	BeforeEach(func() {
+		cfg = &bgptopology.Config{
+			BGPTopology: &operatorv1.BGPTopology{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec: operatorv1.BGPTopologySpec{
+					RouteReflectors: &operatorv1.BGPRouteReflectors{
+						NodeSelector: map[string]string{"route-reflector": "true"},
+						ClusterID:    "244.0.0.1",
+					},
+					Racks: []operatorv1.BGPRack{
+						{
+							Name:         "r1",
+							NodeSelector: map[string]string{"rack": "r1", "zone": "a"},
+							Peers: []operatorv1.BGPRackPeer{
+								{PeerIP: "10.0.0.1", ASNumber: 65001},
+								{PeerIP: "10.0.0.2", ASNumber: 65001},
+							},
+							Filters: []string{"export-pods"},
+						},
+					},
+					Filters: []operatorv1.BGPTopologyFilter{
+						{
+							Name: "export-pods",
+							BGPFilterSpec: v3.BGPFilterSpec{
+								ExportV4: []v3.BGPFilterRuleV4{{CIDR: "192.168.0.0/16", MatchOperator: v3.In, Action: v3.Accept}},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("renders the filters before the peers", func() {
+		toCreate, toDelete := bgptopology.BGPTopology(cfg).Objects()
+		Expect(toDelete).To(BeEmpty())
+		Expect(toCreate).To(HaveLen(4))
+
+		filter, ok := toCreate[0].(*v3.BGPFilter)
+		Expect(ok).To(BeTrue())
+		Expect(filter.Name).To(Equal("export-pods"))
+		Expect(filter.Labels).To(HaveKeyWithValue(bgptopology.ManagedByLabel, bgptopology.ManagedByValue))
+		Expect(filter.Spec.ExportV4).To(HaveLen(1))
+
+		rr, ok := toCreate[1].(*v3.BGPPeer)
+		Expect(ok).To(BeTrue())
+		Expect(rr.Name).To(Equal(bgptopology.RouteReflectorPeerName))
+		Expect(rr.Labels).To(HaveKeyWithValue(bgptopology.ManagedByLabel, bgptopology.ManagedByValue))
+		Expect(rr.Spec.NodeSelector).To(Equal("all()"))
+		Expect(rr.Spec.PeerSelector).To(Equal("route-reflector == 'true'"))
+
+		for i, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+			peer, ok := toCreate[2+i].(*v3.BGPPeer)
+			Expect(ok).To(BeTrue())
+			Expect(peer.Name).To(Equal(bgptopology.RackPeerName("r1", i)))
+			Expect(peer.Spec.NodeSelector).To(Equal("rack == 'r1' && zone == 'a'"))
+			Expect(peer.Spec.PeerIP).To(Equal(ip))
+			Expect(peer.Spec.ASNumber).To(Equal(numorstring.ASNumber(65001)))
+			Expect(peer.Spec.Filters).To(Equal([]string{"export-pods"}))
+		}
+	})
+
+	It("deletes the peers and filters that are no longer part of the topology", func() {
+		cfg.BGPTopology.Spec.Racks = nil
+		cfg.ExistingPeers = []v3.BGPPeer{
+			{ObjectMeta: metav1.ObjectMeta{Name: bgptopology.RouteReflectorPeerName}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "rack-r1-0"}},
+		}
+		cfg.ExistingFilters = []v3.BGPFilter{
+			{ObjectMeta: metav1.ObjectMeta{Name: "export-pods"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "old-filter"}},
+		}
+
+		toCreate, toDelete := bgptopology.BGPTopology(cfg).Objects()
+		Expect(toCreate).To(HaveLen(2))
+		Expect(toDelete).To(HaveLen(2))
+		// The peers are deleted before the filters they may refer to.
+		Expect(toDelete[0].GetName()).To(Equal("rack-r1-0"))
+		Expect(toDelete[1].GetName()).To(Equal("old-filter"))
+	})
+
+	It("only deletes the existing resources without a BGPTopology", func() {
+		cfg.BGPTopology = nil
+		cfg.ExistingPeers = []v3.BGPPeer{{ObjectMeta: metav1.ObjectMeta{Name: bgptopology.RouteReflectorPeerName}}}
+		cfg.ExistingFilters = []v3.BGPFilter{{ObjectMeta: metav1.ObjectMeta{Name: "export-pods"}}}
+
+		toCreate, toDelete := bgptopology.BGPTopology(cfg).Objects()
+		Expect(toCreate).To(BeEmpty())
+		Expect(toDelete).To(HaveLen(2))
+	})
+
+	It("selects all nodes with an empty selector", func() {
+		Expect(bgptopology.Selector(nil)).To(Equal("all()"))
+	})
+})
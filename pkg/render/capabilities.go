@@ -0,0 +1,161 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// optionalAPIs are the APIs that components render objects of, but that not every cluster serves: PodSecurityPolicies
+// were removed in Kubernetes v1.25, SecurityContextConstraints only exist on OpenShift, and the monitoring.coreos.com
+// APIs only exist once the Prometheus operator is installed. An empty kind stands for every kind of the group.
+var optionalAPIs = []schema.GroupKind{
+	{Group: "policy", Kind: "PodSecurityPolicy"},
+	{Group: "security.openshift.io", Kind: "SecurityContextConstraints"},
+	{Group: "monitoring.coreos.com"},
+}
+
+// IsOptionalAPI returns true if the kind belongs to an API that not every cluster serves.
+func IsOptionalAPI(gvk schema.GroupVersionKind) bool {
+	for _, api := range optionalAPIs {
+		if api.Group == gvk.Group && (api.Kind == "" || api.Kind == gvk.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingAPIPolicy is what is done with the objects of a component whose optional API the cluster doesn't serve.
+type MissingAPIPolicy string
+
+const (
+	// MissingAPISkip leaves out the objects of the missing APIs and renders the rest of the component.
+	MissingAPISkip MissingAPIPolicy = "Skip"
+
+	// MissingAPIFail fails the component, for components that don't work without the API.
+	MissingAPIFail MissingAPIPolicy = "Fail"
+)
+
+// MissingAPIPolicyComponent is implemented by the components whose objects of missing optional APIs should be handled
+// other than by skipping them.
+type MissingAPIPolicyComponent interface {
+	MissingAPIPolicy() MissingAPIPolicy
+}
+
+// MissingAPIPolicyOf returns the MissingAPIPolicy of the component, which is MissingAPISkip unless the component
+// implements MissingAPIPolicyComponent.
+func MissingAPIPolicyOf(component Component) MissingAPIPolicy {
+	if c, ok := component.(MissingAPIPolicyComponent); ok {
+		return c.MissingAPIPolicy()
+	}
+	return MissingAPISkip
+}
+
+// capabilitiesTTL is how long the APIs discovered for a group version are cached, so that APIs installed after the
+// operator started, such as the CRDs of the Prometheus operator, are picked up.
+const capabilitiesTTL = time.Minute
+
+// Capabilities detects through API discovery which of the optional APIs the cluster serves. It is safe for concurrent
+// use, and caches the results of discovery for capabilitiesTTL.
+type Capabilities struct {
+	discovery discovery.DiscoveryInterface
+	now       func() time.Time
+
+	lock  sync.Mutex
+	kinds map[schema.GroupVersion]discoveredKinds
+}
+
+type discoveredKinds struct {
+	kinds      map[string]bool
+	discovered time.Time
+}
+
+// NewCapabilities returns Capabilities that use the given discovery client.
+func NewCapabilities(d discovery.DiscoveryInterface) *Capabilities {
+	return &Capabilities{discovery: d, now: time.Now, kinds: map[schema.GroupVersion]discoveredKinds{}}
+}
+
+// Serves returns true if the cluster serves the kind.
+func (c *Capabilities) Serves(gvk schema.GroupVersionKind) (bool, error) {
+	gv := gvk.GroupVersion()
+	c.lock.Lock()
+	cached, ok := c.kinds[gv]
+	c.lock.Unlock()
+	if ok && c.now().Sub(cached.discovered) < capabilitiesTTL {
+		return cached.kinds[gvk.Kind], nil
+	}
+
+	resources, err := c.discovery.ServerResourcesForGroupVersion(gv.String())
+	if err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to discover the resources of %s: %w", gv, err)
+	}
+	cached = discoveredKinds{kinds: map[string]bool{}, discovered: c.now()}
+	if resources != nil {
+		for _, r := range resources.APIResources {
+			cached.kinds[r.Kind] = true
+		}
+	}
+	c.lock.Lock()
+	c.kinds[gv] = cached
+	c.lock.Unlock()
+	return cached.kinds[gvk.Kind], nil
+}
+
+// ServedObjects returns the objects whose API the cluster serves, along with the kinds of the objects of optional APIs
+// that it doesn't. Objects of APIs that aren't optional are always returned, so that a missing API that a component
+// depends on is still reported when its objects are applied. If c is nil, every API is taken to be served.
+func (c *Capabilities) ServedObjects(objs []client.Object, scheme *runtime.Scheme) ([]client.Object, []schema.GroupVersionKind, error) {
+	if c == nil {
+		return objs, nil, nil
+	}
+	var served []client.Object
+	var missing []schema.GroupVersionKind
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil || !IsOptionalAPI(gvk) {
+			// Objects of kinds that aren't registered fail when they are applied instead.
+			served = append(served, obj)
+			continue
+		}
+		ok, err := c.Serves(gvk)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			served = append(served, obj)
+		} else {
+			missing = appendUniqueKind(missing, gvk)
+		}
+	}
+	return served, missing, nil
+}
+
+func appendUniqueKind(kinds []schema.GroupVersionKind, gvk schema.GroupVersionKind) []schema.GroupVersionKind {
+	for _, k := range kinds {
+		if k == gvk {
+			return kinds
+		}
+	}
+	return append(kinds, gvk)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
+)
+
+var _ = Describe("Capabilities", func() {
+	var (
+		discovery    *fakediscovery.FakeDiscovery
+		capabilities *render.Capabilities
+		scheme       *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		discovery = kfake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+		discovery.Resources = []*metav1.APIResourceList{{
+			GroupVersion: "monitoring.coreos.com/v1",
+			APIResources: []metav1.APIResource{{Name: "servicemonitors", Kind: "ServiceMonitor"}},
+		}}
+		capabilities = render.NewCapabilities(discovery)
+		scheme = runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(monitoringv1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+	})
+
+	It("should only treat the optional APIs as optional", func() {
+		Expect(render.IsOptionalAPI(schema.GroupVersionKind{Group: "policy", Version: "v1beta1", Kind: "PodSecurityPolicy"})).To(BeTrue())
+		Expect(render.IsOptionalAPI(schema.GroupVersionKind{Group: "policy", Version: "v1", Kind: "PodDisruptionBudget"})).To(BeFalse())
+		Expect(render.IsOptionalAPI(schema.GroupVersionKind{Group: "security.openshift.io", Version: "v1", Kind: "SecurityContextConstraints"})).To(BeTrue())
+		Expect(render.IsOptionalAPI(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "Prometheus"})).To(BeTrue())
+		Expect(render.IsOptionalAPI(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})).To(BeFalse())
+	})
+
+	It("should detect the kinds that the cluster serves and cache them", func() {
+		Expect(capabilities.Serves(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"})).To(BeTrue())
+		Expect(capabilities.Serves(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "Prometheus"})).To(BeFalse())
+		Expect(capabilities.Serves(schema.GroupVersionKind{Group: "security.openshift.io", Version: "v1", Kind: "SecurityContextConstraints"})).To(BeFalse())
+		Expect(capabilities.Serves(schema.GroupVersionKind{Group: "security.openshift.io", Version: "v1", Kind: "SecurityContextConstraints"})).To(BeFalse())
+		Expect(discovery.Actions()).To(HaveLen(2))
+	})
+
+	It("should leave out the objects of the optional APIs that the cluster doesn't serve", func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}
+		sm := &monitoringv1.ServiceMonitor{ObjectMeta: metav1.ObjectMeta{Name: "sm", Namespace: "ns"}}
+		prometheus := &monitoringv1.Prometheus{ObjectMeta: metav1.ObjectMeta{Name: "prometheus", Namespace: "ns"}}
+		scc := securitycontextconstraints.NewNonRootSecurityContextConstraints("scc", nil)
+
+		served, missing, err := capabilities.ServedObjects([]client.Object{cm, sm, prometheus, scc, scc.DeepCopy()}, scheme)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(served).To(Equal([]client.Object{cm, sm}))
+		Expect(missing).To(Equal([]schema.GroupVersionKind{
+			{Group: "monitoring.coreos.com", Version: "v1", Kind: "Prometheus"},
+			{Group: "security.openshift.io", Version: "v1", Kind: "SecurityContextConstraints"},
+		}))
+	})
+
+	It("should take every API to be served without Capabilities", func() {
+		var none *render.Capabilities
+		scc := securitycontextconstraints.NewNonRootSecurityContextConstraints("scc", nil)
+		served, missing, err := none.ServedObjects([]client.Object{scc}, scheme)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(served).To(Equal([]client.Object{scc}))
+		Expect(missing).To(BeEmpty())
+	})
+})
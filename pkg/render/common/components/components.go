@@ -36,11 +36,12 @@ var log = logf.Log.WithName("components")
 
 // replicatedPodResource contains the overridable data for a Deployment or DaemonSet.
 type replicatedPodResource struct {
-	labels             map[string]string
-	annotations        map[string]string
-	minReadySeconds    *int32
-	podTemplateSpec    *corev1.PodTemplateSpec
-	deploymentStrategy *appsv1.DeploymentStrategy // Deployments only
+	labels                  map[string]string
+	annotations             map[string]string
+	minReadySeconds         *int32
+	podTemplateSpec         *corev1.PodTemplateSpec
+	deploymentStrategy      *appsv1.DeploymentStrategy      // Deployments only
+	daemonSetUpdateStrategy *appsv1.DaemonSetUpdateStrategy // DaemonSets only
 }
 
 // applyReplicatedPodResourceOverrides takes the given replicated pod resource data and applies the overrides.
@@ -74,12 +75,18 @@ func applyReplicatedPodResourceOverrides(r *replicatedPodResource, overrides com
 	if ds := overrides.GetDeploymentStrategy(); ds != nil {
 		r.deploymentStrategy = ds
 	}
+	if us := overrides.GetDaemonSetUpdateStrategy(); us != nil {
+		r.daemonSetUpdateStrategy = us
+	}
 	if initContainers := overrides.GetInitContainers(); initContainers != nil {
 		mergeContainers(r.podTemplateSpec.Spec.InitContainers, initContainers)
 	}
 	if containers := overrides.GetContainers(); containers != nil {
 		mergeContainers(r.podTemplateSpec.Spec.Containers, containers)
 	}
+	if probes := overrides.GetContainerProbes(); probes != nil {
+		applyProbeOverrides(r.podTemplateSpec.Spec.Containers, probes)
+	}
 	if affinity := overrides.GetAffinity(); affinity != nil {
 		r.podTemplateSpec.Spec.Affinity = affinity
 	}
@@ -96,6 +103,14 @@ func applyReplicatedPodResourceOverrides(r *replicatedPodResource, overrides com
 	if priorityClassName := overrides.GetPriorityClassName(); priorityClassName != "" {
 		r.podTemplateSpec.Spec.PriorityClassName = priorityClassName
 	}
+	if dns := overrides.GetPodDNSOverrides(); dns != nil {
+		if dns.DNSPolicy != "" {
+			r.podTemplateSpec.Spec.DNSPolicy = dns.DNSPolicy
+		}
+		if dns.DNSConfig != nil {
+			r.podTemplateSpec.Spec.DNSConfig = dns.DNSConfig
+		}
+	}
 
 	return r
 }
@@ -110,10 +125,11 @@ func ApplyDaemonSetOverrides(ds *appsv1.DaemonSet, overrides components.Replicat
 
 	// Pull out the data we'll override from the DaemonSet.
 	r := &replicatedPodResource{
-		labels:          ds.Labels,
-		annotations:     ds.Annotations,
-		minReadySeconds: &ds.Spec.MinReadySeconds,
-		podTemplateSpec: &ds.Spec.Template,
+		labels:                  ds.Labels,
+		annotations:             ds.Annotations,
+		minReadySeconds:         &ds.Spec.MinReadySeconds,
+		podTemplateSpec:         &ds.Spec.Template,
+		daemonSetUpdateStrategy: &ds.Spec.UpdateStrategy,
 	}
 	// Apply the overrides.
 	applyReplicatedPodResourceOverrides(r, overrides)
@@ -123,6 +139,7 @@ func ApplyDaemonSetOverrides(ds *appsv1.DaemonSet, overrides components.Replicat
 	ds.Annotations = r.annotations
 	ds.Spec.MinReadySeconds = *r.minReadySeconds
 	ds.Spec.Template = *r.podTemplateSpec
+	ds.Spec.UpdateStrategy = *r.daemonSetUpdateStrategy
 }
 
 // ApplyDeploymentOverrides applies the overrides to the given Deployment.
@@ -280,6 +297,45 @@ func mergeContainers(current []corev1.Container, provided []corev1.Container) {
 	}
 }
 
+// applyProbeOverrides overrides the timings of the probes of the given containers. A container that has no startup
+// probe gets one that performs the same check as its liveness probe when the startup probe timings are overridden.
+func applyProbeOverrides(containers []corev1.Container, probes map[string]operator.ContainerProbeOverrides) {
+	for i := range containers {
+		c := &containers[i]
+		override, ok := probes[c.Name]
+		if !ok {
+			continue
+		}
+		if override.StartupProbe != nil && c.StartupProbe == nil && c.LivenessProbe != nil {
+			c.StartupProbe = &corev1.Probe{ProbeHandler: *c.LivenessProbe.ProbeHandler.DeepCopy()}
+		}
+		applyProbeOverride(c.LivenessProbe, override.LivenessProbe)
+		applyProbeOverride(c.ReadinessProbe, override.ReadinessProbe)
+		applyProbeOverride(c.StartupProbe, override.StartupProbe)
+	}
+}
+
+func applyProbeOverride(probe *corev1.Probe, override *operator.ProbeOverride) {
+	if probe == nil || override == nil {
+		return
+	}
+	if override.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *override.InitialDelaySeconds
+	}
+	if override.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *override.TimeoutSeconds
+	}
+	if override.PeriodSeconds != nil {
+		probe.PeriodSeconds = *override.PeriodSeconds
+	}
+	if override.SuccessThreshold != nil {
+		probe.SuccessThreshold = *override.SuccessThreshold
+	}
+	if override.FailureThreshold != nil {
+		probe.FailureThreshold = *override.FailureThreshold
+	}
+}
+
 // ClusterRoleBinding returns a cluster role binding with the given name, that binds the given cluster role
 // to the service account in each of the provided namespaces.
 func ClusterRoleBinding(name, clusterRole, sa string, namespaces []string) *rbacv1.ClusterRoleBinding {
@@ -554,8 +554,121 @@ var _ = Describe("Common components render tests", func() {
 				Expect(result.Spec.Template.Spec.Tolerations).To(Equal(expected.Spec.Template.Spec.Tolerations))
 				Expect(result).To(Equal(expected))
 			}),
+		Entry("strategy",
+			defaultedDaemonSet,
+			func() *v1.CalicoNodeDaemonSet {
+				return &v1.CalicoNodeDaemonSet{
+					Spec: &v1.CalicoNodeDaemonSetSpec{
+						Strategy: &v1.DaemonSetUpdateStrategy{
+							RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+								MaxUnavailable: ptr.IntOrStrPtr("10%"),
+							},
+						},
+					},
+				}
+			},
+			func(result appsv1.DaemonSet) {
+				expected := defaultedDaemonSet()
+				expected.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+					Type: appsv1.RollingUpdateDaemonSetStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+						MaxUnavailable: ptr.IntOrStrPtr("10%"),
+					},
+				}
+				Expect(result).To(Equal(expected))
+			}),
 	)
 
+	It("should override probe timings", func() {
+		handler := corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/liveness"}}
+		ds := appsv1.DaemonSet{
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:           "calico-node",
+								LivenessProbe:  &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10, TimeoutSeconds: 10},
+								ReadinessProbe: &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10},
+							},
+							{
+								Name:          "other",
+								LivenessProbe: &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		ApplyDaemonSetOverrides(&ds, &v1.CalicoNodeDaemonSet{
+			Spec: &v1.CalicoNodeDaemonSetSpec{
+				Template: &v1.CalicoNodeDaemonSetPodTemplateSpec{
+					Spec: &v1.CalicoNodeDaemonSetPodSpec{
+						Containers: []v1.CalicoNodeDaemonSetContainer{{
+							Name: "calico-node",
+							ContainerProbeOverrides: v1.ContainerProbeOverrides{
+								LivenessProbe:  &v1.ProbeOverride{PeriodSeconds: &three},
+								ReadinessProbe: &v1.ProbeOverride{FailureThreshold: &three},
+								StartupProbe:   &v1.ProbeOverride{FailureThreshold: &three, PeriodSeconds: &three},
+							},
+						}},
+					},
+				},
+			},
+		})
+
+		containers := ds.Spec.Template.Spec.Containers
+		Expect(*containers[0].LivenessProbe).To(Equal(corev1.Probe{ProbeHandler: handler, PeriodSeconds: 3, TimeoutSeconds: 10}))
+		Expect(*containers[0].ReadinessProbe).To(Equal(corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10, FailureThreshold: 3}))
+		Expect(*containers[0].StartupProbe).To(Equal(corev1.Probe{ProbeHandler: handler, PeriodSeconds: 3, FailureThreshold: 3}))
+		Expect(*containers[1].LivenessProbe).To(Equal(corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10}))
+		Expect(containers[1].StartupProbe).To(BeNil())
+	})
+
+	It("should override the pod DNS policy and config", func() {
+		d := appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						DNSPolicy:  corev1.DNSClusterFirst,
+						Containers: []corev1.Container{{Name: "linseed"}},
+					},
+				},
+			},
+		}
+		dnsConfig := &corev1.PodDNSConfig{
+			Nameservers: []string{"169.254.20.10"},
+			Searches:    []string{"es.example.com"},
+		}
+
+		ApplyDeploymentOverrides(&d, &v1.LinseedDeployment{
+			Spec: &v1.LinseedDeploymentSpec{
+				Template: &v1.LinseedDeploymentPodTemplateSpec{
+					Spec: &v1.LinseedDeploymentPodSpec{
+						PodDNSOverrides: v1.PodDNSOverrides{DNSPolicy: corev1.DNSNone, DNSConfig: dnsConfig},
+					},
+				},
+			},
+		})
+		Expect(d.Spec.Template.Spec.DNSPolicy).To(Equal(corev1.DNSNone))
+		Expect(d.Spec.Template.Spec.DNSConfig).To(Equal(dnsConfig))
+
+		// Without a policy override, only the config is changed.
+		d.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+		ApplyDeploymentOverrides(&d, &v1.LinseedDeployment{
+			Spec: &v1.LinseedDeploymentSpec{
+				Template: &v1.LinseedDeploymentPodTemplateSpec{
+					Spec: &v1.LinseedDeploymentPodSpec{
+						PodDNSOverrides: v1.PodDNSOverrides{DNSConfig: dnsConfig},
+					},
+				},
+			},
+		})
+		Expect(d.Spec.Template.Spec.DNSPolicy).To(Equal(corev1.DNSClusterFirst))
+		Expect(d.Spec.Template.Spec.DNSConfig).To(Equal(dnsConfig))
+	})
+
 	DescribeTable("test ApplyDeploymentOverrides",
 		func(original func() appsv1.Deployment, override func() *v1.TyphaDeployment, expectations func(set appsv1.Deployment)) {
 			orig := original()
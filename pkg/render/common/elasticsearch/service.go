@@ -17,6 +17,7 @@ package elasticsearch
 import (
 	"fmt"
 
+	operatorv1 "github.com/tigera/operator/api/v1"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 )
 
@@ -53,3 +54,12 @@ func HTTPSEndpoint(osType rmeta.OSType, clusterDomain string) string {
 func ECKElasticEndpoint() string {
 	return "https://tigera-secure-es-http.tigera-elasticsearch.svc:9200"
 }
+
+// InternalElasticEndpoint returns the URL that the Elasticsearch provisioned by the ECK operator is reached at. This is
+// the ECK endpoint, unless the LogStorage overrides it. This endpoint is only valid when using internal elasticsearch.
+func InternalElasticEndpoint(ls *operatorv1.LogStorage) string {
+	if ls != nil && ls.Spec.ElasticsearchEndpoint != "" {
+		return ls.Spec.ElasticsearchEndpoint
+	}
+	return ECKElasticEndpoint()
+}
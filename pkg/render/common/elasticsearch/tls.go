@@ -1,4 +1,4 @@
-// Copyright (c) 2022 - 2023 Tigera, Inc. All rights reserved.
+// Copyright (c) 2022 - 2024 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -14,7 +14,32 @@
 
 package elasticsearch
 
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+)
+
 const (
 	PublicCertSecret = "tigera-secure-es-gateway-http-certs-public"
 	UnusedCertSecret = "tigera-secure-es-http-certs-unused"
 )
+
+// ServerTLSEnvVars returns the environment variables that configure the minimum TLS version and the cipher suites that
+// the server of a log storage component accepts, as set in the LogStorage. The names of the variables start with the
+// given prefix. Nothing is returned for the settings that are left to the defaults of the component.
+func ServerTLSEnvVars(prefix string, ls *operatorv1.LogStorage) []corev1.EnvVar {
+	if ls == nil || ls.Spec.TLS == nil {
+		return nil
+	}
+	var envVars []corev1.EnvVar
+	if ls.Spec.TLS.MinVersion != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: prefix + "TLS_MIN_VERSION", Value: string(*ls.Spec.TLS.MinVersion)})
+	}
+	if len(ls.Spec.TLS.CipherSuites) > 0 {
+		envVars = append(envVars, corev1.EnvVar{Name: prefix + "TLS_CIPHER_SUITES", Value: strings.Join(ls.Spec.TLS.CipherSuites, ",")})
+	}
+	return envVars
+}
@@ -16,7 +16,12 @@ package meta
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
+	"reflect"
+	"sort"
 	"time"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
@@ -37,6 +42,10 @@ const (
 	// NOTE: Do not change this field since we use this value to identify
 	// certificates managed by this operator.
 	TigeraOperatorCAIssuerPrefix = "tigera-operator-signer"
+
+	// MountedObjectsHashAnnotation is the pod template annotation holding a hash of the data of all the secrets and
+	// config maps used by the pod, so that the pods are rolled when any of them change.
+	MountedObjectsHashAnnotation = "hash.operator.tigera.io/mounted-objects"
 )
 
 var (
@@ -60,6 +69,15 @@ var (
 
 	TolerateCriticalAddonsAndControlPlane = append(TolerateControlPlane, TolerateCriticalAddonsOnly)
 
+	// TolerateGKEARM64 allows pods to be scheduled on GKE arm64 nodes, which GKE taints so that only workloads
+	// that are known to support arm64 are scheduled on them.
+	TolerateGKEARM64 = corev1.Toleration{
+		Key:      "kubernetes.io/arch",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "arm64",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+
 	// TolerateAll returns tolerations to tolerate all taints. When used, it is not necessary
 	// to include the user's custom tolerations because we already tolerate everything.
 	TolerateAll = []corev1.Toleration{
@@ -102,6 +120,189 @@ func SecretsAnnotationHash(secrets ...*corev1.Secret) string {
 	return AnnotationHash(annoteArr)
 }
 
+// SecretDataHash returns a SHA-256 hash of the content of the given secret. StringData is merged into Data the way the
+// API server does on write, so a secret that is rendered with StringData has the same hash as the secret read back
+// from the cluster. The hash only changes when the content changes: it does not depend on the order of the keys, and
+// a nil Data is the same as an empty one.
+func SecretDataHash(s *corev1.Secret) string {
+	data := make(map[string][]byte, len(s.Data)+len(s.StringData))
+	for k, v := range s.Data {
+		data[k] = v
+	}
+	for k, v := range s.StringData {
+		data[k] = []byte(v)
+	}
+	return dataHash(data)
+}
+
+// ConfigMapDataHash returns a SHA-256 hash of the content of the given config map, like SecretDataHash.
+func ConfigMapDataHash(cm *corev1.ConfigMap) string {
+	data := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for k, v := range cm.Data {
+		data[k] = []byte(v)
+	}
+	for k, v := range cm.BinaryData {
+		data[k] = v
+	}
+	return dataHash(data)
+}
+
+func dataHash(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		writeHashField(h, []byte(k))
+		writeHashField(h, data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeHashField writes a length prefixed field, so that different sequences of fields can not hash the same.
+func writeHashField(h hash.Hash, b []byte) {
+	_, _ = fmt.Fprintf(h, "%d:", len(b))
+	_, _ = h.Write(b)
+}
+
+// Workload describes the properties of a workload that decide which of the taints of the provider it tolerates.
+type Workload struct {
+	// AMD64Only is true if an image of the workload is only published for amd64.
+	AMD64Only bool
+
+	// CriticalAddon is true for the control plane components that the cluster depends on, which are allowed to run
+	// on the system node pools of the provider. Components that store or process large amounts of data, such as
+	// Elasticsearch, Kibana or Prometheus, are not critical add-ons and should not use up the resources of those pools.
+	CriticalAddon bool
+}
+
+// ProviderTolerations returns the tolerations for the taints that the given provider places on nodes that the
+// workload is able to run on:
+//   - GKE taints arm64 nodes. They are tolerated by workloads whose images are published for arm64.
+//   - AKS and EKS system node pools are tainted to only run critical add-ons. They are tolerated by the workloads
+//     that are critical add-ons.
+func ProviderTolerations(p operatorv1.Provider, w Workload) []corev1.Toleration {
+	switch {
+	case p.IsGKE() && !w.AMD64Only:
+		return []corev1.Toleration{TolerateGKEARM64}
+	case (p.IsAKS() || p.IsEKS()) && w.CriticalAddon:
+		return []corev1.Toleration{TolerateCriticalAddonsOnly}
+	}
+	return nil
+}
+
+// ControlPlaneTolerations returns the tolerations for control plane workloads: the control plane tolerations from
+// the installation, followed by the given tolerations and the tolerations for the taints of the installation's
+// provider that apply to the workload. Duplicates are removed, and the installation's tolerations are never modified.
+func ControlPlaneTolerations(installation *operatorv1.InstallationSpec, w Workload, tolerations ...corev1.Toleration) []corev1.Toleration {
+	var result []corev1.Toleration
+	for _, list := range [][]corev1.Toleration{
+		installation.ControlPlaneTolerations,
+		tolerations,
+		ProviderTolerations(installation.KubernetesProvider, w),
+	} {
+		for _, t := range list {
+			if !containsToleration(result, t) {
+				result = append(result, t)
+			}
+		}
+	}
+	return result
+}
+
+func containsToleration(tolerations []corev1.Toleration, t corev1.Toleration) bool {
+	for _, x := range tolerations {
+		if x.MatchToleration(&t) && reflect.DeepEqual(x.TolerationSeconds, t.TolerationSeconds) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReferencedSecretsAndConfigMaps returns the sorted names of the secrets and config maps that the pod spec uses
+// through its volumes and the environment of its containers. Image pull secrets are not included since changes to
+// them do not require the pods to be restarted.
+func ReferencedSecretsAndConfigMaps(spec *corev1.PodSpec) (secrets, configMaps []string) {
+	secretSet := map[string]bool{}
+	configMapSet := map[string]bool{}
+
+	for _, v := range spec.Volumes {
+		if v.Secret != nil {
+			secretSet[v.Secret.SecretName] = true
+		}
+		if v.ConfigMap != nil {
+			configMapSet[v.ConfigMap.Name] = true
+		}
+		if v.Projected != nil {
+			for _, src := range v.Projected.Sources {
+				if src.Secret != nil {
+					secretSet[src.Secret.Name] = true
+				}
+				if src.ConfigMap != nil {
+					configMapSet[src.ConfigMap.Name] = true
+				}
+			}
+		}
+	}
+
+	for _, c := range append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...) {
+		for _, e := range c.EnvFrom {
+			if e.SecretRef != nil {
+				secretSet[e.SecretRef.Name] = true
+			}
+			if e.ConfigMapRef != nil {
+				configMapSet[e.ConfigMapRef.Name] = true
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if e.ValueFrom.SecretKeyRef != nil {
+				secretSet[e.ValueFrom.SecretKeyRef.Name] = true
+			}
+			if e.ValueFrom.ConfigMapKeyRef != nil {
+				configMapSet[e.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+		}
+	}
+
+	return sortedKeys(secretSet), sortedKeys(configMapSet)
+}
+
+// MountedObjectsHash generates a hash based off of the data of the given secrets and config maps that can be used by
+// Deployments or DaemonSets to trigger a restart/rolling update when any of them change. The result does not depend
+// on the order of the objects.
+func MountedObjectsHash(secrets []*corev1.Secret, configMaps []*corev1.ConfigMap) string {
+	data := map[string]interface{}{}
+	for _, s := range secrets {
+		if s == nil {
+			continue
+		}
+		data[fmt.Sprintf("secret/%s/%s", s.Namespace, s.Name)] = []interface{}{s.Data, s.StringData}
+	}
+	for _, cm := range configMaps {
+		if cm == nil {
+			continue
+		}
+		data[fmt.Sprintf("configmap/%s/%s", cm.Namespace, cm.Name)] = []interface{}{cm.Data, cm.BinaryData}
+	}
+	// Maps are printed in key order, so the hash is stable.
+	return AnnotationHash(data)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // APIServerNamespace returns the namespace to use for the API server component.
 func APIServerNamespace(v operatorv1.ProductVariant) string {
 	if v == operatorv1.Calico {
@@ -0,0 +1,120 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+var _ = Describe("Meta helpers", func() {
+	Context("tolerations", func() {
+		userToleration := corev1.Toleration{Key: "user", Operator: corev1.TolerationOpExists}
+
+		DescribeTable("provider tolerations", func(provider operatorv1.Provider, w rmeta.Workload, expected []corev1.Toleration) {
+			Expect(rmeta.ProviderTolerations(provider, w)).To(Equal(expected))
+		},
+			Entry("none", operatorv1.ProviderNone, rmeta.Workload{CriticalAddon: true}, nil),
+			Entry("OpenShift", operatorv1.ProviderOpenShift, rmeta.Workload{CriticalAddon: true}, nil),
+			Entry("GKE", operatorv1.ProviderGKE, rmeta.Workload{}, []corev1.Toleration{rmeta.TolerateGKEARM64}),
+			Entry("GKE with an amd64 only image", operatorv1.ProviderGKE, rmeta.Workload{AMD64Only: true}, nil),
+			Entry("AKS", operatorv1.ProviderAKS, rmeta.Workload{}, nil),
+			Entry("AKS critical add-on", operatorv1.ProviderAKS, rmeta.Workload{CriticalAddon: true}, []corev1.Toleration{rmeta.TolerateCriticalAddonsOnly}),
+			Entry("EKS", operatorv1.ProviderEKS, rmeta.Workload{}, nil),
+			Entry("EKS critical add-on", operatorv1.ProviderEKS, rmeta.Workload{CriticalAddon: true}, []corev1.Toleration{rmeta.TolerateCriticalAddonsOnly}),
+		)
+
+		It("should combine installation, component and provider tolerations", func() {
+			installation := &operatorv1.InstallationSpec{
+				KubernetesProvider:      operatorv1.ProviderGKE,
+				ControlPlaneTolerations: []corev1.Toleration{userToleration},
+			}
+			Expect(rmeta.ControlPlaneTolerations(installation, rmeta.Workload{}, rmeta.TolerateControlPlane...)).To(Equal([]corev1.Toleration{
+				userToleration,
+				rmeta.TolerateControlPlane[0],
+				rmeta.TolerateControlPlane[1],
+				rmeta.TolerateGKEARM64,
+			}))
+		})
+
+		It("should not duplicate tolerations or modify the installation", func() {
+			installation := &operatorv1.InstallationSpec{
+				KubernetesProvider:      operatorv1.ProviderAKS,
+				ControlPlaneTolerations: make([]corev1.Toleration, 1, 10),
+			}
+			installation.ControlPlaneTolerations[0] = userToleration
+
+			tolerations := rmeta.ControlPlaneTolerations(installation, rmeta.Workload{CriticalAddon: true}, rmeta.TolerateCriticalAddonsAndControlPlane...)
+			Expect(tolerations).To(Equal(append([]corev1.Toleration{userToleration}, rmeta.TolerateCriticalAddonsAndControlPlane...)))
+			Expect(installation.ControlPlaneTolerations).To(Equal([]corev1.Toleration{userToleration}))
+			Expect(installation.ControlPlaneTolerations[:2][1]).To(Equal(corev1.Toleration{}))
+		})
+
+		It("should return no tolerations when there are none to add", func() {
+			Expect(rmeta.ControlPlaneTolerations(&operatorv1.InstallationSpec{}, rmeta.Workload{})).To(BeNil())
+		})
+	})
+
+	Context("content hashes", func() {
+		secret := func(data map[string][]byte) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "ns"},
+				Data:       data,
+			}
+		}
+
+		It("should hash string data and data the same way", func() {
+			s := secret(nil)
+			s.StringData = map[string]string{"password": "foo"}
+			Expect(rmeta.SecretDataHash(s)).To(Equal(rmeta.SecretDataHash(secret(map[string][]byte{"password": []byte("foo")}))))
+		})
+
+		It("should hash nil and empty data the same way", func() {
+			Expect(rmeta.SecretDataHash(secret(nil))).To(Equal(rmeta.SecretDataHash(secret(map[string][]byte{}))))
+		})
+
+		It("should only change when the content changes", func() {
+			s := secret(map[string][]byte{"password": []byte("foo")})
+			hash := rmeta.SecretDataHash(s)
+
+			// Metadata is not part of the hash.
+			s.ResourceVersion = "2"
+			s.Labels = map[string]string{"foo": "bar"}
+			Expect(rmeta.SecretDataHash(s)).To(Equal(hash))
+
+			s.Data["password"] = []byte("bar")
+			Expect(rmeta.SecretDataHash(s)).NotTo(Equal(hash))
+		})
+
+		It("should not be ambiguous about key and value boundaries", func() {
+			Expect(rmeta.SecretDataHash(secret(map[string][]byte{"ab": []byte("c")}))).
+				NotTo(Equal(rmeta.SecretDataHash(secret(map[string][]byte{"a": []byte("bc")}))))
+		})
+
+		It("should hash config map binary data", func() {
+			cm := &corev1.ConfigMap{Data: map[string]string{"a": "b"}}
+			hash := rmeta.ConfigMapDataHash(cm)
+			cm.BinaryData = map[string][]byte{"c": []byte("d")}
+			Expect(rmeta.ConfigMapDataHash(cm)).NotTo(Equal(hash))
+		})
+	})
+})
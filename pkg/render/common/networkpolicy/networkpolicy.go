@@ -16,8 +16,10 @@ package networkpolicy
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
+	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
@@ -28,6 +30,10 @@ const (
 	TigeraComponentTierName              = "allow-tigera"
 	TigeraComponentPolicyPrefix          = TigeraComponentTierName + "."
 	TigeraComponentDefaultDenyPolicyName = TigeraComponentPolicyPrefix + "default-deny"
+
+	// NamespaceDefaultDenyPolicyName is the name of the Kubernetes NetworkPolicy that denies all traffic in the
+	// namespaces of the components.
+	NamespaceDefaultDenyPolicyName = "tigera-default-deny"
 )
 
 var (
@@ -160,6 +166,20 @@ func Ports(ports ...uint16) []numorstring.Port {
 	return nsPorts
 }
 
+// AddressEntityRule returns the entity rule of a destination outside of the cluster. A host that is not an IP address is
+// matched as a domain name.
+func AddressEntityRule(host string, port uint16) v3.EntityRule {
+	rule := v3.EntityRule{Ports: Ports(port)}
+	if ip := net.ParseIP(host); ip == nil {
+		rule.Domains = []string{host}
+	} else if ip.To4() != nil {
+		rule.Nets = []string{ip.String() + "/32"}
+	} else {
+		rule.Nets = []string{ip.String() + "/128"}
+	}
+	return rule
+}
+
 func AllowTigeraDefaultDeny(namespace string) *v3.NetworkPolicy {
 	return &v3.NetworkPolicy{
 		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
@@ -175,6 +195,22 @@ func AllowTigeraDefaultDeny(namespace string) *v3.NetworkPolicy {
 	}
 }
 
+// NamespaceDefaultDeny returns a Kubernetes NetworkPolicy that denies all ingress and egress traffic of the pods in the
+// namespace. It is evaluated after the allow-tigera tier, so it only denies the traffic that is passed on by it.
+func NamespaceDefaultDeny(namespace string) *netv1.NetworkPolicy {
+	return &netv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "networking.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NamespaceDefaultDenyPolicyName,
+			Namespace: namespace,
+		},
+		Spec: netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress, netv1.PolicyTypeEgress},
+		},
+	}
+}
+
 // Entity rules not belonging to Calico/Tigera components.
 var KubeAPIServerEntityRule = v3.EntityRule{
 	NamespaceSelector: "projectcalico.org/name == 'default'",
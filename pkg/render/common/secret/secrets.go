@@ -17,6 +17,7 @@ package secret
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/openshift/library-go/pkg/crypto"
@@ -26,6 +27,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -113,6 +115,44 @@ func CopyToNamespace(ns string, oSecrets ...*corev1.Secret) []*corev1.Secret {
 	return secrets
 }
 
+const (
+	// MirrorLabel is set on the secrets created by MirrorToNamespace, so that the secret mirror controller can find them.
+	MirrorLabel = "operator.tigera.io/mirrored"
+	// MirrorSourceAnnotation holds the <namespace>/<name> of the secret that a mirrored secret is copied from.
+	MirrorSourceAnnotation = "operator.tigera.io/mirror-source"
+	// PullSecretLabel is set on the copies of the Installation's image pull secrets, so that the pull secret
+	// controller can find and delete the copies of secrets that are no longer configured.
+	PullSecretLabel = "operator.tigera.io/pull-secret"
+)
+
+// MirrorToNamespace returns copies of the given secrets in the given namespace, like CopyToNamespace. The copies are
+// labeled with the secret they were copied from, so that the secret mirror controller keeps their data in sync with
+// the source and deletes them once the source is deleted. The given secrets must therefore exist in the cluster; a
+// secret without a namespace, or one that is already in the given namespace, is copied as is.
+func MirrorToNamespace(ns string, oSecrets ...*corev1.Secret) []*corev1.Secret {
+	secrets := CopyToNamespace(ns, oSecrets...)
+	for i, s := range oSecrets {
+		if s.Namespace == "" || s.Namespace == ns {
+			continue
+		}
+		secrets[i].Labels = map[string]string{MirrorLabel: "true"}
+		secrets[i].Annotations = map[string]string{MirrorSourceAnnotation: fmt.Sprintf("%s/%s", s.Namespace, s.Name)}
+	}
+	return secrets
+}
+
+// MirrorSource returns the key of the secret that the given secret is mirrored from, or false if it is not a mirror.
+func MirrorSource(s *corev1.Secret) (types.NamespacedName, bool) {
+	if s.Labels[MirrorLabel] != "true" {
+		return types.NamespacedName{}, false
+	}
+	ns, name, found := strings.Cut(s.Annotations[MirrorSourceAnnotation], "/")
+	if !found || ns == "" || name == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: ns, Name: name}, true
+}
+
 // ToRuntimeObjects converts the given list of secrets to a list of client.Objects
 func ToRuntimeObjects(secrets ...*corev1.Secret) []client.Object {
 	var objs []client.Object
@@ -0,0 +1,107 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securitycontext
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+// Builder builds a container security context. It starts from the non-root and non-privileged context that complies
+// with the "restricted" pod security standard and the nonroot-v2 OpenShift SCC. Components that need more access
+// must ask for it explicitly, so any deviation from the defaults is visible where the container is rendered.
+type Builder struct {
+	sc *corev1.SecurityContext
+}
+
+// NewBuilder returns a Builder for the default non-root and non-privileged container security context.
+func NewBuilder() *Builder {
+	return &Builder{
+		sc: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.BoolToPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			Privileged:   ptr.BoolToPtr(false),
+			RunAsGroup:   &runAsGroupID,
+			RunAsNonRoot: ptr.BoolToPtr(true),
+			RunAsUser:    &runAsUserID,
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+	}
+}
+
+// RunAsRoot runs the container as the root user and group, for containers that access host files or network.
+func (b *Builder) RunAsRoot() *Builder {
+	b.sc.RunAsGroup = ptr.Int64ToPtr(0)
+	b.sc.RunAsNonRoot = ptr.BoolToPtr(false)
+	b.sc.RunAsUser = ptr.Int64ToPtr(0)
+	return b
+}
+
+// RunAsUser runs the container as the given non-root user, for images that expect a specific UID.
+func (b *Builder) RunAsUser(uid int64) *Builder {
+	b.sc.RunAsUser = &uid
+	return b
+}
+
+// RunAsGroup runs the container with the given primary group.
+func (b *Builder) RunAsGroup(gid int64) *Builder {
+	b.sc.RunAsGroup = &gid
+	return b
+}
+
+// AddCapabilities adds the given capabilities to the container. All other capabilities remain dropped.
+func (b *Builder) AddCapabilities(caps ...corev1.Capability) *Builder {
+	b.sc.Capabilities.Add = append(b.sc.Capabilities.Add, caps...)
+	return b
+}
+
+// KeepDefaultCapabilities stops dropping the capabilities that the container runtime grants by default.
+func (b *Builder) KeepDefaultCapabilities() *Builder {
+	b.sc.Capabilities.Drop = []corev1.Capability{}
+	return b
+}
+
+// AllowPrivilegeEscalation allows processes in the container to gain more privileges than their parent.
+func (b *Builder) AllowPrivilegeEscalation() *Builder {
+	b.sc.AllowPrivilegeEscalation = ptr.BoolToPtr(true)
+	return b
+}
+
+// Privileged runs the container as privileged. This is the escape hatch for containers that need full access to the
+// host, and should only be used by components that cannot work otherwise.
+func (b *Builder) Privileged() *Builder {
+	b.sc.AllowPrivilegeEscalation = ptr.BoolToPtr(true)
+	b.sc.Privileged = ptr.BoolToPtr(true)
+	return b
+}
+
+// PrivilegedOnOpenShift runs the container as privileged when openShift is true. OpenShift SCCs only allow
+// privileged containers to write to host path volumes, which root containers elsewhere can do unprivileged.
+func (b *Builder) PrivilegedOnOpenShift(openShift bool) *Builder {
+	if openShift {
+		return b.Privileged()
+	}
+	return b
+}
+
+// Build returns the security context.
+func (b *Builder) Build() *corev1.SecurityContext {
+	return b.sc.DeepCopy()
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securitycontext_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tigera/operator/pkg/render/common/securitycontext"
+)
+
+var _ = Describe("Security context builder", func() {
+	It("should build a restricted non-root context by default", func() {
+		sc := securitycontext.NewBuilder().Build()
+		Expect(sc).To(Equal(securitycontext.NewNonRootContext()))
+		Expect(*sc.RunAsUser).To(BeEquivalentTo(10001))
+		Expect(*sc.RunAsGroup).To(BeEquivalentTo(10001))
+	})
+
+	It("should build root contexts", func() {
+		sc := securitycontext.NewBuilder().RunAsRoot().Build()
+		Expect(sc).To(Equal(securitycontext.NewRootContext(false)))
+		Expect(*sc.RunAsUser).To(BeEquivalentTo(0))
+		Expect(*sc.Privileged).To(BeFalse())
+
+		sc = securitycontext.NewBuilder().RunAsRoot().Privileged().Build()
+		Expect(sc).To(Equal(securitycontext.NewRootContext(true)))
+		Expect(*sc.Privileged).To(BeTrue())
+		Expect(*sc.AllowPrivilegeEscalation).To(BeTrue())
+	})
+
+	It("should only make containers privileged on OpenShift when asked to", func() {
+		Expect(*securitycontext.NewBuilder().RunAsRoot().PrivilegedOnOpenShift(false).Build().Privileged).To(BeFalse())
+		Expect(*securitycontext.NewBuilder().RunAsRoot().PrivilegedOnOpenShift(true).Build().Privileged).To(BeTrue())
+	})
+
+	It("should add capabilities while still dropping the rest", func() {
+		sc := securitycontext.NewBuilder().AddCapabilities("NET_ADMIN").AddCapabilities("NET_RAW").Build()
+		Expect(sc.Capabilities.Add).To(Equal([]corev1.Capability{"NET_ADMIN", "NET_RAW"}))
+		Expect(sc.Capabilities.Drop).To(Equal([]corev1.Capability{"ALL"}))
+	})
+
+	It("should not share state between built contexts", func() {
+		b := securitycontext.NewBuilder()
+		first := b.Build()
+		second := b.RunAsUser(999).Build()
+		Expect(*first.RunAsUser).To(BeEquivalentTo(10001))
+		Expect(*second.RunAsUser).To(BeEquivalentTo(999))
+	})
+
+	It("should only loosen the settings that are asked for", func() {
+		sc := securitycontext.NewBuilder().KeepDefaultCapabilities().Build()
+		Expect(sc.Capabilities.Drop).To(BeEmpty())
+		Expect(*sc.AllowPrivilegeEscalation).To(BeFalse())
+
+		sc = securitycontext.NewBuilder().AllowPrivilegeEscalation().Build()
+		Expect(*sc.AllowPrivilegeEscalation).To(BeTrue())
+		Expect(*sc.Privileged).To(BeFalse())
+		Expect(*sc.RunAsNonRoot).To(BeTrue())
+	})
+})
@@ -32,36 +32,16 @@ var (
 // NewNonRootContext returns the non-root and non-privileged container security context that most of
 // the containers should be using.
 func NewNonRootContext() *corev1.SecurityContext {
-	return &corev1.SecurityContext{
-		AllowPrivilegeEscalation: ptr.BoolToPtr(false),
-		Capabilities: &corev1.Capabilities{
-			Drop: []corev1.Capability{"ALL"},
-		},
-		Privileged:   ptr.BoolToPtr(false),
-		RunAsGroup:   &runAsGroupID,
-		RunAsNonRoot: ptr.BoolToPtr(true),
-		RunAsUser:    &runAsUserID,
-		SeccompProfile: &corev1.SeccompProfile{
-			Type: corev1.SeccompProfileTypeRuntimeDefault,
-		},
-	}
+	return NewBuilder().Build()
 }
 
 // NewRootContext returns the root container security context for containers that access host files or network.
 func NewRootContext(privileged bool) *corev1.SecurityContext {
-	return &corev1.SecurityContext{
-		AllowPrivilegeEscalation: ptr.BoolToPtr(privileged),
-		Capabilities: &corev1.Capabilities{
-			Drop: []corev1.Capability{"ALL"},
-		},
-		Privileged:   ptr.BoolToPtr(privileged),
-		RunAsGroup:   ptr.Int64ToPtr(0),
-		RunAsNonRoot: ptr.BoolToPtr(false),
-		RunAsUser:    ptr.Int64ToPtr(0),
-		SeccompProfile: &corev1.SeccompProfile{
-			Type: corev1.SeccompProfileTypeRuntimeDefault,
-		},
+	b := NewBuilder().RunAsRoot()
+	if privileged {
+		b.Privileged()
 	}
+	return b.Build()
 }
 
 func NewWindowsHostProcessContext() *corev1.SecurityContext {
@@ -0,0 +1,105 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package servicemesh renders components so that they run inside the service mesh configured on the Installation.
+package servicemesh
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+)
+
+const (
+	IstioInjectLabel                   = "sidecar.istio.io/inject"
+	IstioProxyConfigAnnotation         = "proxy.istio.io/config"
+	IstioExcludeInboundPortsAnnotation = "traffic.sidecar.istio.io/excludeInboundPorts"
+
+	LinkerdInjectAnnotation           = "linkerd.io/inject"
+	LinkerdProxyAwaitAnnotation       = "config.linkerd.io/proxy-await"
+	LinkerdSkipInboundPortsAnnotation = "config.linkerd.io/skip-inbound-ports"
+)
+
+// ApplyPodTemplate adds the sidecar of the mesh to the pods of template, and holds the start of their containers
+// until the sidecar is ready, so that the components can reach other meshed services as soon as they start. Traffic
+// to tlsPorts, on which the component terminates TLS itself, is not intercepted by the sidecar so that mesh mTLS is
+// not layered on top of it. Nothing is changed if mesh is nil.
+func ApplyPodTemplate(template *corev1.PodTemplateSpec, mesh *operatorv1.ServiceMesh, tlsPorts ...uint16) {
+	if mesh == nil {
+		return
+	}
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+
+	var ports []string
+	for _, p := range tlsPorts {
+		ports = append(ports, fmt.Sprint(p))
+	}
+
+	switch mesh.Type {
+	case operatorv1.ServiceMeshTypeIstio:
+		template.Labels[IstioInjectLabel] = "true"
+		template.Annotations[IstioProxyConfigAnnotation] = `{"holdApplicationUntilProxyStarts":true}`
+		if len(ports) > 0 {
+			template.Annotations[IstioExcludeInboundPortsAnnotation] = strings.Join(ports, ",")
+		}
+	case operatorv1.ServiceMeshTypeLinkerd:
+		template.Annotations[LinkerdInjectAnnotation] = "enabled"
+		template.Annotations[LinkerdProxyAwaitAnnotation] = "enabled"
+		if len(ports) > 0 {
+			template.Annotations[LinkerdSkipInboundPortsAnnotation] = strings.Join(ports, ",")
+		}
+	}
+}
+
+// AppendEgressRules appends a rule to the provided slice that allows the sidecar of the mesh to reach the control
+// plane of the mesh, which it gets its configuration and certificates from. Nothing is appended if mesh is nil.
+func AppendEgressRules(egressRules []v3.Rule, mesh *operatorv1.ServiceMesh) []v3.Rule {
+	if mesh == nil {
+		return egressRules
+	}
+	switch mesh.Type {
+	case operatorv1.ServiceMeshTypeIstio:
+		egressRules = append(egressRules, v3.Rule{
+			Action:   v3.Allow,
+			Protocol: &networkpolicy.TCPProtocol,
+			Destination: v3.EntityRule{
+				NamespaceSelector: "projectcalico.org/name == 'istio-system'",
+				Selector:          "app == 'istiod'",
+				Ports:             networkpolicy.Ports(15012),
+			},
+		})
+	case operatorv1.ServiceMeshTypeLinkerd:
+		egressRules = append(egressRules, v3.Rule{
+			Action:   v3.Allow,
+			Protocol: &networkpolicy.TCPProtocol,
+			Destination: v3.EntityRule{
+				NamespaceSelector: "projectcalico.org/name == 'linkerd'",
+				Selector:          "linkerd.io/control-plane-ns == 'linkerd'",
+				Ports:             networkpolicy.Ports(8080, 8086, 8090),
+			},
+		})
+	}
+	return egressRules
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicemesh_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/servicemesh"
+)
+
+var _ = Describe("Service mesh rendering", func() {
+	var template *corev1.PodTemplateSpec
+
+	BeforeEach(func() {
+		template = &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"hash": "abc"}}}
+	})
+
+	It("should not change anything when no service mesh is configured", func() {
+		servicemesh.ApplyPodTemplate(template, nil, 5554)
+		Expect(template.Labels).To(BeEmpty())
+		Expect(template.Annotations).To(Equal(map[string]string{"hash": "abc"}))
+
+		Expect(servicemesh.AppendEgressRules([]v3.Rule{}, nil)).To(BeEmpty())
+	})
+
+	It("should inject the Istio sidecar", func() {
+		mesh := &operatorv1.ServiceMesh{Type: operatorv1.ServiceMeshTypeIstio}
+		servicemesh.ApplyPodTemplate(template, mesh, 5554, 9200)
+		Expect(template.Labels).To(Equal(map[string]string{servicemesh.IstioInjectLabel: "true"}))
+		Expect(template.Annotations).To(Equal(map[string]string{
+			"hash":                                 "abc",
+			servicemesh.IstioProxyConfigAnnotation: `{"holdApplicationUntilProxyStarts":true}`,
+			servicemesh.IstioExcludeInboundPortsAnnotation: "5554,9200",
+		}))
+
+		rules := servicemesh.AppendEgressRules([]v3.Rule{}, mesh)
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].Destination.NamespaceSelector).To(Equal("projectcalico.org/name == 'istio-system'"))
+		Expect(rules[0].Destination.Ports).To(Equal(networkpolicy.Ports(15012)))
+	})
+
+	It("should inject the Linkerd sidecar", func() {
+		mesh := &operatorv1.ServiceMesh{Type: operatorv1.ServiceMeshTypeLinkerd}
+		servicemesh.ApplyPodTemplate(template, mesh, 5554)
+		Expect(template.Labels).To(BeEmpty())
+		Expect(template.Annotations).To(Equal(map[string]string{
+			"hash":                                        "abc",
+			servicemesh.LinkerdInjectAnnotation:           "enabled",
+			servicemesh.LinkerdProxyAwaitAnnotation:       "enabled",
+			servicemesh.LinkerdSkipInboundPortsAnnotation: "5554",
+		}))
+
+		rules := servicemesh.AppendEgressRules([]v3.Rule{}, mesh)
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].Destination.NamespaceSelector).To(Equal("projectcalico.org/name == 'linkerd'"))
+		Expect(rules[0].Destination.Ports).To(Equal(networkpolicy.Ports(8080, 8086, 8090)))
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	ocsv1 "github.com/openshift/api/security/v1"
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -38,6 +39,7 @@ import (
 	"github.com/tigera/operator/pkg/render"
 	rcomp "github.com/tigera/operator/pkg/render/common/components"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
@@ -79,6 +81,9 @@ type Config struct {
 
 	OpenShift         bool
 	NamespaceAndNames []string
+
+	// Whether the allow-tigera tier exists and the egress gateway policy can be rendered.
+	IncludeV3NetworkPolicy bool
 }
 
 func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
@@ -118,6 +123,10 @@ func (c *component) Objects() ([]client.Object, []client.Object) {
 		objectsToDelete = append(objectsToDelete, c.egwRole(), c.egwRoleBinding())
 	}
 
+	if c.config.IncludeV3NetworkPolicy {
+		objectsToCreate = append(objectsToCreate, c.egwAllowTigeraPolicy())
+	}
+
 	objectsToCreate = append(objectsToCreate, c.egwDeployment())
 	return objectsToCreate, objectsToDelete
 }
@@ -191,8 +200,7 @@ func (c *component) egwInitContainer() *corev1.Container {
 }
 
 func (c *component) egwContainer() *corev1.Container {
-	sc := securitycontext.NewRootContext(false)
-	sc.Capabilities.Add = []corev1.Capability{"NET_ADMIN", "NET_RAW"}
+	sc := securitycontext.NewBuilder().RunAsRoot().AddCapabilities("NET_ADMIN", "NET_RAW").Build()
 
 	return &corev1.Container{
 		Name:            "egress-gateway",
@@ -353,6 +361,41 @@ func (c *component) egwRoleBinding() *rbacv1.RoleBinding {
 	}
 }
 
+// egwAllowTigeraPolicy allows the VXLAN traffic from the nodes and the health checks that the egress gateway depends on.
+// All other traffic is passed to subsequent tiers, since the gateway forwards application traffic that is subject to
+// the user's own policy.
+func (c *component) egwAllowTigeraPolicy() *v3.NetworkPolicy {
+	return &v3.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      networkpolicy.TigeraComponentPolicyPrefix + c.config.EgressGW.Name,
+			Namespace: c.config.EgressGW.Namespace,
+		},
+		Spec: v3.NetworkPolicySpec{
+			Order:    &networkpolicy.HighPrecedenceOrder,
+			Tier:     networkpolicy.TigeraComponentTierName,
+			Selector: labelSelector(c.config.EgressGW.Spec.Template.Metadata.Labels),
+			Types:    []v3.PolicyType{v3.PolicyTypeIngress},
+			Ingress: []v3.Rule{
+				{
+					Action:      v3.Allow,
+					Protocol:    &networkpolicy.UDPProtocol,
+					Destination: v3.EntityRule{Ports: networkpolicy.Ports(uint16(c.config.VXLANPort))},
+				},
+				{
+					Action:      v3.Allow,
+					Protocol:    &networkpolicy.TCPProtocol,
+					Destination: v3.EntityRule{Ports: networkpolicy.Ports(uint16(DefaultHealthPort))},
+				},
+				{
+					// Pass to subsequent tiers for further enforcement
+					Action: v3.Pass,
+				},
+			},
+		},
+	}
+}
+
 func (c *component) egwServiceAccount() *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		TypeMeta: metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
@@ -461,6 +504,16 @@ func SecurityContextConstraints() *ocsv1.SecurityContextConstraints {
 	return scc
 }
 
+// labelSelector converts the pod labels into a Calico selector.
+func labelSelector(labels map[string]string) string {
+	var terms []string
+	for k, v := range labels {
+		terms = append(terms, fmt.Sprintf("%s == '%s'", k, v))
+	}
+	sort.Strings(terms)
+	return strings.Join(terms, " && ")
+}
+
 func concatString(arr []string) string {
 	str, err := json.Marshal(arr)
 	if err != nil {
@@ -18,6 +18,8 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -26,6 +28,7 @@ import (
 
 	operatorv1 "github.com/tigera/operator/api/v1"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/egressgateway"
 )
@@ -280,6 +283,27 @@ var _ = Describe("Egress Gateway rendering tests", func() {
 		Expect(elasticIPAnnotation).To(Equal("[\"1.2.3.4\",\"5.6.7.8\"]"))
 	})
 
+	It("should render the allow-tigera policy when the tier exists", func() {
+		component := egressgateway.EgressGateway(&egressgateway.Config{
+			Installation:           installation,
+			OSType:                 rmeta.OSTypeLinux,
+			EgressGW:               egw,
+			VXLANVNI:               4097,
+			VXLANPort:              4790,
+			IncludeV3NetworkPolicy: true,
+		})
+		resources, _ := component.Objects()
+		policy := rtest.GetResource(resources, "allow-tigera.egress-test", "test-ns", "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+		Expect(policy.Spec.Tier).To(Equal(networkpolicy.TigeraComponentTierName))
+		Expect(policy.Spec.Selector).To(Equal("egress-code == 'red'"))
+		Expect(policy.Spec.Types).To(Equal([]v3.PolicyType{v3.PolicyTypeIngress}))
+		Expect(policy.Spec.Ingress).To(Equal([]v3.Rule{
+			{Action: v3.Allow, Protocol: &networkpolicy.UDPProtocol, Destination: v3.EntityRule{Ports: networkpolicy.Ports(4790)}},
+			{Action: v3.Allow, Protocol: &networkpolicy.TCPProtocol, Destination: v3.EntityRule{Ports: networkpolicy.Ports(8080)}},
+			{Action: v3.Pass},
+		}))
+	})
+
 	It("should create SecurityContextConstraints if platform is OpenShift", func() {
 		expectedResources := []struct {
 			name    string
@@ -51,6 +51,10 @@ const (
 	S3KeyIdName                = "key-id"
 	S3KeySecretName            = "key-secret"
 
+	// AWSRoleARNAnnotation is the ServiceAccount annotation from which the EKS pod identity webhook configures pods
+	// to assume an IAM role, so that they don't need static AWS credentials.
+	AWSRoleARNAnnotation = "eks.amazonaws.com/role-arn"
+
 	// FluentdPrometheusTLSSecretName is the name of the secret containing the key pair fluentd presents to identify itself.
 	// Somewhat confusingly, this is named the prometheus TLS key pair because that was the first
 	// use-case for this credential. However, it is used on all TLS connections served by fluentd.
@@ -283,6 +287,9 @@ func (c *fluentdComponent) Objects() ([]client.Object, []client.Object) {
 	}
 	if c.cfg.S3Credential != nil {
 		objs = append(objs, c.s3CredentialSecret())
+	} else if c.s3RoleARN() != "" {
+		// The S3 bucket is accessed with an IAM role, so remove any credentials copied for an earlier configuration.
+		toDelete = append(toDelete, &corev1.Secret{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: S3FluentdSecretName, Namespace: LogCollectorNamespace}})
 	}
 	if c.cfg.SplkCredential != nil {
 		objs = append(objs, secret.ToRuntimeObjects(secret.CopyToNamespace(LogCollectorNamespace, c.splunkCredentialSecret()...)...)...)
@@ -406,11 +413,24 @@ func (c *fluentdComponent) splunkCredentialSecret() []*corev1.Secret {
 	}
 }
 
+// s3RoleARN returns the IAM role that fluentd assumes to write to S3, or an empty string if it uses the static
+// credentials from the log-collector-s3-credentials secret.
+func (c *fluentdComponent) s3RoleARN() string {
+	if c.cfg.LogCollector.Spec.AdditionalStores == nil || c.cfg.LogCollector.Spec.AdditionalStores.S3 == nil {
+		return ""
+	}
+	return c.cfg.LogCollector.Spec.AdditionalStores.S3.RoleARN
+}
+
 func (c *fluentdComponent) fluentdServiceAccount() *corev1.ServiceAccount {
-	return &corev1.ServiceAccount{
+	sa := &corev1.ServiceAccount{
 		TypeMeta:   metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
 		ObjectMeta: metav1.ObjectMeta{Name: c.fluentdNodeName(), Namespace: LogCollectorNamespace},
 	}
+	if roleARN := c.s3RoleARN(); roleARN != "" {
+		sa.Annotations = map[string]string{AWSRoleARNAnnotation: roleARN}
+	}
+	return sa
 }
 
 // packetCaptureApiRole creates a role in the tigera-fluentd namespace to allow pod/exec
@@ -648,7 +668,7 @@ func (c *fluentdComponent) envvars() []corev1.EnvVar {
 
 	if c.cfg.LogCollector.Spec.AdditionalStores != nil {
 		s3 := c.cfg.LogCollector.Spec.AdditionalStores.S3
-		if s3 != nil {
+		if s3 != nil && s3.RoleARN == "" {
 			envs = append(envs,
 				corev1.EnvVar{
 					Name: "AWS_KEY_ID",
@@ -672,6 +692,10 @@ func (c *fluentdComponent) envvars() []corev1.EnvVar {
 						},
 					},
 				},
+			)
+		}
+		if s3 != nil {
+			envs = append(envs,
 				corev1.EnvVar{Name: "S3_STORAGE", Value: "true"},
 				corev1.EnvVar{Name: "S3_BUCKET_NAME", Value: s3.BucketName},
 				corev1.EnvVar{Name: "AWS_REGION", Value: s3.Region},
@@ -1044,7 +1068,7 @@ func (c *fluentdComponent) eksLogForwarderDeployment() *appsv1.Deployment {
 					Annotations: annots,
 				},
 				Spec: corev1.PodSpec{
-					Tolerations:        c.cfg.Installation.ControlPlaneTolerations,
+					Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.Workload{}),
 					ServiceAccountName: EKSLogForwarderName,
 					ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 					InitContainers: []corev1.Container{{
@@ -709,6 +709,36 @@ var _ = Describe("Tigera Secure Fluentd rendering tests", func() {
 			}
 		}
 	})
+	It("should render with S3 configuration using an IAM role", func() {
+		cfg.LogCollector.Spec.AdditionalStores = &operatorv1.AdditionalLogStoreSpec{
+			S3: &operatorv1.S3StoreSpec{
+				Region:     "anyplace",
+				BucketName: "thebucket",
+				BucketPath: "bucketpath",
+				RoleARN:    "arn:aws:iam::123456789012:role/fluentd",
+			},
+		}
+
+		component := render.Fluentd(cfg)
+		resources, toDelete := component.Objects()
+		Expect(rtest.GetResource(resources, "log-collector-s3-credentials", "tigera-fluentd", "", "v1", "Secret")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, "log-collector-s3-credentials", "tigera-fluentd", "", "v1", "Secret")).NotTo(BeNil())
+
+		sa := rtest.GetResource(resources, "fluentd-node", "tigera-fluentd", "", "v1", "ServiceAccount").(*corev1.ServiceAccount)
+		Expect(sa.Annotations).To(HaveKeyWithValue("eks.amazonaws.com/role-arn", "arn:aws:iam::123456789012:role/fluentd"))
+
+		ds := rtest.GetResource(resources, "fluentd-node", "tigera-fluentd", "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(ds.Spec.Template.Annotations).NotTo(HaveKey("hash.operator.tigera.io/s3-credentials"))
+		envs := ds.Spec.Template.Spec.Containers[0].Env
+		Expect(envs).To(ContainElements(
+			corev1.EnvVar{Name: "S3_STORAGE", Value: "true"},
+			corev1.EnvVar{Name: "S3_BUCKET_NAME", Value: "thebucket"},
+			corev1.EnvVar{Name: "AWS_REGION", Value: "anyplace"},
+		))
+		for _, env := range envs {
+			Expect(env.Name).NotTo(BeElementOf("AWS_KEY_ID", "AWS_SECRET_KEY"))
+		}
+	})
 	It("should render with Syslog configuration", func() {
 		expectedResources := []struct {
 			name    string
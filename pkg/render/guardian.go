@@ -21,6 +21,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -31,9 +32,11 @@ import (
 	"github.com/tigera/api/pkg/lib/numorstring"
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/ptr"
 	rcomponents "github.com/tigera/operator/pkg/render/common/components"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/podaffinity"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
@@ -139,7 +142,14 @@ func (c *GuardianComponent) Objects() ([]client.Object, []client.Object) {
 		managerClusterWideDefaultView(),
 	)
 
-	return objs, nil
+	var objsToDelete []client.Object
+	if c.replicas() > 1 {
+		objs = append(objs, c.podDisruptionBudget())
+	} else {
+		objsToDelete = append(objsToDelete, c.podDisruptionBudget())
+	}
+
+	return objs, objsToDelete
 }
 
 func (c *GuardianComponent) Ready() bool {
@@ -244,8 +254,36 @@ func (c *GuardianComponent) clusterRoleBinding() *rbacv1.ClusterRoleBinding {
 	}
 }
 
+// replicas returns the number of guardian pods. Each of them opens a tunnel to the management cluster.
+func (c *GuardianComponent) replicas() int32 {
+	if c.cfg.ManagementClusterConnection == nil {
+		return 1
+	}
+	return c.cfg.ManagementClusterConnection.Spec.GuardianDeployment.GetReplicas()
+}
+
+// podDisruptionBudget keeps all but one of the tunnels to the management cluster open while nodes are drained.
+func (c *GuardianComponent) podDisruptionBudget() *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt(1)
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{Kind: "PodDisruptionBudget", APIVersion: "policy/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GuardianDeploymentName,
+			Namespace: GuardianNamespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"k8s-app": GuardianDeploymentName,
+				},
+			},
+		},
+	}
+}
+
 func (c *GuardianComponent) deployment() *appsv1.Deployment {
-	var replicas int32 = 1
+	replicas := c.replicas()
 
 	d := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
@@ -267,7 +305,7 @@ func (c *GuardianComponent) deployment() *appsv1.Deployment {
 				Spec: corev1.PodSpec{
 					NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
 					ServiceAccountName: GuardianServiceAccountName,
-					Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateCriticalAddonsAndControlPlane...),
+					Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.Workload{CriticalAddon: true}, rmeta.TolerateCriticalAddonsAndControlPlane...),
 					ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 					Containers:         c.container(),
 					Volumes:            c.volumes(),
@@ -276,6 +314,19 @@ func (c *GuardianComponent) deployment() *appsv1.Deployment {
 		},
 	}
 
+	if replicas > 1 {
+		// With a single tunnel, the old pod is stopped before the new one connects. With more, the pods are replaced
+		// one at a time on different nodes, so that the management cluster can always reach this cluster.
+		d.Spec.Strategy = appsv1.DeploymentStrategy{
+			Type: appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{
+				MaxUnavailable: ptr.IntOrStrPtr("0"),
+				MaxSurge:       ptr.IntOrStrPtr("1"),
+			},
+		}
+		d.Spec.Template.Spec.Affinity = podaffinity.NewPodAntiAffinity(GuardianDeploymentName, GuardianNamespace)
+	}
+
 	if c.cfg.ManagementClusterConnection != nil {
 		if overrides := c.cfg.ManagementClusterConnection.Spec.GuardianDeployment; overrides != nil {
 			rcomponents.ApplyDeploymentOverrides(d, overrides)
@@ -22,6 +22,7 @@ import (
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +39,7 @@ import (
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/podaffinity"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/testutils"
 )
@@ -329,5 +331,65 @@ var _ = Describe("guardian", func() {
 			Expect(container).NotTo(BeNil())
 			Expect(container.Resources).To(Equal(guardianResources))
 		})
+
+		It("should apply the scheduling overrides of the guardian pods", func() {
+			tolerations := []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "tunnel", Effect: corev1.TaintEffectNoSchedule}}
+			cfg.ManagementClusterConnection = &operatorv1.ManagementClusterConnection{
+				Spec: operatorv1.ManagementClusterConnectionSpec{
+					GuardianDeployment: &operatorv1.GuardianDeployment{
+						Spec: &operatorv1.GuardianDeploymentSpec{
+							Template: &operatorv1.GuardianDeploymentPodTemplateSpec{
+								Spec: &operatorv1.GuardianDeploymentPodSpec{
+									NodeSelector: map[string]string{"role": "tunnel"},
+									Tolerations:  tolerations,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			resources, _ := render.Guardian(cfg).Objects()
+			deployment, ok := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(deployment.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("role", "tunnel"))
+			Expect(deployment.Spec.Template.Spec.Tolerations).To(Equal(tolerations))
+		})
+
+		It("should run a single replica that is recreated by default", func() {
+			resources, toDelete := render.Guardian(cfg).Objects()
+			deployment, ok := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(1))
+			Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RecreateDeploymentStrategyType))
+			Expect(deployment.Spec.Template.Spec.Affinity).To(BeNil())
+			Expect(rtest.GetResource(toDelete, render.GuardianDeploymentName, render.GuardianNamespace, "policy", "v1", "PodDisruptionBudget")).NotTo(BeNil())
+		})
+
+		It("should spread multiple replicas and replace them one at a time", func() {
+			replicas := int32(3)
+			cfg.ManagementClusterConnection = &operatorv1.ManagementClusterConnection{
+				Spec: operatorv1.ManagementClusterConnectionSpec{
+					GuardianDeployment: &operatorv1.GuardianDeployment{
+						Spec: &operatorv1.GuardianDeploymentSpec{Replicas: &replicas},
+					},
+				},
+			}
+
+			resources, toDelete := render.Guardian(cfg).Objects()
+			Expect(toDelete).To(BeEmpty())
+			deployment, ok := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(3))
+			Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+			Expect(deployment.Spec.Strategy.RollingUpdate.MaxUnavailable.IntValue()).To(Equal(0))
+			Expect(deployment.Spec.Strategy.RollingUpdate.MaxSurge.IntValue()).To(Equal(1))
+			Expect(deployment.Spec.Template.Spec.Affinity).To(Equal(podaffinity.NewPodAntiAffinity(render.GuardianDeploymentName, render.GuardianNamespace)))
+
+			pdb, ok := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "policy", "v1", "PodDisruptionBudget").(*policyv1.PodDisruptionBudget)
+			Expect(ok).To(BeTrue())
+			Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			Expect(pdb.Spec.Selector.MatchLabels).To(Equal(map[string]string{"k8s-app": render.GuardianDeploymentName}))
+		})
 	})
 })
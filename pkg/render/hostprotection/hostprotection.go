@@ -0,0 +1,207 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostprotection
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+)
+
+const (
+	// CreatedByLabel is set on the HostEndpoints that the operator manages, so that they can be told apart from
+	// HostEndpoints created by users or by kube-controllers.
+	CreatedByLabel = "projectcalico.org/created-by"
+	CreatedByValue = "tigera-operator"
+
+	HostEndpointNameSuffix = "-tigera-hep"
+	AllInterfaces          = "*"
+	DefaultAllowProfile    = "projectcalico-default-allow"
+
+	FailsafePolicyName = networkpolicy.TigeraComponentPolicyPrefix + "host-endpoint-failsafe"
+)
+
+var (
+	// The failsafe ports match the default FailsafeInboundHostPorts and FailsafeOutboundHostPorts of Felix, plus the
+	// kubelet port that the Kubernetes API server connects to.
+	failsafeInboundTCPPorts  = []uint16{22, 179, 2379, 2380, 5473, 6443, 10250}
+	failsafeInboundUDPPorts  = []uint16{68}
+	failsafeOutboundTCPPorts = []uint16{179, 2379, 2380, 5473, 6443}
+	failsafeOutboundUDPPorts = []uint16{53, 67}
+)
+
+// Config contains the information needed to render the HostEndpoints of the cluster.
+type Config struct {
+	HostProtection *operatorv1.HostProtection
+
+	// Nodes are the nodes that a HostEndpoint is rendered for.
+	Nodes []corev1.Node
+
+	// ExistingHostEndpoints are the HostEndpoints that the operator created earlier. The ones that do not belong to
+	// any of the Nodes are deleted.
+	ExistingHostEndpoints []v3.HostEndpoint
+
+	// FailsafePolicy renders the failsafe policy in the allow-tigera tier if true, and deletes it otherwise. It must
+	// only be set for Calico Enterprise.
+	FailsafePolicy bool
+	Variant        operatorv1.ProductVariant
+}
+
+func HostProtection(cfg *Config) render.Component {
+	return &component{cfg: cfg}
+}
+
+type component struct {
+	cfg *Config
+}
+
+func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
+	return nil
+}
+
+func (c *component) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeAny
+}
+
+func (c *component) Ready() bool {
+	return true
+}
+
+func (c *component) Objects() ([]client.Object, []client.Object) {
+	var objsToCreate, objsToDelete []client.Object
+
+	rendered := map[string]bool{}
+	for _, node := range c.cfg.Nodes {
+		hep := c.hostEndpoint(node)
+		rendered[hep.Name] = true
+		objsToCreate = append(objsToCreate, hep)
+	}
+	for _, hep := range c.cfg.ExistingHostEndpoints {
+		if !rendered[hep.Name] {
+			objsToDelete = append(objsToDelete, &v3.HostEndpoint{
+				TypeMeta:   metav1.TypeMeta{Kind: "HostEndpoint", APIVersion: "projectcalico.org/v3"},
+				ObjectMeta: metav1.ObjectMeta{Name: hep.Name},
+			})
+		}
+	}
+
+	if c.cfg.Variant == operatorv1.TigeraSecureEnterprise {
+		if c.cfg.FailsafePolicy {
+			objsToCreate = append(objsToCreate, failsafePolicy())
+		} else {
+			objsToDelete = append(objsToDelete, failsafePolicy())
+		}
+	}
+
+	return objsToCreate, objsToDelete
+}
+
+// HostEndpointName returns the name of the HostEndpoint that the operator renders for the node.
+func HostEndpointName(nodeName string) string {
+	return nodeName + HostEndpointNameSuffix
+}
+
+func (c *component) hostEndpoint(node corev1.Node) *v3.HostEndpoint {
+	spec := c.cfg.HostProtection.Spec
+
+	labels := map[string]string{}
+	for k, v := range node.Labels {
+		labels[k] = v
+	}
+	for k, v := range spec.Labels {
+		labels[k] = v
+	}
+	labels[CreatedByLabel] = CreatedByValue
+
+	interfaceName := spec.InterfaceName
+	if interfaceName == "" {
+		interfaceName = AllInterfaces
+	}
+
+	var expectedIPs []string
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == corev1.NodeInternalIP || addr.Type == corev1.NodeExternalIP {
+			expectedIPs = append(expectedIPs, addr.Address)
+		}
+	}
+
+	return &v3.HostEndpoint{
+		TypeMeta: metav1.TypeMeta{Kind: "HostEndpoint", APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   HostEndpointName(node.Name),
+			Labels: labels,
+		},
+		Spec: v3.HostEndpointSpec{
+			Node:          node.Name,
+			InterfaceName: interfaceName,
+			ExpectedIPs:   expectedIPs,
+			Profiles:      []string{DefaultAllowProfile},
+		},
+	}
+}
+
+// failsafePolicy allows the traffic of the HostEndpoints that the cluster needs to function, and passes all other
+// traffic on to the tiers that follow, so that user policies cannot lock out the nodes.
+func failsafePolicy() *v3.GlobalNetworkPolicy {
+	return &v3.GlobalNetworkPolicy{
+		TypeMeta: metav1.TypeMeta{Kind: "GlobalNetworkPolicy", APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: FailsafePolicyName,
+		},
+		Spec: v3.GlobalNetworkPolicySpec{
+			Order:    &networkpolicy.HighPrecedenceOrder,
+			Tier:     networkpolicy.TigeraComponentTierName,
+			Selector: CreatedByLabel + " == '" + CreatedByValue + "'",
+			Types:    []v3.PolicyType{v3.PolicyTypeIngress, v3.PolicyTypeEgress},
+			Ingress: []v3.Rule{
+				{
+					Action:      v3.Allow,
+					Protocol:    &networkpolicy.TCPProtocol,
+					Destination: v3.EntityRule{Ports: networkpolicy.Ports(failsafeInboundTCPPorts...)},
+				},
+				{
+					Action:      v3.Allow,
+					Protocol:    &networkpolicy.UDPProtocol,
+					Destination: v3.EntityRule{Ports: networkpolicy.Ports(failsafeInboundUDPPorts...)},
+				},
+				{
+					Action: v3.Pass,
+				},
+			},
+			Egress: []v3.Rule{
+				{
+					Action:      v3.Allow,
+					Protocol:    &networkpolicy.TCPProtocol,
+					Destination: v3.EntityRule{Ports: networkpolicy.Ports(failsafeOutboundTCPPorts...)},
+				},
+				{
+					Action:      v3.Allow,
+					Protocol:    &networkpolicy.UDPProtocol,
+					Destination: v3.EntityRule{Ports: networkpolicy.Ports(failsafeOutboundUDPPorts...)},
+				},
+				{
+					Action: v3.Pass,
+				},
+			},
+		},
+	}
+}
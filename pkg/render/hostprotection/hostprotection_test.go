@@ -0,0 +1,124 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostprotection_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/hostprotection"
+)
+
+var _ = Describe("HostProtection rendering tests", func() {
+	var cfg *hostprotection.Config
+
+	node := func(name string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/hostname": name}},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{
+					{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+					{Type: corev1.NodeExternalIP, Address: "1.2.3.4"},
+					{Type: corev1.NodeHostName, Address: name},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cfg = &hostprotection.Config{
+			HostProtection: &operatorv1.HostProtection{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			Nodes:          []corev1.Node{node("node-a")},
+			Variant:        operatorv1.Calico,
+		}
+	})
+
+	It("renders a HostEndpoint for each node", func() {
+		cfg.HostProtection.Spec.Labels = map[string]string{"host-protection": "enabled"}
+		toCreate, toDelete := hostprotection.HostProtection(cfg).Objects()
+		Expect(toDelete).To(BeEmpty())
+		Expect(toCreate).To(HaveLen(1))
+
+		hep := toCreate[0].(*v3.HostEndpoint)
+		Expect(hep.Name).To(Equal("node-a-tigera-hep"))
+		Expect(hep.Labels).To(Equal(map[string]string{
+			"kubernetes.io/hostname":       "node-a",
+			"host-protection":              "enabled",
+			"projectcalico.org/created-by": "tigera-operator",
+		}))
+		Expect(hep.Spec).To(Equal(v3.HostEndpointSpec{
+			Node:          "node-a",
+			InterfaceName: "*",
+			ExpectedIPs:   []string{"10.0.0.1", "1.2.3.4"},
+			Profiles:      []string{"projectcalico-default-allow"},
+		}))
+	})
+
+	It("applies the HostEndpoints to the configured interface", func() {
+		cfg.HostProtection.Spec.InterfaceName = "eth0"
+		toCreate, _ := hostprotection.HostProtection(cfg).Objects()
+		Expect(toCreate[0].(*v3.HostEndpoint).Spec.InterfaceName).To(Equal("eth0"))
+	})
+
+	It("deletes the HostEndpoints of nodes that are no longer selected", func() {
+		cfg.ExistingHostEndpoints = []v3.HostEndpoint{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-a-tigera-hep"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-b-tigera-hep"}},
+		}
+		toCreate, toDelete := hostprotection.HostProtection(cfg).Objects()
+		Expect(toCreate).To(HaveLen(1))
+		Expect(toDelete).To(HaveLen(1))
+		Expect(toDelete[0].GetName()).To(Equal("node-b-tigera-hep"))
+	})
+
+	It("does not render the failsafe policy for Calico", func() {
+		cfg.FailsafePolicy = true
+		toCreate, toDelete := hostprotection.HostProtection(cfg).Objects()
+		Expect(toCreate).To(HaveLen(1))
+		Expect(toDelete).To(BeEmpty())
+	})
+
+	It("renders the failsafe policy for Calico Enterprise", func() {
+		cfg.Variant = operatorv1.TigeraSecureEnterprise
+		cfg.FailsafePolicy = true
+		toCreate, _ := hostprotection.HostProtection(cfg).Objects()
+		Expect(toCreate).To(HaveLen(2))
+
+		policy := toCreate[1].(*v3.GlobalNetworkPolicy)
+		Expect(policy.Name).To(Equal("allow-tigera.host-endpoint-failsafe"))
+		Expect(policy.Spec.Tier).To(Equal(networkpolicy.TigeraComponentTierName))
+		Expect(policy.Spec.Selector).To(Equal("projectcalico.org/created-by == 'tigera-operator'"))
+		Expect(policy.Spec.Ingress).To(HaveLen(3))
+		Expect(policy.Spec.Ingress[0].Destination.Ports).To(Equal(networkpolicy.Ports(22, 179, 2379, 2380, 5473, 6443, 10250)))
+		Expect(policy.Spec.Ingress[2].Action).To(BeEquivalentTo(v3.Pass))
+		Expect(policy.Spec.Egress).To(HaveLen(3))
+		Expect(policy.Spec.Egress[1].Destination.Ports).To(Equal(networkpolicy.Ports(53, 67)))
+		Expect(policy.Spec.Egress[2].Action).To(BeEquivalentTo(v3.Pass))
+	})
+
+	It("deletes the failsafe policy for Calico Enterprise when it is disabled", func() {
+		cfg.Variant = operatorv1.TigeraSecureEnterprise
+		_, toDelete := hostprotection.HostProtection(cfg).Objects()
+		Expect(toDelete).To(HaveLen(1))
+		Expect(toDelete[0].GetName()).To(Equal("allow-tigera.host-endpoint-failsafe"))
+	})
+})
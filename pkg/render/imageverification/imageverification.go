@@ -0,0 +1,173 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageverification
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/render"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+const (
+	PolicyName = "tigera-operator-image-verification"
+
+	// webhookTimeoutSeconds allows for the time needed to fetch signatures from the registry.
+	webhookTimeoutSeconds = 30
+)
+
+// ClusterPolicyGVK is the GroupVersionKind of the Kyverno ClusterPolicy rendered by this component.
+var ClusterPolicyGVK = schema.GroupVersionKind{Group: "kyverno.io", Version: "v1", Kind: "ClusterPolicy"}
+
+// ImageVerification renders a Kyverno ClusterPolicy that requires the cosign signatures of every image
+// deployed by the operator to be verified. If the Installation does not configure image verification the
+// policy is deleted.
+func ImageVerification(cfg *Config) render.Component {
+	return &component{cfg: cfg}
+}
+
+type Config struct {
+	Installation *operatorv1.InstallationSpec
+}
+
+type component struct {
+	cfg *Config
+
+	// imageReferences are the Kyverno image reference patterns matching the images deployed by the operator.
+	imageReferences []string
+}
+
+func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
+	reg := c.cfg.Installation.Registry
+	path := c.cfg.Installation.ImagePath
+	prefix := c.cfg.Installation.ImagePrefix
+
+	refs := map[string]bool{}
+	for _, img := range append(components.CalicoImages, components.EnterpriseImages...) {
+		ref, err := components.GetReference(img, reg, path, prefix, is)
+		if err != nil {
+			// The ImageSet does not contain the image, so it cannot be deployed by the operator.
+			continue
+		}
+		name := imageName(ref)
+		refs[name+":*"] = true
+		refs[name+"@*"] = true
+	}
+
+	c.imageReferences = make([]string, 0, len(refs))
+	for r := range refs {
+		c.imageReferences = append(c.imageReferences, r)
+	}
+	sort.Strings(c.imageReferences)
+	return nil
+}
+
+func (c *component) Objects() ([]client.Object, []client.Object) {
+	if c.cfg.Installation.ImageVerification == nil {
+		return nil, []client.Object{c.clusterPolicy()}
+	}
+	return []client.Object{c.clusterPolicy()}, nil
+}
+
+func (c *component) Ready() bool {
+	return true
+}
+
+func (c *component) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeAny
+}
+
+func (c *component) clusterPolicy() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(ClusterPolicyGVK)
+	u.SetName(PolicyName)
+
+	iv := c.cfg.Installation.ImageVerification
+	if iv == nil {
+		return u
+	}
+
+	action := operatorv1.ImageVerificationFailureActionEnforce
+	if iv.FailureAction != nil {
+		action = *iv.FailureAction
+	}
+
+	var entries []interface{}
+	for _, key := range iv.PublicKeys {
+		entries = append(entries, map[string]interface{}{
+			"keys": map[string]interface{}{
+				"publicKeys": key,
+			},
+		})
+	}
+
+	var imageReferences []interface{}
+	for _, r := range c.imageReferences {
+		imageReferences = append(imageReferences, r)
+	}
+
+	u.Object["spec"] = map[string]interface{}{
+		"validationFailureAction": string(action),
+		"background":              false,
+		"webhookTimeoutSeconds":   int64(webhookTimeoutSeconds),
+		"rules": []interface{}{
+			map[string]interface{}{
+				"name": "verify-image-signatures",
+				"match": map[string]interface{}{
+					"any": []interface{}{
+						map[string]interface{}{
+							"resources": map[string]interface{}{
+								"kinds": []interface{}{"Pod"},
+							},
+						},
+					},
+				},
+				"verifyImages": []interface{}{
+					map[string]interface{}{
+						"imageReferences": imageReferences,
+						"mutateDigest":    false,
+						"verifyDigest":    false,
+						"attestors": []interface{}{
+							map[string]interface{}{
+								// Images need to be signed by any one of the configured keys.
+								"count":   int64(1),
+								"entries": entries,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return u
+}
+
+// imageName returns the image reference without its tag or digest.
+func imageName(ref string) string {
+	if i := strings.Index(ref, "@"); i >= 0 {
+		return ref[:i]
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i]
+	}
+	return ref
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageverification_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/render/imageverification"
+)
+
+var _ = Describe("Image verification rendering tests", func() {
+	var installation *operatorv1.InstallationSpec
+
+	BeforeEach(func() {
+		installation = &operatorv1.InstallationSpec{
+			Registry: "my-registry.io/",
+			ImageVerification: &operatorv1.ImageVerification{
+				PublicKeys: []string{"key-a", "key-b"},
+			},
+		}
+	})
+
+	It("should delete the policy when image verification is not configured", func() {
+		installation.ImageVerification = nil
+		component := imageverification.ImageVerification(&imageverification.Config{Installation: installation})
+		Expect(component.ResolveImages(nil)).To(Succeed())
+
+		toCreate, toDelete := component.Objects()
+		Expect(toCreate).To(BeEmpty())
+		Expect(toDelete).To(HaveLen(1))
+		Expect(toDelete[0].GetName()).To(Equal(imageverification.PolicyName))
+		Expect(toDelete[0].GetObjectKind().GroupVersionKind()).To(Equal(imageverification.ClusterPolicyGVK))
+	})
+
+	It("should render a policy that verifies every operator image", func() {
+		component := imageverification.ImageVerification(&imageverification.Config{Installation: installation})
+		Expect(component.ResolveImages(nil)).To(Succeed())
+
+		toCreate, toDelete := component.Objects()
+		Expect(toDelete).To(BeEmpty())
+		Expect(toCreate).To(HaveLen(1))
+		policy := toCreate[0].(*unstructured.Unstructured)
+
+		action, _, _ := unstructured.NestedString(policy.Object, "spec", "validationFailureAction")
+		Expect(action).To(Equal("Enforce"))
+
+		rules, _, _ := unstructured.NestedSlice(policy.Object, "spec", "rules")
+		Expect(rules).To(HaveLen(1))
+		verifyImages, _, _ := unstructured.NestedSlice(rules[0].(map[string]interface{}), "verifyImages")
+		Expect(verifyImages).To(HaveLen(1))
+		verify := verifyImages[0].(map[string]interface{})
+
+		refs, _, _ := unstructured.NestedStringSlice(verify, "imageReferences")
+		Expect(refs).To(ContainElements(
+			"my-registry.io/"+components.ComponentCalicoNode.Image+":*",
+			"my-registry.io/"+components.ComponentCalicoNode.Image+"@*",
+			"my-registry.io/"+components.ComponentTigeraNode.Image+":*",
+		))
+
+		attestors := verify["attestors"].([]interface{})
+		Expect(attestors).To(HaveLen(1))
+		entries := attestors[0].(map[string]interface{})["entries"].([]interface{})
+		Expect(entries).To(ConsistOf(
+			map[string]interface{}{"keys": map[string]interface{}{"publicKeys": "key-a"}},
+			map[string]interface{}{"keys": map[string]interface{}{"publicKeys": "key-b"}},
+		))
+	})
+
+	It("should only include images from the ImageSet and honor the failure action", func() {
+		audit := operatorv1.ImageVerificationFailureActionAudit
+		installation.ImageVerification.FailureAction = &audit
+		component := imageverification.ImageVerification(&imageverification.Config{Installation: installation})
+		Expect(component.ResolveImages(&operatorv1.ImageSet{
+			Spec: operatorv1.ImageSetSpec{
+				Images: []operatorv1.Image{{Image: components.ComponentCalicoNode.Image, Digest: "sha256:abc"}},
+			},
+		})).To(Succeed())
+
+		toCreate, _ := component.Objects()
+		policy := toCreate[0].(*unstructured.Unstructured)
+		action, _, _ := unstructured.NestedString(policy.Object, "spec", "validationFailureAction")
+		Expect(action).To(Equal("Audit"))
+
+		rules, _, _ := unstructured.NestedSlice(policy.Object, "spec", "rules")
+		verifyImages, _, _ := unstructured.NestedSlice(rules[0].(map[string]interface{}), "verifyImages")
+		refs, _, _ := unstructured.NestedStringSlice(verifyImages[0].(map[string]interface{}), "imageReferences")
+		Expect(refs).To(ConsistOf(
+			"my-registry.io/"+components.ComponentCalicoNode.Image+":*",
+			"my-registry.io/"+components.ComponentCalicoNode.Image+"@*",
+		))
+	})
+})
@@ -16,7 +16,9 @@ package render
 
 import (
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -527,6 +529,26 @@ func (c *intrusionDetectionComponent) multiTenantManagedClustersAccess() []clien
 	return objects
 }
 
+// threatFeeds returns the GlobalThreatFeed pull configuration of the IntrusionDetection Controller, which may be nil.
+func (c *intrusionDetectionComponent) threatFeeds() *operatorv1.ThreatFeedsSpec {
+	if c.cfg.IntrusionDetection == nil {
+		return nil
+	}
+	return c.cfg.IntrusionDetection.Spec.ThreatFeeds
+}
+
+// detectors returns the configuration of the built-in detectors of the IntrusionDetection Controller as a JSON list
+// sorted by name, or an empty string if no detectors are configured.
+func (c *intrusionDetectionComponent) detectors() string {
+	if c.cfg.IntrusionDetection == nil || len(c.cfg.IntrusionDetection.Spec.Detectors) == 0 {
+		return ""
+	}
+	detectors := append([]operatorv1.IntrusionDetectionDetector{}, c.cfg.IntrusionDetection.Spec.Detectors...)
+	sort.Slice(detectors, func(i, j int) bool { return detectors[i].Name < detectors[j].Name })
+	detectorsJSON, _ := json.Marshal(detectors)
+	return string(detectorsJSON)
+}
+
 func (c *intrusionDetectionComponent) intrusionDetectionDeployment() *appsv1.Deployment {
 	var replicas int32 = 1
 
@@ -616,7 +638,7 @@ func (c *intrusionDetectionComponent) deploymentPodTemplate() *corev1.PodTemplat
 			Annotations: c.intrusionDetectionAnnotations(),
 		},
 		Spec: corev1.PodSpec{
-			Tolerations:        c.cfg.Installation.ControlPlaneTolerations,
+			Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.Workload{}),
 			NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
 			ServiceAccountName: IntrusionDetectionName,
 			ImagePullSecrets:   ps,
@@ -715,6 +737,23 @@ func (c *intrusionDetectionComponent) intrusionDetectionControllerContainer() co
 			envs = append(envs, corev1.EnvVar{Name: "MULTI_CLUSTER_FORWARDING_ENDPOINT", Value: ManagerService(c.cfg.Tenant)})
 		}
 	}
+	if detectors := c.detectors(); detectors != "" {
+		envs = append(envs, corev1.EnvVar{Name: "IDS_DETECTORS", Value: detectors})
+	}
+	if tf := c.threatFeeds(); tf != nil {
+		if tf.PullInterval != nil {
+			envs = append(envs, corev1.EnvVar{Name: "IDS_FEED_PULL_INTERVAL", Value: tf.PullInterval.Duration.String()})
+		}
+		if tf.PullTimeout != nil {
+			envs = append(envs, corev1.EnvVar{Name: "IDS_FEED_PULL_TIMEOUT", Value: tf.PullTimeout.Duration.String()})
+		}
+		if tf.MaxRetries != nil {
+			envs = append(envs, corev1.EnvVar{Name: "IDS_FEED_PULL_MAX_RETRIES", Value: fmt.Sprint(*tf.MaxRetries)})
+		}
+		if tf.RetryBackoff != nil {
+			envs = append(envs, corev1.EnvVar{Name: "IDS_FEED_PULL_RETRY_BACKOFF", Value: tf.RetryBackoff.Duration.String()})
+		}
+	}
 	sc := securitycontext.NewNonRootContext()
 
 	// If syslog forwarding is enabled then set the necessary ENV var and volume mount to
@@ -731,7 +770,7 @@ func (c *intrusionDetectionComponent) intrusionDetectionControllerContainer() co
 		// use privileged UID/GID 0.
 		// On OpenShift, if we need the volume mount to hostpath volume for syslog forwarding,
 		// then IDS controller needs privileged access to write event logs to that volume
-		sc = securitycontext.NewRootContext(c.cfg.OpenShift)
+		sc = securitycontext.NewBuilder().RunAsRoot().PrivilegedOnOpenShift(c.cfg.OpenShift).Build()
 	}
 
 	if c.cfg.ManagedCluster {
@@ -16,6 +16,7 @@ package render_test
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -40,6 +41,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
@@ -748,6 +750,59 @@ var _ = Describe("Intrusion Detection rendering tests", func() {
 		Expect(cr.Rules).NotTo(ContainElements(expectedRules))
 	})
 
+	It("should render the detector configuration", func() {
+		cfg.IntrusionDetection = &operatorv1.IntrusionDetection{
+			Spec: operatorv1.IntrusionDetectionSpec{
+				Detectors: []operatorv1.IntrusionDetectionDetector{
+					{Name: operatorv1.DetectorPortScan, Enabled: ptr.BoolToPtr(false)},
+					{Name: operatorv1.DetectorDGA, Threshold: ptr.Int32ToPtr(5)},
+				},
+			},
+		}
+		component := render.IntrusionDetection(cfg)
+		toCreate, _ := component.Objects()
+
+		d := rtest.GetResource(toCreate, render.IntrusionDetectionName, render.IntrusionDetectionNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := test.GetContainer(d.Spec.Template.Spec.Containers, "controller")
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{
+			Name:  "IDS_DETECTORS",
+			Value: `[{"name":"dga","threshold":5},{"name":"port-scan","enabled":false}]`,
+		}))
+	})
+
+	It("should render the threat feed pull configuration", func() {
+		cfg.IntrusionDetection = &operatorv1.IntrusionDetection{
+			Spec: operatorv1.IntrusionDetectionSpec{
+				ThreatFeeds: &operatorv1.ThreatFeedsSpec{
+					PullInterval: &metav1.Duration{Duration: 6 * time.Hour},
+					PullTimeout:  &metav1.Duration{Duration: 30 * time.Second},
+					MaxRetries:   ptr.Int32ToPtr(5),
+					RetryBackoff: &metav1.Duration{Duration: 10 * time.Second},
+				},
+			},
+		}
+		component := render.IntrusionDetection(cfg)
+		toCreate, _ := component.Objects()
+
+		d := rtest.GetResource(toCreate, render.IntrusionDetectionName, render.IntrusionDetectionNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := test.GetContainer(d.Spec.Template.Spec.Containers, "controller")
+		Expect(container.Env).To(ContainElements(
+			corev1.EnvVar{Name: "IDS_FEED_PULL_INTERVAL", Value: "6h0m0s"},
+			corev1.EnvVar{Name: "IDS_FEED_PULL_TIMEOUT", Value: "30s"},
+			corev1.EnvVar{Name: "IDS_FEED_PULL_MAX_RETRIES", Value: "5"},
+			corev1.EnvVar{Name: "IDS_FEED_PULL_RETRY_BACKOFF", Value: "10s"},
+		))
+	})
+
+	It("should not render the detector configuration when no detectors are configured", func() {
+		component := render.IntrusionDetection(cfg)
+		toCreate, _ := component.Objects()
+
+		d := rtest.GetResource(toCreate, render.IntrusionDetectionName, render.IntrusionDetectionNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := test.GetContainer(d.Spec.Template.Spec.Containers, "controller")
+		Expect(container.Env).NotTo(ContainElement(HaveField("Name", "IDS_DETECTORS")))
+	})
+
 	Context("multi-tenant rendering", func() {
 		tenantANamespace := "tenant-a-ns"
 		tenantBNamespace := "tenant-b-ns"
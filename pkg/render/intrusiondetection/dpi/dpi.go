@@ -174,6 +174,7 @@ func (d *dpiComponent) dpiDaemonset() *appsv1.DaemonSet {
 		},
 		Spec: corev1.PodSpec{
 			Tolerations:                   meta.TolerateAll,
+			NodeSelector:                  d.spec().NodeSelector,
 			ImagePullSecrets:              secret.GetReferenceList(d.cfg.PullSecrets),
 			ServiceAccountName:            DeepPacketInspectionName,
 			TerminationGracePeriodSeconds: &terminationGracePeriod,
@@ -198,16 +199,16 @@ func (d *dpiComponent) dpiDaemonset() *appsv1.DaemonSet {
 }
 
 func (d *dpiComponent) dpiContainer() corev1.Container {
-	sc := securitycontext.NewRootContext(d.cfg.OpenShift)
-	sc.Capabilities.Add = []corev1.Capability{
-		"NET_ADMIN",
-		"NET_RAW",
-	}
+	sc := securitycontext.NewBuilder().
+		RunAsRoot().
+		PrivilegedOnOpenShift(d.cfg.OpenShift).
+		AddCapabilities("NET_ADMIN", "NET_RAW").
+		Build()
 	dpiContainer := corev1.Container{
 		Name:            DeepPacketInspectionName,
 		Image:           d.dpiImage,
 		ImagePullPolicy: render.ImagePullPolicy(),
-		Resources:       *d.cfg.IntrusionDetection.Spec.ComponentResources[0].ResourceRequirements,
+		Resources:       d.dpiResources(),
 		Env:             d.dpiEnvVars(),
 		VolumeMounts:    d.dpiVolumeMounts(),
 		// On OpenShift Snort needs privileged access to access host network
@@ -218,6 +219,29 @@ func (d *dpiComponent) dpiContainer() corev1.Container {
 	return dpiContainer
 }
 
+// spec returns the DeepPacketInspection configuration of the IntrusionDetection resource, or an empty one if it is
+// not set.
+func (d *dpiComponent) spec() *operatorv1.DeepPacketInspectionSpec {
+	if d.cfg.IntrusionDetection.Spec.DeepPacketInspection == nil {
+		return &operatorv1.DeepPacketInspectionSpec{}
+	}
+	return d.cfg.IntrusionDetection.Spec.DeepPacketInspection
+}
+
+// dpiResources returns the resource requirements of the DeepPacketInspection container. The resources of the
+// DeepPacketInspection spec take precedence over the ones in ComponentResources.
+func (d *dpiComponent) dpiResources() corev1.ResourceRequirements {
+	if r := d.spec().Resources; r != nil {
+		return *r
+	}
+	for _, cr := range d.cfg.IntrusionDetection.Spec.ComponentResources {
+		if cr.ComponentName == operatorv1.ComponentNameDeepPacketInspection && cr.ResourceRequirements != nil {
+			return *cr.ResourceRequirements
+		}
+	}
+	return corev1.ResourceRequirements{}
+}
+
 func (d *dpiComponent) dpiVolumes() []corev1.Volume {
 	dirOrCreate := corev1.HostPathDirectoryOrCreate
 
@@ -279,6 +303,9 @@ func (d *dpiComponent) dpiEnvVars() []corev1.EnvVar {
 	if d.cfg.TyphaNodeTLS.TyphaURISAN != "" {
 		env = append(env, corev1.EnvVar{Name: "DPI_TYPHAURISAN", Value: d.cfg.TyphaNodeTLS.TyphaURISAN})
 	}
+	if n := d.spec().MaxConcurrentInspections; n != nil {
+		env = append(env, corev1.EnvVar{Name: "DPI_MAX_CONCURRENT_INSPECTIONS", Value: fmt.Sprint(*n)})
+	}
 	return env
 }
 
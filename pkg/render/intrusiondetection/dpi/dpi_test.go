@@ -36,6 +36,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
@@ -443,6 +444,29 @@ var _ = Describe("DPI rendering tests", func() {
 		validateDPIComponents(resources, true)
 	})
 
+	It("should render the DeepPacketInspection overrides", func() {
+		memoryRequest := resource.MustParse("1Gi")
+		ids2 := ids.DeepCopy()
+		ids2.Spec.DeepPacketInspection = &operatorv1.DeepPacketInspectionSpec{
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{"memory": memoryRequest},
+			},
+			NodeSelector:             map[string]string{"dpi": "true"},
+			MaxConcurrentInspections: ptr.Int32ToPtr(4),
+		}
+		cfg.IntrusionDetection = ids2
+		component := dpi.DPI(cfg)
+
+		resources, _ := component.Objects()
+
+		ds := rtest.GetResource(resources, dpi.DeepPacketInspectionName, dpi.DeepPacketInspectionNamespace, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(ds.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"dpi": "true"}))
+		Expect(ds.Spec.Template.Spec.Containers).To(HaveLen(1))
+		// The resources of the DeepPacketInspection spec take precedence over the ComponentResources.
+		Expect(ds.Spec.Template.Spec.Containers[0].Resources).To(Equal(*ids2.Spec.DeepPacketInspection.Resources))
+		Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "DPI_MAX_CONCURRENT_INSPECTIONS", Value: "4"}))
+	})
+
 	It("should delete resources for deep packet inspection if there is no valid product license", func() {
 		cfg.HasNoLicense = true
 		component := dpi.DPI(cfg)
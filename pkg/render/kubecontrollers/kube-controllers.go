@@ -33,7 +33,6 @@ import (
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
-	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rcomp "github.com/tigera/operator/pkg/render/common/components"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
@@ -238,7 +237,8 @@ type kubeControllersComponent struct {
 	cfg *KubeControllersConfiguration
 
 	// Internal state generated by the given configuration.
-	image string
+	image     string
+	amd64Only bool
 
 	kubeControllerServiceAccountName string
 	kubeControllerRoleName           string
@@ -262,11 +262,12 @@ func (c *kubeControllersComponent) ResolveImages(is *operatorv1.ImageSet) error
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
 		c.image, err = components.GetReference(components.ComponentTigeraKubeControllers, reg, path, prefix, is)
 	} else {
+		kubeControllers := components.ComponentCalicoKubeControllers
 		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.image, err = components.GetReference(components.ComponentCalicoKubeControllersFIPS, reg, path, prefix, is)
-		} else {
-			c.image, err = components.GetReference(components.ComponentCalicoKubeControllers, reg, path, prefix, is)
+			kubeControllers = components.ComponentCalicoKubeControllersFIPS
 		}
+		c.image, err = components.GetReference(kubeControllers, reg, path, prefix, is)
+		c.amd64Only = !components.SupportsArchitecture(kubeControllers, components.ArchitectureARM64)
 	}
 	return err
 }
@@ -301,7 +302,7 @@ func (c *kubeControllersComponent) Objects() ([]client.Object, []client.Object)
 	objectsToDelete := []client.Object{}
 	if c.cfg.KubeControllersGatewaySecret != nil {
 		objectsToCreate = append(objectsToCreate, secret.ToRuntimeObjects(
-			secret.CopyToNamespace(c.cfg.Namespace, c.cfg.KubeControllersGatewaySecret)...)...)
+			secret.MirrorToNamespace(c.cfg.Namespace, c.cfg.KubeControllersGatewaySecret)...)...)
 	}
 
 	if c.cfg.MetricsPort != 0 {
@@ -574,9 +575,7 @@ func (c *kubeControllersComponent) controllersDeployment() *appsv1.Deployment {
 	}
 
 	// UID 999 is used in kube-controller Dockerfile.
-	sc := securitycontext.NewNonRootContext()
-	sc.RunAsUser = ptr.Int64ToPtr(999)
-	sc.RunAsGroup = ptr.Int64ToPtr(0)
+	sc := securitycontext.NewBuilder().RunAsUser(999).RunAsGroup(0).Build()
 
 	container := corev1.Container{
 		Name:            c.kubeControllerName,
@@ -629,7 +628,7 @@ func (c *kubeControllersComponent) controllersDeployment() *appsv1.Deployment {
 	}
 	podSpec := corev1.PodSpec{
 		NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
-		Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateCriticalAddonsAndControlPlane...),
+		Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.Workload{AMD64Only: c.amd64Only, CriticalAddon: true}, rmeta.TolerateCriticalAddonsAndControlPlane...),
 		ImagePullSecrets:   c.cfg.Installation.ImagePullSecrets,
 		ServiceAccountName: c.kubeControllerServiceAccountName,
 		InitContainers:     initContainers,
@@ -44,6 +44,7 @@ import (
 	"github.com/tigera/operator/pkg/dns"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	rsecret "github.com/tigera/operator/pkg/render/common/secret"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/kubecontrollers"
 	"github.com/tigera/operator/pkg/render/testutils"
@@ -324,6 +325,11 @@ var _ = Describe("kube-controllers rendering tests", func() {
 			i++
 		}
 
+		// The gateway secret is mirrored from the operator namespace, so it is kept in sync with its source.
+		gwSecret := rtest.GetResource(resources, kubecontrollers.ElasticsearchKubeControllersUserSecret, common.CalicoNamespace, "", "v1", "Secret").(*corev1.Secret)
+		Expect(gwSecret.Labels).To(HaveKeyWithValue(rsecret.MirrorLabel, "true"))
+		Expect(gwSecret.Annotations).To(HaveKeyWithValue(rsecret.MirrorSourceAnnotation, common.OperatorNamespace()+"/"+kubecontrollers.ElasticsearchKubeControllersUserSecret))
+
 		// The Deployment should have the correct configuration.
 		dp := rtest.GetResource(resources, kubecontrollers.EsKubeController, common.CalicoNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
 
@@ -661,6 +667,23 @@ var _ = Describe("kube-controllers rendering tests", func() {
 		}
 	})
 
+	It("should only tolerate GKE arm64 nodes when the image is published for arm64", func() {
+		cfg.Installation.KubernetesProvider = operatorv1.ProviderGKE
+		cfg.Installation.Variant = operatorv1.Calico
+		tolerations := func() []corev1.Toleration {
+			component := kubecontrollers.NewCalicoKubeControllers(&cfg)
+			Expect(component.ResolveImages(nil)).To(BeNil())
+			resources, _ := component.Objects()
+			deployment := rtest.GetResource(resources, kubecontrollers.KubeController, common.CalicoNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			return deployment.Spec.Template.Spec.Tolerations
+		}
+		Expect(tolerations()).To(ContainElement(rmeta.TolerateGKEARM64))
+
+		fipsEnabled := operatorv1.FIPSModeEnabled
+		cfg.Installation.FIPSMode = &fipsEnabled
+		Expect(tolerations()).NotTo(ContainElement(rmeta.TolerateGKEARM64))
+	})
+
 	It("should add the OIDC prefix env variables", func() {
 		instance.Variant = operatorv1.TigeraSecureEnterprise
 		cfg.LogStorageExists = true
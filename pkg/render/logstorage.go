@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"net"
+	"strconv"
 	"strings"
 
 	cmnv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/common/v1"
@@ -40,6 +42,7 @@ import (
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/render/common/configmap"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
@@ -98,6 +101,10 @@ const (
 	OIDCUsersConfigMapName = "tigera-known-oidc-users"
 	OIDCUsersESSecretName  = "tigera-oidc-users-elasticsearch-credentials"
 
+	// ElasticsearchRemoteClusterCAConfigMap is the name of the ConfigMap in the operator namespace that holds the CAs,
+	// in ca.crt, that sign the transport certificates of the remote clusters of the LogStorage.
+	ElasticsearchRemoteClusterCAConfigMap = "tigera-elasticsearch-remote-cluster-ca"
+
 	ElasticsearchLicenseTypeBasic           ElasticsearchLicenseType = "basic"
 	ElasticsearchLicenseTypeEnterprise      ElasticsearchLicenseType = "enterprise"
 	ElasticsearchLicenseTypeEnterpriseTrial ElasticsearchLicenseType = "enterprise_trial"
@@ -149,6 +156,19 @@ var (
 	ECKOperatorSourceEntityRule = networkpolicy.CreateSourceEntityRule("tigera-eck-operator", "elastic-operator")
 )
 
+// ECKOperatorSourceEntityRuleFor returns the source entity rule of the ECK operator running in the given namespace.
+// ECK operators installed by users don't have the k8s-app label of the one deployed by the operator, so they are
+// selected by the control-plane label of the manifests and Helm chart of ECK instead.
+func ECKOperatorSourceEntityRuleFor(namespace string) v3.EntityRule {
+	if namespace == "" || namespace == "tigera-eck-operator" {
+		return ECKOperatorSourceEntityRule
+	}
+	return v3.EntityRule{
+		Selector:          "control-plane == 'elastic-operator'",
+		NamespaceSelector: fmt.Sprintf("projectcalico.org/name == '%s'", namespace),
+	}
+}
+
 var log = logf.Log.WithName("render")
 
 // LogStorage renders the components necessary for kibana and elasticsearch
@@ -177,12 +197,20 @@ type ElasticsearchConfiguration struct {
 	UnusedTLSSecret         *corev1.Secret
 	ApplyTrial              bool
 	KeyStoreSecret          *corev1.Secret
+
+	// RemoteClusterCA holds the CAs of the transport certificates of the remote clusters of the LogStorage, if any.
+	RemoteClusterCA *corev1.ConfigMap
+
+	// ECKOperatorNamespace is the namespace of the ECK operator that manages Elasticsearch, if it isn't the one
+	// deployed by the operator.
+	ECKOperatorNamespace string
 }
 
 type elasticsearchComponent struct {
-	cfg      *ElasticsearchConfiguration
-	esImage  string
-	csrImage string
+	cfg       *ElasticsearchConfiguration
+	esImage   string
+	csrImage  string
+	amd64Only bool
 }
 
 func (es *elasticsearchComponent) ResolveImages(is *operatorv1.ImageSet) error {
@@ -190,11 +218,12 @@ func (es *elasticsearchComponent) ResolveImages(is *operatorv1.ImageSet) error {
 	path := es.cfg.Installation.ImagePath
 	prefix := es.cfg.Installation.ImagePrefix
 	var err error
+	elasticsearch := components.ComponentElasticsearch
 	if operatorv1.IsFIPSModeEnabled(es.cfg.Installation.FIPSMode) {
-		es.esImage, err = components.GetReference(components.ComponentElasticsearchFIPS, reg, path, prefix, is)
-	} else {
-		es.esImage, err = components.GetReference(components.ComponentElasticsearch, reg, path, prefix, is)
+		elasticsearch = components.ComponentElasticsearchFIPS
 	}
+	es.esImage, err = components.GetReference(elasticsearch, reg, path, prefix, is)
+	es.amd64Only = !components.SupportsArchitecture(elasticsearch, components.ArchitectureARM64)
 	errMsgs := make([]string, 0)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
@@ -250,6 +279,10 @@ func (es *elasticsearchComponent) Objects() ([]client.Object, []client.Object) {
 	toCreate = append(toCreate, es.elasticsearchServiceAccount())
 	toCreate = append(toCreate, es.cfg.ClusterConfig.ConfigMap())
 
+	if es.cfg.RemoteClusterCA != nil {
+		toCreate = append(toCreate, configmap.ToRuntimeObjects(configmap.CopyToNamespace(ElasticsearchNamespace, es.cfg.RemoteClusterCA)...)...)
+	}
+
 	toCreate = append(toCreate, es.elasticsearchCluster())
 
 	if es.cfg.Installation.KubernetesProvider.IsOpenShift() {
@@ -407,15 +440,10 @@ func (es elasticsearchComponent) podTemplate() corev1.PodTemplateSpec {
 		})
 	}
 
-	sc := securitycontext.NewRootContext(false)
 	// These capabilities are required for docker-entrypoint.sh.
 	// See: https://github.com/elastic/elasticsearch/blob/7.17/distribution/docker/src/docker/bin/docker-entrypoint.sh.
 	// TODO Consider removing for Elasticsearch v8+.
-	sc.Capabilities.Add = []corev1.Capability{
-		"SETGID",
-		"SETUID",
-		"SYS_CHROOT",
-	}
+	sc := securitycontext.NewBuilder().RunAsRoot().AddCapabilities("SETGID", "SETUID", "SYS_CHROOT").Build()
 
 	esContainer := corev1.Container{
 		Name: "elasticsearch",
@@ -457,10 +485,9 @@ func (es elasticsearchComponent) podTemplate() corev1.PodTemplateSpec {
 	annotations[es.cfg.ElasticsearchKeyPair.HashAnnotationKey()] = es.cfg.ElasticsearchKeyPair.HashAnnotationValue()
 
 	if operatorv1.IsFIPSModeEnabled(es.cfg.Installation.FIPSMode) {
-		sc := securitycontext.NewRootContext(false)
 		// keystore init container converts jdk jks to bcfks and chown the new file to
 		// elasticsearch user and group for the main container to consume.
-		sc.Capabilities.Add = []corev1.Capability{"CHOWN"}
+		sc := securitycontext.NewBuilder().RunAsRoot().AddCapabilities("CHOWN").Build()
 
 		initKeystore := corev1.Container{
 			Name:            keystoreInitContainerName,
@@ -596,7 +623,7 @@ func (es elasticsearchComponent) podTemplate() corev1.PodTemplateSpec {
 			Containers:                   []corev1.Container{esContainer},
 			ImagePullSecrets:             secret.GetReferenceList(es.cfg.PullSecrets),
 			NodeSelector:                 nodeSels,
-			Tolerations:                  es.cfg.Installation.ControlPlaneTolerations,
+			Tolerations:                  rmeta.ControlPlaneTolerations(es.cfg.Installation, rmeta.Workload{AMD64Only: es.amd64Only}),
 			ServiceAccountName:           ElasticsearchObjectName,
 			Volumes:                      volumes,
 			AutomountServiceAccountToken: &autoMountToken,
@@ -628,6 +655,16 @@ func (es elasticsearchComponent) elasticsearchCluster() *esv1.Elasticsearch {
 		},
 	}
 
+	if es.cfg.LogStorage.RetainDataOnDeletion() {
+		// Keep the PersistentVolumeClaims when the cluster is deleted. They are still removed on scale down.
+		elasticsearch.Spec.VolumeClaimDeletePolicy = esv1.DeleteOnScaledownOnlyPolicy
+	}
+
+	if es.cfg.RemoteClusterCA != nil {
+		// ECK adds these CAs to the ones trusted on the transport layer, so that the remote clusters can be connected to.
+		elasticsearch.Spec.Transport.TLS.CertificateAuthorities = cmnv1.ConfigMapRef{ConfigMapName: ElasticsearchRemoteClusterCAConfigMap}
+	}
+
 	return elasticsearch
 }
 
@@ -737,9 +774,17 @@ func (es elasticsearchComponent) nodeSets() []esv1.NodeSet {
 				break
 			}
 
-			nodeSet := es.nodeSetTemplate(pvcTemplate)
+			// The disks of the NodeSet may be provisioned with a storage class of its own. As the name of the NodeSet is
+			// derived from its PVC template, changing the storage class migrates the NodeSet to new disks.
+			nodeSetPVCTemplate := pvcTemplate
+			if nodeSetConfig.StorageClassName != "" {
+				nodeSetPVCTemplate = *pvcTemplate.DeepCopy()
+				nodeSetPVCTemplate.Spec.StorageClassName = &nodeSetConfig.StorageClassName
+			}
+
+			nodeSet := es.nodeSetTemplate(nodeSetPVCTemplate)
 			// Each NodeSet needs a unique name, so just add the index as a suffix
-			nodeSet.Name = fmt.Sprintf("%s-%d", nodeSetName(pvcTemplate), i)
+			nodeSet.Name = fmt.Sprintf("%s-%d", nodeSetName(nodeSetPVCTemplate), i)
 			nodeSet.Count = int32(numNodes)
 
 			podTemplate := es.podTemplate()
@@ -812,6 +857,22 @@ func (es elasticsearchComponent) nodeSetTemplate(pvcTemplate corev1.PersistentVo
 		config["xpack.security.authc.password_hashing.algorithm"] = "pbkdf2_stretch"
 	}
 
+	// The remote clusters for cross-cluster search are configured statically, so that removing one from the LogStorage
+	// removes it from Elasticsearch as well.
+	for _, remote := range es.cfg.LogStorage.Spec.RemoteClusters {
+		prefix := fmt.Sprintf("cluster.remote.%s.", remote.Name)
+		if remote.ProxyAddress != "" {
+			config[prefix+"mode"] = "proxy"
+			config[prefix+"proxy_address"] = remote.ProxyAddress
+		} else {
+			config[prefix+"mode"] = "sniff"
+			config[prefix+"seeds"] = remote.Seeds
+		}
+		if remote.SkipUnavailable != nil {
+			config[prefix+"skip_unavailable"] = *remote.SkipUnavailable
+		}
+	}
+
 	return esv1.NodeSet{
 		// This is configuration that ends up in /usr/share/elasticsearch/config/elasticsearch.yml on the Elastic container.
 		Config: &cmnv1.Config{
@@ -993,6 +1054,7 @@ func (es *elasticsearchComponent) elasticsearchAllowTigeraPolicy() *v3.NetworkPo
 			Destination: networkpolicy.KubeAPIServerServiceSelectorEntityRule,
 		},
 	}...)
+	egressRules = append(egressRules, es.remoteClusterEgressRules()...)
 
 	elasticSearchIngressDestinationEntityRule := v3.EntityRule{
 		Ports: networkpolicy.Ports(ElasticsearchDefaultPort),
@@ -1030,7 +1092,7 @@ func (es *elasticsearchComponent) elasticsearchAllowTigeraPolicy() *v3.NetworkPo
 				{
 					Action:      v3.Allow,
 					Protocol:    &networkpolicy.TCPProtocol,
-					Source:      ECKOperatorSourceEntityRule,
+					Source:      ECKOperatorSourceEntityRuleFor(es.cfg.ECKOperatorNamespace),
 					Destination: elasticSearchIngressDestinationEntityRule,
 				},
 				{
@@ -1045,6 +1107,34 @@ func (es *elasticsearchComponent) elasticsearchAllowTigeraPolicy() *v3.NetworkPo
 	}
 }
 
+// remoteClusterEgressRules allows egress to the transport addresses of the remote clusters of the LogStorage. The nodes
+// that are discovered from the seeds of a remote cluster are not known up front, so they must be allowed separately.
+func (es *elasticsearchComponent) remoteClusterEgressRules() []v3.Rule {
+	var rules []v3.Rule
+	for _, remote := range es.cfg.LogStorage.Spec.RemoteClusters {
+		addresses := remote.Seeds
+		if remote.ProxyAddress != "" {
+			addresses = []string{remote.ProxyAddress}
+		}
+		for _, address := range addresses {
+			host, portStr, err := net.SplitHostPort(address)
+			if err != nil {
+				continue
+			}
+			port, err := strconv.ParseUint(portStr, 10, 16)
+			if err != nil {
+				continue
+			}
+			rules = append(rules, v3.Rule{
+				Action:      v3.Allow,
+				Protocol:    &networkpolicy.TCPProtocol,
+				Destination: networkpolicy.AddressEntityRule(host, uint16(port)),
+			})
+		}
+	}
+	return rules
+}
+
 // Allow internal communication within the ElasticSearch cluster
 func (es *elasticsearchComponent) elasticsearchInternalAllowTigeraPolicy() *v3.NetworkPolicy {
 	return &v3.NetworkPolicy{
@@ -200,6 +200,25 @@ var _ = Describe("Dashboards rendering tests", func() {
 			_, ok := rtest.GetResource(resources, Name, render.ElasticsearchNamespace, "batch", "v1", "Job").(*batchv1.Job)
 			Expect(ok).To(BeFalse(), "Jobs not found")
 		})
+
+		It("should not render when Kibana is disabled", func() {
+			bundle := getBundle(installation)
+			disabled := operatorv1.FeatureDisabled
+			installation.Features = &operatorv1.Features{Kibana: &disabled}
+			component := Dashboards(&Config{
+				Installation:  installation,
+				TrustedBundle: bundle,
+				Namespace:     render.ElasticsearchNamespace,
+				KibanaHost:    "tigera-secure-kb-http.tigera-kibana.tigera-kibana.svc",
+				KibanaScheme:  "htpps",
+				KibanaPort:    5601,
+			})
+
+			resources, toDelete := component.Objects()
+			Expect(resources).To(BeEmpty())
+			_, ok := rtest.GetResource(toDelete, Name, render.ElasticsearchNamespace, "batch", "v1", "Job").(*batchv1.Job)
+			Expect(ok).To(BeTrue())
+		})
 	})
 
 	Context("multi-tenant rendering", func() {
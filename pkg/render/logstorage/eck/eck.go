@@ -75,8 +75,16 @@ func (e *eck) ResolveImages(is *operatorv1.ImageSet) error {
 	prefix := e.cfg.Installation.ImagePrefix
 	errMsgs := make([]string, 0)
 
+	operatorComponent := components.ComponentElasticsearchOperator
+	if cfg := e.cfg.LogStorage.Spec.ECKOperator; cfg != nil && cfg.Version != "" {
+		if is != nil {
+			errMsgs = append(errMsgs, "the ECK operator version cannot be pinned when an ImageSet is used, set its digest in the ImageSet instead")
+		}
+		operatorComponent.Version = cfg.Version
+	}
+
 	var err error
-	e.esOperatorImage, err = components.GetReference(components.ComponentElasticsearchOperator, reg, path, prefix, is)
+	e.esOperatorImage, err = components.GetReference(operatorComponent, reg, path, prefix, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
@@ -94,6 +102,16 @@ func (e *eck) SupportedOSType() rmeta.OSType {
 func (e *eck) Objects() ([]client.Object, []client.Object) {
 	var toCreate, toDelete []client.Object
 
+	if e.cfg.LogStorage.UserManagedECKOperator() {
+		// Remove the ECK operator deployed before switching to a user-managed one. Deleting the namespace removes the
+		// StatefulSet and the namespaced objects. The elastic-operator ClusterRole and ClusterRoleBinding are left
+		// alone, since the manifests and Helm chart of ECK create them with the same names.
+		return nil, []client.Object{
+			render.CreateNamespace(OperatorNamespace, e.cfg.Installation.KubernetesProvider, render.PSSRestricted),
+			e.operatorClusterAdminClusterRoleBinding(),
+		}
+	}
+
 	toCreate = append(toCreate,
 		render.CreateNamespace(OperatorNamespace, e.cfg.Installation.KubernetesProvider, render.PSSRestricted),
 		e.operatorAllowTigeraPolicy(),
@@ -333,7 +351,7 @@ func (e *eck) operatorStatefulSet() *appsv1.StatefulSet {
 					ImagePullSecrets:   secret.GetReferenceList(e.cfg.PullSecrets),
 					HostNetwork:        false,
 					NodeSelector:       e.cfg.Installation.ControlPlaneNodeSelector,
-					Tolerations:        e.cfg.Installation.ControlPlaneTolerations,
+					Tolerations:        rmeta.ControlPlaneTolerations(e.cfg.Installation, rmeta.Workload{}),
 					Containers: []corev1.Container{{
 						Image:           e.esOperatorImage,
 						ImagePullPolicy: render.ImagePullPolicy(),
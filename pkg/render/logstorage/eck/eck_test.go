@@ -254,6 +254,37 @@ var _ = Describe("ECK rendering tests", func() {
 			}))
 		})
 
+		It("should render the pinned version of the ECK operator", func() {
+			cfg.LogStorage.Spec.ECKOperator = &operatorv1.ECKOperator{Version: "2.14.0"}
+			component := eck.ECK(cfg)
+			Expect(component.ResolveImages(nil)).To(BeNil())
+			resources, _ := component.Objects()
+
+			sts := rtest.GetResource(resources, eck.OperatorName, eck.OperatorNamespace, "apps", "v1", "StatefulSet").(*appsv1.StatefulSet)
+			Expect(sts.Spec.Template.Spec.Containers[0].Image).To(Equal("testregistry.com/tigera/eck-operator:2.14.0"))
+		})
+
+		It("should not pin the version of the ECK operator when an ImageSet is used", func() {
+			cfg.LogStorage.Spec.ECKOperator = &operatorv1.ECKOperator{Version: "2.14.0"}
+			component := eck.ECK(cfg)
+			err := component.ResolveImages(&operatorv1.ImageSet{
+				Spec: operatorv1.ImageSetSpec{Images: []operatorv1.Image{{Image: "tigera/eck-operator", Digest: "sha256:eck"}}},
+			})
+			Expect(err).To(MatchError(ContainSubstring("cannot be pinned when an ImageSet is used")))
+		})
+
+		It("should remove the ECK operator it deployed when a user-managed ECK operator is used", func() {
+			mode := operatorv1.ECKOperatorModeUserManaged
+			cfg.LogStorage.Spec.ECKOperator = &operatorv1.ECKOperator{Mode: &mode}
+			component := eck.ECK(cfg)
+			toCreate, toDelete := component.Objects()
+			Expect(toCreate).To(BeEmpty())
+			rtest.ExpectResources(toDelete, []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: eck.OperatorNamespace}},
+				&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "elastic-operator-docker-enterprise"}},
+			})
+		})
+
 		Context("allow-tigera rendering", func() {
 			policyNames := []types.NamespacedName{
 				{Name: "allow-tigera.kibana-access", Namespace: "tigera-kibana"},
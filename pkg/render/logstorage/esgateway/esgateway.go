@@ -15,7 +15,9 @@
 package esgateway
 
 import (
+	"crypto/x509"
 	"fmt"
+	"strconv"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -29,8 +31,10 @@ import (
 
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/dns"
 	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
+	rcomponents "github.com/tigera/operator/pkg/render/common/components"
 	"github.com/tigera/operator/pkg/render/common/elasticsearch"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
@@ -38,9 +42,11 @@ import (
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
+	"github.com/tigera/operator/pkg/render/common/servicemesh"
 	"github.com/tigera/operator/pkg/render/logstorage/esmetrics"
 	"github.com/tigera/operator/pkg/render/logstorage/kibana"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+	"github.com/tigera/operator/pkg/tls/certkeyusage"
 )
 
 const (
@@ -53,11 +59,65 @@ const (
 	KibanaPortName        = "es-gateway-kibana-port"
 	Port                  = 5554
 
-	ElasticsearchHTTPSEndpoint = "https://tigera-secure-es-http.tigera-elasticsearch.svc:9200"
+	// MetricsPort is the port that es-gateway exposes its Prometheus metrics on when the LogStorage makes it validate
+	// the tenant of the requests.
+	MetricsPort = 9091
 
 	KibanaHTTPSEndpoint = "https://tigera-secure-kb-http.tigera-kibana.svc:5601"
+
+	// NonClusterHostServiceName is the Service that exposes ES gateway to hosts outside of the cluster.
+	NonClusterHostServiceName = "tigera-secure-es-gateway-noncluster-host"
+
+	// The gateway credentials that non-cluster hosts present to ES gateway, and the secrets ES gateway uses to verify
+	// them and to swap in the real Elasticsearch credentials.
+	NonClusterHostUserSecret             = "tigera-noncluster-host-elasticsearch-access"
+	NonClusterHostUserName               = "tigera-noncluster-host"
+	NonClusterHostSecureUserSecret       = "tigera-noncluster-host-elasticsearch-access-gateway"
+	NonClusterHostVerificationUserSecret = "tigera-noncluster-host-gateway-verification-credentials"
+
+	// MetricsUserSecret holds the Elasticsearch credentials of es-metrics, which ES gateway authenticates with on behalf
+	// of es-metrics when es-metrics presents its client certificate instead of a password.
+	MetricsUserSecret = "tigera-ee-elasticsearch-metrics-gateway-elasticsearch-access"
+
+	// AuditLogIndex is the index that es-gateway writes its audit log to when the LogStorage sends it to Elasticsearch.
+	AuditLogIndex = "tigera_secure_ee_esgateway_audit"
+
+	// DefaultAuditLogMaxEventsPerSecond is the number of requests that each es-gateway replica logs per second, unless
+	// the LogStorage sets another limit.
+	DefaultAuditLogMaxEventsPerSecond = 100
 )
 
+func init() {
+	certkeyusage.SetCertKeyUsage(render.TigeraElasticsearchGatewaySecret, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+}
+
+// DNSNames returns the DNS names of the es-gateway certificate. For legacy reasons, es-gateway is sitting behind two
+// services: tigera-secure-es-http (where originally ES resided) and tigera-secure-es-gateway-http. The LogStorage may
+// add names that es-gateway is reached through from outside of the cluster.
+func DNSNames(namespace, clusterDomain string, ls *operatorv1.LogStorage) []string {
+	names := append(
+		dns.GetServiceDNSNames(render.ElasticsearchServiceName, namespace, clusterDomain),
+		dns.GetServiceDNSNames(ServiceName, namespace, clusterDomain)...,
+	)
+	if ls != nil {
+		names = append(names, ls.Spec.ESGatewayCertificateDNSNames...)
+	}
+	return names
+}
+
+// ClientDNSNames returns the DNS names that clients use to reach es-gateway. Unlike an operator issued certificate, a
+// user provided certificate only needs to be valid for these names.
+func ClientDNSNames(namespace, clusterDomain string, ls *operatorv1.LogStorage) []string {
+	names := []string{
+		fmt.Sprintf("%s.%s.svc", ServiceName, namespace),
+		fmt.Sprintf("%s.%s.svc.%s", ServiceName, namespace, clusterDomain),
+	}
+	if ls != nil {
+		names = append(names, ls.Spec.ESGatewayCertificateDNSNames...)
+	}
+	return names
+}
+
 func EsGateway(c *Config) render.Component {
 	return &esGateway{
 		cfg: c,
@@ -73,14 +133,22 @@ type esGateway struct {
 // Config contains all the config information needed to render the EsGateway component.
 type Config struct {
 	Installation               *operatorv1.InstallationSpec
+	LogStorage                 *operatorv1.LogStorage
 	PullSecrets                []*corev1.Secret
 	KubeControllersUserSecrets []*corev1.Secret
-	ESGatewayKeyPair           certificatemanagement.KeyPairInterface
-	TrustedBundle              certificatemanagement.TrustedBundleRO
-	ClusterDomain              string
-	EsAdminUserName            string
-	Namespace                  string
-	TruthNamespace             string
+	// NonClusterHostUserSecrets allow non-cluster hosts to send their logs through ES gateway. They are only set when
+	// the LogStorage enables log ingestion for non-cluster hosts.
+	NonClusterHostUserSecrets []*corev1.Secret
+	ESGatewayKeyPair          certificatemanagement.KeyPairInterface
+	TrustedBundle             certificatemanagement.TrustedBundleRO
+	ClusterDomain             string
+	EsAdminUserName           string
+	Namespace                 string
+	TruthNamespace            string
+
+	// ESMetricsUserSecret holds the Elasticsearch credentials of es-metrics. It is only set when the LogStorage makes
+	// es-metrics authenticate with a client certificate, so that ES gateway can authenticate on its behalf.
+	ESMetricsUserSecret *corev1.Secret
 }
 
 func (e *esGateway) ResolveImages(is *operatorv1.ImageSet) error {
@@ -114,6 +182,24 @@ func (e *esGateway) Objects() (toCreate, toDelete []client.Object) {
 	toCreate = append(toCreate, e.esGatewayRoleBinding())
 	toCreate = append(toCreate, e.esGatewayServiceAccount())
 
+	if e.nonClusterHosts() != nil {
+		toCreate = append(toCreate, secret.ToRuntimeObjects(e.cfg.NonClusterHostUserSecrets...)...)
+		toCreate = append(toCreate, e.nonClusterHostService())
+	} else {
+		toDelete = append(toDelete,
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostServiceName, Namespace: e.cfg.Namespace}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostUserSecret, Namespace: e.cfg.TruthNamespace}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostVerificationUserSecret, Namespace: e.cfg.Namespace}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostSecureUserSecret, Namespace: e.cfg.Namespace}},
+		)
+	}
+
+	if e.metricsClientCertificate() {
+		toCreate = append(toCreate, e.metricsUserSecret())
+	} else {
+		toDelete = append(toDelete, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: MetricsUserSecret, Namespace: e.cfg.Namespace}})
+	}
+
 	// The following secret is used by kube controllers and sent to managed clusters. It is also used by manifests in our docs.
 	if e.cfg.ESGatewayKeyPair.UseCertificateManagement() {
 		toCreate = append(toCreate, render.CreateCertificateSecret(e.cfg.Installation.CertificateManagement.CACert, elasticsearch.PublicCertSecret, e.cfg.TruthNamespace))
@@ -184,11 +270,94 @@ func (e *esGateway) esGatewayRoleBinding() *rbacv1.RoleBinding {
 	}
 }
 
+// metricsClientCertificate returns true if es-metrics authenticates to ES gateway with a client certificate.
+func (e *esGateway) metricsClientCertificate() bool {
+	return e.cfg.LogStorage != nil && e.cfg.LogStorage.ElasticsearchMetricsClientCertificate() && e.cfg.ESMetricsUserSecret != nil
+}
+
+// metricsUserSecret copies the Elasticsearch credentials of es-metrics to the namespace of ES gateway.
+func (e *esGateway) metricsUserSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: MetricsUserSecret, Namespace: e.cfg.Namespace},
+		Data: map[string][]byte{
+			"username": e.cfg.ESMetricsUserSecret.Data["username"],
+			"password": e.cfg.ESMetricsUserSecret.Data["password"],
+		},
+	}
+}
+
+// metricsClientCertEnvVars returns the environment variables that make ES gateway accept the client certificate of
+// es-metrics, and authenticate to Elasticsearch with the credentials of es-metrics on its behalf.
+func (e *esGateway) metricsClientCertEnvVars() []corev1.EnvVar {
+	if !e.metricsClientCertificate() {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{Name: "ES_GATEWAY_METRICS_CLIENT_CERT_COMMON_NAME", Value: esmetrics.ElasticsearchMetricsName},
+		{Name: "ES_GATEWAY_METRICS_CLIENT_CA_BUNDLE_PATH", Value: e.cfg.TrustedBundle.MountPath()},
+		{Name: "ES_GATEWAY_METRICS_USERNAME", ValueFrom: secret.GetEnvVarSource(MetricsUserSecret, "username", false)},
+		{Name: "ES_GATEWAY_METRICS_PASSWORD", ValueFrom: secret.GetEnvVarSource(MetricsUserSecret, "password", false)},
+	}
+}
+
+// auditLogEnvVars returns the environment variables that configure the audit log of the requests proxied by es-gateway,
+// or nothing if the LogStorage doesn't enable it.
+func (e *esGateway) auditLogEnvVars() []corev1.EnvVar {
+	if e.cfg.LogStorage == nil || e.cfg.LogStorage.Spec.ESGatewayAuditLog == nil {
+		return nil
+	}
+	auditLog := e.cfg.LogStorage.Spec.ESGatewayAuditLog
+	verbosity := operatorv1.ESGatewayAuditLogVerbosityMetadata
+	if auditLog.Verbosity != nil {
+		verbosity = *auditLog.Verbosity
+	}
+	maxEventsPerSecond := int32(DefaultAuditLogMaxEventsPerSecond)
+	if auditLog.MaxEventsPerSecond != nil {
+		maxEventsPerSecond = *auditLog.MaxEventsPerSecond
+	}
+	sink := e.cfg.LogStorage.ESGatewayAuditLogSink()
+
+	envVars := []corev1.EnvVar{
+		{Name: "ES_GATEWAY_AUDIT_LOG_ENABLED", Value: "true"},
+		{Name: "ES_GATEWAY_AUDIT_LOG_VERBOSITY", Value: string(verbosity)},
+		{Name: "ES_GATEWAY_AUDIT_LOG_SINK", Value: string(sink)},
+		{Name: "ES_GATEWAY_AUDIT_LOG_MAX_EVENTS_PER_SECOND", Value: strconv.Itoa(int(maxEventsPerSecond))},
+	}
+	if sink == operatorv1.ESGatewayAuditLogSinkElasticsearch {
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_INDEX", Value: AuditLogIndex},
+			corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_ILM_POLICY", Value: AuditLogIndex + "_policy"},
+		)
+	}
+	return envVars
+}
+
+// tenancyEnvVars returns the environment variables that make es-gateway validate the tenant of the requests that it
+// proxies, and expose the counts of the requests that it accepts and rejects as metrics, or nothing if the LogStorage
+// doesn't enable it.
+func (e *esGateway) tenancyEnvVars() []corev1.EnvVar {
+	if e.cfg.LogStorage == nil || e.cfg.LogStorage.Spec.ESGatewayTenancy == nil {
+		return nil
+	}
+	tenancy := e.cfg.LogStorage.Spec.ESGatewayTenancy
+	mode := operatorv1.ESGatewayTenancyModeEnforce
+	if tenancy.Mode != nil {
+		mode = *tenancy.Mode
+	}
+	return []corev1.EnvVar{
+		{Name: "ES_GATEWAY_EXPECTED_TENANT_ID", Value: tenancy.ExpectedTenantID},
+		{Name: "ES_GATEWAY_TENANT_VALIDATION_MODE", Value: string(mode)},
+		{Name: "ES_GATEWAY_PROMETHEUS_METRICS_ENABLED", Value: "true"},
+		{Name: "ES_GATEWAY_PROMETHEUS_METRICS_PORT", Value: strconv.Itoa(MetricsPort)},
+	}
+}
+
 func (e *esGateway) esGatewayDeployment() *appsv1.Deployment {
 	envVars := []corev1.EnvVar{
 		{Name: "NAMESPACE", Value: e.cfg.Namespace},
 		{Name: "ES_GATEWAY_LOG_LEVEL", Value: "INFO"},
-		{Name: "ES_GATEWAY_ELASTIC_ENDPOINT", Value: ElasticsearchHTTPSEndpoint},
+		{Name: "ES_GATEWAY_ELASTIC_ENDPOINT", Value: elasticsearch.InternalElasticEndpoint(e.cfg.LogStorage)},
 		{Name: "ES_GATEWAY_KIBANA_ENDPOINT", Value: KibanaHTTPSEndpoint},
 		{Name: "ES_GATEWAY_HTTPS_CERT", Value: e.cfg.ESGatewayKeyPair.VolumeMountCertificateFilePath()},
 		{Name: "ES_GATEWAY_HTTPS_KEY", Value: e.cfg.ESGatewayKeyPair.VolumeMountKeyFilePath()},
@@ -207,6 +376,10 @@ func (e *esGateway) esGatewayDeployment() *appsv1.Deployment {
 		}},
 		{Name: "ES_GATEWAY_FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(e.cfg.Installation.FIPSMode)},
 	}
+	envVars = append(envVars, elasticsearch.ServerTLSEnvVars("ES_GATEWAY_", e.cfg.LogStorage)...)
+	envVars = append(envVars, e.auditLogEnvVars()...)
+	envVars = append(envVars, e.metricsClientCertEnvVars()...)
+	envVars = append(envVars, e.tenancyEnvVars()...)
 
 	var initContainers []corev1.Container
 	if e.cfg.ESGatewayKeyPair.UseCertificateManagement() {
@@ -225,6 +398,10 @@ func (e *esGateway) esGatewayDeployment() *appsv1.Deployment {
 
 	annotations := e.cfg.TrustedBundle.HashAnnotations()
 	annotations[e.cfg.ESGatewayKeyPair.HashAnnotationKey()] = e.cfg.ESGatewayKeyPair.HashAnnotationValue()
+	if e.metricsClientCertificate() {
+		// Restart ES gateway when the credentials of es-metrics change, since they are read from the environment.
+		annotations["hash.operator.tigera.io/es-metrics-user-secret"] = rmeta.AnnotationHash(e.metricsUserSecret().Data)
+	}
 	podTemplate := &corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        DeploymentName,
@@ -232,7 +409,7 @@ func (e *esGateway) esGatewayDeployment() *appsv1.Deployment {
 			Annotations: annotations,
 		},
 		Spec: corev1.PodSpec{
-			Tolerations:        e.cfg.Installation.ControlPlaneTolerations,
+			Tolerations:        rmeta.ControlPlaneTolerations(e.cfg.Installation, rmeta.Workload{}),
 			NodeSelector:       e.cfg.Installation.ControlPlaneNodeSelector,
 			ServiceAccountName: ServiceAccountName,
 			ImagePullSecrets:   secret.GetReferenceList(e.cfg.PullSecrets),
@@ -261,11 +438,13 @@ func (e *esGateway) esGatewayDeployment() *appsv1.Deployment {
 		},
 	}
 
+	servicemesh.ApplyPodTemplate(podTemplate, e.cfg.Installation.ServiceMesh, Port)
+
 	if e.cfg.Installation.ControlPlaneReplicas != nil && *e.cfg.Installation.ControlPlaneReplicas > 1 {
 		podTemplate.Spec.Affinity = podaffinity.NewPodAntiAffinity(DeploymentName, e.cfg.Namespace)
 	}
 
-	return &appsv1.Deployment{
+	d := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      DeploymentName,
@@ -286,6 +465,13 @@ func (e *esGateway) esGatewayDeployment() *appsv1.Deployment {
 			Replicas: e.cfg.Installation.ControlPlaneReplicas,
 		},
 	}
+
+	if e.cfg.LogStorage != nil {
+		if overrides := e.cfg.LogStorage.Spec.ESGatewayDeployment; overrides != nil {
+			rcomponents.ApplyDeploymentOverrides(d, overrides)
+		}
+	}
+	return d
 }
 
 func (e *esGateway) esGatewayServiceAccount() *corev1.ServiceAccount {
@@ -325,6 +511,50 @@ func (e *esGateway) esGatewayService() *corev1.Service {
 	}
 }
 
+// nonClusterHosts returns the configuration of log ingestion for non-cluster hosts, or nil if it is not enabled.
+func (e *esGateway) nonClusterHosts() *operatorv1.NonClusterHostLogIngestion {
+	if e.cfg.LogStorage == nil {
+		return nil
+	}
+	return e.cfg.LogStorage.Spec.NonClusterHosts
+}
+
+// nonClusterHostService exposes ES gateway outside of the cluster, so that non-cluster hosts can send their logs to it.
+func (e *esGateway) nonClusterHostService() *corev1.Service {
+	nch := e.nonClusterHosts()
+	serviceType := corev1.ServiceTypeLoadBalancer
+	if nch.ServiceType != nil {
+		serviceType = *nch.ServiceType
+	}
+	svc := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NonClusterHostServiceName,
+			Namespace: e.cfg.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"k8s-app": DeploymentName},
+			Type:     serviceType,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       ElasticsearchPortName,
+					Port:       int32(render.ElasticsearchDefaultPort),
+					TargetPort: intstr.FromInt(Port),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+	if len(nch.SourceCIDRs) > 0 {
+		// Preserve the addresses of the hosts so that the network policy can match them.
+		svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyLocal
+		if serviceType == corev1.ServiceTypeLoadBalancer {
+			svc.Spec.LoadBalancerSourceRanges = nch.SourceCIDRs
+		}
+	}
+	return svc
+}
+
 // Allow access to ES Gateway from components that need to talk to Elasticsearch or Kibana.
 func (e *esGateway) esGatewayAllowTigeraPolicy() *v3.NetworkPolicy {
 	egressRules := []v3.Rule{}
@@ -351,10 +581,81 @@ func (e *esGateway) esGatewayAllowTigeraPolicy() *v3.NetworkPolicy {
 			Destination: kibana.EntityRule,
 		},
 	}...)
+	egressRules = servicemesh.AppendEgressRules(egressRules, e.cfg.Installation.ServiceMesh)
 
 	esgatewayIngressDestinationEntityRule := v3.EntityRule{
 		Ports: networkpolicy.Ports(Port),
 	}
+	ingressRules := []v3.Rule{
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      render.FluentdSourceEntityRule,
+			Destination: esgatewayIngressDestinationEntityRule,
+		},
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      render.EKSLogForwarderEntityRule,
+			Destination: esgatewayIngressDestinationEntityRule,
+		},
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      render.IntrusionDetectionInstallerSourceEntityRule,
+			Destination: esgatewayIngressDestinationEntityRule,
+		},
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      networkpolicy.DefaultHelper().ManagerSourceEntityRule(),
+			Destination: esgatewayIngressDestinationEntityRule,
+		},
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      render.IntrusionDetectionSourceEntityRule,
+			Destination: esgatewayIngressDestinationEntityRule,
+		},
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      render.ECKOperatorSourceEntityRule,
+			Destination: esgatewayIngressDestinationEntityRule,
+		},
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      esmetrics.ESMetricsSourceEntityRule,
+			Destination: esgatewayIngressDestinationEntityRule,
+		},
+	}
+	if nch := e.nonClusterHosts(); nch != nil {
+		// Non-cluster hosts send their logs from outside of the cluster.
+		ingressRules = append(ingressRules, v3.Rule{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      v3.EntityRule{Nets: nch.SourceCIDRs},
+			Destination: esgatewayIngressDestinationEntityRule,
+		})
+	}
+	if e.cfg.LogStorage != nil && e.cfg.LogStorage.Spec.ESGatewayTenancy != nil {
+		ingressRules = append(ingressRules, v3.Rule{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      networkpolicy.PrometheusSourceEntityRule,
+			Destination: v3.EntityRule{Ports: networkpolicy.Ports(MetricsPort)},
+		})
+	}
+	ingressRules = append(ingressRules, v3.Rule{
+		Action:      v3.Allow,
+		Protocol:    &networkpolicy.TCPProtocol,
+		Destination: esgatewayIngressDestinationEntityRule,
+		// The operator needs access to Elasticsearch and Kibana (through ES Gateway), however, since the
+		// operator is on the hostnetwork it's hard to create specific network policies for it.
+		// Allow all sources, as node CIDRs are not known. This also applies to DPI, which is host networked
+	})
+
 	return &v3.NetworkPolicy{
 		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
 		ObjectMeta: metav1.ObjectMeta{
@@ -366,59 +667,8 @@ func (e *esGateway) esGatewayAllowTigeraPolicy() *v3.NetworkPolicy {
 			Tier:     networkpolicy.TigeraComponentTierName,
 			Selector: networkpolicy.KubernetesAppSelector(DeploymentName),
 			Types:    []v3.PolicyType{v3.PolicyTypeIngress, v3.PolicyTypeEgress},
-			Ingress: []v3.Rule{
-				{
-					Action:      v3.Allow,
-					Protocol:    &networkpolicy.TCPProtocol,
-					Source:      render.FluentdSourceEntityRule,
-					Destination: esgatewayIngressDestinationEntityRule,
-				},
-				{
-					Action:      v3.Allow,
-					Protocol:    &networkpolicy.TCPProtocol,
-					Source:      render.EKSLogForwarderEntityRule,
-					Destination: esgatewayIngressDestinationEntityRule,
-				},
-				{
-					Action:      v3.Allow,
-					Protocol:    &networkpolicy.TCPProtocol,
-					Source:      render.IntrusionDetectionInstallerSourceEntityRule,
-					Destination: esgatewayIngressDestinationEntityRule,
-				},
-				{
-					Action:      v3.Allow,
-					Protocol:    &networkpolicy.TCPProtocol,
-					Source:      networkpolicy.DefaultHelper().ManagerSourceEntityRule(),
-					Destination: esgatewayIngressDestinationEntityRule,
-				},
-				{
-					Action:      v3.Allow,
-					Protocol:    &networkpolicy.TCPProtocol,
-					Source:      render.IntrusionDetectionSourceEntityRule,
-					Destination: esgatewayIngressDestinationEntityRule,
-				},
-				{
-					Action:      v3.Allow,
-					Protocol:    &networkpolicy.TCPProtocol,
-					Source:      render.ECKOperatorSourceEntityRule,
-					Destination: esgatewayIngressDestinationEntityRule,
-				},
-				{
-					Action:      v3.Allow,
-					Protocol:    &networkpolicy.TCPProtocol,
-					Source:      esmetrics.ESMetricsSourceEntityRule,
-					Destination: esgatewayIngressDestinationEntityRule,
-				},
-				{
-					Action:      v3.Allow,
-					Protocol:    &networkpolicy.TCPProtocol,
-					Destination: esgatewayIngressDestinationEntityRule,
-					// The operator needs access to Elasticsearch and Kibana (through ES Gateway), however, since the
-					// operator is on the hostnetwork it's hard to create specific network policies for it.
-					// Allow all sources, as node CIDRs are not known. This also applies to DPI, which is host networked
-				},
-			},
-			Egress: egressRules,
+			Ingress:  ingressRules,
+			Egress:   egressRules,
 		},
 	}
 }
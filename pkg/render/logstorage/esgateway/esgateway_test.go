@@ -24,9 +24,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
@@ -37,11 +39,14 @@ import (
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/podaffinity"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/kubecontrollers"
+	"github.com/tigera/operator/pkg/render/logstorage/esmetrics"
 	"github.com/tigera/operator/pkg/render/testutils"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
@@ -178,6 +183,214 @@ var _ = Describe("ES Gateway rendering tests", func() {
 			Expect(deploy.Spec.Template.Spec.Affinity).To(Equal(podaffinity.NewPodAntiAffinity(DeploymentName, render.ElasticsearchNamespace)))
 		})
 
+		It("should use the Elasticsearch endpoint from the LogStorage", func() {
+			cfg.LogStorage = &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{ElasticsearchEndpoint: "https://es.mesh.example.com:8443"}}
+			component := EsGateway(cfg)
+
+			resources, _ := component.Objects()
+			d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "ES_GATEWAY_ELASTIC_ENDPOINT", Value: "https://es.mesh.example.com:8443"}))
+		})
+
+		It("should set the TLS settings from the LogStorage", func() {
+			cfg.LogStorage = &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{TLS: &operatorv1.LogStorageTLS{
+				CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+			}}}
+
+			resources, _ := EsGateway(cfg).Objects()
+			d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "ES_GATEWAY_TLS_CIPHER_SUITES", Value: "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}))
+			for _, env := range d.Spec.Template.Spec.Containers[0].Env {
+				Expect(env.Name).NotTo(Equal("ES_GATEWAY_TLS_MIN_VERSION"))
+			}
+		})
+
+		It("should configure the audit log from the LogStorage", func() {
+			envOf := func() []corev1.EnvVar {
+				resources, _ := EsGateway(cfg).Objects()
+				d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+				Expect(ok).To(BeTrue())
+				return d.Spec.Template.Spec.Containers[0].Env
+			}
+
+			By("not enabling it by default")
+			cfg.LogStorage = &operatorv1.LogStorage{}
+			for _, env := range envOf() {
+				Expect(env.Name).NotTo(HavePrefix("ES_GATEWAY_AUDIT_LOG_"))
+			}
+
+			By("defaulting to metadata written to stdout")
+			cfg.LogStorage.Spec.ESGatewayAuditLog = &operatorv1.ESGatewayAuditLog{}
+			env := envOf()
+			Expect(env).To(ContainElements(
+				corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_ENABLED", Value: "true"},
+				corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_VERBOSITY", Value: "Metadata"},
+				corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_SINK", Value: "Stdout"},
+				corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_MAX_EVENTS_PER_SECOND", Value: "100"},
+			))
+			for _, e := range env {
+				Expect(e.Name).NotTo(Equal("ES_GATEWAY_AUDIT_LOG_INDEX"))
+			}
+
+			By("writing the requests to the audit index")
+			verbosity := operatorv1.ESGatewayAuditLogVerbosityRequest
+			sink := operatorv1.ESGatewayAuditLogSinkElasticsearch
+			var maxEvents int32 = 20
+			cfg.LogStorage.Spec.ESGatewayAuditLog = &operatorv1.ESGatewayAuditLog{Verbosity: &verbosity, Sink: &sink, MaxEventsPerSecond: &maxEvents}
+			Expect(envOf()).To(ContainElements(
+				corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_VERBOSITY", Value: "Request"},
+				corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_SINK", Value: "Elasticsearch"},
+				corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_MAX_EVENTS_PER_SECOND", Value: "20"},
+				corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_INDEX", Value: "tigera_secure_ee_esgateway_audit"},
+				corev1.EnvVar{Name: "ES_GATEWAY_AUDIT_LOG_ILM_POLICY", Value: "tigera_secure_ee_esgateway_audit_policy"},
+			))
+		})
+
+		It("should validate the tenant of the requests when the LogStorage enables it", func() {
+			renderGateway := func() ([]corev1.EnvVar, *v3.NetworkPolicy) {
+				resources, _ := EsGateway(cfg).Objects()
+				d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+				Expect(ok).To(BeTrue())
+				policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: PolicyName, Namespace: render.ElasticsearchNamespace}, resources)
+				return d.Spec.Template.Spec.Containers[0].Env, policy
+			}
+			metricsRule := v3.Rule{
+				Action:      v3.Allow,
+				Protocol:    &networkpolicy.TCPProtocol,
+				Source:      networkpolicy.PrometheusSourceEntityRule,
+				Destination: v3.EntityRule{Ports: networkpolicy.Ports(MetricsPort)},
+			}
+
+			By("not validating the tenant by default")
+			cfg.LogStorage = &operatorv1.LogStorage{}
+			env, policy := renderGateway()
+			for _, e := range env {
+				Expect(e.Name).NotTo(HavePrefix("ES_GATEWAY_TENANT_"))
+				Expect(e.Name).NotTo(HavePrefix("ES_GATEWAY_PROMETHEUS_"))
+				Expect(e.Name).NotTo(Equal("ES_GATEWAY_EXPECTED_TENANT_ID"))
+			}
+			Expect(policy.Spec.Ingress).NotTo(ContainElement(metricsRule))
+
+			By("enforcing the tenant by default")
+			cfg.LogStorage.Spec.ESGatewayTenancy = &operatorv1.ESGatewayTenancy{ExpectedTenantID: "tenant-a"}
+			env, policy = renderGateway()
+			Expect(env).To(ContainElements(
+				corev1.EnvVar{Name: "ES_GATEWAY_EXPECTED_TENANT_ID", Value: "tenant-a"},
+				corev1.EnvVar{Name: "ES_GATEWAY_TENANT_VALIDATION_MODE", Value: "Enforce"},
+				corev1.EnvVar{Name: "ES_GATEWAY_PROMETHEUS_METRICS_ENABLED", Value: "true"},
+				corev1.EnvVar{Name: "ES_GATEWAY_PROMETHEUS_METRICS_PORT", Value: "9091"},
+			))
+			Expect(policy.Spec.Ingress).To(ContainElement(metricsRule))
+
+			By("only auditing the requests")
+			mode := operatorv1.ESGatewayTenancyModeAudit
+			cfg.LogStorage.Spec.ESGatewayTenancy.Mode = &mode
+			env, _ = renderGateway()
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: "ES_GATEWAY_TENANT_VALIDATION_MODE", Value: "Audit"}))
+		})
+
+		It("should render the ingestion endpoint for non-cluster hosts", func() {
+			nodePort := corev1.ServiceTypeNodePort
+			cfg.LogStorage = &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{
+				NonClusterHosts: &operatorv1.NonClusterHostLogIngestion{ServiceType: &nodePort, SourceCIDRs: []string{"192.168.0.0/24"}},
+			}}
+			cfg.NonClusterHostUserSecrets = []*corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostUserSecret, Namespace: common.OperatorNamespace()}},
+				{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostVerificationUserSecret, Namespace: render.ElasticsearchNamespace}},
+				{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostSecureUserSecret, Namespace: render.ElasticsearchNamespace}},
+			}
+
+			resources, toDelete := EsGateway(cfg).Objects()
+			rtest.ExpectResources(toDelete, []client.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: MetricsUserSecret, Namespace: render.ElasticsearchNamespace}},
+			})
+			Expect(rtest.ExpectResource(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostUserSecret, Namespace: common.OperatorNamespace()}}, resources)).NotTo(HaveOccurred())
+			Expect(rtest.ExpectResource(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostVerificationUserSecret, Namespace: render.ElasticsearchNamespace}}, resources)).NotTo(HaveOccurred())
+			Expect(rtest.ExpectResource(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostSecureUserSecret, Namespace: render.ElasticsearchNamespace}}, resources)).NotTo(HaveOccurred())
+
+			svc, ok := rtest.GetResource(resources, NonClusterHostServiceName, render.ElasticsearchNamespace, "", "v1", "Service").(*corev1.Service)
+			Expect(ok).To(BeTrue())
+			Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+			Expect(svc.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyLocal))
+			Expect(svc.Spec.Ports).To(ConsistOf(corev1.ServicePort{
+				Name:       ElasticsearchPortName,
+				Port:       9200,
+				TargetPort: intstr.FromInt(Port),
+				Protocol:   corev1.ProtocolTCP,
+			}))
+
+			policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: PolicyName, Namespace: render.ElasticsearchNamespace}, resources)
+			Expect(policy.Spec.Ingress).To(ContainElement(v3.Rule{
+				Action:      v3.Allow,
+				Protocol:    &networkpolicy.TCPProtocol,
+				Source:      v3.EntityRule{Nets: []string{"192.168.0.0/24"}},
+				Destination: v3.EntityRule{Ports: networkpolicy.Ports(Port)},
+			}))
+		})
+
+		It("should delete the ingestion endpoint for non-cluster hosts when it is not enabled", func() {
+			_, toDelete := EsGateway(cfg).Objects()
+			rtest.ExpectResources(toDelete, []client.Object{
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostServiceName, Namespace: render.ElasticsearchNamespace}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostUserSecret, Namespace: common.OperatorNamespace()}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostVerificationUserSecret, Namespace: render.ElasticsearchNamespace}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: NonClusterHostSecureUserSecret, Namespace: render.ElasticsearchNamespace}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: MetricsUserSecret, Namespace: render.ElasticsearchNamespace}},
+			})
+		})
+
+		It("should authenticate to Elasticsearch on behalf of es-metrics when it presents a client certificate", func() {
+			auth := operatorv1.ElasticsearchMetricsAuthenticationClientCertificate
+			cfg.LogStorage = &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{ElasticsearchMetricsAuthentication: &auth}}
+			cfg.ESMetricsUserSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: esmetrics.ElasticsearchMetricsSecret, Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{"username": []byte("tigera-ee-es-metrics"), "password": []byte("password")},
+			}
+
+			resources, toDelete := EsGateway(cfg).Objects()
+			for _, obj := range toDelete {
+				Expect(obj.GetName()).NotTo(Equal(MetricsUserSecret))
+			}
+			userSecret, ok := rtest.GetResource(resources, MetricsUserSecret, render.ElasticsearchNamespace, "", "v1", "Secret").(*corev1.Secret)
+			Expect(ok).To(BeTrue())
+			Expect(userSecret.Data).To(Equal(cfg.ESMetricsUserSecret.Data))
+
+			d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(d.Spec.Template.Annotations).To(HaveKey("hash.operator.tigera.io/es-metrics-user-secret"))
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: "ES_GATEWAY_METRICS_CLIENT_CERT_COMMON_NAME", Value: esmetrics.ElasticsearchMetricsName},
+				corev1.EnvVar{Name: "ES_GATEWAY_METRICS_USERNAME", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: MetricsUserSecret},
+					Key:                  "username",
+				}}},
+			))
+		})
+
+		It("should render ES Gateway inside the Istio service mesh", func() {
+			installation.ServiceMesh = &operatorv1.ServiceMesh{Type: operatorv1.ServiceMeshTypeIstio}
+			component := EsGateway(cfg)
+
+			resources, _ := component.Objects()
+			d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(d.Spec.Template.Labels).To(HaveKeyWithValue("sidecar.istio.io/inject", "true"))
+			Expect(d.Spec.Template.Annotations).To(HaveKeyWithValue("traffic.sidecar.istio.io/excludeInboundPorts", "5554"))
+
+			policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: PolicyName, Namespace: render.ElasticsearchNamespace}, resources)
+			Expect(policy.Spec.Egress).To(ContainElement(v3.Rule{
+				Action:   v3.Allow,
+				Protocol: &networkpolicy.TCPProtocol,
+				Destination: v3.EntityRule{
+					NamespaceSelector: "projectcalico.org/name == 'istio-system'",
+					Selector:          "app == 'istiod'",
+					Ports:             networkpolicy.Ports(15012),
+				},
+			}))
+		})
+
 		It("should apply controlPlaneNodeSelector correctly", func() {
 			installation.ControlPlaneNodeSelector = map[string]string{"foo": "bar"}
 
@@ -205,6 +418,39 @@ var _ = Describe("ES Gateway rendering tests", func() {
 			Expect(d.Spec.Template.Spec.Tolerations).To(ConsistOf(t))
 		})
 
+		It("should apply the ESGatewayDeployment overrides from the LogStorage", func() {
+			rr := corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			}
+			rollingUpdate := &appsv1.RollingUpdateDeployment{
+				MaxUnavailable: ptr.IntOrStrPtr("1"),
+				MaxSurge:       ptr.IntOrStrPtr("0"),
+			}
+			cfg.LogStorage = &operatorv1.LogStorage{
+				Spec: operatorv1.LogStorageSpec{
+					ESGatewayDeployment: &operatorv1.ESGatewayDeployment{
+						Spec: &operatorv1.ESGatewayDeploymentSpec{
+							Strategy: &operatorv1.DeploymentUpdateStrategy{RollingUpdate: rollingUpdate},
+							Template: &operatorv1.ESGatewayDeploymentPodTemplateSpec{
+								Spec: &operatorv1.ESGatewayDeploymentPodSpec{
+									Containers: []operatorv1.ESGatewayDeploymentContainer{{Name: DeploymentName, Resources: &rr}},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			resources, _ := EsGateway(cfg).Objects()
+			d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(d.Spec.Strategy).To(Equal(appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: rollingUpdate,
+			}))
+			Expect(d.Spec.Template.Spec.Containers[0].Resources).To(Equal(rr))
+		})
+
 		Context("allow-tigera rendering", func() {
 			policyName := types.NamespacedName{Name: "allow-tigera.es-gateway-access", Namespace: "tigera-elasticsearch"}
 
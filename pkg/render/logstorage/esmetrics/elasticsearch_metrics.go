@@ -15,6 +15,7 @@
 package esmetrics
 
 import (
+	"crypto/x509"
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -38,12 +39,14 @@ import (
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+	"github.com/tigera/operator/pkg/tls/certkeyusage"
 	"github.com/tigera/operator/pkg/url"
 )
 
 const (
 	ElasticsearchMetricsSecret          = "tigera-ee-elasticsearch-metrics-elasticsearch-access"
 	ElasticsearchMetricsServerTLSSecret = "tigera-ee-elasticsearch-metrics-tls"
+	ElasticsearchMetricsClientTLSSecret = "tigera-ee-elasticsearch-metrics-client-tls"
 	ElasticsearchMetricsName            = "tigera-elasticsearch-metrics"
 	ElasticsearchMetricsRoleName        = "tigera-elasticsearch-metrics"
 	ElasticsearchMetricsPolicyName      = networkpolicy.TigeraComponentPolicyPrefix + "elasticsearch-metrics"
@@ -52,6 +55,11 @@ const (
 
 var ESMetricsSourceEntityRule = networkpolicy.CreateSourceEntityRule(render.ElasticsearchNamespace, ElasticsearchMetricsName)
 
+func init() {
+	certkeyusage.SetCertKeyUsage(ElasticsearchMetricsServerTLSSecret, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+	certkeyusage.SetCertKeyUsage(ElasticsearchMetricsClientTLSSecret, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth})
+}
+
 func ElasticsearchMetrics(cfg *Config) render.Component {
 	return &elasticsearchMetrics{
 		cfg: cfg,
@@ -67,6 +75,10 @@ type Config struct {
 	ServerTLS            certificatemanagement.KeyPairInterface
 	TrustedBundle        certificatemanagement.TrustedBundleRO
 
+	// ClientTLS is the client certificate that es-metrics presents to es-gateway instead of a username and password.
+	// It is only set when the LogStorage enables authentication with a client certificate.
+	ClientTLS certificatemanagement.KeyPairInterface
+
 	LogStorage *operatorv1.LogStorage
 }
 
@@ -94,7 +106,7 @@ func (e *elasticsearchMetrics) Objects() (objsToCreate, objsToDelete []client.Ob
 	toCreate := []client.Object{
 		e.allowTigeraPolicy(),
 	}
-	toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(render.ElasticsearchNamespace, e.cfg.ESMetricsCredsSecret)...)...)
+	toCreate = append(toCreate, secret.ToRuntimeObjects(secret.MirrorToNamespace(render.ElasticsearchNamespace, e.cfg.ESMetricsCredsSecret)...)...)
 	toCreate = append(toCreate, e.metricsService(), e.metricsDeployment(), e.serviceAccount())
 
 	if e.cfg.Installation.KubernetesProvider.IsOpenShift() {
@@ -103,6 +115,23 @@ func (e *elasticsearchMetrics) Objects() (objsToCreate, objsToDelete []client.Ob
 	return toCreate, objsToDelete
 }
 
+// ObjectsToDelete returns the objects of the Elasticsearch metrics component, so that they can be deleted when the
+// component is disabled by the Installation Features.
+func ObjectsToDelete() []client.Object {
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: render.ElasticsearchNamespace}
+	}
+	return []client.Object{
+		&v3.NetworkPolicy{ObjectMeta: meta(ElasticsearchMetricsPolicyName)},
+		&corev1.Secret{ObjectMeta: meta(ElasticsearchMetricsSecret)},
+		&corev1.Service{ObjectMeta: meta(ElasticsearchMetricsName)},
+		&appsv1.Deployment{ObjectMeta: meta(ElasticsearchMetricsName)},
+		&corev1.ServiceAccount{ObjectMeta: meta(ElasticsearchMetricsName)},
+		&rbacv1.Role{ObjectMeta: meta(ElasticsearchMetricsRoleName)},
+		&rbacv1.RoleBinding{ObjectMeta: meta(ElasticsearchMetricsRoleName)},
+	}
+}
+
 func (e elasticsearchMetrics) serviceAccount() *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		TypeMeta: metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
@@ -203,6 +232,59 @@ func (e elasticsearchMetrics) metricsDeployment() *appsv1.Deployment {
 
 	_, esHost, esPort, _ := url.ParseEndpoint(relasticsearch.GatewayEndpoint(e.SupportedOSType(), e.cfg.ClusterDomain, render.ElasticsearchNamespace))
 
+	esURI := "--es.uri=https://$(ELASTIC_USERNAME):$(ELASTIC_PASSWORD)@$(ELASTIC_HOST):$(ELASTIC_PORT)"
+	var esAuthArgs []string
+	envVars := []corev1.EnvVar{
+		{Name: "FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(e.cfg.Installation.FIPSMode)},
+	}
+	volumeMounts := append(
+		e.cfg.TrustedBundle.VolumeMounts(e.SupportedOSType()),
+		e.cfg.ServerTLS.VolumeMount(e.SupportedOSType()),
+	)
+	volumes := []corev1.Volume{
+		e.cfg.ServerTLS.Volume(),
+		e.cfg.TrustedBundle.Volume(),
+	}
+	credsSecrets := []*corev1.Secret{e.cfg.ESMetricsCredsSecret}
+	if e.cfg.ClientTLS != nil {
+		// es-gateway authenticates to Elasticsearch on behalf of es-metrics, so the credentials are left out of the pod.
+		esURI = "--es.uri=https://$(ELASTIC_HOST):$(ELASTIC_PORT)"
+		esAuthArgs = []string{
+			fmt.Sprintf("--es.client-cert=%s", e.cfg.ClientTLS.VolumeMountCertificateFilePath()),
+			fmt.Sprintf("--es.client-private-key=%s", e.cfg.ClientTLS.VolumeMountKeyFilePath()),
+		}
+		volumeMounts = append(volumeMounts, e.cfg.ClientTLS.VolumeMount(e.SupportedOSType()))
+		volumes = append(volumes, e.cfg.ClientTLS.Volume())
+		if e.cfg.ClientTLS.UseCertificateManagement() {
+			initContainers = append(initContainers, e.cfg.ClientTLS.InitContainer(render.ElasticsearchNamespace))
+		} else {
+			annotations[e.cfg.ClientTLS.HashAnnotationKey()] = e.cfg.ClientTLS.HashAnnotationValue()
+		}
+		credsSecrets = nil
+	} else {
+		envVars = append(envVars,
+			relasticsearch.ElasticUsernameEnvVar(ElasticsearchMetricsSecret),
+			relasticsearch.ElasticPasswordEnvVar(ElasticsearchMetricsSecret),
+		)
+	}
+	envVars = append(envVars,
+		relasticsearch.ElasticHostEnvVar(esHost),
+		relasticsearch.ElasticPortEnvVar(esPort),
+		relasticsearch.ElasticCAEnvVar(e.SupportedOSType()),
+	)
+	envVars = append(envVars, relasticsearch.ServerTLSEnvVars("", e.cfg.LogStorage)...)
+
+	args := []string{esURI, "--es.all"}
+	if e.perIndexMetrics() {
+		args = append(args, "--es.indices", "--es.indices_settings", "--es.shards")
+	}
+	args = append(args,
+		"--es.cluster_settings",
+		"--es.timeout=30s", "--es.ca=$(ELASTIC_CA)", "--web.listen-address=:9081",
+		"--web.telemetry-path=/metrics", "--tls.key=/tigera-ee-elasticsearch-metrics-tls/tls.key", "--tls.crt=/tigera-ee-elasticsearch-metrics-tls/tls.crt", fmt.Sprintf("--ca.crt=%s", certificatemanagement.TrustedCertBundleMountPath),
+	)
+	args = append(args, esAuthArgs...)
+
 	d := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
 		ObjectMeta: metav1.ObjectMeta{
@@ -216,7 +298,7 @@ func (e elasticsearchMetrics) metricsDeployment() *appsv1.Deployment {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					Tolerations:        e.cfg.Installation.ControlPlaneTolerations,
+					Tolerations:        rmeta.ControlPlaneTolerations(e.cfg.Installation, rmeta.Workload{}),
 					NodeSelector:       e.cfg.Installation.ControlPlaneNodeSelector,
 					ImagePullSecrets:   secret.GetReferenceList(e.cfg.PullSecrets),
 					ServiceAccountName: ElasticsearchMetricsName,
@@ -228,32 +310,14 @@ func (e elasticsearchMetrics) metricsDeployment() *appsv1.Deployment {
 							ImagePullPolicy: render.ImagePullPolicy(),
 							SecurityContext: securitycontext.NewNonRootContext(),
 							Command:         []string{"/bin/elasticsearch_exporter"},
-							Args: []string{
-								"--es.uri=https://$(ELASTIC_USERNAME):$(ELASTIC_PASSWORD)@$(ELASTIC_HOST):$(ELASTIC_PORT)",
-								"--es.all", "--es.indices", "--es.indices_settings", "--es.shards", "--es.cluster_settings",
-								"--es.timeout=30s", "--es.ca=$(ELASTIC_CA)", "--web.listen-address=:9081",
-								"--web.telemetry-path=/metrics", "--tls.key=/tigera-ee-elasticsearch-metrics-tls/tls.key", "--tls.crt=/tigera-ee-elasticsearch-metrics-tls/tls.crt", fmt.Sprintf("--ca.crt=%s", certificatemanagement.TrustedCertBundleMountPath),
-							},
-							VolumeMounts: append(
-								e.cfg.TrustedBundle.VolumeMounts(e.SupportedOSType()),
-								e.cfg.ServerTLS.VolumeMount(e.SupportedOSType()),
-							),
-							Env: []corev1.EnvVar{
-								{Name: "FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(e.cfg.Installation.FIPSMode)},
-								relasticsearch.ElasticUsernameEnvVar(ElasticsearchMetricsSecret),
-								relasticsearch.ElasticPasswordEnvVar(ElasticsearchMetricsSecret),
-								relasticsearch.ElasticHostEnvVar(esHost),
-								relasticsearch.ElasticPortEnvVar(esPort),
-								relasticsearch.ElasticCAEnvVar(e.SupportedOSType()),
-							},
+							Args:            args,
+							VolumeMounts:    volumeMounts,
+							Env:             envVars,
 						},
 					},
-					Volumes: []corev1.Volume{
-						e.cfg.ServerTLS.Volume(),
-						e.cfg.TrustedBundle.Volume(),
-					},
+					Volumes: volumes,
 				},
-			}, []*corev1.Secret{e.cfg.ESMetricsCredsSecret}).(*corev1.PodTemplateSpec),
+			}, credsSecrets).(*corev1.PodTemplateSpec),
 		},
 	}
 
@@ -266,6 +330,15 @@ func (e elasticsearchMetrics) metricsDeployment() *appsv1.Deployment {
 	return d
 }
 
+// perIndexMetrics returns whether es-metrics collects the metrics of each index and shard.
+func (e elasticsearchMetrics) perIndexMetrics() bool {
+	if e.cfg.LogStorage == nil || e.cfg.LogStorage.Spec.ElasticsearchMetricsCollection == nil {
+		return true
+	}
+	mode := e.cfg.LogStorage.Spec.ElasticsearchMetricsCollection.PerIndexMetrics
+	return mode == nil || *mode != operatorv1.PerIndexMetricsDisabled
+}
+
 func (e *elasticsearchMetrics) allowTigeraPolicy() *v3.NetworkPolicy {
 	egressRules := []v3.Rule{
 		{
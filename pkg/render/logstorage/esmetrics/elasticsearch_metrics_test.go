@@ -54,6 +54,7 @@ var _ = Describe("Elasticsearch metrics", func() {
 		var esConfig *relasticsearch.ClusterConfig
 		var cfg *Config
 		var cli client.Client
+		var certificateManager certificatemanager.CertificateManager
 		clusterDomain := dns.DefaultClusterDomain
 		expectedPolicy := testutils.GetExpectedPolicyFromFile("../../testutils/expected_policies/es-metrics.json")
 		expectedPolicyForOpenshift := testutils.GetExpectedPolicyFromFile("../../testutils/expected_policies/es-metrics_ocp.json")
@@ -69,7 +70,8 @@ var _ = Describe("Elasticsearch metrics", func() {
 			Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
 			cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
 
-			certificateManager, err := certificatemanager.Create(cli, nil, "", common.OperatorNamespace(), certificatemanager.AllowCACreation())
+			var err error
+			certificateManager, err = certificatemanager.Create(cli, nil, "", common.OperatorNamespace(), certificatemanager.AllowCACreation())
 			Expect(err).NotTo(HaveOccurred())
 
 			bundle := certificateManager.CreateTrustedBundle()
@@ -323,6 +325,62 @@ var _ = Describe("Elasticsearch metrics", func() {
 			}))
 		})
 
+		It("should set the TLS settings from the LogStorage", func() {
+			minVersion := operatorv1.TLSVersion13
+			cfg.LogStorage = &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{TLS: &operatorv1.LogStorageTLS{MinVersion: &minVersion}}}
+
+			resources, _ := ElasticsearchMetrics(cfg).Objects()
+			d, ok := rtest.GetResource(resources, ElasticsearchMetricsName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "TLS_MIN_VERSION", Value: "VersionTLS13"}))
+			for _, env := range d.Spec.Template.Spec.Containers[0].Env {
+				Expect(env.Name).NotTo(Equal("TLS_CIPHER_SUITES"))
+			}
+		})
+
+		It("should authenticate with a client certificate instead of a password", func() {
+			clientTLS, err := certificateManager.GetOrCreateKeyPair(cli, ElasticsearchMetricsClientTLSSecret, common.OperatorNamespace(), []string{ElasticsearchMetricsName})
+			Expect(err).ShouldNot(HaveOccurred())
+			cfg.ClientTLS = clientTLS
+
+			resources, _ := ElasticsearchMetrics(cfg).Objects()
+			d, ok := rtest.GetResource(resources, ElasticsearchMetricsName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			container := d.Spec.Template.Spec.Containers[0]
+			Expect(container.Args).To(ContainElements(
+				"--es.uri=https://$(ELASTIC_HOST):$(ELASTIC_PORT)",
+				"--es.client-cert=/tigera-ee-elasticsearch-metrics-client-tls/tls.crt",
+				"--es.client-private-key=/tigera-ee-elasticsearch-metrics-client-tls/tls.key",
+			))
+			for _, env := range container.Env {
+				Expect(env.Name).NotTo(BeElementOf("ELASTIC_USERNAME", "ELASTIC_PASSWORD"))
+			}
+			Expect(container.VolumeMounts).To(ContainElement(clientTLS.VolumeMount(meta.OSTypeLinux)))
+			Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(clientTLS.Volume()))
+			Expect(d.Spec.Template.Annotations).To(HaveKey(clientTLS.HashAnnotationKey()))
+		})
+
+		It("should only collect the metrics of each index and shard while per-index metrics are enabled", func() {
+			disabled := operatorv1.PerIndexMetricsDisabled
+			cfg.LogStorage = &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{
+				ElasticsearchMetricsCollection: &operatorv1.ElasticsearchMetricsCollection{PerIndexMetrics: &disabled},
+			}}
+
+			resources, _ := ElasticsearchMetrics(cfg).Objects()
+			d, ok := rtest.GetResource(resources, ElasticsearchMetricsName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			args := d.Spec.Template.Spec.Containers[0].Args
+			Expect(args).To(ContainElements("--es.all", "--es.cluster_settings"))
+			Expect(args).NotTo(ContainElement(BeElementOf("--es.indices", "--es.indices_settings", "--es.shards")))
+
+			enabled := operatorv1.PerIndexMetricsEnabled
+			cfg.LogStorage.Spec.ElasticsearchMetricsCollection.PerIndexMetrics = &enabled
+			resources, _ = ElasticsearchMetrics(cfg).Objects()
+			d, ok = rtest.GetResource(resources, ElasticsearchMetricsName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElements("--es.indices", "--es.indices_settings", "--es.shards"))
+		})
+
 		It("should apply controlPlaneNodeSelector correctly", func() {
 			cfg.Installation.ControlPlaneNodeSelector = map[string]string{"foo": "bar"}
 
@@ -25,4 +25,10 @@ const (
 	// used as part of CA bundles to trust external Elasticsearch and Kibana instances.
 	ExternalESPublicCertName = "tigera-secure-es-http-certs-public"
 	ExternalKBPublicCertName = "tigera-secure-kb-http-certs-public"
+
+	// SecondaryElasticsearchSecret is the name of the secret in the operator namespace that holds the username,
+	// password and, optionally, the CA certificate (ca.crt) used to access the secondary Elasticsearch cluster of the
+	// LogStorage. The secret is mirrored into the Linseed namespace when Linseed writes to both clusters.
+	SecondaryElasticsearchSecret     = "tigera-secondary-elasticsearch"
+	SecondaryElasticsearchVolumeName = "tigera-secondary-elasticsearch"
 )
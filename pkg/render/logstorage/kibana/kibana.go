@@ -94,6 +94,10 @@ type Configuration struct {
 	TrustedBundle   certificatemanagement.TrustedBundleRO
 	UnusedTLSSecret *corev1.Secret
 	Enabled         bool
+
+	// ECKOperatorNamespace is the namespace of the ECK operator that manages Kibana, if it isn't the one deployed by
+	// the operator.
+	ECKOperatorNamespace string
 }
 
 type kibana struct {
@@ -321,7 +325,7 @@ func (k *kibana) kibanaCR() *kbv1.Kibana {
 					ImagePullSecrets:             secret.GetReferenceList(k.cfg.PullSecrets),
 					ServiceAccountName:           ObjectName,
 					NodeSelector:                 k.cfg.Installation.ControlPlaneNodeSelector,
-					Tolerations:                  k.cfg.Installation.ControlPlaneTolerations,
+					Tolerations:                  rmeta.ControlPlaneTolerations(k.cfg.Installation, rmeta.Workload{}),
 					InitContainers:               initContainers,
 					AutomountServiceAccountToken: &automountToken,
 					Containers: []corev1.Container{{
@@ -468,7 +472,7 @@ func (k *kibana) allowTigeraPolicy() *v3.NetworkPolicy {
 				{
 					Action:      v3.Allow,
 					Protocol:    &networkpolicy.TCPProtocol,
-					Source:      render.ECKOperatorSourceEntityRule,
+					Source:      render.ECKOperatorSourceEntityRuleFor(k.cfg.ECKOperatorNamespace),
 					Destination: kibanaPortIngressDestination,
 				},
 			},
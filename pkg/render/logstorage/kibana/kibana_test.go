@@ -239,6 +239,21 @@ var _ = Describe("Kibana rendering tests", func() {
 				Entry("for management/standalone, kube-dns", testutils.AllowTigeraScenario{ManagedCluster: false, OpenShift: false}),
 				Entry("for management/standalone, openshift-dns", testutils.AllowTigeraScenario{ManagedCluster: false, OpenShift: true}),
 			)
+
+			It("should allow a user-managed ECK operator to reach Kibana", func() {
+				cfg.ECKOperatorNamespace = "elastic-system"
+				resources, _ := kibana.Kibana(cfg).Objects()
+
+				policy := testutils.GetAllowTigeraPolicyFromResources(policyNames[0], resources)
+				var sources []v3.EntityRule
+				for _, rule := range policy.Spec.Ingress {
+					sources = append(sources, rule.Source)
+				}
+				Expect(sources).To(ContainElement(v3.EntityRule{
+					Selector:          "control-plane == 'elastic-operator'",
+					NamespaceSelector: "projectcalico.org/name == 'elastic-system'",
+				}))
+			})
 		})
 
 		It("Should not install Kibana when instructed so", func() {
@@ -42,8 +42,10 @@ import (
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
+	"github.com/tigera/operator/pkg/render/common/servicemesh"
 	"github.com/tigera/operator/pkg/render/logstorage"
 	"github.com/tigera/operator/pkg/render/logstorage/esmetrics"
+	"github.com/tigera/operator/pkg/render/runtimesecurity"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
@@ -54,6 +56,7 @@ const (
 	PortName                                               = "tigera-linseed"
 	TargetPort                                             = 8444
 	Port                                                   = 443
+	MetricsPort                                            = 9095
 	ClusterRoleName                                        = "tigera-linseed"
 	MultiTenantManagedClustersAccessClusterRoleBindingName = "tigera-linseed-managed-cluster-access"
 )
@@ -125,6 +128,12 @@ type Config struct {
 	ElasticHost string
 	ElasticPort string
 
+	// Secret containing the credentials of the secondary Elasticsearch cluster of the LogStorage. If configured,
+	// Linseed writes the log data to the secondary cluster at SecondaryElasticHost:SecondaryElasticPort as well.
+	SecondaryElasticsearchSecret *corev1.Secret
+	SecondaryElasticHost         string
+	SecondaryElasticPort         uint16
+
 	LogStorage *operatorv1.LogStorage
 }
 
@@ -165,7 +174,17 @@ func (l *linseed) Objects() (toCreate, toDelete []client.Object) {
 	toCreate = append(toCreate, l.linseedDeployment())
 	if l.cfg.ElasticClientSecret != nil {
 		// If using External ES, we need to copy the client certificates into Linseed's naespace to be mounted.
-		toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(l.cfg.Namespace, l.cfg.ElasticClientSecret)...)...)
+		toCreate = append(toCreate, secret.ToRuntimeObjects(secret.MirrorToNamespace(l.cfg.Namespace, l.cfg.ElasticClientSecret)...)...)
+	}
+	if l.cfg.SecondaryElasticsearchSecret != nil {
+		toCreate = append(toCreate, secret.ToRuntimeObjects(secret.MirrorToNamespace(l.cfg.Namespace, l.cfg.SecondaryElasticsearchSecret)...)...)
+	} else {
+		// Remove the copy of the credentials once Linseed no longer writes to a secondary cluster, for example when the
+		// migration to external Elasticsearch completes.
+		toDelete = append(toDelete, &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: logstorage.SecondaryElasticsearchSecret, Namespace: l.cfg.Namespace},
+		})
 	}
 	return toCreate, toDelete
 }
@@ -296,6 +315,10 @@ func (l *linseed) linseedDeployment() *appsv1.Deployment {
 		// Configure the CA certificate used for verifying client certs.
 		{Name: "LINSEED_CA_CERT", Value: l.cfg.TrustedBundle.MountPath()},
 
+		// Expose the ingestion metrics, which the operator collects to detect backpressure.
+		{Name: "LINSEED_ENABLE_METRICS", Value: "true"},
+		{Name: "LINSEED_METRICS_PORT", Value: strconv.Itoa(MetricsPort)},
+
 		// Configure default shards and replicas for indices
 		{Name: "ELASTIC_REPLICAS", Value: strconv.Itoa(l.cfg.ESClusterConfig.Replicas())},
 		{Name: "ELASTIC_SHARDS", Value: strconv.Itoa(l.cfg.ESClusterConfig.Shards())},
@@ -330,6 +353,7 @@ func (l *linseed) linseedDeployment() *appsv1.Deployment {
 		},
 		{Name: "ELASTIC_CA", Value: l.cfg.TrustedBundle.MountPath()},
 	}
+	envVars = append(envVars, relasticsearch.ServerTLSEnvVars("LINSEED_", l.cfg.LogStorage)...)
 
 	volumes := []corev1.Volume{
 		l.cfg.KeyPair.Volume(),
@@ -363,6 +387,46 @@ func (l *linseed) linseedDeployment() *appsv1.Deployment {
 		envVars = append(envVars, corev1.EnvVar{Name: "ELASTIC_CLIENT_CERT", Value: "/certs/elasticsearch/mtls/client.crt"})
 	}
 
+	if l.cfg.SecondaryElasticsearchSecret != nil {
+		// Configure Linseed to write the log data to the secondary Elasticsearch cluster as well.
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "ELASTIC_SECONDARY_ENABLED", Value: "true"},
+			corev1.EnvVar{Name: "ELASTIC_SECONDARY_SCHEME", Value: "https"},
+			corev1.EnvVar{Name: "ELASTIC_SECONDARY_HOST", Value: l.cfg.SecondaryElasticHost},
+			corev1.EnvVar{Name: "ELASTIC_SECONDARY_PORT", Value: strconv.Itoa(int(l.cfg.SecondaryElasticPort))},
+			corev1.EnvVar{
+				Name:      "ELASTIC_SECONDARY_USERNAME",
+				ValueFrom: secret.GetEnvVarSource(logstorage.SecondaryElasticsearchSecret, "username", false),
+			},
+			corev1.EnvVar{
+				Name:      "ELASTIC_SECONDARY_PASSWORD",
+				ValueFrom: secret.GetEnvVarSource(logstorage.SecondaryElasticsearchSecret, "password", false),
+			},
+		)
+
+		// Without a CA certificate in the secret, the server certificate of the secondary cluster is validated with the
+		// trusted bundle.
+		secondaryCA := l.cfg.TrustedBundle.MountPath()
+		if _, ok := l.cfg.SecondaryElasticsearchSecret.Data[corev1.ServiceAccountRootCAKey]; ok {
+			volumes = append(volumes, corev1.Volume{
+				Name: logstorage.SecondaryElasticsearchVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: logstorage.SecondaryElasticsearchSecret,
+						Items:      []corev1.KeyToPath{{Key: corev1.ServiceAccountRootCAKey, Path: corev1.ServiceAccountRootCAKey}},
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      logstorage.SecondaryElasticsearchVolumeName,
+				MountPath: "/certs/elasticsearch/secondary",
+				ReadOnly:  true,
+			})
+			secondaryCA = "/certs/elasticsearch/secondary/ca.crt"
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: "ELASTIC_SECONDARY_CA", Value: secondaryCA})
+	}
+
 	if l.cfg.ManagementCluster {
 		envVars = append(envVars,
 			corev1.EnvVar{Name: "MANAGEMENT_OPERATOR_NS", Value: common.OperatorNamespace()},
@@ -406,6 +470,9 @@ func (l *linseed) linseedDeployment() *appsv1.Deployment {
 	if l.cfg.ElasticClientCredentialsSecret != nil {
 		annotations[fmt.Sprintf("hash.operator.tigera.io/%s", render.ElasticsearchLinseedUserSecret)] = rmeta.SecretsAnnotationHash(l.cfg.ElasticClientCredentialsSecret)
 	}
+	if l.cfg.SecondaryElasticsearchSecret != nil {
+		annotations[fmt.Sprintf("hash.operator.tigera.io/%s", logstorage.SecondaryElasticsearchSecret)] = rmeta.SecretsAnnotationHash(l.cfg.SecondaryElasticsearchSecret)
+	}
 
 	if l.cfg.TokenKeyPair != nil {
 		envVars = append(envVars,
@@ -426,7 +493,7 @@ func (l *linseed) linseedDeployment() *appsv1.Deployment {
 			Annotations: annotations,
 		},
 		Spec: corev1.PodSpec{
-			Tolerations:        l.cfg.Installation.ControlPlaneTolerations,
+			Tolerations:        rmeta.ControlPlaneTolerations(l.cfg.Installation, rmeta.Workload{}),
 			NodeSelector:       l.cfg.Installation.ControlPlaneNodeSelector,
 			ServiceAccountName: ServiceAccountName,
 			ImagePullSecrets:   secret.GetReferenceList(l.cfg.PullSecrets),
@@ -461,6 +528,8 @@ func (l *linseed) linseedDeployment() *appsv1.Deployment {
 		},
 	}
 
+	servicemesh.ApplyPodTemplate(podTemplate, l.cfg.Installation.ServiceMesh, TargetPort)
+
 	if replicas != nil && *replicas > 1 {
 		podTemplate.Spec.Affinity = podaffinity.NewPodAntiAffinity(DeploymentName, l.namespace)
 	}
@@ -532,6 +601,15 @@ func (l *linseed) linseedService() *corev1.Service {
 }
 
 // Allow access to Linseed from components that need it.
+// secondaryElasticsearchEgressRule allows egress to the secondary Elasticsearch cluster, which is outside of the cluster.
+func (l *linseed) secondaryElasticsearchEgressRule() v3.Rule {
+	return v3.Rule{
+		Action:      v3.Allow,
+		Protocol:    &networkpolicy.TCPProtocol,
+		Destination: networkpolicy.AddressEntityRule(l.cfg.SecondaryElasticHost, l.cfg.SecondaryElasticPort),
+	}
+}
+
 func (l *linseed) linseedAllowTigeraPolicy() *v3.NetworkPolicy {
 	// Egress needs to be allowed to:
 	// - Kubernetes API
@@ -551,6 +629,10 @@ func (l *linseed) linseedAllowTigeraPolicy() *v3.NetworkPolicy {
 			Destination: render.ElasticsearchEntityRule,
 		},
 	}...)
+	egressRules = servicemesh.AppendEgressRules(egressRules, l.cfg.Installation.ServiceMesh)
+	if l.cfg.SecondaryElasticsearchSecret != nil {
+		egressRules = append(egressRules, l.secondaryElasticsearchEgressRule())
+	}
 
 	networkpolicyHelper := networkpolicy.Helper(l.cfg.Tenant.MultiTenant(), l.cfg.Namespace)
 
@@ -641,8 +723,38 @@ func (l *linseed) linseedAllowTigeraPolicy() *v3.NetworkPolicy {
 			Source:      networkpolicyHelper.PolicyRecommendationSourceEntityRule(),
 			Destination: linseedIngressDestinationEntityRule,
 		},
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      runtimesecurity.RuntimeSecuritySourceEntityRule,
+			Destination: linseedIngressDestinationEntityRule,
+		},
+	}
+
+	// The workloads that the LogStorage issues Linseed tokens for read the logs from any pod of their namespace.
+	if l.cfg.LogStorage != nil {
+		for _, token := range l.cfg.LogStorage.Spec.LinseedTokens {
+			ingressRules = append(ingressRules, v3.Rule{
+				Action:   v3.Allow,
+				Protocol: &networkpolicy.TCPProtocol,
+				Source: v3.EntityRule{
+					NamespaceSelector: fmt.Sprintf("projectcalico.org/name == '%s'", token.Namespace),
+				},
+				Destination: linseedIngressDestinationEntityRule,
+			})
+		}
 	}
 
+	// The operator collects the ingestion metrics of Linseed from the host network.
+	// Allow all sources, as node CIDRs are not known.
+	ingressRules = append(ingressRules, v3.Rule{
+		Action:   v3.Allow,
+		Protocol: &networkpolicy.TCPProtocol,
+		Destination: v3.EntityRule{
+			Ports: networkpolicy.Ports(MetricsPort),
+		},
+	})
+
 	if l.cfg.HasDPIResource {
 		// DPI needs to access Linseed, however, since the is on the host network
 		// it's hard to create specific network policies for it.
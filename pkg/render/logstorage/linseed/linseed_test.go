@@ -44,7 +44,9 @@ import (
 	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/podaffinity"
+	"github.com/tigera/operator/pkg/render/common/secret"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/logstorage"
 	"github.com/tigera/operator/pkg/render/testutils"
@@ -193,6 +195,131 @@ var _ = Describe("Linseed rendering tests", func() {
 			Expect(s.Data).To(Equal(cfg.ElasticClientSecret.Data))
 		})
 
+		It("should write to the secondary elasticsearch cluster", func() {
+			cfg.SecondaryElasticsearchSecret = &corev1.Secret{
+				TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      logstorage.SecondaryElasticsearchSecret,
+					Namespace: common.OperatorNamespace(),
+				},
+				Data: map[string][]byte{
+					"username": []byte("secondary-username"),
+					"password": []byte("secondary-password"),
+				},
+			}
+			cfg.SecondaryElasticHost = "es.dr.example.com"
+			cfg.SecondaryElasticPort = 9243
+			component := Linseed(cfg)
+			createResources, _ := component.Objects()
+			d, ok := rtest.GetResource(createResources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue(), "Deployment not found")
+
+			Expect(d.Spec.Template.Annotations).To(HaveKey(fmt.Sprintf("hash.operator.tigera.io/%s", logstorage.SecondaryElasticsearchSecret)))
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: "ELASTIC_SECONDARY_ENABLED", Value: "true"},
+				corev1.EnvVar{Name: "ELASTIC_SECONDARY_SCHEME", Value: "https"},
+				corev1.EnvVar{Name: "ELASTIC_SECONDARY_HOST", Value: "es.dr.example.com"},
+				corev1.EnvVar{Name: "ELASTIC_SECONDARY_PORT", Value: "9243"},
+				corev1.EnvVar{Name: "ELASTIC_SECONDARY_USERNAME", ValueFrom: secret.GetEnvVarSource(logstorage.SecondaryElasticsearchSecret, "username", false)},
+				corev1.EnvVar{Name: "ELASTIC_SECONDARY_PASSWORD", ValueFrom: secret.GetEnvVarSource(logstorage.SecondaryElasticsearchSecret, "password", false)},
+				corev1.EnvVar{Name: "ELASTIC_SECONDARY_CA", Value: cfg.TrustedBundle.MountPath()},
+			))
+			for _, v := range d.Spec.Template.Spec.Volumes {
+				Expect(v.Name).NotTo(Equal(logstorage.SecondaryElasticsearchVolumeName))
+			}
+
+			// The secret is copied to Linseed's namespace.
+			s, ok := rtest.GetResource(createResources, logstorage.SecondaryElasticsearchSecret, render.ElasticsearchNamespace, "", "v1", "Secret").(*corev1.Secret)
+			Expect(ok).To(BeTrue(), "Secret not copied")
+			Expect(s.Data).To(Equal(cfg.SecondaryElasticsearchSecret.Data))
+
+			// Egress to the secondary cluster is allowed.
+			policy, ok := rtest.GetResource(createResources, PolicyName, render.ElasticsearchNamespace, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+			Expect(ok).To(BeTrue(), "NetworkPolicy not found")
+			Expect(policy.Spec.Egress).To(ContainElement(v3.Rule{
+				Action:   v3.Allow,
+				Protocol: &networkpolicy.TCPProtocol,
+				Destination: v3.EntityRule{
+					Domains: []string{"es.dr.example.com"},
+					Ports:   networkpolicy.Ports(9243),
+				},
+			}))
+		})
+
+		It("should remove the copy of the secondary elasticsearch credentials when not writing to a secondary cluster", func() {
+			component := Linseed(cfg)
+			createResources, deleteResources := component.Objects()
+			_, ok := rtest.GetResource(createResources, logstorage.SecondaryElasticsearchSecret, render.ElasticsearchNamespace, "", "v1", "Secret").(*corev1.Secret)
+			Expect(ok).To(BeFalse())
+			_, ok = rtest.GetResource(deleteResources, logstorage.SecondaryElasticsearchSecret, render.ElasticsearchNamespace, "", "v1", "Secret").(*corev1.Secret)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should mount the CA certificate of the secondary elasticsearch cluster", func() {
+			cfg.SecondaryElasticsearchSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      logstorage.SecondaryElasticsearchSecret,
+					Namespace: common.OperatorNamespace(),
+				},
+				Data: map[string][]byte{
+					"username": []byte("secondary-username"),
+					"password": []byte("secondary-password"),
+					"ca.crt":   []byte("ca"),
+				},
+			}
+			cfg.SecondaryElasticHost = "10.0.0.10"
+			cfg.SecondaryElasticPort = 9200
+			component := Linseed(cfg)
+			createResources, _ := component.Objects()
+			d, ok := rtest.GetResource(createResources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue(), "Deployment not found")
+
+			Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name: logstorage.SecondaryElasticsearchVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: logstorage.SecondaryElasticsearchSecret,
+						Items:      []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+					},
+				},
+			}))
+			Expect(d.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      logstorage.SecondaryElasticsearchVolumeName,
+				MountPath: "/certs/elasticsearch/secondary",
+				ReadOnly:  true,
+			}))
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+				Name: "ELASTIC_SECONDARY_CA", Value: "/certs/elasticsearch/secondary/ca.crt",
+			}))
+
+			policy, ok := rtest.GetResource(createResources, PolicyName, render.ElasticsearchNamespace, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+			Expect(ok).To(BeTrue(), "NetworkPolicy not found")
+			Expect(policy.Spec.Egress).To(ContainElement(v3.Rule{
+				Action:   v3.Allow,
+				Protocol: &networkpolicy.TCPProtocol,
+				Destination: v3.EntityRule{
+					Nets:  []string{"10.0.0.10/32"},
+					Ports: networkpolicy.Ports(9200),
+				},
+			}))
+		})
+
+		It("should allow ingress from the namespaces of the Linseed tokens", func() {
+			cfg.LogStorage = &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{LinseedTokens: []operatorv1.LinseedToken{
+				{Name: "siem", Namespace: "analytics", Resources: []operatorv1.LinseedResource{operatorv1.LinseedResourceFlows}},
+			}}}
+			component := Linseed(cfg)
+			createResources, _ := component.Objects()
+			policy, ok := rtest.GetResource(createResources, PolicyName, render.ElasticsearchNamespace, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+			Expect(ok).To(BeTrue(), "NetworkPolicy not found")
+			Expect(policy.Spec.Ingress).To(ContainElement(v3.Rule{
+				Action:      v3.Allow,
+				Protocol:    &networkpolicy.TCPProtocol,
+				Source:      v3.EntityRule{NamespaceSelector: "projectcalico.org/name == 'analytics'"},
+				Destination: v3.EntityRule{Ports: networkpolicy.Ports(TargetPort)},
+			}))
+		})
+
 		It("should render SecurityContextConstrains properly when provider is OpenShift", func() {
 			cfg.Installation.KubernetesProvider = operatorv1.ProviderOpenShift
 			component := Linseed(cfg)
@@ -421,6 +548,35 @@ var _ = Describe("Linseed rendering tests", func() {
 			Expect(ok).To(BeTrue(), "Deployment not found")
 			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "LINSEED_FIPS_MODE_ENABLED", Value: "true"}))
 		})
+
+		It("should set the TLS settings from the LogStorage", func() {
+			kp, tokenKP, bundle := getTLS(installation)
+			minVersion := operatorv1.TLSVersion12
+			component := Linseed(&Config{
+				Installation:    installation,
+				KeyPair:         kp,
+				TokenKeyPair:    tokenKP,
+				TrustedBundle:   bundle,
+				ClusterDomain:   clusterDomain,
+				ESClusterConfig: esClusterConfig,
+				Namespace:       render.ElasticsearchNamespace,
+				BindNamespaces:  []string{render.ElasticsearchNamespace},
+				ElasticHost:     "tigera-secure-es-http.tigera-elasticsearch.svc",
+				ElasticPort:     "9200",
+				LogStorage: &operatorv1.LogStorage{Spec: operatorv1.LogStorageSpec{TLS: &operatorv1.LogStorageTLS{
+					MinVersion:   &minVersion,
+					CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				}}},
+			})
+
+			resources, _ := component.Objects()
+			d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue(), "Deployment not found")
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: "LINSEED_TLS_MIN_VERSION", Value: "VersionTLS12"},
+				corev1.EnvVar{Name: "LINSEED_TLS_CIPHER_SUITES", Value: "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			))
+		})
 	})
 
 	Context("multi-tenant rendering", func() {
@@ -1032,6 +1188,14 @@ func expectedContainers() []corev1.Container {
 					Name:  "LINSEED_CA_CERT",
 					Value: "/etc/pki/tls/certs/tigera-ca-bundle.crt",
 				},
+				{
+					Name:  "LINSEED_ENABLE_METRICS",
+					Value: "true",
+				},
+				{
+					Name:  "LINSEED_METRICS_PORT",
+					Value: "9095",
+				},
 				{
 					Name:  "ELASTIC_REPLICAS",
 					Value: "1",
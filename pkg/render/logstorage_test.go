@@ -17,6 +17,7 @@ package render_test
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -43,6 +44,7 @@ import (
 	"github.com/tigera/operator/pkg/dns"
 	"github.com/tigera/operator/pkg/render"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/testutils"
@@ -495,6 +497,52 @@ var _ = Describe("Elasticsearch rendering tests", func() {
 			Expect(nodeSelectors["k2"]).To(Equal("v2"))
 		})
 
+		It("should not schedule Elasticsearch on the system node pools of AKS", func() {
+			cfg.Installation.KubernetesProvider = operatorv1.ProviderAKS
+			component := render.LogStorage(cfg)
+
+			createResources, _ := component.Objects()
+			tolerations := getElasticsearch(createResources).Spec.NodeSets[0].PodTemplate.Spec.Tolerations
+			Expect(tolerations).NotTo(ContainElement(rmeta.TolerateCriticalAddonsOnly))
+		})
+
+		It("should render the remote clusters for cross-cluster search", func() {
+			skipUnavailable := true
+			cfg.LogStorage.Spec.RemoteClusters = []operatorv1.ElasticsearchRemoteCluster{
+				{Name: "east", Seeds: []string{"es-east-0.example.com:9300", "10.0.0.1:9300"}},
+				{Name: "west", ProxyAddress: "es-west.example.com:9400", SkipUnavailable: &skipUnavailable},
+			}
+			cfg.RemoteClusterCA = &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: render.ElasticsearchRemoteClusterCAConfigMap, Namespace: common.OperatorNamespace()},
+				Data:       map[string]string{"ca.crt": "ca"},
+			}
+			component := render.LogStorage(cfg)
+			createResources, _ := component.Objects()
+
+			es := getElasticsearch(createResources)
+			Expect(es.Spec.Transport.TLS.CertificateAuthorities.ConfigMapName).To(Equal(render.ElasticsearchRemoteClusterCAConfigMap))
+			config := es.Spec.NodeSets[0].Config.Data
+			Expect(config).To(HaveKeyWithValue("cluster.remote.east.mode", "sniff"))
+			Expect(config).To(HaveKeyWithValue("cluster.remote.east.seeds", []string{"es-east-0.example.com:9300", "10.0.0.1:9300"}))
+			Expect(config).NotTo(HaveKey("cluster.remote.east.skip_unavailable"))
+			Expect(config).To(HaveKeyWithValue("cluster.remote.west.mode", "proxy"))
+			Expect(config).To(HaveKeyWithValue("cluster.remote.west.proxy_address", "es-west.example.com:9400"))
+			Expect(config).To(HaveKeyWithValue("cluster.remote.west.skip_unavailable", true))
+
+			// The CAs are copied to the Elasticsearch namespace, where ECK reads them.
+			cm, ok := rtest.GetResource(createResources, render.ElasticsearchRemoteClusterCAConfigMap, render.ElasticsearchNamespace, "", "v1", "ConfigMap").(*corev1.ConfigMap)
+			Expect(ok).To(BeTrue(), "ConfigMap not copied")
+			Expect(cm.Data).To(Equal(cfg.RemoteClusterCA.Data))
+
+			policy := rtest.GetResource(createResources, render.ElasticsearchPolicyName, render.ElasticsearchNamespace, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+			Expect(policy.Spec.Egress).To(ContainElements(
+				v3.Rule{Action: v3.Allow, Protocol: &networkpolicy.TCPProtocol, Destination: v3.EntityRule{Domains: []string{"es-east-0.example.com"}, Ports: networkpolicy.Ports(9300)}},
+				v3.Rule{Action: v3.Allow, Protocol: &networkpolicy.TCPProtocol, Destination: v3.EntityRule{Nets: []string{"10.0.0.1/32"}, Ports: networkpolicy.Ports(9300)}},
+				v3.Rule{Action: v3.Allow, Protocol: &networkpolicy.TCPProtocol, Destination: v3.EntityRule{Domains: []string{"es-west.example.com"}, Ports: networkpolicy.Ports(9400)}},
+			))
+		})
+
 		It("should render SecurityContextConstrains properly when provider is OpenShift", func() {
 			cfg.Installation.KubernetesProvider = operatorv1.ProviderOpenShift
 			component := render.LogStorage(cfg)
@@ -1022,6 +1070,30 @@ var _ = Describe("Elasticsearch rendering tests", func() {
 				})
 			})
 
+			When("a NodeSet has a storage class of its own", func() {
+				It("provisions the disks of that NodeSet with its storage class", func() {
+					cfg.LogStorage.Spec.StorageClassName = "tigera-elasticsearch"
+					cfg.LogStorage.Spec.Nodes = &operatorv1.Nodes{
+						Count:    2,
+						NodeSets: []operatorv1.NodeSet{{StorageClassName: "fast-ssd"}, {}},
+					}
+
+					createResources, _ := render.LogStorage(cfg).Objects()
+					nodeSets := getElasticsearch(createResources).Spec.NodeSets
+
+					Expect(nodeSets).To(HaveLen(2))
+					Expect(*nodeSets[0].VolumeClaimTemplates[0].Spec.StorageClassName).To(Equal("fast-ssd"))
+					Expect(*nodeSets[1].VolumeClaimTemplates[0].Spec.StorageClassName).To(Equal("tigera-elasticsearch"))
+
+					By("naming the NodeSets after their own PVC template")
+					Expect(strings.TrimSuffix(nodeSets[0].Name, "-0")).NotTo(Equal(strings.TrimSuffix(nodeSets[1].Name, "-1")))
+
+					cfg.LogStorage.Spec.Nodes.NodeSets[0].StorageClassName = ""
+					createResources, _ = render.LogStorage(cfg).Objects()
+					Expect(getElasticsearch(createResources).Spec.NodeSets[1].Name).To(Equal(nodeSets[1].Name))
+				})
+			})
+
 			When("there is a single selection attribute for a NodeSet", func() {
 				It("sets the Node Affinity Elasticsearch cluster awareness attributes with the single selection attribute", func() {
 					cfg.LogStorage.Spec.Nodes = &operatorv1.Nodes{
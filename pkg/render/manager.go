@@ -414,7 +414,7 @@ func (c *managerComponent) managerProxyProbe() *corev1.Probe {
 }
 
 func KibanaEnabled(tenant *operatorv1.Tenant, installation *operatorv1.InstallationSpec) bool {
-	enableKibana := !operatorv1.IsFIPSModeEnabled(installation.FIPSMode)
+	enableKibana := !operatorv1.IsFIPSModeEnabled(installation.FIPSMode) && !installation.Features.KibanaDisabled()
 	if tenant.MultiTenant() {
 		enableKibana = false
 	}
@@ -645,7 +645,7 @@ func (c *managerComponent) managerEsProxyContainer() corev1.Container {
 
 // managerTolerations returns the tolerations for the Tigera Secure manager deployment pods.
 func (c *managerComponent) managerTolerations() []corev1.Toleration {
-	return append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateCriticalAddonsAndControlPlane...)
+	return rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.Workload{CriticalAddon: true}, rmeta.TolerateCriticalAddonsAndControlPlane...)
 }
 
 // managerService returns the service exposing the Tigera Secure web app.
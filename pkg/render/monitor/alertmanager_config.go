@@ -0,0 +1,221 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+)
+
+// AlertmanagerConfigKey is the key of the Alertmanager configuration secret that holds the configuration file.
+const AlertmanagerConfigKey = "alertmanager.yaml"
+
+// The types below mirror the parts of the Alertmanager configuration file that can be declared in the Monitor.
+type alertmanagerConfigFile struct {
+	Global    map[string]string      `json:"global,omitempty"`
+	Route     alertmanagerRoute      `json:"route"`
+	Receivers []alertmanagerReceiver `json:"receivers"`
+}
+
+type alertmanagerRoute struct {
+	Receiver       string              `json:"receiver"`
+	GroupBy        []string            `json:"group_by,omitempty"`
+	GroupWait      string              `json:"group_wait,omitempty"`
+	GroupInterval  string              `json:"group_interval,omitempty"`
+	RepeatInterval string              `json:"repeat_interval,omitempty"`
+	Matchers       []string            `json:"matchers,omitempty"`
+	Continue       bool                `json:"continue,omitempty"`
+	Routes         []alertmanagerRoute `json:"routes,omitempty"`
+}
+
+type alertmanagerReceiver struct {
+	Name             string                   `json:"name"`
+	SlackConfigs     []map[string]interface{} `json:"slack_configs,omitempty"`
+	PagerdutyConfigs []map[string]interface{} `json:"pagerduty_configs,omitempty"`
+	WebhookConfigs   []map[string]interface{} `json:"webhook_configs,omitempty"`
+	EmailConfigs     []map[string]interface{} `json:"email_configs,omitempty"`
+}
+
+// AlertmanagerConfigSecretNames returns the names of the secrets that the receivers of the configuration refer to.
+func AlertmanagerConfigSecretNames(cfg *operatorv1.AlertmanagerConfiguration) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(selector *corev1.SecretKeySelector) {
+		if selector != nil && !seen[selector.Name] {
+			seen[selector.Name] = true
+			names = append(names, selector.Name)
+		}
+	}
+	for _, r := range cfg.Receivers {
+		for i := range r.SlackConfigs {
+			add(&r.SlackConfigs[i].APIURL)
+		}
+		for _, c := range r.PagerDutyConfigs {
+			add(c.RoutingKey)
+			add(c.ServiceKey)
+		}
+		for _, c := range r.WebhookConfigs {
+			add(c.URLSecret)
+		}
+		for _, c := range r.EmailConfigs {
+			add(c.AuthPassword)
+		}
+	}
+	return names
+}
+
+// AlertmanagerConfigurationSecret renders the Alertmanager configuration secret in the tigera-prometheus namespace from
+// the routes and receivers of the Monitor. It is not written to the tigera-operator namespace, where it would replace a
+// secret provided by the user. The values of the secret keys that the receivers refer to are inlined, so secrets
+// must contain all of the secrets returned by AlertmanagerConfigSecretNames, keyed by name.
+func AlertmanagerConfigurationSecret(cfg *operatorv1.AlertmanagerConfiguration, secrets map[string]*corev1.Secret) (*corev1.Secret, error) {
+	value := func(selector *corev1.SecretKeySelector) (string, error) {
+		s, ok := secrets[selector.Name]
+		if !ok {
+			return "", fmt.Errorf("secret %s/%s not found", common.OperatorNamespace(), selector.Name)
+		}
+		v, ok := s.Data[selector.Key]
+		if !ok {
+			return "", fmt.Errorf("secret %s/%s does not contain key %s", common.OperatorNamespace(), selector.Name, selector.Key)
+		}
+		return string(v), nil
+	}
+
+	file := alertmanagerConfigFile{
+		Global: map[string]string{"resolve_timeout": "5m"},
+		Route: alertmanagerRoute{
+			Receiver:       cfg.Route.Receiver,
+			GroupBy:        cfg.Route.GroupBy,
+			GroupWait:      cfg.Route.GroupWait,
+			GroupInterval:  cfg.Route.GroupInterval,
+			RepeatInterval: cfg.Route.RepeatInterval,
+		},
+	}
+	for _, r := range cfg.Route.Routes {
+		file.Route.Routes = append(file.Route.Routes, alertmanagerRoute{
+			Receiver:       r.Receiver,
+			GroupBy:        r.GroupBy,
+			GroupWait:      r.GroupWait,
+			GroupInterval:  r.GroupInterval,
+			RepeatInterval: r.RepeatInterval,
+			Matchers:       r.Matchers,
+			Continue:       r.Continue,
+		})
+	}
+
+	for _, r := range cfg.Receivers {
+		receiver := alertmanagerReceiver{Name: r.Name}
+		for _, c := range r.SlackConfigs {
+			url, err := value(&c.APIURL)
+			if err != nil {
+				return nil, err
+			}
+			slack := map[string]interface{}{"api_url": url}
+			if c.Channel != "" {
+				slack["channel"] = c.Channel
+			}
+			if c.SendResolved != nil {
+				slack["send_resolved"] = *c.SendResolved
+			}
+			receiver.SlackConfigs = append(receiver.SlackConfigs, slack)
+		}
+		for _, c := range r.PagerDutyConfigs {
+			pagerduty := map[string]interface{}{}
+			if c.RoutingKey != nil {
+				key, err := value(c.RoutingKey)
+				if err != nil {
+					return nil, err
+				}
+				pagerduty["routing_key"] = key
+			}
+			if c.ServiceKey != nil {
+				key, err := value(c.ServiceKey)
+				if err != nil {
+					return nil, err
+				}
+				pagerduty["service_key"] = key
+			}
+			if c.SendResolved != nil {
+				pagerduty["send_resolved"] = *c.SendResolved
+			}
+			receiver.PagerdutyConfigs = append(receiver.PagerdutyConfigs, pagerduty)
+		}
+		for _, c := range r.WebhookConfigs {
+			webhook := map[string]interface{}{"url": c.URL}
+			if c.URLSecret != nil {
+				url, err := value(c.URLSecret)
+				if err != nil {
+					return nil, err
+				}
+				webhook["url"] = url
+			}
+			if c.MaxAlerts != 0 {
+				webhook["max_alerts"] = c.MaxAlerts
+			}
+			if c.SendResolved != nil {
+				webhook["send_resolved"] = *c.SendResolved
+			}
+			receiver.WebhookConfigs = append(receiver.WebhookConfigs, webhook)
+		}
+		for _, c := range r.EmailConfigs {
+			email := map[string]interface{}{
+				"to":        c.To,
+				"from":      c.From,
+				"smarthost": c.Smarthost,
+			}
+			if c.AuthUsername != "" {
+				email["auth_username"] = c.AuthUsername
+			}
+			if c.AuthPassword != nil {
+				password, err := value(c.AuthPassword)
+				if err != nil {
+					return nil, err
+				}
+				email["auth_password"] = password
+			}
+			if c.RequireTLS != nil {
+				email["require_tls"] = *c.RequireTLS
+			}
+			if c.SendResolved != nil {
+				email["send_resolved"] = *c.SendResolved
+			}
+			receiver.EmailConfigs = append(receiver.EmailConfigs, email)
+		}
+		file.Receivers = append(file.Receivers, receiver)
+	}
+
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        AlertmanagerConfigSecret,
+			Namespace:   common.TigeraPrometheusNamespace,
+			Annotations: map[string]string{AlertmanagerGeneratedConfigAnnotation: "true"},
+		},
+		Data: map[string][]byte{
+			AlertmanagerConfigKey: data,
+		},
+	}, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/ptr"
+	"github.com/tigera/operator/pkg/render/monitor"
+)
+
+var _ = Describe("Alertmanager configuration rendering tests", func() {
+	var configuration *operatorv1.AlertmanagerConfiguration
+	var secrets map[string]*corev1.Secret
+
+	BeforeEach(func() {
+		configuration = &operatorv1.AlertmanagerConfiguration{
+			Route: operatorv1.AlertmanagerRoute{
+				AlertmanagerRouteOptions: operatorv1.AlertmanagerRouteOptions{
+					Receiver:  "slack",
+					GroupBy:   []string{"alertname"},
+					GroupWait: "30s",
+				},
+				Routes: []operatorv1.AlertmanagerChildRoute{{
+					AlertmanagerRouteOptions: operatorv1.AlertmanagerRouteOptions{Receiver: "pagerduty"},
+					Matchers:                 []string{`severity="critical"`},
+					Continue:                 true,
+				}},
+			},
+			Receivers: []operatorv1.AlertmanagerReceiver{
+				{
+					Name: "slack",
+					SlackConfigs: []operatorv1.AlertmanagerSlackConfig{{
+						APIURL:       corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "alerting"}, Key: "slack"},
+						Channel:      "#alerts",
+						SendResolved: ptr.BoolToPtr(true),
+					}},
+				},
+				{
+					Name: "pagerduty",
+					PagerDutyConfigs: []operatorv1.AlertmanagerPagerDutyConfig{{
+						RoutingKey: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "alerting"}, Key: "pagerduty"},
+					}},
+					WebhookConfigs: []operatorv1.AlertmanagerWebhookConfig{{URL: "http://example.com/hook", MaxAlerts: 10}},
+				},
+			},
+		}
+		secrets = map[string]*corev1.Secret{
+			"alerting": {
+				ObjectMeta: metav1.ObjectMeta{Name: "alerting", Namespace: common.OperatorNamespace()},
+				Data: map[string][]byte{
+					"slack":     []byte("https://hooks.slack.com/services/abc"),
+					"pagerduty": []byte("routing-key"),
+				},
+			},
+		}
+	})
+
+	It("should return the names of the referenced secrets once", func() {
+		Expect(monitor.AlertmanagerConfigSecretNames(configuration)).To(Equal([]string{"alerting"}))
+	})
+
+	It("should render the routes and receivers with the secret values inlined", func() {
+		secret, err := monitor.AlertmanagerConfigurationSecret(configuration, secrets)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret.Name).To(Equal(monitor.AlertmanagerConfigSecret))
+		Expect(secret.Namespace).To(Equal(common.TigeraPrometheusNamespace))
+		Expect(secret.Annotations).To(HaveKeyWithValue(monitor.AlertmanagerGeneratedConfigAnnotation, "true"))
+		Expect(string(secret.Data[monitor.AlertmanagerConfigKey])).To(MatchYAML(`
+global:
+  resolve_timeout: 5m
+route:
+  receiver: slack
+  group_by: [alertname]
+  group_wait: 30s
+  routes:
+  - receiver: pagerduty
+    matchers: ['severity="critical"']
+    continue: true
+receivers:
+- name: slack
+  slack_configs:
+  - api_url: https://hooks.slack.com/services/abc
+    channel: '#alerts'
+    send_resolved: true
+- name: pagerduty
+  pagerduty_configs:
+  - routing_key: routing-key
+  webhook_configs:
+  - url: http://example.com/hook
+    max_alerts: 10
+`))
+	})
+
+	It("should return an error when a referenced secret key does not exist", func() {
+		delete(secrets["alerting"].Data, "pagerduty")
+		_, err := monitor.AlertmanagerConfigurationSecret(configuration, secrets)
+		Expect(err).To(HaveOccurred())
+	})
+})
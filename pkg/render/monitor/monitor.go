@@ -16,8 +16,11 @@ package monitor
 
 import (
 	"crypto/x509"
-	_ "embed"
+	"embed"
 	"fmt"
+	"net"
+	"net/url"
+	"path"
 	"strings"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -28,9 +31,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	"github.com/tigera/api/pkg/lib/numorstring"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
@@ -81,6 +87,10 @@ const (
 	AlertmanagerPort           = 9093
 	MeshAlertManagerPolicyName = AlertManagerPolicyName + "-mesh"
 
+	// AlertmanagerGeneratedConfigAnnotation is set on the Alertmanager configuration secret when it is generated from the
+	// configuration of the Monitor, rather than copied from the tigera-operator namespace.
+	AlertmanagerGeneratedConfigAnnotation = "operator.tigera.io/generated-alertmanager-config"
+
 	ElasticsearchMetrics = "elasticsearch-metrics"
 	FluentdMetrics       = "fluentd-metrics"
 
@@ -89,8 +99,23 @@ const (
 
 	bearerTokenFile       = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 	KubeControllerMetrics = "calico-kube-controllers-metrics"
+
+	// GrafanaDashboardPrefix is the name prefix of the ConfigMaps that hold the Grafana dashboards.
+	GrafanaDashboardPrefix = "tigera-grafana-dashboard-"
+
+	// GrafanaDashboardLabel is set on the Grafana dashboard ConfigMaps, so that the ones left in a namespace that is no
+	// longer configured can be found and deleted.
+	GrafanaDashboardLabel = "operator.tigera.io/grafana-dashboard"
 )
 
+// grafanaDashboards holds the Grafana dashboard definitions, one JSON document per dashboard.
+//
+//go:embed dashboards/*.json
+var grafanaDashboards embed.FS
+
+// defaultGrafanaDashboardLabels is the label that the Grafana dashboard sidecar watches for by default.
+var defaultGrafanaDashboardLabels = map[string]string{"grafana_dashboard": "1"}
+
 var alertManagerSelector = fmt.Sprintf(
 	"(app == 'alertmanager' && alertmanager == '%[1]s') || (app.kubernetes.io/name == 'alertmanager' && alertmanager == '%[1]s')",
 	CalicoNodeAlertmanager,
@@ -124,6 +149,8 @@ type Config struct {
 	Installation             *operatorv1.InstallationSpec
 	PullSecrets              []*corev1.Secret
 	AlertmanagerConfigSecret *corev1.Secret
+	RemoteWriteSecrets       []*corev1.Secret
+	ThanosSecret             *corev1.Secret
 	KeyValidatorConfig       authentication.KeyValidatorConfig
 	ServerTLSSecret          certificatemanagement.KeyPairInterface
 	ClientTLSSecret          certificatemanagement.KeyPairInterface
@@ -131,6 +158,14 @@ type Config struct {
 	TrustedCertBundle        certificatemanagement.TrustedBundle
 	OpenShift                bool
 	KubeControllerPort       int
+
+	// GrafanaDashboardNamespaces are the namespaces that hold Grafana dashboard ConfigMaps rendered before. The
+	// dashboards are deleted from the ones that are no longer configured.
+	GrafanaDashboardNamespaces []string
+
+	// ElasticsearchMetricsCollection limits the metrics of es-metrics that Prometheus keeps. It is taken from the
+	// LogStorage, if any.
+	ElasticsearchMetricsCollection *operatorv1.ElasticsearchMetricsCollection
 }
 
 type monitorComponent struct {
@@ -138,6 +173,7 @@ type monitorComponent struct {
 	alertmanagerImage      string
 	prometheusImage        string
 	prometheusServiceImage string
+	thanosImage            string
 }
 
 func (mc *monitorComponent) ResolveImages(is *operatorv1.ImageSet) error {
@@ -163,6 +199,13 @@ func (mc *monitorComponent) ResolveImages(is *operatorv1.ImageSet) error {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
+	if mc.cfg.Monitor.Prometheus.GetThanos() != nil {
+		mc.thanosImage, err = components.GetReference(components.ComponentThanos, reg, path, prefix, is)
+		if err != nil {
+			errMsgs = append(errMsgs, err.Error())
+		}
+	}
+
 	if len(errMsgs) != 0 {
 		return fmt.Errorf(strings.Join(errMsgs, ","))
 	}
@@ -173,6 +216,12 @@ func (mc *monitorComponent) SupportedOSType() rmeta.OSType {
 	return rmeta.OSTypeLinux
 }
 
+// MissingAPIPolicy fails the component when the cluster doesn't serve the monitoring.coreos.com APIs, rather than
+// rendering it without the Prometheus and Alertmanager that it is made of.
+func (mc *monitorComponent) MissingAPIPolicy() render.MissingAPIPolicy {
+	return render.MissingAPIFail
+}
+
 func (mc *monitorComponent) Objects() ([]client.Object, []client.Object) {
 	toCreate := []client.Object{
 		// We create the namespace with "privileged" security context because the containers deployed by the prometheus operator
@@ -192,7 +241,21 @@ func (mc *monitorComponent) Objects() ([]client.Object, []client.Object) {
 	)
 
 	toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(common.TigeraPrometheusNamespace, mc.cfg.PullSecrets...)...)...)
-	toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(common.TigeraPrometheusNamespace, mc.cfg.AlertmanagerConfigSecret)...)...)
+	if mc.cfg.AlertmanagerConfigSecret.Namespace == common.TigeraPrometheusNamespace {
+		// A configuration generated from the Monitor is only rendered in the tigera-prometheus namespace.
+		toCreate = append(toCreate, mc.cfg.AlertmanagerConfigSecret)
+	} else {
+		toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(common.TigeraPrometheusNamespace, mc.cfg.AlertmanagerConfigSecret)...)...)
+	}
+
+	var toDelete []client.Object
+	inClusterPrometheus := mc.cfg.Monitor.Prometheus.InClusterPrometheusEnabled()
+	if inClusterPrometheus {
+		toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(common.TigeraPrometheusNamespace, mc.cfg.RemoteWriteSecrets...)...)...)
+		if mc.cfg.ThanosSecret != nil {
+			toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(common.TigeraPrometheusNamespace, mc.cfg.ThanosSecret)...)...)
+		}
+	}
 
 	toCreate = append(toCreate,
 		mc.prometheusOperatorServiceAccount(),
@@ -201,19 +264,44 @@ func (mc *monitorComponent) Objects() ([]client.Object, []client.Object) {
 		mc.prometheusServiceAccount(),
 		mc.prometheusClusterRole(),
 		mc.prometheusClusterRoleBinding(),
-		mc.prometheus(),
+	)
+	if inClusterPrometheus {
+		toCreate = append(toCreate, mc.prometheus())
+	}
+	toCreate = append(toCreate,
 		mc.alertmanagerService(),
 		mc.alertmanager(),
-		mc.prometheusServiceService(),
+	)
+	if inClusterPrometheus {
+		toCreate = append(toCreate, mc.prometheusServiceService())
+	}
+	toCreate = append(toCreate,
 		mc.prometheusServiceClusterRole(),
 		mc.prometheusServiceClusterRoleBinding(),
+	)
+
+	monitoringObjects := []client.Object{
 		mc.prometheusRule(),
 		mc.serviceMonitorCalicoNode(),
 		mc.serviceMonitorElasticsearch(),
 		mc.serviceMonitorFluentd(),
 		mc.serviceMonitorQueryServer(),
 		mc.serviceMonitorCalicoKubeControllers(),
-	)
+	}
+	if inClusterPrometheus {
+		toCreate = append(toCreate, monitoringObjects...)
+	} else {
+		// The ServiceMonitors and PrometheusRule are rendered in the namespace of the external Prometheus instead.
+		toDelete = append(toDelete, mc.prometheus(), mc.prometheusServiceService())
+		toDelete = append(toDelete, monitoringObjects...)
+	}
+
+	dashboardNamespace, dashboardLabels := mc.grafanaDashboardsConfig()
+	toCreate = append(toCreate, grafanaDashboardConfigMaps(dashboardNamespace, dashboardLabels)...)
+	staleDashboardNamespaces := append([]string{common.TigeraPrometheusNamespace}, mc.cfg.GrafanaDashboardNamespaces...)
+	for _, ns := range sets.List(sets.New(staleDashboardNamespaces...).Delete(dashboardNamespace)) {
+		toDelete = append(toDelete, grafanaDashboardConfigMaps(ns, nil)...)
+	}
 
 	if mc.cfg.KeyValidatorConfig != nil {
 		toCreate = append(toCreate, secret.ToRuntimeObjects(mc.cfg.KeyValidatorConfig.RequiredSecrets(common.TigeraPrometheusNamespace)...)...)
@@ -221,18 +309,49 @@ func (mc *monitorComponent) Objects() ([]client.Object, []client.Object) {
 	}
 
 	if mc.cfg.Monitor.ExternalPrometheus != nil {
-		toCreate = append(toCreate, mc.externalConfigMap())
-		if mc.cfg.Monitor.ExternalPrometheus.ServiceMonitor != nil {
-			externalServiceMonitor, needsRBAC := mc.externalServiceMonitor()
-			toCreate = append(toCreate, externalServiceMonitor)
-			if needsRBAC {
-				toCreate = append(toCreate, mc.externalPrometheusRole(), mc.externalPrometheusRoleBinding(), mc.externalServiceAccount(), mc.externalPrometheusTokenSecret())
+		if inClusterPrometheus {
+			toCreate = append(toCreate, mc.externalConfigMap())
+			if mc.cfg.Monitor.ExternalPrometheus.ServiceMonitor != nil {
+				externalServiceMonitor, needsRBAC := mc.externalServiceMonitor()
+				toCreate = append(toCreate, externalServiceMonitor)
+				if needsRBAC {
+					toCreate = append(toCreate, mc.externalPrometheusRole(), mc.externalPrometheusRoleBinding(), mc.externalServiceAccount(), mc.externalPrometheusTokenSecret())
+				}
+			}
+		} else {
+			// Without an in-cluster Prometheus there is nothing to federate, so the external Prometheus scrapes the
+			// Calico Enterprise components directly.
+			toCreate = append(toCreate,
+				mc.externalPrometheusRole(),
+				mc.externalPrometheusRoleBinding(),
+				mc.externalServiceAccount(),
+				mc.externalPrometheusTokenSecret(),
+				mc.cfg.TrustedCertBundle.ConfigMap(mc.cfg.Monitor.ExternalPrometheus.Namespace),
+			)
+			// The client key is only copied when asked for, since it grants access to the metrics of the components.
+			if mc.cfg.Monitor.ExternalPrometheus.ClientKeyExportEnabled() {
+				toCreate = append(toCreate, mc.cfg.ClientTLSSecret.Secret(mc.cfg.Monitor.ExternalPrometheus.Namespace))
+			} else {
+				toDelete = append(toDelete, mc.cfg.ClientTLSSecret.Secret(mc.cfg.Monitor.ExternalPrometheus.Namespace))
 			}
+			toCreate = append(toCreate, mc.externalMonitoringObjects(monitoringObjects)...)
+			if mc.cfg.Installation.TyphaMetricsPort != nil {
+				toCreate = append(toCreate, mc.externalMonitoringObjects([]client.Object{mc.typhaServiceMonitor()})...)
+			}
+			toDelete = append(toDelete,
+				&corev1.ConfigMap{
+					TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{Name: TigeraExternalPrometheus, Namespace: mc.cfg.Monitor.ExternalPrometheus.Namespace},
+				},
+				&monitoringv1.ServiceMonitor{
+					TypeMeta:   metav1.TypeMeta{Kind: monitoringv1.ServiceMonitorsKind, APIVersion: MonitoringAPIVersion},
+					ObjectMeta: metav1.ObjectMeta{Name: TigeraExternalPrometheus, Namespace: mc.cfg.Monitor.ExternalPrometheus.Namespace},
+				},
+			)
 		}
 	}
 
-	var toDelete []client.Object
-	if mc.cfg.Installation.TyphaMetricsPort != nil {
+	if mc.cfg.Installation.TyphaMetricsPort != nil && inClusterPrometheus {
 		toCreate = append(toCreate, mc.typhaServiceMonitor())
 	} else {
 		toDelete = append(toDelete, mc.typhaServiceMonitor())
@@ -425,7 +544,7 @@ func (mc *monitorComponent) alertmanager() *monitoringv1.Alertmanager {
 			Replicas:           mc.cfg.Installation.ControlPlaneReplicas,
 			SecurityContext:    securitycontext.NewNonRootPodContext(),
 			ServiceAccountName: PrometheusServiceAccountName,
-			Tolerations:        mc.cfg.Installation.ControlPlaneTolerations,
+			Tolerations:        rmeta.ControlPlaneTolerations(mc.cfg.Installation, rmeta.Workload{}),
 			Version:            components.ComponentCoreOSAlertmanager.Version,
 			Resources:          resources,
 		},
@@ -576,7 +695,7 @@ func (mc *monitorComponent) prometheus() *monitoringv1.Prometheus {
 				SecurityContext:        securitycontext.NewNonRootPodContext(),
 				ServiceAccountName:     PrometheusServiceAccountName,
 				ServiceMonitorSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "network-operators"}},
-				Tolerations:            mc.cfg.Installation.ControlPlaneTolerations,
+				Tolerations:            rmeta.ControlPlaneTolerations(mc.cfg.Installation, rmeta.Workload{}),
 				Version:                components.ComponentCoreOSPrometheus.Version,
 				VolumeMounts:           volumeMounts,
 				Volumes:                volumes,
@@ -599,6 +718,25 @@ func (mc *monitorComponent) prometheus() *monitoringv1.Prometheus {
 		},
 	}
 
+	for _, rw := range mc.cfg.Monitor.Prometheus.GetRemoteWrite() {
+		prometheus.Spec.RemoteWrite = append(prometheus.Spec.RemoteWrite, remoteWriteSpec(rw))
+	}
+
+	if retention := mc.cfg.Monitor.Prometheus.GetRetention(); retention != "" {
+		prometheus.Spec.Retention = monitoringv1.Duration(retention)
+	}
+
+	if thanos := mc.cfg.Monitor.Prometheus.GetThanos(); thanos != nil {
+		// The object storage secret is copied to the tigera-prometheus namespace under the same name.
+		prometheus.Spec.Thanos = &monitoringv1.ThanosSpec{
+			Image:               &mc.thanosImage,
+			ObjectStorageConfig: thanos.ObjectStorageConfig.DeepCopy(),
+		}
+		if thanos.Resources != nil {
+			prometheus.Spec.Thanos.Resources = *thanos.Resources
+		}
+	}
+
 	if overrides := mc.cfg.Monitor.Prometheus; overrides != nil {
 		rcomponents.ApplyPrometheusOverrides(prometheus, overrides)
 	}
@@ -606,6 +744,45 @@ func (mc *monitorComponent) prometheus() *monitoringv1.Prometheus {
 	return prometheus
 }
 
+// remoteWriteSpec converts a remote write endpoint of the Monitor into its prometheus-operator equivalent. The referenced
+// secrets are copied to the tigera-prometheus namespace under the same name.
+func remoteWriteSpec(rw operatorv1.RemoteWriteSpec) monitoringv1.RemoteWriteSpec {
+	spec := monitoringv1.RemoteWriteSpec{
+		Name: rw.Name,
+		URL:  rw.URL,
+	}
+	if rw.BearerTokenSecret != nil {
+		spec.Authorization = &monitoringv1.Authorization{
+			SafeAuthorization: monitoringv1.SafeAuthorization{
+				Type:        "Bearer",
+				Credentials: rw.BearerTokenSecret,
+			},
+		}
+	}
+	if rw.BasicAuth != nil {
+		spec.BasicAuth = &monitoringv1.BasicAuth{
+			Username: rw.BasicAuth.Username,
+			Password: rw.BasicAuth.Password,
+		}
+	}
+	if tls := rw.TLS; tls != nil {
+		spec.TLSConfig = &monitoringv1.TLSConfig{
+			SafeTLSConfig: monitoringv1.SafeTLSConfig{
+				KeySecret:          tls.KeySecret,
+				ServerName:         tls.ServerName,
+				InsecureSkipVerify: tls.InsecureSkipVerify,
+			},
+		}
+		if tls.CASecret != nil {
+			spec.TLSConfig.CA = monitoringv1.SecretOrConfigMap{Secret: tls.CASecret}
+		}
+		if tls.CertSecret != nil {
+			spec.TLSConfig.Cert = monitoringv1.SecretOrConfigMap{Secret: tls.CertSecret}
+		}
+	}
+	return spec
+}
+
 func (mc *monitorComponent) prometheusServiceAccount() *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		TypeMeta: metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
@@ -871,12 +1048,41 @@ func (mc *monitorComponent) serviceMonitorElasticsearch() *monitoringv1.ServiceM
 					ScrapeTimeout: "5s",
 					Scheme:        "https",
 					TLSConfig:     mc.tlsConfig(esmetrics.ElasticsearchMetricsName),
+
+					MetricRelabelConfigs: mc.elasticsearchMetricRelabelings(),
 				},
 			},
 		},
 	}
 }
 
+// elasticsearchMetricRelabelings returns the relabelings that Prometheus applies to the metrics of es-metrics: the
+// per-index metrics of the indices outside the index allowlist are dropped, then the relabelings of the LogStorage are
+// applied.
+func (mc *monitorComponent) elasticsearchMetricRelabelings() []*monitoringv1.RelabelConfig {
+	collection := mc.cfg.ElasticsearchMetricsCollection
+	if collection == nil {
+		return nil
+	}
+	var relabelings []*monitoringv1.RelabelConfig
+	if len(collection.IndexAllowlist) != 0 {
+		// The metrics without an index label match the empty alternative, so that only per-index metrics are dropped.
+		alternatives := []string{""}
+		for _, pattern := range collection.IndexAllowlist {
+			alternatives = append(alternatives, fmt.Sprintf("(?:%s)", pattern))
+		}
+		relabelings = append(relabelings, &monitoringv1.RelabelConfig{
+			SourceLabels: []monitoringv1.LabelName{"index"},
+			Regex:        strings.Join(alternatives, "|"),
+			Action:       "keep",
+		})
+	}
+	for _, relabeling := range collection.MetricRelabelings {
+		relabelings = append(relabelings, relabeling.DeepCopy())
+	}
+	return relabelings
+}
+
 // serviceMonitorFluentd creates a service monitor to make Prometheus watch Fluentd. Previously, a pod monitor was used.
 // However, the pod monitor does not have all the tls configuration options that we need, namely reading them from the
 // file system, as opposed to getting them from watching kubernetes secrets.
@@ -1150,6 +1356,15 @@ func allowTigeraPrometheusPolicy(cfg *Config) *v3.NetworkPolicy {
 		})
 	}
 
+	for _, rw := range cfg.Monitor.Prometheus.GetRemoteWrite() {
+		if rule := remoteWriteEgressRule(rw); rule != nil {
+			egressRules = append(egressRules, *rule)
+		}
+	}
+	if thanos := cfg.Monitor.Prometheus.GetThanos(); thanos != nil {
+		egressRules = append(egressRules, thanosEgressRule(thanos, cfg.ThanosSecret))
+	}
+
 	typhaMetricsPort := cfg.Installation.TyphaMetricsPort
 	if typhaMetricsPort != nil {
 		egressRules = append(egressRules, v3.Rule{
@@ -1189,6 +1404,89 @@ func allowTigeraPrometheusPolicy(cfg *Config) *v3.NetworkPolicy {
 }
 
 // Creates a network policy to allow traffic to access through tigera-prometheus-api
+// remoteWriteEgressRule returns the rule that allows Prometheus to reach a remote write endpoint, or nil if the URL of
+// the endpoint cannot be parsed.
+func remoteWriteEgressRule(rw operatorv1.RemoteWriteSpec) *v3.Rule {
+	u, err := url.Parse(rw.URL)
+	if err != nil || u.Hostname() == "" {
+		return nil
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	parsedPort, err := numorstring.PortFromString(port)
+	if err != nil {
+		return nil
+	}
+	rule := endpointEgressRule(u.Hostname(), parsedPort)
+	return &rule
+}
+
+// thanosEgressRule returns the rule that allows the Thanos sidecar to reach the object storage. Only the endpoint of
+// an S3 compatible storage is part of the configuration; the other providers are reached on their default HTTPS port.
+func thanosEgressRule(thanos *operatorv1.Thanos, objstore *corev1.Secret) v3.Rule {
+	httpsRule := v3.Rule{
+		Action:      v3.Allow,
+		Protocol:    &networkpolicy.TCPProtocol,
+		Destination: v3.EntityRule{Ports: networkpolicy.Ports(443)},
+	}
+	if objstore == nil {
+		return httpsRule
+	}
+
+	var config struct {
+		Type   string `json:"type"`
+		Config struct {
+			Endpoint string `json:"endpoint"`
+			Insecure bool   `json:"insecure"`
+		} `json:"config"`
+	}
+	if err := yaml.Unmarshal(objstore.Data[thanos.ObjectStorageConfig.Key], &config); err != nil {
+		return httpsRule
+	}
+	if !strings.EqualFold(config.Type, "S3") || config.Config.Endpoint == "" {
+		return httpsRule
+	}
+
+	host, port, err := net.SplitHostPort(config.Config.Endpoint)
+	if err != nil {
+		// The endpoint has no port, so the client uses the default port of the scheme.
+		host, port = config.Config.Endpoint, "443"
+		if config.Config.Insecure {
+			port = "80"
+		}
+	}
+	parsedPort, err := numorstring.PortFromString(port)
+	if err != nil {
+		return httpsRule
+	}
+	return endpointEgressRule(host, parsedPort)
+}
+
+// endpointEgressRule returns the rule that allows egress to the given host, which is either an IP address or a domain
+// name, on the given port.
+func endpointEgressRule(host string, port numorstring.Port) v3.Rule {
+	destination := v3.EntityRule{Ports: []numorstring.Port{port}}
+	if ip := net.ParseIP(host); ip != nil {
+		netSuffix := "/32"
+		if ip.To4() == nil {
+			netSuffix = "/128"
+		}
+		destination.Nets = []string{ip.String() + netSuffix}
+	} else {
+		destination.Domains = []string{host}
+	}
+	return v3.Rule{
+		Action:      v3.Allow,
+		Protocol:    &networkpolicy.TCPProtocol,
+		Destination: destination,
+	}
+}
+
 func allowTigeraPrometheusAPIPolicy(cfg *Config) *v3.NetworkPolicy {
 	egressRules := []v3.Rule{}
 	egressRules = networkpolicy.AppendDNSEgressRules(egressRules, cfg.OpenShift)
@@ -1355,6 +1653,121 @@ func (mc *monitorComponent) externalServiceAccount() client.Object {
 	}
 }
 
+// externalMonitoringObjects returns copies of the ServiceMonitors and PrometheusRule for Calico Enterprise that target
+// the external Prometheus. The in-cluster objects refer to files that are only mounted into our Prometheus pods, so the
+// copies refer to the trusted bundle, client certificate and bearer token that are rendered in the external namespace.
+func (mc *monitorComponent) externalMonitoringObjects(objs []client.Object) []client.Object {
+	namespace := mc.cfg.Monitor.ExternalPrometheus.Namespace
+	labels := map[string]string{render.AppLabelName: TigeraExternalPrometheus}
+	if sm := mc.cfg.Monitor.ExternalPrometheus.ServiceMonitor; sm != nil && len(sm.Labels) > 0 {
+		labels = sm.Labels
+	}
+
+	var external []client.Object
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *monitoringv1.ServiceMonitor:
+			o = o.DeepCopy()
+			for i, ep := range o.Spec.Endpoints {
+				if ep.BearerTokenFile != "" {
+					ep.BearerTokenFile = ""
+					ep.BearerTokenSecret = corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: TigeraExternalPrometheus},
+						Key:                  "token",
+					}
+				}
+				if ep.TLSConfig != nil {
+					ep.TLSConfig = mc.externalTLSConfig(ep.TLSConfig)
+				}
+				o.Spec.Endpoints[i] = ep
+			}
+			o.Namespace = namespace
+			o.Labels = labels
+			external = append(external, o)
+		case *monitoringv1.PrometheusRule:
+			o = o.DeepCopy()
+			o.Namespace = namespace
+			o.Labels = labels
+			external = append(external, o)
+		}
+	}
+	return external
+}
+
+// externalTLSConfig replaces the file references of the TLS config with references to the trusted bundle and, if it is
+// exported, the client certificate that are rendered in the external Prometheus namespace.
+func (mc *monitorComponent) externalTLSConfig(tlsConfig *monitoringv1.TLSConfig) *monitoringv1.TLSConfig {
+	external := &monitoringv1.TLSConfig{
+		SafeTLSConfig: monitoringv1.SafeTLSConfig{
+			ServerName: tlsConfig.ServerName,
+			CA: monitoringv1.SecretOrConfigMap{
+				ConfigMap: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: certificatemanagement.TrustedCertConfigMapName},
+					Key:                  certificatemanagement.TrustedCertConfigMapKeyName,
+				},
+			},
+		},
+	}
+	if tlsConfig.CertFile != "" && mc.cfg.Monitor.ExternalPrometheus.ClientKeyExportEnabled() {
+		external.Cert = monitoringv1.SecretOrConfigMap{
+			Secret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: PrometheusClientTLSSecretName},
+				Key:                  corev1.TLSCertKey,
+			},
+		}
+		external.KeySecret = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: PrometheusClientTLSSecretName},
+			Key:                  corev1.TLSPrivateKeyKey,
+		}
+	}
+	return external
+}
+
+// grafanaDashboardsConfig returns the namespace and labels of the Grafana dashboard ConfigMaps.
+func (mc *monitorComponent) grafanaDashboardsConfig() (string, map[string]string) {
+	namespace, labels := common.TigeraPrometheusNamespace, defaultGrafanaDashboardLabels
+	if dashboards := mc.cfg.Monitor.GrafanaDashboards; dashboards != nil {
+		if dashboards.Namespace != "" {
+			namespace = dashboards.Namespace
+		}
+		if len(dashboards.Labels) > 0 {
+			labels = dashboards.Labels
+		}
+	}
+	return namespace, labels
+}
+
+// grafanaDashboardConfigMaps creates a ConfigMap for each of the embedded Grafana dashboards.
+func grafanaDashboardConfigMaps(namespace string, labels map[string]string) []client.Object {
+	entries, err := grafanaDashboards.ReadDir("dashboards")
+	if err != nil {
+		// The dashboards are embedded at build time, so this cannot happen.
+		panic(err)
+	}
+
+	var objs []client.Object
+	for _, entry := range entries {
+		data, err := grafanaDashboards.ReadFile(path.Join("dashboards", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		cmLabels := map[string]string{GrafanaDashboardLabel: "true"}
+		for k, v := range labels {
+			cmLabels[k] = v
+		}
+		objs = append(objs, &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GrafanaDashboardPrefix + strings.TrimSuffix(entry.Name(), ".json"),
+				Namespace: namespace,
+				Labels:    cmLabels,
+			},
+			Data: map[string]string{entry.Name(): string(data)},
+		})
+	}
+	return objs
+}
+
 // externalConfigMap creates the configmap with the TLS certificate required to scrape our prometheus server.
 func (mc *monitorComponent) externalConfigMap() client.Object {
 	return render.CreateCertificateConfigMap(
@@ -15,6 +15,7 @@
 package monitor_test
 
 import (
+	"encoding/json"
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	"github.com/tigera/api/pkg/lib/numorstring"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/apis"
@@ -43,6 +45,7 @@ import (
 	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/monitor"
 	"github.com/tigera/operator/pkg/render/testutils"
@@ -601,6 +604,37 @@ var _ = Describe("monitor rendering tests", func() {
 		Expect(rolebindingObj.Subjects[0].Namespace).To(Equal(common.OperatorNamespace()))
 	})
 
+	It("should drop the metrics of es-metrics that the LogStorage limits", func() {
+		getEndpoint := func() monitoringv1.Endpoint {
+			component := monitor.Monitor(cfg)
+			Expect(component.ResolveImages(nil)).To(BeNil())
+			toCreate, _ := component.Objects()
+			servicemonitorObj, ok := rtest.GetResource(toCreate, monitor.ElasticsearchMetrics, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind).(*monitoringv1.ServiceMonitor)
+			Expect(ok).To(BeTrue())
+			Expect(servicemonitorObj.Spec.Endpoints).To(HaveLen(1))
+			return servicemonitorObj.Spec.Endpoints[0]
+		}
+		Expect(getEndpoint().MetricRelabelConfigs).To(BeEmpty())
+
+		dropJVMPools := &monitoringv1.RelabelConfig{
+			SourceLabels: []monitoringv1.LabelName{"__name__"},
+			Regex:        "elasticsearch_jvm_memory_pool_.*",
+			Action:       "drop",
+		}
+		cfg.ElasticsearchMetricsCollection = &operatorv1.ElasticsearchMetricsCollection{
+			IndexAllowlist:    []string{"tigera_secure_ee_flows.*", "tigera_secure_ee_dns.*"},
+			MetricRelabelings: []*monitoringv1.RelabelConfig{dropJVMPools},
+		}
+		Expect(getEndpoint().MetricRelabelConfigs).To(Equal([]*monitoringv1.RelabelConfig{
+			{
+				SourceLabels: []monitoringv1.LabelName{"index"},
+				Regex:        "|(?:tigera_secure_ee_flows.*)|(?:tigera_secure_ee_dns.*)",
+				Action:       "keep",
+			},
+			dropJVMPools,
+		}))
+	})
+
 	It("should render SecurityContextConstrains properly when provider is OpenShift", func() {
 		cfg.Installation.KubernetesProvider = operatorv1.ProviderOpenShift
 		cfg.OpenShift = true
@@ -888,6 +922,216 @@ var _ = Describe("monitor rendering tests", func() {
 		Expect(toCreate).To(HaveLen(len(expectedResources)))
 		Expect(toDelete).To(HaveLen(3))
 	})
+	It("Should render the remote write endpoints of the Prometheus", func() {
+		cfg.Monitor.Prometheus = &operatorv1.Prometheus{
+			PrometheusSpec: &operatorv1.PrometheusSpec{
+				CommonPrometheusFields: &operatorv1.CommonPrometheusFields{
+					RemoteWrite: []operatorv1.RemoteWriteSpec{{
+						Name: "central",
+						URL:  "https://metrics.example.com/api/v1/write",
+						BasicAuth: &operatorv1.RemoteWriteBasicAuth{
+							Username: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write"}, Key: "username"},
+							Password: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write"}, Key: "password"},
+						},
+						TLS: &operatorv1.RemoteWriteTLS{
+							CASecret:   &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write"}, Key: "ca.crt"},
+							ServerName: "metrics.example.com",
+						},
+					}},
+				},
+			},
+		}
+		cfg.RemoteWriteSecrets = []*corev1.Secret{{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "remote-write", Namespace: common.OperatorNamespace()},
+		}}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+
+		Expect(rtest.GetResource(toCreate, "remote-write", common.TigeraPrometheusNamespace, "", "v1", "Secret")).NotTo(BeNil())
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.RemoteWrite).To(Equal([]monitoringv1.RemoteWriteSpec{{
+			Name: "central",
+			URL:  "https://metrics.example.com/api/v1/write",
+			BasicAuth: &monitoringv1.BasicAuth{
+				Username: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write"}, Key: "username"},
+				Password: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write"}, Key: "password"},
+			},
+			TLSConfig: &monitoringv1.TLSConfig{
+				SafeTLSConfig: monitoringv1.SafeTLSConfig{
+					CA: monitoringv1.SecretOrConfigMap{
+						Secret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write"}, Key: "ca.crt"},
+					},
+					ServerName: "metrics.example.com",
+				},
+			},
+		}}))
+
+		// Prometheus may only reach the remote write endpoint.
+		policies, _ := monitor.MonitorPolicy(cfg).Objects()
+		policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: monitor.PrometheusPolicyName, Namespace: common.TigeraPrometheusNamespace}, policies)
+		tcp := numorstring.ProtocolFromString(numorstring.ProtocolTCP)
+		Expect(policy.Spec.Egress).To(ContainElement(v3.Rule{
+			Action:      v3.Allow,
+			Protocol:    &tcp,
+			Destination: v3.EntityRule{Domains: []string{"metrics.example.com"}, Ports: networkpolicy.Ports(443)},
+		}))
+		Expect(policy.Spec.Egress).NotTo(ContainElement(v3.Rule{Action: v3.Allow, Protocol: &tcp}))
+	})
+
+	It("Should render a Thanos sidecar and the local retention of the Prometheus", func() {
+		cfg.Monitor.Prometheus = &operatorv1.Prometheus{
+			PrometheusSpec: &operatorv1.PrometheusSpec{
+				Retention: "6h",
+				Thanos: &operatorv1.Thanos{
+					ObjectStorageConfig: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-objstore"}, Key: "objstore.yml"},
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("128Mi")},
+					},
+				},
+			},
+		}
+		cfg.ThanosSecret = &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "thanos-objstore", Namespace: common.OperatorNamespace()},
+		}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+
+		Expect(rtest.GetResource(toCreate, "thanos-objstore", common.TigeraPrometheusNamespace, "", "v1", "Secret")).NotTo(BeNil())
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Retention).To(Equal(monitoringv1.Duration("6h")))
+		Expect(prometheus.Spec.Thanos).NotTo(BeNil())
+		Expect(*prometheus.Spec.Thanos.Image).To(Equal(fmt.Sprintf("%s%s:%s", components.TigeraRegistry, components.ComponentThanos.Image, components.ComponentThanos.Version)))
+		Expect(prometheus.Spec.Thanos.ObjectStorageConfig).To(Equal(&corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-objstore"}, Key: "objstore.yml"}))
+		Expect(prometheus.Spec.Thanos.Resources.Requests).To(HaveKeyWithValue(corev1.ResourceMemory, k8sresource.MustParse("128Mi")))
+
+		// Prometheus needs to reach the object storage, which is on the default HTTPS port for this configuration.
+		policies, _ := monitor.MonitorPolicy(cfg).Objects()
+		policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: monitor.PrometheusPolicyName, Namespace: common.TigeraPrometheusNamespace}, policies)
+		tcp := numorstring.ProtocolFromString(numorstring.ProtocolTCP)
+		Expect(policy.Spec.Egress).To(ContainElement(v3.Rule{Action: v3.Allow, Protocol: &tcp, Destination: v3.EntityRule{Ports: networkpolicy.Ports(443)}}))
+		Expect(policy.Spec.Egress).NotTo(ContainElement(v3.Rule{Action: v3.Allow, Protocol: &tcp}))
+	})
+
+	It("Should only allow Prometheus to reach the S3 endpoint of the Thanos object storage", func() {
+		cfg.Monitor.Prometheus = &operatorv1.Prometheus{
+			PrometheusSpec: &operatorv1.PrometheusSpec{
+				Thanos: &operatorv1.Thanos{
+					ObjectStorageConfig: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-objstore"}, Key: "objstore.yml"},
+				},
+			},
+		}
+		cfg.ThanosSecret = &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "thanos-objstore", Namespace: common.OperatorNamespace()},
+			Data:       map[string][]byte{"objstore.yml": []byte("type: S3\nconfig:\n  bucket: metrics\n  endpoint: minio.example.com:9000\n")},
+		}
+
+		policies, _ := monitor.MonitorPolicy(cfg).Objects()
+		policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: monitor.PrometheusPolicyName, Namespace: common.TigeraPrometheusNamespace}, policies)
+		tcp := numorstring.ProtocolFromString(numorstring.ProtocolTCP)
+		Expect(policy.Spec.Egress).To(ContainElement(v3.Rule{
+			Action:      v3.Allow,
+			Protocol:    &tcp,
+			Destination: v3.EntityRule{Domains: []string{"minio.example.com"}, Ports: networkpolicy.Ports(9000)},
+		}))
+		Expect(policy.Spec.Egress).NotTo(ContainElement(v3.Rule{Action: v3.Allow, Protocol: &tcp, Destination: v3.EntityRule{Ports: networkpolicy.Ports(443)}}))
+	})
+
+	It("Should render the ServiceMonitors for an external Prometheus when the in-cluster Prometheus is disabled", func() {
+		disabled := operatorv1.PrometheusStateDisabled
+		cfg.Monitor.Prometheus = &operatorv1.Prometheus{State: &disabled}
+		cfg.Monitor.ExternalPrometheus = &operatorv1.ExternalPrometheus{
+			ServiceMonitor: &operatorv1.ServiceMonitor{Labels: map[string]string{"prometheus": "external"}},
+			Namespace:      "external-prometheus",
+		}
+		cfg.Installation.TyphaMetricsPort = ptr.Int32ToPtr(9093)
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		Expect(rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind)).To(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind)).NotTo(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.CalicoNodeMonitor, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind)).NotTo(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.TigeraExternalPrometheus, "external-prometheus", "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind)).NotTo(BeNil())
+		Expect(rtest.GetResource(toCreate, monitor.PrometheusClientTLSSecretName, "external-prometheus", "", "v1", "Secret")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.PrometheusClientTLSSecretName, "external-prometheus", "", "v1", "Secret")).NotTo(BeNil())
+		Expect(rtest.GetResource(toCreate, "tigera-ca-bundle", "external-prometheus", "", "v1", "ConfigMap")).NotTo(BeNil())
+		Expect(rtest.GetResource(toCreate, monitor.TigeraPrometheusDPRate, "external-prometheus", "monitoring.coreos.com", "v1", monitoringv1.PrometheusRuleKind)).NotTo(BeNil())
+		Expect(rtest.GetResource(toCreate, render.TyphaMetricsName, "external-prometheus", "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind)).NotTo(BeNil())
+
+		nodeMonitor := rtest.GetResource(toCreate, monitor.CalicoNodeMonitor, "external-prometheus", "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind).(*monitoringv1.ServiceMonitor)
+		Expect(nodeMonitor.Labels).To(Equal(map[string]string{"prometheus": "external"}))
+		Expect(nodeMonitor.Spec.Endpoints[0].TLSConfig).To(Equal(&monitoringv1.TLSConfig{
+			SafeTLSConfig: monitoringv1.SafeTLSConfig{
+				CA: monitoringv1.SecretOrConfigMap{
+					ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tigera-ca-bundle"}, Key: "tigera-ca-bundle.crt"},
+				},
+				ServerName: render.CalicoNodeMetricsService,
+			},
+		}))
+
+		queryServerMonitor := rtest.GetResource(toCreate, render.QueryserverServiceName, "external-prometheus", "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind).(*monitoringv1.ServiceMonitor)
+		Expect(queryServerMonitor.Spec.Endpoints[0].BearerTokenFile).To(BeEmpty())
+		Expect(queryServerMonitor.Spec.Endpoints[0].BearerTokenSecret.Name).To(Equal(monitor.TigeraExternalPrometheus))
+		Expect(queryServerMonitor.Spec.Endpoints[0].TLSConfig.Cert).To(Equal(monitoringv1.SecretOrConfigMap{}))
+	})
+
+	It("Should only copy the Prometheus client key to the external Prometheus namespace when asked to", func() {
+		disabled := operatorv1.PrometheusStateDisabled
+		export := operatorv1.ClientKeyExportEnabled
+		cfg.Monitor.Prometheus = &operatorv1.Prometheus{State: &disabled}
+		cfg.Monitor.ExternalPrometheus = &operatorv1.ExternalPrometheus{
+			Namespace:       "external-prometheus",
+			ClientKeyExport: &export,
+		}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+
+		Expect(rtest.GetResource(toCreate, monitor.PrometheusClientTLSSecretName, "external-prometheus", "", "v1", "Secret")).NotTo(BeNil())
+		nodeMonitor := rtest.GetResource(toCreate, monitor.CalicoNodeMonitor, "external-prometheus", "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind).(*monitoringv1.ServiceMonitor)
+		Expect(nodeMonitor.Spec.Endpoints[0].TLSConfig).To(Equal(&monitoringv1.TLSConfig{
+			SafeTLSConfig: monitoringv1.SafeTLSConfig{
+				CA: monitoringv1.SecretOrConfigMap{
+					ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tigera-ca-bundle"}, Key: "tigera-ca-bundle.crt"},
+				},
+				Cert: monitoringv1.SecretOrConfigMap{
+					Secret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: monitor.PrometheusClientTLSSecretName}, Key: corev1.TLSCertKey},
+				},
+				KeySecret:  &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: monitor.PrometheusClientTLSSecretName}, Key: corev1.TLSPrivateKeyKey},
+				ServerName: render.CalicoNodeMetricsService,
+			},
+		}))
+	})
+
+	It("Should render the Grafana dashboards in a custom namespace with custom labels", func() {
+		cfg.Monitor.GrafanaDashboards = &operatorv1.GrafanaDashboards{
+			Namespace: "grafana",
+			Labels:    map[string]string{"dashboards": "tigera"},
+		}
+		// The dashboards were rendered in another custom namespace before.
+		cfg.GrafanaDashboardNamespaces = []string{"grafana", "old-grafana"}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		for _, name := range []string{"denied-traffic", "elasticsearch-health", "flow-log-ingest"} {
+			cm := rtest.GetResource(toCreate, monitor.GrafanaDashboardPrefix+name, "grafana", "", "v1", "ConfigMap").(*corev1.ConfigMap)
+			Expect(cm.Labels).To(Equal(map[string]string{"dashboards": "tigera", monitor.GrafanaDashboardLabel: "true"}))
+			var dashboard map[string]interface{}
+			Expect(json.Unmarshal([]byte(cm.Data[name+".json"]), &dashboard)).NotTo(HaveOccurred())
+			Expect(dashboard).To(HaveKey("panels"))
+
+			Expect(rtest.GetResource(toCreate, monitor.GrafanaDashboardPrefix+name, common.TigeraPrometheusNamespace, "", "v1", "ConfigMap")).To(BeNil())
+			Expect(rtest.GetResource(toDelete, monitor.GrafanaDashboardPrefix+name, common.TigeraPrometheusNamespace, "", "v1", "ConfigMap")).NotTo(BeNil())
+			Expect(rtest.GetResource(toDelete, monitor.GrafanaDashboardPrefix+name, "old-grafana", "", "v1", "ConfigMap")).NotTo(BeNil())
+			Expect(rtest.GetResource(toDelete, monitor.GrafanaDashboardPrefix+name, "grafana", "", "v1", "ConfigMap")).To(BeNil())
+		}
+	})
 	It("Should render external prometheus resources without service monitor", func() {
 		cfg.Monitor.ExternalPrometheus = &operatorv1.ExternalPrometheus{
 			Namespace: "external-prometheus",
@@ -988,5 +1232,8 @@ func expectedBaseResources() []resource {
 		{"fluentd-metrics", common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind},
 		{"tigera-api", common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind},
 		{"calico-kube-controllers-metrics", common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind},
+		{"tigera-grafana-dashboard-denied-traffic", common.TigeraPrometheusNamespace, "", "v1", "ConfigMap"},
+		{"tigera-grafana-dashboard-elasticsearch-health", common.TigeraPrometheusNamespace, "", "v1", "ConfigMap"},
+		{"tigera-grafana-dashboard-flow-log-ingest", common.TigeraPrometheusNamespace, "", "v1", "ConfigMap"},
 	}
 }
@@ -0,0 +1,95 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectMutator is a post-render hook that makes last-mile changes to an object that a component renders, such as
+// injecting labels or rewriting images for a disconnected registry. It is given the component that rendered the
+// object so that it can limit its changes to some components. It must modify obj in place, and must be idempotent
+// since it is applied on every reconcile.
+type ObjectMutator func(component Component, obj client.Object) error
+
+type namedObjectMutator struct {
+	name    string
+	mutator ObjectMutator
+}
+
+var (
+	objectMutatorsLock sync.RWMutex
+	objectMutators     []namedObjectMutator
+)
+
+// RegisterObjectMutator registers an ObjectMutator under the given name. Mutators are applied to the objects to create
+// of every component in the order they were registered, before the operator creates or updates them. It is meant to
+// be called from the init function of the package that provides the mutator, and panics if the name is empty or
+// already registered, or the mutator is nil.
+func RegisterObjectMutator(name string, mutator ObjectMutator) {
+	objectMutatorsLock.Lock()
+	defer objectMutatorsLock.Unlock()
+	if name == "" {
+		panic("object mutator name must not be empty")
+	}
+	if mutator == nil {
+		panic(fmt.Sprintf("object mutator %q is nil", name))
+	}
+	for _, m := range objectMutators {
+		if m.name == name {
+			panic(fmt.Sprintf("object mutator %q is already registered", name))
+		}
+	}
+	objectMutators = append(objectMutators, namedObjectMutator{name: name, mutator: mutator})
+}
+
+// UnregisterObjectMutator removes the ObjectMutator registered under the given name, if any.
+func UnregisterObjectMutator(name string) {
+	objectMutatorsLock.Lock()
+	defer objectMutatorsLock.Unlock()
+	for i, m := range objectMutators {
+		if m.name == name {
+			objectMutators = append(objectMutators[:i:i], objectMutators[i+1:]...)
+			return
+		}
+	}
+}
+
+// MutateObjects applies the registered ObjectMutators to the objects rendered by the component. The objects are copied
+// before they are mutated, so the component's own objects are left unchanged. If no mutators are registered, the
+// objects are returned as they are.
+func MutateObjects(component Component, objs []client.Object) ([]client.Object, error) {
+	objectMutatorsLock.RLock()
+	mutators := objectMutators
+	objectMutatorsLock.RUnlock()
+	if len(mutators) == 0 {
+		return objs, nil
+	}
+
+	mutated := make([]client.Object, 0, len(objs))
+	for _, obj := range objs {
+		obj = obj.DeepCopyObject().(client.Object)
+		for _, m := range mutators {
+			if err := m.mutator(component, obj); err != nil {
+				return nil, fmt.Errorf("object mutator %q failed on %T %s: %w", m.name, obj, client.ObjectKeyFromObject(obj), err)
+			}
+		}
+		mutated = append(mutated, obj)
+	}
+	return mutated, nil
+}
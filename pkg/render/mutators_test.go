@@ -0,0 +1,127 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("Object mutators", func() {
+	var (
+		deployment *appsv1.Deployment
+		component  render.Component
+	)
+
+	rewriteImages := func(_ render.Component, obj client.Object) error {
+		if d, ok := obj.(*appsv1.Deployment); ok {
+			for i, c := range d.Spec.Template.Spec.Containers {
+				d.Spec.Template.Spec.Containers[i].Image = strings.Replace(c.Image, "quay.io/", "mirror.local/", 1)
+			}
+		}
+		return nil
+	}
+	addLabel := func(_ render.Component, obj client.Object) error {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels["cost-center"] = "networking"
+		obj.SetLabels(labels)
+		return nil
+	}
+
+	BeforeEach(func() {
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "quay.io/tigera/test:v1"}}},
+				},
+			},
+		}
+		component = render.NewPassthrough(deployment)
+	})
+
+	AfterEach(func() {
+		render.UnregisterObjectMutator("rewrite-images")
+		render.UnregisterObjectMutator("add-label")
+		render.UnregisterObjectMutator("fail")
+	})
+
+	It("should return the objects as they are without mutators", func() {
+		objs, err := render.MutateObjects(component, []client.Object{deployment})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(objs[0]).To(BeIdenticalTo(deployment))
+	})
+
+	It("should apply the mutators in order to copies of the objects", func() {
+		var order []string
+		render.RegisterObjectMutator("rewrite-images", func(c render.Component, obj client.Object) error {
+			order = append(order, "rewrite-images")
+			return rewriteImages(c, obj)
+		})
+		render.RegisterObjectMutator("add-label", func(c render.Component, obj client.Object) error {
+			order = append(order, "add-label")
+			return addLabel(c, obj)
+		})
+
+		objs, err := render.MutateObjects(component, []client.Object{deployment})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(order).To(Equal([]string{"rewrite-images", "add-label"}))
+
+		d := objs[0].(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Image).To(Equal("mirror.local/tigera/test:v1"))
+		Expect(d.Labels).To(HaveKeyWithValue("cost-center", "networking"))
+
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("quay.io/tigera/test:v1"))
+		Expect(deployment.Labels).To(BeNil())
+	})
+
+	It("should stop at the first mutator that fails", func() {
+		render.RegisterObjectMutator("fail", func(render.Component, client.Object) error { return fmt.Errorf("boom") })
+		render.RegisterObjectMutator("add-label", addLabel)
+
+		_, err := render.MutateObjects(component, []client.Object{deployment})
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`object mutator "fail" failed`))
+		Expect(err.Error()).To(ContainSubstring("test/test"))
+	})
+
+	It("should no longer apply an unregistered mutator", func() {
+		render.RegisterObjectMutator("add-label", addLabel)
+		render.UnregisterObjectMutator("add-label")
+
+		objs, err := render.MutateObjects(component, []client.Object{deployment})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(objs[0].GetLabels()).To(BeNil())
+	})
+
+	It("should reject invalid registrations", func() {
+		render.RegisterObjectMutator("add-label", addLabel)
+		Expect(func() { render.RegisterObjectMutator("add-label", addLabel) }).To(Panic())
+		Expect(func() { render.RegisterObjectMutator("", addLabel) }).To(Panic())
+		Expect(func() { render.RegisterObjectMutator("nil", nil) }).To(Panic())
+	})
+})
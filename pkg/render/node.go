@@ -35,7 +35,6 @@ import (
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
 	"github.com/tigera/operator/pkg/controller/migration"
-	"github.com/tigera/operator/pkg/ptr"
 	rcomp "github.com/tigera/operator/pkg/render/common/components"
 	"github.com/tigera/operator/pkg/render/common/configmap"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
@@ -124,6 +123,9 @@ type NodeConfiguration struct {
 	// The bindMode read from the default BGPConfiguration. Used to trigger rolling updates
 	// should this value change.
 	BindMode string
+
+	// The address of Goldmane, which Felix sends flow logs to. Only set when Whisker is installed.
+	GoldmaneServer string
 }
 
 // Node creates the node daemonset and other resources for the daemonset to operate normally.
@@ -1273,17 +1275,14 @@ func (c *nodeComponent) cniEnvvars() []corev1.EnvVar {
 func (c *nodeComponent) nodeContainer() corev1.Container {
 	sc := securitycontext.NewRootContext(true)
 	if c.runAsNonPrivileged() {
-		sc = securitycontext.NewNonRootContext()
-		// Set the group to be the root user group since all container users should be a member
-		sc.RunAsGroup = ptr.Int64ToPtr(0)
-		sc.Capabilities.Add = []corev1.Capability{
-			"NET_ADMIN",
-			"NET_BIND_SERVICE",
-			"NET_RAW",
-		}
-		// Set the privilege escalation to true so that routes, ipsets can be programmed.
-		sc.AllowPrivilegeEscalation = ptr.BoolToPtr(true)
-		sc.Capabilities.Drop = []corev1.Capability{}
+		sc = securitycontext.NewBuilder().
+			// Set the group to be the root user group since all container users should be a member
+			RunAsGroup(0).
+			AddCapabilities("NET_ADMIN", "NET_BIND_SERVICE", "NET_RAW").
+			// Set the privilege escalation to true so that routes, ipsets can be programmed.
+			AllowPrivilegeEscalation().
+			KeepDefaultCapabilities().
+			Build()
 	}
 
 	lp, rp := c.nodeLivenessReadinessProbes()
@@ -1482,6 +1481,10 @@ func (c *nodeComponent) nodeEnvVars() []corev1.EnvVar {
 		nodeEnv = append(nodeEnv, corev1.EnvVar{Name: "FELIX_FLOWLOGSCOLLECTPROCESSPATH", Value: "true"})
 	}
 
+	if c.cfg.GoldmaneServer != "" {
+		nodeEnv = append(nodeEnv, corev1.EnvVar{Name: "FELIX_FLOWLOGSGOLDMANESERVER", Value: c.cfg.GoldmaneServer})
+	}
+
 	// Determine MTU to use. If specified explicitly, use that. Otherwise, set defaults based on an overall
 	// MTU of 1460.
 	mtu := getMTU(c.cfg.Installation)
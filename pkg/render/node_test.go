@@ -157,6 +157,16 @@ var _ = Describe("Node rendering tests", func() {
 				}))
 			})
 
+			It("should configure Felix to send flow logs to Goldmane", func() {
+				cfg.GoldmaneServer = "goldmane.calico-system.svc.cluster.local:7443"
+				component := render.Node(&cfg)
+				Expect(component.ResolveImages(nil)).To(BeNil())
+				resources, _ := component.Objects()
+
+				ds := rtest.GetResource(resources, "calico-node", "calico-system", "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+				rtest.ExpectEnv(ds.Spec.Template.Spec.Containers[0].Env, "FELIX_FLOWLOGSGOLDMANESERVER", "goldmane.calico-system.svc.cluster.local:7443")
+			})
+
 			It("should render all resources for a default configuration", func() {
 				expectedResources := []struct {
 					name    string
@@ -18,6 +18,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,6 +51,11 @@ const (
 	PacketCapturePolicyName             = networkpolicy.TigeraComponentPolicyPrefix + PacketCaptureName
 	PacketCapturePort                   = 8444
 	PacketCaptureServerCert             = "tigera-packetcapture-server-tls"
+	PacketCapturePVCName                = PacketCaptureName
+
+	packetCaptureStorageVolumeName = "packet-captures"
+	packetCaptureStoragePath       = "/var/lib/packet-captures"
+	packetCaptureDefaultPVCSize    = "10Gi"
 )
 
 var (
@@ -117,6 +123,12 @@ func (pc *packetCaptureApiComponent) Objects() ([]client.Object, []client.Object
 		pc.service(),
 	)
 
+	// The PersistentVolumeClaim is not deleted when the storage is changed to an emptyDir volume, since it holds the
+	// capture files of the user.
+	if pc.storage().GetType() == operatorv1.PacketCaptureStoragePersistentVolumeClaim {
+		objs = append(objs, pc.persistentVolumeClaim())
+	}
+
 	if pc.cfg.KeyValidatorConfig != nil {
 		objs = append(objs, secret.ToRuntimeObjects(pc.cfg.KeyValidatorConfig.RequiredSecrets(PacketCaptureNamespace)...)...)
 		objs = append(objs, configmap.ToRuntimeObjects(pc.cfg.KeyValidatorConfig.RequiredConfigMaps(PacketCaptureNamespace)...)...)
@@ -247,7 +259,7 @@ func (pc *packetCaptureApiComponent) deployment() *appsv1.Deployment {
 				Spec: corev1.PodSpec{
 					NodeSelector:       pc.cfg.Installation.ControlPlaneNodeSelector,
 					ServiceAccountName: PacketCaptureServiceAccountName,
-					Tolerations:        append(pc.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateCriticalAddonsAndControlPlane...),
+					Tolerations:        rmeta.ControlPlaneTolerations(pc.cfg.Installation, rmeta.Workload{CriticalAddon: true}, rmeta.TolerateCriticalAddonsAndControlPlane...),
 					ImagePullSecrets:   secret.GetReferenceList(pc.cfg.PullSecrets),
 					InitContainers:     pc.initContainers(),
 					Containers:         []corev1.Container{pc.container()},
@@ -266,6 +278,60 @@ func (pc *packetCaptureApiComponent) deployment() *appsv1.Deployment {
 	return d
 }
 
+func (pc *packetCaptureApiComponent) storage() *operatorv1.PacketCaptureStorage {
+	if pc.cfg.PacketCaptureAPI == nil {
+		return nil
+	}
+	return pc.cfg.PacketCaptureAPI.Spec.Storage
+}
+
+func (pc *packetCaptureApiComponent) persistentVolumeClaim() *corev1.PersistentVolumeClaim {
+	storage := pc.storage()
+	size := resource.MustParse(packetCaptureDefaultPVCSize)
+	if storage != nil && storage.Size != nil {
+		size = *storage.Size
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PacketCapturePVCName,
+			Namespace: PacketCaptureNamespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+	if storage != nil && storage.StorageClassName != "" {
+		pvc.Spec.StorageClassName = ptr.ToPtr(storage.StorageClassName)
+	}
+	return pvc
+}
+
+func (pc *packetCaptureApiComponent) storageVolume() corev1.Volume {
+	storage := pc.storage()
+	if storage.GetType() == operatorv1.PacketCaptureStoragePersistentVolumeClaim {
+		return corev1.Volume{
+			Name: packetCaptureStorageVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: PacketCapturePVCName},
+			},
+		}
+	}
+
+	emptyDir := &corev1.EmptyDirVolumeSource{}
+	if storage != nil {
+		emptyDir.SizeLimit = storage.Size
+	}
+	return corev1.Volume{
+		Name:         packetCaptureStorageVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: emptyDir},
+	}
+}
+
 func (pc *packetCaptureApiComponent) initContainers() []corev1.Container {
 	var initContainers []corev1.Container
 	if pc.cfg.ServerCertSecret.UseCertificateManagement() {
@@ -277,6 +343,7 @@ func (pc *packetCaptureApiComponent) initContainers() []corev1.Container {
 func (pc *packetCaptureApiComponent) container() corev1.Container {
 	volumeMounts := []corev1.VolumeMount{
 		pc.cfg.ServerCertSecret.VolumeMount(pc.SupportedOSType()),
+		{Name: packetCaptureStorageVolumeName, MountPath: packetCaptureStoragePath},
 	}
 	env := []corev1.EnvVar{
 		{Name: "PACKETCAPTURE_API_LOG_LEVEL", Value: "Info"},
@@ -320,6 +387,7 @@ func (pc *packetCaptureApiComponent) healthProbe() *corev1.Probe {
 func (pc *packetCaptureApiComponent) volumes() []corev1.Volume {
 	volumes := []corev1.Volume{
 		pc.cfg.ServerCertSecret.Volume(),
+		pc.storageVolume(),
 	}
 
 	if pc.cfg.TrustedBundle != nil {
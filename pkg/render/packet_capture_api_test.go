@@ -192,6 +192,7 @@ var _ = Describe("Rendering tests for PacketCapture API component", func() {
 				MountPath: "/tigera-packetcapture-server-tls",
 				ReadOnly:  true,
 			},
+			{Name: "packet-captures", MountPath: "/var/lib/packet-captures"},
 		}
 		return volumeMounts
 	}
@@ -267,6 +268,10 @@ var _ = Describe("Rendering tests for PacketCapture API component", func() {
 				},
 			})
 		}
+		volumes = append(volumes, corev1.Volume{
+			Name:         "packet-captures",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
 
 		return volumes
 	}
@@ -527,4 +532,71 @@ var _ = Describe("Rendering tests for PacketCapture API component", func() {
 			}))
 		})
 	})
+
+	Context("storage", func() {
+		var cfg *render.PacketCaptureApiConfiguration
+
+		BeforeEach(func() {
+			cfg = &render.PacketCaptureApiConfiguration{
+				PullSecrets:      pullSecrets,
+				Installation:     &defaultInstallation,
+				ServerCertSecret: secret,
+				PacketCaptureAPI: &operatorv1.PacketCaptureAPI{},
+			}
+		})
+
+		It("should store captures in a persistent volume claim", func() {
+			pvcType := operatorv1.PacketCaptureStoragePersistentVolumeClaim
+			size := resource.MustParse("50Gi")
+			cfg.PacketCaptureAPI.Spec.Storage = &operatorv1.PacketCaptureStorage{Type: &pvcType, Size: &size, StorageClassName: "fast"}
+
+			resources, toDelete := render.PacketCaptureAPI(cfg).Objects()
+			Expect(toDelete).To(BeEmpty())
+			pvc := rtest.GetResource(resources, render.PacketCapturePVCName, render.PacketCaptureNamespace, "", "v1", "PersistentVolumeClaim").(*corev1.PersistentVolumeClaim)
+			Expect(*pvc.Spec.StorageClassName).To(Equal("fast"))
+			Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(size))
+
+			d := rtest.GetResource(resources, render.PacketCaptureDeploymentName, render.PacketCaptureNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name: "packet-captures",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: render.PacketCapturePVCName},
+				},
+			}))
+		})
+
+		It("should limit the size of the emptyDir volume and keep the persistent volume claim", func() {
+			size := resource.MustParse("1Gi")
+			cfg.PacketCaptureAPI.Spec.Storage = &operatorv1.PacketCaptureStorage{Size: &size}
+
+			resources, toDelete := render.PacketCaptureAPI(cfg).Objects()
+			Expect(rtest.GetResource(resources, render.PacketCapturePVCName, render.PacketCaptureNamespace, "", "v1", "PersistentVolumeClaim")).To(BeNil())
+			Expect(toDelete).To(BeEmpty())
+
+			d := rtest.GetResource(resources, render.PacketCaptureDeploymentName, render.PacketCaptureNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name:         "packet-captures",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &size}},
+			}))
+		})
+
+		It("should apply scheduling overrides", func() {
+			toleration := corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpExists}
+			cfg.PacketCaptureAPI.Spec.PacketCaptureAPIDeployment = &operatorv1.PacketCaptureAPIDeployment{
+				Spec: &operatorv1.PacketCaptureAPIDeploymentSpec{
+					Template: &operatorv1.PacketCaptureAPIDeploymentPodTemplateSpec{
+						Spec: &operatorv1.PacketCaptureAPIDeploymentPodSpec{
+							NodeSelector: map[string]string{"capture": "true"},
+							Tolerations:  []corev1.Toleration{toleration},
+						},
+					},
+				},
+			}
+
+			resources, _ := render.PacketCaptureAPI(cfg).Objects()
+			d := rtest.GetResource(resources, render.PacketCaptureDeploymentName, render.PacketCaptureNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("capture", "true"))
+			Expect(d.Spec.Template.Spec.Tolerations).To(ConsistOf(toleration))
+		})
+	})
 })
@@ -338,7 +338,7 @@ func (pr *policyRecommendationComponent) deployment() *appsv1.Deployment {
 			Annotations: pr.policyRecommendationAnnotations(),
 		},
 		Spec: corev1.PodSpec{
-			Tolerations:        pr.cfg.Installation.ControlPlaneTolerations,
+			Tolerations:        rmeta.ControlPlaneTolerations(pr.cfg.Installation, rmeta.Workload{}),
 			NodeSelector:       pr.cfg.Installation.ControlPlaneNodeSelector,
 			ServiceAccountName: PolicyRecommendationName,
 			ImagePullSecrets:   secret.GetReferenceList(pr.cfg.PullSecrets),
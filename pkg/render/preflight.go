@@ -0,0 +1,209 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/ptr"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/securitycontext"
+)
+
+const PreflightName = "tigera-preflight"
+
+// Preflight renders a Job that runs the operator's preflight checks in the cluster and logs the report as JSON.
+func Preflight(cfg *PreflightConfiguration) Component {
+	return &preflightComponent{cfg: cfg}
+}
+
+// PreflightConfiguration contains all the config information needed to render the component.
+type PreflightConfiguration struct {
+	PullSecrets  []corev1.LocalObjectReference
+	Installation *operatorv1.InstallationSpec
+}
+
+type preflightComponent struct {
+	cfg   *PreflightConfiguration
+	image string
+}
+
+func (c *preflightComponent) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeLinux
+}
+
+func (c *preflightComponent) ResolveImages(is *operatorv1.ImageSet) error {
+	reg := c.cfg.Installation.Registry
+	path := c.cfg.Installation.ImagePath
+	prefix := c.cfg.Installation.ImagePrefix
+	var err error
+	c.image, err = components.GetReference(components.ComponentOperatorInit, reg, path, prefix, is)
+	return err
+}
+
+func (c *preflightComponent) Objects() ([]client.Object, []client.Object) {
+	return []client.Object{
+		c.serviceAccount(),
+		c.clusterRole(),
+		c.clusterRoleBinding(),
+		c.role(),
+		c.roleBinding(),
+		c.job(),
+	}, nil
+}
+
+func (c *preflightComponent) Ready() bool {
+	return true
+}
+
+func (c *preflightComponent) job() *batchv1.Job {
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PreflightName,
+			Namespace: common.OperatorNamespace(),
+		},
+		Spec: batchv1.JobSpec{
+			// The checks are deterministic, so a failed run is not retried.
+			BackoffLimit: ptr.Int32ToPtr(0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ImagePullSecrets:   c.cfg.PullSecrets,
+					ServiceAccountName: PreflightName,
+					Tolerations:        rmeta.TolerateControlPlane,
+					Containers: []corev1.Container{{
+						Name:            PreflightName,
+						Image:           c.image,
+						ImagePullPolicy: ImagePullPolicy(),
+						Args:            []string{"--preflight=json"},
+						SecurityContext: securitycontext.NewNonRootContext(),
+					}},
+				},
+			},
+		},
+	}
+}
+
+func (c *preflightComponent) serviceAccount() *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PreflightName,
+			Namespace: common.OperatorNamespace(),
+		},
+	}
+}
+
+// clusterRole allows reading the cluster-wide resources that the preflight checks inspect.
+func (c *preflightComponent) clusterRole() *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: PreflightName},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"nodes"},
+				Verbs:     []string{"list"},
+			},
+			{
+				APIGroups: []string{"storage.k8s.io"},
+				Resources: []string{"storageclasses"},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{"apiextensions.k8s.io"},
+				Resources: []string{"customresourcedefinitions"},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{"operator.tigera.io"},
+				Resources: []string{"installations", "logstorages"},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{"operator.tigera.io"},
+				Resources: []string{"tenants"},
+				Verbs:     []string{"list"},
+			},
+		},
+	}
+}
+
+func (c *preflightComponent) clusterRoleBinding() *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: PreflightName},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     PreflightName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      PreflightName,
+				Namespace: common.OperatorNamespace(),
+			},
+		},
+	}
+}
+
+// role allows reading the operator's bootstrap configuration, which determines whether Elasticsearch is external.
+func (c *preflightComponent) role() *rbacv1.Role {
+	return &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{Kind: "Role", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PreflightName,
+			Namespace: common.OperatorNamespace(),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get"},
+			},
+		},
+	}
+}
+
+func (c *preflightComponent) roleBinding() *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{Kind: "RoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PreflightName,
+			Namespace: common.OperatorNamespace(),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     PreflightName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      PreflightName,
+				Namespace: common.OperatorNamespace(),
+			},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	rtest "github.com/tigera/operator/pkg/render/common/test"
+)
+
+var _ = Describe("Preflight rendering tests", func() {
+	var cfg *PreflightConfiguration
+
+	BeforeEach(func() {
+		cfg = &PreflightConfiguration{
+			PullSecrets:  []corev1.LocalObjectReference{{Name: "pull-secret"}},
+			Installation: &operatorv1.InstallationSpec{Registry: "example.com/"},
+		}
+	})
+
+	It("should render the preflight Job and its RBAC", func() {
+		component := Preflight(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		expectedResources := []struct {
+			name    string
+			ns      string
+			group   string
+			version string
+			kind    string
+		}{
+			{PreflightName, "tigera-operator", "", "v1", "ServiceAccount"},
+			{PreflightName, "", "rbac.authorization.k8s.io", "v1", "ClusterRole"},
+			{PreflightName, "", "rbac.authorization.k8s.io", "v1", "ClusterRoleBinding"},
+			{PreflightName, "tigera-operator", "rbac.authorization.k8s.io", "v1", "Role"},
+			{PreflightName, "tigera-operator", "rbac.authorization.k8s.io", "v1", "RoleBinding"},
+			{PreflightName, "tigera-operator", "batch", "v1", "Job"},
+		}
+		Expect(toCreate).To(HaveLen(len(expectedResources)))
+		for i, expectedRes := range expectedResources {
+			rtest.ExpectResourceTypeAndObjectMetadata(toCreate[i], expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
+		}
+		Expect(toDelete).To(BeNil())
+
+		job := rtest.GetResource(toCreate, PreflightName, "tigera-operator", "batch", "v1", "Job").(*batchv1.Job)
+		Expect(*job.Spec.BackoffLimit).To(BeZero())
+		Expect(job.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		Expect(job.Spec.Template.Spec.ImagePullSecrets).To(Equal(cfg.PullSecrets))
+		Expect(job.Spec.Template.Spec.Containers).To(HaveLen(1))
+		container := job.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(HavePrefix("example.com/tigera/operator:"))
+		Expect(container.Args).To(Equal([]string{"--preflight=json"}))
+		Expect(*container.SecurityContext.RunAsNonRoot).To(BeTrue())
+	})
+})
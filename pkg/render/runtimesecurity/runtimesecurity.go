@@ -0,0 +1,377 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimesecurity
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/render"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/render/common/securitycontext"
+	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+const (
+	RuntimeSecurityNamespace       = "tigera-runtime-security"
+	RuntimeSecurityName            = "tigera-runtime-security"
+	RuntimeSecurityPolicyName      = networkpolicy.TigeraComponentPolicyPrefix + RuntimeSecurityName
+	RuntimeSecurityTLSSecretName   = "tigera-runtime-security-tls"
+	RuntimeSecurityLinseedRBACName = "tigera-runtime-security-linseed-permissions"
+)
+
+// RuntimeSecuritySourceEntityRule selects the runtime security agent pods, so that Linseed can accept the events that
+// they forward.
+var RuntimeSecuritySourceEntityRule = networkpolicy.CreateSourceEntityRule(RuntimeSecurityNamespace, RuntimeSecurityName)
+
+type Config struct {
+	RuntimeSecurity *operatorv1.RuntimeSecurity
+	Installation    *operatorv1.InstallationSpec
+	PullSecrets     []*corev1.Secret
+	OpenShift       bool
+	ManagedCluster  bool
+	ClusterDomain   string
+
+	// The key pair that the runtime security agent presents to Linseed, and the bundle it uses to verify Linseed.
+	KeyPair       certificatemanagement.KeyPairInterface
+	TrustedBundle certificatemanagement.TrustedBundleRO
+}
+
+func RuntimeSecurity(cfg *Config) render.Component {
+	return &component{cfg: cfg}
+}
+
+type component struct {
+	cfg   *Config
+	image string
+}
+
+func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
+	var err error
+	c.image, err = components.GetReference(
+		components.ComponentRuntimeSecurity,
+		c.cfg.Installation.Registry,
+		c.cfg.Installation.ImagePath,
+		c.cfg.Installation.ImagePrefix,
+		is)
+	return err
+}
+
+func (c *component) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeLinux
+}
+
+func (c *component) Ready() bool {
+	return true
+}
+
+func (c *component) Objects() ([]client.Object, []client.Object) {
+	toCreate := []client.Object{
+		render.CreateNamespace(RuntimeSecurityNamespace, c.cfg.Installation.KubernetesProvider, render.PSSPrivileged),
+		c.allowTigeraPolicy(),
+	}
+	toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(RuntimeSecurityNamespace, c.cfg.PullSecrets...)...)...)
+	toCreate = append(toCreate,
+		c.serviceAccount(),
+		c.clusterRole(),
+		c.clusterRoleBinding(),
+		c.daemonset(),
+	)
+
+	var toDelete []client.Object
+	if c.cfg.ManagedCluster {
+		// Managed clusters forward events through Guardian with a token that is issued by the management cluster, so
+		// Linseed needs to manage the token secret in our namespace rather than grant permissions to our service account.
+		toCreate = append(toCreate, c.externalLinseedRoleBinding())
+		toDelete = append(toDelete, c.linseedAccessClusterRole(), c.linseedAccessClusterRoleBinding())
+	} else {
+		toCreate = append(toCreate, c.linseedAccessClusterRole(), c.linseedAccessClusterRoleBinding())
+		toDelete = append(toDelete, c.externalLinseedRoleBinding())
+	}
+	return toCreate, toDelete
+}
+
+func (c *component) daemonset() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RuntimeSecurityName,
+			Namespace: RuntimeSecurityNamespace,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: c.annotations(),
+				},
+				Spec: corev1.PodSpec{
+					Tolerations:        rmeta.TolerateAll,
+					NodeSelector:       c.cfg.RuntimeSecurity.Spec.NodeSelector,
+					ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
+					ServiceAccountName: RuntimeSecurityName,
+					// The agent inspects the processes of all containers on the node.
+					HostPID:    true,
+					Containers: []corev1.Container{c.container()},
+					Volumes:    c.volumes(),
+				},
+			},
+		},
+	}
+}
+
+func (c *component) container() corev1.Container {
+	var resources corev1.ResourceRequirements
+	if r := c.cfg.RuntimeSecurity.Spec.Resources; r != nil {
+		resources = *r
+	}
+	return corev1.Container{
+		Name:            RuntimeSecurityName,
+		Image:           c.image,
+		ImagePullPolicy: render.ImagePullPolicy(),
+		Resources:       resources,
+		Env:             c.env(),
+		VolumeMounts:    c.volumeMounts(),
+		// The agent loads eBPF programs to observe process, file and network activity.
+		SecurityContext: securitycontext.NewRootContext(true),
+	}
+}
+
+func (c *component) env() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name: "NODENAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+			},
+		},
+		{Name: "LINSEED_URL", Value: relasticsearch.LinseedEndpoint(c.SupportedOSType(), c.cfg.ClusterDomain, render.ElasticsearchNamespace)},
+		{Name: "LINSEED_CA", Value: c.cfg.TrustedBundle.MountPath()},
+		{Name: "LINSEED_CLIENT_CERT", Value: c.cfg.KeyPair.VolumeMountCertificateFilePath()},
+		{Name: "LINSEED_CLIENT_KEY", Value: c.cfg.KeyPair.VolumeMountKeyFilePath()},
+		{Name: "LINSEED_TOKEN", Value: render.GetLinseedTokenPath(c.cfg.ManagedCluster)},
+		{Name: "FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(c.cfg.Installation.FIPSMode)},
+	}
+}
+
+func (c *component) volumes() []corev1.Volume {
+	volumes := []corev1.Volume{
+		c.cfg.KeyPair.Volume(),
+		c.cfg.TrustedBundle.Volume(),
+	}
+	if c.cfg.ManagedCluster {
+		volumes = append(volumes, corev1.Volume{
+			Name: render.LinseedTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: fmt.Sprintf(render.LinseedTokenSecret, RuntimeSecurityName),
+					Items:      []corev1.KeyToPath{{Key: render.LinseedTokenKey, Path: render.LinseedTokenSubPath}},
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+func (c *component) volumeMounts() []corev1.VolumeMount {
+	volumeMounts := append(
+		c.cfg.TrustedBundle.VolumeMounts(c.SupportedOSType()),
+		c.cfg.KeyPair.VolumeMount(c.SupportedOSType()),
+	)
+	if c.cfg.ManagedCluster {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      render.LinseedTokenVolumeName,
+			MountPath: render.LinseedVolumeMountPath,
+		})
+	}
+	return volumeMounts
+}
+
+func (c *component) annotations() map[string]string {
+	annotations := c.cfg.TrustedBundle.HashAnnotations()
+	annotations[c.cfg.KeyPair.HashAnnotationKey()] = c.cfg.KeyPair.HashAnnotationValue()
+	return annotations
+}
+
+func (c *component) serviceAccount() *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RuntimeSecurityName,
+			Namespace: RuntimeSecurityNamespace,
+		},
+	}
+}
+
+func (c *component) clusterRole() *rbacv1.ClusterRole {
+	role := &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: RuntimeSecurityName,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				// Used to add the pod and namespace of a container to the events that are detected in it.
+				APIGroups: []string{""},
+				Resources: []string{"pods", "namespaces"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	if c.cfg.OpenShift {
+		role.Rules = append(role.Rules, rbacv1.PolicyRule{
+			APIGroups:     []string{"security.openshift.io"},
+			Resources:     []string{"securitycontextconstraints"},
+			Verbs:         []string{"use"},
+			ResourceNames: []string{securitycontextconstraints.Privileged},
+		})
+	}
+	return role
+}
+
+func (c *component) clusterRoleBinding() *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: RuntimeSecurityName,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     RuntimeSecurityName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      RuntimeSecurityName,
+				Namespace: RuntimeSecurityNamespace,
+			},
+		},
+	}
+}
+
+func (c *component) linseedAccessClusterRole() *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: RuntimeSecurityLinseedRBACName,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				// Add write access to Linseed APIs.
+				APIGroups: []string{"linseed.tigera.io"},
+				Resources: []string{"events"},
+				Verbs:     []string{"create"},
+			},
+		},
+	}
+}
+
+func (c *component) linseedAccessClusterRoleBinding() *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: RuntimeSecurityLinseedRBACName,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     RuntimeSecurityLinseedRBACName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      RuntimeSecurityName,
+				Namespace: RuntimeSecurityNamespace,
+			},
+		},
+	}
+}
+
+func (c *component) externalLinseedRoleBinding() *rbacv1.RoleBinding {
+	linseed := "tigera-linseed"
+	return &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{Kind: "RoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      linseed,
+			Namespace: RuntimeSecurityNamespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     render.TigeraLinseedSecretsClusterRole,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      linseed,
+				Namespace: render.ElasticsearchNamespace,
+			},
+		},
+	}
+}
+
+// allowTigeraPolicy allows the runtime security agent to reach the Kubernetes API server, DNS and Linseed, which is
+// reached through Guardian in managed clusters.
+func (c *component) allowTigeraPolicy() *v3.NetworkPolicy {
+	egressRules := []v3.Rule{
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: networkpolicy.KubeAPIServerServiceSelectorEntityRule,
+		},
+	}
+	egressRules = networkpolicy.AppendServiceSelectorDNSEgressRules(egressRules, c.cfg.OpenShift)
+
+	if c.cfg.ManagedCluster {
+		egressRules = append(egressRules, v3.Rule{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: render.GuardianServiceSelectorEntityRule,
+		})
+	} else {
+		egressRules = append(egressRules, v3.Rule{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: networkpolicy.DefaultHelper().LinseedServiceSelectorEntityRule(),
+		})
+	}
+
+	return &v3.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RuntimeSecurityPolicyName,
+			Namespace: RuntimeSecurityNamespace,
+		},
+		Spec: v3.NetworkPolicySpec{
+			Order:    &networkpolicy.HighPrecedenceOrder,
+			Tier:     networkpolicy.TigeraComponentTierName,
+			Selector: networkpolicy.KubernetesAppSelector(RuntimeSecurityName),
+			Types:    []v3.PolicyType{v3.PolicyTypeEgress},
+			Egress:   egressRules,
+		},
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimesecurity_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/render"
+	rtest "github.com/tigera/operator/pkg/render/common/test"
+	"github.com/tigera/operator/pkg/render/runtimesecurity"
+)
+
+var _ = Describe("Runtime security rendering tests", func() {
+	var cfg *runtimesecurity.Config
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli := ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		certificateManager, err := certificatemanager.Create(cli, nil, dns.DefaultClusterDomain, common.OperatorNamespace(), certificatemanager.AllowCACreation())
+		Expect(err).NotTo(HaveOccurred())
+		keyPair, err := certificateManager.GetOrCreateKeyPair(cli, runtimesecurity.RuntimeSecurityTLSSecretName, common.OperatorNamespace(), []string{runtimesecurity.RuntimeSecurityTLSSecretName})
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg = &runtimesecurity.Config{
+			RuntimeSecurity: &operatorv1.RuntimeSecurity{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}},
+			Installation:    &operatorv1.InstallationSpec{Registry: "testregistry.com/"},
+			PullSecrets: []*corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: common.OperatorNamespace()}},
+			},
+			ClusterDomain: dns.DefaultClusterDomain,
+			KeyPair:       keyPair,
+			TrustedBundle: certificateManager.CreateTrustedBundle(),
+		}
+	})
+
+	It("should render all resources for a standalone cluster", func() {
+		component := runtimesecurity.RuntimeSecurity(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		rtest.ExpectResources(toCreate, []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: runtimesecurity.RuntimeSecurityNamespace}},
+			&v3.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: runtimesecurity.RuntimeSecurityPolicyName, Namespace: runtimesecurity.RuntimeSecurityNamespace}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: runtimesecurity.RuntimeSecurityNamespace}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: runtimesecurity.RuntimeSecurityName, Namespace: runtimesecurity.RuntimeSecurityNamespace}},
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: runtimesecurity.RuntimeSecurityName}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: runtimesecurity.RuntimeSecurityName}},
+			&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: runtimesecurity.RuntimeSecurityName, Namespace: runtimesecurity.RuntimeSecurityNamespace}},
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: runtimesecurity.RuntimeSecurityLinseedRBACName}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: runtimesecurity.RuntimeSecurityLinseedRBACName}},
+		})
+		rtest.ExpectResources(toDelete, []client.Object{
+			&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "tigera-linseed", Namespace: runtimesecurity.RuntimeSecurityNamespace}},
+		})
+
+		ds := rtest.GetResource(toCreate, runtimesecurity.RuntimeSecurityName, runtimesecurity.RuntimeSecurityNamespace, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(ds.Spec.Template.Spec.HostPID).To(BeTrue())
+		Expect(ds.Spec.Template.Spec.Containers).To(HaveLen(1))
+		container := ds.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(HavePrefix("testregistry.com/tigera/runtime-security"))
+		Expect(*container.SecurityContext.Privileged).To(BeTrue())
+		Expect(container.Env).To(ContainElements(
+			corev1.EnvVar{Name: "LINSEED_URL", Value: "https://tigera-linseed.tigera-elasticsearch.svc"},
+			corev1.EnvVar{Name: "LINSEED_CA", Value: "/etc/pki/tls/certs/tigera-ca-bundle.crt"},
+			corev1.EnvVar{Name: "LINSEED_CLIENT_CERT", Value: "/tigera-runtime-security-tls/tls.crt"},
+			corev1.EnvVar{Name: "LINSEED_CLIENT_KEY", Value: "/tigera-runtime-security-tls/tls.key"},
+			corev1.EnvVar{Name: "LINSEED_TOKEN", Value: "/var/run/secrets/kubernetes.io/serviceaccount/token"},
+		))
+
+		policy := rtest.GetResource(toCreate, runtimesecurity.RuntimeSecurityPolicyName, runtimesecurity.RuntimeSecurityNamespace, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+		Expect(policy.Spec.Egress[len(policy.Spec.Egress)-1].Destination.Services).To(Equal(&v3.ServiceMatch{Namespace: render.ElasticsearchNamespace, Name: "tigera-linseed"}))
+	})
+
+	It("should forward events through Guardian in a managed cluster", func() {
+		cfg.ManagedCluster = true
+		toCreate, toDelete := runtimesecurity.RuntimeSecurity(cfg).Objects()
+
+		rtest.ExpectResourceInList(toCreate, "tigera-linseed", runtimesecurity.RuntimeSecurityNamespace, "rbac.authorization.k8s.io", "v1", "RoleBinding")
+		rtest.ExpectResourceInList(toDelete, runtimesecurity.RuntimeSecurityLinseedRBACName, "", "rbac.authorization.k8s.io", "v1", "ClusterRole")
+		rtest.ExpectResourceInList(toDelete, runtimesecurity.RuntimeSecurityLinseedRBACName, "", "rbac.authorization.k8s.io", "v1", "ClusterRoleBinding")
+
+		ds := rtest.GetResource(toCreate, runtimesecurity.RuntimeSecurityName, runtimesecurity.RuntimeSecurityNamespace, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		container := ds.Spec.Template.Spec.Containers[0]
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "LINSEED_TOKEN", Value: render.LinseedTokenPath}))
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: render.LinseedTokenVolumeName, MountPath: render.LinseedVolumeMountPath}))
+		Expect(ds.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Secret.SecretName", "tigera-runtime-security-tigera-linseed-token")))
+
+		policy := rtest.GetResource(toCreate, runtimesecurity.RuntimeSecurityPolicyName, runtimesecurity.RuntimeSecurityNamespace, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+		Expect(policy.Spec.Egress[len(policy.Spec.Egress)-1].Destination).To(Equal(render.GuardianServiceSelectorEntityRule))
+	})
+
+	It("should apply the resources and node selector of the spec", func() {
+		resources := &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		}
+		cfg.RuntimeSecurity.Spec = operatorv1.RuntimeSecuritySpec{
+			Resources:    resources,
+			NodeSelector: map[string]string{"runtime-security": "enabled"},
+		}
+		toCreate, _ := runtimesecurity.RuntimeSecurity(cfg).Objects()
+
+		ds := rtest.GetResource(toCreate, runtimesecurity.RuntimeSecurityName, runtimesecurity.RuntimeSecurityNamespace, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(ds.Spec.Template.Spec.Containers[0].Resources).To(Equal(*resources))
+		Expect(ds.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"runtime-security": "enabled"}))
+	})
+
+	It("should allow the use of the privileged SCC on OpenShift", func() {
+		cfg.OpenShift = true
+		toCreate, _ := runtimesecurity.RuntimeSecurity(cfg).Objects()
+
+		role := rtest.GetResource(toCreate, runtimesecurity.RuntimeSecurityName, "", "rbac.authorization.k8s.io", "v1", "ClusterRole").(*rbacv1.ClusterRole)
+		Expect(role.Rules).To(ContainElement(rbacv1.PolicyRule{
+			APIGroups:     []string{"security.openshift.io"},
+			Resources:     []string{"securitycontextconstraints"},
+			Verbs:         []string{"use"},
+			ResourceNames: []string{"privileged"},
+		}))
+	})
+})
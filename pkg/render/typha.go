@@ -82,6 +82,7 @@ type typhaComponent struct {
 
 	// Generated internal config, built from the given configuration.
 	typhaImage string
+	amd64Only  bool
 }
 
 func (c *typhaComponent) ResolveImages(is *operatorv1.ImageSet) error {
@@ -92,11 +93,12 @@ func (c *typhaComponent) ResolveImages(is *operatorv1.ImageSet) error {
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
 		c.typhaImage, err = components.GetReference(components.ComponentTigeraTypha, reg, path, prefix, is)
 	} else {
+		typha := components.ComponentCalicoTypha
 		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.typhaImage, err = components.GetReference(components.ComponentCalicoTyphaFIPS, reg, path, prefix, is)
-		} else {
-			c.typhaImage, err = components.GetReference(components.ComponentCalicoTypha, reg, path, prefix, is)
+			typha = components.ComponentCalicoTyphaFIPS
 		}
+		c.typhaImage, err = components.GetReference(typha, reg, path, prefix, is)
+		c.amd64Only = !components.SupportsArchitecture(typha, components.ArchitectureARM64)
 	}
 	if err != nil {
 		return err
@@ -385,7 +387,7 @@ func (c *typhaComponent) typhaDeployment() *appsv1.Deployment {
 	// Allow tolerations to be overwritten by the end-user.
 	tolerations := rmeta.TolerateAll
 	if len(c.cfg.Installation.ControlPlaneTolerations) != 0 {
-		tolerations = c.cfg.Installation.ControlPlaneTolerations
+		tolerations = rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.Workload{AMD64Only: c.amd64Only, CriticalAddon: true})
 	}
 
 	d := appsv1.Deployment{
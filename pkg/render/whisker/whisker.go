@@ -0,0 +1,358 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package whisker renders Whisker, the flow log visualization UI, and Goldmane, the flow log aggregator that it reads
+// flows from.
+package whisker
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/ptr"
+	"github.com/tigera/operator/pkg/render"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/render/common/securitycontext"
+	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+const (
+	Namespace = common.CalicoNamespace
+
+	WhiskerName                 = "whisker"
+	WhiskerServiceAccountName   = WhiskerName
+	WhiskerDeploymentName       = WhiskerName
+	WhiskerServiceName          = WhiskerName
+	WhiskerPolicyName           = "allow-" + WhiskerName
+	WhiskerContainerName        = WhiskerName
+	WhiskerBackendContainerName = "whisker-backend"
+	WhiskerPort                 = 8081
+	WhiskerBackendPort          = 3002
+
+	GoldmaneName               = "goldmane"
+	GoldmaneServiceAccountName = GoldmaneName
+	GoldmaneDeploymentName     = GoldmaneName
+	GoldmaneServiceName        = GoldmaneName
+	GoldmanePolicyName         = "allow-" + GoldmaneName
+	GoldmaneContainerName      = GoldmaneName
+	GoldmanePort               = 7443
+	GoldmaneKeyPairSecret      = "goldmane-key-pair"
+)
+
+// GoldmaneServer returns the address that Felix and Whisker connect to Goldmane on.
+func GoldmaneServer(clusterDomain string) string {
+	return fmt.Sprintf("%s.%s.svc.%s:%d", GoldmaneServiceName, Namespace, clusterDomain, GoldmanePort)
+}
+
+// Config contains the information needed to render Whisker and Goldmane.
+type Config struct {
+	Whisker      *operatorv1.Whisker
+	Installation *operatorv1.InstallationSpec
+	PullSecrets  []*corev1.Secret
+	OpenShift    bool
+
+	// GoldmaneKeyPair is the key pair that Goldmane serves flows with.
+	GoldmaneKeyPair certificatemanagement.KeyPairInterface
+
+	// TrustedBundle is the bundle of the calico-system namespace, which Whisker uses to verify Goldmane.
+	TrustedBundle certificatemanagement.TrustedBundleRO
+
+	ClusterDomain string
+}
+
+func Whisker(cfg *Config) render.Component {
+	return &component{cfg: cfg}
+}
+
+type component struct {
+	cfg *Config
+
+	whiskerImage        string
+	whiskerBackendImage string
+	goldmaneImage       string
+}
+
+func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
+	reg := c.cfg.Installation.Registry
+	path := c.cfg.Installation.ImagePath
+	prefix := c.cfg.Installation.ImagePrefix
+
+	var err error
+	if c.whiskerImage, err = components.GetReference(components.ComponentCalicoWhisker, reg, path, prefix, is); err != nil {
+		return err
+	}
+	if c.whiskerBackendImage, err = components.GetReference(components.ComponentCalicoWhiskerBackend, reg, path, prefix, is); err != nil {
+		return err
+	}
+	if c.goldmaneImage, err = components.GetReference(components.ComponentCalicoGoldmane, reg, path, prefix, is); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *component) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeLinux
+}
+
+func (c *component) Ready() bool {
+	return true
+}
+
+func (c *component) Objects() ([]client.Object, []client.Object) {
+	objs := []client.Object{
+		serviceAccount(GoldmaneServiceAccountName),
+		serviceAccount(WhiskerServiceAccountName),
+	}
+
+	// The components only need access to the Kubernetes API on OpenShift, to use the security context constraints.
+	var toDelete []client.Object
+	sccObjs := []client.Object{
+		clusterRole(GoldmaneName),
+		clusterRoleBinding(GoldmaneName, GoldmaneServiceAccountName),
+		clusterRole(WhiskerName),
+		clusterRoleBinding(WhiskerName, WhiskerServiceAccountName),
+	}
+	if c.cfg.OpenShift {
+		objs = append(objs, sccObjs...)
+	} else {
+		toDelete = append(toDelete, sccObjs...)
+	}
+
+	objs = append(objs,
+		c.goldmaneDeployment(),
+		service(GoldmaneServiceName, GoldmaneName, GoldmanePort),
+		networkPolicy(GoldmanePolicyName, GoldmaneName, GoldmanePort),
+		c.whiskerDeployment(),
+		service(WhiskerServiceName, WhiskerName, WhiskerPort),
+		networkPolicy(WhiskerPolicyName, WhiskerName, WhiskerPort),
+	)
+	return objs, toDelete
+}
+
+func serviceAccount(name string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		TypeMeta:   metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: Namespace},
+	}
+}
+
+func clusterRole(name string) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "calico-" + name},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{"security.openshift.io"},
+				Resources:     []string{"securitycontextconstraints"},
+				Verbs:         []string{"use"},
+				ResourceNames: []string{securitycontextconstraints.NonRootV2},
+			},
+		},
+	}
+}
+
+func clusterRoleBinding(name, serviceAccount string) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "calico-" + name},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "calico-" + name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      serviceAccount,
+				Namespace: Namespace,
+			},
+		},
+	}
+}
+
+func (c *component) goldmaneDeployment() *appsv1.Deployment {
+	keyPair := c.cfg.GoldmaneKeyPair
+	annotations := map[string]string{keyPair.HashAnnotationKey(): keyPair.HashAnnotationValue()}
+	for k, v := range c.cfg.TrustedBundle.HashAnnotations() {
+		annotations[k] = v
+	}
+
+	var initContainers []corev1.Container
+	if keyPair.UseCertificateManagement() {
+		initContainers = append(initContainers, keyPair.InitContainer(Namespace))
+	}
+
+	container := corev1.Container{
+		Name:            GoldmaneContainerName,
+		Image:           c.goldmaneImage,
+		ImagePullPolicy: render.ImagePullPolicy(),
+		Env: []corev1.EnvVar{
+			{Name: "LOG_LEVEL", Value: "INFO"},
+			{Name: "PORT", Value: fmt.Sprintf("%d", GoldmanePort)},
+			{Name: "SERVER_CERT_PATH", Value: keyPair.VolumeMountCertificateFilePath()},
+			{Name: "SERVER_KEY_PATH", Value: keyPair.VolumeMountKeyFilePath()},
+			{Name: "CA_CERT_PATH", Value: c.cfg.TrustedBundle.MountPath()},
+		},
+		ReadinessProbe:  tcpProbe(GoldmanePort),
+		LivenessProbe:   tcpProbe(GoldmanePort),
+		SecurityContext: securitycontext.NewNonRootContext(),
+		VolumeMounts: append(
+			[]corev1.VolumeMount{keyPair.VolumeMount(c.SupportedOSType())},
+			c.cfg.TrustedBundle.VolumeMounts(c.SupportedOSType())...,
+		),
+	}
+
+	return c.deployment(GoldmaneDeploymentName, GoldmaneServiceAccountName, annotations, initContainers,
+		[]corev1.Container{container},
+		[]corev1.Volume{keyPair.Volume(), c.cfg.TrustedBundle.Volume()},
+	)
+}
+
+func (c *component) whiskerDeployment() *appsv1.Deployment {
+	notifications := operatorv1.WhiskerNotificationsEnabled
+	if c.cfg.Whisker != nil && c.cfg.Whisker.Spec.Notifications != nil {
+		notifications = *c.cfg.Whisker.Spec.Notifications
+	}
+
+	whisker := corev1.Container{
+		Name:            WhiskerContainerName,
+		Image:           c.whiskerImage,
+		ImagePullPolicy: render.ImagePullPolicy(),
+		Env: []corev1.EnvVar{
+			{Name: "LOG_LEVEL", Value: "INFO"},
+			{Name: "NOTIFICATIONS", Value: string(notifications)},
+		},
+		ReadinessProbe:  tcpProbe(WhiskerPort),
+		LivenessProbe:   tcpProbe(WhiskerPort),
+		SecurityContext: securitycontext.NewNonRootContext(),
+	}
+
+	backend := corev1.Container{
+		Name:            WhiskerBackendContainerName,
+		Image:           c.whiskerBackendImage,
+		ImagePullPolicy: render.ImagePullPolicy(),
+		Env: []corev1.EnvVar{
+			{Name: "LOG_LEVEL", Value: "INFO"},
+			{Name: "PORT", Value: fmt.Sprintf("%d", WhiskerBackendPort)},
+			{Name: "GOLDMANE_HOST", Value: GoldmaneServer(c.cfg.ClusterDomain)},
+			{Name: "CA_CERT_PATH", Value: c.cfg.TrustedBundle.MountPath()},
+		},
+		ReadinessProbe:  tcpProbe(WhiskerBackendPort),
+		LivenessProbe:   tcpProbe(WhiskerBackendPort),
+		SecurityContext: securitycontext.NewNonRootContext(),
+		VolumeMounts:    c.cfg.TrustedBundle.VolumeMounts(c.SupportedOSType()),
+	}
+
+	return c.deployment(WhiskerDeploymentName, WhiskerServiceAccountName, c.cfg.TrustedBundle.HashAnnotations(), nil,
+		[]corev1.Container{whisker, backend},
+		[]corev1.Volume{c.cfg.TrustedBundle.Volume()},
+	)
+}
+
+func (c *component) deployment(name, serviceAccount string, annotations map[string]string, initContainers, containers []corev1.Container, volumes []corev1.Volume) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32ToPtr(1),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   Namespace,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
+					ServiceAccountName: serviceAccount,
+					Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.Workload{CriticalAddon: true}, rmeta.TolerateCriticalAddonsAndControlPlane...),
+					ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
+					InitContainers:     initContainers,
+					Containers:         containers,
+					Volumes:            volumes,
+				},
+			},
+		},
+	}
+}
+
+func service(name, app string, port int) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"k8s-app": app},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       name,
+					Port:       int32(port),
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt(port),
+				},
+			},
+		},
+	}
+}
+
+// networkPolicy returns a NP to allow traffic to the port that the app serves on, so that it is not cut off by
+// policies that select the calico-system namespace. Goldmane receives flows from Felix on every node, and Whisker is
+// reached by users through a port-forward or a Service of their own, so the traffic is allowed from any source.
+func networkPolicy(name, app string, port int) *netv1.NetworkPolicy {
+	tcp := corev1.ProtocolTCP
+	p := intstr.FromInt(port)
+	return &netv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "networking.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: Namespace},
+		Spec: netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": app}},
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+			Ingress: []netv1.NetworkPolicyIngressRule{
+				{
+					Ports: []netv1.NetworkPolicyPort{
+						{
+							Protocol: &tcp,
+							Port:     &p,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func tcpProbe(port int) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(port)},
+		},
+		InitialDelaySeconds: 10,
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whisker_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/dns"
+	rtest "github.com/tigera/operator/pkg/render/common/test"
+	"github.com/tigera/operator/pkg/render/whisker"
+)
+
+var _ = Describe("Whisker rendering tests", func() {
+	var cfg *whisker.Config
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli := ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		certificateManager, err := certificatemanager.Create(cli, nil, dns.DefaultClusterDomain, common.OperatorNamespace(), certificatemanager.AllowCACreation())
+		Expect(err).NotTo(HaveOccurred())
+		keyPair, err := certificateManager.GetOrCreateKeyPair(cli, whisker.GoldmaneKeyPairSecret, common.OperatorNamespace(), []string{whisker.GoldmaneServiceName})
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg = &whisker.Config{
+			Whisker:         &operatorv1.Whisker{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			Installation:    &operatorv1.InstallationSpec{Variant: operatorv1.Calico},
+			PullSecrets:     []*corev1.Secret{{ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: common.OperatorNamespace()}}},
+			GoldmaneKeyPair: keyPair,
+			TrustedBundle:   certificateManager.CreateTrustedBundle(),
+			ClusterDomain:   dns.DefaultClusterDomain,
+		}
+	})
+
+	It("renders Whisker and Goldmane", func() {
+		component := whisker.Whisker(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		rtest.ExpectResources(toCreate, []client.Object{
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "goldmane", Namespace: "calico-system"}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "whisker", Namespace: "calico-system"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "goldmane", Namespace: "calico-system"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "goldmane", Namespace: "calico-system"}},
+			&netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "allow-goldmane", Namespace: "calico-system"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "whisker", Namespace: "calico-system"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "whisker", Namespace: "calico-system"}},
+			&netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "allow-whisker", Namespace: "calico-system"}},
+		})
+		rtest.ExpectResources(toDelete, []client.Object{
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "calico-goldmane"}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "calico-goldmane"}},
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "calico-whisker"}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "calico-whisker"}},
+		})
+
+		goldmane, err := rtest.GetResourceOfType[*appsv1.Deployment](toCreate, "goldmane", "calico-system")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(goldmane.Spec.Template.Spec.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "pull-secret"}))
+		Expect(goldmane.Spec.Template.Spec.Containers).To(HaveLen(1))
+		container := goldmane.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(ContainSubstring("calico/goldmane"))
+		rtest.ExpectEnv(container.Env, "PORT", "7443")
+		rtest.ExpectEnv(container.Env, "SERVER_CERT_PATH", cfg.GoldmaneKeyPair.VolumeMountCertificateFilePath())
+		rtest.ExpectEnv(container.Env, "SERVER_KEY_PATH", cfg.GoldmaneKeyPair.VolumeMountKeyFilePath())
+		Expect(goldmane.Spec.Template.Annotations).To(HaveKey(cfg.GoldmaneKeyPair.HashAnnotationKey()))
+
+		ui, err := rtest.GetResourceOfType[*appsv1.Deployment](toCreate, "whisker", "calico-system")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ui.Spec.Template.Spec.Containers).To(HaveLen(2))
+		rtest.ExpectEnv(rtest.GetContainer(ui.Spec.Template.Spec.Containers, "whisker").Env, "NOTIFICATIONS", "Enabled")
+		backend := rtest.GetContainer(ui.Spec.Template.Spec.Containers, "whisker-backend")
+		Expect(backend.Image).To(ContainSubstring("calico/whisker-backend"))
+		rtest.ExpectEnv(backend.Env, "GOLDMANE_HOST", "goldmane.calico-system.svc.cluster.local:7443")
+		rtest.ExpectEnv(backend.Env, "CA_CERT_PATH", cfg.TrustedBundle.MountPath())
+	})
+
+	It("disables notifications", func() {
+		disabled := operatorv1.WhiskerNotificationsDisabled
+		cfg.Whisker.Spec.Notifications = &disabled
+		toCreate, _ := whisker.Whisker(cfg).Objects()
+
+		ui, err := rtest.GetResourceOfType[*appsv1.Deployment](toCreate, "whisker", "calico-system")
+		Expect(err).NotTo(HaveOccurred())
+		rtest.ExpectEnv(rtest.GetContainer(ui.Spec.Template.Spec.Containers, "whisker").Env, "NOTIFICATIONS", "Disabled")
+	})
+
+	It("allows the use of the security context constraints on OpenShift", func() {
+		cfg.OpenShift = true
+		toCreate, toDelete := whisker.Whisker(cfg).Objects()
+		Expect(toDelete).To(BeEmpty())
+
+		role := rtest.GetResource(toCreate, "calico-whisker", "", "rbac.authorization.k8s.io", "v1", "ClusterRole").(*rbacv1.ClusterRole)
+		Expect(role.Rules).To(ConsistOf(rbacv1.PolicyRule{
+			APIGroups:     []string{"security.openshift.io"},
+			Resources:     []string{"securitycontextconstraints"},
+			Verbs:         []string{"use"},
+			ResourceNames: []string{"nonroot-v2"},
+		}))
+		binding := rtest.GetResource(toCreate, "calico-goldmane", "", "rbac.authorization.k8s.io", "v1", "ClusterRoleBinding").(*rbacv1.ClusterRoleBinding)
+		Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: "ServiceAccount", Name: "goldmane", Namespace: "calico-system"}))
+	})
+})
@@ -339,7 +339,7 @@ func (c *windowsComponent) createCalicoPluginConfig() map[string]interface{} {
 	dns := map[string]interface{}{
 		"Nameservers": c.cfg.K8sDNSServers,
 		"Search": []string{
-			"svc.cluster.local",
+			fmt.Sprintf("svc.%s", c.cfg.ClusterDomain),
 		},
 	}
 	calicoPluginConfig["DNS"] = dns
@@ -48,7 +48,7 @@ var _ = Describe("Windows rendering tests", func() {
 	var k8sServiceEp k8sapi.ServiceEndpoint
 	one := intstr.FromInt(1)
 	defaultNumExpectedResources := 2
-	const defaultClusterDomain = "svc.cluster.local"
+	const defaultClusterDomain = "cluster.local"
 	var defaultMode int32 = 420
 	var cfg render.WindowsConfiguration
 	var cli client.Client
@@ -0,0 +1,171 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tigera/operator/pkg/components"
+)
+
+const (
+	// componentVolumeName is the name of the volume that holds the TLS material of the OTLP secret in component pods.
+	componentVolumeName = "otlp-credentials"
+	// componentMountPath is where the TLS material of the OTLP secret is mounted in component containers.
+	componentMountPath = "/etc/otlp"
+)
+
+// componentTelemetry holds the settings that point rendered components at the OTLP endpoint, or nil if component
+// telemetry is not enabled. It is set once at startup; the operator restarts when its bootstrap configuration changes.
+var componentTelemetry *componentConfig
+
+type componentConfig struct {
+	endpoint string
+
+	// secret is the OTLP secret, or nil if there is none. Components read it from a mirror in their own namespace.
+	secret *corev1.Secret
+
+	// The keys of the secret that the components use.
+	headers, ca, clientCert bool
+}
+
+func newComponentConfig(cfg *Config) *componentConfig {
+	c := &componentConfig{endpoint: cfg.Endpoint, secret: cfg.Secret}
+	if c.secret == nil {
+		return c
+	}
+	_, c.headers = c.secret.Data[HeadersKey]
+	if cfg.TLSConfig != nil {
+		_, c.ca = c.secret.Data[CACertKey]
+		c.clientCert = len(cfg.TLSConfig.Certificates) > 0
+	}
+	return c
+}
+
+// ComponentSecret returns the OTLP secret that ConfigureComponent points components at, or nil if there is none. A
+// mirror of it, see secret.MirrorToNamespace, must exist in the namespace of each configured component.
+func ComponentSecret() *corev1.Secret {
+	if componentTelemetry == nil || (!componentTelemetry.headers && !componentTelemetry.ca && !componentTelemetry.clientCert) {
+		return nil
+	}
+	return componentTelemetry.secret
+}
+
+// ConfigureComponent points the containers of the pod spec that run Tigera images at the OTLP endpoint, with the
+// headers and TLS material of the OTLP secret, if component telemetry is enabled. The OpenTelemetry SDKs in the
+// components pick up the standard OTEL_* environment; the service name is set to the name of the container. Variables
+// that a container sets itself are left as-is. It returns true if any container was configured.
+func ConfigureComponent(podSpec *corev1.PodSpec) bool {
+	c := componentTelemetry
+	if c == nil {
+		return false
+	}
+
+	configured := false
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		if !components.IsTigeraImage(container.Image) {
+			continue
+		}
+		configured = true
+
+		env := []corev1.EnvVar{
+			{Name: "OTEL_SERVICE_NAME", Value: strings.ToLower(container.Name)},
+			{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: c.endpoint},
+			{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"},
+		}
+		if c.headers {
+			// The headers usually carry credentials, so they are read from the secret rather than put in the pod spec.
+			env = append(env, corev1.EnvVar{
+				Name: "OTEL_EXPORTER_OTLP_HEADERS",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: c.secret.Name},
+					Key:                  HeadersKey,
+				}},
+			})
+		}
+		if c.ca {
+			env = append(env, corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_CERTIFICATE", Value: filepath.Join(componentMountPath, CACertKey)})
+		}
+		if c.clientCert {
+			env = append(env,
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE", Value: filepath.Join(componentMountPath, ClientCertKey)},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_CLIENT_KEY", Value: filepath.Join(componentMountPath, ClientKeyKey)},
+			)
+		}
+		for _, e := range env {
+			if !hasEnv(container.Env, e.Name) {
+				container.Env = append(container.Env, e)
+			}
+		}
+		if (c.ca || c.clientCert) && !hasVolumeMount(container.VolumeMounts, componentVolumeName) {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      componentVolumeName,
+				MountPath: componentMountPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	if configured && (c.ca || c.clientCert) && !hasVolume(podSpec.Volumes, componentVolumeName) {
+		var items []corev1.KeyToPath
+		if c.ca {
+			items = append(items, corev1.KeyToPath{Key: CACertKey, Path: CACertKey})
+		}
+		if c.clientCert {
+			items = append(items,
+				corev1.KeyToPath{Key: ClientCertKey, Path: ClientCertKey},
+				corev1.KeyToPath{Key: ClientKeyKey, Path: ClientKeyKey},
+			)
+		}
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: componentVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: c.secret.Name, Items: items},
+			},
+		})
+	}
+	return configured
+}
+
+func hasEnv(env []corev1.EnvVar, name string) bool {
+	for _, e := range env {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeMount(mounts []corev1.VolumeMount, name string) bool {
+	for _, m := range mounts {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, v := range volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/tigera/operator/pkg/common"
+)
+
+const (
+	// EndpointKey is the key in the operator bootstrap configmap that holds the URL of the OTLP/HTTP receiver that
+	// operator telemetry is exported to, e.g. https://otel-collector.observability.svc:4318. Telemetry export is
+	// disabled when it is not set.
+	EndpointKey = "OTLP_ENDPOINT"
+
+	// SecretKey is the key in the operator bootstrap configmap that holds the name of a secret in the operator
+	// namespace with the TLS and authentication material used to connect to the OTLP endpoint.
+	SecretKey = "OTLP_SECRET"
+
+	// ComponentTelemetryKey is the key in the operator bootstrap configmap that, when set to "true", points the
+	// components that the operator renders at the OTLP endpoint as well.
+	ComponentTelemetryKey = "OTLP_COMPONENT_TELEMETRY"
+
+	// Keys of the OTLP secret. All of them are optional.
+	CACertKey     = "ca.crt"
+	ClientCertKey = corev1.TLSCertKey
+	ClientKeyKey  = corev1.TLSPrivateKeyKey
+	// HeadersKey holds the headers sent with every export request, using the same key1=value1,key2=value2 format as
+	// OTEL_EXPORTER_OTLP_HEADERS. This is where authentication headers such as bearer tokens belong.
+	HeadersKey = "headers"
+)
+
+// Config is the configuration of the OTLP exporter used by the operator.
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP receiver. The scheme determines whether TLS is used.
+	Endpoint string
+
+	// TLSConfig is the TLS configuration used for https endpoints, or nil to use the system defaults.
+	TLSConfig *tls.Config
+
+	// Headers are sent with every export request.
+	Headers map[string]string
+
+	// Secret is the secret that the headers and TLS material are read from, or nil if there is none. Components are
+	// pointed at the same material when component telemetry is enabled.
+	Secret *corev1.Secret
+
+	// ComponentTelemetry is true if rendered components should export their telemetry to the endpoint as well.
+	ComponentTelemetry bool
+}
+
+// ConfigFromBootstrap builds the exporter configuration from the operator bootstrap configmap. It returns nil if
+// telemetry export is not configured.
+func ConfigFromBootstrap(ctx context.Context, cs kubernetes.Interface, cm *corev1.ConfigMap) (*Config, error) {
+	if cm == nil || cm.Data[EndpointKey] == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(cm.Data[EndpointKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", EndpointKey, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid %s %q: the scheme must be http or https", EndpointKey, cm.Data[EndpointKey])
+	}
+
+	cfg := &Config{
+		Endpoint:           u.String(),
+		ComponentTelemetry: strings.ToLower(cm.Data[ComponentTelemetryKey]) == "true",
+	}
+
+	name := cm.Data[SecretKey]
+	if name == "" {
+		return cfg, nil
+	}
+	secret, err := cs.CoreV1().Secrets(common.OperatorNamespace()).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OTLP secret %s/%s: %w", common.OperatorNamespace(), name, err)
+	}
+	cfg.Secret = secret
+
+	if headers, ok := secret.Data[HeadersKey]; ok {
+		cfg.Headers, err = parseHeaders(string(headers))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in secret %s/%s: %w", HeadersKey, common.OperatorNamespace(), name, err)
+		}
+	}
+
+	if u.Scheme == "https" {
+		cfg.TLSConfig, err = tlsConfig(secret)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS configuration in secret %s/%s: %w", common.OperatorNamespace(), name, err)
+		}
+	}
+	return cfg, nil
+}
+
+// parseHeaders parses headers in the key1=value1,key2=value2 format.
+func parseHeaders(s string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, kv := range strings.Split(strings.TrimSpace(s), ",") {
+		if strings.TrimSpace(kv) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("header %q is not in the key=value format", kv)
+		}
+		value, err := url.PathUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		headers[strings.TrimSpace(k)] = value
+	}
+	return headers, nil
+}
+
+func tlsConfig(secret *corev1.Secret) (*tls.Config, error) {
+	_, hasCA := secret.Data[CACertKey]
+	_, hasCert := secret.Data[ClientCertKey]
+	_, hasKey := secret.Data[ClientKeyKey]
+	if !hasCA && !hasCert && !hasKey {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if hasCA {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(secret.Data[CACertKey]) {
+			return nil, fmt.Errorf("%s does not contain a PEM encoded certificate", CACertKey)
+		}
+		cfg.RootCAs = pool
+	}
+	if hasCert != hasKey {
+		return nil, fmt.Errorf("%s and %s must be set together", ClientCertKey, ClientKeyKey)
+	}
+	if hasCert {
+		cert, err := tls.X509KeyPair(secret.Data[ClientCertKey], secret.Data[ClientKeyKey])
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.uber.org/zap/zapcore"
+)
+
+// logCore is a zapcore.Core that emits log entries as OpenTelemetry log records through the global logger provider.
+type logCore struct {
+	logger log.Logger
+	attrs  []log.KeyValue
+}
+
+func newLogCore(name string) zapcore.Core {
+	return &logCore{logger: global.Logger(name)}
+}
+
+// Enabled always returns true, the levels are restricted by the core that logCore is teed with.
+func (c *logCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c *logCore) With(fields []zapcore.Field) zapcore.Core {
+	return &logCore{logger: c.logger, attrs: append(append([]log.KeyValue{}, c.attrs...), logAttributes(fields)...)}
+}
+
+func (c *logCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(e, c)
+}
+
+func (c *logCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	var r log.Record
+	r.SetTimestamp(e.Time)
+	r.SetBody(log.StringValue(e.Message))
+	r.SetSeverity(logSeverity(e.Level))
+	r.SetSeverityText(e.Level.String())
+	if e.LoggerName != "" {
+		r.AddAttributes(log.String("logger", e.LoggerName))
+	}
+	r.AddAttributes(c.attrs...)
+	r.AddAttributes(logAttributes(fields)...)
+	c.logger.Emit(context.Background(), r)
+	return nil
+}
+
+func (c *logCore) Sync() error {
+	return nil
+}
+
+// logAttributes converts zap fields to log attributes, sorted by key.
+func logAttributes(fields []zapcore.Field) []log.KeyValue {
+	if len(fields) == 0 {
+		return nil
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	keys := make([]string, 0, len(enc.Fields))
+	for k := range enc.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]log.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		switch v := enc.Fields[k].(type) {
+		case string:
+			attrs = append(attrs, log.String(k, v))
+		case bool:
+			attrs = append(attrs, log.Bool(k, v))
+		case int:
+			attrs = append(attrs, log.Int(k, v))
+		case int32:
+			attrs = append(attrs, log.Int64(k, int64(v)))
+		case int64:
+			attrs = append(attrs, log.Int64(k, v))
+		case float64:
+			attrs = append(attrs, log.Float64(k, v))
+		default:
+			attrs = append(attrs, log.String(k, fmt.Sprint(v)))
+		}
+	}
+	return attrs
+}
+
+// logSeverity maps a zap level to an OpenTelemetry severity. The V levels of logr below debug are reported as trace.
+func logSeverity(l zapcore.Level) log.Severity {
+	switch {
+	case l < zapcore.DebugLevel:
+		return log.SeverityTrace
+	case l == zapcore.DebugLevel:
+		return log.SeverityDebug
+	case l == zapcore.InfoLevel:
+		return log.SeverityInfo
+	case l == zapcore.WarnLevel:
+		return log.SeverityWarn
+	case l == zapcore.ErrorLevel:
+		return log.SeverityError
+	default:
+		return log.SeverityFatal
+	}
+}
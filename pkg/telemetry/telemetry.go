@@ -0,0 +1,152 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetry exports the operator's logs, metrics and traces to an OpenTelemetry pipeline over OTLP/HTTP.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+
+	"github.com/prometheus/client_golang/prometheus"
+	promotel "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/version"
+)
+
+// ServiceName is the OpenTelemetry service name of the operator.
+const ServiceName = "tigera-operator"
+
+// Start installs OTLP exporters for traces, metrics and logs as the global OpenTelemetry providers. The metrics in
+// gatherer, i.e. the controller-runtime metrics registry, are exported along with any metrics recorded through the
+// OpenTelemetry API. The returned function flushes and stops the exporters.
+func Start(ctx context.Context, cfg *Config, gatherer prometheus.Gatherer) (func(context.Context) error, error) {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	insecure := u.Scheme == "http"
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(version.VERSION),
+		semconv.K8SNamespaceName(common.OperatorNamespace()),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	traceOpts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(path.Join("/", u.Path, "v1/traces")),
+		otlptracehttp.WithHeaders(cfg.Headers),
+	}
+	metricOpts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(u.Host),
+		otlpmetrichttp.WithURLPath(path.Join("/", u.Path, "v1/metrics")),
+		otlpmetrichttp.WithHeaders(cfg.Headers),
+	}
+	logOpts := []otlploghttp.Option{
+		otlploghttp.WithEndpoint(u.Host),
+		otlploghttp.WithURLPath(path.Join("/", u.Path, "v1/logs")),
+		otlploghttp.WithHeaders(cfg.Headers),
+	}
+	if insecure {
+		traceOpts = append(traceOpts, otlptracehttp.WithInsecure())
+		metricOpts = append(metricOpts, otlpmetrichttp.WithInsecure())
+		logOpts = append(logOpts, otlploghttp.WithInsecure())
+	} else if cfg.TLSConfig != nil {
+		traceOpts = append(traceOpts, otlptracehttp.WithTLSClientConfig(cfg.TLSConfig))
+		metricOpts = append(metricOpts, otlpmetrichttp.WithTLSClientConfig(cfg.TLSConfig))
+		logOpts = append(logOpts, otlploghttp.WithTLSClientConfig(cfg.TLSConfig))
+	}
+
+	traceExporter, err := otlptracehttp.New(ctx, traceOpts...)
+	if err != nil {
+		return nil, err
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx, metricOpts...)
+	if err != nil {
+		return nil, err
+	}
+	logExporter, err := otlploghttp.New(ctx, logOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter), sdktrace.WithResource(res))
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter,
+			sdkmetric.WithProducer(promotel.NewMetricProducer(promotel.WithGatherer(gatherer))),
+		)),
+		sdkmetric.WithResource(res),
+	)
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(logExporter)), sdklog.WithResource(res))
+
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	global.SetLoggerProvider(lp)
+
+	if cfg.ComponentTelemetry {
+		componentTelemetry = newComponentConfig(cfg)
+	}
+
+	return func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), mp.Shutdown(ctx), lp.Shutdown(ctx))
+	}, nil
+}
+
+// WrapCore tees the operator's log output into the global OpenTelemetry logger provider. Until Start is called
+// the provider is a no-op, so this can be installed before the telemetry configuration is known. Only entries at the
+// levels enabled on core are exported.
+func WrapCore(core zapcore.Core) zapcore.Core {
+	return zapcore.NewTee(core, leveledCore{Core: newLogCore("github.com/tigera/operator"), enabler: core})
+}
+
+// leveledCore restricts a core to the levels of another.
+type leveledCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+func (c leveledCore) Enabled(l zapcore.Level) bool {
+	return c.enabler.Enabled(l) && c.Core.Enabled(l)
+}
+
+func (c leveledCore) With(fields []zapcore.Field) zapcore.Core {
+	return leveledCore{Core: c.Core.With(fields), enabler: c.enabler}
+}
+
+func (c leveledCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.enabler.Enabled(e.Level) {
+		return ce
+	}
+	return c.Core.Check(e, ce)
+}
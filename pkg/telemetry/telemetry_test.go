@@ -0,0 +1,196 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/telemetry"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+var _ = Describe("OTLP telemetry configuration", func() {
+	var ctx context.Context
+	var cm *corev1.ConfigMap
+	var secret *corev1.Secret
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "operator-bootstrap-config", Namespace: common.OperatorNamespace()},
+			Data: map[string]string{
+				telemetry.EndpointKey: "https://otel-collector.observability.svc:4318",
+				telemetry.SecretKey:   "otlp",
+			},
+		}
+
+		var err error
+		secret, err = certificatemanagement.CreateSelfSignedSecret("otlp", common.OperatorNamespace(), "operator", nil)
+		Expect(err).NotTo(HaveOccurred())
+		secret.Data[telemetry.CACertKey] = secret.Data[corev1.TLSCertKey]
+		secret.Data[telemetry.HeadersKey] = []byte("Authorization=Bearer%20token, X-Scope-OrgID=tigera")
+	})
+
+	It("should be disabled without an endpoint", func() {
+		cfg, err := telemetry.ConfigFromBootstrap(ctx, fake.NewSimpleClientset(), &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg).To(BeNil())
+
+		cfg, err = telemetry.ConfigFromBootstrap(ctx, fake.NewSimpleClientset(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg).To(BeNil())
+	})
+
+	It("should read the TLS and authentication material from the secret", func() {
+		cfg, err := telemetry.ConfigFromBootstrap(ctx, fake.NewSimpleClientset(secret), cm)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Endpoint).To(Equal("https://otel-collector.observability.svc:4318"))
+		Expect(cfg.Headers).To(Equal(map[string]string{
+			"Authorization": "Bearer token",
+			"X-Scope-OrgID": "tigera",
+		}))
+		Expect(cfg.TLSConfig).NotTo(BeNil())
+		Expect(cfg.TLSConfig.RootCAs).NotTo(BeNil())
+		Expect(cfg.TLSConfig.Certificates).To(HaveLen(1))
+		Expect(cfg.ComponentTelemetry).To(BeFalse())
+	})
+
+	It("should not use TLS for http endpoints", func() {
+		cm.Data[telemetry.EndpointKey] = "http://otel-collector.observability.svc:4318"
+		cfg, err := telemetry.ConfigFromBootstrap(ctx, fake.NewSimpleClientset(secret), cm)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.TLSConfig).To(BeNil())
+		Expect(cfg.Headers).To(HaveLen(2))
+	})
+
+	It("should return an error for an endpoint without a scheme", func() {
+		cm.Data[telemetry.EndpointKey] = "otel-collector:4318"
+		_, err := telemetry.ConfigFromBootstrap(ctx, fake.NewSimpleClientset(secret), cm)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error when the secret does not exist", func() {
+		_, err := telemetry.ConfigFromBootstrap(ctx, fake.NewSimpleClientset(), cm)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error when only the client certificate is set", func() {
+		delete(secret.Data, corev1.TLSPrivateKeyKey)
+		_, err := telemetry.ConfigFromBootstrap(ctx, fake.NewSimpleClientset(secret), cm)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error for malformed headers", func() {
+		secret.Data[telemetry.HeadersKey] = []byte("Authorization")
+		_, err := telemetry.ConfigFromBootstrap(ctx, fake.NewSimpleClientset(secret), cm)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("component telemetry", func() {
+		var podSpec *corev1.PodSpec
+		var shutdown func(context.Context) error
+
+		start := func() {
+			cm.Data[telemetry.ComponentTelemetryKey] = "true"
+			cfg, err := telemetry.ConfigFromBootstrap(ctx, fake.NewSimpleClientset(secret), cm)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.ComponentTelemetry).To(BeTrue())
+
+			shutdown, err = telemetry.Start(ctx, cfg, prometheus.NewRegistry())
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		AfterEach(func() {
+			if shutdown != nil {
+				ctx, cancel := context.WithTimeout(ctx, time.Second)
+				defer cancel()
+				_ = shutdown(ctx)
+			}
+		})
+
+		BeforeEach(func() {
+			shutdown = nil
+			podSpec = &corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "calico-node", Image: "docker.io/calico/node:master"},
+				{Name: "elasticsearch", Image: "quay.io/tigera/elasticsearch:master"},
+			}}
+		})
+
+		It("should point Tigera components at the endpoint with the headers from the secret", func() {
+			Expect(telemetry.ConfigureComponent(podSpec)).To(BeFalse())
+			Expect(podSpec.Containers[0].Env).To(BeEmpty())
+
+			cm.Data[telemetry.EndpointKey] = "http://127.0.0.1:4318"
+			start()
+
+			Expect(telemetry.ConfigureComponent(podSpec)).To(BeTrue())
+			Expect(podSpec.Containers[0].Env).To(ConsistOf(
+				corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: "calico-node"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://127.0.0.1:4318"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_HEADERS", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "otlp"},
+					Key:                  telemetry.HeadersKey,
+				}}},
+			))
+			Expect(podSpec.Volumes).To(BeEmpty())
+			Expect(telemetry.ComponentSecret().Name).To(Equal("otlp"))
+
+			// Third-party components don't use the OpenTelemetry SDK.
+			Expect(podSpec.Containers[1].Env).To(BeEmpty())
+		})
+
+		It("should mount the TLS material from the secret", func() {
+			start()
+
+			Expect(telemetry.ConfigureComponent(podSpec)).To(BeTrue())
+			Expect(podSpec.Containers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "https://otel-collector.observability.svc:4318"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_CERTIFICATE", Value: "/etc/otlp/ca.crt"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE", Value: "/etc/otlp/tls.crt"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_CLIENT_KEY", Value: "/etc/otlp/tls.key"},
+			))
+			Expect(podSpec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: "otlp-credentials", MountPath: "/etc/otlp", ReadOnly: true}))
+			Expect(podSpec.Volumes).To(ConsistOf(corev1.Volume{
+				Name: "otlp-credentials",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+					SecretName: "otlp",
+					Items: []corev1.KeyToPath{
+						{Key: "ca.crt", Path: "ca.crt"},
+						{Key: "tls.crt", Path: "tls.crt"},
+						{Key: "tls.key", Path: "tls.key"},
+					},
+				}},
+			}))
+			Expect(podSpec.Containers[1].VolumeMounts).To(BeEmpty())
+
+			// Configuring the pod spec again leaves it as it is.
+			Expect(telemetry.ConfigureComponent(podSpec)).To(BeTrue())
+			Expect(podSpec.Volumes).To(HaveLen(1))
+			Expect(podSpec.Containers[0].VolumeMounts).To(HaveLen(1))
+			Expect(podSpec.Containers[0].Env).To(HaveLen(7))
+		})
+	})
+})
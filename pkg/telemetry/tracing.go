@@ -0,0 +1,215 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TracerName is the instrumentation scope of the spans recorded by the operator.
+const TracerName = "github.com/tigera/operator"
+
+// Attributes recorded on the spans of reconciles and Kubernetes API calls.
+const (
+	ControllerKey = attribute.Key("operator.controller")
+	RequeueKey    = attribute.Key("operator.requeue")
+	KindKey       = attribute.Key("k8s.object.kind")
+	NameKey       = attribute.Key("k8s.object.name")
+	NamespaceKey  = attribute.Key("k8s.namespace.name")
+)
+
+// tracer returns the operator's tracer. It is looked up on every use so that spans go to the provider installed by
+// Start, which may happen after the controllers have been created. Until then spans are not recorded.
+func tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// endSpan records err, if any, on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Reconciler wraps r so that every reconcile is recorded as a span. The context passed to r carries the span, so the
+// Kubernetes and Elasticsearch calls made with it are recorded as its children.
+func Reconciler(controller string, r reconcile.Reconciler) reconcile.Reconciler {
+	return &tracingReconciler{controller: controller, Reconciler: r}
+}
+
+type tracingReconciler struct {
+	reconcile.Reconciler
+	controller string
+}
+
+func (r *tracingReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracer().Start(ctx, fmt.Sprintf("%s reconcile", r.controller), trace.WithAttributes(
+		ControllerKey.String(r.controller),
+		NameKey.String(request.Name),
+		NamespaceKey.String(request.Namespace),
+	))
+	result, err := r.Reconciler.Reconcile(ctx, request)
+	span.SetAttributes(RequeueKey.Bool(result.Requeue || result.RequeueAfter > 0))
+	endSpan(span, err)
+	return result, err
+}
+
+// Client wraps c so that every call it makes is recorded as a span. Reads served from the cache are recorded too, so
+// the time a reconcile spends waiting on the API server and on the cache can be told apart by the span durations.
+func Client(c client.Client) client.Client {
+	return &tracingClient{Client: c}
+}
+
+type tracingClient struct {
+	client.Client
+}
+
+// startCall starts the span of an API call for obj, which may be an object or a list.
+func (c *tracingClient) startCall(ctx context.Context, op string, obj runtime.Object, name, namespace string) (context.Context, trace.Span) {
+	kind := "Unknown"
+	if gvk, err := c.GroupVersionKindFor(obj); err == nil {
+		kind = gvk.Kind
+	}
+	return tracer().Start(ctx, fmt.Sprintf("%s %s", op, kind), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		KindKey.String(kind),
+		NameKey.String(name),
+		NamespaceKey.String(namespace),
+	))
+}
+
+func (c *tracingClient) start(ctx context.Context, op string, obj client.Object) (context.Context, trace.Span) {
+	return c.startCall(ctx, op, obj, obj.GetName(), obj.GetNamespace())
+}
+
+func (c *tracingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	ctx, span := c.startCall(ctx, "Get", obj, key.Name, key.Namespace)
+	err := c.Client.Get(ctx, key, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c *tracingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	ctx, span := c.startCall(ctx, "List", list, "", listOpts.Namespace)
+	err := c.Client.List(ctx, list, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c *tracingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := c.start(ctx, "Create", obj)
+	err := c.Client.Create(ctx, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c *tracingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := c.start(ctx, "Delete", obj)
+	err := c.Client.Delete(ctx, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c *tracingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := c.start(ctx, "Update", obj)
+	err := c.Client.Update(ctx, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c *tracingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := c.start(ctx, "Patch", obj)
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c *tracingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	ctx, span := c.start(ctx, "DeleteAllOf", obj)
+	err := c.Client.DeleteAllOf(ctx, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c *tracingClient) Status() client.SubResourceWriter {
+	return &tracingStatusWriter{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+type tracingStatusWriter struct {
+	client.SubResourceWriter
+	client *tracingClient
+}
+
+func (w *tracingStatusWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	ctx, span := w.client.start(ctx, "CreateStatus", obj)
+	err := w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (w *tracingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	ctx, span := w.client.start(ctx, "UpdateStatus", obj)
+	err := w.SubResourceWriter.Update(ctx, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (w *tracingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	ctx, span := w.client.start(ctx, "PatchStatus", obj)
+	err := w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	endSpan(span, err)
+	return err
+}
+
+// Transport wraps rt so that every HTTP request made through it is recorded as a span, using the context of the
+// request as the parent. It is used for the Elasticsearch client, whose API calls all take a context.
+func Transport(rt http.RoundTripper) http.RoundTripper {
+	return &tracingTransport{RoundTripper: rt}
+}
+
+type tracingTransport struct {
+	http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer().Start(req.Context(), req.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.Redacted()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	))
+	resp, err := t.RoundTripper.RoundTrip(req.WithContext(ctx))
+	if err == nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	endSpan(span, err)
+	return resp, err
+}
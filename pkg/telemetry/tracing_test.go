@@ -0,0 +1,135 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/telemetry"
+)
+
+var _ = Describe("Reconcile tracing", func() {
+	var ctx context.Context
+	var recorder *tracetest.SpanRecorder
+	var previous trace.TracerProvider
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = tracetest.NewSpanRecorder()
+		previous = otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	It("records Kubernetes API calls as children of the reconcile span", func() {
+		s := runtime.NewScheme()
+		Expect(scheme.AddToScheme(s)).NotTo(HaveOccurred())
+		cli := telemetry.Client(ctrlrfake.DefaultFakeClientBuilder(s).Build())
+
+		r := telemetry.Reconciler("test-controller", reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: request.Name, Namespace: request.Namespace}}
+			if err := cli.Create(ctx, cm); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, cli.Get(ctx, types.NamespacedName{Name: "missing", Namespace: request.Namespace}, &corev1.ConfigMap{})
+		}))
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "cm", Namespace: "ns"}})
+		Expect(err).To(HaveOccurred())
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(3))
+		create, get, rec := spans[0], spans[1], spans[2]
+
+		Expect(rec.Name()).To(Equal("test-controller reconcile"))
+		Expect(rec.Status().Code).To(Equal(codes.Error))
+		Expect(rec.Attributes()).To(ContainElements(
+			telemetry.ControllerKey.String("test-controller"),
+			telemetry.NameKey.String("cm"),
+			telemetry.NamespaceKey.String("ns"),
+		))
+
+		Expect(create.Name()).To(Equal("Create ConfigMap"))
+		Expect(create.Parent().SpanID()).To(Equal(rec.SpanContext().SpanID()))
+		Expect(create.Status().Code).To(Equal(codes.Unset))
+		Expect(create.Attributes()).To(ContainElements(
+			telemetry.KindKey.String("ConfigMap"),
+			telemetry.NameKey.String("cm"),
+		))
+
+		Expect(get.Name()).To(Equal("Get ConfigMap"))
+		Expect(get.Parent().SpanID()).To(Equal(rec.SpanContext().SpanID()))
+		Expect(get.Status().Code).To(Equal(codes.Error))
+		Expect(get.Attributes()).To(ContainElement(telemetry.NameKey.String("missing")))
+	})
+
+	It("records the namespace of List calls", func() {
+		s := runtime.NewScheme()
+		Expect(scheme.AddToScheme(s)).NotTo(HaveOccurred())
+		cli := telemetry.Client(ctrlrfake.DefaultFakeClientBuilder(s).Build())
+
+		Expect(cli.List(ctx, &corev1.SecretList{}, client.InNamespace("ns"))).NotTo(HaveOccurred())
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name()).To(Equal("List SecretList"))
+		Expect(spans[0].Attributes()).To(ContainElement(telemetry.NamespaceKey.String("ns")))
+	})
+
+	It("records HTTP requests made through the transport", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/_security/user" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}))
+		defer server.Close()
+
+		h := &http.Client{Transport: telemetry.Transport(http.DefaultTransport)}
+		for _, path := range []string{"/", "/_security/user"} {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", server.URL, path), nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := h.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).NotTo(HaveOccurred())
+		}
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(2))
+		Expect(spans[0].Name()).To(Equal("GET"))
+		Expect(spans[0].Status().Code).To(Equal(codes.Unset))
+		Expect(spans[1].Status().Code).To(Equal(codes.Error))
+	})
+})